@@ -19,6 +19,7 @@ package v1b1
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // OrchestratorConfig represents the configuration for the Score Orchestrator
@@ -35,6 +36,10 @@ type OrchestratorConfig struct {
 type OrchestratorConfigMeta struct {
 	Name    string `json:"name" yaml:"name"`
 	Version string `json:"version,omitempty" yaml:"version,omitempty"`
+
+	// Generation is set by the Orchestrator when it loads the configuration: it increases each time the
+	// loaded content changes, and is recorded on the WorkloadPlans and ResourceClaims produced from it
+	Generation int64 `json:"-" yaml:"-"`
 }
 
 // OrchestratorConfigSpec defines the specification for orchestrator configuration
@@ -47,6 +52,61 @@ type OrchestratorConfigSpec struct {
 
 	// Defaults defines default values and selection policies
 	Defaults DefaultsSpec `json:"defaults" yaml:"defaults"`
+
+	// Quotas limit the workloads orchestrated per namespace or per team
+	Quotas []QuotaSpec `json:"quotas,omitempty" yaml:"quotas,omitempty"`
+
+	// Policies are platform policies evaluated against Workloads as CEL expressions
+	Policies []PolicySpec `json:"policies,omitempty" yaml:"policies,omitempty"`
+
+	// Targets are remote clusters WorkloadPlans are delivered to, for runtimes that do not run in this cluster
+	Targets []RuntimeTargetSpec `json:"targets,omitempty" yaml:"targets,omitempty"`
+
+	// Namespaces makes the Orchestrator provision a namespace per Workload environment, into which
+	// runtimes materialize the Workloads of that environment
+	Namespaces *NamespacesSpec `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+
+	// Notifications are the sinks lifecycle notifications (e.g., a Workload becoming ready) are posted to
+	Notifications []NotificationSinkSpec `json:"notifications,omitempty" yaml:"notifications,omitempty"`
+
+	// Audit enables the audit trail of orchestration decisions, recorded as WorkloadAudit resources,
+	// and configures its retention. No decisions are recorded when unset.
+	Audit *AuditSpec `json:"audit,omitempty" yaml:"audit,omitempty"`
+
+	// SupplyChain verifies the OCI template refs of backends before WorkloadPlans are created from them.
+	// Template refs are used unverified when unset.
+	SupplyChain *SupplyChainSpec `json:"supplyChain,omitempty" yaml:"supplyChain,omitempty"`
+
+	// Sharding splits Workload reconciliation across groups of orchestrator replicas. It is read when the
+	// manager starts; the --shard-count and --shard-index flags override it.
+	Sharding *ShardingSpec `json:"sharding,omitempty" yaml:"sharding,omitempty"`
+}
+
+// ShardingSpec configures the shards Workload reconciliation is split into. Each replica reconciles the shard
+// of the ordinal of its pod name modulo count, e.g. shard 1 for "score-orchestrator-5" with 4 shards, so the
+// replicas of a StatefulSet spread evenly over the shards and the replicas of one shard elect a leader.
+type ShardingSpec struct {
+	// Count is the number of shards; values below 2 disable sharding
+	Count int32 `json:"count" yaml:"count"`
+}
+
+// PropagationSpec is an allow-list of the Workload labels and annotations that runtimes and provisioners
+// copy to the resources they generate. Keys are matched by prefix, e.g. "cost-center" or "example.com/".
+// Keys under score.dev/ are owned by the Orchestrator and never propagated.
+type PropagationSpec struct {
+	// Labels are the prefixes of the propagated label keys
+	Labels []string `json:"labels,omitempty" yaml:"labels,omitempty"`
+
+	// Annotations are the prefixes of the propagated annotation keys
+	Annotations []string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+}
+
+// PlanValuesSpec configures how resolved values are stored. Values larger than MaxInlineSize are written to
+// an immutable ConfigMap referenced from the WorkloadPlan, keeping the plan well below the object size limit.
+type PlanValuesSpec struct {
+	// MaxInlineSize is the largest resolved values payload embedded in a WorkloadPlan, as a quantity
+	// of bytes (default 256Ki)
+	MaxInlineSize string `json:"maxInlineSize,omitempty" yaml:"maxInlineSize,omitempty"`
 }
 
 // ProfileSpec defines an abstract workload profile
@@ -57,10 +117,59 @@ type ProfileSpec struct {
 	// Description is an optional human-readable description
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
 
+	// Kind is the workload kind materialized for this profile: "Service" (default) | "Job" | "CronJob".
+	// Workloads that declare spec.schedule are always materialized as CronJobs.
+	Kind string `json:"kind,omitempty" yaml:"kind,omitempty"`
+
 	// Backends is an array of backend implementations for this profile
 	Backends []BackendSpec `json:"backends" yaml:"backends"`
+
+	// Defaults are the reliability defaults materialized for the Workloads of this profile
+	Defaults *WorkloadDefaultsSpec `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+
+	// WorkloadDefaults is a base Workload fragment merged into the spec of the Workloads of this profile
+	// before their plans are generated
+	WorkloadDefaults *WorkloadFragmentSpec `json:"workloadDefaults,omitempty" yaml:"workloadDefaults,omitempty"`
+
+	// ImagePolicy controls how the container images of the Workloads of this profile are planned
+	ImagePolicy *ImagePolicySpec `json:"imagePolicy,omitempty" yaml:"imagePolicy,omitempty"`
+
+	// ReadinessGates are the WorkloadPlan conditions, published by the runtime or other controllers, that
+	// must be True in addition to the plan phase before the Workloads of this profile are Ready
+	ReadinessGates []ReadinessGate `json:"readinessGates,omitempty" yaml:"readinessGates,omitempty"`
+}
+
+// ReadinessGate names a WorkloadPlan condition required for the Workload to be Ready
+type ReadinessGate struct {
+	// ConditionType is the type of the condition in WorkloadPlan.status.conditions (e.g., "MeshInjected")
+	ConditionType string `json:"conditionType" yaml:"conditionType"`
+}
+
+// ImagePolicySpec controls how container images are planned. Images resolved to digests are published in
+// WorkloadPlan.spec.resolvedValues under containers.<name>.image, so that nodes pull what was planned even
+// when the tag is pushed again.
+type ImagePolicySpec struct {
+	// ResolveDigests resolves the tag of each container image to the digest it points to when the plan is
+	// created, through the registries and credentials of the supply-chain configuration. The digest is kept
+	// until the image of the container changes. Workloads override it with the score.dev/image-digests
+	// annotation.
+	ResolveDigests bool `json:"resolveDigests,omitempty" yaml:"resolveDigests,omitempty"`
+
+	// DisallowLatest rejects container images tagged "latest", or with neither a tag nor a digest, with
+	// reason PolicyViolation
+	DisallowLatest bool `json:"disallowLatest,omitempty" yaml:"disallowLatest,omitempty"`
 }
 
+// Workload kinds materialized by runtime controllers
+const (
+	// WorkloadKindService runs the workload continuously (default)
+	WorkloadKindService = "Service"
+	// WorkloadKindJob runs the workload to completion once
+	WorkloadKindJob = "Job"
+	// WorkloadKindCronJob runs the workload to completion on spec.schedule
+	WorkloadKindCronJob = "CronJob"
+)
+
 // BackendSpec represents a concrete runtime implementation for a profile
 type BackendSpec struct {
 	// BackendId is a stable identifier (not user-visible)
@@ -80,6 +189,120 @@ type BackendSpec struct {
 
 	// Constraints define selection constraints for this backend
 	Constraints *ConstraintsSpec `json:"constraints,omitempty" yaml:"constraints,omitempty"`
+
+	// Exposure defines how the runtime publishes workload endpoints for this backend
+	Exposure *ExposureSpec `json:"exposure,omitempty" yaml:"exposure,omitempty"`
+
+	// Target names the remote cluster the WorkloadPlans of this backend are delivered to.
+	// Empty runs the plans by the runtime of this cluster.
+	Target string `json:"target,omitempty" yaml:"target,omitempty"`
+
+	// Defaults override the defaults of the profile for the Workloads running on this backend, field by field
+	Defaults *WorkloadDefaultsSpec `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+
+	// ValuesFrom routes claim outputs to arbitrary paths of the resolved values, in addition to the
+	// resources.<key>.outputs layout, for templates that expect them elsewhere
+	ValuesFrom []ValuesFromSpec `json:"valuesFrom,omitempty" yaml:"valuesFrom,omitempty"`
+}
+
+// ValuesFromSpec maps an output of a claim to a path of the resolved values
+type ValuesFromSpec struct {
+	// Claim is the key of the resource in the Workload whose claim publishes the output
+	Claim string `json:"claim" yaml:"claim"`
+
+	// Output is the name of the output, e.g. "uri" or a key of the output Secret
+	Output string `json:"output" yaml:"output"`
+
+	// Path is the dot-separated values path the output is written to, e.g. ".db.connectionString"
+	Path string `json:"path" yaml:"path"`
+}
+
+// Exposure modes supported by runtime controllers
+const (
+	// ExposureModeClusterIP publishes cluster-local endpoints (default)
+	ExposureModeClusterIP = "ClusterIP"
+	// ExposureModeNodePort publishes endpoints on node addresses, intended for local clusters (kind/minikube)
+	ExposureModeNodePort = "NodePort"
+	// ExposureModePortForward publishes localhost endpoints reached through `kubectl port-forward`, intended for local clusters
+	ExposureModePortForward = "PortForward"
+)
+
+// ExposureSpec defines how workload endpoints are exposed by the runtime
+type ExposureSpec struct {
+	// Mode is the exposure mode: "ClusterIP" | "NodePort" | "PortForward"
+	Mode string `json:"mode,omitempty" yaml:"mode,omitempty"`
+}
+
+// WorkloadDefaultsSpec defines reliability policy the runtime materializes alongside the workload
+type WorkloadDefaultsSpec struct {
+	// DisruptionBudget makes the runtime create a PodDisruptionBudget for continuously running Workloads
+	// +optional
+	DisruptionBudget *DisruptionBudgetSpec `json:"disruptionBudget,omitempty" yaml:"disruptionBudget,omitempty"`
+
+	// Resources are the default requests and limits of containers. A default applies to a resource
+	// (e.g., "cpu") only when the container declares neither a request nor a limit for it.
+	// +optional
+	Resources *ResourceRequirements `json:"resources,omitempty" yaml:"resources,omitempty"`
+
+	// PriorityClass is the PriorityClass of the pods, so that critical Workloads outrank batch Workloads
+	// when nodes are under pressure
+	// +optional
+	PriorityClass *PriorityClassSpec `json:"priorityClass,omitempty" yaml:"priorityClass,omitempty"`
+}
+
+// Preemption policies of PriorityClasses
+const (
+	// PreemptionPolicyPreemptLowerPriority lets pending pods preempt pods of lower priority (default)
+	PreemptionPolicyPreemptLowerPriority = "PreemptLowerPriority"
+	// PreemptionPolicyNever queues pending pods ahead of those of lower priority without preempting any
+	PreemptionPolicyNever = "Never"
+)
+
+// PriorityClassSpec names the PriorityClass of the pods of a Workload. With a value, the runtime creates the
+// PriorityClass when it does not exist; without one, the PriorityClass must exist.
+type PriorityClassSpec struct {
+	// Name is the name of the PriorityClass
+	Name string `json:"name" yaml:"name"`
+
+	// Value is the priority of the PriorityClass the runtime creates, at most 1000000000
+	// +optional
+	Value *int32 `json:"value,omitempty" yaml:"value,omitempty"`
+
+	// PreemptionPolicy of the PriorityClass the runtime creates: "PreemptLowerPriority" (default) | "Never"
+	// +optional
+	PreemptionPolicy string `json:"preemptionPolicy,omitempty" yaml:"preemptionPolicy,omitempty"`
+
+	// Description of the PriorityClass the runtime creates
+	// +optional
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+}
+
+// WorkloadFragmentSpec is a base Workload fragment a profile merges into the Workloads it selects.
+// Values declared by a Workload always take precedence over those of the fragment.
+type WorkloadFragmentSpec struct {
+	// Containers are added to every Workload that does not declare a container of the same name (e.g., sidecars)
+	// +optional
+	Containers map[string]ContainerSpec `json:"containers,omitempty" yaml:"containers,omitempty"`
+
+	// Variables are set on every container, including the added ones, that does not declare them
+	// +optional
+	Variables map[string]string `json:"variables,omitempty" yaml:"variables,omitempty"`
+
+	// Resources are the requests and limits set on every container, including the added ones. A value
+	// applies to a resource (e.g., "cpu") only when the container declares neither a request nor a limit for it.
+	// +optional
+	Resources *ResourceRequirements `json:"resources,omitempty" yaml:"resources,omitempty"`
+}
+
+// DisruptionBudgetSpec bounds voluntary disruptions of the pods of a Workload. Exactly one field must be set.
+type DisruptionBudgetSpec struct {
+	// MinAvailable is the number or percentage of pods that must remain available
+	// +optional
+	MinAvailable *intstr.IntOrString `json:"minAvailable,omitempty" yaml:"minAvailable,omitempty"`
+
+	// MaxUnavailable is the number or percentage of pods that may be unavailable
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty" yaml:"maxUnavailable,omitempty"`
 }
 
 // TemplateSpec defines template configuration for backend materialization
@@ -87,11 +310,35 @@ type TemplateSpec struct {
 	// Kind is the template type: "manifests" | "helm" | "kustomize"
 	Kind string `json:"kind" yaml:"kind"`
 
-	// Ref is the immutable reference (OCI digest recommended)
+	// Ref is the immutable reference (OCI digest recommended), verified per spec.supplyChain when configured
 	Ref string `json:"ref" yaml:"ref"`
 
 	// Values are optional default template values
 	Values *runtime.RawExtension `json:"values,omitempty" yaml:"values,omitempty"`
+
+	// ValuesSchema is an optional JSON Schema the composed template values must satisfy
+	ValuesSchema *ValuesSchemaSpec `json:"valuesSchema,omitempty" yaml:"valuesSchema,omitempty"`
+}
+
+// ValuesSchemaSpec holds the JSON Schema of template values, inline or by reference. Exactly one must be set.
+type ValuesSchemaSpec struct {
+	// Inline is the JSON Schema document
+	Inline *runtime.RawExtension `json:"inline,omitempty" yaml:"inline,omitempty"`
+
+	// Ref references a ConfigMap key holding the JSON Schema document (JSON or YAML)
+	Ref *ValuesSchemaRef `json:"ref,omitempty" yaml:"ref,omitempty"`
+}
+
+// ValuesSchemaRef references a ConfigMap key holding a JSON Schema document
+type ValuesSchemaRef struct {
+	// Namespace is the namespace of the ConfigMap
+	Namespace string `json:"namespace" yaml:"namespace"`
+
+	// Name is the name of the ConfigMap
+	Name string `json:"name" yaml:"name"`
+
+	// Key is the ConfigMap data key holding the schema
+	Key string `json:"key" yaml:"key"`
 }
 
 // ConstraintsSpec defines constraints for backend selection
@@ -102,9 +349,17 @@ type ConstraintsSpec struct {
 	// Features are required features for this backend
 	Features []string `json:"features,omitempty" yaml:"features,omitempty"`
 
-	// Regions are allowed regions for this backend
+	// Regions are allowed regions for this backend. Backends without regions are region-agnostic.
 	Regions []string `json:"regions,omitempty" yaml:"regions,omitempty"`
 
+	// Namespaces restricts the backend to Workloads in these namespaces. Entries are namespace names or
+	// glob patterns (e.g., "ml-*"). Only evaluated for backends.
+	Namespaces []string `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+
+	// NamespaceSelector restricts the backend to Workloads in namespaces whose labels match, e.g. a tenant
+	// label. Only evaluated for backends.
+	NamespaceSelector *metav1.LabelSelector `json:"namespaceSelector,omitempty" yaml:"namespaceSelector,omitempty"`
+
 	// Resources define resource constraints
 	Resources *ResourceConstraints `json:"resources,omitempty" yaml:"resources,omitempty"`
 }
@@ -129,13 +384,201 @@ type ProvisionerSpec struct {
 	// Provisioner is the controller name/identifier
 	Provisioner string `json:"provisioner" yaml:"provisioner"`
 
+	// Strategy names the registered strategy the built-in provisioner uses for claims of this type
+	// (defaults to the type)
+	Strategy string `json:"strategy,omitempty" yaml:"strategy,omitempty"`
+
 	// Classes are available service tiers/sizes for this resource type
 	Classes []ClassSpec `json:"classes" yaml:"classes"`
 
 	// Defaults are default parameters for this provisioner
 	Defaults *ProvisionerDefaults `json:"defaults,omitempty" yaml:"defaults,omitempty"`
+
+	// ParamsSchema is the JSON Schema the params of claims of this type must satisfy; claims whose params
+	// violate it fail with reason SpecInvalid before they are provisioned
+	ParamsSchema *runtime.RawExtension `json:"paramsSchema,omitempty" yaml:"paramsSchema,omitempty"`
+
+	// OutputKeys are the keys of the output Secret of claims of this type (e.g., host, port, username, password).
+	// Secrets adopted by claims with provision external must contain all of them.
+	OutputKeys []string `json:"outputKeys,omitempty" yaml:"outputKeys,omitempty"`
+
+	// Retry controls how failed claims of this type are retried; unset fields use the defaults
+	Retry *RetryPolicy `json:"retry,omitempty" yaml:"retry,omitempty"`
+
+	// ProvisioningTimeout bounds how long a claim of this type may stay Claiming before it
+	// fails with reason Timeout (default 10m)
+	ProvisioningTimeout *metav1.Duration `json:"provisioningTimeout,omitempty" yaml:"provisioningTimeout,omitempty"`
+
+	// Concurrency limits how many claims of this type are provisioned at the same time; when unset,
+	// every claim is provisioned as soon as it is reconciled
+	Concurrency *ConcurrencyPolicy `json:"concurrency,omitempty" yaml:"concurrency,omitempty"`
+
+	// Webhook configures the out-of-process provisioner claims are proxied to; required for the webhook strategy
+	Webhook *WebhookProvisionerSpec `json:"webhook,omitempty" yaml:"webhook,omitempty"`
+
+	// Terraform configures the Terraform or OpenTofu module claims are provisioned with; required for the
+	// terraform strategy
+	Terraform *TerraformProvisionerSpec `json:"terraform,omitempty" yaml:"terraform,omitempty"`
+
+	// SecretStore moves the credentials of claims of this type to an external secret store; claims then
+	// publish an externalSecretRef instead of a secretRef
+	SecretStore *SecretStoreSpec `json:"secretStore,omitempty" yaml:"secretStore,omitempty"`
+
+	// NetworkPolicy restricts ingress to the pods of claims of this type to the pods of the claiming
+	// Workload and the listed peers
+	NetworkPolicy *IngressPolicySpec `json:"networkPolicy,omitempty" yaml:"networkPolicy,omitempty"`
+}
+
+// ProvisionerStrategyWebhook is the strategy that proxies claims to an out-of-process provisioner
+const ProvisionerStrategyWebhook = "webhook"
+
+// WebhookProvisionerSpec configures an out-of-process provisioner service reached over HTTPS
+type WebhookProvisionerSpec struct {
+	// URL is the https base URL of the provisioner service; the protocol paths are appended to it
+	URL string `json:"url" yaml:"url"`
+
+	// CABundle is a PEM bundle used to verify the service certificate (default: system roots)
+	CABundle string `json:"caBundle,omitempty" yaml:"caBundle,omitempty"`
+
+	// ClientCertSecretRef references a kubernetes.io/tls Secret whose certificate is presented to the service
+	ClientCertSecretRef *NamespacedName `json:"clientCertSecretRef,omitempty" yaml:"clientCertSecretRef,omitempty"`
+
+	// Timeout bounds each request to the service (default 30s)
+	Timeout *metav1.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+
+	// MaxRetries is the number of times a request that failed with a transient error is retried (default 3)
+	MaxRetries *int32 `json:"maxRetries,omitempty" yaml:"maxRetries,omitempty"`
+}
+
+// ProvisionerStrategyTerraform is the strategy that provisions claims by applying a Terraform or OpenTofu module
+const ProvisionerStrategyTerraform = "terraform"
+
+// Binaries the terraform strategy runs modules with
+const (
+	// TerraformBinaryTofu runs modules with OpenTofu (default)
+	TerraformBinaryTofu = "tofu"
+	// TerraformBinaryTerraform runs modules with Terraform
+	TerraformBinaryTerraform = "terraform"
+)
+
+// TerraformProvisionerSpec configures the module the terraform strategy applies for each claim. The module
+// runs in Jobs of the claim namespace, with the parameters of the claim's class and the claim params as
+// input variables; its outputs become the outputs of the claim.
+type TerraformProvisionerSpec struct {
+	// Module is the module source, e.g. "git::https://example.com/modules.git//postgres?ref=v1.2.0" or,
+	// with OpenTofu, "oci://registry.example.com/modules/postgres?tag=1.2.0"
+	Module string `json:"module" yaml:"module"`
+
+	// Image is the runner image; it must provide a POSIX shell, the binary and kubectl
+	Image string `json:"image" yaml:"image"`
+
+	// Binary is the command modules are run with: "tofu" (default) | "terraform"
+	Binary string `json:"binary,omitempty" yaml:"binary,omitempty"`
+
+	// Backend configures where the state of the module is stored, one state per claim
+	Backend TerraformBackendSpec `json:"backend" yaml:"backend"`
+
+	// ServiceAccountName is the ServiceAccount the runner Jobs run as in the claim namespace. It must be
+	// allowed to create and update Secrets there, to publish the module outputs.
+	ServiceAccountName string `json:"serviceAccountName,omitempty" yaml:"serviceAccountName,omitempty"`
+
+	// EnvFromSecret names a Secret of the claim namespace whose keys are set as environment variables of the
+	// runner, e.g. the credentials of the providers and the backend
+	EnvFromSecret string `json:"envFromSecret,omitempty" yaml:"envFromSecret,omitempty"`
+}
+
+// TerraformBackendSpec configures the state backend of a module
+type TerraformBackendSpec struct {
+	// Type is the backend type, e.g. "s3", "gcs", "azurerm" or "kubernetes"; the local backend is not supported
+	Type string `json:"type" yaml:"type"`
+
+	// Config are the settings of the backend, passed to init as -backend-config
+	Config map[string]string `json:"config,omitempty" yaml:"config,omitempty"`
+
+	// KeyAttribute is the setting the strategy sets to the state key of each claim. It defaults to "key" for
+	// s3 and azurerm, "prefix" for gcs and "secret_suffix" for kubernetes, and is required for other types.
+	KeyAttribute string `json:"keyAttribute,omitempty" yaml:"keyAttribute,omitempty"`
+}
+
+// SecretStoreSpec configures the external secret store claim credentials are written to.
+// Exactly one of Vault and AWSSecretsManager must be set.
+type SecretStoreSpec struct {
+	// Name is the store name published in claim outputs; runtimes resolve it to the store they read
+	// credentials through (e.g., an external-secrets ClusterSecretStore of the same name)
+	Name string `json:"name" yaml:"name"`
+
+	// PathPrefix is prepended to the per-claim path <namespace>/<claim> (default "score")
+	PathPrefix string `json:"pathPrefix,omitempty" yaml:"pathPrefix,omitempty"`
+
+	// Vault writes credentials to a HashiCorp Vault KV version 2 secrets engine
+	Vault *VaultSecretStoreSpec `json:"vault,omitempty" yaml:"vault,omitempty"`
+
+	// AWSSecretsManager writes credentials to AWS Secrets Manager
+	AWSSecretsManager *AWSSecretsManagerSpec `json:"awsSecretsManager,omitempty" yaml:"awsSecretsManager,omitempty"`
+}
+
+// VaultSecretStoreSpec configures a HashiCorp Vault KV version 2 secrets engine
+type VaultSecretStoreSpec struct {
+	// Address is the base URL of the Vault server
+	Address string `json:"address" yaml:"address"`
+
+	// MountPath is the mount path of the KV secrets engine (default "secret")
+	MountPath string `json:"mountPath,omitempty" yaml:"mountPath,omitempty"`
+
+	// CABundle is a PEM bundle used to verify the server certificate (default: system roots)
+	CABundle string `json:"caBundle,omitempty" yaml:"caBundle,omitempty"`
+
+	// TokenSecretRef references the Secret whose "token" key holds the Vault token
+	TokenSecretRef NamespacedName `json:"tokenSecretRef" yaml:"tokenSecretRef"`
+}
+
+// AWSSecretsManagerSpec configures AWS Secrets Manager
+type AWSSecretsManagerSpec struct {
+	// Region is the AWS region of the secrets
+	Region string `json:"region" yaml:"region"`
+
+	// Endpoint overrides the service endpoint (default https://secretsmanager.<region>.amazonaws.com)
+	Endpoint string `json:"endpoint,omitempty" yaml:"endpoint,omitempty"`
+
+	// CredentialsSecretRef references the Secret whose "accessKeyId", "secretAccessKey" and optional
+	// "sessionToken" keys hold the AWS credentials
+	CredentialsSecretRef NamespacedName `json:"credentialsSecretRef" yaml:"credentialsSecretRef"`
+}
+
+// RetryPolicy defines the exponential backoff between provisioning retries of a failed ResourceClaim.
+// Once MaxRetries retries have failed, the claim stays Failed with reason RetryLimitExceeded
+// until its spec changes.
+type RetryPolicy struct {
+	// InitialBackoff is the delay before the first retry (default 10s)
+	InitialBackoff *metav1.Duration `json:"initialBackoff,omitempty" yaml:"initialBackoff,omitempty"`
+
+	// Multiplier scales the delay after each retry (default 2)
+	Multiplier *float64 `json:"multiplier,omitempty" yaml:"multiplier,omitempty"`
+
+	// MaxRetries is the number of retries before the claim fails terminally (default 5)
+	MaxRetries *int32 `json:"maxRetries,omitempty" yaml:"maxRetries,omitempty"`
+}
+
+// ConcurrencyPolicy limits the claims of a type that are provisioning, i.e. in the Claiming phase, at the
+// same time. Claims beyond the limit stay Pending with reason Throttled until a provisioning claim of the
+// type becomes Bound or Failed.
+type ConcurrencyPolicy struct {
+	// MaxConcurrentProvisions is the number of claims of the type that may be provisioning at once
+	MaxConcurrentProvisions int32 `json:"maxConcurrentProvisions" yaml:"maxConcurrentProvisions"`
+
+	// Queue orders the throttled claims: "fifo" (default) provisions the oldest claims first, "unordered"
+	// provisions whichever claim is reconciled first once a slot frees up
+	Queue string `json:"queue,omitempty" yaml:"queue,omitempty"`
 }
 
+// Queue orders of throttled claims
+const (
+	// ConcurrencyQueueFIFO provisions throttled claims in creation order
+	ConcurrencyQueueFIFO = "fifo"
+	// ConcurrencyQueueUnordered provisions throttled claims in reconcile order
+	ConcurrencyQueueUnordered = "unordered"
+)
+
 // ClassSpec defines available service tiers/sizes for a resource type
 type ClassSpec struct {
 	// Name is the class identifier (e.g., "small", "large", "enterprise")
@@ -167,8 +610,213 @@ type DefaultsSpec struct {
 
 	// Selectors are conditional defaults based on label selectors
 	Selectors []SelectorSpec `json:"selectors,omitempty" yaml:"selectors,omitempty"`
+
+	// DerivationRules derive the profile of Workloads without a profile hint from their characteristics.
+	// They are evaluated in order before the selectors; the first matching rule wins. When unset, no
+	// profile is derived.
+	DerivationRules []DerivationRuleSpec `json:"derivationRules,omitempty" yaml:"derivationRules,omitempty"`
+
+	// ReselectionPolicy controls whether existing Workloads follow backend changes:
+	// "sticky" (default) | "reselect-on-change"
+	ReselectionPolicy string `json:"reselectionPolicy,omitempty" yaml:"reselectionPolicy,omitempty"`
+
+	// RegionLabel is the label key carrying the region, read from Workload labels first and
+	// then from cluster Node labels. Defaults to DefaultRegionLabel.
+	RegionLabel string `json:"regionLabel,omitempty" yaml:"regionLabel,omitempty"`
+
+	// SecurityContext configures the security context of generated pods. When unset, pods get no
+	// security context unless the Workload opts in. Unset fields fall back to values that satisfy
+	// the "restricted" Pod Security Standard.
+	SecurityContext *SecurityContextSpec `json:"securityContext,omitempty" yaml:"securityContext,omitempty"`
+
+	// RolloutDeadline bounds how long the runtime may take to roll out a Workload before
+	// RuntimeReady turns False with reason RuntimeDegraded (default 10m)
+	RolloutDeadline *metav1.Duration `json:"rolloutDeadline,omitempty" yaml:"rolloutDeadline,omitempty"`
+
+	// NetworkPolicy denies ingress to the pods of every Workload except from the pods of the same Workload
+	// and the listed peers. When unset, Workload pods accept traffic from anywhere.
+	NetworkPolicy *IngressPolicySpec `json:"networkPolicy,omitempty" yaml:"networkPolicy,omitempty"`
+
+	// RequireRuntimeRegistration only selects backends whose runtimeClass has a live runtime registered
+	// through a runtime registration ConfigMap. Workloads whose candidate backends all lack one report
+	// RuntimeReady=False with reason RuntimeUnavailable.
+	RequireRuntimeRegistration bool `json:"requireRuntimeRegistration,omitempty" yaml:"requireRuntimeRegistration,omitempty"`
+
+	// Propagation selects the Workload labels and annotations copied to the resources generated for it.
+	// When unset, generated resources only carry the labels and annotations the Orchestrator owns.
+	Propagation *PropagationSpec `json:"propagation,omitempty" yaml:"propagation,omitempty"`
+
+	// PlanValues bounds the size of the resolved values embedded in WorkloadPlans
+	PlanValues *PlanValuesSpec `json:"planValues,omitempty" yaml:"planValues,omitempty"`
+
+	// Naming controls the names of the runtime resources materialized for Workloads, and what the runtime
+	// does with existing objects of those names that it did not create. When unset, resources are named
+	// after the Workload and foreign objects block materialization.
+	Naming *NamingSpec `json:"naming,omitempty" yaml:"naming,omitempty"`
+}
+
+// SecurityContextSpec defines the security settings applied to every generated pod and container.
+// Workloads can opt out with the score.dev/security-defaults: disabled annotation, or opt in with "enabled"
+// when no defaults are configured.
+type SecurityContextSpec struct {
+	// RunAsNonRoot requires containers to run as a non-root user (default true)
+	// +optional
+	RunAsNonRoot *bool `json:"runAsNonRoot,omitempty" yaml:"runAsNonRoot,omitempty"`
+
+	// SeccompProfile is the seccomp profile type of the pod: "RuntimeDefault" (default) | "Unconfined"
+	// +kubebuilder:validation:Enum=RuntimeDefault;Unconfined
+	// +optional
+	SeccompProfile string `json:"seccompProfile,omitempty" yaml:"seccompProfile,omitempty"`
+
+	// DropCapabilities lists the Linux capabilities dropped from every container (default ["ALL"])
+	// +optional
+	DropCapabilities []string `json:"dropCapabilities,omitempty" yaml:"dropCapabilities,omitempty"`
+
+	// ReadOnlyRootFilesystem mounts the root filesystem of every container read-only (default false)
+	// +optional
+	ReadOnlyRootFilesystem *bool `json:"readOnlyRootFilesystem,omitempty" yaml:"readOnlyRootFilesystem,omitempty"`
+}
+
+// Seccomp profile types
+const (
+	// SeccompProfileRuntimeDefault uses the container runtime's default seccomp profile
+	SeccompProfileRuntimeDefault = "RuntimeDefault"
+	// SeccompProfileUnconfined runs containers without a seccomp profile
+	SeccompProfileUnconfined = "Unconfined"
+)
+
+// IngressPolicySpec lists the peers admitted to pods protected by a generated NetworkPolicy
+type IngressPolicySpec struct {
+	// AllowFrom lists the additional peers admitted, e.g. an ingress controller or monitoring
+	// +optional
+	AllowFrom []NetworkPolicyPeerSpec `json:"allowFrom,omitempty" yaml:"allowFrom,omitempty"`
+}
+
+// ConflictPolicy is what a runtime does with an existing object of a materialized name it did not create
+// +kubebuilder:validation:Enum=Fail;Adopt
+type ConflictPolicy string
+
+const (
+	// ConflictPolicyFail leaves the existing object untouched and fails the plan with reason RuntimeConflict
+	ConflictPolicyFail ConflictPolicy = "Fail"
+	// ConflictPolicyAdopt takes over the existing object unless another controller owns it
+	ConflictPolicyAdopt ConflictPolicy = "Adopt"
+)
+
+// NamingSpec names the runtime resources materialized for a Workload <prefix><workload name><suffix>
+type NamingSpec struct {
+	// Prefix is prepended to the Workload name, e.g. "score-"
+	// +optional
+	Prefix string `json:"prefix,omitempty" yaml:"prefix,omitempty"`
+
+	// Suffix is appended to the Workload name
+	// +optional
+	Suffix string `json:"suffix,omitempty" yaml:"suffix,omitempty"`
+
+	// ConflictPolicy is "Fail" (default) | "Adopt"
+	// +optional
+	ConflictPolicy ConflictPolicy `json:"conflictPolicy,omitempty" yaml:"conflictPolicy,omitempty"`
 }
 
+// NetworkPolicyPeerSpec admits the pods matching PodLabels in the namespaces matching NamespaceLabels.
+// Without PodLabels all pods of the namespaces are admitted; without NamespaceLabels only pods of the
+// namespace of the protected pods are. At least one of them must be set.
+type NetworkPolicyPeerSpec struct {
+	// PodLabels are the labels of the admitted pods
+	// +optional
+	PodLabels map[string]string `json:"podLabels,omitempty" yaml:"podLabels,omitempty"`
+
+	// NamespaceLabels are the labels of the namespaces of the admitted pods
+	// +optional
+	NamespaceLabels map[string]string `json:"namespaceLabels,omitempty" yaml:"namespaceLabels,omitempty"`
+}
+
+// QuotaSpec limits the Workloads orchestrated within a scope.
+// A Workload is subject to the quota if its namespace and labels match.
+type QuotaSpec struct {
+	// Name identifies the quota in status messages and metrics
+	Name string `json:"name" yaml:"name"`
+
+	// Scope is "namespace" (default) to apply the limits to each namespace separately,
+	// or "cluster" to apply them to all matching Workloads together
+	Scope string `json:"scope,omitempty" yaml:"scope,omitempty"`
+
+	// Namespaces restricts the quota to the listed namespaces; empty means all namespaces
+	Namespaces []string `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+
+	// Selector restricts the quota to Workloads whose labels match (e.g., a team label)
+	Selector *metav1.LabelSelector `json:"selector,omitempty" yaml:"selector,omitempty"`
+
+	// MaxWorkloads is the maximum number of Workloads
+	MaxWorkloads *int32 `json:"maxWorkloads,omitempty" yaml:"maxWorkloads,omitempty"`
+
+	// MaxClaims is the maximum number of resource requests per resource type (e.g., "postgres": 2)
+	MaxClaims map[string]int32 `json:"maxClaims,omitempty" yaml:"maxClaims,omitempty"`
+
+	// CPU is the maximum aggregate CPU request of all containers (e.g., "8")
+	CPU string `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+
+	// Memory is the maximum aggregate memory request of all containers (e.g., "16Gi")
+	Memory string `json:"memory,omitempty" yaml:"memory,omitempty"`
+}
+
+// Quota scopes
+const (
+	// QuotaScopeNamespace applies the quota limits to each namespace separately
+	QuotaScopeNamespace = "namespace"
+	// QuotaScopeCluster applies the quota limits to all matching Workloads together
+	QuotaScopeCluster = "cluster"
+)
+
+// PolicySpec is a platform policy evaluated against Workloads.
+// The expression is CEL and evaluates to true when the Workload complies. It can reference `workload`
+// (the Workload object) and, at the Plan stage, `profile` (the selected profile name) and `backend`
+// (`backendId`, `runtimeClass`, `version` and `kind` of the selected backend).
+type PolicySpec struct {
+	// Name identifies the policy in status messages and events
+	Name string `json:"name" yaml:"name"`
+
+	// Stage is "Admission" (default) to evaluate the policy when the Workload is validated,
+	// or "Plan" to evaluate it before the WorkloadPlan is created, once the backend is selected
+	Stage string `json:"stage,omitempty" yaml:"stage,omitempty"`
+
+	// Expression is the CEL expression; it must evaluate to a boolean
+	Expression string `json:"expression" yaml:"expression"`
+
+	// Mode is "Deny" (default) to hold back non-compliant Workloads, or "Warn" to only report them
+	Mode string `json:"mode,omitempty" yaml:"mode,omitempty"`
+
+	// Message explains the violation to users; defaults to the expression
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+}
+
+// Policy stages
+const (
+	// PolicyStageAdmission evaluates the policy when the Workload is validated
+	PolicyStageAdmission = "Admission"
+	// PolicyStagePlan evaluates the policy before the WorkloadPlan is created
+	PolicyStagePlan = "Plan"
+)
+
+// Policy modes
+const (
+	// PolicyModeDeny holds back Workloads that violate the policy
+	PolicyModeDeny = "Deny"
+	// PolicyModeWarn reports Workloads that violate the policy with a Warning event
+	PolicyModeWarn = "Warn"
+)
+
+// DefaultRegionLabel is the well-known Kubernetes topology label used when RegionLabel is not set
+const DefaultRegionLabel = "topology.kubernetes.io/region"
+
+// Backend reselection policies
+const (
+	// ReselectionPolicySticky keeps the recorded backend as long as it remains an eligible candidate
+	ReselectionPolicySticky = "sticky"
+	// ReselectionPolicyReselectOnChange re-runs backend selection whenever the selection-relevant configuration changes
+	ReselectionPolicyReselectOnChange = "reselect-on-change"
+)
+
 // SelectorSpec defines Kubernetes-style label selectors for conditional configuration
 type SelectorSpec struct {
 	// MatchLabels is a map of exact label matches
@@ -183,3 +831,176 @@ type SelectorSpec struct {
 	// Constraints are additional constraints when this selector matches
 	Constraints *ConstraintsSpec `json:"constraints,omitempty" yaml:"constraints,omitempty"`
 }
+
+// DerivationRuleSpec maps Workloads with the given characteristics to a profile.
+// Unset predicates match every Workload; the set ones are ANDed.
+type DerivationRuleSpec struct {
+	// Profile is the profile to use when this rule matches
+	Profile string `json:"profile" yaml:"profile"`
+
+	// HasService matches Workloads that expose (true) or do not expose (false) service ports
+	HasService *bool `json:"hasService,omitempty" yaml:"hasService,omitempty"`
+
+	// Ports matches Workloads whose service exposes at least one of the ports
+	Ports []int32 `json:"ports,omitempty" yaml:"ports,omitempty"`
+
+	// Annotations matches Workloads carrying all of the annotations with exactly these values
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+}
+
+// RuntimeTargetSpec defines a remote cluster whose runtime controllers run the WorkloadPlans delivered to it
+type RuntimeTargetSpec struct {
+	// Name identifies the target in BackendSpec.Target
+	Name string `json:"name" yaml:"name"`
+
+	// KubeconfigSecretRef references a Secret whose "kubeconfig" key holds the credentials for the remote cluster
+	KubeconfigSecretRef NamespacedName `json:"kubeconfigSecretRef" yaml:"kubeconfigSecretRef"`
+}
+
+// DefaultEnvironmentLabel is the Workload label naming its environment when EnvironmentLabel is not set
+const DefaultEnvironmentLabel = "score.dev/environment"
+
+// NamespacesSpec defines the namespaces provisioned for Workload environments.
+// A Workload labeled with one of the environments is materialized into the namespace
+// "<workload namespace>-<environment>" instead of its own namespace.
+type NamespacesSpec struct {
+	// EnvironmentLabel is the Workload label naming its environment (default "score.dev/environment")
+	EnvironmentLabel string `json:"environmentLabel,omitempty" yaml:"environmentLabel,omitempty"`
+
+	// Environments are the environments Workloads may declare, with the template of their namespaces
+	Environments []EnvironmentSpec `json:"environments" yaml:"environments"`
+}
+
+// EnvironmentSpec is the template of the namespaces provisioned for an environment
+type EnvironmentSpec struct {
+	// Name is the value of the environment label (e.g., "staging")
+	Name string `json:"name" yaml:"name"`
+
+	// Labels are added to the namespaces (e.g., Pod Security admission labels)
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+
+	// Annotations are added to the namespaces
+	Annotations map[string]string `json:"annotations,omitempty" yaml:"annotations,omitempty"`
+
+	// Quota is the ResourceQuota of the namespaces; none when unset
+	Quota *NamespaceQuotaSpec `json:"quota,omitempty" yaml:"quota,omitempty"`
+
+	// NetworkPolicy is "None" (default) or "Isolated" to admit ingress traffic only from pods of the same namespace
+	NetworkPolicy string `json:"networkPolicy,omitempty" yaml:"networkPolicy,omitempty"`
+}
+
+// NamespaceQuotaSpec limits the compute resources of a provisioned namespace
+type NamespaceQuotaSpec struct {
+	// CPU is the maximum aggregate CPU request of the pods (e.g., "8")
+	CPU string `json:"cpu,omitempty" yaml:"cpu,omitempty"`
+
+	// Memory is the maximum aggregate memory request of the pods (e.g., "16Gi")
+	Memory string `json:"memory,omitempty" yaml:"memory,omitempty"`
+
+	// Pods is the maximum number of pods
+	Pods *int32 `json:"pods,omitempty" yaml:"pods,omitempty"`
+}
+
+// Network policies of provisioned namespaces
+const (
+	// NetworkPolicyNone leaves the traffic of the namespace unrestricted (default)
+	NetworkPolicyNone = "None"
+	// NetworkPolicyIsolated admits ingress traffic only from pods of the same namespace
+	NetworkPolicyIsolated = "Isolated"
+)
+
+// AuditSpec configures the retention of the audit trail
+type AuditSpec struct {
+	// MaxRecordsPerWorkload is the number of records kept per Workload; older records are pruned (default 100)
+	MaxRecordsPerWorkload *int32 `json:"maxRecordsPerWorkload,omitempty" yaml:"maxRecordsPerWorkload,omitempty"`
+
+	// MaxAge is how long records are kept, including those of deleted Workloads (default 720h)
+	MaxAge *metav1.Duration `json:"maxAge,omitempty" yaml:"maxAge,omitempty"`
+}
+
+// SupplyChainSpec configures the verification of template refs. Refs pinned by a digest are checked against
+// the manifest the registry serves, and refs matched by a trust policy must carry a cosign signature by one
+// of its keys.
+type SupplyChainSpec struct {
+	// RequireDigest rejects template refs that are not pinned by an OCI digest (e.g., "@sha256:...")
+	RequireDigest bool `json:"requireDigest,omitempty" yaml:"requireDigest,omitempty"`
+
+	// PullSecretRef references a kubernetes.io/dockerconfigjson Secret with the registry credentials.
+	// Registries are accessed anonymously when unset.
+	PullSecretRef *NamespacedName `json:"pullSecretRef,omitempty" yaml:"pullSecretRef,omitempty"`
+
+	// InsecureRegistries are the registry hosts (host or host:port) accessed over plain HTTP
+	InsecureRegistries []string `json:"insecureRegistries,omitempty" yaml:"insecureRegistries,omitempty"`
+
+	// TrustPolicies require cosign signatures on the templates of the repositories they match
+	TrustPolicies []TrustPolicySpec `json:"trustPolicies,omitempty" yaml:"trustPolicies,omitempty"`
+}
+
+// TrustPolicySpec requires templates of matching repositories to be signed with cosign by one of its keys
+type TrustPolicySpec struct {
+	// Name identifies the policy in verification errors
+	Name string `json:"name" yaml:"name"`
+
+	// Repositories are glob patterns of the repositories the policy applies to, including the registry host
+	// (e.g., "registry.example.com/templates/*")
+	Repositories []string `json:"repositories" yaml:"repositories"`
+
+	// PublicKeys are PEM encoded ECDSA public keys (as generated by "cosign generate-key-pair").
+	// A signature by any of them satisfies the policy.
+	PublicKeys []string `json:"publicKeys" yaml:"publicKeys"`
+}
+
+// NotificationSinkSpec defines a destination for lifecycle notifications and the notifications it receives
+type NotificationSinkSpec struct {
+	// Name identifies the sink in logs and metrics
+	Name string `json:"name" yaml:"name"`
+
+	// Type is the payload format: "Webhook" (default) | "Slack" | "CloudEvents"
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+
+	// URL is the endpoint notifications are posted to. Exactly one of url and urlSecretRef must be set.
+	URL string `json:"url,omitempty" yaml:"url,omitempty"`
+
+	// URLSecretRef references a Secret whose "url" key holds the endpoint, for URLs that carry credentials
+	// (e.g., Slack incoming webhooks)
+	URLSecretRef *NamespacedName `json:"urlSecretRef,omitempty" yaml:"urlSecretRef,omitempty"`
+
+	// Events limits the sink to these notification types (e.g., "WorkloadReady"); empty receives all
+	Events []string `json:"events,omitempty" yaml:"events,omitempty"`
+
+	// Namespaces limits the sink to notifications about objects in these namespaces; empty receives all
+	Namespaces []string `json:"namespaces,omitempty" yaml:"namespaces,omitempty"`
+
+	// MinSeverity is the lowest severity the sink receives: "Info" (default) | "Warning"
+	MinSeverity string `json:"minSeverity,omitempty" yaml:"minSeverity,omitempty"`
+}
+
+// Notification sink types
+const (
+	// NotificationSinkWebhook posts the notification as JSON (default)
+	NotificationSinkWebhook = "Webhook"
+	// NotificationSinkSlack posts a message to a Slack incoming webhook
+	NotificationSinkSlack = "Slack"
+	// NotificationSinkCloudEvents posts the notification as a structured CloudEvent
+	NotificationSinkCloudEvents = "CloudEvents"
+)
+
+// Notification severities
+const (
+	// NotificationSeverityInfo marks notifications about progress, such as a Workload becoming ready
+	NotificationSeverityInfo = "Info"
+	// NotificationSeverityWarning marks notifications about failures
+	NotificationSeverityWarning = "Warning"
+)
+
+// Notification types
+const (
+	// NotificationWorkloadReady is sent when a Workload becomes ready
+	NotificationWorkloadReady = "WorkloadReady"
+	// NotificationWorkloadDegraded is sent when a Workload stops being ready because of a failure
+	NotificationWorkloadDegraded = "WorkloadDegraded"
+	// NotificationClaimFailed is sent when a ResourceClaim fails
+	NotificationClaimFailed = "ClaimFailed"
+	// NotificationExposurePublished is sent when the runtime publishes new endpoints of a Workload
+	NotificationExposurePublished = "ExposurePublished"
+)
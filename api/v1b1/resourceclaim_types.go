@@ -74,6 +74,27 @@ type ResourceClaimSpec struct {
 	Params *apiextv1.JSON `json:"params,omitempty"`
 	// DeprovisionPolicy controls lifecycle of provisioned resources when unbound.
 	DeprovisionPolicy *DeprovisionPolicy `json:"deprovisionPolicy,omitempty"`
+	// Metadata are the labels and annotations of the Workload propagated to the provisioned resources.
+	// +optional
+	Metadata *PropagatedMetadata `json:"metadata,omitempty"`
+	// Provision is "external" for claims that adopt an existing resource instead of provisioning one.
+	// +optional
+	Provision string `json:"provision,omitempty"`
+	// SecretRef names the Secret holding the connection details of an external resource.
+	// +optional
+	SecretRef *LocalObjectReference `json:"secretRef,omitempty"`
+	// Optional is true for claims of optional resources, which the Workload runs without until they are bound.
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+// PropagatedMetadata are the Workload labels and annotations selected by the propagation policy of the
+// OrchestratorConfig, to be copied to the resources generated for the Workload.
+type PropagatedMetadata struct {
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
 }
 
 // ResourceClaimPhase indicates coarse-grained resolver progress.
@@ -97,16 +118,45 @@ type LocalObjectReference struct {
 type CertificateOutput struct {
 	// SecretName optionally points to a Secret containing certificate/key data.
 	SecretName *string `json:"secretName,omitempty"`
-	// Data allows inlined certificate/key material (base64-encoded in JSON).
+	// Data allows inlined certificate material (base64-encoded in JSON).
+	// Private keys must not be inlined; publish them through SecretName.
 	Data map[string][]byte `json:"data,omitempty"`
 }
 
+// PersistentVolumeClaimOutput references a provisioned PersistentVolumeClaim and describes its storage.
+type PersistentVolumeClaimOutput struct {
+	// Name is the PersistentVolumeClaim name (namespace is implicit from the claim).
+	Name string `json:"name"`
+	// StorageClass is the storage class of the volume; empty when the cluster default applies.
+	// +optional
+	StorageClass string `json:"storageClass,omitempty"`
+	// Capacity is the capacity of the volume, or the requested size until it is bound (e.g., "10Gi").
+	// +optional
+	Capacity string `json:"capacity,omitempty"`
+}
+
+// ExternalSecretReference locates credentials written to an external secret store (e.g., Vault or AWS Secrets Manager).
+type ExternalSecretReference struct {
+	// Store names the secret store the runtime reads the credentials through.
+	Store string `json:"store"`
+	// Path locates the credentials in the store.
+	Path string `json:"path"`
+	// Version identifies the version of the credentials written by the provisioner.
+	// +optional
+	Version string `json:"version,omitempty"`
+	// Keys lists the credential keys stored at Path.
+	// +optional
+	Keys []string `json:"keys,omitempty"`
+}
+
 // ResourceClaimOutputs groups standardized outputs published by the resolver.
 // At least one field must be set; platforms may define additional conventions by profile.
-// +kubebuilder:validation:XValidation:rule="has(self.secretRef) || has(self.configMapRef) || has(self.uri) || has(self.image) || has(self.cert)",message="at least one of secretRef|configMapRef|uri|image|cert must be set"
+// +kubebuilder:validation:XValidation:rule="has(self.secretRef) || has(self.externalSecretRef) || has(self.configMapRef) || has(self.uri) || has(self.image) || has(self.cert) || has(self.pvcRef) || has(self.hostname)",message="at least one of secretRef|externalSecretRef|configMapRef|uri|image|cert|pvcRef|hostname must be set"
 type ResourceClaimOutputs struct {
 	// SecretRef points to a Secret containing credentials or connection data.
 	SecretRef *LocalObjectReference `json:"secretRef,omitempty"`
+	// ExternalSecretRef points to credentials held by an external secret store instead of a Secret.
+	ExternalSecretRef *ExternalSecretReference `json:"externalSecretRef,omitempty"`
 	// ConfigMapRef points to a ConfigMap containing configuration data.
 	ConfigMapRef *LocalObjectReference `json:"configMapRef,omitempty"`
 	// URI exposes a connection endpoint (e.g., jdbc:, redis:, https:).
@@ -115,6 +165,10 @@ type ResourceClaimOutputs struct {
 	Image *string `json:"image,omitempty"`
 	// Cert provides certificate/key material or a reference to it.
 	Cert *CertificateOutput `json:"cert,omitempty"`
+	// PVCRef points to a PersistentVolumeClaim Workloads can mount.
+	PVCRef *PersistentVolumeClaimOutput `json:"pvcRef,omitempty"`
+	// Hostname exposes a DNS name served for the Workload (e.g., a public DNS record or a certificate subject).
+	Hostname *string `json:"hostname,omitempty"`
 }
 
 // ResourceClaimStatus is written by resolvers to report progress and outputs.
@@ -130,6 +184,22 @@ type ResourceClaimStatus struct {
 	Outputs *ResourceClaimOutputs `json:"outputs,omitempty"`
 	// OutputsAvailable indicates whether outputs are ready for consumption.
 	OutputsAvailable bool `json:"outputsAvailable,omitempty"`
+	// RetryCount is the number of provisioning retries since the claim was last bound.
+	RetryCount int32 `json:"retryCount,omitempty"`
+	// Strategy is the provisioning strategy that provisions the claim (e.g., "postgres" or "webhook").
+	Strategy string `json:"strategy,omitempty"`
+	// ConfigGeneration is the generation of the orchestrator configuration the claim was last
+	// provisioned with.
+	ConfigGeneration int64 `json:"configGeneration,omitempty"`
+	// StartedAt records when provisioning of the observed generation started, including retries.
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+	// BoundAt records when the claim was last bound.
+	BoundAt *metav1.Time `json:"boundAt,omitempty"`
+	// PhaseTransitions records when the claim last entered each phase.
+	// +listType=map
+	// +listMapKey=phase
+	// +optional
+	PhaseTransitions []ResourceClaimPhaseTransition `json:"phaseTransitions,omitempty"`
 
 	// ObservedGeneration is the last reconciled spec generation.
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
@@ -137,6 +207,15 @@ type ResourceClaimStatus struct {
 	LastTransitionTime *metav1.Time `json:"lastTransitionTime,omitempty"`
 }
 
+// ResourceClaimPhaseTransition records when a claim last entered a phase.
+type ResourceClaimPhaseTransition struct {
+	// Phase is the phase entered.
+	// +kubebuilder:validation:Enum=Pending;Claiming;Bound;Failed
+	Phase ResourceClaimPhase `json:"phase"`
+	// LastTransitionTime is when the claim last entered the phase.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+}
+
 // +kubebuilder:object:root=true
 // ResourceClaimList contains a list of ResourceClaim.
 type ResourceClaimList struct {
@@ -19,6 +19,8 @@ package v1b1
 import (
 	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // ContainerSpec defines a container within the workload
@@ -36,7 +38,19 @@ type ContainerSpec struct {
 	// +optional
 	Args []string `json:"args,omitempty"`
 
-	// Variables define environment variables for the container
+	// WorkingDir is the working directory of the container command
+	// +optional
+	WorkingDir string `json:"workingDir,omitempty"`
+
+	// Lifecycle defines hooks run after the container starts and before it stops
+	// +optional
+	Lifecycle *LifecycleSpec `json:"lifecycle,omitempty"`
+
+	// Variables define environment variables for the container. Names starting with SCORE_ are reserved
+	// for the variables runtimes inject (SCORE_WORKLOAD_NAME, SCORE_WORKLOAD_NAMESPACE and
+	// SCORE_WORKLOAD_ENDPOINT) and are rejected.
+	// +kubebuilder:validation:MaxProperties=256
+	// +kubebuilder:validation:XValidation:rule="self.all(name, name.matches('^[A-Za-z_][A-Za-z0-9_]*$'))",message="variable names must consist of letters, digits and '_', and must not start with a digit"
 	// +optional
 	Variables map[string]string `json:"variables,omitempty"`
 
@@ -80,6 +94,10 @@ type FileSpec struct {
 	// BinaryContent is base64-encoded binary file content
 	// +optional
 	BinaryContent *string `json:"binaryContent,omitempty"`
+
+	// NoExpand disables placeholder expansion in Content, which is then mounted verbatim
+	// +optional
+	NoExpand bool `json:"noExpand,omitempty"`
 }
 
 // FileSourceSpec defines an external file source
@@ -123,6 +141,29 @@ type ExecProbe struct {
 	Command []string `json:"command"`
 }
 
+// LifecycleSpec defines the lifecycle hooks of a container
+type LifecycleSpec struct {
+	// PostStart runs immediately after the container is created
+	// +optional
+	PostStart *LifecycleHandler `json:"postStart,omitempty"`
+
+	// PreStop runs before the container is terminated
+	// +optional
+	PreStop *LifecycleHandler `json:"preStop,omitempty"`
+}
+
+// LifecycleHandler defines the action of a lifecycle hook
+// +kubebuilder:validation:XValidation:rule="(has(self.exec) ? 1 : 0) + (has(self.httpGet) ? 1 : 0) == 1",message="exactly one of exec or httpGet must be specified"
+type LifecycleHandler struct {
+	// Exec runs a command in the container
+	// +optional
+	Exec *ExecProbe `json:"exec,omitempty"`
+
+	// HTTPGet sends an HTTP request to the container
+	// +optional
+	HTTPGet *HTTPGetProbe `json:"httpGet,omitempty"`
+}
+
 // ResourceRequirements defines compute resource requirements
 type ResourceRequirements struct {
 	// Limits defines maximum resource usage
@@ -141,8 +182,35 @@ type ServiceSpec struct {
 	Ports []ServicePort `json:"ports,omitempty"`
 }
 
+// ServiceAccountSpec defines the identity the workload's pods run as
+// +kubebuilder:validation:XValidation:rule="!has(self.annotations) || !has(self.create) || self.create",message="annotations require create"
+type ServiceAccountSpec struct {
+	// Create makes the runtime create and manage the ServiceAccount (default true).
+	// When false, the named ServiceAccount must already exist.
+	// +optional
+	Create *bool `json:"create,omitempty"`
+
+	// Name of the ServiceAccount; defaults to the Workload name
+	// +kubebuilder:validation:MaxLength=253
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*$`
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Annotations are set on the created ServiceAccount, e.g. to bind a cloud identity
+	// (eks.amazonaws.com/role-arn for IRSA, iam.gke.io/gcp-service-account for GKE Workload Identity)
+	// +optional
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
 // ServicePort defines a service port
 type ServicePort struct {
+	// Name names the port on the generated Service, "port-<index>" by default.
+	// Endpoints prefer ports named "https" or "http"; names must be unique within the Workload.
+	// +kubebuilder:validation:MaxLength=15
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	Name string `json:"name,omitempty"`
+
 	// Port is the service port number
 	// +kubebuilder:validation:Minimum=1
 	// +kubebuilder:validation:Maximum=65535
@@ -154,12 +222,66 @@ type ServicePort struct {
 	// +optional
 	Protocol string `json:"protocol,omitempty"`
 
-	// TargetPort is the container port to forward to
+	// TargetPort is the container port to forward to: a port number, a named container port,
+	// or a placeholder resolving to either (e.g., "${resources.app.outputs.port}")
+	// +kubebuilder:validation:XValidation:rule="type(self) == string || (self >= 1 && self <= 65535)",message="targetPort must be between 1 and 65535"
+	// +optional
+	TargetPort *intstr.IntOrString `json:"targetPort,omitempty"`
+
+	// TLS marks the port as serving TLS, so endpoints published for it use the https scheme
+	// +optional
+	TLS bool `json:"tls,omitempty"`
+}
+
+// StorageSpec declares persistent storage for the Workload
+type StorageSpec struct {
+	// Volumes are persistent volumes mounted into every container of the Workload.
+	// Each replica gets its own copy of every volume with a size; volumes with a source are shared.
+	// +kubebuilder:validation:MinItems=1
+	// +kubebuilder:validation:MaxItems=10
+	// +listType=map
+	// +listMapKey=name
+	Volumes []PersistentVolumeSpec `json:"volumes"`
+}
+
+// PersistentVolumeSpec defines a persistent volume of the Workload
+// +kubebuilder:validation:XValidation:rule="has(self.size) != has(self.source)",message="exactly one of size or source must be specified"
+type PersistentVolumeSpec struct {
+	// Name identifies the volume
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:MaxLength=63
+	Name string `json:"name"`
+
+	// Size is the requested capacity of a per-replica volume (e.g., "10Gi")
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Size string `json:"size,omitempty"`
+
+	// Source names an existing PersistentVolumeClaim shared by all replicas instead of a per-replica
+	// volume, typically the output of a volume resource (e.g., "${resources.data.outputs.pvcRef}")
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Source string `json:"source,omitempty"`
+
+	// Target is the path where the volume is mounted in the containers
+	// +kubebuilder:validation:MinLength=1
+	Target string `json:"target"`
+
+	// ReadOnly mounts the volume read-only
 	// +optional
-	TargetPort *int32 `json:"targetPort,omitempty"`
+	ReadOnly bool `json:"readOnly,omitempty"`
 }
 
+// Provision modes of a resource
+const (
+	// ProvisionManaged provisions the resource through the provisioner of its type
+	ProvisionManaged = "managed"
+	// ProvisionExternal adopts a resource the user runs themselves; its connection details are read from a Secret
+	ProvisionExternal = "external"
+)
+
 // ResourceSpec defines an external resource dependency
+// +kubebuilder:validation:XValidation:rule="has(self.secretRef) == (has(self.provision) && self.provision == 'external')",message="secretRef is required for, and only allowed with, provision external"
 type ResourceSpec struct {
 	// Type of the resource (e.g., "postgresql", "redis", "s3")
 	// +kubebuilder:validation:MinLength=1
@@ -172,15 +294,49 @@ type ResourceSpec struct {
 	// Params are resource-specific parameters
 	// +optional
 	Params *apiextv1.JSON `json:"params,omitempty"`
+
+	// Provision selects how the resource is provided: "managed" (default) provisions it through the
+	// provisioner of its type; "external" adopts an existing resource whose connection details are in SecretRef
+	// +kubebuilder:validation:Enum=managed;external
+	// +optional
+	Provision string `json:"provision,omitempty"`
+
+	// SecretRef names a Secret in the Workload namespace holding the connection details of an external resource.
+	// Its keys must include the output keys configured for the type; claims publish it as their secretRef output.
+	// +optional
+	SecretRef *LocalObjectReference `json:"secretRef,omitempty"`
+
+	// Optional marks a nice-to-have resource (e.g., a feature-flag service) the Workload starts without: its claim
+	// does not hold back ClaimsReady or the plan, and its placeholders resolve to their default or "" until it is bound
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+
+	// DependsOn lists resources of the same Workload whose claims must be bound before the claim
+	// for this resource is created (e.g., a schema migration that needs its database)
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:MinLength=1
+	// +listType=set
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
 }
 
 // WorkloadSpec defines the desired state of Workload
 type WorkloadSpec struct {
-	// Profile specifies which orchestrator profile to use for this workload
-	// If not specified, the default profile from orchestrator config will be used
+	// Profile specifies which orchestrator profile to use for this workload.
+	// It takes precedence over the score.dev/profile annotation and must name a configured profile.
+	// If neither is specified, the profile is derived from the workload or the configured defaults.
+	// +kubebuilder:validation:MinLength=1
 	// +optional
 	Profile *string `json:"profile,omitempty"`
 
+	// Requirements lists abstract features the workload requires (e.g., "scale-to-zero"), in addition to
+	// those of the score.dev/requirements annotation. Each must be a feature constrained by a configured backend.
+	// +kubebuilder:validation:MaxItems=32
+	// +kubebuilder:validation:items:MinLength=1
+	// +listType=set
+	// +optional
+	Requirements []string `json:"requirements,omitempty"`
+
 	// Containers define the containers in the workload
 	// +kubebuilder:validation:MinProperties=1
 	// +kubebuilder:validation:MaxProperties=10
@@ -191,9 +347,44 @@ type WorkloadSpec struct {
 	// +optional
 	Service *ServiceSpec `json:"service,omitempty"`
 
-	// Resources define external resource dependencies
+	// Resources define external resource dependencies. Keys name the ResourceClaims ("<workload>-<key>")
+	// and must be DNS labels.
+	// +kubebuilder:validation:MaxProperties=64
+	// +kubebuilder:validation:XValidation:rule="self.all(key, size(key) <= 63 && key.matches('^[a-z0-9]([-a-z0-9]*[a-z0-9])?$'))",message="resource keys must be DNS labels: at most 63 lowercase alphanumeric characters or '-', starting and ending with an alphanumeric character"
 	// +optional
 	Resources map[string]ResourceSpec `json:"resources,omitempty"`
+
+	// ServiceAccount configures the ServiceAccount the workload's pods run as
+	// +optional
+	ServiceAccount *ServiceAccountSpec `json:"serviceAccount,omitempty"`
+
+	// Storage declares persistent volumes. Continuously running Workloads with per-replica volumes
+	// get a stable identity per replica (a StatefulSet on Kubernetes).
+	// +optional
+	Storage *StorageSpec `json:"storage,omitempty"`
+
+	// Schedule runs the Workload to completion on a cron schedule (e.g., "0 3 * * *").
+	// Workloads with a schedule are materialized as CronJobs regardless of the profile kind.
+	// +kubebuilder:validation:MinLength=1
+	// +optional
+	Schedule *string `json:"schedule,omitempty"`
+
+	// DependsOn lists Workloads in the same namespace that must report Ready=True
+	// before the WorkloadPlan for this Workload is created
+	// +kubebuilder:validation:MaxItems=32
+	// +listType=set
+	// +optional
+	DependsOn []string `json:"dependsOn,omitempty"`
+
+	// Environments instantiates the Workload once per listed environment instead of running it directly.
+	// Each instance is a Workload named "<name>-<environment>" and labeled with the environment, with its own
+	// claims, plan and profile selection; the readiness of the instances is aggregated in status.environments.
+	// +kubebuilder:validation:MaxItems=16
+	// +kubebuilder:validation:items:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +kubebuilder:validation:items:MaxLength=63
+	// +listType=set
+	// +optional
+	Environments []string `json:"environments,omitempty"`
 }
 
 // ClaimSummary provides a summary of a resource claim status
@@ -202,6 +393,10 @@ type ClaimSummary struct {
 	// +kubebuilder:validation:MinLength=1
 	Key string `json:"key"`
 
+	// Type is the resource type of the claim (e.g., "postgres", "redis")
+	// +optional
+	Type string `json:"type,omitempty"`
+
 	// Phase indicates the current phase of the claim
 	// +kubebuilder:validation:Enum=Pending;Claiming;Bound;Failed
 	Phase ResourceClaimPhase `json:"phase"`
@@ -217,6 +412,116 @@ type ClaimSummary struct {
 	// OutputsAvailable indicates whether the claim outputs are available
 	// +optional
 	OutputsAvailable bool `json:"outputsAvailable,omitempty"`
+
+	// Optional indicates that the claim is for an optional resource and does not affect readiness
+	// +optional
+	Optional bool `json:"optional,omitempty"`
+}
+
+// WorkloadEndpoint describes a single endpoint through which the workload is reachable
+type WorkloadEndpoint struct {
+	// URL is the accessible endpoint URL
+	// +kubebuilder:validation:Format=uri
+	URL string `json:"url"`
+
+	// Type describes the exposure mechanism (e.g., "clusterip", "nodeport", "loadbalancer")
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// Ready indicates if this endpoint is ready to serve traffic
+	Ready bool `json:"ready"`
+
+	// PortName identifies the service port backing this endpoint
+	// +optional
+	PortName string `json:"portName,omitempty"`
+}
+
+// WorkloadEnvironmentStatus reports the instance of a Workload for one of its environments
+type WorkloadEnvironmentStatus struct {
+	// Name is the environment
+	Name string `json:"name"`
+
+	// Workload is the name of the Workload instantiated for the environment
+	Workload string `json:"workload"`
+
+	// Ready is the status of the Ready condition of the instance
+	// +kubebuilder:validation:Enum=True;False;Unknown
+	Ready metav1.ConditionStatus `json:"ready"`
+
+	// Reason is the abstract reason of the instance status
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is the human-readable message of the instance status
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Endpoint is the primary endpoint of the instance
+	// +optional
+	Endpoint *string `json:"endpoint,omitempty"`
+}
+
+// WorkloadPreview describes what the Orchestrator would emit for a Workload in dry-run mode
+type WorkloadPreview struct {
+	// ObservedGeneration is the Workload generation the preview was computed from
+	ObservedGeneration int64 `json:"observedGeneration"`
+
+	// BackendID is the backend that would be selected
+	BackendID string `json:"backendId"`
+
+	// RuntimeClass is the runtime controller class that would materialize the plan
+	RuntimeClass string `json:"runtimeClass"`
+
+	// Template is the template the plan would reference
+	// +optional
+	Template *TemplateSpec `json:"template,omitempty"`
+
+	// Exposure is the exposure mode configured on the selected backend
+	// +optional
+	Exposure *ExposureSpec `json:"exposure,omitempty"`
+
+	// Claims lists the resource claims that would be created
+	// +optional
+	Claims []PlanClaim `json:"claims,omitempty"`
+
+	// ResolvedValues contains the composed values of the plan.
+	// Placeholders whose outputs are not available yet are kept verbatim.
+	// +optional
+	ResolvedValues *runtime.RawExtension `json:"resolvedValues,omitempty"`
+
+	// UnresolvedPlaceholders lists the value paths that reference outputs which are not available yet
+	// +optional
+	UnresolvedPlaceholders []string `json:"unresolvedPlaceholders,omitempty"`
+}
+
+// WorkloadBinding records which profile and backend were selected for a Workload
+type WorkloadBinding struct {
+	// Profile is the name of the selected profile
+	Profile string `json:"profile"`
+
+	// BackendID is the identifier of the selected backend
+	BackendID string `json:"backendId"`
+
+	// RuntimeClass is the runtime class of the selected backend
+	// +optional
+	RuntimeClass string `json:"runtimeClass,omitempty"`
+
+	// TemplateRef is the template reference (digest recommended) of the selected backend
+	// +optional
+	TemplateRef string `json:"templateRef,omitempty"`
+
+	// TemplateDigest is the sha256 digest of the selected template (kind, ref and default values)
+	// +optional
+	TemplateDigest string `json:"templateDigest,omitempty"`
+
+	// SelectedAt is the time the binding last changed
+	// +optional
+	SelectedAt *metav1.Time `json:"selectedAt,omitempty"`
+
+	// ConfigHash fingerprints the selection-relevant orchestrator configuration
+	// (profiles and defaults) the selection was made with
+	// +optional
+	ConfigHash string `json:"configHash,omitempty"`
 }
 
 // WorkloadStatus defines the observed state of Workload.
@@ -226,6 +531,20 @@ type WorkloadStatus struct {
 	// +optional
 	Endpoint *string `json:"endpoint,omitempty"`
 
+	// Endpoints lists all URIs through which the workload is reachable,
+	// ordered by priority. The first entry matches Endpoint.
+	// +optional
+	Endpoints []WorkloadEndpoint `json:"endpoints,omitempty"`
+
+	// Reason is a machine-readable summary of the workload state,
+	// taken from the canonical abstract reason vocabulary (e.g., "Succeeded", "ProfileNotFound")
+	// +optional
+	Reason string `json:"reason,omitempty"`
+
+	// Message is a neutral, human-readable summary accompanying Reason
+	// +optional
+	Message string `json:"message,omitempty"`
+
 	// Conditions represent the current state of the Workload resource.
 	// Standard condition types:
 	// - "Ready": the workload is fully functional
@@ -237,15 +556,40 @@ type WorkloadStatus struct {
 	// +optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
-	// Claims provide a summary of resource claim statuses
+	// ObservedGeneration is the Workload generation the status was computed from.
+	// The status may be stale while it is lower than metadata.generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Claims summarize the status of each resource claim, ordered by key,
+	// so that the claims blocking ClaimsReady can be identified without listing ResourceClaims
 	// +optional
 	Claims []ClaimSummary `json:"claims,omitempty"`
+
+	// Binding records the profile and backend currently selected for the workload
+	// +optional
+	Binding *WorkloadBinding `json:"binding,omitempty"`
+
+	// Preview shows what the Orchestrator would emit. It is only set while the
+	// score.dev/dry-run annotation is "true"; no claims or plans are created in that mode.
+	// +optional
+	Preview *WorkloadPreview `json:"preview,omitempty"`
+
+	// Environments reports the instances of a Workload that lists spec.environments, ordered as listed
+	// +optional
+	Environments []WorkloadEnvironmentStatus `json:"environments,omitempty"`
+
+	// ExpiresAt is when the Orchestrator deletes the Workload. It is only set while the
+	// score.dev/ttl annotation is present.
+	// +optional
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
 }
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:shortName=wl
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="READY",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="REASON",type="string",JSONPath=".status.reason"
 // +kubebuilder:printcolumn:name="ENDPOINT",type="string",JSONPath=".status.endpoint"
 // +kubebuilder:printcolumn:name="CLAIMS",type="string",JSONPath=".status.claims"
 // +kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
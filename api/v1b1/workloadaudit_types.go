@@ -0,0 +1,98 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1b1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Audited orchestration decisions
+const (
+	// AuditActionBackendSelected records that a backend was bound to the Workload
+	AuditActionBackendSelected = "BackendSelected"
+	// AuditActionPlanCreated records that the WorkloadPlan of the Workload was created
+	AuditActionPlanCreated = "PlanCreated"
+	// AuditActionPlanUpdated records that the WorkloadPlan of the Workload was updated
+	AuditActionPlanUpdated = "PlanUpdated"
+	// AuditActionClaimBound records that a ResourceClaim of the Workload was bound
+	AuditActionClaimBound = "ClaimBound"
+	// AuditActionExposurePublished records that endpoints of the Workload were published
+	AuditActionExposurePublished = "ExposurePublished"
+)
+
+// WorkloadAuditSpec records one orchestration decision about a Workload.
+// Records are written by the Orchestrator and cannot be changed once created.
+// +kubebuilder:validation:XValidation:rule="self == oldSelf",message="audit records are immutable"
+type WorkloadAuditSpec struct {
+	// WorkloadRef identifies the Workload the decision was made for.
+	WorkloadRef WorkloadAuditWorkloadRef `json:"workloadRef"`
+	// Action is the decision that was made.
+	// +kubebuilder:validation:Enum=BackendSelected;PlanCreated;PlanUpdated;ClaimBound;ExposurePublished
+	Action string `json:"action"`
+	// Controller identifies the Orchestrator component that made the decision (e.g., "plan-manager").
+	Controller string `json:"controller"`
+	// Time is when the decision was made.
+	Time metav1.Time `json:"time"`
+	// Message describes the decision in human-readable form.
+	// +optional
+	Message string `json:"message,omitempty"`
+	// Details carries the facts of the decision, such as the selected backend or the hash of the plan values.
+	// +optional
+	Details map[string]string `json:"details,omitempty"`
+}
+
+// WorkloadAuditWorkloadRef identifies a Workload and the generation a decision was made for.
+type WorkloadAuditWorkloadRef struct {
+	// Name is the name of the Workload, in the namespace of the record.
+	Name string `json:"name"`
+	// UID is the UID of the Workload, which distinguishes Workloads recreated under the same name.
+	// +optional
+	UID string `json:"uid,omitempty"`
+	// Generation is the Workload generation the decision was made for, when it depends on the Workload spec.
+	// +optional
+	Generation int64 `json:"generation,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced
+// +kubebuilder:printcolumn:name="Workload",type="string",JSONPath=".spec.workloadRef.name"
+// +kubebuilder:printcolumn:name="Action",type="string",JSONPath=".spec.action"
+// +kubebuilder:printcolumn:name="Controller",type="string",JSONPath=".spec.controller"
+// +kubebuilder:printcolumn:name="Time",type="date",JSONPath=".spec.time"
+
+// WorkloadAudit is an append-only record of an orchestration decision, kept for compliance.
+// Records are not owned by their Workload, so the audit trail outlives it until the retention
+// configured in the OrchestratorConfig prunes it.
+type WorkloadAudit struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec WorkloadAuditSpec `json:"spec"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkloadAuditList contains a list of WorkloadAudit
+type WorkloadAuditList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkloadAudit `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkloadAudit{}, &WorkloadAuditList{})
+}
@@ -29,6 +29,14 @@ type WorkloadExposureSpec struct {
 	ObservedWorkloadGeneration int64 `json:"observedWorkloadGeneration"`
 	// RuntimeClass is the runtime controller class responsible for materializing exposures.
 	RuntimeClass string `json:"runtimeClass"`
+	// Ports are the service ports of the Workload at ObservedWorkloadGeneration.
+	// +optional
+	Ports []ServicePort `json:"ports,omitempty"`
+	// Scheme overrides the URL scheme used when publishing exposures.
+	// If not specified, the runtime infers the scheme from ports and annotations.
+	// +kubebuilder:validation:Enum=http;https
+	// +optional
+	Scheme string `json:"scheme,omitempty"`
 }
 
 // WorkloadExposureWorkloadRef identifies a Workload resource.
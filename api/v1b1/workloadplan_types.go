@@ -76,15 +76,92 @@ type WorkloadPlanSpec struct {
 	ObservedWorkloadGeneration int64 `json:"observedWorkloadGeneration"`
 	// RuntimeClass is the selected runtime controller class.
 	RuntimeClass string `json:"runtimeClass"`
+	// Target names the remote cluster the plan is delivered to. Runtime controllers of this cluster
+	// ignore plans with a target; the Orchestrator writes a copy without it to the remote cluster.
+	// +optional
+	Target string `json:"target,omitempty"`
+	// Namespace is the namespace the runtime materializes the Workload into, provisioned by the Orchestrator
+	// for the environment of the Workload. Empty materializes it into the namespace of the Workload.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
 	// Template contains the reference and type information for runtime materialization.
 	Template *TemplateSpec `json:"template,omitempty"`
 	// ResolvedValues contains fully resolved final values with all placeholders substituted.
 	// Runtime controllers should use this as the single source of truth for template values.
-	// Format: { containers: { <name>: { env: { <key>: <value|valueFrom> }}}, service: {...}, ... }
+	// Format: { containers: { <name>: { env: { <key>: <value> }, files: [ { target, mode, content, source, binaryContent } ] }}, serviceAccount: { name, annotations }, ... }
 	// Note: CEL validation for placeholder prevention is not implemented due to RawExtension type limitations
 	ResolvedValues *runtime.RawExtension `json:"resolvedValues,omitempty"`
+	// ResolvedValuesRef references the ConfigMap holding the resolved values when they are too large to be
+	// embedded in the plan. It is mutually exclusive with ResolvedValues; runtimes must verify the hash.
+	// +optional
+	ResolvedValuesRef *ValuesReference `json:"resolvedValuesRef,omitempty"`
 	// Claims declares resource requirements to be materialized by the runtime.
 	Claims []PlanClaim `json:"claims,omitempty"`
+	// Exposure carries the exposure mode configured on the selected backend.
+	// +optional
+	Exposure *ExposureSpec `json:"exposure,omitempty"`
+	// Kind is the workload kind to materialize: "Service" (default) | "Job" | "CronJob".
+	// +kubebuilder:validation:Enum=Service;Job;CronJob
+	// +optional
+	Kind string `json:"kind,omitempty"`
+	// Schedule is the cron schedule for the CronJob kind.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+	// SecurityContext is the resolved security context for generated pods.
+	// Nil when the Workload opted out of the security defaults.
+	// +optional
+	SecurityContext *SecurityContextSpec `json:"securityContext,omitempty"`
+	// NetworkPolicy is the resolved ingress policy of the Workload pods. Nil when none is configured.
+	// +optional
+	NetworkPolicy *IngressPolicySpec `json:"networkPolicy,omitempty"`
+	// DisruptionBudget is the PodDisruptionBudget policy of a Service workload, resolved from the
+	// defaults of the profile and backend. Nil when none is configured.
+	// +optional
+	DisruptionBudget *DisruptionBudgetSpec `json:"disruptionBudget,omitempty"`
+	// DefaultResources are the container requests and limits applied to resources a container does not
+	// declare, resolved from the defaults of the profile and backend.
+	// +optional
+	DefaultResources *ResourceRequirements `json:"defaultResources,omitempty"`
+	// PriorityClass is the PriorityClass of the pods, resolved from the defaults of the profile and backend.
+	// Nil leaves the priority of the pods to the cluster default.
+	// +optional
+	PriorityClass *PriorityClassSpec `json:"priorityClass,omitempty"`
+	// RolloutDeadline bounds how long the runtime may take to roll out a Service workload
+	// before it reports the plan as Failed. Nil means no deadline.
+	// +optional
+	RolloutDeadline *metav1.Duration `json:"rolloutDeadline,omitempty"`
+	// WorkloadSnapshot is the Workload spec the plan was computed from. It is set only when the plan was
+	// restored from history after a failed rollout, or when the profile merged workload defaults into the
+	// Workload spec; runtimes then materialize it instead of the current Workload spec.
+	// +optional
+	WorkloadSnapshot *runtime.RawExtension `json:"workloadSnapshot,omitempty"`
+	// Metadata are the labels and annotations of the Workload the runtime copies to the resources it
+	// generates, in addition to the labels it owns.
+	// +optional
+	Metadata *PropagatedMetadata `json:"metadata,omitempty"`
+	// Naming is the naming strategy and conflict policy of the runtime resources, resolved from the
+	// defaults of the configuration. Nil names them after the Workload and fails on conflicts.
+	// +optional
+	Naming *NamingSpec `json:"naming,omitempty"`
+	// ReadinessGates are the conditions of the plan status that must be True, in addition to the Ready
+	// phase, before the Workload is Ready. They are resolved from the profile.
+	// +listType=atomic
+	// +optional
+	ReadinessGates []ReadinessGate `json:"readinessGates,omitempty"`
+	// RestartedAt is the score.dev/restart annotation of the Workload. Runtimes roll out new instances
+	// whenever it changes, even if nothing else in the plan did.
+	// +optional
+	RestartedAt string `json:"restartedAt,omitempty"`
+}
+
+// ValuesReference references resolved values stored outside of the WorkloadPlan
+type ValuesReference struct {
+	// Name of the ConfigMap in the namespace of the plan; the values are stored under the key values.json
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// SHA256 is the hex-encoded SHA-256 digest of the stored values
+	// +kubebuilder:validation:Pattern=`^[0-9a-f]{64}$`
+	SHA256 string `json:"sha256"`
 }
 
 // WorkloadPlanPhase represents the current phase of WorkloadPlan runtime provisioning.
@@ -117,6 +194,84 @@ type WorkloadPlanStatus struct {
 	// Message provides human-readable status information.
 	// +optional
 	Message string `json:"message,omitempty"`
+
+	// ObservedGeneration is the plan generation the runtime last materialized.
+	// The phase may be stale while it is lower than metadata.generation.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Rollout reports the progress of a canary or blue/green rollout.
+	// Nil when the runtime updates the workload in place.
+	// +optional
+	Rollout *RolloutStatus `json:"rollout,omitempty"`
+
+	// Acknowledgment identifies the runtime consuming the plan. Runtimes MUST set it and renew
+	// lastHeartbeat periodically; the Orchestrator reports RuntimeReady=False with reason
+	// RuntimeUnresponsive once the heartbeat is stale, or when no runtime acknowledged the plan in time.
+	// +optional
+	Acknowledgment *WorkloadPlanAcknowledgment `json:"acknowledgment,omitempty"`
+}
+
+// WorkloadPlanAcknowledgment records which runtime consumes a plan and when it last confirmed it is running.
+type WorkloadPlanAcknowledgment struct {
+	// ConsumedBy names the runtime controller consuming the plan, e.g. its runtimeClass.
+	ConsumedBy string `json:"consumedBy"`
+
+	// ConsumerVersion is the version of the runtime controller.
+	// +optional
+	ConsumerVersion string `json:"consumerVersion,omitempty"`
+
+	// LastHeartbeat is when the runtime last confirmed it is running.
+	LastHeartbeat metav1.Time `json:"lastHeartbeat"`
+}
+
+// RolloutStrategy is a progressive rollout strategy implemented by the runtime.
+// +kubebuilder:validation:Enum=Canary;BlueGreen
+type RolloutStrategy string
+
+const (
+	// RolloutStrategyCanary shifts an increasing share of replicas to the new revision in steps
+	RolloutStrategyCanary RolloutStrategy = "Canary"
+	// RolloutStrategyBlueGreen starts the new revision next to the current one and switches traffic at once
+	RolloutStrategyBlueGreen RolloutStrategy = "BlueGreen"
+)
+
+// RolloutPhase is the stage of a progressive rollout.
+// +kubebuilder:validation:Enum=Progressing;Promoting;Completed
+type RolloutPhase string
+
+const (
+	// RolloutPhaseProgressing indicates the new revision runs next to the current one
+	RolloutPhaseProgressing RolloutPhase = "Progressing"
+	// RolloutPhasePromoting indicates the new revision replaces the current one
+	RolloutPhasePromoting RolloutPhase = "Promoting"
+	// RolloutPhaseCompleted indicates the new revision has been fully promoted
+	RolloutPhaseCompleted RolloutPhase = "Completed"
+)
+
+// RolloutStatus reports the progress of a progressive rollout.
+type RolloutStatus struct {
+	// Strategy is the rollout strategy in use.
+	Strategy RolloutStrategy `json:"strategy"`
+
+	// Revision identifies the pod template being rolled out.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// Phase is the stage of the rollout.
+	Phase RolloutPhase `json:"phase"`
+
+	// Step is the index of the current canary step.
+	// +optional
+	Step int32 `json:"step,omitempty"`
+
+	// Weight is the replica share of the new revision during a canary step, in percent.
+	// +optional
+	Weight int32 `json:"weight,omitempty"`
+
+	// StepStartTime is when the current step became ready; its pause is counted from here.
+	// +optional
+	StepStartTime *metav1.Time `json:"stepStartTime,omitempty"`
 }
 
 // +kubebuilder:object:root=true
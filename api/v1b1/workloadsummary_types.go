@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1b1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// WorkloadSummaryName is the name of the WorkloadSummary the Orchestrator maintains in each namespace.
+// With sharded Orchestrators, each shard maintains "<name>-<shard index>" for the Workloads it reconciles.
+const WorkloadSummaryName = "workloads"
+
+// WorkloadSummaryStatus summarizes the readiness of the Workloads of a namespace
+type WorkloadSummaryStatus struct {
+	// Workloads is the number of Workloads in the namespace.
+	Workloads int32 `json:"workloads"`
+	// Ready is the number of Workloads whose Ready condition is True.
+	Ready int32 `json:"ready"`
+	// NotReady is the number of Workloads whose Ready condition is False.
+	NotReady int32 `json:"notReady"`
+	// Unknown is the number of Workloads whose readiness was not reported yet.
+	Unknown int32 `json:"unknown"`
+	// Reasons counts the Workloads that are not ready by the reason of their Ready condition.
+	// +optional
+	Reasons map[string]int32 `json:"reasons,omitempty"`
+	// ClaimFailures lists the resource types with failed claims, most failures first.
+	// +optional
+	// +listType=atomic
+	ClaimFailures []ClaimFailureSummary `json:"claimFailures,omitempty"`
+	// SlowestPending lists the Workloads that have not been ready for the longest time, longest first.
+	// +optional
+	// +listType=atomic
+	SlowestPending []PendingWorkloadSummary `json:"slowestPending,omitempty"`
+	// LastUpdateTime is when the summary was last written.
+	// +optional
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+}
+
+// ClaimFailureSummary counts the failed claims of a resource type
+type ClaimFailureSummary struct {
+	// Type is the resource type of the claims.
+	Type string `json:"type"`
+	// Failed is the number of failed claims of the type.
+	Failed int32 `json:"failed"`
+	// Workloads lists the first Workloads, by name, with failed claims of the type.
+	// +optional
+	// +listType=atomic
+	Workloads []string `json:"workloads,omitempty"`
+}
+
+// PendingWorkloadSummary identifies a Workload that is not ready
+type PendingWorkloadSummary struct {
+	// Name is the name of the Workload.
+	Name string `json:"name"`
+	// Reason is the reason of its Ready condition.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+	// Since is when the Workload stopped being ready, or when it was created if its readiness was not reported yet.
+	Since metav1.Time `json:"since"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Namespaced
+// +kubebuilder:printcolumn:name="Workloads",type="integer",JSONPath=".status.workloads"
+// +kubebuilder:printcolumn:name="Ready",type="integer",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="NotReady",type="integer",JSONPath=".status.notReady"
+// +kubebuilder:printcolumn:name="Updated",type="date",JSONPath=".status.lastUpdateTime"
+
+// WorkloadSummary summarizes the Workloads of a namespace for dashboards, so that they need not list and join
+// Workloads, ResourceClaims and WorkloadPlans. It is maintained by the Orchestrator and read-only for users.
+type WorkloadSummary struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Status WorkloadSummaryStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// WorkloadSummaryList contains a list of WorkloadSummary
+type WorkloadSummaryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []WorkloadSummary `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&WorkloadSummary{}, &WorkloadSummaryList{})
+}
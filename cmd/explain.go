@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"sigs.k8s.io/yaml"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/config"
+	"github.com/cappyzawa/score-orchestrator/internal/selection"
+)
+
+// explainCommand is the subcommand that explains backend selection offline
+const explainCommand = "explain"
+
+// runExplain runs the backend selection pipeline for a Workload manifest against an orchestrator
+// configuration file and prints the explanation. It returns the process exit code.
+// The cluster is not contacted, so the region is taken from the Workload labels only.
+func runExplain(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet(explainCommand, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var configPath, workloadPath string
+	fs.StringVar(&configPath, "config", "",
+		"Path to the orchestrator configuration YAML (the config.yaml key of the orchestrator ConfigMap).")
+	fs.StringVar(&workloadPath, "workload", "",
+		"Path to the Workload YAML. A status.binding, e.g. from 'kubectl get workload -o yaml', is honored.")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: %s %s --config <file> --workload <file>\n", os.Args[0], explainCommand)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if configPath == "" || workloadPath == "" {
+		fs.Usage()
+		return 2
+	}
+
+	orchestratorConfig, err := loadExplainConfig(configPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "%s: %v\n", explainCommand, err)
+		return 1
+	}
+	workload, err := loadExplainWorkload(workloadPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "%s: %v\n", explainCommand, err)
+		return 1
+	}
+
+	explanation := selection.Explain(context.Background(), nil, workload, orchestratorConfig)
+	_, _ = fmt.Fprint(stdout, explanation.String())
+	if explanation.Error != "" {
+		return 1
+	}
+	return 0
+}
+
+// loadExplainConfig reads and validates an orchestrator configuration file
+func loadExplainConfig(path string) (*scorev1b1.OrchestratorConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	orchestratorConfig, err := config.ParseConfig(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	if err := config.NewValidator().Validate(orchestratorConfig); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	return orchestratorConfig, nil
+}
+
+// loadExplainWorkload reads a Workload manifest
+func loadExplainWorkload(path string) (*scorev1b1.Workload, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read workload: %w", err)
+	}
+	workload := &scorev1b1.Workload{}
+	if err := yaml.Unmarshal(data, workload); err != nil {
+		return nil, fmt.Errorf("failed to parse workload: %w", err)
+	}
+	return workload, nil
+}
@@ -17,9 +17,13 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
+	"fmt"
+	"net/http"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -30,18 +34,31 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/audit"
 	"github.com/cappyzawa/score-orchestrator/internal/config"
 	"github.com/cappyzawa/score-orchestrator/internal/controller"
 	"github.com/cappyzawa/score-orchestrator/internal/controller/managers"
+	"github.com/cappyzawa/score-orchestrator/internal/delivery"
 	"github.com/cappyzawa/score-orchestrator/internal/endpoint"
+	"github.com/cappyzawa/score-orchestrator/internal/events"
+	"github.com/cappyzawa/score-orchestrator/internal/faultinject"
+	"github.com/cappyzawa/score-orchestrator/internal/health"
+	"github.com/cappyzawa/score-orchestrator/internal/logging"
 	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/notification"
+	"github.com/cappyzawa/score-orchestrator/internal/sharding"
+	"github.com/cappyzawa/score-orchestrator/internal/summary"
+	"github.com/cappyzawa/score-orchestrator/internal/tracing"
 	// +kubebuilder:scaffold:imports
 )
 
@@ -59,6 +76,15 @@ func init() {
 
 // nolint:gocyclo
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case explainCommand:
+			os.Exit(runExplain(os.Args[2:], os.Stdout, os.Stderr))
+		case restartCommand:
+			os.Exit(runRestart(os.Args[2:], os.Stdout, os.Stderr))
+		}
+	}
+
 	var metricsAddr string
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
@@ -66,6 +92,11 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var enableTracing bool
+	var logLevel string
+	var workloadConcurrency, provisionerConcurrency int
+	var orphanSweepInterval time.Duration
+	var shard sharding.Shard
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -84,12 +115,39 @@ func main() {
 	flag.StringVar(&metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.BoolVar(&enableTracing, "enable-tracing", false,
+		"If set, reconcile spans are exported via OTLP/gRPC. Configure the exporter with OTEL_EXPORTER_OTLP_* variables.")
+	flag.StringVar(&logLevel, "log-level", "",
+		"Log level (debug, info, error, or a logr verbosity such as 2). "+
+			"Can be changed at runtime via GET/PUT on /log-level of the metrics endpoint "+
+			"when it is enabled with --metrics-bind-address and served with --metrics-secure (authn/authz required).")
+	flag.IntVar(&workloadConcurrency, "workload-max-concurrent-reconciles", 1,
+		"The maximum number of Workloads reconciled in parallel. "+
+			"Workloads annotated with score.dev/priority=high are always dequeued first.")
+	flag.IntVar(&provisionerConcurrency, "provisioner-max-concurrent-reconciles", 1,
+		"The maximum number of ResourceClaims reconciled in parallel.")
+	flag.DurationVar(&orphanSweepInterval, "orphan-sweep-interval", controller.DefaultOrphanSweepInterval,
+		"How often WorkloadPlans and ResourceClaims left behind by force-deleted Workloads are garbage collected.")
+	flag.IntVar(&shard.Count, "shard-count", 1,
+		"The number of shards Workload reconciliation is split into, overriding sharding.count of the OrchestratorConfig. "+
+			"Each Workload is assigned to one shard by a hash of its namespace and name.")
+	flag.IntVar(&shard.Index, "shard-index", 0,
+		"The shard reconciled by this replica, in [0, shard-count), overriding the index derived from the pod name. "+
+			"Replicas with the same index elect one leader per shard when --leader-elect is set. "+
+			"Only shard 0 runs the Provisioner, ExposureMirror and orphan sweeper.")
 	opts := zap.Options{
 		Development: true,
 	}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
+	// Use an atomic level so that verbosity can be adjusted without restarting the manager
+	atomicLevel, err := logging.NewAtomicLevel(logLevel, opts)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "invalid --log-level: %v\n", err)
+		os.Exit(1)
+	}
+	opts.Level = atomicLevel
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
 	// if the enable-http2 flag is false (the default), http/2 should be disabled
@@ -140,6 +198,13 @@ func main() {
 		// can access the metrics endpoint. The RBAC are configured in 'config/rbac/kustomization.yaml'. More info:
 		// https://pkg.go.dev/sigs.k8s.io/controller-runtime@v0.21.0/pkg/metrics/filters#WithAuthenticationAndAuthorization
 		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
+
+		// The log level can be changed at runtime only behind the same authn/authz filter
+		metricsServerOptions.ExtraHandlers = map[string]http.Handler{
+			"/log-level": atomicLevel,
+		}
+	} else if metricsAddr != "0" {
+		setupLog.Info("runtime log level changes are disabled because the metrics endpoint is not secured")
 	}
 
 	// If the certificate is not specified, controller-runtime will automatically
@@ -159,13 +224,40 @@ func main() {
 		metricsServerOptions.KeyName = metricsCertKey
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	restConfig := ctrl.GetConfigOrDie()
+
+	// Create Kubernetes clientset for ConfigMapLoader
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		setupLog.Error(err, "unable to create kubernetes clientset")
+		os.Exit(1)
+	}
+
+	// Create ConfigMapLoader with environment-aware options
+	loaderOptions := config.DefaultLoaderOptions()
+	if envNamespace := os.Getenv("CONFIG_NAMESPACE"); envNamespace != "" {
+		loaderOptions.Namespace = envNamespace
+	}
+
+	// The shard decides the leader election lease, so it is resolved before the manager is created
+	shard, err = configuredShard(config.NewConfigMapLoader(clientset, loaderOptions), shard)
+	if err != nil {
+		setupLog.Error(err, "invalid sharding configuration")
+		os.Exit(1)
+	}
+	if shard.Enabled() {
+		setupLog.Info("Reconciling one shard of the Workloads", "shard", shard.Index, "shards", shard.Count)
+	}
+
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "95568818.dev",
+		LeaderElectionID:       shard.LeaderElectionID("95568818.dev"),
+		// Audit records are only appended and pruned; caching them would hold the whole trail in memory
+		Client: client.Options{Cache: &client.CacheOptions{DisableFor: []client.Object{&scorev1b1.WorkloadAudit{}}}},
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -186,108 +278,211 @@ func main() {
 	// Setup signal handler and context
 	ctx := ctrl.SetupSignalHandler()
 
+	// Setup tracing
+	if enableTracing {
+		shutdownTracing, err := tracing.Setup(ctx, "score-orchestrator")
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		// Flush pending spans when the manager stops
+		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return shutdownTracing(context.Background())
+		})); err != nil {
+			setupLog.Error(err, "unable to register tracing shutdown")
+			os.Exit(1)
+		}
+	}
+
 	// Setup indexers
 	if err := controller.SetupIndexers(ctx, mgr); err != nil {
 		setupLog.Error(err, "unable to set up indexers")
 		os.Exit(1)
 	}
 
-	// Create Kubernetes clientset for ConfigMapLoader
-	clientset, err := kubernetes.NewForConfig(mgr.GetConfig())
+	// All controllers read one shared, versioned snapshot of the configuration, which replaces the cache of
+	// the ConfigMapLoader so that they never see different versions of it
+	cacheTTL, _ := time.ParseDuration(loaderOptions.CacheTTL)
+	loaderOptions.EnableCache = false
+	configCache := config.NewSharedCache(config.NewConfigMapLoader(clientset, loaderOptions), cacheTTL)
+	if err := mgr.Add(configCache); err != nil {
+		setupLog.Error(err, "unable to register configuration cache")
+		os.Exit(1)
+	}
+	var configLoader config.ConfigLoader = configCache
+
+	// Inject faults for integration tests of degraded paths; never enabled in production deployments
+	faults, err := faultinject.FromEnv()
 	if err != nil {
-		setupLog.Error(err, "unable to create kubernetes clientset")
+		setupLog.Error(err, "unable to configure fault injection")
 		os.Exit(1)
 	}
+	if faults != nil {
+		setupLog.Info("Fault injection is enabled, do not use this manager in production", "faults", os.Getenv(faultinject.EnvVar))
+		configLoader = faults.ConfigLoader(configLoader)
+	}
 
-	// Create ConfigMapLoader with environment-aware options
-	loaderOptions := config.DefaultLoaderOptions()
-	if envNamespace := os.Getenv("CONFIG_NAMESPACE"); envNamespace != "" {
-		loaderOptions.Namespace = envNamespace
+	// Wrap event recorders with deduplication, rate limiting and correlation IDs
+	eventRecorderFor := func(name string) *events.Emitter {
+		return events.NewEmitter(mgr.GetEventRecorderFor(name), events.DefaultOptions())
+	}
+	// Attribute audited decisions to the component that made them
+	auditRecorderFor := func(name string) *audit.Recorder {
+		return audit.NewRecorder(mgr.GetClient(), configLoader, name)
 	}
-	configLoader := config.NewConfigMapLoader(clientset, loaderOptions)
 
 	// Create ClaimManager
 	claimManager := managers.NewClaimManager(
 		mgr.GetClient(),
 		mgr.GetScheme(),
-		mgr.GetEventRecorderFor("claim-manager"),
+		eventRecorderFor("claim-manager"),
 	)
 
 	// Create StatusManager
 	statusManager := managers.NewStatusManager(
 		mgr.GetClient(),
 		mgr.GetScheme(),
-		mgr.GetEventRecorderFor("status-manager"),
+		eventRecorderFor("status-manager"),
 		endpoint.NewEndpointDeriver(mgr.GetClient()),
 	)
+	statusManager.SetFaultInjector(faults)
+	// Summarize the Workloads of each namespace for dashboards
+	summaries := summary.NewAggregator(mgr.GetClient(), shard)
+	statusManager.SetSummaryAggregator(summaries)
+	if err := mgr.Add(summaries); err != nil {
+		setupLog.Error(err, "unable to register Workload summaries")
+		os.Exit(1)
+	}
 
 	// Create PlanManager
 	planManager := managers.NewPlanManager(
 		mgr.GetClient(),
 		mgr.GetScheme(),
-		mgr.GetEventRecorderFor("plan-manager"),
+		eventRecorderFor("plan-manager"),
 		configLoader,
 		endpoint.NewEndpointDeriver(mgr.GetClient()),
 		statusManager,
+		auditRecorderFor("plan-manager"),
+	)
+
+	// Create QuotaManager
+	quotaManager := managers.NewQuotaManager(
+		mgr.GetClient(),
+		eventRecorderFor("quota-manager"),
+		configLoader,
 	)
 
 	if err := (&controller.WorkloadReconciler{
 		Client:          mgr.GetClient(),
 		Scheme:          mgr.GetScheme(),
-		Recorder:        mgr.GetEventRecorderFor("workload-controller"),
+		Recorder:        eventRecorderFor("workload-controller"),
 		ConfigLoader:    configLoader,
 		EndpointDeriver: endpoint.NewEndpointDeriver(mgr.GetClient()),
 		ClaimManager:    claimManager,
 		PlanManager:     planManager,
 		StatusManager:   statusManager,
+		QuotaManager:    quotaManager,
+
+		MaxConcurrentReconciles: workloadConcurrency,
+		Shard:                   shard,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Workload")
 		os.Exit(1)
 	}
 
-	// Setup Provisioner Controller
-	setupLog.Info("Setting up Provisioner Controller")
-	provisioner := controller.NewProvisionerReconciler(
-		mgr.GetClient(),
-		mgr.GetScheme(),
-		mgr.GetEventRecorderFor("provisioner-controller"),
-		configLoader,
-	)
-	setupLog.Info("Created Provisioner Reconciler, calling SetupWithManager")
-	if err := provisioner.SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "Provisioner")
-		os.Exit(1)
-	}
-	setupLog.Info("Provisioner Controller setup completed successfully")
-
-	// Setup ExposureMirror Controller
-	setupLog.Info("Setting up ExposureMirror Controller")
-	exposureMirror := &controller.ExposureMirrorReconciler{
-		Client:   mgr.GetClient(),
-		Scheme:   mgr.GetScheme(),
-		Recorder: mgr.GetEventRecorderFor("exposure-mirror-controller"),
-	}
-	if err := exposureMirror.SetupWithManager(mgr); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "ExposureMirror")
-		os.Exit(1)
-	}
-	setupLog.Info("ExposureMirror Controller setup completed successfully")
-
 	// Setup WorkloadExposureRegistrar Controller
 	setupLog.Info("Setting up WorkloadExposureRegistrar Controller")
 	workloadExposureRegistrar := &controller.WorkloadExposureRegistrar{
 		Client:       mgr.GetClient(),
 		Scheme:       mgr.GetScheme(),
-		Recorder:     mgr.GetEventRecorderFor("workload-exposure-registrar"),
+		Recorder:     eventRecorderFor("workload-exposure-registrar"),
 		RuntimeClass: meta.RuntimeClassKubernetes,
+		Shard:        shard,
 	}
 	if err := workloadExposureRegistrar.SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "WorkloadExposureRegistrar")
 		os.Exit(1)
 	}
 	setupLog.Info("WorkloadExposureRegistrar Controller setup completed successfully")
+
+	// Setup Notification Controller
+	if err := (&controller.NotificationReconciler{
+		Client:   mgr.GetClient(),
+		Notifier: &notification.Notifier{Client: mgr.GetClient(), ConfigLoader: configLoader},
+		Shard:    shard,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Notification")
+		os.Exit(1)
+	}
+
+	// ResourceClaims, WorkloadExposures and orphans are not sharded and are handled by shard 0 only
+	if shard.Index == 0 {
+		// Setup Provisioner Controller
+		setupLog.Info("Setting up Provisioner Controller")
+		provisioner := controller.NewProvisionerReconciler(
+			mgr.GetClient(),
+			mgr.GetScheme(),
+			eventRecorderFor("provisioner-controller"),
+			configLoader,
+		)
+		provisioner.MaxConcurrentReconciles = provisionerConcurrency
+		provisioner.Auditor = auditRecorderFor("provisioner-controller")
+		provisioner.FaultInjector = faults
+		setupLog.Info("Created Provisioner Reconciler, calling SetupWithManager")
+		if err := provisioner.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "Provisioner")
+			os.Exit(1)
+		}
+		setupLog.Info("Provisioner Controller setup completed successfully")
+
+		// Setup ExposureMirror Controller
+		setupLog.Info("Setting up ExposureMirror Controller")
+		exposureMirror := &controller.ExposureMirrorReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Recorder: eventRecorderFor("exposure-mirror-controller"),
+			Auditor:  auditRecorderFor("exposure-mirror-controller"),
+		}
+		if err := exposureMirror.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ExposureMirror")
+			os.Exit(1)
+		}
+		setupLog.Info("ExposureMirror Controller setup completed successfully")
+
+		// Setup PlanDelivery Controller
+		if err := (&controller.PlanDeliveryReconciler{
+			Client:       mgr.GetClient(),
+			Scheme:       mgr.GetScheme(),
+			Recorder:     eventRecorderFor("plan-delivery-controller"),
+			ConfigLoader: configLoader,
+			Deliverer:    &delivery.Deliverer{Client: mgr.GetClient(), Scheme: mgr.GetScheme()},
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "PlanDelivery")
+			os.Exit(1)
+		}
+
+		// Setup orphan sweeper
+		if err := mgr.Add(&controller.OrphanSweeper{
+			Client:   mgr.GetClient(),
+			Recorder: eventRecorderFor("orphan-sweeper"),
+			Interval: orphanSweepInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to register orphan sweeper")
+			os.Exit(1)
+		}
+
+		// Setup audit record pruning
+		if err := mgr.Add(&audit.Pruner{Client: mgr.GetClient(), ConfigLoader: configLoader}); err != nil {
+			setupLog.Error(err, "unable to register audit pruner")
+			os.Exit(1)
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	// Report the state of the control plane itself on the metrics endpoint
+	metrics.Registry.MustRegister(&health.Collector{Client: mgr.GetClient(), Shard: shard})
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -303,3 +498,35 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// configuredShard returns the shard of this replica. The --shard-count and --shard-index flags that were set
+// override the sharding of the OrchestratorConfig, under which the index is derived from the ordinal of the
+// pod name (POD_NAME, or the hostname). Without a configuration the flags apply.
+func configuredShard(loader config.ConfigLoader, flags sharding.Shard) (sharding.Shard, error) {
+	set := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { set[f.Name] = true })
+
+	shard := flags
+	if !set["shard-count"] || !set["shard-index"] {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		orchestratorConfig, err := loader.LoadConfig(ctx)
+		if err != nil {
+			setupLog.Info("Sharding falls back to the flags, the configuration could not be loaded", "error", err.Error())
+		} else if spec := orchestratorConfig.Spec.Sharding; spec != nil && !set["shard-count"] {
+			shard.Count = int(spec.Count)
+		}
+	}
+	if !set["shard-index"] {
+		podName := os.Getenv("POD_NAME")
+		if podName == "" {
+			podName, _ = os.Hostname()
+		}
+		index, err := sharding.IndexForPod(podName, shard.Count)
+		if err != nil {
+			return sharding.Shard{}, fmt.Errorf("%w; set --shard-index", err)
+		}
+		shard.Index = index
+	}
+	return shard, shard.Validate()
+}
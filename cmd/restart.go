@@ -0,0 +1,107 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+// restartCommand is the subcommand that requests a rolling restart of a Workload
+const restartCommand = "restart"
+
+// runRestart sets the score.dev/restart annotation of a Workload to the current time, so that its runtime
+// rolls out new instances even though the spec did not change. It returns the process exit code.
+func runRestart(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet(restartCommand, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var namespace, kubeconfig string
+	fs.StringVar(&namespace, "namespace", "default", "Namespace of the Workload.")
+	fs.StringVar(&kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file. Defaults to $KUBECONFIG, the in-cluster config or ~/.kube/config.")
+	fs.Usage = func() {
+		_, _ = fmt.Fprintf(stderr, "Usage: %s %s [--namespace <namespace>] <workload>\n", os.Args[0], restartCommand)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	restConfig, err := restartConfig(kubeconfig)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "%s: failed to load kubeconfig: %v\n", restartCommand, err)
+		return 1
+	}
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "%s: failed to create client: %v\n", restartCommand, err)
+		return 1
+	}
+
+	key := types.NamespacedName{Namespace: namespace, Name: fs.Arg(0)}
+	restartedAt, err := restartWorkload(context.Background(), c, key, time.Now())
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "%s: %v\n", restartCommand, err)
+		return 1
+	}
+	_, _ = fmt.Fprintf(stdout, "workload %s restart requested at %s\n", key, restartedAt)
+	return 0
+}
+
+// restartConfig loads the given kubeconfig, or the default client configuration when none is given
+func restartConfig(kubeconfig string) (*rest.Config, error) {
+	if kubeconfig == "" {
+		return ctrl.GetConfig()
+	}
+	return clientcmd.BuildConfigFromFlags("", kubeconfig)
+}
+
+// restartWorkload annotates the Workload with the restart time and returns the annotation value
+func restartWorkload(ctx context.Context, c client.Client, key types.NamespacedName, now time.Time) (string, error) {
+	workload := &scorev1b1.Workload{}
+	if err := c.Get(ctx, key, workload); err != nil {
+		return "", fmt.Errorf("failed to get workload: %w", err)
+	}
+
+	patch := client.MergeFrom(workload.DeepCopy())
+	if workload.Annotations == nil {
+		workload.Annotations = map[string]string{}
+	}
+	restartedAt := now.UTC().Format(time.RFC3339)
+	workload.Annotations[meta.AnnotationRestart] = restartedAt
+	if err := c.Patch(ctx, workload, patch); err != nil {
+		return "", fmt.Errorf("failed to annotate workload: %w", err)
+	}
+	return restartedAt, nil
+}
@@ -0,0 +1,175 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records significant orchestration decisions, such as the backend selected for a Workload or
+// the update of its WorkloadPlan, as append-only WorkloadAudit resources and as structured log lines, and
+// prunes the records according to the retention configured in the OrchestratorConfig.
+package audit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/config"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+// Retention applied when the OrchestratorConfig enables the audit trail without limits
+const (
+	DefaultMaxRecordsPerWorkload = 100
+	DefaultMaxAge                = 30 * 24 * time.Hour
+)
+
+// +kubebuilder:rbac:groups=score.dev,resources=workloadaudits,verbs=get;list;create;delete
+
+// Recorder records the decisions of one Orchestrator component. A nil Recorder records nothing.
+type Recorder struct {
+	client       client.Client
+	configLoader config.ConfigLoader
+	controller   string
+	now          func() time.Time
+}
+
+// NewRecorder creates a Recorder that attributes the decisions it records to the named controller
+func NewRecorder(c client.Client, configLoader config.ConfigLoader, controller string) *Recorder {
+	return &Recorder{client: c, configLoader: configLoader, controller: controller, now: time.Now}
+}
+
+// WorkloadRef returns the reference of audit records about decisions made for the current spec of the workload
+func WorkloadRef(workload *scorev1b1.Workload) scorev1b1.WorkloadAuditWorkloadRef {
+	return scorev1b1.WorkloadAuditWorkloadRef{Name: workload.Name, UID: string(workload.UID), Generation: workload.Generation}
+}
+
+// Record records a decision about the referenced Workload in the given namespace, unless the audit trail is
+// disabled. Records are named after the decision they record, so recording the same decision again, e.g. when
+// a reconcile is retried, keeps the original record. Recording a new decision prunes the oldest records of
+// the Workload beyond the retention.
+func (r *Recorder) Record(ctx context.Context, namespace string, ref scorev1b1.WorkloadAuditWorkloadRef, action, message string, details map[string]string) error {
+	if r == nil {
+		return nil
+	}
+	orchestratorConfig, err := r.configLoader.LoadConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load orchestrator config: %w", err)
+	}
+	if orchestratorConfig.Spec.Audit == nil {
+		return nil
+	}
+
+	record := &scorev1b1.WorkloadAudit{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      recordName(ref, action, details),
+			Namespace: namespace,
+			Labels:    map[string]string{meta.LabelWorkload: ref.Name},
+		},
+		Spec: scorev1b1.WorkloadAuditSpec{
+			WorkloadRef: ref,
+			Action:      action,
+			Controller:  r.controller,
+			Time:        metav1.NewTime(r.now()),
+			Message:     message,
+			Details:     details,
+		},
+	}
+	if err := r.client.Create(ctx, record); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		RecordsTotal.WithLabelValues(action, "error").Inc()
+		return fmt.Errorf("failed to record %s decision: %w", action, err)
+	}
+	RecordsTotal.WithLabelValues(action, "success").Inc()
+
+	// The audit log carries the same decision for log-based compliance pipelines
+	log.FromContext(ctx).WithName("audit").Info("Recorded orchestration decision",
+		"record", record.Name, "namespace", namespace, "workload", ref.Name, "workloadUID", ref.UID,
+		"workloadGeneration", ref.Generation, "action", action, "controller", r.controller,
+		"message", message, "details", details)
+
+	return r.prune(ctx, namespace, ref.Name, orchestratorConfig.Spec.Audit)
+}
+
+// prune deletes the records of the workload beyond the retention
+func (r *Recorder) prune(ctx context.Context, namespace, workload string, retention *scorev1b1.AuditSpec) error {
+	var records scorev1b1.WorkloadAuditList
+	if err := r.client.List(ctx, &records, client.InNamespace(namespace),
+		client.MatchingLabels{meta.LabelWorkload: workload}); err != nil {
+		return fmt.Errorf("failed to list audit records: %w", err)
+	}
+
+	// Newest first
+	sort.Slice(records.Items, func(i, j int) bool {
+		return records.Items[j].Spec.Time.Before(&records.Items[i].Spec.Time)
+	})
+	maxRecords, maxAge := Retention(retention)
+	cutoff := r.now().Add(-maxAge)
+
+	var errs []error
+	for i := range records.Items {
+		record := &records.Items[i]
+		if i < maxRecords && record.Spec.Time.After(cutoff) {
+			continue
+		}
+		if err := r.client.Delete(ctx, record); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to prune audit record %s: %w", record.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Retention returns the number of records kept per Workload and how long records are kept
+func Retention(spec *scorev1b1.AuditSpec) (int, time.Duration) {
+	maxRecords, maxAge := DefaultMaxRecordsPerWorkload, DefaultMaxAge
+	if spec == nil {
+		return maxRecords, maxAge
+	}
+	if spec.MaxRecordsPerWorkload != nil {
+		maxRecords = int(*spec.MaxRecordsPerWorkload)
+	}
+	if spec.MaxAge != nil {
+		maxAge = spec.MaxAge.Duration
+	}
+	return maxRecords, maxAge
+}
+
+// recordName derives the name of the record of a decision from the Workload, the action and its details
+func recordName(ref scorev1b1.WorkloadAuditWorkloadRef, action string, details map[string]string) string {
+	keys := make([]string, 0, len(details))
+	for key := range details {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	parts := []string{ref.UID, strconv.FormatInt(ref.Generation, 10), action}
+	for _, key := range keys {
+		parts = append(parts, key+"="+details[key])
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\n")))
+	return fmt.Sprintf("%s-%s-%s", ref.Name, strings.ToLower(action), hex.EncodeToString(sum[:])[:10])
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/config"
+)
+
+func newTestRecorder(t *testing.T, auditSpec *scorev1b1.AuditSpec, objects ...client.Object) (*Recorder, client.Client, *time.Time) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	loader := config.NewMockLoader()
+	loader.SetConfig(&scorev1b1.OrchestratorConfig{Spec: scorev1b1.OrchestratorConfigSpec{Audit: auditSpec}})
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	recorder := NewRecorder(c, loader, "plan-manager")
+	recorder.now = func() time.Time { return now }
+	return recorder, c, &now
+}
+
+func listRecords(t *testing.T, c client.Client) []scorev1b1.WorkloadAudit {
+	t.Helper()
+	var records scorev1b1.WorkloadAuditList
+	if err := c.List(context.Background(), &records); err != nil {
+		t.Fatalf("failed to list audit records: %v", err)
+	}
+	return records.Items
+}
+
+func TestRecord(t *testing.T) {
+	recorder, c, _ := newTestRecorder(t, &scorev1b1.AuditSpec{})
+	ref := scorev1b1.WorkloadAuditWorkloadRef{Name: "app", UID: "uid-1", Generation: 2}
+	details := map[string]string{"backendId": "k8s-standard", "runtimeClass": "kubernetes"}
+
+	for range 2 {
+		if err := recorder.Record(context.Background(), "team-a", ref, scorev1b1.AuditActionBackendSelected, "Selected backend", details); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	records := listRecords(t, c)
+	if len(records) != 1 {
+		t.Fatalf("recording the same decision twice kept %d records, want 1", len(records))
+	}
+	record := records[0]
+	if record.Spec.Action != scorev1b1.AuditActionBackendSelected || record.Spec.Controller != "plan-manager" {
+		t.Errorf("record = %+v, want a BackendSelected decision of plan-manager", record.Spec)
+	}
+	if record.Labels["score.dev/workload"] != "app" {
+		t.Errorf("record labels = %v, want the workload label", record.Labels)
+	}
+	if record.Spec.Details["backendId"] != "k8s-standard" {
+		t.Errorf("record details = %v, want the selected backend", record.Spec.Details)
+	}
+}
+
+func TestRecordDisabled(t *testing.T) {
+	recorder, c, _ := newTestRecorder(t, nil)
+	ref := scorev1b1.WorkloadAuditWorkloadRef{Name: "app"}
+	if err := recorder.Record(context.Background(), "team-a", ref, scorev1b1.AuditActionPlanCreated, "", nil); err != nil {
+		t.Fatalf("Record() error = %v", err)
+	}
+	if records := listRecords(t, c); len(records) != 0 {
+		t.Errorf("disabled audit trail recorded %d records", len(records))
+	}
+
+	var nilRecorder *Recorder
+	if err := nilRecorder.Record(context.Background(), "team-a", ref, scorev1b1.AuditActionPlanCreated, "", nil); err != nil {
+		t.Errorf("nil Recorder returned error %v", err)
+	}
+}
+
+func TestRecordPrunes(t *testing.T) {
+	recorder, c, now := newTestRecorder(t, &scorev1b1.AuditSpec{
+		MaxRecordsPerWorkload: ptr.To[int32](2),
+		MaxAge:                &metav1.Duration{Duration: time.Hour},
+	})
+	ref := scorev1b1.WorkloadAuditWorkloadRef{Name: "app", UID: "uid-1"}
+	start := *now
+
+	// The first record expires, the second is pushed out by the fourth
+	for i, offset := range []time.Duration{-2 * time.Hour, -30 * time.Minute, -20 * time.Minute, 0} {
+		*now = start.Add(offset)
+		ref.Generation = int64(i + 1)
+		if err := recorder.Record(context.Background(), "team-a", ref, scorev1b1.AuditActionPlanUpdated, "", nil); err != nil {
+			t.Fatalf("Record() error = %v", err)
+		}
+	}
+
+	records := listRecords(t, c)
+	if len(records) != 2 {
+		t.Fatalf("kept %d records, want 2", len(records))
+	}
+	for _, record := range records {
+		if record.Spec.WorkloadRef.Generation < 3 {
+			t.Errorf("record of generation %d was not pruned", record.Spec.WorkloadRef.Generation)
+		}
+	}
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	record := func(name string, age time.Duration) *scorev1b1.WorkloadAudit {
+		return &scorev1b1.WorkloadAudit{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: name},
+			Spec:       scorev1b1.WorkloadAuditSpec{WorkloadRef: scorev1b1.WorkloadAuditWorkloadRef{Name: "deleted"}, Time: metav1.NewTime(now.Add(-age))},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		auditSpec *scorev1b1.AuditSpec
+		wantKept  int
+	}{
+		{name: "default retention", auditSpec: &scorev1b1.AuditSpec{}, wantKept: 1},
+		{name: "custom retention", auditSpec: &scorev1b1.AuditSpec{MaxAge: &metav1.Duration{Duration: time.Hour}}, wantKept: 0},
+		{name: "disabled", wantKept: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder, c, _ := newTestRecorder(t, tt.auditSpec, record("recent", 2*time.Hour), record("expired", 31*24*time.Hour))
+			pruner := &Pruner{Client: c, ConfigLoader: recorder.configLoader}
+			if err := pruner.Prune(context.Background(), now); err != nil {
+				t.Fatalf("Prune() error = %v", err)
+			}
+			if records := listRecords(t, c); len(records) != tt.wantKept {
+				t.Errorf("kept %d records, want %d", len(records), tt.wantKept)
+			}
+		})
+	}
+}
+
+func TestRecordName(t *testing.T) {
+	ref := scorev1b1.WorkloadAuditWorkloadRef{Name: "app", UID: "uid-1", Generation: 1}
+	details := map[string]string{"backendId": "a", "runtimeClass": "kubernetes"}
+
+	name := recordName(ref, scorev1b1.AuditActionBackendSelected, details)
+	if got := recordName(ref, scorev1b1.AuditActionBackendSelected, map[string]string{"runtimeClass": "kubernetes", "backendId": "a"}); got != name {
+		t.Errorf("recordName() = %q for the same decision, want %q", got, name)
+	}
+	if got := recordName(ref, scorev1b1.AuditActionBackendSelected, map[string]string{"backendId": "b"}); got == name {
+		t.Error("recordName() returned the same name for a different decision")
+	}
+	if len(name) != len("app-backendselected-")+10 {
+		t.Errorf("recordName() = %q, want the workload, the action and a short hash", name)
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// RecordsTotal counts audit records written, by outcome, so that gaps in the audit trail can be alerted on
+var RecordsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "score_orchestrator_audit_records_total",
+		Help: "Number of orchestration decisions recorded in the audit trail",
+	},
+	[]string{"action", "result"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(RecordsTotal)
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/config"
+)
+
+// DefaultPruneInterval is how often expired audit records are pruned
+const DefaultPruneInterval = time.Hour
+
+// Pruner periodically deletes audit records older than the configured maximum age. Records of live Workloads
+// are also pruned whenever a decision is recorded; the Pruner covers the records of deleted Workloads.
+type Pruner struct {
+	Client       client.Client
+	ConfigLoader config.ConfigLoader
+
+	// Interval between prunes. Defaults to DefaultPruneInterval.
+	Interval time.Duration
+}
+
+// Start prunes immediately and then once per interval until ctx is cancelled
+func (p *Pruner) Start(ctx context.Context) error {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = DefaultPruneInterval
+	}
+
+	logger := log.FromContext(ctx).WithName("audit-pruner")
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := p.Prune(ctx, time.Now()); err != nil {
+			logger.Error(err, "Failed to prune audit records")
+		}
+	}, interval)
+	return nil
+}
+
+// NeedLeaderElection ensures only the leader prunes records
+func (p *Pruner) NeedLeaderElection() bool {
+	return true
+}
+
+// Prune deletes the audit records older than the maximum age at the given time. Records are kept while the
+// audit trail is disabled, so that disabling it does not erase the trail.
+func (p *Pruner) Prune(ctx context.Context, now time.Time) error {
+	orchestratorConfig, err := p.ConfigLoader.LoadConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load orchestrator config: %w", err)
+	}
+	if orchestratorConfig.Spec.Audit == nil {
+		return nil
+	}
+	_, maxAge := Retention(orchestratorConfig.Spec.Audit)
+	cutoff := now.Add(-maxAge)
+
+	var records scorev1b1.WorkloadAuditList
+	if err := p.Client.List(ctx, &records); err != nil {
+		return fmt.Errorf("failed to list audit records: %w", err)
+	}
+
+	var errs []error
+	for i := range records.Items {
+		record := &records.Items[i]
+		if record.Spec.Time.After(cutoff) {
+			continue
+		}
+		if err := p.Client.Delete(ctx, record); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to prune audit record %s/%s: %w", record.Namespace, record.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package backoff computes the delays after which reconcilers look at an object again.
+// Delays grow exponentially with the consecutive requeues of an object and are jittered, so that objects
+// requeued together, e.g. after a configuration error, are not all reconciled again at the same instant.
+// Errors are left to the rate limiter of the controller, which already backs off exponentially per object
+// and ignores any delay returned together with an error.
+package backoff
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// Class is the reason an object is requeued; each class has its own Policy
+type Class string
+
+const (
+	// ClassWaiting requeues an object waiting for another object or an external resource,
+	// e.g. a claim being provisioned or a Workload blocked by its dependencies
+	ClassWaiting Class = "Waiting"
+	// ClassResync requeues an object that is ready, to check it periodically
+	ClassResync Class = "Resync"
+	// ClassError counts the retries of failed reconciles, which are delayed by the rate limiter of the controller
+	ClassError Class = "Error"
+)
+
+// Policy describes the requeue delays of a class
+type Policy struct {
+	// Base is the delay of the first requeue
+	Base time.Duration
+	// Max caps the delay
+	Max time.Duration
+	// Multiplier grows the delay with every consecutive requeue; 1 keeps it constant
+	Multiplier float64
+	// Jitter is the largest fraction of the delay added at random
+	Jitter float64
+}
+
+// DefaultPolicies are the policies of the classes a Backoff is not given a policy for
+var DefaultPolicies = map[Class]Policy{
+	ClassWaiting: {Base: 10 * time.Second, Max: 5 * time.Minute, Multiplier: 2, Jitter: 0.2},
+	ClassResync:  {Base: 10 * time.Minute, Max: 10 * time.Minute, Multiplier: 1, Jitter: 0.2},
+}
+
+// Delay returns the delay of the requeue following the given number of consecutive requeues, without jitter
+func (p Policy) Delay(requeues int) time.Duration {
+	delay := float64(p.Base)
+	if p.Multiplier > 1 {
+		delay *= math.Pow(p.Multiplier, float64(requeues))
+	}
+	if p.Max > 0 && delay > float64(p.Max) {
+		return p.Max
+	}
+	return time.Duration(delay)
+}
+
+type state struct {
+	class    Class
+	requeues int
+}
+
+// Backoff tracks the consecutive requeues of the objects of one controller.
+// A nil Backoff uses the default policies and does not track requeues.
+type Backoff struct {
+	controller string
+	policies   map[Class]Policy
+
+	mu     sync.Mutex
+	states map[types.NamespacedName]state
+}
+
+// New returns a Backoff for the named controller; the given policies override the default ones
+func New(controller string, policies map[Class]Policy) *Backoff {
+	merged := make(map[Class]Policy, len(DefaultPolicies)+len(policies))
+	for class, policy := range DefaultPolicies {
+		merged[class] = policy
+	}
+	for class, policy := range policies {
+		merged[class] = policy
+	}
+	return &Backoff{
+		controller: controller,
+		policies:   merged,
+		states:     make(map[types.NamespacedName]state),
+	}
+}
+
+// Requeue returns the jittered delay after which the object is reconciled again for the class, and counts
+// the requeue. Consecutive requeues for the same class grow the delay; a requeue for another class starts over.
+func (b *Backoff) Requeue(key types.NamespacedName, class Class) time.Duration {
+	if b == nil {
+		policy := DefaultPolicies[class]
+		RequeuesTotal.WithLabelValues("", string(class)).Inc()
+		return wait.Jitter(policy.Delay(0), policy.Jitter)
+	}
+
+	b.mu.Lock()
+	current := b.states[key]
+	if current.class != class {
+		current = state{class: class}
+	}
+	policy := b.policies[class]
+	delay := policy.Delay(current.requeues)
+	current.requeues++
+	b.states[key] = current
+	b.mu.Unlock()
+
+	RequeuesTotal.WithLabelValues(b.controller, string(class)).Inc()
+	return wait.Jitter(delay, policy.Jitter)
+}
+
+// Result returns a result requeuing the object after the delay of the class
+func (b *Backoff) Result(key types.NamespacedName, class Class) ctrl.Result {
+	return ctrl.Result{RequeueAfter: b.Requeue(key, class)}
+}
+
+// Error counts the retry of a failed reconcile and returns the error, so that the rate limiter of the
+// controller delays the retry
+func (b *Backoff) Error(err error) (ctrl.Result, error) {
+	controller := ""
+	if b != nil {
+		controller = b.controller
+	}
+	RequeuesTotal.WithLabelValues(controller, string(ClassError)).Inc()
+	return ctrl.Result{}, err
+}
+
+// Forget resets the consecutive requeues of the object, once it progressed or was deleted
+func (b *Backoff) Forget(key types.NamespacedName) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	delete(b.states, key)
+	b.mu.Unlock()
+}
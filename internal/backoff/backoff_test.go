@@ -0,0 +1,96 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package backoff
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestPolicyDelay(t *testing.T) {
+	policy := Policy{Base: 10 * time.Second, Max: time.Minute, Multiplier: 2}
+
+	for requeues, want := range []time.Duration{10 * time.Second, 20 * time.Second, 40 * time.Second, time.Minute, time.Minute} {
+		if got := policy.Delay(requeues); got != want {
+			t.Errorf("Delay(%d) = %s, want %s", requeues, got, want)
+		}
+	}
+	if got := (Policy{Base: time.Minute, Multiplier: 1}).Delay(5); got != time.Minute {
+		t.Errorf("Delay() with a constant policy = %s, want 1m", got)
+	}
+}
+
+func TestBackoffRequeue(t *testing.T) {
+	b := New("test", map[Class]Policy{
+		ClassWaiting: {Base: 10 * time.Second, Max: time.Minute, Multiplier: 2, Jitter: 0.5},
+	})
+	key := types.NamespacedName{Namespace: "default", Name: "web"}
+	other := types.NamespacedName{Namespace: "default", Name: "api"}
+
+	within := func(got, base time.Duration) bool {
+		return got >= base && got <= base+base/2
+	}
+
+	if got := b.Requeue(key, ClassWaiting); !within(got, 10*time.Second) {
+		t.Errorf("first requeue = %s, want 10s plus jitter", got)
+	}
+	if got := b.Requeue(key, ClassWaiting); !within(got, 20*time.Second) {
+		t.Errorf("second requeue = %s, want 20s plus jitter", got)
+	}
+	if got := b.Requeue(other, ClassWaiting); !within(got, 10*time.Second) {
+		t.Errorf("requeue of another object = %s, want 10s plus jitter", got)
+	}
+	if got := b.Requeue(key, ClassResync); !within(got, 10*time.Minute) {
+		t.Errorf("requeue for another class = %s, want the default resync delay plus jitter", got)
+	}
+
+	b.Requeue(key, ClassWaiting)
+	b.Forget(key)
+	if got := b.Requeue(key, ClassWaiting); !within(got, 10*time.Second) {
+		t.Errorf("requeue after Forget = %s, want 10s plus jitter", got)
+	}
+}
+
+func TestNilBackoff(t *testing.T) {
+	var b *Backoff
+	key := types.NamespacedName{Namespace: "default", Name: "web"}
+
+	for range 3 {
+		if got := b.Requeue(key, ClassWaiting); got < 10*time.Second || got > 12*time.Second {
+			t.Errorf("Requeue() = %s, want the default base delay plus jitter", got)
+		}
+	}
+	b.Forget(key)
+}
+
+func TestBackoffErrorCountsRetries(t *testing.T) {
+	b := New("errors", nil)
+	before := testutil.ToFloat64(RequeuesTotal.WithLabelValues("errors", string(ClassError)))
+
+	reconcileErr := errors.New("boom")
+	result, err := b.Error(reconcileErr)
+	if !errors.Is(err, reconcileErr) || result.RequeueAfter != 0 {
+		t.Errorf("Error() = %+v, %v, want the error without a delay", result, err)
+	}
+	if got := testutil.ToFloat64(RequeuesTotal.WithLabelValues("errors", string(ClassError))); got != before+1 {
+		t.Errorf("requeues = %v, want %v", got, before+1)
+	}
+}
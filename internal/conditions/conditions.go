@@ -28,6 +28,14 @@ const (
 	ConditionClaimsReady  = "ClaimsReady"
 	ConditionRuntimeReady = "RuntimeReady"
 	ConditionInputsValid  = "InputsValid"
+	// ConditionBlocked is True while Workloads in dependsOn hold back the plan; it is not part of Ready
+	ConditionBlocked = "Blocked"
+	// ConditionDegraded is True while a Workload that was ready is not, because a claim or the runtime regressed;
+	// it is not part of Ready
+	ConditionDegraded = "Degraded"
+	// ConditionOptionalClaimsUnavailable is True while claims of optional resources are not bound or failed;
+	// it is not part of Ready
+	ConditionOptionalClaimsUnavailable = "OptionalClaimsUnavailable"
 )
 
 // Reasons (abstract vocabulary - platform-agnostic)
@@ -35,6 +43,9 @@ const (
 	ReasonSucceeded           = "Succeeded"
 	ReasonSpecInvalid         = "SpecInvalid"
 	ReasonPolicyViolation     = "PolicyViolation"
+	ReasonProfileNotFound     = "ProfileNotFound"
+	ReasonBackendUnavailable  = "BackendUnavailable"
+	ReasonBackendFiltered     = "BackendFiltered"
 	ReasonClaimPending        = "ClaimPending"
 	ReasonClaiming            = "Claiming"
 	ReasonClaimFailed         = "ClaimFailed"
@@ -42,9 +53,27 @@ const (
 	ReasonRuntimeSelecting    = "RuntimeSelecting"
 	ReasonRuntimeProvisioning = "RuntimeProvisioning"
 	ReasonRuntimeDegraded     = "RuntimeDegraded"
+	ReasonRuntimeUnavailable  = "RuntimeUnavailable"
+	ReasonRuntimeConflict     = "RuntimeConflict"
+	ReasonRuntimeUnresponsive = "RuntimeUnresponsive"
 	ReasonQuotaExceeded       = "QuotaExceeded"
 	ReasonPermissionDenied    = "PermissionDenied"
 	ReasonNetworkUnavailable  = "NetworkUnavailable"
+	ReasonDryRun              = "DryRun"
+	ReasonBlocked             = "Blocked"
+	ReasonSupplyChainError    = "SupplyChainError"
+)
+
+// ResourceClaim reasons reported by the provisioner in addition to the vocabulary above
+const (
+	// ReasonRetryLimitExceeded marks a claim that stays Failed after its provisioning retries are exhausted
+	ReasonRetryLimitExceeded = "RetryLimitExceeded"
+	// ReasonTimeout marks a claim whose provisioning did not complete within the provisioning timeout
+	ReasonTimeout = "Timeout"
+	// ReasonNameConflict marks a claim whose resources would take the name of objects it does not control
+	ReasonNameConflict = "NameConflict"
+	// ReasonThrottled marks a claim kept Pending by the concurrency limit of its type
+	ReasonThrottled = "Throttled"
 )
 
 // Standard condition messages (platform-agnostic)
@@ -60,10 +89,61 @@ const (
 	MessageClaimsFailed              = "One or more resource claims have failed"
 	MessageNoClaimsFound             = "No resource claims found"
 	MessageProjectionError           = "One or more required outputs are not resolved."
+	MessageValuesSchemaViolation     = "Template values do not satisfy the values schema of the backend."
+	MessageProfileNotFound           = "The requested profile is not available"
+	MessageBackendUnavailable        = "No runtime backend satisfies the workload requirements"
+	MessageBackendFiltered           = "No runtime backend of the profile is allowed in the namespace of the workload"
+	MessageRuntimeSelecting          = "Runtime is being selected"
+	MessageRuntimeDegraded           = "Runtime is degraded"
+	MessageRuntimeUnavailable        = "No live runtime is registered for the selected backend"
+	MessageRuntimeConflict           = "Existing objects not created for the workload block its runtime resources"
+	MessageRuntimeUnresponsive       = "The runtime consuming the plan of the workload stopped sending heartbeats"
+	MessageQuotaExceeded             = "Resource quota has been exceeded"
+	MessagePolicyViolation           = "The workload violates a platform policy"
+	MessagePermissionDenied          = "Permission denied while reconciling the workload"
+	MessageNetworkUnavailable        = "A required network dependency is unavailable"
+	MessageDryRun                    = "Dry-run preview is available in status; no resources are created"
+	MessageBlocked                   = "Waiting for dependent workloads to become ready"
+	MessageDependenciesReady         = "All dependent workloads are ready"
+	MessageSupplyChainError          = "The template of the selected backend or an image of the Workload failed supply-chain verification"
+	MessageOptionalClaimsReady       = "All optional resource claims are ready"
 )
 
-// SetCondition updates a condition in the conditions slice
-func SetCondition(conditions *[]metav1.Condition, conditionType string, status metav1.ConditionStatus, reason string, message string) {
+// reasonMessages maps each canonical reason to its neutral default message
+var reasonMessages = map[string]string{
+	ReasonSucceeded:           MessageWorkloadReady,
+	ReasonSpecInvalid:         MessageSpecValidationFailed,
+	ReasonProfileNotFound:     MessageProfileNotFound,
+	ReasonBackendUnavailable:  MessageBackendUnavailable,
+	ReasonBackendFiltered:     MessageBackendFiltered,
+	ReasonClaimPending:        MessageClaimsProvisioning,
+	ReasonClaimFailed:         MessageClaimsFailed,
+	ReasonProjectionError:     MessageProjectionError,
+	ReasonRuntimeSelecting:    MessageRuntimeSelecting,
+	ReasonRuntimeProvisioning: MessageRuntimeProvisioning,
+	ReasonRuntimeDegraded:     MessageRuntimeDegraded,
+	ReasonRuntimeUnavailable:  MessageRuntimeUnavailable,
+	ReasonRuntimeConflict:     MessageRuntimeConflict,
+	ReasonRuntimeUnresponsive: MessageRuntimeUnresponsive,
+	ReasonQuotaExceeded:       MessageQuotaExceeded,
+	ReasonPolicyViolation:     MessagePolicyViolation,
+	ReasonPermissionDenied:    MessagePermissionDenied,
+	ReasonNetworkUnavailable:  MessageNetworkUnavailable,
+	ReasonDryRun:              MessageDryRun,
+	ReasonBlocked:             MessageBlocked,
+	ReasonSupplyChainError:    MessageSupplyChainError,
+}
+
+// MessageForReason returns the neutral default message for a canonical reason
+func MessageForReason(reason string) string {
+	if message, ok := reasonMessages[reason]; ok {
+		return message
+	}
+	return ""
+}
+
+// SetCondition updates a condition in the conditions slice, recording the object generation it was computed from
+func SetCondition(conditions *[]metav1.Condition, conditionType string, status metav1.ConditionStatus, reason string, message string, observedGeneration int64) {
 	now := metav1.NewTime(time.Now())
 
 	for i, condition := range *conditions {
@@ -78,6 +158,8 @@ func SetCondition(conditions *[]metav1.Condition, conditionType string, status m
 				// Update message only without changing transition time
 				(*conditions)[i].Message = message
 			}
+			// A condition confirmed for a newer generation is current again, even when unchanged
+			(*conditions)[i].ObservedGeneration = observedGeneration
 			return
 		}
 	}
@@ -86,6 +168,7 @@ func SetCondition(conditions *[]metav1.Condition, conditionType string, status m
 	*conditions = append(*conditions, metav1.Condition{
 		Type:               conditionType,
 		Status:             status,
+		ObservedGeneration: observedGeneration,
 		LastTransitionTime: now,
 		Reason:             reason,
 		Message:            message,
@@ -132,10 +215,10 @@ type ConditionUpdate struct {
 	Message string
 }
 
-// ApplyConditionUpdates applies multiple condition updates to a conditions slice
-func ApplyConditionUpdates(conditions *[]metav1.Condition, updates []ConditionUpdate) {
+// ApplyConditionUpdates applies multiple condition updates computed from the given generation to a conditions slice
+func ApplyConditionUpdates(conditions *[]metav1.Condition, updates []ConditionUpdate, observedGeneration int64) {
 	for _, update := range updates {
-		SetCondition(conditions, update.Type, update.Status, update.Reason, update.Message)
+		SetCondition(conditions, update.Type, update.Status, update.Reason, update.Message, observedGeneration)
 	}
 }
 
@@ -0,0 +1,47 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestSetConditionObservedGeneration(t *testing.T) {
+	var conds []metav1.Condition
+
+	SetCondition(&conds, ConditionReady, metav1.ConditionFalse, ReasonClaimPending, MessageClaimsProvisioning, 1)
+	if got := GetCondition(conds, ConditionReady); got == nil || got.ObservedGeneration != 1 {
+		t.Fatalf("condition = %+v, want one for generation 1", got)
+	}
+	transitioned := conds[0].LastTransitionTime
+
+	// An unchanged condition confirmed for a newer generation keeps its transition time
+	SetCondition(&conds, ConditionReady, metav1.ConditionFalse, ReasonClaimPending, MessageClaimsProvisioning, 2)
+	if got := GetCondition(conds, ConditionReady); got.ObservedGeneration != 2 || !got.LastTransitionTime.Equal(&transitioned) {
+		t.Errorf("condition = %+v, want generation 2 with the original transition time", got)
+	}
+
+	SetCondition(&conds, ConditionReady, metav1.ConditionTrue, ReasonSucceeded, MessageWorkloadReady, 3)
+	if got := GetCondition(conds, ConditionReady); got.ObservedGeneration != 3 || got.Status != metav1.ConditionTrue {
+		t.Errorf("condition = %+v, want True for generation 3", got)
+	}
+	if len(conds) != 1 {
+		t.Errorf("conditions = %d, want 1", len(conds))
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	"github.com/cappyzawa/score-orchestrator/internal/environment"
+	"github.com/cappyzawa/score-orchestrator/internal/policy"
+	"github.com/cappyzawa/score-orchestrator/internal/quota"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+	"github.com/cappyzawa/score-orchestrator/internal/selection"
+	"github.com/cappyzawa/score-orchestrator/internal/supplychain"
+	"github.com/cappyzawa/score-orchestrator/internal/valuesschema"
+)
+
+// ReasonForError maps an internal error onto the canonical reason vocabulary.
+// The mapping is deterministic and only inspects error identity and API status reasons, never error text;
+// fallback is returned for errors without a specific mapping.
+// API server quota admission rejects with a plain Forbidden status, so it maps to PermissionDenied.
+func ReasonForError(err error, fallback string) string {
+	var netErr net.Error
+
+	switch {
+	case err == nil:
+		return ReasonSucceeded
+	case errors.Is(err, selection.ErrProfileNotFound):
+		return ReasonProfileNotFound
+	case errors.Is(err, selection.ErrNoBackendAvailable):
+		return ReasonBackendUnavailable
+	case errors.Is(err, selection.ErrBackendFiltered):
+		return ReasonBackendFiltered
+	case errors.Is(err, selection.ErrRuntimeUnavailable):
+		return ReasonRuntimeUnavailable
+	case errors.Is(err, reconcile.ErrUnresolvedPlaceholders), errors.Is(err, valuesschema.ErrViolation):
+		return ReasonProjectionError
+	case errors.Is(err, quota.ErrQuotaExceeded):
+		return ReasonQuotaExceeded
+	case errors.Is(err, policy.ErrPolicyDenied):
+		return ReasonPolicyViolation
+	case errors.Is(err, supplychain.ErrVerification):
+		return ReasonSupplyChainError
+	case errors.Is(err, environment.ErrUnknownEnvironment):
+		return ReasonSpecInvalid
+	case errors.Is(err, environment.ErrNamespaceConflict):
+		return ReasonRuntimeConflict
+	case apierrors.IsForbidden(err), apierrors.IsUnauthorized(err):
+		return ReasonPermissionDenied
+	case apierrors.IsInvalid(err), apierrors.IsBadRequest(err):
+		return ReasonSpecInvalid
+	case apierrors.IsServiceUnavailable(err), apierrors.IsTimeout(err), apierrors.IsServerTimeout(err),
+		errors.As(err, &netErr):
+		return ReasonNetworkUnavailable
+	default:
+		return fallback
+	}
+}
+
+// MessageForError returns the canonical message for reason. Placeholder errors additionally name the
+// offending container variable and placeholder, which only refer to the user's own Workload spec, and
+// values schema violations name the JSON pointer paths of the offending template values, and policy
+// denials list the violated policies with the messages the platform configured for them, and supply-chain
+// errors name the template ref and why it failed verification.
+func MessageForError(err error, reason string) string {
+	message := MessageForReason(reason)
+	var placeholderErr *reconcile.PlaceholderError
+	var schemaErr *valuesschema.ValidationError
+	var denial *policy.Denial
+	var verificationErr *supplychain.VerificationError
+	switch {
+	case reason == ReasonPolicyViolation && errors.As(err, &denial):
+		message = fmt.Sprintf("%s: %s", message, denial.Details())
+	case reason == ReasonSupplyChainError && errors.As(err, &verificationErr):
+		message = fmt.Sprintf("%s: %s", message, verificationErr.Error())
+	case reason != ReasonProjectionError:
+	case errors.As(err, &placeholderErr):
+		message = fmt.Sprintf("%s %s", message, placeholderErr.Error())
+	case errors.As(err, &schemaErr):
+		message = fmt.Sprintf("%s %s", MessageValuesSchemaViolation, schemaErr.Details())
+	}
+	return message
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package conditions
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/cappyzawa/score-orchestrator/internal/environment"
+	"github.com/cappyzawa/score-orchestrator/internal/policy"
+	"github.com/cappyzawa/score-orchestrator/internal/quota"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+	"github.com/cappyzawa/score-orchestrator/internal/selection"
+	"github.com/cappyzawa/score-orchestrator/internal/supplychain"
+	"github.com/cappyzawa/score-orchestrator/internal/valuesschema"
+)
+
+func TestReasonForError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"nil error", nil, ReasonSucceeded},
+		{"profile not found", fmt.Errorf("failed to select backend: %w", selection.ErrProfileNotFound), ReasonProfileNotFound},
+		{"no backend available", fmt.Errorf("failed to select backend: %w", selection.ErrNoBackendAvailable), ReasonBackendUnavailable},
+		{"backend filtered", fmt.Errorf("failed to select backend: %w", selection.ErrBackendFiltered), ReasonBackendFiltered},
+		{"runtime unavailable", fmt.Errorf("failed to select backend: %w", selection.ErrRuntimeUnavailable), ReasonRuntimeUnavailable},
+		{"unresolved placeholders", fmt.Errorf("failed to resolve placeholders: %w", reconcile.ErrUnresolvedPlaceholders), ReasonProjectionError},
+		{"values schema violation", fmt.Errorf("template values: %w", &valuesschema.ValidationError{}), ReasonProjectionError},
+		{"policy denial", fmt.Errorf("admission: %w", &policy.Denial{}), ReasonPolicyViolation},
+		{"supply-chain verification", fmt.Errorf("plan: %w", &supplychain.VerificationError{Ref: "web", Detail: "is not signed"}), ReasonSupplyChainError},
+		{"unknown environment", fmt.Errorf("plan: %w", environment.ErrUnknownEnvironment), ReasonSpecInvalid},
+		{"environment namespace conflict", fmt.Errorf("plan: %w", environment.ErrNamespaceConflict), ReasonRuntimeConflict},
+		{"quota violation", fmt.Errorf("admission: %w", &quota.Violation{Quota: "team", Limit: "workloads"}), ReasonQuotaExceeded},
+		{"forbidden", apierrors.NewForbidden(schema.GroupResource{Resource: "secrets"}, "db", errors.New("denied")), ReasonPermissionDenied},
+		{
+			name: "forbidden message mentioning quota is not a quota error",
+			err:  apierrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "app", errors.New("exceeded quota: compute")),
+			want: ReasonPermissionDenied,
+		},
+		{"invalid", apierrors.NewInvalid(schema.GroupKind{Kind: "Workload"}, "app", nil), ReasonSpecInvalid},
+		{"service unavailable", apierrors.NewServiceUnavailable("unavailable"), ReasonNetworkUnavailable},
+		{"unknown error falls back", errors.New("boom"), ReasonRuntimeDegraded},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ReasonForError(tt.err, ReasonRuntimeDegraded); got != tt.want {
+				t.Errorf("ReasonForError() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessageForError(t *testing.T) {
+	placeholderErr := &reconcile.PlaceholderError{Placeholder: "${resources.db.outputs.uri}", Reason: "resource not found"}
+
+	if got := MessageForError(placeholderErr, ReasonProjectionError); got == MessageForReason(ReasonProjectionError) {
+		t.Errorf("MessageForError() = %q, want the placeholder to be named", got)
+	}
+	schemaErr := fmt.Errorf("template values: %w", &valuesschema.ValidationError{
+		Violations: []valuesschema.Violation{{Path: "/replicas", Message: "must be of type integer"}},
+	})
+	if got, want := MessageForError(schemaErr, ReasonProjectionError), MessageValuesSchemaViolation+" /replicas: must be of type integer"; got != want {
+		t.Errorf("MessageForError() = %q, want %q", got, want)
+	}
+	denial := &policy.Denial{Violations: []policy.Violation{{Policy: "limits", Message: "production workloads must set limits"}}}
+	if got, want := MessageForError(denial, ReasonPolicyViolation), MessagePolicyViolation+": limits: production workloads must set limits"; got != want {
+		t.Errorf("MessageForError() = %q, want %q", got, want)
+	}
+	verificationErr := fmt.Errorf("plan: %w", &supplychain.VerificationError{Ref: "registry.example.com/templates/web:v1", Detail: "is not pinned by a digest"})
+	if got, want := MessageForError(verificationErr, ReasonSupplyChainError), MessageSupplyChainError+": template registry.example.com/templates/web:v1: is not pinned by a digest"; got != want {
+		t.Errorf("MessageForError() = %q, want %q", got, want)
+	}
+	if got := MessageForError(errors.New("secret detail"), ReasonRuntimeDegraded); got != MessageForReason(ReasonRuntimeDegraded) {
+		t.Errorf("MessageForError() = %q, want the canonical message only", got)
+	}
+}
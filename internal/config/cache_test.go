@@ -17,9 +17,13 @@ limitations under the License.
 package config
 
 import (
+	"reflect"
 	"testing"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
 )
 
@@ -220,6 +224,47 @@ func TestConfigCache_DeepCopy(t *testing.T) {
 	// In real usage, values would be properly marshaled/unmarshaled
 }
 
+func TestConfigCache_CopiesDefaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		defaults scorev1b1.DefaultsSpec
+	}{
+		{name: "rollout deadline", defaults: scorev1b1.DefaultsSpec{RolloutDeadline: &metav1.Duration{Duration: 20 * time.Minute}}},
+		{name: "runtime registration", defaults: scorev1b1.DefaultsSpec{RequireRuntimeRegistration: true}},
+		{
+			name:     "propagation",
+			defaults: scorev1b1.DefaultsSpec{Propagation: &scorev1b1.PropagationSpec{Labels: []string{"team"}, Annotations: []string{"example.com/"}}},
+		},
+		{name: "plan values", defaults: scorev1b1.DefaultsSpec{PlanValues: &scorev1b1.PlanValuesSpec{MaxInlineSize: "64Ki"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newConfigCache(1 * time.Minute)
+			cache.set(&scorev1b1.OrchestratorConfig{Spec: scorev1b1.OrchestratorConfigSpec{Defaults: tt.defaults}})
+
+			if got := cache.get().Spec.Defaults; !reflect.DeepEqual(got, tt.defaults) {
+				t.Errorf("cached defaults = %+v, want %+v", got, tt.defaults)
+			}
+		})
+	}
+}
+
+func TestConfigCache_CopiesProvisioners(t *testing.T) {
+	provisioner := scorev1b1.ProvisionerSpec{
+		Type:         "postgres",
+		Provisioner:  "builtin",
+		ParamsSchema: &runtime.RawExtension{Raw: []byte(`{"type":"object","properties":{"version":{"type":"string"}}}`)},
+	}
+	cache := newConfigCache(1 * time.Minute)
+	cache.set(&scorev1b1.OrchestratorConfig{Spec: scorev1b1.OrchestratorConfigSpec{Provisioners: []scorev1b1.ProvisionerSpec{provisioner}}})
+
+	got := cache.get().Spec.Provisioners
+	if len(got) != 1 || !reflect.DeepEqual(got[0], provisioner) {
+		t.Errorf("cached provisioners = %+v, want %+v", got, provisioner)
+	}
+}
+
 func TestConfigCache_ConcurrentAccess(t *testing.T) {
 	cache := newConfigCache(1 * time.Minute)
 
@@ -85,7 +85,22 @@ func (l *ConfigMapLoader) LoadConfig(ctx context.Context) (*scorev1b1.Orchestrat
 		}
 	}
 
-	// Load from ConfigMap
+	data, config, err := l.loadFromConfigMap(ctx)
+	recordLoad(data, config, err, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Cache the configuration if caching is enabled
+	if l.cache != nil {
+		l.cache.set(config)
+	}
+
+	return config, nil
+}
+
+// loadFromConfigMap reads, parses and validates the configuration, returning its raw content as well
+func (l *ConfigMapLoader) loadFromConfigMap(ctx context.Context) ([]byte, *scorev1b1.OrchestratorConfig, error) {
 	configMap, err := l.client.CoreV1().ConfigMaps(l.options.Namespace).Get(
 		ctx,
 		l.options.ConfigMapName,
@@ -93,34 +108,29 @@ func (l *ConfigMapLoader) LoadConfig(ctx context.Context) (*scorev1b1.Orchestrat
 	)
 	if err != nil {
 		if apierrors.IsNotFound(err) {
-			return nil, fmt.Errorf("%w: ConfigMap %s/%s not found", ErrConfigNotFound, l.options.Namespace, l.options.ConfigMapName)
+			return nil, nil, fmt.Errorf("%w: ConfigMap %s/%s not found", ErrConfigNotFound, l.options.Namespace, l.options.ConfigMapName)
 		}
-		return nil, fmt.Errorf("failed to get ConfigMap %s/%s: %w", l.options.Namespace, l.options.ConfigMapName, err)
+		return nil, nil, fmt.Errorf("failed to get ConfigMap %s/%s: %w", l.options.Namespace, l.options.ConfigMapName, err)
 	}
 
 	// Extract YAML content
 	yamlContent, exists := configMap.Data[l.options.ConfigMapKey]
 	if !exists {
-		return nil, fmt.Errorf("%w: key %s not found in ConfigMap %s/%s", ErrConfigMalformed, l.options.ConfigMapKey, l.options.Namespace, l.options.ConfigMapName)
+		return nil, nil, fmt.Errorf("%w: key %s not found in ConfigMap %s/%s", ErrConfigMalformed, l.options.ConfigMapKey, l.options.Namespace, l.options.ConfigMapName)
 	}
 
 	// Parse YAML
-	config, err := l.parseConfig(yamlContent)
+	config, err := ParseConfig([]byte(yamlContent))
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrConfigMalformed, err)
+		return nil, nil, fmt.Errorf("%w: %v", ErrConfigMalformed, err)
 	}
 
 	// Validate configuration
 	if err := l.validator.Validate(config); err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrConfigInvalid, err)
-	}
-
-	// Cache the configuration if caching is enabled
-	if l.cache != nil {
-		l.cache.set(config)
+		return nil, nil, fmt.Errorf("%w: %v", ErrConfigInvalid, err)
 	}
 
-	return config, nil
+	return []byte(yamlContent), config, nil
 }
 
 // Watch returns a channel that receives configuration updates
@@ -228,29 +238,36 @@ func (l *ConfigMapLoader) handleConfigMapEvent(eventType ConfigEventType, obj in
 	if eventType != ConfigEventDeleted {
 		yamlContent, exists := configMap.Data[l.options.ConfigMapKey]
 		if !exists {
+			err := fmt.Errorf("%w: key %s not found in ConfigMap", ErrConfigMalformed, l.options.ConfigMapKey)
+			recordLoad(nil, nil, err, time.Now())
 			l.broadcastEvent(ConfigEvent{
 				Type:  ConfigEventError,
-				Error: fmt.Errorf("key %s not found in ConfigMap", l.options.ConfigMapKey),
+				Error: err,
 			})
 			return
 		}
 
-		config, err = l.parseConfig(yamlContent)
+		config, err = ParseConfig([]byte(yamlContent))
 		if err != nil {
+			err = fmt.Errorf("%w: failed to parse configuration: %v", ErrConfigMalformed, err)
+			recordLoad(nil, nil, err, time.Now())
 			l.broadcastEvent(ConfigEvent{
 				Type:  ConfigEventError,
-				Error: fmt.Errorf("failed to parse configuration: %w", err),
+				Error: err,
 			})
 			return
 		}
 
 		if err := l.validator.Validate(config); err != nil {
+			err = fmt.Errorf("%w: configuration validation failed: %v", ErrConfigInvalid, err)
+			recordLoad(nil, nil, err, time.Now())
 			l.broadcastEvent(ConfigEvent{
 				Type:  ConfigEventError,
-				Error: fmt.Errorf("configuration validation failed: %w", err),
+				Error: err,
 			})
 			return
 		}
+		recordLoad([]byte(yamlContent), config, nil, time.Now())
 
 		// Update cache if enabled
 		if l.cache != nil {
@@ -287,10 +304,10 @@ func (l *ConfigMapLoader) broadcastEvent(event ConfigEvent) {
 	}
 }
 
-// parseConfig parses YAML configuration into OrchestratorConfig
-func (l *ConfigMapLoader) parseConfig(yamlContent string) (*scorev1b1.OrchestratorConfig, error) {
+// ParseConfig parses YAML configuration into OrchestratorConfig
+func ParseConfig(data []byte) (*scorev1b1.OrchestratorConfig, error) {
 	var config scorev1b1.OrchestratorConfig
-	if err := yaml.Unmarshal([]byte(yamlContent), &config); err != nil {
+	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal YAML: %w", err)
 	}
 	return &config, nil
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+var (
+	// configInfo identifies the configuration currently in use by its content digest and metadata version
+	configInfo = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "score_orchestrator_config_info",
+			Help: "Orchestrator configuration currently loaded, identified by digest and version (always 1)",
+		},
+		[]string{"digest", "version"},
+	)
+
+	// configLoadSuccess is 1 when the last configuration load succeeded and 0 when it failed
+	configLoadSuccess = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "score_orchestrator_config_last_load_success",
+			Help: "Whether the last orchestrator configuration load succeeded (1) or failed (0)",
+		},
+	)
+
+	// configLoadTimestamp is the time of the last successful configuration load
+	configLoadTimestamp = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "score_orchestrator_config_last_load_timestamp_seconds",
+			Help: "Unix time of the last successful orchestrator configuration load",
+		},
+	)
+
+	// configLoadErrorsTotal counts failed configuration loads by cause
+	configLoadErrorsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "score_orchestrator_config_load_errors_total",
+			Help: "Number of failed orchestrator configuration loads",
+		},
+		[]string{"reason"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(configInfo, configLoadSuccess, configLoadTimestamp, configLoadErrorsTotal)
+}
+
+// Digest returns a short content digest of the raw configuration
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// recordLoad publishes the outcome of loading the configuration from its raw content.
+// A failed load keeps the info of the last configuration that loaded successfully.
+func recordLoad(data []byte, config *scorev1b1.OrchestratorConfig, err error, now time.Time) {
+	if err != nil {
+		configLoadSuccess.Set(0)
+		configLoadErrorsTotal.WithLabelValues(loadErrorReason(err)).Inc()
+		return
+	}
+
+	configInfo.Reset()
+	configInfo.WithLabelValues(Digest(data), config.Metadata.Version).Set(1)
+	configLoadSuccess.Set(1)
+	configLoadTimestamp.Set(float64(now.Unix()))
+}
+
+// loadErrorReason maps a load error to the reason label of configLoadErrorsTotal
+func loadErrorReason(err error) string {
+	switch {
+	case errors.Is(err, ErrConfigNotFound):
+		return "NotFound"
+	case errors.Is(err, ErrConfigMalformed):
+		return "Malformed"
+	case errors.Is(err, ErrConfigInvalid):
+		return "Invalid"
+	default:
+		return "Unavailable"
+	}
+}
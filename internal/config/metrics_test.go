@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestRecordLoad(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	data := []byte("apiVersion: score.dev/v1b1\nkind: OrchestratorConfig\n")
+	config := &scorev1b1.OrchestratorConfig{Metadata: scorev1b1.OrchestratorConfigMeta{Name: "default", Version: "1.4.0"}}
+
+	recordLoad(data, config, nil, now)
+	if got := testutil.ToFloat64(configInfo.WithLabelValues(Digest(data), "1.4.0")); got != 1 {
+		t.Errorf("config info = %v, want 1 for the loaded digest and version", got)
+	}
+	if got := testutil.ToFloat64(configLoadSuccess); got != 1 {
+		t.Errorf("last load success = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(configLoadTimestamp); got != float64(now.Unix()) {
+		t.Errorf("last load timestamp = %v, want %v", got, now.Unix())
+	}
+
+	invalid := fmt.Errorf("%w: profiles are required", ErrConfigInvalid)
+	before := testutil.ToFloat64(configLoadErrorsTotal.WithLabelValues("Invalid"))
+	recordLoad(nil, nil, invalid, now.Add(time.Minute))
+	if got := testutil.ToFloat64(configLoadSuccess); got != 0 {
+		t.Errorf("last load success = %v, want 0 after a failed load", got)
+	}
+	if got := testutil.ToFloat64(configLoadErrorsTotal.WithLabelValues("Invalid")); got != before+1 {
+		t.Errorf("Invalid load errors = %v, want %v", got, before+1)
+	}
+	if got := testutil.CollectAndCount(configInfo); got != 1 {
+		t.Errorf("config info series = %d, want the last loaded configuration to be kept", got)
+	}
+	if got := testutil.ToFloat64(configLoadTimestamp); got != float64(now.Unix()) {
+		t.Errorf("last load timestamp = %v, want the time of the last successful load", got)
+	}
+}
+
+func TestLoadErrorReason(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{fmt.Errorf("%w: ConfigMap score-system/orchestrator-config not found", ErrConfigNotFound), "NotFound"},
+		{fmt.Errorf("%w: bad yaml", ErrConfigMalformed), "Malformed"},
+		{fmt.Errorf("%w: no profiles", ErrConfigInvalid), "Invalid"},
+		{errors.New("connection refused"), "Unavailable"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := loadErrorReason(tt.err); got != tt.want {
+				t.Errorf("loadErrorReason(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}
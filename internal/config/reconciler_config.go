@@ -30,6 +30,12 @@ type RetryConfig struct {
 
 	// BackoffMultiplier is the multiplier for exponential backoff
 	BackoffMultiplier float64 `json:"backoffMultiplier" yaml:"backoffMultiplier"`
+
+	// MaxRequeueDelay caps the delay between consecutive requeues of a waiting Workload
+	MaxRequeueDelay time.Duration `json:"maxRequeueDelay" yaml:"maxRequeueDelay"`
+
+	// RequeueJitter is the largest fraction of a requeue delay added at random
+	RequeueJitter float64 `json:"requeueJitter" yaml:"requeueJitter"`
 }
 
 // TimeoutConfig defines timeout settings for different operations
@@ -70,6 +76,8 @@ func DefaultReconcilerConfig() *ReconcilerConfig {
 			ConflictRequeueDelay: 1 * time.Second,
 			MaxRetries:           3,
 			BackoffMultiplier:    2.0,
+			MaxRequeueDelay:      5 * time.Minute,
+			RequeueJitter:        0.2,
 		},
 		Timeouts: TimeoutConfig{
 			ClaimTimeout:    5 * time.Minute,
@@ -104,6 +112,14 @@ func (c *ReconcilerConfig) Validate() error {
 		c.Retry.BackoffMultiplier = 2.0
 	}
 
+	if c.Retry.MaxRequeueDelay < c.Retry.DefaultRequeueDelay {
+		c.Retry.MaxRequeueDelay = max(5*time.Minute, c.Retry.DefaultRequeueDelay)
+	}
+
+	if c.Retry.RequeueJitter < 0 || c.Retry.RequeueJitter > 1 {
+		c.Retry.RequeueJitter = 0.2
+	}
+
 	if c.Timeouts.ClaimTimeout <= 0 {
 		c.Timeouts.ClaimTimeout = 5 * time.Minute
 	}
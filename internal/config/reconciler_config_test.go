@@ -23,6 +23,8 @@ var _ = Describe("ReconcilerConfig", func() {
 			Expect(config.Retry.ConflictRequeueDelay).To(Equal(1 * time.Second))
 			Expect(config.Retry.MaxRetries).To(Equal(3))
 			Expect(config.Retry.BackoffMultiplier).To(Equal(2.0))
+			Expect(config.Retry.MaxRequeueDelay).To(Equal(5 * time.Minute))
+			Expect(config.Retry.RequeueJitter).To(Equal(0.2))
 
 			Expect(config.Timeouts.ClaimTimeout).To(Equal(5 * time.Minute))
 			Expect(config.Timeouts.PlanTimeout).To(Equal(3 * time.Minute))
@@ -76,6 +78,21 @@ var _ = Describe("ReconcilerConfig", func() {
 			Expect(config.Retry.BackoffMultiplier).To(Equal(2.0))
 		})
 
+		It("should raise a MaxRequeueDelay below DefaultRequeueDelay", func() {
+			config.Retry.DefaultRequeueDelay = 10 * time.Minute
+			config.Retry.MaxRequeueDelay = time.Minute
+			err := config.Validate()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.Retry.MaxRequeueDelay).To(Equal(10 * time.Minute))
+		})
+
+		It("should fix invalid RequeueJitter", func() {
+			config.Retry.RequeueJitter = -0.5
+			err := config.Validate()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(config.Retry.RequeueJitter).To(Equal(0.2))
+		})
+
 		It("should fix invalid ClaimTimeout", func() {
 			config.Timeouts.ClaimTimeout = -1 * time.Minute
 			err := config.Validate()
@@ -0,0 +1,184 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// DefaultSharedCacheTTL is how long the SharedCache serves a configuration before reloading it from its source
+const DefaultSharedCacheTTL = 5 * time.Minute
+
+// SharedCache is the single in-memory copy of the configuration shared by all controllers of a manager.
+// Every LoadConfig reads the same immutable snapshot, so that controllers never see different versions of
+// the configuration in the middle of a rollout. A reload or watch event replaces the snapshot atomically.
+// The snapshot is versioned by a generation that increases each time the loaded content changes; copies
+// returned by LoadConfig carry it in metadata.generation.
+type SharedCache struct {
+	source ConfigLoader
+	ttl    time.Duration
+
+	current atomic.Pointer[configSnapshot]
+
+	// reloadMu lets a single caller reload an expired snapshot while the others keep reading it
+	reloadMu sync.Mutex
+	// swapMu serializes the swaps of reloads and watch events, which assign the generations
+	swapMu     sync.Mutex
+	generation int64
+}
+
+// configSnapshot is a loaded configuration; it is never modified once published
+type configSnapshot struct {
+	config     *scorev1b1.OrchestratorConfig
+	digest     string
+	generation int64
+	loadedAt   time.Time
+}
+
+// NewSharedCache creates a SharedCache that loads the configuration from source and reloads it once ttl
+// has passed. A ttl of zero or less defaults to DefaultSharedCacheTTL.
+func NewSharedCache(source ConfigLoader, ttl time.Duration) *SharedCache {
+	if ttl <= 0 {
+		ttl = DefaultSharedCacheTTL
+	}
+	return &SharedCache{source: source, ttl: ttl}
+}
+
+// LoadConfig returns a copy of the current configuration, loading it from the source when there is none
+// yet or it has expired. While one caller reloads an expired configuration, the others get the current one.
+func (s *SharedCache) LoadConfig(ctx context.Context) (*scorev1b1.OrchestratorConfig, error) {
+	snapshot := s.current.Load()
+	if snapshot != nil && time.Since(snapshot.loadedAt) <= s.ttl {
+		return snapshot.copy(), nil
+	}
+
+	if snapshot != nil {
+		if !s.reloadMu.TryLock() {
+			return snapshot.copy(), nil
+		}
+	} else {
+		s.reloadMu.Lock()
+	}
+	defer s.reloadMu.Unlock()
+
+	// Another caller may have loaded the configuration while this one waited
+	if current := s.current.Load(); current != nil && current != snapshot && time.Since(current.loadedAt) <= s.ttl {
+		return current.copy(), nil
+	}
+
+	config, err := s.source.LoadConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.swap(config).copy(), nil
+}
+
+// Generation returns the generation of the current configuration, or 0 when none is loaded
+func (s *SharedCache) Generation() int64 {
+	if snapshot := s.current.Load(); snapshot != nil {
+		return snapshot.generation
+	}
+	return 0
+}
+
+// Watch returns the configuration events of the source
+func (s *SharedCache) Watch(ctx context.Context) (<-chan ConfigEvent, error) {
+	return s.source.Watch(ctx)
+}
+
+// Close releases the resources held by the source
+func (s *SharedCache) Close() error {
+	return s.source.Close()
+}
+
+// Start swaps in the configurations the source reports until ctx is cancelled, so that changes reach all
+// controllers at once instead of when the snapshot expires. Without a watch, the cache only reloads on expiry.
+func (s *SharedCache) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("config-cache")
+
+	events, err := s.source.Watch(ctx)
+	if err != nil {
+		logger.Error(err, "Failed to watch the orchestrator configuration, reloading it on expiry only")
+		<-ctx.Done()
+		return nil
+	}
+
+	for event := range events {
+		switch event.Type {
+		case ConfigEventAdded, ConfigEventModified:
+			if event.Config == nil {
+				continue
+			}
+			snapshot := s.swap(event.Config)
+			logger.V(1).Info("Orchestrator configuration updated", "generation", snapshot.generation, "digest", snapshot.digest)
+		case ConfigEventDeleted:
+			// The next LoadConfig reports the missing configuration
+			s.current.Store(nil)
+			logger.Info("Orchestrator configuration deleted")
+		case ConfigEventError:
+			// Keep serving the last valid configuration
+			logger.Error(event.Error, "Ignoring invalid orchestrator configuration update")
+		}
+	}
+	return nil
+}
+
+// NeedLeaderElection returns false: every replica serves the configuration to its controllers
+func (s *SharedCache) NeedLeaderElection() bool {
+	return false
+}
+
+// swap publishes config as the current snapshot. The generation is kept when the content is unchanged.
+func (s *SharedCache) swap(config *scorev1b1.OrchestratorConfig) *configSnapshot {
+	snapshot := &configSnapshot{
+		config:   config.DeepCopy(),
+		digest:   contentDigest(config),
+		loadedAt: time.Now(),
+	}
+
+	s.swapMu.Lock()
+	defer s.swapMu.Unlock()
+	if previous := s.current.Load(); previous == nil || previous.digest != snapshot.digest || snapshot.digest == "" {
+		s.generation++
+	}
+	snapshot.generation = s.generation
+	snapshot.config.Metadata.Generation = snapshot.generation
+	s.current.Store(snapshot)
+	return snapshot
+}
+
+// copy returns a copy of the configuration that callers may modify
+func (s *configSnapshot) copy() *scorev1b1.OrchestratorConfig {
+	return s.config.DeepCopy()
+}
+
+// contentDigest returns the digest of the content of the configuration, or "" when it cannot be encoded
+func contentDigest(config *scorev1b1.OrchestratorConfig) string {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return ""
+	}
+	return Digest(data)
+}
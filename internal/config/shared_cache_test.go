@@ -0,0 +1,266 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/randfill"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/planvalues"
+)
+
+// countingLoader returns its configuration after an optional delay and counts the loads
+type countingLoader struct {
+	mu     sync.Mutex
+	config *scorev1b1.OrchestratorConfig
+	delay  time.Duration
+	loads  atomic.Int32
+	events chan ConfigEvent
+}
+
+func (l *countingLoader) LoadConfig(ctx context.Context) (*scorev1b1.OrchestratorConfig, error) {
+	l.loads.Add(1)
+	time.Sleep(l.delay)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.config == nil {
+		return nil, ErrConfigNotFound
+	}
+	return l.config.DeepCopy(), nil
+}
+
+func (l *countingLoader) setProfile(profile string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.config = newSharedCacheConfig(profile)
+}
+
+func (l *countingLoader) Watch(ctx context.Context) (<-chan ConfigEvent, error) {
+	return l.events, nil
+}
+
+func (l *countingLoader) Close() error {
+	return nil
+}
+
+func newSharedCacheConfig(profile string) *scorev1b1.OrchestratorConfig {
+	return &scorev1b1.OrchestratorConfig{
+		APIVersion: "score.dev/v1b1",
+		Kind:       "OrchestratorConfig",
+		Metadata:   scorev1b1.OrchestratorConfigMeta{Name: "test-config"},
+		Spec:       scorev1b1.OrchestratorConfigSpec{Defaults: scorev1b1.DefaultsSpec{Profile: profile}},
+	}
+}
+
+func TestSharedCache_LoadConfig(t *testing.T) {
+	source := &countingLoader{config: newSharedCacheConfig("web")}
+	cache := NewSharedCache(source, time.Minute)
+
+	first, err := cache.LoadConfig(context.Background())
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if first.Metadata.Generation != 1 {
+		t.Errorf("LoadConfig() generation = %d, want 1", first.Metadata.Generation)
+	}
+
+	// Callers get copies they may modify
+	first.Spec.Defaults.Profile = "modified"
+	second, err := cache.LoadConfig(context.Background())
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if second.Spec.Defaults.Profile != "web" {
+		t.Errorf("LoadConfig() profile = %q, want the cached web", second.Spec.Defaults.Profile)
+	}
+	if loads := source.loads.Load(); loads != 1 {
+		t.Errorf("source loads = %d, want 1", loads)
+	}
+}
+
+func TestSharedCache_LoadConfigKeepsPlanValues(t *testing.T) {
+	config := newSharedCacheConfig("web")
+	config.Spec.Defaults.PlanValues = &scorev1b1.PlanValuesSpec{MaxInlineSize: "1Mi"}
+	cache := NewSharedCache(&countingLoader{config: config}, time.Minute)
+
+	loaded, err := cache.LoadConfig(context.Background())
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	limit, err := planvalues.MaxInlineSize(loaded.Spec.Defaults.PlanValues)
+	if err != nil {
+		t.Fatalf("MaxInlineSize() error = %v", err)
+	}
+	if limit != 1<<20 {
+		t.Errorf("MaxInlineSize() = %d, want the configured %d", limit, 1<<20)
+	}
+}
+
+func TestSharedCache_CopiesEveryField(t *testing.T) {
+	// Every pointer, slice and map is populated, so that a field the copy drops cannot go unnoticed
+	filler := randfill.New().NilChance(0).NumElements(1, 2).MaxDepth(12).Funcs(
+		func(raw *runtime.RawExtension, c randfill.Continue) {
+			raw.Raw = []byte(`{"key":"` + c.String(8) + `"}`)
+		},
+	)
+
+	for i := 0; i < 20; i++ {
+		config := &scorev1b1.OrchestratorConfig{}
+		filler.Fill(config)
+
+		cache := NewSharedCache(&countingLoader{}, time.Minute)
+		snapshot := cache.swap(config)
+		want := config.DeepCopy()
+		want.Metadata.Generation = snapshot.generation
+		if got := snapshot.copy(); !reflect.DeepEqual(got, want) {
+			t.Fatalf("SharedCache copy differs from the loaded configuration:\n got: %+v\nwant: %+v", got, want)
+		}
+
+		configs := newConfigCache(time.Minute)
+		configs.set(config)
+		if got := configs.get(); !reflect.DeepEqual(got, config) {
+			t.Fatalf("configCache copy differs from the loaded configuration:\n got: %+v\nwant: %+v", got, config)
+		}
+	}
+}
+
+func TestSharedCache_LoadConfigError(t *testing.T) {
+	cache := NewSharedCache(&countingLoader{}, time.Minute)
+
+	if _, err := cache.LoadConfig(context.Background()); err == nil {
+		t.Fatal("LoadConfig() error = nil, want the error of the source")
+	}
+	if generation := cache.Generation(); generation != 0 {
+		t.Errorf("Generation() = %d, want 0", generation)
+	}
+}
+
+func TestSharedCache_GenerationFollowsContent(t *testing.T) {
+	source := &countingLoader{config: newSharedCacheConfig("web")}
+	cache := NewSharedCache(source, time.Nanosecond)
+
+	load := func() int64 {
+		t.Helper()
+		time.Sleep(time.Millisecond)
+		config, err := cache.LoadConfig(context.Background())
+		if err != nil {
+			t.Fatalf("LoadConfig() error = %v", err)
+		}
+		return config.Metadata.Generation
+	}
+
+	if generation := load(); generation != 1 {
+		t.Errorf("first load generation = %d, want 1", generation)
+	}
+	if generation := load(); generation != 1 {
+		t.Errorf("reload of unchanged content generation = %d, want 1", generation)
+	}
+	source.setProfile("worker")
+	if generation := load(); generation != 2 {
+		t.Errorf("reload of changed content generation = %d, want 2", generation)
+	}
+	if loads := source.loads.Load(); loads != 3 {
+		t.Errorf("source loads = %d, want 3", loads)
+	}
+}
+
+func TestSharedCache_ConcurrentLoadsShareOneLoad(t *testing.T) {
+	source := &countingLoader{config: newSharedCacheConfig("web"), delay: 20 * time.Millisecond}
+	cache := NewSharedCache(source, time.Minute)
+
+	var wg sync.WaitGroup
+	generations := make([]int64, 20)
+	for i := range generations {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			config, err := cache.LoadConfig(context.Background())
+			if err != nil {
+				t.Errorf("LoadConfig() error = %v", err)
+				return
+			}
+			generations[i] = config.Metadata.Generation
+		}(i)
+	}
+	wg.Wait()
+
+	if loads := source.loads.Load(); loads != 1 {
+		t.Errorf("source loads = %d, want 1", loads)
+	}
+	for i, generation := range generations {
+		if generation != 1 {
+			t.Errorf("caller %d generation = %d, want 1", i, generation)
+		}
+	}
+}
+
+func TestSharedCache_StartSwapsWatchedConfig(t *testing.T) {
+	source := &countingLoader{config: newSharedCacheConfig("web"), events: make(chan ConfigEvent)}
+	cache := NewSharedCache(source, time.Hour)
+	if _, err := cache.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	done := make(chan error)
+	go func() { done <- cache.Start(context.Background()) }()
+
+	source.events <- ConfigEvent{Type: ConfigEventError, Error: ErrConfigInvalid}
+	source.events <- ConfigEvent{Type: ConfigEventModified, Config: newSharedCacheConfig("worker")}
+	source.events <- ConfigEvent{Type: ConfigEventAdded, Config: newSharedCacheConfig("worker")}
+	close(source.events)
+	if err := <-done; err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	config, err := cache.LoadConfig(context.Background())
+	if err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+	if config.Spec.Defaults.Profile != "worker" || config.Metadata.Generation != 2 {
+		t.Errorf("LoadConfig() = %s generation %d, want worker generation 2", config.Spec.Defaults.Profile, config.Metadata.Generation)
+	}
+	if loads := source.loads.Load(); loads != 1 {
+		t.Errorf("source loads = %d, want 1", loads)
+	}
+}
+
+func TestSharedCache_StartClearsDeletedConfig(t *testing.T) {
+	source := &countingLoader{config: newSharedCacheConfig("web"), events: make(chan ConfigEvent, 1)}
+	cache := NewSharedCache(source, time.Hour)
+	if _, err := cache.LoadConfig(context.Background()); err != nil {
+		t.Fatalf("LoadConfig() error = %v", err)
+	}
+
+	source.events <- ConfigEvent{Type: ConfigEventDeleted}
+	close(source.events)
+	if err := cache.Start(context.Background()); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	source.config = nil
+	if _, err := cache.LoadConfig(context.Background()); err == nil {
+		t.Error("LoadConfig() error = nil, want the missing configuration to be reported")
+	}
+}
@@ -17,13 +17,27 @@ limitations under the License.
 package config
 
 import (
+	"crypto/x509"
+	"fmt"
+	"net/url"
+	"path"
 	"regexp"
+	"slices"
 	"strings"
 
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/policy"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner"
+	"github.com/cappyzawa/score-orchestrator/internal/selection"
+	"github.com/cappyzawa/score-orchestrator/internal/supplychain"
+	"github.com/cappyzawa/score-orchestrator/internal/valuesschema"
 )
 
 // Validator validates orchestrator configuration
@@ -48,6 +62,7 @@ func NewValidator() *Validator {
 		},
 		validRuntimeClasses: map[string]bool{
 			"kubernetes": true,
+			"docker":     true,
 			"ecs":        true,
 			"nomad":      true,
 		},
@@ -77,6 +92,36 @@ func (v *Validator) Validate(config *scorev1b1.OrchestratorConfig) error {
 	allErrs = append(allErrs, v.validateProfiles(config.Spec.Profiles, specPath.Child("profiles"))...)
 	allErrs = append(allErrs, v.validateProvisioners(config.Spec.Provisioners, specPath.Child("provisioners"))...)
 	allErrs = append(allErrs, v.validateDefaults(&config.Spec.Defaults, specPath.Child("defaults"))...)
+	allErrs = append(allErrs, v.validateQuotas(config.Spec.Quotas, specPath.Child("quotas"))...)
+
+	// Validate policies
+	allErrs = append(allErrs, v.validatePolicies(config.Spec.Policies, specPath.Child("policies"))...)
+
+	// Validate remote runtime targets
+	allErrs = append(allErrs, v.validateTargets(config.Spec.Targets, specPath.Child("targets"))...)
+
+	// Validate notification sinks
+	allErrs = append(allErrs, v.validateNotifications(config.Spec.Notifications, specPath.Child("notifications"))...)
+
+	// Validate audit trail retention
+	if config.Spec.Audit != nil {
+		allErrs = append(allErrs, v.validateAudit(config.Spec.Audit, specPath.Child("audit"))...)
+	}
+
+	// Validate template verification
+	if config.Spec.SupplyChain != nil {
+		allErrs = append(allErrs, v.validateSupplyChain(config.Spec.SupplyChain, specPath.Child("supplyChain"))...)
+	}
+
+	// Validate sharding
+	if config.Spec.Sharding != nil && config.Spec.Sharding.Count < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("sharding", "count"), config.Spec.Sharding.Count, "must not be negative"))
+	}
+
+	// Validate environment namespaces
+	if config.Spec.Namespaces != nil {
+		allErrs = append(allErrs, v.validateNamespaces(config.Spec.Namespaces, specPath.Child("namespaces"))...)
+	}
 
 	// Validate cross-references
 	allErrs = append(allErrs, v.validateCrossReferences(config)...)
@@ -116,6 +161,23 @@ func (v *Validator) validateProfiles(profiles []scorev1b1.ProfileSpec, fldPath *
 			profileNames[profile.Name] = true
 		}
 
+		// Validate kind
+		switch profile.Kind {
+		case "", scorev1b1.WorkloadKindService, scorev1b1.WorkloadKindJob, scorev1b1.WorkloadKindCronJob:
+		default:
+			allErrs = append(allErrs, field.NotSupported(profilePath.Child("kind"), profile.Kind,
+				[]string{scorev1b1.WorkloadKindService, scorev1b1.WorkloadKindJob, scorev1b1.WorkloadKindCronJob}))
+		}
+
+		// Validate defaults if present
+		if profile.Defaults != nil {
+			allErrs = append(allErrs, v.validateWorkloadDefaults(profile.Defaults, profilePath.Child("defaults"))...)
+		}
+		if profile.WorkloadDefaults != nil {
+			allErrs = append(allErrs, v.validateWorkloadFragment(profile.WorkloadDefaults, profilePath.Child("workloadDefaults"))...)
+		}
+		allErrs = append(allErrs, v.validateReadinessGates(profile.ReadinessGates, profilePath.Child("readinessGates"))...)
+
 		// Validate backends
 		if len(profile.Backends) == 0 {
 			allErrs = append(allErrs, field.Required(profilePath.Child("backends"), "at least one backend must be defined"))
@@ -130,6 +192,33 @@ func (v *Validator) validateProfiles(profiles []scorev1b1.ProfileSpec, fldPath *
 	return allErrs
 }
 
+// validateReadinessGates validates the readiness gates of a profile. The plan conditions owned by the
+// orchestrator and the runtime contract cannot be used as gates.
+func (v *Validator) validateReadinessGates(gates []scorev1b1.ReadinessGate, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	seen := make(map[string]bool)
+	for i, gate := range gates {
+		typePath := fldPath.Index(i).Child("conditionType")
+		switch {
+		case gate.ConditionType == "":
+			allErrs = append(allErrs, field.Required(typePath, "conditionType is required"))
+			continue
+		case gate.ConditionType == meta.PlanConditionReady || gate.ConditionType == meta.PlanConditionDelivered:
+			allErrs = append(allErrs, field.Invalid(typePath, gate.ConditionType, "condition type is reserved by the plan contract"))
+		}
+		if errs := validation.IsQualifiedName(gate.ConditionType); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(typePath, gate.ConditionType, strings.Join(errs, "; ")))
+		}
+		if seen[gate.ConditionType] {
+			allErrs = append(allErrs, field.Duplicate(typePath, gate.ConditionType))
+		}
+		seen[gate.ConditionType] = true
+	}
+
+	return allErrs
+}
+
 // validateBackend validates a single backend
 func (v *Validator) validateBackend(backend *scorev1b1.BackendSpec, fldPath *field.Path, backendIds map[string]bool) field.ErrorList {
 	var allErrs field.ErrorList
@@ -173,6 +262,205 @@ func (v *Validator) validateBackend(backend *scorev1b1.BackendSpec, fldPath *fie
 		allErrs = append(allErrs, v.validateConstraints(backend.Constraints, fldPath.Child("constraints"))...)
 	}
 
+	// Validate exposure if present
+	if backend.Exposure != nil {
+		allErrs = append(allErrs, v.validateExposure(backend.Exposure, fldPath.Child("exposure"))...)
+	}
+
+	// Validate defaults if present
+	if backend.Defaults != nil {
+		allErrs = append(allErrs, v.validateWorkloadDefaults(backend.Defaults, fldPath.Child("defaults"))...)
+	}
+
+	allErrs = append(allErrs, v.validateValuesFrom(backend.ValuesFrom, fldPath.Child("valuesFrom"))...)
+
+	return allErrs
+}
+
+// validateValuesFrom validates the claim outputs a backend routes to values paths
+func (v *Validator) validateValuesFrom(valuesFrom []scorev1b1.ValuesFromSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	paths := make(map[string]bool)
+	for i, mapping := range valuesFrom {
+		mappingPath := fldPath.Index(i)
+		if mapping.Claim == "" {
+			allErrs = append(allErrs, field.Required(mappingPath.Child("claim"), "claim is required"))
+		}
+		if mapping.Output == "" {
+			allErrs = append(allErrs, field.Required(mappingPath.Child("output"), "output is required"))
+		}
+
+		switch {
+		case mapping.Path == "":
+			allErrs = append(allErrs, field.Required(mappingPath.Child("path"), "path is required"))
+		case !strings.HasPrefix(mapping.Path, ".") || slices.Contains(strings.Split(mapping.Path[1:], "."), ""):
+			allErrs = append(allErrs, field.Invalid(mappingPath.Child("path"), mapping.Path,
+				"must be a dot-separated values path starting with '.', e.g. .db.connectionString"))
+		case paths[mapping.Path]:
+			allErrs = append(allErrs, field.Duplicate(mappingPath.Child("path"), mapping.Path))
+		}
+		paths[mapping.Path] = true
+	}
+
+	return allErrs
+}
+
+// validateWorkloadDefaults validates the reliability defaults of a profile or backend
+func (v *Validator) validateWorkloadDefaults(defaults *scorev1b1.WorkloadDefaultsSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if budget := defaults.DisruptionBudget; budget != nil {
+		budgetPath := fldPath.Child("disruptionBudget")
+		switch {
+		case budget.MinAvailable == nil && budget.MaxUnavailable == nil:
+			allErrs = append(allErrs, field.Required(budgetPath, "one of minAvailable or maxUnavailable is required"))
+		case budget.MinAvailable != nil && budget.MaxUnavailable != nil:
+			allErrs = append(allErrs, field.Forbidden(budgetPath, "minAvailable and maxUnavailable are mutually exclusive"))
+		}
+		allErrs = append(allErrs, validateIntOrPercent(budget.MinAvailable, budgetPath.Child("minAvailable"))...)
+		allErrs = append(allErrs, validateIntOrPercent(budget.MaxUnavailable, budgetPath.Child("maxUnavailable"))...)
+	}
+
+	if defaults.Resources != nil {
+		allErrs = append(allErrs, validateResourceRequirements(defaults.Resources, fldPath.Child("resources"))...)
+	}
+
+	if defaults.PriorityClass != nil {
+		allErrs = append(allErrs, validatePriorityClass(defaults.PriorityClass, fldPath.Child("priorityClass"))...)
+	}
+
+	return allErrs
+}
+
+// maxPriorityClassValue is the highest priority of PriorityClasses that are not reserved by Kubernetes
+const maxPriorityClassValue = 1000000000
+
+// validatePriorityClass validates the PriorityClass of the pods. PriorityClasses prefixed "system-" are
+// reserved by Kubernetes and can be referenced but not created.
+func validatePriorityClass(priorityClass *scorev1b1.PriorityClassSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	namePath := fldPath.Child("name")
+	if priorityClass.Name == "" {
+		allErrs = append(allErrs, field.Required(namePath, "name is required"))
+	} else {
+		for _, msg := range validation.IsDNS1123Subdomain(priorityClass.Name) {
+			allErrs = append(allErrs, field.Invalid(namePath, priorityClass.Name, msg))
+		}
+	}
+
+	if priorityClass.Value == nil {
+		if priorityClass.PreemptionPolicy != "" {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("preemptionPolicy"), "only applies to a PriorityClass created from a value"))
+		}
+		return allErrs
+	}
+	if strings.HasPrefix(priorityClass.Name, "system-") {
+		allErrs = append(allErrs, field.Forbidden(fldPath.Child("value"), "PriorityClasses prefixed system- are reserved and cannot be created"))
+	}
+	if *priorityClass.Value > maxPriorityClassValue {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("value"), *priorityClass.Value, fmt.Sprintf("must be at most %d", maxPriorityClassValue)))
+	}
+	switch priorityClass.PreemptionPolicy {
+	case "", scorev1b1.PreemptionPolicyPreemptLowerPriority, scorev1b1.PreemptionPolicyNever:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("preemptionPolicy"), priorityClass.PreemptionPolicy,
+			[]string{scorev1b1.PreemptionPolicyPreemptLowerPriority, scorev1b1.PreemptionPolicyNever}))
+	}
+
+	return allErrs
+}
+
+// validateWorkloadFragment validates the base Workload fragment of a profile
+func (v *Validator) validateWorkloadFragment(fragment *scorev1b1.WorkloadFragmentSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for name, container := range fragment.Containers {
+		containerPath := fldPath.Child("containers").Key(name)
+		if container.Image == "" {
+			allErrs = append(allErrs, field.Required(containerPath.Child("image"), "image is required"))
+		}
+		allErrs = append(allErrs, validateFragmentVariables(container.Variables, containerPath.Child("variables"))...)
+		if container.Resources != nil {
+			allErrs = append(allErrs, validateResourceRequirements(container.Resources, containerPath.Child("resources"))...)
+		}
+	}
+	allErrs = append(allErrs, validateFragmentVariables(fragment.Variables, fldPath.Child("variables"))...)
+	if fragment.Resources != nil {
+		allErrs = append(allErrs, validateResourceRequirements(fragment.Resources, fldPath.Child("resources"))...)
+	}
+
+	return allErrs
+}
+
+// validateFragmentVariables rejects variables under the prefix reserved for the variables runtimes inject
+func validateFragmentVariables(variables map[string]string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for key := range variables {
+		if strings.HasPrefix(key, meta.EnvReservedPrefix) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), key,
+				fmt.Sprintf("the prefix %s is reserved for variables injected by the platform", meta.EnvReservedPrefix)))
+		}
+	}
+	return allErrs
+}
+
+// validateResourceRequirements validates the quantities of container requests and limits, and that no
+// request exceeds the limit of the same resource
+func validateResourceRequirements(resources *scorev1b1.ResourceRequirements, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	requests := make(map[string]resource.Quantity, len(resources.Requests))
+	for name, value := range resources.Requests {
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("requests").Key(name), value, fmt.Sprintf("invalid quantity: %v", err)))
+			continue
+		}
+		requests[name] = q
+	}
+	for name, value := range resources.Limits {
+		q, err := resource.ParseQuantity(value)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("limits").Key(name), value, fmt.Sprintf("invalid quantity: %v", err)))
+			continue
+		}
+		if request, ok := requests[name]; ok && request.Cmp(q) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("requests").Key(name), resources.Requests[name],
+				fmt.Sprintf("must be less than or equal to the %s limit", name)))
+		}
+	}
+
+	return allErrs
+}
+
+// validateIntOrPercent validates a non-negative count or percentage, if set
+func validateIntOrPercent(value *intstr.IntOrString, fldPath *field.Path) field.ErrorList {
+	if value == nil {
+		return nil
+	}
+	scaled, err := intstr.GetScaledValueFromIntOrPercent(value, 100, false)
+	if err != nil {
+		return field.ErrorList{field.Invalid(fldPath, value.String(), "must be an integer or a percentage")}
+	}
+	if scaled < 0 {
+		return field.ErrorList{field.Invalid(fldPath, value.String(), "must not be negative")}
+	}
+	return nil
+}
+
+// validateExposure validates an exposure specification
+func (v *Validator) validateExposure(exposure *scorev1b1.ExposureSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch exposure.Mode {
+	case "", scorev1b1.ExposureModeClusterIP, scorev1b1.ExposureModeNodePort, scorev1b1.ExposureModePortForward:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("mode"), exposure.Mode,
+			[]string{scorev1b1.ExposureModeClusterIP, scorev1b1.ExposureModeNodePort, scorev1b1.ExposureModePortForward}))
+	}
+
 	return allErrs
 }
 
@@ -196,6 +484,41 @@ func (v *Validator) validateTemplate(template *scorev1b1.TemplateSpec, fldPath *
 		allErrs = append(allErrs, field.Required(fldPath.Child("ref"), "ref is required"))
 	}
 
+	// Validate values schema if present
+	if template.ValuesSchema != nil {
+		allErrs = append(allErrs, v.validateValuesSchema(template.ValuesSchema, fldPath.Child("valuesSchema"))...)
+	}
+
+	return allErrs
+}
+
+// validateValuesSchema validates a values schema specification. Inline schemas are compiled so that
+// malformed schemas are rejected with the configuration; referenced schemas are compiled when used.
+func (v *Validator) validateValuesSchema(schema *scorev1b1.ValuesSchemaSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch {
+	case schema.Inline == nil && schema.Ref == nil:
+		allErrs = append(allErrs, field.Required(fldPath, "one of inline or ref is required"))
+	case schema.Inline != nil && schema.Ref != nil:
+		allErrs = append(allErrs, field.Forbidden(fldPath, "inline and ref are mutually exclusive"))
+	case schema.Inline != nil:
+		if _, err := valuesschema.Compile(schema.Inline.Raw); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("inline"), field.OmitValueType{}, err.Error()))
+		}
+	default:
+		refPath := fldPath.Child("ref")
+		if schema.Ref.Namespace == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("namespace"), "namespace is required"))
+		}
+		if schema.Ref.Name == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), "name is required"))
+		}
+		if schema.Ref.Key == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("key"), "key is required"))
+		}
+	}
+
 	return allErrs
 }
 
@@ -203,6 +526,32 @@ func (v *Validator) validateTemplate(template *scorev1b1.TemplateSpec, fldPath *
 func (v *Validator) validateConstraints(constraints *scorev1b1.ConstraintsSpec, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
+	// Validate regions
+	regions := make(map[string]bool)
+	for i, region := range constraints.Regions {
+		regionPath := fldPath.Child("regions").Index(i)
+		if region == "" {
+			allErrs = append(allErrs, field.Required(regionPath, "region must not be empty"))
+			continue
+		}
+		if regions[region] {
+			allErrs = append(allErrs, field.Duplicate(regionPath, region))
+		}
+		regions[region] = true
+	}
+
+	// Validate namespace restrictions
+	for i, pattern := range constraints.Namespaces {
+		if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespaces").Index(i), pattern, "must be a namespace name or glob pattern"))
+		}
+	}
+	if constraints.NamespaceSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(constraints.NamespaceSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespaceSelector"), constraints.NamespaceSelector, err.Error()))
+		}
+	}
+
 	// Validate resource constraints if present
 	if constraints.Resources != nil {
 		allErrs = append(allErrs, v.validateResourceConstraints(constraints.Resources, fldPath.Child("resources"))...)
@@ -215,20 +564,23 @@ func (v *Validator) validateConstraints(constraints *scorev1b1.ConstraintsSpec,
 func (v *Validator) validateResourceConstraints(resources *scorev1b1.ResourceConstraints, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
-	// Basic validation for resource constraint format
-	// Format should be "<quantity>" or "<min>-<max>"
-	constraintPattern := regexp.MustCompile(`^([0-9]+[a-zA-Z]*|[0-9]+[a-zA-Z]*-[0-9]+[a-zA-Z]*|-[0-9]+[a-zA-Z]*|[0-9]+[a-zA-Z]*-)$`)
-
-	if resources.CPU != "" && !constraintPattern.MatchString(resources.CPU) {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("cpu"), resources.CPU, "invalid CPU constraint format"))
-	}
-
-	if resources.Memory != "" && !constraintPattern.MatchString(resources.Memory) {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("memory"), resources.Memory, "invalid memory constraint format"))
-	}
-
-	if resources.Storage != "" && !constraintPattern.MatchString(resources.Storage) {
-		allErrs = append(allErrs, field.Invalid(fldPath.Child("storage"), resources.Storage, "invalid storage constraint format"))
+	// Constraints use the selection grammar: "<quantity>", "<min>-<max>", "<min>-", "-<max>",
+	// or a comparison such as ">=1Gi"
+	for _, constraint := range []struct {
+		name  string
+		value string
+	}{
+		{"cpu", resources.CPU},
+		{"memory", resources.Memory},
+		{"storage", resources.Storage},
+	} {
+		if constraint.value == "" {
+			continue
+		}
+		if _, err := selection.ParseQuantityConstraint(constraint.value); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(constraint.name), constraint.value,
+				fmt.Sprintf("invalid %s constraint: %v", constraint.name, err)))
+		}
 	}
 
 	return allErrs
@@ -260,6 +612,183 @@ func (v *Validator) validateProvisioners(provisioners []scorev1b1.ProvisionerSpe
 
 		// Validate classes
 		allErrs = append(allErrs, v.validateClasses(provisioner.Classes, provisionerPath.Child("classes"))...)
+
+		if provisioner.ParamsSchema != nil {
+			if _, err := valuesschema.Compile(provisioner.ParamsSchema.Raw); err != nil {
+				allErrs = append(allErrs, field.Invalid(provisionerPath.Child("paramsSchema"), field.OmitValueType{}, err.Error()))
+			}
+		}
+
+		if provisioner.Retry != nil {
+			allErrs = append(allErrs, v.validateRetryPolicy(provisioner.Retry, provisionerPath.Child("retry"))...)
+		}
+		if provisioner.ProvisioningTimeout != nil && provisioner.ProvisioningTimeout.Duration <= 0 {
+			allErrs = append(allErrs, field.Invalid(provisionerPath.Child("provisioningTimeout"),
+				provisioner.ProvisioningTimeout.Duration.String(), "must be positive"))
+		}
+		if provisioner.Concurrency != nil {
+			allErrs = append(allErrs, v.validateConcurrencyPolicy(provisioner.Concurrency, provisionerPath.Child("concurrency"))...)
+		}
+
+		if provisioner.Webhook != nil {
+			allErrs = append(allErrs, v.validateWebhookProvisioner(provisioner.Webhook, provisionerPath.Child("webhook"))...)
+		} else if provisioner.Strategy == scorev1b1.ProvisionerStrategyWebhook {
+			allErrs = append(allErrs, field.Required(provisionerPath.Child("webhook"), "webhook is required for the webhook strategy"))
+		}
+		if provisioner.Terraform != nil {
+			allErrs = append(allErrs, v.validateTerraformProvisioner(provisioner.Terraform, provisionerPath.Child("terraform"))...)
+		} else if provisioner.Strategy == scorev1b1.ProvisionerStrategyTerraform {
+			allErrs = append(allErrs, field.Required(provisionerPath.Child("terraform"), "terraform is required for the terraform strategy"))
+		}
+
+		if provisioner.SecretStore != nil {
+			allErrs = append(allErrs, v.validateSecretStore(provisioner.SecretStore, provisionerPath.Child("secretStore"))...)
+		}
+		if provisioner.NetworkPolicy != nil {
+			allErrs = append(allErrs, v.validateIngressPolicy(provisioner.NetworkPolicy, provisionerPath.Child("networkPolicy"))...)
+		}
+	}
+
+	return allErrs
+}
+
+// validateWebhookProvisioner validates the out-of-process provisioner service of a provisioner
+func (v *Validator) validateWebhookProvisioner(webhook *scorev1b1.WebhookProvisionerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if webhook.URL == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("url"), "url is required"))
+	} else if u, err := url.Parse(webhook.URL); err != nil || u.Scheme != "https" || u.Host == "" {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("url"), webhook.URL, "must be an https URL"))
+	}
+	if webhook.CABundle != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(webhook.CABundle)) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("caBundle"), "", "must contain PEM encoded certificates"))
+	}
+	if ref := webhook.ClientCertSecretRef; ref != nil && (ref.Namespace == "" || ref.Name == "") {
+		allErrs = append(allErrs, field.Required(fldPath.Child("clientCertSecretRef"), "namespace and name are required"))
+	}
+	if webhook.Timeout != nil && webhook.Timeout.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("timeout"), webhook.Timeout.Duration.String(), "must be positive"))
+	}
+	if webhook.MaxRetries != nil && *webhook.MaxRetries < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxRetries"), *webhook.MaxRetries, "must not be negative"))
+	}
+
+	return allErrs
+}
+
+// validateTerraformProvisioner validates the module and state backend of a terraform provisioner
+func (v *Validator) validateTerraformProvisioner(terraform *scorev1b1.TerraformProvisionerSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if terraform.Module == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("module"), "module is required"))
+	}
+	if terraform.Image == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("image"), "image is required"))
+	}
+	switch terraform.Binary {
+	case "", scorev1b1.TerraformBinaryTofu, scorev1b1.TerraformBinaryTerraform:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("binary"), terraform.Binary,
+			[]string{scorev1b1.TerraformBinaryTofu, scorev1b1.TerraformBinaryTerraform}))
+	}
+
+	backend := &terraform.Backend
+	backendPath := fldPath.Child("backend")
+	switch backend.Type {
+	case "":
+		allErrs = append(allErrs, field.Required(backendPath.Child("type"), "type is required"))
+	case "local":
+		allErrs = append(allErrs, field.Invalid(backendPath.Child("type"), backend.Type, "state must be stored in a remote backend"))
+	default:
+		keyAttribute := provisioner.TerraformStateKeyAttribute(backend)
+		if keyAttribute == "" {
+			allErrs = append(allErrs, field.Required(backendPath.Child("keyAttribute"),
+				fmt.Sprintf("keyAttribute is required for the %s backend", backend.Type)))
+		} else if _, ok := backend.Config[keyAttribute]; ok {
+			allErrs = append(allErrs, field.Invalid(backendPath.Child("config").Key(keyAttribute), backend.Config[keyAttribute],
+				"is set to the state key of each claim"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateSecretStore validates the external secret store claim credentials are written to
+func (v *Validator) validateSecretStore(store *scorev1b1.SecretStoreSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if store.Name == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), "name is required"))
+	}
+	if (store.Vault == nil) == (store.AWSSecretsManager == nil) {
+		allErrs = append(allErrs, field.Invalid(fldPath, "", "exactly one of vault and awsSecretsManager must be set"))
+	}
+	requireSecretRef := func(ref scorev1b1.NamespacedName, refPath *field.Path) {
+		if ref.Namespace == "" || ref.Name == "" {
+			allErrs = append(allErrs, field.Required(refPath, "namespace and name are required"))
+		}
+	}
+
+	if vault := store.Vault; vault != nil {
+		vaultPath := fldPath.Child("vault")
+		if vault.Address == "" {
+			allErrs = append(allErrs, field.Required(vaultPath.Child("address"), "address is required"))
+		} else if u, err := url.Parse(vault.Address); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			allErrs = append(allErrs, field.Invalid(vaultPath.Child("address"), vault.Address, "must be an http or https URL"))
+		}
+		if vault.CABundle != "" && !x509.NewCertPool().AppendCertsFromPEM([]byte(vault.CABundle)) {
+			allErrs = append(allErrs, field.Invalid(vaultPath.Child("caBundle"), "", "must contain PEM encoded certificates"))
+		}
+		requireSecretRef(vault.TokenSecretRef, vaultPath.Child("tokenSecretRef"))
+	}
+
+	if aws := store.AWSSecretsManager; aws != nil {
+		awsPath := fldPath.Child("awsSecretsManager")
+		if aws.Region == "" {
+			allErrs = append(allErrs, field.Required(awsPath.Child("region"), "region is required"))
+		}
+		if aws.Endpoint != "" {
+			if u, err := url.Parse(aws.Endpoint); err != nil || u.Scheme != "https" || u.Host == "" {
+				allErrs = append(allErrs, field.Invalid(awsPath.Child("endpoint"), aws.Endpoint, "must be an https URL"))
+			}
+		}
+		requireSecretRef(aws.CredentialsSecretRef, awsPath.Child("credentialsSecretRef"))
+	}
+
+	return allErrs
+}
+
+// validateRetryPolicy validates the retry policy of a provisioner
+func (v *Validator) validateRetryPolicy(retry *scorev1b1.RetryPolicy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if retry.InitialBackoff != nil && retry.InitialBackoff.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("initialBackoff"), retry.InitialBackoff.Duration.String(), "must be positive"))
+	}
+	if retry.Multiplier != nil && *retry.Multiplier < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("multiplier"), *retry.Multiplier, "must be at least 1"))
+	}
+	if retry.MaxRetries != nil && *retry.MaxRetries < 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxRetries"), *retry.MaxRetries, "must not be negative"))
+	}
+
+	return allErrs
+}
+
+// validateConcurrencyPolicy validates the concurrency limit of a provisioner
+func (v *Validator) validateConcurrencyPolicy(concurrency *scorev1b1.ConcurrencyPolicy, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if concurrency.MaxConcurrentProvisions < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxConcurrentProvisions"), concurrency.MaxConcurrentProvisions, "must be at least 1"))
+	}
+	switch concurrency.Queue {
+	case "", scorev1b1.ConcurrencyQueueFIFO, scorev1b1.ConcurrencyQueueUnordered:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("queue"), concurrency.Queue,
+			[]string{scorev1b1.ConcurrencyQueueFIFO, scorev1b1.ConcurrencyQueueUnordered}))
 	}
 
 	return allErrs
@@ -302,6 +831,67 @@ func (v *Validator) validateDefaults(defaults *scorev1b1.DefaultsSpec, fldPath *
 		allErrs = append(allErrs, field.Required(fldPath.Child("profile"), "default profile is required"))
 	}
 
+	// Validate reselection policy
+	switch defaults.ReselectionPolicy {
+	case "", scorev1b1.ReselectionPolicySticky, scorev1b1.ReselectionPolicyReselectOnChange:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("reselectionPolicy"), defaults.ReselectionPolicy,
+			[]string{scorev1b1.ReselectionPolicySticky, scorev1b1.ReselectionPolicyReselectOnChange}))
+	}
+
+	// Validate region label key
+	if defaults.RegionLabel != "" {
+		for _, msg := range validation.IsQualifiedName(defaults.RegionLabel) {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("regionLabel"), defaults.RegionLabel, msg))
+		}
+	}
+
+	// Validate pod security defaults
+	if defaults.SecurityContext != nil {
+		allErrs = append(allErrs, v.validateSecurityContext(defaults.SecurityContext, fldPath.Child("securityContext"))...)
+	}
+
+	// Validate the ingress policy of Workload pods
+	if defaults.NetworkPolicy != nil {
+		allErrs = append(allErrs, v.validateIngressPolicy(defaults.NetworkPolicy, fldPath.Child("networkPolicy"))...)
+	}
+
+	// Validate propagation prefixes; an empty prefix would propagate every key
+	if defaults.Propagation != nil {
+		propagationPath := fldPath.Child("propagation")
+		for i, prefix := range defaults.Propagation.Labels {
+			if prefix == "" {
+				allErrs = append(allErrs, field.Required(propagationPath.Child("labels").Index(i), "prefix must not be empty"))
+			}
+		}
+		for i, prefix := range defaults.Propagation.Annotations {
+			if prefix == "" {
+				allErrs = append(allErrs, field.Required(propagationPath.Child("annotations").Index(i), "prefix must not be empty"))
+			}
+		}
+	}
+
+	// Validate the inline size limit of plan values
+	if defaults.PlanValues != nil && defaults.PlanValues.MaxInlineSize != "" {
+		value := defaults.PlanValues.MaxInlineSize
+		sizePath := fldPath.Child("planValues", "maxInlineSize")
+		if q, err := resource.ParseQuantity(value); err != nil {
+			allErrs = append(allErrs, field.Invalid(sizePath, value, fmt.Sprintf("invalid quantity: %v", err)))
+		} else if q.Sign() <= 0 {
+			allErrs = append(allErrs, field.Invalid(sizePath, value, "must be positive"))
+		}
+	}
+
+	// Validate the naming of runtime resources
+	if defaults.Naming != nil {
+		allErrs = append(allErrs, v.validateNaming(defaults.Naming, fldPath.Child("naming"))...)
+	}
+
+	// Validate rollout deadline
+	if defaults.RolloutDeadline != nil && defaults.RolloutDeadline.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("rolloutDeadline"), defaults.RolloutDeadline.Duration.String(), "must be positive"))
+	}
+
 	// Validate selectors
 	for i, selector := range defaults.Selectors {
 		selectorPath := fldPath.Child("selectors").Index(i)
@@ -317,6 +907,362 @@ func (v *Validator) validateDefaults(defaults *scorev1b1.DefaultsSpec, fldPath *
 		}
 	}
 
+	// Validate derivation rules
+	for i, rule := range defaults.DerivationRules {
+		rulePath := fldPath.Child("derivationRules").Index(i)
+
+		if rule.Profile == "" {
+			allErrs = append(allErrs, field.Required(rulePath.Child("profile"), "profile is required"))
+		}
+		for j, port := range rule.Ports {
+			if port < 1 || port > 65535 {
+				allErrs = append(allErrs, field.Invalid(rulePath.Child("ports").Index(j), port, "must be between 1 and 65535"))
+			}
+		}
+		if rule.HasService != nil && !*rule.HasService && len(rule.Ports) > 0 {
+			allErrs = append(allErrs, field.Invalid(rulePath.Child("ports"), rule.Ports, "cannot match Workloads without a service"))
+		}
+		for key := range rule.Annotations {
+			if key == "" {
+				allErrs = append(allErrs, field.Required(rulePath.Child("annotations"), "annotation key must not be empty"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateSecurityContext validates the pod security defaults
+func (v *Validator) validateSecurityContext(securityContext *scorev1b1.SecurityContextSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	switch securityContext.SeccompProfile {
+	case "", scorev1b1.SeccompProfileRuntimeDefault, scorev1b1.SeccompProfileUnconfined:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("seccompProfile"), securityContext.SeccompProfile,
+			[]string{scorev1b1.SeccompProfileRuntimeDefault, scorev1b1.SeccompProfileUnconfined}))
+	}
+
+	for i, capability := range securityContext.DropCapabilities {
+		if capability == "" {
+			allErrs = append(allErrs, field.Required(fldPath.Child("dropCapabilities").Index(i), "capability name is required"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateQuotas validates the quotas section
+func (v *Validator) validateQuotas(quotas []scorev1b1.QuotaSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	quotaNames := make(map[string]bool)
+
+	for i, quota := range quotas {
+		quotaPath := fldPath.Index(i)
+
+		// Validate name
+		if quota.Name == "" {
+			allErrs = append(allErrs, field.Required(quotaPath.Child("name"), "name is required"))
+		} else {
+			if quotaNames[quota.Name] {
+				allErrs = append(allErrs, field.Duplicate(quotaPath.Child("name"), quota.Name))
+			}
+			quotaNames[quota.Name] = true
+		}
+
+		// Validate scope
+		switch quota.Scope {
+		case "", scorev1b1.QuotaScopeNamespace, scorev1b1.QuotaScopeCluster:
+		default:
+			allErrs = append(allErrs, field.NotSupported(quotaPath.Child("scope"), quota.Scope,
+				[]string{scorev1b1.QuotaScopeNamespace, scorev1b1.QuotaScopeCluster}))
+		}
+
+		// Validate selector
+		if quota.Selector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(quota.Selector); err != nil {
+				allErrs = append(allErrs, field.Invalid(quotaPath.Child("selector"), quota.Selector, err.Error()))
+			}
+		}
+
+		// Validate limits
+		if quota.MaxWorkloads != nil && *quota.MaxWorkloads < 0 {
+			allErrs = append(allErrs, field.Invalid(quotaPath.Child("maxWorkloads"), *quota.MaxWorkloads, "must not be negative"))
+		}
+		for resourceType, limit := range quota.MaxClaims {
+			if limit < 0 {
+				allErrs = append(allErrs, field.Invalid(quotaPath.Child("maxClaims").Key(resourceType), limit, "must not be negative"))
+			}
+		}
+		for _, limit := range []struct {
+			name  string
+			value string
+		}{
+			{"cpu", quota.CPU},
+			{"memory", quota.Memory},
+		} {
+			if limit.value == "" {
+				continue
+			}
+			q, err := resource.ParseQuantity(limit.value)
+			if err != nil {
+				allErrs = append(allErrs, field.Invalid(quotaPath.Child(limit.name), limit.value,
+					fmt.Sprintf("invalid %s quantity: %v", limit.name, err)))
+			} else if q.Sign() < 0 {
+				allErrs = append(allErrs, field.Invalid(quotaPath.Child(limit.name), limit.value, "must not be negative"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validatePolicies validates the policies section. Expressions are compiled so that policies that
+// cannot be evaluated are rejected with the configuration.
+func (v *Validator) validatePolicies(policies []scorev1b1.PolicySpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	policyNames := make(map[string]bool)
+
+	for i, p := range policies {
+		policyPath := fldPath.Index(i)
+
+		// Validate name
+		if p.Name == "" {
+			allErrs = append(allErrs, field.Required(policyPath.Child("name"), "name is required"))
+		} else {
+			if policyNames[p.Name] {
+				allErrs = append(allErrs, field.Duplicate(policyPath.Child("name"), p.Name))
+			}
+			policyNames[p.Name] = true
+		}
+
+		// Validate stage and mode
+		switch p.Stage {
+		case "", scorev1b1.PolicyStageAdmission, scorev1b1.PolicyStagePlan:
+		default:
+			allErrs = append(allErrs, field.NotSupported(policyPath.Child("stage"), p.Stage,
+				[]string{scorev1b1.PolicyStageAdmission, scorev1b1.PolicyStagePlan}))
+		}
+		switch p.Mode {
+		case "", scorev1b1.PolicyModeDeny, scorev1b1.PolicyModeWarn:
+		default:
+			allErrs = append(allErrs, field.NotSupported(policyPath.Child("mode"), p.Mode,
+				[]string{scorev1b1.PolicyModeDeny, scorev1b1.PolicyModeWarn}))
+		}
+
+		// Validate expression
+		if p.Expression == "" {
+			allErrs = append(allErrs, field.Required(policyPath.Child("expression"), "expression is required"))
+		} else if _, err := policy.Compile(p.Expression); err != nil {
+			allErrs = append(allErrs, field.Invalid(policyPath.Child("expression"), p.Expression, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// validateTargets validates the remote clusters WorkloadPlans are delivered to
+func (v *Validator) validateTargets(targets []scorev1b1.RuntimeTargetSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	names := make(map[string]bool)
+	for i, target := range targets {
+		targetPath := fldPath.Index(i)
+		if target.Name == "" {
+			allErrs = append(allErrs, field.Required(targetPath.Child("name"), "name is required"))
+		} else {
+			if errs := validation.IsDNS1123Label(target.Name); len(errs) > 0 {
+				allErrs = append(allErrs, field.Invalid(targetPath.Child("name"), target.Name, strings.Join(errs, "; ")))
+			}
+			if names[target.Name] {
+				allErrs = append(allErrs, field.Duplicate(targetPath.Child("name"), target.Name))
+			}
+			names[target.Name] = true
+		}
+		if ref := target.KubeconfigSecretRef; ref.Namespace == "" || ref.Name == "" {
+			allErrs = append(allErrs, field.Required(targetPath.Child("kubeconfigSecretRef"), "namespace and name are required"))
+		}
+	}
+
+	return allErrs
+}
+
+// validateNotifications validates the sinks lifecycle notifications are posted to
+func (v *Validator) validateNotifications(sinks []scorev1b1.NotificationSinkSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	sinkTypes := []string{scorev1b1.NotificationSinkWebhook, scorev1b1.NotificationSinkSlack, scorev1b1.NotificationSinkCloudEvents}
+	notificationTypes := []string{
+		scorev1b1.NotificationWorkloadReady, scorev1b1.NotificationWorkloadDegraded,
+		scorev1b1.NotificationClaimFailed, scorev1b1.NotificationExposurePublished,
+	}
+	names := make(map[string]bool)
+	for i, sink := range sinks {
+		sinkPath := fldPath.Index(i)
+		if sink.Name == "" {
+			allErrs = append(allErrs, field.Required(sinkPath.Child("name"), "name is required"))
+		} else {
+			if errs := validation.IsDNS1123Label(sink.Name); len(errs) > 0 {
+				allErrs = append(allErrs, field.Invalid(sinkPath.Child("name"), sink.Name, strings.Join(errs, "; ")))
+			}
+			if names[sink.Name] {
+				allErrs = append(allErrs, field.Duplicate(sinkPath.Child("name"), sink.Name))
+			}
+			names[sink.Name] = true
+		}
+		if sink.Type != "" && !slices.Contains(sinkTypes, sink.Type) {
+			allErrs = append(allErrs, field.NotSupported(sinkPath.Child("type"), sink.Type, sinkTypes))
+		}
+
+		if (sink.URL == "") == (sink.URLSecretRef == nil) {
+			allErrs = append(allErrs, field.Invalid(sinkPath, "", "exactly one of url and urlSecretRef must be set"))
+		}
+		if sink.URL != "" {
+			if u, err := url.Parse(sink.URL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+				allErrs = append(allErrs, field.Invalid(sinkPath.Child("url"), sink.URL, "must be an http or https URL"))
+			}
+		}
+		if ref := sink.URLSecretRef; ref != nil && (ref.Namespace == "" || ref.Name == "") {
+			allErrs = append(allErrs, field.Required(sinkPath.Child("urlSecretRef"), "namespace and name are required"))
+		}
+
+		for j, event := range sink.Events {
+			if !slices.Contains(notificationTypes, event) {
+				allErrs = append(allErrs, field.NotSupported(sinkPath.Child("events").Index(j), event, notificationTypes))
+			}
+		}
+		severities := []string{scorev1b1.NotificationSeverityInfo, scorev1b1.NotificationSeverityWarning}
+		if sink.MinSeverity != "" && !slices.Contains(severities, sink.MinSeverity) {
+			allErrs = append(allErrs, field.NotSupported(sinkPath.Child("minSeverity"), sink.MinSeverity, severities))
+		}
+	}
+
+	return allErrs
+}
+
+// validateAudit validates the retention of the audit trail
+func (v *Validator) validateAudit(audit *scorev1b1.AuditSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if audit.MaxRecordsPerWorkload != nil && *audit.MaxRecordsPerWorkload < 1 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxRecordsPerWorkload"), *audit.MaxRecordsPerWorkload, "must be at least 1"))
+	}
+	if audit.MaxAge != nil && audit.MaxAge.Duration <= 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("maxAge"), audit.MaxAge.Duration.String(), "must be positive"))
+	}
+
+	return allErrs
+}
+
+// validateSupplyChain validates the verification of template refs
+func (v *Validator) validateSupplyChain(supplyChain *scorev1b1.SupplyChainSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if ref := supplyChain.PullSecretRef; ref != nil && (ref.Namespace == "" || ref.Name == "") {
+		allErrs = append(allErrs, field.Required(fldPath.Child("pullSecretRef"), "namespace and name are required"))
+	}
+	for i, host := range supplyChain.InsecureRegistries {
+		if host == "" || strings.ContainsAny(host, "/ ") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("insecureRegistries").Index(i), host, "must be a registry host"))
+		}
+	}
+
+	names := make(map[string]bool)
+	for i, trustPolicy := range supplyChain.TrustPolicies {
+		policyPath := fldPath.Child("trustPolicies").Index(i)
+		if trustPolicy.Name == "" {
+			allErrs = append(allErrs, field.Required(policyPath.Child("name"), "name is required"))
+		} else if names[trustPolicy.Name] {
+			allErrs = append(allErrs, field.Duplicate(policyPath.Child("name"), trustPolicy.Name))
+		}
+		names[trustPolicy.Name] = true
+
+		if len(trustPolicy.Repositories) == 0 {
+			allErrs = append(allErrs, field.Required(policyPath.Child("repositories"), "at least one repository pattern is required"))
+		}
+		for j, pattern := range trustPolicy.Repositories {
+			if _, err := path.Match(pattern, ""); err != nil || pattern == "" {
+				allErrs = append(allErrs, field.Invalid(policyPath.Child("repositories").Index(j), pattern, "must be a glob pattern"))
+			}
+		}
+		if len(trustPolicy.PublicKeys) == 0 {
+			allErrs = append(allErrs, field.Required(policyPath.Child("publicKeys"), "at least one public key is required"))
+		}
+		for j, key := range trustPolicy.PublicKeys {
+			if _, err := supplychain.ParsePublicKey(key); err != nil {
+				allErrs = append(allErrs, field.Invalid(policyPath.Child("publicKeys").Index(j), "<public key>", err.Error()))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateNamespaces validates the namespaces provisioned for Workload environments.
+// Environment names become the suffix of the namespace names and must therefore be DNS labels.
+func (v *Validator) validateNamespaces(namespaces *scorev1b1.NamespacesSpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if namespaces.EnvironmentLabel != "" {
+		if errs := validation.IsQualifiedName(namespaces.EnvironmentLabel); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("environmentLabel"), namespaces.EnvironmentLabel, strings.Join(errs, "; ")))
+		}
+	}
+	if len(namespaces.Environments) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("environments"), "at least one environment is required"))
+	}
+
+	names := make(map[string]bool)
+	for i, environment := range namespaces.Environments {
+		environmentPath := fldPath.Child("environments").Index(i)
+		if environment.Name == "" {
+			allErrs = append(allErrs, field.Required(environmentPath.Child("name"), "name is required"))
+		} else {
+			if errs := validation.IsDNS1123Label(environment.Name); len(errs) > 0 {
+				allErrs = append(allErrs, field.Invalid(environmentPath.Child("name"), environment.Name, strings.Join(errs, "; ")))
+			}
+			if names[environment.Name] {
+				allErrs = append(allErrs, field.Duplicate(environmentPath.Child("name"), environment.Name))
+			}
+			names[environment.Name] = true
+		}
+
+		allErrs = append(allErrs, validateLabels(environment.Labels, environmentPath.Child("labels"))...)
+
+		if quota := environment.Quota; quota != nil {
+			for _, limit := range []struct {
+				name  string
+				value string
+			}{
+				{"cpu", quota.CPU},
+				{"memory", quota.Memory},
+			} {
+				if limit.value == "" {
+					continue
+				}
+				q, err := resource.ParseQuantity(limit.value)
+				if err != nil {
+					allErrs = append(allErrs, field.Invalid(environmentPath.Child("quota", limit.name), limit.value,
+						fmt.Sprintf("invalid %s quantity: %v", limit.name, err)))
+				} else if q.Sign() < 0 {
+					allErrs = append(allErrs, field.Invalid(environmentPath.Child("quota", limit.name), limit.value, "must not be negative"))
+				}
+			}
+			if quota.Pods != nil && *quota.Pods < 0 {
+				allErrs = append(allErrs, field.Invalid(environmentPath.Child("quota", "pods"), *quota.Pods, "must not be negative"))
+			}
+		}
+
+		switch environment.NetworkPolicy {
+		case "", scorev1b1.NetworkPolicyNone, scorev1b1.NetworkPolicyIsolated:
+		default:
+			allErrs = append(allErrs, field.NotSupported(environmentPath.Child("networkPolicy"), environment.NetworkPolicy,
+				[]string{scorev1b1.NetworkPolicyNone, scorev1b1.NetworkPolicyIsolated}))
+		}
+	}
+
 	return allErrs
 }
 
@@ -342,6 +1288,26 @@ func (v *Validator) validateCrossReferences(config *scorev1b1.OrchestratorConfig
 		}
 	}
 
+	// Validate derivation rule profile references
+	for i, rule := range config.Spec.Defaults.DerivationRules {
+		if rule.Profile != "" && !profileNames[rule.Profile] {
+			allErrs = append(allErrs, field.NotFound(field.NewPath("spec", "defaults", "derivationRules").Index(i).Child("profile"), rule.Profile))
+		}
+	}
+
+	// Validate backend target references
+	targetNames := make(map[string]bool)
+	for _, target := range config.Spec.Targets {
+		targetNames[target.Name] = true
+	}
+	for i, profile := range config.Spec.Profiles {
+		for j, backend := range profile.Backends {
+			if backend.Target != "" && !targetNames[backend.Target] {
+				allErrs = append(allErrs, field.NotFound(field.NewPath("spec", "profiles").Index(i).Child("backends").Index(j).Child("target"), backend.Target))
+			}
+		}
+	}
+
 	// Validate provisioner class references
 	for i, provisioner := range config.Spec.Provisioners {
 		if provisioner.Defaults != nil && provisioner.Defaults.Class != "" {
@@ -361,3 +1327,69 @@ func (v *Validator) validateCrossReferences(config *scorev1b1.OrchestratorConfig
 
 	return allErrs
 }
+
+// maxNamingAffixLength bounds the prefix and suffix of runtime resource names together, leaving the
+// Workload name most of the 63 characters of a DNS label
+const maxNamingAffixLength = 20
+
+// validateNaming validates the prefix, suffix and conflict policy of runtime resource names.
+// Materialized names must remain DNS labels, so the prefix and suffix leave room for the Workload name.
+func (v *Validator) validateNaming(naming *scorev1b1.NamingSpec, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	affixes := []struct {
+		name  string
+		value string
+	}{{"prefix", naming.Prefix}, {"suffix", naming.Suffix}}
+	for _, affix := range affixes {
+		if affix.value == "" {
+			continue
+		}
+		// Wrapped around a Workload name, the affix must still form a DNS label
+		for _, msg := range validation.IsDNS1123Label("a" + affix.value + "a") {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child(affix.name), affix.value, msg))
+		}
+	}
+	if length := len(naming.Prefix) + len(naming.Suffix); length > maxNamingAffixLength {
+		allErrs = append(allErrs, field.TooLong(fldPath, length, maxNamingAffixLength))
+	}
+
+	switch naming.ConflictPolicy {
+	case "", scorev1b1.ConflictPolicyFail, scorev1b1.ConflictPolicyAdopt:
+	default:
+		allErrs = append(allErrs, field.NotSupported(fldPath.Child("conflictPolicy"), naming.ConflictPolicy,
+			[]scorev1b1.ConflictPolicy{scorev1b1.ConflictPolicyFail, scorev1b1.ConflictPolicyAdopt}))
+	}
+
+	return allErrs
+}
+
+// validateIngressPolicy validates the peers admitted by a generated NetworkPolicy
+func (v *Validator) validateIngressPolicy(policy *scorev1b1.IngressPolicySpec, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, peer := range policy.AllowFrom {
+		peerPath := fldPath.Child("allowFrom").Index(i)
+		if len(peer.PodLabels) == 0 && len(peer.NamespaceLabels) == 0 {
+			allErrs = append(allErrs, field.Required(peerPath, "peer must specify podLabels or namespaceLabels"))
+		}
+		allErrs = append(allErrs, validateLabels(peer.PodLabels, peerPath.Child("podLabels"))...)
+		allErrs = append(allErrs, validateLabels(peer.NamespaceLabels, peerPath.Child("namespaceLabels"))...)
+	}
+
+	return allErrs
+}
+
+// validateLabels validates the keys and values of a label map
+func validateLabels(labels map[string]string, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+	for key, value := range labels {
+		if errs := validation.IsQualifiedName(key); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), key, strings.Join(errs, "; ")))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Key(key), value, strings.Join(errs, "; ")))
+		}
+	}
+	return allErrs
+}
@@ -17,7 +17,19 @@ limitations under the License.
 package config
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/ptr"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
 )
@@ -387,6 +399,15 @@ func TestValidator_ValidateResourceConstraints(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid fractional and comparison constraints",
+			constraints: &scorev1b1.ResourceConstraints{
+				CPU:     "0.5-2",
+				Memory:  ">=1.5Gi",
+				Storage: "<500Gi",
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalid CPU format",
 			constraints: &scorev1b1.ResourceConstraints{
@@ -394,6 +415,13 @@ func TestValidator_ValidateResourceConstraints(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "inverted storage range",
+			constraints: &scorev1b1.ResourceConstraints{
+				Storage: "100Gi-1Gi",
+			},
+			wantErr: true,
+		},
 		{
 			name: "invalid memory format",
 			constraints: &scorev1b1.ResourceConstraints{
@@ -415,3 +443,1122 @@ func TestValidator_ValidateResourceConstraints(t *testing.T) {
 		})
 	}
 }
+
+func TestValidator_ValidateNamespaceConstraints(t *testing.T) {
+	tests := []struct {
+		name        string
+		constraints *scorev1b1.ConstraintsSpec
+		wantErr     bool
+	}{
+		{
+			name: "names, patterns and selector",
+			constraints: &scorev1b1.ConstraintsSpec{
+				Namespaces:        []string{"ml-*", "research"},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "ml"}},
+			},
+		},
+		{
+			name:        "empty namespace",
+			constraints: &scorev1b1.ConstraintsSpec{Namespaces: []string{""}},
+			wantErr:     true,
+		},
+		{
+			name:        "malformed pattern",
+			constraints: &scorev1b1.ConstraintsSpec{Namespaces: []string{"ml-["}},
+			wantErr:     true,
+		},
+		{
+			name: "invalid selector",
+			constraints: &scorev1b1.ConstraintsSpec{
+				NamespaceSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "tenant", Operator: "Matches"},
+				}},
+			},
+			wantErr: true,
+		},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.validateConstraints(tt.constraints, nil)
+			if hasErr := len(errs) > 0; hasErr != tt.wantErr {
+				t.Errorf("validateConstraints() error = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateExposure(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		wantErr bool
+	}{
+		{"empty mode defaults to ClusterIP", "", false},
+		{"ClusterIP mode", scorev1b1.ExposureModeClusterIP, false},
+		{"NodePort mode", scorev1b1.ExposureModeNodePort, false},
+		{"PortForward mode", scorev1b1.ExposureModePortForward, false},
+		{"unsupported mode", "LoadBalancer", true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.validateExposure(&scorev1b1.ExposureSpec{Mode: tt.mode}, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateExposure() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateValuesFrom(t *testing.T) {
+	tests := []struct {
+		name       string
+		valuesFrom []scorev1b1.ValuesFromSpec
+		wantErr    bool
+	}{
+		{"no mappings", nil, false},
+		{"valid mappings", []scorev1b1.ValuesFromSpec{
+			{Claim: "db", Output: "uri", Path: ".db.connectionString"},
+			{Claim: "cache", Output: "host", Path: ".cache.host"},
+		}, false},
+		{"missing claim", []scorev1b1.ValuesFromSpec{{Output: "uri", Path: ".db.url"}}, true},
+		{"missing output", []scorev1b1.ValuesFromSpec{{Claim: "db", Path: ".db.url"}}, true},
+		{"missing path", []scorev1b1.ValuesFromSpec{{Claim: "db", Output: "uri"}}, true},
+		{"path without leading dot", []scorev1b1.ValuesFromSpec{{Claim: "db", Output: "uri", Path: "db.url"}}, true},
+		{"path with empty key", []scorev1b1.ValuesFromSpec{{Claim: "db", Output: "uri", Path: ".db..url"}}, true},
+		{"duplicate path", []scorev1b1.ValuesFromSpec{
+			{Claim: "db", Output: "uri", Path: ".db.url"},
+			{Claim: "replica", Output: "uri", Path: ".db.url"},
+		}, true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.validateValuesFrom(tt.valuesFrom, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateValuesFrom() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateValuesSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  scorev1b1.ValuesSchemaSpec
+		wantErr bool
+	}{
+		{"inline schema", scorev1b1.ValuesSchemaSpec{
+			Inline: &runtime.RawExtension{Raw: []byte(`{"type":"object","properties":{"replicas":{"type":"integer"}}}`)},
+		}, false},
+		{"configmap ref", scorev1b1.ValuesSchemaSpec{
+			Ref: &scorev1b1.ValuesSchemaRef{Namespace: "score-system", Name: "schemas", Key: "web.json"},
+		}, false},
+		{"neither inline nor ref", scorev1b1.ValuesSchemaSpec{}, true},
+		{"both inline and ref", scorev1b1.ValuesSchemaSpec{
+			Inline: &runtime.RawExtension{Raw: []byte(`{}`)},
+			Ref:    &scorev1b1.ValuesSchemaRef{Namespace: "score-system", Name: "schemas", Key: "web.json"},
+		}, true},
+		{"malformed inline schema", scorev1b1.ValuesSchemaSpec{
+			Inline: &runtime.RawExtension{Raw: []byte(`{"type":"text"}`)},
+		}, true},
+		{"ref without key", scorev1b1.ValuesSchemaSpec{
+			Ref: &scorev1b1.ValuesSchemaRef{Namespace: "score-system", Name: "schemas"},
+		}, true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.validateValuesSchema(&tt.schema, field.NewPath("valuesSchema"))
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateValuesSchema() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateProfileKind(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    string
+		wantErr bool
+	}{
+		{"empty kind defaults to Service", "", false},
+		{"Service kind", scorev1b1.WorkloadKindService, false},
+		{"Job kind", scorev1b1.WorkloadKindJob, false},
+		{"CronJob kind", scorev1b1.WorkloadKindCronJob, false},
+		{"unsupported kind", "DaemonSet", true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profiles := []scorev1b1.ProfileSpec{{Name: "batch-job", Kind: tt.kind}}
+			errs := validator.validateProfiles(profiles, field.NewPath("profiles"))
+			hasKindErr := false
+			for _, err := range errs {
+				if err.Field == "profiles[0].kind" {
+					hasKindErr = true
+				}
+			}
+			if hasKindErr != tt.wantErr {
+				t.Errorf("validateProfiles() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateDefaultsReselectionPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  string
+		wantErr bool
+	}{
+		{"empty policy defaults to sticky", "", false},
+		{"sticky policy", scorev1b1.ReselectionPolicySticky, false},
+		{"reselect-on-change policy", scorev1b1.ReselectionPolicyReselectOnChange, false},
+		{"unsupported policy", "always", true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaults := &scorev1b1.DefaultsSpec{Profile: "web-service", ReselectionPolicy: tt.policy}
+			errs := validator.validateDefaults(defaults, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateDefaults() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateDerivationRules(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    scorev1b1.DerivationRuleSpec
+		wantErr bool
+	}{
+		{"rule without predicates", scorev1b1.DerivationRuleSpec{Profile: "web-service"}, false},
+		{"all predicates", scorev1b1.DerivationRuleSpec{
+			Profile: "web-service", HasService: ptr.To(true), Ports: []int32{80}, Annotations: map[string]string{"tier": "web"},
+		}, false},
+		{"missing profile", scorev1b1.DerivationRuleSpec{HasService: ptr.To(true)}, true},
+		{"invalid port", scorev1b1.DerivationRuleSpec{Profile: "web-service", Ports: []int32{0}}, true},
+		{"ports without service", scorev1b1.DerivationRuleSpec{Profile: "web-service", HasService: ptr.To(false), Ports: []int32{80}}, true},
+		{"empty annotation key", scorev1b1.DerivationRuleSpec{Profile: "web-service", Annotations: map[string]string{"": "web"}}, true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaults := &scorev1b1.DefaultsSpec{Profile: "web-service", DerivationRules: []scorev1b1.DerivationRuleSpec{tt.rule}}
+			errs := validator.validateDefaults(defaults, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateDefaults() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateDefaultsSecurityContext(t *testing.T) {
+	tests := []struct {
+		name            string
+		securityContext *scorev1b1.SecurityContextSpec
+		wantErr         bool
+	}{
+		{"no security context", nil, false},
+		{"empty security context uses restricted defaults", &scorev1b1.SecurityContextSpec{}, false},
+		{"RuntimeDefault seccomp profile", &scorev1b1.SecurityContextSpec{SeccompProfile: scorev1b1.SeccompProfileRuntimeDefault}, false},
+		{"Unconfined seccomp profile", &scorev1b1.SecurityContextSpec{SeccompProfile: scorev1b1.SeccompProfileUnconfined}, false},
+		{"unsupported seccomp profile", &scorev1b1.SecurityContextSpec{SeccompProfile: "Localhost"}, true},
+		{"dropped capabilities", &scorev1b1.SecurityContextSpec{DropCapabilities: []string{"NET_RAW", "SYS_ADMIN"}}, false},
+		{"empty capability name", &scorev1b1.SecurityContextSpec{DropCapabilities: []string{""}}, true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaults := &scorev1b1.DefaultsSpec{Profile: "web-service", SecurityContext: tt.securityContext}
+			errs := validator.validateDefaults(defaults, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateDefaults() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateProvisionerRetry(t *testing.T) {
+	tests := []struct {
+		name    string
+		retry   *scorev1b1.RetryPolicy
+		wantErr bool
+	}{
+		{"no retry policy", nil, false},
+		{"empty retry policy uses defaults", &scorev1b1.RetryPolicy{}, false},
+		{
+			"full retry policy",
+			&scorev1b1.RetryPolicy{InitialBackoff: &metav1.Duration{Duration: 30 * time.Second}, Multiplier: ptr.To(1.5), MaxRetries: ptr.To[int32](3)},
+			false,
+		},
+		{"no retries", &scorev1b1.RetryPolicy{MaxRetries: ptr.To[int32](0)}, false},
+		{"zero initial backoff", &scorev1b1.RetryPolicy{InitialBackoff: &metav1.Duration{}}, true},
+		{"shrinking multiplier", &scorev1b1.RetryPolicy{Multiplier: ptr.To(0.5)}, true},
+		{"negative max retries", &scorev1b1.RetryPolicy{MaxRetries: ptr.To[int32](-1)}, true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provisioners := []scorev1b1.ProvisionerSpec{{Type: "postgres", Provisioner: "postgres-operator", Retry: tt.retry}}
+			errs := validator.validateProvisioners(provisioners, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateProvisioners() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateProvisionerConcurrency(t *testing.T) {
+	tests := []struct {
+		name        string
+		concurrency *scorev1b1.ConcurrencyPolicy
+		wantErr     bool
+	}{
+		{"no concurrency limit", nil, false},
+		{"limit with the default queue", &scorev1b1.ConcurrencyPolicy{MaxConcurrentProvisions: 3}, false},
+		{"unordered queue", &scorev1b1.ConcurrencyPolicy{MaxConcurrentProvisions: 1, Queue: scorev1b1.ConcurrencyQueueUnordered}, false},
+		{"zero limit", &scorev1b1.ConcurrencyPolicy{}, true},
+		{"unknown queue", &scorev1b1.ConcurrencyPolicy{MaxConcurrentProvisions: 1, Queue: "lifo"}, true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provisioners := []scorev1b1.ProvisionerSpec{{Type: "postgres", Provisioner: "postgres-operator", Concurrency: tt.concurrency}}
+			errs := validator.validateProvisioners(provisioners, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateProvisioners() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateParamsSchema(t *testing.T) {
+	tests := []struct {
+		name    string
+		schema  string
+		wantErr bool
+	}{
+		{"object schema", `{"type":"object","properties":{"size":{"type":"string"}},"additionalProperties":false}`, false},
+		{"unknown type", `{"properties":{"size":{"type":"text"}}}`, true},
+		{"references", `{"$ref":"#/definitions/params"}`, true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provisioners := []scorev1b1.ProvisionerSpec{{
+				Type: "volume", Provisioner: "score-orchestrator", ParamsSchema: &runtime.RawExtension{Raw: []byte(tt.schema)},
+			}}
+			errs := validator.validateProvisioners(provisioners, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateProvisioners() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateWebhookProvisioner(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy string
+		webhook  *scorev1b1.WebhookProvisionerSpec
+		wantErr  bool
+	}{
+		{
+			name:     "valid webhook",
+			strategy: scorev1b1.ProvisionerStrategyWebhook,
+			webhook: &scorev1b1.WebhookProvisionerSpec{
+				URL:                 "https://provisioner.example.com",
+				ClientCertSecretRef: &scorev1b1.NamespacedName{Namespace: "score-system", Name: "provisioner-client"},
+				Timeout:             &metav1.Duration{Duration: 10 * time.Second},
+				MaxRetries:          ptr.To[int32](0),
+			},
+		},
+		{name: "webhook strategy without webhook", strategy: scorev1b1.ProvisionerStrategyWebhook, wantErr: true},
+		{name: "missing url", webhook: &scorev1b1.WebhookProvisionerSpec{}, wantErr: true},
+		{name: "plain http url", webhook: &scorev1b1.WebhookProvisionerSpec{URL: "http://provisioner.example.com"}, wantErr: true},
+		{name: "invalid ca bundle", webhook: &scorev1b1.WebhookProvisionerSpec{URL: "https://p.example.com", CABundle: "not a certificate"}, wantErr: true},
+		{
+			name:    "incomplete client certificate reference",
+			webhook: &scorev1b1.WebhookProvisionerSpec{URL: "https://p.example.com", ClientCertSecretRef: &scorev1b1.NamespacedName{Name: "client"}},
+			wantErr: true,
+		},
+		{name: "zero timeout", webhook: &scorev1b1.WebhookProvisionerSpec{URL: "https://p.example.com", Timeout: &metav1.Duration{}}, wantErr: true},
+		{name: "negative max retries", webhook: &scorev1b1.WebhookProvisionerSpec{URL: "https://p.example.com", MaxRetries: ptr.To[int32](-1)}, wantErr: true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provisioners := []scorev1b1.ProvisionerSpec{{Type: "mysql", Provisioner: "dba-service", Strategy: tt.strategy, Webhook: tt.webhook}}
+			errs := validator.validateProvisioners(provisioners, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateProvisioners() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateTerraformProvisioner(t *testing.T) {
+	terraform := func(backend scorev1b1.TerraformBackendSpec) *scorev1b1.TerraformProvisionerSpec {
+		return &scorev1b1.TerraformProvisionerSpec{
+			Module:  "git::https://example.com/modules.git//postgres?ref=v1.2.0",
+			Image:   "registry.example.com/tofu-runner:1.8",
+			Backend: backend,
+		}
+	}
+	s3 := scorev1b1.TerraformBackendSpec{Type: "s3", Config: map[string]string{"bucket": "state", "region": "us-east-1"}}
+
+	tests := []struct {
+		name      string
+		strategy  string
+		terraform *scorev1b1.TerraformProvisionerSpec
+		wantErr   bool
+	}{
+		{name: "valid terraform", strategy: scorev1b1.ProvisionerStrategyTerraform, terraform: terraform(s3)},
+		{
+			name:      "custom backend with key attribute",
+			terraform: terraform(scorev1b1.TerraformBackendSpec{Type: "http", KeyAttribute: "address"}),
+		},
+		{name: "terraform strategy without terraform", strategy: scorev1b1.ProvisionerStrategyTerraform, wantErr: true},
+		{name: "missing module", terraform: &scorev1b1.TerraformProvisionerSpec{Image: "runner:1", Backend: s3}, wantErr: true},
+		{name: "missing image", terraform: &scorev1b1.TerraformProvisionerSpec{Module: "./postgres", Backend: s3}, wantErr: true},
+		{
+			name:      "unsupported binary",
+			terraform: &scorev1b1.TerraformProvisionerSpec{Module: "./postgres", Image: "runner:1", Binary: "pulumi", Backend: s3},
+			wantErr:   true,
+		},
+		{name: "missing backend type", terraform: terraform(scorev1b1.TerraformBackendSpec{}), wantErr: true},
+		{name: "local backend", terraform: terraform(scorev1b1.TerraformBackendSpec{Type: "local"}), wantErr: true},
+		{name: "custom backend without key attribute", terraform: terraform(scorev1b1.TerraformBackendSpec{Type: "http"}), wantErr: true},
+		{
+			name:      "state key set in config",
+			terraform: terraform(scorev1b1.TerraformBackendSpec{Type: "gcs", Config: map[string]string{"bucket": "state", "prefix": "shared"}}),
+			wantErr:   true,
+		},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provisioners := []scorev1b1.ProvisionerSpec{{Type: "postgres", Provisioner: "tofu", Strategy: tt.strategy, Terraform: tt.terraform}}
+			errs := validator.validateProvisioners(provisioners, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateProvisioners() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateSecretStore(t *testing.T) {
+	tokenRef := scorev1b1.NamespacedName{Namespace: "score-system", Name: "vault-token"}
+	vault := func(address string) *scorev1b1.VaultSecretStoreSpec {
+		return &scorev1b1.VaultSecretStoreSpec{Address: address, TokenSecretRef: tokenRef}
+	}
+	aws := &scorev1b1.AWSSecretsManagerSpec{
+		Region:               "us-east-1",
+		CredentialsSecretRef: scorev1b1.NamespacedName{Namespace: "score-system", Name: "aws-credentials"},
+	}
+
+	tests := []struct {
+		name    string
+		store   *scorev1b1.SecretStoreSpec
+		wantErr bool
+	}{
+		{name: "vault", store: &scorev1b1.SecretStoreSpec{Name: "vault", Vault: vault("https://vault.example.com:8200")}},
+		{name: "aws secrets manager", store: &scorev1b1.SecretStoreSpec{Name: "aws", AWSSecretsManager: aws}},
+		{name: "missing name", store: &scorev1b1.SecretStoreSpec{Vault: vault("https://vault.example.com")}, wantErr: true},
+		{name: "no backend", store: &scorev1b1.SecretStoreSpec{Name: "vault"}, wantErr: true},
+		{name: "both backends", store: &scorev1b1.SecretStoreSpec{Name: "vault", Vault: vault("https://vault.example.com"), AWSSecretsManager: aws}, wantErr: true},
+		{name: "invalid vault address", store: &scorev1b1.SecretStoreSpec{Name: "vault", Vault: vault("vault:8200")}, wantErr: true},
+		{
+			name:    "missing vault token",
+			store:   &scorev1b1.SecretStoreSpec{Name: "vault", Vault: &scorev1b1.VaultSecretStoreSpec{Address: "https://vault.example.com"}},
+			wantErr: true,
+		},
+		{
+			name: "plain http aws endpoint",
+			store: &scorev1b1.SecretStoreSpec{Name: "aws", AWSSecretsManager: &scorev1b1.AWSSecretsManagerSpec{
+				Region: "us-east-1", Endpoint: "http://localhost:4566", CredentialsSecretRef: aws.CredentialsSecretRef,
+			}},
+			wantErr: true,
+		},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provisioners := []scorev1b1.ProvisionerSpec{{Type: "postgres", Provisioner: "dba-service", SecretStore: tt.store}}
+			errs := validator.validateProvisioners(provisioners, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateProvisioners() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateTimeouts(t *testing.T) {
+	tests := []struct {
+		name                string
+		provisioningTimeout *metav1.Duration
+		rolloutDeadline     *metav1.Duration
+		wantErr             bool
+	}{
+		{"defaults", nil, nil, false},
+		{"configured timeouts", &metav1.Duration{Duration: 15 * time.Minute}, &metav1.Duration{Duration: 5 * time.Minute}, false},
+		{"zero provisioning timeout", &metav1.Duration{}, nil, true},
+		{"negative rollout deadline", nil, &metav1.Duration{Duration: -time.Minute}, true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provisioners := []scorev1b1.ProvisionerSpec{{Type: "postgres", Provisioner: "postgres-operator", ProvisioningTimeout: tt.provisioningTimeout}}
+			defaults := &scorev1b1.DefaultsSpec{Profile: "web-service", RolloutDeadline: tt.rolloutDeadline}
+			errs := append(validator.validateProvisioners(provisioners, nil), validator.validateDefaults(defaults, nil)...)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validation errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidatePlanValues(t *testing.T) {
+	tests := []struct {
+		name          string
+		maxInlineSize string
+		wantErr       bool
+	}{
+		{"default", "", false},
+		{"quantity", "512Ki", false},
+		{"zero", "0", true},
+		{"malformed", "large", true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaults := &scorev1b1.DefaultsSpec{
+				Profile:    "web-service",
+				PlanValues: &scorev1b1.PlanValuesSpec{MaxInlineSize: tt.maxInlineSize},
+			}
+			errs := validator.validateDefaults(defaults, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateDefaults() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateNaming(t *testing.T) {
+	tests := []struct {
+		name    string
+		naming  scorev1b1.NamingSpec
+		wantErr bool
+	}{
+		{"default", scorev1b1.NamingSpec{}, false},
+		{"prefix and suffix", scorev1b1.NamingSpec{Prefix: "score-", Suffix: "-app", ConflictPolicy: scorev1b1.ConflictPolicyAdopt}, false},
+		{"uppercase prefix", scorev1b1.NamingSpec{Prefix: "Score-"}, true},
+		{"dotted suffix", scorev1b1.NamingSpec{Suffix: ".app"}, true},
+		{"too long", scorev1b1.NamingSpec{Prefix: "platform-team-", Suffix: "-workload"}, true},
+		{"unknown policy", scorev1b1.NamingSpec{ConflictPolicy: "Replace"}, true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defaults := &scorev1b1.DefaultsSpec{Profile: "web-service", Naming: &tt.naming}
+			errs := validator.validateDefaults(defaults, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateDefaults() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateWorkloadFragment(t *testing.T) {
+	tests := []struct {
+		name     string
+		fragment *scorev1b1.WorkloadFragmentSpec
+		wantErr  bool
+	}{
+		{
+			name: "valid fragment",
+			fragment: &scorev1b1.WorkloadFragmentSpec{
+				Containers: map[string]scorev1b1.ContainerSpec{"proxy": {Image: "envoy:v1"}},
+				Variables:  map[string]string{"LOG_LEVEL": "info"},
+				Resources:  &scorev1b1.ResourceRequirements{Requests: map[string]string{"cpu": "100m"}},
+			},
+		},
+		{
+			name: "container without image",
+			fragment: &scorev1b1.WorkloadFragmentSpec{
+				Containers: map[string]scorev1b1.ContainerSpec{"proxy": {}},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "reserved variable",
+			fragment: &scorev1b1.WorkloadFragmentSpec{Variables: map[string]string{"SCORE_WORKLOAD_NAME": "x"}},
+			wantErr:  true,
+		},
+		{
+			name: "reserved container variable",
+			fragment: &scorev1b1.WorkloadFragmentSpec{
+				Containers: map[string]scorev1b1.ContainerSpec{
+					"proxy": {Image: "envoy:v1", Variables: map[string]string{"SCORE_ENDPOINT": "x"}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "request above limit",
+			fragment: &scorev1b1.WorkloadFragmentSpec{
+				Resources: &scorev1b1.ResourceRequirements{
+					Requests: map[string]string{"memory": "1Gi"},
+					Limits:   map[string]string{"memory": "512Mi"},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.validateWorkloadFragment(tt.fragment, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateWorkloadFragment() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateQuotas(t *testing.T) {
+	maxWorkloads := int32(10)
+	negative := int32(-1)
+
+	tests := []struct {
+		name    string
+		quotas  []scorev1b1.QuotaSpec
+		wantErr bool
+	}{
+		{
+			name: "valid namespace quota",
+			quotas: []scorev1b1.QuotaSpec{{
+				Name:         "per-namespace",
+				MaxWorkloads: &maxWorkloads,
+				MaxClaims:    map[string]int32{"postgres": 2},
+				CPU:          "8",
+				Memory:       "16Gi",
+			}},
+		},
+		{
+			name: "valid team quota",
+			quotas: []scorev1b1.QuotaSpec{{
+				Name:     "team-a",
+				Scope:    scorev1b1.QuotaScopeCluster,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+				CPU:      "500m",
+			}},
+		},
+		{
+			name:    "missing name",
+			quotas:  []scorev1b1.QuotaSpec{{MaxWorkloads: &maxWorkloads}},
+			wantErr: true,
+		},
+		{
+			name:    "duplicate name",
+			quotas:  []scorev1b1.QuotaSpec{{Name: "q"}, {Name: "q"}},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported scope",
+			quotas:  []scorev1b1.QuotaSpec{{Name: "q", Scope: "team"}},
+			wantErr: true,
+		},
+		{
+			name:    "negative max workloads",
+			quotas:  []scorev1b1.QuotaSpec{{Name: "q", MaxWorkloads: &negative}},
+			wantErr: true,
+		},
+		{
+			name:    "negative max claims",
+			quotas:  []scorev1b1.QuotaSpec{{Name: "q", MaxClaims: map[string]int32{"redis": -1}}},
+			wantErr: true,
+		},
+		{
+			name:    "invalid memory quantity",
+			quotas:  []scorev1b1.QuotaSpec{{Name: "q", Memory: "lots"}},
+			wantErr: true,
+		},
+		{
+			name: "invalid selector",
+			quotas: []scorev1b1.QuotaSpec{{
+				Name: "q",
+				Selector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "team", Operator: "Near"},
+				}},
+			}},
+			wantErr: true,
+		},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.validateQuotas(tt.quotas, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateQuotas() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidatePolicies(t *testing.T) {
+	tests := []struct {
+		name     string
+		policies []scorev1b1.PolicySpec
+		wantErr  bool
+	}{
+		{
+			name: "valid policies",
+			policies: []scorev1b1.PolicySpec{
+				{Name: "owner", Mode: scorev1b1.PolicyModeWarn, Expression: `"owner" in workload.metadata.labels`},
+				{Name: "prod-backends", Stage: scorev1b1.PolicyStagePlan, Expression: `profile != "prod" || backend.runtimeClass == "kubernetes"`},
+			},
+		},
+		{
+			name:     "missing name",
+			policies: []scorev1b1.PolicySpec{{Expression: "true"}},
+			wantErr:  true,
+		},
+		{
+			name:     "duplicate name",
+			policies: []scorev1b1.PolicySpec{{Name: "p", Expression: "true"}, {Name: "p", Expression: "true"}},
+			wantErr:  true,
+		},
+		{
+			name:     "unsupported stage",
+			policies: []scorev1b1.PolicySpec{{Name: "p", Stage: "Runtime", Expression: "true"}},
+			wantErr:  true,
+		},
+		{
+			name:     "unsupported mode",
+			policies: []scorev1b1.PolicySpec{{Name: "p", Mode: "Audit", Expression: "true"}},
+			wantErr:  true,
+		},
+		{
+			name:     "missing expression",
+			policies: []scorev1b1.PolicySpec{{Name: "p"}},
+			wantErr:  true,
+		},
+		{
+			name:     "syntax error",
+			policies: []scorev1b1.PolicySpec{{Name: "p", Expression: "workload.spec.("}},
+			wantErr:  true,
+		},
+		{
+			name:     "non-boolean expression",
+			policies: []scorev1b1.PolicySpec{{Name: "p", Expression: "profile"}},
+			wantErr:  true,
+		},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.validatePolicies(tt.policies, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validatePolicies() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateTargets(t *testing.T) {
+	kubeconfigRef := scorev1b1.NamespacedName{Namespace: "score-system", Name: "eu-west-kubeconfig"}
+
+	tests := []struct {
+		name          string
+		targets       []scorev1b1.RuntimeTargetSpec
+		backendTarget string
+		wantErr       bool
+	}{
+		{name: "no targets"},
+		{name: "backend delivered to a target", targets: []scorev1b1.RuntimeTargetSpec{{Name: "eu-west", KubeconfigSecretRef: kubeconfigRef}}, backendTarget: "eu-west"},
+		{name: "missing name", targets: []scorev1b1.RuntimeTargetSpec{{KubeconfigSecretRef: kubeconfigRef}}, wantErr: true},
+		{name: "invalid name", targets: []scorev1b1.RuntimeTargetSpec{{Name: "EU_West", KubeconfigSecretRef: kubeconfigRef}}, wantErr: true},
+		{
+			name: "duplicate name",
+			targets: []scorev1b1.RuntimeTargetSpec{
+				{Name: "eu-west", KubeconfigSecretRef: kubeconfigRef},
+				{Name: "eu-west", KubeconfigSecretRef: kubeconfigRef},
+			},
+			wantErr: true,
+		},
+		{name: "missing kubeconfig secret", targets: []scorev1b1.RuntimeTargetSpec{{Name: "eu-west"}}, wantErr: true},
+		{name: "unknown backend target", backendTarget: "eu-west", wantErr: true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &scorev1b1.OrchestratorConfig{Spec: scorev1b1.OrchestratorConfigSpec{
+				Profiles: []scorev1b1.ProfileSpec{{Name: "web-service", Backends: []scorev1b1.BackendSpec{{
+					BackendId: "k8s-web", RuntimeClass: "kubernetes", Target: tt.backendTarget,
+				}}}},
+				Targets: tt.targets,
+			}}
+			errs := validator.validateTargets(config.Spec.Targets, nil)
+			errs = append(errs, validator.validateCrossReferences(config)...)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateTargets() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateNotifications(t *testing.T) {
+	secretRef := &scorev1b1.NamespacedName{Namespace: "score-system", Name: "slack-webhook"}
+
+	tests := []struct {
+		name    string
+		sinks   []scorev1b1.NotificationSinkSpec
+		wantErr bool
+	}{
+		{name: "no sinks"},
+		{name: "webhook", sinks: []scorev1b1.NotificationSinkSpec{{Name: "audit", URL: "https://audit.example.com/events"}}},
+		{
+			name: "filtered slack sink",
+			sinks: []scorev1b1.NotificationSinkSpec{{
+				Name: "alerts", Type: scorev1b1.NotificationSinkSlack, URLSecretRef: secretRef,
+				Events:      []string{scorev1b1.NotificationWorkloadDegraded, scorev1b1.NotificationClaimFailed},
+				Namespaces:  []string{"team-a"},
+				MinSeverity: scorev1b1.NotificationSeverityWarning,
+			}},
+		},
+		{name: "missing name", sinks: []scorev1b1.NotificationSinkSpec{{URL: "https://audit.example.com"}}, wantErr: true},
+		{
+			name: "duplicate name",
+			sinks: []scorev1b1.NotificationSinkSpec{
+				{Name: "audit", URL: "https://audit.example.com"},
+				{Name: "audit", URL: "https://audit.example.com"},
+			},
+			wantErr: true,
+		},
+		{name: "unknown type", sinks: []scorev1b1.NotificationSinkSpec{{Name: "audit", Type: "Email", URL: "https://audit.example.com"}}, wantErr: true},
+		{name: "no url", sinks: []scorev1b1.NotificationSinkSpec{{Name: "audit"}}, wantErr: true},
+		{name: "url and secret", sinks: []scorev1b1.NotificationSinkSpec{{Name: "audit", URL: "https://audit.example.com", URLSecretRef: secretRef}}, wantErr: true},
+		{name: "invalid url", sinks: []scorev1b1.NotificationSinkSpec{{Name: "audit", URL: "audit.example.com"}}, wantErr: true},
+		{name: "incomplete secret ref", sinks: []scorev1b1.NotificationSinkSpec{{Name: "audit", URLSecretRef: &scorev1b1.NamespacedName{Name: "x"}}}, wantErr: true},
+		{name: "unknown event", sinks: []scorev1b1.NotificationSinkSpec{{Name: "audit", URL: "https://audit.example.com", Events: []string{"WorkloadDeleted"}}}, wantErr: true},
+		{name: "unknown severity", sinks: []scorev1b1.NotificationSinkSpec{{Name: "audit", URL: "https://audit.example.com", MinSeverity: "Error"}}, wantErr: true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.validateNotifications(tt.sinks, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateNotifications() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateAudit(t *testing.T) {
+	validator := NewValidator()
+	tests := []struct {
+		name    string
+		audit   scorev1b1.AuditSpec
+		wantErr bool
+	}{
+		{name: "defaults"},
+		{name: "custom retention", audit: scorev1b1.AuditSpec{MaxRecordsPerWorkload: ptr.To[int32](20), MaxAge: &metav1.Duration{Duration: 90 * 24 * time.Hour}}},
+		{name: "no records", audit: scorev1b1.AuditSpec{MaxRecordsPerWorkload: ptr.To[int32](0)}, wantErr: true},
+		{name: "zero max age", audit: scorev1b1.AuditSpec{MaxAge: &metav1.Duration{}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.validateAudit(&tt.audit, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateAudit() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateSupplyChain(t *testing.T) {
+	validator := NewValidator()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	publicKey := string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+	trustPolicy := func(name, repository, publicKey string) scorev1b1.TrustPolicySpec {
+		return scorev1b1.TrustPolicySpec{Name: name, Repositories: []string{repository}, PublicKeys: []string{publicKey}}
+	}
+
+	tests := []struct {
+		name        string
+		supplyChain scorev1b1.SupplyChainSpec
+		wantErr     bool
+	}{
+		{name: "digests only", supplyChain: scorev1b1.SupplyChainSpec{RequireDigest: true}},
+		{
+			name: "trust policies",
+			supplyChain: scorev1b1.SupplyChainSpec{
+				PullSecretRef:      &scorev1b1.NamespacedName{Namespace: "score-system", Name: "registry"},
+				InsecureRegistries: []string{"registry.local:5000"},
+				TrustPolicies:      []scorev1b1.TrustPolicySpec{trustPolicy("platform", "registry.example.com/templates/*", publicKey)},
+			},
+		},
+		{name: "pull secret without namespace", supplyChain: scorev1b1.SupplyChainSpec{PullSecretRef: &scorev1b1.NamespacedName{Name: "registry"}}, wantErr: true},
+		{name: "insecure registry url", supplyChain: scorev1b1.SupplyChainSpec{InsecureRegistries: []string{"http://registry.local"}}, wantErr: true},
+		{
+			name: "duplicate trust policy",
+			supplyChain: scorev1b1.SupplyChainSpec{TrustPolicies: []scorev1b1.TrustPolicySpec{
+				trustPolicy("platform", "registry.example.com/*", publicKey), trustPolicy("platform", "ghcr.io/*", publicKey),
+			}},
+			wantErr: true,
+		},
+		{name: "invalid pattern", supplyChain: scorev1b1.SupplyChainSpec{TrustPolicies: []scorev1b1.TrustPolicySpec{trustPolicy("platform", "registry.example.com/[", publicKey)}}, wantErr: true},
+		{name: "invalid public key", supplyChain: scorev1b1.SupplyChainSpec{TrustPolicies: []scorev1b1.TrustPolicySpec{trustPolicy("platform", "registry.example.com/*", "not a key")}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.validateSupplyChain(&tt.supplyChain, field.NewPath("spec", "supplyChain"))
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateSupplyChain() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateNamespaces(t *testing.T) {
+	tests := []struct {
+		name       string
+		namespaces scorev1b1.NamespacesSpec
+		wantErr    bool
+	}{
+		{
+			name: "isolated environment with quota",
+			namespaces: scorev1b1.NamespacesSpec{Environments: []scorev1b1.EnvironmentSpec{{
+				Name:          "staging",
+				Labels:        map[string]string{"pod-security.kubernetes.io/enforce": "restricted"},
+				Quota:         &scorev1b1.NamespaceQuotaSpec{CPU: "8", Memory: "16Gi", Pods: ptr.To[int32](50)},
+				NetworkPolicy: scorev1b1.NetworkPolicyIsolated,
+			}}},
+		},
+		{name: "custom environment label", namespaces: scorev1b1.NamespacesSpec{EnvironmentLabel: "example.com/env", Environments: []scorev1b1.EnvironmentSpec{{Name: "dev"}}}},
+		{name: "no environments", wantErr: true},
+		{name: "invalid environment label", namespaces: scorev1b1.NamespacesSpec{EnvironmentLabel: "not a label", Environments: []scorev1b1.EnvironmentSpec{{Name: "dev"}}}, wantErr: true},
+		{name: "invalid environment name", namespaces: scorev1b1.NamespacesSpec{Environments: []scorev1b1.EnvironmentSpec{{Name: "Dev"}}}, wantErr: true},
+		{name: "duplicate environment", namespaces: scorev1b1.NamespacesSpec{Environments: []scorev1b1.EnvironmentSpec{{Name: "dev"}, {Name: "dev"}}}, wantErr: true},
+		{name: "invalid label value", namespaces: scorev1b1.NamespacesSpec{Environments: []scorev1b1.EnvironmentSpec{{Name: "dev", Labels: map[string]string{"team": "a b"}}}}, wantErr: true},
+		{name: "invalid quota", namespaces: scorev1b1.NamespacesSpec{Environments: []scorev1b1.EnvironmentSpec{{Name: "dev", Quota: &scorev1b1.NamespaceQuotaSpec{Memory: "lots"}}}}, wantErr: true},
+		{name: "unknown network policy", namespaces: scorev1b1.NamespacesSpec{Environments: []scorev1b1.EnvironmentSpec{{Name: "dev", NetworkPolicy: "DenyAll"}}}, wantErr: true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.validateNamespaces(&tt.namespaces, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateNamespaces() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateReadinessGates(t *testing.T) {
+	tests := []struct {
+		name    string
+		gates   []scorev1b1.ReadinessGate
+		wantErr bool
+	}{
+		{name: "no gates"},
+		{name: "custom conditions", gates: []scorev1b1.ReadinessGate{{ConditionType: "MeshInjected"}, {ConditionType: "example.com/DNSReady"}}},
+		{name: "missing condition type", gates: []scorev1b1.ReadinessGate{{}}, wantErr: true},
+		{name: "reserved condition type", gates: []scorev1b1.ReadinessGate{{ConditionType: "Ready"}}, wantErr: true},
+		{name: "invalid condition type", gates: []scorev1b1.ReadinessGate{{ConditionType: "DNS Ready"}}, wantErr: true},
+		{name: "duplicate condition type", gates: []scorev1b1.ReadinessGate{{ConditionType: "DNSReady"}, {ConditionType: "DNSReady"}}, wantErr: true},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.validateReadinessGates(tt.gates, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateReadinessGates() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateIngressPolicy(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  scorev1b1.IngressPolicySpec
+		wantErr bool
+	}{
+		{name: "no additional peers"},
+		{
+			name: "ingress controller and monitoring",
+			policy: scorev1b1.IngressPolicySpec{AllowFrom: []scorev1b1.NetworkPolicyPeerSpec{
+				{NamespaceLabels: map[string]string{"kubernetes.io/metadata.name": "ingress-nginx"}},
+				{PodLabels: map[string]string{"app": "prometheus"}, NamespaceLabels: map[string]string{"team": "monitoring"}},
+			}},
+		},
+		{name: "empty peer", policy: scorev1b1.IngressPolicySpec{AllowFrom: []scorev1b1.NetworkPolicyPeerSpec{{}}}, wantErr: true},
+		{
+			name:    "invalid label key",
+			policy:  scorev1b1.IngressPolicySpec{AllowFrom: []scorev1b1.NetworkPolicyPeerSpec{{PodLabels: map[string]string{"not a key": "x"}}}},
+			wantErr: true,
+		},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.validateIngressPolicy(&tt.policy, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateIngressPolicy() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestValidator_ValidateWorkloadDefaults(t *testing.T) {
+	two := intstr.FromInt32(2)
+	half := intstr.FromString("50%")
+	negative := intstr.FromInt32(-1)
+	invalid := intstr.FromString("half")
+
+	tests := []struct {
+		name     string
+		defaults scorev1b1.WorkloadDefaultsSpec
+		wantErr  bool
+	}{
+		{name: "empty"},
+		{
+			name: "min available and guaranteed resources",
+			defaults: scorev1b1.WorkloadDefaultsSpec{
+				DisruptionBudget: &scorev1b1.DisruptionBudgetSpec{MinAvailable: &two},
+				Resources: &scorev1b1.ResourceRequirements{
+					Requests: map[string]string{"cpu": "500m", "memory": "256Mi"},
+					Limits:   map[string]string{"cpu": "500m", "memory": "256Mi"},
+				},
+			},
+		},
+		{name: "max unavailable percentage", defaults: scorev1b1.WorkloadDefaultsSpec{DisruptionBudget: &scorev1b1.DisruptionBudgetSpec{MaxUnavailable: &half}}},
+		{name: "empty disruption budget", defaults: scorev1b1.WorkloadDefaultsSpec{DisruptionBudget: &scorev1b1.DisruptionBudgetSpec{}}, wantErr: true},
+		{
+			name:     "both budget fields",
+			defaults: scorev1b1.WorkloadDefaultsSpec{DisruptionBudget: &scorev1b1.DisruptionBudgetSpec{MinAvailable: &two, MaxUnavailable: &half}},
+			wantErr:  true,
+		},
+		{name: "negative budget", defaults: scorev1b1.WorkloadDefaultsSpec{DisruptionBudget: &scorev1b1.DisruptionBudgetSpec{MinAvailable: &negative}}, wantErr: true},
+		{name: "invalid percentage", defaults: scorev1b1.WorkloadDefaultsSpec{DisruptionBudget: &scorev1b1.DisruptionBudgetSpec{MaxUnavailable: &invalid}}, wantErr: true},
+		{
+			name:     "invalid quantity",
+			defaults: scorev1b1.WorkloadDefaultsSpec{Resources: &scorev1b1.ResourceRequirements{Limits: map[string]string{"memory": "lots"}}},
+			wantErr:  true,
+		},
+		{
+			name: "request above limit",
+			defaults: scorev1b1.WorkloadDefaultsSpec{Resources: &scorev1b1.ResourceRequirements{
+				Requests: map[string]string{"cpu": "2"},
+				Limits:   map[string]string{"cpu": "1"},
+			}},
+			wantErr: true,
+		},
+		{name: "existing priority class", defaults: scorev1b1.WorkloadDefaultsSpec{PriorityClass: &scorev1b1.PriorityClassSpec{Name: "system-cluster-critical"}}},
+		{
+			name: "created priority class",
+			defaults: scorev1b1.WorkloadDefaultsSpec{PriorityClass: &scorev1b1.PriorityClassSpec{
+				Name: "batch-low", Value: ptr.To[int32](-10), PreemptionPolicy: scorev1b1.PreemptionPolicyNever,
+			}},
+		},
+		{name: "priority class without name", defaults: scorev1b1.WorkloadDefaultsSpec{PriorityClass: &scorev1b1.PriorityClassSpec{Value: ptr.To[int32](100)}}, wantErr: true},
+		{
+			name:     "reserved priority class created",
+			defaults: scorev1b1.WorkloadDefaultsSpec{PriorityClass: &scorev1b1.PriorityClassSpec{Name: "system-critical", Value: ptr.To[int32](100)}},
+			wantErr:  true,
+		},
+		{
+			name:     "priority value above the user range",
+			defaults: scorev1b1.WorkloadDefaultsSpec{PriorityClass: &scorev1b1.PriorityClassSpec{Name: "critical", Value: ptr.To[int32](2000000000)}},
+			wantErr:  true,
+		},
+		{
+			name:     "preemption policy without value",
+			defaults: scorev1b1.WorkloadDefaultsSpec{PriorityClass: &scorev1b1.PriorityClassSpec{Name: "critical", PreemptionPolicy: scorev1b1.PreemptionPolicyNever}},
+			wantErr:  true,
+		},
+		{
+			name:     "unknown preemption policy",
+			defaults: scorev1b1.WorkloadDefaultsSpec{PriorityClass: &scorev1b1.PriorityClassSpec{Name: "critical", Value: ptr.To[int32](100), PreemptionPolicy: "Sometimes"}},
+			wantErr:  true,
+		},
+	}
+
+	validator := NewValidator()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validator.validateWorkloadDefaults(&tt.defaults, nil)
+			if (len(errs) > 0) != tt.wantErr {
+				t.Errorf("validateWorkloadDefaults() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
@@ -2,7 +2,11 @@ package controller
 
 import (
 	"context"
+	"fmt"
 	"net/url"
+	"reflect"
+	"slices"
+	"strings"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -13,6 +17,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/audit"
 	"github.com/cappyzawa/score-orchestrator/internal/status"
 )
 
@@ -21,6 +26,8 @@ type ExposureMirrorReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+	// Auditor records published endpoints in the audit trail; no decisions are recorded when nil
+	Auditor *audit.Recorder
 }
 
 // +kubebuilder:rbac:groups=score.dev,resources=workloadexposures,verbs=get;list;watch
@@ -74,10 +81,15 @@ func (r *ExposureMirrorReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 
 	// Create a patch base for the Workload status
 	patch := client.MergeFrom(workload.DeepCopy())
+	previousURLs := endpointURLs(workload.Status.Endpoints)
 
 	// Mirror endpoint from the first exposure if available
 	updated := r.mirrorEndpoint(&workload, &exposure)
 
+	// Mirror the full list of endpoints
+	endpointsUpdated := r.mirrorEndpoints(&workload, &exposure)
+	updated = updated || endpointsUpdated
+
 	// Mirror normalized conditions
 	conditionsUpdated := r.mirrorConditions(&workload, &exposure)
 	updated = updated || conditionsUpdated
@@ -91,6 +103,10 @@ func (r *ExposureMirrorReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 		logger.V(1).Info("Successfully mirrored WorkloadExposure status to Workload")
 
+		if urls := endpointURLs(workload.Status.Endpoints); len(urls) > 0 && !slices.Equal(urls, previousURLs) {
+			r.auditPublished(ctx, &workload, &exposure, urls)
+		}
+
 		// Record event for successful mirroring
 		if workload.Status.Endpoint != nil {
 			r.Recorder.Eventf(&workload, "Normal", "EndpointMirrored",
@@ -104,6 +120,27 @@ func (r *ExposureMirrorReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	return ctrl.Result{}, nil
 }
 
+// auditPublished records the endpoints published for the Workload in the audit trail
+func (r *ExposureMirrorReconciler) auditPublished(ctx context.Context, workload *scorev1b1.Workload, exposure *scorev1b1.WorkloadExposure, urls []string) {
+	details := map[string]string{
+		"exposure":  exposure.Name,
+		"endpoints": strings.Join(urls, ","),
+	}
+	message := fmt.Sprintf("Published %d endpoint(s)", len(urls))
+	if err := r.Auditor.Record(ctx, workload.Namespace, audit.WorkloadRef(workload), scorev1b1.AuditActionExposurePublished, message, details); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to record audit decision", "action", scorev1b1.AuditActionExposurePublished)
+	}
+}
+
+// endpointURLs returns the URLs of the endpoints in order
+func endpointURLs(endpoints []scorev1b1.WorkloadEndpoint) []string {
+	urls := make([]string, 0, len(endpoints))
+	for _, endpoint := range endpoints {
+		urls = append(urls, endpoint.URL)
+	}
+	return urls
+}
+
 // mirrorEndpoint updates the Workload endpoint from exposures[0] (mirror-only)
 func (r *ExposureMirrorReconciler) mirrorEndpoint(workload *scorev1b1.Workload, exposure *scorev1b1.WorkloadExposure) bool {
 	var newEndpoint *string
@@ -127,6 +164,34 @@ func (r *ExposureMirrorReconciler) mirrorEndpoint(workload *scorev1b1.Workload,
 	return true
 }
 
+// mirrorEndpoints updates the Workload endpoints list from all valid exposures (mirror-only)
+func (r *ExposureMirrorReconciler) mirrorEndpoints(workload *scorev1b1.Workload, exposure *scorev1b1.WorkloadExposure) bool {
+	var newEndpoints []scorev1b1.WorkloadEndpoint
+
+	// Preserve runtime ordering; skip entries that are not valid URLs
+	for _, entry := range exposure.Status.Exposures {
+		if !isValidURL(entry.URL) {
+			continue
+		}
+		newEndpoints = append(newEndpoints, scorev1b1.WorkloadEndpoint{
+			URL:      entry.URL,
+			Type:     entry.Type,
+			Ready:    entry.Ready,
+			PortName: entry.Name,
+		})
+	}
+
+	if len(newEndpoints) == 0 && len(workload.Status.Endpoints) == 0 {
+		return false
+	}
+	if reflect.DeepEqual(workload.Status.Endpoints, newEndpoints) {
+		return false
+	}
+
+	workload.Status.Endpoints = newEndpoints
+	return true
+}
+
 // mirrorConditions updates Workload conditions from normalized WorkloadExposure conditions
 func (r *ExposureMirrorReconciler) mirrorConditions(workload *scorev1b1.Workload, exposure *scorev1b1.WorkloadExposure) bool {
 	// Normalize the exposure conditions
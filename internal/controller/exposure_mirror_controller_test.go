@@ -235,10 +235,12 @@ var _ = Describe("ExposureMirrorReconciler", func() {
 				exposure.Status = scorev1b1.WorkloadExposureStatus{
 					Exposures: []scorev1b1.ExposureEntry{
 						{
+							Name:  "api",
 							URL:   "https://primary.example.com",
 							Ready: false, // Runtime ordered this first regardless of ready state
 						},
 						{
+							Name:  "metrics",
 							URL:   "https://secondary.example.com",
 							Ready: true,
 						},
@@ -261,6 +263,24 @@ var _ = Describe("ExposureMirrorReconciler", func() {
 				Expect(updatedWorkload.Status.Endpoint).NotTo(BeNil())
 				Expect(*updatedWorkload.Status.Endpoint).To(Equal("https://primary.example.com"))
 			})
+
+			It("should mirror all exposures into endpoints in order", func() {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+
+				var updatedWorkload scorev1b1.Workload
+				Expect(k8sClient.Get(ctx, types.NamespacedName{
+					Name:      workload.Name,
+					Namespace: workload.Namespace,
+				}, &updatedWorkload)).To(Succeed())
+
+				Expect(updatedWorkload.Status.Endpoints).To(HaveLen(2))
+				Expect(updatedWorkload.Status.Endpoints[0].URL).To(Equal("https://primary.example.com"))
+				Expect(updatedWorkload.Status.Endpoints[0].Ready).To(BeFalse())
+				Expect(updatedWorkload.Status.Endpoints[1].URL).To(Equal("https://secondary.example.com"))
+				Expect(updatedWorkload.Status.Endpoints[1].Ready).To(BeTrue())
+				Expect(updatedWorkload.Status.Endpoints[1].PortName).To(Equal("metrics"))
+			})
 		})
 	})
 
@@ -38,6 +38,19 @@ func SetupIndexers(ctx context.Context, mgr manager.Manager) error {
 		return err
 	}
 
+	// Index ResourceClaim by the Secret holding its outputs
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &scorev1b1.ResourceClaim{}, meta.IndexResourceClaimByOutputsSecret,
+		func(obj client.Object) []string {
+			claim := obj.(*scorev1b1.ResourceClaim)
+			if claim.Status.Outputs == nil || claim.Status.Outputs.SecretRef == nil {
+				return nil
+			}
+			return []string{claim.Namespace + "/" + claim.Status.Outputs.SecretRef.Name}
+		},
+	); err != nil {
+		return err
+	}
+
 	// Index WorkloadPlan by workloadRef
 	if err := mgr.GetFieldIndexer().IndexField(ctx, &scorev1b1.WorkloadPlan{}, meta.IndexWorkloadPlanByWorkload,
 		func(obj client.Object) []string {
@@ -48,5 +61,29 @@ func SetupIndexers(ctx context.Context, mgr manager.Manager) error {
 		return err
 	}
 
+	// Index WorkloadPlan by runtimeClass
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &scorev1b1.WorkloadPlan{}, meta.IndexWorkloadPlanByRuntimeClass,
+		func(obj client.Object) []string {
+			plan := obj.(*scorev1b1.WorkloadPlan)
+			return []string{plan.Spec.RuntimeClass}
+		},
+	); err != nil {
+		return err
+	}
+
+	// Index Workload by the Workloads it depends on
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &scorev1b1.Workload{}, meta.IndexWorkloadByDependency,
+		func(obj client.Object) []string {
+			workload := obj.(*scorev1b1.Workload)
+			keys := make([]string, 0, len(workload.Spec.DependsOn))
+			for _, name := range workload.Spec.DependsOn {
+				keys = append(keys, workload.Namespace+"/"+name)
+			}
+			return keys
+		},
+	); err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -19,12 +19,12 @@ package managers
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 
-	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -33,8 +33,12 @@ import (
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
 	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+	"github.com/cappyzawa/score-orchestrator/internal/dependency"
+	"github.com/cappyzawa/score-orchestrator/internal/events"
 	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
 	"github.com/cappyzawa/score-orchestrator/internal/status"
+	"github.com/cappyzawa/score-orchestrator/internal/tracing"
 )
 
 // ClaimManager handles ResourceClaim operations for Workloads
@@ -54,33 +58,100 @@ func NewClaimManager(c client.Client, scheme *runtime.Scheme, recorder record.Ev
 }
 
 // EnsureClaims creates or updates ResourceClaim resources for each resource in the Workload spec
-func (cm *ClaimManager) EnsureClaims(ctx context.Context, workload *scorev1b1.Workload) error {
-	for key, resource := range workload.Spec.Resources {
-		if err := cm.upsertResourceClaim(ctx, workload, key, resource); err != nil {
-			return fmt.Errorf("failed to upsert ResourceClaim for key %q: %w", key, err)
+// and returns the Workload's claims as observed after the update. metadata are the Workload labels and
+// annotations the claims propagate to the provisioned resources, or nil.
+// Existing claims are read with a single indexed List from the cache; only claims whose spec or
+// correlation ID differ are written, using server-side apply so that concurrent writers do not
+// cause conflict retries.
+// Claims are created in dependency order: the claim of a resource is only created once the claims of
+// the resources it depends on are bound, so the returned claims may not cover every resource yet.
+func (cm *ClaimManager) EnsureClaims(ctx context.Context, workload *scorev1b1.Workload, metadata *scorev1b1.PropagatedMetadata) ([]scorev1b1.ResourceClaim, error) {
+	ctx, span := tracing.StartSpan(ctx, "ClaimManager.EnsureClaims", tracing.WorkloadAttributes(workload)...)
+	defer span.End()
+
+	existing, err := cm.GetClaims(ctx, workload)
+	if err != nil {
+		err = fmt.Errorf("failed to list ResourceClaims: %w", err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+	claimsByName := make(map[string]int, len(existing))
+	for i := range existing {
+		claimsByName[existing[i].Name] = i
+	}
+
+	bound := make(map[string]bool, len(existing))
+	for i := range existing {
+		bound[existing[i].Spec.Key] = claimBound(&existing[i])
+	}
+
+	for _, key := range dependency.ResourceOrder(workload.Spec.Resources) {
+		var current *scorev1b1.ResourceClaim
+		if i, ok := claimsByName[claimNameFor(workload, key)]; ok {
+			current = &existing[i]
+		}
+		if current == nil {
+			if waiting := unboundDependencies(workload.Spec.Resources[key], bound); len(waiting) > 0 {
+				ctrl.LoggerFrom(ctx).V(1).Info("Waiting for dependencies before creating ResourceClaim",
+					"key", key, "dependsOn", waiting)
+				continue
+			}
+		}
+		applied, err := cm.upsertResourceClaim(ctx, workload, key, workload.Spec.Resources[key], metadata, current)
+		if err != nil {
+			err = fmt.Errorf("failed to upsert ResourceClaim for key %q: %w", key, err)
+			tracing.RecordError(span, err)
+			return nil, err
+		}
+		if applied == nil {
+			continue
+		}
+		if current != nil {
+			*current = *applied
+		} else {
+			claimsByName[applied.Name] = len(existing)
+			existing = append(existing, *applied)
 		}
 	}
-	return nil
+	return existing, nil
 }
 
-// upsertResourceClaim creates or updates a single ResourceClaim
-func (cm *ClaimManager) upsertResourceClaim(ctx context.Context, workload *scorev1b1.Workload, key string, resource scorev1b1.ResourceSpec) error {
-	claimName := fmt.Sprintf("%s-%s", workload.Name, key)
-	claim := &scorev1b1.ResourceClaim{}
+// claimBound reports whether the claim is bound and its outputs describe its current spec
+func claimBound(claim *scorev1b1.ResourceClaim) bool {
+	return claim.Status.Phase == scorev1b1.ResourceClaimPhaseBound && claim.Status.OutputsAvailable &&
+		!status.ClaimStatusStale(claim)
+}
 
-	log := ctrl.LoggerFrom(ctx).WithValues("claimName", claimName, "workload", workload.Name, "key", key)
-	log.Info("Upserting ResourceClaim")
+// unboundDependencies returns the resources the resource depends on whose claims are not bound
+func unboundDependencies(resource scorev1b1.ResourceSpec, bound map[string]bool) []string {
+	var waiting []string
+	for _, dep := range resource.DependsOn {
+		if !bound[dep] {
+			waiting = append(waiting, dep)
+		}
+	}
+	return waiting
+}
 
-	// Try to get existing claim
-	err := cm.client.Get(ctx, types.NamespacedName{
-		Name:      claimName,
-		Namespace: workload.Namespace,
-	}, claim)
+// claimNameFor returns the ResourceClaim name for a Workload resource key
+func claimNameFor(workload *scorev1b1.Workload, key string) string {
+	return fmt.Sprintf("%s-%s", workload.Name, key)
+}
 
-	if err != nil && !errors.IsNotFound(err) {
-		log.Error(err, "Failed to get ResourceClaim")
-		return fmt.Errorf("failed to get ResourceClaim %s: %w", claimName, err)
-	}
+// upsertResourceClaim applies a single ResourceClaim if it is missing or out of date.
+// current is the existing claim from the cache, or nil. Returns the written claim, or nil if
+// the existing claim is up to date.
+func (cm *ClaimManager) upsertResourceClaim(
+	ctx context.Context,
+	workload *scorev1b1.Workload,
+	key string,
+	resource scorev1b1.ResourceSpec,
+	metadata *scorev1b1.PropagatedMetadata,
+	current *scorev1b1.ResourceClaim,
+) (*scorev1b1.ResourceClaim, error) {
+	claimName := claimNameFor(workload, key)
+
+	log := ctrl.LoggerFrom(ctx).WithValues("claimName", claimName, "workload", workload.Name, "key", key)
 
 	// Prepare the desired spec
 	desiredSpec := scorev1b1.ResourceClaimSpec{
@@ -99,64 +170,63 @@ func (cm *ClaimManager) upsertResourceClaim(ctx context.Context, workload *score
 	if resource.Params != nil {
 		desiredSpec.Params = resource.Params
 	}
-
-	if errors.IsNotFound(err) {
-		log.Info("Creating new ResourceClaim")
-		// Create new claim
-		claim = &scorev1b1.ResourceClaim{
-			TypeMeta: metav1.TypeMeta{
-				APIVersion: "score.dev/v1b1",
-				Kind:       "ResourceClaim",
-			},
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      claimName,
-				Namespace: workload.Namespace,
-				Labels: map[string]string{
-					"score.dev/workload": workload.Name,
-					"score.dev/key":      key,
-				},
-			},
-			Spec: desiredSpec,
+	if resource.Provision == scorev1b1.ProvisionExternal {
+		desiredSpec.Provision = resource.Provision
+		desiredSpec.SecretRef = resource.SecretRef.DeepCopy()
+	}
+	desiredSpec.Optional = resource.Optional
+	desiredSpec.Metadata = metadata
+
+	if current != nil {
+		// Skip the write if spec and correlation ID are unchanged
+		correlationChanged := events.PropagateCorrelationID(workload, current.DeepCopy())
+		if cm.resourceClaimSpecEqual(current.Spec, desiredSpec) && !correlationChanged {
+			log.V(1).Info("ResourceClaim spec is up to date")
+			return nil, nil
 		}
+	}
 
-		// Set owner reference with blockOwnerDeletion=false to allow proper deletion
-		gvk, err := apiutil.GVKForObject(workload, cm.scheme)
-		if err != nil {
-			log.Error(err, "Failed to get GVK for workload")
-			return fmt.Errorf("failed to get GVK for workload: %w", err)
-		}
+	claim := &scorev1b1.ResourceClaim{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "score.dev/v1b1",
+			Kind:       "ResourceClaim",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claimName,
+			Namespace: workload.Namespace,
+			Labels: map[string]string{
+				meta.LabelWorkload: workload.Name,
+				"score.dev/key":    key,
+			},
+		},
+		Spec: desiredSpec,
+	}
+	events.PropagateCorrelationID(workload, claim)
+	tracing.InjectIntoAnnotations(ctx, claim)
 
-		claim.SetOwnerReferences([]metav1.OwnerReference{{
-			APIVersion:         gvk.GroupVersion().String(),
-			Kind:               gvk.Kind,
-			Name:               workload.GetName(),
-			UID:                workload.GetUID(),
-			Controller:         ptr.To(true),
-			BlockOwnerDeletion: ptr.To(false), // Allow owner deletion even with finalizers
-		}})
-
-		if err := cm.client.Create(ctx, claim); err != nil {
-			log.Error(err, "Failed to create ResourceClaim")
-			return fmt.Errorf("failed to create ResourceClaim: %w", err)
-		}
-		log.Info("Successfully created ResourceClaim")
-	} else {
-		log.Info("ResourceClaim already exists, checking for updates")
-		// Update existing claim if spec differs
-		if !cm.resourceClaimSpecEqual(claim.Spec, desiredSpec) {
-			log.Info("Updating ResourceClaim spec")
-			claim.Spec = desiredSpec
-			if err := cm.client.Update(ctx, claim); err != nil {
-				log.Error(err, "Failed to update ResourceClaim")
-				return fmt.Errorf("failed to update ResourceClaim: %w", err)
-			}
-			log.Info("Successfully updated ResourceClaim")
-		} else {
-			log.Info("ResourceClaim spec is up to date")
-		}
+	// Set owner reference with blockOwnerDeletion=false to allow proper deletion
+	gvk, err := apiutil.GVKForObject(workload, cm.scheme)
+	if err != nil {
+		log.Error(err, "Failed to get GVK for workload")
+		return nil, fmt.Errorf("failed to get GVK for workload: %w", err)
 	}
 
-	return nil
+	claim.SetOwnerReferences([]metav1.OwnerReference{{
+		APIVersion:         gvk.GroupVersion().String(),
+		Kind:               gvk.Kind,
+		Name:               workload.GetName(),
+		UID:                workload.GetUID(),
+		Controller:         ptr.To(true),
+		BlockOwnerDeletion: ptr.To(false), // Allow owner deletion even with finalizers
+	}})
+
+	log.Info("Applying ResourceClaim", "create", current == nil)
+	if err := reconcile.Apply(ctx, cm.client, claim, meta.FieldManagerOrchestrator); err != nil {
+		log.Error(err, "Failed to apply ResourceClaim")
+		return nil, fmt.Errorf("failed to apply ResourceClaim: %w", err)
+	}
+	log.Info("Successfully applied ResourceClaim")
+	return claim, nil
 }
 
 // resourceClaimSpecEqual compares two ResourceClaimSpec structs for equality
@@ -186,7 +256,15 @@ func (cm *ClaimManager) resourceClaimSpecEqual(a, b scorev1b1.ResourceClaimSpec)
 		return false
 	}
 
-	return true
+	if a.Provision != b.Provision || !reflect.DeepEqual(a.SecretRef, b.SecretRef) {
+		return false
+	}
+
+	if a.Optional != b.Optional {
+		return false
+	}
+
+	return reflect.DeepEqual(a.Metadata, b.Metadata)
 }
 
 // GetClaims retrieves all ResourceClaims for a given Workload
@@ -215,7 +293,7 @@ func (cm *ClaimManager) getResourceClaimsWithoutIndex(ctx context.Context, workl
 	// Use label selector to find claims for this workload
 	err := cm.client.List(ctx, claimList,
 		client.InNamespace(workload.Namespace),
-		client.MatchingLabels{"score.dev/workload": workload.Name})
+		client.MatchingLabels{meta.LabelWorkload: workload.Name})
 	if err != nil {
 		return nil, err
 	}
@@ -225,68 +303,54 @@ func (cm *ClaimManager) getResourceClaimsWithoutIndex(ctx context.Context, workl
 
 // AggregateStatus processes all ResourceClaims and returns aggregated status
 func (cm *ClaimManager) AggregateStatus(claims []scorev1b1.ResourceClaim) status.ClaimAggregation {
-	if len(claims) == 0 {
-		return status.ClaimAggregation{
-			Ready:   true,
-			Reason:  conditions.ReasonSucceeded,
-			Message: "No resource dependencies",
-			Claims:  []scorev1b1.ClaimSummary{},
-		}
-	}
-
-	summaries := make([]scorev1b1.ClaimSummary, 0, len(claims))
-	var boundCount, failedCount int
+	return status.AggregateClaimStatuses(claims)
+}
 
-	for _, claim := range claims {
-		summary := scorev1b1.ClaimSummary{
-			Key:              claim.Spec.Key,
-			Phase:            claim.Status.Phase,
-			Reason:           claim.Status.Reason,
-			Message:          claim.Status.Message,
-			OutputsAvailable: claim.Status.OutputsAvailable,
-		}
+// AggregateWorkloadStatus aggregates the claims of the Workload like AggregateStatus, and additionally reports
+// the resources whose claims are not created yet because they wait for their dependencies as pending
+func (cm *ClaimManager) AggregateWorkloadStatus(workload *scorev1b1.Workload, claims []scorev1b1.ResourceClaim) status.ClaimAggregation {
+	agg := cm.AggregateStatus(claims)
 
-		// Handle empty phase as Pending
-		if summary.Phase == "" {
-			summary.Phase = scorev1b1.ResourceClaimPhasePending
+	claimed := make(map[string]bool, len(claims))
+	bound := make(map[string]bool, len(claims))
+	for i := range claims {
+		claimed[claims[i].Spec.Key] = true
+		bound[claims[i].Spec.Key] = claimBound(&claims[i])
+	}
+	var waiting, waitingOptional int
+	for _, key := range dependency.ResourceOrder(workload.Spec.Resources) {
+		if claimed[key] {
+			continue
 		}
-
-		summaries = append(summaries, summary)
-
-		// Count phases for overall status
-		switch claim.Status.Phase {
-		case scorev1b1.ResourceClaimPhaseBound:
-			if claim.Status.OutputsAvailable {
-				boundCount++
-			}
-		case scorev1b1.ResourceClaimPhaseFailed:
-			failedCount++
+		resource := workload.Spec.Resources[key]
+		agg.Claims = append(agg.Claims, scorev1b1.ClaimSummary{
+			Key:      key,
+			Type:     resource.Type,
+			Phase:    scorev1b1.ResourceClaimPhasePending,
+			Reason:   conditions.ReasonBlocked,
+			Message:  fmt.Sprintf("Waiting for resources %s to be bound", strings.Join(unboundDependencies(resource, bound), ", ")),
+			Optional: resource.Optional,
+		})
+		// Optional resources do not hold back readiness while they wait
+		if resource.Optional {
+			agg.OptionalPending = append(agg.OptionalPending, key)
+			waitingOptional++
+			continue
 		}
+		waiting++
 	}
-
-	// Determine overall claim readiness
-	totalClaims := len(claims)
-	var ready bool
-	var reason, message string
-
-	if failedCount > 0 {
-		ready = false
-		reason = conditions.ReasonClaimFailed
-		message = conditions.MessageClaimsFailed
-	} else if boundCount == totalClaims {
-		ready = true
-		reason = conditions.ReasonSucceeded
-		message = conditions.MessageAllClaimsReady
-	} else {
-		ready = false
-		reason = conditions.ReasonClaimPending
-		message = conditions.MessageClaimsProvisioning
+	if waiting+waitingOptional == 0 {
+		return agg
 	}
 
-	return status.ClaimAggregation{
-		Ready:   ready,
-		Reason:  reason,
-		Message: message,
-		Claims:  summaries,
+	sort.Slice(agg.Claims, func(i, j int) bool {
+		return agg.Claims[i].Key < agg.Claims[j].Key
+	})
+	sort.Strings(agg.OptionalPending)
+	if waiting > 0 && agg.Ready {
+		agg.Ready = false
+		agg.Reason = conditions.ReasonClaimPending
+		agg.Message = conditions.MessageClaimsProvisioning
 	}
+	return agg
 }
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+// countingClient returns a fake client with the ResourceClaim workload index that counts API calls
+func countingClient(b *testing.B, objs ...client.Object) (client.Client, *int) {
+	b.Helper()
+	scheme := runtime.NewScheme()
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		b.Fatal(err)
+	}
+
+	calls := 0
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&scorev1b1.ResourceClaim{}).
+		WithIndex(&scorev1b1.ResourceClaim{}, meta.IndexResourceClaimByWorkload, func(obj client.Object) []string {
+			claim := obj.(*scorev1b1.ResourceClaim)
+			return []string{claim.Spec.WorkloadRef.Namespace + "/" + claim.Spec.WorkloadRef.Name}
+		}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				calls++
+				return c.Get(ctx, key, obj, opts...)
+			},
+			List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+				calls++
+				return c.List(ctx, list, opts...)
+			},
+			Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+				calls++
+				return c.Create(ctx, obj, opts...)
+			},
+			Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+				calls++
+				return c.Update(ctx, obj, opts...)
+			},
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				calls++
+				return applyAsCreateOrUpdate(ctx, c, obj, patch, opts...)
+			},
+		}).
+		Build()
+	return c, &calls
+}
+
+// benchmarkWorkload returns a Workload with the given number of resources
+func benchmarkWorkload(resources int) *scorev1b1.Workload {
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "bench", Namespace: "default", UID: "bench-uid"},
+		Spec:       scorev1b1.WorkloadSpec{Resources: make(map[string]scorev1b1.ResourceSpec, resources)},
+	}
+	for i := 0; i < resources; i++ {
+		workload.Spec.Resources[fmt.Sprintf("res%d", i)] = scorev1b1.ResourceSpec{Type: "postgres"}
+	}
+	return workload
+}
+
+// BenchmarkEnsureClaimsSteadyState measures API calls per reconcile when all claims are up to date:
+// a single indexed List, whatever the number of claims.
+func BenchmarkEnsureClaimsSteadyState(b *testing.B) {
+	const resources = 20
+	workload := benchmarkWorkload(resources)
+
+	c, calls := countingClient(b, workload)
+	cm := NewClaimManager(c, c.Scheme(), record.NewFakeRecorder(100))
+	ctx := context.Background()
+	if _, err := cm.EnsureClaims(ctx, workload, nil); err != nil {
+		b.Fatal(err)
+	}
+
+	*calls = 0
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := cm.EnsureClaims(ctx, workload, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(*calls)/float64(b.N), "apicalls/op")
+	if *calls != b.N {
+		b.Fatalf("API calls = %d over %d reconciles, want one List per reconcile", *calls, b.N)
+	}
+}
+
+// BenchmarkEnsureClaimsCreate measures API calls for the first reconcile of a Workload: the List,
+// then for each new claim the Get that upgrades the managed fields of legacy claims and the apply.
+func BenchmarkEnsureClaimsCreate(b *testing.B) {
+	const resources = 20
+	ctx := context.Background()
+	total := 0
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		workload := benchmarkWorkload(resources)
+		c, calls := countingClient(b, workload)
+		cm := NewClaimManager(c, c.Scheme(), record.NewFakeRecorder(100))
+		b.StartTimer()
+
+		if _, err := cm.EnsureClaims(ctx, workload, nil); err != nil {
+			b.Fatal(err)
+		}
+		total += *calls
+	}
+	b.ReportMetric(float64(total)/float64(b.N), "apicalls/op")
+}
@@ -18,19 +18,24 @@ package managers
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
 	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
 )
 
 func TestClaimManager(t *testing.T) {
@@ -38,6 +43,27 @@ func TestClaimManager(t *testing.T) {
 	RunSpecs(t, "ClaimManager Suite")
 }
 
+// applyAsCreateOrUpdate emulates server-side apply, which the fake client does not support,
+// by creating the applied object or replacing the existing one. Other patches pass through.
+func applyAsCreateOrUpdate(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+
+	existing, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", obj)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		return c.Create(ctx, obj)
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return c.Update(ctx, obj)
+}
+
 var _ = Describe("ClaimManager", func() {
 	var (
 		ctx          context.Context
@@ -54,7 +80,11 @@ var _ = Describe("ClaimManager", func() {
 		Expect(scorev1b1.AddToScheme(scheme)).To(Succeed())
 
 		recorder = record.NewFakeRecorder(10)
-		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&scorev1b1.ResourceClaim{}).
+			WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).
+			Build()
 		claimManager = NewClaimManager(fakeClient, scheme, recorder)
 
 		// Create a test workload
@@ -80,7 +110,7 @@ var _ = Describe("ClaimManager", func() {
 
 	Describe("EnsureClaims", func() {
 		It("should create ResourceClaims for all resources in the workload", func() {
-			err := claimManager.EnsureClaims(ctx, workload)
+			_, err := claimManager.EnsureClaims(ctx, workload, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			// Verify db claim was created
@@ -109,7 +139,7 @@ var _ = Describe("ClaimManager", func() {
 
 		It("should update existing claims when spec changes", func() {
 			// Create initial claim
-			err := claimManager.EnsureClaims(ctx, workload)
+			_, err := claimManager.EnsureClaims(ctx, workload, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			// Update workload spec
@@ -119,7 +149,7 @@ var _ = Describe("ClaimManager", func() {
 			Expect(fakeClient.Update(ctx, workload)).To(Succeed())
 
 			// Ensure claims again
-			err = claimManager.EnsureClaims(ctx, workload)
+			_, err = claimManager.EnsureClaims(ctx, workload, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			// Verify claim was updated
@@ -131,12 +161,106 @@ var _ = Describe("ClaimManager", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(*dbClaim.Spec.Class).To(Equal("premium"))
 		})
+
+		It("should return the ensured claims", func() {
+			claims, err := claimManager.EnsureClaims(ctx, workload, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claims).To(HaveLen(2))
+			Expect([]string{claims[0].Spec.Key, claims[1].Spec.Key}).To(ConsistOf("db", "cache"))
+		})
+
+		It("should not write claims that are up to date", func() {
+			_, err := claimManager.EnsureClaims(ctx, workload, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			before := &scorev1b1.ResourceClaim{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "test-workload-db", Namespace: "default"}, before)).To(Succeed())
+
+			claims, err := claimManager.EnsureClaims(ctx, workload, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claims).To(HaveLen(2))
+
+			after := &scorev1b1.ResourceClaim{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "test-workload-db", Namespace: "default"}, after)).To(Succeed())
+			Expect(after.ResourceVersion).To(Equal(before.ResourceVersion))
+		})
+
+		It("should remove fields dropped from claims last written with Update", func() {
+			// A claim written by the client-side updates of earlier releases, owned by the legacy field manager
+			legacy := &scorev1b1.ResourceClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-workload-db",
+					Namespace: "default",
+					Labels:    map[string]string{meta.LabelWorkload: "test-workload", "score.dev/key": "db"},
+					ManagedFields: []metav1.ManagedFieldsEntry{{
+						Manager:    "manager",
+						Operation:  metav1.ManagedFieldsOperationUpdate,
+						APIVersion: "score.dev/v1b1",
+						FieldsType: "FieldsV1",
+						FieldsV1: &metav1.FieldsV1{Raw: []byte(
+							`{"f:spec":{".":{},"f:class":{},"f:key":{},"f:params":{},"f:type":{},"f:workloadRef":{}}}`)},
+					}},
+				},
+				Spec: scorev1b1.ResourceClaimSpec{
+					WorkloadRef: scorev1b1.NamespacedName{Name: "test-workload", Namespace: "default"},
+					Key:         "db",
+					Type:        "postgresql",
+					Class:       stringPtr("standard"),
+					Params:      &apiextv1.JSON{Raw: []byte(`{"version":"16"}`)},
+				},
+			}
+
+			// Record the managers of the claim as the apply finds them
+			var managers []metav1.ManagedFieldsEntry
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(legacy).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+						if patch.Type() == types.ApplyPatchType && obj.GetName() == legacy.Name {
+							current := &scorev1b1.ResourceClaim{}
+							if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+								return err
+							}
+							managers = current.GetManagedFields()
+						}
+						return applyAsCreateOrUpdate(ctx, c, obj, patch, opts...)
+					},
+				}).
+				Build()
+
+			_, err := NewClaimManager(c, scheme, recorder).EnsureClaims(ctx, workload, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(managers).To(HaveLen(1))
+			Expect(managers[0].Manager).To(Equal(meta.FieldManagerOrchestrator))
+			Expect(managers[0].Operation).To(Equal(metav1.ManagedFieldsOperationApply))
+
+			dbClaim := &scorev1b1.ResourceClaim{}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(legacy), dbClaim)).To(Succeed())
+			Expect(dbClaim.Spec.Params).To(BeNil())
+		})
+
+		It("should pass the secret of external resources to their claims", func() {
+			dbResource := workload.Spec.Resources["db"]
+			dbResource.Provision = scorev1b1.ProvisionExternal
+			dbResource.SecretRef = &scorev1b1.LocalObjectReference{Name: "my-db"}
+			workload.Spec.Resources["db"] = dbResource
+
+			_, err := claimManager.EnsureClaims(ctx, workload, nil)
+			Expect(err).ToNot(HaveOccurred())
+
+			dbClaim := &scorev1b1.ResourceClaim{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "test-workload-db", Namespace: "default"}, dbClaim)).To(Succeed())
+			Expect(dbClaim.Spec.Provision).To(Equal(scorev1b1.ProvisionExternal))
+			Expect(dbClaim.Spec.SecretRef).To(Equal(&scorev1b1.LocalObjectReference{Name: "my-db"}))
+		})
 	})
 
 	Describe("GetClaims", func() {
 		It("should retrieve claims using label selector", func() {
 			// Create claims first
-			err := claimManager.EnsureClaims(ctx, workload)
+			_, err := claimManager.EnsureClaims(ctx, workload, nil)
 			Expect(err).ToNot(HaveOccurred())
 
 			// Get claims
@@ -256,6 +380,140 @@ var _ = Describe("ClaimManager", func() {
 			agg := claimManager.AggregateStatus(claims)
 			Expect(agg.Claims[0].Phase).To(Equal(scorev1b1.ResourceClaimPhasePending))
 		})
+
+		It("should not count claims whose updated spec was not provisioned yet as ready", func() {
+			claims := []scorev1b1.ResourceClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{Generation: 2},
+					Spec:       scorev1b1.ResourceClaimSpec{Key: "db"},
+					Status: scorev1b1.ResourceClaimStatus{
+						Phase:              scorev1b1.ResourceClaimPhaseBound,
+						OutputsAvailable:   true,
+						ObservedGeneration: 1,
+					},
+				},
+			}
+
+			agg := claimManager.AggregateStatus(claims)
+			Expect(agg.Ready).To(BeFalse())
+			Expect(agg.Reason).To(Equal(conditions.ReasonClaimPending))
+
+			claims[0].Status.ObservedGeneration = 2
+			Expect(claimManager.AggregateStatus(claims).Ready).To(BeTrue())
+		})
+
+		It("should summarize each claim with its type, ordered by key", func() {
+			claims := []scorev1b1.ResourceClaim{
+				{
+					Spec: scorev1b1.ResourceClaimSpec{Key: "queue", Type: "rabbitmq"},
+					Status: scorev1b1.ResourceClaimStatus{
+						Phase:   scorev1b1.ResourceClaimPhaseClaiming,
+						Reason:  conditions.ReasonClaimPending,
+						Message: "Waiting for broker",
+					},
+				},
+				{
+					Spec: scorev1b1.ResourceClaimSpec{Key: "db", Type: "postgres"},
+					Status: scorev1b1.ResourceClaimStatus{
+						Phase:            scorev1b1.ResourceClaimPhaseBound,
+						OutputsAvailable: true,
+					},
+				},
+			}
+
+			agg := claimManager.AggregateStatus(claims)
+			Expect(agg.Claims).To(Equal([]scorev1b1.ClaimSummary{
+				{Key: "db", Type: "postgres", Phase: scorev1b1.ResourceClaimPhaseBound, OutputsAvailable: true},
+				{Key: "queue", Type: "rabbitmq", Phase: scorev1b1.ResourceClaimPhaseClaiming,
+					Reason: conditions.ReasonClaimPending, Message: "Waiting for broker"},
+			}))
+		})
+	})
+
+	Describe("optional resources", func() {
+		It("should not hold back readiness for claims of optional resources", func() {
+			claims := []scorev1b1.ResourceClaim{
+				{
+					Spec: scorev1b1.ResourceClaimSpec{Key: "db"},
+					Status: scorev1b1.ResourceClaimStatus{
+						Phase:            scorev1b1.ResourceClaimPhaseBound,
+						OutputsAvailable: true,
+					},
+				},
+				{
+					Spec:   scorev1b1.ResourceClaimSpec{Key: "search", Optional: true},
+					Status: scorev1b1.ResourceClaimStatus{Phase: scorev1b1.ResourceClaimPhaseFailed},
+				},
+				{
+					Spec:   scorev1b1.ResourceClaimSpec{Key: "cache", Optional: true},
+					Status: scorev1b1.ResourceClaimStatus{Phase: scorev1b1.ResourceClaimPhaseClaiming},
+				},
+			}
+
+			agg := claimManager.AggregateStatus(claims)
+			Expect(agg.Ready).To(BeTrue())
+			Expect(agg.OptionalFailed).To(Equal([]string{"search"}))
+			Expect(agg.OptionalPending).To(Equal([]string{"cache"}))
+			Expect(agg.HasOptional()).To(BeTrue())
+		})
+
+		It("should pass the optional flag to the claims", func() {
+			workload.Spec.Resources = map[string]scorev1b1.ResourceSpec{
+				"cache": {Type: "redis", Optional: true},
+			}
+
+			claims, err := claimManager.EnsureClaims(ctx, workload, nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(claims).To(HaveLen(1))
+			Expect(claims[0].Spec.Optional).To(BeTrue())
+		})
+	})
+
+	Describe("resource dependencies", func() {
+		BeforeEach(func() {
+			workload.Spec.Resources["migrations"] = scorev1b1.ResourceSpec{Type: "job", DependsOn: []string{"db"}}
+		})
+
+		It("should create the claim of a resource once the claims it depends on are bound", func() {
+			claims, err := claimManager.EnsureClaims(ctx, workload, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claims).To(HaveLen(2))
+
+			migrations := types.NamespacedName{Name: "test-workload-migrations", Namespace: "default"}
+			Expect(apierrors.IsNotFound(fakeClient.Get(ctx, migrations, &scorev1b1.ResourceClaim{}))).To(BeTrue())
+
+			agg := claimManager.AggregateWorkloadStatus(workload, claims)
+			Expect(agg.Ready).To(BeFalse())
+			Expect(agg.Reason).To(Equal(conditions.ReasonClaimPending))
+			Expect(agg.Claims).To(ContainElement(scorev1b1.ClaimSummary{
+				Key: "migrations", Type: "job", Phase: scorev1b1.ResourceClaimPhasePending,
+				Reason: conditions.ReasonBlocked, Message: "Waiting for resources db to be bound",
+			}))
+
+			dbClaim := &scorev1b1.ResourceClaim{}
+			Expect(fakeClient.Get(ctx, types.NamespacedName{Name: "test-workload-db", Namespace: "default"}, dbClaim)).To(Succeed())
+			dbClaim.Status.Phase = scorev1b1.ResourceClaimPhaseBound
+			dbClaim.Status.OutputsAvailable = true
+			Expect(fakeClient.Status().Update(ctx, dbClaim)).To(Succeed())
+
+			claims, err = claimManager.EnsureClaims(ctx, workload, nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(claims).To(HaveLen(3))
+			Expect(fakeClient.Get(ctx, migrations, &scorev1b1.ResourceClaim{})).To(Succeed())
+		})
+
+		It("should report every declared resource once all claims exist", func() {
+			bound := scorev1b1.ResourceClaimStatus{Phase: scorev1b1.ResourceClaimPhaseBound, OutputsAvailable: true}
+			claims := []scorev1b1.ResourceClaim{
+				{Spec: scorev1b1.ResourceClaimSpec{Key: "cache"}, Status: bound},
+				{Spec: scorev1b1.ResourceClaimSpec{Key: "db"}, Status: bound},
+				{Spec: scorev1b1.ResourceClaimSpec{Key: "migrations"}, Status: bound},
+			}
+
+			agg := claimManager.AggregateWorkloadStatus(workload, claims)
+			Expect(agg.Ready).To(BeTrue())
+			Expect(agg.Claims).To(HaveLen(3))
+		})
 	})
 })
 
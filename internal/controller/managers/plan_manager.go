@@ -18,23 +18,35 @@ package managers
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
 
-	"k8s.io/apimachinery/pkg/api/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/audit"
 	"github.com/cappyzawa/score-orchestrator/internal/conditions"
 	"github.com/cappyzawa/score-orchestrator/internal/config"
 	"github.com/cappyzawa/score-orchestrator/internal/endpoint"
+	"github.com/cappyzawa/score-orchestrator/internal/environment"
 	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/policy"
+	"github.com/cappyzawa/score-orchestrator/internal/propagation"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner"
 	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
 	"github.com/cappyzawa/score-orchestrator/internal/selection"
 	"github.com/cappyzawa/score-orchestrator/internal/status"
+	"github.com/cappyzawa/score-orchestrator/internal/supplychain"
+	"github.com/cappyzawa/score-orchestrator/internal/tracing"
 )
 
 // Plan management events
@@ -45,6 +57,20 @@ const (
 	EventReasonPlanError = "PlanError"
 	// EventReasonProjectionError indicates an error in workload projection
 	EventReasonProjectionError = "ProjectionError"
+	// EventReasonBackendSelected indicates that a backend was bound to the workload
+	EventReasonBackendSelected = "BackendSelected"
+	// EventReasonSelectionExplained carries the selection pipeline explanation when no backend could be selected
+	EventReasonSelectionExplained = "SelectionExplained"
+	// EventReasonBackendMigrated indicates that reselection moved the workload to another backend
+	EventReasonBackendMigrated = "BackendMigrated"
+	// EventReasonRolledBack indicates that the WorkloadPlan was restored from history after a failed rollout
+	EventReasonRolledBack = "RolledBack"
+	// EventReasonPolicyViolation indicates that a policy in Deny mode rejected the workload
+	EventReasonPolicyViolation = "PolicyViolation"
+	// EventReasonPolicyWarning indicates that the workload violates a policy in Warn mode
+	EventReasonPolicyWarning = "PolicyWarning"
+	// EventReasonSupplyChainError indicates that the template of the selected backend failed verification
+	EventReasonSupplyChainError = "SupplyChainError"
 )
 
 // Event types
@@ -63,10 +89,23 @@ type PlanManager struct {
 	configLoader    config.ConfigLoader
 	endpointDeriver *endpoint.EndpointDeriver
 	statusManager   *StatusManager
+	auditor         *audit.Recorder
+	verifier        *supplychain.Verifier
+
+	// warnings holds the last policy warnings reported per Workload and stage, so that they are only
+	// emitted as events when they change
+	warningsMu sync.Mutex
+	warnings   map[policyWarningKey]string
+}
+
+// policyWarningKey identifies the policy warnings of a Workload at a stage
+type policyWarningKey struct {
+	workload client.ObjectKey
+	stage    string
 }
 
-// NewPlanManager creates a new PlanManager instance
-func NewPlanManager(c client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, configLoader config.ConfigLoader, endpointDeriver *endpoint.EndpointDeriver, statusManager *StatusManager) *PlanManager {
+// NewPlanManager creates a new PlanManager instance. A nil auditor records no decisions.
+func NewPlanManager(c client.Client, scheme *runtime.Scheme, recorder record.EventRecorder, configLoader config.ConfigLoader, endpointDeriver *endpoint.EndpointDeriver, statusManager *StatusManager, auditor *audit.Recorder) *PlanManager {
 	return &PlanManager{
 		client:          c,
 		scheme:          scheme,
@@ -74,6 +113,9 @@ func NewPlanManager(c client.Client, scheme *runtime.Scheme, recorder record.Eve
 		configLoader:    configLoader,
 		endpointDeriver: endpointDeriver,
 		statusManager:   statusManager,
+		auditor:         auditor,
+		verifier:        supplychain.NewVerifier(),
+		warnings:        map[policyWarningKey]string{},
 	}
 }
 
@@ -84,34 +126,85 @@ func (pm *PlanManager) EnsurePlan(ctx context.Context, workload *scorev1b1.Workl
 	// Create WorkloadPlan if claims are ready
 	if agg.Ready {
 		log.V(1).Info("Claims are ready, creating WorkloadPlan")
-		selectedBackend, err := pm.SelectBackend(ctx, workload)
+		selectCtx, selectSpan := tracing.StartSpan(ctx, "PlanManager.SelectBackend", tracing.WorkloadAttributes(workload)...)
+		selectedBackend, orchestratorConfig, err := pm.selectBackend(selectCtx, workload)
+		tracing.RecordError(selectSpan, err)
+		selectSpan.End()
 		if err != nil {
 			log.Error(err, "Failed to select backend")
-			pm.statusManager.SetRuntimeReadyCondition(
-				workload,
-				false,
-				conditions.ReasonRuntimeSelecting,
-				fmt.Sprintf("Backend selection failed: %v", err))
+			pm.statusManager.SetRuntimeReadyConditionFromError(workload, err, conditions.ReasonRuntimeSelecting)
 			return err
 		}
+		if pm.recordBinding(workload, selectedBackend, selection.ConfigHash(orchestratorConfig)) {
+			pm.audit(ctx, workload, scorev1b1.AuditActionBackendSelected,
+				fmt.Sprintf("Selected backend %s from profile %s", selectedBackend.BackendID, selectedBackend.Profile),
+				map[string]string{
+					"profile":        selectedBackend.Profile,
+					"backendId":      selectedBackend.BackendID,
+					"runtimeClass":   selectedBackend.RuntimeClass,
+					"templateDigest": workload.Status.Binding.TemplateDigest,
+				})
+		}
 
-		if err := reconcile.UpsertWorkloadPlan(ctx, pm.client, workload, claims, selectedBackend); err != nil {
+		applyCtx, applySpan := tracing.StartSpan(ctx, "PlanManager.ApplyPlan", tracing.WorkloadAttributes(workload)...)
+		// Plan stage policies see the selected backend and the containers its profile adds; a denied workload gets no plan
+		effective := reconcile.EffectiveWorkload(workload, selectedBackend.WorkloadDefaults)
+		err = pm.checkPolicies(effective, orchestratorConfig, scorev1b1.PolicyStagePlan, selectedBackend)
+		if err == nil {
+			// Templates that fail verification never reach a runtime
+			err = pm.verifier.Verify(applyCtx, pm.client, orchestratorConfig.Spec.SupplyChain, &selectedBackend.Template)
+		}
+		if err == nil {
+			err = checkImagePolicy(effective, selectedBackend)
+		}
+		if err == nil {
+			// Bad template values are reported before the plan is created rather than when the runtime renders it
+			err = reconcile.ValidateTemplateValues(applyCtx, pm.client, effective, claims, &selectedBackend.Template, selectedBackend.ValuesFrom)
+		}
+		var namespace string
+		if err == nil {
+			namespace, err = pm.ensureEnvironmentNamespace(applyCtx, workload, orchestratorConfig, selectedBackend)
+		}
+		var write reconcile.PlanWrite
+		if err == nil {
+			write, err = reconcile.UpsertWorkloadPlan(applyCtx, pm.client, workload, claims, selectedBackend, orchestratorConfig.Spec.Defaults, orchestratorConfig.Metadata.Generation, namespace,
+				pm.imageResolver(workload, orchestratorConfig, selectedBackend))
+		}
+		tracing.RecordError(applySpan, err)
+		applySpan.End()
+		if errors.Is(err, reconcile.ErrPlanRolledBack) {
+			// The restored plan keeps running; RuntimeReady reports the rollback until the Workload changes
+			log.Info("Rolled back WorkloadPlan after a failed rollout", "reason", err.Error())
+			pm.recorder.Eventf(workload, EventTypeWarning, EventReasonRolledBack, "Rolled back: %v", err)
+			return nil
+		}
+		if errors.Is(err, reconcile.ErrPlanMigrating) {
+			// The old plan is being deleted; its removal triggers the reconcile that creates the new one
+			log.Info("Migrating WorkloadPlan to the newly selected backend", "reason", err.Error())
+			pm.statusManager.SetRuntimeReadyCondition(workload, false, conditions.ReasonRuntimeSelecting, "Runtime is migrating to the newly selected backend")
+			return nil
+		}
+		if err != nil {
 			log.Error(err, "Failed to upsert WorkloadPlan")
 
-			// Check if this is a projection error (missing outputs or unresolved placeholders)
-			if strings.Contains(err.Error(), "missing required outputs for projection") ||
-				strings.Contains(err.Error(), "unresolved placeholders") {
-				pm.statusManager.SetRuntimeReadyCondition(
-					workload,
-					false,
-					conditions.ReasonProjectionError,
-					conditions.MessageProjectionError)
-				pm.recorder.Eventf(workload, EventTypeWarning, EventReasonProjectionError, conditions.MessageProjectionError)
-
-				// Log detailed error information while keeping user messages neutral
-				if strings.Contains(err.Error(), "parse error") {
-					log.Error(err, "Failed to parse composed values; treated as unresolved")
-				}
+			// Map the failure onto the canonical reason vocabulary
+			reason := pm.statusManager.SetRuntimeReadyConditionFromError(workload, err, conditions.ReasonRuntimeDegraded)
+			if reason == conditions.ReasonPolicyViolation {
+				// Retrying cannot help; a change to the Workload or the policies triggers the next attempt
+				pm.recorder.Eventf(workload, EventTypeWarning, EventReasonPolicyViolation, "%v", err)
+				return nil
+			}
+			if errors.Is(err, environment.ErrUnknownEnvironment) {
+				// Likewise, relabeling the Workload or configuring the environment triggers the next attempt
+				pm.recorder.Eventf(workload, EventTypeWarning, EventReasonPlanError, "Failed to create workload plan: %v", err)
+				return nil
+			}
+			if reason == conditions.ReasonSupplyChainError {
+				pm.recorder.Eventf(workload, EventTypeWarning, EventReasonSupplyChainError, "%s", conditions.MessageForError(err, reason))
+				return err
+			}
+			if reason == conditions.ReasonProjectionError {
+				pm.recorder.Eventf(workload, EventTypeWarning, EventReasonProjectionError, "%s", conditions.MessageForError(err, reason))
 				return err
 			}
 
@@ -119,6 +212,7 @@ func (pm *PlanManager) EnsurePlan(ctx context.Context, workload *scorev1b1.Workl
 			return err
 		}
 		pm.recorder.Eventf(workload, EventTypeNormal, EventReasonPlanCreated, "WorkloadPlan created successfully")
+		pm.auditPlanWrite(ctx, workload, selectedBackend, write)
 	} else {
 		log.V(1).Info("Claims are not ready yet", "ready", agg.Ready, "reason", agg.Reason, "message", agg.Message)
 	}
@@ -126,6 +220,25 @@ func (pm *PlanManager) EnsurePlan(ctx context.Context, workload *scorev1b1.Workl
 	return nil
 }
 
+// PreviewPlan computes the WorkloadPlan that would be emitted for the workload without creating it.
+// The RuntimeReady condition reflects the outcome of the preview.
+func (pm *PlanManager) PreviewPlan(ctx context.Context, workload *scorev1b1.Workload, claims []scorev1b1.ResourceClaim) (*scorev1b1.WorkloadPreview, error) {
+	selectedBackend, err := pm.SelectBackend(ctx, workload)
+	if err != nil {
+		pm.statusManager.SetRuntimeReadyConditionFromError(workload, err, conditions.ReasonRuntimeSelecting)
+		return nil, err
+	}
+
+	preview, err := reconcile.PreviewWorkloadPlan(ctx, pm.client, workload, claims, selectedBackend)
+	if err != nil {
+		pm.statusManager.SetRuntimeReadyConditionFromError(workload, err, conditions.ReasonProjectionError)
+		return nil, err
+	}
+
+	pm.statusManager.SetRuntimeReadyCondition(workload, false, conditions.ReasonDryRun, conditions.MessageDryRun)
+	return preview, nil
+}
+
 // GetPlan retrieves the WorkloadPlan for a given Workload
 func (pm *PlanManager) GetPlan(ctx context.Context, workload *scorev1b1.Workload) (*scorev1b1.WorkloadPlan, error) {
 	planList := &scorev1b1.WorkloadPlanList{}
@@ -137,7 +250,7 @@ func (pm *PlanManager) GetPlan(ctx context.Context, workload *scorev1b1.Workload
 		// Fallback: use label selector when indexer is not available (e.g., in tests)
 		err = pm.client.List(ctx, planList,
 			client.InNamespace(workload.Namespace),
-			client.MatchingLabels{"score.dev/workload": workload.Name})
+			client.MatchingLabels{meta.LabelWorkload: workload.Name})
 	}
 
 	if err != nil {
@@ -145,7 +258,7 @@ func (pm *PlanManager) GetPlan(ctx context.Context, workload *scorev1b1.Workload
 	}
 
 	if len(planList.Items) == 0 {
-		return nil, errors.NewNotFound(scorev1b1.GroupVersion.WithResource("workloadplans").GroupResource(), workload.Name)
+		return nil, apierrors.NewNotFound(scorev1b1.GroupVersion.WithResource("workloadplans").GroupResource(), workload.Name)
 	}
 
 	if len(planList.Items) > 1 {
@@ -155,29 +268,292 @@ func (pm *PlanManager) GetPlan(ctx context.Context, workload *scorev1b1.Workload
 	return &planList.Items[0], nil
 }
 
-// SelectBackend selects the backend for the workload using deterministic profile selection pipeline
+// SelectBackend selects the backend for the workload using deterministic profile selection pipeline.
+// A previously recorded selection is kept as long as the backend remains a candidate, unless the
+// reselect-on-change policy is configured and the selection-relevant configuration changed.
 func (pm *PlanManager) SelectBackend(ctx context.Context, workload *scorev1b1.Workload) (*selection.SelectedBackend, error) {
+	selectedBackend, _, err := pm.selectBackend(ctx, workload)
+	return selectedBackend, err
+}
+
+// LoadConfig returns the OrchestratorConfig, so that phases checking a Workload against it load it once
+func (pm *PlanManager) LoadConfig(ctx context.Context) (*scorev1b1.OrchestratorConfig, error) {
+	orchestratorConfig, err := pm.configLoader.LoadConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load orchestrator config: %w", err)
+	}
+	return orchestratorConfig, nil
+}
+
+// WorkloadKind returns the kind the workload is materialized as under its selected profile.
+// Only profile selection runs, so no backend needs to be available.
+func (pm *PlanManager) WorkloadKind(workload *scorev1b1.Workload, orchestratorConfig *scorev1b1.OrchestratorConfig) (string, error) {
+	profile, err := selection.NewProfileSelector(orchestratorConfig, pm.client).SelectProfile(workload)
+	if err != nil {
+		return "", err
+	}
+
+	kind, _ := reconcile.WorkloadKind(workload, profile.Kind)
+	return kind, nil
+}
+
+// CheckAdmissionPolicies evaluates the Admission stage policies of the OrchestratorConfig against the workload.
+// Violated Warn policies are reported as a Warning event; violated Deny policies are returned as a *policy.Denial.
+func (pm *PlanManager) CheckAdmissionPolicies(workload *scorev1b1.Workload, orchestratorConfig *scorev1b1.OrchestratorConfig) error {
+	return pm.checkPolicies(workload, orchestratorConfig, scorev1b1.PolicyStageAdmission, nil)
+}
+
+// ValidateResourceParams validates the params of the resources of the workload against the params schemas of
+// their provisioners, so that invalid params are reported on the Workload before claims are created.
+// The first resource, by key, whose params violate the schema is returned as a *valuesschema.ValidationError
+// wrapped with the field path of its params.
+func (pm *PlanManager) ValidateResourceParams(workload *scorev1b1.Workload, orchestratorConfig *scorev1b1.OrchestratorConfig) error {
+	keys := make([]string, 0, len(workload.Spec.Resources))
+	for key := range workload.Spec.Resources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		resource := workload.Spec.Resources[key]
+		for i := range orchestratorConfig.Spec.Provisioners {
+			spec := &orchestratorConfig.Spec.Provisioners[i]
+			if spec.Type != resource.Type {
+				continue
+			}
+			if err := provisioner.ValidateParams(spec, resource.Params); err != nil {
+				return fmt.Errorf("spec.resources.%s.params: %w", key, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateSelectionHints validates spec.profile and spec.requirements of the workload against the profiles and
+// backends of the OrchestratorConfig. Hints that match none are returned wrapping selection.ErrInvalidHint.
+func (pm *PlanManager) ValidateSelectionHints(workload *scorev1b1.Workload, orchestratorConfig *scorev1b1.OrchestratorConfig) error {
+	if workload.Spec.Profile == nil && len(workload.Spec.Requirements) == 0 {
+		return nil
+	}
+	return selection.ValidateHints(workload, orchestratorConfig)
+}
+
+// PropagatedMetadata returns the labels and annotations of the workload selected by the propagation policy
+// of the OrchestratorConfig, or nil when the policy selects none
+func (pm *PlanManager) PropagatedMetadata(ctx context.Context, workload *scorev1b1.Workload) (*scorev1b1.PropagatedMetadata, error) {
+	orchestratorConfig, err := pm.configLoader.LoadConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load orchestrator config: %w", err)
+	}
+	return propagation.Select(orchestratorConfig.Spec.Defaults.Propagation, workload.Labels, workload.Annotations), nil
+}
+
+// EnvironmentLabel returns the Workload label naming the environment of a Workload
+func (pm *PlanManager) EnvironmentLabel(ctx context.Context) (string, error) {
+	orchestratorConfig, err := pm.configLoader.LoadConfig(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to load orchestrator config: %w", err)
+	}
+	return environment.Label(orchestratorConfig.Spec.Namespaces), nil
+}
+
+// ensureEnvironmentNamespace provisions the namespace of the environment the workload declares and returns its name.
+// Workloads without an environment, and Workloads delivered to a remote cluster, run in their own namespace.
+func (pm *PlanManager) ensureEnvironmentNamespace(ctx context.Context, workload *scorev1b1.Workload, orchestratorConfig *scorev1b1.OrchestratorConfig, selectedBackend *selection.SelectedBackend) (string, error) {
+	if selectedBackend.Target != "" {
+		return "", nil
+	}
+	namespace, env, err := environment.Namespace(orchestratorConfig.Spec.Namespaces, workload)
+	if err != nil || namespace == "" {
+		return "", err
+	}
+	if err := environment.Ensure(ctx, pm.client, namespace, workload.Namespace, env); err != nil {
+		return "", err
+	}
+	return namespace, nil
+}
+
+// checkPolicies evaluates the policies of the given stage and reports violated Warn policies as a Warning event.
+// The event is only emitted when the warnings differ from the ones last reported for the workload at that stage.
+func (pm *PlanManager) checkPolicies(workload *scorev1b1.Workload, orchestratorConfig *scorev1b1.OrchestratorConfig, stage string, selectedBackend *selection.SelectedBackend) error {
+	warnings, err := policy.Evaluate(orchestratorConfig.Spec.Policies, stage, policy.Input{Workload: workload, Backend: selectedBackend})
+	message := ""
+	if len(warnings) > 0 {
+		message = policy.FormatWarnings(warnings)
+	}
+	if pm.recordWarnings(policyWarningKey{workload: client.ObjectKeyFromObject(workload), stage: stage}, message) {
+		pm.recorder.Eventf(workload, EventTypeWarning, EventReasonPolicyWarning, "%s", message)
+	}
+	return err
+}
+
+// recordWarnings stores the policy warnings reported under the key and reports whether non-empty warnings changed
+func (pm *PlanManager) recordWarnings(key policyWarningKey, message string) bool {
+	pm.warningsMu.Lock()
+	defer pm.warningsMu.Unlock()
+
+	if message == "" {
+		delete(pm.warnings, key)
+		return false
+	}
+	if pm.warnings[key] == message {
+		return false
+	}
+	pm.warnings[key] = message
+	return true
+}
+
+// ForgetWorkload drops the policy warnings recorded for a Workload whose deletion completed
+func (pm *PlanManager) ForgetWorkload(workload *scorev1b1.Workload) {
+	pm.warningsMu.Lock()
+	defer pm.warningsMu.Unlock()
+
+	key := client.ObjectKeyFromObject(workload)
+	delete(pm.warnings, policyWarningKey{workload: key, stage: scorev1b1.PolicyStageAdmission})
+	delete(pm.warnings, policyWarningKey{workload: key, stage: scorev1b1.PolicyStagePlan})
+}
+
+// checkImagePolicy denies the workload when its profile disallows images tagged "latest" and it uses one
+func checkImagePolicy(workload *scorev1b1.Workload, selectedBackend *selection.SelectedBackend) error {
+	if selectedBackend.ImagePolicy == nil || !selectedBackend.ImagePolicy.DisallowLatest {
+		return nil
+	}
+	paths := reconcile.LatestImages(workload)
+	if len(paths) == 0 {
+		return nil
+	}
+	return &policy.Denial{Violations: []policy.Violation{{
+		Policy:  "imagePolicy",
+		Message: fmt.Sprintf("profile %s disallows images tagged latest, used by %s", selectedBackend.Profile, strings.Join(paths, ", ")),
+	}}}
+}
+
+// imageResolver returns the resolver pinning the images of the workload by digest through the registries of
+// the supply-chain configuration, or nil when neither the profile nor the score.dev/image-digests annotation
+// enables it
+func (pm *PlanManager) imageResolver(workload *scorev1b1.Workload, orchestratorConfig *scorev1b1.OrchestratorConfig, selectedBackend *selection.SelectedBackend) reconcile.ImageResolver {
+	enabled := selectedBackend.ImagePolicy != nil && selectedBackend.ImagePolicy.ResolveDigests
+	switch workload.Annotations[meta.AnnotationImageDigests] {
+	case meta.ImageDigestsEnabled:
+		enabled = true
+	case meta.ImageDigestsDisabled:
+		enabled = false
+	}
+	if !enabled {
+		return nil
+	}
+	spec := orchestratorConfig.Spec.SupplyChain
+	return func(ctx context.Context, image string) (string, error) {
+		return supplychain.ResolveImage(ctx, pm.client, spec, image)
+	}
+}
+
+// selectBackend applies the reselection policy and returns the selected backend with the configuration it was selected from
+func (pm *PlanManager) selectBackend(ctx context.Context, workload *scorev1b1.Workload) (*selection.SelectedBackend, *scorev1b1.OrchestratorConfig, error) {
 	log := ctrl.LoggerFrom(ctx)
 
 	// Load Orchestrator Configuration
 	orchestratorConfig, err := pm.configLoader.LoadConfig(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to load orchestrator config: %w", err)
+		return nil, nil, fmt.Errorf("failed to load orchestrator config: %w", err)
 	}
 
 	// Create ProfileSelector
 	selector := selection.NewProfileSelector(orchestratorConfig, pm.client)
+	configHash := selection.ConfigHash(orchestratorConfig)
+
+	// Keep the recorded backend according to the reselection policy
+	var selectedBackend *selection.SelectedBackend
+	previous := workload.Status.Binding
+	if selection.KeepBinding(orchestratorConfig.Spec.Defaults.ReselectionPolicy, previous, configHash) {
+		selectedBackend, err = selector.SelectBackendByID(ctx, workload, previous.BackendID)
+		if err != nil {
+			log.V(1).Info("Recorded backend is no longer eligible, reselecting", "backend", previous.BackendID, "reason", err.Error())
+			selectedBackend = nil
+		}
+	}
 
 	// Select backend using deterministic pipeline
-	selectedBackend, err := selector.SelectBackend(ctx, workload)
-	if err != nil {
-		return nil, fmt.Errorf("failed to select backend: %w", err)
+	if selectedBackend == nil {
+		selectedBackend, err = selector.SelectBackend(ctx, workload)
+		if err != nil {
+			pm.recorder.Eventf(workload, EventTypeWarning, EventReasonSelectionExplained,
+				"Backend selection failed: %s", selection.Explain(ctx, pm.client, workload, orchestratorConfig).Summary())
+			return nil, nil, fmt.Errorf("failed to select backend: %w", err)
+		}
+	}
+
+	if debugLog := log.V(2); debugLog.Enabled() {
+		debugLog.Info("Explained backend selection", "explanation", selection.Explain(ctx, pm.client, workload, orchestratorConfig).Summary())
 	}
 
 	log.V(1).Info("Selected backend for workload",
+		"profile", selectedBackend.Profile,
 		"backend", selectedBackend.BackendID,
 		"runtime", selectedBackend.RuntimeClass,
 		"template", fmt.Sprintf("%s:%s", selectedBackend.Template.Kind, selectedBackend.Template.Ref))
 
-	return selectedBackend, nil
+	return selectedBackend, orchestratorConfig, nil
+}
+
+// recordBinding stores the selected profile and backend in the workload status and reports whether the binding changed.
+// Events carrying the deciding criteria are emitted only when the binding changes.
+func (pm *PlanManager) recordBinding(workload *scorev1b1.Workload, selectedBackend *selection.SelectedBackend, configHash string) bool {
+	previous := workload.Status.Binding
+	binding := &scorev1b1.WorkloadBinding{
+		Profile:        selectedBackend.Profile,
+		BackendID:      selectedBackend.BackendID,
+		RuntimeClass:   selectedBackend.RuntimeClass,
+		TemplateRef:    selectedBackend.Template.Ref,
+		TemplateDigest: selection.TemplateDigest(selectedBackend.Template),
+		ConfigHash:     configHash,
+	}
+
+	if previous != nil && previous.Profile == binding.Profile && previous.BackendID == binding.BackendID &&
+		previous.RuntimeClass == binding.RuntimeClass && previous.TemplateDigest == binding.TemplateDigest {
+		// Unchanged binding: keep the original selection time
+		binding.SelectedAt = previous.SelectedAt
+		workload.Status.Binding = binding
+		return false
+	}
+
+	now := metav1.Now()
+	binding.SelectedAt = &now
+	workload.Status.Binding = binding
+
+	pm.recorder.Eventf(workload, EventTypeNormal, EventReasonBackendSelected,
+		"Selected backend %s from profile %s (priority %d, version %s)",
+		selectedBackend.BackendID, selectedBackend.Profile, selectedBackend.Priority, selectedBackend.Version)
+	if previous != nil && previous.BackendID != "" && previous.BackendID != binding.BackendID {
+		pm.recorder.Eventf(workload, EventTypeNormal, EventReasonBackendMigrated,
+			"Backend reselected from %s to %s", previous.BackendID, binding.BackendID)
+	}
+	return true
+}
+
+// auditPlanWrite records the creation or update of the WorkloadPlan in the audit trail
+func (pm *PlanManager) auditPlanWrite(ctx context.Context, workload *scorev1b1.Workload, selectedBackend *selection.SelectedBackend, write reconcile.PlanWrite) {
+	var action string
+	switch write.Operation {
+	case controllerutil.OperationResultCreated:
+		action = scorev1b1.AuditActionPlanCreated
+	case controllerutil.OperationResultUpdated:
+		action = scorev1b1.AuditActionPlanUpdated
+	default:
+		return
+	}
+	pm.audit(ctx, workload, action,
+		fmt.Sprintf("WorkloadPlan %s for backend %s", strings.ToLower(string(write.Operation)), selectedBackend.BackendID),
+		map[string]string{
+			"backendId":    selectedBackend.BackendID,
+			"runtimeClass": selectedBackend.RuntimeClass,
+			"valuesHash":   write.ValuesHash,
+		})
+}
+
+// audit records a decision about the workload. Failures are logged rather than returned,
+// so that the audit trail never blocks orchestration.
+func (pm *PlanManager) audit(ctx context.Context, workload *scorev1b1.Workload, action, message string, details map[string]string) {
+	if err := pm.auditor.Record(ctx, workload.Namespace, audit.WorkloadRef(workload), action, message, details); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to record audit decision", "action", action)
+	}
 }
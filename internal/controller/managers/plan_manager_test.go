@@ -22,18 +22,25 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
 	"github.com/cappyzawa/score-orchestrator/internal/conditions"
 	"github.com/cappyzawa/score-orchestrator/internal/config"
 	"github.com/cappyzawa/score-orchestrator/internal/endpoint"
+	"github.com/cappyzawa/score-orchestrator/internal/policy"
+	"github.com/cappyzawa/score-orchestrator/internal/selection"
 	"github.com/cappyzawa/score-orchestrator/internal/status"
+	"github.com/cappyzawa/score-orchestrator/internal/valuesschema"
 )
 
 // Mock implementations
@@ -73,6 +80,13 @@ func (m *mockEventRecorder) Eventf(object runtime.Object, eventtype, reason, mes
 	m.events = append(m.events, reason)
 }
 
+// loadConfig returns the OrchestratorConfig of the config loader of the PlanManager
+func loadConfig(pm *PlanManager) *scorev1b1.OrchestratorConfig {
+	orchestratorConfig, err := pm.LoadConfig(context.Background())
+	Expect(err).NotTo(HaveOccurred())
+	return orchestratorConfig
+}
+
 var _ = Describe("PlanManager", func() {
 	var (
 		scheme   *runtime.Scheme
@@ -122,7 +136,7 @@ var _ = Describe("PlanManager", func() {
 	Describe("EnsurePlan", func() {
 		Context("when claims are ready", func() {
 			It("should create plan", func() {
-				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&scorev1b1.WorkloadPlan{}).WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).Build()
 				endpointDeriver := endpoint.NewEndpointDeriver(fakeClient)
 				mockRecorder := &mockEventRecorder{}
 
@@ -160,7 +174,7 @@ var _ = Describe("PlanManager", func() {
 
 				// Create a status manager for the test
 				statusManager := NewStatusManager(fakeClient, scheme, mockRecorder, endpointDeriver)
-				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager)
+				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager, nil)
 
 				agg := status.ClaimAggregation{
 					Ready:   true,
@@ -188,14 +202,14 @@ var _ = Describe("PlanManager", func() {
 
 		Context("when claims are not ready", func() {
 			It("should skip plan creation", func() {
-				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&scorev1b1.WorkloadPlan{}).WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).Build()
 				mockConfigLoader := &mockConfigLoader{}
 				endpointDeriver := endpoint.NewEndpointDeriver(fakeClient)
 				mockRecorder := &mockEventRecorder{}
 
 				// Create a status manager for the test
 				statusManager := NewStatusManager(fakeClient, scheme, mockRecorder, endpointDeriver)
-				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager)
+				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager, nil)
 
 				agg := status.ClaimAggregation{
 					Ready:   false,
@@ -218,7 +232,7 @@ var _ = Describe("PlanManager", func() {
 
 		Context("when backend selection fails", func() {
 			It("should handle error", func() {
-				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&scorev1b1.WorkloadPlan{}).WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).Build()
 				endpointDeriver := endpoint.NewEndpointDeriver(fakeClient)
 				mockRecorder := &mockEventRecorder{}
 
@@ -231,7 +245,7 @@ var _ = Describe("PlanManager", func() {
 
 				// Create a status manager for the test
 				statusManager := NewStatusManager(fakeClient, scheme, mockRecorder, endpointDeriver)
-				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager)
+				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager, nil)
 
 				agg := status.ClaimAggregation{
 					Ready:   true,
@@ -252,7 +266,7 @@ var _ = Describe("PlanManager", func() {
 
 		Context("when placeholders are unresolved", func() {
 			It("should skip plan creation and set ProjectionError", func() {
-				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&scorev1b1.WorkloadPlan{}).WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).Build()
 				endpointDeriver := endpoint.NewEndpointDeriver(fakeClient)
 				mockRecorder := &mockEventRecorder{}
 
@@ -289,7 +303,7 @@ var _ = Describe("PlanManager", func() {
 
 				// Create a status manager for the test
 				statusManager := NewStatusManager(fakeClient, scheme, mockRecorder, endpointDeriver)
-				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager)
+				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager, nil)
 
 				// Create workload with unresolved placeholders
 				workloadWithPlaceholders := &scorev1b1.Workload{
@@ -345,11 +359,224 @@ var _ = Describe("PlanManager", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(planList.Items).To(BeEmpty())
 
-				// Verify error event was recorded (either ProjectionError or PlanError)
-				Expect(mockRecorder.events).To(HaveLen(1))
-				Expect(mockRecorder.events[0]).To(BeElementOf(EventReasonProjectionError, EventReasonPlanError))
+				// Verify error event was recorded after the backend binding (either ProjectionError or PlanError)
+				Expect(mockRecorder.events).To(HaveLen(2))
+				Expect(mockRecorder.events[0]).To(Equal(EventReasonBackendSelected))
+				Expect(mockRecorder.events[1]).To(BeElementOf(EventReasonProjectionError, EventReasonPlanError))
+			})
+		})
+
+		Context("when template values violate the values schema", func() {
+			It("should skip plan creation and set ProjectionError naming the values", func() {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&scorev1b1.WorkloadPlan{}).WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).Build()
+				endpointDeriver := endpoint.NewEndpointDeriver(fakeClient)
+				mockRecorder := &mockEventRecorder{}
+
+				testConfig := &scorev1b1.OrchestratorConfig{
+					Spec: scorev1b1.OrchestratorConfigSpec{
+						Profiles: []scorev1b1.ProfileSpec{
+							{
+								Name: "test-profile",
+								Backends: []scorev1b1.BackendSpec{
+									{
+										BackendId:    "test-backend",
+										RuntimeClass: "kubernetes",
+										Priority:     100,
+										Template: scorev1b1.TemplateSpec{
+											Kind:   "manifests",
+											Ref:    "test-template:latest",
+											Values: &runtime.RawExtension{Raw: []byte(`{"replicas":"two"}`)},
+											ValuesSchema: &scorev1b1.ValuesSchemaSpec{
+												Inline: &runtime.RawExtension{Raw: []byte(`{"properties":{"replicas":{"type":"integer"}}}`)},
+											},
+										},
+									},
+								},
+							},
+						},
+						Defaults: scorev1b1.DefaultsSpec{
+							Profile: "test-profile",
+						},
+					},
+				}
+
+				mockConfigLoader := &mockConfigLoader{
+					loadConfigFunc: func(ctx context.Context) (*scorev1b1.OrchestratorConfig, error) {
+						return testConfig, nil
+					},
+				}
+
+				statusManager := NewStatusManager(fakeClient, scheme, mockRecorder, endpointDeriver)
+				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager, nil)
+
+				err := pm.EnsurePlan(context.Background(), workload, claims, status.ClaimAggregation{Ready: true})
+				Expect(err).To(MatchError(valuesschema.ErrViolation))
+
+				planList := &scorev1b1.WorkloadPlanList{}
+				Expect(fakeClient.List(context.Background(), planList, client.InNamespace("test-ns"))).To(Succeed())
+				Expect(planList.Items).To(BeEmpty())
+
+				runtimeReady := apimeta.FindStatusCondition(workload.Status.Conditions, conditions.ConditionRuntimeReady)
+				Expect(runtimeReady).NotTo(BeNil())
+				Expect(runtimeReady.Reason).To(Equal(conditions.ReasonProjectionError))
+				Expect(runtimeReady.Message).To(ContainSubstring("/replicas: must be of type integer"))
+				Expect(mockRecorder.events).To(ContainElement(EventReasonProjectionError))
 			})
 		})
+
+		Context("when a Plan stage policy denies the selected backend", func() {
+			It("should skip plan creation and set PolicyViolation", func() {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&scorev1b1.WorkloadPlan{}).WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).Build()
+				endpointDeriver := endpoint.NewEndpointDeriver(fakeClient)
+				mockRecorder := &mockEventRecorder{}
+
+				testConfig := &scorev1b1.OrchestratorConfig{
+					Spec: scorev1b1.OrchestratorConfigSpec{
+						Profiles: []scorev1b1.ProfileSpec{
+							{
+								Name: "test-profile",
+								Backends: []scorev1b1.BackendSpec{
+									{
+										BackendId:    "test-backend",
+										RuntimeClass: "kubernetes",
+										Priority:     100,
+										Template:     scorev1b1.TemplateSpec{Kind: "manifests", Ref: "test-template:latest"},
+									},
+								},
+							},
+						},
+						Defaults: scorev1b1.DefaultsSpec{
+							Profile: "test-profile",
+						},
+						Policies: []scorev1b1.PolicySpec{
+							{
+								Name:       "pinned-images",
+								Stage:      scorev1b1.PolicyStagePlan,
+								Expression: `profile != "test-profile" || workload.spec.containers.all(c, !workload.spec.containers[c].image.endsWith(":latest"))`,
+								Message:    "images must not use the latest tag",
+							},
+							{
+								Name:       "owner-label",
+								Stage:      scorev1b1.PolicyStagePlan,
+								Mode:       scorev1b1.PolicyModeWarn,
+								Expression: `has(workload.metadata.labels) && "owner" in workload.metadata.labels`,
+							},
+						},
+					},
+				}
+
+				mockConfigLoader := &mockConfigLoader{
+					loadConfigFunc: func(ctx context.Context) (*scorev1b1.OrchestratorConfig, error) {
+						return testConfig, nil
+					},
+				}
+
+				statusManager := NewStatusManager(fakeClient, scheme, mockRecorder, endpointDeriver)
+				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager, nil)
+
+				Expect(pm.EnsurePlan(context.Background(), workload, claims, status.ClaimAggregation{Ready: true})).To(Succeed())
+
+				planList := &scorev1b1.WorkloadPlanList{}
+				Expect(fakeClient.List(context.Background(), planList, client.InNamespace("test-ns"))).To(Succeed())
+				Expect(planList.Items).To(BeEmpty())
+
+				runtimeReady := apimeta.FindStatusCondition(workload.Status.Conditions, conditions.ConditionRuntimeReady)
+				Expect(runtimeReady).NotTo(BeNil())
+				Expect(runtimeReady.Reason).To(Equal(conditions.ReasonPolicyViolation))
+				Expect(runtimeReady.Message).To(ContainSubstring("pinned-images: images must not use the latest tag"))
+				Expect(mockRecorder.events).To(ContainElements(EventReasonPolicyWarning, EventReasonPolicyViolation))
+			})
+		})
+	})
+
+	Describe("CheckAdmissionPolicies", func() {
+		newManager := func(policies ...scorev1b1.PolicySpec) (*PlanManager, *mockEventRecorder) {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			endpointDeriver := endpoint.NewEndpointDeriver(fakeClient)
+			mockRecorder := &mockEventRecorder{}
+			configLoader := &mockConfigLoader{
+				loadConfigFunc: func(ctx context.Context) (*scorev1b1.OrchestratorConfig, error) {
+					return &scorev1b1.OrchestratorConfig{Spec: scorev1b1.OrchestratorConfigSpec{Policies: policies}}, nil
+				},
+			}
+			statusManager := NewStatusManager(fakeClient, scheme, mockRecorder, endpointDeriver)
+			return NewPlanManager(fakeClient, scheme, mockRecorder, configLoader, endpointDeriver, statusManager, nil), mockRecorder
+		}
+
+		It("should deny workloads violating a Deny policy", func() {
+			pm, _ := newManager(scorev1b1.PolicySpec{Name: "single-container", Expression: "size(workload.spec.containers) == 2"})
+
+			err := pm.CheckAdmissionPolicies(workload, loadConfig(pm))
+			Expect(err).To(MatchError(policy.ErrPolicyDenied))
+			Expect(err.Error()).To(ContainSubstring("single-container"))
+		})
+
+		It("should only report violated Warn policies and ignore Plan stage policies", func() {
+			pm, recorder := newManager(
+				scorev1b1.PolicySpec{Name: "owner-label", Mode: scorev1b1.PolicyModeWarn, Expression: "has(workload.metadata.labels)"},
+				scorev1b1.PolicySpec{Name: "never", Stage: scorev1b1.PolicyStagePlan, Expression: "false"},
+			)
+
+			Expect(pm.CheckAdmissionPolicies(workload, loadConfig(pm))).To(Succeed())
+			Expect(recorder.events).To(Equal([]string{EventReasonPolicyWarning}))
+		})
+
+		It("should only report Warn policies again once their result changes", func() {
+			pm, recorder := newManager(
+				scorev1b1.PolicySpec{Name: "owner-label", Mode: scorev1b1.PolicyModeWarn, Expression: "has(workload.metadata.labels)"},
+			)
+
+			Expect(pm.CheckAdmissionPolicies(workload, loadConfig(pm))).To(Succeed())
+			Expect(pm.CheckAdmissionPolicies(workload, loadConfig(pm))).To(Succeed())
+			Expect(recorder.events).To(HaveLen(1))
+
+			workload.Labels = map[string]string{"owner": "team-a"}
+			Expect(pm.CheckAdmissionPolicies(workload, loadConfig(pm))).To(Succeed())
+			workload.Labels = nil
+			Expect(pm.CheckAdmissionPolicies(workload, loadConfig(pm))).To(Succeed())
+			Expect(recorder.events).To(HaveLen(2))
+		})
+	})
+
+	Describe("ValidateResourceParams", func() {
+		newManager := func(provisioners ...scorev1b1.ProvisionerSpec) *PlanManager {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			endpointDeriver := endpoint.NewEndpointDeriver(fakeClient)
+			mockRecorder := &mockEventRecorder{}
+			configLoader := &mockConfigLoader{
+				loadConfigFunc: func(ctx context.Context) (*scorev1b1.OrchestratorConfig, error) {
+					return &scorev1b1.OrchestratorConfig{Spec: scorev1b1.OrchestratorConfigSpec{Provisioners: provisioners}}, nil
+				},
+			}
+			statusManager := NewStatusManager(fakeClient, scheme, mockRecorder, endpointDeriver)
+			return NewPlanManager(fakeClient, scheme, mockRecorder, configLoader, endpointDeriver, statusManager, nil)
+		}
+		volumeProvisioner := scorev1b1.ProvisionerSpec{
+			Type:         "volume",
+			ParamsSchema: &runtime.RawExtension{Raw: []byte(`{"properties":{"size":{"type":"string"}},"additionalProperties":false}`)},
+		}
+
+		It("should name the resource and the path of params violating the schema", func() {
+			workload.Spec.Resources = map[string]scorev1b1.ResourceSpec{
+				"data": {Type: "volume", Params: &apiextv1.JSON{Raw: []byte(`{"sise":"10Gi"}`)}},
+			}
+
+			pm := newManager(volumeProvisioner)
+			err := pm.ValidateResourceParams(workload, loadConfig(pm))
+			Expect(err).To(MatchError(valuesschema.ErrViolation))
+			Expect(err.Error()).To(ContainSubstring("spec.resources.data.params"))
+			Expect(err.Error()).To(ContainSubstring("/sise"))
+		})
+
+		It("should accept valid params and types without a schema", func() {
+			workload.Spec.Resources = map[string]scorev1b1.ResourceSpec{
+				"data":  {Type: "volume", Params: &apiextv1.JSON{Raw: []byte(`{"size":"10Gi"}`)}},
+				"cache": {Type: "redis", Params: &apiextv1.JSON{Raw: []byte(`{"anything":true}`)}},
+			}
+
+			pm := newManager(volumeProvisioner)
+			Expect(pm.ValidateResourceParams(workload, loadConfig(pm))).To(Succeed())
+		})
 	})
 
 	Describe("GetPlan", func() {
@@ -386,14 +613,14 @@ var _ = Describe("PlanManager", func() {
 
 		Context("when plan exists", func() {
 			It("should return existing plan", func() {
-				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existingPlan).Build()
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existingPlan).WithStatusSubresource(&scorev1b1.WorkloadPlan{}).WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).Build()
 				mockConfigLoader := &mockConfigLoader{}
 				endpointDeriver := endpoint.NewEndpointDeriver(fakeClient)
 				mockRecorder := &mockEventRecorder{}
 
 				// Create a status manager for the test
 				statusManager := NewStatusManager(fakeClient, scheme, mockRecorder, endpointDeriver)
-				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager)
+				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager, nil)
 
 				plan, err := pm.GetPlan(context.Background(), testWorkload)
 				Expect(err).ToNot(HaveOccurred())
@@ -405,14 +632,14 @@ var _ = Describe("PlanManager", func() {
 
 		Context("when plan doesn't exist", func() {
 			It("should return NotFound error", func() {
-				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&scorev1b1.WorkloadPlan{}).WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).Build()
 				mockConfigLoader := &mockConfigLoader{}
 				endpointDeriver := endpoint.NewEndpointDeriver(fakeClient)
 				mockRecorder := &mockEventRecorder{}
 
 				// Create a status manager for the test
 				statusManager := NewStatusManager(fakeClient, scheme, mockRecorder, endpointDeriver)
-				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager)
+				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager, nil)
 
 				plan, err := pm.GetPlan(context.Background(), testWorkload)
 				Expect(err).To(HaveOccurred())
@@ -445,7 +672,7 @@ var _ = Describe("PlanManager", func() {
 
 		Context("when config loading succeeds", func() {
 			It("should return selected backend", func() {
-				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&scorev1b1.WorkloadPlan{}).WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).Build()
 				endpointDeriver := endpoint.NewEndpointDeriver(fakeClient)
 				mockRecorder := &mockEventRecorder{}
 
@@ -482,7 +709,7 @@ var _ = Describe("PlanManager", func() {
 
 				// Create a status manager for the test
 				statusManager := NewStatusManager(fakeClient, scheme, mockRecorder, endpointDeriver)
-				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager)
+				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager, nil)
 
 				selectedBackend, err := pm.SelectBackend(context.Background(), testWorkload)
 				Expect(err).ToNot(HaveOccurred())
@@ -494,7 +721,7 @@ var _ = Describe("PlanManager", func() {
 
 		Context("when config loading fails", func() {
 			It("should handle error", func() {
-				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&scorev1b1.WorkloadPlan{}).WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).Build()
 				endpointDeriver := endpoint.NewEndpointDeriver(fakeClient)
 				mockRecorder := &mockEventRecorder{}
 
@@ -506,7 +733,7 @@ var _ = Describe("PlanManager", func() {
 
 				// Create a status manager for the test
 				statusManager := NewStatusManager(fakeClient, scheme, mockRecorder, endpointDeriver)
-				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager)
+				pm := NewPlanManager(fakeClient, scheme, mockRecorder, mockConfigLoader, endpointDeriver, statusManager, nil)
 
 				selectedBackend, err := pm.SelectBackend(context.Background(), testWorkload)
 				Expect(err).To(HaveOccurred())
@@ -515,4 +742,171 @@ var _ = Describe("PlanManager", func() {
 			})
 		})
 	})
+
+	Describe("WorkloadKind", func() {
+		newManager := func(profileKind string) *PlanManager {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			endpointDeriver := endpoint.NewEndpointDeriver(fakeClient)
+			mockRecorder := &mockEventRecorder{}
+			configLoader := &mockConfigLoader{
+				loadConfigFunc: func(ctx context.Context) (*scorev1b1.OrchestratorConfig, error) {
+					return &scorev1b1.OrchestratorConfig{
+						Spec: scorev1b1.OrchestratorConfigSpec{
+							Profiles: []scorev1b1.ProfileSpec{{Name: "test-profile", Kind: profileKind}},
+							Defaults: scorev1b1.DefaultsSpec{Profile: "test-profile"},
+						},
+					}, nil
+				},
+			}
+			statusManager := NewStatusManager(fakeClient, scheme, mockRecorder, endpointDeriver)
+			return NewPlanManager(fakeClient, scheme, mockRecorder, configLoader, endpointDeriver, statusManager, nil)
+		}
+
+		DescribeTable("resolves the materialized kind from the profile and schedule",
+			func(profileKind string, schedule *string, want string) {
+				workload := &scorev1b1.Workload{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-workload", Namespace: "test-ns"},
+					Spec:       scorev1b1.WorkloadSpec{Schedule: schedule},
+				}
+
+				pm := newManager(profileKind)
+				kind, err := pm.WorkloadKind(workload, loadConfig(pm))
+
+				Expect(err).ToNot(HaveOccurred())
+				Expect(kind).To(Equal(want))
+			},
+			Entry("service profile", "", nil, scorev1b1.WorkloadKindService),
+			Entry("job profile", scorev1b1.WorkloadKindJob, nil, scorev1b1.WorkloadKindJob),
+			Entry("schedule on a service profile", "", ptr.To("0 3 * * *"), scorev1b1.WorkloadKindCronJob),
+		)
+	})
+
+	Describe("backend reselection", func() {
+		var (
+			fakeClient   client.Client
+			mockRecorder *mockEventRecorder
+			testConfig   *scorev1b1.OrchestratorConfig
+			pm           *PlanManager
+		)
+
+		readyClaims := status.ClaimAggregation{Ready: true, Message: "All claims are ready"}
+
+		newBackend := func(id, runtimeClass string, priority int) scorev1b1.BackendSpec {
+			return scorev1b1.BackendSpec{
+				BackendId:    id,
+				RuntimeClass: runtimeClass,
+				Priority:     priority,
+				Template:     scorev1b1.TemplateSpec{Kind: "manifests", Ref: id + "-template:latest"},
+			}
+		}
+
+		// newPlanManager builds a PlanManager whose configuration has a primary backend and
+		// a higher-priority backend added after the workload was bound to the primary one
+		newPlanManager := func(policy, addedRuntimeClass string, objs ...client.Object) {
+			fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).WithStatusSubresource(&scorev1b1.WorkloadPlan{}).WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).Build()
+			endpointDeriver := endpoint.NewEndpointDeriver(fakeClient)
+			mockRecorder = &mockEventRecorder{}
+			testConfig = &scorev1b1.OrchestratorConfig{
+				Spec: scorev1b1.OrchestratorConfigSpec{
+					Profiles: []scorev1b1.ProfileSpec{
+						{
+							Name: "test-profile",
+							Backends: []scorev1b1.BackendSpec{
+								newBackend("primary", "kubernetes", 100),
+								newBackend("added", addedRuntimeClass, 200),
+							},
+						},
+					},
+					Defaults: scorev1b1.DefaultsSpec{Profile: "test-profile", ReselectionPolicy: policy},
+				},
+			}
+			loader := &mockConfigLoader{
+				loadConfigFunc: func(ctx context.Context) (*scorev1b1.OrchestratorConfig, error) {
+					return testConfig, nil
+				},
+			}
+			statusManager := NewStatusManager(fakeClient, scheme, mockRecorder, endpointDeriver)
+			pm = NewPlanManager(fakeClient, scheme, mockRecorder, loader, endpointDeriver, statusManager, nil)
+		}
+
+		bind := func(backendID string, configHash string) {
+			selectedAt := metav1.Now()
+			workload.Status.Binding = &scorev1b1.WorkloadBinding{
+				Profile:      "test-profile",
+				BackendID:    backendID,
+				RuntimeClass: "kubernetes",
+				TemplateRef:  backendID + "-template:latest",
+				TemplateDigest: selection.TemplateDigest(scorev1b1.TemplateSpec{
+					Kind: "manifests",
+					Ref:  backendID + "-template:latest",
+				}),
+				SelectedAt: &selectedAt,
+				ConfigHash: configHash,
+			}
+		}
+
+		DescribeTable("applies the reselection policy to the recorded binding",
+			func(policy, recordedBackend string, configUnchanged bool, wantBackend string, wantMigrated bool) {
+				newPlanManager(policy, "kubernetes")
+				configHash := "stale"
+				if configUnchanged {
+					configHash = selection.ConfigHash(testConfig)
+				}
+				bind(recordedBackend, configHash)
+				selectedAt := workload.Status.Binding.SelectedAt
+
+				Expect(pm.EnsurePlan(context.Background(), workload, claims, readyClaims)).To(Succeed())
+
+				Expect(workload.Status.Binding.BackendID).To(Equal(wantBackend))
+				Expect(workload.Status.Binding.ConfigHash).To(Equal(selection.ConfigHash(testConfig)))
+				Expect(workload.Status.Binding.TemplateDigest).To(Equal(selection.TemplateDigest(newBackend(wantBackend, "kubernetes", 0).Template)))
+				if wantMigrated {
+					Expect(mockRecorder.events).To(ContainElements(EventReasonBackendSelected, EventReasonBackendMigrated))
+				} else {
+					Expect(mockRecorder.events).NotTo(ContainElement(EventReasonBackendMigrated))
+					Expect(workload.Status.Binding.SelectedAt).To(Equal(selectedAt))
+				}
+
+				plan := &scorev1b1.WorkloadPlan{}
+				Expect(fakeClient.Get(context.Background(), client.ObjectKeyFromObject(workload), plan)).To(Succeed())
+				Expect(plan.Spec.Template.Ref).To(Equal(wantBackend + "-template:latest"))
+			},
+			Entry("default policy keeps the selection after a config change", "", "primary", false, "primary", false),
+			Entry("sticky keeps the selection after a config change", scorev1b1.ReselectionPolicySticky, "primary", false, "primary", false),
+			Entry("reselect-on-change keeps the selection while the config is unchanged", scorev1b1.ReselectionPolicyReselectOnChange, "primary", true, "primary", false),
+			Entry("reselect-on-change reselects after a config change", scorev1b1.ReselectionPolicyReselectOnChange, "primary", false, "added", true),
+			Entry("sticky reselects when the recorded backend is gone", scorev1b1.ReselectionPolicySticky, "removed", true, "added", true),
+		)
+
+		It("deletes the plan when the reselected backend uses another runtime class", func() {
+			existing := &scorev1b1.WorkloadPlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      workload.Name,
+					Namespace: workload.Namespace,
+					Labels:    map[string]string{"score.dev/workload": workload.Name},
+				},
+				Spec: scorev1b1.WorkloadPlanSpec{
+					WorkloadRef:  scorev1b1.WorkloadPlanWorkloadRef{Name: workload.Name, Namespace: workload.Namespace},
+					RuntimeClass: "kubernetes",
+				},
+			}
+			newPlanManager(scorev1b1.ReselectionPolicyReselectOnChange, "ecs", existing)
+			bind("primary", "stale")
+
+			Expect(pm.EnsurePlan(context.Background(), workload, claims, readyClaims)).To(Succeed())
+
+			Expect(workload.Status.Binding.BackendID).To(Equal("added"))
+			Expect(workload.Status.Binding.RuntimeClass).To(Equal("ecs"))
+			Expect(mockRecorder.events).To(ContainElement(EventReasonBackendMigrated))
+			Expect(mockRecorder.events).NotTo(ContainElement(EventReasonPlanCreated))
+
+			err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(workload), &scorev1b1.WorkloadPlan{})
+			Expect(errors.IsNotFound(err)).To(BeTrue())
+
+			runtimeReady := apimeta.FindStatusCondition(workload.Status.Conditions, conditions.ConditionRuntimeReady)
+			Expect(runtimeReady).NotTo(BeNil())
+			Expect(runtimeReady.Status).To(Equal(metav1.ConditionFalse))
+			Expect(runtimeReady.Reason).To(Equal(conditions.ReasonRuntimeSelecting))
+		})
+	})
 })
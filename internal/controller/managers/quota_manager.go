@@ -0,0 +1,86 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/config"
+	"github.com/cappyzawa/score-orchestrator/internal/quota"
+)
+
+// EventReasonQuotaExceeded indicates that a Workload was held back by a quota
+const EventReasonQuotaExceeded = "QuotaExceeded"
+
+// QuotaManager enforces the quotas configured in the OrchestratorConfig
+type QuotaManager struct {
+	client       client.Client
+	recorder     record.EventRecorder
+	configLoader config.ConfigLoader
+}
+
+// NewQuotaManager creates a new QuotaManager instance
+func NewQuotaManager(c client.Client, recorder record.EventRecorder, configLoader config.ConfigLoader) *QuotaManager {
+	return &QuotaManager{
+		client:       c,
+		recorder:     recorder,
+		configLoader: configLoader,
+	}
+}
+
+// CheckQuotas returns a *quota.Violation if admitting the Workload would exceed a quota.
+// A violation is counted in the quota metric and reported as a Warning event only when the
+// stored Workload was not already held back, so periodic re-evaluation does not repeat them.
+func (qm *QuotaManager) CheckQuotas(ctx context.Context, workload *scorev1b1.Workload) error {
+	orchestratorConfig, err := qm.configLoader.LoadConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load orchestrator config: %w", err)
+	}
+	if len(orchestratorConfig.Spec.Quotas) == 0 {
+		return nil
+	}
+
+	workloads := &scorev1b1.WorkloadList{}
+	if err := qm.client.List(ctx, workloads); err != nil {
+		return fmt.Errorf("failed to list workloads: %w", err)
+	}
+
+	err = quota.Evaluate(orchestratorConfig.Spec.Quotas, workload, workloads.Items)
+	var violation *quota.Violation
+	if errors.As(err, &violation) && !heldBack(workload, workloads.Items) {
+		quota.ExceededTotal.WithLabelValues(violation.Quota, workload.Namespace).Inc()
+		qm.recorder.Event(workload, EventTypeWarning, EventReasonQuotaExceeded, violation.Error())
+	}
+	return err
+}
+
+// heldBack reports whether the stored copy of the Workload is already held back by a quota.
+// The in-memory Workload cannot be used because earlier phases rewrite its InputsValid condition.
+func heldBack(workload *scorev1b1.Workload, workloads []scorev1b1.Workload) bool {
+	for i := range workloads {
+		if workloads[i].Namespace == workload.Namespace && workloads[i].Name == workload.Name {
+			return quota.IsHeldBack(&workloads[i])
+		}
+	}
+	return false
+}
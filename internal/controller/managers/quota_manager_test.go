@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package managers
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	dto "github.com/prometheus/client_model/go"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+	"github.com/cappyzawa/score-orchestrator/internal/quota"
+)
+
+var _ = Describe("QuotaManager", func() {
+	var (
+		ctx        context.Context
+		fakeClient client.Client
+		recorder   *record.FakeRecorder
+		qm         *QuotaManager
+		existing   *scorev1b1.Workload
+		workload   *scorev1b1.Workload
+	)
+
+	newQuotaWorkload := func(name string, age time.Duration) *scorev1b1.Workload {
+		return &scorev1b1.Workload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              name,
+				Namespace:         "quota-ns",
+				CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+			},
+			Spec: scorev1b1.WorkloadSpec{
+				Containers: map[string]scorev1b1.ContainerSpec{"main": {Image: "nginx"}},
+			},
+		}
+	}
+
+	exceededTotal := func() float64 {
+		metric := &dto.Metric{}
+		Expect(quota.ExceededTotal.WithLabelValues("one-per-namespace", "quota-ns").Write(metric)).To(Succeed())
+		return metric.GetCounter().GetValue()
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(scorev1b1.AddToScheme(scheme)).To(Succeed())
+
+		existing = newQuotaWorkload("existing", time.Hour)
+		workload = newQuotaWorkload("new", 0)
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing, workload).Build()
+		recorder = record.NewFakeRecorder(10)
+
+		loader := &mockConfigLoader{
+			loadConfigFunc: func(ctx context.Context) (*scorev1b1.OrchestratorConfig, error) {
+				maxWorkloads := int32(1)
+				return &scorev1b1.OrchestratorConfig{
+					Spec: scorev1b1.OrchestratorConfigSpec{
+						Quotas: []scorev1b1.QuotaSpec{{Name: "one-per-namespace", MaxWorkloads: &maxWorkloads}},
+					},
+				}, nil
+			},
+		}
+		qm = NewQuotaManager(fakeClient, recorder, loader)
+	})
+
+	It("should report a violation once per transition", func() {
+		before := exceededTotal()
+
+		Expect(qm.CheckQuotas(ctx, workload)).To(MatchError(quota.ErrQuotaExceeded))
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(exceededTotal()).To(Equal(before + 1))
+
+		// Record the rejection the way the quota phase does
+		stored := &scorev1b1.Workload{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(workload), stored)).To(Succeed())
+		stored.Status.Conditions = []metav1.Condition{{
+			Type:               conditions.ConditionInputsValid,
+			Status:             metav1.ConditionFalse,
+			Reason:             conditions.ReasonQuotaExceeded,
+			LastTransitionTime: metav1.Now(),
+		}}
+		Expect(fakeClient.Update(ctx, stored)).To(Succeed())
+
+		Expect(qm.CheckQuotas(ctx, workload)).To(MatchError(quota.ErrQuotaExceeded))
+		Expect(recorder.Events).To(HaveLen(1))
+		Expect(exceededTotal()).To(Equal(before + 1))
+	})
+
+	It("should not count Workloads held back by a quota", func() {
+		stored := &scorev1b1.Workload{}
+		Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(existing), stored)).To(Succeed())
+		stored.Status.Conditions = []metav1.Condition{{
+			Type:               conditions.ConditionInputsValid,
+			Status:             metav1.ConditionFalse,
+			Reason:             conditions.ReasonQuotaExceeded,
+			LastTransitionTime: metav1.Now(),
+		}}
+		Expect(fakeClient.Update(ctx, stored)).To(Succeed())
+
+		Expect(qm.CheckQuotas(ctx, workload)).To(Succeed())
+		Expect(recorder.Events).To(BeEmpty())
+	})
+})
@@ -3,6 +3,8 @@ package managers
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -13,6 +15,13 @@ import (
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
 	"github.com/cappyzawa/score-orchestrator/internal/conditions"
 	"github.com/cappyzawa/score-orchestrator/internal/endpoint"
+	"github.com/cappyzawa/score-orchestrator/internal/fanout"
+	"github.com/cappyzawa/score-orchestrator/internal/faultinject"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+	"github.com/cappyzawa/score-orchestrator/internal/runtimeregistry"
+	"github.com/cappyzawa/score-orchestrator/internal/status"
+	"github.com/cappyzawa/score-orchestrator/internal/summary"
 )
 
 // Event constants for StatusManager
@@ -28,6 +37,8 @@ type StatusManager struct {
 	scheme          *runtime.Scheme
 	recorder        record.EventRecorder
 	endpointDeriver *endpoint.EndpointDeriver
+	faults          *faultinject.Injector
+	summaries       *summary.Aggregator
 }
 
 // NewStatusManager creates a new StatusManager instance
@@ -49,15 +60,24 @@ func NewStatusManager(
 func (sm *StatusManager) UpdateStatus(ctx context.Context, workload *scorev1b1.Workload) error {
 	log := ctrl.LoggerFrom(ctx)
 
+	// Every status written through the StatusManager was computed from the current spec
+	workload.Status.ObservedGeneration = workload.Generation
 	if err := sm.client.Status().Update(ctx, workload); err != nil {
 		log.Error(err, "Failed to update Workload status")
 		return fmt.Errorf("failed to update workload status: %w", err)
 	}
 
+	sm.summaries.Observe(workload)
+
 	log.V(1).Info("Successfully updated Workload status")
 	return nil
 }
 
+// ForgetWorkload removes a Workload whose deletion completed from the summary of its namespace
+func (sm *StatusManager) ForgetWorkload(workload *scorev1b1.Workload) {
+	sm.summaries.Forget(client.ObjectKeyFromObject(workload))
+}
+
 // ComputeReadyCondition determines the Ready condition based on other conditions
 // Ready = InputsValid ∧ ClaimsReady ∧ RuntimeReady
 func (sm *StatusManager) ComputeReadyCondition(conditionsSlice []metav1.Condition) (metav1.ConditionStatus, string, string) {
@@ -103,6 +123,78 @@ func (sm *StatusManager) SetInputsValidCondition(
 		status,
 		reason,
 		message,
+		workload.Generation,
+	)
+}
+
+// SetQuotaExceeded marks the workload as held back by a quota.
+// InputsValid and Ready are set to False with reason QuotaExceeded.
+func (sm *StatusManager) SetQuotaExceeded(workload *scorev1b1.Workload, message string) {
+	sm.setInputsInvalid(workload, conditions.ReasonQuotaExceeded, message)
+}
+
+// SetSpecInvalid marks the workload specification as invalid.
+// InputsValid and Ready are set to False with reason SpecInvalid.
+func (sm *StatusManager) SetSpecInvalid(workload *scorev1b1.Workload, message string) {
+	sm.setInputsInvalid(workload, conditions.ReasonSpecInvalid, message)
+}
+
+// SetPolicyViolation marks the workload as rejected by an Admission stage policy.
+// InputsValid and Ready are set to False with reason PolicyViolation.
+func (sm *StatusManager) SetPolicyViolation(workload *scorev1b1.Workload, message string) {
+	sm.setInputsInvalid(workload, conditions.ReasonPolicyViolation, message)
+}
+
+// setInputsInvalid sets InputsValid and Ready to False with the given reason
+func (sm *StatusManager) setInputsInvalid(workload *scorev1b1.Workload, reason, message string) {
+	sm.SetInputsValidCondition(workload, false, reason, message)
+	conditions.SetCondition(
+		&workload.Status.Conditions,
+		conditions.ConditionReady,
+		metav1.ConditionFalse,
+		reason,
+		message,
+		workload.Generation,
+	)
+	workload.Status.Reason = reason
+	workload.Status.Message = message
+}
+
+// SetBlocked marks the workload as waiting for the Workloads it depends on.
+// Blocked is set to True and Ready to False with reason Blocked; message lists the blocking Workloads.
+func (sm *StatusManager) SetBlocked(workload *scorev1b1.Workload, message string) {
+	conditions.SetCondition(
+		&workload.Status.Conditions,
+		conditions.ConditionBlocked,
+		metav1.ConditionTrue,
+		conditions.ReasonBlocked,
+		message,
+		workload.Generation,
+	)
+	conditions.SetCondition(
+		&workload.Status.Conditions,
+		conditions.ConditionReady,
+		metav1.ConditionFalse,
+		conditions.ReasonBlocked,
+		message,
+		workload.Generation,
+	)
+	workload.Status.Reason = conditions.ReasonBlocked
+	workload.Status.Message = message
+}
+
+// ClearBlocked sets a previously reported Blocked condition to False once the dependencies are ready
+func (sm *StatusManager) ClearBlocked(workload *scorev1b1.Workload) {
+	if !conditions.IsConditionTrue(workload.Status.Conditions, conditions.ConditionBlocked) {
+		return
+	}
+	conditions.SetCondition(
+		&workload.Status.Conditions,
+		conditions.ConditionBlocked,
+		metav1.ConditionFalse,
+		conditions.ReasonSucceeded,
+		conditions.MessageDependenciesReady,
+		workload.Generation,
 	)
 }
 
@@ -123,6 +215,41 @@ func (sm *StatusManager) SetClaimsReadyCondition(
 		status,
 		reason,
 		message,
+		workload.Generation,
+	)
+}
+
+// SetClaimsStatus records the per-claim summaries and the ClaimsReady condition aggregated from them
+func (sm *StatusManager) SetClaimsStatus(workload *scorev1b1.Workload, agg status.ClaimAggregation) {
+	workload.Status.Claims = agg.Claims
+	sm.SetClaimsReadyCondition(workload, agg.Ready, agg.Reason, agg.Message)
+	sm.setOptionalClaimsCondition(workload, agg)
+}
+
+// setOptionalClaimsCondition warns about claims of optional resources that failed or are not bound yet.
+// OptionalClaimsUnavailable is only reported for Workloads that have, or had, optional resources.
+func (sm *StatusManager) setOptionalClaimsCondition(workload *scorev1b1.Workload, agg status.ClaimAggregation) {
+	if !agg.HasOptional() &&
+		conditions.GetCondition(workload.Status.Conditions, conditions.ConditionOptionalClaimsUnavailable) == nil {
+		return
+	}
+
+	conditionStatus, reason, message := metav1.ConditionFalse, conditions.ReasonSucceeded, conditions.MessageOptionalClaimsReady
+	switch {
+	case len(agg.OptionalFailed) > 0:
+		conditionStatus, reason = metav1.ConditionTrue, conditions.ReasonClaimFailed
+		message = fmt.Sprintf("Optional resources %s failed; the Workload runs without them", strings.Join(agg.OptionalFailed, ", "))
+	case len(agg.OptionalPending) > 0:
+		conditionStatus, reason = metav1.ConditionTrue, conditions.ReasonClaimPending
+		message = fmt.Sprintf("Optional resources %s are not bound yet; the Workload runs without them", strings.Join(agg.OptionalPending, ", "))
+	}
+	conditions.SetCondition(
+		&workload.Status.Conditions,
+		conditions.ConditionOptionalClaimsUnavailable,
+		conditionStatus,
+		reason,
+		message,
+		workload.Generation,
 	)
 }
 
@@ -143,9 +270,21 @@ func (sm *StatusManager) SetRuntimeReadyCondition(
 		status,
 		reason,
 		message,
+		workload.Generation,
 	)
 }
 
+// SetRuntimeReadyConditionFromError sets RuntimeReady=False using the canonical reason and message for err
+func (sm *StatusManager) SetRuntimeReadyConditionFromError(
+	workload *scorev1b1.Workload,
+	err error,
+	fallback string,
+) string {
+	reason := conditions.ReasonForError(err, fallback)
+	sm.SetRuntimeReadyCondition(workload, false, reason, conditions.MessageForError(err, reason))
+	return reason
+}
+
 // ComputeFinalStatus updates runtime status and computes Ready condition
 func (sm *StatusManager) ComputeFinalStatus(
 	ctx context.Context,
@@ -154,19 +293,35 @@ func (sm *StatusManager) ComputeFinalStatus(
 ) error {
 	log := ctrl.LoggerFrom(ctx)
 
-	// Update RuntimeReady condition and endpoint based on plan
-	sm.updateRuntimeStatusFromPlan(workload, plan)
+	var readyStatus metav1.ConditionStatus
+	var readyReason, readyMessage string
+	if reconcile.IsDryRun(workload) {
+		// Dry-run workloads are never materialized; RuntimeReady carries the preview outcome
+		readyStatus, readyReason, readyMessage = metav1.ConditionFalse, conditions.ReasonDryRun, conditions.MessageDryRun
+		if runtimeCond := conditions.GetCondition(workload.Status.Conditions, conditions.ConditionRuntimeReady); runtimeCond != nil {
+			readyReason, readyMessage = runtimeCond.Reason, runtimeCond.Message
+		}
+	} else {
+		// Update RuntimeReady condition and endpoint based on plan
+		sm.updateRuntimeStatusFromPlan(workload, plan)
 
-	// Compute and set Ready condition
-	readyStatus, readyReason, readyMessage := sm.ComputeReadyCondition(workload.Status.Conditions)
+		// Compute Ready condition
+		readyStatus, readyReason, readyMessage = sm.ComputeReadyCondition(workload.Status.Conditions)
+		sm.updateDegradedCondition(workload, readyStatus, readyReason, readyMessage)
+	}
 	conditions.SetCondition(
 		&workload.Status.Conditions,
 		conditions.ConditionReady,
 		readyStatus,
 		readyReason,
 		readyMessage,
+		workload.Generation,
 	)
 
+	// Surface the abstract summary at the top level of the status
+	workload.Status.Reason = readyReason
+	workload.Status.Message = readyMessage
+
 	// Emit events based on Ready status
 	if readyStatus == metav1.ConditionTrue {
 		sm.recorder.Event(workload, "Normal", EventReasonReady, "Workload is ready and operational")
@@ -178,6 +333,84 @@ func (sm *StatusManager) ComputeFinalStatus(
 	return nil
 }
 
+// updateDegradedCondition tracks whether a Workload that was ready regressed, before Ready is set to readyStatus.
+// Degraded becomes True when Ready turns False at the generation it was ready at because a claim or the runtime is
+// no longer ready, keeps the reason and transition time of the regression until the Workload is ready again, and
+// is False while the Workload is ready. Workloads that were never ready get no Degraded condition.
+func (sm *StatusManager) updateDegradedCondition(workload *scorev1b1.Workload, readyStatus metav1.ConditionStatus, readyReason, readyMessage string) {
+	if readyStatus == metav1.ConditionTrue {
+		conditions.SetCondition(
+			&workload.Status.Conditions,
+			conditions.ConditionDegraded,
+			metav1.ConditionFalse,
+			conditions.ReasonSucceeded,
+			conditions.MessageWorkloadReady,
+			workload.Generation,
+		)
+		return
+	}
+
+	if degraded := conditions.GetCondition(workload.Status.Conditions, conditions.ConditionDegraded); degraded != nil &&
+		degraded.Status == metav1.ConditionTrue {
+		conditions.SetCondition(
+			&workload.Status.Conditions,
+			conditions.ConditionDegraded,
+			metav1.ConditionTrue,
+			degraded.Reason,
+			readyMessage,
+			workload.Generation,
+		)
+		return
+	}
+
+	// Changes to the spec and invalid inputs are not regressions of a healthy Workload
+	ready := conditions.GetCondition(workload.Status.Conditions, conditions.ConditionReady)
+	if ready == nil || ready.Status != metav1.ConditionTrue || ready.ObservedGeneration != workload.Generation ||
+		!conditions.IsConditionTrue(workload.Status.Conditions, conditions.ConditionInputsValid) {
+		return
+	}
+	conditions.SetCondition(
+		&workload.Status.Conditions,
+		conditions.ConditionDegraded,
+		metav1.ConditionTrue,
+		readyReason,
+		readyMessage,
+		workload.Generation,
+	)
+}
+
+// SetEnvironmentsStatus records the status of the instances of a Workload that fans out to environments
+// and sets Ready from them. The Workload runs nothing itself, so it has no endpoint.
+func (sm *StatusManager) SetEnvironmentsStatus(workload *scorev1b1.Workload, environments []scorev1b1.WorkloadEnvironmentStatus) {
+	workload.Status.Environments = environments
+	workload.Status.Endpoint = nil
+	workload.Status.Endpoints = nil
+
+	readyStatus, readyReason, readyMessage := fanout.Aggregate(environments)
+	conditions.SetCondition(
+		&workload.Status.Conditions,
+		conditions.ConditionReady,
+		readyStatus,
+		readyReason,
+		readyMessage,
+		workload.Generation,
+	)
+	workload.Status.Reason = readyReason
+	workload.Status.Message = readyMessage
+}
+
+// SetFaultInjector makes the StatusManager report ready runtimes as degraded while the injector flaps
+// runtime readiness, for integration tests
+func (sm *StatusManager) SetFaultInjector(faults *faultinject.Injector) {
+	sm.faults = faults
+}
+
+// SetSummaryAggregator makes the StatusManager maintain the WorkloadSummary of each namespace from the
+// statuses it writes
+func (sm *StatusManager) SetSummaryAggregator(summaries *summary.Aggregator) {
+	sm.summaries = summaries
+}
+
 // updateRuntimeStatusFromPlan updates RuntimeReady condition and endpoint based on WorkloadPlan
 func (sm *StatusManager) updateRuntimeStatusFromPlan(
 	workload *scorev1b1.Workload,
@@ -206,15 +439,38 @@ func (sm *StatusManager) checkRuntimeStatusFromPlan(plan *scorev1b1.WorkloadPlan
 	if plan == nil {
 		return false, conditions.ReasonRuntimeSelecting, "Runtime controller is being selected"
 	}
+	if plan.DeletionTimestamp != nil {
+		return false, conditions.ReasonRuntimeSelecting, "Runtime is migrating to the newly selected backend"
+	}
+	if unresponsive, message := runtimeregistry.PlanUnresponsive(plan, time.Now()); unresponsive {
+		return false, conditions.ReasonRuntimeUnresponsive, message
+	}
+	if generation := reconcile.RolledBackGeneration(plan); generation != 0 {
+		return false, conditions.ReasonRuntimeDegraded, fmt.Sprintf(
+			"Rollout of Workload generation %d failed; rolled back to generation %d", generation, plan.Spec.ObservedWorkloadGeneration)
+	}
+	if status.PlanStatusStale(plan) {
+		return false, conditions.ReasonRuntimeProvisioning, "Runtime is applying the updated plan"
+	}
 
 	switch plan.Status.Phase {
 	case scorev1b1.WorkloadPlanPhaseReady:
+		if sm.faults.RuntimeDegraded() {
+			return false, conditions.ReasonRuntimeDegraded, "Injected fault: runtime readiness flapping"
+		}
+		if unmet := status.UnmetReadinessGates(plan); len(unmet) > 0 {
+			return false, conditions.ReasonRuntimeProvisioning, status.ReadinessGatesMessage(unmet)
+		}
 		return true, conditions.ReasonSucceeded, "Runtime provisioned successfully"
 	case scorev1b1.WorkloadPlanPhaseFailed:
 		message := plan.Status.Message
 		if message == "" {
 			message = "Runtime provisioning failed"
 		}
+		if ready := conditions.GetCondition(plan.Status.Conditions, meta.PlanConditionReady); ready != nil &&
+			ready.Reason == meta.PlanReasonRuntimeConflict {
+			return false, conditions.ReasonRuntimeConflict, message
+		}
 		return false, conditions.ReasonRuntimeDegraded, message
 	case scorev1b1.WorkloadPlanPhaseProvisioning:
 		message := plan.Status.Message
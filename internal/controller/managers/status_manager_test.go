@@ -2,17 +2,25 @@ package managers
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
 	"github.com/cappyzawa/score-orchestrator/internal/conditions"
 	"github.com/cappyzawa/score-orchestrator/internal/endpoint"
+	"github.com/cappyzawa/score-orchestrator/internal/faultinject"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+	"github.com/cappyzawa/score-orchestrator/internal/selection"
+	"github.com/cappyzawa/score-orchestrator/internal/status"
 )
 
 var _ = Describe("StatusManager", func() {
@@ -57,6 +65,17 @@ var _ = Describe("StatusManager", func() {
 				err = sm.UpdateStatus(context.Background(), workload)
 				Expect(err).ToNot(HaveOccurred())
 			})
+
+			It("should record the observed generation", func() {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&scorev1b1.Workload{}).Build()
+				sm := NewStatusManager(fakeClient, scheme, &mockEventRecorder{}, endpoint.NewEndpointDeriver(fakeClient))
+
+				workload.Generation = 4
+				Expect(fakeClient.Create(context.Background(), workload)).To(Succeed())
+				Expect(sm.UpdateStatus(context.Background(), workload)).To(Succeed())
+
+				Expect(workload.Status.ObservedGeneration).To(Equal(workload.Generation))
+			})
 		})
 
 		Context("when client does not have status subresource", func() {
@@ -273,6 +292,47 @@ var _ = Describe("StatusManager", func() {
 			})
 		})
 
+		Describe("SetClaimsStatus", func() {
+			It("should record the claim summaries and the ClaimsReady condition", func() {
+				summaries := []scorev1b1.ClaimSummary{
+					{Key: "db", Type: "postgres", Phase: scorev1b1.ResourceClaimPhaseFailed, Reason: "ClaimFailed"},
+				}
+				sm.SetClaimsStatus(testWorkload, status.ClaimAggregation{
+					Reason:  conditions.ReasonClaimFailed,
+					Message: conditions.MessageClaimsFailed,
+					Claims:  summaries,
+				})
+
+				Expect(testWorkload.Status.Claims).To(Equal(summaries))
+				condition := conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionClaimsReady)
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(conditions.ReasonClaimFailed))
+				Expect(conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionOptionalClaimsUnavailable)).To(BeNil())
+			})
+
+			It("should warn about optional claims without holding back ClaimsReady", func() {
+				sm.SetClaimsStatus(testWorkload, status.ClaimAggregation{
+					Ready:          true,
+					Reason:         conditions.ReasonSucceeded,
+					Message:        conditions.MessageAllClaimsReady,
+					Claims:         []scorev1b1.ClaimSummary{{Key: "search", Phase: scorev1b1.ResourceClaimPhaseFailed, Optional: true}},
+					OptionalFailed: []string{"search"},
+				})
+
+				Expect(conditions.IsConditionTrue(testWorkload.Status.Conditions, conditions.ConditionClaimsReady)).To(BeTrue())
+				condition := conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionOptionalClaimsUnavailable)
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.Reason).To(Equal(conditions.ReasonClaimFailed))
+				Expect(condition.Message).To(ContainSubstring("search"))
+
+				sm.SetClaimsStatus(testWorkload, status.ClaimAggregation{Ready: true, Reason: conditions.ReasonSucceeded})
+				condition = conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionOptionalClaimsUnavailable)
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			})
+		})
+
 		Describe("SetRuntimeReadyCondition", func() {
 			It("should set the RuntimeReady condition", func() {
 				sm.SetRuntimeReadyCondition(testWorkload, true, "Succeeded", "Runtime is ready")
@@ -284,6 +344,53 @@ var _ = Describe("StatusManager", func() {
 				Expect(condition.Message).To(Equal("Runtime is ready"))
 			})
 		})
+
+		Describe("SetBlocked", func() {
+			It("should set Blocked to True and Ready to False with reason Blocked", func() {
+				message := "Waiting for workloads to become ready: db-migrator"
+				sm.SetBlocked(testWorkload, message)
+
+				blocked := conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionBlocked)
+				Expect(blocked).ToNot(BeNil())
+				Expect(blocked.Status).To(Equal(metav1.ConditionTrue))
+				Expect(blocked.Reason).To(Equal(conditions.ReasonBlocked))
+				Expect(blocked.Message).To(Equal(message))
+
+				ready := conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionReady)
+				Expect(ready).ToNot(BeNil())
+				Expect(ready.Status).To(Equal(metav1.ConditionFalse))
+				Expect(ready.Reason).To(Equal(conditions.ReasonBlocked))
+				Expect(testWorkload.Status.Reason).To(Equal(conditions.ReasonBlocked))
+				Expect(conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionRuntimeReady)).To(BeNil())
+			})
+
+			It("should clear the Blocked condition once dependencies are ready", func() {
+				sm.ClearBlocked(testWorkload)
+				Expect(conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionBlocked)).To(BeNil())
+
+				sm.SetBlocked(testWorkload, "Waiting for workloads to become ready: db-migrator")
+				sm.ClearBlocked(testWorkload)
+
+				blocked := conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionBlocked)
+				Expect(blocked).ToNot(BeNil())
+				Expect(blocked.Status).To(Equal(metav1.ConditionFalse))
+				Expect(blocked.Reason).To(Equal(conditions.ReasonSucceeded))
+			})
+		})
+
+		Describe("SetSpecInvalid", func() {
+			It("should set InputsValid and Ready to False with reason SpecInvalid", func() {
+				sm.SetSpecInvalid(testWorkload, "dependency cycle detected: api -> api")
+
+				for _, conditionType := range []string{conditions.ConditionInputsValid, conditions.ConditionReady} {
+					condition := conditions.GetCondition(testWorkload.Status.Conditions, conditionType)
+					Expect(condition).ToNot(BeNil())
+					Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+					Expect(condition.Reason).To(Equal(conditions.ReasonSpecInvalid))
+				}
+				Expect(testWorkload.Status.Message).To(Equal("dependency cycle detected: api -> api"))
+			})
+		})
 	})
 
 	Describe("ComputeFinalStatus", func() {
@@ -344,6 +451,10 @@ var _ = Describe("StatusManager", func() {
 				readyCondition := conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionReady)
 				Expect(readyCondition).ToNot(BeNil())
 
+				// Check that the top-level summary mirrors the Ready condition
+				Expect(testWorkload.Status.Reason).To(Equal(readyCondition.Reason))
+				Expect(testWorkload.Status.Message).To(Equal(readyCondition.Message))
+
 				// Check that endpoint is NOT set per ADR-0007
 				Expect(testWorkload.Status.Endpoint).To(BeNil())
 
@@ -371,6 +482,67 @@ var _ = Describe("StatusManager", func() {
 				Expect(runtimeCondition.Reason).To(Equal("RuntimeSelecting"))
 			})
 		})
+
+		Context("when tracking regressions", func() {
+			var sm *StatusManager
+
+			BeforeEach(func() {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				sm = NewStatusManager(fakeClient, scheme, record.NewFakeRecorder(10), endpoint.NewEndpointDeriver(fakeClient))
+				testWorkload.Generation = 1
+				sm.SetInputsValidCondition(testWorkload, true, conditions.ReasonSucceeded, "Valid")
+				sm.SetClaimsReadyCondition(testWorkload, true, conditions.ReasonSucceeded, "Ready")
+			})
+
+			It("should not report Workloads that never became ready as degraded", func() {
+				plan.Status.Phase = scorev1b1.WorkloadPlanPhaseFailed
+				Expect(sm.ComputeFinalStatus(context.Background(), testWorkload, plan)).To(Succeed())
+
+				Expect(conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionDegraded)).To(BeNil())
+			})
+
+			It("should report a runtime that regressed after being ready until it recovers", func() {
+				plan.Status.Phase = scorev1b1.WorkloadPlanPhaseReady
+				Expect(sm.ComputeFinalStatus(context.Background(), testWorkload, plan)).To(Succeed())
+				degraded := conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionDegraded)
+				Expect(degraded).ToNot(BeNil())
+				Expect(degraded.Status).To(Equal(metav1.ConditionFalse))
+
+				plan.Status.Phase = scorev1b1.WorkloadPlanPhaseFailed
+				plan.Status.Message = "pods are crash looping"
+				Expect(sm.ComputeFinalStatus(context.Background(), testWorkload, plan)).To(Succeed())
+				degraded = conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionDegraded)
+				Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+				Expect(degraded.Reason).To(Equal(conditions.ReasonRuntimeDegraded))
+				since := degraded.LastTransitionTime
+
+				// The regression keeps its reason and transition time while the Workload is not ready
+				sm.SetClaimsReadyCondition(testWorkload, false, conditions.ReasonClaimFailed, "Failed")
+				Expect(sm.ComputeFinalStatus(context.Background(), testWorkload, plan)).To(Succeed())
+				degraded = conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionDegraded)
+				Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+				Expect(degraded.Reason).To(Equal(conditions.ReasonRuntimeDegraded))
+				Expect(degraded.LastTransitionTime).To(Equal(since))
+
+				sm.SetClaimsReadyCondition(testWorkload, true, conditions.ReasonSucceeded, "Ready")
+				plan.Status.Phase = scorev1b1.WorkloadPlanPhaseReady
+				Expect(sm.ComputeFinalStatus(context.Background(), testWorkload, plan)).To(Succeed())
+				degraded = conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionDegraded)
+				Expect(degraded.Status).To(Equal(metav1.ConditionFalse))
+			})
+
+			It("should not report the rollout of a new generation as degraded", func() {
+				plan.Status.Phase = scorev1b1.WorkloadPlanPhaseReady
+				Expect(sm.ComputeFinalStatus(context.Background(), testWorkload, plan)).To(Succeed())
+
+				testWorkload.Generation = 2
+				plan.Status.Phase = scorev1b1.WorkloadPlanPhaseProvisioning
+				Expect(sm.ComputeFinalStatus(context.Background(), testWorkload, plan)).To(Succeed())
+
+				degraded := conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionDegraded)
+				Expect(degraded.Status).To(Equal(metav1.ConditionFalse))
+			})
+		})
 	})
 
 	Describe("updateRuntimeStatusFromPlan", func() {
@@ -514,6 +686,187 @@ var _ = Describe("StatusManager", func() {
 					Expect(condition.Message).To(Equal(tc.expectedMessage), "Phase: %s", tc.phase)
 				}
 			})
+
+			It("should report plans blocked by foreign objects as RuntimeConflict", func() {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				sm := NewStatusManager(fakeClient, scheme, &mockEventRecorder{}, endpoint.NewEndpointDeriver(fakeClient))
+
+				plan := &scorev1b1.WorkloadPlan{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-workload", Namespace: "test-ns"},
+					Status: scorev1b1.WorkloadPlanStatus{
+						Phase:   scorev1b1.WorkloadPlanPhaseFailed,
+						Message: "Deployment test-ns/test-workload exists and was not created for Workload test-workload",
+						Conditions: []metav1.Condition{{
+							Type:   meta.PlanConditionReady,
+							Status: metav1.ConditionFalse,
+							Reason: meta.PlanReasonRuntimeConflict,
+						}},
+					},
+				}
+
+				sm.updateRuntimeStatusFromPlan(testWorkload, plan)
+
+				condition := conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionRuntimeReady)
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(conditions.ReasonRuntimeConflict))
+				Expect(condition.Message).To(Equal(plan.Status.Message))
+			})
+
+			It("should report a runtime that stopped sending heartbeats as RuntimeUnresponsive", func() {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				sm := NewStatusManager(fakeClient, scheme, &mockEventRecorder{}, endpoint.NewEndpointDeriver(fakeClient))
+
+				plan := &scorev1b1.WorkloadPlan{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:              "test-workload",
+						Namespace:         "test-ns",
+						CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+					},
+					Spec: scorev1b1.WorkloadPlanSpec{RuntimeClass: "kubernetes"},
+					Status: scorev1b1.WorkloadPlanStatus{
+						Phase: scorev1b1.WorkloadPlanPhaseReady,
+						Acknowledgment: &scorev1b1.WorkloadPlanAcknowledgment{
+							ConsumedBy:    "score-runtime-kubernetes",
+							LastHeartbeat: metav1.NewTime(time.Now().Add(-time.Minute)),
+						},
+					},
+				}
+				sm.updateRuntimeStatusFromPlan(testWorkload, plan)
+				Expect(conditions.IsConditionTrue(testWorkload.Status.Conditions, conditions.ConditionRuntimeReady)).To(BeTrue())
+
+				By("letting the heartbeat expire")
+				plan.Status.Acknowledgment.LastHeartbeat = metav1.NewTime(time.Now().Add(-time.Hour))
+				sm.updateRuntimeStatusFromPlan(testWorkload, plan)
+				condition := conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionRuntimeReady)
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(conditions.ReasonRuntimeUnresponsive))
+				Expect(condition.Message).To(ContainSubstring("score-runtime-kubernetes has not sent a heartbeat"))
+
+				By("never acknowledging the plan")
+				plan.Status.Acknowledgment = nil
+				sm.updateRuntimeStatusFromPlan(testWorkload, plan)
+				condition = conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionRuntimeReady)
+				Expect(condition.Reason).To(Equal(conditions.ReasonRuntimeUnresponsive))
+				Expect(condition.Message).To(ContainSubstring("No kubernetes runtime has acknowledged the plan"))
+			})
+
+			It("should hold a ready runtime until the readiness gates of the plan are satisfied", func() {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				sm := NewStatusManager(fakeClient, scheme, &mockEventRecorder{}, endpoint.NewEndpointDeriver(fakeClient))
+
+				plan := &scorev1b1.WorkloadPlan{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-workload", Namespace: "test-ns", Generation: 1},
+					Spec: scorev1b1.WorkloadPlanSpec{
+						ReadinessGates: []scorev1b1.ReadinessGate{{ConditionType: "MeshInjected"}, {ConditionType: "DNSReady"}},
+					},
+					Status: scorev1b1.WorkloadPlanStatus{
+						Phase: scorev1b1.WorkloadPlanPhaseReady,
+						Conditions: []metav1.Condition{
+							{Type: "MeshInjected", Status: metav1.ConditionTrue, Reason: "Injected", ObservedGeneration: 1},
+						},
+					},
+				}
+				sm.updateRuntimeStatusFromPlan(testWorkload, plan)
+
+				condition := conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionRuntimeReady)
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(conditions.ReasonRuntimeProvisioning))
+				Expect(condition.Message).To(Equal("Readiness gates not satisfied: DNSReady (not reported)"))
+
+				plan.Status.Conditions = append(plan.Status.Conditions,
+					metav1.Condition{Type: "DNSReady", Status: metav1.ConditionTrue, Reason: "RecordPublished", ObservedGeneration: 1})
+				sm.updateRuntimeStatusFromPlan(testWorkload, plan)
+				Expect(conditions.IsConditionTrue(testWorkload.Status.Conditions, conditions.ConditionRuntimeReady)).To(BeTrue())
+			})
+
+			It("should not report a runtime status computed for a previous plan generation", func() {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				sm := NewStatusManager(fakeClient, scheme, &mockEventRecorder{}, endpoint.NewEndpointDeriver(fakeClient))
+				testWorkload.Generation = 2
+
+				plan := &scorev1b1.WorkloadPlan{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-workload", Namespace: "test-ns", Generation: 2},
+					Status: scorev1b1.WorkloadPlanStatus{
+						Phase:              scorev1b1.WorkloadPlanPhaseReady,
+						ObservedGeneration: 1,
+					},
+				}
+				sm.updateRuntimeStatusFromPlan(testWorkload, plan)
+
+				condition := conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionRuntimeReady)
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(conditions.ReasonRuntimeProvisioning))
+				Expect(condition.ObservedGeneration).To(Equal(int64(2)))
+
+				plan.Status.ObservedGeneration = 2
+				sm.updateRuntimeStatusFromPlan(testWorkload, plan)
+				Expect(conditions.IsConditionTrue(testWorkload.Status.Conditions, conditions.ConditionRuntimeReady)).To(BeTrue())
+			})
+
+			It("should report a ready runtime as degraded while fault injection flaps its readiness", func() {
+				fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+				sm := NewStatusManager(fakeClient, scheme, &mockEventRecorder{}, endpoint.NewEndpointDeriver(fakeClient))
+				start := time.Now()
+				now := start
+				faults := faultinject.New()
+				faults.SetClock(func() time.Time { return now })
+				faults.FlapRuntimeReadiness(time.Minute)
+				sm.SetFaultInjector(faults)
+
+				plan := &scorev1b1.WorkloadPlan{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-workload", Namespace: "test-ns"},
+					Status:     scorev1b1.WorkloadPlanStatus{Phase: scorev1b1.WorkloadPlanPhaseReady},
+				}
+				sm.updateRuntimeStatusFromPlan(testWorkload, plan)
+				Expect(conditions.IsConditionTrue(testWorkload.Status.Conditions, conditions.ConditionRuntimeReady)).To(BeTrue())
+
+				now = start.Add(time.Minute)
+				sm.updateRuntimeStatusFromPlan(testWorkload, plan)
+				condition := conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionRuntimeReady)
+				Expect(condition).ToNot(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+				Expect(condition.Reason).To(Equal(conditions.ReasonRuntimeDegraded))
+			})
+		})
+	})
+
+	Describe("ReasonForError", func() {
+		var sm *StatusManager
+
+		BeforeEach(func() {
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+			sm = NewStatusManager(fakeClient, scheme, record.NewFakeRecorder(10), endpoint.NewEndpointDeriver(fakeClient))
+		})
+
+		It("should set RuntimeReady with the canonical message", func() {
+			testWorkload := workload.DeepCopy()
+			reason := sm.SetRuntimeReadyConditionFromError(testWorkload, selection.ErrProfileNotFound, conditions.ReasonRuntimeSelecting)
+			Expect(reason).To(Equal(conditions.ReasonProfileNotFound))
+
+			condition := conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionRuntimeReady)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Message).To(Equal(conditions.MessageProfileNotFound))
+		})
+
+		It("should point at the offending container variable for placeholder errors", func() {
+			testWorkload := workload.DeepCopy()
+			err := fmt.Errorf("failed to resolve placeholders: %w", &reconcile.PlaceholderError{
+				Path:        "containers.app.variables.DATABASE_URL",
+				Placeholder: "${resources.db.outputs.uri}",
+				Reason:      "resource 'db' has no outputs available",
+			})
+			reason := sm.SetRuntimeReadyConditionFromError(testWorkload, err, conditions.ReasonRuntimeDegraded)
+			Expect(reason).To(Equal(conditions.ReasonProjectionError))
+
+			condition := conditions.GetCondition(testWorkload.Status.Conditions, conditions.ConditionRuntimeReady)
+			Expect(condition).ToNot(BeNil())
+			Expect(condition.Message).To(HavePrefix(conditions.MessageProjectionError))
+			Expect(condition.Message).To(ContainSubstring("containers.app.variables.DATABASE_URL: ${resources.db.outputs.uri}"))
 		})
 	})
 })
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/notification"
+	"github.com/cappyzawa/score-orchestrator/internal/sharding"
+)
+
+// NotificationReconciler posts lifecycle notifications when Workloads, ResourceClaims and WorkloadExposures
+// change state. It only reads the objects it watches.
+type NotificationReconciler struct {
+	client.Client
+	Notifier *notification.Notifier
+	// Tracker remembers the states already notified; it is created at startup, so notifications about
+	// states entered before a restart are not repeated
+	Tracker *notification.Tracker
+	// Shard limits Workload notifications to the Workloads hashed onto it. ResourceClaims and
+	// WorkloadExposures are not sharded and are notified by shard 0 only.
+	Shard sharding.Shard
+}
+
+// +kubebuilder:rbac:groups=score.dev,resources=workloads,verbs=get;list;watch
+// +kubebuilder:rbac:groups=score.dev,resources=resourceclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups=score.dev,resources=workloadexposures,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get
+
+// reconcileWorkload notifies changes of the Ready condition of a Workload
+func (r *NotificationReconciler) reconcileWorkload(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if !r.Shard.Owns(req.NamespacedName) {
+		return ctrl.Result{}, nil
+	}
+	workload := &scorev1b1.Workload{}
+	if err := r.Get(ctx, req.NamespacedName, workload); err != nil {
+		return r.forget(ctx, "Workload", req, err)
+	}
+	n, state, notify := notification.ForWorkload(workload)
+	if state == "" {
+		return ctrl.Result{}, nil
+	}
+	r.observe(ctx, n, state, notify)
+	return ctrl.Result{}, nil
+}
+
+// reconcileClaim notifies failures of a ResourceClaim
+func (r *NotificationReconciler) reconcileClaim(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	claim := &scorev1b1.ResourceClaim{}
+	if err := r.Get(ctx, req.NamespacedName, claim); err != nil {
+		return r.forget(ctx, "ResourceClaim", req, err)
+	}
+	n, state, notify := notification.ForClaim(claim)
+	r.observe(ctx, n, state, notify)
+	return ctrl.Result{}, nil
+}
+
+// reconcileExposure notifies the endpoints a WorkloadExposure publishes
+func (r *NotificationReconciler) reconcileExposure(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	exposure := &scorev1b1.WorkloadExposure{}
+	if err := r.Get(ctx, req.NamespacedName, exposure); err != nil {
+		return r.forget(ctx, "WorkloadExposure", req, err)
+	}
+	n, state, notify := notification.ForExposure(exposure)
+	r.observe(ctx, n, state, notify)
+	return ctrl.Result{}, nil
+}
+
+// observe records the state of the object and posts the notification when the state changed.
+// Delivery is attempted once: failures are logged and counted, not retried, so that a failing sink
+// cannot hold up or duplicate the notifications of other sinks.
+func (r *NotificationReconciler) observe(ctx context.Context, n notification.Notification, state string, notify bool) {
+	key := n.Kind + "/" + n.Namespace + "/" + n.Name
+	if !r.Tracker.Changed(key, state, n.Time) || !notify {
+		return
+	}
+	if err := r.Notifier.Notify(ctx, n); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to deliver notification", "type", n.Type, "id", n.ID)
+	}
+}
+
+// forget drops the tracked state of a deleted object
+func (r *NotificationReconciler) forget(ctx context.Context, kind string, req ctrl.Request, err error) (ctrl.Result, error) {
+	if client.IgnoreNotFound(err) != nil {
+		return ctrl.Result{}, err
+	}
+	r.Tracker.Forget(kind + "/" + req.Namespace + "/" + req.Name)
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the notification controllers with the Manager
+func (r *NotificationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Tracker == nil {
+		r.Tracker = notification.NewTracker(time.Now())
+	}
+	if err := ctrl.NewControllerManagedBy(mgr).
+		For(&scorev1b1.Workload{}).
+		Named("notification-workload").
+		Complete(reconcile.Func(r.reconcileWorkload)); err != nil {
+		return err
+	}
+	if r.Shard.Index != 0 {
+		return nil
+	}
+	if err := ctrl.NewControllerManagedBy(mgr).
+		For(&scorev1b1.ResourceClaim{}).
+		Named("notification-claim").
+		Complete(reconcile.Func(r.reconcileClaim)); err != nil {
+		return err
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&scorev1b1.WorkloadExposure{}).
+		Named("notification-exposure").
+		Complete(reconcile.Func(r.reconcileExposure))
+}
@@ -0,0 +1,199 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// Event reasons for orphan garbage collection
+const (
+	EventReasonOrphanDeleted  = "OrphanDeleted"
+	EventReasonOrphanDetached = "OrphanDetached"
+)
+
+// DefaultOrphanSweepInterval is how often orphaned plans and claims are swept when no interval is configured.
+const DefaultOrphanSweepInterval = 10 * time.Minute
+
+// +kubebuilder:rbac:groups=score.dev,resources=workloads,verbs=get;list;watch
+// +kubebuilder:rbac:groups=score.dev,resources=workloadplans,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups=score.dev,resources=resourceclaims,verbs=get;list;watch;update;delete
+
+// OrphanSweeper periodically garbage collects WorkloadPlans and ResourceClaims whose owning Workload is gone.
+// An object is orphaned when its controller reference names a Workload that no longer exists, or that was
+// recreated under the same name with a different UID. This happens when a Workload is force-deleted with its
+// finalizer stripped, so the deletion phase never ran. Plans are deleted; claims follow their DeprovisionPolicy.
+type OrphanSweeper struct {
+	Client   client.Client
+	Recorder record.EventRecorder
+
+	// Interval between sweeps. Defaults to DefaultOrphanSweepInterval.
+	Interval time.Duration
+}
+
+// Start runs a sweep immediately and then once per interval until ctx is cancelled.
+func (s *OrphanSweeper) Start(ctx context.Context) error {
+	interval := s.Interval
+	if interval <= 0 {
+		interval = DefaultOrphanSweepInterval
+	}
+
+	logger := log.FromContext(ctx).WithName("orphan-sweeper")
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := s.Sweep(ctx); err != nil {
+			logger.Error(err, "Failed to sweep orphaned objects")
+		}
+	}, interval)
+	return nil
+}
+
+// NeedLeaderElection ensures only the leader deletes or detaches orphans.
+func (s *OrphanSweeper) NeedLeaderElection() bool {
+	return true
+}
+
+// Sweep performs a single garbage collection pass over all WorkloadPlans and ResourceClaims.
+// Failures on individual objects do not stop the pass; they are joined into the returned error.
+func (s *OrphanSweeper) Sweep(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("orphan-sweeper")
+	var errs []error
+
+	var plans scorev1b1.WorkloadPlanList
+	if err := s.Client.List(ctx, &plans); err != nil {
+		return fmt.Errorf("failed to list WorkloadPlans: %w", err)
+	}
+	for i := range plans.Items {
+		plan := &plans.Items[i]
+		ref := types.NamespacedName{Namespace: plan.Spec.WorkloadRef.Namespace, Name: plan.Spec.WorkloadRef.Name}
+		orphaned, err := s.isOrphaned(ctx, plan, ref)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !orphaned {
+			continue
+		}
+
+		logger.Info("Deleting orphaned WorkloadPlan", "plan", client.ObjectKeyFromObject(plan), "workload", ref)
+		if err := s.Client.Delete(ctx, plan); client.IgnoreNotFound(err) != nil {
+			errs = append(errs, fmt.Errorf("failed to delete orphaned WorkloadPlan %s/%s: %w", plan.Namespace, plan.Name, err))
+			continue
+		}
+		s.Recorder.Eventf(plan, EventTypeNormal, EventReasonOrphanDeleted,
+			"Deleted WorkloadPlan orphaned by Workload %s", ref.Name)
+	}
+
+	var claims scorev1b1.ResourceClaimList
+	if err := s.Client.List(ctx, &claims); err != nil {
+		return errors.Join(append(errs, fmt.Errorf("failed to list ResourceClaims: %w", err))...)
+	}
+	for i := range claims.Items {
+		claim := &claims.Items[i]
+		ref := types.NamespacedName{Namespace: claim.Spec.WorkloadRef.Namespace, Name: claim.Spec.WorkloadRef.Name}
+		orphaned, err := s.isOrphaned(ctx, claim, ref)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !orphaned {
+			continue
+		}
+
+		if err := s.collectClaim(ctx, claim, ref); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// collectClaim applies the claim's DeprovisionPolicy the same way the Workload deletion phase would:
+// Delete hands the claim to the Provisioner for cleanup, Retain and Orphan detach it from the Workload
+// so the Kubernetes garbage collector does not remove it along with its dangling owner.
+func (s *OrphanSweeper) collectClaim(ctx context.Context, claim *scorev1b1.ResourceClaim, ref types.NamespacedName) error {
+	logger := log.FromContext(ctx).WithName("orphan-sweeper").WithValues("claim", client.ObjectKeyFromObject(claim), "workload", ref)
+
+	policy := scorev1b1.DeprovisionDelete
+	if claim.Spec.DeprovisionPolicy != nil {
+		policy = *claim.Spec.DeprovisionPolicy
+	}
+
+	switch policy {
+	case scorev1b1.DeprovisionRetain, scorev1b1.DeprovisionOrphan:
+		logger.Info("Detaching orphaned ResourceClaim", "deprovisionPolicy", policy)
+		detached := claim.DeepCopy()
+		detached.OwnerReferences = nil
+		for _, ownerRef := range claim.OwnerReferences {
+			if !isWorkloadOwnerRef(ownerRef) {
+				detached.OwnerReferences = append(detached.OwnerReferences, ownerRef)
+			}
+		}
+		if err := s.Client.Update(ctx, detached); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to detach orphaned ResourceClaim %s/%s: %w", claim.Namespace, claim.Name, err)
+		}
+		s.Recorder.Eventf(detached, EventTypeNormal, EventReasonOrphanDetached,
+			"Detached ResourceClaim orphaned by Workload %s (deprovisionPolicy=%s)", ref.Name, policy)
+	default:
+		logger.Info("Deleting orphaned ResourceClaim", "deprovisionPolicy", policy)
+		if err := s.Client.Delete(ctx, claim); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete orphaned ResourceClaim %s/%s: %w", claim.Namespace, claim.Name, err)
+		}
+		s.Recorder.Eventf(claim, EventTypeNormal, EventReasonOrphanDeleted,
+			"Deleted ResourceClaim orphaned by Workload %s", ref.Name)
+	}
+	return nil
+}
+
+// isOrphaned reports whether obj is controlled by a Workload that no longer exists under ref.
+// Objects without a Workload controller reference (for example claims already detached by a Retain
+// policy) and objects already being deleted are never considered orphaned.
+func (s *OrphanSweeper) isOrphaned(ctx context.Context, obj client.Object, ref types.NamespacedName) (bool, error) {
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return false, nil
+	}
+	owner := metav1.GetControllerOf(obj)
+	if owner == nil || !isWorkloadOwnerRef(*owner) {
+		return false, nil
+	}
+
+	var workload scorev1b1.Workload
+	if err := s.Client.Get(ctx, ref, &workload); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get Workload %s: %w", ref, err)
+	}
+	return workload.UID != owner.UID, nil
+}
+
+// isWorkloadOwnerRef reports whether ownerRef points at a score.dev Workload.
+func isWorkloadOwnerRef(ownerRef metav1.OwnerReference) bool {
+	return ownerRef.Kind == "Workload" && ownerRef.APIVersion == scorev1b1.GroupVersion.String()
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+var _ = Describe("OrphanSweeper", func() {
+	const namespace = "default"
+
+	var (
+		sweeper  *OrphanSweeper
+		recorder *record.FakeRecorder
+	)
+
+	workloadOwner := func(uid types.UID) []metav1.OwnerReference {
+		return []metav1.OwnerReference{{
+			APIVersion: scorev1b1.GroupVersion.String(),
+			Kind:       "Workload",
+			Name:       "web",
+			UID:        uid,
+			Controller: ptr.To(true),
+		}}
+	}
+
+	newPlan := func(uid types.UID) *scorev1b1.WorkloadPlan {
+		return &scorev1b1.WorkloadPlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: namespace, OwnerReferences: workloadOwner(uid)},
+			Spec: scorev1b1.WorkloadPlanSpec{
+				WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "web", Namespace: namespace},
+			},
+		}
+	}
+
+	newClaim := func(name string, uid types.UID, policy *scorev1b1.DeprovisionPolicy) *scorev1b1.ResourceClaim {
+		return &scorev1b1.ResourceClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, OwnerReferences: workloadOwner(uid)},
+			Spec: scorev1b1.ResourceClaimSpec{
+				WorkloadRef:       scorev1b1.NamespacedName{Name: "web", Namespace: namespace},
+				Key:               name,
+				Type:              "postgres",
+				DeprovisionPolicy: policy,
+			},
+		}
+	}
+
+	setup := func(objs ...client.Object) {
+		recorder = record.NewFakeRecorder(10)
+		sweeper = &OrphanSweeper{
+			Client:   fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(objs...).Build(),
+			Recorder: recorder,
+		}
+	}
+
+	exists := func(obj client.Object) bool {
+		err := sweeper.Client.Get(ctx, client.ObjectKeyFromObject(obj), obj)
+		if apierrors.IsNotFound(err) {
+			return false
+		}
+		Expect(err).NotTo(HaveOccurred())
+		return true
+	}
+
+	It("keeps plans and claims whose Workload still exists", func() {
+		workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: namespace, UID: "uid-1"}}
+		plan := newPlan("uid-1")
+		claim := newClaim("db", "uid-1", nil)
+		setup(workload, plan, claim)
+
+		Expect(sweeper.Sweep(ctx)).To(Succeed())
+		Expect(exists(plan)).To(BeTrue())
+		Expect(exists(claim)).To(BeTrue())
+	})
+
+	It("deletes plans and Delete-policy claims whose Workload is gone", func() {
+		plan := newPlan("uid-1")
+		claim := newClaim("db", "uid-1", nil)
+		setup(plan, claim)
+
+		Expect(sweeper.Sweep(ctx)).To(Succeed())
+		Expect(exists(plan)).To(BeFalse())
+		Expect(exists(claim)).To(BeFalse())
+		Expect(recorder.Events).To(Receive(ContainSubstring(EventReasonOrphanDeleted)))
+	})
+
+	It("treats a Workload recreated under the same name as a different owner", func() {
+		workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: namespace, UID: "uid-2"}}
+		plan := newPlan("uid-1")
+		setup(workload, plan)
+
+		Expect(sweeper.Sweep(ctx)).To(Succeed())
+		Expect(exists(plan)).To(BeFalse())
+	})
+
+	It("detaches Retain and Orphan claims instead of deleting them", func() {
+		retained := newClaim("db", "uid-1", ptr.To(scorev1b1.DeprovisionRetain))
+		orphaned := newClaim("cache", "uid-1", ptr.To(scorev1b1.DeprovisionOrphan))
+		setup(retained, orphaned)
+
+		Expect(sweeper.Sweep(ctx)).To(Succeed())
+		for _, claim := range []*scorev1b1.ResourceClaim{retained, orphaned} {
+			Expect(exists(claim)).To(BeTrue())
+			Expect(claim.OwnerReferences).To(BeEmpty())
+			Expect(recorder.Events).To(Receive(ContainSubstring(EventReasonOrphanDetached)))
+		}
+
+		By("leaving detached claims alone on the next sweep")
+		Expect(sweeper.Sweep(ctx)).To(Succeed())
+		Expect(exists(retained)).To(BeTrue())
+		Expect(recorder.Events).NotTo(Receive())
+	})
+})
@@ -3,7 +3,7 @@ package phases
 import (
 	"context"
 
-	"github.com/cappyzawa/score-orchestrator/internal/status"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
 )
 
 // Event constants for claim phase
@@ -25,30 +25,30 @@ func (p *ClaimPhase) Execute(ctx context.Context, phaseCtx *PhaseContext) PhaseR
 	log := phaseCtx.Logger.WithValues("phase", p.Name())
 	log.V(1).Info("Starting claim phase")
 
-	// Create/update ResourceClaims using ClaimManager
-	if err := phaseCtx.ClaimManager.EnsureClaims(ctx, phaseCtx.Workload); err != nil {
-		log.Error(err, "Failed to ensure ResourceClaims")
-		phaseCtx.Recorder.Eventf(phaseCtx.Workload, EventTypeWarning, EventReasonClaimError, "Failed to create resource claims: %v", err)
+	// Claims carry the Workload labels and annotations selected by the propagation policy
+	metadata, err := phaseCtx.PlanManager.PropagatedMetadata(ctx, phaseCtx.Workload)
+	if err != nil {
+		log.Error(err, "Failed to select propagated metadata")
 		return PhaseResult{Error: err}
 	}
 
-	log.V(1).Info("ResourceClaims ensured successfully")
-
-	// Get and aggregate claim statuses using ClaimManager
-	claims, err := phaseCtx.ClaimManager.GetClaims(ctx, phaseCtx.Workload)
+	// Create/update ResourceClaims using ClaimManager; the returned claims are
+	// reused for aggregation so that the claims are listed only once per reconcile
+	claims, err := phaseCtx.ClaimManager.EnsureClaims(ctx, phaseCtx.Workload, metadata)
 	if err != nil {
-		log.Error(err, "Failed to get ResourceClaims")
+		log.Error(err, "Failed to ensure ResourceClaims")
+		phaseCtx.Recorder.Eventf(phaseCtx.Workload, EventTypeWarning, EventReasonClaimError, "Failed to create resource claims: %v", err)
 		return PhaseResult{Error: err}
 	}
 
-	log.V(1).Info("Retrieved ResourceClaims", "count", len(claims))
+	log.V(1).Info("ResourceClaims ensured successfully", "count", len(claims))
 
 	// Update context with claim data
 	phaseCtx.Claims = claims
-	phaseCtx.ClaimAgg = phaseCtx.ClaimManager.AggregateStatus(claims)
+	phaseCtx.ClaimAgg = phaseCtx.ClaimManager.AggregateWorkloadStatus(phaseCtx.Workload, claims)
 
 	// Update workload status from aggregation
-	status.UpdateWorkloadStatusFromAggregation(phaseCtx.Workload, phaseCtx.ClaimAgg)
+	phaseCtx.StatusManager.SetClaimsStatus(phaseCtx.Workload, phaseCtx.ClaimAgg)
 
 	log.V(1).Info("Claim phase completed successfully")
 	return PhaseResult{}
@@ -56,6 +56,6 @@ func (p *ClaimPhase) Execute(ctx context.Context, phaseCtx *PhaseContext) PhaseR
 
 // ShouldSkip determines if claim phase should be skipped
 func (p *ClaimPhase) ShouldSkip(ctx context.Context, phaseCtx *PhaseContext) bool {
-	// Skip claim phase during deletion
-	return !phaseCtx.Workload.DeletionTimestamp.IsZero()
+	// Skip claim phase during deletion and in dry-run mode
+	return !phaseCtx.Workload.DeletionTimestamp.IsZero() || reconcile.IsDryRun(phaseCtx.Workload)
 }
@@ -5,8 +5,10 @@ import (
 
 	"github.com/go-logr/logr"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/backoff"
 	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
 )
 
@@ -61,6 +63,12 @@ func (p *DeletionPhase) Execute(ctx context.Context, phaseCtx *PhaseContext) Pha
 		}
 	}
 
+	// Remove the WorkloadExposure so runtimes stop publishing endpoints for the Workload
+	if err := p.deleteWorkloadExposure(ctx, phaseCtx); err != nil {
+		log.Error(err, "Failed to delete WorkloadExposure")
+		return PhaseResult{Error: err}
+	}
+
 	// Wait for ResourceClaims to be cleaned up by their owners (Provisioners)
 	// Only wait for claims that should be deleted according to their policy
 	remainingClaims, err := phaseCtx.ClaimManager.GetClaims(ctx, phaseCtx.Workload)
@@ -80,7 +88,7 @@ func (p *DeletionPhase) Execute(ctx context.Context, phaseCtx *PhaseContext) Pha
 
 	if claimsToWaitFor > 0 {
 		log.V(1).Info("Waiting for ResourceClaims to be cleaned up", "count", claimsToWaitFor)
-		requeueDelay := phaseCtx.ReconcilerConfig.Retry.DefaultRequeueDelay
+		requeueDelay := phaseCtx.Backoff.Requeue(client.ObjectKeyFromObject(phaseCtx.Workload), backoff.ClassWaiting)
 		return PhaseResult{Requeue: true, RequeueAfter: requeueDelay}
 	}
 
@@ -89,6 +97,8 @@ func (p *DeletionPhase) Execute(ctx context.Context, phaseCtx *PhaseContext) Pha
 		log.Error(err, "Failed to remove finalizer")
 		return PhaseResult{Error: err}
 	}
+	phaseCtx.StatusManager.ForgetWorkload(phaseCtx.Workload)
+	phaseCtx.PlanManager.ForgetWorkload(phaseCtx.Workload)
 
 	phaseCtx.Recorder.Event(phaseCtx.Workload, EventTypeNormal, EventReasonDeleted, "Workload cleanup completed")
 	log.V(1).Info("Deletion phase completed successfully")
@@ -161,6 +171,17 @@ func (p *DeletionPhase) deleteResourceClaim(ctx context.Context, phaseCtx *Phase
 	return phaseCtx.Client.Delete(ctx, claim)
 }
 
+// deleteWorkloadExposure deletes the WorkloadExposure registered for the Workload, if any
+func (p *DeletionPhase) deleteWorkloadExposure(ctx context.Context, phaseCtx *PhaseContext) error {
+	exposure := &scorev1b1.WorkloadExposure{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      phaseCtx.Workload.Name,
+			Namespace: phaseCtx.Workload.Namespace,
+		},
+	}
+	return client.IgnoreNotFound(phaseCtx.Client.Delete(ctx, exposure))
+}
+
 // ShouldSkip determines if deletion phase should be skipped
 func (p *DeletionPhase) ShouldSkip(ctx context.Context, phaseCtx *PhaseContext) bool {
 	// Deletion phase is only executed when workload is being deleted
@@ -0,0 +1,78 @@
+package phases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cappyzawa/score-orchestrator/internal/backoff"
+	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+	"github.com/cappyzawa/score-orchestrator/internal/dependency"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+)
+
+// DependencyPhase holds back WorkloadPlan creation until the Workloads listed in
+// spec.dependsOn report Ready=True
+type DependencyPhase struct{}
+
+// Name returns the name of the dependency phase
+func (p *DependencyPhase) Name() string {
+	return "Dependency"
+}
+
+// Execute checks the readiness of the workload's dependencies.
+// While any dependency is not ready the workload is marked Blocked and requeued;
+// dependents are also enqueued when a dependency changes.
+func (p *DependencyPhase) Execute(ctx context.Context, phaseCtx *PhaseContext) PhaseResult {
+	log := phaseCtx.Logger.WithValues("phase", p.Name())
+	log.V(1).Info("Starting dependency phase")
+
+	// Dependencies only gate the creation of the plan; an existing plan keeps being updated
+	if _, err := phaseCtx.PlanManager.GetPlan(ctx, phaseCtx.Workload); err == nil {
+		log.V(1).Info("WorkloadPlan already exists, dependencies are not re-checked")
+		phaseCtx.StatusManager.ClearBlocked(phaseCtx.Workload)
+		return PhaseResult{}
+	} else if !apierrors.IsNotFound(err) {
+		log.Error(err, "Failed to get WorkloadPlan")
+		return PhaseResult{Error: err}
+	}
+
+	blocking, err := dependency.Blocking(ctx, phaseCtx.Client, phaseCtx.Workload)
+	if err != nil {
+		log.Error(err, "Failed to check workload dependencies")
+		return PhaseResult{Error: err}
+	}
+	if len(blocking) == 0 {
+		log.V(1).Info("All dependencies are ready")
+		phaseCtx.StatusManager.ClearBlocked(phaseCtx.Workload)
+		return PhaseResult{}
+	}
+
+	message := fmt.Sprintf("Waiting for workloads to become ready: %s", strings.Join(blocking, ", "))
+	log.Info("Workload is blocked by its dependencies", "blocking", blocking)
+	phaseCtx.StatusManager.SetBlocked(phaseCtx.Workload, message)
+
+	if err := phaseCtx.StatusManager.UpdateStatus(ctx, phaseCtx.Workload); err != nil {
+		if apierrors.IsConflict(err) {
+			log.V(1).Info("Resource version conflict, requeuing", "error", err)
+			return PhaseResult{Requeue: true, RequeueAfter: phaseCtx.ReconcilerConfig.Retry.ConflictRequeueDelay}
+		}
+		log.Error(err, "Failed to update Workload status")
+		return PhaseResult{Error: err}
+	}
+
+	return PhaseResult{Requeue: true, RequeueAfter: phaseCtx.Backoff.Requeue(client.ObjectKeyFromObject(phaseCtx.Workload), backoff.ClassWaiting)}
+}
+
+// ShouldSkip determines if dependency phase should be skipped
+func (p *DependencyPhase) ShouldSkip(ctx context.Context, phaseCtx *PhaseContext) bool {
+	// Only workloads with dependencies, or a Blocked condition left to clear, are gated;
+	// no plan is created for deleting or dry-run workloads
+	return (len(phaseCtx.Workload.Spec.DependsOn) == 0 &&
+		!conditions.IsConditionTrue(phaseCtx.Workload.Status.Conditions, conditions.ConditionBlocked)) ||
+		!phaseCtx.Workload.DeletionTimestamp.IsZero() ||
+		reconcile.IsDryRun(phaseCtx.Workload)
+}
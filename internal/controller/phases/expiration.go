@@ -0,0 +1,57 @@
+package phases
+
+import (
+	"context"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+)
+
+// Event constants for expiration phase
+const (
+	EventReasonExpired = "Expired"
+)
+
+// ExpirationPhase deletes Workloads whose score.dev/ttl has passed, e.g. pull request previews.
+// The deletion pipeline then deprovisions their claims like for any deleted Workload.
+type ExpirationPhase struct{}
+
+// Name returns the name of the expiration phase
+func (p *ExpirationPhase) Name() string {
+	return "Expiration"
+}
+
+// Execute records when the Workload expires, and deletes it once it has
+func (p *ExpirationPhase) Execute(ctx context.Context, phaseCtx *PhaseContext) PhaseResult {
+	log := phaseCtx.Logger.WithValues("phase", p.Name())
+	workload := phaseCtx.Workload
+
+	expiresAt, err := reconcile.ExpiresAt(workload)
+	if err != nil {
+		// The validation phase reports the invalid annotation
+		workload.Status.ExpiresAt = nil
+		return PhaseResult{}
+	}
+	workload.Status.ExpiresAt = expiresAt
+	if expiresAt == nil || time.Now().Before(expiresAt.Time) {
+		return PhaseResult{}
+	}
+
+	log.Info("Workload TTL has passed, deleting", "expiresAt", expiresAt.Time)
+	if err := phaseCtx.Client.Delete(ctx, workload); client.IgnoreNotFound(err) != nil {
+		log.Error(err, "Failed to delete expired Workload")
+		return PhaseResult{Error: err}
+	}
+	phaseCtx.Recorder.Eventf(workload, EventTypeNormal, EventReasonExpired, "Workload expired at %s", expiresAt.UTC().Format(time.RFC3339))
+
+	// The deletion is reconciled by the deletion pipeline
+	return PhaseResult{Skip: true}
+}
+
+// ShouldSkip determines if the expiration phase should be skipped
+func (p *ExpirationPhase) ShouldSkip(ctx context.Context, phaseCtx *PhaseContext) bool {
+	// Skip during deletion
+	return !phaseCtx.Workload.DeletionTimestamp.IsZero()
+}
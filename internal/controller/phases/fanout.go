@@ -0,0 +1,83 @@
+package phases
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/fanout"
+)
+
+// FanOutPhase instantiates Workloads that list spec.environments once per environment.
+// Such Workloads get no claims or plan of their own, so the remaining phases are skipped for them.
+type FanOutPhase struct{}
+
+// Name returns the name of the fan-out phase
+func (p *FanOutPhase) Name() string {
+	return "FanOut"
+}
+
+// Execute applies the instances of the environments and aggregates their readiness
+func (p *FanOutPhase) Execute(ctx context.Context, phaseCtx *PhaseContext) PhaseResult {
+	log := phaseCtx.Logger.WithValues("phase", p.Name())
+	workload := phaseCtx.Workload
+
+	// A Workload that stopped listing environments runs directly again; its instances are removed
+	if len(workload.Spec.Environments) == 0 {
+		log.V(1).Info("Workload no longer fans out, removing its instances")
+		if err := fanout.Prune(ctx, phaseCtx.Client, workload); err != nil {
+			log.Error(err, "Failed to remove environment instances")
+			return PhaseResult{Error: err}
+		}
+		workload.Status.Environments = nil
+		return PhaseResult{}
+	}
+
+	log.V(1).Info("Starting fan-out phase", "environments", workload.Spec.Environments)
+
+	environmentLabel, err := phaseCtx.PlanManager.EnvironmentLabel(ctx)
+	if err != nil {
+		// Instances report the configuration failure themselves; the default label still selects their profile
+		log.V(1).Info("Could not load the environment label, using the default", "error", err.Error())
+		environmentLabel = scorev1b1.DefaultEnvironmentLabel
+	}
+
+	// A plan created before the Workload fanned out would keep running next to the instances
+	if plan, err := phaseCtx.PlanManager.GetPlan(ctx, workload); err == nil {
+		if err := phaseCtx.Client.Delete(ctx, plan); client.IgnoreNotFound(err) != nil {
+			log.Error(err, "Failed to delete WorkloadPlan")
+			return PhaseResult{Error: err}
+		}
+	} else if !apierrors.IsNotFound(err) {
+		log.Error(err, "Failed to get WorkloadPlan")
+		return PhaseResult{Error: err}
+	}
+
+	environments, err := fanout.Ensure(ctx, phaseCtx.Client, workload, environmentLabel)
+	if err != nil {
+		log.Error(err, "Failed to apply environment instances")
+		return PhaseResult{Error: err}
+	}
+
+	phaseCtx.StatusManager.SetEnvironmentsStatus(workload, environments)
+	if err := phaseCtx.StatusManager.UpdateStatus(ctx, workload); err != nil {
+		if apierrors.IsConflict(err) {
+			log.V(1).Info("Resource version conflict, requeuing", "error", err)
+			return PhaseResult{Requeue: true, RequeueAfter: phaseCtx.ReconcilerConfig.Retry.ConflictRequeueDelay}
+		}
+		log.Error(err, "Failed to update Workload status")
+		return PhaseResult{Error: err}
+	}
+
+	log.V(1).Info("Fan-out phase completed successfully", "reason", workload.Status.Reason)
+	return PhaseResult{Skip: true}
+}
+
+// ShouldSkip determines if the fan-out phase should be skipped
+func (p *FanOutPhase) ShouldSkip(ctx context.Context, phaseCtx *PhaseContext) bool {
+	// Only Workloads that list environments, or still report instances to remove, fan out
+	return (len(phaseCtx.Workload.Spec.Environments) == 0 && len(phaseCtx.Workload.Status.Environments) == 0) ||
+		!phaseCtx.Workload.DeletionTimestamp.IsZero()
+}
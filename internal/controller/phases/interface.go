@@ -10,6 +10,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/backoff"
 	"github.com/cappyzawa/score-orchestrator/internal/config"
 	"github.com/cappyzawa/score-orchestrator/internal/controller/managers"
 	"github.com/cappyzawa/score-orchestrator/internal/status"
@@ -43,6 +44,9 @@ type PhaseContext struct {
 	ClaimManager  *managers.ClaimManager
 	PlanManager   *managers.PlanManager
 	StatusManager *managers.StatusManager
+	QuotaManager  *managers.QuotaManager
+	// Backoff computes the delays of Workloads requeued while waiting
+	Backoff *backoff.Backoff
 
 	// Phase-specific data
 	Claims            []scorev1b1.ResourceClaim
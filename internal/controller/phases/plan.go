@@ -3,6 +3,8 @@ package phases
 import (
 	"context"
 	"strings"
+
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
 )
 
 // PlanPhase handles WorkloadPlan creation and updates
@@ -48,6 +50,6 @@ func (p *PlanPhase) Execute(ctx context.Context, phaseCtx *PhaseContext) PhaseRe
 
 // ShouldSkip determines if plan phase should be skipped
 func (p *PlanPhase) ShouldSkip(ctx context.Context, phaseCtx *PhaseContext) bool {
-	// Skip plan phase during deletion
-	return !phaseCtx.Workload.DeletionTimestamp.IsZero()
+	// Skip plan phase during deletion and in dry-run mode
+	return !phaseCtx.Workload.DeletionTimestamp.IsZero() || reconcile.IsDryRun(phaseCtx.Workload)
 }
@@ -0,0 +1,53 @@
+package phases
+
+import (
+	"context"
+
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+)
+
+// PreviewPhase computes a dry-run preview for Workloads annotated with score.dev/dry-run
+type PreviewPhase struct{}
+
+// Name returns the name of the preview phase
+func (p *PreviewPhase) Name() string {
+	return "Preview"
+}
+
+// Execute performs backend selection and values composition without creating claims or plans
+func (p *PreviewPhase) Execute(ctx context.Context, phaseCtx *PhaseContext) PhaseResult {
+	log := phaseCtx.Logger.WithValues("phase", p.Name())
+
+	// Drop a stale preview once dry-run mode is turned off
+	if !reconcile.IsDryRun(phaseCtx.Workload) {
+		phaseCtx.Workload.Status.Preview = nil
+		return PhaseResult{}
+	}
+
+	log.V(1).Info("Starting preview phase")
+
+	// Existing claims are only read to reuse their outputs
+	claims, err := phaseCtx.ClaimManager.GetClaims(ctx, phaseCtx.Workload)
+	if err != nil {
+		log.Error(err, "Failed to get ResourceClaims")
+		return PhaseResult{Error: err}
+	}
+
+	preview, err := phaseCtx.PlanManager.PreviewPlan(ctx, phaseCtx.Workload, claims)
+	if err != nil {
+		// The failure is reported through the RuntimeReady condition by the status phase
+		log.V(1).Info("Failed to compute preview", "error", err)
+		phaseCtx.Workload.Status.Preview = nil
+		return PhaseResult{}
+	}
+
+	phaseCtx.Workload.Status.Preview = preview
+	log.V(1).Info("Preview phase completed successfully", "backend", preview.BackendID)
+	return PhaseResult{}
+}
+
+// ShouldSkip determines if preview phase should be skipped
+func (p *PreviewPhase) ShouldSkip(ctx context.Context, phaseCtx *PhaseContext) bool {
+	// Skip preview phase during deletion
+	return !phaseCtx.Workload.DeletionTimestamp.IsZero()
+}
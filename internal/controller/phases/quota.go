@@ -0,0 +1,62 @@
+package phases
+
+import (
+	"context"
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/cappyzawa/score-orchestrator/internal/backoff"
+	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+	"github.com/cappyzawa/score-orchestrator/internal/quota"
+)
+
+// QuotaPhase enforces the configured quotas before any claims or plans are created
+type QuotaPhase struct{}
+
+// Name returns the name of the quota phase
+func (p *QuotaPhase) Name() string {
+	return "Quota"
+}
+
+// Execute checks the workload against the configured quotas.
+// On a violation the status is updated and the workload is requeued, so it is
+// admitted once capacity frees up.
+func (p *QuotaPhase) Execute(ctx context.Context, phaseCtx *PhaseContext) PhaseResult {
+	log := phaseCtx.Logger.WithValues("phase", p.Name())
+	log.V(1).Info("Starting quota phase")
+
+	err := phaseCtx.QuotaManager.CheckQuotas(ctx, phaseCtx.Workload)
+	if err == nil {
+		log.V(1).Info("Workload is within quota")
+		return PhaseResult{}
+	}
+	if !errors.Is(err, quota.ErrQuotaExceeded) {
+		log.Error(err, "Failed to check quotas")
+		return PhaseResult{Error: err}
+	}
+
+	log.Info("Workload exceeds quota", "reason", err.Error())
+	phaseCtx.InputsValid = false
+	phaseCtx.ValidationReason = conditions.ReasonQuotaExceeded
+	phaseCtx.ValidationMessage = err.Error()
+	phaseCtx.StatusManager.SetQuotaExceeded(phaseCtx.Workload, err.Error())
+
+	if err := phaseCtx.StatusManager.UpdateStatus(ctx, phaseCtx.Workload); err != nil {
+		if apierrors.IsConflict(err) {
+			log.V(1).Info("Resource version conflict, requeuing", "error", err)
+			return PhaseResult{Requeue: true, RequeueAfter: phaseCtx.ReconcilerConfig.Retry.ConflictRequeueDelay}
+		}
+		log.Error(err, "Failed to update Workload status")
+		return PhaseResult{Error: err}
+	}
+
+	return PhaseResult{Requeue: true, RequeueAfter: phaseCtx.Backoff.Requeue(client.ObjectKeyFromObject(phaseCtx.Workload), backoff.ClassWaiting)}
+}
+
+// ShouldSkip determines if quota phase should be skipped
+func (p *QuotaPhase) ShouldSkip(ctx context.Context, phaseCtx *PhaseContext) bool {
+	// Quotas are only enforced when configured, and never for deleting workloads
+	return phaseCtx.QuotaManager == nil || !phaseCtx.Workload.DeletionTimestamp.IsZero()
+}
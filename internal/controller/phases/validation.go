@@ -2,8 +2,23 @@ package phases
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
 	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+	"github.com/cappyzawa/score-orchestrator/internal/dependency"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/policy"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+	cronschedule "github.com/cappyzawa/score-orchestrator/internal/schedule"
+	"github.com/cappyzawa/score-orchestrator/internal/selection"
+	"github.com/cappyzawa/score-orchestrator/internal/valuesschema"
 )
 
 // ValidationPhase handles input validation and policy checks
@@ -20,7 +35,11 @@ func (p *ValidationPhase) Execute(ctx context.Context, phaseCtx *PhaseContext) P
 	log.V(1).Info("Starting validation phase")
 
 	// Perform validation logic
-	inputsValid, reason, message := p.validateInputsAndPolicy(ctx, phaseCtx)
+	inputsValid, reason, message, err := p.validateInputsAndPolicy(ctx, phaseCtx)
+	if err != nil {
+		log.Error(err, "Failed to validate inputs")
+		return PhaseResult{Error: err}
+	}
 
 	// Update context with validation results
 	phaseCtx.InputsValid = inputsValid
@@ -32,7 +51,20 @@ func (p *ValidationPhase) Execute(ctx context.Context, phaseCtx *PhaseContext) P
 
 	if !inputsValid {
 		log.V(1).Info("Inputs validation failed", "reason", reason, "message", message)
-		// Skip remaining phases and update status
+		if reason == conditions.ReasonPolicyViolation {
+			phaseCtx.StatusManager.SetPolicyViolation(phaseCtx.Workload, message)
+		} else {
+			phaseCtx.StatusManager.SetSpecInvalid(phaseCtx.Workload, message)
+		}
+		if err := phaseCtx.StatusManager.UpdateStatus(ctx, phaseCtx.Workload); err != nil {
+			if apierrors.IsConflict(err) {
+				log.V(1).Info("Resource version conflict, requeuing", "error", err)
+				return PhaseResult{Requeue: true, RequeueAfter: phaseCtx.ReconcilerConfig.Retry.ConflictRequeueDelay}
+			}
+			log.Error(err, "Failed to update Workload status")
+			return PhaseResult{Error: err}
+		}
+		// Skip remaining phases
 		return PhaseResult{Skip: true}
 	}
 
@@ -48,11 +80,154 @@ func (p *ValidationPhase) ShouldSkip(ctx context.Context, phaseCtx *PhaseContext
 
 // validateInputsAndPolicy performs the actual validation logic
 // This is extracted to allow for easier testing and future expansion
-func (p *ValidationPhase) validateInputsAndPolicy(_ context.Context, phaseCtx *PhaseContext) (bool, string, string) {
+func (p *ValidationPhase) validateInputsAndPolicy(ctx context.Context, phaseCtx *PhaseContext) (bool, string, string, error) {
 	// For MVP: basic validation (CRD-level validation handles most cases)
 	// Resources are optional - workloads can be stateless without dependencies
 
-	// ADR-0003: Policy validation is now handled via Orchestrator Config + Admission
-	// For MVP, basic spec validation is sufficient
-	return true, conditions.ReasonSucceeded, "Workload specification is valid"
+	// Only the documented values opt in to or out of the pod security defaults
+	switch value := phaseCtx.Workload.Annotations[meta.AnnotationSecurityDefaults]; value {
+	case "", meta.SecurityDefaultsEnabled, meta.SecurityDefaultsDisabled:
+	default:
+		return false, conditions.ReasonSpecInvalid, fmt.Sprintf("annotation %s must be %q or %q, got %q",
+			meta.AnnotationSecurityDefaults, meta.SecurityDefaultsEnabled, meta.SecurityDefaultsDisabled, value), nil
+	}
+
+	// Likewise for resolving image tags to digests
+	switch value := phaseCtx.Workload.Annotations[meta.AnnotationImageDigests]; value {
+	case "", meta.ImageDigestsEnabled, meta.ImageDigestsDisabled:
+	default:
+		return false, conditions.ReasonSpecInvalid, fmt.Sprintf("annotation %s must be %q or %q, got %q",
+			meta.AnnotationImageDigests, meta.ImageDigestsEnabled, meta.ImageDigestsDisabled, value), nil
+	}
+
+	// A TTL that cannot be parsed would keep the Workload forever
+	if _, err := reconcile.ExpiresAt(phaseCtx.Workload); err != nil {
+		return false, conditions.ReasonSpecInvalid, err.Error(), nil
+	}
+
+	// Runtimes compare the restart request as written, so only timestamps are accepted
+	if value, ok := phaseCtx.Workload.Annotations[meta.AnnotationRestart]; ok {
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return false, conditions.ReasonSpecInvalid, fmt.Sprintf("annotation %s must be an RFC 3339 timestamp, got %q",
+				meta.AnnotationRestart, value), nil
+		}
+	}
+
+	// Variables under the reserved prefix would be overridden by the ones runtimes inject
+	if name := reservedVariable(phaseCtx.Workload); name != "" {
+		return false, conditions.ReasonSpecInvalid, fmt.Sprintf("variable %s uses the prefix %s, which is reserved for variables injected by the platform",
+			name, meta.EnvReservedPrefix), nil
+	}
+
+	// Runtimes name the ports of the Service they generate after the declared names
+	if name := duplicatePortName(phaseCtx.Workload); name != "" {
+		return false, conditions.ReasonSpecInvalid, fmt.Sprintf("service port name %q is used by more than one port", name), nil
+	}
+
+	// A schedule the runtime cannot parse would only fail once the CronJob is written
+	if schedule := phaseCtx.Workload.Spec.Schedule; schedule != nil {
+		if err := cronschedule.Validate(*schedule); err != nil {
+			return false, conditions.ReasonSpecInvalid, fmt.Sprintf("invalid spec.schedule %q: %v", *schedule, err), nil
+		}
+	}
+
+	// Checks against the OrchestratorConfig are skipped without one; the plan phase reports the failure
+	orchestratorConfig, err := phaseCtx.PlanManager.LoadConfig(ctx)
+	if err != nil {
+		phaseCtx.Logger.V(1).Info("Could not load orchestrator config", "error", err.Error())
+	}
+
+	// Persistent volumes are only materialized for continuously running workloads
+	if storage := phaseCtx.Workload.Spec.Storage; orchestratorConfig != nil && storage != nil && len(storage.Volumes) > 0 {
+		kind, err := phaseCtx.PlanManager.WorkloadKind(phaseCtx.Workload, orchestratorConfig)
+		if err != nil {
+			// Profile selection failures are reported by the plan phase
+			phaseCtx.Logger.V(1).Info("Could not determine workload kind", "error", err.Error())
+		} else if kind != scorev1b1.WorkloadKindService {
+			return false, conditions.ReasonSpecInvalid, fmt.Sprintf("spec.storage is not supported for workloads of kind %s", kind), nil
+		}
+	}
+
+	// Workloads that (transitively) depend on themselves can never become ready
+	cycle, err := dependency.FindCycle(ctx, phaseCtx.Client, phaseCtx.Workload)
+	if err != nil {
+		return false, "", "", fmt.Errorf("failed to check workload dependencies: %w", err)
+	}
+	if cycle != nil {
+		return false, conditions.ReasonSpecInvalid, fmt.Sprintf("dependency cycle detected: %s", strings.Join(cycle, " -> ")), nil
+	}
+
+	// Claims of resources that (transitively) depend on themselves or on undeclared resources are never created
+	if key, dep := dependency.UndeclaredResource(phaseCtx.Workload.Spec.Resources); key != "" {
+		return false, conditions.ReasonSpecInvalid, fmt.Sprintf("resource %s depends on undeclared resource %s", key, dep), nil
+	}
+	if cycle := dependency.FindResourceCycle(phaseCtx.Workload.Spec.Resources); cycle != nil {
+		return false, conditions.ReasonSpecInvalid, fmt.Sprintf("resource dependency cycle detected: %s", strings.Join(cycle, " -> ")), nil
+	}
+
+	if orchestratorConfig == nil {
+		return true, conditions.ReasonSucceeded, "Workload specification is valid", nil
+	}
+
+	// Params that violate the schema of their provisioner would only fail the claims created for them
+	var schemaErr *valuesschema.ValidationError
+	if err := phaseCtx.PlanManager.ValidateResourceParams(phaseCtx.Workload, orchestratorConfig); errors.As(err, &schemaErr) {
+		return false, conditions.ReasonSpecInvalid, err.Error(), nil
+	} else if err != nil {
+		// An invalid params schema is a configuration error; the claims report it
+		phaseCtx.Logger.V(1).Info("Could not validate resource params", "error", err.Error())
+	}
+
+	// A profile or requirement no backend offers would only fail profile selection
+	if err := phaseCtx.PlanManager.ValidateSelectionHints(phaseCtx.Workload, orchestratorConfig); errors.Is(err, selection.ErrInvalidHint) {
+		return false, conditions.ReasonSpecInvalid, err.Error(), nil
+	} else if err != nil {
+		return false, "", "", fmt.Errorf("failed to validate selection hints: %w", err)
+	}
+
+	// ADR-0003: Platform policies are configured in the Orchestrator Config
+	if err := phaseCtx.PlanManager.CheckAdmissionPolicies(phaseCtx.Workload, orchestratorConfig); errors.Is(err, policy.ErrPolicyDenied) {
+		return false, conditions.ReasonPolicyViolation, err.Error(), nil
+	} else if err != nil {
+		return false, "", "", fmt.Errorf("failed to evaluate admission policies: %w", err)
+	}
+
+	return true, conditions.ReasonSucceeded, "Workload specification is valid", nil
+}
+
+// reservedVariable returns the first container variable (as containers.<name>.variables.<key>) that uses
+// the reserved prefix, or "" when there is none
+func reservedVariable(workload *scorev1b1.Workload) string {
+	var reserved []string
+	for containerName, container := range workload.Spec.Containers {
+		for key := range container.Variables {
+			if strings.HasPrefix(key, meta.EnvReservedPrefix) {
+				reserved = append(reserved, fmt.Sprintf("containers.%s.variables.%s", containerName, key))
+			}
+		}
+	}
+	if len(reserved) == 0 {
+		return ""
+	}
+	sort.Strings(reserved)
+	return reserved[0]
+}
+
+// duplicatePortName returns the first service port name declared by more than one port, or "" when
+// names are unique
+func duplicatePortName(workload *scorev1b1.Workload) string {
+	if workload.Spec.Service == nil {
+		return ""
+	}
+	seen := make(map[string]bool, len(workload.Spec.Service.Ports))
+	for _, port := range workload.Spec.Service.Ports {
+		if port.Name == "" {
+			continue
+		}
+		if seen[port.Name] {
+			return port.Name
+		}
+		seen[port.Name] = true
+	}
+	return ""
 }
@@ -0,0 +1,227 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/config"
+	"github.com/cappyzawa/score-orchestrator/internal/delivery"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+// errTargetNotConfigured indicates that a plan names a target missing from the orchestrator configuration
+var errTargetNotConfigured = errors.New("target is not configured")
+
+// planDeliveryResync is how often the status of delivered plans is read back from the remote clusters
+const planDeliveryResync = 30 * time.Second
+
+// Plan delivery condition reasons
+const (
+	reasonDelivered      = "Delivered"
+	reasonDeliveryFailed = "DeliveryFailed"
+)
+
+// PlanDeliveryReconciler delivers WorkloadPlans with a target to the remote cluster of the target and
+// mirrors the status reported by the remote runtime back onto them
+type PlanDeliveryReconciler struct {
+	client.Client
+	Scheme       *runtime.Scheme
+	Recorder     record.EventRecorder
+	ConfigLoader config.ConfigLoader
+	Deliverer    *delivery.Deliverer
+}
+
+// +kubebuilder:rbac:groups=score.dev,resources=workloadplans,verbs=get;list;watch;update
+// +kubebuilder:rbac:groups=score.dev,resources=workloadplans/status,verbs=get;update
+// +kubebuilder:rbac:groups=score.dev,resources=workloadplans/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch
+
+// Reconcile delivers the WorkloadPlan to its target and reads its status back
+func (r *PlanDeliveryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx).WithValues("workloadplan", req.NamespacedName)
+
+	plan := &scorev1b1.WorkloadPlan{}
+	if err := r.Get(ctx, req.NamespacedName, plan); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	if !plan.DeletionTimestamp.IsZero() || plan.Spec.Target == "" ||
+		(deliveredTarget(plan) != "" && deliveredTarget(plan) != plan.Spec.Target) {
+		if !controllerutil.ContainsFinalizer(plan, meta.PlanDeliveryFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		return r.removeDelivered(ctx, plan)
+	}
+
+	// The target is recorded before delivering, so that the remote copy can be found after the target changes
+	if !controllerutil.ContainsFinalizer(plan, meta.PlanDeliveryFinalizer) || plan.Annotations[meta.AnnotationDeliveredTarget] != plan.Spec.Target {
+		controllerutil.AddFinalizer(plan, meta.PlanDeliveryFinalizer)
+		if plan.Annotations == nil {
+			plan.Annotations = map[string]string{}
+		}
+		plan.Annotations[meta.AnnotationDeliveredTarget] = plan.Spec.Target
+		if err := r.Update(ctx, plan); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to record delivery target: %w", err)
+		}
+	}
+
+	original := plan.Status.DeepCopy()
+	delivered, err := r.deliver(ctx, plan)
+	if err != nil {
+		logger.Error(err, "Failed to deliver WorkloadPlan", "target", plan.Spec.Target)
+		r.Recorder.Event(plan, corev1.EventTypeWarning, reasonDeliveryFailed, err.Error())
+		r.setDelivered(plan, metav1.ConditionFalse, reasonDeliveryFailed, err.Error())
+	} else {
+		delivery.MirrorStatus(plan, delivered)
+		r.setDelivered(plan, metav1.ConditionTrue, reasonDelivered,
+			fmt.Sprintf("Delivered to target %s", plan.Spec.Target))
+	}
+
+	if !equality.Semantic.DeepEqual(original, &plan.Status) {
+		if err := r.Status().Update(ctx, plan); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to update WorkloadPlan status: %w", err)
+		}
+	}
+	// The remote runtime reports progress only in the remote cluster, so its status is polled
+	return ctrl.Result{RequeueAfter: planDeliveryResync}, nil
+}
+
+// deliver applies the remote copy of the plan to the cluster of its target
+func (r *PlanDeliveryReconciler) deliver(ctx context.Context, plan *scorev1b1.WorkloadPlan) (*scorev1b1.WorkloadPlan, error) {
+	remote, err := r.remoteClient(ctx, plan.Spec.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	var workload *scorev1b1.Workload
+	if plan.Spec.WorkloadSnapshot == nil {
+		workload = &scorev1b1.Workload{}
+		key := types.NamespacedName{Namespace: plan.Spec.WorkloadRef.Namespace, Name: plan.Spec.WorkloadRef.Name}
+		if err := r.Get(ctx, key, workload); err != nil {
+			return nil, fmt.Errorf("failed to get workload %s: %w", key, err)
+		}
+	}
+	delivered, err := delivery.Deliver(ctx, remote, plan, workload)
+	if err != nil {
+		return nil, err
+	}
+	// The remote runtime reads values too large to be embedded in the plan from its own cluster
+	if err := delivery.DeliverValues(ctx, r.Client, remote, plan, delivered); err != nil {
+		return nil, err
+	}
+	return delivered, nil
+}
+
+// removeDelivered deletes the remote copy of the plan from the target it was delivered to, and then releases
+// the plan, or lets it be delivered to its new target. A copy whose target is no longer configured cannot be
+// reached and is left in place.
+func (r *PlanDeliveryReconciler) removeDelivered(ctx context.Context, plan *scorev1b1.WorkloadPlan) (ctrl.Result, error) {
+	target := deliveredTarget(plan)
+	remote, err := r.remoteClient(ctx, target)
+	switch {
+	case errors.Is(err, errTargetNotConfigured):
+		r.Recorder.Eventf(plan, corev1.EventTypeWarning, reasonDeliveryFailed,
+			"Remote copy of the plan is left in place: %v", err)
+	case err != nil:
+		return ctrl.Result{}, err
+	default:
+		gone, err := delivery.Remove(ctx, remote, plan)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if !gone {
+			// The remote runtime tears down its resources before the remote plan disappears
+			return ctrl.Result{RequeueAfter: planDeliveryResync}, nil
+		}
+	}
+
+	delete(plan.Annotations, meta.AnnotationDeliveredTarget)
+	released := !plan.DeletionTimestamp.IsZero() || plan.Spec.Target == ""
+	if released {
+		controllerutil.RemoveFinalizer(plan, meta.PlanDeliveryFinalizer)
+	}
+	if err := r.Update(ctx, plan); err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("failed to release plan: %w", err)
+	}
+	if !released {
+		r.Recorder.Eventf(plan, corev1.EventTypeNormal, reasonDelivered,
+			"Removed the remote copy of the plan from target %s", target)
+		return ctrl.Result{Requeue: true}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// deliveredTarget returns the target the remote copy of the plan was delivered to. Plans delivered before the
+// target was recorded fall back to their current target.
+func deliveredTarget(plan *scorev1b1.WorkloadPlan) string {
+	if target := plan.Annotations[meta.AnnotationDeliveredTarget]; target != "" {
+		return target
+	}
+	return plan.Spec.Target
+}
+
+// remoteClient returns the client of the named target of the current configuration
+func (r *PlanDeliveryReconciler) remoteClient(ctx context.Context, name string) (client.Client, error) {
+	orchestratorConfig, err := r.ConfigLoader.LoadConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load orchestrator config: %w", err)
+	}
+	for i := range orchestratorConfig.Spec.Targets {
+		if target := &orchestratorConfig.Spec.Targets[i]; target.Name == name {
+			return r.Deliverer.RemoteClient(ctx, target)
+		}
+	}
+	return nil, fmt.Errorf("%w: %q", errTargetNotConfigured, name)
+}
+
+// setDelivered records the outcome of the last delivery on the plan
+func (r *PlanDeliveryReconciler) setDelivered(plan *scorev1b1.WorkloadPlan, status metav1.ConditionStatus, reason, message string) {
+	apimeta.SetStatusCondition(&plan.Status.Conditions, metav1.Condition{
+		Type:               meta.PlanConditionDelivered,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: plan.Generation,
+	})
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *PlanDeliveryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&scorev1b1.WorkloadPlan{}).
+		Named("plan-delivery").
+		Complete(r)
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/config"
+	"github.com/cappyzawa/score-orchestrator/internal/delivery"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+// applyAsCreateOrUpdate emulates server-side apply, which the fake client does not support,
+// by creating the applied object or replacing the existing one. Other patches pass through.
+func applyAsCreateOrUpdate(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+
+	existing, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", obj)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		return c.Create(ctx, obj)
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return c.Update(ctx, obj)
+}
+
+var _ = Describe("PlanDeliveryReconciler", func() {
+	const namespace = "default"
+
+	var (
+		reconciler *PlanDeliveryReconciler
+		local      client.Client
+		remote     client.Client
+		plan       *scorev1b1.WorkloadPlan
+	)
+
+	BeforeEach(func() {
+		kubeconfig := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "eu-west-kubeconfig", Namespace: "score-system"},
+			Data: map[string][]byte{delivery.KubeconfigKey: []byte(
+				"apiVersion: v1\nkind: Config\nclusters:\n- name: spoke\n  cluster:\n    server: https://spoke.example.com\n" +
+					"contexts:\n- name: spoke\n  context:\n    cluster: spoke\ncurrent-context: spoke\n",
+			)},
+		}
+		workload := &scorev1b1.Workload{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: namespace, Generation: 1},
+			Spec:       scorev1b1.WorkloadSpec{Containers: map[string]scorev1b1.ContainerSpec{"web": {Image: "nginx:1"}}},
+		}
+		plan = &scorev1b1.WorkloadPlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: namespace},
+			Spec: scorev1b1.WorkloadPlanSpec{
+				WorkloadRef:                scorev1b1.WorkloadPlanWorkloadRef{Name: "web", Namespace: namespace},
+				ObservedWorkloadGeneration: 1,
+				RuntimeClass:               meta.RuntimeClassKubernetes,
+				Target:                     "eu-west",
+			},
+		}
+
+		local = fake.NewClientBuilder().WithScheme(scheme.Scheme).
+			WithObjects(kubeconfig, workload, plan).
+			WithStatusSubresource(&scorev1b1.WorkloadPlan{}).
+			Build()
+		remote = fake.NewClientBuilder().WithScheme(scheme.Scheme).WithStatusSubresource(&scorev1b1.WorkloadPlan{}).
+			WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).
+			Build()
+
+		loader := config.NewMockLoader()
+		loader.SetConfig(&scorev1b1.OrchestratorConfig{Spec: scorev1b1.OrchestratorConfigSpec{
+			Targets: []scorev1b1.RuntimeTargetSpec{{
+				Name:                "eu-west",
+				KubeconfigSecretRef: scorev1b1.NamespacedName{Namespace: "score-system", Name: "eu-west-kubeconfig"},
+			}},
+		}})
+		reconciler = &PlanDeliveryReconciler{
+			Client:       local,
+			Scheme:       scheme.Scheme,
+			Recorder:     record.NewFakeRecorder(10),
+			ConfigLoader: loader,
+			Deliverer: &delivery.Deliverer{
+				Client: local,
+				Scheme: scheme.Scheme,
+				NewClient: func(*rest.Config, client.Options) (client.Client, error) {
+					return remote, nil
+				},
+			},
+		}
+	})
+
+	reconcile := func() {
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(plan)})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	It("delivers the plan and mirrors the status of the remote runtime", func() {
+		reconcile()
+
+		delivered := &scorev1b1.WorkloadPlan{}
+		Expect(remote.Get(ctx, client.ObjectKeyFromObject(plan), delivered)).To(Succeed())
+		Expect(delivered.Spec.Target).To(BeEmpty())
+		Expect(delivered.Spec.WorkloadSnapshot).NotTo(BeNil())
+
+		Expect(local.Get(ctx, client.ObjectKeyFromObject(plan), plan)).To(Succeed())
+		Expect(plan.Finalizers).To(ContainElement(meta.PlanDeliveryFinalizer))
+		Expect(apimeta.IsStatusConditionTrue(plan.Status.Conditions, meta.PlanConditionDelivered)).To(BeTrue())
+
+		By("reporting the remote status once the remote runtime observed the plan")
+		delivered.Status.Phase = scorev1b1.WorkloadPlanPhaseReady
+		delivered.Status.ObservedGeneration = delivered.Generation
+		Expect(remote.Status().Update(ctx, delivered)).To(Succeed())
+		reconcile()
+
+		Expect(local.Get(ctx, client.ObjectKeyFromObject(plan), plan)).To(Succeed())
+		Expect(plan.Status.Phase).To(Equal(scorev1b1.WorkloadPlanPhaseReady))
+		Expect(plan.Status.ObservedGeneration).To(Equal(plan.Generation))
+	})
+
+	It("removes the remote plan before releasing a deleted plan", func() {
+		reconcile()
+		Expect(local.Get(ctx, client.ObjectKeyFromObject(plan), plan)).To(Succeed())
+		Expect(local.Delete(ctx, plan)).To(Succeed())
+
+		reconcile()
+		Expect(apierrors.IsNotFound(remote.Get(ctx, client.ObjectKeyFromObject(plan), &scorev1b1.WorkloadPlan{}))).To(BeTrue())
+
+		reconcile()
+		Expect(apierrors.IsNotFound(local.Get(ctx, client.ObjectKeyFromObject(plan), &scorev1b1.WorkloadPlan{}))).To(BeTrue())
+	})
+
+	It("removes the remote plan from the recorded target once the target is cleared", func() {
+		reconcile()
+		Expect(local.Get(ctx, client.ObjectKeyFromObject(plan), plan)).To(Succeed())
+		Expect(plan.Annotations).To(HaveKeyWithValue(meta.AnnotationDeliveredTarget, "eu-west"))
+
+		plan.Spec.Target = ""
+		Expect(local.Update(ctx, plan)).To(Succeed())
+		reconcile()
+		Expect(apierrors.IsNotFound(remote.Get(ctx, client.ObjectKeyFromObject(plan), &scorev1b1.WorkloadPlan{}))).To(BeTrue())
+
+		reconcile()
+		Expect(local.Get(ctx, client.ObjectKeyFromObject(plan), plan)).To(Succeed())
+		Expect(plan.Finalizers).NotTo(ContainElement(meta.PlanDeliveryFinalizer))
+		Expect(plan.Annotations).NotTo(HaveKey(meta.AnnotationDeliveredTarget))
+	})
+
+	It("reports a target missing from the configuration", func() {
+		plan.Spec.Target = "us-east"
+		Expect(local.Update(ctx, plan)).To(Succeed())
+		reconcile()
+
+		Expect(local.Get(ctx, client.ObjectKeyFromObject(plan), plan)).To(Succeed())
+		delivered := apimeta.FindStatusCondition(plan.Status.Conditions, meta.PlanConditionDelivered)
+		Expect(delivered).NotTo(BeNil())
+		Expect(delivered.Status).To(Equal(metav1.ConditionFalse))
+		Expect(delivered.Message).To(ContainSubstring("us-east"))
+	})
+})
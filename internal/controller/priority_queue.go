@@ -0,0 +1,110 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+// HighPriority is the queue priority of Workloads annotated with score.dev/priority=high.
+// Items with a higher priority are always handed to workers first.
+const HighPriority = 100
+
+// priorityLookupTimeout bounds the Workload lookup done while enqueueing a request
+const priorityLookupTimeout = time.Second
+
+// NewWorkloadPriorityQueue returns a workqueue constructor for the Workload controller.
+// Requests for Workloads annotated with score.dev/priority=high are raised to HighPriority,
+// regardless of which watch enqueued them, so urgent Workloads skip the backlog of routine updates.
+func NewWorkloadPriorityQueue(reader client.Reader) func(string, workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+	return func(controllerName string, rateLimiter workqueue.TypedRateLimiter[reconcile.Request]) workqueue.TypedRateLimitingInterface[reconcile.Request] {
+		return &workloadPriorityQueue{
+			PriorityQueue: priorityqueue.New(controllerName, func(o *priorityqueue.Opts[reconcile.Request]) {
+				o.RateLimiter = rateLimiter
+			}),
+			reader: reader,
+			log:    ctrl.Log.WithName("priority-queue").WithValues("controller", controllerName),
+		}
+	}
+}
+
+// workloadPriorityQueue raises the priority of requests for high-priority Workloads
+type workloadPriorityQueue struct {
+	priorityqueue.PriorityQueue[reconcile.Request]
+	reader client.Reader
+	log    logr.Logger
+}
+
+// Add adds a request with the Workload's priority
+func (q *workloadPriorityQueue) Add(item reconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{}, item)
+}
+
+// AddAfter adds a request with the Workload's priority after the given delay
+func (q *workloadPriorityQueue) AddAfter(item reconcile.Request, duration time.Duration) {
+	q.AddWithOpts(priorityqueue.AddOpts{After: duration}, item)
+}
+
+// AddRateLimited adds a request with the Workload's priority after the rate limiter says it's ok
+func (q *workloadPriorityQueue) AddRateLimited(item reconcile.Request) {
+	q.AddWithOpts(priorityqueue.AddOpts{RateLimited: true}, item)
+}
+
+// AddWithOpts adds requests, raising the priority of high-priority Workloads
+func (q *workloadPriorityQueue) AddWithOpts(o priorityqueue.AddOpts, items ...reconcile.Request) {
+	for _, item := range items {
+		opts := o
+		if opts.Priority < HighPriority && q.isHighPriority(item) {
+			opts.Priority = HighPriority
+		}
+		q.PriorityQueue.AddWithOpts(opts, item)
+	}
+}
+
+// isHighPriority looks up the Workload in the cache and checks its priority annotation.
+// Requests whose Workload cannot be read keep their priority.
+func (q *workloadPriorityQueue) isHighPriority(item reconcile.Request) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), priorityLookupTimeout)
+	defer cancel()
+
+	workload := &scorev1b1.Workload{}
+	if err := q.reader.Get(ctx, item.NamespacedName, workload); err != nil {
+		if !apierrors.IsNotFound(err) {
+			q.log.V(1).Info("Failed to look up Workload priority", "workload", item.NamespacedName, "error", err.Error())
+		}
+		return false
+	}
+	return IsHighPriority(workload)
+}
+
+// IsHighPriority reports whether the Workload is annotated with score.dev/priority=high
+func IsHighPriority(workload *scorev1b1.Workload) bool {
+	return strings.EqualFold(strings.TrimSpace(workload.Annotations[meta.AnnotationPriority]), meta.PriorityHigh)
+}
@@ -0,0 +1,93 @@
+package controller
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/controller/priorityqueue"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+var _ = Describe("WorkloadPriorityQueue", func() {
+	newWorkload := func(name string, annotations map[string]string) *scorev1b1.Workload {
+		return &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Annotations: annotations,
+		}}
+	}
+	request := func(name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: name}}
+	}
+
+	It("should dequeue high-priority Workloads first", func() {
+		reader := fake.NewClientBuilder().WithScheme(provisionerScheme).WithObjects(
+			newWorkload("routine", nil),
+			newWorkload("urgent", map[string]string{meta.AnnotationPriority: meta.PriorityHigh}),
+		).Build()
+
+		queue := NewWorkloadPriorityQueue(reader)("workload-priority-test",
+			workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		defer queue.ShutDown()
+
+		queue.Add(request("routine"))
+		queue.Add(request("urgent"))
+		queue.Add(request("missing"))
+		Expect(queue.Len()).To(Equal(3))
+
+		pq, ok := queue.(priorityqueue.PriorityQueue[reconcile.Request])
+		Expect(ok).To(BeTrue())
+		item, priority, _ := pq.GetWithPriority()
+		Expect(item).To(Equal(request("urgent")))
+		Expect(priority).To(Equal(HighPriority))
+		pq.Done(item)
+
+		item, priority, _ = pq.GetWithPriority()
+		Expect(item).To(Equal(request("routine")))
+		Expect(priority).To(Equal(0))
+		pq.Done(item)
+	})
+
+	It("should bound the Workload lookup and keep the priority when it fails", func() {
+		var hasDeadline bool
+		reader := fake.NewClientBuilder().WithScheme(provisionerScheme).
+			WithObjects(newWorkload("urgent", map[string]string{meta.AnnotationPriority: meta.PriorityHigh})).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+					_, hasDeadline = ctx.Deadline()
+					return errors.New("cache unavailable")
+				},
+			}).Build()
+
+		queue := NewWorkloadPriorityQueue(reader)("workload-priority-lookup-test",
+			workqueue.DefaultTypedControllerRateLimiter[reconcile.Request]())
+		defer queue.ShutDown()
+
+		queue.Add(request("urgent"))
+		Expect(hasDeadline).To(BeTrue())
+
+		pq, ok := queue.(priorityqueue.PriorityQueue[reconcile.Request])
+		Expect(ok).To(BeTrue())
+		item, priority, _ := pq.GetWithPriority()
+		Expect(item).To(Equal(request("urgent")))
+		Expect(priority).To(Equal(0))
+		pq.Done(item)
+	})
+
+	It("should accept the annotation case-insensitively", func() {
+		Expect(IsHighPriority(newWorkload("a", map[string]string{meta.AnnotationPriority: " High "}))).To(BeTrue())
+		Expect(IsHighPriority(newWorkload("b", map[string]string{meta.AnnotationPriority: "low"}))).To(BeFalse())
+		Expect(IsHighPriority(newWorkload("c", nil))).To(BeFalse())
+	})
+})
@@ -0,0 +1,59 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// throttled reports whether the concurrency limit of the claim type keeps the claim from being provisioned,
+// along with the message explaining why. Claims of the type in the Claiming phase occupy the slots; under
+// the fifo queue, older Pending claims of the type take the free slots first.
+func (r *ProvisionerReconciler) throttled(ctx context.Context, claim *scorev1b1.ResourceClaim, concurrency *scorev1b1.ConcurrencyPolicy) (bool, string, error) {
+	if concurrency == nil {
+		return false, "", nil
+	}
+
+	claims := &scorev1b1.ResourceClaimList{}
+	if err := r.List(ctx, claims); err != nil {
+		return false, "", fmt.Errorf("failed to list ResourceClaims: %w", err)
+	}
+
+	provisioning, queuedAhead := 0, 0
+	for i := range claims.Items {
+		other := &claims.Items[i]
+		if other.Spec.Type != claim.Spec.Type || other.UID == claim.UID || other.DeletionTimestamp != nil {
+			continue
+		}
+		switch other.Status.Phase {
+		case scorev1b1.ResourceClaimPhaseClaiming:
+			provisioning++
+		case "", scorev1b1.ResourceClaimPhasePending:
+			if concurrency.Queue != scorev1b1.ConcurrencyQueueUnordered && queuedBefore(other, claim) {
+				queuedAhead++
+			}
+		}
+	}
+
+	limit := int(concurrency.MaxConcurrentProvisions)
+	if provisioning >= limit {
+		return true, fmt.Sprintf("Waiting for a provisioning slot: %d of %d %s claims are provisioning", provisioning, limit, claim.Spec.Type), nil
+	}
+	if free := limit - provisioning; queuedAhead >= free {
+		return true, fmt.Sprintf("Waiting for a provisioning slot behind %d older %s claims", queuedAhead, claim.Spec.Type), nil
+	}
+	return false, "", nil
+}
+
+// queuedBefore reports whether claim a was queued before claim b, ordering claims created in the same
+// second by namespace and name
+func queuedBefore(a, b *scorev1b1.ResourceClaim) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
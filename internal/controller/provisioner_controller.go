@@ -2,25 +2,44 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/audit"
+	"github.com/cappyzawa/score-orchestrator/internal/backoff"
 	"github.com/cappyzawa/score-orchestrator/internal/conditions"
 	"github.com/cappyzawa/score-orchestrator/internal/config"
+	"github.com/cappyzawa/score-orchestrator/internal/faultinject"
 	"github.com/cappyzawa/score-orchestrator/internal/provisioner"
 	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
-	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy/postgres"
-	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy/redis"
-	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy/secret"
+	"github.com/cappyzawa/score-orchestrator/internal/valuesschema"
+
+	// Built-in strategies register themselves with the strategy registry
+	_ "github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy/dns"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy/external"
+	_ "github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy/postgres"
+	_ "github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy/redis"
+	_ "github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy/secret"
+	_ "github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy/staticuri"
+	_ "github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy/terraform"
+	_ "github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy/tlscert"
+	_ "github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy/topic"
+	_ "github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy/volume"
+	_ "github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy/webhook"
 )
 
 // Event constants for ProvisionerReconciler
@@ -28,6 +47,8 @@ const (
 	EventReasonProvisioning      = "Provisioning"
 	EventReasonProvisioned       = "Provisioned"
 	EventReasonProvisionFailed   = "ProvisionFailed"
+	EventReasonProvisionTimeout  = "ProvisionTimeout"
+	EventReasonThrottled         = "ProvisionThrottled"
 	EventReasonDeprovisioning    = "Deprovisioning"
 	EventReasonDeprovisioned     = "Deprovisioned"
 	EventReasonDeprovisionFailed = "DeprovisionFailed"
@@ -43,6 +64,19 @@ type ProvisionerReconciler struct {
 	OutputManager    *provisioner.OutputManager
 	LifecycleManager *ResourceClaimLifecycleManager
 	supportedTypes   map[string]bool
+	logger           logr.Logger
+
+	// MaxConcurrentReconciles is the number of ResourceClaims reconciled in parallel (default 1)
+	MaxConcurrentReconciles int
+
+	// Auditor records bound claims in the audit trail; no decisions are recorded when nil
+	Auditor *audit.Recorder
+
+	// FaultInjector slows down and fails provisioning in integration tests; no faults are injected when nil
+	FaultInjector *faultinject.Injector
+
+	// Backoff computes the delays of claims requeued while their resource is not ready
+	Backoff *backoff.Backoff
 }
 
 // NewProvisionerReconciler creates a new ProvisionerReconciler
@@ -57,42 +91,32 @@ func NewProvisionerReconciler(
 		Scheme:           scheme,
 		Recorder:         recorder,
 		ConfigLoader:     configLoader,
-		StrategySelector: strategy.NewSelector(),
+		StrategySelector: strategy.NewSelector(k8sClient),
 		OutputManager:    provisioner.NewOutputManager(),
 		LifecycleManager: NewResourceClaimLifecycleManager(),
 		supportedTypes:   make(map[string]bool),
+		logger:           ctrl.Log.WithName("provisioner"),
+		Backoff:          backoff.New("resourceclaim", nil),
 	}
 }
 
 // SetupWithManager sets up the controller with the Manager
 func (r *ProvisionerReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	fmt.Printf("DEBUG: ProvisionerReconciler.SetupWithManager called\n")
+	r.logger = mgr.GetLogger().WithName("provisioner")
 
-	// Load supported types from environment or config
-	fmt.Printf("DEBUG: Loading supported types\n")
+	// Load supported types from environment; without them the OrchestratorConfig decides
 	r.loadSupportedTypes()
+	r.logger.Info("Registered provisioning strategies", "strategies", strategy.Registered())
 
-	// Load provisioning configuration
-	fmt.Printf("DEBUG: Loading provisioning configuration\n")
-	r.loadProvisioningConfig()
-
-	// Register concrete strategies
-	fmt.Printf("DEBUG: Registering strategies\n")
-	r.registerStrategies()
-
-	fmt.Printf("DEBUG: Setting up controller with manager\n")
-	controller := ctrl.NewControllerManagedBy(mgr).
+	if err := ctrl.NewControllerManagedBy(mgr).
 		For(&scorev1b1.ResourceClaim{}).
-		WithEventFilter(predicate.NewPredicateFuncs(r.filterSupportedTypes))
-
-	fmt.Printf("DEBUG: Controller builder created, calling Complete\n")
-	err := controller.Complete(r)
-	if err != nil {
-		fmt.Printf("DEBUG: Controller.Complete failed: %v\n", err)
+		WithEventFilter(predicate.NewPredicateFuncs(r.filterSupportedTypes)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		Complete(r); err != nil {
 		return err
 	}
 
-	fmt.Printf("DEBUG: Provisioner controller setup completed successfully\n")
+	r.logger.V(1).Info("Provisioner controller setup completed")
 	return nil
 }
 
@@ -102,47 +126,54 @@ func (r *ProvisionerReconciler) SetupWithManager(mgr ctrl.Manager) error {
 // +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=postgresql.cnpg.io,resources=clusters,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=externaldns.k8s.io,resources=dnsendpoints,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cert-manager.io,resources=certificates,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=kafka.strimzi.io,resources=kafkatopics;kafkausers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=jetstream.nats.io,resources=streams,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=persistentvolumeclaims,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile handles ResourceClaim reconciliation
 func (r *ProvisionerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
-	fmt.Printf("DEBUG: Provisioner.Reconcile called for %s/%s\n", req.Namespace, req.Name)
 
 	// Fetch the ResourceClaim
 	claim := &scorev1b1.ResourceClaim{}
 	if err := r.Get(ctx, req.NamespacedName, claim); err != nil {
-		fmt.Printf("DEBUG: Failed to fetch ResourceClaim %s/%s: %v\n", req.Namespace, req.Name, err)
+		if client.IgnoreNotFound(err) == nil {
+			log.V(1).Info("ResourceClaim not found, assuming deleted")
+			r.Backoff.Forget(req.NamespacedName)
+			return ctrl.Result{}, nil
+		}
 		log.Error(err, "Failed to fetch ResourceClaim")
-		return ctrl.Result{}, client.IgnoreNotFound(err)
+		return ctrl.Result{}, err
 	}
 
-	fmt.Printf("DEBUG: Found ResourceClaim %s/%s with type=%s, phase=%s\n",
-		claim.Namespace, claim.Name, claim.Spec.Type, claim.Status.Phase)
+	// Attach per-claim fields to every log line of this reconcile, including those of the phase handlers
+	log = log.WithValues("type", claim.Spec.Type, "key", claim.Spec.Key, "workload", claim.Spec.WorkloadRef.Name)
+	ctx = ctrl.LoggerInto(ctx, log)
 
 	// Check if we should reconcile this claim
 	if !r.LifecycleManager.ShouldReconcile(claim) {
-		fmt.Printf("DEBUG: Skipping reconciliation for %s/%s, phase=%s\n", claim.Namespace, claim.Name, claim.Status.Phase)
 		log.V(1).Info("Skipping reconciliation", "phase", claim.Status.Phase)
 		return ctrl.Result{}, nil
 	}
 
-	fmt.Printf("DEBUG: Proceeding with reconciliation for %s/%s\n", claim.Namespace, claim.Name)
-	log.Info("Reconciling ResourceClaim", "type", claim.Spec.Type, "phase", claim.Status.Phase)
+	log.Info("Reconciling ResourceClaim", "phase", claim.Status.Phase)
 
 	// Handle deletion
 	if r.LifecycleManager.IsBeingDeleted(claim) {
-		fmt.Printf("DEBUG: ResourceClaim %s/%s is being deleted\n", claim.Namespace, claim.Name)
+		log.V(1).Info("ResourceClaim is being deleted")
 		return r.handleDeletion(ctx, claim)
 	}
 
 	// Ensure finalizer is present
 	if !r.LifecycleManager.HasFinalizer(claim) {
-		fmt.Printf("DEBUG: Adding finalizer to ResourceClaim %s/%s\n", claim.Namespace, claim.Name)
 		r.LifecycleManager.AddFinalizer(claim)
 		if err := r.Update(ctx, claim); err != nil {
-			fmt.Printf("DEBUG: Failed to add finalizer to %s/%s: %v\n", claim.Namespace, claim.Name, err)
 			log.Error(err, "Failed to add finalizer")
 			return ctrl.Result{}, err
 		}
@@ -151,43 +182,90 @@ func (r *ProvisionerReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		r.LifecycleManager.SetPending(claim, conditions.ReasonClaimPending, "Initializing resource claim")
 		// Update status after setting initial phase
 		if statusErr := r.Status().Update(ctx, claim); statusErr != nil {
-			fmt.Printf("DEBUG: Failed to update ResourceClaim status after adding finalizer %s/%s: %v\n", claim.Namespace, claim.Name, statusErr)
 			log.Error(statusErr, "Failed to update ResourceClaim status after adding finalizer")
 			return ctrl.Result{}, statusErr
 		}
-		fmt.Printf("DEBUG: Successfully added finalizer and set initial phase for %s/%s\n", claim.Namespace, claim.Name)
-		return r.LifecycleManager.GetReconcileResult(ctx, claim, nil)
+		log.V(1).Info("Set initial phase", "phase", claim.Status.Phase)
+		return r.LifecycleManager.GetReconcileResult(ctx, claim, r.Backoff, nil)
 	}
 
-	fmt.Printf("DEBUG: Handling provisioning for ResourceClaim %s/%s\n", claim.Namespace, claim.Name)
 	// Handle provisioning
-	_, err := r.handleProvisioning(ctx, claim)
+	previousPhase := claim.Status.Phase
+	result, err := r.handleProvisioning(ctx, claim)
 
 	// Update status
 	if statusErr := r.Status().Update(ctx, claim); statusErr != nil {
-		fmt.Printf("DEBUG: Failed to update ResourceClaim status %s/%s: %v\n", claim.Namespace, claim.Name, statusErr)
 		log.Error(statusErr, "Failed to update ResourceClaim status")
 		if err == nil {
 			err = statusErr
 		}
+	} else if previousPhase != scorev1b1.ResourceClaimPhaseBound && claim.Status.Phase == scorev1b1.ResourceClaimPhaseBound {
+		r.auditBound(ctx, claim)
+		if duration, ok := r.LifecycleManager.ProvisioningDuration(claim); ok {
+			provisioner.ProvisioningDuration.WithLabelValues(claim.Spec.Type, claim.Status.Strategy).Observe(duration.Seconds())
+		}
 	}
 
-	fmt.Printf("DEBUG: Provisioner.Reconcile completed for %s/%s, err=%v\n", claim.Namespace, claim.Name, err)
-	return r.LifecycleManager.GetReconcileResult(ctx, claim, err)
+	log.V(1).Info("Reconcile completed", "phase", claim.Status.Phase, "error", err)
+	if err == nil && claim.Status.Phase == scorev1b1.ResourceClaimPhaseFailed {
+		// Failed claims are requeued according to the retry policy, or not at all once it is exhausted
+		return result, nil
+	}
+	return r.LifecycleManager.GetReconcileResult(ctx, claim, r.Backoff, err)
+}
+
+// auditBound records the binding of the claim in the audit trail of its Workload
+func (r *ProvisionerReconciler) auditBound(ctx context.Context, claim *scorev1b1.ResourceClaim) {
+	ref := scorev1b1.WorkloadAuditWorkloadRef{Name: claim.Spec.WorkloadRef.Name}
+	if owner := metav1.GetControllerOf(claim); owner != nil && isWorkloadOwnerRef(*owner) {
+		ref.UID = string(owner.UID)
+	}
+	details := map[string]string{
+		"claim":           claim.Name,
+		"key":             claim.Spec.Key,
+		"type":            claim.Spec.Type,
+		"claimGeneration": strconv.FormatInt(claim.Generation, 10),
+	}
+	message := fmt.Sprintf("ResourceClaim %s (%s) bound", claim.Name, claim.Spec.Type)
+	if err := r.Auditor.Record(ctx, claim.Namespace, ref, scorev1b1.AuditActionClaimBound, message, details); err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to record audit decision", "action", scorev1b1.AuditActionClaimBound)
+	}
 }
 
 // handleProvisioning handles the provisioning logic
 func (r *ProvisionerReconciler) handleProvisioning(ctx context.Context, claim *scorev1b1.ResourceClaim) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
-	// Get strategy for this resource type
-	provisioningStrategy, err := r.StrategySelector.GetStrategy(claim.Spec.Type)
+	// Get strategy for this resource type; the strategy reads the configured provisioner from the context
+	provisionerSpec, configGeneration := r.provisionerConfigFor(ctx, claim.Spec.Type)
+	ctx = strategy.WithProvisioner(ctx, provisionerSpec)
+	claim.Status.Strategy = strategyName(provisionerSpec, claim)
+	if configGeneration > 0 {
+		claim.Status.ConfigGeneration = configGeneration
+	}
+	provisioningStrategy, err := r.strategyFor(provisionerSpec, claim)
 	if err != nil {
 		r.LifecycleManager.SetFailed(claim, conditions.ReasonClaimFailed, fmt.Sprintf("No strategy available: %v", err))
 		r.Recorder.Event(claim, "Warning", EventReasonProvisionFailed, err.Error())
 		return ctrl.Result{}, fmt.Errorf("strategy not found: %w", err)
 	}
 
+	// Params that violate the schema of the type fail the claim before they reach the strategy;
+	// the claim stays Failed until its spec changes
+	if claim.Status.ObservedGeneration != claim.Generation || claim.Status.Phase == "" || claim.Status.Phase == scorev1b1.ResourceClaimPhasePending {
+		if err := provisioner.ValidateParams(provisionerSpec, claim.Spec.Params); err != nil {
+			message := fmt.Sprintf("Invalid params: %v", err)
+			var schemaErr *valuesschema.ValidationError
+			if errors.As(err, &schemaErr) {
+				message = fmt.Sprintf("Invalid params: %s", schemaErr.Details())
+			}
+			log.Info("Params violate the schema of the type", "type", claim.Spec.Type, "error", err.Error())
+			r.LifecycleManager.SetFailed(claim, conditions.ReasonSpecInvalid, message)
+			r.Recorder.Event(claim, "Warning", EventReasonProvisionFailed, message)
+			return ctrl.Result{}, nil
+		}
+	}
+
 	// Handle phase transitions
 	switch claim.Status.Phase {
 	case "", scorev1b1.ResourceClaimPhasePending:
@@ -209,14 +287,45 @@ func (r *ProvisionerReconciler) handleProvisioning(ctx context.Context, claim *s
 func (r *ProvisionerReconciler) handlePendingPhase(ctx context.Context, claim *scorev1b1.ResourceClaim, provisioningStrategy strategy.Strategy) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
+	// Park the claim while the concurrency limit of its type is reached; it is requeued like other Pending claims
+	if provisionerSpec := strategy.ProvisionerFromContext(ctx); provisionerSpec != nil {
+		throttled, message, err := r.throttled(ctx, claim, provisionerSpec.Concurrency)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if throttled {
+			log.V(1).Info("Provisioning throttled", "type", claim.Spec.Type, "reason", message)
+			if claim.Status.Reason != conditions.ReasonThrottled {
+				r.Recorder.Event(claim, "Normal", EventReasonThrottled, message)
+			}
+			r.LifecycleManager.SetPending(claim, conditions.ReasonThrottled, message)
+			return ctrl.Result{}, nil
+		}
+	}
+
 	log.Info("Starting provisioning", "type", claim.Spec.Type)
 
 	// Call the Provision method to create the resource
 	outputs, err := provisioningStrategy.Provision(ctx, claim)
+	if errors.Is(err, strategy.ErrInProgress) {
+		// The resource is created asynchronously; the Claiming phase waits for it to become ready
+		log.Info("Provisioning in progress", "type", claim.Spec.Type)
+		r.LifecycleManager.SetClaiming(claim, conditions.ReasonClaiming, "Waiting for the resource to become ready")
+		return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+	}
+	if errors.Is(err, strategy.ErrTransient) {
+		// The claim stays in its phase and is provisioned again after a growing delay
+		log.Info("Provisioning failed transiently, retrying", "type", claim.Spec.Type, "error", err.Error())
+		return ctrl.Result{}, nil
+	}
 	if err != nil {
 		log.Error(err, "Failed to provision resource")
-		r.LifecycleManager.SetFailed(claim, conditions.ReasonClaimFailed, fmt.Sprintf("Provisioning failed: %v", err))
+		r.LifecycleManager.SetFailed(claim, provisionFailureReason(err), fmt.Sprintf("Provisioning failed: %v", err))
 		r.Recorder.Event(claim, "Warning", EventReasonProvisionFailed, err.Error())
+		if errors.Is(err, strategy.ErrInvalidParams) {
+			// Retrying cannot help until the spec changes
+			return ctrl.Result{}, nil
+		}
 		return ctrl.Result{}, err
 	}
 
@@ -227,6 +336,22 @@ func (r *ProvisionerReconciler) handlePendingPhase(ctx context.Context, claim *s
 		return ctrl.Result{}, err
 	}
 
+	// Move credentials to the secret store configured for the type
+	if outputs, err = r.externalizeOutputs(ctx, claim, outputs); err != nil {
+		log.Error(err, "Failed to write credentials to the secret store")
+		r.LifecycleManager.SetFailed(claim, conditions.ReasonClaimFailed, fmt.Sprintf("Secret store write failed: %v", err))
+		r.Recorder.Event(claim, "Warning", EventReasonProvisionFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
+	// Restrict access to the provisioned pods before the claiming Workload is handed the outputs
+	if err := r.reconcileAccessPolicy(ctx, claim); err != nil {
+		log.Error(err, "Failed to reconcile network policy")
+		r.LifecycleManager.SetFailed(claim, conditions.ReasonClaimFailed, fmt.Sprintf("Network policy failed: %v", err))
+		r.Recorder.Event(claim, "Warning", EventReasonProvisionFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
 	// Set to Bound phase with outputs
 	r.LifecycleManager.SetBound(claim, outputs)
 	r.Recorder.Event(claim, "Normal", EventReasonProvisioned, "Resource successfully provisioned")
@@ -241,6 +366,10 @@ func (r *ProvisionerReconciler) handleClaimingPhase(ctx context.Context, claim *
 
 	// Check current status from strategy
 	phase, reason, message, err := provisioningStrategy.GetStatus(ctx, claim)
+	if errors.Is(err, strategy.ErrTransient) {
+		log.Info("Status check failed transiently, retrying", "error", err.Error())
+		return ctrl.Result{}, nil
+	}
 	if err != nil {
 		log.Error(err, "Failed to get status from strategy")
 		r.LifecycleManager.SetFailed(claim, conditions.ReasonClaimFailed, fmt.Sprintf("Status check failed: %v", err))
@@ -252,9 +381,17 @@ func (r *ProvisionerReconciler) handleClaimingPhase(ctx context.Context, claim *
 		// Since we're already bound, we need to get the outputs without re-provisioning
 		// This happens when the resource was created but the status wasn't updated yet
 		outputs, err := provisioningStrategy.Provision(ctx, claim)
+		if errors.Is(err, strategy.ErrInProgress) {
+			log.V(1).Info("Outputs not available yet")
+			return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		}
+		if errors.Is(err, strategy.ErrTransient) {
+			log.Info("Collecting outputs failed transiently, retrying", "error", err.Error())
+			return ctrl.Result{}, nil
+		}
 		if err != nil {
 			log.Error(err, "Failed to get outputs from strategy")
-			r.LifecycleManager.SetFailed(claim, conditions.ReasonClaimFailed, fmt.Sprintf("Failed to get outputs: %v", err))
+			r.LifecycleManager.SetFailed(claim, provisionFailureReason(err), fmt.Sprintf("Failed to get outputs: %v", err))
 			r.Recorder.Event(claim, "Warning", EventReasonProvisionFailed, err.Error())
 			return ctrl.Result{}, err
 		}
@@ -266,6 +403,20 @@ func (r *ProvisionerReconciler) handleClaimingPhase(ctx context.Context, claim *
 			return ctrl.Result{}, err
 		}
 
+		if outputs, err = r.externalizeOutputs(ctx, claim, outputs); err != nil {
+			log.Error(err, "Failed to write credentials to the secret store")
+			r.LifecycleManager.SetFailed(claim, conditions.ReasonClaimFailed, fmt.Sprintf("Secret store write failed: %v", err))
+			r.Recorder.Event(claim, "Warning", EventReasonProvisionFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
+		if err := r.reconcileAccessPolicy(ctx, claim); err != nil {
+			log.Error(err, "Failed to reconcile network policy")
+			r.LifecycleManager.SetFailed(claim, conditions.ReasonClaimFailed, fmt.Sprintf("Network policy failed: %v", err))
+			r.Recorder.Event(claim, "Warning", EventReasonProvisionFailed, err.Error())
+			return ctrl.Result{}, err
+		}
+
 		r.LifecycleManager.SetBound(claim, outputs)
 		r.Recorder.Event(claim, "Normal", EventReasonProvisioned, "Resource successfully provisioned")
 		log.Info("Resource provisioned successfully")
@@ -279,6 +430,16 @@ func (r *ProvisionerReconciler) handleClaimingPhase(ctx context.Context, claim *
 		return ctrl.Result{}, fmt.Errorf("provisioning failed: %s", message)
 	}
 
+	// Fail claims that have been provisioning for too long; the retry policy then applies
+	timeout := r.provisioningTimeoutFor(ctx, claim.Spec.Type)
+	if claim.Status.LastTransitionTime != nil && time.Since(claim.Status.LastTransitionTime.Time) > timeout {
+		message := fmt.Sprintf("Provisioning did not complete within %s", timeout)
+		log.Info("Provisioning timed out", "timeout", timeout)
+		r.LifecycleManager.SetFailed(claim, conditions.ReasonTimeout, message)
+		r.Recorder.Event(claim, "Warning", EventReasonProvisionTimeout, message)
+		return ctrl.Result{Requeue: true}, nil
+	}
+
 	// Continue claiming - still in progress
 	log.V(1).Info("Provisioning in progress", "reason", reason, "message", message)
 	return ctrl.Result{RequeueAfter: time.Second * 10}, nil
@@ -288,8 +449,18 @@ func (r *ProvisionerReconciler) handleClaimingPhase(ctx context.Context, claim *
 func (r *ProvisionerReconciler) handleBoundPhase(ctx context.Context, claim *scorev1b1.ResourceClaim, provisioningStrategy strategy.Strategy) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
+	// A spec change is provisioned again; the claim reports the new generation once bound
+	if claim.Status.ObservedGeneration != claim.Generation {
+		log.Info("Spec changed, provisioning again", "generation", claim.Generation)
+		return r.handlePendingPhase(ctx, claim, provisioningStrategy)
+	}
+
 	// Check if the resource is still healthy
 	phase, reason, message, err := provisioningStrategy.GetStatus(ctx, claim)
+	if errors.Is(err, strategy.ErrTransient) {
+		log.Info("Health check failed transiently, retrying", "error", err.Error())
+		return ctrl.Result{}, nil
+	}
 	if err != nil {
 		log.Error(err, "Failed to check resource health")
 		return ctrl.Result{}, err
@@ -302,30 +473,117 @@ func (r *ProvisionerReconciler) handleBoundPhase(ctx context.Context, claim *sco
 		return ctrl.Result{}, fmt.Errorf("resource became unhealthy: %s", message)
 	}
 
+	// Follow changes to the network policy configured for the type
+	if err := r.reconcileAccessPolicy(ctx, claim); err != nil {
+		log.Error(err, "Failed to reconcile network policy")
+		return ctrl.Result{}, err
+	}
+
 	log.V(1).Info("Resource is healthy")
 	return ctrl.Result{}, nil
 }
 
-// handleFailedPhase handles the Failed phase
+// handleFailedPhase handles the Failed phase.
+// Provisioning is retried with exponential backoff until the retry policy of the claim type is exhausted;
+// the claim then stays Failed with reason RetryLimitExceeded until its spec changes, like claims whose
+// params violate the schema of the type (reason SpecInvalid).
 func (r *ProvisionerReconciler) handleFailedPhase(ctx context.Context, claim *scorev1b1.ResourceClaim, provisioningStrategy strategy.Strategy) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
-	// Check if the resource can be recovered
-	phase, reason, message, err := provisioningStrategy.GetStatus(ctx, claim)
-	if err != nil {
-		log.V(1).Info("Resource still failed", "error", err)
-		return ctrl.Result{}, nil
+	if claim.Status.Reason == conditions.ReasonRetryLimitExceeded || claim.Status.Reason == conditions.ReasonSpecInvalid {
+		if claim.Status.ObservedGeneration == claim.Generation {
+			log.V(1).Info("Waiting for a spec change", "reason", claim.Status.Reason, "retries", claim.Status.RetryCount)
+			return ctrl.Result{}, nil
+		}
+		claim.Status.RetryCount = 0
+		r.LifecycleManager.SetPending(claim, conditions.ReasonClaimPending, "Retrying provisioning after a spec change")
+		return ctrl.Result{Requeue: true}, nil
 	}
 
-	// If resource is now available, retry provisioning
-	if phase == scorev1b1.ResourceClaimPhaseBound {
+	// Check if the resource recovered on its own
+	phase, reason, message, err := provisioningStrategy.GetStatus(ctx, claim)
+	if err == nil && phase == scorev1b1.ResourceClaimPhaseBound {
 		log.Info("Resource recovered, retrying provisioning")
 		r.LifecycleManager.SetClaiming(claim, conditions.ReasonClaiming, "Retrying provisioning after recovery")
 		return ctrl.Result{Requeue: true}, nil
 	}
+	log.V(1).Info("Resource still failed", "reason", reason, "message", message, "error", err)
 
-	log.V(1).Info("Resource still failed", "reason", reason, "message", message)
-	return ctrl.Result{}, nil
+	policy := r.retryPolicyFor(ctx, claim.Spec.Type)
+	if claim.Status.RetryCount >= policy.maxRetries {
+		message := fmt.Sprintf("Provisioning failed after %d retries", claim.Status.RetryCount)
+		r.LifecycleManager.SetFailed(claim, conditions.ReasonRetryLimitExceeded, message)
+		r.Recorder.Event(claim, "Warning", EventReasonProvisionFailed, message)
+		return ctrl.Result{}, nil
+	}
+
+	// Wait out the backoff since the claim failed before retrying
+	if claim.Status.LastTransitionTime != nil {
+		retryAt := claim.Status.LastTransitionTime.Add(policy.backoff(claim.Status.RetryCount))
+		if remaining := time.Until(retryAt); remaining > 0 {
+			log.V(1).Info("Waiting before retrying provisioning", "retries", claim.Status.RetryCount, "after", remaining)
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
+	}
+
+	claim.Status.RetryCount++
+	log.Info("Retrying provisioning", "retry", claim.Status.RetryCount, "maxRetries", policy.maxRetries)
+	r.LifecycleManager.SetPending(claim, conditions.ReasonClaimPending,
+		fmt.Sprintf("Retrying provisioning (%d of %d)", claim.Status.RetryCount, policy.maxRetries))
+	return ctrl.Result{Requeue: true}, nil
+}
+
+// retryPolicyFor returns the retry policy configured for the claim type, or the defaults
+func (r *ProvisionerReconciler) retryPolicyFor(ctx context.Context, claimType string) retryPolicy {
+	if provisionerSpec := r.provisionerSpecFor(ctx, claimType); provisionerSpec != nil {
+		return newRetryPolicy(provisionerSpec.Retry)
+	}
+	return newRetryPolicy(nil)
+}
+
+// provisioningTimeoutFor returns the provisioning timeout configured for the claim type, or the default
+func (r *ProvisionerReconciler) provisioningTimeoutFor(ctx context.Context, claimType string) time.Duration {
+	if provisionerSpec := r.provisionerSpecFor(ctx, claimType); provisionerSpec != nil && provisionerSpec.ProvisioningTimeout != nil {
+		return provisionerSpec.ProvisioningTimeout.Duration
+	}
+	return defaultProvisioningTimeout
+}
+
+// provisionerSpecFor returns the configured provisioner for the claim type, or nil when none is configured
+// or the configuration cannot be loaded
+func (r *ProvisionerReconciler) provisionerSpecFor(ctx context.Context, claimType string) *scorev1b1.ProvisionerSpec {
+	provisionerSpec, _ := r.provisionerConfigFor(ctx, claimType)
+	return provisionerSpec
+}
+
+// provisionerConfigFor returns the configured provisioner for the claim type along with the generation of
+// the configuration it was read from. The generation is 0 when the configuration cannot be loaded.
+func (r *ProvisionerReconciler) provisionerConfigFor(ctx context.Context, claimType string) (*scorev1b1.ProvisionerSpec, int64) {
+	if r.ConfigLoader == nil {
+		return nil, 0
+	}
+	orchestratorConfig, err := r.ConfigLoader.LoadConfig(ctx)
+	if err != nil || orchestratorConfig == nil {
+		ctrl.LoggerFrom(ctx).V(1).Info("Using the default provisioner settings", "error", err)
+		return nil, 0
+	}
+	for i := range orchestratorConfig.Spec.Provisioners {
+		if orchestratorConfig.Spec.Provisioners[i].Type == claimType {
+			return &orchestratorConfig.Spec.Provisioners[i], orchestratorConfig.Metadata.Generation
+		}
+	}
+	return nil, orchestratorConfig.Metadata.Generation
+}
+
+// provisionFailureReason returns the reason of a claim whose Provision call failed with err
+func provisionFailureReason(err error) string {
+	if errors.Is(err, strategy.ErrNameConflict) {
+		return conditions.ReasonNameConflict
+	}
+	if errors.Is(err, strategy.ErrInvalidParams) {
+		return conditions.ReasonSpecInvalid
+	}
+	return conditions.ReasonClaimFailed
 }
 
 // handleDeletion handles ResourceClaim deletion
@@ -341,17 +599,36 @@ func (r *ProvisionerReconciler) handleDeletion(ctx context.Context, claim *score
 	r.Recorder.Event(claim, "Normal", EventReasonDeprovisioning, "Starting resource cleanup")
 
 	// Get strategy and deprovision
-	provisioningStrategy, err := r.StrategySelector.GetStrategy(claim.Spec.Type)
+	provisionerSpec := r.provisionerSpecFor(ctx, claim.Spec.Type)
+	ctx = strategy.WithProvisioner(ctx, provisionerSpec)
+	provisioningStrategy, err := r.strategyFor(provisionerSpec, claim)
 	if err != nil {
 		log.Error(err, "Failed to get strategy for deprovisioning, removing finalizer anyway")
 	} else {
-		if err := provisioningStrategy.Deprovision(ctx, claim); err != nil {
+		err := provisioningStrategy.Deprovision(ctx, claim)
+		if errors.Is(err, strategy.ErrInProgress) {
+			// The resource is deleted asynchronously; the finalizer stays until it is gone
+			log.V(1).Info("Deprovisioning in progress", "type", claim.Spec.Type)
+			return ctrl.Result{RequeueAfter: time.Second * 10}, nil
+		}
+		if errors.Is(err, strategy.ErrTransient) {
+			// The finalizer stays until a later call succeeds
+			log.Info("Deprovisioning failed transiently, retrying", "type", claim.Spec.Type, "error", err.Error())
+			return r.Backoff.Result(client.ObjectKeyFromObject(claim), backoff.ClassWaiting), nil
+		}
+		if err != nil {
 			log.Error(err, "Failed to deprovision resource")
 			r.Recorder.Event(claim, "Warning", EventReasonDeprovisionFailed, err.Error())
 			return ctrl.Result{}, err
 		}
 	}
 
+	if err := r.deleteExternalizedOutputs(ctx, claim); err != nil {
+		log.Error(err, "Failed to delete credentials from the secret store")
+		r.Recorder.Event(claim, "Warning", EventReasonDeprovisionFailed, err.Error())
+		return ctrl.Result{}, err
+	}
+
 	// Remove finalizer to allow deletion
 	r.LifecycleManager.RemoveFinalizer(claim)
 	if err := r.Update(ctx, claim); err != nil {
@@ -364,89 +641,79 @@ func (r *ProvisionerReconciler) handleDeletion(ctx context.Context, claim *score
 	return ctrl.Result{}, nil
 }
 
-// loadSupportedTypes loads supported resource types from environment variable
+// loadSupportedTypes loads supported resource types from environment variable.
+// Without it, every type whose strategy can be resolved is supported.
 func (r *ProvisionerReconciler) loadSupportedTypes() {
 	envTypes := os.Getenv("SUPPORTED_RESOURCE_TYPES")
 	if envTypes == "" {
-		// Default supported types including new concrete strategies
-		envTypes = "postgres,redis,secret,test,mock"
+		r.logger.Info("Supporting every resource type with a provisioning strategy")
+		return
 	}
 
-	fmt.Printf("DEBUG: SUPPORTED_RESOURCE_TYPES env var: '%s'\n", envTypes)
 	types := strings.Split(envTypes, ",")
 	for _, t := range types {
 		t = strings.TrimSpace(t)
 		if t != "" {
 			r.supportedTypes[t] = true
-			fmt.Printf("DEBUG: Added supported type: '%s'\n", t)
 		}
 	}
-	fmt.Printf("DEBUG: Final supportedTypes map: %+v\n", r.supportedTypes)
+	r.logger.Info("Loaded supported resource types", "types", envTypes)
 }
 
-// loadProvisioningConfig loads provisioning configuration from orchestrator config
-func (r *ProvisionerReconciler) loadProvisioningConfig() {
-	// For Phase 1, we'll use a minimal configuration
-	// In later phases, this will load from OrchestratorConfig
-	configs := []strategy.ProvisioningConfig{
-		{
-			Type:     "postgres",
-			Strategy: "postgres",
-			Config:   map[string]any{},
-		},
-		{
-			Type:     "redis",
-			Strategy: "redis",
-			Config:   map[string]any{},
-		},
-		{
-			Type:     "test",
-			Strategy: "mock",
-			Config:   map[string]any{},
-		},
-		{
-			Type:     "mock",
-			Strategy: "mock",
-			Config:   map[string]any{},
-		},
-	}
-
-	r.StrategySelector.LoadConfig(configs)
+// strategyFor returns the strategy provisioning the claim. The provisioner configured for its type in the
+// OrchestratorConfig names the strategy; without one the type name is used. Claims adopting an external
+// resource always use the external strategy.
+func (r *ProvisionerReconciler) strategyFor(provisionerSpec *scorev1b1.ProvisionerSpec, claim *scorev1b1.ResourceClaim) (strategy.Strategy, error) {
+	var provisioningStrategy strategy.Strategy
+	var err error
+	if claim.Spec.Provision == scorev1b1.ProvisionExternal {
+		provisioningStrategy, err = r.StrategySelector.GetStrategyByName(external.StrategyName)
+	} else {
+		provisioningStrategy, err = r.StrategySelector.GetStrategy(claim.Spec.Type, strategyName(provisionerSpec, claim))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.FaultInjector.Strategy(provisioningStrategy), nil
 }
 
-// registerStrategies registers concrete strategy implementations
-func (r *ProvisionerReconciler) registerStrategies() {
-	// Register Postgres strategy
-	postgresStrategy := postgres.NewPostgresStrategy(r.Client)
-	r.StrategySelector.RegisterStrategy(postgresStrategy)
-
-	// Register Redis strategy
-	redisStrategy := redis.NewRedisStrategy(r.Client)
-	r.StrategySelector.RegisterStrategy(redisStrategy)
-
-	// Register Secret strategy
-	secretStrategy := secret.NewSecretStrategy(r.Client)
-	r.StrategySelector.RegisterStrategy(secretStrategy)
+// strategyName returns the name of the strategy provisioning the claim
+func strategyName(provisionerSpec *scorev1b1.ProvisionerSpec, claim *scorev1b1.ResourceClaim) string {
+	if claim.Spec.Provision == scorev1b1.ProvisionExternal {
+		return external.StrategyName
+	}
+	if provisionerSpec != nil && provisionerSpec.Strategy != "" {
+		return provisionerSpec.Strategy
+	}
+	return claim.Spec.Type
 }
 
 // filterSupportedTypes filters ResourceClaims to only reconcile supported types
 func (r *ProvisionerReconciler) filterSupportedTypes(obj client.Object) bool {
 	claim, ok := obj.(*scorev1b1.ResourceClaim)
 	if !ok {
-		fmt.Printf("DEBUG: filterSupportedTypes - not a ResourceClaim: %T\n", obj)
 		return false
 	}
 
 	// Always allow deletion events to pass through to ensure proper cleanup
 	if claim.GetDeletionTimestamp() != nil {
-		fmt.Printf("DEBUG: filterSupportedTypes - ResourceClaim %s/%s is being deleted, allowing event\n",
-			claim.Namespace, claim.Name)
 		return true
 	}
 
 	// For non-deletion events, check if type is supported
-	supported := r.supportedTypes[claim.Spec.Type]
-	fmt.Printf("DEBUG: filterSupportedTypes - ResourceClaim %s/%s type=%s, supported=%v, supportedTypes=%+v\n",
-		claim.Namespace, claim.Name, claim.Spec.Type, supported, r.supportedTypes)
+	supported := r.supportsClaim(claim)
+	if !supported {
+		r.logger.V(2).Info("Ignoring ResourceClaim of unsupported type",
+			"resourceClaim", client.ObjectKeyFromObject(claim), "type", claim.Spec.Type)
+	}
 	return supported
 }
+
+// supportsClaim reports whether the claim is provisioned by this controller
+func (r *ProvisionerReconciler) supportsClaim(claim *scorev1b1.ResourceClaim) bool {
+	if len(r.supportedTypes) > 0 {
+		return r.supportedTypes[claim.Spec.Type]
+	}
+	_, err := r.strategyFor(r.provisionerSpecFor(context.Background(), claim.Spec.Type), claim)
+	return err == nil
+}
@@ -3,6 +3,7 @@ package controller
 import (
 	"context"
 	"os"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -12,12 +13,14 @@ import (
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
 	"github.com/cappyzawa/score-orchestrator/internal/conditions"
 	"github.com/cappyzawa/score-orchestrator/internal/config"
+	"github.com/cappyzawa/score-orchestrator/internal/faultinject"
 )
 
 var (
@@ -133,6 +136,30 @@ var _ = Describe("ProvisionerController", func() {
 			Expect(updatedClaim.Status.OutputsAvailable).To(BeTrue())
 		})
 
+		It("Should record the strategy and provisioning timestamps", func() {
+			createResourceClaim("test-claim-timestamps")
+			By("Creating a ResourceClaim with finalizer")
+			resourceClaim.Finalizers = []string{ResourceClaimFinalizer}
+			Expect(k8sClient.Create(ctx, resourceClaim)).To(Succeed())
+
+			mockStrategy.SetStatus(scorev1b1.ResourceClaimPhaseBound, conditions.ReasonSucceeded, "Resource provisioned")
+			mockStrategy.SetOutputs(&scorev1b1.ResourceClaimOutputs{URI: StringPtr("test://localhost:1234")})
+
+			By("Reconciling the ResourceClaim")
+			_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespaceName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Checking the provisioning status")
+			updatedClaim := &scorev1b1.ResourceClaim{}
+			Expect(k8sClient.Get(ctx, namespaceName, updatedClaim)).To(Succeed())
+			Expect(updatedClaim.Status.Strategy).To(Equal("test"))
+			Expect(updatedClaim.Status.StartedAt).NotTo(BeNil())
+			Expect(updatedClaim.Status.BoundAt).NotTo(BeNil())
+			Expect(updatedClaim.Status.BoundAt.Before(updatedClaim.Status.StartedAt)).To(BeFalse())
+			Expect(updatedClaim.Status.PhaseTransitions).To(ContainElement(
+				HaveField("Phase", scorev1b1.ResourceClaimPhaseBound)))
+		})
+
 		It("Should transition to Bound with valid outputs", func() {
 			createResourceClaim("test-claim-bound")
 			By("Creating a ResourceClaim in Claiming phase")
@@ -162,6 +189,34 @@ var _ = Describe("ProvisionerController", func() {
 			Expect(updatedClaim.Status.Outputs.URI).To(Equal(StringPtr("test://localhost:1234")))
 		})
 
+		It("Should provision a Bound claim again when its spec changes", func() {
+			createResourceClaim("test-claim-respec")
+			By("Creating a Bound ResourceClaim")
+			resourceClaim.Finalizers = []string{ResourceClaimFinalizer}
+			Expect(k8sClient.Create(ctx, resourceClaim)).To(Succeed())
+			mockStrategy.SetStatus(scorev1b1.ResourceClaimPhaseBound, conditions.ReasonSucceeded, "Resource provisioned")
+			mockStrategy.SetOutputs(&scorev1b1.ResourceClaimOutputs{URI: StringPtr("test://localhost:1234")})
+			_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespaceName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Changing the claim spec")
+			Expect(k8sClient.Get(ctx, namespaceName, resourceClaim)).To(Succeed())
+			Expect(resourceClaim.Status.ObservedGeneration).To(Equal(resourceClaim.Generation))
+			resourceClaim.Spec.Class = StringPtr("large")
+			Expect(k8sClient.Update(ctx, resourceClaim)).To(Succeed())
+			mockStrategy.SetOutputs(&scorev1b1.ResourceClaimOutputs{URI: StringPtr("test://localhost:5678")})
+
+			By("Reconciling the ResourceClaim")
+			_, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespaceName})
+			Expect(err).NotTo(HaveOccurred())
+
+			By("Checking that the outputs reflect the new generation")
+			updatedClaim := &scorev1b1.ResourceClaim{}
+			Expect(k8sClient.Get(ctx, namespaceName, updatedClaim)).To(Succeed())
+			Expect(updatedClaim.Status.ObservedGeneration).To(Equal(updatedClaim.Generation))
+			Expect(updatedClaim.Status.Outputs.URI).To(Equal(StringPtr("test://localhost:5678")))
+		})
+
 		It("Should handle deletion with finalizer cleanup", func() {
 			createResourceClaim("test-claim-deletion")
 			By("Creating a ResourceClaim with finalizer")
@@ -205,6 +260,152 @@ var _ = Describe("ProvisionerController", func() {
 			Expect(updatedClaim.Status.Phase).To(Equal(scorev1b1.ResourceClaimPhaseFailed))
 			Expect(updatedClaim.Status.OutputsAvailable).To(BeFalse())
 		})
+
+		It("Should fail a claim that stays Claiming beyond the provisioning timeout", func() {
+			createResourceClaim("test-claim-timeout")
+			resourceClaim.Finalizers = []string{ResourceClaimFinalizer}
+			Expect(k8sClient.Create(ctx, resourceClaim)).To(Succeed())
+
+			By("Claiming for longer than the default timeout")
+			startedAt := metav1.NewTime(time.Now().Add(-time.Hour))
+			resourceClaim.Status.Phase = scorev1b1.ResourceClaimPhaseClaiming
+			resourceClaim.Status.LastTransitionTime = &startedAt
+			Expect(k8sClient.Status().Update(ctx, resourceClaim)).To(Succeed())
+			mockStrategy.SetStatus(scorev1b1.ResourceClaimPhaseClaiming, conditions.ReasonClaiming, "Provisioning in progress")
+
+			By("Reconciling the ResourceClaim")
+			_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespaceName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedClaim := &scorev1b1.ResourceClaim{}
+			Expect(k8sClient.Get(ctx, namespaceName, updatedClaim)).To(Succeed())
+			Expect(updatedClaim.Status.Phase).To(Equal(scorev1b1.ResourceClaimPhaseFailed))
+			Expect(updatedClaim.Status.Reason).To(Equal(conditions.ReasonTimeout))
+		})
+
+		It("Should wait out the backoff before retrying a failed claim", func() {
+			createResourceClaim("test-claim-backoff")
+			resourceClaim.Finalizers = []string{ResourceClaimFinalizer}
+			Expect(k8sClient.Create(ctx, resourceClaim)).To(Succeed())
+
+			By("Failing the claim just now after one retry")
+			now := metav1.Now()
+			resourceClaim.Status.Phase = scorev1b1.ResourceClaimPhaseFailed
+			resourceClaim.Status.Reason = conditions.ReasonClaimFailed
+			resourceClaim.Status.RetryCount = 1
+			resourceClaim.Status.LastTransitionTime = &now
+			Expect(k8sClient.Status().Update(ctx, resourceClaim)).To(Succeed())
+			mockStrategy.SetStatus(scorev1b1.ResourceClaimPhaseFailed, conditions.ReasonClaimFailed, "Provisioning failed")
+
+			By("Reconciling the ResourceClaim")
+			result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespaceName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 10*time.Second))
+			Expect(result.RequeueAfter).To(BeNumerically("<=", 20*time.Second))
+
+			updatedClaim := &scorev1b1.ResourceClaim{}
+			Expect(k8sClient.Get(ctx, namespaceName, updatedClaim)).To(Succeed())
+			Expect(updatedClaim.Status.Phase).To(Equal(scorev1b1.ResourceClaimPhaseFailed))
+			Expect(updatedClaim.Status.RetryCount).To(Equal(int32(1)))
+		})
+
+		It("Should fail terminally once the retries are exhausted", func() {
+			createResourceClaim("test-claim-exhausted")
+			resourceClaim.Finalizers = []string{ResourceClaimFinalizer}
+			Expect(k8sClient.Create(ctx, resourceClaim)).To(Succeed())
+			mockConfigLoader.SetConfig(&scorev1b1.OrchestratorConfig{
+				Spec: scorev1b1.OrchestratorConfigSpec{
+					Provisioners: []scorev1b1.ProvisionerSpec{
+						{Type: "test", Provisioner: "mock", Retry: &scorev1b1.RetryPolicy{MaxRetries: ptr.To[int32](2)}},
+					},
+				},
+			})
+
+			By("Failing the claim after the configured number of retries")
+			resourceClaim.Status.Phase = scorev1b1.ResourceClaimPhaseFailed
+			resourceClaim.Status.Reason = conditions.ReasonClaimFailed
+			resourceClaim.Status.RetryCount = 2
+			Expect(k8sClient.Status().Update(ctx, resourceClaim)).To(Succeed())
+			mockStrategy.SetStatus(scorev1b1.ResourceClaimPhaseFailed, conditions.ReasonClaimFailed, "Provisioning failed")
+
+			By("Reconciling the ResourceClaim")
+			result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespaceName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ctrl.Result{}))
+
+			updatedClaim := &scorev1b1.ResourceClaim{}
+			Expect(k8sClient.Get(ctx, namespaceName, updatedClaim)).To(Succeed())
+			Expect(updatedClaim.Status.Phase).To(Equal(scorev1b1.ResourceClaimPhaseFailed))
+			Expect(updatedClaim.Status.Reason).To(Equal(conditions.ReasonRetryLimitExceeded))
+
+			By("Not retrying again until the spec changes")
+			result, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespaceName})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(ctrl.Result{}))
+		})
+
+		It("Should keep a claim Pending while the concurrency limit of its type is reached", func() {
+			mockConfigLoader.SetConfig(&scorev1b1.OrchestratorConfig{
+				Spec: scorev1b1.OrchestratorConfigSpec{
+					Provisioners: []scorev1b1.ProvisionerSpec{
+						{Type: "test", Provisioner: "mock", Concurrency: &scorev1b1.ConcurrencyPolicy{MaxConcurrentProvisions: 1}},
+					},
+				},
+			})
+
+			By("Provisioning another claim of the same type")
+			createResourceClaim("test-claim-provisioning")
+			resourceClaim.Finalizers = []string{ResourceClaimFinalizer}
+			Expect(k8sClient.Create(ctx, resourceClaim)).To(Succeed())
+			resourceClaim.Status.Phase = scorev1b1.ResourceClaimPhaseClaiming
+			resourceClaim.Status.Reason = conditions.ReasonClaiming
+			Expect(k8sClient.Status().Update(ctx, resourceClaim)).To(Succeed())
+
+			createResourceClaim("test-claim-throttled")
+			resourceClaim.Finalizers = []string{ResourceClaimFinalizer}
+			Expect(k8sClient.Create(ctx, resourceClaim)).To(Succeed())
+
+			By("Reconciling the ResourceClaim")
+			_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespaceName})
+			Expect(err).NotTo(HaveOccurred())
+
+			updatedClaim := &scorev1b1.ResourceClaim{}
+			Expect(k8sClient.Get(ctx, namespaceName, updatedClaim)).To(Succeed())
+			Expect(updatedClaim.Status.Phase).To(Equal(scorev1b1.ResourceClaimPhasePending))
+			Expect(updatedClaim.Status.Reason).To(Equal(conditions.ReasonThrottled))
+		})
+
+		It("Should exhaust the retries of a claim whose provisioning fails by fault injection", func() {
+			createResourceClaim("test-claim-injected")
+			resourceClaim.Finalizers = []string{ResourceClaimFinalizer}
+			Expect(k8sClient.Create(ctx, resourceClaim)).To(Succeed())
+			mockConfigLoader.SetConfig(&scorev1b1.OrchestratorConfig{
+				Spec: scorev1b1.OrchestratorConfigSpec{
+					Provisioners: []scorev1b1.ProvisionerSpec{{
+						Type: "test", Provisioner: "mock",
+						Retry: &scorev1b1.RetryPolicy{
+							InitialBackoff: &metav1.Duration{Duration: time.Millisecond},
+							MaxRetries:     ptr.To[int32](1),
+						},
+					}},
+				},
+			})
+			mockStrategy.SetStatus(scorev1b1.ResourceClaimPhaseBound, conditions.ReasonSucceeded, "Resource provisioned")
+			mockStrategy.SetOutputs(&scorev1b1.ResourceClaimOutputs{URI: StringPtr("test://localhost:1234")})
+
+			By("Failing every provisioning attempt of the type")
+			reconciler.FaultInjector = faultinject.New()
+			reconciler.FaultInjector.FailProvisioning("test", -1)
+
+			By("Reconciling until the retries are exhausted")
+			updatedClaim := &scorev1b1.ResourceClaim{}
+			Eventually(func() string {
+				_, _ = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: namespaceName})
+				Expect(k8sClient.Get(ctx, namespaceName, updatedClaim)).To(Succeed())
+				return updatedClaim.Status.Reason
+			}, 5*time.Second, 10*time.Millisecond).Should(Equal(conditions.ReasonRetryLimitExceeded))
+			Expect(updatedClaim.Status.RetryCount).To(Equal(int32(1)))
+		})
 	})
 
 	Context("When filtering ResourceClaims", func() {
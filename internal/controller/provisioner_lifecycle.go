@@ -2,12 +2,15 @@ package controller
 
 import (
 	"context"
+	"math"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/backoff"
 	"github.com/cappyzawa/score-orchestrator/internal/conditions"
 )
 
@@ -17,6 +20,50 @@ const (
 	ResourceClaimFinalizer = "provisioner.score.dev/finalizer"
 )
 
+// Retry defaults applied when the provisioner does not configure a RetryPolicy field
+const (
+	defaultRetryInitialBackoff = 10 * time.Second
+	defaultRetryMultiplier     = 2.0
+	defaultRetryMaxRetries     = 5
+)
+
+// defaultProvisioningTimeout bounds the Claiming phase when the provisioner does not configure a timeout
+const defaultProvisioningTimeout = 10 * time.Minute
+
+// retryPolicy is a RetryPolicy with defaults applied
+type retryPolicy struct {
+	initialBackoff time.Duration
+	multiplier     float64
+	maxRetries     int32
+}
+
+// newRetryPolicy applies the defaults to the configured policy, which may be nil
+func newRetryPolicy(configured *scorev1b1.RetryPolicy) retryPolicy {
+	policy := retryPolicy{
+		initialBackoff: defaultRetryInitialBackoff,
+		multiplier:     defaultRetryMultiplier,
+		maxRetries:     defaultRetryMaxRetries,
+	}
+	if configured == nil {
+		return policy
+	}
+	if configured.InitialBackoff != nil {
+		policy.initialBackoff = configured.InitialBackoff.Duration
+	}
+	if configured.Multiplier != nil {
+		policy.multiplier = *configured.Multiplier
+	}
+	if configured.MaxRetries != nil {
+		policy.maxRetries = *configured.MaxRetries
+	}
+	return policy
+}
+
+// backoff returns the delay before the retry following the given number of retries
+func (p retryPolicy) backoff(retries int32) time.Duration {
+	return time.Duration(float64(p.initialBackoff) * math.Pow(p.multiplier, float64(retries)))
+}
+
 // ResourceClaimLifecycleManager manages ResourceClaim lifecycle operations
 type ResourceClaimLifecycleManager struct{}
 
@@ -33,9 +80,16 @@ func (lm *ResourceClaimLifecycleManager) SetPhase(
 ) {
 	now := metav1.NewTime(time.Now())
 
+	// Provisioning starts for a new claim, a claim that was bound, or a new generation; retries keep the start
+	if claim.Status.StartedAt == nil || (phase != scorev1b1.ResourceClaimPhaseBound &&
+		(claim.Status.Phase == scorev1b1.ResourceClaimPhaseBound || claim.Status.ObservedGeneration != claim.Generation)) {
+		claim.Status.StartedAt = &now
+	}
+
 	// Update phase transition time if phase changed
 	if claim.Status.Phase != phase {
 		claim.Status.LastTransitionTime = &now
+		setPhaseTransition(claim, phase, now)
 	}
 
 	claim.Status.Phase = phase
@@ -63,9 +117,15 @@ func (lm *ResourceClaimLifecycleManager) SetBound(
 	claim *scorev1b1.ResourceClaim,
 	outputs *scorev1b1.ResourceClaimOutputs,
 ) {
+	rebound := claim.Status.Phase != scorev1b1.ResourceClaimPhaseBound || claim.Status.ObservedGeneration != claim.Generation
 	lm.SetPhase(claim, scorev1b1.ResourceClaimPhaseBound, conditions.ReasonSucceeded, "Resource successfully provisioned")
+	if rebound {
+		now := metav1.NewTime(time.Now())
+		claim.Status.BoundAt = &now
+	}
 	claim.Status.Outputs = outputs
 	claim.Status.OutputsAvailable = true
+	claim.Status.RetryCount = 0
 }
 
 // SetFailed sets the ResourceClaim to Failed phase
@@ -75,6 +135,29 @@ func (lm *ResourceClaimLifecycleManager) SetFailed(claim *scorev1b1.ResourceClai
 	claim.Status.Outputs = nil
 }
 
+// setPhaseTransition records that the claim entered the phase at the given time
+func setPhaseTransition(claim *scorev1b1.ResourceClaim, phase scorev1b1.ResourceClaimPhase, at metav1.Time) {
+	for i := range claim.Status.PhaseTransitions {
+		if claim.Status.PhaseTransitions[i].Phase == phase {
+			claim.Status.PhaseTransitions[i].LastTransitionTime = at
+			return
+		}
+	}
+	claim.Status.PhaseTransitions = append(claim.Status.PhaseTransitions, scorev1b1.ResourceClaimPhaseTransition{
+		Phase:              phase,
+		LastTransitionTime: at,
+	})
+}
+
+// ProvisioningDuration returns how long the claim took from the start of provisioning until it was bound,
+// or false when it is not bound or the start is unknown
+func (lm *ResourceClaimLifecycleManager) ProvisioningDuration(claim *scorev1b1.ResourceClaim) (time.Duration, bool) {
+	if claim.Status.Phase != scorev1b1.ResourceClaimPhaseBound || claim.Status.StartedAt == nil || claim.Status.BoundAt == nil {
+		return 0, false
+	}
+	return claim.Status.BoundAt.Sub(claim.Status.StartedAt.Time), true
+}
+
 // NeedsFinalizer checks if the claim needs a finalizer
 func (lm *ResourceClaimLifecycleManager) NeedsFinalizer(claim *scorev1b1.ResourceClaim) bool {
 	return !claim.DeletionTimestamp.IsZero() && !lm.HasFinalizer(claim)
@@ -135,33 +218,32 @@ func (lm *ResourceClaimLifecycleManager) ShouldReconcile(claim *scorev1b1.Resour
 	return true
 }
 
-// GetReconcileResult returns appropriate reconcile result based on phase
+// GetReconcileResult returns appropriate reconcile result based on phase.
+// Errors are retried by the rate limiter of the controller; claims waiting for their resource are requeued
+// with a growing delay, and bound claims are checked periodically.
 func (lm *ResourceClaimLifecycleManager) GetReconcileResult(
 	ctx context.Context,
 	claim *scorev1b1.ResourceClaim,
+	requeues *backoff.Backoff,
 	err error,
 ) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
+	key := client.ObjectKeyFromObject(claim)
 
 	if err != nil {
 		log.Error(err, "Reconciliation failed", "phase", claim.Status.Phase)
-		return ctrl.Result{RequeueAfter: time.Minute * 2}, err
+		return requeues.Error(err)
 	}
 
 	switch claim.Status.Phase {
-	case scorev1b1.ResourceClaimPhasePending:
-		// Requeue quickly for pending claims
-		return ctrl.Result{RequeueAfter: time.Second * 30}, nil
-	case scorev1b1.ResourceClaimPhaseClaiming:
-		// Requeue moderately for claiming
-		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	case scorev1b1.ResourceClaimPhasePending, scorev1b1.ResourceClaimPhaseClaiming, scorev1b1.ResourceClaimPhaseFailed:
+		// Requeue with backoff while the resource is not ready
+		return requeues.Result(key, backoff.ClassWaiting), nil
 	case scorev1b1.ResourceClaimPhaseBound:
 		// Requeue slowly for bound claims (for health checks)
-		return ctrl.Result{RequeueAfter: time.Minute * 10}, nil
-	case scorev1b1.ResourceClaimPhaseFailed:
-		// Requeue with backoff for failed claims
-		return ctrl.Result{RequeueAfter: time.Minute * 5}, nil
+		return requeues.Result(key, backoff.ClassResync), nil
 	default:
+		requeues.Forget(key)
 		return ctrl.Result{}, nil
 	}
 }
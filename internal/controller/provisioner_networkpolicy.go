@@ -0,0 +1,78 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/networkpolicy"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+)
+
+// labelResourceClaim is the label carried by the resources, including pods, provisioned for a claim
+const labelResourceClaim = "score.dev/resource-claim"
+
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+
+// reconcileAccessPolicy restricts ingress to the pods provisioned for the claim to the pods of the claiming
+// Workload when the provisioner of its type configures a network policy, and removes the policy otherwise
+func (r *ProvisionerReconciler) reconcileAccessPolicy(ctx context.Context, claim *scorev1b1.ResourceClaim) error {
+	provisionerSpec := strategy.ProvisionerFromContext(ctx)
+	if provisionerSpec == nil || provisionerSpec.NetworkPolicy == nil {
+		return r.deleteAccessPolicy(ctx, claim)
+	}
+
+	policy := buildAccessPolicy(claim, provisionerSpec.NetworkPolicy)
+	if err := controllerutil.SetControllerReference(claim, policy, r.Scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	if err := reconcile.Apply(ctx, r.Client, policy, meta.FieldManagerOrchestrator); err != nil {
+		return fmt.Errorf("failed to apply network policy %s: %w", policy.Name, err)
+	}
+	ctrl.LoggerFrom(ctx).V(1).Info("Applied network policy", "name", policy.Name)
+	return nil
+}
+
+// deleteAccessPolicy deletes the network policy of the claim, if it exists
+func (r *ProvisionerReconciler) deleteAccessPolicy(ctx context.Context, claim *scorev1b1.ResourceClaim) error {
+	policy := &networkingv1.NetworkPolicy{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: accessPolicyName(claim)}, policy); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !metav1.IsControlledBy(policy, claim) {
+		return nil
+	}
+	if err := r.Delete(ctx, policy); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete network policy %s: %w", policy.Name, err)
+	}
+	ctrl.LoggerFrom(ctx).Info("Deleted network policy", "name", policy.Name)
+	return nil
+}
+
+// buildAccessPolicy returns the NetworkPolicy admitting the pods of the claiming Workload and the configured
+// peers to the pods labeled with the claim
+func buildAccessPolicy(claim *scorev1b1.ResourceClaim, spec *scorev1b1.IngressPolicySpec) *networkingv1.NetworkPolicy {
+	workloadNamespace := claim.Spec.WorkloadRef.Namespace
+	if workloadNamespace == "" {
+		workloadNamespace = claim.Namespace
+	}
+	from := append(networkpolicy.WorkloadPeers(claim.Spec.WorkloadRef.Name, workloadNamespace), networkpolicy.Peers(spec)...)
+	labels := map[string]string{
+		labelResourceClaim:        claim.Name,
+		"score.dev/resource-type": claim.Spec.Type,
+	}
+	return networkpolicy.Ingress(accessPolicyName(claim), claim.Namespace, labels, map[string]string{labelResourceClaim: claim.Name}, from)
+}
+
+// accessPolicyName returns the name of the network policy of the claim
+func accessPolicyName(claim *scorev1b1.ResourceClaim) string {
+	return claim.Name + "-access"
+}
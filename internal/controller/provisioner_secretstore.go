@@ -0,0 +1,90 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/secretstore"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+// externalizeOutputs writes the credentials of the claim to the secret store configured for its type.
+// The data of the Secret referenced by the strategy outputs is copied to the store, and the returned
+// outputs reference the store instead of the Secret. The Secret is then deleted, so that the credentials
+// are not kept in plaintext in the cluster, unless the claim does not control it or the strategy retains it.
+// Outputs are returned unchanged without a store.
+func (r *ProvisionerReconciler) externalizeOutputs(ctx context.Context, claim *scorev1b1.ResourceClaim, outputs *scorev1b1.ResourceClaimOutputs) (*scorev1b1.ResourceClaimOutputs, error) {
+	provisionerSpec := strategy.ProvisionerFromContext(ctx)
+	// Adopted claims keep publishing the Secret of the user
+	if provisionerSpec == nil || provisionerSpec.SecretStore == nil || outputs.SecretRef == nil ||
+		claim.Spec.Provision == scorev1b1.ProvisionExternal {
+		return outputs, nil
+	}
+	storeSpec := provisionerSpec.SecretStore
+
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: outputs.SecretRef.Name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to read credentials from secret %s: %w", outputs.SecretRef.Name, err)
+	}
+	store, err := secretstore.New(ctx, r.Client, storeSpec)
+	if err != nil {
+		return nil, err
+	}
+	path := secretstore.ClaimPath(storeSpec, claim)
+	version, err := store.Write(ctx, path, secret.Data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to write credentials to secret store %s: %w", storeSpec.Name, err)
+	}
+
+	if metav1.IsControlledBy(secret, claim) && secret.Annotations[strategy.AnnotationRetainSecret] != "true" {
+		if err := r.Delete(ctx, secret, client.Preconditions{UID: &secret.UID}); client.IgnoreNotFound(err) != nil {
+			return nil, fmt.Errorf("failed to delete secret %s after writing it to secret store %s: %w", secret.Name, storeSpec.Name, err)
+		}
+	}
+
+	keys := make([]string, 0, len(secret.Data))
+	for key := range secret.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	externalized := outputs.DeepCopy()
+	externalized.SecretRef = nil
+	externalized.ExternalSecretRef = &scorev1b1.ExternalSecretReference{
+		Store:   storeSpec.Name,
+		Path:    path,
+		Version: version,
+		Keys:    keys,
+	}
+	ctrl.LoggerFrom(ctx).Info("Wrote credentials to secret store", "store", storeSpec.Name, "path", path, "version", version)
+	return externalized, nil
+}
+
+// deleteExternalizedOutputs removes the credentials of the claim from the secret store they were written to
+func (r *ProvisionerReconciler) deleteExternalizedOutputs(ctx context.Context, claim *scorev1b1.ResourceClaim) error {
+	provisionerSpec := strategy.ProvisionerFromContext(ctx)
+	if claim.Status.Outputs == nil || claim.Status.Outputs.ExternalSecretRef == nil {
+		return nil
+	}
+	ref := claim.Status.Outputs.ExternalSecretRef
+	if provisionerSpec == nil || provisionerSpec.SecretStore == nil || provisionerSpec.SecretStore.Name != ref.Store {
+		ctrl.LoggerFrom(ctx).Info("Secret store is no longer configured, leaving credentials in place", "store", ref.Store, "path", ref.Path)
+		return nil
+	}
+
+	store, err := secretstore.New(ctx, r.Client, provisionerSpec.SecretStore)
+	if err != nil {
+		return err
+	}
+	if err := store.Delete(ctx, ref.Path); err != nil {
+		return fmt.Errorf("failed to delete credentials from secret store %s: %w", ref.Store, err)
+	}
+	return nil
+}
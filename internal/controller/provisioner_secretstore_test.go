@@ -0,0 +1,116 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+var _ = Describe("externalizeOutputs", func() {
+	var (
+		server *httptest.Server
+		ctx    context.Context
+		claim  *scorev1b1.ResourceClaim
+	)
+
+	BeforeEach(func() {
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/v1/secret/data/") {
+				_, _ = w.Write([]byte(`{"data":{"version":1}}`))
+				return
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		DeferCleanup(server.Close)
+
+		ctx = strategy.WithProvisioner(context.Background(), &scorev1b1.ProvisionerSpec{
+			Type: "postgres",
+			SecretStore: &scorev1b1.SecretStoreSpec{Name: "vault", Vault: &scorev1b1.VaultSecretStoreSpec{
+				Address:        server.URL,
+				TokenSecretRef: scorev1b1.NamespacedName{Namespace: "score-system", Name: "vault-token"},
+			}},
+		})
+		claim = &scorev1b1.ResourceClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", UID: "claim-uid"},
+			Spec:       scorev1b1.ResourceClaimSpec{Key: "db", Type: "postgres"},
+		}
+	})
+
+	outputSecret := func(annotations map[string]string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "db-postgres", Namespace: "default", UID: "secret-uid",
+				Annotations: annotations,
+				OwnerReferences: []metav1.OwnerReference{{
+					APIVersion: scorev1b1.GroupVersion.String(),
+					Kind:       "ResourceClaim",
+					Name:       claim.Name,
+					UID:        claim.UID,
+					Controller: ptr.To(true),
+				}},
+			},
+			Data: map[string][]byte{"password": []byte("s3cr3t")},
+		}
+	}
+
+	externalize := func(secret *corev1.Secret) client.Client {
+		tokenSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "vault-token", Namespace: "score-system"},
+			Data:       map[string][]byte{"token": []byte("s.token")},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(tokenSecret, secret).Build()
+		r := &ProvisionerReconciler{Client: c}
+
+		outputs, err := r.externalizeOutputs(ctx, claim, &scorev1b1.ResourceClaimOutputs{
+			SecretRef: &scorev1b1.LocalObjectReference{Name: secret.Name},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(outputs.SecretRef).To(BeNil())
+		Expect(outputs.ExternalSecretRef).NotTo(BeNil())
+		Expect(outputs.ExternalSecretRef.Keys).To(Equal([]string{"password"}))
+		return c
+	}
+
+	It("should delete the plaintext Secret once the credentials are in the store", func() {
+		c := externalize(outputSecret(nil))
+
+		err := c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "db-postgres"}, &corev1.Secret{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue(), "expected the Secret to be deleted, got %v", err)
+	})
+
+	It("should keep Secrets the strategy retains", func() {
+		c := externalize(outputSecret(map[string]string{strategy.AnnotationRetainSecret: "true"}))
+
+		Expect(c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: "db-postgres"}, &corev1.Secret{})).To(Succeed())
+	})
+})
@@ -2,16 +2,19 @@ package reconciler
 
 import (
 	"context"
+	"time"
 
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/backoff"
 	"github.com/cappyzawa/score-orchestrator/internal/config"
 	"github.com/cappyzawa/score-orchestrator/internal/controller/managers"
 	"github.com/cappyzawa/score-orchestrator/internal/controller/phases"
 	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+	"github.com/cappyzawa/score-orchestrator/internal/runtimeregistry"
 )
 
 // WorkloadPipeline implements the phase-based reconciliation pipeline
@@ -21,7 +24,9 @@ type WorkloadPipeline struct {
 	claimManager  *managers.ClaimManager
 	planManager   *managers.PlanManager
 	statusManager *managers.StatusManager
+	quotaManager  *managers.QuotaManager
 	config        *config.ReconcilerConfig
+	backoff       *backoff.Backoff
 
 	// Phases for normal reconciliation
 	normalPhases []phases.Phase
@@ -36,6 +41,7 @@ func NewWorkloadPipeline(
 	claimManager *managers.ClaimManager,
 	planManager *managers.PlanManager,
 	statusManager *managers.StatusManager,
+	quotaManager *managers.QuotaManager,
 	reconcilerConfig *config.ReconcilerConfig,
 ) *WorkloadPipeline {
 	return &WorkloadPipeline{
@@ -44,11 +50,25 @@ func NewWorkloadPipeline(
 		claimManager:  claimManager,
 		planManager:   planManager,
 		statusManager: statusManager,
+		quotaManager:  quotaManager,
 		config:        reconcilerConfig,
+		backoff: backoff.New("workload", map[backoff.Class]backoff.Policy{
+			backoff.ClassWaiting: {
+				Base:       reconcilerConfig.Retry.DefaultRequeueDelay,
+				Max:        reconcilerConfig.Retry.MaxRequeueDelay,
+				Multiplier: reconcilerConfig.Retry.BackoffMultiplier,
+				Jitter:     reconcilerConfig.Retry.RequeueJitter,
+			},
+		}),
 		normalPhases: []phases.Phase{
+			&phases.ExpirationPhase{},
 			&phases.ValidationPhase{},
+			&phases.FanOutPhase{},
+			&phases.QuotaPhase{},
 			&phases.ClaimPhase{},
+			&phases.DependencyPhase{},
 			&phases.PlanPhase{},
+			&phases.PreviewPhase{},
 			&phases.StatusPhase{},
 		},
 		deletionPhase: &phases.DeletionPhase{},
@@ -70,6 +90,8 @@ func (p *WorkloadPipeline) Execute(ctx context.Context, workload *scorev1b1.Work
 		ClaimManager:     p.claimManager,
 		PlanManager:      p.planManager,
 		StatusManager:    p.statusManager,
+		QuotaManager:     p.quotaManager,
+		Backoff:          p.backoff,
 	}
 
 	// Handle deletion vs normal reconciliation
@@ -105,7 +127,7 @@ func (p *WorkloadPipeline) executeNormalPipeline(ctx context.Context, phaseCtx *
 		// Handle phase result
 		if result.Error != nil {
 			log.Error(result.Error, "Phase execution failed", "phase", phase.Name())
-			return ctrl.Result{}, result.Error
+			return p.backoff.Error(result.Error)
 		}
 
 		if result.Requeue {
@@ -125,7 +147,30 @@ func (p *WorkloadPipeline) executeNormalPipeline(ctx context.Context, phaseCtx *
 	}
 
 	log.V(1).Info("Normal pipeline execution completed")
-	return ctrl.Result{}, nil
+	p.backoff.Forget(client.ObjectKeyFromObject(phaseCtx.Workload))
+
+	return ctrl.Result{RequeueAfter: requeueAfter(phaseCtx)}, nil
+}
+
+// requeueAfter returns when the Workload must be reconciled again without a watch event: when its TTL
+// passes, or when the heartbeat of the runtime consuming its plan expires. Heartbeats renewed in time
+// move the deadline, so RuntimeUnresponsive is only reported for runtimes that stopped.
+func requeueAfter(phaseCtx *phases.PhaseContext) time.Duration {
+	var after time.Duration
+	if expiresAt := phaseCtx.Workload.Status.ExpiresAt; expiresAt != nil {
+		after = max(time.Until(expiresAt.Time), 0)
+	}
+	if plan := phaseCtx.Plan; plan != nil && !reconcile.IsDryRun(phaseCtx.Workload) {
+		// Plans whose heartbeat already expired are reconciled again when the runtime renews it
+		if until := time.Until(runtimeregistry.PlanHeartbeatDeadline(plan)); until > 0 {
+			// Check a little after the deadline so that the heartbeat has expired
+			heartbeatIn := until + time.Second
+			if after == 0 || heartbeatIn < after {
+				after = heartbeatIn
+			}
+		}
+	}
+	return after
 }
 
 // executeDeletionPipeline executes the deletion phase
@@ -143,7 +188,7 @@ func (p *WorkloadPipeline) executeDeletionPipeline(ctx context.Context, phaseCtx
 	// Handle phase result
 	if result.Error != nil {
 		log.Error(result.Error, "Deletion phase execution failed", "phase", p.deletionPhase.Name())
-		return ctrl.Result{}, result.Error
+		return p.backoff.Error(result.Error)
 	}
 
 	if result.Requeue {
@@ -155,5 +200,6 @@ func (p *WorkloadPipeline) executeDeletionPipeline(ctx context.Context, phaseCtx
 	}
 
 	log.V(1).Info("Deletion pipeline execution completed")
+	p.backoff.Forget(client.ObjectKeyFromObject(phaseCtx.Workload))
 	return ctrl.Result{}, nil
 }
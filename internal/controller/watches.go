@@ -19,14 +19,20 @@ package controller
 import (
 	"context"
 	"fmt"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
 	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/runtimeregistry"
 )
 
 // EnqueueRequestForOwningWorkload returns a handler that enqueues the owner Workload
@@ -62,6 +68,51 @@ func EnqueueRequestForOwningWorkload() handler.EventHandler {
 	})
 }
 
+// EnqueueRequestsForDependentWorkloads returns a handler that enqueues the Workloads
+// listing the changed Workload in spec.dependsOn, so blocked dependents proceed as soon
+// as their dependency becomes ready
+func EnqueueRequestsForDependentWorkloads(c client.Reader) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		dependents := &scorev1b1.WorkloadList{}
+		key := fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
+		if err := c.List(ctx, dependents, client.MatchingFields{meta.IndexWorkloadByDependency: key}); err != nil {
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(dependents.Items))
+		for _, dependent := range dependents.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: dependent.Name, Namespace: dependent.Namespace},
+			})
+		}
+		return requests
+	})
+}
+
+// EnqueueRequestsForClaimOutputsSecret returns a handler that enqueues the Workloads whose
+// ResourceClaims publish their outputs through the changed Secret, so rotated credentials
+// are re-resolved into the WorkloadPlan
+func EnqueueRequestsForClaimOutputsSecret(c client.Reader) handler.EventHandler {
+	return handler.EnqueueRequestsFromMapFunc(func(ctx context.Context, obj client.Object) []reconcile.Request {
+		claims := &scorev1b1.ResourceClaimList{}
+		key := fmt.Sprintf("%s/%s", obj.GetNamespace(), obj.GetName())
+		if err := c.List(ctx, claims, client.MatchingFields{meta.IndexResourceClaimByOutputsSecret: key}); err != nil {
+			return nil
+		}
+
+		requests := make([]reconcile.Request, 0, len(claims.Items))
+		for _, claim := range claims.Items {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      claim.Spec.WorkloadRef.Name,
+					Namespace: claim.Spec.WorkloadRef.Namespace,
+				},
+			})
+		}
+		return requests
+	})
+}
+
 // GetResourceClaimsForWorkload retrieves all ResourceClaims for a given Workload
 func GetResourceClaimsForWorkload(ctx context.Context, c client.Client, workload *scorev1b1.Workload) ([]scorev1b1.ResourceClaim, error) {
 	claimList := &scorev1b1.ResourceClaimList{}
@@ -87,10 +138,41 @@ func getResourceClaimsWithoutIndex(ctx context.Context, c client.Client, workloa
 	// Use label selector to find claims for this workload
 	err := c.List(ctx, claimList,
 		client.InNamespace(workload.Namespace),
-		client.MatchingLabels{"score.dev/workload": workload.Name})
+		client.MatchingLabels{meta.LabelWorkload: workload.Name})
 	if err != nil {
 		return nil, err
 	}
 
 	return claimList.Items, nil
 }
+
+// IgnorePlanHeartbeats returns a predicate that drops WorkloadPlan updates which only renew the heartbeat of
+// the runtime consuming the plan. Workloads are requeued when the heartbeat of their plan expires instead;
+// a renewal of an expired heartbeat still passes so that RuntimeUnresponsive is cleared.
+func IgnorePlanHeartbeats() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldPlan, ok := e.ObjectOld.(*scorev1b1.WorkloadPlan)
+			if !ok || oldPlan.Status.Acknowledgment == nil {
+				return true
+			}
+			newPlan, ok := e.ObjectNew.(*scorev1b1.WorkloadPlan)
+			if !ok || newPlan.Status.Acknowledgment == nil {
+				return true
+			}
+			if expired, _ := runtimeregistry.PlanUnresponsive(oldPlan, time.Now()); expired {
+				return true
+			}
+			return !equality.Semantic.DeepEqual(withoutHeartbeat(oldPlan), withoutHeartbeat(newPlan))
+		},
+	}
+}
+
+// withoutHeartbeat returns a copy of the plan without the fields a heartbeat renewal changes
+func withoutHeartbeat(plan *scorev1b1.WorkloadPlan) *scorev1b1.WorkloadPlan {
+	plan = plan.DeepCopy()
+	plan.ResourceVersion = ""
+	plan.ManagedFields = nil
+	plan.Status.Acknowledgment.LastHeartbeat = metav1.Time{}
+	return plan
+}
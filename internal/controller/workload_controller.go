@@ -18,11 +18,14 @@ package controller
 
 import (
 	"context"
+	"sync"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 
@@ -31,6 +34,8 @@ import (
 	"github.com/cappyzawa/score-orchestrator/internal/controller/managers"
 	"github.com/cappyzawa/score-orchestrator/internal/controller/reconciler"
 	"github.com/cappyzawa/score-orchestrator/internal/endpoint"
+	"github.com/cappyzawa/score-orchestrator/internal/sharding"
+	"github.com/cappyzawa/score-orchestrator/internal/tracing"
 )
 
 // WorkloadReconciler reconciles a Workload object
@@ -44,27 +49,47 @@ type WorkloadReconciler struct {
 	ClaimManager           *managers.ClaimManager
 	PlanManager            *managers.PlanManager
 	StatusManager          *managers.StatusManager
+	// QuotaManager enforces configured quotas; quotas are not enforced if nil
+	QuotaManager *managers.QuotaManager
+
+	// MaxConcurrentReconciles is the number of Workloads reconciled in parallel (default 1)
+	MaxConcurrentReconciles int
+
+	// Shard limits reconciliation to the Workloads hashed onto it; the zero value reconciles all Workloads
+	Shard sharding.Shard
 
 	// Pipeline for phase-based reconciliation
-	Pipeline *reconciler.WorkloadPipeline
+	Pipeline     *reconciler.WorkloadPipeline
+	pipelineOnce sync.Once
 }
 
-// +kubebuilder:rbac:groups=score.dev,resources=workloads,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=score.dev,resources=workloads,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=score.dev,resources=workloads/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=score.dev,resources=workloads/finalizers,verbs=update
 // +kubebuilder:rbac:groups=score.dev,resources=resourceclaims,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=score.dev,resources=resourceclaims/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=score.dev,resources=workloadplans,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=controllerrevisions,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
 // +kubebuilder:rbac:groups=score.dev,resources=workloadexposures,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=score.dev,resources=workloadexposures/status,verbs=get;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
-// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;patch
+// +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch;create;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;patch;delete
 
 // Reconcile handles Workload reconciliation - the single writer of Workload.status
 func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx).WithValues("workload", req.NamespacedName)
 	log.V(1).Info("Reconcile called", "namespace", req.Namespace, "name", req.Name)
 
+	if !r.Shard.Owns(req.NamespacedName) {
+		log.V(2).Info("Workload belongs to another shard, skipping")
+		return ctrl.Result{}, nil
+	}
+
 	// Get the Workload
 	workload := &scorev1b1.Workload{}
 	if err := r.Get(ctx, req.NamespacedName, workload); err != nil {
@@ -78,6 +103,9 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 	log.V(1).Info("Processing Workload", "generation", workload.Generation, "resourceVersion", workload.ResourceVersion)
 
+	ctx, span := tracing.StartSpan(ctx, "Workload.Reconcile", tracing.WorkloadAttributes(workload)...)
+	defer span.End()
+
 	// Get current reconciler configuration
 	var reconcilerConfig *config.ReconcilerConfig
 	if r.ReconcilerConfigLoader != nil {
@@ -87,31 +115,44 @@ func (r *WorkloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		reconcilerConfig = config.DefaultReconcilerConfig()
 	}
 
-	// Initialize pipeline if not already done
-	if r.Pipeline == nil {
-		r.Pipeline = reconciler.NewWorkloadPipeline(
-			r.Client,
-			r.Recorder,
-			r.ClaimManager,
-			r.PlanManager,
-			r.StatusManager,
-			reconcilerConfig,
-		)
-	}
+	// Initialize pipeline if not already done; workers may run concurrently
+	r.pipelineOnce.Do(func() {
+		if r.Pipeline == nil {
+			r.Pipeline = reconciler.NewWorkloadPipeline(
+				r.Client,
+				r.Recorder,
+				r.ClaimManager,
+				r.PlanManager,
+				r.StatusManager,
+				r.QuotaManager,
+				reconcilerConfig,
+			)
+		}
+	})
 
 	// Execute the pipeline
-	return r.Pipeline.Execute(ctx, workload)
+	result, err := r.Pipeline.Execute(ctx, workload)
+	tracing.RecordError(span, err)
+	return result, err
 }
 
 // SetupWithManager sets up the controller with the Manager.
+// Workloads annotated with score.dev/priority=high are reconciled ahead of other queued Workloads.
 func (r *WorkloadReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&scorev1b1.Workload{}).
 		Owns(&scorev1b1.ResourceClaim{}).
-		Owns(&scorev1b1.WorkloadPlan{}).
+		Owns(&scorev1b1.WorkloadPlan{}, builder.WithPredicates(IgnorePlanHeartbeats())).
+		Owns(&scorev1b1.Workload{}).
 		Watches(&scorev1b1.ResourceClaim{}, EnqueueRequestForOwningWorkload()).
-		Watches(&scorev1b1.WorkloadPlan{}, EnqueueRequestForOwningWorkload()).
+		Watches(&scorev1b1.WorkloadPlan{}, EnqueueRequestForOwningWorkload(), builder.WithPredicates(IgnorePlanHeartbeats())).
+		Watches(&scorev1b1.Workload{}, EnqueueRequestsForDependentWorkloads(mgr.GetClient())).
+		// Only the metadata of Secrets is cached for the watch; their data may be any tenant's credentials
+		Watches(&corev1.Secret{}, EnqueueRequestsForClaimOutputsSecret(mgr.GetClient()), builder.OnlyMetadata).
 		Named("workload").
-		WithOptions(controller.Options{MaxConcurrentReconciles: 1}).
+		WithOptions(controller.Options{
+			MaxConcurrentReconciles: r.MaxConcurrentReconciles,
+			NewQueue:                NewWorkloadPriorityQueue(mgr.GetClient()),
+		}).
 		Complete(r)
 }
@@ -19,8 +19,12 @@ package controller
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"strings"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/sharding"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -44,34 +48,56 @@ type WorkloadExposureRegistrar struct {
 	Scheme       *runtime.Scheme
 	Recorder     record.EventRecorder
 	RuntimeClass string
+	// Shard limits registration to the Workloads hashed onto it; the zero value registers all Workloads
+	Shard sharding.Shard
 }
 
 // Reconcile registers WorkloadExposure resources (spec-only) for Workloads.
 func (r *WorkloadExposureRegistrar) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	log := ctrllog.FromContext(ctx)
 
+	if !r.Shard.Owns(req.NamespacedName) {
+		return ctrl.Result{}, nil
+	}
+
 	// 1) Fetch Workload
 	var wl scorev1b1.Workload
 	if err := r.Get(ctx, req.NamespacedName, &wl); err != nil {
 		if apierrors.IsNotFound(err) {
-			// Workload was deleted, let GC handle WorkloadExposure cleanup
+			// Workload was deleted; its deletion phase or ownerRef GC removed the WorkloadExposure
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "unable to fetch Workload")
 		return ctrl.Result{}, err
 	}
 
-	// avoid creating/updating exposure during workload deletion; let ownerRef GC handle cleanup
+	// avoid recreating the exposure during workload deletion; the Workload deletion phase removes it
 	if wl.DeletionTimestamp != nil {
 		log.V(1).Info("Workload is being deleted, skipping WorkloadExposure operations", "workload", req.NamespacedName)
 		return ctrl.Result{}, nil
 	}
 
-	// 2) Desired WorkloadExposure (spec-only)
+	// 2) Resolve the runtime selected for the Workload; fall back to the default until a plan exists
+	runtimeClass := r.RuntimeClass
+	var plan scorev1b1.WorkloadPlan
+	if err := r.Get(ctx, req.NamespacedName, &plan); err != nil {
+		if !apierrors.IsNotFound(err) {
+			log.Error(err, "unable to fetch WorkloadPlan")
+			return ctrl.Result{}, err
+		}
+	} else if plan.Spec.RuntimeClass != "" {
+		runtimeClass = plan.Spec.RuntimeClass
+	}
+
+	// 3) Desired WorkloadExposure (spec-only)
 	desired := scorev1b1.WorkloadExposure{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      wl.Name,
 			Namespace: wl.Namespace,
+			Labels: map[string]string{
+				meta.LabelWorkload:     wl.Name,
+				meta.LabelRuntimeClass: runtimeClass,
+			},
 			OwnerReferences: []metav1.OwnerReference{
 				*metav1.NewControllerRef(&wl, scorev1b1.GroupVersion.WithKind("Workload")),
 			},
@@ -82,12 +108,14 @@ func (r *WorkloadExposureRegistrar) Reconcile(ctx context.Context, req ctrl.Requ
 				Namespace: ptr.To(wl.Namespace),
 				UID:       string(wl.UID),
 			},
-			RuntimeClass:               r.RuntimeClass,
+			RuntimeClass:               runtimeClass,
 			ObservedWorkloadGeneration: wl.Generation,
+			Ports:                      servicePorts(&wl),
+			Scheme:                     schemeOverride(&wl),
 		},
 	}
 
-	// 3) Create or Patch (spec-only)
+	// 4) Create or Patch (spec-only)
 	var we scorev1b1.WorkloadExposure
 	err := r.Get(ctx, types.NamespacedName{Name: wl.Name, Namespace: wl.Namespace}, &we)
 	switch {
@@ -97,7 +125,7 @@ func (r *WorkloadExposureRegistrar) Reconcile(ctx context.Context, req ctrl.Requ
 			log.Error(err, "failed to create WorkloadExposure")
 			return ctrl.Result{}, err
 		}
-		r.Recorder.Eventf(&wl, corev1.EventTypeNormal, "ExposureRegistered", "WorkloadExposure %s created with runtimeClass=%s", desired.Name, r.RuntimeClass)
+		r.Recorder.Eventf(&wl, corev1.EventTypeNormal, "ExposureRegistered", "WorkloadExposure %s created with runtimeClass=%s", desired.Name, runtimeClass)
 		log.Info("WorkloadExposure created", "workload", req.NamespacedName)
 		return ctrl.Result{}, nil
 	case err != nil:
@@ -107,8 +135,14 @@ func (r *WorkloadExposureRegistrar) Reconcile(ctx context.Context, req ctrl.Requ
 		// Patch spec if changed (do not touch status)
 		if updateReason := r.getUpdateReason(&we, &desired); updateReason != "" {
 			patch := client.MergeFrom(we.DeepCopy())
-			// Only replace Spec; keep metadata/status
+			// Only replace Spec and the labels set by the registrar; keep other metadata and status
 			we.Spec = desired.Spec
+			if we.Labels == nil {
+				we.Labels = make(map[string]string, len(desired.Labels))
+			}
+			for key, value := range desired.Labels {
+				we.Labels[key] = value
+			}
 			if err := r.Patch(ctx, &we, patch); err != nil {
 				log.Error(err, "failed to patch WorkloadExposure")
 				return ctrl.Result{}, err
@@ -131,6 +165,18 @@ func (r *WorkloadExposureRegistrar) getUpdateReason(current, desired *scorev1b1.
 	if current.Spec.RuntimeClass != desired.Spec.RuntimeClass {
 		reasons = append(reasons, "runtimeClass")
 	}
+	if current.Spec.Scheme != desired.Spec.Scheme {
+		reasons = append(reasons, "scheme")
+	}
+	for key, value := range desired.Labels {
+		if current.Labels[key] != value {
+			reasons = append(reasons, "labels")
+			break
+		}
+	}
+	if !reflect.DeepEqual(current.Spec.Ports, desired.Spec.Ports) {
+		reasons = append(reasons, "ports")
+	}
 	if current.Spec.WorkloadRef.Name != desired.Spec.WorkloadRef.Name {
 		reasons = append(reasons, "workloadRef.name")
 	}
@@ -155,6 +201,26 @@ func (r *WorkloadExposureRegistrar) getUpdateReason(current, desired *scorev1b1.
 	}()
 }
 
+// servicePorts returns a copy of the Workload's service ports, or nil when it declares no service.
+func servicePorts(wl *scorev1b1.Workload) []scorev1b1.ServicePort {
+	if wl.Spec.Service == nil || len(wl.Spec.Service.Ports) == 0 {
+		return nil
+	}
+	return wl.Spec.Service.DeepCopy().Ports
+}
+
+// schemeOverride returns the URL scheme requested via the Workload annotation.
+// Unsupported values are ignored so the runtime falls back to scheme inference.
+func schemeOverride(wl *scorev1b1.Workload) string {
+	scheme := strings.ToLower(strings.TrimSpace(wl.Annotations[meta.AnnotationScheme]))
+	switch scheme {
+	case "http", "https":
+		return scheme
+	default:
+		return ""
+	}
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *WorkloadExposureRegistrar) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
@@ -163,7 +229,8 @@ func (r *WorkloadExposureRegistrar) SetupWithManager(mgr ctrl.Manager) error {
 		// Watch WorkloadPlan to trigger Workload reconciliation when Plans are created
 		Watches(&scorev1b1.WorkloadPlan{},
 			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &scorev1b1.Workload{}, handler.OnlyControllerOwner())).
-		WithEventFilter(predicate.GenerationChangedPredicate{}).
+		// Annotation changes carry the scheme override, which does not bump generation
+		WithEventFilter(predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{})).
 		Named("workload-exposure-registrar").
 		Complete(r)
 }
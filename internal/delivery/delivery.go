@@ -0,0 +1,258 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package delivery writes WorkloadPlans to runtimes that run in remote clusters and reads their status back.
+// The plan in this cluster stays the source of truth: its copy in the remote cluster carries no target,
+// so the runtime controllers of the remote cluster materialize it, and its status is mirrored onto the
+// plan in this cluster, from which the Orchestrator aggregates the Workload conditions as usual.
+package delivery
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/planvalues"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+)
+
+// KubeconfigKey is the key of the kubeconfig in the Secret referenced by a target
+const KubeconfigKey = "kubeconfig"
+
+// Deliverer connects to the remote clusters of the configured targets
+type Deliverer struct {
+	// Client reads the kubeconfig Secrets of the targets
+	Client client.Reader
+	// Scheme is used by the clients of remote clusters
+	Scheme *runtime.Scheme
+	// NewClient creates the client of a remote cluster; defaults to client.New
+	NewClient func(config *rest.Config, options client.Options) (client.Client, error)
+
+	mu      sync.Mutex
+	clients map[string]client.Client
+}
+
+// RemoteClient returns the client of the target's cluster. Clients are reused until the kubeconfig changes.
+func (d *Deliverer) RemoteClient(ctx context.Context, target *scorev1b1.RuntimeTargetSpec) (client.Client, error) {
+	ref := target.KubeconfigSecretRef
+	secret := &corev1.Secret{}
+	if err := d.Client.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig Secret %s/%s of target %q: %w", ref.Namespace, ref.Name, target.Name, err)
+	}
+	kubeconfig := secret.Data[KubeconfigKey]
+	if len(kubeconfig) == 0 {
+		return nil, fmt.Errorf("kubeconfig Secret %s/%s of target %q has no %q key", ref.Namespace, ref.Name, target.Name, KubeconfigKey)
+	}
+
+	sum := sha256.Sum256(kubeconfig)
+	key := target.Name + "/" + hex.EncodeToString(sum[:])
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if remote, exists := d.clients[key]; exists {
+		return remote, nil
+	}
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid kubeconfig of target %q: %w", target.Name, err)
+	}
+	newClient := d.NewClient
+	if newClient == nil {
+		newClient = client.New
+	}
+	remote, err := newClient(restConfig, client.Options{Scheme: d.Scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client of target %q: %w", target.Name, err)
+	}
+
+	// Drop the client of a replaced kubeconfig
+	for existing := range d.clients {
+		if strings.HasPrefix(existing, target.Name+"/") {
+			delete(d.clients, existing)
+		}
+	}
+	if d.clients == nil {
+		d.clients = make(map[string]client.Client)
+	}
+	d.clients[key] = remote
+	return remote, nil
+}
+
+// RemoteCopy returns the WorkloadPlan written to the remote cluster for plan. The Workload does not exist
+// in the remote cluster, so the copy carries the Workload spec as a snapshot. workload must be at the
+// generation the plan was computed from; it is not used for plans that already carry a snapshot.
+func RemoteCopy(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) (*scorev1b1.WorkloadPlan, error) {
+	remote := &scorev1b1.WorkloadPlan{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: scorev1b1.GroupVersion.String(),
+			Kind:       "WorkloadPlan",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        plan.Name,
+			Namespace:   plan.Namespace,
+			Labels:      make(map[string]string, len(plan.Labels)),
+			Annotations: make(map[string]string, len(plan.Annotations)+1),
+		},
+		Spec: *plan.Spec.DeepCopy(),
+	}
+	for key, value := range plan.Labels {
+		remote.Labels[key] = value
+	}
+	for key, value := range plan.Annotations {
+		remote.Annotations[key] = value
+	}
+	delete(remote.Annotations, meta.AnnotationDeliveredTarget)
+	remote.Annotations[meta.AnnotationSourceGeneration] = strconv.FormatInt(plan.Generation, 10)
+	remote.Spec.Target = ""
+
+	if remote.Spec.WorkloadSnapshot == nil {
+		if workload == nil || workload.Generation != plan.Spec.ObservedWorkloadGeneration {
+			return nil, fmt.Errorf("workload of plan %s/%s is not at generation %d", plan.Namespace, plan.Name, plan.Spec.ObservedWorkloadGeneration)
+		}
+		snapshot, err := json.Marshal(workload.Spec)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal workload spec: %w", err)
+		}
+		remote.Spec.WorkloadSnapshot = &runtime.RawExtension{Raw: snapshot}
+	}
+	return remote, nil
+}
+
+// Deliver applies the remote copy of plan to the remote cluster and returns the remote plan as stored there
+func Deliver(ctx context.Context, remote client.Client, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) (*scorev1b1.WorkloadPlan, error) {
+	delivered, err := RemoteCopy(plan, workload)
+	if err != nil {
+		return nil, err
+	}
+	if err := reconcile.Apply(ctx, remote, delivered, meta.FieldManagerPlanDelivery); err != nil {
+		return nil, fmt.Errorf("failed to apply WorkloadPlan to remote cluster: %w", err)
+	}
+	return delivered, nil
+}
+
+// DeliverValues copies the values referenced by plan to the remote cluster, where the remote runtime reads
+// them from. The copy is owned by delivered, the remote plan as returned by Deliver, so that it is removed
+// together with it, and copies of values the plan no longer references are deleted. local reads the values
+// of plan in this cluster.
+func DeliverValues(ctx context.Context, local client.Reader, remote client.Client, plan, delivered *scorev1b1.WorkloadPlan) error {
+	ref := plan.Spec.ResolvedValuesRef
+	if ref != nil {
+		values, err := planvalues.Load(ctx, local, plan)
+		if err != nil {
+			return err
+		}
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ref.Name,
+				Namespace: plan.Namespace,
+				Labels:    map[string]string{meta.LabelPlanValues: plan.Spec.WorkloadRef.Name},
+			},
+			Immutable: ptr.To(true),
+			Data:      map[string]string{planvalues.DataKey: string(values.Raw)},
+		}
+		if err := controllerutil.SetOwnerReference(delivered, configMap, remote.Scheme()); err != nil {
+			return fmt.Errorf("failed to set owner reference: %w", err)
+		}
+		if err := remote.Create(ctx, configMap); err != nil && !apierrors.IsAlreadyExists(err) {
+			return fmt.Errorf("failed to copy resolved values %s to remote cluster: %w", ref.Name, err)
+		}
+	}
+
+	var configMaps corev1.ConfigMapList
+	if err := remote.List(ctx, &configMaps, client.InNamespace(plan.Namespace),
+		client.MatchingLabels{meta.LabelPlanValues: plan.Spec.WorkloadRef.Name}); err != nil {
+		return fmt.Errorf("failed to list resolved values in remote cluster: %w", err)
+	}
+	for i := range configMaps.Items {
+		configMap := &configMaps.Items[i]
+		if (ref != nil && configMap.Name == ref.Name) || !isOwnedBy(configMap, delivered) {
+			continue
+		}
+		if err := remote.Delete(ctx, configMap); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete resolved values %s from remote cluster: %w", configMap.Name, err)
+		}
+	}
+	return nil
+}
+
+// isOwnedBy reports whether owner is among the owners of obj
+func isOwnedBy(obj, owner metav1.Object) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == owner.GetUID() {
+			return true
+		}
+	}
+	return false
+}
+
+// Remove deletes the remote copy of plan. It reports whether the copy is gone.
+func Remove(ctx context.Context, remote client.Client, plan *scorev1b1.WorkloadPlan) (bool, error) {
+	delivered := &scorev1b1.WorkloadPlan{}
+	if err := remote.Get(ctx, client.ObjectKeyFromObject(plan), delivered); err != nil {
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, fmt.Errorf("failed to get remote WorkloadPlan: %w", err)
+	}
+	if delivered.DeletionTimestamp.IsZero() {
+		if err := remote.Delete(ctx, delivered); err != nil && !apierrors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to delete remote WorkloadPlan: %w", err)
+		}
+	}
+	return false, nil
+}
+
+// MirrorStatus copies the status the remote runtime reported for the delivered generation of plan onto plan.
+// A status the remote runtime computed for an earlier generation is not mirrored, so that the plan does
+// not report the state of a previous spec as current. It reports whether the status was mirrored.
+func MirrorStatus(plan, delivered *scorev1b1.WorkloadPlan) bool {
+	if delivered.Annotations[meta.AnnotationSourceGeneration] != strconv.FormatInt(plan.Generation, 10) ||
+		delivered.Status.ObservedGeneration < delivered.Generation {
+		return false
+	}
+
+	// Keep the delivery condition written in this cluster
+	delivery := apimeta.FindStatusCondition(plan.Status.Conditions, meta.PlanConditionDelivered)
+	status := delivered.Status.DeepCopy()
+	status.ObservedGeneration = plan.Generation
+	for i := range status.Conditions {
+		status.Conditions[i].ObservedGeneration = plan.Generation
+	}
+	if delivery != nil {
+		status.Conditions = append(status.Conditions, *delivery)
+	}
+	plan.Status = *status
+	return true
+}
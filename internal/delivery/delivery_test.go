@@ -0,0 +1,269 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package delivery
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+const kubeconfig = `apiVersion: v1
+kind: Config
+clusters:
+- name: spoke
+  cluster:
+    server: https://spoke.example.com:6443
+users:
+- name: orchestrator
+  user:
+    token: secret-token
+contexts:
+- name: spoke
+  context:
+    cluster: spoke
+    user: orchestrator
+current-context: spoke
+`
+
+// applyAsCreateOrUpdate emulates server-side apply, which the fake client does not support,
+// by creating the applied object or replacing the existing one. Other patches pass through.
+func applyAsCreateOrUpdate(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+
+	existing, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", obj)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		return c.Create(ctx, obj)
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return c.Update(ctx, obj)
+}
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+func deliveredPlan() (*scorev1b1.WorkloadPlan, *scorev1b1.Workload) {
+	plan := &scorev1b1.WorkloadPlan{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "web", Namespace: "default", Generation: 3,
+			Labels:      map[string]string{"score.dev/workload": "web"},
+			Annotations: map[string]string{meta.AnnotationCorrelationID: "abc", meta.AnnotationDeliveredTarget: "eu-west"},
+		},
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef:                scorev1b1.WorkloadPlanWorkloadRef{Name: "web", Namespace: "default"},
+			ObservedWorkloadGeneration: 2,
+			RuntimeClass:               "kubernetes",
+			Target:                     "eu-west",
+		},
+	}
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Generation: 2},
+		Spec:       scorev1b1.WorkloadSpec{Containers: map[string]scorev1b1.ContainerSpec{"web": {Image: "nginx:1"}}},
+	}
+	return plan, workload
+}
+
+func TestRemoteCopy(t *testing.T) {
+	plan, workload := deliveredPlan()
+
+	remote, err := RemoteCopy(plan, workload)
+	if err != nil {
+		t.Fatalf("RemoteCopy() error = %v", err)
+	}
+	if remote.Spec.Target != "" {
+		t.Errorf("target = %q, want the remote copy to run in the remote cluster", remote.Spec.Target)
+	}
+	if remote.Annotations[meta.AnnotationSourceGeneration] != "3" || remote.Annotations[meta.AnnotationCorrelationID] != "abc" {
+		t.Errorf("annotations = %v, want the plan annotations and its generation", remote.Annotations)
+	}
+	if _, ok := remote.Annotations[meta.AnnotationDeliveredTarget]; ok {
+		t.Errorf("annotations = %v, want the delivered target to stay in this cluster", remote.Annotations)
+	}
+	if remote.Labels["score.dev/workload"] != "web" {
+		t.Errorf("labels = %v, want the plan labels", remote.Labels)
+	}
+	if remote.Spec.WorkloadSnapshot == nil || string(remote.Spec.WorkloadSnapshot.Raw) != `{"containers":{"web":{"image":"nginx:1"}}}` {
+		t.Errorf("workload snapshot = %v, want the Workload spec", remote.Spec.WorkloadSnapshot)
+	}
+	if plan.Spec.Target != "eu-west" {
+		t.Errorf("RemoteCopy() modified the plan")
+	}
+
+	workload.Generation = 3
+	if _, err := RemoteCopy(plan, workload); err == nil {
+		t.Error("RemoteCopy() succeeded for a Workload at another generation than the plan")
+	}
+
+	// A restored plan carries its own snapshot
+	plan.Spec.WorkloadSnapshot = &runtime.RawExtension{Raw: []byte(`{"containers":{}}`)}
+	remote, err = RemoteCopy(plan, nil)
+	if err != nil {
+		t.Fatalf("RemoteCopy() error = %v", err)
+	}
+	if string(remote.Spec.WorkloadSnapshot.Raw) != `{"containers":{}}` {
+		t.Errorf("workload snapshot = %s, want the snapshot of the plan", remote.Spec.WorkloadSnapshot.Raw)
+	}
+}
+
+func TestMirrorStatus(t *testing.T) {
+	plan, _ := deliveredPlan()
+	plan.Status.Phase = scorev1b1.WorkloadPlanPhaseProvisioning
+	apimeta.SetStatusCondition(&plan.Status.Conditions, metav1.Condition{
+		Type: meta.PlanConditionDelivered, Status: metav1.ConditionTrue, Reason: "Delivered", ObservedGeneration: 3,
+	})
+
+	delivered := &scorev1b1.WorkloadPlan{
+		ObjectMeta: metav1.ObjectMeta{Generation: 7, Annotations: map[string]string{meta.AnnotationSourceGeneration: "3"}},
+		Status: scorev1b1.WorkloadPlanStatus{
+			ObservedGeneration: 6,
+			Phase:              scorev1b1.WorkloadPlanPhaseReady,
+			Conditions:         []metav1.Condition{{Type: meta.PlanConditionReady, Status: metav1.ConditionTrue, Reason: "Ready", ObservedGeneration: 6}},
+		},
+	}
+	if MirrorStatus(plan, delivered) || plan.Status.Phase != scorev1b1.WorkloadPlanPhaseProvisioning {
+		t.Fatalf("MirrorStatus() mirrored a status computed for a previous remote generation")
+	}
+
+	delivered.Status.ObservedGeneration = 7
+	delivered.Status.Conditions[0].ObservedGeneration = 7
+	if !MirrorStatus(plan, delivered) {
+		t.Fatal("MirrorStatus() = false, want the status of the delivered generation to be mirrored")
+	}
+	if plan.Status.Phase != scorev1b1.WorkloadPlanPhaseReady || plan.Status.ObservedGeneration != 3 {
+		t.Errorf("status = %s at generation %d, want Ready at generation 3", plan.Status.Phase, plan.Status.ObservedGeneration)
+	}
+	ready := apimeta.FindStatusCondition(plan.Status.Conditions, meta.PlanConditionReady)
+	if ready == nil || ready.ObservedGeneration != 3 {
+		t.Errorf("Ready condition = %+v, want it at the plan generation", ready)
+	}
+	if apimeta.FindStatusCondition(plan.Status.Conditions, meta.PlanConditionDelivered) == nil {
+		t.Error("MirrorStatus() dropped the Delivered condition")
+	}
+
+	delivered.Annotations[meta.AnnotationSourceGeneration] = "2"
+	if MirrorStatus(plan, delivered) {
+		t.Error("MirrorStatus() mirrored the status of a previous plan generation")
+	}
+}
+
+func TestDeliverAndRemove(t *testing.T) {
+	ctx := context.Background()
+	remote := fake.NewClientBuilder().WithScheme(newScheme(t)).
+		WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).
+		Build()
+	plan, workload := deliveredPlan()
+
+	if _, err := Deliver(ctx, remote, plan, workload); err != nil {
+		t.Fatalf("Deliver() error = %v", err)
+	}
+	got := &scorev1b1.WorkloadPlan{}
+	if err := remote.Get(ctx, client.ObjectKeyFromObject(plan), got); err != nil {
+		t.Fatalf("remote plan: %v", err)
+	}
+	if got.Spec.Target != "" || got.Spec.WorkloadSnapshot == nil {
+		t.Errorf("remote plan spec = %+v, want no target and a Workload snapshot", got.Spec)
+	}
+
+	gone, err := Remove(ctx, remote, plan)
+	if err != nil || gone {
+		t.Fatalf("Remove() = %v, %v, want the deletion to be started", gone, err)
+	}
+	gone, err = Remove(ctx, remote, plan)
+	if err != nil || !gone {
+		t.Errorf("Remove() = %v, %v, want the remote plan to be gone", gone, err)
+	}
+}
+
+func TestRemoteClient(t *testing.T) {
+	ctx := context.Background()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "eu-west-kubeconfig", Namespace: "score-system"},
+		Data:       map[string][]byte{KubeconfigKey: []byte(kubeconfig)},
+	}
+	local := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(secret).Build()
+
+	var hosts []string
+	deliverer := &Deliverer{
+		Client: local,
+		Scheme: newScheme(t),
+		NewClient: func(config *rest.Config, options client.Options) (client.Client, error) {
+			hosts = append(hosts, config.Host)
+			return fake.NewClientBuilder().WithScheme(options.Scheme).Build(), nil
+		},
+	}
+	target := &scorev1b1.RuntimeTargetSpec{
+		Name:                "eu-west",
+		KubeconfigSecretRef: scorev1b1.NamespacedName{Namespace: "score-system", Name: "eu-west-kubeconfig"},
+	}
+
+	first, err := deliverer.RemoteClient(ctx, target)
+	if err != nil {
+		t.Fatalf("RemoteClient() error = %v", err)
+	}
+	second, err := deliverer.RemoteClient(ctx, target)
+	if err != nil {
+		t.Fatalf("RemoteClient() error = %v", err)
+	}
+	if first != second || len(hosts) != 1 || hosts[0] != "https://spoke.example.com:6443" {
+		t.Errorf("clients created for %v, want one client of the spoke cluster to be reused", hosts)
+	}
+
+	secret.Data[KubeconfigKey] = []byte("not a kubeconfig")
+	if err := local.Update(ctx, secret); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := deliverer.RemoteClient(ctx, target); err == nil {
+		t.Error("RemoteClient() succeeded with an invalid kubeconfig")
+	}
+
+	target.KubeconfigSecretRef.Name = "missing"
+	if _, err := deliverer.RemoteClient(ctx, target); err == nil {
+		t.Error("RemoteClient() succeeded without a kubeconfig Secret")
+	}
+}
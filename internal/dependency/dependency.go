@@ -0,0 +1,100 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+)
+
+// FindCycle walks spec.dependsOn starting at the Workload and returns the first cycle found
+// as a path of Workload names that starts and ends with the same name (e.g., [api db api]).
+// Dependencies that do not exist yet end the walk; they block the Workload but cannot form a cycle.
+// Returns nil if the dependency graph reachable from the Workload is acyclic.
+func FindCycle(ctx context.Context, c client.Reader, workload *scorev1b1.Workload) ([]string, error) {
+	visited := map[string]bool{}
+	onPath := map[string]bool{workload.Name: true}
+	path := []string{workload.Name}
+
+	var visit func(dependsOn []string) ([]string, error)
+	visit = func(dependsOn []string) ([]string, error) {
+		for _, name := range dependsOn {
+			if onPath[name] {
+				return append(append([]string{}, path[indexOf(path, name):]...), name), nil
+			}
+			if visited[name] {
+				continue
+			}
+			visited[name] = true
+
+			dep := &scorev1b1.Workload{}
+			if err := c.Get(ctx, types.NamespacedName{Namespace: workload.Namespace, Name: name}, dep); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return nil, err
+			}
+
+			onPath[name] = true
+			path = append(path, name)
+			cycle, err := visit(dep.Spec.DependsOn)
+			if cycle != nil || err != nil {
+				return cycle, err
+			}
+			path = path[:len(path)-1]
+			onPath[name] = false
+		}
+		return nil, nil
+	}
+
+	return visit(workload.Spec.DependsOn)
+}
+
+// Blocking returns the dependencies of the Workload that do not report Ready=True,
+// including dependencies that do not exist, in spec.dependsOn order
+func Blocking(ctx context.Context, c client.Reader, workload *scorev1b1.Workload) ([]string, error) {
+	var blocking []string
+	for _, name := range workload.Spec.DependsOn {
+		dep := &scorev1b1.Workload{}
+		if err := c.Get(ctx, types.NamespacedName{Namespace: workload.Namespace, Name: name}, dep); err != nil {
+			if apierrors.IsNotFound(err) {
+				blocking = append(blocking, name)
+				continue
+			}
+			return nil, err
+		}
+		if !dep.DeletionTimestamp.IsZero() || !conditions.IsConditionTrue(dep.Status.Conditions, conditions.ConditionReady) {
+			blocking = append(blocking, name)
+		}
+	}
+	return blocking, nil
+}
+
+func indexOf(path []string, name string) int {
+	for i, n := range path {
+		if n == name {
+			return i
+		}
+	}
+	return 0
+}
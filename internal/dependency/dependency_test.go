@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+)
+
+func newWorkload(name string, ready bool, dependsOn ...string) *scorev1b1.Workload {
+	wl := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       scorev1b1.WorkloadSpec{DependsOn: dependsOn},
+	}
+	status := metav1.ConditionFalse
+	if ready {
+		status = metav1.ConditionTrue
+	}
+	conditions.SetCondition(&wl.Status.Conditions, conditions.ConditionReady, status, conditions.ReasonSucceeded, "", wl.Generation)
+	return wl
+}
+
+func newClient(t *testing.T, workloads ...*scorev1b1.Workload) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	objs := make([]client.Object, 0, len(workloads))
+	for _, wl := range workloads {
+		objs = append(objs, wl)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+}
+
+func TestFindCycle(t *testing.T) {
+	tests := []struct {
+		name     string
+		workload *scorev1b1.Workload
+		others   []*scorev1b1.Workload
+		want     []string
+	}{
+		{
+			name:     "no dependencies",
+			workload: newWorkload("api", false),
+		},
+		{
+			name:     "acyclic chain",
+			workload: newWorkload("api", false, "migrator"),
+			others:   []*scorev1b1.Workload{newWorkload("migrator", false, "db"), newWorkload("db", true)},
+		},
+		{
+			name:     "diamond is not a cycle",
+			workload: newWorkload("api", false, "cache", "migrator"),
+			others: []*scorev1b1.Workload{
+				newWorkload("cache", false, "db"), newWorkload("migrator", false, "db"), newWorkload("db", true),
+			},
+		},
+		{
+			name:     "self dependency",
+			workload: newWorkload("api", false, "api"),
+			want:     []string{"api", "api"},
+		},
+		{
+			name:     "two workloads",
+			workload: newWorkload("api", false, "migrator"),
+			others:   []*scorev1b1.Workload{newWorkload("migrator", false, "api")},
+			want:     []string{"api", "migrator", "api"},
+		},
+		{
+			name:     "cycle further down the graph",
+			workload: newWorkload("api", false, "migrator"),
+			others:   []*scorev1b1.Workload{newWorkload("migrator", false, "db"), newWorkload("db", false, "migrator")},
+			want:     []string{"migrator", "db", "migrator"},
+		},
+		{
+			name:     "missing dependency",
+			workload: newWorkload("api", false, "missing"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClient(t, append(tt.others, tt.workload)...)
+			got, err := FindCycle(context.Background(), c, tt.workload)
+			if err != nil {
+				t.Fatalf("FindCycle() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindCycle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBlocking(t *testing.T) {
+	tests := []struct {
+		name     string
+		workload *scorev1b1.Workload
+		others   []*scorev1b1.Workload
+		want     []string
+	}{
+		{
+			name:     "no dependencies",
+			workload: newWorkload("api", false),
+		},
+		{
+			name:     "all dependencies ready",
+			workload: newWorkload("api", false, "migrator", "cache"),
+			others:   []*scorev1b1.Workload{newWorkload("migrator", true), newWorkload("cache", true)},
+		},
+		{
+			name:     "not ready and missing dependencies block",
+			workload: newWorkload("api", false, "migrator", "cache", "missing"),
+			others:   []*scorev1b1.Workload{newWorkload("migrator", false), newWorkload("cache", true)},
+			want:     []string{"migrator", "missing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newClient(t, append(tt.others, tt.workload)...)
+			got, err := Blocking(context.Background(), c, tt.workload)
+			if err != nil {
+				t.Fatalf("Blocking() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Blocking() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"sort"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// FindResourceCycle walks the dependsOn of the resources of a Workload and returns the first cycle found
+// as a path of resource keys that starts and ends with the same key (e.g., [migrations db migrations]).
+// Resources are walked in key order so that the same cycle is reported on every reconcile.
+// Returns nil if the resource dependencies are acyclic.
+func FindResourceCycle(resources map[string]scorev1b1.ResourceSpec) []string {
+	visited := map[string]bool{}
+	onPath := map[string]bool{}
+	var path []string
+
+	var visit func(key string) []string
+	visit = func(key string) []string {
+		if onPath[key] {
+			return append(append([]string{}, path[indexOf(path, key):]...), key)
+		}
+		if visited[key] {
+			return nil
+		}
+		visited[key] = true
+		resource, ok := resources[key]
+		if !ok {
+			// Undeclared dependencies are reported by UndeclaredResource
+			return nil
+		}
+		onPath[key] = true
+		path = append(path, key)
+		for _, dep := range resource.DependsOn {
+			if cycle := visit(dep); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		onPath[key] = false
+		return nil
+	}
+
+	for _, key := range sortedKeys(resources) {
+		if cycle := visit(key); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// UndeclaredResource returns the first resource (in key order) that depends on a resource the Workload does not
+// declare, and that dependency. Returns empty strings if every dependency is declared.
+func UndeclaredResource(resources map[string]scorev1b1.ResourceSpec) (string, string) {
+	for _, key := range sortedKeys(resources) {
+		for _, dep := range resources[key].DependsOn {
+			if _, ok := resources[dep]; !ok {
+				return key, dep
+			}
+		}
+	}
+	return "", ""
+}
+
+// ResourceOrder returns the keys of the resources ordered so that every resource comes after the resources it
+// depends on; independent resources are ordered by key. Resources on a cycle come last, ordered by key.
+func ResourceOrder(resources map[string]scorev1b1.ResourceSpec) []string {
+	order := make([]string, 0, len(resources))
+	placed := make(map[string]bool, len(resources))
+	keys := sortedKeys(resources)
+
+	for len(order) < len(keys) {
+		progress := false
+		for _, key := range keys {
+			if placed[key] || !dependenciesPlaced(resources[key], resources, placed) {
+				continue
+			}
+			order = append(order, key)
+			placed[key] = true
+			progress = true
+		}
+		if !progress {
+			break
+		}
+	}
+	for _, key := range keys {
+		if !placed[key] {
+			order = append(order, key)
+		}
+	}
+	return order
+}
+
+// dependenciesPlaced reports whether every declared dependency of the resource has been placed
+func dependenciesPlaced(resource scorev1b1.ResourceSpec, resources map[string]scorev1b1.ResourceSpec, placed map[string]bool) bool {
+	for _, dep := range resource.DependsOn {
+		if _, ok := resources[dep]; ok && !placed[dep] {
+			return false
+		}
+	}
+	return true
+}
+
+func sortedKeys(resources map[string]scorev1b1.ResourceSpec) []string {
+	keys := make([]string, 0, len(resources))
+	for key := range resources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
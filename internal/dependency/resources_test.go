@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dependency
+
+import (
+	"reflect"
+	"testing"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func newResources(dependsOn map[string][]string) map[string]scorev1b1.ResourceSpec {
+	resources := make(map[string]scorev1b1.ResourceSpec, len(dependsOn))
+	for key, deps := range dependsOn {
+		resources[key] = scorev1b1.ResourceSpec{Type: "postgres", DependsOn: deps}
+	}
+	return resources
+}
+
+func TestFindResourceCycle(t *testing.T) {
+	tests := []struct {
+		name      string
+		dependsOn map[string][]string
+		want      []string
+	}{
+		{
+			name:      "no dependencies",
+			dependsOn: map[string][]string{"db": nil, "cache": nil},
+		},
+		{
+			name:      "diamond is not a cycle",
+			dependsOn: map[string][]string{"db": nil, "migrations": {"db"}, "seed": {"db"}, "report": {"migrations", "seed"}},
+		},
+		{
+			name:      "self dependency",
+			dependsOn: map[string][]string{"db": {"db"}},
+			want:      []string{"db", "db"},
+		},
+		{
+			name:      "two resources",
+			dependsOn: map[string][]string{"db": {"migrations"}, "migrations": {"db"}},
+			want:      []string{"db", "migrations", "db"},
+		},
+		{
+			name:      "undeclared dependency",
+			dependsOn: map[string][]string{"migrations": {"db"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindResourceCycle(newResources(tt.dependsOn)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindResourceCycle() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUndeclaredResource(t *testing.T) {
+	key, dep := UndeclaredResource(newResources(map[string][]string{"db": nil, "migrations": {"db", "queue"}}))
+	if key != "migrations" || dep != "queue" {
+		t.Errorf("UndeclaredResource() = %q, %q, want migrations, queue", key, dep)
+	}
+
+	if key, dep := UndeclaredResource(newResources(map[string][]string{"db": nil, "migrations": {"db"}})); key != "" || dep != "" {
+		t.Errorf("UndeclaredResource() = %q, %q, want none", key, dep)
+	}
+}
+
+func TestResourceOrder(t *testing.T) {
+	tests := []struct {
+		name      string
+		dependsOn map[string][]string
+		want      []string
+	}{
+		{
+			name:      "independent resources by key",
+			dependsOn: map[string][]string{"db": nil, "cache": nil},
+			want:      []string{"cache", "db"},
+		},
+		{
+			name:      "dependencies first",
+			dependsOn: map[string][]string{"a-migrations": {"db"}, "db": nil, "cache": nil},
+			want:      []string{"cache", "db", "a-migrations"},
+		},
+		{
+			name:      "chain",
+			dependsOn: map[string][]string{"a": {"b"}, "b": {"c"}, "c": nil},
+			want:      []string{"c", "b", "a"},
+		},
+		{
+			name:      "cycle comes last",
+			dependsOn: map[string][]string{"a": {"b"}, "b": {"a"}, "c": nil},
+			want:      []string{"c", "a", "b"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResourceOrder(newResources(tt.dependsOn)); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ResourceOrder() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -113,7 +113,7 @@ func (e *EndpointDeriver) selectBestPort(ports []scorev1b1.ServicePort) *scorev1
 		return &ports[0]
 	}
 
-	// Prioritize by port characteristics (since Score spec doesn't have named ports)
+	// Prioritize by port name, then by port number
 	prioritized := e.prioritizePortsByCharacteristics(ports)
 	if len(prioritized) > 0 {
 		return &prioritized[0]
@@ -123,8 +123,8 @@ func (e *EndpointDeriver) selectBestPort(ports []scorev1b1.ServicePort) *scorev1
 	return &ports[0]
 }
 
-// prioritizePortsByCharacteristics prioritizes ports by port number characteristics
-// Priority: HTTPS ports (443, 8443) > HTTP ports (80, 8080) > others
+// prioritizePortsByCharacteristics prioritizes ports by name and port number characteristics
+// Priority: ports named https > ports named http > HTTPS ports (443, 8443) > HTTP ports (80, 8080) > others
 func (e *EndpointDeriver) prioritizePortsByCharacteristics(ports []scorev1b1.ServicePort) []scorev1b1.ServicePort {
 	// Create a slice of ports with their priority scores
 	type portWithPriority struct {
@@ -138,15 +138,19 @@ func (e *EndpointDeriver) prioritizePortsByCharacteristics(ports []scorev1b1.Ser
 	for _, port := range ports {
 		p := portWithPriority{
 			port:    port,
-			isHTTPS: e.isHTTPSPort(port.Port),
+			isHTTPS: e.getSchemeForPort(&port) == schemeHTTPS,
 		}
 
 		// Assign priority based on port characteristics
 		switch {
+		case port.Name == schemeHTTPS:
+			p.priority = 0 // Ports named https have highest priority
+		case port.Name == schemeHTTP:
+			p.priority = 0 // Ports named http as well; the HTTPS tie-break below prefers https
 		case e.isHTTPSPort(port.Port):
-			p.priority = 1 // HTTPS ports have highest priority
+			p.priority = 1 // HTTPS ports
 		case e.isHTTPPort(port.Port):
-			p.priority = 2 // HTTP ports have second priority
+			p.priority = 2 // HTTP ports
 		default:
 			p.priority = 10 // Other ports have lower priority
 		}
@@ -184,7 +188,12 @@ func (e *EndpointDeriver) isHTTPPort(port int32) bool {
 
 // getSchemeForPort returns the appropriate scheme for a port
 func (e *EndpointDeriver) getSchemeForPort(port *scorev1b1.ServicePort) string {
-	if e.isHTTPSPort(port.Port) {
+	switch {
+	case port.Name == schemeHTTPS:
+		return schemeHTTPS
+	case port.Name == schemeHTTP:
+		return schemeHTTP
+	case e.isHTTPSPort(port.Port):
 		return schemeHTTPS
 	}
 	return schemeHTTP
@@ -253,6 +253,15 @@ func TestEndpointDeriver_prioritizePortsByCharacteristics(t *testing.T) {
 			},
 			want: []int32{80, 3000},
 		},
+		{
+			name: "named ports over port numbers",
+			ports: []scorev1b1.ServicePort{
+				{Port: 443},
+				{Name: "http", Port: 3000},
+				{Name: "https", Port: 3443},
+			},
+			want: []int32{3443, 3000, 443},
+		},
 	}
 
 	for _, tt := range tests {
@@ -388,3 +397,26 @@ func TestEndpointDeriver_isStandardPort(t *testing.T) {
 		})
 	}
 }
+
+func TestEndpointDeriver_getSchemeForPort(t *testing.T) {
+	deriver := NewEndpointDeriver(fake.NewClientBuilder().Build())
+
+	tests := []struct {
+		name string
+		port scorev1b1.ServicePort
+		want string
+	}{
+		{name: "well-known https port", port: scorev1b1.ServicePort{Port: 8443}, want: schemeHTTPS},
+		{name: "port named https", port: scorev1b1.ServicePort{Name: "https", Port: 3000}, want: schemeHTTPS},
+		{name: "port named http", port: scorev1b1.ServicePort{Name: "http", Port: 8443}, want: schemeHTTP},
+		{name: "other port", port: scorev1b1.ServicePort{Name: "grpc", Port: 9090}, want: schemeHTTP},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := deriver.getSchemeForPort(&tt.port); got != tt.want {
+				t.Errorf("getSchemeForPort() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
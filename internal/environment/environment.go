@@ -0,0 +1,206 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package environment provisions the namespaces Workloads of an environment are materialized into.
+// A Workload labeled with an environment configured in OrchestratorConfig.spec.namespaces runs in the
+// namespace "<workload namespace>-<environment>", which carries the labels, quota and network policy
+// of the environment. Namespaces are never deleted by the Orchestrator, since other tenants may use them.
+package environment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+)
+
+// ErrUnknownEnvironment indicates that a Workload declares an environment the configuration does not define
+var ErrUnknownEnvironment = errors.New("environment is not configured")
+
+// ErrNamespaceConflict indicates that the namespace of an environment already exists without having been
+// provisioned for the namespace of the Workload
+var ErrNamespaceConflict = errors.New("namespace was not provisioned for the workload namespace")
+
+const (
+	// LabelSourceNamespace names, on a provisioned namespace, the namespace of the Workloads it was provisioned for
+	LabelSourceNamespace = "score.dev/source-namespace"
+
+	// quotaName is the name of the ResourceQuota of a provisioned namespace
+	quotaName = "score-environment"
+
+	// networkPolicyName is the name of the NetworkPolicy isolating a provisioned namespace
+	networkPolicyName = "score-environment-isolation"
+)
+
+// Namespace returns the namespace provisioned for the environment of the workload together with the environment.
+// It returns an empty name when no namespaces are provisioned or the workload declares no environment.
+func Namespace(namespaces *scorev1b1.NamespacesSpec, workload *scorev1b1.Workload) (string, *scorev1b1.EnvironmentSpec, error) {
+	if namespaces == nil {
+		return "", nil, nil
+	}
+	label := Label(namespaces)
+	name, ok := workload.Labels[label]
+	if !ok || name == "" {
+		return "", nil, nil
+	}
+
+	for i := range namespaces.Environments {
+		if environment := &namespaces.Environments[i]; environment.Name == name {
+			namespace := workload.Namespace + "-" + environment.Name
+			if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+				return "", nil, fmt.Errorf("namespace %q of environment %q is invalid: %s", namespace, name, strings.Join(errs, "; "))
+			}
+			return namespace, environment, nil
+		}
+	}
+	return "", nil, fmt.Errorf("%w: %q (label %s)", ErrUnknownEnvironment, name, label)
+}
+
+// Label returns the Workload label naming the environment of a Workload
+func Label(namespaces *scorev1b1.NamespacesSpec) string {
+	if namespaces == nil || namespaces.EnvironmentLabel == "" {
+		return scorev1b1.DefaultEnvironmentLabel
+	}
+	return namespaces.EnvironmentLabel
+}
+
+// Ensure applies the namespace of the environment with its quota and network policy.
+// The quota and network policy are removed when the environment no longer declares them.
+// An existing namespace is only adopted when its source namespace label names sourceNamespace,
+// so that a Workload cannot take over a namespace it was not provisioned for.
+func Ensure(ctx context.Context, c client.Client, name, sourceNamespace string, environment *scorev1b1.EnvironmentSpec) error {
+	existing := &corev1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: name}, existing); err == nil {
+		if existing.Labels[LabelSourceNamespace] != sourceNamespace {
+			return fmt.Errorf("%w: %s is not labeled %s=%s", ErrNamespaceConflict, name, LabelSourceNamespace, sourceNamespace)
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get namespace %s: %w", name, err)
+	}
+
+	labels := map[string]string{
+		scorev1b1.DefaultEnvironmentLabel: environment.Name,
+		LabelSourceNamespace:              sourceNamespace,
+		"app.kubernetes.io/managed-by":    "score-orchestrator",
+	}
+	for key, value := range environment.Labels {
+		labels[key] = value
+	}
+	namespace := &corev1.Namespace{
+		TypeMeta:   metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "Namespace"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: environment.Annotations},
+	}
+	if err := reconcile.Apply(ctx, c, namespace, meta.FieldManagerOrchestrator); err != nil {
+		return fmt.Errorf("failed to apply namespace %s: %w", name, err)
+	}
+
+	quota, err := buildResourceQuota(name, environment.Quota)
+	if err != nil {
+		return err
+	}
+	if quota != nil {
+		if err := reconcile.Apply(ctx, c, quota, meta.FieldManagerOrchestrator); err != nil {
+			return fmt.Errorf("failed to apply resource quota of namespace %s: %w", name, err)
+		}
+	} else if err := deleteIfExists(ctx, c, &corev1.ResourceQuota{}, types.NamespacedName{Namespace: name, Name: quotaName}); err != nil {
+		return err
+	}
+
+	if environment.NetworkPolicy == scorev1b1.NetworkPolicyIsolated {
+		if err := reconcile.Apply(ctx, c, buildNetworkPolicy(name), meta.FieldManagerOrchestrator); err != nil {
+			return fmt.Errorf("failed to apply network policy of namespace %s: %w", name, err)
+		}
+	} else if err := deleteIfExists(ctx, c, &networkingv1.NetworkPolicy{}, types.NamespacedName{Namespace: name, Name: networkPolicyName}); err != nil {
+		return err
+	}
+	return nil
+}
+
+// buildResourceQuota returns the ResourceQuota of the namespace, or nil when the environment declares no limits
+func buildResourceQuota(namespace string, quota *scorev1b1.NamespaceQuotaSpec) (*corev1.ResourceQuota, error) {
+	if quota == nil {
+		return nil, nil
+	}
+	hard := corev1.ResourceList{}
+	for _, limit := range []struct {
+		name  corev1.ResourceName
+		value string
+	}{
+		{corev1.ResourceRequestsCPU, quota.CPU},
+		{corev1.ResourceRequestsMemory, quota.Memory},
+	} {
+		if limit.value == "" {
+			continue
+		}
+		q, err := resource.ParseQuantity(limit.value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s quota %q: %w", limit.name, limit.value, err)
+		}
+		hard[limit.name] = q
+	}
+	if quota.Pods != nil {
+		hard[corev1.ResourcePods] = *resource.NewQuantity(int64(*quota.Pods), resource.DecimalSI)
+	}
+	if len(hard) == 0 {
+		return nil, nil
+	}
+
+	return &corev1.ResourceQuota{
+		TypeMeta:   metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "ResourceQuota"},
+		ObjectMeta: metav1.ObjectMeta{Name: quotaName, Namespace: namespace},
+		Spec:       corev1.ResourceQuotaSpec{Hard: hard},
+	}, nil
+}
+
+// buildNetworkPolicy returns the NetworkPolicy admitting ingress traffic to the pods of the namespace
+// only from pods of the same namespace
+func buildNetworkPolicy(namespace string) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		TypeMeta:   metav1.TypeMeta{APIVersion: networkingv1.SchemeGroupVersion.String(), Kind: "NetworkPolicy"},
+		ObjectMeta: metav1.ObjectMeta{Name: networkPolicyName, Namespace: namespace},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{{
+				From: []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}},
+			}},
+		},
+	}
+}
+
+// deleteIfExists deletes the object with the given key, ignoring objects that do not exist
+func deleteIfExists(ctx context.Context, c client.Client, obj client.Object, key types.NamespacedName) error {
+	if err := c.Get(ctx, key, obj); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if err := c.Delete(ctx, obj); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete %T %s: %w", obj, key, err)
+	}
+	return nil
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package environment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// applyAsCreateOrUpdate emulates server-side apply, which the fake client does not support,
+// by creating the applied object or replacing the existing one. Other patches pass through.
+func applyAsCreateOrUpdate(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+
+	existing, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", obj)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		return c.Create(ctx, obj)
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return c.Update(ctx, obj)
+}
+
+func TestNamespace(t *testing.T) {
+	namespaces := &scorev1b1.NamespacesSpec{Environments: []scorev1b1.EnvironmentSpec{{Name: "staging"}, {Name: "prod"}}}
+
+	tests := []struct {
+		name       string
+		namespaces *scorev1b1.NamespacesSpec
+		labels     map[string]string
+		namespace  string
+		want       string
+		wantErr    bool
+		wantErrIs  error
+	}{
+		{name: "provisioning disabled", labels: map[string]string{scorev1b1.DefaultEnvironmentLabel: "staging"}},
+		{name: "no environment declared", namespaces: namespaces},
+		{name: "configured environment", namespaces: namespaces, labels: map[string]string{scorev1b1.DefaultEnvironmentLabel: "staging"}, want: "team-a-staging"},
+		{
+			name:       "custom environment label",
+			namespaces: &scorev1b1.NamespacesSpec{EnvironmentLabel: "example.com/env", Environments: namespaces.Environments},
+			labels:     map[string]string{"example.com/env": "prod", scorev1b1.DefaultEnvironmentLabel: "staging"},
+			want:       "team-a-prod",
+		},
+		{name: "unknown environment", namespaces: namespaces, labels: map[string]string{scorev1b1.DefaultEnvironmentLabel: "qa"}, wantErr: true, wantErrIs: ErrUnknownEnvironment},
+		{
+			name:       "namespace name too long",
+			namespaces: namespaces,
+			labels:     map[string]string{scorev1b1.DefaultEnvironmentLabel: "staging"},
+			namespace:  strings.Repeat("a", 60),
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a", Labels: tt.labels}}
+			if tt.namespace != "" {
+				workload.Namespace = tt.namespace
+			}
+			got, environment, err := Namespace(tt.namespaces, workload)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Namespace() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErrIs != nil && !errors.Is(err, tt.wantErrIs) {
+				t.Errorf("Namespace() error = %v, want %v", err, tt.wantErrIs)
+			}
+			if got != tt.want {
+				t.Errorf("Namespace() = %q, want %q", got, tt.want)
+			}
+			if (environment != nil) != (tt.want != "") {
+				t.Errorf("Namespace() environment = %v, want one only with a namespace", environment)
+			}
+		})
+	}
+}
+
+func TestEnsure(t *testing.T) {
+	ctx := context.Background()
+	scheme := clientgoscheme.Scheme
+	c := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).Build()
+
+	environment := &scorev1b1.EnvironmentSpec{
+		Name:          "staging",
+		Labels:        map[string]string{"pod-security.kubernetes.io/enforce": "restricted"},
+		Quota:         &scorev1b1.NamespaceQuotaSpec{CPU: "8", Memory: "16Gi", Pods: ptr.To[int32](20)},
+		NetworkPolicy: scorev1b1.NetworkPolicyIsolated,
+	}
+	if err := Ensure(ctx, c, "team-a-staging", "team-a", environment); err != nil {
+		t.Fatalf("Ensure() error = %v", err)
+	}
+
+	namespace := &corev1.Namespace{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "team-a-staging"}, namespace); err != nil {
+		t.Fatalf("namespace was not created: %v", err)
+	}
+	if namespace.Labels["pod-security.kubernetes.io/enforce"] != "restricted" || namespace.Labels[LabelSourceNamespace] != "team-a" ||
+		namespace.Labels[scorev1b1.DefaultEnvironmentLabel] != "staging" {
+		t.Errorf("namespace labels = %v, want the environment template and source labels", namespace.Labels)
+	}
+
+	quota := &corev1.ResourceQuota{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "team-a-staging", Name: quotaName}, quota); err != nil {
+		t.Fatalf("resource quota was not created: %v", err)
+	}
+	if want := resource.MustParse("16Gi"); !quota.Spec.Hard[corev1.ResourceRequestsMemory].Equal(want) {
+		t.Errorf("memory quota = %v, want %v", quota.Spec.Hard[corev1.ResourceRequestsMemory], want)
+	}
+	if got := quota.Spec.Hard[corev1.ResourcePods]; got.Value() != 20 {
+		t.Errorf("pods quota = %v, want 20", got.Value())
+	}
+
+	policy := &networkingv1.NetworkPolicy{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "team-a-staging", Name: networkPolicyName}, policy); err != nil {
+		t.Fatalf("network policy was not created: %v", err)
+	}
+
+	// Dropping the quota and the isolation from the environment removes them from the namespace
+	if err := Ensure(ctx, c, "team-a-staging", "team-a", &scorev1b1.EnvironmentSpec{Name: "staging"}); err != nil {
+		t.Fatalf("Ensure() error = %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "team-a-staging", Name: quotaName}, quota); !apierrors.IsNotFound(err) {
+		t.Errorf("resource quota still exists: %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "team-a-staging", Name: networkPolicyName}, policy); !apierrors.IsNotFound(err) {
+		t.Errorf("network policy still exists: %v", err)
+	}
+}
+
+func TestEnsureRefusesForeignNamespace(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+	}{
+		{name: "unlabeled namespace"},
+		{name: "namespace of another workload namespace", labels: map[string]string{LabelSourceNamespace: "team-b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "team-a-staging", Labels: tt.labels}}
+			c := fake.NewClientBuilder().WithScheme(clientgoscheme.Scheme).WithObjects(existing).
+				WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).Build()
+
+			environment := &scorev1b1.EnvironmentSpec{Name: "staging", NetworkPolicy: scorev1b1.NetworkPolicyIsolated}
+			if err := Ensure(ctx, c, "team-a-staging", "team-a", environment); !errors.Is(err, ErrNamespaceConflict) {
+				t.Fatalf("Ensure() error = %v, want %v", err, ErrNamespaceConflict)
+			}
+
+			namespace := &corev1.Namespace{}
+			if err := c.Get(ctx, types.NamespacedName{Name: "team-a-staging"}, namespace); err != nil {
+				t.Fatalf("failed to get namespace: %v", err)
+			}
+			if _, ok := namespace.Labels[scorev1b1.DefaultEnvironmentLabel]; ok {
+				t.Errorf("namespace labels = %v, want the namespace left untouched", namespace.Labels)
+			}
+			policy := &networkingv1.NetworkPolicy{}
+			if err := c.Get(ctx, types.NamespacedName{Namespace: "team-a-staging", Name: networkPolicyName}, policy); !apierrors.IsNotFound(err) {
+				t.Errorf("network policy was created in a foreign namespace: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	scoremeta "github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+// CorrelationID returns the correlation ID of an object.
+// The score.dev/correlation-id annotation wins; Workloads without it fall back to their UID.
+func CorrelationID(object runtime.Object) string {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return ""
+	}
+
+	if id := accessor.GetAnnotations()[scoremeta.AnnotationCorrelationID]; id != "" {
+		return id
+	}
+
+	// Only the root of the chain derives an ID; children must inherit it explicitly
+	if _, isWorkload := object.(*scorev1b1.Workload); isWorkload {
+		return string(accessor.GetUID())
+	}
+	return ""
+}
+
+// PropagateCorrelationID copies the correlation ID of the source onto the target's annotations.
+// It returns true if the target annotations were changed.
+func PropagateCorrelationID(source runtime.Object, target metav1.Object) bool {
+	id := CorrelationID(source)
+	if id == "" {
+		return false
+	}
+
+	annotations := target.GetAnnotations()
+	if annotations[scoremeta.AnnotationCorrelationID] == id {
+		return false
+	}
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[scoremeta.AnnotationCorrelationID] = id
+	target.SetAnnotations(annotations)
+	return true
+}
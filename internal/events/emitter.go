@@ -0,0 +1,209 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+
+	scoremeta "github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+// Options configures deduplication and rate limiting for an Emitter
+type Options struct {
+	// DedupWindow suppresses events with the same object, type, reason and message within this window,
+	// unless the object's status transitioned since the event was last emitted
+	DedupWindow time.Duration
+
+	// RateLimitWindow is the window over which RateLimitBurst applies
+	RateLimitWindow time.Duration
+
+	// RateLimitBurst is the maximum number of events emitted per object within RateLimitWindow
+	RateLimitBurst int
+}
+
+// DefaultOptions returns the default emitter options
+func DefaultOptions() Options {
+	return Options{
+		DedupWindow:     5 * time.Minute,
+		RateLimitWindow: time.Minute,
+		RateLimitBurst:  10,
+	}
+}
+
+// Emitter is a record.EventRecorder that deduplicates and rate limits events,
+// and annotates them with the correlation ID of the involved object
+type Emitter struct {
+	recorder record.EventRecorder
+	opts     Options
+	now      func() time.Time
+
+	mu        sync.Mutex
+	seen      map[string]time.Time
+	buckets   map[string]*bucket
+	lastPrune time.Time
+}
+
+// bucket tracks the number of events emitted for an object in the current window
+type bucket struct {
+	start time.Time
+	count int
+}
+
+var _ record.EventRecorder = &Emitter{}
+
+// NewEmitter creates a new Emitter wrapping the given recorder
+func NewEmitter(recorder record.EventRecorder, opts Options) *Emitter {
+	return &Emitter{
+		recorder: recorder,
+		opts:     opts,
+		now:      time.Now,
+		seen:     make(map[string]time.Time),
+		buckets:  make(map[string]*bucket),
+	}
+}
+
+// Event emits an event unless it is a duplicate or the object is rate limited
+func (e *Emitter) Event(object runtime.Object, eventtype, reason, message string) {
+	e.emit(object, nil, eventtype, reason, message)
+}
+
+// Eventf is like Event, but uses fmt.Sprintf to construct the message
+func (e *Emitter) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	e.emit(object, nil, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// AnnotatedEventf is like Eventf, but attaches the given annotations to the event
+func (e *Emitter) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	e.emit(object, annotations, eventtype, reason, fmt.Sprintf(messageFmt, args...))
+}
+
+// emit applies deduplication and rate limiting before forwarding the event
+func (e *Emitter) emit(object runtime.Object, annotations map[string]string, eventtype, reason, message string) {
+	objectKey := objectKey(object)
+	if !e.allow(objectKey, transitionSequence(object), eventtype, reason, message) {
+		return
+	}
+
+	if correlationID := CorrelationID(object); correlationID != "" {
+		merged := make(map[string]string, len(annotations)+1)
+		for k, v := range annotations {
+			merged[k] = v
+		}
+		merged[scoremeta.AnnotationCorrelationID] = correlationID
+		annotations = merged
+	}
+
+	if len(annotations) > 0 {
+		e.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+		return
+	}
+	e.recorder.Event(object, eventtype, reason, message)
+}
+
+// allow reports whether an event should be emitted and records it if so.
+// The transition sequence is part of the dedup key, so an event repeated after a status flip
+// (e.g., Ready -> NotReady -> Ready) is emitted again even within the dedup window.
+func (e *Emitter) allow(objectKey, sequence, eventtype, reason, message string) bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	now := e.now()
+	if now.Sub(e.lastPrune) >= e.pruneInterval() {
+		e.prune(now)
+		e.lastPrune = now
+	}
+
+	dedupKey := fmt.Sprintf("%s/%s/%s/%s/%s", objectKey, sequence, eventtype, reason, message)
+	if last, ok := e.seen[dedupKey]; ok && now.Sub(last) < e.opts.DedupWindow {
+		return false
+	}
+
+	b, ok := e.buckets[objectKey]
+	if !ok || now.Sub(b.start) >= e.opts.RateLimitWindow {
+		b = &bucket{start: now}
+		e.buckets[objectKey] = b
+	}
+	if e.opts.RateLimitBurst > 0 && b.count >= e.opts.RateLimitBurst {
+		return false
+	}
+
+	b.count++
+	e.seen[dedupKey] = now
+	return true
+}
+
+// pruneInterval returns how often expired entries are dropped. Pruning walks every entry,
+// so it runs at most once per the shorter window instead of on every event.
+func (e *Emitter) pruneInterval() time.Duration {
+	return min(e.opts.DedupWindow, e.opts.RateLimitWindow)
+}
+
+// prune drops expired dedup entries and rate limit buckets to bound memory usage
+func (e *Emitter) prune(now time.Time) {
+	for key, last := range e.seen {
+		if now.Sub(last) >= e.opts.DedupWindow {
+			delete(e.seen, key)
+		}
+	}
+	for key, b := range e.buckets {
+		if now.Sub(b.start) >= e.opts.RateLimitWindow {
+			delete(e.buckets, key)
+		}
+	}
+}
+
+// transitionSequence identifies the latest status transition of the object: the most recent
+// lastTransitionTime among its status conditions. Objects without conditions have an empty sequence.
+func transitionSequence(object runtime.Object) string {
+	content, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	if err != nil {
+		return ""
+	}
+	conditions, _, _ := unstructured.NestedSlice(content, "status", "conditions")
+
+	// lastTransitionTime is serialized as RFC 3339 in UTC, so string order is chronological
+	var latest string
+	for _, condition := range conditions {
+		fields, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if transitioned, ok := fields["lastTransitionTime"].(string); ok && transitioned > latest {
+			latest = transitioned
+		}
+	}
+	return latest
+}
+
+// objectKey returns a stable identity for the involved object
+func objectKey(object runtime.Object) string {
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return fmt.Sprintf("%T", object)
+	}
+	if uid := accessor.GetUID(); uid != "" {
+		return string(uid)
+	}
+	return fmt.Sprintf("%T/%s/%s", object, accessor.GetNamespace(), accessor.GetName())
+}
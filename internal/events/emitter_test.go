@@ -0,0 +1,204 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package events
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	scoremeta "github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+type recordedEvent struct {
+	reason      string
+	message     string
+	annotations map[string]string
+}
+
+// captureRecorder records every event forwarded by the Emitter
+type captureRecorder struct {
+	events []recordedEvent
+}
+
+func (c *captureRecorder) Event(_ runtime.Object, _, reason, message string) {
+	c.events = append(c.events, recordedEvent{reason: reason, message: message})
+}
+
+func (c *captureRecorder) Eventf(_ runtime.Object, _, reason, messageFmt string, args ...interface{}) {
+	c.events = append(c.events, recordedEvent{reason: reason, message: fmt.Sprintf(messageFmt, args...)})
+}
+
+func (c *captureRecorder) AnnotatedEventf(_ runtime.Object, annotations map[string]string, _, reason, messageFmt string, args ...interface{}) {
+	c.events = append(c.events, recordedEvent{reason: reason, message: fmt.Sprintf(messageFmt, args...), annotations: annotations})
+}
+
+func newTestEmitter(opts Options) (*Emitter, *captureRecorder, *time.Time) {
+	recorder := &captureRecorder{}
+	emitter := NewEmitter(recorder, opts)
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	emitter.now = func() time.Time { return now }
+	return emitter, recorder, &now
+}
+
+func TestEmitter_Deduplication(t *testing.T) {
+	emitter, recorder, now := newTestEmitter(DefaultOptions())
+	workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: types.UID("uid-1")}}
+
+	emitter.Event(workload, corev1.EventTypeNormal, "Selected", "backend selected")
+	emitter.Event(workload, corev1.EventTypeNormal, "Selected", "backend selected")
+	emitter.Event(workload, corev1.EventTypeNormal, "Selected", "backend changed")
+	if got := len(recorder.events); got != 2 {
+		t.Fatalf("expected 2 events after duplicates, got %d", got)
+	}
+
+	*now = now.Add(DefaultOptions().DedupWindow)
+	emitter.Event(workload, corev1.EventTypeNormal, "Selected", "backend selected")
+	if got := len(recorder.events); got != 3 {
+		t.Errorf("expected duplicate to be emitted again after the dedup window, got %d events", got)
+	}
+}
+
+func TestEmitter_TransitionResetsDeduplication(t *testing.T) {
+	emitter, recorder, now := newTestEmitter(DefaultOptions())
+	workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: types.UID("uid-1")}}
+	setReady := func(status metav1.ConditionStatus) {
+		*now = now.Add(time.Minute)
+		workload.Status.Conditions = []metav1.Condition{
+			{Type: "Ready", Status: status, LastTransitionTime: metav1.NewTime(*now)},
+		}
+	}
+
+	setReady(metav1.ConditionTrue)
+	emitter.Event(workload, corev1.EventTypeNormal, "Ready", "Workload is ready and operational")
+	emitter.Event(workload, corev1.EventTypeNormal, "Ready", "Workload is ready and operational")
+	if got := len(recorder.events); got != 1 {
+		t.Fatalf("expected repeated Ready to be suppressed, got %d events", got)
+	}
+
+	setReady(metav1.ConditionFalse)
+	setReady(metav1.ConditionTrue)
+	emitter.Event(workload, corev1.EventTypeNormal, "Ready", "Workload is ready and operational")
+	if got := len(recorder.events); got != 2 {
+		t.Errorf("expected Ready to be emitted again after a Ready -> NotReady -> Ready flip within the dedup window, got %d events", got)
+	}
+}
+
+func TestEmitter_PruneIsAmortized(t *testing.T) {
+	opts := Options{DedupWindow: time.Minute, RateLimitWindow: time.Minute, RateLimitBurst: 1000}
+	emitter, _, now := newTestEmitter(opts)
+
+	for i := 0; i < 10; i++ {
+		workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: fmt.Sprintf("app-%d", i), UID: types.UID(fmt.Sprintf("uid-%d", i))}}
+		emitter.Event(workload, corev1.EventTypeNormal, "Reason", "message")
+	}
+
+	// Entries expire, but are only dropped once the prune interval elapsed since the last prune
+	*now = now.Add(30 * time.Second)
+	emitter.Event(&scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "other", UID: "uid-other"}}, corev1.EventTypeNormal, "Reason", "message")
+	if got := len(emitter.seen); got != 11 {
+		t.Fatalf("expected no prune before the interval, got %d entries", got)
+	}
+
+	*now = now.Add(opts.DedupWindow)
+	emitter.Event(&scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "last", UID: "uid-last"}}, corev1.EventTypeNormal, "Reason", "message")
+	if got := len(emitter.seen); got != 1 {
+		t.Errorf("expected expired entries to be pruned, got %d entries", got)
+	}
+}
+
+func TestEmitter_RateLimit(t *testing.T) {
+	emitter, recorder, now := newTestEmitter(Options{DedupWindow: time.Minute, RateLimitWindow: time.Minute, RateLimitBurst: 2})
+	workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: types.UID("uid-1")}}
+	other := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default", UID: types.UID("uid-2")}}
+
+	for i := 0; i < 5; i++ {
+		emitter.Eventf(workload, corev1.EventTypeWarning, "Failed", "attempt %d", i)
+	}
+	emitter.Eventf(other, corev1.EventTypeWarning, "Failed", "attempt %d", 0)
+	if got := len(recorder.events); got != 3 {
+		t.Fatalf("expected burst of 2 plus 1 event for another object, got %d", got)
+	}
+
+	*now = now.Add(time.Minute)
+	emitter.Eventf(workload, corev1.EventTypeWarning, "Failed", "attempt %d", 5)
+	if got := len(recorder.events); got != 4 {
+		t.Errorf("expected event to be emitted after the rate limit window, got %d events", got)
+	}
+}
+
+func TestEmitter_CorrelationID(t *testing.T) {
+	tests := []struct {
+		name   string
+		object runtime.Object
+		want   string
+	}{
+		{
+			name:   "workload falls back to UID",
+			object: &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "app", UID: types.UID("uid-1")}},
+			want:   "uid-1",
+		},
+		{
+			name: "annotation takes precedence",
+			object: &scorev1b1.WorkloadPlan{ObjectMeta: metav1.ObjectMeta{
+				Name:        "app",
+				UID:         types.UID("uid-2"),
+				Annotations: map[string]string{scoremeta.AnnotationCorrelationID: "uid-1"},
+			}},
+			want: "uid-1",
+		},
+		{
+			name:   "child without annotation has no correlation ID",
+			object: &scorev1b1.ResourceClaim{ObjectMeta: metav1.ObjectMeta{Name: "db", UID: types.UID("uid-3")}},
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			emitter, recorder, _ := newTestEmitter(DefaultOptions())
+			emitter.Event(tt.object, corev1.EventTypeNormal, "Reason", "message")
+			if len(recorder.events) != 1 {
+				t.Fatalf("expected 1 event, got %d", len(recorder.events))
+			}
+			if got := recorder.events[0].annotations[scoremeta.AnnotationCorrelationID]; got != tt.want {
+				t.Errorf("correlation ID = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPropagateCorrelationID(t *testing.T) {
+	workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "app", UID: types.UID("uid-1")}}
+	plan := &scorev1b1.WorkloadPlan{ObjectMeta: metav1.ObjectMeta{Name: "app"}}
+
+	if !PropagateCorrelationID(workload, plan) {
+		t.Fatal("expected annotations to change on first propagation")
+	}
+	if got := plan.Annotations[scoremeta.AnnotationCorrelationID]; got != "uid-1" {
+		t.Errorf("plan correlation ID = %q, want %q", got, "uid-1")
+	}
+	if PropagateCorrelationID(workload, plan) {
+		t.Error("expected no change on second propagation")
+	}
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fanout instantiates a Workload once per environment listed in spec.environments.
+// Each instance is a Workload "<name>-<environment>" owned by the listing Workload and labeled with the
+// environment, so that it gets its own claims, plan, profile selection and environment namespace.
+// The listing Workload runs nothing itself and aggregates the readiness of its instances.
+package fanout
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+)
+
+// InstanceName returns the name of the Workload instantiated for the environment
+func InstanceName(parent *scorev1b1.Workload, environment string) string {
+	return parent.Name + "-" + environment
+}
+
+// Instance returns the Workload instantiated for the environment: the spec of the parent without its
+// environments, and its labels and annotations with the environment label and the parent label added
+func Instance(parent *scorev1b1.Workload, environment, environmentLabel string) *scorev1b1.Workload {
+	labels := make(map[string]string, len(parent.Labels)+2)
+	for key, value := range parent.Labels {
+		labels[key] = value
+	}
+	labels[environmentLabel] = environment
+	labels[meta.LabelParentWorkload] = parent.Name
+
+	var annotations map[string]string
+	for key, value := range parent.Annotations {
+		if key == corev1.LastAppliedConfigAnnotation {
+			continue
+		}
+		if annotations == nil {
+			annotations = make(map[string]string, len(parent.Annotations))
+		}
+		annotations[key] = value
+	}
+
+	spec := parent.Spec.DeepCopy()
+	spec.Environments = nil
+
+	return &scorev1b1.Workload{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: scorev1b1.GroupVersion.String(),
+			Kind:       "Workload",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        InstanceName(parent, environment),
+			Namespace:   parent.Namespace,
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: *spec,
+	}
+}
+
+// Ensure applies the instances of the environments the parent lists and deletes the instances of the
+// environments it no longer lists. It returns the status of each instance, ordered as the environments.
+// A Workload of the same name that is not an instance of the parent is left untouched and reported as a
+// NameConflict.
+func Ensure(ctx context.Context, c client.Client, parent *scorev1b1.Workload, environmentLabel string) ([]scorev1b1.WorkloadEnvironmentStatus, error) {
+	statuses := make([]scorev1b1.WorkloadEnvironmentStatus, 0, len(parent.Spec.Environments))
+	for _, environment := range parent.Spec.Environments {
+		desired := Instance(parent, environment, environmentLabel)
+
+		current := &scorev1b1.Workload{}
+		err := c.Get(ctx, types.NamespacedName{Namespace: desired.Namespace, Name: desired.Name}, current)
+		switch {
+		case err == nil && !metav1.IsControlledBy(current, parent):
+			statuses = append(statuses, scorev1b1.WorkloadEnvironmentStatus{
+				Name:     environment,
+				Workload: desired.Name,
+				Ready:    metav1.ConditionFalse,
+				Reason:   conditions.ReasonNameConflict,
+				Message:  fmt.Sprintf("Workload %s already exists and is not an instance of %s", desired.Name, parent.Name),
+			})
+			continue
+		case apierrors.IsNotFound(err):
+			current = nil
+		case err != nil:
+			return nil, fmt.Errorf("failed to get Workload %s: %w", desired.Name, err)
+		}
+
+		if err := controllerutil.SetControllerReference(parent, desired, c.Scheme()); err != nil {
+			return nil, fmt.Errorf("failed to set owner reference: %w", err)
+		}
+		if err := reconcile.Apply(ctx, c, desired, meta.FieldManagerOrchestrator); err != nil {
+			return nil, fmt.Errorf("failed to apply Workload %s: %w", desired.Name, err)
+		}
+		statuses = append(statuses, InstanceStatus(environment, desired.Name, current))
+	}
+
+	if err := Prune(ctx, c, parent); err != nil {
+		return nil, err
+	}
+	return statuses, nil
+}
+
+// Prune deletes the instances of the parent for environments it no longer lists
+func Prune(ctx context.Context, c client.Client, parent *scorev1b1.Workload) error {
+	instances := &scorev1b1.WorkloadList{}
+	if err := c.List(ctx, instances, client.InNamespace(parent.Namespace),
+		client.MatchingLabels{meta.LabelParentWorkload: parent.Name}); err != nil {
+		return fmt.Errorf("failed to list instances of Workload %s: %w", parent.Name, err)
+	}
+
+	listed := make(map[string]bool, len(parent.Spec.Environments))
+	for _, environment := range parent.Spec.Environments {
+		listed[InstanceName(parent, environment)] = true
+	}
+	for i := range instances.Items {
+		instance := &instances.Items[i]
+		if listed[instance.Name] || !metav1.IsControlledBy(instance, parent) || !instance.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := c.Delete(ctx, instance); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete Workload %s: %w", instance.Name, err)
+		}
+	}
+	return nil
+}
+
+// InstanceStatus reports the Ready condition of the instance of an environment.
+// An instance that was just created, or whose status is stale, is reported as Unknown.
+func InstanceStatus(environment, name string, instance *scorev1b1.Workload) scorev1b1.WorkloadEnvironmentStatus {
+	status := scorev1b1.WorkloadEnvironmentStatus{
+		Name:     environment,
+		Workload: name,
+		Ready:    metav1.ConditionUnknown,
+		Message:  "Waiting for the instance to be reconciled",
+	}
+	if instance == nil || instance.Status.ObservedGeneration < instance.Generation {
+		return status
+	}
+	ready := conditions.GetCondition(instance.Status.Conditions, conditions.ConditionReady)
+	if ready == nil {
+		return status
+	}
+	status.Ready = ready.Status
+	status.Reason = ready.Reason
+	status.Message = ready.Message
+	if instance.Status.Endpoint != nil {
+		status.Endpoint = ptr.To(*instance.Status.Endpoint)
+	}
+	return status
+}
+
+// Aggregate computes the Ready condition of the parent: True when the instances of all environments are
+// ready, and otherwise False with the reason of the first environment that is not
+func Aggregate(statuses []scorev1b1.WorkloadEnvironmentStatus) (metav1.ConditionStatus, string, string) {
+	var notReady []string
+	reason := ""
+	for _, status := range statuses {
+		if status.Ready == metav1.ConditionTrue {
+			continue
+		}
+		detail := status.Reason
+		if detail == "" {
+			detail = string(status.Ready)
+		}
+		notReady = append(notReady, fmt.Sprintf("%s (%s)", status.Name, detail))
+		if reason == "" && status.Reason != "" {
+			reason = status.Reason
+		}
+	}
+	if len(notReady) == 0 {
+		return metav1.ConditionTrue, conditions.ReasonSucceeded, fmt.Sprintf("All %d environments are ready", len(statuses))
+	}
+	if reason == "" {
+		reason = conditions.ReasonRuntimeProvisioning
+	}
+	return metav1.ConditionFalse, reason, "Environments not ready: " + strings.Join(notReady, ", ")
+}
@@ -0,0 +1,236 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fanout
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+func newParent(environments ...string) *scorev1b1.Workload {
+	return &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "team-a",
+			UID:       "parent-uid",
+			Labels:    map[string]string{"app-tier": "frontend"},
+			Annotations: map[string]string{
+				"score.dev/dry-run":                "true",
+				corev1.LastAppliedConfigAnnotation: "{}",
+			},
+		},
+		Spec: scorev1b1.WorkloadSpec{
+			Containers:   map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx"}},
+			DependsOn:    []string{"db"},
+			Environments: environments,
+		},
+	}
+}
+
+func newScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme: %v", err)
+	}
+	return scheme
+}
+
+func TestInstance(t *testing.T) {
+	parent := newParent("staging", "prod")
+	instance := Instance(parent, "staging", scorev1b1.DefaultEnvironmentLabel)
+
+	if instance.Name != "web-staging" || instance.Namespace != "team-a" {
+		t.Errorf("Instance() = %s/%s, want team-a/web-staging", instance.Namespace, instance.Name)
+	}
+	wantLabels := map[string]string{
+		"app-tier":                        "frontend",
+		scorev1b1.DefaultEnvironmentLabel: "staging",
+		meta.LabelParentWorkload:          "web",
+	}
+	for key, value := range wantLabels {
+		if instance.Labels[key] != value {
+			t.Errorf("Instance() label %s = %q, want %q", key, instance.Labels[key], value)
+		}
+	}
+	if instance.Annotations["score.dev/dry-run"] != "true" {
+		t.Errorf("Instance() did not keep the annotations of the parent: %v", instance.Annotations)
+	}
+	if _, ok := instance.Annotations[corev1.LastAppliedConfigAnnotation]; ok {
+		t.Errorf("Instance() copied the last applied configuration of the parent")
+	}
+	if len(instance.Spec.Environments) != 0 {
+		t.Errorf("Instance() environments = %v, want none", instance.Spec.Environments)
+	}
+	if len(instance.Spec.DependsOn) != 1 || instance.Spec.DependsOn[0] != "db" {
+		t.Errorf("Instance() dependsOn = %v, want [db]", instance.Spec.DependsOn)
+	}
+	if len(parent.Spec.Environments) != 2 {
+		t.Errorf("Instance() modified the parent spec: %v", parent.Spec.Environments)
+	}
+}
+
+func TestInstanceStatus(t *testing.T) {
+	ready := func(status metav1.ConditionStatus, reason string) *scorev1b1.Workload {
+		return &scorev1b1.Workload{
+			ObjectMeta: metav1.ObjectMeta{Generation: 2},
+			Status: scorev1b1.WorkloadStatus{
+				ObservedGeneration: 2,
+				Endpoint:           ptr.To("http://web-prod.team-a-prod.svc:80"),
+				Conditions: []metav1.Condition{
+					{Type: conditions.ConditionReady, Status: status, Reason: reason, Message: "message"},
+				},
+			},
+		}
+	}
+	stale := ready(metav1.ConditionTrue, conditions.ReasonSucceeded)
+	stale.Generation = 3
+
+	tests := []struct {
+		name       string
+		instance   *scorev1b1.Workload
+		wantReady  metav1.ConditionStatus
+		wantReason string
+	}{
+		{"not created yet", nil, metav1.ConditionUnknown, ""},
+		{"no status", &scorev1b1.Workload{}, metav1.ConditionUnknown, ""},
+		{"stale status", stale, metav1.ConditionUnknown, ""},
+		{"ready", ready(metav1.ConditionTrue, conditions.ReasonSucceeded), metav1.ConditionTrue, conditions.ReasonSucceeded},
+		{"not ready", ready(metav1.ConditionFalse, conditions.ReasonClaimPending), metav1.ConditionFalse, conditions.ReasonClaimPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := InstanceStatus("prod", "web-prod", tt.instance)
+			if status.Name != "prod" || status.Workload != "web-prod" {
+				t.Errorf("InstanceStatus() = %s/%s, want prod/web-prod", status.Name, status.Workload)
+			}
+			if status.Ready != tt.wantReady || status.Reason != tt.wantReason {
+				t.Errorf("InstanceStatus() = %s/%s, want %s/%s", status.Ready, status.Reason, tt.wantReady, tt.wantReason)
+			}
+			if tt.wantReady == metav1.ConditionTrue && status.Endpoint == nil {
+				t.Errorf("InstanceStatus() endpoint = nil, want the endpoint of the instance")
+			}
+		})
+	}
+}
+
+func TestAggregate(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   []scorev1b1.WorkloadEnvironmentStatus
+		wantStatus metav1.ConditionStatus
+		wantReason string
+	}{
+		{
+			name: "all ready",
+			statuses: []scorev1b1.WorkloadEnvironmentStatus{
+				{Name: "staging", Ready: metav1.ConditionTrue, Reason: conditions.ReasonSucceeded},
+				{Name: "prod", Ready: metav1.ConditionTrue, Reason: conditions.ReasonSucceeded},
+			},
+			wantStatus: metav1.ConditionTrue,
+			wantReason: conditions.ReasonSucceeded,
+		},
+		{
+			name: "first environment that is not ready",
+			statuses: []scorev1b1.WorkloadEnvironmentStatus{
+				{Name: "staging", Ready: metav1.ConditionTrue, Reason: conditions.ReasonSucceeded},
+				{Name: "prod", Ready: metav1.ConditionFalse, Reason: conditions.ReasonClaimPending},
+				{Name: "preview", Ready: metav1.ConditionFalse, Reason: conditions.ReasonNameConflict},
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: conditions.ReasonClaimPending,
+		},
+		{
+			name: "instances not reconciled yet",
+			statuses: []scorev1b1.WorkloadEnvironmentStatus{
+				{Name: "staging", Ready: metav1.ConditionUnknown},
+			},
+			wantStatus: metav1.ConditionFalse,
+			wantReason: conditions.ReasonRuntimeProvisioning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, reason, message := Aggregate(tt.statuses)
+			if status != tt.wantStatus || reason != tt.wantReason {
+				t.Errorf("Aggregate() = %s/%s (%s), want %s/%s", status, reason, message, tt.wantStatus, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestPrune(t *testing.T) {
+	scheme := newScheme(t)
+	parent := newParent("prod")
+
+	instance := func(environment string, owned bool) *scorev1b1.Workload {
+		workload := Instance(parent, environment, scorev1b1.DefaultEnvironmentLabel)
+		if owned {
+			if err := controllerutil.SetControllerReference(parent, workload, scheme); err != nil {
+				t.Fatalf("failed to set owner reference: %v", err)
+			}
+		}
+		return workload
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(instance("prod", true), instance("staging", true), instance("preview", false)).
+		Build()
+
+	if err := Prune(context.Background(), c, parent); err != nil {
+		t.Fatalf("Prune() error = %v", err)
+	}
+
+	for name, wantExists := range map[string]bool{"web-prod": true, "web-staging": false, "web-preview": true} {
+		err := c.Get(context.Background(), types.NamespacedName{Namespace: "team-a", Name: name}, &scorev1b1.Workload{})
+		if exists := err == nil; exists != wantExists {
+			t.Errorf("Workload %s exists = %v (error %v), want %v", name, exists, err, wantExists)
+		} else if err != nil && !apierrors.IsNotFound(err) {
+			t.Errorf("failed to get Workload %s: %v", name, err)
+		}
+	}
+}
+
+func TestEnsureReportsNameConflicts(t *testing.T) {
+	parent := newParent("prod")
+	existing := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-prod", Namespace: "team-a"},
+		Spec:       scorev1b1.WorkloadSpec{Containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx"}}},
+	}
+	c := fake.NewClientBuilder().WithScheme(newScheme(t)).WithObjects(existing).Build()
+
+	statuses, err := Ensure(context.Background(), c, parent, scorev1b1.DefaultEnvironmentLabel)
+	if err != nil {
+		t.Fatalf("Ensure() error = %v", err)
+	}
+	if len(statuses) != 1 || statuses[0].Ready != metav1.ConditionFalse || statuses[0].Reason != conditions.ReasonNameConflict {
+		t.Errorf("Ensure() = %+v, want a NameConflict for prod", statuses)
+	}
+}
@@ -0,0 +1,279 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package faultinject injects faults into the Orchestrator so that integration tests can drive it through
+// its degraded paths deterministically: claim provisioning that is slow or fails, an OrchestratorConfig that
+// cannot be loaded, and runtimes whose readiness flaps. Faults are only injected through an Injector, which
+// test suites configure programmatically and the manager creates from the SCORE_FAULT_INJECTION environment
+// variable. A nil Injector injects no faults.
+package faultinject
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// EnvVar enables fault injection in the manager. It must never be set in production.
+const EnvVar = "SCORE_FAULT_INJECTION"
+
+// AnyType selects claims of every type
+const AnyType = "*"
+
+// ErrInjected is wrapped by every error returned by an injected fault
+var ErrInjected = errors.New("injected fault")
+
+// Injector holds the faults to inject. Faults can be changed while the manager runs.
+type Injector struct {
+	mu  sync.Mutex
+	now func() time.Time
+
+	// provisionFailures is the number of provisioning attempts failing per claim, by claim type; negative fails every attempt
+	provisionFailures map[string]int
+	// provisionDelays is how long claims take to provision after their creation, by claim type
+	provisionDelays map[string]time.Duration
+	// attempts counts the failed provisioning attempts per claim UID
+	attempts map[string]int
+
+	// configFailures is the number of config loads left to fail; negative fails every load
+	configFailures int
+
+	// flapPeriod is how long runtimes stay ready and degraded in turn, starting ready at flapStart
+	flapPeriod time.Duration
+	flapStart  time.Time
+}
+
+// New creates an Injector that injects no faults until they are configured
+func New() *Injector {
+	return &Injector{
+		now:               time.Now,
+		provisionFailures: make(map[string]int),
+		provisionDelays:   make(map[string]time.Duration),
+		attempts:          make(map[string]int),
+	}
+}
+
+// FromEnv creates an Injector from the faults in the SCORE_FAULT_INJECTION environment variable,
+// or returns nil when it is not set
+func FromEnv() (*Injector, error) {
+	spec := os.Getenv(EnvVar)
+	if spec == "" {
+		return nil, nil
+	}
+	injector, err := Parse(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", EnvVar, err)
+	}
+	return injector, nil
+}
+
+// Parse creates an Injector from a comma-separated list of faults:
+//
+//	provision-fail=<type>[:<attempts>]  fail the first attempts (default: every attempt) to provision claims of the type
+//	provision-delay=<type>:<duration>   keep claims of the type provisioning for the duration after their creation
+//	config-fail=<loads>                 fail the next loads of the OrchestratorConfig (-1: every load)
+//	runtime-flap=<period>               report ready runtimes as degraded during every other period
+//
+// The type "*" selects claims of every type.
+func Parse(spec string) (*Injector, error) {
+	injector := New()
+	for _, fault := range strings.Split(spec, ",") {
+		fault = strings.TrimSpace(fault)
+		if fault == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(fault, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("fault %q must be <name>=<value>", fault)
+		}
+
+		switch name {
+		case "provision-fail":
+			claimType, attempts, hasAttempts := strings.Cut(value, ":")
+			times := -1
+			if hasAttempts {
+				n, err := strconv.Atoi(attempts)
+				if err != nil || n < 1 {
+					return nil, fmt.Errorf("fault %q: attempts must be a positive integer", fault)
+				}
+				times = n
+			}
+			injector.FailProvisioning(claimType, times)
+		case "provision-delay":
+			claimType, duration, _ := strings.Cut(value, ":")
+			delay, err := time.ParseDuration(duration)
+			if err != nil || delay <= 0 {
+				return nil, fmt.Errorf("fault %q: delay must be a positive duration", fault)
+			}
+			injector.DelayProvisioning(claimType, delay)
+		case "config-fail":
+			loads, err := strconv.Atoi(value)
+			if err != nil || loads == 0 || loads < -1 {
+				return nil, fmt.Errorf("fault %q: loads must be a positive integer or -1", fault)
+			}
+			injector.FailConfigLoads(loads)
+		case "runtime-flap":
+			period, err := time.ParseDuration(value)
+			if err != nil || period <= 0 {
+				return nil, fmt.Errorf("fault %q: period must be a positive duration", fault)
+			}
+			injector.FlapRuntimeReadiness(period)
+		default:
+			return nil, fmt.Errorf("unknown fault %q", name)
+		}
+	}
+	return injector, nil
+}
+
+// SetClock replaces the clock that provisioning delays and readiness flapping are measured with
+func (i *Injector) SetClock(now func() time.Time) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.now = now
+}
+
+// FailProvisioning fails the first attempts to provision each claim of the type; negative attempts fail
+// every attempt, which drives claims to RetryLimitExceeded. Zero attempts stop failing claims of the type.
+func (i *Injector) FailProvisioning(claimType string, attempts int) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if attempts == 0 {
+		delete(i.provisionFailures, claimType)
+		return
+	}
+	i.provisionFailures[claimType] = attempts
+}
+
+// DelayProvisioning keeps claims of the type provisioning until the delay has passed since their creation.
+// Delays beyond the provisioning timeout fail the claims with reason Timeout. A zero delay removes the delay.
+func (i *Injector) DelayProvisioning(claimType string, delay time.Duration) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if delay <= 0 {
+		delete(i.provisionDelays, claimType)
+		return
+	}
+	i.provisionDelays[claimType] = delay
+}
+
+// FailConfigLoads fails the next loads of the OrchestratorConfig; negative loads fail every load and zero
+// loads stop failing
+func (i *Injector) FailConfigLoads(loads int) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.configFailures = loads
+}
+
+// FlapRuntimeReadiness reports ready runtimes as degraded during every other period, starting with a ready
+// period now. A zero period stops the flapping.
+func (i *Injector) FlapRuntimeReadiness(period time.Duration) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.flapPeriod = period
+	i.flapStart = i.now()
+}
+
+// Reset removes every fault
+func (i *Injector) Reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	clear(i.provisionFailures)
+	clear(i.provisionDelays)
+	clear(i.attempts)
+	i.configFailures = 0
+	i.flapPeriod = 0
+}
+
+// configFault returns the error of an injected config load failure, if any
+func (i *Injector) configFault() error {
+	if i == nil {
+		return nil
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.configFailures == 0 {
+		return nil
+	}
+	if i.configFailures > 0 {
+		i.configFailures--
+	}
+	return fmt.Errorf("%w: orchestrator configuration unavailable", ErrInjected)
+}
+
+// provisionDelayed reports whether the injected delay of the claim has not passed yet
+func (i *Injector) provisionDelayed(claim *scorev1b1.ResourceClaim) bool {
+	if i == nil {
+		return false
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	delay, ok := i.provisionDelays[claim.Spec.Type]
+	if !ok {
+		delay, ok = i.provisionDelays[AnyType]
+	}
+	return ok && i.now().Before(claim.CreationTimestamp.Add(delay))
+}
+
+// provisionFailing reports whether the next attempt to provision the claim fails
+func (i *Injector) provisionFailing(claim *scorev1b1.ResourceClaim) bool {
+	if i == nil {
+		return false
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.failingLocked(claim)
+}
+
+// provisionFault counts an attempt to provision the claim and returns its injected error, if any
+func (i *Injector) provisionFault(claim *scorev1b1.ResourceClaim) error {
+	if i == nil {
+		return nil
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if !i.failingLocked(claim) {
+		return nil
+	}
+	i.attempts[string(claim.UID)]++
+	return fmt.Errorf("%w: provisioning of %s claims fails", ErrInjected, claim.Spec.Type)
+}
+
+func (i *Injector) failingLocked(claim *scorev1b1.ResourceClaim) bool {
+	failures, ok := i.provisionFailures[claim.Spec.Type]
+	if !ok {
+		failures, ok = i.provisionFailures[AnyType]
+	}
+	return ok && (failures < 0 || i.attempts[string(claim.UID)] < failures)
+}
+
+// RuntimeDegraded reports whether ready runtimes are reported as degraded at the moment
+func (i *Injector) RuntimeDegraded() bool {
+	if i == nil {
+		return false
+	}
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.flapPeriod <= 0 {
+		return false
+	}
+	return (i.now().Sub(i.flapStart)/i.flapPeriod)%2 == 1
+}
@@ -0,0 +1,202 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package faultinject
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/config"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+// boundStrategy provisions every claim immediately
+type boundStrategy struct{}
+
+func (boundStrategy) GetType() string { return "postgres" }
+
+func (boundStrategy) Provision(context.Context, *scorev1b1.ResourceClaim) (*scorev1b1.ResourceClaimOutputs, error) {
+	return &scorev1b1.ResourceClaimOutputs{}, nil
+}
+
+func (boundStrategy) Deprovision(context.Context, *scorev1b1.ResourceClaim) error { return nil }
+
+func (boundStrategy) GetStatus(context.Context, *scorev1b1.ResourceClaim) (scorev1b1.ResourceClaimPhase, string, string, error) {
+	return scorev1b1.ResourceClaimPhaseBound, "", "", nil
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr bool
+	}{
+		{name: "every fault", spec: "provision-fail=postgres:2, provision-delay=*:1m,config-fail=-1,runtime-flap=30s"},
+		{name: "always failing type", spec: "provision-fail=redis"},
+		{name: "unknown fault", spec: "network-partition=1", wantErr: true},
+		{name: "missing value", spec: "config-fail", wantErr: true},
+		{name: "invalid attempts", spec: "provision-fail=postgres:0", wantErr: true},
+		{name: "missing delay", spec: "provision-delay=postgres", wantErr: true},
+		{name: "invalid loads", spec: "config-fail=-2", wantErr: true},
+		{name: "invalid period", spec: "runtime-flap=often", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestFromEnv(t *testing.T) {
+	t.Setenv(EnvVar, "")
+	if injector, err := FromEnv(); injector != nil || err != nil {
+		t.Errorf("FromEnv() = %v, %v without %s, want no injector", injector, err, EnvVar)
+	}
+
+	t.Setenv(EnvVar, "config-fail=1")
+	injector, err := FromEnv()
+	if err != nil || injector == nil {
+		t.Fatalf("FromEnv() = %v, %v, want an injector", injector, err)
+	}
+	if injector.configFault() == nil {
+		t.Error("injector from the environment did not fail the config load")
+	}
+}
+
+func TestStrategyFailures(t *testing.T) {
+	injector := New()
+	injector.FailProvisioning("postgres", 2)
+	s := injector.Strategy(boundStrategy{})
+	claim := &scorev1b1.ResourceClaim{ObjectMeta: metav1.ObjectMeta{UID: "claim-1"}, Spec: scorev1b1.ResourceClaimSpec{Type: "postgres"}}
+	ctx := context.Background()
+
+	for attempt := 1; attempt <= 2; attempt++ {
+		if phase, _, _, _ := s.GetStatus(ctx, claim); phase != scorev1b1.ResourceClaimPhaseFailed {
+			t.Errorf("GetStatus() before attempt %d = %s, want Failed", attempt, phase)
+		}
+		if _, err := s.Provision(ctx, claim); !errors.Is(err, ErrInjected) {
+			t.Errorf("Provision() attempt %d error = %v, want an injected fault", attempt, err)
+		}
+	}
+	if _, err := s.Provision(ctx, claim); err != nil {
+		t.Errorf("Provision() after the failing attempts error = %v", err)
+	}
+	if phase, _, _, _ := s.GetStatus(ctx, claim); phase != scorev1b1.ResourceClaimPhaseBound {
+		t.Errorf("GetStatus() after the failing attempts = %s, want Bound", phase)
+	}
+
+	other := &scorev1b1.ResourceClaim{ObjectMeta: metav1.ObjectMeta{UID: "claim-2"}, Spec: scorev1b1.ResourceClaimSpec{Type: "redis"}}
+	if _, err := s.Provision(ctx, other); err != nil {
+		t.Errorf("Provision() of another type error = %v", err)
+	}
+
+	injector.FailProvisioning(AnyType, -1)
+	for range 3 {
+		if _, err := s.Provision(ctx, other); !errors.Is(err, ErrInjected) {
+			t.Errorf("Provision() with every attempt failing error = %v, want an injected fault", err)
+		}
+	}
+}
+
+func TestStrategyDelay(t *testing.T) {
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	now := created
+	injector := New()
+	injector.SetClock(func() time.Time { return now })
+	injector.DelayProvisioning("postgres", time.Minute)
+	s := injector.Strategy(boundStrategy{})
+	claim := &scorev1b1.ResourceClaim{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)}, Spec: scorev1b1.ResourceClaimSpec{Type: "postgres"}}
+	ctx := context.Background()
+
+	if _, err := s.Provision(ctx, claim); !errors.Is(err, strategy.ErrInProgress) {
+		t.Errorf("Provision() within the delay error = %v, want in progress", err)
+	}
+	if phase, _, _, _ := s.GetStatus(ctx, claim); phase != scorev1b1.ResourceClaimPhaseClaiming {
+		t.Errorf("GetStatus() within the delay = %s, want Claiming", phase)
+	}
+
+	now = created.Add(time.Minute)
+	if _, err := s.Provision(ctx, claim); err != nil {
+		t.Errorf("Provision() after the delay error = %v", err)
+	}
+}
+
+func TestConfigLoader(t *testing.T) {
+	loader := config.NewMockLoader()
+	loader.SetConfig(&scorev1b1.OrchestratorConfig{})
+	injector := New()
+	faulty := injector.ConfigLoader(loader)
+	ctx := context.Background()
+
+	injector.FailConfigLoads(2)
+	for range 2 {
+		if _, err := faulty.LoadConfig(ctx); !errors.Is(err, ErrInjected) {
+			t.Errorf("LoadConfig() error = %v, want an injected fault", err)
+		}
+	}
+	if _, err := faulty.LoadConfig(ctx); err != nil {
+		t.Errorf("LoadConfig() after the failing loads error = %v", err)
+	}
+
+	var nilInjector *Injector
+	if nilInjector.ConfigLoader(loader) != config.ConfigLoader(loader) {
+		t.Error("nil Injector wrapped the config loader")
+	}
+}
+
+func TestRuntimeDegraded(t *testing.T) {
+	start := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	now := start
+	injector := New()
+	injector.SetClock(func() time.Time { return now })
+
+	if injector.RuntimeDegraded() {
+		t.Error("runtime degraded without flapping")
+	}
+
+	injector.FlapRuntimeReadiness(time.Minute)
+	for _, tt := range []struct {
+		offset time.Duration
+		want   bool
+	}{
+		{offset: 0, want: false},
+		{offset: 30 * time.Second, want: false},
+		{offset: time.Minute, want: true},
+		{offset: 90 * time.Second, want: true},
+		{offset: 2 * time.Minute, want: false},
+	} {
+		now = start.Add(tt.offset)
+		if got := injector.RuntimeDegraded(); got != tt.want {
+			t.Errorf("RuntimeDegraded() after %s = %v, want %v", tt.offset, got, tt.want)
+		}
+	}
+
+	injector.Reset()
+	now = start.Add(time.Minute)
+	if injector.RuntimeDegraded() {
+		t.Error("runtime degraded after Reset()")
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package faultinject
+
+import (
+	"context"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+	"github.com/cappyzawa/score-orchestrator/internal/config"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+// Strategy wraps a provisioning strategy so that it is slowed down and failed by the provisioning faults
+// of the injector. A nil Injector returns the strategy unchanged.
+func (i *Injector) Strategy(s strategy.Strategy) strategy.Strategy {
+	if i == nil {
+		return s
+	}
+	return &faultyStrategy{Strategy: s, injector: i}
+}
+
+// ConfigLoader wraps a config loader so that loads fail while the injector fails config loads.
+// A nil Injector returns the loader unchanged.
+func (i *Injector) ConfigLoader(loader config.ConfigLoader) config.ConfigLoader {
+	if i == nil {
+		return loader
+	}
+	return &faultyConfigLoader{ConfigLoader: loader, injector: i}
+}
+
+type faultyStrategy struct {
+	strategy.Strategy
+	injector *Injector
+}
+
+// Provision reports delayed claims as in progress and fails failing claims before provisioning them
+func (s *faultyStrategy) Provision(ctx context.Context, claim *scorev1b1.ResourceClaim) (*scorev1b1.ResourceClaimOutputs, error) {
+	if s.injector.provisionDelayed(claim) {
+		return nil, strategy.ErrInProgress
+	}
+	if err := s.injector.provisionFault(claim); err != nil {
+		return nil, err
+	}
+	return s.Strategy.Provision(ctx, claim)
+}
+
+// GetStatus keeps delayed claims Claiming and failing claims Failed, so that they do not recover on their own
+func (s *faultyStrategy) GetStatus(ctx context.Context, claim *scorev1b1.ResourceClaim) (scorev1b1.ResourceClaimPhase, string, string, error) {
+	if s.injector.provisionDelayed(claim) {
+		return scorev1b1.ResourceClaimPhaseClaiming, conditions.ReasonClaiming, "Injected fault: provisioning delayed", nil
+	}
+	if s.injector.provisionFailing(claim) {
+		return scorev1b1.ResourceClaimPhaseFailed, conditions.ReasonClaimFailed, "Injected fault: provisioning fails", nil
+	}
+	return s.Strategy.GetStatus(ctx, claim)
+}
+
+type faultyConfigLoader struct {
+	config.ConfigLoader
+	injector *Injector
+}
+
+// LoadConfig fails while the injector fails config loads
+func (l *faultyConfigLoader) LoadConfig(ctx context.Context) (*scorev1b1.OrchestratorConfig, error) {
+	if err := l.injector.configFault(); err != nil {
+		return nil, err
+	}
+	return l.ConfigLoader.LoadConfig(ctx)
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package health reports the state of the control plane itself as Prometheus metrics: the provisioning
+// strategies compiled in, the runtimes that registered and the plans they run, and how many Workloads are not ready.
+// The state of the orchestrator configuration is reported by the config package as it is loaded.
+package health
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+	"github.com/cappyzawa/score-orchestrator/internal/runtimeregistry"
+	"github.com/cappyzawa/score-orchestrator/internal/sharding"
+)
+
+const (
+	// collectTimeout bounds the cache reads of a single scrape
+	collectTimeout = 10 * time.Second
+
+	// reasonUnknown labels Workloads that have no Ready condition yet
+	reasonUnknown = "Unknown"
+)
+
+var (
+	strategyDesc = prometheus.NewDesc(
+		"score_orchestrator_provisioner_strategy_info",
+		"Provisioning strategy registered with the built-in provisioner (always 1)",
+		[]string{"strategy"}, nil,
+	)
+	runtimeDesc = prometheus.NewDesc(
+		"score_orchestrator_runtime_live",
+		"Whether the most recent registration of a runtime class is live (1) or expired (0)",
+		[]string{"runtime_class", "version"}, nil,
+	)
+	runtimePlansDesc = prometheus.NewDesc(
+		"score_orchestrator_runtime_plans",
+		"Number of WorkloadPlans handled by this shard that target a registered runtime class",
+		[]string{"runtime_class"}, nil,
+	)
+	workloadsDesc = prometheus.NewDesc(
+		"score_orchestrator_workloads",
+		"Number of Workloads handled by this shard",
+		nil, nil,
+	)
+	workloadsNotReadyDesc = prometheus.NewDesc(
+		"score_orchestrator_workloads_not_ready",
+		"Number of Workloads handled by this shard whose Ready condition is not True, by reason",
+		[]string{"reason"}, nil,
+	)
+)
+
+// Collector computes the control plane metrics from the manager cache on every scrape
+type Collector struct {
+	Client client.Reader
+	// Shard restricts the Workload counts to the Workloads of this shard, so that they add up across shards
+	Shard sharding.Shard
+	// Now returns the current time; defaults to time.Now
+	Now func() time.Time
+}
+
+var _ prometheus.Collector = &Collector{}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- strategyDesc
+	ch <- runtimeDesc
+	ch <- runtimePlansDesc
+	ch <- workloadsDesc
+	ch <- workloadsNotReadyDesc
+}
+
+// Collect implements prometheus.Collector. Metrics whose source cannot be read are omitted from the scrape.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), collectTimeout)
+	defer cancel()
+	logger := log.FromContext(ctx).WithName("health")
+
+	for _, name := range strategy.Registered() {
+		ch <- prometheus.MustNewConstMetric(strategyDesc, prometheus.GaugeValue, 1, name)
+	}
+
+	now := time.Now
+	if c.Now != nil {
+		now = c.Now
+	}
+	registrations, err := runtimeregistry.LatestRegistrations(ctx, c.Client)
+	if err != nil {
+		logger.Error(err, "Failed to collect runtime registrations")
+	}
+	for runtimeClass, registration := range registrations {
+		live := 0.0
+		if registration.Live(now()) {
+			live = 1
+		}
+		ch <- prometheus.MustNewConstMetric(runtimeDesc, prometheus.GaugeValue, live, runtimeClass, registration.Version)
+
+		plans := &scorev1b1.WorkloadPlanList{}
+		if err := c.Client.List(ctx, plans, client.MatchingFields{meta.IndexWorkloadPlanByRuntimeClass: runtimeClass}); err != nil {
+			logger.Error(err, "Failed to collect WorkloadPlans", "runtimeClass", runtimeClass)
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(runtimePlansDesc, prometheus.GaugeValue, float64(countPlans(plans.Items, c.Shard)), runtimeClass)
+	}
+
+	workloads := &scorev1b1.WorkloadList{}
+	if err := c.Client.List(ctx, workloads); err != nil {
+		logger.Error(err, "Failed to collect Workloads")
+		return
+	}
+	total, notReady := countWorkloads(workloads.Items, c.Shard)
+	ch <- prometheus.MustNewConstMetric(workloadsDesc, prometheus.GaugeValue, float64(total))
+	for reason, count := range notReady {
+		ch <- prometheus.MustNewConstMetric(workloadsNotReadyDesc, prometheus.GaugeValue, float64(count), reason)
+	}
+}
+
+// countPlans counts the WorkloadPlans of the Workloads owned by the shard. Plans being deleted are not counted.
+func countPlans(plans []scorev1b1.WorkloadPlan, shard sharding.Shard) int {
+	total := 0
+	for i := range plans {
+		plan := &plans[i]
+		ref := types.NamespacedName{Namespace: plan.Spec.WorkloadRef.Namespace, Name: plan.Spec.WorkloadRef.Name}
+		if plan.DeletionTimestamp.IsZero() && shard.Owns(ref) {
+			total++
+		}
+	}
+	return total
+}
+
+// countWorkloads counts the Workloads owned by the shard and, by reason, those that are not ready.
+// Workloads being deleted are not counted.
+func countWorkloads(workloads []scorev1b1.Workload, shard sharding.Shard) (int, map[string]int) {
+	total := 0
+	notReady := make(map[string]int)
+	for i := range workloads {
+		workload := &workloads[i]
+		if !workload.DeletionTimestamp.IsZero() || !shard.Owns(types.NamespacedName{Namespace: workload.Namespace, Name: workload.Name}) {
+			continue
+		}
+		total++
+
+		ready := conditions.GetCondition(workload.Status.Conditions, conditions.ConditionReady)
+		switch {
+		case ready == nil:
+			// Not reconciled yet
+			notReady[reasonUnknown]++
+		case ready.Status != metav1.ConditionTrue:
+			notReady[ready.Reason]++
+		}
+	}
+	return total, notReady
+}
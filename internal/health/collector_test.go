@@ -0,0 +1,128 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package health
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+	"github.com/cappyzawa/score-orchestrator/internal/runtimeregistry"
+	"github.com/cappyzawa/score-orchestrator/internal/sharding"
+)
+
+func workloadWithReady(name string, status metav1.ConditionStatus, reason string) *scorev1b1.Workload {
+	workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"}}
+	if status != "" {
+		workload.Status.Conditions = []metav1.Condition{{Type: conditions.ConditionReady, Status: status, Reason: reason}}
+	}
+	return workload
+}
+
+func planForRuntime(name, runtimeClass string) *scorev1b1.WorkloadPlan {
+	return &scorev1b1.WorkloadPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef:  scorev1b1.WorkloadPlanWorkloadRef{Name: name, Namespace: "default"},
+			RuntimeClass: runtimeClass,
+		},
+	}
+}
+
+func TestCollector(t *testing.T) {
+	strategy.Register("health-test", func(client.Client) strategy.Strategy { return nil })
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = scorev1b1.AddToScheme(scheme)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		runtimeregistry.Registration{
+			RuntimeClass: "kubernetes", Version: "v1.2.0", RenewTime: now.Add(-10 * time.Second), LeaseDuration: time.Minute,
+		}.ConfigMap("score-system", "score-runtime-kubernetes"),
+		runtimeregistry.Registration{
+			RuntimeClass: "ecs", Version: "v0.1.0", RenewTime: now.Add(-time.Hour), LeaseDuration: time.Minute,
+		}.ConfigMap("score-system", "score-runtime-ecs"),
+		workloadWithReady("web", metav1.ConditionTrue, conditions.ReasonSucceeded),
+		workloadWithReady("api", metav1.ConditionFalse, conditions.ReasonClaimFailed),
+		workloadWithReady("worker", metav1.ConditionFalse, conditions.ReasonClaimFailed),
+		workloadWithReady("new", "", ""),
+		planForRuntime("web", "kubernetes"),
+		planForRuntime("api", "kubernetes"),
+		planForRuntime("worker", "docker"),
+	).WithIndex(&scorev1b1.WorkloadPlan{}, meta.IndexWorkloadPlanByRuntimeClass, func(obj client.Object) []string {
+		return []string{obj.(*scorev1b1.WorkloadPlan).Spec.RuntimeClass}
+	}).Build()
+
+	collector := &Collector{Client: c, Now: func() time.Time { return now }}
+	expected := `
+# HELP score_orchestrator_provisioner_strategy_info Provisioning strategy registered with the built-in provisioner (always 1)
+# TYPE score_orchestrator_provisioner_strategy_info gauge
+score_orchestrator_provisioner_strategy_info{strategy="health-test"} 1
+# HELP score_orchestrator_runtime_live Whether the most recent registration of a runtime class is live (1) or expired (0)
+# TYPE score_orchestrator_runtime_live gauge
+score_orchestrator_runtime_live{runtime_class="ecs",version="v0.1.0"} 0
+score_orchestrator_runtime_live{runtime_class="kubernetes",version="v1.2.0"} 1
+# HELP score_orchestrator_runtime_plans Number of WorkloadPlans handled by this shard that target a registered runtime class
+# TYPE score_orchestrator_runtime_plans gauge
+score_orchestrator_runtime_plans{runtime_class="ecs"} 0
+score_orchestrator_runtime_plans{runtime_class="kubernetes"} 2
+# HELP score_orchestrator_workloads Number of Workloads handled by this shard
+# TYPE score_orchestrator_workloads gauge
+score_orchestrator_workloads 4
+# HELP score_orchestrator_workloads_not_ready Number of Workloads handled by this shard whose Ready condition is not True, by reason
+# TYPE score_orchestrator_workloads_not_ready gauge
+score_orchestrator_workloads_not_ready{reason="ClaimFailed"} 2
+score_orchestrator_workloads_not_ready{reason="Unknown"} 1
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCountWorkloadsByShard(t *testing.T) {
+	workloads := []scorev1b1.Workload{
+		*workloadWithReady("a", metav1.ConditionFalse, conditions.ReasonClaimPending),
+		*workloadWithReady("b", metav1.ConditionFalse, conditions.ReasonClaimPending),
+		*workloadWithReady("c", metav1.ConditionFalse, conditions.ReasonClaimPending),
+		*workloadWithReady("d", metav1.ConditionFalse, conditions.ReasonClaimPending),
+	}
+
+	totals := 0
+	for index := range 2 {
+		total, notReady := countWorkloads(workloads, sharding.Shard{Index: index, Count: 2})
+		if notReady[conditions.ReasonClaimPending] != total {
+			t.Errorf("shard %d: not ready = %v, want all %d workloads", index, notReady, total)
+		}
+		totals += total
+	}
+	if totals != len(workloads) {
+		t.Errorf("workloads counted across shards = %d, want %d", totals, len(workloads))
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"fmt"
+	"strconv"
+
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+// NewAtomicLevel returns the initial log level as an atomic level, so that verbosity can be adjusted
+// without restarting the manager. level is a zap level name (debug, info, error) or a logr verbosity.
+// An explicit level wins over --zap-log-level; otherwise the zap options decide.
+func NewAtomicLevel(level string, opts zap.Options) (uberzap.AtomicLevel, error) {
+	if level == "" {
+		if atomic, ok := opts.Level.(uberzap.AtomicLevel); ok {
+			return atomic, nil
+		}
+		if opts.Development {
+			return uberzap.NewAtomicLevelAt(zapcore.DebugLevel), nil
+		}
+		return uberzap.NewAtomicLevelAt(zapcore.InfoLevel), nil
+	}
+
+	// logr verbosity N maps to zap level -N
+	if verbosity, err := strconv.Atoi(level); err == nil {
+		if verbosity < 0 {
+			return uberzap.AtomicLevel{}, fmt.Errorf("verbosity must not be negative: %d", verbosity)
+		}
+		return uberzap.NewAtomicLevelAt(zapcore.Level(-verbosity)), nil
+	}
+
+	parsed, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return uberzap.AtomicLevel{}, err
+	}
+	return uberzap.NewAtomicLevelAt(parsed), nil
+}
@@ -0,0 +1,72 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logging
+
+import (
+	"testing"
+
+	uberzap "go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+)
+
+func TestNewAtomicLevel(t *testing.T) {
+	configured := uberzap.NewAtomicLevelAt(zapcore.WarnLevel)
+
+	tests := []struct {
+		name    string
+		level   string
+		opts    zap.Options
+		want    zapcore.Level
+		wantErr bool
+	}{
+		{name: "development default", opts: zap.Options{Development: true}, want: zapcore.DebugLevel},
+		{name: "production default", want: zapcore.InfoLevel},
+		{name: "zap flag level is kept", opts: zap.Options{Level: configured}, want: zapcore.WarnLevel},
+		{name: "level name", level: "error", want: zapcore.ErrorLevel},
+		{name: "level name wins over zap flag level", level: "info", opts: zap.Options{Level: configured}, want: zapcore.InfoLevel},
+		{name: "logr verbosity", level: "2", want: zapcore.Level(-2)},
+		{name: "negative verbosity", level: "-1", wantErr: true},
+		{name: "unknown level", level: "loud", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAtomicLevel(tt.level, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewAtomicLevel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Level() != tt.want {
+				t.Errorf("NewAtomicLevel() = %v, want %v", got.Level(), tt.want)
+			}
+		})
+	}
+}
+
+func TestNewAtomicLevelIsAdjustable(t *testing.T) {
+	level, err := NewAtomicLevel("info", zap.Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	level.SetLevel(zapcore.DebugLevel)
+	if !level.Enabled(zapcore.DebugLevel) {
+		t.Error("expected the level to be adjustable at runtime")
+	}
+}
@@ -19,12 +19,19 @@ package meta
 // Finalizer names
 const (
 	WorkloadFinalizer = "workloads.score.dev/finalizer"
+	// PlanDeliveryFinalizer removes the remote copy of a delivered WorkloadPlan before the plan is deleted
+	PlanDeliveryFinalizer = "delivery.score.dev/finalizer"
 )
 
 // Field indexer names
 const (
-	IndexResourceClaimByWorkload = "resourceclaim.workloadRef"
-	IndexWorkloadPlanByWorkload  = "workloadplan.workloadRef"
+	IndexResourceClaimByWorkload      = "resourceclaim.workloadRef"
+	IndexResourceClaimByOutputsSecret = "resourceclaim.outputsSecretRef"
+	IndexWorkloadPlanByWorkload       = "workloadplan.workloadRef"
+	IndexWorkloadByDependency         = "workload.dependsOn"
+	IndexWorkloadPlanByRuntimeClass   = "workloadplan.runtimeClass"
+	IndexWorkloadExposureByWorkload   = "workloadexposure.workloadRef"
+	IndexIngressByBackendService      = "ingress.backendService"
 )
 
 // Event reasons
@@ -34,7 +41,148 @@ const (
 	EventReady        = "Ready"
 )
 
+// Annotations
+const (
+	// AnnotationScheme overrides the URL scheme ("http" or "https") of published endpoints
+	AnnotationScheme = "score.dev/scheme"
+
+	// AnnotationCorrelationID links a Workload with its ResourceClaims, WorkloadPlan and their events
+	AnnotationCorrelationID = "score.dev/correlation-id"
+
+	// AnnotationTraceParent carries the W3C trace context of the reconcile that last wrote the object
+	AnnotationTraceParent = "score.dev/traceparent"
+
+	// AnnotationDryRun makes the Orchestrator publish a preview in Workload.status instead of creating claims and plans
+	AnnotationDryRun = "score.dev/dry-run"
+
+	// AnnotationPriority routes a Workload to the high-priority reconcile lane when set to PriorityHigh
+	AnnotationPriority = "score.dev/priority"
+
+	// AnnotationSecurityDefaults opts a Workload in to or out of the pod security defaults
+	AnnotationSecurityDefaults = "score.dev/security-defaults"
+
+	// AnnotationImageDigests opts a Workload in to or out of resolving its image tags to digests, overriding
+	// the image policy of its profile
+	AnnotationImageDigests = "score.dev/image-digests"
+
+	// AnnotationTTL makes the Orchestrator delete the Workload once the given duration (e.g., "72h") has
+	// passed since its creation
+	AnnotationTTL = "score.dev/ttl"
+
+	// AnnotationRestart requests a rolling restart of the Workload: setting it to a new RFC 3339 timestamp
+	// rolls out new instances even though the spec did not change
+	AnnotationRestart = "score.dev/restart"
+
+	// AnnotationRolledBackGeneration marks a WorkloadPlan restored from history after the rollout of the
+	// annotated Workload generation failed
+	AnnotationRolledBackGeneration = "score.dev/rolled-back-generation"
+
+	// AnnotationSourceGeneration records on a delivered WorkloadPlan the generation of the plan it was copied from
+	AnnotationSourceGeneration = "score.dev/source-generation"
+
+	// AnnotationDeliveredTarget records on a WorkloadPlan the target its remote copy was delivered to, so that
+	// the copy is removed from that target after the target of the plan changes or is cleared
+	AnnotationDeliveredTarget = "score.dev/delivered-target"
+
+	// AnnotationConfigGeneration records on a WorkloadPlan the generation of the orchestrator configuration
+	// it was last written with
+	AnnotationConfigGeneration = "score.dev/config-generation"
+)
+
+// Labels
+const (
+	// LabelWorkload names the Workload an object was generated for. The Orchestrator puts it on the claims,
+	// plans and audit records of a Workload, runtimes on the resources they materialize, so that they can be
+	// listed by selector.
+	LabelWorkload = "score.dev/workload"
+
+	// LabelRuntimeClass names the runtime class of a WorkloadPlan or WorkloadExposure
+	LabelRuntimeClass = "score.dev/runtime-class"
+
+	// LabelPlanHistory names the Workload whose plan history a ControllerRevision belongs to
+	LabelPlanHistory = "score.dev/plan-history"
+
+	// LabelPlanValues names the Workload whose externalized plan values a ConfigMap holds
+	LabelPlanValues = "score.dev/plan-values"
+
+	// LabelParentWorkload names, on a Workload instantiated for an environment, the Workload listing the environment
+	LabelParentWorkload = "score.dev/parent-workload"
+)
+
+// WorkloadPlan condition types written by runtimes
+const (
+	// PlanConditionReady reports whether the runtime rolled out the plan generation it observed
+	PlanConditionReady = "Ready"
+	// PlanConditionDelivered reports whether a plan with a target was delivered to its remote cluster
+	PlanConditionDelivered = "Delivered"
+)
+
+// PlanReasonRuntimeConflict is the reason of the Ready condition of a plan whose resources would take the
+// name of existing objects the runtime did not create
+const PlanReasonRuntimeConflict = "RuntimeConflict"
+
+// PlanReasonLimitRangeViolation is the reason of the Ready condition of a plan whose pods would be rejected by
+// the LimitRanges of the namespace they are created in
+const PlanReasonLimitRangeViolation = "LimitRangeViolation"
+
+// PlanReasonPriorityClassNotFound is the reason of the Ready condition of a plan whose pods reference a
+// PriorityClass that does not exist and that the plan gives no value to create
+const PlanReasonPriorityClassNotFound = "PriorityClassNotFound"
+
+// Values of AnnotationSecurityDefaults
+const (
+	// SecurityDefaultsEnabled applies the pod security defaults, falling back to the restricted values when none are configured
+	SecurityDefaultsEnabled = "enabled"
+	// SecurityDefaultsDisabled generates pods without a security context
+	SecurityDefaultsDisabled = "disabled"
+)
+
+// Values of AnnotationImageDigests
+const (
+	// ImageDigestsEnabled resolves the image tags of the Workload to digests
+	ImageDigestsEnabled = "enabled"
+	// ImageDigestsDisabled plans the images of the Workload as written
+	ImageDigestsDisabled = "disabled"
+)
+
+// Reconcile priorities
+const (
+	// PriorityHigh is the AnnotationPriority value for urgent Workloads
+	PriorityHigh = "high"
+)
+
+// Server-side apply field managers
+const (
+	// FieldManagerOrchestrator owns the ResourceClaims and WorkloadPlans written by the Orchestrator
+	FieldManagerOrchestrator = "score-orchestrator"
+
+	// FieldManagerRuntimeKubernetes owns the Deployments and Services written by the Kubernetes runtime
+	FieldManagerRuntimeKubernetes = "score-runtime-k8s"
+
+	// FieldManagerRuntimeDocker owns the registration ConfigMap written by the local Docker runtime
+	FieldManagerRuntimeDocker = "score-runtime-docker"
+
+	// FieldManagerPlanDelivery owns the WorkloadPlans delivered to remote clusters
+	FieldManagerPlanDelivery = "score-plan-delivery"
+)
+
 // Runtime classes
 const (
 	RuntimeClassKubernetes = "kubernetes"
+	// RuntimeClassDocker runs Workloads as Docker Compose projects on a developer machine
+	RuntimeClassDocker = "docker"
+)
+
+// Environment variables runtimes inject into every container. Workload variables must not use the
+// reserved prefix, so injected values can never be overridden by the Workload.
+const (
+	// EnvReservedPrefix is the prefix of the variable names reserved for the platform
+	EnvReservedPrefix = "SCORE_"
+
+	// EnvWorkloadName carries the Workload name
+	EnvWorkloadName = "SCORE_WORKLOAD_NAME"
+	// EnvWorkloadNamespace carries the Workload namespace, which may differ from the namespace the pods run in
+	EnvWorkloadNamespace = "SCORE_WORKLOAD_NAMESPACE"
+	// EnvWorkloadEndpoint carries the address other Workloads reach the Workload at; only set for Workloads with a service
+	EnvWorkloadEndpoint = "SCORE_WORKLOAD_ENDPOINT"
 )
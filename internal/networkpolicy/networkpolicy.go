@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package networkpolicy builds the NetworkPolicies generated for provisioned resources and Workload pods.
+// Every generated policy denies ingress to the pods it selects except from the pods of a Workload and
+// the peers configured in an IngressPolicySpec.
+package networkpolicy
+
+import (
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/environment"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+// LabelWorkload is the label the runtime puts on the pods of a Workload
+const LabelWorkload = meta.LabelWorkload
+
+// Ingress returns a NetworkPolicy admitting ingress to the pods matching podLabels only from the given peers
+func Ingress(name, namespace string, labels, podLabels map[string]string, from []networkingv1.NetworkPolicyPeer) *networkingv1.NetworkPolicy {
+	return &networkingv1.NetworkPolicy{
+		TypeMeta:   metav1.TypeMeta{APIVersion: networkingv1.SchemeGroupVersion.String(), Kind: "NetworkPolicy"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: podLabels},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress:     []networkingv1.NetworkPolicyIngressRule{{From: from}},
+		},
+	}
+}
+
+// WorkloadPeers returns the peers selecting the pods of the named Workload, both in the namespace of the
+// Workload and in the environment namespaces provisioned for that namespace
+func WorkloadPeers(name, namespace string) []networkingv1.NetworkPolicyPeer {
+	pods := map[string]string{LabelWorkload: name}
+	return []networkingv1.NetworkPolicyPeer{
+		{PodSelector: &metav1.LabelSelector{MatchLabels: pods}},
+		{
+			PodSelector:       &metav1.LabelSelector{MatchLabels: pods},
+			NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{environment.LabelSourceNamespace: namespace}},
+		},
+	}
+}
+
+// Peers converts the peers admitted by an ingress policy. A peer without namespace labels admits pods
+// of the namespace of the policy only, one without pod labels every pod of the matching namespaces.
+func Peers(policy *scorev1b1.IngressPolicySpec) []networkingv1.NetworkPolicyPeer {
+	if policy == nil {
+		return nil
+	}
+	peers := make([]networkingv1.NetworkPolicyPeer, 0, len(policy.AllowFrom))
+	for _, allowed := range policy.AllowFrom {
+		peer := networkingv1.NetworkPolicyPeer{}
+		if len(allowed.PodLabels) > 0 || len(allowed.NamespaceLabels) == 0 {
+			peer.PodSelector = &metav1.LabelSelector{MatchLabels: allowed.PodLabels}
+		}
+		if len(allowed.NamespaceLabels) > 0 {
+			peer.NamespaceSelector = &metav1.LabelSelector{MatchLabels: allowed.NamespaceLabels}
+		}
+		peers = append(peers, peer)
+	}
+	return peers
+}
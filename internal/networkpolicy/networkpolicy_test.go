@@ -0,0 +1,84 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package networkpolicy
+
+import (
+	"reflect"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestPeers(t *testing.T) {
+	pods := map[string]string{"app": "prometheus"}
+	namespaces := map[string]string{"team": "monitoring"}
+
+	tests := []struct {
+		name   string
+		policy *scorev1b1.IngressPolicySpec
+		want   []networkingv1.NetworkPolicyPeer
+	}{
+		{name: "no policy"},
+		{
+			name:   "pods of the namespace of the policy",
+			policy: &scorev1b1.IngressPolicySpec{AllowFrom: []scorev1b1.NetworkPolicyPeerSpec{{PodLabels: pods}}},
+			want:   []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{MatchLabels: pods}}},
+		},
+		{
+			name:   "all pods of other namespaces",
+			policy: &scorev1b1.IngressPolicySpec{AllowFrom: []scorev1b1.NetworkPolicyPeerSpec{{NamespaceLabels: namespaces}}},
+			want:   []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{MatchLabels: namespaces}}},
+		},
+		{
+			name:   "pods of other namespaces",
+			policy: &scorev1b1.IngressPolicySpec{AllowFrom: []scorev1b1.NetworkPolicyPeerSpec{{PodLabels: pods, NamespaceLabels: namespaces}}},
+			want: []networkingv1.NetworkPolicyPeer{{
+				PodSelector:       &metav1.LabelSelector{MatchLabels: pods},
+				NamespaceSelector: &metav1.LabelSelector{MatchLabels: namespaces},
+			}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Peers(tt.policy); len(got) != len(tt.want) || (len(got) > 0 && !reflect.DeepEqual(got, tt.want)) {
+				t.Errorf("Peers() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkloadPeers(t *testing.T) {
+	peers := WorkloadPeers("web", "team-a")
+	if len(peers) != 2 {
+		t.Fatalf("WorkloadPeers() = %+v, want the Workload pods of its own and its environment namespaces", peers)
+	}
+	for _, peer := range peers {
+		if peer.PodSelector == nil || peer.PodSelector.MatchLabels[LabelWorkload] != "web" {
+			t.Errorf("peer %+v does not select the pods of the Workload", peer)
+		}
+	}
+	if peers[0].NamespaceSelector != nil {
+		t.Errorf("first peer %+v should select the namespace of the policy", peers[0])
+	}
+	if selector := peers[1].NamespaceSelector; selector == nil || selector.MatchLabels["score.dev/source-namespace"] != "team-a" {
+		t.Errorf("second peer %+v should select the environment namespaces of team-a", peers[1])
+	}
+}
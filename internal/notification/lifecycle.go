@@ -0,0 +1,173 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+	"github.com/cappyzawa/score-orchestrator/internal/events"
+)
+
+// progressReasons are the Ready reasons of Workloads that are on their way to become ready; they are not
+// reported as degraded
+var progressReasons = map[string]bool{
+	conditions.ReasonClaimPending:        true,
+	conditions.ReasonClaiming:            true,
+	conditions.ReasonRuntimeSelecting:    true,
+	conditions.ReasonRuntimeProvisioning: true,
+	conditions.ReasonDryRun:              true,
+	conditions.ReasonBlocked:             true,
+}
+
+// ForWorkload returns the notification about the Ready condition of the workload and the state it reports.
+// notify is false for states that are not notified, such as provisioning progress, which must be tracked
+// nonetheless. The state is empty while the workload has no Ready condition.
+func ForWorkload(workload *scorev1b1.Workload) (notification Notification, state string, notify bool) {
+	ready := conditions.GetCondition(workload.Status.Conditions, conditions.ConditionReady)
+	if ready == nil {
+		return Notification{}, "", false
+	}
+	state = string(ready.Status) + "/" + ready.Reason
+
+	notification = Notification{
+		Type:          scorev1b1.NotificationWorkloadDegraded,
+		Severity:      scorev1b1.NotificationSeverityWarning,
+		Kind:          "Workload",
+		Namespace:     workload.Namespace,
+		Name:          workload.Name,
+		Workload:      workload.Name,
+		Reason:        ready.Reason,
+		Message:       ready.Message,
+		CorrelationID: events.CorrelationID(workload),
+		Time:          ready.LastTransitionTime.Time,
+	}
+	switch {
+	case ready.Status == metav1.ConditionTrue:
+		notification.Type, notification.Severity = scorev1b1.NotificationWorkloadReady, scorev1b1.NotificationSeverityInfo
+		for _, endpoint := range workload.Status.Endpoints {
+			notification.Endpoints = append(notification.Endpoints, endpoint.URL)
+		}
+	case progressReasons[ready.Reason]:
+		return notification, state, false
+	}
+	notification.ID = newID(notification.Kind, workload.Namespace, workload.Name, notification.Type, stateAt(state, notification.Time))
+	return notification, state, true
+}
+
+// ForClaim returns the notification about the phase of the claim and the state it reports. Only failed
+// claims are notified.
+func ForClaim(claim *scorev1b1.ResourceClaim) (notification Notification, state string, notify bool) {
+	state = string(claim.Status.Phase) + "/" + claim.Status.Reason
+	changedAt := claim.CreationTimestamp.Time
+	if claim.Status.LastTransitionTime != nil {
+		changedAt = claim.Status.LastTransitionTime.Time
+	}
+
+	notification = Notification{
+		Type:          scorev1b1.NotificationClaimFailed,
+		Severity:      scorev1b1.NotificationSeverityWarning,
+		Kind:          "ResourceClaim",
+		Namespace:     claim.Namespace,
+		Name:          claim.Name,
+		Workload:      claim.Spec.WorkloadRef.Name,
+		Reason:        claim.Status.Reason,
+		Message:       claim.Status.Message,
+		CorrelationID: events.CorrelationID(claim),
+		Time:          changedAt,
+	}
+	if claim.Status.Phase != scorev1b1.ResourceClaimPhaseFailed {
+		return notification, state, false
+	}
+	notification.ID = newID(notification.Kind, claim.Namespace, claim.Name, notification.Type, stateAt(state, changedAt))
+	return notification, state, true
+}
+
+// ForExposure returns the notification about the endpoints the exposure publishes and the state it reports.
+// Only exposures that publish at least one endpoint are notified.
+func ForExposure(exposure *scorev1b1.WorkloadExposure) (notification Notification, state string, notify bool) {
+	var urls []string
+	for _, entry := range exposure.Status.Exposures {
+		if entry.URL != "" {
+			urls = append(urls, entry.URL)
+		}
+	}
+	state = strings.Join(urls, " ")
+
+	notification = Notification{
+		Type:          scorev1b1.NotificationExposurePublished,
+		Severity:      scorev1b1.NotificationSeverityInfo,
+		Kind:          "WorkloadExposure",
+		Namespace:     exposure.Namespace,
+		Name:          exposure.Name,
+		Workload:      exposure.Spec.WorkloadRef.Name,
+		Endpoints:     urls,
+		CorrelationID: events.CorrelationID(exposure),
+		// Exposures carry no transition time; a newly created exposure publishes its first endpoints
+		Time: exposure.CreationTimestamp.Time,
+	}
+	if len(urls) == 0 {
+		return notification, state, false
+	}
+	notification.ID = newID(notification.Kind, exposure.Namespace, exposure.Name, notification.Type, state)
+	return notification, state, true
+}
+
+// stateAt qualifies a state with the time it was entered, so that re-entering a state gets a new ID
+func stateAt(state string, at time.Time) string {
+	return state + "@" + at.UTC().Format(time.RFC3339)
+}
+
+// Tracker remembers the last observed state of objects, so that each state change is notified once.
+// A state observed for an object for the first time counts as a change only when it was entered after
+// the tracker was created, so that restarting the Orchestrator does not repeat earlier notifications.
+type Tracker struct {
+	since time.Time
+
+	mu     sync.Mutex
+	states map[string]string
+}
+
+// NewTracker creates a Tracker that considers states entered after since as new
+func NewTracker(since time.Time) *Tracker {
+	return &Tracker{since: since, states: make(map[string]string)}
+}
+
+// Changed records the state of the object identified by key and reports whether it changed
+func (t *Tracker) Changed(key, state string, enteredAt time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	previous, seen := t.states[key]
+	t.states[key] = state
+	if !seen {
+		return enteredAt.After(t.since)
+	}
+	return previous != state
+}
+
+// Forget drops the state of a deleted object
+func (t *Tracker) Forget(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.states, key)
+}
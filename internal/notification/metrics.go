@@ -0,0 +1,35 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// SentTotal counts notifications posted to sinks, by outcome
+var SentTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "score_orchestrator_notifications_total",
+		Help: "Number of lifecycle notifications posted to notification sinks",
+	},
+	[]string{"sink", "type", "result"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(SentTotal)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package notification posts structured lifecycle notifications, such as a Workload becoming ready or a
+// ResourceClaim failing, to the sinks configured in the OrchestratorConfig. Each sink receives the
+// notifications matching its filters, formatted as a generic JSON webhook, a Slack message or a CloudEvent.
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/config"
+)
+
+// URLSecretKey is the key of the Secret referenced by urlSecretRef that holds the sink URL
+const URLSecretKey = "url"
+
+// defaultTimeout bounds the delivery of a notification to one sink
+const defaultTimeout = 10 * time.Second
+
+// Notification is a lifecycle notification about an object orchestrated for a Workload
+type Notification struct {
+	// ID identifies the state change the notification reports; receivers can use it to drop duplicates
+	ID string `json:"id"`
+	// Type is the notification type, e.g. "WorkloadReady"
+	Type string `json:"type"`
+	// Severity is "Info" or "Warning"
+	Severity string `json:"severity"`
+	// Kind is the kind of the object, e.g. "Workload" or "ResourceClaim"
+	Kind string `json:"kind"`
+	// Namespace and Name identify the object
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Workload is the name of the Workload the object belongs to
+	Workload string `json:"workload"`
+	// Reason and Message describe the state, from the abstract reason vocabulary
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Endpoints are the URLs the Workload is reachable through
+	Endpoints []string `json:"endpoints,omitempty"`
+	// CorrelationID is the correlation ID of the Workload
+	CorrelationID string `json:"correlationId,omitempty"`
+	// Time is when the state change happened
+	Time time.Time `json:"time"`
+}
+
+// newID derives the ID of a notification from the object and the state it reports
+func newID(kind, namespace, name, notificationType, state string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{kind, namespace, name, notificationType, state}, "/")))
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// Notifier delivers notifications to the sinks of the current OrchestratorConfig
+type Notifier struct {
+	// Client reads the Secrets holding sink URLs
+	Client client.Reader
+	// ConfigLoader loads the OrchestratorConfig defining the sinks
+	ConfigLoader config.ConfigLoader
+	// HTTPClient posts the notifications; a client with a 10s timeout is used when nil
+	HTTPClient *http.Client
+}
+
+// Notify delivers the notification to every sink whose filters match it. Delivery is attempted once per sink;
+// the failures of all sinks are returned joined.
+func (n *Notifier) Notify(ctx context.Context, notification Notification) error {
+	orchestratorConfig, err := n.ConfigLoader.LoadConfig(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load orchestrator config: %w", err)
+	}
+
+	var errs []error
+	for i := range orchestratorConfig.Spec.Notifications {
+		sink := &orchestratorConfig.Spec.Notifications[i]
+		if !Matches(sink, notification) {
+			continue
+		}
+		err := n.send(ctx, sink, notification)
+		result := "success"
+		if err != nil {
+			result = "error"
+			errs = append(errs, fmt.Errorf("sink %s: %w", sink.Name, err))
+		}
+		SentTotal.WithLabelValues(sink.Name, notification.Type, result).Inc()
+	}
+	return errors.Join(errs...)
+}
+
+// Matches reports whether the filters of the sink select the notification
+func Matches(sink *scorev1b1.NotificationSinkSpec, notification Notification) bool {
+	if len(sink.Events) > 0 && !slices.Contains(sink.Events, notification.Type) {
+		return false
+	}
+	if len(sink.Namespaces) > 0 && !slices.Contains(sink.Namespaces, notification.Namespace) {
+		return false
+	}
+	return sink.MinSeverity != scorev1b1.NotificationSeverityWarning ||
+		notification.Severity == scorev1b1.NotificationSeverityWarning
+}
+
+// send posts the notification to the sink in the payload format of its type
+func (n *Notifier) send(ctx context.Context, sink *scorev1b1.NotificationSinkSpec, notification Notification) error {
+	url, err := n.sinkURL(ctx, sink)
+	if err != nil {
+		return err
+	}
+	contentType, body, err := encode(sink.Type, notification)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+
+	httpClient := n.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: defaultTimeout}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to post notification: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("sink responded with status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	}
+	return nil
+}
+
+// sinkURL returns the URL of the sink, reading it from its Secret when it is not configured inline
+func (n *Notifier) sinkURL(ctx context.Context, sink *scorev1b1.NotificationSinkSpec) (string, error) {
+	ref := sink.URLSecretRef
+	if ref == nil {
+		return sink.URL, nil
+	}
+	secret := &corev1.Secret{}
+	if err := n.Client.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return "", fmt.Errorf("failed to get URL secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	url := strings.TrimSpace(string(secret.Data[URLSecretKey]))
+	if url == "" {
+		return "", fmt.Errorf("secret %s/%s has no key %s", ref.Namespace, ref.Name, URLSecretKey)
+	}
+	return url, nil
+}
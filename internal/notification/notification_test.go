@@ -0,0 +1,248 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+	"github.com/cappyzawa/score-orchestrator/internal/config"
+)
+
+func TestMatches(t *testing.T) {
+	degraded := Notification{Type: scorev1b1.NotificationWorkloadDegraded, Severity: scorev1b1.NotificationSeverityWarning, Namespace: "team-a"}
+	ready := Notification{Type: scorev1b1.NotificationWorkloadReady, Severity: scorev1b1.NotificationSeverityInfo, Namespace: "team-a"}
+
+	tests := []struct {
+		name         string
+		sink         scorev1b1.NotificationSinkSpec
+		notification Notification
+		want         bool
+	}{
+		{name: "no filters", notification: ready, want: true},
+		{name: "event selected", sink: scorev1b1.NotificationSinkSpec{Events: []string{scorev1b1.NotificationWorkloadReady}}, notification: ready, want: true},
+		{name: "event not selected", sink: scorev1b1.NotificationSinkSpec{Events: []string{scorev1b1.NotificationClaimFailed}}, notification: ready},
+		{name: "namespace selected", sink: scorev1b1.NotificationSinkSpec{Namespaces: []string{"team-a"}}, notification: ready, want: true},
+		{name: "namespace not selected", sink: scorev1b1.NotificationSinkSpec{Namespaces: []string{"team-b"}}, notification: ready},
+		{name: "warning above info", sink: scorev1b1.NotificationSinkSpec{MinSeverity: scorev1b1.NotificationSeverityInfo}, notification: degraded, want: true},
+		{name: "info below warning", sink: scorev1b1.NotificationSinkSpec{MinSeverity: scorev1b1.NotificationSeverityWarning}, notification: ready},
+		{name: "warning at warning", sink: scorev1b1.NotificationSinkSpec{MinSeverity: scorev1b1.NotificationSeverityWarning}, notification: degraded, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Matches(&tt.sink, tt.notification); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEncode(t *testing.T) {
+	notification := Notification{
+		ID: "abc", Type: scorev1b1.NotificationClaimFailed, Severity: scorev1b1.NotificationSeverityWarning,
+		Kind: "ResourceClaim", Namespace: "team-a", Name: "app-db", Workload: "app",
+		Reason: conditions.ReasonClaimFailed, Message: "database quota exceeded",
+		Time: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	tests := []struct {
+		sinkType        string
+		wantContentType string
+		wantFields      map[string]interface{}
+	}{
+		{
+			sinkType:        scorev1b1.NotificationSinkWebhook,
+			wantContentType: "application/json",
+			wantFields:      map[string]interface{}{"id": "abc", "type": "ClaimFailed", "workload": "app"},
+		},
+		{
+			sinkType:        scorev1b1.NotificationSinkSlack,
+			wantContentType: "application/json",
+			wantFields: map[string]interface{}{
+				"text": ":warning: *ClaimFailed*: ResourceClaim `team-a/app-db` of Workload `app`\nClaimFailed: database quota exceeded",
+			},
+		},
+		{
+			sinkType:        scorev1b1.NotificationSinkCloudEvents,
+			wantContentType: "application/cloudevents+json",
+			wantFields: map[string]interface{}{
+				"specversion": "1.0", "id": "abc", "source": "score-orchestrator",
+				"type": "dev.score.orchestrator.ClaimFailed", "subject": "team-a/app-db", "time": "2025-01-02T03:04:05Z",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sinkType, func(t *testing.T) {
+			contentType, body, err := encode(tt.sinkType, notification)
+			if err != nil {
+				t.Fatalf("encode() error = %v", err)
+			}
+			if contentType != tt.wantContentType {
+				t.Errorf("content type = %q, want %q", contentType, tt.wantContentType)
+			}
+			var payload map[string]interface{}
+			if err := json.Unmarshal(body, &payload); err != nil {
+				t.Fatalf("failed to unmarshal payload: %v", err)
+			}
+			for key, want := range tt.wantFields {
+				if payload[key] != want {
+					t.Errorf("payload[%q] = %v, want %v", key, payload[key], want)
+				}
+			}
+		})
+	}
+
+	if _, _, err := encode("Email", notification); err == nil {
+		t.Error("encode() with an unsupported sink type succeeded")
+	}
+}
+
+func TestNotify(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		received = map[string][]string{}
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		received[r.URL.Path] = append(received[r.URL.Path], string(body))
+		mu.Unlock()
+		if r.URL.Path == "/broken" {
+			w.WriteHeader(http.StatusBadGateway)
+		}
+	}))
+	defer server.Close()
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "score-system", Name: "slack"},
+		Data:       map[string][]byte{URLSecretKey: []byte(server.URL + "/slack\n")},
+	}
+	loader := config.NewMockLoader()
+	loader.SetConfig(&scorev1b1.OrchestratorConfig{Spec: scorev1b1.OrchestratorConfigSpec{
+		Notifications: []scorev1b1.NotificationSinkSpec{
+			{Name: "audit", URL: server.URL + "/audit"},
+			{Name: "slack", Type: scorev1b1.NotificationSinkSlack, URLSecretRef: &scorev1b1.NamespacedName{Namespace: "score-system", Name: "slack"}},
+			{Name: "team-b", URL: server.URL + "/team-b", Namespaces: []string{"team-b"}},
+			{Name: "broken", URL: server.URL + "/broken"},
+		},
+	}})
+	notifier := &Notifier{
+		Client:       fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build(),
+		ConfigLoader: loader,
+	}
+
+	err := notifier.Notify(context.Background(), Notification{
+		Type: scorev1b1.NotificationWorkloadReady, Severity: scorev1b1.NotificationSeverityInfo,
+		Kind: "Workload", Namespace: "team-a", Name: "app", Workload: "app",
+	})
+	if err == nil || !strings.Contains(err.Error(), "sink broken") {
+		t.Errorf("Notify() error = %v, want the failure of sink broken", err)
+	}
+
+	for _, path := range []string{"/audit", "/slack", "/broken"} {
+		if len(received[path]) != 1 {
+			t.Errorf("%s received %d notifications, want 1", path, len(received[path]))
+		}
+	}
+	if len(received["/team-b"]) != 0 {
+		t.Errorf("/team-b received notifications outside its namespaces: %v", received["/team-b"])
+	}
+	if !strings.Contains(received["/slack"][0], `"text"`) {
+		t.Errorf("/slack received %s, want a Slack message", received["/slack"][0])
+	}
+}
+
+func TestForWorkload(t *testing.T) {
+	workload := func(status metav1.ConditionStatus, reason string) *scorev1b1.Workload {
+		return &scorev1b1.Workload{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "team-a", Name: "app"},
+			Status: scorev1b1.WorkloadStatus{
+				Conditions: []metav1.Condition{{Type: conditions.ConditionReady, Status: status, Reason: reason}},
+				Endpoints:  []scorev1b1.WorkloadEndpoint{{URL: "https://app.example.com"}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name       string
+		workload   *scorev1b1.Workload
+		wantType   string
+		wantNotify bool
+	}{
+		{name: "ready", workload: workload(metav1.ConditionTrue, conditions.ReasonSucceeded), wantType: scorev1b1.NotificationWorkloadReady, wantNotify: true},
+		{name: "degraded", workload: workload(metav1.ConditionFalse, conditions.ReasonRuntimeDegraded), wantType: scorev1b1.NotificationWorkloadDegraded, wantNotify: true},
+		{name: "provisioning", workload: workload(metav1.ConditionFalse, conditions.ReasonRuntimeProvisioning)},
+		{name: "no ready condition", workload: &scorev1b1.Workload{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, _, notify := ForWorkload(tt.workload)
+			if notify != tt.wantNotify {
+				t.Fatalf("ForWorkload() notify = %v, want %v", notify, tt.wantNotify)
+			}
+			if notify && n.Type != tt.wantType {
+				t.Errorf("ForWorkload() type = %q, want %q", n.Type, tt.wantType)
+			}
+			if notify && n.ID == "" {
+				t.Error("ForWorkload() returned a notification without ID")
+			}
+		})
+	}
+}
+
+func TestTracker(t *testing.T) {
+	start := time.Now()
+	tracker := NewTracker(start)
+
+	if tracker.Changed("Workload/team-a/app", "True/Succeeded", start.Add(-time.Hour)) {
+		t.Error("state entered before the tracker was created was reported as changed")
+	}
+	if tracker.Changed("Workload/team-a/app", "True/Succeeded", start.Add(-time.Hour)) {
+		t.Error("unchanged state was reported as changed")
+	}
+	if !tracker.Changed("Workload/team-a/app", "False/RuntimeDegraded", start.Add(time.Minute)) {
+		t.Error("state change was not reported")
+	}
+	if !tracker.Changed("Workload/team-a/new", "True/Succeeded", start.Add(time.Minute)) {
+		t.Error("state of a new object entered after the tracker was created was not reported")
+	}
+
+	tracker.Forget("Workload/team-a/app")
+	if tracker.Changed("Workload/team-a/app", "False/RuntimeDegraded", start.Add(-time.Hour)) {
+		t.Error("forgotten object was reported with an old state")
+	}
+}
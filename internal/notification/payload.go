@@ -0,0 +1,102 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package notification
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// cloudEventSource is the source attribute of the CloudEvents the Orchestrator emits
+const cloudEventSource = "score-orchestrator"
+
+// cloudEventTypePrefix prefixes the notification type in the type attribute of CloudEvents
+const cloudEventTypePrefix = "dev.score.orchestrator."
+
+// cloudEvent is a CloudEvent in the JSON event format (structured content mode)
+type cloudEvent struct {
+	SpecVersion     string       `json:"specversion"`
+	ID              string       `json:"id"`
+	Source          string       `json:"source"`
+	Type            string       `json:"type"`
+	Subject         string       `json:"subject"`
+	Time            time.Time    `json:"time"`
+	DataContentType string       `json:"datacontenttype"`
+	Data            Notification `json:"data"`
+}
+
+// slackMessage is the payload of a Slack incoming webhook
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// encode returns the content type and body posted to a sink of the given type
+func encode(sinkType string, notification Notification) (string, []byte, error) {
+	var (
+		contentType = "application/json"
+		payload     interface{}
+	)
+	switch sinkType {
+	case "", scorev1b1.NotificationSinkWebhook:
+		payload = notification
+	case scorev1b1.NotificationSinkSlack:
+		payload = slackMessage{Text: slackText(notification)}
+	case scorev1b1.NotificationSinkCloudEvents:
+		contentType = "application/cloudevents+json"
+		payload = cloudEvent{
+			SpecVersion:     "1.0",
+			ID:              notification.ID,
+			Source:          cloudEventSource,
+			Type:            cloudEventTypePrefix + notification.Type,
+			Subject:         notification.Namespace + "/" + notification.Name,
+			Time:            notification.Time,
+			DataContentType: "application/json",
+			Data:            notification,
+		}
+	default:
+		return "", nil, fmt.Errorf("unsupported sink type %q", sinkType)
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal notification: %w", err)
+	}
+	return contentType, body, nil
+}
+
+// slackText renders the notification as a single Slack message
+func slackText(notification Notification) string {
+	var b strings.Builder
+	if notification.Severity == scorev1b1.NotificationSeverityWarning {
+		b.WriteString(":warning: ")
+	}
+	fmt.Fprintf(&b, "*%s*: %s `%s/%s`", notification.Type, notification.Kind, notification.Namespace, notification.Name)
+	if notification.Kind != "Workload" && notification.Workload != "" {
+		fmt.Fprintf(&b, " of Workload `%s`", notification.Workload)
+	}
+	if notification.Reason != "" {
+		fmt.Fprintf(&b, "\n%s: %s", notification.Reason, notification.Message)
+	}
+	for _, endpoint := range notification.Endpoints {
+		fmt.Fprintf(&b, "\n%s", endpoint)
+	}
+	return b.String()
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package planvalues externalizes the resolved values of WorkloadPlans that are too large to be embedded in
+// the plan. The Orchestrator stores such values in an immutable ConfigMap named after their hash and references
+// it from WorkloadPlan.spec.resolvedValuesRef; runtimes load the values back and verify the hash before use.
+package planvalues
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// DataKey is the ConfigMap key the resolved values are stored under
+const DataKey = "values.json"
+
+// DefaultMaxInlineSize is the largest resolved values payload embedded in a plan when none is configured
+const DefaultMaxInlineSize = 256 * 1024
+
+// ErrIntegrity indicates that externalized values are missing or do not match the hash recorded in the plan
+var ErrIntegrity = errors.New("resolved values do not match their reference")
+
+// MaxInlineSize returns the largest resolved values payload embedded in a plan under the given configuration
+func MaxInlineSize(spec *scorev1b1.PlanValuesSpec) (int64, error) {
+	if spec == nil || spec.MaxInlineSize == "" {
+		return DefaultMaxInlineSize, nil
+	}
+	quantity, err := resource.ParseQuantity(spec.MaxInlineSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid planValues.maxInlineSize %q: %w", spec.MaxInlineSize, err)
+	}
+	return quantity.Value(), nil
+}
+
+// Hash returns the hex-encoded SHA-256 digest of the values
+func Hash(raw []byte) string {
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])
+}
+
+// ConfigMapName returns the name of the ConfigMap holding the values with the given hash for a plan
+func ConfigMapName(plan, hash string) string {
+	return fmt.Sprintf("%s-values-%s", plan, hash[:10])
+}
+
+// Load returns the resolved values of the plan, reading them from the referenced ConfigMap when they are
+// externalized. It returns an error wrapping ErrIntegrity when the ConfigMap does not hold the referenced values.
+func Load(ctx context.Context, c client.Reader, plan *scorev1b1.WorkloadPlan) (*runtime.RawExtension, error) {
+	ref := plan.Spec.ResolvedValuesRef
+	if ref == nil {
+		return plan.Spec.ResolvedValues, nil
+	}
+
+	configMap := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: plan.Namespace, Name: ref.Name}, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get resolved values %s: %w", ref.Name, err)
+	}
+	data, ok := configMap.Data[DataKey]
+	if !ok {
+		return nil, fmt.Errorf("%w: ConfigMap %s has no key %s", ErrIntegrity, ref.Name, DataKey)
+	}
+	if hash := Hash([]byte(data)); hash != ref.SHA256 {
+		return nil, fmt.Errorf("%w: ConfigMap %s has hash %s, want %s", ErrIntegrity, ref.Name, hash, ref.SHA256)
+	}
+	return &runtime.RawExtension{Raw: []byte(data)}, nil
+}
+
+// Inline replaces the values reference of the plan with the values it references, so that the plan can be
+// materialized like a plan with embedded values. The plan must not be written back afterwards.
+func Inline(ctx context.Context, c client.Reader, plan *scorev1b1.WorkloadPlan) error {
+	if plan.Spec.ResolvedValuesRef == nil {
+		return nil
+	}
+	values, err := Load(ctx, c, plan)
+	if err != nil {
+		return err
+	}
+	plan.Spec.ResolvedValues = values
+	plan.Spec.ResolvedValuesRef = nil
+	return nil
+}
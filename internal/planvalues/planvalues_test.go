@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package planvalues
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestMaxInlineSize(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    *scorev1b1.PlanValuesSpec
+		want    int64
+		wantErr bool
+	}{
+		{name: "default", want: DefaultMaxInlineSize},
+		{name: "empty", spec: &scorev1b1.PlanValuesSpec{}, want: DefaultMaxInlineSize},
+		{name: "quantity", spec: &scorev1b1.PlanValuesSpec{MaxInlineSize: "1Mi"}, want: 1 << 20},
+		{name: "invalid", spec: &scorev1b1.PlanValuesSpec{MaxInlineSize: "large"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MaxInlineSize(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MaxInlineSize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("MaxInlineSize() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestInline(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	values := `{"containers":{"app":{"env":{"A":"1"}}}}`
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-values-0123456789", Namespace: "default"},
+		Data:       map[string]string{DataKey: values},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(configMap).Build()
+
+	newPlan := func(hash string) *scorev1b1.WorkloadPlan {
+		return &scorev1b1.WorkloadPlan{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec: scorev1b1.WorkloadPlanSpec{
+				ResolvedValuesRef: &scorev1b1.ValuesReference{Name: configMap.Name, SHA256: hash},
+			},
+		}
+	}
+
+	plan := newPlan(Hash([]byte(values)))
+	if err := Inline(ctx, c, plan); err != nil {
+		t.Fatal(err)
+	}
+	if plan.Spec.ResolvedValuesRef != nil || plan.Spec.ResolvedValues == nil || string(plan.Spec.ResolvedValues.Raw) != values {
+		t.Errorf("Inline() left spec %+v, want the referenced values embedded", plan.Spec)
+	}
+
+	// Values that do not match the recorded hash are never used
+	plan = newPlan(Hash([]byte(`{}`)))
+	if err := Inline(ctx, c, plan); !errors.Is(err, ErrIntegrity) {
+		t.Errorf("Inline() error = %v, want ErrIntegrity", err)
+	}
+	if plan.Spec.ResolvedValues != nil {
+		t.Errorf("Inline() embedded values that do not match their hash")
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/lru"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/selection"
+)
+
+// ErrPolicyDenied indicates that a Workload violates a policy in Deny mode
+var ErrPolicyDenied = errors.New("denied by policy")
+
+const (
+	// costLimit bounds the evaluation cost of a single policy expression
+	costLimit = 1_000_000
+
+	// maxPrograms bounds the number of compiled programs kept; expressions edited out of the
+	// OrchestratorConfig are evicted once newer ones take their place
+	maxPrograms = 256
+)
+
+// Input is the data policies are evaluated against
+type Input struct {
+	// Workload is the Workload being evaluated
+	Workload *scorev1b1.Workload
+	// Backend is the selected backend; it is only known at the Plan stage
+	Backend *selection.SelectedBackend
+}
+
+// Violation is a policy the Workload does not comply with
+type Violation struct {
+	// Policy is the name of the policy
+	Policy string
+	// Message explains the violation
+	Message string
+}
+
+func (v Violation) String() string {
+	return fmt.Sprintf("%s: %s", v.Policy, v.Message)
+}
+
+// Denial lists the Deny policies a Workload violates
+type Denial struct {
+	Violations []Violation
+}
+
+func (d *Denial) Error() string {
+	return fmt.Sprintf("%v: %s", ErrPolicyDenied, d.Details())
+}
+
+// Details lists the violated policies with their messages
+func (d *Denial) Details() string {
+	return joinViolations(d.Violations)
+}
+
+func (d *Denial) Unwrap() error {
+	return ErrPolicyDenied
+}
+
+var (
+	// env declares the variables available to policy expressions
+	env = sync.OnceValues(func() (*cel.Env, error) {
+		return cel.NewEnv(
+			cel.Variable("workload", cel.MapType(cel.StringType, cel.DynType)),
+			cel.Variable("profile", cel.StringType),
+			cel.Variable("backend", cel.MapType(cel.StringType, cel.StringType)),
+			ext.Strings(),
+		)
+	})
+
+	// programs caches compiled programs by expression
+	programs = lru.New(maxPrograms)
+)
+
+// Compile type-checks a policy expression, which must evaluate to a boolean
+func Compile(expression string) (cel.Program, error) {
+	if cached, ok := programs.Get(expression); ok {
+		return cached.(cel.Program), nil
+	}
+
+	celEnv, err := env()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+	ast, issues := celEnv.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("expression must evaluate to bool, got %s", ast.OutputType())
+	}
+	program, err := celEnv.Program(ast, cel.CostLimit(costLimit))
+	if err != nil {
+		return nil, err
+	}
+
+	programs.Add(expression, program)
+	return program, nil
+}
+
+// Evaluate evaluates the policies of the given stage in order. It returns the violations of Warn policies,
+// and a *Denial listing the violations of Deny policies, if any. A policy whose expression cannot be
+// evaluated (e.g., it reads a field the Workload does not set without has()) counts as violated.
+func Evaluate(policies []scorev1b1.PolicySpec, stage string, input Input) ([]Violation, error) {
+	var activation map[string]any
+	var warnings, denials []Violation
+
+	for _, policy := range policies {
+		if policyStage(policy) != stage {
+			continue
+		}
+		if activation == nil {
+			var err error
+			if activation, err = newActivation(input); err != nil {
+				return nil, err
+			}
+		}
+
+		compliant, err := evaluate(policy.Expression, activation)
+		if compliant {
+			continue
+		}
+		message := policy.Message
+		if message == "" {
+			message = fmt.Sprintf("expression %q is not satisfied", policy.Expression)
+		}
+		if err != nil {
+			message = fmt.Sprintf("evaluation failed: %v", err)
+		}
+
+		violation := Violation{Policy: policy.Name, Message: message}
+		if policy.Mode == scorev1b1.PolicyModeWarn {
+			warnings = append(warnings, violation)
+		} else {
+			denials = append(denials, violation)
+		}
+	}
+
+	if len(denials) > 0 {
+		return warnings, &Denial{Violations: denials}
+	}
+	return warnings, nil
+}
+
+// FormatWarnings renders the violations of Warn policies for an event message
+func FormatWarnings(warnings []Violation) string {
+	return "policy warning: " + joinViolations(warnings)
+}
+
+// evaluate runs a policy expression and reports whether the Workload complies
+func evaluate(expression string, activation map[string]any) (bool, error) {
+	program, err := Compile(expression)
+	if err != nil {
+		return false, err
+	}
+	result, _, err := program.Eval(activation)
+	if err != nil {
+		return false, err
+	}
+	compliant, ok := result.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("expression evaluated to %v, want bool", result.Value())
+	}
+	return compliant, nil
+}
+
+// newActivation binds the policy variables to the input
+func newActivation(input Input) (map[string]any, error) {
+	workload, err := runtime.DefaultUnstructuredConverter.ToUnstructured(input.Workload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert workload: %w", err)
+	}
+	// Status is owned by the Orchestrator and changes while policies are evaluated
+	delete(workload, "status")
+
+	profile := ""
+	backend := map[string]string{}
+	if selected := input.Backend; selected != nil {
+		profile = selected.Profile
+		backend = map[string]string{
+			"backendId":    selected.BackendID,
+			"runtimeClass": selected.RuntimeClass,
+			"version":      selected.Version,
+			"kind":         selected.Kind,
+		}
+	}
+
+	return map[string]any{"workload": workload, "profile": profile, "backend": backend}, nil
+}
+
+// policyStage returns the stage of the policy, defaulting to Admission
+func policyStage(policy scorev1b1.PolicySpec) string {
+	if policy.Stage == "" {
+		return scorev1b1.PolicyStageAdmission
+	}
+	return policy.Stage
+}
+
+func joinViolations(violations []Violation) string {
+	messages := make([]string, 0, len(violations))
+	for _, violation := range violations {
+		messages = append(messages, violation.String())
+	}
+	return strings.Join(messages, "; ")
+}
@@ -0,0 +1,145 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/selection"
+)
+
+func TestEvaluate(t *testing.T) {
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: map[string]string{"team": "web"}},
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"main": {Image: "nginx:latest"}},
+		},
+	}
+	backend := &selection.SelectedBackend{Profile: "prod", BackendID: "k8s-prod", RuntimeClass: "kubernetes"}
+
+	tests := []struct {
+		name         string
+		policies     []scorev1b1.PolicySpec
+		stage        string
+		backend      *selection.SelectedBackend
+		wantWarnings []Violation
+		wantDenied   []Violation
+	}{
+		{
+			name: "compliant workload",
+			policies: []scorev1b1.PolicySpec{
+				{Name: "team", Expression: `workload.metadata.labels.team == "web"`},
+			},
+			stage: scorev1b1.PolicyStageAdmission,
+		},
+		{
+			name: "deny and warn",
+			policies: []scorev1b1.PolicySpec{
+				{Name: "pinned", Expression: `workload.spec.containers.all(c, !workload.spec.containers[c].image.endsWith(":latest"))`, Message: "images must be pinned"},
+				{Name: "owner", Mode: scorev1b1.PolicyModeWarn, Expression: `"owner" in workload.metadata.labels`},
+			},
+			stage:        scorev1b1.PolicyStageAdmission,
+			wantWarnings: []Violation{{Policy: "owner", Message: `expression "\"owner\" in workload.metadata.labels" is not satisfied`}},
+			wantDenied:   []Violation{{Policy: "pinned", Message: "images must be pinned"}},
+		},
+		{
+			name: "policies of other stages are ignored",
+			policies: []scorev1b1.PolicySpec{
+				{Name: "never", Stage: scorev1b1.PolicyStagePlan, Expression: "false"},
+			},
+			stage: scorev1b1.PolicyStageAdmission,
+		},
+		{
+			name: "plan stage sees the selected backend",
+			policies: []scorev1b1.PolicySpec{
+				{Name: "prod-runtime", Stage: scorev1b1.PolicyStagePlan, Expression: `profile != "prod" || backend.runtimeClass == "ecs"`},
+			},
+			stage:      scorev1b1.PolicyStagePlan,
+			backend:    backend,
+			wantDenied: []Violation{{Policy: "prod-runtime", Message: `expression "profile != \"prod\" || backend.runtimeClass == \"ecs\"" is not satisfied`}},
+		},
+		{
+			name: "evaluation errors count as violations",
+			policies: []scorev1b1.PolicySpec{
+				{Name: "schedule", Expression: `workload.spec.schedule != ""`},
+			},
+			stage:      scorev1b1.PolicyStageAdmission,
+			wantDenied: []Violation{{Policy: "schedule", Message: "evaluation failed: no such key: schedule"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings, err := Evaluate(tt.policies, tt.stage, Input{Workload: workload, Backend: tt.backend})
+			if !reflect.DeepEqual(warnings, tt.wantWarnings) {
+				t.Errorf("Evaluate() warnings = %v, want %v", warnings, tt.wantWarnings)
+			}
+
+			var denial *Denial
+			if tt.wantDenied == nil {
+				if err != nil {
+					t.Errorf("Evaluate() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.As(err, &denial) || !errors.Is(err, ErrPolicyDenied) {
+				t.Fatalf("Evaluate() error = %v, want a *Denial", err)
+			}
+			if !reflect.DeepEqual(denial.Violations, tt.wantDenied) {
+				t.Errorf("Evaluate() denied = %v, want %v", denial.Violations, tt.wantDenied)
+			}
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       string
+	}{
+		{"undeclared variable", "claims.size() > 0", "undeclared reference"},
+		{"non-boolean result", "profile", "must evaluate to bool"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile(tt.expression)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Compile() error = %v, want an error containing %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCompileBoundsProgramCache(t *testing.T) {
+	for i := range maxPrograms + 10 {
+		if _, err := Compile(fmt.Sprintf("size(profile) > %d", i)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got := programs.Len(); got > maxPrograms {
+		t.Errorf("cached programs = %d, want at most %d", got, maxPrograms)
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package propagation selects the Workload labels and annotations allowed by the propagation policy of the
+// OrchestratorConfig and merges them into the metadata of generated resources. The Orchestrator records
+// the selection in WorkloadPlans and ResourceClaims; runtimes and provisioner strategies merge it with the
+// labels and annotations they own, which always take precedence.
+package propagation
+
+import (
+	"strings"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// Select returns the labels and annotations allowed by the policy, or nil when the policy selects none
+func Select(policy *scorev1b1.PropagationSpec, labels, annotations map[string]string) *scorev1b1.PropagatedMetadata {
+	if policy == nil {
+		return nil
+	}
+	selected := &scorev1b1.PropagatedMetadata{
+		Labels:      selectKeys(labels, policy.Labels),
+		Annotations: selectKeys(annotations, policy.Annotations),
+	}
+	if selected.Labels == nil && selected.Annotations == nil {
+		return nil
+	}
+	return selected
+}
+
+// Labels returns the owned labels merged with the propagated labels of metadata
+func Labels(owned map[string]string, metadata *scorev1b1.PropagatedMetadata) map[string]string {
+	if metadata == nil {
+		return owned
+	}
+	return merge(owned, metadata.Labels)
+}
+
+// Annotations returns the owned annotations merged with the propagated annotations of metadata
+func Annotations(owned map[string]string, metadata *scorev1b1.PropagatedMetadata) map[string]string {
+	if metadata == nil {
+		return owned
+	}
+	return merge(owned, metadata.Annotations)
+}
+
+// IsOwned reports whether the key belongs to the Orchestrator (score.dev/ or a subdomain of score.dev)
+func IsOwned(key string) bool {
+	prefix, _, found := strings.Cut(key, "/")
+	return found && (prefix == "score.dev" || strings.HasSuffix(prefix, ".score.dev"))
+}
+
+// selectKeys returns the entries whose key starts with one of the prefixes and is not owned
+func selectKeys(values map[string]string, prefixes []string) map[string]string {
+	var selected map[string]string
+	for key, value := range values {
+		if IsOwned(key) || !hasPrefix(key, prefixes) {
+			continue
+		}
+		if selected == nil {
+			selected = map[string]string{}
+		}
+		selected[key] = value
+	}
+	return selected
+}
+
+func hasPrefix(key string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if prefix != "" && strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// merge returns a copy of owned with the propagated entries that neither are owned keys nor override owned
+func merge(owned, propagated map[string]string) map[string]string {
+	if len(propagated) == 0 {
+		return owned
+	}
+	merged := make(map[string]string, len(owned)+len(propagated))
+	for key, value := range propagated {
+		if !IsOwned(key) {
+			merged[key] = value
+		}
+	}
+	for key, value := range owned {
+		merged[key] = value
+	}
+	return merged
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package propagation
+
+import (
+	"reflect"
+	"testing"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestSelect(t *testing.T) {
+	labels := map[string]string{
+		"cost-center":        "cc-42",
+		"team":               "payments",
+		"score.dev/workload": "web",
+	}
+	annotations := map[string]string{
+		"example.com/owner":        "payments@example.com",
+		"example.com.evil/owner":   "nobody",
+		"score.dev/correlation-id": "abc",
+	}
+
+	tests := []struct {
+		name   string
+		policy *scorev1b1.PropagationSpec
+		want   *scorev1b1.PropagatedMetadata
+	}{
+		{name: "no policy"},
+		{name: "no matching keys", policy: &scorev1b1.PropagationSpec{Labels: []string{"app"}}},
+		{
+			name:   "prefixes",
+			policy: &scorev1b1.PropagationSpec{Labels: []string{"cost-"}, Annotations: []string{"example.com/"}},
+			want: &scorev1b1.PropagatedMetadata{
+				Labels:      map[string]string{"cost-center": "cc-42"},
+				Annotations: map[string]string{"example.com/owner": "payments@example.com"},
+			},
+		},
+		{
+			name:   "owned keys are never selected",
+			policy: &scorev1b1.PropagationSpec{Labels: []string{"score.dev/", "team"}, Annotations: []string{"score."}},
+			want:   &scorev1b1.PropagatedMetadata{Labels: map[string]string{"team": "payments"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Select(tt.policy, labels, annotations); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Select() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLabels(t *testing.T) {
+	owned := map[string]string{"score.dev/workload": "web", "app.kubernetes.io/name": "web"}
+	metadata := &scorev1b1.PropagatedMetadata{Labels: map[string]string{
+		"cost-center":            "cc-42",
+		"app.kubernetes.io/name": "other",
+		"team.score.dev/owner":   "someone",
+	}}
+
+	want := map[string]string{"score.dev/workload": "web", "app.kubernetes.io/name": "web", "cost-center": "cc-42"}
+	if got := Labels(owned, metadata); !reflect.DeepEqual(got, want) {
+		t.Errorf("Labels() = %v, want %v", got, want)
+	}
+	if len(owned) != 2 {
+		t.Errorf("Labels() modified the owned labels: %v", owned)
+	}
+	if got := Labels(owned, nil); !reflect.DeepEqual(got, owned) {
+		t.Errorf("Labels() without metadata = %v, want %v", got, owned)
+	}
+}
@@ -0,0 +1,20 @@
+package provisioner
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// ProvisioningDuration observes how long claims take from the start of provisioning until they are bound
+var ProvisioningDuration = prometheus.NewHistogramVec(
+	prometheus.HistogramOpts{
+		Name:    "score_orchestrator_claim_provisioning_duration_seconds",
+		Help:    "Time from the start of provisioning until a ResourceClaim is bound, including retries",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 12),
+	},
+	[]string{"type", "strategy"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(ProvisioningDuration)
+}
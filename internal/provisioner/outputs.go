@@ -1,11 +1,17 @@
 package provisioner
 
 import (
+	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
 )
 
+// ErrPlaintextSecret indicates that claim outputs carry secret material outside of a Secret
+var ErrPlaintextSecret = errors.New("secret material in plaintext outputs")
+
 // OutputManager handles ResourceClaim output validation and management
 type OutputManager struct{}
 
@@ -15,7 +21,7 @@ func NewOutputManager() *OutputManager {
 }
 
 // ValidateOutputs validates that outputs comply with the CEL constraint:
-// at least one of secretRef, configMapRef, uri, image, or cert must be set
+// at least one of secretRef, externalSecretRef, configMapRef, uri, image, cert, pvcRef, or hostname must be set
 func (om *OutputManager) ValidateOutputs(outputs *scorev1b1.ResourceClaimOutputs) error {
 	if outputs == nil {
 		return fmt.Errorf("outputs cannot be nil")
@@ -23,18 +29,47 @@ func (om *OutputManager) ValidateOutputs(outputs *scorev1b1.ResourceClaimOutputs
 
 	// Check if at least one output field is set (CEL constraint)
 	hasSecretRef := outputs.SecretRef != nil && outputs.SecretRef.Name != ""
+	hasExternalSecretRef := outputs.ExternalSecretRef != nil && outputs.ExternalSecretRef.Path != ""
 	hasConfigMapRef := outputs.ConfigMapRef != nil && outputs.ConfigMapRef.Name != ""
 	hasURI := outputs.URI != nil && *outputs.URI != ""
 	hasImage := outputs.Image != nil && *outputs.Image != ""
 	hasCert := outputs.Cert != nil && (outputs.Cert.SecretName != nil || len(outputs.Cert.Data) > 0)
+	hasPVCRef := outputs.PVCRef != nil && outputs.PVCRef.Name != ""
+	hasHostname := outputs.Hostname != nil && *outputs.Hostname != ""
 
-	if !hasSecretRef && !hasConfigMapRef && !hasURI && !hasImage && !hasCert {
-		return fmt.Errorf("at least one output field (secretRef, configMapRef, uri, image, or cert) must be set")
+	if !hasSecretRef && !hasExternalSecretRef && !hasConfigMapRef && !hasURI && !hasImage && !hasCert && !hasPVCRef && !hasHostname {
+		return fmt.Errorf("at least one output field (secretRef, externalSecretRef, configMapRef, uri, image, cert, pvcRef, or hostname) must be set")
 	}
 
+	return CheckPlaintextSecrets(outputs)
+}
+
+// CheckPlaintextSecrets rejects outputs that publish secret material in plaintext: claim status is readable
+// by anyone who can read the claim, so credentials and private keys must be published through secretRef.
+func CheckPlaintextSecrets(outputs *scorev1b1.ResourceClaimOutputs) error {
+	if outputs.URI != nil && URIContainsPassword(*outputs.URI) {
+		return fmt.Errorf("%w: uri contains a password, publish it through secretRef instead", ErrPlaintextSecret)
+	}
+	if outputs.Cert != nil {
+		for key, data := range outputs.Cert.Data {
+			if strings.Contains(string(data), "PRIVATE KEY-----") {
+				return fmt.Errorf("%w: cert.data[%s] contains a private key, publish it through cert.secretName instead", ErrPlaintextSecret, key)
+			}
+		}
+	}
 	return nil
 }
 
+// URIContainsPassword reports whether the URI carries a password in its userinfo
+func URIContainsPassword(uri string) bool {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.User == nil {
+		return false
+	}
+	_, hasPassword := parsed.User.Password()
+	return hasPassword
+}
+
 // SetOutputsAvailable updates the outputsAvailable field based on outputs validation
 func (om *OutputManager) SetOutputsAvailable(claim *scorev1b1.ResourceClaim) {
 	err := om.ValidateOutputs(claim.Status.Outputs)
@@ -85,6 +120,9 @@ func (om *OutputManager) MergeOutputs(outputs ...*scorev1b1.ResourceClaimOutputs
 		if output.SecretRef != nil && result.SecretRef == nil {
 			result.SecretRef = output.SecretRef
 		}
+		if output.ExternalSecretRef != nil && result.ExternalSecretRef == nil {
+			result.ExternalSecretRef = output.ExternalSecretRef
+		}
 		if output.ConfigMapRef != nil && result.ConfigMapRef == nil {
 			result.ConfigMapRef = output.ConfigMapRef
 		}
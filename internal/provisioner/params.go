@@ -0,0 +1,31 @@
+package provisioner
+
+import (
+	"fmt"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/valuesschema"
+)
+
+// ValidateParams validates the params of a claim against the params schema of its provisioner.
+// Params that violate the schema are reported as a *valuesschema.ValidationError naming the JSON pointers
+// of the offending params. Types without a provisioner or a schema accept any params, and claims without
+// params are validated as an empty object so that required params are reported.
+func ValidateParams(spec *scorev1b1.ProvisionerSpec, params *apiextv1.JSON) error {
+	if spec == nil || spec.ParamsSchema == nil {
+		return nil
+	}
+
+	schema, err := valuesschema.Compile(spec.ParamsSchema.Raw)
+	if err != nil {
+		return fmt.Errorf("invalid params schema of type %s: %w", spec.Type, err)
+	}
+
+	data := []byte(`{}`)
+	if params != nil && len(params.Raw) > 0 {
+		data = params.Raw
+	}
+	return schema.Validate(data)
+}
@@ -0,0 +1,64 @@
+package provisioner
+
+import (
+	"errors"
+	"testing"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/valuesschema"
+)
+
+func TestValidateParams(t *testing.T) {
+	spec := &scorev1b1.ProvisionerSpec{
+		Type: "volume",
+		ParamsSchema: &runtime.RawExtension{Raw: []byte(`{
+			"type": "object",
+			"required": ["size"],
+			"properties": {
+				"size": {"type": "string", "pattern": "^[0-9]+[MG]i$"},
+				"accessMode": {"enum": ["ReadWriteOnce", "ReadWriteMany"]}
+			},
+			"additionalProperties": false
+		}`)},
+	}
+
+	tests := []struct {
+		name   string
+		spec   *scorev1b1.ProvisionerSpec
+		params string
+		want   string
+	}{
+		{name: "valid params", spec: spec, params: `{"size":"10Gi","accessMode":"ReadWriteMany"}`},
+		{name: "misspelled param", spec: spec, params: `{"size":"10Gi","accesMode":"ReadWriteMany"}`, want: "/accesMode"},
+		{name: "invalid value", spec: spec, params: `{"size":"ten"}`, want: "/size"},
+		{name: "missing params", spec: spec, want: "/size"},
+		{name: "no schema", spec: &scorev1b1.ProvisionerSpec{Type: "volume"}, params: `{"anything":true}`},
+		{name: "no provisioner", params: `{"anything":true}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var params *apiextv1.JSON
+			if tt.params != "" {
+				params = &apiextv1.JSON{Raw: []byte(tt.params)}
+			}
+			err := ValidateParams(tt.spec, params)
+			if tt.want == "" {
+				if err != nil {
+					t.Errorf("ValidateParams() error = %v, want nil", err)
+				}
+				return
+			}
+			var schemaErr *valuesschema.ValidationError
+			if !errors.As(err, &schemaErr) {
+				t.Fatalf("ValidateParams() error = %v, want a *valuesschema.ValidationError", err)
+			}
+			if len(schemaErr.Violations) != 1 || schemaErr.Violations[0].Path != tt.want {
+				t.Errorf("violations = %v, want one at %s", schemaErr.Violations, tt.want)
+			}
+		})
+	}
+}
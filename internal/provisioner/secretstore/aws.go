@@ -0,0 +1,206 @@
+package secretstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+const (
+	// awsService is the signing name of AWS Secrets Manager
+	awsService = "secretsmanager"
+	// awsTimeFormat is the format of the X-Amz-Date header
+	awsTimeFormat = "20060102T150405Z"
+)
+
+// errAWSResourceNotFound is returned for requests on secrets that do not exist
+var errAWSResourceNotFound = errors.New("ResourceNotFoundException")
+
+// awsCredentials are static AWS credentials
+type awsCredentials struct {
+	accessKeyID     string
+	secretAccessKey string
+	sessionToken    string
+}
+
+// awsStore writes credentials to AWS Secrets Manager through its JSON API, signing requests with Signature Version 4
+type awsStore struct {
+	endpoint    string
+	region      string
+	credentials awsCredentials
+	httpClient  *http.Client
+	now         func() time.Time
+}
+
+func newAWSStore(spec *scorev1b1.AWSSecretsManagerSpec, credentials awsCredentials, httpClient *http.Client) *awsStore {
+	endpoint := spec.Endpoint
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://%s.%s.amazonaws.com", awsService, spec.Region)
+	}
+	return &awsStore{
+		endpoint:    endpoint,
+		region:      spec.Region,
+		credentials: credentials,
+		httpClient:  httpClient,
+		now:         time.Now,
+	}
+}
+
+// Write puts a new version of the secret named path, creating the secret on first write
+func (s *awsStore) Write(ctx context.Context, secretPath string, data map[string][]byte) (string, error) {
+	values := make(map[string]string, len(data))
+	for key, value := range data {
+		values[key] = string(value)
+	}
+	secretString, err := json.Marshal(values)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode secret: %w", err)
+	}
+
+	var response struct {
+		VersionID string `json:"VersionId"`
+	}
+	err = s.call(ctx, "PutSecretValue", map[string]any{"SecretId": secretPath, "SecretString": string(secretString)}, &response)
+	if errors.Is(err, errAWSResourceNotFound) {
+		err = s.call(ctx, "CreateSecret", map[string]any{"Name": secretPath, "SecretString": string(secretString)}, &response)
+	}
+	if err != nil {
+		return "", err
+	}
+	return response.VersionID, nil
+}
+
+// Delete deletes the secret named path without a recovery window
+func (s *awsStore) Delete(ctx context.Context, secretPath string) error {
+	err := s.call(ctx, "DeleteSecret", map[string]any{"SecretId": secretPath, "ForceDeleteWithoutRecovery": true}, nil)
+	if errors.Is(err, errAWSResourceNotFound) {
+		return nil
+	}
+	return err
+}
+
+// call invokes an API action and decodes the response into out, if not nil
+func (s *awsStore) call(ctx context.Context, action string, input, out any) error {
+	body, err := json.Marshal(input)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", action, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create %s request: %w", action, err)
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager."+action)
+	signV4(req, body, s.credentials, s.region, awsService, s.now())
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", action, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := readBody(resp)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", action, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		var awsErr struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(respBody, &awsErr)
+		// The type may be qualified with a namespace (e.g., "aws.secretsmanager#ResourceNotFoundException")
+		errType := awsErr.Type[strings.LastIndex(awsErr.Type, "#")+1:]
+		if errType == errAWSResourceNotFound.Error() {
+			return fmt.Errorf("%s: %w: %s", action, errAWSResourceNotFound, awsErr.Message)
+		}
+		return fmt.Errorf("%s returned %d: %s %s", action, resp.StatusCode, errType, awsErr.Message)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", action, err)
+	}
+	return nil
+}
+
+// signV4 adds the Signature Version 4 authorization for the service to the request
+func signV4(req *http.Request, body []byte, credentials awsCredentials, region, service string, now time.Time) {
+	amzDate := now.UTC().Format(awsTimeFormat)
+	date := amzDate[:8]
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	if credentials.sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", credentials.sessionToken)
+	}
+
+	// Canonical headers are the lowercased headers sorted by name, including the host
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(req.Header.Get(name))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalPath := req.URL.EscapedPath()
+	if canonicalPath == "" {
+		canonicalPath = "/"
+	}
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalPath,
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		hashHex(body),
+	}, "\n")
+
+	scope := strings.Join([]string{date, region, service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{"AWS4-HMAC-SHA256", amzDate, scope, hashHex([]byte(canonicalRequest))}, "\n")
+
+	key := []byte("AWS4" + credentials.secretAccessKey)
+	for _, part := range []string{date, region, service, "aws4_request"} {
+		key = hmacSHA256(key, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		credentials.accessKeyID, scope, signedHeaders, signature))
+}
+
+// canonicalQuery encodes the query parameters sorted by name
+func canonicalQuery(query url.Values) string {
+	return strings.ReplaceAll(query.Encode(), "+", "%20")
+}
+
+func hashHex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
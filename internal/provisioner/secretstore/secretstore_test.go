@@ -0,0 +1,144 @@
+package secretstore
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestClaimPath(t *testing.T) {
+	claim := &scorev1b1.ResourceClaim{ObjectMeta: metav1.ObjectMeta{Name: "app-db", Namespace: "team-a"}}
+	if got := ClaimPath(&scorev1b1.SecretStoreSpec{}, claim); got != "score/team-a/app-db" {
+		t.Errorf("ClaimPath() = %q, want the default prefix", got)
+	}
+	if got := ClaimPath(&scorev1b1.SecretStoreSpec{PathPrefix: "platform/claims/"}, claim); got != "platform/claims/team-a/app-db" {
+		t.Errorf("ClaimPath() = %q, want the configured prefix", got)
+	}
+}
+
+func TestVaultStore(t *testing.T) {
+	secrets := map[string]map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "s.token" {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		switch {
+		case r.Method == http.MethodPost && strings.HasPrefix(r.URL.Path, "/v1/kv/data/"):
+			var body struct {
+				Data map[string]string `json:"data"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			secrets[strings.TrimPrefix(r.URL.Path, "/v1/kv/data/")] = body.Data
+			_, _ = w.Write([]byte(`{"data":{"version":3}}`))
+		case r.Method == http.MethodDelete && strings.HasPrefix(r.URL.Path, "/v1/kv/metadata/"):
+			delete(secrets, strings.TrimPrefix(r.URL.Path, "/v1/kv/metadata/"))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "vault-token", Namespace: "score-system"},
+		Data:       map[string][]byte{"token": []byte("s.token")},
+	}
+	spec := &scorev1b1.SecretStoreSpec{Name: "vault", Vault: &scorev1b1.VaultSecretStoreSpec{
+		Address:        server.URL + "/",
+		MountPath:      "/kv/",
+		TokenSecretRef: scorev1b1.NamespacedName{Namespace: "score-system", Name: "vault-token"},
+	}}
+	store, err := New(context.Background(), fake.NewClientBuilder().WithObjects(tokenSecret).Build(), spec)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	version, err := store.Write(context.Background(), "score/default/app-db", map[string][]byte{"password": []byte("s3cr3t")})
+	if err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	if version != "3" || secrets["score/default/app-db"]["password"] != "s3cr3t" {
+		t.Errorf("Write() = %q, stored %v; want version 3 and the password stored", version, secrets)
+	}
+	if err := store.Delete(context.Background(), "score/default/app-db"); err != nil || len(secrets) != 0 {
+		t.Errorf("Delete() error = %v, stored %v", err, secrets)
+	}
+
+	store.(*vaultStore).token = "wrong"
+	if _, err := store.Write(context.Background(), "score/default/app-db", nil); err == nil || !strings.Contains(err.Error(), "permission denied") {
+		t.Errorf("Write() error = %v, want the vault error", err)
+	}
+}
+
+func TestAWSStore(t *testing.T) {
+	var actions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=AKID/") ||
+			!strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/secretsmanager/aws4_request") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		action := strings.TrimPrefix(r.Header.Get("X-Amz-Target"), "secretsmanager.")
+		actions = append(actions, action)
+		switch action {
+		case "PutSecretValue", "DeleteSecret":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"ResourceNotFoundException","message":"Secrets Manager can't find the specified secret."}`))
+		case "CreateSecret":
+			var input struct{ Name, SecretString string }
+			if err := json.NewDecoder(r.Body).Decode(&input); err != nil || input.Name != "score/default/app-db" ||
+				input.SecretString != `{"password":"s3cr3t"}` {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte(`{"VersionId":"v1"}`))
+		}
+	}))
+	defer server.Close()
+
+	store := newAWSStore(&scorev1b1.AWSSecretsManagerSpec{Region: "eu-west-1", Endpoint: server.URL},
+		awsCredentials{accessKeyID: "AKID", secretAccessKey: "secret"}, server.Client())
+
+	version, err := store.Write(context.Background(), "score/default/app-db", map[string][]byte{"password": []byte("s3cr3t")})
+	if err != nil || version != "v1" {
+		t.Fatalf("Write() = %q, %v; want the version of the created secret", version, err)
+	}
+	if err := store.Delete(context.Background(), "score/default/app-db"); err != nil {
+		t.Errorf("Delete() error = %v, want missing secrets to be ignored", err)
+	}
+	if want := []string{"PutSecretValue", "CreateSecret", "DeleteSecret"}; strings.Join(actions, ",") != strings.Join(want, ",") {
+		t.Errorf("actions = %v, want %v", actions, want)
+	}
+}
+
+// TestSignV4 checks the signature against the example request of the AWS Signature Version 4 documentation
+func TestSignV4(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "https://iam.amazonaws.com/?Action=ListUsers&Version=2010-05-08", nil)
+	req.Header = http.Header{}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded; charset=utf-8")
+	credentials := awsCredentials{accessKeyID: "AKIDEXAMPLE", secretAccessKey: "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY"}
+
+	signV4(req, nil, credentials, "us-east-1", "iam", time.Date(2015, 8, 30, 12, 36, 0, 0, time.UTC))
+
+	want := "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/iam/aws4_request, " +
+		"SignedHeaders=content-type;host;x-amz-date, " +
+		"Signature=5d672d79c15b13162d9279b0855cfba6789a8edb4c82c400e06b5924a6f2b5d7"
+	if got := req.Header.Get("Authorization"); got != want {
+		t.Errorf("Authorization = %q, want %q", got, want)
+	}
+}
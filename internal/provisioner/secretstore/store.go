@@ -0,0 +1,111 @@
+// Package secretstore writes claim credentials to external secret stores, so that claims publish a reference
+// to the store instead of a Secret.
+package secretstore
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+const (
+	// defaultPathPrefix is prepended to claim paths when the store sets no prefix
+	defaultPathPrefix = "score"
+	// requestTimeout bounds each request to the store
+	requestTimeout = 30 * time.Second
+	// maxResponseSize bounds the responses read from the store
+	maxResponseSize = 1 << 20
+)
+
+// Store writes claim credentials to an external secret store
+type Store interface {
+	// Write stores data at path, replacing the previous version, and returns the version written
+	Write(ctx context.Context, path string, data map[string][]byte) (string, error)
+	// Delete removes the credentials at path with all their versions; missing credentials are not an error
+	Delete(ctx context.Context, path string) error
+}
+
+// ClaimPath returns the path of the credentials of a claim in the store
+func ClaimPath(spec *scorev1b1.SecretStoreSpec, claim *scorev1b1.ResourceClaim) string {
+	prefix := spec.PathPrefix
+	if prefix == "" {
+		prefix = defaultPathPrefix
+	}
+	return path.Join(prefix, claim.Namespace, claim.Name)
+}
+
+// New creates the store configured by spec, reading its credentials from the referenced Secret
+func New(ctx context.Context, c client.Client, spec *scorev1b1.SecretStoreSpec) (Store, error) {
+	switch {
+	case spec.Vault != nil:
+		vault := spec.Vault
+		data, err := readSecret(ctx, c, vault.TokenSecretRef, "token")
+		if err != nil {
+			return nil, err
+		}
+		httpClient, err := newHTTPClient(vault.CABundle)
+		if err != nil {
+			return nil, err
+		}
+		return newVaultStore(vault, string(data["token"]), httpClient), nil
+	case spec.AWSSecretsManager != nil:
+		aws := spec.AWSSecretsManager
+		data, err := readSecret(ctx, c, aws.CredentialsSecretRef, "accessKeyId", "secretAccessKey")
+		if err != nil {
+			return nil, err
+		}
+		credentials := awsCredentials{
+			accessKeyID:     string(data["accessKeyId"]),
+			secretAccessKey: string(data["secretAccessKey"]),
+			sessionToken:    string(data["sessionToken"]),
+		}
+		return newAWSStore(aws, credentials, &http.Client{Timeout: requestTimeout}), nil
+	default:
+		return nil, fmt.Errorf("secret store %q configures neither vault nor awsSecretsManager", spec.Name)
+	}
+}
+
+// readSecret reads the referenced Secret and checks that it has the required keys
+func readSecret(ctx context.Context, c client.Client, ref scorev1b1.NamespacedName, required ...string) (map[string][]byte, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret store credentials %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	for _, key := range required {
+		if len(secret.Data[key]) == 0 {
+			return nil, fmt.Errorf("secret store credentials %s/%s have no %q key", ref.Namespace, ref.Name, key)
+		}
+	}
+	return secret.Data, nil
+}
+
+// newHTTPClient creates a client that verifies the server certificate against caBundle, or the system roots
+func newHTTPClient(caBundle string) (*http.Client, error) {
+	if caBundle == "" {
+		return &http.Client{Timeout: requestTimeout}, nil
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM([]byte(caBundle)) {
+		return nil, fmt.Errorf("caBundle contains no PEM encoded certificates")
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	// Stores are created per reconcile, so idle connections would never be reused
+	transport.DisableKeepAlives = true
+	return &http.Client{Timeout: requestTimeout, Transport: transport}, nil
+}
+
+// readBody reads a bounded response body
+func readBody(resp *http.Response) ([]byte, error) {
+	return io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+}
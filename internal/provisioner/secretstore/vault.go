@@ -0,0 +1,109 @@
+package secretstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// defaultVaultMountPath is the mount path of the KV secrets engine when the store sets none
+const defaultVaultMountPath = "secret"
+
+// vaultStore writes credentials to a HashiCorp Vault KV version 2 secrets engine
+type vaultStore struct {
+	address    string
+	mountPath  string
+	token      string
+	httpClient *http.Client
+}
+
+func newVaultStore(spec *scorev1b1.VaultSecretStoreSpec, token string, httpClient *http.Client) *vaultStore {
+	mountPath := strings.Trim(spec.MountPath, "/")
+	if mountPath == "" {
+		mountPath = defaultVaultMountPath
+	}
+	return &vaultStore{
+		address:    strings.TrimSuffix(spec.Address, "/"),
+		mountPath:  mountPath,
+		token:      token,
+		httpClient: httpClient,
+	}
+}
+
+// Write creates a new version of the secret at path
+func (s *vaultStore) Write(ctx context.Context, secretPath string, data map[string][]byte) (string, error) {
+	values := make(map[string]string, len(data))
+	for key, value := range data {
+		values[key] = string(value)
+	}
+	body, err := json.Marshal(map[string]any{"data": values})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode vault secret: %w", err)
+	}
+
+	var response struct {
+		Data struct {
+			Version int `json:"version"`
+		} `json:"data"`
+	}
+	if err := s.do(ctx, http.MethodPost, "data", secretPath, body, &response); err != nil {
+		return "", err
+	}
+	return strconv.Itoa(response.Data.Version), nil
+}
+
+// Delete removes the metadata and all versions of the secret at path
+func (s *vaultStore) Delete(ctx context.Context, secretPath string) error {
+	return s.do(ctx, http.MethodDelete, "metadata", secretPath, nil, nil)
+}
+
+// do sends a request to the KV API and decodes the response into out, if not nil
+func (s *vaultStore) do(ctx context.Context, method, api, secretPath string, body []byte, out any) error {
+	url := s.address + "/v1/" + path.Join(s.mountPath, api, secretPath)
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", s.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault request %s %s failed: %w", method, url, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := readBody(resp)
+	if err != nil {
+		return fmt.Errorf("failed to read vault response: %w", err)
+	}
+
+	if method == http.MethodDelete && resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var vaultErr struct {
+			Errors []string `json:"errors"`
+		}
+		message := strings.TrimSpace(string(respBody))
+		if json.Unmarshal(respBody, &vaultErr) == nil && len(vaultErr.Errors) > 0 {
+			message = strings.Join(vaultErr.Errors, "; ")
+		}
+		return fmt.Errorf("vault request %s %s returned %d: %s", method, url, resp.StatusCode, message)
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode vault response: %w", err)
+	}
+	return nil
+}
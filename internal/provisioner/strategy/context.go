@@ -0,0 +1,20 @@
+package strategy
+
+import (
+	"context"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+type provisionerKey struct{}
+
+// WithProvisioner returns a context carrying the provisioner configured for the claim type being reconciled
+func WithProvisioner(ctx context.Context, provisioner *scorev1b1.ProvisionerSpec) context.Context {
+	return context.WithValue(ctx, provisionerKey{}, provisioner)
+}
+
+// ProvisionerFromContext returns the provisioner configured for the claim type, or nil when none is configured
+func ProvisionerFromContext(ctx context.Context) *scorev1b1.ProvisionerSpec {
+	provisioner, _ := ctx.Value(provisionerKey{}).(*scorev1b1.ProvisionerSpec)
+	return provisioner
+}
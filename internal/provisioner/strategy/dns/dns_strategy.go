@@ -0,0 +1,258 @@
+package dns
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+// defaultTTL is the TTL of the record in seconds when neither the class nor the claim sets one
+const defaultTTL = 300
+
+// endpointGVK is the external-dns DNSEndpoint kind
+var endpointGVK = schema.GroupVersionKind{Group: "externaldns.k8s.io", Version: "v1alpha1", Kind: "DNSEndpoint"}
+
+func init() {
+	strategy.Register("dns", func(c client.Client) strategy.Strategy { return NewDNSStrategy(c) })
+}
+
+// Parameters are the options of a DNS record, read from the provisioner defaults, the claim's class and
+// the claim params, in increasing precedence
+type Parameters struct {
+	// Zone is the DNS zone the record is created in; the hostname defaults to <workload>-<namespace>.<zone>
+	Zone string `json:"zone,omitempty"`
+
+	// Hostname overrides the name of the record
+	Hostname string `json:"hostname,omitempty"`
+
+	// Targets are the addresses the record points to, e.g. the load balancer of the ingress controller
+	Targets []string `json:"targets,omitempty"`
+
+	// RecordType is the type of the record (default A for IPv4 targets, AAAA for IPv6 targets, CNAME otherwise)
+	RecordType string `json:"recordType,omitempty"`
+
+	// TTL is the TTL of the record in seconds (default 300)
+	TTL int64 `json:"ttl,omitempty"`
+}
+
+// DNSStrategy implements the Strategy interface for DNS records published by external-dns
+type DNSStrategy struct {
+	client client.Client
+}
+
+// NewDNSStrategy creates a new DNSStrategy
+func NewDNSStrategy(k8sClient client.Client) *DNSStrategy {
+	return &DNSStrategy{
+		client: k8sClient,
+	}
+}
+
+// GetType returns the resource type this strategy handles
+func (s *DNSStrategy) GetType() string {
+	return "dns"
+}
+
+// Provision applies a DNSEndpoint for the record and publishes its hostname once external-dns has
+// observed the current generation of the DNSEndpoint
+func (s *DNSStrategy) Provision(ctx context.Context, claim *scorev1b1.ResourceClaim) (*scorev1b1.ResourceClaimOutputs, error) {
+	params, err := parametersFor(ctx, claim)
+	if err != nil {
+		return nil, err
+	}
+	hostname, err := strategy.Hostname(claim, params.Hostname, params.Zone)
+	if err != nil {
+		return nil, err
+	}
+	desired, err := buildEndpoint(claim, params, hostname)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint, err := s.applyEndpoint(ctx, claim, desired)
+	if err != nil {
+		return nil, err
+	}
+	if !observed(endpoint) {
+		return nil, strategy.ErrInProgress
+	}
+	return &scorev1b1.ResourceClaimOutputs{Hostname: &hostname}, nil
+}
+
+// Deprovision deletes the DNSEndpoint, upon which external-dns removes the record
+func (s *DNSStrategy) Deprovision(ctx context.Context, claim *scorev1b1.ResourceClaim) error {
+	endpoint := &unstructured.Unstructured{}
+	endpoint.SetGroupVersionKind(endpointGVK)
+	endpoint.SetName(endpointName(claim))
+	endpoint.SetNamespace(claim.Namespace)
+	if err := s.client.Delete(ctx, endpoint); err != nil && !apierrors.IsNotFound(err) && !apimeta.IsNoMatchError(err) {
+		return fmt.Errorf("failed to delete dns endpoint: %w", err)
+	}
+	return nil
+}
+
+// GetStatus returns the current status of the DNS record
+func (s *DNSStrategy) GetStatus(ctx context.Context, claim *scorev1b1.ResourceClaim) (phase scorev1b1.ResourceClaimPhase, reason, message string, err error) {
+	endpoint, err := s.getEndpoint(ctx, claim)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return scorev1b1.ResourceClaimPhaseClaiming, "RecordCreating",
+				"DNS record is being created", nil
+		}
+		if apimeta.IsNoMatchError(err) {
+			return scorev1b1.ResourceClaimPhaseFailed, "OperatorNotInstalled",
+				"The external-dns DNSEndpoint CRD is not installed", nil
+		}
+		return scorev1b1.ResourceClaimPhaseFailed, "RecordAccessFailed",
+			fmt.Sprintf("Failed to access dns endpoint: %v", err), err
+	}
+
+	if !observed(endpoint) {
+		return scorev1b1.ResourceClaimPhaseClaiming, "RecordPending",
+			"DNS record has not been published by external-dns yet", nil
+	}
+	return scorev1b1.ResourceClaimPhaseBound, "Succeeded",
+		"DNS record is published", nil
+}
+
+// parametersFor returns the parameters of the claim's class overlaid with the claim params
+func parametersFor(ctx context.Context, claim *scorev1b1.ResourceClaim) (*Parameters, error) {
+	params := &Parameters{}
+	if err := strategy.DecodeParameters(ctx, claim, params); err != nil {
+		return nil, err
+	}
+	if len(params.Targets) == 0 {
+		return nil, fmt.Errorf("targets must be set")
+	}
+	if params.TTL < 0 {
+		return nil, fmt.Errorf("invalid ttl %d: must not be negative", params.TTL)
+	}
+	return params, nil
+}
+
+// recordType returns the type of the record, inferred from the targets unless the parameters set it
+func (p *Parameters) recordType() (string, error) {
+	if p.RecordType != "" {
+		return p.RecordType, nil
+	}
+	inferred := ""
+	for _, target := range p.Targets {
+		kind := "CNAME"
+		if ip := net.ParseIP(target); ip != nil {
+			kind = "AAAA"
+			if ip.To4() != nil {
+				kind = "A"
+			}
+		}
+		if inferred != "" && inferred != kind {
+			return "", fmt.Errorf("targets %v mix record types, set recordType", p.Targets)
+		}
+		inferred = kind
+	}
+	if inferred == "CNAME" && len(p.Targets) > 1 {
+		return "", fmt.Errorf("a CNAME record has a single target, got %d", len(p.Targets))
+	}
+	return inferred, nil
+}
+
+// buildEndpoint returns the DNSEndpoint of the claim
+func buildEndpoint(claim *scorev1b1.ResourceClaim, params *Parameters, hostname string) (*unstructured.Unstructured, error) {
+	recordType, err := params.recordType()
+	if err != nil {
+		return nil, err
+	}
+	ttl := params.TTL
+	if ttl == 0 {
+		ttl = defaultTTL
+	}
+	targets := make([]interface{}, 0, len(params.Targets))
+	for _, target := range params.Targets {
+		targets = append(targets, target)
+	}
+
+	endpoint := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": map[string]interface{}{
+			"endpoints": []interface{}{
+				map[string]interface{}{
+					"dnsName":    hostname,
+					"recordType": recordType,
+					"recordTTL":  ttl,
+					"targets":    targets,
+				},
+			},
+		},
+	}}
+	endpoint.SetGroupVersionKind(endpointGVK)
+	endpoint.SetName(endpointName(claim))
+	endpoint.SetNamespace(claim.Namespace)
+	endpoint.SetLabels(strategy.Labels(claim, "dns"))
+	endpoint.SetAnnotations(strategy.Annotations(claim))
+	return endpoint, nil
+}
+
+// applyEndpoint creates the DNSEndpoint of the claim, or updates its endpoints when they changed, and returns it
+func (s *DNSStrategy) applyEndpoint(ctx context.Context, claim *scorev1b1.ResourceClaim, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	existing, err := s.getEndpoint(ctx, claim)
+	if apierrors.IsNotFound(err) {
+		// Set ResourceClaim as owner for garbage collection
+		if err := controllerutil.SetControllerReference(claim, desired, s.client.Scheme()); err != nil {
+			return nil, fmt.Errorf("failed to set owner reference: %w", err)
+		}
+		if err := s.client.Create(ctx, desired); err != nil {
+			return nil, fmt.Errorf("failed to create dns endpoint: %w", err)
+		}
+		return desired, nil
+	} else if err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("the external-dns DNSEndpoint CRD is not installed: %w", err)
+		}
+		return nil, fmt.Errorf("failed to check existing dns endpoint: %w", err)
+	}
+	if err := strategy.CheckControlled(claim, existing, "dns endpoint"); err != nil {
+		return nil, err
+	}
+
+	metadataChanged := strategy.SyncMetadata(existing, desired)
+	want, _, _ := unstructured.NestedFieldNoCopy(desired.Object, "spec", "endpoints")
+	got, _, _ := unstructured.NestedFieldNoCopy(existing.Object, "spec", "endpoints")
+	if !metadataChanged && equality.Semantic.DeepEqual(want, got) {
+		return existing, nil
+	}
+	if err := unstructured.SetNestedField(existing.Object, want, "spec", "endpoints"); err != nil {
+		return nil, fmt.Errorf("failed to set endpoints of dns endpoint: %w", err)
+	}
+	if err := s.client.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to update dns endpoint: %w", err)
+	}
+	return existing, nil
+}
+
+// getEndpoint returns the DNSEndpoint of the claim
+func (s *DNSStrategy) getEndpoint(ctx context.Context, claim *scorev1b1.ResourceClaim) (*unstructured.Unstructured, error) {
+	endpoint := &unstructured.Unstructured{}
+	endpoint.SetGroupVersionKind(endpointGVK)
+	if err := s.client.Get(ctx, client.ObjectKey{Name: endpointName(claim), Namespace: claim.Namespace}, endpoint); err != nil {
+		return nil, err
+	}
+	return endpoint, nil
+}
+
+// observed reports whether external-dns has processed the current generation of the DNSEndpoint
+func observed(endpoint *unstructured.Unstructured) bool {
+	observedGeneration, found, _ := unstructured.NestedInt64(endpoint.Object, "status", "observedGeneration")
+	return found && observedGeneration >= endpoint.GetGeneration()
+}
+
+func endpointName(claim *scorev1b1.ResourceClaim) string {
+	return strategy.ResourceName(claim, "dns")
+}
@@ -0,0 +1,118 @@
+package dns
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+func TestProvisionDNSEndpoint(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	s := NewDNSStrategy(c)
+
+	ctx := strategy.WithProvisioner(context.Background(), &scorev1b1.ProvisionerSpec{
+		Type: "dns",
+		Classes: []scorev1b1.ClassSpec{{
+			Name:       "public",
+			Parameters: &runtime.RawExtension{Raw: []byte(`{"zone":"apps.example.com","targets":["203.0.113.10"]}`)},
+		}},
+		Defaults: &scorev1b1.ProvisionerDefaults{Class: "public"},
+	})
+	claim := &scorev1b1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-dns", Namespace: "team-a", UID: "uid"},
+		Spec: scorev1b1.ResourceClaimSpec{
+			WorkloadRef: scorev1b1.NamespacedName{Name: "web", Namespace: "team-a"},
+			Key:         "dns",
+			Type:        "dns",
+			Params:      &apiextv1.JSON{Raw: []byte(`{"ttl":60}`)},
+		},
+	}
+
+	// The claim waits until external-dns has observed the record
+	if _, err := s.Provision(ctx, claim); !errors.Is(err, strategy.ErrInProgress) {
+		t.Fatalf("Provision() error = %v, want ErrInProgress", err)
+	}
+	endpoint, err := s.getEndpoint(ctx, claim)
+	if err != nil {
+		t.Fatalf("dns endpoint was not created: %v", err)
+	}
+	endpoints, _, _ := unstructured.NestedSlice(endpoint.Object, "spec", "endpoints")
+	if len(endpoints) != 1 {
+		t.Fatalf("endpoints = %v, want one record", endpoints)
+	}
+	record := endpoints[0].(map[string]interface{})
+	if record["dnsName"] != "web-team-a.apps.example.com" || record["recordType"] != "A" || record["recordTTL"] != int64(60) {
+		t.Errorf("record = %v, want an A record for web-team-a.apps.example.com with TTL 60", record)
+	}
+	if phase, reason, _, err := s.GetStatus(ctx, claim); err != nil || phase != scorev1b1.ResourceClaimPhaseClaiming {
+		t.Errorf("GetStatus() = %s (%s), %v, want Claiming", phase, reason, err)
+	}
+
+	if err := unstructured.SetNestedField(endpoint.Object, endpoint.GetGeneration(), "status", "observedGeneration"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Update(ctx, endpoint); err != nil {
+		t.Fatal(err)
+	}
+	outputs, err := s.Provision(ctx, claim)
+	if err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+	if outputs.Hostname == nil || *outputs.Hostname != "web-team-a.apps.example.com" {
+		t.Errorf("hostname = %v, want web-team-a.apps.example.com", outputs.Hostname)
+	}
+	if phase, reason, _, err := s.GetStatus(ctx, claim); err != nil || phase != scorev1b1.ResourceClaimPhaseBound {
+		t.Errorf("GetStatus() = %s (%s), %v, want Bound", phase, reason, err)
+	}
+
+	if err := s.Deprovision(ctx, claim); err != nil {
+		t.Fatalf("Deprovision() error = %v", err)
+	}
+	if _, err := s.getEndpoint(ctx, claim); err == nil {
+		t.Error("dns endpoint still exists after Deprovision")
+	}
+}
+
+func TestRecordType(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  Parameters
+		want    string
+		wantErr bool
+	}{
+		{name: "ipv4", params: Parameters{Targets: []string{"203.0.113.10", "203.0.113.11"}}, want: "A"},
+		{name: "ipv6", params: Parameters{Targets: []string{"2001:db8::1"}}, want: "AAAA"},
+		{name: "hostname", params: Parameters{Targets: []string{"lb.example.com"}}, want: "CNAME"},
+		{name: "explicit", params: Parameters{Targets: []string{"lb.example.com"}, RecordType: "TXT"}, want: "TXT"},
+		{name: "mixed", params: Parameters{Targets: []string{"203.0.113.10", "lb.example.com"}}, wantErr: true},
+		{name: "several hostnames", params: Parameters{Targets: []string{"a.example.com", "b.example.com"}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.params.recordType()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("recordType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("recordType() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
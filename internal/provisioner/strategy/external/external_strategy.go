@@ -0,0 +1,117 @@
+package external
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+// StrategyName is the name the strategy is registered under. Claims with provision external use it
+// regardless of the provisioner configured for their type.
+const StrategyName = "external"
+
+func init() {
+	strategy.Register(StrategyName, func(c client.Client) strategy.Strategy { return NewExternalStrategy(c) })
+}
+
+// ExternalStrategy implements the Strategy interface for resources the user runs themselves, e.g. a
+// database they bring. The connection details are read from the Secret the claim references, which must
+// contain the output keys configured for the type. Nothing is created or deleted.
+type ExternalStrategy struct {
+	client client.Client
+}
+
+// NewExternalStrategy creates a new ExternalStrategy
+func NewExternalStrategy(k8sClient client.Client) *ExternalStrategy {
+	return &ExternalStrategy{
+		client: k8sClient,
+	}
+}
+
+// GetType returns the resource type this strategy handles
+func (s *ExternalStrategy) GetType() string {
+	return StrategyName
+}
+
+// Provision checks the referenced Secret and publishes it as the outputs of the claim
+func (s *ExternalStrategy) Provision(ctx context.Context, claim *scorev1b1.ResourceClaim) (*scorev1b1.ResourceClaimOutputs, error) {
+	if claim.Spec.SecretRef == nil || claim.Spec.SecretRef.Name == "" {
+		return nil, fmt.Errorf("%w: provision external requires secretRef", strategy.ErrInvalidParams)
+	}
+
+	secret, err := s.getSecret(ctx, claim)
+	if apierrors.IsNotFound(err) {
+		// The user may create the Secret after the Workload; wait for it
+		return nil, strategy.ErrInProgress
+	}
+	if err != nil {
+		return nil, err
+	}
+	if missing := missingKeys(ctx, secret); len(missing) > 0 {
+		return nil, fmt.Errorf("secret %s is missing keys %s", secret.Name, strings.Join(missing, ", "))
+	}
+
+	return &scorev1b1.ResourceClaimOutputs{
+		SecretRef: &scorev1b1.LocalObjectReference{Name: secret.Name},
+	}, nil
+}
+
+// Deprovision does nothing: the Secret and the resource belong to the user
+func (s *ExternalStrategy) Deprovision(ctx context.Context, claim *scorev1b1.ResourceClaim) error {
+	return nil
+}
+
+// GetStatus reports the claim Bound while the referenced Secret exists with the output keys of the type
+func (s *ExternalStrategy) GetStatus(ctx context.Context, claim *scorev1b1.ResourceClaim) (phase scorev1b1.ResourceClaimPhase, reason, message string, err error) {
+	if claim.Spec.SecretRef == nil || claim.Spec.SecretRef.Name == "" {
+		return scorev1b1.ResourceClaimPhaseFailed, "SpecInvalid", "provision external requires secretRef", nil
+	}
+
+	secret, err := s.getSecret(ctx, claim)
+	if apierrors.IsNotFound(err) {
+		return scorev1b1.ResourceClaimPhaseClaiming, "SecretNotFound",
+			fmt.Sprintf("Waiting for secret %s", claim.Spec.SecretRef.Name), nil
+	}
+	if err != nil {
+		return scorev1b1.ResourceClaimPhaseFailed, "SecretAccessFailed",
+			fmt.Sprintf("Failed to access secret: %v", err), err
+	}
+	if missing := missingKeys(ctx, secret); len(missing) > 0 {
+		return scorev1b1.ResourceClaimPhaseFailed, "SecretInvalid",
+			fmt.Sprintf("Secret %s is missing keys %s", secret.Name, strings.Join(missing, ", ")), nil
+	}
+
+	return scorev1b1.ResourceClaimPhaseBound, "Succeeded", "Secret is available", nil
+}
+
+func (s *ExternalStrategy) getSecret(ctx context.Context, claim *scorev1b1.ResourceClaim) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := s.client.Get(ctx, client.ObjectKey{Namespace: claim.Namespace, Name: claim.Spec.SecretRef.Name}, secret)
+	return secret, err
+}
+
+// missingKeys returns the output keys configured for the type that the Secret lacks
+func missingKeys(ctx context.Context, secret *corev1.Secret) []string {
+	provisioner := strategy.ProvisionerFromContext(ctx)
+	if provisioner == nil {
+		return nil
+	}
+	var missing []string
+	for _, key := range provisioner.OutputKeys {
+		if _, ok := secret.Data[key]; ok {
+			continue
+		}
+		if _, ok := secret.StringData[key]; ok {
+			continue
+		}
+		missing = append(missing, key)
+	}
+	return missing
+}
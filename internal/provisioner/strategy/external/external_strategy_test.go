@@ -0,0 +1,90 @@
+package external
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+func testClaim(secretName string) *scorev1b1.ResourceClaim {
+	claim := &scorev1b1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-db", Namespace: "default", UID: "uid"},
+		Spec:       scorev1b1.ResourceClaimSpec{Key: "db", Type: "postgres", Provision: scorev1b1.ProvisionExternal},
+	}
+	if secretName != "" {
+		claim.Spec.SecretRef = &scorev1b1.LocalObjectReference{Name: secretName}
+	}
+	return claim
+}
+
+func TestProvision(t *testing.T) {
+	ctx := strategy.WithProvisioner(context.Background(), &scorev1b1.ProvisionerSpec{
+		Type:       "postgres",
+		OutputKeys: []string{"host", "password"},
+	})
+	complete := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-db", Namespace: "default"},
+		Data:       map[string][]byte{"host": []byte("db.example.com"), "password": []byte("s3cr3t")},
+	}
+	partial := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "partial-db", Namespace: "default"},
+		Data:       map[string][]byte{"host": []byte("db.example.com")},
+	}
+	s := NewExternalStrategy(fake.NewClientBuilder().WithObjects(complete, partial).Build())
+
+	tests := []struct {
+		name       string
+		claim      *scorev1b1.ResourceClaim
+		wantPhase  scorev1b1.ResourceClaimPhase
+		wantErr    error
+		wantFailed bool
+	}{
+		{name: "secret with the output keys", claim: testClaim("my-db"), wantPhase: scorev1b1.ResourceClaimPhaseBound},
+		{name: "missing secret", claim: testClaim("other-db"), wantPhase: scorev1b1.ResourceClaimPhaseClaiming, wantErr: strategy.ErrInProgress},
+		{name: "missing keys", claim: testClaim("partial-db"), wantPhase: scorev1b1.ResourceClaimPhaseFailed, wantFailed: true},
+		{name: "missing secretRef", claim: testClaim(""), wantPhase: scorev1b1.ResourceClaimPhaseFailed, wantErr: strategy.ErrInvalidParams},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputs, err := s.Provision(ctx, tt.claim)
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("Provision() error = %v, want %v", err, tt.wantErr)
+				}
+			case tt.wantFailed:
+				if err == nil {
+					t.Errorf("Provision() = %+v, want an error", outputs)
+				}
+			case err != nil:
+				t.Fatalf("Provision() error = %v", err)
+			case outputs.SecretRef == nil || outputs.SecretRef.Name != tt.claim.Spec.SecretRef.Name:
+				t.Errorf("Provision() secretRef = %v, want %s", outputs.SecretRef, tt.claim.Spec.SecretRef.Name)
+			}
+			if phase, _, _, _ := s.GetStatus(ctx, tt.claim); phase != tt.wantPhase {
+				t.Errorf("GetStatus() phase = %s, want %s", phase, tt.wantPhase)
+			}
+		})
+	}
+}
+
+func TestDeprovisionKeepsTheSecret(t *testing.T) {
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "my-db", Namespace: "default"}}
+	c := fake.NewClientBuilder().WithObjects(secret).Build()
+
+	if err := NewExternalStrategy(c).Deprovision(context.Background(), testClaim("my-db")); err != nil {
+		t.Fatalf("Deprovision() error = %v", err)
+	}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(secret), &corev1.Secret{}); err != nil {
+		t.Errorf("Deprovision() removed the secret of the user: %v", err)
+	}
+}
@@ -0,0 +1,33 @@
+package strategy
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// Hostname returns the DNS name a claim for a public endpoint serves: hostname when set, or
+// <workload>-<namespace>.<zone> otherwise, so that the record and the certificate of a Workload
+// match when their classes configure the same zone
+func Hostname(claim *scorev1b1.ResourceClaim, hostname, zone string) (string, error) {
+	if hostname == "" {
+		if zone == "" {
+			return "", fmt.Errorf("either hostname or zone must be set")
+		}
+		namespace := claim.Spec.WorkloadRef.Namespace
+		if namespace == "" {
+			namespace = claim.Namespace
+		}
+		hostname = fmt.Sprintf("%s-%s.%s", claim.Spec.WorkloadRef.Name, namespace, strings.TrimSuffix(zone, "."))
+	}
+	if errs := validation.IsDNS1123Subdomain(hostname); len(errs) > 0 {
+		return "", fmt.Errorf("invalid hostname %q: %s", hostname, strings.Join(errs, ", "))
+	}
+	if zone != "" && hostname != strings.TrimSuffix(zone, ".") && !strings.HasSuffix(hostname, "."+strings.TrimSuffix(zone, ".")) {
+		return "", fmt.Errorf("hostname %q is not in zone %q", hostname, zone)
+	}
+	return hostname, nil
+}
@@ -2,10 +2,23 @@ package strategy
 
 import (
 	"context"
+	"errors"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
 )
 
+// ErrInProgress is returned by Provision when the resource was requested but is not ready to publish
+// outputs yet. The claim stays Claiming until GetStatus reports Bound, and Provision is then called again.
+var ErrInProgress = errors.New("provisioning in progress")
+
+// ErrInvalidParams is returned by Provision when the params of the claim cannot be provisioned. The claim
+// fails with reason SpecInvalid and is not retried until its spec changes.
+var ErrInvalidParams = errors.New("invalid params")
+
+// ErrTransient is returned, wrapped, by strategy calls that failed in a way that may succeed when made again,
+// e.g. because a service is unavailable. The claim keeps its phase and is reconciled again after a growing delay.
+var ErrTransient = errors.New("transient failure")
+
 // Strategy defines the interface for provisioning resource types
 type Strategy interface {
 	// GetType returns the resource type this strategy handles
@@ -14,17 +27,10 @@ type Strategy interface {
 	// Provision creates or updates the resource and returns outputs
 	Provision(ctx context.Context, claim *scorev1b1.ResourceClaim) (*scorev1b1.ResourceClaimOutputs, error)
 
-	// Deprovision cleans up the resource
+	// Deprovision cleans up the resource. It returns ErrInProgress while the resource is being deleted
+	// asynchronously; the claim keeps its finalizer and Deprovision is called again.
 	Deprovision(ctx context.Context, claim *scorev1b1.ResourceClaim) error
 
 	// GetStatus returns the current status of the resource
 	GetStatus(ctx context.Context, claim *scorev1b1.ResourceClaim) (phase scorev1b1.ResourceClaimPhase, reason, message string, err error)
 }
-
-// ProvisioningConfig represents configuration for a provisioning strategy
-type ProvisioningConfig struct {
-	Type     string            `json:"type"`
-	Strategy string            `json:"strategy"`
-	Config   map[string]any    `json:"config,omitempty"`
-	Outputs  map[string]string `json:"outputs,omitempty"`
-}
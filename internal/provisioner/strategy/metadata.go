@@ -0,0 +1,60 @@
+package strategy
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/propagation"
+)
+
+// AnnotationRetainSecret marks an output Secret that the provisioned resource reads itself, or that keeps the only
+// copy of credentials generated once. It is kept when the outputs of the claim are moved to a secret store.
+const AnnotationRetainSecret = "score.dev/retain-secret"
+
+// Labels returns the labels of a resource of the given type provisioned for the claim: the score.dev labels
+// identifying the claim, merged with the Workload labels the claim propagates
+func Labels(claim *scorev1b1.ResourceClaim, resourceType string) map[string]string {
+	return propagation.Labels(map[string]string{
+		"score.dev/resource-claim": claim.Name,
+		"score.dev/resource-type":  resourceType,
+	}, claim.Spec.Metadata)
+}
+
+// Annotations returns the Workload annotations the claim propagates to the resources provisioned for it, or nil
+func Annotations(claim *scorev1b1.ResourceClaim) map[string]string {
+	return propagation.Annotations(nil, claim.Spec.Metadata)
+}
+
+// RetainedSecretAnnotations returns Annotations with AnnotationRetainSecret set, for output Secrets that must
+// outlive the move of the outputs to a secret store
+func RetainedSecretAnnotations(claim *scorev1b1.ResourceClaim) map[string]string {
+	annotations := Annotations(claim)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[AnnotationRetainSecret] = "true"
+	return annotations
+}
+
+// SyncMetadata sets the labels and annotations of the desired resource on the existing one, leaving other keys
+// alone, and reports whether the existing resource changed. Strategies call it with resources built from
+// Labels and Annotations so that Workload label changes reach resources provisioned earlier.
+func SyncMetadata(existing, desired metav1.Object) bool {
+	changed := false
+	sync := func(current, want map[string]string) map[string]string {
+		for key, value := range want {
+			if got, ok := current[key]; ok && got == value {
+				continue
+			}
+			if current == nil {
+				current = map[string]string{}
+			}
+			current[key] = value
+			changed = true
+		}
+		return current
+	}
+	existing.SetLabels(sync(existing.GetLabels(), desired.GetLabels()))
+	existing.SetAnnotations(sync(existing.GetAnnotations(), desired.GetAnnotations()))
+	return changed
+}
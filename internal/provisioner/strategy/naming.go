@@ -0,0 +1,52 @@
+package strategy
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// ErrNameConflict is returned by Provision when a resource the claim would create already exists but is
+// controlled by something else, e.g. a deleted claim of the same name or an object created by hand
+var ErrNameConflict = errors.New("resource name conflict")
+
+const (
+	// maxNameLength bounds the names of child resources so that they are valid DNS labels for every kind and
+	// leave room for the suffixes Kubernetes appends to the pods and revisions of StatefulSets
+	maxNameLength = 52
+
+	// uidHashLength is the number of hex characters of the claim UID hash in child resource names
+	uidHashLength = 8
+)
+
+// ResourceName returns the name of the child resource of the claim with the given role suffix (e.g. "postgres"),
+// <claim>-<suffix>-<hash>. The hash of the claim UID keeps the resources of a claim apart from those of an
+// earlier claim of the same name, and the claim name is truncated so that the result fits maxNameLength.
+// The name only depends on the claim, so repeated provisioning finds the resources it created.
+func ResourceName(claim *scorev1b1.ResourceClaim, suffix string) string {
+	sum := sha256.Sum256([]byte(claim.UID))
+	tail := "-" + suffix + "-" + hex.EncodeToString(sum[:])[:uidHashLength]
+
+	base := claim.Name
+	if len(base)+len(tail) > maxNameLength {
+		base = strings.TrimRight(base[:maxNameLength-len(tail)], "-.")
+	}
+	return base + tail
+}
+
+// CheckControlled returns an error wrapping ErrNameConflict unless the existing object is controlled by the
+// claim. Strategies call it before updating or publishing an object they found by name, so that a claim
+// fails instead of adopting the resources of someone else.
+func CheckControlled(claim *scorev1b1.ResourceClaim, obj metav1.Object, kind string) error {
+	if metav1.IsControlledBy(obj, claim) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s %s/%s exists and is not controlled by claim %s",
+		ErrNameConflict, kind, obj.GetNamespace(), obj.GetName(), claim.Name)
+}
@@ -0,0 +1,68 @@
+package strategy
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func namingClaim(name, uid string) *scorev1b1.ResourceClaim {
+	return &scorev1b1.ResourceClaim{
+		TypeMeta:   metav1.TypeMeta{APIVersion: scorev1b1.GroupVersion.String(), Kind: "ResourceClaim"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID(uid)},
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	claim := namingClaim("web-db", "uid-1")
+
+	name := ResourceName(claim, "postgres")
+	if name != ResourceName(namingClaim("web-db", "uid-1"), "postgres") {
+		t.Errorf("ResourceName() is not deterministic")
+	}
+	if !strings.HasPrefix(name, "web-db-postgres-") || len(name) != len("web-db-postgres-")+uidHashLength {
+		t.Errorf("ResourceName() = %q, want web-db-postgres-<hash>", name)
+	}
+	if other := ResourceName(namingClaim("web-db", "uid-2"), "postgres"); other == name {
+		t.Errorf("ResourceName() = %q for claims with different UIDs", other)
+	}
+
+	long := ResourceName(namingClaim(strings.Repeat("a", 40)+"-"+strings.Repeat("b", 40), "uid-1"), "postgres-service")
+	if len(long) > maxNameLength {
+		t.Errorf("ResourceName() = %q, longer than %d characters", long, maxNameLength)
+	}
+	if strings.Contains(long, "--") {
+		t.Errorf("ResourceName() = %q, want the truncated claim name without a trailing '-'", long)
+	}
+}
+
+func TestCheckControlled(t *testing.T) {
+	claim := namingClaim("web-db", "uid-1")
+
+	owned := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name: "web-db-secret", Namespace: "default",
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: scorev1b1.GroupVersion.String(), Kind: "ResourceClaim",
+			Name: "web-db", UID: claim.UID, Controller: ptr.To(true),
+		}},
+	}}
+	if err := CheckControlled(claim, owned, "secret"); err != nil {
+		t.Errorf("CheckControlled() error = %v for an object controlled by the claim", err)
+	}
+
+	// An object left behind by an earlier claim of the same name has another owner UID
+	stale := owned.DeepCopy()
+	stale.OwnerReferences[0].UID = "uid-0"
+	for _, obj := range []*corev1.Secret{stale, {ObjectMeta: metav1.ObjectMeta{Name: "web-db-secret", Namespace: "default"}}} {
+		if err := CheckControlled(claim, obj, "secret"); !errors.Is(err, ErrNameConflict) {
+			t.Errorf("CheckControlled() error = %v, want ErrNameConflict", err)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package strategy
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// DecodeClassParameters decodes the default parameters of the provisioner configured for the claim type
+// and then the parameters of the claim's class into out, so that parameters of the class take precedence.
+// The class defaults to the provisioner's default class; an unknown class is an error.
+func DecodeClassParameters(ctx context.Context, claim *scorev1b1.ResourceClaim, out any) error {
+	provisioner := ProvisionerFromContext(ctx)
+	if provisioner == nil {
+		return nil
+	}
+
+	className := ""
+	if provisioner.Defaults != nil {
+		className = provisioner.Defaults.Class
+		if err := decodeParameters(provisioner.Defaults.Params, out); err != nil {
+			return fmt.Errorf("invalid default parameters: %w", err)
+		}
+	}
+	if claim.Spec.Class != nil && *claim.Spec.Class != "" {
+		className = *claim.Spec.Class
+	}
+	if className == "" {
+		return nil
+	}
+	for _, class := range provisioner.Classes {
+		if class.Name == className {
+			if err := decodeParameters(class.Parameters, out); err != nil {
+				return fmt.Errorf("invalid parameters of class %s: %w", className, err)
+			}
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown %s class %q", claim.Spec.Type, className)
+}
+
+// DecodeParameters decodes the parameters of the claim's class like DecodeClassParameters and then the params
+// of the claim, which take precedence over both
+func DecodeParameters(ctx context.Context, claim *scorev1b1.ResourceClaim, out any) error {
+	if err := DecodeClassParameters(ctx, claim, out); err != nil {
+		return err
+	}
+	if claim.Spec.Params != nil && len(claim.Spec.Params.Raw) > 0 {
+		if err := json.Unmarshal(claim.Spec.Params.Raw, out); err != nil {
+			return fmt.Errorf("invalid %s params: %w", claim.Spec.Type, err)
+		}
+	}
+	return nil
+}
+
+// decodeParameters overlays the parameters set in raw onto out
+func decodeParameters(raw *runtime.RawExtension, out any) error {
+	if raw == nil || len(raw.Raw) == 0 {
+		return nil
+	}
+	return json.Unmarshal(raw.Raw, out)
+}
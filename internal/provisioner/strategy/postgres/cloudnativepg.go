@@ -0,0 +1,359 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+// ModeCloudNativePG provisions a CloudNativePG Cluster instead of the development StatefulSet
+const ModeCloudNativePG = "cloudnative-pg"
+
+const (
+	// defaultInstances is the number of PostgreSQL instances of a Cluster when the class sets none
+	defaultInstances = 3
+
+	// defaultStorage is the volume size of every instance when the class sets none
+	defaultStorage = "10Gi"
+
+	// defaultDatabase is the application database bootstrapped in a Cluster when the class sets none
+	defaultDatabase = "app"
+)
+
+// clusterGVK is the CloudNativePG Cluster kind
+var clusterGVK = schema.GroupVersionKind{Group: "postgresql.cnpg.io", Version: "v1", Kind: "Cluster"}
+
+// Parameters are the options of a PostgreSQL claim, read from the provisioner defaults and the claim's class
+type Parameters struct {
+	// Mode selects how the database is provisioned: "" for the development StatefulSet,
+	// "cloudnative-pg" for a Cluster of the CloudNativePG operator
+	Mode string `json:"mode,omitempty"`
+
+	// Instances is the number of PostgreSQL instances of the Cluster (default 3)
+	Instances int64 `json:"instances,omitempty"`
+
+	// Storage is the volume size of every instance (default 10Gi)
+	Storage string `json:"storage,omitempty"`
+
+	// StorageClass is the storage class of the volumes (default: the cluster default)
+	StorageClass string `json:"storageClass,omitempty"`
+
+	// ImageName overrides the PostgreSQL image chosen by the operator
+	ImageName string `json:"imageName,omitempty"`
+
+	// Database is the application database and the name of its owner (default "app")
+	Database string `json:"database,omitempty"`
+}
+
+// parametersFor returns the parameters of the claim's class
+func parametersFor(ctx context.Context, claim *scorev1b1.ResourceClaim) (*Parameters, error) {
+	params := &Parameters{}
+	if err := strategy.DecodeClassParameters(ctx, claim, params); err != nil {
+		return nil, err
+	}
+	switch params.Mode {
+	case "", ModeCloudNativePG:
+	default:
+		return nil, fmt.Errorf("unsupported postgres mode %q", params.Mode)
+	}
+	if params.Instances < 0 {
+		return nil, fmt.Errorf("invalid instances %d: must not be negative", params.Instances)
+	}
+	if params.Storage != "" {
+		if _, err := resource.ParseQuantity(params.Storage); err != nil {
+			return nil, fmt.Errorf("invalid storage %q: %w", params.Storage, err)
+		}
+	}
+	return params, nil
+}
+
+// provisionCluster applies the CloudNativePG Cluster of the claim and, once the operator reports it ready,
+// maps the application Secret generated by the operator to the connection Secret of the claim
+func (s *PostgresStrategy) provisionCluster(ctx context.Context, claim *scorev1b1.ResourceClaim, params *Parameters) (*scorev1b1.ResourceClaimOutputs, error) {
+	if err := s.applyCluster(ctx, claim, params); err != nil {
+		return nil, err
+	}
+
+	cluster, err := s.getCluster(ctx, claim)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get postgres cluster: %w", err)
+	}
+	if ready, _ := clusterReady(cluster); !ready {
+		return nil, strategy.ErrInProgress
+	}
+
+	appSecret := &corev1.Secret{}
+	if err := s.client.Get(ctx, client.ObjectKey{Name: clusterAppSecretName(claim), Namespace: claim.Namespace}, appSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, strategy.ErrInProgress
+		}
+		return nil, fmt.Errorf("failed to get operator secret: %w", err)
+	}
+
+	if err := s.applyMappedSecret(ctx, claim, mapClusterSecret(appSecret)); err != nil {
+		return nil, err
+	}
+	return &scorev1b1.ResourceClaimOutputs{
+		SecretRef: &scorev1b1.LocalObjectReference{Name: secretName(claim)},
+	}, nil
+}
+
+// applyCluster creates the Cluster of the claim, or updates the fields the class sets when they changed.
+// The bootstrap section only takes effect when the Cluster is created and is never updated.
+func (s *PostgresStrategy) applyCluster(ctx context.Context, claim *scorev1b1.ResourceClaim, params *Parameters) error {
+	desired := buildCluster(claim, params)
+
+	existing, err := s.getCluster(ctx, claim)
+	if apierrors.IsNotFound(err) {
+		// Set ResourceClaim as owner for garbage collection
+		if err := controllerutil.SetControllerReference(claim, desired, s.client.Scheme()); err != nil {
+			return fmt.Errorf("failed to set owner reference: %w", err)
+		}
+		if err := s.client.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create postgres cluster: %w", err)
+		}
+		return nil
+	} else if err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return fmt.Errorf("the CloudNativePG operator is not installed: %w", err)
+		}
+		return fmt.Errorf("failed to check existing postgres cluster: %w", err)
+	}
+	if err := strategy.CheckControlled(claim, existing, "postgres cluster"); err != nil {
+		return err
+	}
+
+	changed := strategy.SyncMetadata(existing, desired)
+	for _, field := range []string{"instances", "storage", "imageName"} {
+		want, wantFound, _ := unstructured.NestedFieldNoCopy(desired.Object, "spec", field)
+		got, gotFound, _ := unstructured.NestedFieldNoCopy(existing.Object, "spec", field)
+		if wantFound == gotFound && equality.Semantic.DeepEqual(want, got) {
+			continue
+		}
+		changed = true
+		if wantFound {
+			if err := unstructured.SetNestedField(existing.Object, want, "spec", field); err != nil {
+				return fmt.Errorf("failed to set %s of postgres cluster: %w", field, err)
+			}
+		} else {
+			unstructured.RemoveNestedField(existing.Object, "spec", field)
+		}
+	}
+	if !changed {
+		return nil
+	}
+	if err := s.client.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update postgres cluster: %w", err)
+	}
+	return nil
+}
+
+// buildCluster returns the CloudNativePG Cluster of the claim
+func buildCluster(claim *scorev1b1.ResourceClaim, params *Parameters) *unstructured.Unstructured {
+	instances := params.Instances
+	if instances == 0 {
+		instances = defaultInstances
+	}
+	storageSize := params.Storage
+	if storageSize == "" {
+		storageSize = defaultStorage
+	}
+	database := params.Database
+	if database == "" {
+		database = defaultDatabase
+	}
+
+	storage := map[string]interface{}{"size": storageSize}
+	if params.StorageClass != "" {
+		storage["storageClass"] = params.StorageClass
+	}
+	spec := map[string]interface{}{
+		"instances": instances,
+		"storage":   storage,
+		"bootstrap": map[string]interface{}{
+			"initdb": map[string]interface{}{
+				"database": database,
+				"owner":    database,
+			},
+		},
+		// Selected by the network policy restricting access to the claiming Workload
+		"inheritedMetadata": map[string]interface{}{
+			"labels": map[string]interface{}{"score.dev/resource-claim": claim.Name},
+		},
+	}
+	if params.ImageName != "" {
+		spec["imageName"] = params.ImageName
+	}
+
+	cluster := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	cluster.SetGroupVersionKind(clusterGVK)
+	cluster.SetName(clusterName(claim))
+	cluster.SetNamespace(claim.Namespace)
+	cluster.SetLabels(strategy.Labels(claim, "postgres"))
+	cluster.SetAnnotations(strategy.Annotations(claim))
+	return cluster
+}
+
+// getCluster returns the Cluster of the claim
+func (s *PostgresStrategy) getCluster(ctx context.Context, claim *scorev1b1.ResourceClaim) (*unstructured.Unstructured, error) {
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(clusterGVK)
+	if err := s.client.Get(ctx, client.ObjectKey{Name: clusterName(claim), Namespace: claim.Namespace}, cluster); err != nil {
+		return nil, err
+	}
+	return cluster, nil
+}
+
+// clusterReady reports whether the operator reports the Cluster ready, with the phase it reports
+func clusterReady(cluster *unstructured.Unstructured) (bool, string) {
+	phase, _, _ := unstructured.NestedString(cluster.Object, "status", "phase")
+	rawConditions, _, _ := unstructured.NestedSlice(cluster.Object, "status", "conditions")
+	for _, raw := range rawConditions {
+		condition, ok := raw.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		return condition["status"] == string(metav1.ConditionTrue), phase
+	}
+	// Operator releases without the Ready condition only report the phase
+	return phase == "Cluster in healthy state", phase
+}
+
+// getClusterStatus returns the status of the Cluster of the claim
+func (s *PostgresStrategy) getClusterStatus(ctx context.Context, claim *scorev1b1.ResourceClaim) (phase scorev1b1.ResourceClaimPhase, reason, message string, err error) {
+	cluster, err := s.getCluster(ctx, claim)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return scorev1b1.ResourceClaimPhaseClaiming, "ClusterCreating",
+				"PostgreSQL cluster is being created", nil
+		}
+		if apimeta.IsNoMatchError(err) {
+			return scorev1b1.ResourceClaimPhaseFailed, "OperatorNotInstalled",
+				"The CloudNativePG operator is not installed", nil
+		}
+		return scorev1b1.ResourceClaimPhaseFailed, "ClusterAccessFailed",
+			fmt.Sprintf("Failed to access postgres cluster: %v", err), err
+	}
+
+	if ready, operatorPhase := clusterReady(cluster); !ready {
+		message := "PostgreSQL cluster is not ready yet"
+		if operatorPhase != "" {
+			message = fmt.Sprintf("PostgreSQL cluster is not ready yet: %s", operatorPhase)
+		}
+		return scorev1b1.ResourceClaimPhaseClaiming, "ClusterNotReady", message, nil
+	}
+
+	err = s.client.Get(ctx, client.ObjectKey{Name: clusterAppSecretName(claim), Namespace: claim.Namespace}, &corev1.Secret{})
+	if err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return scorev1b1.ResourceClaimPhaseFailed, "SecretAccessFailed",
+				fmt.Sprintf("Failed to access operator secret: %v", err), err
+		}
+		return scorev1b1.ResourceClaimPhaseClaiming, "SecretCreating",
+			"PostgreSQL credentials are being created by the operator", nil
+	}
+
+	return scorev1b1.ResourceClaimPhaseBound, "Succeeded",
+		"PostgreSQL cluster is ready and available", nil
+}
+
+// mapClusterSecret maps the application Secret generated by the operator to the keys of the development mode,
+// so that Workloads consume both modes alike. Clients connect through the read-write Service of the Cluster.
+func mapClusterSecret(appSecret *corev1.Secret) map[string][]byte {
+	data := map[string][]byte{
+		"username": appSecret.Data["username"],
+		"password": appSecret.Data["password"],
+		"host":     appSecret.Data["host"],
+		"port":     appSecret.Data["port"],
+		"database": appSecret.Data["dbname"],
+	}
+	uri := url.URL{
+		Scheme: "postgresql",
+		User:   url.UserPassword(string(data["username"]), string(data["password"])),
+		Host:   string(data["host"]) + ":" + string(data["port"]),
+		Path:   "/" + string(data["database"]),
+	}
+	data["uri"] = []byte(uri.String())
+	return data
+}
+
+// applyMappedSecret creates the connection Secret of the claim, or updates its data and metadata when they changed
+func (s *PostgresStrategy) applyMappedSecret(ctx context.Context, claim *scorev1b1.ResourceClaim, data map[string][]byte) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secretName(claim),
+			Namespace:   claim.Namespace,
+			Labels:      strategy.Labels(claim, "postgres"),
+			Annotations: strategy.Annotations(claim),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+
+	existing := &corev1.Secret{}
+	err := s.client.Get(ctx, client.ObjectKeyFromObject(secret), existing)
+	if err == nil {
+		if err := strategy.CheckControlled(claim, existing, "secret"); err != nil {
+			return err
+		}
+		if !strategy.SyncMetadata(existing, secret) && equality.Semantic.DeepEqual(existing.Data, data) {
+			return nil
+		}
+		existing.Data = data
+		if err := s.client.Update(ctx, existing); err != nil {
+			return fmt.Errorf("failed to update secret: %w", err)
+		}
+		return nil
+	} else if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to check existing secret: %w", err)
+	}
+
+	if err := controllerutil.SetControllerReference(claim, secret, s.client.Scheme()); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	if err := s.client.Create(ctx, secret); err != nil {
+		return fmt.Errorf("failed to create secret: %w", err)
+	}
+	return nil
+}
+
+// deleteCluster deletes the Cluster of the claim; nothing is left to delete when the operator is not installed
+func (s *PostgresStrategy) deleteCluster(ctx context.Context, claim *scorev1b1.ResourceClaim) error {
+	cluster := &unstructured.Unstructured{}
+	cluster.SetGroupVersionKind(clusterGVK)
+	cluster.SetName(clusterName(claim))
+	cluster.SetNamespace(claim.Namespace)
+	if err := s.client.Delete(ctx, cluster); err != nil && !apierrors.IsNotFound(err) && !apimeta.IsNoMatchError(err) {
+		return err
+	}
+	return nil
+}
+
+// clusterName is the name of the Cluster of the claim, like the StatefulSet of the development mode
+func clusterName(claim *scorev1b1.ResourceClaim) string {
+	return statefulSetName(claim)
+}
+
+// clusterAppSecretName is the application Secret the operator generates for the Cluster
+func clusterAppSecretName(claim *scorev1b1.ResourceClaim) string {
+	return clusterName(claim) + "-app"
+}
+
+// secretName is the connection Secret of the claim in both modes
+func secretName(claim *scorev1b1.ResourceClaim) string {
+	return strategy.ResourceName(claim, "postgres-secret")
+}
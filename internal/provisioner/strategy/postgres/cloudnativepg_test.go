@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+func TestProvisionCloudNativePGCluster(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	s := NewPostgresStrategy(c)
+
+	ctx := strategy.WithProvisioner(context.Background(), &scorev1b1.ProvisionerSpec{
+		Type: "postgres",
+		Classes: []scorev1b1.ClassSpec{{
+			Name:       "production",
+			Parameters: &runtime.RawExtension{Raw: []byte(`{"mode":"cloudnative-pg","instances":2,"storage":"20Gi"}`)},
+		}},
+	})
+	class := "production"
+	claim := &scorev1b1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-db", Namespace: "default", UID: "uid"},
+		Spec:       scorev1b1.ResourceClaimSpec{Key: "db", Type: "postgres", Class: &class},
+	}
+
+	// The claim waits for the operator until the Cluster is ready
+	if _, err := s.Provision(ctx, claim); !errors.Is(err, strategy.ErrInProgress) {
+		t.Fatalf("Provision() error = %v, want ErrInProgress", err)
+	}
+	cluster, err := s.getCluster(ctx, claim)
+	if err != nil {
+		t.Fatalf("cluster was not created: %v", err)
+	}
+	if instances, _, _ := unstructured.NestedInt64(cluster.Object, "spec", "instances"); instances != 2 {
+		t.Errorf("instances = %d, want 2", instances)
+	}
+	if size, _, _ := unstructured.NestedString(cluster.Object, "spec", "storage", "size"); size != "20Gi" {
+		t.Errorf("storage size = %s, want 20Gi", size)
+	}
+	if phase, reason, _, err := s.GetStatus(ctx, claim); err != nil || phase != scorev1b1.ResourceClaimPhaseClaiming {
+		t.Errorf("GetStatus() = %s (%s), %v, want Claiming", phase, reason, err)
+	}
+
+	// The operator reports the Cluster ready and generates the application Secret
+	if err := unstructured.SetNestedSlice(cluster.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True"},
+	}, "status", "conditions"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Update(ctx, cluster); err != nil {
+		t.Fatal(err)
+	}
+	appSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: clusterAppSecretName(claim), Namespace: "default"},
+		Data: map[string][]byte{
+			"username": []byte("app"), "password": []byte("s3cr3t"), "host": []byte(clusterName(claim) + "-rw"),
+			"port": []byte("5432"), "dbname": []byte("app"),
+		},
+	}
+	if err := c.Create(ctx, appSecret); err != nil {
+		t.Fatal(err)
+	}
+
+	if phase, reason, _, err := s.GetStatus(ctx, claim); err != nil || phase != scorev1b1.ResourceClaimPhaseBound {
+		t.Errorf("GetStatus() = %s (%s), %v, want Bound", phase, reason, err)
+	}
+	outputs, err := s.Provision(ctx, claim)
+	if err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+	if outputs.SecretRef == nil || outputs.SecretRef.Name != secretName(claim) {
+		t.Fatalf("secretRef = %+v, want %s", outputs.SecretRef, secretName(claim))
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Name: secretName(claim), Namespace: "default"}, secret); err != nil {
+		t.Fatal(err)
+	}
+	if got := string(secret.Data["database"]); got != "app" {
+		t.Errorf("database = %q, want the operator dbname", got)
+	}
+	if got, want := string(secret.Data["uri"]), "postgresql://app:s3cr3t@"+clusterName(claim)+"-rw:5432/app"; got != want {
+		t.Errorf("uri = %q, want %q", got, want)
+	}
+
+	if err := s.Deprovision(ctx, claim); err != nil {
+		t.Fatalf("Deprovision() error = %v", err)
+	}
+	if _, err := s.getCluster(ctx, claim); err == nil {
+		t.Error("cluster still exists after Deprovision")
+	}
+}
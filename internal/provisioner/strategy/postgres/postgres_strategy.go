@@ -15,8 +15,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
 )
 
+func init() {
+	strategy.Register("postgres", func(c client.Client) strategy.Strategy { return NewPostgresStrategy(c) })
+}
+
 // PostgresStrategy implements the Strategy interface for PostgreSQL provisioning
 type PostgresStrategy struct {
 	client client.Client
@@ -34,8 +39,17 @@ func (s *PostgresStrategy) GetType() string {
 	return "postgres"
 }
 
-// Provision creates PostgreSQL development resources: StatefulSet, Service, and Secret
+// Provision creates PostgreSQL development resources: StatefulSet, Service, and Secret.
+// Classes with mode "cloudnative-pg" get a CloudNativePG Cluster instead.
 func (s *PostgresStrategy) Provision(ctx context.Context, claim *scorev1b1.ResourceClaim) (*scorev1b1.ResourceClaimOutputs, error) {
+	params, err := parametersFor(ctx, claim)
+	if err != nil {
+		return nil, err
+	}
+	if params.Mode == ModeCloudNativePG {
+		return s.provisionCluster(ctx, claim, params)
+	}
+
 	// Generate database credentials
 	username := "postgres"
 	password, err := generateRandomPassword(16)
@@ -43,7 +57,7 @@ func (s *PostgresStrategy) Provision(ctx context.Context, claim *scorev1b1.Resou
 		return nil, fmt.Errorf("failed to generate password: %w", err)
 	}
 
-	host := fmt.Sprintf("%s-postgres-service", claim.Name)
+	host := serviceName(claim)
 	port := "5432"
 
 	// Create StatefulSet for PostgreSQL
@@ -59,12 +73,11 @@ func (s *PostgresStrategy) Provision(ctx context.Context, claim *scorev1b1.Resou
 	// Create Secret with database credentials
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-postgres-secret", claim.Name),
+			Name:      secretName(claim),
 			Namespace: claim.Namespace,
-			Labels: map[string]string{
-				"score.dev/resource-claim": claim.Name,
-				"score.dev/resource-type":  "postgres",
-			},
+			Labels:    strategy.Labels(claim, "postgres"),
+			// The generated password is not recorded anywhere else
+			Annotations: strategy.RetainedSecretAnnotations(claim),
 		},
 		Type: corev1.SecretTypeOpaque,
 		Data: map[string][]byte{
@@ -94,6 +107,12 @@ func (s *PostgresStrategy) Provision(ctx context.Context, claim *scorev1b1.Resou
 		if err := s.client.Create(ctx, secret); err != nil {
 			return nil, fmt.Errorf("failed to create secret: %w", err)
 		}
+	} else if err := strategy.CheckControlled(claim, existing, "secret"); err != nil {
+		return nil, err
+	} else if strategy.SyncMetadata(existing, secret) {
+		if err := s.client.Update(ctx, existing); err != nil {
+			return nil, fmt.Errorf("failed to update secret: %w", err)
+		}
 	}
 
 	// Return outputs pointing to the created Secret
@@ -106,12 +125,17 @@ func (s *PostgresStrategy) Provision(ctx context.Context, claim *scorev1b1.Resou
 	return outputs, nil
 }
 
-// Deprovision cleans up all PostgreSQL resources: StatefulSet, Service, and Secret
+// Deprovision cleans up all PostgreSQL resources: Cluster, StatefulSet, Service, and Secret
 func (s *PostgresStrategy) Deprovision(ctx context.Context, claim *scorev1b1.ResourceClaim) error {
+	// The class of the claim may have changed since it was provisioned, so resources of both modes are deleted
+	if err := s.deleteCluster(ctx, claim); err != nil {
+		return fmt.Errorf("failed to delete postgres cluster: %w", err)
+	}
+
 	// Delete StatefulSet
 	statefulSet := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-postgres", claim.Name),
+			Name:      statefulSetName(claim),
 			Namespace: claim.Namespace,
 		},
 	}
@@ -123,7 +147,7 @@ func (s *PostgresStrategy) Deprovision(ctx context.Context, claim *scorev1b1.Res
 	// Delete Service
 	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-postgres-service", claim.Name),
+			Name:      serviceName(claim),
 			Namespace: claim.Namespace,
 		},
 	}
@@ -135,7 +159,7 @@ func (s *PostgresStrategy) Deprovision(ctx context.Context, claim *scorev1b1.Res
 	// Delete Secret
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-postgres-secret", claim.Name),
+			Name:      secretName(claim),
 			Namespace: claim.Namespace,
 		},
 	}
@@ -149,12 +173,19 @@ func (s *PostgresStrategy) Deprovision(ctx context.Context, claim *scorev1b1.Res
 
 // GetStatus returns the current status of the PostgreSQL resource
 func (s *PostgresStrategy) GetStatus(ctx context.Context, claim *scorev1b1.ResourceClaim) (phase scorev1b1.ResourceClaimPhase, reason, message string, err error) {
+	params, err := parametersFor(ctx, claim)
+	if err != nil {
+		return scorev1b1.ResourceClaimPhaseFailed, "InvalidParameters", err.Error(), nil
+	}
+	if params.Mode == ModeCloudNativePG {
+		return s.getClusterStatus(ctx, claim)
+	}
+
 	// Check Secret
-	secretName := fmt.Sprintf("%s-postgres-secret", claim.Name)
 	secret := &corev1.Secret{}
 
 	err = s.client.Get(ctx, client.ObjectKey{
-		Name:      secretName,
+		Name:      secretName(claim),
 		Namespace: claim.Namespace,
 	}, secret)
 
@@ -169,11 +200,10 @@ func (s *PostgresStrategy) GetStatus(ctx context.Context, claim *scorev1b1.Resou
 	}
 
 	// Check StatefulSet
-	statefulSetName := fmt.Sprintf("%s-postgres", claim.Name)
 	statefulSet := &appsv1.StatefulSet{}
 
 	err = s.client.Get(ctx, client.ObjectKey{
-		Name:      statefulSetName,
+		Name:      statefulSetName(claim),
 		Namespace: claim.Namespace,
 	}, statefulSet)
 
@@ -194,11 +224,10 @@ func (s *PostgresStrategy) GetStatus(ctx context.Context, claim *scorev1b1.Resou
 	}
 
 	// Check Service
-	serviceName := fmt.Sprintf("%s-postgres-service", claim.Name)
 	service := &corev1.Service{}
 
 	err = s.client.Get(ctx, client.ObjectKey{
-		Name:      serviceName,
+		Name:      serviceName(claim),
 		Namespace: claim.Namespace,
 	}, service)
 
@@ -239,26 +268,25 @@ func generateRandomPassword(length int) (string, error) {
 func (s *PostgresStrategy) createStatefulSet(ctx context.Context, claim *scorev1b1.ResourceClaim, username, password string) error {
 	statefulSet := &appsv1.StatefulSet{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-postgres", claim.Name),
-			Namespace: claim.Namespace,
-			Labels: map[string]string{
-				"score.dev/resource-claim": claim.Name,
-				"score.dev/resource-type":  "postgres",
-				"app":                      fmt.Sprintf("%s-postgres", claim.Name),
-			},
+			Name:        statefulSetName(claim),
+			Namespace:   claim.Namespace,
+			Labels:      labels(claim),
+			Annotations: strategy.Annotations(claim),
 		},
 		Spec: appsv1.StatefulSetSpec{
-			ServiceName: fmt.Sprintf("%s-postgres-service", claim.Name),
+			ServiceName: serviceName(claim),
 			Replicas:    int32Ptr(1),
 			Selector: &metav1.LabelSelector{
 				MatchLabels: map[string]string{
-					"app": fmt.Sprintf("%s-postgres", claim.Name),
+					"app": statefulSetName(claim),
 				},
 			},
 			Template: corev1.PodTemplateSpec{
 				ObjectMeta: metav1.ObjectMeta{
 					Labels: map[string]string{
-						"app": fmt.Sprintf("%s-postgres", claim.Name),
+						"app": statefulSetName(claim),
+						// Selected by the network policy restricting access to the claiming Workload
+						"score.dev/resource-claim": claim.Name,
 					},
 				},
 				Spec: corev1.PodSpec{
@@ -392,6 +420,12 @@ func (s *PostgresStrategy) createStatefulSet(ctx context.Context, claim *scorev1
 		if err := s.client.Create(ctx, statefulSet); err != nil {
 			return fmt.Errorf("failed to create statefulset: %w", err)
 		}
+	} else if err := strategy.CheckControlled(claim, existing, "statefulset"); err != nil {
+		return err
+	} else if strategy.SyncMetadata(existing, statefulSet) {
+		if err := s.client.Update(ctx, existing); err != nil {
+			return fmt.Errorf("failed to update statefulset: %w", err)
+		}
 	}
 
 	return nil
@@ -401,13 +435,10 @@ func (s *PostgresStrategy) createStatefulSet(ctx context.Context, claim *scorev1
 func (s *PostgresStrategy) createService(ctx context.Context, claim *scorev1b1.ResourceClaim) error {
 	service := &corev1.Service{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-postgres-service", claim.Name),
-			Namespace: claim.Namespace,
-			Labels: map[string]string{
-				"score.dev/resource-claim": claim.Name,
-				"score.dev/resource-type":  "postgres",
-				"app":                      fmt.Sprintf("%s-postgres", claim.Name),
-			},
+			Name:        serviceName(claim),
+			Namespace:   claim.Namespace,
+			Labels:      labels(claim),
+			Annotations: strategy.Annotations(claim),
 		},
 		Spec: corev1.ServiceSpec{
 			Type: corev1.ServiceTypeClusterIP,
@@ -420,7 +451,7 @@ func (s *PostgresStrategy) createService(ctx context.Context, claim *scorev1b1.R
 				},
 			},
 			Selector: map[string]string{
-				"app": fmt.Sprintf("%s-postgres", claim.Name),
+				"app": statefulSetName(claim),
 			},
 		},
 	}
@@ -441,11 +472,33 @@ func (s *PostgresStrategy) createService(ctx context.Context, claim *scorev1b1.R
 		if err := s.client.Create(ctx, service); err != nil {
 			return fmt.Errorf("failed to create service: %w", err)
 		}
+	} else if err := strategy.CheckControlled(claim, existing, "service"); err != nil {
+		return err
+	} else if strategy.SyncMetadata(existing, service) {
+		if err := s.client.Update(ctx, existing); err != nil {
+			return fmt.Errorf("failed to update service: %w", err)
+		}
 	}
 
 	return nil
 }
 
+// labels returns the labels of the development StatefulSet and Service of the claim
+func labels(claim *scorev1b1.ResourceClaim) map[string]string {
+	labels := strategy.Labels(claim, "postgres")
+	labels["app"] = statefulSetName(claim)
+	return labels
+}
+
+// statefulSetName is the name of the development StatefulSet, and of the pods it selects
+func statefulSetName(claim *scorev1b1.ResourceClaim) string {
+	return strategy.ResourceName(claim, "postgres")
+}
+
+func serviceName(claim *scorev1b1.ResourceClaim) string {
+	return strategy.ResourceName(claim, "postgres-service")
+}
+
 // Helper functions for pointer values
 func int32Ptr(i int32) *int32 {
 	return &i
@@ -0,0 +1,63 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+// defaultMemory is the memory limit of the Redis container when the class sets none
+const defaultMemory = "256Mi"
+
+// maxMemoryPercent is the share of the container memory limit Redis may use for data, leaving
+// headroom for fragmentation, replication buffers and the process itself
+const maxMemoryPercent = 75
+
+// Parameters are the options of a Redis instance, read from the provisioner defaults and the claim's class
+type Parameters struct {
+	// Auth requires clients to authenticate with the generated password (default true)
+	Auth *bool `json:"auth,omitempty"`
+
+	// TLS serves Redis over TLS only, with a certificate issued by a generated CA (default false)
+	TLS bool `json:"tls,omitempty"`
+
+	// Memory is the memory limit of the Redis container (default 256Mi)
+	Memory string `json:"memory,omitempty"`
+
+	// MaxMemoryPolicy is the eviction policy once maxmemory is reached (Redis default noeviction)
+	MaxMemoryPolicy string `json:"maxMemoryPolicy,omitempty"`
+}
+
+// authEnabled reports whether clients must authenticate
+func (p *Parameters) authEnabled() bool {
+	return p.Auth == nil || *p.Auth
+}
+
+// memory returns the memory limit of the container and the maxmemory of Redis in bytes
+func (p *Parameters) memory() (resource.Quantity, int64, error) {
+	value := p.Memory
+	if value == "" {
+		value = defaultMemory
+	}
+	limit, err := resource.ParseQuantity(value)
+	if err != nil {
+		return resource.Quantity{}, 0, fmt.Errorf("invalid memory %q: %w", value, err)
+	}
+	if limit.Sign() <= 0 {
+		return resource.Quantity{}, 0, fmt.Errorf("invalid memory %q: must be positive", value)
+	}
+	return limit, limit.Value() * maxMemoryPercent / 100, nil
+}
+
+// parametersFor returns the parameters of the claim's class
+func parametersFor(ctx context.Context, claim *scorev1b1.ResourceClaim) (*Parameters, error) {
+	params := &Parameters{}
+	if err := strategy.DecodeClassParameters(ctx, claim, params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
@@ -3,26 +3,57 @@ package redis
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"strconv"
+	"time"
 
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
 )
 
+const (
+	// redisImage is the image of provisioned Redis instances
+	redisImage = "redis:7-alpine"
+
+	// redisPort is the port Redis serves on, over TLS when enabled
+	redisPort = 6379
+
+	// tlsMountPath is where the TLS Secret is mounted in the Redis container
+	tlsMountPath = "/etc/redis/tls"
+
+	// annotationCertificateHash on the pod template rolls out new pods when the certificate is replaced
+	annotationCertificateHash = "score.dev/certificate-hash"
+)
+
+func init() {
+	strategy.Register("redis", func(c client.Client) strategy.Strategy { return NewRedisStrategy(c) })
+}
+
 // RedisStrategy implements the Strategy interface for Redis provisioning
 type RedisStrategy struct {
 	client client.Client
+
+	// now returns the current time; replaced in tests
+	now func() time.Time
 }
 
 // NewRedisStrategy creates a new RedisStrategy
 func NewRedisStrategy(k8sClient client.Client) *RedisStrategy {
 	return &RedisStrategy{
 		client: k8sClient,
+		now:    time.Now,
 	}
 }
 
@@ -31,55 +62,76 @@ func (s *RedisStrategy) GetType() string {
 	return "redis"
 }
 
-// Provision creates a Secret with Redis credentials and connection details
+// Provision creates a Redis development instance (Deployment and Service) configured by the parameters of the
+// claim's class, and a Secret with its connection details. The password is generated once and kept across
+// reconciles; with TLS, a certificate issued by a generated CA is stored in a TLS Secret.
 func (s *RedisStrategy) Provision(ctx context.Context, claim *scorev1b1.ResourceClaim) (*scorev1b1.ResourceClaimOutputs, error) {
-	// Generate redis credentials
-	password, err := generateRandomPassword(16)
+	params, err := parametersFor(ctx, claim)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate password: %w", err)
+		return nil, err
+	}
+	memoryLimit, maxMemory, err := params.memory()
+	if err != nil {
+		return nil, err
 	}
 
-	// For Phase 1, use mock values for host and port
-	// In later phases, this could create actual Redis/ElastiCache resources
-	host := fmt.Sprintf("%s-redis-service", claim.Name)
-	port := "6379"
+	secret, err := s.getSecret(ctx, claim, secretName(claim))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing secret: %w", err)
+	}
+	password := ""
+	if params.authEnabled() {
+		password = string(secret.Data["password"])
+		if password == "" {
+			if password, err = generateRandomPassword(16); err != nil {
+				return nil, fmt.Errorf("failed to generate password: %w", err)
+			}
+		}
+	}
 
-	// Create Secret with redis credentials
-	secret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-redis-secret", claim.Name),
-			Namespace: claim.Namespace,
-			Labels: map[string]string{
-				"score.dev/resource-claim": claim.Name,
-				"score.dev/resource-type":  "redis",
-			},
-		},
-		Type: corev1.SecretTypeOpaque,
-		Data: map[string][]byte{
-			"password": []byte(password),
-			"host":     []byte(host),
-			"port":     []byte(port),
-			// Connection string for convenience
-			"uri": []byte(fmt.Sprintf("redis://:%s@%s:%s", password, host, port)),
-		},
+	var tlsSecret *corev1.Secret
+	if params.TLS {
+		if tlsSecret, err = s.ensureTLSSecret(ctx, claim); err != nil {
+			return nil, err
+		}
+	} else if err := s.deleteObject(ctx, &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: tlsSecretName(claim), Namespace: claim.Namespace}}); err != nil {
+		return nil, fmt.Errorf("failed to delete redis tls secret: %w", err)
 	}
 
-	// Set ResourceClaim as owner for garbage collection
-	if err := controllerutil.SetControllerReference(claim, secret, s.client.Scheme()); err != nil {
-		return nil, fmt.Errorf("failed to set owner reference: %w", err)
+	host := serviceName(claim)
+	port := strconv.Itoa(redisPort)
+	scheme := "redis"
+	if params.TLS {
+		scheme = "rediss"
+	}
+	uri := fmt.Sprintf("%s://%s:%s", scheme, host, port)
+	if password != "" {
+		uri = fmt.Sprintf("%s://:%s@%s:%s", scheme, password, host, port)
+	}
+	data := map[string][]byte{
+		"host":      []byte(host),
+		"port":      []byte(port),
+		"tls":       []byte(strconv.FormatBool(params.TLS)),
+		"maxmemory": []byte(strconv.FormatInt(maxMemory, 10)),
+		// Connection string for convenience
+		"uri": []byte(uri),
+	}
+	if password != "" {
+		data["password"] = []byte(password)
+	}
+	if tlsSecret != nil {
+		data["caCert"] = tlsSecret.Data[caCertKey]
 	}
 
-	// Create or update the Secret
-	existing := &corev1.Secret{}
-	err = s.client.Get(ctx, client.ObjectKeyFromObject(secret), existing)
-	if err != nil {
-		if client.IgnoreNotFound(err) != nil {
-			return nil, fmt.Errorf("failed to check existing secret: %w", err)
-		}
-		// Secret doesn't exist, create it
-		if err := s.client.Create(ctx, secret); err != nil {
-			return nil, fmt.Errorf("failed to create secret: %w", err)
-		}
+	// The Secret is written first: the Redis container reads its password from it
+	if err := s.applySecret(ctx, claim, secret, data); err != nil {
+		return nil, err
+	}
+	if err := s.applyDeployment(ctx, claim, params, memoryLimit, maxMemory, tlsSecret); err != nil {
+		return nil, fmt.Errorf("failed to create redis deployment: %w", err)
+	}
+	if err := s.createService(ctx, claim); err != nil {
+		return nil, fmt.Errorf("failed to create redis service: %w", err)
 	}
 
 	// Return outputs pointing to the created Secret
@@ -88,23 +140,29 @@ func (s *RedisStrategy) Provision(ctx context.Context, claim *scorev1b1.Resource
 			Name: secret.Name,
 		},
 	}
+	if tlsSecret != nil {
+		// The CA certificate is public; the private key stays in the TLS Secret
+		outputs.Cert = &scorev1b1.CertificateOutput{
+			SecretName: &tlsSecret.Name,
+			Data:       map[string][]byte{caCertKey: tlsSecret.Data[caCertKey]},
+		}
+	}
 
 	return outputs, nil
 }
 
 // Deprovision cleans up the Redis resources
 func (s *RedisStrategy) Deprovision(ctx context.Context, claim *scorev1b1.ResourceClaim) error {
-	secretName := fmt.Sprintf("%s-redis-secret", claim.Name)
-	secret := &corev1.Secret{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      secretName,
-			Namespace: claim.Namespace,
-		},
+	objects := []client.Object{
+		&appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: deploymentName(claim), Namespace: claim.Namespace}},
+		&corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: serviceName(claim), Namespace: claim.Namespace}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: tlsSecretName(claim), Namespace: claim.Namespace}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName(claim), Namespace: claim.Namespace}},
 	}
-
-	err := s.client.Delete(ctx, secret)
-	if client.IgnoreNotFound(err) != nil {
-		return fmt.Errorf("failed to delete redis secret: %w", err)
+	for _, object := range objects {
+		if err := s.deleteObject(ctx, object); err != nil {
+			return fmt.Errorf("failed to delete redis %T %s: %w", object, object.GetName(), err)
+		}
 	}
 
 	return nil
@@ -112,11 +170,9 @@ func (s *RedisStrategy) Deprovision(ctx context.Context, claim *scorev1b1.Resour
 
 // GetStatus returns the current status of the Redis resource
 func (s *RedisStrategy) GetStatus(ctx context.Context, claim *scorev1b1.ResourceClaim) (phase scorev1b1.ResourceClaimPhase, reason, message string, err error) {
-	secretName := fmt.Sprintf("%s-redis-secret", claim.Name)
 	secret := &corev1.Secret{}
-
 	err = s.client.Get(ctx, client.ObjectKey{
-		Name:      secretName,
+		Name:      secretName(claim),
 		Namespace: claim.Namespace,
 	}, secret)
 
@@ -130,9 +186,365 @@ func (s *RedisStrategy) GetStatus(ctx context.Context, claim *scorev1b1.Resource
 			"Redis secret is being created", nil
 	}
 
-	// Secret exists and is ready
+	deployment := &appsv1.Deployment{}
+	err = s.client.Get(ctx, client.ObjectKey{
+		Name:      deploymentName(claim),
+		Namespace: claim.Namespace,
+	}, deployment)
+
+	if err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return scorev1b1.ResourceClaimPhaseFailed, "DeploymentAccessFailed",
+				fmt.Sprintf("Failed to access redis deployment: %v", err), err
+		}
+		return scorev1b1.ResourceClaimPhaseClaiming, "DeploymentCreating",
+			"Redis Deployment is being created", nil
+	}
+
+	// Check if the Deployment is ready
+	if deployment.Spec.Replicas != nil && deployment.Status.ReadyReplicas < *deployment.Spec.Replicas {
+		return scorev1b1.ResourceClaimPhaseClaiming, "DeploymentNotReady",
+			"Redis Deployment is not ready yet", nil
+	}
+
+	// Secret exists and the instance is ready
 	return scorev1b1.ResourceClaimPhaseBound, "Succeeded",
-		"Redis credentials are available", nil
+		"Redis instance is ready and available", nil
+}
+
+// getSecret returns the named Secret of the claim, or an empty Secret with that name when it does not exist.
+// A Secret of that name controlled by something else is a conflict, so its data is never read.
+func (s *RedisStrategy) getSecret(ctx context.Context, claim *scorev1b1.ResourceClaim, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	err := s.client.Get(ctx, client.ObjectKey{Name: name, Namespace: claim.Namespace}, secret)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, err
+	}
+	if err != nil {
+		return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: claim.Namespace}}, nil
+	}
+	if err := strategy.CheckControlled(claim, secret, "secret"); err != nil {
+		return nil, err
+	}
+	return secret, nil
+}
+
+// applySecret creates the connection Secret of the claim, or updates its data and metadata when they changed
+func (s *RedisStrategy) applySecret(ctx context.Context, claim *scorev1b1.ResourceClaim, secret *corev1.Secret, data map[string][]byte) error {
+	// The server reads its password from the Secret
+	desired := &metav1.ObjectMeta{Labels: labels(claim), Annotations: strategy.RetainedSecretAnnotations(claim)}
+	if secret.ResourceVersion != "" {
+		if !strategy.SyncMetadata(secret, desired) && equality.Semantic.DeepEqual(secret.Data, data) {
+			return nil
+		}
+		secret.Data = data
+		if err := s.client.Update(ctx, secret); err != nil {
+			return fmt.Errorf("failed to update secret: %w", err)
+		}
+		return nil
+	}
+
+	secret.Labels = desired.Labels
+	secret.Annotations = desired.Annotations
+	secret.Type = corev1.SecretTypeOpaque
+	secret.Data = data
+	// Set ResourceClaim as owner for garbage collection
+	if err := controllerutil.SetControllerReference(claim, secret, s.client.Scheme()); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	if err := s.client.Create(ctx, secret); err != nil {
+		return fmt.Errorf("failed to create secret: %w", err)
+	}
+	return nil
+}
+
+// ensureTLSSecret returns the TLS Secret of the claim, issuing a new certificate when there is none or it expires soon
+func (s *RedisStrategy) ensureTLSSecret(ctx context.Context, claim *scorev1b1.ResourceClaim) (*corev1.Secret, error) {
+	secret, err := s.getSecret(ctx, claim, tlsSecretName(claim))
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing tls secret: %w", err)
+	}
+	now := s.now()
+	dnsNames := serviceDNSNames(claim)
+	if secret.ResourceVersion != "" && certificateCurrent(secret, dnsNames, now) {
+		if strategy.SyncMetadata(secret, objectMeta(claim)) {
+			if err := s.client.Update(ctx, secret); err != nil {
+				return nil, fmt.Errorf("failed to update tls secret: %w", err)
+			}
+		}
+		return secret, nil
+	}
+
+	caPEM, certPEM, keyPEM, err := generateCertificate(dnsNames, now)
+	if err != nil {
+		return nil, err
+	}
+	data := map[string][]byte{
+		caCertKey:               caPEM,
+		corev1.TLSCertKey:       certPEM,
+		corev1.TLSPrivateKeyKey: keyPEM,
+	}
+	if secret.ResourceVersion != "" {
+		strategy.SyncMetadata(secret, objectMeta(claim))
+		secret.Data = data
+		if err := s.client.Update(ctx, secret); err != nil {
+			return nil, fmt.Errorf("failed to update tls secret: %w", err)
+		}
+		return secret, nil
+	}
+
+	secret.Labels = labels(claim)
+	secret.Annotations = strategy.Annotations(claim)
+	secret.Type = corev1.SecretTypeTLS
+	secret.Data = data
+	if err := controllerutil.SetControllerReference(claim, secret, s.client.Scheme()); err != nil {
+		return nil, fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	if err := s.client.Create(ctx, secret); err != nil {
+		return nil, fmt.Errorf("failed to create tls secret: %w", err)
+	}
+	return secret, nil
+}
+
+// applyDeployment creates the Redis Deployment, or updates its pod template when the parameters changed
+func (s *RedisStrategy) applyDeployment(ctx context.Context, claim *scorev1b1.ResourceClaim, params *Parameters, memoryLimit resource.Quantity, maxMemory int64, tlsSecret *corev1.Secret) error {
+	deployment := buildDeployment(claim, params, memoryLimit, maxMemory, tlsSecret)
+
+	existing := &appsv1.Deployment{}
+	err := s.client.Get(ctx, client.ObjectKeyFromObject(deployment), existing)
+	if err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to check existing deployment: %w", err)
+		}
+		// Set ResourceClaim as owner for garbage collection
+		if err := controllerutil.SetControllerReference(claim, deployment, s.client.Scheme()); err != nil {
+			return fmt.Errorf("failed to set owner reference: %w", err)
+		}
+		if err := s.client.Create(ctx, deployment); err != nil {
+			return fmt.Errorf("failed to create deployment: %w", err)
+		}
+		return nil
+	}
+	if err := strategy.CheckControlled(claim, existing, "deployment"); err != nil {
+		return err
+	}
+
+	// Fields defaulted by the API server are not declared, so they do not count as changes
+	metadataChanged := strategy.SyncMetadata(existing, deployment)
+	if !metadataChanged && equality.Semantic.DeepDerivative(deployment.Spec.Template, existing.Spec.Template) {
+		return nil
+	}
+	existing.Spec.Template = deployment.Spec.Template
+	if err := s.client.Update(ctx, existing); err != nil {
+		return fmt.Errorf("failed to update deployment: %w", err)
+	}
+	return nil
+}
+
+// buildDeployment returns the Redis Deployment of the claim. The password is passed through the environment
+// from the connection Secret; Kubernetes expands it in the arguments.
+func buildDeployment(claim *scorev1b1.ResourceClaim, params *Parameters, memoryLimit resource.Quantity, maxMemory int64, tlsSecret *corev1.Secret) *appsv1.Deployment {
+	name := deploymentName(claim)
+	args := []string{"redis-server", "--maxmemory", strconv.FormatInt(maxMemory, 10)}
+	if params.MaxMemoryPolicy != "" {
+		args = append(args, "--maxmemory-policy", params.MaxMemoryPolicy)
+	}
+
+	container := corev1.Container{
+		Name:  "redis",
+		Image: redisImage,
+		Ports: []corev1.ContainerPort{
+			{
+				ContainerPort: redisPort,
+				Name:          "redis",
+			},
+		},
+		Resources: corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{
+				corev1.ResourceMemory: memoryLimit,
+				corev1.ResourceCPU:    resource.MustParse("50m"),
+			},
+			Limits: corev1.ResourceList{
+				corev1.ResourceMemory: memoryLimit,
+				corev1.ResourceCPU:    resource.MustParse("500m"),
+			},
+		},
+		ReadinessProbe: &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				TCPSocket: &corev1.TCPSocketAction{Port: intstr.FromInt(redisPort)},
+			},
+			PeriodSeconds:    10,
+			TimeoutSeconds:   5,
+			FailureThreshold: 3,
+		},
+		SecurityContext: &corev1.SecurityContext{
+			RunAsUser:                int64Ptr(999), // redis user
+			RunAsGroup:               int64Ptr(999), // redis group
+			RunAsNonRoot:             boolPtr(true),
+			AllowPrivilegeEscalation: boolPtr(false),
+			Capabilities: &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			},
+		},
+	}
+	if params.authEnabled() {
+		container.Env = []corev1.EnvVar{{
+			Name: "REDIS_PASSWORD",
+			ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName(claim)},
+				Key:                  "password",
+			}},
+		}}
+		args = append(args, "--requirepass", "$(REDIS_PASSWORD)")
+	}
+
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels: map[string]string{
+				"app": name,
+				// Selected by the network policy restricting access to the claiming Workload
+				"score.dev/resource-claim": claim.Name,
+			},
+		},
+		Spec: corev1.PodSpec{
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot: boolPtr(true),
+				FSGroup:      int64Ptr(999),
+			},
+		},
+	}
+	if tlsSecret != nil {
+		// Serve TLS only; clients verify the server with the CA certificate but present none
+		args = append(args,
+			"--port", "0",
+			"--tls-port", strconv.Itoa(redisPort),
+			"--tls-cert-file", tlsMountPath+"/"+corev1.TLSCertKey,
+			"--tls-key-file", tlsMountPath+"/"+corev1.TLSPrivateKeyKey,
+			"--tls-ca-cert-file", tlsMountPath+"/"+caCertKey,
+			"--tls-auth-clients", "no",
+		)
+		container.VolumeMounts = []corev1.VolumeMount{{Name: "tls", MountPath: tlsMountPath, ReadOnly: true}}
+		template.Spec.Volumes = []corev1.Volume{{
+			Name:         "tls",
+			VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: tlsSecret.Name}},
+		}}
+		hash := sha256.Sum256(tlsSecret.Data[corev1.TLSCertKey])
+		template.Annotations = map[string]string{annotationCertificateHash: hex.EncodeToString(hash[:8])}
+	}
+	container.Args = args
+	template.Spec.Containers = []corev1.Container{container}
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   claim.Namespace,
+			Labels:      labels(claim),
+			Annotations: strategy.Annotations(claim),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(1),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": name},
+			},
+			// A single instance without persistence; never run two during an update
+			Strategy: appsv1.DeploymentStrategy{Type: appsv1.RecreateDeploymentStrategyType},
+			Template: template,
+		},
+	}
+}
+
+// createService creates a ClusterIP service for Redis
+func (s *RedisStrategy) createService(ctx context.Context, claim *scorev1b1.ResourceClaim) error {
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        serviceName(claim),
+			Namespace:   claim.Namespace,
+			Labels:      labels(claim),
+			Annotations: strategy.Annotations(claim),
+		},
+		Spec: corev1.ServiceSpec{
+			Type: corev1.ServiceTypeClusterIP,
+			Ports: []corev1.ServicePort{
+				{
+					Name:       "redis",
+					Port:       redisPort,
+					TargetPort: intstr.FromInt(redisPort),
+					Protocol:   corev1.ProtocolTCP,
+				},
+			},
+			Selector: map[string]string{
+				"app": deploymentName(claim),
+			},
+		},
+	}
+
+	// Set ResourceClaim as owner for garbage collection
+	if err := controllerutil.SetControllerReference(claim, service, s.client.Scheme()); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+
+	existing := &corev1.Service{}
+	err := s.client.Get(ctx, client.ObjectKeyFromObject(service), existing)
+	if err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to check existing service: %w", err)
+		}
+		if err := s.client.Create(ctx, service); err != nil {
+			return fmt.Errorf("failed to create service: %w", err)
+		}
+	} else if err := strategy.CheckControlled(claim, existing, "service"); err != nil {
+		return err
+	} else if strategy.SyncMetadata(existing, service) {
+		if err := s.client.Update(ctx, existing); err != nil {
+			return fmt.Errorf("failed to update service: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// deleteObject deletes the object unless it does not exist
+func (s *RedisStrategy) deleteObject(ctx context.Context, object client.Object) error {
+	return client.IgnoreNotFound(s.client.Delete(ctx, object))
+}
+
+// labels returns the labels of the resources provisioned for the claim
+func labels(claim *scorev1b1.ResourceClaim) map[string]string {
+	labels := strategy.Labels(claim, "redis")
+	labels["app"] = deploymentName(claim)
+	return labels
+}
+
+// objectMeta returns the labels and annotations of the resources provisioned for the claim
+func objectMeta(claim *scorev1b1.ResourceClaim) *metav1.ObjectMeta {
+	return &metav1.ObjectMeta{Labels: labels(claim), Annotations: strategy.Annotations(claim)}
+}
+
+func deploymentName(claim *scorev1b1.ResourceClaim) string {
+	return strategy.ResourceName(claim, "redis")
+}
+
+func serviceName(claim *scorev1b1.ResourceClaim) string {
+	return strategy.ResourceName(claim, "redis-service")
+}
+
+func secretName(claim *scorev1b1.ResourceClaim) string {
+	return strategy.ResourceName(claim, "redis-secret")
+}
+
+func tlsSecretName(claim *scorev1b1.ResourceClaim) string {
+	return strategy.ResourceName(claim, "redis-tls")
+}
+
+// serviceDNSNames returns the names clients reach the Redis Service by, which the certificate is issued for
+func serviceDNSNames(claim *scorev1b1.ResourceClaim) []string {
+	name := serviceName(claim)
+	return []string{
+		name,
+		name + "." + claim.Namespace,
+		name + "." + claim.Namespace + ".svc",
+		name + "." + claim.Namespace + ".svc.cluster.local",
+	}
 }
 
 // generateRandomPassword generates a cryptographically secure random password
@@ -152,3 +564,16 @@ func generateRandomPassword(length int) (string, error) {
 
 	return password, nil
 }
+
+// Helper functions for pointer values
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func int64Ptr(i int64) *int64 {
+	return &i
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
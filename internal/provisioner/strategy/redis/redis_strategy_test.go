@@ -0,0 +1,169 @@
+package redis
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+func newTestStrategy(t *testing.T) (*RedisStrategy, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	return NewRedisStrategy(c), c
+}
+
+func testClaim(class string) *scorev1b1.ResourceClaim {
+	claim := &scorev1b1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-cache", Namespace: "default", UID: "uid"},
+		Spec:       scorev1b1.ResourceClaimSpec{Key: "cache", Type: "redis"},
+	}
+	if class != "" {
+		claim.Spec.Class = &class
+	}
+	return claim
+}
+
+func redisProvisioner() *scorev1b1.ProvisionerSpec {
+	return &scorev1b1.ProvisionerSpec{
+		Type: "redis",
+		Classes: []scorev1b1.ClassSpec{
+			{Name: "dev", Parameters: &runtime.RawExtension{Raw: []byte(`{"auth":false}`)}},
+			{Name: "secure", Parameters: &runtime.RawExtension{Raw: []byte(`{"tls":true,"memory":"512Mi","maxMemoryPolicy":"allkeys-lru"}`)}},
+		},
+		Defaults: &scorev1b1.ProvisionerDefaults{Class: "dev"},
+	}
+}
+
+func TestProvisionWithTLS(t *testing.T) {
+	s, c := newTestStrategy(t)
+	ctx := strategy.WithProvisioner(context.Background(), redisProvisioner())
+	claim := testClaim("secure")
+
+	outputs, err := s.Provision(ctx, claim)
+	if err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+	if outputs.SecretRef == nil || outputs.SecretRef.Name != secretName(claim) {
+		t.Errorf("secretRef = %+v, want %s", outputs.SecretRef, secretName(claim))
+	}
+	if outputs.Cert == nil || outputs.Cert.SecretName == nil || *outputs.Cert.SecretName != tlsSecretName(claim) {
+		t.Fatalf("cert = %+v, want the TLS Secret", outputs.Cert)
+	}
+	if !strings.Contains(string(outputs.Cert.Data[caCertKey]), "BEGIN CERTIFICATE") || len(outputs.Cert.Data) != 1 {
+		t.Errorf("cert data = %v, want only the CA certificate", outputs.Cert.Data)
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Name: secretName(claim), Namespace: "default"}, secret); err != nil {
+		t.Fatal(err)
+	}
+	password := string(secret.Data["password"])
+	if password == "" {
+		t.Fatal("secret has no password")
+	}
+	if want := "rediss://:" + password + "@" + serviceName(claim) + ":6379"; string(secret.Data["uri"]) != want {
+		t.Errorf("uri = %q, want %q", secret.Data["uri"], want)
+	}
+	if got := string(secret.Data["maxmemory"]); got != "402653184" {
+		t.Errorf("maxmemory = %s, want 75%% of 512Mi", got)
+	}
+
+	deployment := &appsv1.Deployment{}
+	if err := c.Get(ctx, client.ObjectKey{Name: deploymentName(claim), Namespace: "default"}, deployment); err != nil {
+		t.Fatal(err)
+	}
+	container := deployment.Spec.Template.Spec.Containers[0]
+	for _, arg := range []string{"--requirepass", "--tls-port", "--tls-cert-file", "allkeys-lru", "402653184"} {
+		if !slices.Contains(container.Args, arg) {
+			t.Errorf("args = %v, want %s", container.Args, arg)
+		}
+	}
+	if got := container.Resources.Limits.Memory().String(); got != "512Mi" {
+		t.Errorf("memory limit = %s, want 512Mi", got)
+	}
+	if deployment.Spec.Template.Labels["score.dev/resource-claim"] != "web-cache" {
+		t.Error("pod template is not labeled with the claim")
+	}
+
+	// The password and certificate are kept across reconciles
+	tlsSecret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Name: tlsSecretName(claim), Namespace: "default"}, tlsSecret); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Provision(ctx, claim); err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(secret), secret); err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data["password"]) != password {
+		t.Error("password was regenerated")
+	}
+	renewed := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(tlsSecret), renewed); err != nil {
+		t.Fatal(err)
+	}
+	if string(renewed.Data[corev1.TLSCertKey]) != string(tlsSecret.Data[corev1.TLSCertKey]) {
+		t.Error("certificate was reissued")
+	}
+
+	// Deprovision removes the instance and both Secrets
+	if err := s.Deprovision(ctx, claim); err != nil {
+		t.Fatalf("Deprovision() error = %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(tlsSecret), &corev1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Errorf("tls secret still exists: %v", err)
+	}
+}
+
+func TestProvisionDefaultClassWithoutAuth(t *testing.T) {
+	s, c := newTestStrategy(t)
+	ctx := strategy.WithProvisioner(context.Background(), redisProvisioner())
+
+	claim := testClaim("")
+	outputs, err := s.Provision(ctx, claim)
+	if err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+	if outputs.Cert != nil {
+		t.Errorf("cert = %+v, want none without TLS", outputs.Cert)
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Name: secretName(claim), Namespace: "default"}, secret); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := secret.Data["password"]; ok {
+		t.Error("secret has a password although auth is disabled")
+	}
+	if got := string(secret.Data["uri"]); got != "redis://"+serviceName(claim)+":6379" {
+		t.Errorf("uri = %q, want a redis:// URI without credentials", got)
+	}
+}
+
+func TestProvisionUnknownClass(t *testing.T) {
+	s, _ := newTestStrategy(t)
+	ctx := strategy.WithProvisioner(context.Background(), redisProvisioner())
+	if _, err := s.Provision(ctx, testClaim("huge")); err == nil {
+		t.Error("Provision() error = nil, want an unknown class error")
+	}
+}
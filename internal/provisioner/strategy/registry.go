@@ -0,0 +1,61 @@
+package strategy
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Factory creates a strategy that provisions resources through the given client
+type Factory func(c client.Client) Strategy
+
+var (
+	registryMu sync.RWMutex
+	factories  = map[string]Factory{}
+)
+
+// Register makes a strategy available under name. Packages providing strategies call it from an init
+// function, so a strategy is available as soon as its package is linked into the manager binary.
+// Register panics if name is empty, factory is nil or name is already registered.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if name == "" {
+		panic("strategy: Register called with an empty name")
+	}
+	if factory == nil {
+		panic("strategy: Register factory is nil for " + name)
+	}
+	if _, exists := factories[name]; exists {
+		panic("strategy: Register called twice for " + name)
+	}
+	factories[name] = factory
+}
+
+// Registered returns the sorted names of the registered strategies
+func Registered() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	names := make([]string, 0, len(factories))
+	for name := range factories {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// New creates the strategy registered under name
+func New(name string, c client.Client) (Strategy, error) {
+	registryMu.RLock()
+	factory, exists := factories[name]
+	registryMu.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("no strategy registered with name: %s", name)
+	}
+	return factory(c), nil
+}
@@ -12,8 +12,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
 )
 
+func init() {
+	strategy.Register("secret", func(c client.Client) strategy.Strategy { return NewSecretStrategy(c) })
+}
+
 // SecretStrategy implements the Strategy interface for generic secret provisioning
 type SecretStrategy struct {
 	client client.Client
@@ -48,12 +53,10 @@ func (s *SecretStrategy) Provision(ctx context.Context, claim *scorev1b1.Resourc
 	// Create Secret
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-secret", claim.Name),
-			Namespace: claim.Namespace,
-			Labels: map[string]string{
-				"score.dev/resource-claim": claim.Name,
-				"score.dev/resource-type":  "secret",
-			},
+			Name:        secretName(claim),
+			Namespace:   claim.Namespace,
+			Labels:      strategy.Labels(claim, "secret"),
+			Annotations: strategy.Annotations(claim),
 		},
 		Type: corev1.SecretTypeOpaque,
 		Data: secretData,
@@ -75,6 +78,12 @@ func (s *SecretStrategy) Provision(ctx context.Context, claim *scorev1b1.Resourc
 		if err := s.client.Create(ctx, secret); err != nil {
 			return nil, fmt.Errorf("failed to create secret: %w", err)
 		}
+	} else if err := strategy.CheckControlled(claim, existing, "secret"); err != nil {
+		return nil, err
+	} else if strategy.SyncMetadata(existing, secret) {
+		if err := s.client.Update(ctx, existing); err != nil {
+			return nil, fmt.Errorf("failed to update secret: %w", err)
+		}
 	}
 
 	// Return outputs pointing to the created Secret
@@ -89,10 +98,10 @@ func (s *SecretStrategy) Provision(ctx context.Context, claim *scorev1b1.Resourc
 
 // Deprovision cleans up the secret resources
 func (s *SecretStrategy) Deprovision(ctx context.Context, claim *scorev1b1.ResourceClaim) error {
-	secretName := fmt.Sprintf("%s-secret", claim.Name)
+	name := secretName(claim)
 	secret := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      secretName,
+			Name:      name,
 			Namespace: claim.Namespace,
 		},
 	}
@@ -107,11 +116,11 @@ func (s *SecretStrategy) Deprovision(ctx context.Context, claim *scorev1b1.Resou
 
 // GetStatus returns the current status of the secret resource
 func (s *SecretStrategy) GetStatus(ctx context.Context, claim *scorev1b1.ResourceClaim) (phase scorev1b1.ResourceClaimPhase, reason, message string, err error) {
-	secretName := fmt.Sprintf("%s-secret", claim.Name)
+	name := secretName(claim)
 	secret := &corev1.Secret{}
 
 	err = s.client.Get(ctx, client.ObjectKey{
-		Name:      secretName,
+		Name:      name,
 		Namespace: claim.Namespace,
 	}, secret)
 
@@ -130,6 +139,10 @@ func (s *SecretStrategy) GetStatus(ctx context.Context, claim *scorev1b1.Resourc
 		"Secret is available", nil
 }
 
+func secretName(claim *scorev1b1.ResourceClaim) string {
+	return strategy.ResourceName(claim, "secret")
+}
+
 // generateRandomToken generates a cryptographically secure random token
 func generateRandomToken(length int) string {
 	bytes := make([]byte, length)
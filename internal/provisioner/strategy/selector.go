@@ -1,68 +1,68 @@
 package strategy
 
 import (
-	"fmt"
+	"sync"
 
-	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-// Selector manages strategy selection and configuration
+// Selector manages strategy selection for resource types
 type Selector struct {
+	client client.Client
+
+	mu sync.Mutex
+	// strategies are registered on the selector by resource type and take precedence over the registry
 	strategies map[string]Strategy
-	configs    map[string]*ProvisioningConfig
+	// instances are created from the registry on first use, by strategy name
+	instances map[string]Strategy
 }
 
-// NewSelector creates a new strategy selector
-func NewSelector() *Selector {
+// NewSelector creates a new strategy selector whose strategies provision resources through c
+func NewSelector(c client.Client) *Selector {
 	return &Selector{
+		client:     c,
 		strategies: make(map[string]Strategy),
-		configs:    make(map[string]*ProvisioningConfig),
+		instances:  make(map[string]Strategy),
 	}
 }
 
 // RegisterStrategy registers a strategy for a resource type
 func (s *Selector) RegisterStrategy(strategy Strategy) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.strategies[strategy.GetType()] = strategy
 }
 
-// LoadConfig loads provisioning configuration
-func (s *Selector) LoadConfig(configs []ProvisioningConfig) {
-	s.configs = make(map[string]*ProvisioningConfig)
-	for i := range configs {
-		config := &configs[i]
-		s.configs[config.Type] = config
-	}
-}
+// GetStrategy returns the strategy for a given resource type. A strategy registered on the selector for
+// the type is used if present; otherwise the strategy registered as strategyName with Register is used.
+func (s *Selector) GetStrategy(resourceType, strategyName string) (Strategy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 
-// GetStrategy returns the strategy for a given resource type
-func (s *Selector) GetStrategy(resourceType string) (Strategy, error) {
-	strategy, exists := s.strategies[resourceType]
-	if !exists {
-		return nil, fmt.Errorf("no strategy registered for resource type: %s", resourceType)
+	if strategy, exists := s.strategies[resourceType]; exists {
+		return strategy, nil
 	}
-	return strategy, nil
+	return s.instance(strategyName)
 }
 
-// GetConfig returns the configuration for a given resource type
-func (s *Selector) GetConfig(resourceType string) (*ProvisioningConfig, error) {
-	config, exists := s.configs[resourceType]
-	if !exists {
-		return nil, fmt.Errorf("no configuration found for resource type: %s", resourceType)
-	}
-	return config, nil
+// GetStrategyByName returns the strategy registered as strategyName with Register, ignoring the strategies
+// registered on the selector for resource types
+func (s *Selector) GetStrategyByName(strategyName string) (Strategy, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.instance(strategyName)
 }
 
-// GetSupportedTypes returns all supported resource types
-func (s *Selector) GetSupportedTypes() []string {
-	types := make([]string, 0, len(s.strategies))
-	for resourceType := range s.strategies {
-		types = append(types, resourceType)
+// instance returns the strategy registered as strategyName, creating it on first use. s.mu must be held.
+func (s *Selector) instance(strategyName string) (Strategy, error) {
+	if strategy, exists := s.instances[strategyName]; exists {
+		return strategy, nil
 	}
-	return types
-}
 
-// IsSupported checks if a resource type is supported
-func (s *Selector) IsSupported(claim *scorev1b1.ResourceClaim) bool {
-	_, exists := s.strategies[claim.Spec.Type]
-	return exists
+	strategy, err := New(strategyName, s.client)
+	if err != nil {
+		return nil, err
+	}
+	s.instances[strategyName] = strategy
+	return strategy, nil
 }
@@ -0,0 +1,79 @@
+package strategy
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+type fakeStrategy struct {
+	resourceType string
+}
+
+func (s *fakeStrategy) GetType() string { return s.resourceType }
+
+func (s *fakeStrategy) Provision(context.Context, *scorev1b1.ResourceClaim) (*scorev1b1.ResourceClaimOutputs, error) {
+	return &scorev1b1.ResourceClaimOutputs{}, nil
+}
+
+func (s *fakeStrategy) Deprovision(context.Context, *scorev1b1.ResourceClaim) error { return nil }
+
+func (s *fakeStrategy) GetStatus(context.Context, *scorev1b1.ResourceClaim) (scorev1b1.ResourceClaimPhase, string, string, error) {
+	return scorev1b1.ResourceClaimPhaseBound, "", "", nil
+}
+
+func TestSelectorGetStrategy(t *testing.T) {
+	created := 0
+	Register("selector-test", func(client.Client) Strategy {
+		created++
+		return &fakeStrategy{resourceType: "mysql"}
+	})
+
+	selector := NewSelector(nil)
+	override := &fakeStrategy{resourceType: "redis"}
+	selector.RegisterStrategy(override)
+
+	tests := []struct {
+		name         string
+		resourceType string
+		strategyName string
+		wantType     string
+		wantErr      bool
+	}{
+		{name: "registered strategy", resourceType: "mysql", strategyName: "selector-test", wantType: "mysql"},
+		{name: "instance is reused", resourceType: "mariadb", strategyName: "selector-test", wantType: "mysql"},
+		{name: "selector strategy takes precedence", resourceType: "redis", strategyName: "selector-test", wantType: "redis"},
+		{name: "unknown strategy", resourceType: "s3", strategyName: "s3", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := selector.GetStrategy(tt.resourceType, tt.strategyName)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetStrategy() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && got.GetType() != tt.wantType {
+				t.Errorf("GetStrategy() type = %q, want %q", got.GetType(), tt.wantType)
+			}
+		})
+	}
+
+	if created != 1 {
+		t.Errorf("factory called %d times, want 1", created)
+	}
+}
+
+func TestRegisterPanicsOnDuplicateName(t *testing.T) {
+	factory := func(client.Client) Strategy { return &fakeStrategy{} }
+	Register("duplicate-test", factory)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("Register() did not panic for a duplicate name")
+		}
+	}()
+	Register("duplicate-test", factory)
+}
@@ -0,0 +1,81 @@
+package staticuri
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+// StrategyName is the name the strategy is registered under
+const StrategyName = "static-uri"
+
+func init() {
+	strategy.Register(StrategyName, func(c client.Client) strategy.Strategy { return NewStaticURIStrategy(c) })
+}
+
+// Parameters are the options of a static URI, read from the provisioner defaults, the claim's class and
+// the claim params, in increasing precedence
+type Parameters struct {
+	// URI is published unchanged as outputs.uri, e.g. the address of a managed database outside the cluster
+	URI string `json:"uri,omitempty"`
+}
+
+// StaticURIStrategy implements the Strategy interface for resources that exist outside the orchestrator and
+// are only referenced by a URI. Nothing is created or deleted.
+type StaticURIStrategy struct {
+	client client.Client
+}
+
+// NewStaticURIStrategy creates a new StaticURIStrategy
+func NewStaticURIStrategy(k8sClient client.Client) *StaticURIStrategy {
+	return &StaticURIStrategy{
+		client: k8sClient,
+	}
+}
+
+// GetType returns the resource type this strategy handles
+func (s *StaticURIStrategy) GetType() string {
+	return StrategyName
+}
+
+// Provision publishes the configured URI
+func (s *StaticURIStrategy) Provision(ctx context.Context, claim *scorev1b1.ResourceClaim) (*scorev1b1.ResourceClaimOutputs, error) {
+	uri, err := uriFor(ctx, claim)
+	if err != nil {
+		return nil, err
+	}
+	return &scorev1b1.ResourceClaimOutputs{URI: &uri}, nil
+}
+
+// Deprovision has nothing to clean up; the referenced resource is not managed by the claim
+func (s *StaticURIStrategy) Deprovision(ctx context.Context, claim *scorev1b1.ResourceClaim) error {
+	return nil
+}
+
+// GetStatus reports the claim Bound while a valid URI is configured
+func (s *StaticURIStrategy) GetStatus(ctx context.Context, claim *scorev1b1.ResourceClaim) (phase scorev1b1.ResourceClaimPhase, reason, message string, err error) {
+	if _, err := uriFor(ctx, claim); err != nil {
+		return scorev1b1.ResourceClaimPhaseFailed, "SpecInvalid", err.Error(), nil
+	}
+	return scorev1b1.ResourceClaimPhaseBound, "Succeeded", "Static URI is configured", nil
+}
+
+// uriFor returns the URI of the claim's class overlaid with the claim params
+func uriFor(ctx context.Context, claim *scorev1b1.ResourceClaim) (string, error) {
+	params := &Parameters{}
+	if err := strategy.DecodeParameters(ctx, claim, params); err != nil {
+		return "", fmt.Errorf("%w: %w", strategy.ErrInvalidParams, err)
+	}
+	if params.URI == "" {
+		return "", fmt.Errorf("%w: uri must be set", strategy.ErrInvalidParams)
+	}
+	if u, err := url.Parse(params.URI); err != nil || u.Scheme == "" {
+		return "", fmt.Errorf("%w: uri %q must be an absolute URI", strategy.ErrInvalidParams, params.URI)
+	}
+	return params.URI, nil
+}
@@ -0,0 +1,88 @@
+package staticuri
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+func testClaim(class, params string) *scorev1b1.ResourceClaim {
+	claim := &scorev1b1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-db", Namespace: "default", UID: "uid"},
+		Spec:       scorev1b1.ResourceClaimSpec{Key: "db", Type: "postgres"},
+	}
+	if class != "" {
+		claim.Spec.Class = &class
+	}
+	if params != "" {
+		claim.Spec.Params = &apiextv1.JSON{Raw: []byte(params)}
+	}
+	return claim
+}
+
+func TestProvision(t *testing.T) {
+	ctx := strategy.WithProvisioner(context.Background(), &scorev1b1.ProvisionerSpec{
+		Type:     "postgres",
+		Strategy: StrategyName,
+		Classes: []scorev1b1.ClassSpec{
+			{Name: "shared", Parameters: &runtime.RawExtension{Raw: []byte(`{"uri":"postgres://shared.db.example.com:5432/app"}`)}},
+		},
+	})
+
+	tests := []struct {
+		name    string
+		claim   *scorev1b1.ResourceClaim
+		wantURI string
+		wantErr bool
+	}{
+		{name: "claim params", claim: testClaim("", `{"uri":"postgres://db.example.com:5432/app"}`), wantURI: "postgres://db.example.com:5432/app"},
+		{name: "class parameters", claim: testClaim("shared", ""), wantURI: "postgres://shared.db.example.com:5432/app"},
+		{name: "params override the class", claim: testClaim("shared", `{"uri":"postgres://other.example.com/app"}`), wantURI: "postgres://other.example.com/app"},
+		{name: "missing uri", claim: testClaim("", ""), wantErr: true},
+		{name: "relative uri", claim: testClaim("", `{"uri":"db.example.com"}`), wantErr: true},
+		{name: "unknown class", claim: testClaim("dedicated", ""), wantErr: true},
+	}
+
+	s := NewStaticURIStrategy(nil)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputs, err := s.Provision(ctx, tt.claim)
+			if tt.wantErr {
+				if !errors.Is(err, strategy.ErrInvalidParams) {
+					t.Errorf("Provision() error = %v, want invalid params", err)
+				}
+				phase, reason, _, _ := s.GetStatus(ctx, tt.claim)
+				if phase != scorev1b1.ResourceClaimPhaseFailed || reason != "SpecInvalid" {
+					t.Errorf("GetStatus() = %s/%s, want Failed/SpecInvalid", phase, reason)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Provision() error = %v", err)
+			}
+			if outputs.URI == nil || *outputs.URI != tt.wantURI {
+				t.Errorf("Provision() uri = %v, want %s", outputs.URI, tt.wantURI)
+			}
+			if phase, _, _, _ := s.GetStatus(ctx, tt.claim); phase != scorev1b1.ResourceClaimPhaseBound {
+				t.Errorf("GetStatus() phase = %s, want Bound", phase)
+			}
+		})
+	}
+}
+
+func TestRegistered(t *testing.T) {
+	s, err := strategy.New(StrategyName, nil)
+	if err != nil {
+		t.Fatalf("strategy.New() error = %v", err)
+	}
+	if s.GetType() != StrategyName {
+		t.Errorf("GetType() = %s, want %s", s.GetType(), StrategyName)
+	}
+}
@@ -0,0 +1,555 @@
+package terraform
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+func init() {
+	strategy.Register(scorev1b1.ProvisionerStrategyTerraform, func(c client.Client) strategy.Strategy { return NewTerraformStrategy(c) })
+}
+
+const (
+	// labelAction on runner Jobs is the command they run, actionApply or actionDestroy
+	labelAction = "score.dev/terraform-action"
+	// labelRevision on apply Jobs is the revision of the module and variables they apply
+	labelRevision = "score.dev/terraform-revision"
+
+	actionApply   = "apply"
+	actionDestroy = "destroy"
+
+	// revisionLength is the number of hex characters of the revision hash in apply Job names
+	revisionLength = 8
+
+	// outputsKey is the key of the runner outputs Secret holding the output of "output -json"
+	outputsKey = "outputs.json"
+
+	// workspaceDir is where the runner checks out and runs the module
+	workspaceDir = "/workspace"
+)
+
+// runnerScript initializes the module without a backend, adds the backend configured for the provisioner,
+// runs the action and, after an apply, publishes the outputs to a Secret of the claim namespace. Modules
+// must not declare a backend themselves.
+const runnerScript = `set -eu
+cd "$SCORE_WORKSPACE"
+"$SCORE_BINARY" init -input=false -backend=false -from-module="$SCORE_MODULE"
+printf 'terraform {\n  backend "%s" {}\n}\n' "$SCORE_BACKEND" > score_backend.tf
+printf '%s\n' "$SCORE_BACKEND_CONFIG" > score.tfbackend
+printf '%s\n' "$SCORE_VARIABLES" > score.auto.tfvars.json
+"$SCORE_BINARY" init -input=false -reconfigure -backend-config=score.tfbackend
+"$SCORE_BINARY" "$SCORE_ACTION" -input=false -auto-approve
+if [ "$SCORE_ACTION" = apply ]; then
+  "$SCORE_BINARY" output -json > outputs.json
+  kubectl create secret generic "$SCORE_OUTPUTS_SECRET" --from-file=outputs.json=outputs.json \
+    --dry-run=client -o yaml | kubectl apply -f -
+fi
+`
+
+// TerraformStrategy implements the Strategy interface by running a Terraform or OpenTofu module in Jobs of
+// the claim namespace. The claim params are the variables of the module, and its outputs are published in
+// a Secret; the state of each claim is kept in the backend configured by the terraform field of the
+// provisioner, and the resources are destroyed when the claim is deleted.
+type TerraformStrategy struct {
+	client client.Client
+}
+
+// NewTerraformStrategy creates a new TerraformStrategy
+func NewTerraformStrategy(k8sClient client.Client) *TerraformStrategy {
+	return &TerraformStrategy{
+		client: k8sClient,
+	}
+}
+
+// GetType returns the resource type this strategy handles
+func (s *TerraformStrategy) GetType() string {
+	return scorev1b1.ProvisionerStrategyTerraform
+}
+
+// Provision runs an apply Job for the current revision of the module and variables and publishes the module
+// outputs once it completes. A failed Job is deleted so that the retry runs the apply again. Apply Jobs of
+// earlier revisions hold the state lock, so a new revision waits for them to finish.
+func (s *TerraformStrategy) Provision(ctx context.Context, claim *scorev1b1.ResourceClaim) (*scorev1b1.ResourceClaimOutputs, error) {
+	terraform, variables, err := configFor(ctx, claim)
+	if err != nil {
+		return nil, err
+	}
+	revision, err := revisionOf(terraform, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := s.getJob(ctx, claim, applyJobName(claim, revision))
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		jobs, err := s.listJobs(ctx, claim, actionApply)
+		if err != nil {
+			return nil, err
+		}
+		if slices.ContainsFunc(jobs, running) {
+			return nil, strategy.ErrInProgress
+		}
+		if err := s.createJob(ctx, claim, terraform, variables, actionApply, revision); err != nil {
+			return nil, err
+		}
+		return nil, strategy.ErrInProgress
+	}
+
+	if failed, message := jobFailed(job); failed {
+		if err := s.deleteJob(ctx, job); err != nil {
+			return nil, err
+		}
+		return nil, fmt.Errorf("%s apply failed: %s", binaryOf(terraform), message)
+	}
+	if running(job) {
+		return nil, strategy.ErrInProgress
+	}
+
+	outputs, err := s.publishOutputs(ctx, claim)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.deleteStaleJobs(ctx, claim, job.Name); err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+// Deprovision runs a destroy Job once no apply Job is running and returns ErrInProgress until it completes.
+// A failed destroy Job is deleted so that the next attempt runs it again.
+func (s *TerraformStrategy) Deprovision(ctx context.Context, claim *scorev1b1.ResourceClaim) error {
+	terraform, variables, err := configFor(ctx, claim)
+	if err != nil {
+		return err
+	}
+
+	job, err := s.getJob(ctx, claim, destroyJobName(claim))
+	if err != nil {
+		return err
+	}
+	if job == nil {
+		jobs, err := s.listJobs(ctx, claim, actionApply)
+		if err != nil {
+			return err
+		}
+		if slices.ContainsFunc(jobs, running) {
+			return strategy.ErrInProgress
+		}
+		if err := s.createJob(ctx, claim, terraform, variables, actionDestroy, ""); err != nil {
+			return err
+		}
+		return strategy.ErrInProgress
+	}
+
+	if failed, message := jobFailed(job); failed {
+		if err := s.deleteJob(ctx, job); err != nil {
+			return err
+		}
+		return fmt.Errorf("%s destroy failed: %s", binaryOf(terraform), message)
+	}
+	if running(job) {
+		return strategy.ErrInProgress
+	}
+
+	runnerOutputs := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: runnerOutputsName(claim), Namespace: claim.Namespace}}
+	if err := s.client.Delete(ctx, runnerOutputs); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete runner outputs secret: %w", err)
+	}
+	return nil
+}
+
+// GetStatus returns the status of the apply Job of the current revision
+func (s *TerraformStrategy) GetStatus(ctx context.Context, claim *scorev1b1.ResourceClaim) (phase scorev1b1.ResourceClaimPhase, reason, message string, err error) {
+	terraform, variables, err := configFor(ctx, claim)
+	if err != nil {
+		return scorev1b1.ResourceClaimPhaseFailed, "InvalidConfiguration", err.Error(), err
+	}
+	revision, err := revisionOf(terraform, variables)
+	if err != nil {
+		return scorev1b1.ResourceClaimPhaseFailed, "InvalidConfiguration", err.Error(), err
+	}
+
+	job, err := s.getJob(ctx, claim, applyJobName(claim, revision))
+	if err != nil {
+		return scorev1b1.ResourceClaimPhaseFailed, "JobAccessFailed",
+			fmt.Sprintf("Failed to access apply job: %v", err), err
+	}
+	if job == nil {
+		return scorev1b1.ResourceClaimPhaseClaiming, "ApplyPending",
+			"Apply job is being created", nil
+	}
+	if failed, message := jobFailed(job); failed {
+		return scorev1b1.ResourceClaimPhaseFailed, "ApplyFailed",
+			fmt.Sprintf("Apply job %s failed: %s", job.Name, message), nil
+	}
+	if running(job) {
+		return scorev1b1.ResourceClaimPhaseClaiming, "ApplyRunning",
+			fmt.Sprintf("Apply job %s is running", job.Name), nil
+	}
+	return scorev1b1.ResourceClaimPhaseBound, "Succeeded",
+		"Module is applied", nil
+}
+
+// configFor returns the terraform configuration of the provisioner and the variables of the claim: the
+// parameters of its class overlaid with the claim params
+func configFor(ctx context.Context, claim *scorev1b1.ResourceClaim) (*scorev1b1.TerraformProvisionerSpec, map[string]any, error) {
+	provisioner := strategy.ProvisionerFromContext(ctx)
+	if provisioner == nil || provisioner.Terraform == nil {
+		return nil, nil, fmt.Errorf("no terraform module is configured for resource type %s", claim.Spec.Type)
+	}
+	variables := map[string]any{}
+	if err := strategy.DecodeParameters(ctx, claim, &variables); err != nil {
+		return nil, nil, err
+	}
+	return provisioner.Terraform, variables, nil
+}
+
+// revisionOf returns the hash identifying what an apply Job runs, so that a change of the module, the runner
+// or the variables runs a new Job
+func revisionOf(terraform *scorev1b1.TerraformProvisionerSpec, variables map[string]any) (string, error) {
+	data, err := json.Marshal(struct {
+		Terraform *scorev1b1.TerraformProvisionerSpec `json:"terraform"`
+		Variables map[string]any                      `json:"variables"`
+	}{terraform, variables})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode variables: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:revisionLength], nil
+}
+
+// binaryOf returns the command the module is run with
+func binaryOf(terraform *scorev1b1.TerraformProvisionerSpec) string {
+	if terraform.Binary != "" {
+		return terraform.Binary
+	}
+	return scorev1b1.TerraformBinaryTofu
+}
+
+// stateKey returns the key the state of the claim is stored under, in the form the key attribute of the
+// backend expects. The hash of the claim UID keeps it apart from the state of an earlier claim of the same name.
+func stateKey(claim *scorev1b1.ResourceClaim, keyAttribute string) string {
+	name := strategy.ResourceName(claim, "terraform")
+	switch keyAttribute {
+	case "secret_suffix":
+		return claim.Namespace + "-" + name
+	case "prefix":
+		return "score/" + claim.Namespace + "/" + name
+	default:
+		return "score/" + claim.Namespace + "/" + name + "/terraform.tfstate"
+	}
+}
+
+// backendConfig renders the settings of the backend and the state key of the claim as a backend
+// configuration file
+func backendConfig(claim *scorev1b1.ResourceClaim, backend *scorev1b1.TerraformBackendSpec) string {
+	settings := make(map[string]string, len(backend.Config)+1)
+	for key, value := range backend.Config {
+		settings[key] = value
+	}
+	keyAttribute := provisioner.TerraformStateKeyAttribute(backend)
+	settings[keyAttribute] = stateKey(claim, keyAttribute)
+
+	lines := make([]string, 0, len(settings))
+	for key, value := range settings {
+		lines = append(lines, key+" = "+hclString(value))
+	}
+	slices.Sort(lines)
+	return strings.Join(lines, "\n")
+}
+
+// hclString quotes the value as an HCL string literal, escaping template sequences
+func hclString(value string) string {
+	quoted := strconv.Quote(value)
+	quoted = strings.ReplaceAll(quoted, "${", "$${")
+	return strings.ReplaceAll(quoted, "%{", "%%{")
+}
+
+// createJob creates the Job running the action for the claim
+func (s *TerraformStrategy) createJob(ctx context.Context, claim *scorev1b1.ResourceClaim, terraform *scorev1b1.TerraformProvisionerSpec, variables map[string]any, action, revision string) error {
+	job, err := buildJob(claim, terraform, variables, action, revision)
+	if err != nil {
+		return err
+	}
+	if err := controllerutil.SetControllerReference(claim, job, s.client.Scheme()); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	if err := s.client.Create(ctx, job); err != nil {
+		return fmt.Errorf("failed to create %s job: %w", action, err)
+	}
+	return nil
+}
+
+// buildJob constructs the Job running the action. Failed runs are not retried by the Job: the strategy
+// reports them and the retry policy of the claim type applies.
+func buildJob(claim *scorev1b1.ResourceClaim, terraform *scorev1b1.TerraformProvisionerSpec, variables map[string]any, action, revision string) (*batchv1.Job, error) {
+	variablesJSON, err := json.Marshal(variables)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode variables: %w", err)
+	}
+
+	labels := strategy.Labels(claim, scorev1b1.ProvisionerStrategyTerraform)
+	labels[labelAction] = action
+	name := destroyJobName(claim)
+	if action == actionApply {
+		labels[labelRevision] = revision
+		name = applyJobName(claim, revision)
+	}
+
+	container := corev1.Container{
+		Name:       "runner",
+		Image:      terraform.Image,
+		Command:    []string{"/bin/sh", "-c", runnerScript},
+		WorkingDir: workspaceDir,
+		Env: []corev1.EnvVar{
+			{Name: "SCORE_WORKSPACE", Value: workspaceDir},
+			{Name: "SCORE_MODULE", Value: terraform.Module},
+			{Name: "SCORE_BINARY", Value: binaryOf(terraform)},
+			{Name: "SCORE_ACTION", Value: action},
+			{Name: "SCORE_BACKEND", Value: terraform.Backend.Type},
+			{Name: "SCORE_BACKEND_CONFIG", Value: backendConfig(claim, &terraform.Backend)},
+			{Name: "SCORE_VARIABLES", Value: string(variablesJSON)},
+			{Name: "SCORE_OUTPUTS_SECRET", Value: runnerOutputsName(claim)},
+			{Name: "TF_IN_AUTOMATION", Value: "true"},
+		},
+		VolumeMounts: []corev1.VolumeMount{{Name: "workspace", MountPath: workspaceDir}},
+	}
+	if terraform.EnvFromSecret != "" {
+		container.EnvFrom = []corev1.EnvFromSource{{
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: terraform.EnvFromSecret}},
+		}}
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   claim.Namespace,
+			Labels:      labels,
+			Annotations: strategy.Annotations(claim),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: ptr.To[int32](0),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: terraform.ServiceAccountName,
+					Containers:         []corev1.Container{container},
+					Volumes: []corev1.Volume{{
+						Name:         "workspace",
+						VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+					}},
+				},
+			},
+		},
+	}, nil
+}
+
+// getJob returns the Job of the claim with the given name, or nil when it does not exist
+func (s *TerraformStrategy) getJob(ctx context.Context, claim *scorev1b1.ResourceClaim, name string) (*batchv1.Job, error) {
+	job := &batchv1.Job{}
+	err := s.client.Get(ctx, client.ObjectKey{Name: name, Namespace: claim.Namespace}, job)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed to get job %s: %w", name, err)
+	}
+	if err != nil {
+		return nil, nil
+	}
+	if err := strategy.CheckControlled(claim, job, "job"); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// listJobs returns the Jobs of the claim running the action
+func (s *TerraformStrategy) listJobs(ctx context.Context, claim *scorev1b1.ResourceClaim, action string) ([]*batchv1.Job, error) {
+	list := &batchv1.JobList{}
+	if err := s.client.List(ctx, list, client.InNamespace(claim.Namespace), client.MatchingLabels{
+		"score.dev/resource-claim": claim.Name,
+		labelAction:                action,
+	}); err != nil {
+		return nil, fmt.Errorf("failed to list %s jobs: %w", action, err)
+	}
+	var jobs []*batchv1.Job
+	for i := range list.Items {
+		if metav1.IsControlledBy(&list.Items[i], claim) {
+			jobs = append(jobs, &list.Items[i])
+		}
+	}
+	return jobs, nil
+}
+
+// deleteStaleJobs deletes the apply Jobs of the claim other than the current one
+func (s *TerraformStrategy) deleteStaleJobs(ctx context.Context, claim *scorev1b1.ResourceClaim, current string) error {
+	jobs, err := s.listJobs(ctx, claim, actionApply)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		if job.Name == current {
+			continue
+		}
+		if err := s.deleteJob(ctx, job); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteJob deletes the Job along with its pods
+func (s *TerraformStrategy) deleteJob(ctx context.Context, job *batchv1.Job) error {
+	if err := s.client.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete job %s: %w", job.Name, err)
+	}
+	return nil
+}
+
+// running reports whether the Job has neither completed nor failed
+func running(job *batchv1.Job) bool {
+	for _, condition := range job.Status.Conditions {
+		if (condition.Type == batchv1.JobComplete || condition.Type == batchv1.JobFailed) && condition.Status == corev1.ConditionTrue {
+			return false
+		}
+	}
+	return true
+}
+
+// jobFailed reports whether the Job failed, along with the reason
+func jobFailed(job *batchv1.Job) (bool, string) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Type == batchv1.JobFailed && condition.Status == corev1.ConditionTrue {
+			return true, condition.Message
+		}
+	}
+	return false, ""
+}
+
+// moduleOutput is an output of the module as printed by "output -json"
+type moduleOutput struct {
+	Sensitive bool            `json:"sensitive"`
+	Value     json.RawMessage `json:"value"`
+}
+
+// publishOutputs copies the outputs the runner published into the output Secret of the claim. String outputs
+// are stored as they are and other outputs as JSON. Non-sensitive "uri" and "hostname" string outputs are
+// published as the typed outputs of the claim as well.
+func (s *TerraformStrategy) publishOutputs(ctx context.Context, claim *scorev1b1.ResourceClaim) (*scorev1b1.ResourceClaimOutputs, error) {
+	runnerOutputs := &corev1.Secret{}
+	if err := s.client.Get(ctx, client.ObjectKey{Name: runnerOutputsName(claim), Namespace: claim.Namespace}, runnerOutputs); err != nil {
+		return nil, fmt.Errorf("failed to get runner outputs secret: %w", err)
+	}
+	moduleOutputs := map[string]moduleOutput{}
+	if err := json.Unmarshal(runnerOutputs.Data[outputsKey], &moduleOutputs); err != nil {
+		return nil, fmt.Errorf("failed to decode module outputs: %w", err)
+	}
+	if len(moduleOutputs) == 0 {
+		return nil, fmt.Errorf("module has no outputs")
+	}
+
+	outputs := &scorev1b1.ResourceClaimOutputs{
+		SecretRef: &scorev1b1.LocalObjectReference{Name: outputSecretName(claim)},
+	}
+	data := make(map[string][]byte, len(moduleOutputs))
+	for name, output := range moduleOutputs {
+		var text string
+		if err := json.Unmarshal(output.Value, &text); err != nil {
+			data[name] = output.Value
+			continue
+		}
+		data[name] = []byte(text)
+		if output.Sensitive {
+			continue
+		}
+		switch name {
+		case "uri":
+			if !provisioner.URIContainsPassword(text) {
+				outputs.URI = &text
+			}
+		case "hostname":
+			outputs.Hostname = &text
+		}
+	}
+
+	if err := s.applyOutputSecret(ctx, claim, data); err != nil {
+		return nil, err
+	}
+	return outputs, nil
+}
+
+// applyOutputSecret creates or updates the output Secret of the claim
+func (s *TerraformStrategy) applyOutputSecret(ctx context.Context, claim *scorev1b1.ResourceClaim, data map[string][]byte) error {
+	desired := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        outputSecretName(claim),
+			Namespace:   claim.Namespace,
+			Labels:      strategy.Labels(claim, scorev1b1.ProvisionerStrategyTerraform),
+			Annotations: strategy.Annotations(claim),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+
+	secret := &corev1.Secret{}
+	err := s.client.Get(ctx, client.ObjectKeyFromObject(desired), secret)
+	if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to get output secret: %w", err)
+	}
+	if err != nil {
+		if err := controllerutil.SetControllerReference(claim, desired, s.client.Scheme()); err != nil {
+			return fmt.Errorf("failed to set owner reference: %w", err)
+		}
+		if err := s.client.Create(ctx, desired); err != nil {
+			return fmt.Errorf("failed to create output secret: %w", err)
+		}
+		return nil
+	}
+
+	if err := strategy.CheckControlled(claim, secret, "secret"); err != nil {
+		return err
+	}
+	if !strategy.SyncMetadata(secret, desired) && equality.Semantic.DeepEqual(secret.Data, data) {
+		return nil
+	}
+	secret.Data = data
+	if err := s.client.Update(ctx, secret); err != nil {
+		return fmt.Errorf("failed to update output secret: %w", err)
+	}
+	return nil
+}
+
+func applyJobName(claim *scorev1b1.ResourceClaim, revision string) string {
+	return strategy.ResourceName(claim, "tf-apply-"+revision)
+}
+
+func destroyJobName(claim *scorev1b1.ResourceClaim) string {
+	return strategy.ResourceName(claim, "tf-destroy")
+}
+
+// runnerOutputsName returns the name of the Secret the runner publishes the module outputs to
+func runnerOutputsName(claim *scorev1b1.ResourceClaim) string {
+	return strategy.ResourceName(claim, "tf-outputs")
+}
+
+func outputSecretName(claim *scorev1b1.ResourceClaim) string {
+	return strategy.ResourceName(claim, "terraform")
+}
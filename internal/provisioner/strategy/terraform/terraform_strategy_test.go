@@ -0,0 +1,270 @@
+package terraform
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+func newTestStrategy(t *testing.T) (*TerraformStrategy, client.Client) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	return NewTerraformStrategy(c), c
+}
+
+func testContext() context.Context {
+	return strategy.WithProvisioner(context.Background(), &scorev1b1.ProvisionerSpec{
+		Type:     "postgres",
+		Strategy: scorev1b1.ProvisionerStrategyTerraform,
+		Terraform: &scorev1b1.TerraformProvisionerSpec{
+			Module:        "git::https://example.com/modules.git//postgres?ref=v1.2.0",
+			Image:         "registry.example.com/tofu-runner:1.8",
+			Backend:       scorev1b1.TerraformBackendSpec{Type: "s3", Config: map[string]string{"bucket": "state"}},
+			EnvFromSecret: "cloud-credentials",
+		},
+	})
+}
+
+func testClaim(params string) *scorev1b1.ResourceClaim {
+	return &scorev1b1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "orders-db", Namespace: "team-a", UID: "uid"},
+		Spec: scorev1b1.ResourceClaimSpec{
+			WorkloadRef: scorev1b1.NamespacedName{Name: "orders", Namespace: "team-a"},
+			Key:         "db",
+			Type:        "postgres",
+			Params:      &apiextv1.JSON{Raw: []byte(params)},
+		},
+	}
+}
+
+// finishJob sets the condition of a finished Job on the only Job of the claim running the action
+func finishJob(t *testing.T, ctx context.Context, c client.Client, s *TerraformStrategy, claim *scorev1b1.ResourceClaim, action string, conditionType batchv1.JobConditionType) *batchv1.Job {
+	t.Helper()
+	jobs, err := s.listJobs(ctx, claim, action)
+	if err != nil || len(jobs) != 1 {
+		t.Fatalf("%s jobs = %d, %v, want one", action, len(jobs), err)
+	}
+	job := jobs[0]
+	job.Status.Conditions = []batchv1.JobCondition{{Type: conditionType, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}}
+	if err := c.Status().Update(ctx, job); err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+func TestProvisionRunsApplyJob(t *testing.T) {
+	s, c := newTestStrategy(t)
+	ctx := testContext()
+	claim := testClaim(`{"instanceClass":"db.t3.micro","storageGB":20}`)
+
+	if _, err := s.Provision(ctx, claim); !errors.Is(err, strategy.ErrInProgress) {
+		t.Fatalf("Provision() error = %v, want ErrInProgress", err)
+	}
+	if phase, reason, _, err := s.GetStatus(ctx, claim); err != nil || phase != scorev1b1.ResourceClaimPhaseClaiming {
+		t.Errorf("GetStatus() = %s (%s), %v, want Claiming", phase, reason, err)
+	}
+
+	job := finishJob(t, ctx, c, s, claim, actionApply, batchv1.JobComplete)
+	env := map[string]string{}
+	container := job.Spec.Template.Spec.Containers[0]
+	for _, variable := range container.Env {
+		env[variable.Name] = variable.Value
+	}
+	if env["SCORE_BINARY"] != "tofu" || env["SCORE_VARIABLES"] != `{"instanceClass":"db.t3.micro","storageGB":20}` {
+		t.Errorf("runner env = %v, want tofu with the claim params as variables", env)
+	}
+	wantKey := `key = "score/team-a/` + strategy.ResourceName(claim, "terraform") + `/terraform.tfstate"`
+	if config := env["SCORE_BACKEND_CONFIG"]; !strings.Contains(config, `bucket = "state"`) || !strings.Contains(config, wantKey) {
+		t.Errorf("backend config = %q, want the bucket and %s", config, wantKey)
+	}
+	if len(container.EnvFrom) != 1 || container.EnvFrom[0].SecretRef.Name != "cloud-credentials" {
+		t.Errorf("runner envFrom = %v, want the cloud-credentials Secret", container.EnvFrom)
+	}
+	if !metav1.IsControlledBy(job, claim) {
+		t.Error("apply job is not controlled by the claim")
+	}
+
+	runnerOutputs := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: runnerOutputsName(claim), Namespace: claim.Namespace},
+		Data: map[string][]byte{outputsKey: []byte(`{
+			"host": {"sensitive": false, "type": "string", "value": "orders.abc.rds.amazonaws.com"},
+			"port": {"sensitive": false, "type": "number", "value": 5432},
+			"password": {"sensitive": true, "type": "string", "value": "s3cr3t"},
+			"uri": {"sensitive": false, "type": "string", "value": "postgres://orders.abc.rds.amazonaws.com:5432/orders"}
+		}`)},
+	}
+	if err := c.Create(ctx, runnerOutputs); err != nil {
+		t.Fatal(err)
+	}
+
+	if phase, reason, _, err := s.GetStatus(ctx, claim); err != nil || phase != scorev1b1.ResourceClaimPhaseBound {
+		t.Errorf("GetStatus() = %s (%s), %v, want Bound", phase, reason, err)
+	}
+	outputs, err := s.Provision(ctx, claim)
+	if err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+	if outputs.SecretRef == nil || outputs.SecretRef.Name != outputSecretName(claim) {
+		t.Fatalf("secretRef = %v, want %s", outputs.SecretRef, outputSecretName(claim))
+	}
+	if outputs.URI == nil || *outputs.URI != "postgres://orders.abc.rds.amazonaws.com:5432/orders" {
+		t.Errorf("uri = %v, want the uri output", outputs.URI)
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Name: outputSecretName(claim), Namespace: claim.Namespace}, secret); err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data["password"]) != "s3cr3t" || string(secret.Data["port"]) != "5432" || string(secret.Data["host"]) != "orders.abc.rds.amazonaws.com" {
+		t.Errorf("output secret data = %v, want the module outputs", secret.Data)
+	}
+	if !metav1.IsControlledBy(secret, claim) {
+		t.Error("output secret is not controlled by the claim")
+	}
+
+	// Changed params run a new revision; the completed Job of the earlier one is deleted once it is applied
+	claim.Spec.Params = &apiextv1.JSON{Raw: []byte(`{"instanceClass":"db.t3.small","storageGB":20}`)}
+	if _, err := s.Provision(ctx, claim); !errors.Is(err, strategy.ErrInProgress) {
+		t.Fatalf("Provision() error = %v, want ErrInProgress", err)
+	}
+	jobs, err := s.listJobs(ctx, claim, actionApply)
+	if err != nil || len(jobs) != 2 {
+		t.Fatalf("apply jobs = %d, %v, want two revisions", len(jobs), err)
+	}
+	current := jobs[0]
+	if current.Name == job.Name {
+		current = jobs[1]
+	}
+	current.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	if err := c.Status().Update(ctx, current); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Provision(ctx, claim); err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(job), &batchv1.Job{}); !apierrors.IsNotFound(err) {
+		t.Errorf("apply job of the earlier revision was not deleted: %v", err)
+	}
+}
+
+func TestProvisionReportsFailedApply(t *testing.T) {
+	s, c := newTestStrategy(t)
+	ctx := testContext()
+	claim := testClaim(`{}`)
+
+	if _, err := s.Provision(ctx, claim); !errors.Is(err, strategy.ErrInProgress) {
+		t.Fatalf("Provision() error = %v, want ErrInProgress", err)
+	}
+	job := finishJob(t, ctx, c, s, claim, actionApply, batchv1.JobFailed)
+
+	if phase, reason, _, err := s.GetStatus(ctx, claim); err != nil || phase != scorev1b1.ResourceClaimPhaseFailed || reason != "ApplyFailed" {
+		t.Errorf("GetStatus() = %s (%s), %v, want Failed with reason ApplyFailed", phase, reason, err)
+	}
+	if _, err := s.Provision(ctx, claim); err == nil || errors.Is(err, strategy.ErrInProgress) {
+		t.Fatalf("Provision() error = %v, want the apply failure", err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(job), &batchv1.Job{}); !apierrors.IsNotFound(err) {
+		t.Errorf("failed apply job was not deleted for the retry: %v", err)
+	}
+}
+
+func TestDeprovisionRunsDestroyJob(t *testing.T) {
+	s, c := newTestStrategy(t)
+	ctx := testContext()
+	claim := testClaim(`{}`)
+
+	if _, err := s.Provision(ctx, claim); !errors.Is(err, strategy.ErrInProgress) {
+		t.Fatalf("Provision() error = %v, want ErrInProgress", err)
+	}
+
+	// The destroy waits for the running apply, which holds the state lock
+	if err := s.Deprovision(ctx, claim); !errors.Is(err, strategy.ErrInProgress) {
+		t.Fatalf("Deprovision() error = %v, want ErrInProgress", err)
+	}
+	if jobs, err := s.listJobs(ctx, claim, actionDestroy); err != nil || len(jobs) != 0 {
+		t.Fatalf("destroy jobs = %d, %v, want none while the apply is running", len(jobs), err)
+	}
+	finishJob(t, ctx, c, s, claim, actionApply, batchv1.JobComplete)
+
+	if err := s.Deprovision(ctx, claim); !errors.Is(err, strategy.ErrInProgress) {
+		t.Fatalf("Deprovision() error = %v, want ErrInProgress", err)
+	}
+	job := finishJob(t, ctx, c, s, claim, actionDestroy, batchv1.JobFailed)
+	if err := s.Deprovision(ctx, claim); err == nil || errors.Is(err, strategy.ErrInProgress) {
+		t.Fatalf("Deprovision() error = %v, want the destroy failure", err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(job), &batchv1.Job{}); !apierrors.IsNotFound(err) {
+		t.Errorf("failed destroy job was not deleted for the retry: %v", err)
+	}
+
+	if err := s.Deprovision(ctx, claim); !errors.Is(err, strategy.ErrInProgress) {
+		t.Fatalf("Deprovision() error = %v, want ErrInProgress", err)
+	}
+	job = finishJob(t, ctx, c, s, claim, actionDestroy, batchv1.JobComplete)
+	if action := job.Labels[labelAction]; action != actionDestroy {
+		t.Errorf("runner action = %q, want destroy", action)
+	}
+	if err := s.Deprovision(ctx, claim); err != nil {
+		t.Errorf("Deprovision() error = %v", err)
+	}
+}
+
+func TestBackendConfig(t *testing.T) {
+	claim := testClaim(`{}`)
+	name := strategy.ResourceName(claim, "terraform")
+
+	tests := []struct {
+		name    string
+		backend scorev1b1.TerraformBackendSpec
+		want    string
+	}{
+		{
+			name:    "s3",
+			backend: scorev1b1.TerraformBackendSpec{Type: "s3", Config: map[string]string{"region": "us-east-1", "bucket": "state"}},
+			want:    `bucket = "state"` + "\n" + `key = "score/team-a/` + name + `/terraform.tfstate"` + "\n" + `region = "us-east-1"`,
+		},
+		{
+			name:    "gcs",
+			backend: scorev1b1.TerraformBackendSpec{Type: "gcs"},
+			want:    `prefix = "score/team-a/` + name + `"`,
+		},
+		{
+			name:    "kubernetes",
+			backend: scorev1b1.TerraformBackendSpec{Type: "kubernetes", Config: map[string]string{"namespace": "tfstate"}},
+			want:    `namespace = "tfstate"` + "\n" + `secret_suffix = "team-a-` + name + `"`,
+		},
+		{
+			name:    "custom key attribute with a template sequence",
+			backend: scorev1b1.TerraformBackendSpec{Type: "http", KeyAttribute: "address", Config: map[string]string{"username": "${user}"}},
+			want:    `address = "score/team-a/` + name + `/terraform.tfstate"` + "\n" + `username = "$${user}"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := backendConfig(claim, &tt.backend); got != tt.want {
+				t.Errorf("backendConfig() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
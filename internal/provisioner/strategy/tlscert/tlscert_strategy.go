@@ -0,0 +1,296 @@
+package tlscert
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+// defaultIssuerKind is the kind of the issuer when neither the class nor the claim sets one
+const defaultIssuerKind = "ClusterIssuer"
+
+// certificateGVK is the cert-manager Certificate kind
+var certificateGVK = schema.GroupVersionKind{Group: "cert-manager.io", Version: "v1", Kind: "Certificate"}
+
+func init() {
+	strategy.Register("tls-cert", func(c client.Client) strategy.Strategy { return NewTLSCertStrategy(c) })
+}
+
+// Parameters are the options of a certificate, read from the provisioner defaults, the claim's class and
+// the claim params, in increasing precedence
+type Parameters struct {
+	// Issuer is the name of the cert-manager issuer that signs the certificate
+	Issuer string `json:"issuer,omitempty"`
+
+	// IssuerKind is the kind of the issuer: "ClusterIssuer" (default) or "Issuer"
+	IssuerKind string `json:"issuerKind,omitempty"`
+
+	// Zone is the DNS zone of the certificate subject; the hostname defaults to <workload>-<namespace>.<zone>
+	Zone string `json:"zone,omitempty"`
+
+	// Hostname overrides the subject of the certificate
+	Hostname string `json:"hostname,omitempty"`
+
+	// DNSNames are additional names the certificate is valid for
+	DNSNames []string `json:"dnsNames,omitempty"`
+
+	// Duration is the requested lifetime of the certificate (default: chosen by the issuer)
+	Duration string `json:"duration,omitempty"`
+
+	// RenewBefore is how long before expiry cert-manager renews the certificate (default: chosen by cert-manager)
+	RenewBefore string `json:"renewBefore,omitempty"`
+}
+
+// TLSCertStrategy implements the Strategy interface for certificates issued by cert-manager
+type TLSCertStrategy struct {
+	client client.Client
+}
+
+// NewTLSCertStrategy creates a new TLSCertStrategy
+func NewTLSCertStrategy(k8sClient client.Client) *TLSCertStrategy {
+	return &TLSCertStrategy{
+		client: k8sClient,
+	}
+}
+
+// GetType returns the resource type this strategy handles
+func (s *TLSCertStrategy) GetType() string {
+	return "tls-cert"
+}
+
+// Provision applies a Certificate for the hostname and, once cert-manager reports it ready, publishes the
+// kubernetes.io/tls Secret it issued together with the hostname
+func (s *TLSCertStrategy) Provision(ctx context.Context, claim *scorev1b1.ResourceClaim) (*scorev1b1.ResourceClaimOutputs, error) {
+	params, err := parametersFor(ctx, claim)
+	if err != nil {
+		return nil, err
+	}
+	hostname, err := strategy.Hostname(claim, params.Hostname, params.Zone)
+	if err != nil {
+		return nil, err
+	}
+
+	certificate, err := s.applyCertificate(ctx, claim, buildCertificate(claim, params, hostname))
+	if err != nil {
+		return nil, err
+	}
+	if ready, _ := certificateReady(certificate); !ready {
+		return nil, strategy.ErrInProgress
+	}
+
+	name := secretName(claim)
+	return &scorev1b1.ResourceClaimOutputs{
+		SecretRef: &scorev1b1.LocalObjectReference{Name: name},
+		Cert:      &scorev1b1.CertificateOutput{SecretName: &name},
+		Hostname:  &hostname,
+	}, nil
+}
+
+// Deprovision deletes the Certificate and the Secret cert-manager issued for it, which cert-manager
+// does not delete by default
+func (s *TLSCertStrategy) Deprovision(ctx context.Context, claim *scorev1b1.ResourceClaim) error {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certificateGVK)
+	certificate.SetName(certificateName(claim))
+	certificate.SetNamespace(claim.Namespace)
+	if err := s.client.Delete(ctx, certificate); err != nil && !apierrors.IsNotFound(err) && !apimeta.IsNoMatchError(err) {
+		return fmt.Errorf("failed to delete certificate: %w", err)
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName(claim), Namespace: claim.Namespace}}
+	if err := s.client.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete certificate secret: %w", err)
+	}
+	return nil
+}
+
+// GetStatus returns the current status of the certificate
+func (s *TLSCertStrategy) GetStatus(ctx context.Context, claim *scorev1b1.ResourceClaim) (phase scorev1b1.ResourceClaimPhase, reason, message string, err error) {
+	certificate, err := s.getCertificate(ctx, claim)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return scorev1b1.ResourceClaimPhaseClaiming, "CertificateCreating",
+				"Certificate is being created", nil
+		}
+		if apimeta.IsNoMatchError(err) {
+			return scorev1b1.ResourceClaimPhaseFailed, "OperatorNotInstalled",
+				"cert-manager is not installed", nil
+		}
+		return scorev1b1.ResourceClaimPhaseFailed, "CertificateAccessFailed",
+			fmt.Sprintf("Failed to access certificate: %v", err), err
+	}
+
+	if ready, issuerMessage := certificateReady(certificate); !ready {
+		message := "Certificate has not been issued yet"
+		if issuerMessage != "" {
+			message = fmt.Sprintf("Certificate has not been issued yet: %s", issuerMessage)
+		}
+		return scorev1b1.ResourceClaimPhaseClaiming, "CertificateNotReady", message, nil
+	}
+	return scorev1b1.ResourceClaimPhaseBound, "Succeeded",
+		"Certificate is issued and available", nil
+}
+
+// parametersFor returns the parameters of the claim's class overlaid with the claim params
+func parametersFor(ctx context.Context, claim *scorev1b1.ResourceClaim) (*Parameters, error) {
+	params := &Parameters{}
+	if err := strategy.DecodeParameters(ctx, claim, params); err != nil {
+		return nil, err
+	}
+	if params.Issuer == "" {
+		return nil, fmt.Errorf("issuer must be set")
+	}
+	switch params.IssuerKind {
+	case "":
+		params.IssuerKind = defaultIssuerKind
+	case "ClusterIssuer", "Issuer":
+	default:
+		return nil, fmt.Errorf("unsupported issuer kind %q", params.IssuerKind)
+	}
+	for field, value := range map[string]string{"duration": params.Duration, "renewBefore": params.RenewBefore} {
+		if value == "" {
+			continue
+		}
+		if _, err := time.ParseDuration(value); err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", field, value, err)
+		}
+	}
+	return params, nil
+}
+
+// buildCertificate returns the Certificate of the claim, valid for the hostname and the additional DNS names
+func buildCertificate(claim *scorev1b1.ResourceClaim, params *Parameters, hostname string) *unstructured.Unstructured {
+	dnsNames := []interface{}{hostname}
+	for _, name := range params.DNSNames {
+		if name != hostname {
+			dnsNames = append(dnsNames, name)
+		}
+	}
+	spec := map[string]interface{}{
+		"secretName": secretName(claim),
+		"dnsNames":   dnsNames,
+		"issuerRef": map[string]interface{}{
+			"group": certificateGVK.Group,
+			"kind":  params.IssuerKind,
+			"name":  params.Issuer,
+		},
+		// Labels the issued Secret like the other resources of the claim
+		"secretTemplate": secretTemplate(claim),
+	}
+	if params.Duration != "" {
+		spec["duration"] = params.Duration
+	}
+	if params.RenewBefore != "" {
+		spec["renewBefore"] = params.RenewBefore
+	}
+
+	certificate := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	certificate.SetGroupVersionKind(certificateGVK)
+	certificate.SetName(certificateName(claim))
+	certificate.SetNamespace(claim.Namespace)
+	certificate.SetLabels(strategy.Labels(claim, "tls-cert"))
+	certificate.SetAnnotations(strategy.Annotations(claim))
+	return certificate
+}
+
+// secretTemplate returns the labels and annotations cert-manager puts on the issued Secret
+func secretTemplate(claim *scorev1b1.ResourceClaim) map[string]interface{} {
+	template := map[string]interface{}{}
+	for field, values := range map[string]map[string]string{
+		"labels":      strategy.Labels(claim, "tls-cert"),
+		"annotations": strategy.Annotations(claim),
+	} {
+		if len(values) == 0 {
+			continue
+		}
+		converted := map[string]interface{}{}
+		for key, value := range values {
+			converted[key] = value
+		}
+		template[field] = converted
+	}
+	return template
+}
+
+// applyCertificate creates the Certificate of the claim, or replaces its spec when it changed, and returns it
+func (s *TLSCertStrategy) applyCertificate(ctx context.Context, claim *scorev1b1.ResourceClaim, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	existing, err := s.getCertificate(ctx, claim)
+	if apierrors.IsNotFound(err) {
+		// Set ResourceClaim as owner for garbage collection
+		if err := controllerutil.SetControllerReference(claim, desired, s.client.Scheme()); err != nil {
+			return nil, fmt.Errorf("failed to set owner reference: %w", err)
+		}
+		if err := s.client.Create(ctx, desired); err != nil {
+			return nil, fmt.Errorf("failed to create certificate: %w", err)
+		}
+		return desired, nil
+	} else if err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("cert-manager is not installed: %w", err)
+		}
+		return nil, fmt.Errorf("failed to check existing certificate: %w", err)
+	}
+	if err := strategy.CheckControlled(claim, existing, "certificate"); err != nil {
+		return nil, err
+	}
+
+	metadataChanged := strategy.SyncMetadata(existing, desired)
+	want, _, _ := unstructured.NestedMap(desired.Object, "spec")
+	got, _, _ := unstructured.NestedMap(existing.Object, "spec")
+	if !metadataChanged && equality.Semantic.DeepEqual(want, got) {
+		return existing, nil
+	}
+	if err := unstructured.SetNestedMap(existing.Object, want, "spec"); err != nil {
+		return nil, fmt.Errorf("failed to set spec of certificate: %w", err)
+	}
+	if err := s.client.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to update certificate: %w", err)
+	}
+	return existing, nil
+}
+
+// getCertificate returns the Certificate of the claim
+func (s *TLSCertStrategy) getCertificate(ctx context.Context, claim *scorev1b1.ResourceClaim) (*unstructured.Unstructured, error) {
+	certificate := &unstructured.Unstructured{}
+	certificate.SetGroupVersionKind(certificateGVK)
+	if err := s.client.Get(ctx, client.ObjectKey{Name: certificateName(claim), Namespace: claim.Namespace}, certificate); err != nil {
+		return nil, err
+	}
+	return certificate, nil
+}
+
+// certificateReady reports whether cert-manager reports the Certificate ready, with the message of its Ready condition
+func certificateReady(certificate *unstructured.Unstructured) (bool, string) {
+	rawConditions, _, _ := unstructured.NestedSlice(certificate.Object, "status", "conditions")
+	for _, raw := range rawConditions {
+		condition, ok := raw.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		message, _ := condition["message"].(string)
+		return condition["status"] == string(metav1.ConditionTrue), message
+	}
+	return false, ""
+}
+
+func certificateName(claim *scorev1b1.ResourceClaim) string {
+	return strategy.ResourceName(claim, "tls")
+}
+
+// secretName is the kubernetes.io/tls Secret cert-manager issues the certificate into
+func secretName(claim *scorev1b1.ResourceClaim) string {
+	return strategy.ResourceName(claim, "tls")
+}
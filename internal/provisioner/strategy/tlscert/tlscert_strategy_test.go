@@ -0,0 +1,88 @@
+package tlscert
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+func TestProvisionCertificate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	s := NewTLSCertStrategy(c)
+
+	ctx := strategy.WithProvisioner(context.Background(), &scorev1b1.ProvisionerSpec{
+		Type: "tls-cert",
+		Defaults: &scorev1b1.ProvisionerDefaults{
+			Params: &runtime.RawExtension{Raw: []byte(`{"issuer":"letsencrypt","zone":"apps.example.com"}`)},
+		},
+	})
+	claim := &scorev1b1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-cert", Namespace: "team-a", UID: "uid"},
+		Spec: scorev1b1.ResourceClaimSpec{
+			WorkloadRef: scorev1b1.NamespacedName{Name: "web", Namespace: "team-a"},
+			Key:         "cert",
+			Type:        "tls-cert",
+		},
+	}
+
+	// The claim waits until cert-manager has issued the certificate
+	if _, err := s.Provision(ctx, claim); !errors.Is(err, strategy.ErrInProgress) {
+		t.Fatalf("Provision() error = %v, want ErrInProgress", err)
+	}
+	certificate, err := s.getCertificate(ctx, claim)
+	if err != nil {
+		t.Fatalf("certificate was not created: %v", err)
+	}
+	dnsNames, _, _ := unstructured.NestedStringSlice(certificate.Object, "spec", "dnsNames")
+	if len(dnsNames) != 1 || dnsNames[0] != "web-team-a.apps.example.com" {
+		t.Errorf("dnsNames = %v, want [web-team-a.apps.example.com]", dnsNames)
+	}
+	if kind, _, _ := unstructured.NestedString(certificate.Object, "spec", "issuerRef", "kind"); kind != "ClusterIssuer" {
+		t.Errorf("issuer kind = %q, want ClusterIssuer", kind)
+	}
+
+	if err := unstructured.SetNestedSlice(certificate.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True"},
+	}, "status", "conditions"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Update(ctx, certificate); err != nil {
+		t.Fatal(err)
+	}
+	outputs, err := s.Provision(ctx, claim)
+	if err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+	if outputs.SecretRef == nil || outputs.SecretRef.Name != secretName(claim) {
+		t.Errorf("secretRef = %+v, want %s", outputs.SecretRef, secretName(claim))
+	}
+	if outputs.Hostname == nil || *outputs.Hostname != "web-team-a.apps.example.com" {
+		t.Errorf("hostname = %v, want web-team-a.apps.example.com", outputs.Hostname)
+	}
+	if phase, reason, _, err := s.GetStatus(ctx, claim); err != nil || phase != scorev1b1.ResourceClaimPhaseBound {
+		t.Errorf("GetStatus() = %s (%s), %v, want Bound", phase, reason, err)
+	}
+
+	if err := s.Deprovision(ctx, claim); err != nil {
+		t.Fatalf("Deprovision() error = %v", err)
+	}
+	if _, err := s.getCertificate(ctx, claim); err == nil {
+		t.Error("certificate still exists after Deprovision")
+	}
+}
@@ -0,0 +1,61 @@
+package topic
+
+import (
+	"context"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+// streamGVK is the Stream kind of the NATS JetStream controller (nack)
+var streamGVK = schema.GroupVersionKind{Group: "jetstream.nats.io", Version: "v1beta2", Kind: "Stream"}
+
+// provisionStream applies the JetStream Stream of the claim and returns the connection details once the
+// controller reports it ready. Streams carry no credentials; NATS accounts are managed outside of the claim.
+func (s *TopicStrategy) provisionStream(ctx context.Context, claim *scorev1b1.ResourceClaim, params *Parameters) (map[string][]byte, error) {
+	stream, err := s.applyObject(ctx, claim, buildStream(claim, params))
+	if err != nil {
+		return nil, err
+	}
+	if ready, _ := objectReady(stream); !ready {
+		return nil, strategy.ErrInProgress
+	}
+
+	return map[string][]byte{
+		"url":      []byte(params.URL),
+		"stream":   []byte(params.Name),
+		"subjects": []byte(strings.Join(streamSubjects(params), ",")),
+	}, nil
+}
+
+// buildStream returns the Stream of the claim
+func buildStream(claim *scorev1b1.ResourceClaim, params *Parameters) *unstructured.Unstructured {
+	subjects := []interface{}{}
+	for _, subject := range streamSubjects(params) {
+		subjects = append(subjects, subject)
+	}
+	spec := map[string]interface{}{
+		"name":     params.Name,
+		"subjects": subjects,
+		"storage":  "file",
+	}
+	if params.Replicas > 0 {
+		spec["replicas"] = params.Replicas
+	}
+	if params.Retention != "" {
+		spec["maxAge"] = params.Retention
+	}
+	return newObject(claim, streamGVK, nil, spec)
+}
+
+// streamSubjects returns the subjects of the stream, defaulting to the name of the stream
+func streamSubjects(params *Parameters) []string {
+	if len(params.Subjects) > 0 {
+		return params.Subjects
+	}
+	return []string{params.Name}
+}
@@ -0,0 +1,121 @@
+package topic
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+// AuthenticationSCRAM authenticates clients with SCRAM-SHA-512 credentials of a KafkaUser
+const AuthenticationSCRAM = "scram-sha-512"
+
+// defaultPartitions is the number of partitions of a Kafka topic when neither the class nor the claim sets one
+const defaultPartitions = 1
+
+var (
+	// kafkaTopicGVK is the Strimzi KafkaTopic kind
+	kafkaTopicGVK = schema.GroupVersionKind{Group: "kafka.strimzi.io", Version: "v1beta2", Kind: "KafkaTopic"}
+
+	// kafkaUserGVK is the Strimzi KafkaUser kind
+	kafkaUserGVK = schema.GroupVersionKind{Group: "kafka.strimzi.io", Version: "v1beta2", Kind: "KafkaUser"}
+)
+
+// provisionKafkaTopic applies the KafkaTopic of the claim, and the KafkaUser when the parameters ask for
+// authentication, and returns the connection details once the operator reports them ready
+func (s *TopicStrategy) provisionKafkaTopic(ctx context.Context, claim *scorev1b1.ResourceClaim, params *Parameters) (map[string][]byte, error) {
+	kafkaTopic, err := s.applyObject(ctx, claim, buildKafkaTopic(claim, params))
+	if err != nil {
+		return nil, err
+	}
+	if ready, _ := objectReady(kafkaTopic); !ready {
+		return nil, strategy.ErrInProgress
+	}
+
+	data := map[string][]byte{
+		"bootstrapServers": []byte(bootstrapServers(params)),
+		"topic":            []byte(params.Name),
+	}
+	if params.Authentication == "" {
+		return data, nil
+	}
+
+	kafkaUser, err := s.applyObject(ctx, claim, buildKafkaUser(claim, params))
+	if err != nil {
+		return nil, err
+	}
+	if ready, _ := objectReady(kafkaUser); !ready {
+		return nil, strategy.ErrInProgress
+	}
+	// The user operator generates a Secret named like the KafkaUser with the password of the user
+	userSecret := &corev1.Secret{}
+	if err := s.client.Get(ctx, client.ObjectKey{Name: objectName(claim), Namespace: claim.Namespace}, userSecret); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, strategy.ErrInProgress
+		}
+		return nil, fmt.Errorf("failed to get kafka user secret: %w", err)
+	}
+	data["username"] = []byte(objectName(claim))
+	data["password"] = userSecret.Data["password"]
+	data["saslMechanism"] = []byte("SCRAM-SHA-512")
+	return data, nil
+}
+
+// buildKafkaTopic returns the KafkaTopic of the claim
+func buildKafkaTopic(claim *scorev1b1.ResourceClaim, params *Parameters) *unstructured.Unstructured {
+	partitions := params.Partitions
+	if partitions == 0 {
+		partitions = defaultPartitions
+	}
+	spec := map[string]interface{}{
+		"topicName":  params.Name,
+		"partitions": partitions,
+	}
+	if params.Replicas > 0 {
+		spec["replicas"] = params.Replicas
+	}
+	if retention := params.retentionMillis(); retention > 0 {
+		spec["config"] = map[string]interface{}{"retention.ms": retention}
+	}
+	// The topic operator only reconciles topics labelled with their cluster
+	return newObject(claim, kafkaTopicGVK, map[string]string{"strimzi.io/cluster": params.Cluster}, spec)
+}
+
+// buildKafkaUser returns the KafkaUser of the claim, allowed to produce to and consume from the topic
+func buildKafkaUser(claim *scorev1b1.ResourceClaim, params *Parameters) *unstructured.Unstructured {
+	topic := map[string]interface{}{"type": "topic", "name": params.Name, "patternType": "literal"}
+	spec := map[string]interface{}{
+		"authentication": map[string]interface{}{"type": params.Authentication},
+		"authorization": map[string]interface{}{
+			"type": "simple",
+			"acls": []interface{}{
+				map[string]interface{}{
+					"resource":   topic,
+					"operations": []interface{}{"Describe", "Read", "Write"},
+				},
+				// Consumer groups are not known in advance, so the user may join any group
+				map[string]interface{}{
+					"resource":   map[string]interface{}{"type": "group", "name": "*", "patternType": "literal"},
+					"operations": []interface{}{"Read"},
+				},
+			},
+		},
+	}
+	return newObject(claim, kafkaUserGVK, map[string]string{"strimzi.io/cluster": params.Cluster}, spec)
+}
+
+// bootstrapServers returns the bootstrap servers of the parameters, defaulting to the plain listener of the
+// bootstrap Service Strimzi creates for the cluster
+func bootstrapServers(params *Parameters) string {
+	if params.BootstrapServers != "" {
+		return params.BootstrapServers
+	}
+	return params.Cluster + "-kafka-bootstrap:9092"
+}
@@ -0,0 +1,366 @@
+package topic
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+const (
+	// BackendStrimzi provisions a KafkaTopic of the Strimzi operator
+	BackendStrimzi = "strimzi"
+
+	// BackendNATS provisions a JetStream Stream of the NATS JetStream controller
+	BackendNATS = "nats"
+)
+
+// topicNamePattern are the characters Kafka and JetStream both accept in topic and stream names
+var topicNamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,249}$`)
+
+func init() {
+	strategy.Register("topic", func(c client.Client) strategy.Strategy { return NewTopicStrategy(c) })
+}
+
+// Parameters are the options of a topic, read from the provisioner defaults, the claim's class and
+// the claim params, in increasing precedence
+type Parameters struct {
+	// Backend selects the messaging system: "strimzi" (default) or "nats"
+	Backend string `json:"backend,omitempty"`
+
+	// Name is the name of the topic or stream (default: the name of the claim)
+	Name string `json:"name,omitempty"`
+
+	// Partitions is the number of partitions of a Kafka topic (default 1)
+	Partitions int64 `json:"partitions,omitempty"`
+
+	// Replicas is the replication factor of the topic or stream (default: chosen by the operator)
+	Replicas int64 `json:"replicas,omitempty"`
+
+	// Retention is how long messages are kept, as a Go duration (default: chosen by the broker)
+	Retention string `json:"retention,omitempty"`
+
+	// Cluster is the Strimzi Kafka cluster the topic is created in (required for strimzi)
+	Cluster string `json:"cluster,omitempty"`
+
+	// BootstrapServers are the addresses clients connect to (default <cluster>-kafka-bootstrap:9092)
+	BootstrapServers string `json:"bootstrapServers,omitempty"`
+
+	// Authentication creates a KafkaUser with access to the topic: "" (none) or "scram-sha-512"
+	Authentication string `json:"authentication,omitempty"`
+
+	// URL is the address of the NATS servers clients connect to (required for nats)
+	URL string `json:"url,omitempty"`
+
+	// Subjects are the subjects the stream captures (default: the name of the stream)
+	Subjects []string `json:"subjects,omitempty"`
+}
+
+// TopicStrategy implements the Strategy interface for topics and streams of event-driven Workloads
+type TopicStrategy struct {
+	client client.Client
+}
+
+// NewTopicStrategy creates a new TopicStrategy
+func NewTopicStrategy(k8sClient client.Client) *TopicStrategy {
+	return &TopicStrategy{
+		client: k8sClient,
+	}
+}
+
+// GetType returns the resource type this strategy handles
+func (s *TopicStrategy) GetType() string {
+	return "topic"
+}
+
+// Provision applies the topic or stream of the claim and, once the operator reports it ready, publishes
+// the connection details through the Secret <claim>-topic-secret
+func (s *TopicStrategy) Provision(ctx context.Context, claim *scorev1b1.ResourceClaim) (*scorev1b1.ResourceClaimOutputs, error) {
+	params, err := parametersFor(ctx, claim)
+	if err != nil {
+		return nil, err
+	}
+
+	var data map[string][]byte
+	if params.Backend == BackendNATS {
+		data, err = s.provisionStream(ctx, claim, params)
+	} else {
+		data, err = s.provisionKafkaTopic(ctx, claim, params)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applySecret(ctx, claim, data); err != nil {
+		return nil, err
+	}
+	return &scorev1b1.ResourceClaimOutputs{
+		SecretRef: &scorev1b1.LocalObjectReference{Name: secretName(claim)},
+	}, nil
+}
+
+// Deprovision deletes the topic or stream together with the KafkaUser of the claim. Deleting a KafkaTopic
+// deletes the topic and its messages unless the topic operator is configured to keep them.
+func (s *TopicStrategy) Deprovision(ctx context.Context, claim *scorev1b1.ResourceClaim) error {
+	for _, gvk := range []schema.GroupVersionKind{kafkaTopicGVK, kafkaUserGVK, streamGVK} {
+		obj := &unstructured.Unstructured{}
+		obj.SetGroupVersionKind(gvk)
+		obj.SetName(objectName(claim))
+		obj.SetNamespace(claim.Namespace)
+		if err := s.client.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) && !apimeta.IsNoMatchError(err) {
+			return fmt.Errorf("failed to delete %s: %w", gvk.Kind, err)
+		}
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: secretName(claim), Namespace: claim.Namespace}}
+	if err := s.client.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete secret: %w", err)
+	}
+	return nil
+}
+
+// GetStatus returns the current status of the topic or stream
+func (s *TopicStrategy) GetStatus(ctx context.Context, claim *scorev1b1.ResourceClaim) (phase scorev1b1.ResourceClaimPhase, reason, message string, err error) {
+	params, err := parametersFor(ctx, claim)
+	if err != nil {
+		return scorev1b1.ResourceClaimPhaseFailed, "InvalidParameters", err.Error(), nil
+	}
+
+	gvk := kafkaTopicGVK
+	if params.Backend == BackendNATS {
+		gvk = streamGVK
+	}
+	phase, reason, message, err = s.objectStatus(ctx, claim, gvk)
+	if err != nil || phase != scorev1b1.ResourceClaimPhaseBound || params.Backend == BackendNATS || params.Authentication == "" {
+		return phase, reason, message, err
+	}
+
+	// The credentials are ready once the user operator has reconciled the KafkaUser
+	return s.objectStatus(ctx, claim, kafkaUserGVK)
+}
+
+// parametersFor returns the parameters of the claim's class overlaid with the claim params
+func parametersFor(ctx context.Context, claim *scorev1b1.ResourceClaim) (*Parameters, error) {
+	params := &Parameters{}
+	if err := strategy.DecodeParameters(ctx, claim, params); err != nil {
+		return nil, err
+	}
+	if params.Name == "" {
+		params.Name = claim.Name
+	}
+	if !topicNamePattern.MatchString(params.Name) {
+		return nil, fmt.Errorf("invalid topic name %q: must consist of at most 249 alphanumeric characters, '.', '_' or '-'", params.Name)
+	}
+	if params.Partitions < 0 {
+		return nil, fmt.Errorf("invalid partitions %d: must not be negative", params.Partitions)
+	}
+	if params.Replicas < 0 {
+		return nil, fmt.Errorf("invalid replicas %d: must not be negative", params.Replicas)
+	}
+	if params.Retention != "" {
+		if retention, err := time.ParseDuration(params.Retention); err != nil || retention <= 0 {
+			return nil, fmt.Errorf("invalid retention %q: must be a positive duration", params.Retention)
+		}
+	}
+
+	switch params.Backend {
+	case "":
+		params.Backend = BackendStrimzi
+		fallthrough
+	case BackendStrimzi:
+		if params.Cluster == "" {
+			return nil, fmt.Errorf("cluster must be set for the strimzi backend")
+		}
+		switch params.Authentication {
+		case "", AuthenticationSCRAM:
+		default:
+			return nil, fmt.Errorf("unsupported authentication %q", params.Authentication)
+		}
+	case BackendNATS:
+		if params.URL == "" {
+			return nil, fmt.Errorf("url must be set for the nats backend")
+		}
+		if params.Authentication != "" {
+			return nil, fmt.Errorf("authentication is not supported by the nats backend")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported topic backend %q", params.Backend)
+	}
+	return params, nil
+}
+
+// applyObject creates the operator object of the claim, or replaces its spec when it changed, and returns it
+func (s *TopicStrategy) applyObject(ctx context.Context, claim *scorev1b1.ResourceClaim, desired *unstructured.Unstructured) (*unstructured.Unstructured, error) {
+	kind := desired.GetKind()
+	existing, err := s.getObject(ctx, claim, desired.GroupVersionKind())
+	if apierrors.IsNotFound(err) {
+		// Set ResourceClaim as owner for garbage collection
+		if err := controllerutil.SetControllerReference(claim, desired, s.client.Scheme()); err != nil {
+			return nil, fmt.Errorf("failed to set owner reference: %w", err)
+		}
+		if err := s.client.Create(ctx, desired); err != nil {
+			return nil, fmt.Errorf("failed to create %s: %w", kind, err)
+		}
+		return desired, nil
+	} else if err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return nil, fmt.Errorf("the %s CRD is not installed: %w", kind, err)
+		}
+		return nil, fmt.Errorf("failed to check existing %s: %w", kind, err)
+	}
+	if err := strategy.CheckControlled(claim, existing, kind); err != nil {
+		return nil, err
+	}
+
+	metadataChanged := strategy.SyncMetadata(existing, desired)
+	want, _, _ := unstructured.NestedMap(desired.Object, "spec")
+	got, _, _ := unstructured.NestedMap(existing.Object, "spec")
+	if !metadataChanged && equality.Semantic.DeepEqual(want, got) {
+		return existing, nil
+	}
+	if err := unstructured.SetNestedMap(existing.Object, want, "spec"); err != nil {
+		return nil, fmt.Errorf("failed to set spec of %s: %w", kind, err)
+	}
+	if err := s.client.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("failed to update %s: %w", kind, err)
+	}
+	return existing, nil
+}
+
+// getObject returns the operator object of the given kind of the claim
+func (s *TopicStrategy) getObject(ctx context.Context, claim *scorev1b1.ResourceClaim, gvk schema.GroupVersionKind) (*unstructured.Unstructured, error) {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := s.client.Get(ctx, client.ObjectKey{Name: objectName(claim), Namespace: claim.Namespace}, obj); err != nil {
+		return nil, err
+	}
+	return obj, nil
+}
+
+// objectStatus returns the status of the operator object of the given kind of the claim
+func (s *TopicStrategy) objectStatus(ctx context.Context, claim *scorev1b1.ResourceClaim, gvk schema.GroupVersionKind) (phase scorev1b1.ResourceClaimPhase, reason, message string, err error) {
+	obj, err := s.getObject(ctx, claim, gvk)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return scorev1b1.ResourceClaimPhaseClaiming, gvk.Kind + "Creating",
+				fmt.Sprintf("%s is being created", gvk.Kind), nil
+		}
+		if apimeta.IsNoMatchError(err) {
+			return scorev1b1.ResourceClaimPhaseFailed, "OperatorNotInstalled",
+				fmt.Sprintf("The %s CRD is not installed", gvk.Kind), nil
+		}
+		return scorev1b1.ResourceClaimPhaseFailed, gvk.Kind + "AccessFailed",
+			fmt.Sprintf("Failed to access %s: %v", gvk.Kind, err), err
+	}
+
+	if ready, operatorMessage := objectReady(obj); !ready {
+		message := fmt.Sprintf("%s is not ready yet", gvk.Kind)
+		if operatorMessage != "" {
+			message = fmt.Sprintf("%s is not ready yet: %s", gvk.Kind, operatorMessage)
+		}
+		return scorev1b1.ResourceClaimPhaseClaiming, gvk.Kind + "NotReady", message, nil
+	}
+	return scorev1b1.ResourceClaimPhaseBound, "Succeeded",
+		fmt.Sprintf("%s is ready and available", gvk.Kind), nil
+}
+
+// objectReady reports whether the operator reports the object ready, with the message of its Ready condition.
+// Strimzi and the NATS JetStream controller both report readiness through a Ready condition.
+func objectReady(obj *unstructured.Unstructured) (bool, string) {
+	rawConditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, raw := range rawConditions {
+		condition, ok := raw.(map[string]interface{})
+		if !ok || condition["type"] != "Ready" {
+			continue
+		}
+		message, _ := condition["message"].(string)
+		return condition["status"] == string(metav1.ConditionTrue), message
+	}
+	return false, ""
+}
+
+// applySecret creates the connection Secret of the claim, or updates its data and metadata when they changed
+func (s *TopicStrategy) applySecret(ctx context.Context, claim *scorev1b1.ResourceClaim, data map[string][]byte) error {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        secretName(claim),
+			Namespace:   claim.Namespace,
+			Labels:      strategy.Labels(claim, "topic"),
+			Annotations: strategy.Annotations(claim),
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: data,
+	}
+
+	existing := &corev1.Secret{}
+	err := s.client.Get(ctx, client.ObjectKeyFromObject(secret), existing)
+	if err == nil {
+		if err := strategy.CheckControlled(claim, existing, "secret"); err != nil {
+			return err
+		}
+		if !strategy.SyncMetadata(existing, secret) && equality.Semantic.DeepEqual(existing.Data, data) {
+			return nil
+		}
+		existing.Data = data
+		if err := s.client.Update(ctx, existing); err != nil {
+			return fmt.Errorf("failed to update secret: %w", err)
+		}
+		return nil
+	} else if client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to check existing secret: %w", err)
+	}
+
+	if err := controllerutil.SetControllerReference(claim, secret, s.client.Scheme()); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	if err := s.client.Create(ctx, secret); err != nil {
+		return fmt.Errorf("failed to create secret: %w", err)
+	}
+	return nil
+}
+
+// newObject returns an operator object of the claim with the given spec
+func newObject(claim *scorev1b1.ResourceClaim, gvk schema.GroupVersionKind, labels map[string]string, spec map[string]interface{}) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+	obj.SetGroupVersionKind(gvk)
+	obj.SetName(objectName(claim))
+	obj.SetNamespace(claim.Namespace)
+	objLabels := strategy.Labels(claim, "topic")
+	for key, value := range labels {
+		objLabels[key] = value
+	}
+	obj.SetLabels(objLabels)
+	obj.SetAnnotations(strategy.Annotations(claim))
+	return obj
+}
+
+// retentionMillis returns the retention in milliseconds, or 0 when the parameters set none
+func (p *Parameters) retentionMillis() int64 {
+	if p.Retention == "" {
+		return 0
+	}
+	retention, _ := time.ParseDuration(p.Retention)
+	return retention.Milliseconds()
+}
+
+// objectName is the name of the KafkaTopic, KafkaUser or Stream of the claim
+func objectName(claim *scorev1b1.ResourceClaim) string {
+	return strategy.ResourceName(claim, "topic")
+}
+
+func secretName(claim *scorev1b1.ResourceClaim) string {
+	return strategy.ResourceName(claim, "topic-secret")
+}
@@ -0,0 +1,205 @@
+package topic
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+func newTestStrategy(t *testing.T) (*TopicStrategy, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	return NewTopicStrategy(c), c
+}
+
+func testClaim(class, params string) *scorev1b1.ResourceClaim {
+	claim := &scorev1b1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-events", Namespace: "default", UID: "uid"},
+		Spec:       scorev1b1.ResourceClaimSpec{Key: "events", Type: "topic", Class: &class},
+	}
+	if params != "" {
+		claim.Spec.Params = &apiextv1.JSON{Raw: []byte(params)}
+	}
+	return claim
+}
+
+func topicProvisioner() *scorev1b1.ProvisionerSpec {
+	return &scorev1b1.ProvisionerSpec{
+		Type: "topic",
+		Classes: []scorev1b1.ClassSpec{
+			{Name: "kafka", Parameters: &runtime.RawExtension{Raw: []byte(`{"cluster":"events","authentication":"scram-sha-512"}`)}},
+			{Name: "nats", Parameters: &runtime.RawExtension{Raw: []byte(`{"backend":"nats","url":"nats://nats:4222"}`)}},
+		},
+	}
+}
+
+// markReady sets the Ready condition the operator reports on the object of the claim
+func markReady(t *testing.T, c client.Client, claim *scorev1b1.ResourceClaim, gvk schema.GroupVersionKind) {
+	t.Helper()
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	if err := c.Get(context.Background(), client.ObjectKey{Name: objectName(claim), Namespace: claim.Namespace}, obj); err != nil {
+		t.Fatal(err)
+	}
+	if err := unstructured.SetNestedSlice(obj.Object, []interface{}{
+		map[string]interface{}{"type": "Ready", "status": "True"},
+	}, "status", "conditions"); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Update(context.Background(), obj); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProvisionKafkaTopic(t *testing.T) {
+	s, c := newTestStrategy(t)
+	ctx := strategy.WithProvisioner(context.Background(), topicProvisioner())
+	claim := testClaim("kafka", `{"partitions":6,"retention":"168h"}`)
+
+	if _, err := s.Provision(ctx, claim); !errors.Is(err, strategy.ErrInProgress) {
+		t.Fatalf("Provision() error = %v, want ErrInProgress", err)
+	}
+	kafkaTopic, err := s.getObject(ctx, claim, kafkaTopicGVK)
+	if err != nil {
+		t.Fatalf("kafka topic was not created: %v", err)
+	}
+	if cluster := kafkaTopic.GetLabels()["strimzi.io/cluster"]; cluster != "events" {
+		t.Errorf("strimzi.io/cluster = %q, want events", cluster)
+	}
+	if partitions, _, _ := unstructured.NestedInt64(kafkaTopic.Object, "spec", "partitions"); partitions != 6 {
+		t.Errorf("partitions = %d, want 6", partitions)
+	}
+	if retention, _, _ := unstructured.NestedInt64(kafkaTopic.Object, "spec", "config", "retention.ms"); retention != 604800000 {
+		t.Errorf("retention.ms = %d, want 604800000", retention)
+	}
+
+	// The credentials are published once the user operator has created the user
+	markReady(t, c, claim, kafkaTopicGVK)
+	if _, err := s.Provision(ctx, claim); !errors.Is(err, strategy.ErrInProgress) {
+		t.Fatalf("Provision() error = %v, want ErrInProgress", err)
+	}
+	markReady(t, c, claim, kafkaUserGVK)
+	userSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: objectName(claim), Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("s3cret")},
+	}
+	if err := c.Create(ctx, userSecret); err != nil {
+		t.Fatal(err)
+	}
+
+	outputs, err := s.Provision(ctx, claim)
+	if err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+	if outputs.SecretRef == nil || outputs.SecretRef.Name != secretName(claim) {
+		t.Fatalf("secretRef = %+v, want %s", outputs.SecretRef, secretName(claim))
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Name: secretName(claim), Namespace: "default"}, secret); err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"bootstrapServers": "events-kafka-bootstrap:9092",
+		"topic":            "web-events",
+		"username":         objectName(claim),
+		"password":         "s3cret",
+		"saslMechanism":    "SCRAM-SHA-512",
+	}
+	for key, value := range want {
+		if got := string(secret.Data[key]); got != value {
+			t.Errorf("secret[%s] = %q, want %q", key, got, value)
+		}
+	}
+
+	phase, _, _, err := s.GetStatus(ctx, claim)
+	if err != nil || phase != scorev1b1.ResourceClaimPhaseBound {
+		t.Errorf("GetStatus() = %s, %v, want Bound", phase, err)
+	}
+
+	if err := s.Deprovision(ctx, claim); err != nil {
+		t.Fatalf("Deprovision() error = %v", err)
+	}
+	for _, gvk := range []schema.GroupVersionKind{kafkaTopicGVK, kafkaUserGVK} {
+		if _, err := s.getObject(ctx, claim, gvk); err == nil {
+			t.Errorf("%s still exists after Deprovision", gvk.Kind)
+		}
+	}
+}
+
+func TestProvisionNATSStream(t *testing.T) {
+	s, c := newTestStrategy(t)
+	ctx := strategy.WithProvisioner(context.Background(), topicProvisioner())
+	claim := testClaim("nats", `{"name":"orders","subjects":["orders.>"],"retention":"24h"}`)
+
+	if _, err := s.Provision(ctx, claim); !errors.Is(err, strategy.ErrInProgress) {
+		t.Fatalf("Provision() error = %v, want ErrInProgress", err)
+	}
+	phase, reason, _, err := s.GetStatus(ctx, claim)
+	if err != nil || phase != scorev1b1.ResourceClaimPhaseClaiming || reason != "StreamNotReady" {
+		t.Errorf("GetStatus() = %s (%s), %v, want Claiming (StreamNotReady)", phase, reason, err)
+	}
+	stream, err := s.getObject(ctx, claim, streamGVK)
+	if err != nil {
+		t.Fatalf("stream was not created: %v", err)
+	}
+	if maxAge, _, _ := unstructured.NestedString(stream.Object, "spec", "maxAge"); maxAge != "24h" {
+		t.Errorf("maxAge = %q, want 24h", maxAge)
+	}
+
+	markReady(t, c, claim, streamGVK)
+	if _, err := s.Provision(ctx, claim); err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Name: secretName(claim), Namespace: "default"}, secret); err != nil {
+		t.Fatal(err)
+	}
+	if string(secret.Data["url"]) != "nats://nats:4222" || string(secret.Data["stream"]) != "orders" || string(secret.Data["subjects"]) != "orders.>" {
+		t.Errorf("secret data = %v, want the url, stream and subjects", secret.Data)
+	}
+}
+
+func TestProvisionInvalidParameters(t *testing.T) {
+	tests := []struct {
+		name   string
+		class  string
+		params string
+	}{
+		{"invalid name", "kafka", `{"name":"orders/v1"}`},
+		{"negative partitions", "kafka", `{"partitions":-1}`},
+		{"invalid retention", "kafka", `{"retention":"a week"}`},
+		{"unsupported authentication", "kafka", `{"authentication":"plain"}`},
+		{"missing cluster", "kafka", `{"cluster":""}`},
+		{"nats authentication", "nats", `{"authentication":"scram-sha-512"}`},
+		{"unsupported backend", "kafka", `{"backend":"rabbitmq"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestStrategy(t)
+			ctx := strategy.WithProvisioner(context.Background(), topicProvisioner())
+			if _, err := s.Provision(ctx, testClaim(tt.class, tt.params)); err == nil {
+				t.Error("Provision() error = nil, want an error")
+			}
+		})
+	}
+}
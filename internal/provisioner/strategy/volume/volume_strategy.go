@@ -0,0 +1,214 @@
+package volume
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+// defaultSize is the requested capacity when neither the class nor the claim sets one
+const defaultSize = "1Gi"
+
+func init() {
+	strategy.Register("volume", func(c client.Client) strategy.Strategy { return NewVolumeStrategy(c) })
+}
+
+// Parameters are the options of a volume, read from the provisioner defaults, the claim's class and
+// the claim params, in increasing precedence
+type Parameters struct {
+	// Size is the requested capacity (default 1Gi)
+	Size string `json:"size,omitempty"`
+
+	// StorageClass is the storage class of the volume (default: the cluster default)
+	StorageClass string `json:"storageClass,omitempty"`
+
+	// AccessMode is the access mode of the volume (default ReadWriteOnce)
+	AccessMode string `json:"accessMode,omitempty"`
+}
+
+// VolumeStrategy implements the Strategy interface for persistent volume provisioning
+type VolumeStrategy struct {
+	client client.Client
+}
+
+// NewVolumeStrategy creates a new VolumeStrategy
+func NewVolumeStrategy(k8sClient client.Client) *VolumeStrategy {
+	return &VolumeStrategy{
+		client: k8sClient,
+	}
+}
+
+// GetType returns the resource type this strategy handles
+func (s *VolumeStrategy) GetType() string {
+	return "volume"
+}
+
+// Provision creates a PersistentVolumeClaim sized by the parameters and publishes it as the pvcRef output.
+// Volumes can be expanded by raising the size; shrinking is rejected because Kubernetes does not support it.
+func (s *VolumeStrategy) Provision(ctx context.Context, claim *scorev1b1.ResourceClaim) (*scorev1b1.ResourceClaimOutputs, error) {
+	params, err := parametersFor(ctx, claim)
+	if err != nil {
+		return nil, err
+	}
+	size, accessMode, err := params.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	err = s.client.Get(ctx, client.ObjectKey{Name: pvcName(claim), Namespace: claim.Namespace}, pvc)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed to get persistent volume claim: %w", err)
+	}
+	if err != nil {
+		pvc = buildPVC(claim, params, size, accessMode)
+		if err := controllerutil.SetControllerReference(claim, pvc, s.client.Scheme()); err != nil {
+			return nil, fmt.Errorf("failed to set owner reference: %w", err)
+		}
+		if err := s.client.Create(ctx, pvc); err != nil {
+			return nil, fmt.Errorf("failed to create persistent volume claim: %w", err)
+		}
+	} else {
+		if err := strategy.CheckControlled(claim, pvc, "persistent volume claim"); err != nil {
+			return nil, err
+		}
+		changed := strategy.SyncMetadata(pvc, buildPVC(claim, params, size, accessMode))
+		requested := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+		switch size.Cmp(requested) {
+		case -1:
+			return nil, fmt.Errorf("volume size cannot be reduced from %s to %s", requested.String(), size.String())
+		case 1:
+			pvc.Spec.Resources.Requests[corev1.ResourceStorage] = size
+			changed = true
+		}
+		if changed {
+			if err := s.client.Update(ctx, pvc); err != nil {
+				return nil, fmt.Errorf("failed to update persistent volume claim: %w", err)
+			}
+		}
+	}
+
+	return outputsFor(pvc), nil
+}
+
+// Deprovision deletes the PersistentVolumeClaim; whether the data survives is up to the reclaim policy
+// of the storage class
+func (s *VolumeStrategy) Deprovision(ctx context.Context, claim *scorev1b1.ResourceClaim) error {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pvcName(claim),
+			Namespace: claim.Namespace,
+		},
+	}
+	if err := s.client.Delete(ctx, pvc); client.IgnoreNotFound(err) != nil {
+		return fmt.Errorf("failed to delete persistent volume claim: %w", err)
+	}
+	return nil
+}
+
+// GetStatus returns the current status of the volume. A claim waiting for its first consumer stays Pending
+// until a Workload mounts it, so a Pending PersistentVolumeClaim counts as available.
+func (s *VolumeStrategy) GetStatus(ctx context.Context, claim *scorev1b1.ResourceClaim) (phase scorev1b1.ResourceClaimPhase, reason, message string, err error) {
+	pvc := &corev1.PersistentVolumeClaim{}
+	err = s.client.Get(ctx, client.ObjectKey{Name: pvcName(claim), Namespace: claim.Namespace}, pvc)
+	if err != nil {
+		if client.IgnoreNotFound(err) != nil {
+			return scorev1b1.ResourceClaimPhaseFailed, "VolumeAccessFailed",
+				fmt.Sprintf("Failed to access persistent volume claim: %v", err), err
+		}
+		return scorev1b1.ResourceClaimPhaseClaiming, "VolumeCreating",
+			"Persistent volume claim is being created", nil
+	}
+
+	if pvc.Status.Phase == corev1.ClaimLost {
+		return scorev1b1.ResourceClaimPhaseFailed, "VolumeLost",
+			"The volume backing the persistent volume claim was lost", nil
+	}
+	return scorev1b1.ResourceClaimPhaseBound, "Succeeded",
+		"Persistent volume claim is available", nil
+}
+
+// parametersFor returns the parameters of the claim's class overlaid with the claim params
+func parametersFor(ctx context.Context, claim *scorev1b1.ResourceClaim) (*Parameters, error) {
+	params := &Parameters{}
+	if err := strategy.DecodeParameters(ctx, claim, params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
+// validate returns the requested capacity and access mode of the volume
+func (p *Parameters) validate() (resource.Quantity, corev1.PersistentVolumeAccessMode, error) {
+	value := p.Size
+	if value == "" {
+		value = defaultSize
+	}
+	size, err := resource.ParseQuantity(value)
+	if err != nil {
+		return resource.Quantity{}, "", fmt.Errorf("invalid size %q: %w", value, err)
+	}
+	if size.Sign() <= 0 {
+		return resource.Quantity{}, "", fmt.Errorf("invalid size %q: must be positive", value)
+	}
+
+	accessMode := corev1.PersistentVolumeAccessMode(p.AccessMode)
+	switch accessMode {
+	case "":
+		accessMode = corev1.ReadWriteOnce
+	case corev1.ReadWriteOnce, corev1.ReadWriteOncePod, corev1.ReadWriteMany, corev1.ReadOnlyMany:
+	default:
+		return resource.Quantity{}, "", fmt.Errorf("invalid access mode %q", p.AccessMode)
+	}
+	return size, accessMode, nil
+}
+
+// buildPVC constructs the PersistentVolumeClaim of the claim
+func buildPVC(claim *scorev1b1.ResourceClaim, params *Parameters, size resource.Quantity, accessMode corev1.PersistentVolumeAccessMode) *corev1.PersistentVolumeClaim {
+	pvc := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        pvcName(claim),
+			Namespace:   claim.Namespace,
+			Labels:      strategy.Labels(claim, "volume"),
+			Annotations: strategy.Annotations(claim),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{accessMode},
+			Resources: corev1.VolumeResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+			},
+		},
+	}
+	if params.StorageClass != "" {
+		pvc.Spec.StorageClassName = &params.StorageClass
+	}
+	return pvc
+}
+
+// outputsFor returns the outputs of the PersistentVolumeClaim. The capacity is the bound capacity once the
+// volume is bound and the requested size until then.
+func outputsFor(pvc *corev1.PersistentVolumeClaim) *scorev1b1.ResourceClaimOutputs {
+	capacity, ok := pvc.Status.Capacity[corev1.ResourceStorage]
+	if !ok {
+		capacity = pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	}
+	ref := &scorev1b1.PersistentVolumeClaimOutput{
+		Name:     pvc.Name,
+		Capacity: capacity.String(),
+	}
+	if pvc.Spec.StorageClassName != nil {
+		ref.StorageClass = *pvc.Spec.StorageClassName
+	}
+	return &scorev1b1.ResourceClaimOutputs{PVCRef: ref}
+}
+
+func pvcName(claim *scorev1b1.ResourceClaim) string {
+	return strategy.ResourceName(claim, "volume")
+}
@@ -0,0 +1,182 @@
+package volume
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apiextv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+func newTestStrategy(t *testing.T) (*VolumeStrategy, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+	return NewVolumeStrategy(c), c
+}
+
+func testClaim(params string) *scorev1b1.ResourceClaim {
+	class := "fast"
+	claim := &scorev1b1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-data", Namespace: "default", UID: "uid"},
+		Spec:       scorev1b1.ResourceClaimSpec{Key: "data", Type: "volume", Class: &class},
+	}
+	if params != "" {
+		claim.Spec.Params = &apiextv1.JSON{Raw: []byte(params)}
+	}
+	return claim
+}
+
+func volumeProvisioner() *scorev1b1.ProvisionerSpec {
+	return &scorev1b1.ProvisionerSpec{
+		Type: "volume",
+		Classes: []scorev1b1.ClassSpec{
+			{Name: "fast", Parameters: &runtime.RawExtension{Raw: []byte(`{"size":"5Gi","storageClass":"ssd"}`)}},
+		},
+	}
+}
+
+func TestProvisionVolume(t *testing.T) {
+	s, c := newTestStrategy(t)
+	ctx := strategy.WithProvisioner(context.Background(), volumeProvisioner())
+
+	claim := testClaim(`{"size":"10Gi"}`)
+	outputs, err := s.Provision(ctx, claim)
+	if err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+	want := scorev1b1.PersistentVolumeClaimOutput{Name: pvcName(claim), StorageClass: "ssd", Capacity: "10Gi"}
+	if outputs.PVCRef == nil || *outputs.PVCRef != want {
+		t.Errorf("pvcRef = %+v, want %+v", outputs.PVCRef, want)
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := c.Get(ctx, client.ObjectKey{Name: pvcName(claim), Namespace: "default"}, pvc); err != nil {
+		t.Fatal(err)
+	}
+	if pvc.Spec.StorageClassName == nil || *pvc.Spec.StorageClassName != "ssd" {
+		t.Errorf("storageClassName = %v, want ssd", pvc.Spec.StorageClassName)
+	}
+	if len(pvc.Spec.AccessModes) != 1 || pvc.Spec.AccessModes[0] != corev1.ReadWriteOnce {
+		t.Errorf("accessModes = %v, want [ReadWriteOnce]", pvc.Spec.AccessModes)
+	}
+	if len(pvc.OwnerReferences) != 1 || pvc.OwnerReferences[0].Name != "web-data" {
+		t.Errorf("ownerReferences = %v, want the claim", pvc.OwnerReferences)
+	}
+
+	// Raising the size expands the claim; reducing it is rejected
+	if _, err := s.Provision(ctx, testClaim(`{"size":"20Gi"}`)); err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(pvc), pvc); err != nil {
+		t.Fatal(err)
+	}
+	if got := pvc.Spec.Resources.Requests[corev1.ResourceStorage]; got.Cmp(resource.MustParse("20Gi")) != 0 {
+		t.Errorf("requested storage = %s, want 20Gi", got.String())
+	}
+	if _, err := s.Provision(ctx, testClaim("")); err == nil {
+		t.Error("Provision() error = nil, want an error for a smaller size")
+	}
+
+	phase, _, _, err := s.GetStatus(ctx, testClaim(""))
+	if err != nil || phase != scorev1b1.ResourceClaimPhaseBound {
+		t.Errorf("GetStatus() = %s, %v, want Bound", phase, err)
+	}
+
+	if err := s.Deprovision(ctx, testClaim("")); err != nil {
+		t.Fatalf("Deprovision() error = %v", err)
+	}
+	phase, _, _, err = s.GetStatus(ctx, testClaim(""))
+	if err != nil || phase != scorev1b1.ResourceClaimPhaseClaiming {
+		t.Errorf("GetStatus() after Deprovision() = %s, %v, want Claiming", phase, err)
+	}
+}
+
+func TestProvisionPropagatesMetadata(t *testing.T) {
+	s, c := newTestStrategy(t)
+	ctx := strategy.WithProvisioner(context.Background(), volumeProvisioner())
+
+	claim := testClaim("")
+	claim.Spec.Metadata = &scorev1b1.PropagatedMetadata{
+		Labels:      map[string]string{"cost-center": "cc-42"},
+		Annotations: map[string]string{"example.com/owner": "payments"},
+	}
+	if _, err := s.Provision(ctx, claim); err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+	pvc := &corev1.PersistentVolumeClaim{}
+	if err := c.Get(ctx, client.ObjectKey{Name: pvcName(claim), Namespace: "default"}, pvc); err != nil {
+		t.Fatal(err)
+	}
+	if pvc.Labels["cost-center"] != "cc-42" || pvc.Labels["score.dev/resource-claim"] != "web-data" {
+		t.Errorf("labels = %v, want the propagated and the claim labels", pvc.Labels)
+	}
+	if pvc.Annotations["example.com/owner"] != "payments" {
+		t.Errorf("annotations = %v, want the propagated annotation", pvc.Annotations)
+	}
+
+	// Changed Workload labels reach the volume provisioned earlier
+	claim.Spec.Metadata.Labels["cost-center"] = "cc-43"
+	if _, err := s.Provision(ctx, claim); err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(pvc), pvc); err != nil {
+		t.Fatal(err)
+	}
+	if pvc.Labels["cost-center"] != "cc-43" {
+		t.Errorf("cost-center label = %q, want cc-43", pvc.Labels["cost-center"])
+	}
+}
+
+func TestProvisionNameConflict(t *testing.T) {
+	s, c := newTestStrategy(t)
+	ctx := strategy.WithProvisioner(context.Background(), volumeProvisioner())
+
+	// A claim is never handed a persistent volume claim it does not control
+	claim := testClaim("")
+	foreign := &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: pvcName(claim), Namespace: "default"},
+	}
+	if err := c.Create(ctx, foreign); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Provision(ctx, claim); !errors.Is(err, strategy.ErrNameConflict) {
+		t.Errorf("Provision() error = %v, want ErrNameConflict", err)
+	}
+}
+
+func TestProvisionInvalidParameters(t *testing.T) {
+	tests := []struct {
+		name   string
+		params string
+	}{
+		{"invalid size", `{"size":"large"}`},
+		{"zero size", `{"size":"0"}`},
+		{"invalid access mode", `{"accessMode":"ReadWriteSometimes"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, _ := newTestStrategy(t)
+			ctx := strategy.WithProvisioner(context.Background(), volumeProvisioner())
+			if _, err := s.Provision(ctx, testClaim(tt.params)); err == nil {
+				t.Error("Provision() error = nil, want an error")
+			}
+		})
+	}
+}
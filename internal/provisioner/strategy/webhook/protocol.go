@@ -0,0 +1,56 @@
+package webhook
+
+import (
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// APIVersion is the version of the provisioner protocol sent with every request
+const APIVersion = "provisioner.score.dev/v1"
+
+// Protocol endpoints, relative to the URL of the provisioner service. Every endpoint accepts a POSTed
+// Request and must be idempotent, as requests are retried and repeated across reconciles.
+const (
+	// ProvisionPath creates the resource if needed and responds with a ProvisionResponse
+	ProvisionPath = "/v1/provision"
+	// StatusPath responds with the StatusResponse of the resource
+	StatusPath = "/v1/status"
+	// DeprovisionPath deletes the resource; deleting a resource that does not exist succeeds
+	DeprovisionPath = "/v1/deprovision"
+)
+
+// Request is the body of every protocol request
+type Request struct {
+	// APIVersion is the protocol version of the request
+	APIVersion string `json:"apiVersion"`
+	// Claim is the ResourceClaim the request is about
+	Claim Claim `json:"claim"`
+}
+
+// Claim identifies a ResourceClaim and carries its spec, including the resolver-specific params
+type Claim struct {
+	Name      string                      `json:"name"`
+	Namespace string                      `json:"namespace"`
+	UID       string                      `json:"uid"`
+	Spec      scorev1b1.ResourceClaimSpec `json:"spec"`
+}
+
+// ProvisionResponse is the response of the provision endpoint
+type ProvisionResponse struct {
+	// Outputs are the outputs of the provisioned resource
+	Outputs *scorev1b1.ResourceClaimOutputs `json:"outputs"`
+}
+
+// StatusResponse is the response of the status endpoint
+type StatusResponse struct {
+	// Phase is Claiming while the resource is being provisioned, then Bound or Failed
+	Phase scorev1b1.ResourceClaimPhase `json:"phase"`
+	// Reason is an abstract reason for the phase
+	Reason string `json:"reason,omitempty"`
+	// Message is a human-readable message for the phase
+	Message string `json:"message,omitempty"`
+}
+
+// ErrorResponse is the body of responses with a non-2xx status code
+type ErrorResponse struct {
+	Message string `json:"message"`
+}
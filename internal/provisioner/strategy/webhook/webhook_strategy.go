@@ -0,0 +1,286 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/lru"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+func init() {
+	strategy.Register(scorev1b1.ProvisionerStrategyWebhook, func(c client.Client) strategy.Strategy { return NewWebhookStrategy(c) })
+}
+
+const (
+	// defaultTimeout bounds each request when the provisioner sets no timeout
+	defaultTimeout = 30 * time.Second
+	// defaultMaxRetries is the number of retries of transient failures when the provisioner sets none
+	defaultMaxRetries = 3
+	// maxHTTPClients bounds the HTTP clients kept for distinct TLS configurations; the least recently used
+	// one is dropped, e.g. once a rotated client certificate is no longer in use
+	maxHTTPClients = 32
+	// maxResponseSize bounds the responses read from the service
+	maxResponseSize = 1 << 20
+)
+
+// WebhookStrategy implements the Strategy interface by proxying claims to an out-of-process provisioner
+// service configured by the webhook field of the provisioner
+type WebhookStrategy struct {
+	client client.Client
+
+	// httpClients are shared by provisioners with the same TLS configuration
+	httpClients *lru.Cache
+
+	mu sync.Mutex
+	// failures counts the consecutive transient failures of the requests of a claim to an endpoint
+	failures map[requestKey]int
+}
+
+// requestKey identifies the requests of a claim to an endpoint
+type requestKey struct {
+	claim types.UID
+	path  string
+}
+
+// NewWebhookStrategy creates a new WebhookStrategy
+func NewWebhookStrategy(k8sClient client.Client) *WebhookStrategy {
+	return &WebhookStrategy{
+		client: k8sClient,
+		httpClients: lru.NewWithEvictionFunc(maxHTTPClients, func(_ lru.Key, value any) {
+			value.(*http.Client).CloseIdleConnections()
+		}),
+		failures: make(map[requestKey]int),
+	}
+}
+
+// GetType returns the resource type this strategy handles
+func (s *WebhookStrategy) GetType() string {
+	return scorev1b1.ProvisionerStrategyWebhook
+}
+
+// Provision asks the provisioner service to provision the resource and returns its outputs
+func (s *WebhookStrategy) Provision(ctx context.Context, claim *scorev1b1.ResourceClaim) (*scorev1b1.ResourceClaimOutputs, error) {
+	response := &ProvisionResponse{}
+	if err := s.call(ctx, claim, ProvisionPath, response); err != nil {
+		return nil, err
+	}
+	if response.Outputs == nil {
+		return nil, fmt.Errorf("provisioner service returned no outputs")
+	}
+	return response.Outputs, nil
+}
+
+// Deprovision asks the provisioner service to delete the resource
+func (s *WebhookStrategy) Deprovision(ctx context.Context, claim *scorev1b1.ResourceClaim) error {
+	if err := s.call(ctx, claim, DeprovisionPath, nil); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, path := range []string{ProvisionPath, StatusPath} {
+		delete(s.failures, requestKey{claim: claim.UID, path: path})
+	}
+	return nil
+}
+
+// GetStatus asks the provisioner service for the status of the resource
+func (s *WebhookStrategy) GetStatus(ctx context.Context, claim *scorev1b1.ResourceClaim) (phase scorev1b1.ResourceClaimPhase, reason, message string, err error) {
+	response := &StatusResponse{}
+	if err := s.call(ctx, claim, StatusPath, response); err != nil {
+		return "", "", "", err
+	}
+	switch response.Phase {
+	case scorev1b1.ResourceClaimPhaseClaiming, scorev1b1.ResourceClaimPhaseBound, scorev1b1.ResourceClaimPhaseFailed:
+		return response.Phase, response.Reason, response.Message, nil
+	default:
+		return "", "", "", fmt.Errorf("provisioner service returned unsupported phase %q", response.Phase)
+	}
+}
+
+// call POSTs the claim to the endpoint of the configured service and decodes the response into out unless it
+// is nil. A request is made once per call: transient failures are returned wrapping strategy.ErrTransient, so
+// that the claim is reconciled again after a delay, until more than maxRetries consecutive ones failed.
+func (s *WebhookStrategy) call(ctx context.Context, claim *scorev1b1.ResourceClaim, path string, out any) error {
+	provisioner := strategy.ProvisionerFromContext(ctx)
+	if provisioner == nil || provisioner.Webhook == nil {
+		return fmt.Errorf("no webhook is configured for resource type %s", claim.Spec.Type)
+	}
+	webhook := provisioner.Webhook
+
+	httpClient, err := s.httpClient(ctx, webhook)
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(Request{
+		APIVersion: APIVersion,
+		Claim: Claim{
+			Name:      claim.Name,
+			Namespace: claim.Namespace,
+			UID:       string(claim.UID),
+			Spec:      claim.Spec,
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	timeout := defaultTimeout
+	if webhook.Timeout != nil {
+		timeout = webhook.Timeout.Duration
+	}
+	maxRetries := defaultMaxRetries
+	if webhook.MaxRetries != nil {
+		maxRetries = int(*webhook.MaxRetries)
+	}
+	url := strings.TrimSuffix(webhook.URL, "/") + path
+
+	key := requestKey{claim: claim.UID, path: path}
+	response, err := post(ctx, httpClient, url, body, timeout)
+	var transient *transientError
+	if errors.As(err, &transient) {
+		if s.retry(key, maxRetries) {
+			return fmt.Errorf("%w: %w", strategy.ErrTransient, err)
+		}
+		return err
+	}
+	s.mu.Lock()
+	delete(s.failures, key)
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if out != nil {
+		if err := json.Unmarshal(response, out); err != nil {
+			return fmt.Errorf("failed to decode response of %s: %w", url, err)
+		}
+	}
+	return nil
+}
+
+// retry counts a transient failure of the request and reports whether it may be retried. The count is reset
+// once the retries are exhausted.
+func (s *WebhookStrategy) retry(key requestKey, maxRetries int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.failures[key] >= maxRetries {
+		delete(s.failures, key)
+		return false
+	}
+	s.failures[key]++
+	return true
+}
+
+// transientError is a failure that may succeed when retried
+type transientError struct {
+	err error
+}
+
+func (e *transientError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transientError) Unwrap() error {
+	return e.err
+}
+
+// post sends one request. Connection failures, 429 and 5xx responses are reported as a *transientError.
+func post(ctx context.Context, httpClient *http.Client, url string, body []byte, timeout time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return nil, &transientError{err: fmt.Errorf("request to %s failed: %w", url, err)}
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(response.Body, maxResponseSize))
+	if err != nil {
+		return nil, &transientError{err: fmt.Errorf("failed to read response of %s: %w", url, err)}
+	}
+	if response.StatusCode >= 200 && response.StatusCode < 300 {
+		return data, nil
+	}
+
+	message := strings.TrimSpace(string(data))
+	errorResponse := &ErrorResponse{}
+	if json.Unmarshal(data, errorResponse) == nil && errorResponse.Message != "" {
+		message = errorResponse.Message
+	}
+	err = fmt.Errorf("provisioner service responded %d: %s", response.StatusCode, message)
+	if response.StatusCode == http.StatusTooManyRequests || response.StatusCode >= 500 {
+		return nil, &transientError{err: err}
+	}
+	return nil, err
+}
+
+// httpClient returns the client for the TLS configuration of the webhook
+func (s *WebhookStrategy) httpClient(ctx context.Context, webhook *scorev1b1.WebhookProvisionerSpec) (*http.Client, error) {
+	var certPEM, keyPEM []byte
+	if ref := webhook.ClientCertSecretRef; ref != nil {
+		secret := &corev1.Secret{}
+		if err := s.client.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+			return nil, fmt.Errorf("failed to get client certificate Secret %s/%s: %w", ref.Namespace, ref.Name, err)
+		}
+		certPEM, keyPEM = secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]
+	}
+
+	hash := sha256.New()
+	for _, part := range [][]byte{[]byte(webhook.CABundle), certPEM, keyPEM} {
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+	key := hex.EncodeToString(hash.Sum(nil))
+
+	if httpClient, exists := s.httpClients.Get(key); exists {
+		return httpClient.(*http.Client), nil
+	}
+
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+	if webhook.CABundle != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(webhook.CABundle)) {
+			return nil, fmt.Errorf("caBundle contains no valid certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+	if webhook.ClientCertSecretRef != nil {
+		certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("invalid client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+	httpClient := &http.Client{Transport: transport}
+	s.httpClients.Add(key, httpClient)
+	return httpClient, nil
+}
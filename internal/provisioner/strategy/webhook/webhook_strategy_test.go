@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner/strategy"
+)
+
+func TestWebhookStrategy(t *testing.T) {
+	failures := 1
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request := &Request{}
+		if err := json.NewDecoder(r.Body).Decode(request); err != nil || request.APIVersion != APIVersion {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch r.URL.Path {
+		case ProvisionPath:
+			// The first request fails transiently and is retried by the next reconcile
+			if failures > 0 {
+				failures--
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			uri := "mysql://" + request.Claim.Name + ".example.com"
+			_ = json.NewEncoder(w).Encode(ProvisionResponse{Outputs: &scorev1b1.ResourceClaimOutputs{URI: &uri}})
+		case StatusPath:
+			_ = json.NewEncoder(w).Encode(StatusResponse{Phase: scorev1b1.ResourceClaimPhaseBound})
+		case DeprovisionPath:
+			w.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(w).Encode(ErrorResponse{Message: "instance is protected"})
+		}
+	}))
+	defer server.Close()
+
+	caBundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	ctx := strategy.WithProvisioner(context.Background(), &scorev1b1.ProvisionerSpec{
+		Type:     "mysql",
+		Strategy: scorev1b1.ProvisionerStrategyWebhook,
+		Webhook:  &scorev1b1.WebhookProvisionerSpec{URL: server.URL, CABundle: caBundle, MaxRetries: ptr.To[int32](1)},
+	})
+	claim := &scorev1b1.ResourceClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "default", UID: "uid-1"},
+		Spec:       scorev1b1.ResourceClaimSpec{Key: "db", Type: "mysql"},
+	}
+	webhook := NewWebhookStrategy(fake.NewClientBuilder().Build())
+
+	if _, err := webhook.Provision(ctx, claim); !errors.Is(err, strategy.ErrTransient) {
+		t.Fatalf("Provision() error = %v, want a transient error", err)
+	}
+	outputs, err := webhook.Provision(ctx, claim)
+	if err != nil {
+		t.Fatalf("Provision() error = %v", err)
+	}
+	if outputs.URI == nil || *outputs.URI != "mysql://db.example.com" {
+		t.Errorf("Provision() outputs = %+v, want the URI returned by the service", outputs)
+	}
+
+	phase, _, _, err := webhook.GetStatus(ctx, claim)
+	if err != nil || phase != scorev1b1.ResourceClaimPhaseBound {
+		t.Errorf("GetStatus() = %q, %v, want Bound", phase, err)
+	}
+
+	// Client errors are not retried and carry the message of the service
+	if err := webhook.Deprovision(ctx, claim); err == nil || !strings.Contains(err.Error(), "403: instance is protected") {
+		t.Errorf("Deprovision() error = %v, want the error of the service", err)
+	}
+
+	// Without the CA bundle the service certificate is not trusted
+	untrusted := strategy.WithProvisioner(context.Background(), &scorev1b1.ProvisionerSpec{
+		Webhook: &scorev1b1.WebhookProvisionerSpec{URL: server.URL, MaxRetries: ptr.To[int32](0)},
+	})
+	if _, err := webhook.Provision(untrusted, claim); err == nil || errors.Is(err, strategy.ErrTransient) {
+		t.Errorf("Provision() error = %v, want a certificate verification error once the retries are exhausted", err)
+	}
+
+	if _, err := webhook.Provision(context.Background(), claim); err == nil {
+		t.Error("Provision() error = nil, want an error without a configured webhook")
+	}
+}
@@ -0,0 +1,21 @@
+package provisioner
+
+import scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+
+// terraformStateKeyAttributes are the settings the state key of a claim is passed as, by backend type
+var terraformStateKeyAttributes = map[string]string{
+	"s3":         "key",
+	"azurerm":    "key",
+	"gcs":        "prefix",
+	"kubernetes": "secret_suffix",
+}
+
+// TerraformStateKeyAttribute returns the backend setting the terraform strategy sets to the state key of
+// each claim: the configured keyAttribute, or the default of the backend type. It returns "" for backend
+// types without a default when keyAttribute is not set.
+func TerraformStateKeyAttribute(backend *scorev1b1.TerraformBackendSpec) string {
+	if backend.KeyAttribute != "" {
+		return backend.KeyAttribute
+	}
+	return terraformStateKeyAttributes[backend.Type]
+}
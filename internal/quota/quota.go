@@ -0,0 +1,236 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// ErrQuotaExceeded indicates that admitting a Workload would exceed a configured quota
+var ErrQuotaExceeded = errors.New("quota exceeded")
+
+// Condition type and reason recording that a Workload is held back by a quota.
+// They mirror the conditions package, which depends on this package.
+const (
+	conditionInputsValid = "InputsValid"
+	reasonQuotaExceeded  = "QuotaExceeded"
+)
+
+// Violation describes a quota limit that a Workload would exceed
+type Violation struct {
+	// Quota is the name of the violated quota
+	Quota string
+	// Limit names the violated limit (e.g., "maxWorkloads", "maxClaims[postgres]", "cpu")
+	Limit string
+	// Used is the usage including the evaluated Workload
+	Used string
+	// Max is the configured limit
+	Max string
+}
+
+// Error implements error
+func (v *Violation) Error() string {
+	return fmt.Sprintf("%v: quota %q limits %s to %s (requested %s)", ErrQuotaExceeded, v.Quota, v.Limit, v.Max, v.Used)
+}
+
+// Unwrap allows errors.Is(err, ErrQuotaExceeded)
+func (v *Violation) Unwrap() error {
+	return ErrQuotaExceeded
+}
+
+// Evaluate checks whether the Workload fits within every quota that applies to it.
+// Workloads are admitted in creation order: only Workloads created before this one
+// (and neither being deleted nor held back by a quota) count towards its usage, so admitted
+// Workloads keep running when a newer Workload would exceed a limit.
+// Returns a *Violation for the first exceeded limit, or nil.
+func Evaluate(quotas []scorev1b1.QuotaSpec, workload *scorev1b1.Workload, workloads []scorev1b1.Workload) error {
+	for i := range quotas {
+		quota := &quotas[i]
+		selector, err := selectorFor(quota)
+		if err != nil {
+			return fmt.Errorf("invalid selector in quota %q: %w", quota.Name, err)
+		}
+		if !applies(quota, selector, workload) {
+			continue
+		}
+
+		usage := newUsage()
+		usage.add(workload)
+		for j := range workloads {
+			other := &workloads[j]
+			if other.Namespace == workload.Namespace && other.Name == workload.Name {
+				continue
+			}
+			if !other.DeletionTimestamp.IsZero() || IsHeldBack(other) || !admittedBefore(other, workload) {
+				continue
+			}
+			// Workloads fanned out to environments run nothing themselves; their instances are counted instead
+			if len(other.Spec.Environments) > 0 {
+				continue
+			}
+			if quota.Scope != scorev1b1.QuotaScopeCluster && other.Namespace != workload.Namespace {
+				continue
+			}
+			if applies(quota, selector, other) {
+				usage.add(other)
+			}
+		}
+
+		if violation := usage.check(quota); violation != nil {
+			return violation
+		}
+	}
+	return nil
+}
+
+// selectorFor converts the quota label selector, matching everything if unset
+func selectorFor(quota *scorev1b1.QuotaSpec) (labels.Selector, error) {
+	if quota.Selector == nil {
+		return labels.Everything(), nil
+	}
+	return metav1.LabelSelectorAsSelector(quota.Selector)
+}
+
+// applies reports whether the Workload falls under the quota
+func applies(quota *scorev1b1.QuotaSpec, selector labels.Selector, workload *scorev1b1.Workload) bool {
+	if len(quota.Namespaces) > 0 {
+		found := false
+		for _, ns := range quota.Namespaces {
+			if ns == workload.Namespace {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return selector.Matches(labels.Set(workload.Labels))
+}
+
+// IsHeldBack reports whether the Workload status records that it was rejected by a quota
+func IsHeldBack(workload *scorev1b1.Workload) bool {
+	condition := apimeta.FindStatusCondition(workload.Status.Conditions, conditionInputsValid)
+	return condition != nil && condition.Status == metav1.ConditionFalse && condition.Reason == reasonQuotaExceeded
+}
+
+// admittedBefore orders Workloads by creation time, then by namespace and name
+func admittedBefore(a, b *scorev1b1.Workload) bool {
+	if !a.CreationTimestamp.Equal(&b.CreationTimestamp) {
+		return a.CreationTimestamp.Before(&b.CreationTimestamp)
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+// usage aggregates the consumption of a set of Workloads
+type usage struct {
+	workloads int32
+	claims    map[string]int32
+	cpu       resource.Quantity
+	memory    resource.Quantity
+}
+
+func newUsage() *usage {
+	return &usage{claims: make(map[string]int32)}
+}
+
+// add accounts for a Workload. Requests that are not valid quantities are ignored.
+func (u *usage) add(workload *scorev1b1.Workload) {
+	u.workloads++
+	for _, res := range workload.Spec.Resources {
+		u.claims[res.Type]++
+	}
+	for _, container := range workload.Spec.Containers {
+		if container.Resources == nil {
+			continue
+		}
+		for name, total := range map[string]*resource.Quantity{"cpu": &u.cpu, "memory": &u.memory} {
+			if value := container.Resources.Requests[name]; value != "" {
+				if q, err := resource.ParseQuantity(value); err == nil {
+					total.Add(q)
+				}
+			}
+		}
+	}
+}
+
+// check compares the usage against the quota limits
+func (u *usage) check(quota *scorev1b1.QuotaSpec) *Violation {
+	if quota.MaxWorkloads != nil && u.workloads > *quota.MaxWorkloads {
+		return &Violation{
+			Quota: quota.Name,
+			Limit: "maxWorkloads",
+			Used:  fmt.Sprint(u.workloads),
+			Max:   fmt.Sprint(*quota.MaxWorkloads),
+		}
+	}
+
+	types := make([]string, 0, len(quota.MaxClaims))
+	for resourceType := range quota.MaxClaims {
+		types = append(types, resourceType)
+	}
+	sort.Strings(types)
+	for _, resourceType := range types {
+		if limit := quota.MaxClaims[resourceType]; u.claims[resourceType] > limit {
+			return &Violation{
+				Quota: quota.Name,
+				Limit: fmt.Sprintf("maxClaims[%s]", resourceType),
+				Used:  fmt.Sprint(u.claims[resourceType]),
+				Max:   fmt.Sprint(limit),
+			}
+		}
+	}
+
+	for _, limit := range []struct {
+		name  string
+		value string
+		used  resource.Quantity
+	}{
+		{"cpu", quota.CPU, u.cpu},
+		{"memory", quota.Memory, u.memory},
+	} {
+		if limit.value == "" {
+			continue
+		}
+		maxQuantity, err := resource.ParseQuantity(limit.value)
+		if err != nil {
+			continue
+		}
+		if limit.used.Cmp(maxQuantity) > 0 {
+			return &Violation{
+				Quota: quota.Name,
+				Limit: limit.name,
+				Used:  limit.used.String(),
+				Max:   maxQuantity.String(),
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package quota
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func newWorkload(namespace, name string, age time.Duration, labels map[string]string, cpu string, resources ...string) scorev1b1.Workload {
+	wl := scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         namespace,
+			Labels:            labels,
+			CreationTimestamp: metav1.NewTime(time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC).Add(-age)),
+		},
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{
+				"main": {Image: "nginx", Resources: &scorev1b1.ResourceRequirements{Requests: map[string]string{"cpu": cpu}}},
+			},
+		},
+	}
+	if len(resources) > 0 {
+		wl.Spec.Resources = make(map[string]scorev1b1.ResourceSpec)
+		for _, resourceType := range resources {
+			wl.Spec.Resources[resourceType+"-"+name] = scorev1b1.ResourceSpec{Type: resourceType}
+		}
+	}
+	return wl
+}
+
+func int32Ptr(v int32) *int32 {
+	return &v
+}
+
+func TestEvaluate(t *testing.T) {
+	deleting := newWorkload("team-a", "deleting", 3*time.Hour, nil, "1")
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	heldBack := newWorkload("team-a", "held-back", 3*time.Hour, nil, "1", "postgres")
+	heldBack.Status.Conditions = []metav1.Condition{
+		{Type: conditionInputsValid, Status: metav1.ConditionFalse, Reason: reasonQuotaExceeded},
+	}
+	fannedOut := newWorkload("team-a", "fanned-out", 3*time.Hour, nil, "1", "postgres")
+	fannedOut.Spec.Environments = []string{"staging", "prod"}
+
+	existing := []scorev1b1.Workload{
+		newWorkload("team-a", "old", 2*time.Hour, map[string]string{"team": "a"}, "2", "postgres"),
+		newWorkload("team-b", "other", 2*time.Hour, map[string]string{"team": "a"}, "2"),
+		deleting,
+		heldBack,
+		fannedOut,
+	}
+
+	tests := []struct {
+		name      string
+		quotas    []scorev1b1.QuotaSpec
+		workload  scorev1b1.Workload
+		wantLimit string
+	}{
+		{
+			name:     "no quotas",
+			workload: newWorkload("team-a", "new", 0, nil, "1"),
+		},
+		{
+			name:      "namespace workload limit exceeded",
+			quotas:    []scorev1b1.QuotaSpec{{Name: "ns", MaxWorkloads: int32Ptr(1)}},
+			workload:  newWorkload("team-a", "new", 0, nil, "1"),
+			wantLimit: "maxWorkloads",
+		},
+		{
+			name:     "older workload keeps its admission",
+			quotas:   []scorev1b1.QuotaSpec{{Name: "ns", MaxWorkloads: int32Ptr(1)}},
+			workload: existing[0],
+		},
+		{
+			name:     "deleting workloads are not counted",
+			quotas:   []scorev1b1.QuotaSpec{{Name: "ns", MaxWorkloads: int32Ptr(2)}},
+			workload: newWorkload("team-a", "new", 0, nil, "1"),
+		},
+		{
+			name:     "workloads fanned out to environments are not counted",
+			quotas:   []scorev1b1.QuotaSpec{{Name: "cpu", CPU: "3"}},
+			workload: newWorkload("team-a", "new", 0, nil, "1"),
+		},
+		{
+			name:     "workloads held back by a quota are not counted",
+			quotas:   []scorev1b1.QuotaSpec{{Name: "db", MaxClaims: map[string]int32{"postgres": 1}}},
+			workload: existing[0],
+		},
+		{
+			name:      "claims per type",
+			quotas:    []scorev1b1.QuotaSpec{{Name: "db", MaxClaims: map[string]int32{"postgres": 1}}},
+			workload:  newWorkload("team-a", "new", 0, nil, "1", "postgres"),
+			wantLimit: "maxClaims[postgres]",
+		},
+		{
+			name:     "aggregate cpu within limit",
+			quotas:   []scorev1b1.QuotaSpec{{Name: "cpu", CPU: "3"}},
+			workload: newWorkload("team-a", "new", 0, nil, "1000m"),
+		},
+		{
+			name:      "aggregate cpu exceeded",
+			quotas:    []scorev1b1.QuotaSpec{{Name: "cpu", CPU: "3"}},
+			workload:  newWorkload("team-a", "new", 0, nil, "1500m"),
+			wantLimit: "cpu",
+		},
+		{
+			name: "cluster scope team quota spans namespaces",
+			quotas: []scorev1b1.QuotaSpec{{
+				Name:     "team-a",
+				Scope:    scorev1b1.QuotaScopeCluster,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "a"}},
+				CPU:      "4",
+			}},
+			workload:  newWorkload("team-c", "new", 0, map[string]string{"team": "a"}, "1"),
+			wantLimit: "cpu",
+		},
+		{
+			name: "selector does not match",
+			quotas: []scorev1b1.QuotaSpec{{
+				Name:         "team-b",
+				Selector:     &metav1.LabelSelector{MatchLabels: map[string]string{"team": "b"}},
+				MaxWorkloads: int32Ptr(0),
+			}},
+			workload: newWorkload("team-a", "new", 0, map[string]string{"team": "a"}, "1"),
+		},
+		{
+			name:     "namespace not listed",
+			quotas:   []scorev1b1.QuotaSpec{{Name: "ns", Namespaces: []string{"team-b"}, MaxWorkloads: int32Ptr(0)}},
+			workload: newWorkload("team-a", "new", 0, nil, "1"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Evaluate(tt.quotas, &tt.workload, existing)
+			if tt.wantLimit == "" {
+				if err != nil {
+					t.Fatalf("Evaluate() error = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrQuotaExceeded) {
+				t.Fatalf("Evaluate() error = %v, want ErrQuotaExceeded", err)
+			}
+			var violation *Violation
+			if !errors.As(err, &violation) || violation.Limit != tt.wantLimit {
+				t.Errorf("Evaluate() violation = %v, want limit %q", err, tt.wantLimit)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/util/csaupgrade"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// legacyFieldManagers are the field managers of the client-side updates written before the switch to
+// server-side apply. The API server derived them from the binary names of the Orchestrator and the runtime.
+var legacyFieldManagers = sets.New("manager", "kubernetes-runtime")
+
+// Apply writes obj with server-side apply as fieldOwner. obj must carry its TypeMeta and
+// only the fields the caller owns; fields managed by other controllers are left untouched.
+// Conflicts on declared fields are resolved in favour of fieldOwner, which is the authority
+// for everything it declares.
+func Apply(ctx context.Context, c client.Client, obj client.Object, fieldOwner string) error {
+	if err := upgradeManagedFields(ctx, c, obj, fieldOwner); err != nil {
+		return err
+	}
+	return c.Patch(ctx, obj, client.Apply, client.FieldOwner(fieldOwner), client.ForceOwnership)
+}
+
+// upgradeManagedFields moves the fields owned by legacy client-side managers to fieldOwner.
+// Without it, fields dropped from the applied object would stay owned by the legacy manager and never be removed.
+func upgradeManagedFields(ctx context.Context, c client.Client, obj client.Object, fieldOwner string) error {
+	newObj, err := c.Scheme().New(obj.GetObjectKind().GroupVersionKind())
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", obj.GetObjectKind().GroupVersionKind().Kind, err)
+	}
+	current, ok := newObj.(client.Object)
+	if !ok {
+		return fmt.Errorf("%T is not a client.Object", newObj)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), current); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+
+	patch, err := csaupgrade.UpgradeManagedFieldsPatch(current, legacyFieldManagers, fieldOwner)
+	if err != nil {
+		return fmt.Errorf("failed to upgrade managed fields of %s: %w", client.ObjectKeyFromObject(obj), err)
+	}
+	if patch == nil {
+		return nil
+	}
+	if err := c.Patch(ctx, current, client.RawPatch(types.JSONPatchType, patch)); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to upgrade managed fields of %s: %w", client.ObjectKeyFromObject(obj), err)
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// applyAsCreateOrUpdate emulates server-side apply, which the fake client does not support,
+// by creating the applied object or replacing the existing one. Other patches pass through.
+func applyAsCreateOrUpdate(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+
+	existing, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", obj)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		return c.Create(ctx, obj)
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return c.Update(ctx, obj)
+}
+
+func TestUpgradeManagedFields(t *testing.T) {
+	legacy := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-files",
+			Namespace: "default",
+			ManagedFields: []metav1.ManagedFieldsEntry{{
+				Manager:    "kubernetes-runtime",
+				Operation:  metav1.ManagedFieldsOperationUpdate,
+				APIVersion: "v1",
+				FieldsType: "FieldsV1",
+				FieldsV1:   &metav1.FieldsV1{Raw: []byte(`{"f:data":{"f:app.conf":{}}}`)},
+			}},
+		},
+		Data: map[string]string{"app.conf": "old"},
+	}
+	var patches []string
+	c := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		// Serve the stored object with its managedFields, which the fake client does not track
+		Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if configMap, ok := obj.(*corev1.ConfigMap); ok && key == client.ObjectKeyFromObject(legacy) {
+				legacy.DeepCopyInto(configMap)
+				return nil
+			}
+			return c.Get(ctx, key, obj, opts...)
+		},
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			data, err := patch.Data(obj)
+			if err != nil {
+				return err
+			}
+			patches = append(patches, string(patch.Type())+" "+string(data))
+			return nil
+		},
+	}).Build()
+
+	desired := &corev1.ConfigMap{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{Name: "app-files", Namespace: "default"},
+	}
+	if err := upgradeManagedFields(context.TODO(), c, desired, "score-runtime-k8s"); err != nil {
+		t.Fatalf("upgradeManagedFields() error = %v", err)
+	}
+
+	if len(patches) != 1 || !strings.HasPrefix(patches[0], string(types.JSONPatchType)) ||
+		!strings.Contains(patches[0], `"manager":"score-runtime-k8s"`) || strings.Contains(patches[0], `"manager":"kubernetes-runtime"`) {
+		t.Fatalf("patches = %v, want one JSON patch handing the legacy fields to score-runtime-k8s", patches)
+	}
+
+	// Objects that do not exist yet need no upgrade
+	missing := desired.DeepCopy()
+	missing.Name = "missing"
+	if err := upgradeManagedFields(context.TODO(), c, missing, "score-runtime-k8s"); err != nil {
+		t.Errorf("upgradeManagedFields() for a missing object error = %v", err)
+	}
+	if len(patches) != 1 {
+		t.Errorf("patches = %v, want no patch for a missing object", patches)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import "testing"
+
+func TestCanonicalizeJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr bool
+	}{
+		{"sorted keys", `{"b":1,"a":{"d":true,"c":null}}`, `{"a":{"c":null,"d":true},"b":1}`, false},
+		{"whitespace", "{ \"a\" : [ 1 , 2 ] }\n", `{"a":[1,2]}`, false},
+		{"integral floats", `[1.0,1e3,-0,-0.0,2.50]`, `[1,1000,0,0,2.5]`, false},
+		{"fractions", `[0.1,1.5e-7,123456.789]`, `[0.1,1.5e-07,123456.789]`, false},
+		{"large integers", `[12345678901234567890]`, `[12345678901234567890]`, false},
+		{"unescaped HTML", `{"uri":"a&b<"}`, `{"uri":"a&b<"}`, false},
+		{"escaped controls", `["line\nbreak\u0001"]`, `["line\nbreak\u0001"]`, false},
+		{"trailing data", `{} {}`, "", true},
+		{"invalid", `{`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := canonicalizeJSON([]byte(tt.input))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("canonicalizeJSON() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if string(got) != tt.want {
+				t.Errorf("canonicalizeJSON() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanonicalJSONIsStable(t *testing.T) {
+	values := map[string]interface{}{
+		"labels": map[string]string{"z": "1", "a": "2", "m": "3"},
+		"ports":  []interface{}{8080, 9090.0},
+		"nested": map[string]interface{}{"y": 1.25, "x": "<a&b>"},
+	}
+	first, err := canonicalJSON(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"labels":{"a":"2","m":"3","z":"1"},"nested":{"x":"<a&b>","y":1.25},"ports":[8080,9090]}`; string(first) != want {
+		t.Fatalf("canonicalJSON() = %s, want %s", first, want)
+	}
+	for i := 0; i < 20; i++ {
+		again, err := canonicalJSON(values)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(again) != string(first) {
+			t.Fatalf("canonicalJSON() = %s, want %s", again, first)
+		}
+	}
+}
@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
 )
 
 // UpsertResourceClaims creates or updates ResourceClaim resources for each resource in the Workload spec
@@ -85,8 +86,8 @@ func upsertResourceClaim(ctx context.Context, c client.Client, workload *scorev1
 				Name:      claimName,
 				Namespace: workload.Namespace,
 				Labels: map[string]string{
-					"score.dev/workload": workload.Name,
-					"score.dev/key":      key,
+					meta.LabelWorkload: workload.Name,
+					"score.dev/key":    key,
 				},
 			},
 			Spec: desiredSpec,
@@ -0,0 +1,127 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/planvalues"
+	"github.com/cappyzawa/score-orchestrator/internal/supplychain"
+)
+
+// ImageResolver returns the image pinned by the digest its tag currently points to
+type ImageResolver func(ctx context.Context, image string) (string, error)
+
+// buildFromSource is the image of containers built from source, which has nothing to resolve
+const buildFromSource = "."
+
+// LatestImages returns the paths of the container images of the Workload tagged "latest", or with neither
+// a tag nor a digest, in a stable order
+func LatestImages(workload *scorev1b1.Workload) []string {
+	var paths []string
+	for name, container := range workload.Spec.Containers {
+		if container.Image != buildFromSource && supplychain.IsLatest(container.Image) {
+			paths = append(paths, fmt.Sprintf("containers.%s.image", name))
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// pinImages publishes the container images of the Workload pinned by digest under containers.<name>.image of
+// the values. The digests recorded in the current plan are kept while the image of a container is unchanged,
+// so that pushing a tag again does not roll the Workload out behind its back. A nil resolve leaves the values
+// unchanged.
+func pinImages(ctx context.Context, c client.Reader, values *runtime.RawExtension, workload *scorev1b1.Workload, current *scorev1b1.WorkloadPlan, resolve ImageResolver) (*runtime.RawExtension, error) {
+	if resolve == nil {
+		return values, nil
+	}
+
+	valuesMap := make(map[string]interface{})
+	if values != nil && len(values.Raw) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(values.Raw))
+		decoder.UseNumber()
+		if err := decoder.Decode(&valuesMap); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal values: %w", err)
+		}
+	}
+	pinned := currentPins(ctx, c, current)
+
+	containers, _ := valuesMap["containers"].(map[string]interface{})
+	if containers == nil {
+		containers = make(map[string]interface{})
+	}
+	for name, container := range workload.Spec.Containers {
+		if container.Image == buildFromSource {
+			continue
+		}
+		image := pinned[name]
+		if !strings.HasPrefix(image, container.Image+"@") {
+			var err error
+			if image, err = resolve(ctx, container.Image); err != nil {
+				return nil, fmt.Errorf("failed to resolve image of container %s: %w", name, err)
+			}
+		}
+		resolved, _ := containers[name].(map[string]interface{})
+		if resolved == nil {
+			resolved = make(map[string]interface{})
+		}
+		resolved["image"] = image
+		containers[name] = resolved
+	}
+	valuesMap["containers"] = containers
+
+	raw, err := canonicalJSON(valuesMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal values: %w", err)
+	}
+	return &runtime.RawExtension{Raw: raw}, nil
+}
+
+// currentPins returns the pinned images of the current plan by container name. Values that cannot be
+// loaded are resolved again.
+func currentPins(ctx context.Context, c client.Reader, current *scorev1b1.WorkloadPlan) map[string]string {
+	if current == nil {
+		return nil
+	}
+	values, err := planvalues.Load(ctx, c, current)
+	if err != nil || values == nil {
+		return nil
+	}
+	var resolved struct {
+		Containers map[string]struct {
+			Image string `json:"image"`
+		} `json:"containers"`
+	}
+	if err := json.Unmarshal(values.Raw, &resolved); err != nil {
+		return nil
+	}
+	pins := make(map[string]string, len(resolved.Containers))
+	for name, container := range resolved.Containers {
+		pins[name] = container.Image
+	}
+	return pins
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestPinImages(t *testing.T) {
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: scorev1b1.WorkloadSpec{Containers: map[string]scorev1b1.ContainerSpec{
+			"app":     {Image: "nginx:1.27"},
+			"sidecar": {Image: "envoy:1.30"},
+			"builder": {Image: "."},
+		}},
+	}
+	current := &scorev1b1.WorkloadPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: scorev1b1.WorkloadPlanSpec{ResolvedValues: &runtime.RawExtension{
+			Raw: []byte(`{"containers":{"app":{"image":"nginx:1.27@sha256:old"},"sidecar":{"image":"envoy:1.29@sha256:old"}}}`),
+		}},
+	}
+	var resolved []string
+	resolve := func(_ context.Context, image string) (string, error) {
+		resolved = append(resolved, image)
+		return image + "@sha256:new", nil
+	}
+	values := &runtime.RawExtension{Raw: []byte(`{"containers":{"app":{"env":{"PORT":"8080"}}}}`)}
+	c := fake.NewClientBuilder().Build()
+
+	got, err := pinImages(context.Background(), c, values, workload, current, resolve)
+	if err != nil {
+		t.Fatalf("pinImages() error = %v", err)
+	}
+	want := `{"containers":{"app":{"env":{"PORT":"8080"},"image":"nginx:1.27@sha256:old"},"sidecar":{"image":"envoy:1.30@sha256:new"}}}`
+	if string(got.Raw) != want {
+		t.Errorf("pinImages() = %s, want %s", got.Raw, want)
+	}
+	if !reflect.DeepEqual(resolved, []string{"envoy:1.30"}) {
+		t.Errorf("pinImages() resolved %v, want only the changed image envoy:1.30", resolved)
+	}
+
+	if got, err := pinImages(context.Background(), c, values, workload, current, nil); err != nil || got != values {
+		t.Errorf("pinImages() without a resolver = %s, %v; want the values unchanged", got.Raw, err)
+	}
+
+	failing := func(context.Context, string) (string, error) { return "", errors.New("registry unavailable") }
+	if _, err := pinImages(context.Background(), c, values, workload, nil, failing); err == nil {
+		t.Error("pinImages() error = nil, want the resolution error")
+	}
+}
+
+func TestLatestImages(t *testing.T) {
+	workload := &scorev1b1.Workload{Spec: scorev1b1.WorkloadSpec{Containers: map[string]scorev1b1.ContainerSpec{
+		"app":     {Image: "nginx"},
+		"sidecar": {Image: "envoy:latest"},
+		"worker":  {Image: "ghcr.io/app/worker:1.2.0"},
+		"builder": {Image: "."},
+	}}}
+
+	want := []string{"containers.app.image", "containers.sidecar.image"}
+	if got := LatestImages(workload); !reflect.DeepEqual(got, want) {
+		t.Errorf("LatestImages() = %v, want %v", got, want)
+	}
+}
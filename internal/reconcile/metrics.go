@@ -0,0 +1,35 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// PlanValuesBytes observes the size of the resolved values composed for WorkloadPlans
+var PlanValuesBytes = prometheus.NewHistogram(
+	prometheus.HistogramOpts{
+		Name:    "score_orchestrator_plan_values_bytes",
+		Help:    "Size in bytes of the resolved values composed for WorkloadPlans",
+		Buckets: prometheus.ExponentialBuckets(1024, 4, 8),
+	},
+)
+
+func init() {
+	metrics.Registry.MustRegister(PlanValuesBytes)
+}
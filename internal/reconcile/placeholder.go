@@ -0,0 +1,211 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// PlaceholderError reports a placeholder that could not be resolved together with its location
+// in the Workload spec, so that users can find the offending container variable.
+// It wraps ErrUnresolvedPlaceholders.
+type PlaceholderError struct {
+	// Path locates the value in the Workload spec (e.g., containers.app.variables.DATABASE_URL)
+	Path string
+	// Placeholder is the placeholder as written (e.g., ${resources.db.outputs.uri})
+	Placeholder string
+	// Reason explains why the placeholder could not be resolved
+	Reason string
+}
+
+// Error implements error
+func (e *PlaceholderError) Error() string {
+	if e.Path == "" {
+		return fmt.Sprintf("%s: %s", e.Placeholder, e.Reason)
+	}
+	return fmt.Sprintf("%s: %s: %s", e.Path, e.Placeholder, e.Reason)
+}
+
+// Unwrap makes errors.Is(err, ErrUnresolvedPlaceholders) hold for placeholder errors
+func (e *PlaceholderError) Unwrap() error {
+	return ErrUnresolvedPlaceholders
+}
+
+// substitutePlaceholders replaces every placeholder in value using the claim outputs.
+//
+// The grammar is:
+//
+//	${resources.<key>.outputs.<path>}           output of the claim <key>; <path> may be nested (data.host)
+//	${resources.<key>.<path>}                   short form of the above
+//	${resources.<key>.outputs.<path>:-default}  default used when the output is not available
+//	$$                                          literal "$" (so "$${x}" yields "${x}")
+//
+// It returns a *PlaceholderError for the first placeholder that cannot be resolved.
+func substitutePlaceholders(value string, availableOutputs map[string]map[string]string) (string, error) {
+	return expandPlaceholders(value, func(placeholder string) (string, error) {
+		return resolvePlaceholder(placeholder, availableOutputs)
+	})
+}
+
+// expandPlaceholders replaces every placeholder in value with the result of resolve
+func expandPlaceholders(value string, resolve func(placeholder string) (string, error)) (string, error) {
+	var result strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '$' || i+1 == len(value) {
+			result.WriteByte(value[i])
+			continue
+		}
+		switch value[i+1] {
+		case '$':
+			result.WriteByte('$')
+			i++
+		case '{':
+			end := strings.IndexByte(value[i:], '}')
+			if end < 0 {
+				return "", &PlaceholderError{Placeholder: value[i:], Reason: "unterminated placeholder"}
+			}
+			placeholder := value[i : i+end+1]
+			resolved, err := resolve(placeholder)
+			if err != nil {
+				return "", err
+			}
+			result.WriteString(resolved)
+			i += end
+		default:
+			result.WriteByte('$')
+		}
+	}
+	return result.String(), nil
+}
+
+// resolvePlaceholder resolves a single "${...}" expression
+func resolvePlaceholder(placeholder string, availableOutputs map[string]map[string]string) (string, error) {
+	resourceKey, path, err := parsePlaceholder(placeholder)
+	if err != nil {
+		return "", err
+	}
+	expr := strings.TrimSuffix(strings.TrimPrefix(placeholder, "${"), "}")
+	_, defaultValue, hasDefault := strings.Cut(expr, ":-")
+
+	unresolved := func(format string, args ...interface{}) error {
+		return &PlaceholderError{Placeholder: placeholder, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	outputs, exists := availableOutputs[resourceKey]
+	if !exists {
+		if hasDefault {
+			return defaultValue, nil
+		}
+		return "", unresolved("resource '%s' has no outputs available", resourceKey)
+	}
+	resolved, found := lookupOutput(outputs, path)
+	if !found {
+		if hasDefault {
+			return defaultValue, nil
+		}
+		return "", unresolved("resource '%s' missing output '%s'", resourceKey, strings.Join(path, "."))
+	}
+	return resolved, nil
+}
+
+// resolveOptionalPlaceholder resolves a placeholder like resolvePlaceholder, except that a placeholder of an
+// optional resource without outputs resolves to its default, or to an empty string
+func resolveOptionalPlaceholder(placeholder string, availableOutputs map[string]map[string]string, optional map[string]bool) (string, error) {
+	resolved, err := resolvePlaceholder(placeholder, availableOutputs)
+	if err == nil {
+		return resolved, nil
+	}
+	resourceKey, _, parseErr := parsePlaceholder(placeholder)
+	if parseErr != nil {
+		return "", err
+	}
+	if _, exists := availableOutputs[resourceKey]; !exists && optional[resourceKey] {
+		return "", nil
+	}
+	return "", err
+}
+
+// parsePlaceholder returns the resource key and the output path of a "${...}" expression
+func parsePlaceholder(placeholder string) (string, []string, error) {
+	expr := strings.TrimSuffix(strings.TrimPrefix(placeholder, "${"), "}")
+	expr, _, _ = strings.Cut(expr, ":-")
+
+	segments := strings.Split(expr, ".")
+	if len(segments) < 3 || segments[0] != "resources" {
+		return "", nil, &PlaceholderError{Placeholder: placeholder, Reason: "unsupported placeholder, expected ${resources.<key>.outputs.<name>}"}
+	}
+	resourceKey, path := segments[1], segments[2:]
+	if path[0] == "outputs" && len(path) > 1 {
+		path = path[1:]
+	}
+	for _, segment := range append([]string{resourceKey}, path...) {
+		if segment == "" {
+			return "", nil, &PlaceholderError{Placeholder: placeholder, Reason: "empty key in placeholder"}
+		}
+	}
+	return resourceKey, path, nil
+}
+
+// lookupOutput finds the output at path. Output keys may themselves contain dots (tls.crt), so the
+// longest key matching a prefix of the path wins; the remainder of the path is looked up in the
+// output value parsed as a JSON object.
+func lookupOutput(outputs map[string]string, path []string) (string, bool) {
+	for split := len(path); split > 0; split-- {
+		value, exists := outputs[strings.Join(path[:split], ".")]
+		if !exists {
+			continue
+		}
+		if split == len(path) {
+			return value, true
+		}
+		var nested interface{}
+		if err := json.Unmarshal([]byte(value), &nested); err != nil {
+			return "", false
+		}
+		return lookupNested(nested, path[split:])
+	}
+	return "", false
+}
+
+// lookupNested walks a parsed JSON value along path and renders the result as a string
+func lookupNested(value interface{}, path []string) (string, bool) {
+	for _, segment := range path {
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return "", false
+		}
+		if value, ok = object[segment]; !ok {
+			return "", false
+		}
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, true
+	case nil:
+		return "", true
+	default:
+		// Objects, arrays, numbers and booleans are rendered as JSON
+		data, err := canonicalJSON(v)
+		if err != nil {
+			return "", false
+		}
+		return string(data), true
+	}
+}
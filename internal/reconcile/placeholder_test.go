@@ -0,0 +1,101 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestSubstitutePlaceholders(t *testing.T) {
+	outputs := map[string]map[string]string{
+		"db": {
+			"uri":     "postgres://db:5432/app",
+			"data":    `{"host":"db.internal","port":5432,"tls":{"enabled":true}}`,
+			"tls.crt": "certificate",
+		},
+	}
+
+	tests := []struct {
+		name      string
+		value     string
+		want      string
+		wantError string
+	}{
+		{"plain value", "static", "static", ""},
+		{"outputs form", "${resources.db.outputs.uri}", "postgres://db:5432/app", ""},
+		{"short form", "${resources.db.uri}", "postgres://db:5432/app", ""},
+		{"embedded placeholders", "url=${resources.db.uri};host=${resources.db.outputs.data.host}", "url=postgres://db:5432/app;host=db.internal", ""},
+		{"nested string", "${resources.db.outputs.data.host}", "db.internal", ""},
+		{"nested number", "${resources.db.outputs.data.port}", "5432", ""},
+		{"nested object", "${resources.db.outputs.data.tls}", `{"enabled":true}`, ""},
+		{"deeply nested", "${resources.db.outputs.data.tls.enabled}", "true", ""},
+		{"dotted output key", "${resources.db.outputs.tls.crt}", "certificate", ""},
+		{"default for missing output", "${resources.db.outputs.password:-secret}", "secret", ""},
+		{"default for missing resource", "${resources.cache.outputs.uri:-redis://localhost}", "redis://localhost", ""},
+		{"empty default", "${resources.cache.outputs.uri:-}", "", ""},
+		{"default ignored when available", "${resources.db.outputs.uri:-unused}", "postgres://db:5432/app", ""},
+		{"escaped placeholder", "$${resources.db.outputs.uri}", "${resources.db.outputs.uri}", ""},
+		{"escaped dollar", "price: $$5", "price: $5", ""},
+		{"lone dollar", "cost $5 and $", "cost $5 and $", ""},
+		{"missing resource", "${resources.cache.outputs.uri}", "", "resource 'cache' has no outputs available"},
+		{"missing output", "${resources.db.outputs.password}", "", "resource 'db' missing output 'password'"},
+		{"missing nested key", "${resources.db.outputs.data.user}", "", "resource 'db' missing output 'data.user'"},
+		{"unsupported placeholder", "${metadata.name}", "", "unsupported placeholder"},
+		{"unterminated placeholder", "${resources.db.uri", "", "unterminated placeholder"},
+		{"empty key", "${resources..outputs.uri}", "", "empty key in placeholder"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := substitutePlaceholders(tt.value, outputs)
+			if tt.wantError != "" {
+				var placeholderErr *PlaceholderError
+				if !errors.As(err, &placeholderErr) {
+					t.Fatalf("substitutePlaceholders() error = %v, want a *PlaceholderError", err)
+				}
+				if !strings.Contains(placeholderErr.Reason, tt.wantError) {
+					t.Errorf("substitutePlaceholders() reason = %q, want it to contain %q", placeholderErr.Reason, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("substitutePlaceholders() unexpected error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("substitutePlaceholders() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlaceholderErrorLocation(t *testing.T) {
+	err := &PlaceholderError{
+		Path:        "containers.app.variables.DATABASE_URL",
+		Placeholder: "${resources.db.outputs.uri}",
+		Reason:      "resource 'db' has no outputs available",
+	}
+
+	want := "containers.app.variables.DATABASE_URL: ${resources.db.outputs.uri}: resource 'db' has no outputs available"
+	if err.Error() != want {
+		t.Errorf("Error() = %q, want %q", err.Error(), want)
+	}
+	if !errors.Is(err, ErrUnresolvedPlaceholders) {
+		t.Error("PlaceholderError should wrap ErrUnresolvedPlaceholders")
+	}
+}
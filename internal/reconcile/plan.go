@@ -17,28 +17,58 @@ limitations under the License.
 package reconcile
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
+	"strconv"
+	"time"
 
-	"k8s.io/apimachinery/pkg/api/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
-	"github.com/cappyzawa/score-orchestrator/internal/projection"
+	"github.com/cappyzawa/score-orchestrator/internal/events"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/planvalues"
+	"github.com/cappyzawa/score-orchestrator/internal/propagation"
 	"github.com/cappyzawa/score-orchestrator/internal/selection"
+	"github.com/cappyzawa/score-orchestrator/internal/tracing"
 )
 
-// UpsertWorkloadPlan creates or updates the WorkloadPlan for the given Workload
-func UpsertWorkloadPlan(ctx context.Context, c client.Client, workload *scorev1b1.Workload, claims []scorev1b1.ResourceClaim, selectedBackend *selection.SelectedBackend) error {
+// ErrPlanMigrating indicates that the existing WorkloadPlan targets another runtime class and was
+// deleted so that the runtime can tear it down before the plan for the newly selected backend is created
+var ErrPlanMigrating = errors.New("workload plan is migrating to another runtime")
+
+// defaultRolloutDeadline bounds runtime rollouts when the configuration does not set a deadline
+const defaultRolloutDeadline = 10 * time.Minute
+
+// PlanWrite describes the WorkloadPlan write performed by UpsertWorkloadPlan
+type PlanWrite struct {
+	// Operation is created or updated when the plan was written, and none when it was already up to date
+	// or kept as restored from history
+	Operation controllerutil.OperationResult
+	// ValuesHash is the hex-encoded SHA-256 digest of the resolved values of the written plan
+	ValuesHash string
+}
+
+// UpsertWorkloadPlan creates or updates the WorkloadPlan for the given Workload.
+// defaults are the configuration defaults the backend was selected under, configGeneration the generation
+// of that configuration, namespace is the namespace provisioned for the environment of the Workload, if any,
+// and resolveImage, when set, pins the container images by digest.
+func UpsertWorkloadPlan(ctx context.Context, c client.Client, workload *scorev1b1.Workload, claims []scorev1b1.ResourceClaim, selectedBackend *selection.SelectedBackend, defaults scorev1b1.DefaultsSpec, configGeneration int64, namespace string, resolveImage ImageResolver) (PlanWrite, error) {
 	if workload.Name == "" {
-		return fmt.Errorf("workload name cannot be empty")
+		return PlanWrite{}, fmt.Errorf("workload name cannot be empty")
 	}
 	planName := workload.Name // Same name as Workload
 	if planName == "" {
-		return fmt.Errorf("plan name cannot be empty, workload.Name: %q", workload.Name)
+		return PlanWrite{}, fmt.Errorf("plan name cannot be empty, workload.Name: %q", workload.Name)
 	}
 	plan := &scorev1b1.WorkloadPlan{}
 
@@ -48,22 +78,53 @@ func UpsertWorkloadPlan(ctx context.Context, c client.Client, workload *scorev1b
 		Namespace: workload.Namespace,
 	}, plan)
 
-	if getErr != nil && !errors.IsNotFound(getErr) {
-		return fmt.Errorf("failed to get WorkloadPlan %s: %w", planName, getErr)
+	if getErr != nil && !apierrors.IsNotFound(getErr) {
+		return PlanWrite{}, fmt.Errorf("failed to get WorkloadPlan %s: %w", planName, getErr)
+	}
+
+	// Record rolled out plans and restore the last good one when the rollout of this generation failed
+	if getErr == nil {
+		keep, err := reconcilePlanHistory(ctx, c, workload, plan)
+		if keep || err != nil {
+			return PlanWrite{Operation: controllerutil.OperationResultNone}, err
+		}
+	}
+
+	// The plan is computed from the Workload spec merged with the workload defaults of its profile
+	effective := EffectiveWorkload(workload, selectedBackend.WorkloadDefaults)
+	snapshot, err := workloadSnapshot(workload, effective)
+	if err != nil {
+		return PlanWrite{}, err
 	}
 
 	// Resolve all placeholders to create final values
-	resolvedValues, err := resolveAllPlaceholders(ctx, c, workload, claims)
+	resolvedValues, err := resolvePlanValues(ctx, c, effective, claims, selectedBackend.ValuesFrom)
 	if err != nil {
-		return fmt.Errorf("failed to resolve placeholders: %w", err)
+		return PlanWrite{}, err
 	}
 
-	// Check for unresolved placeholders before creating the plan
-	if hasUnresolved, parseErr := projection.HasUnresolvedPlaceholders(resolvedValues.Raw); hasUnresolved {
-		if parseErr != nil {
-			return fmt.Errorf("unresolved placeholders (parse error): %w", parseErr)
-		}
-		return fmt.Errorf("unresolved placeholders found in workload projection")
+	// Images pinned by digest keep nodes pulling what was planned
+	var current *scorev1b1.WorkloadPlan
+	if getErr == nil {
+		current = plan
+	}
+	resolvedValues, err = pinImages(ctx, c, resolvedValues, effective, current, resolveImage)
+	if err != nil {
+		return PlanWrite{}, err
+	}
+
+	// Values too large to embed are stored next to the plan so that it stays well below the object size limit
+	resolvedValuesRef, err := externalizePlanValues(ctx, c, workload, resolvedValues, defaults.PlanValues)
+	if err != nil {
+		return PlanWrite{}, err
+	}
+	var valuesHash string
+	switch {
+	case resolvedValuesRef != nil:
+		valuesHash = resolvedValuesRef.SHA256
+		resolvedValues = nil
+	case resolvedValues != nil:
+		valuesHash = planvalues.Hash(resolvedValues.Raw)
 	}
 
 	// Build the desired spec
@@ -76,61 +137,188 @@ func UpsertWorkloadPlan(ctx context.Context, c client.Client, workload *scorev1b
 		RuntimeClass:               selectedBackend.RuntimeClass,
 		Template:                   &selectedBackend.Template,
 		ResolvedValues:             resolvedValues,
+		ResolvedValuesRef:          resolvedValuesRef,
 		Claims:                     buildPlanClaims(claims),
+		Exposure:                   selectedBackend.Exposure,
+		Target:                     selectedBackend.Target,
+		Namespace:                  namespace,
+		WorkloadSnapshot:           snapshot,
 	}
+	desiredSpec.Kind, desiredSpec.Schedule = WorkloadKind(workload, selectedBackend.Kind)
+	desiredSpec.SecurityContext = workloadSecurityContext(workload, defaults.SecurityContext)
+	desiredSpec.NetworkPolicy = defaults.NetworkPolicy.DeepCopy()
+	desiredSpec.DisruptionBudget, desiredSpec.DefaultResources = workloadDefaults(desiredSpec.Kind, selectedBackend.Defaults)
+	if selectedBackend.Defaults != nil {
+		desiredSpec.PriorityClass = selectedBackend.Defaults.PriorityClass.DeepCopy()
+	}
+	desiredSpec.RolloutDeadline = &metav1.Duration{Duration: defaultRolloutDeadline}
+	if defaults.RolloutDeadline != nil {
+		desiredSpec.RolloutDeadline = defaults.RolloutDeadline.DeepCopy()
+	}
+	desiredSpec.Metadata = propagation.Select(defaults.Propagation, workload.Labels, workload.Annotations)
+	desiredSpec.Naming = defaults.Naming.DeepCopy()
+	desiredSpec.ReadinessGates = selectedBackend.ReadinessGates
+	desiredSpec.RestartedAt = workload.Annotations[meta.AnnotationRestart]
 
-	if errors.IsNotFound(getErr) {
-		// Create new plan
-		plan := &scorev1b1.WorkloadPlan{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      planName,
-				Namespace: workload.Namespace,
-				Labels: map[string]string{
-					"score.dev/workload": workload.Name,
-				},
-			},
-			Spec: desiredSpec,
-		}
-
-		// Set owner reference
-		if err := controllerutil.SetControllerReference(workload, plan, c.Scheme()); err != nil {
-			return fmt.Errorf("failed to set owner reference: %w", err)
-		}
-
-		if err := c.Create(ctx, plan); err != nil {
-			if errors.IsAlreadyExists(err) {
-				// Plan already exists, try to get it and update if needed
-				existingPlan := &scorev1b1.WorkloadPlan{}
-				if getErr := c.Get(ctx, types.NamespacedName{
-					Name:      planName,
-					Namespace: workload.Namespace,
-				}, existingPlan); getErr != nil {
-					return fmt.Errorf("failed to get existing WorkloadPlan after create conflict: %w", getErr)
-				}
-				// Update existing plan if spec differs
-				if !workloadPlanSpecEqual(existingPlan.Spec, desiredSpec) {
-					existingPlan.Spec = desiredSpec
-					if updateErr := c.Update(ctx, existingPlan); updateErr != nil {
-						return fmt.Errorf("failed to update existing WorkloadPlan: %w", updateErr)
-					}
+	if getErr == nil {
+		if runtimeLocation(plan.Spec) != runtimeLocation(desiredSpec) {
+			// The selected backend moved to another runtime, cluster or namespace: delete the old plan first so that
+			// only one runtime owns the workload at a time. The deletion triggers a reconcile that creates the new plan.
+			if plan.DeletionTimestamp == nil {
+				if err := c.Delete(ctx, plan); err != nil && !apierrors.IsNotFound(err) {
+					return PlanWrite{}, fmt.Errorf("failed to delete WorkloadPlan for migration: %w", err)
 				}
-				return nil
 			}
-			return fmt.Errorf("failed to create WorkloadPlan: %w", err)
+			return PlanWrite{}, fmt.Errorf("%w: from %q to %q", ErrPlanMigrating, runtimeLocation(plan.Spec), runtimeLocation(desiredSpec))
 		}
-	} else {
-		// Update existing plan if spec differs
-		if !workloadPlanSpecEqual(plan.Spec, desiredSpec) {
-			plan.Spec = desiredSpec
-			if err := c.Update(ctx, plan); err != nil {
-				return fmt.Errorf("failed to update WorkloadPlan: %w", err)
-			}
+
+		// Skip the write if spec and correlation ID are unchanged
+		correlationChanged := events.PropagateCorrelationID(workload, plan.DeepCopy())
+		if workloadPlanSpecEqual(plan.Spec, desiredSpec) && !correlationChanged {
+			return PlanWrite{Operation: controllerutil.OperationResultNone, ValuesHash: valuesHash}, nil
 		}
 	}
 
+	// A new configuration generation alone does not rewrite the plan; the annotation records the last write
+	var annotations map[string]string
+	if configGeneration > 0 {
+		annotations = map[string]string{meta.AnnotationConfigGeneration: strconv.FormatInt(configGeneration, 10)}
+	}
+	if err := applyWorkloadPlan(ctx, c, workload, desiredSpec, annotations); err != nil {
+		return PlanWrite{}, err
+	}
+	write := PlanWrite{Operation: controllerutil.OperationResultCreated, ValuesHash: valuesHash}
+	if getErr == nil {
+		write.Operation = controllerutil.OperationResultUpdated
+	}
+	// Only plans that externalize values, or replace a plan that did, can leave values behind
+	if resolvedValuesRef == nil && (getErr != nil || plan.Spec.ResolvedValuesRef == nil) {
+		return write, nil
+	}
+	return write, prunePlanValues(ctx, c, workload, resolvedValuesRef)
+}
+
+// applyWorkloadPlan applies the WorkloadPlan of the workload with the given spec and annotations.
+// Annotations the Orchestrator set earlier and that are not passed again are removed.
+func applyWorkloadPlan(ctx context.Context, c client.Client, workload *scorev1b1.Workload, spec scorev1b1.WorkloadPlanSpec, annotations map[string]string) error {
+	desired := &scorev1b1.WorkloadPlan{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: scorev1b1.GroupVersion.String(),
+			Kind:       "WorkloadPlan",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      workload.Name, // Same name as Workload
+			Namespace: workload.Namespace,
+			Labels: map[string]string{
+				meta.LabelWorkload:     workload.Name,
+				meta.LabelRuntimeClass: spec.RuntimeClass,
+			},
+			Annotations: annotations,
+		},
+		Spec: spec,
+	}
+	events.PropagateCorrelationID(workload, desired)
+	tracing.InjectIntoAnnotations(ctx, desired)
+
+	// Set owner reference
+	if err := controllerutil.SetControllerReference(workload, desired, c.Scheme()); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+
+	// Server-side apply creates the plan or updates only the fields owned by the Orchestrator
+	if err := Apply(ctx, c, desired, meta.FieldManagerOrchestrator); err != nil {
+		return fmt.Errorf("failed to apply WorkloadPlan: %w", err)
+	}
+
 	return nil
 }
 
+// resolvePlanValues resolves all placeholders of the Workload and routes the claim outputs mapped by valuesFrom.
+// Placeholders and outputs that cannot be resolved are reported as a *PlaceholderError, so no plan is created
+// with unresolved values.
+func resolvePlanValues(ctx context.Context, c client.Client, workload *scorev1b1.Workload, claims []scorev1b1.ResourceClaim, valuesFrom []scorev1b1.ValuesFromSpec) (*runtime.RawExtension, error) {
+	ctx, span := tracing.StartSpan(ctx, "WorkloadPlan.ComposeValues", tracing.WorkloadAttributes(workload)...)
+	defer span.End()
+
+	resolvedValues, err := resolveAllPlaceholders(ctx, c, workload, claims)
+	if err != nil {
+		err = fmt.Errorf("failed to resolve placeholders: %w", err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	resolvedValues, err = applyValuesFrom(ctx, c, resolvedValues, workload, claims, valuesFrom)
+	if err != nil {
+		err = fmt.Errorf("failed to route claim outputs: %w", err)
+		tracing.RecordError(span, err)
+		return nil, err
+	}
+
+	return resolvedValues, nil
+}
+
+// WorkloadKind returns the kind (and schedule) to materialize for the workload under the given profile kind.
+// A schedule on the Workload always yields a CronJob; otherwise the profile kind applies,
+// and a CronJob profile without a schedule runs the workload once as a Job.
+func WorkloadKind(workload *scorev1b1.Workload, profileKind string) (string, string) {
+	if workload.Spec.Schedule != nil {
+		return scorev1b1.WorkloadKindCronJob, *workload.Spec.Schedule
+	}
+	switch profileKind {
+	case scorev1b1.WorkloadKindJob, scorev1b1.WorkloadKindCronJob:
+		return scorev1b1.WorkloadKindJob, ""
+	default:
+		return scorev1b1.WorkloadKindService, ""
+	}
+}
+
+// workloadDefaults resolves the disruption budget and default container resources of the selected backend.
+// Only continuously running workloads get a disruption budget.
+func workloadDefaults(kind string, defaults *scorev1b1.WorkloadDefaultsSpec) (*scorev1b1.DisruptionBudgetSpec, *scorev1b1.ResourceRequirements) {
+	if defaults == nil {
+		return nil, nil
+	}
+	var budget *scorev1b1.DisruptionBudgetSpec
+	if kind == scorev1b1.WorkloadKindService {
+		budget = defaults.DisruptionBudget.DeepCopy()
+	}
+	return budget, defaults.Resources.DeepCopy()
+}
+
+// workloadSecurityContext resolves the pod security defaults for the workload.
+// Defaults apply when they are configured or the Workload opts in via annotation, and never when it opts out.
+// Unset fields fall back to values that satisfy the "restricted" Pod Security Standard.
+func workloadSecurityContext(workload *scorev1b1.Workload, configured *scorev1b1.SecurityContextSpec) *scorev1b1.SecurityContextSpec {
+	switch workload.Annotations[meta.AnnotationSecurityDefaults] {
+	case meta.SecurityDefaultsDisabled:
+		return nil
+	case meta.SecurityDefaultsEnabled:
+	default:
+		if configured == nil {
+			return nil
+		}
+	}
+
+	resolved := &scorev1b1.SecurityContextSpec{}
+	if configured != nil {
+		resolved = configured.DeepCopy()
+	}
+	if resolved.RunAsNonRoot == nil {
+		resolved.RunAsNonRoot = ptr.To(true)
+	}
+	if resolved.SeccompProfile == "" {
+		resolved.SeccompProfile = scorev1b1.SeccompProfileRuntimeDefault
+	}
+	if resolved.DropCapabilities == nil {
+		resolved.DropCapabilities = []string{"ALL"}
+	}
+	if resolved.ReadOnlyRootFilesystem == nil {
+		resolved.ReadOnlyRootFilesystem = ptr.To(false)
+	}
+
+	return resolved
+}
+
 // buildPlanClaims creates the claim requirements for the runtime
 func buildPlanClaims(claims []scorev1b1.ResourceClaim) []scorev1b1.PlanClaim {
 	planClaims := make([]scorev1b1.PlanClaim, 0, len(claims))
@@ -160,15 +348,98 @@ func workloadPlanSpecEqual(a, b scorev1b1.WorkloadPlanSpec) bool {
 	if a.ObservedWorkloadGeneration != b.ObservedWorkloadGeneration {
 		return false
 	}
-	if a.RuntimeClass != b.RuntimeClass {
+	if runtimeLocation(a) != runtimeLocation(b) {
+		return false
+	}
+	if !reflect.DeepEqual(a.Exposure, b.Exposure) {
+		return false
+	}
+	if a.Kind != b.Kind || a.Schedule != b.Schedule {
+		return false
+	}
+	if !reflect.DeepEqual(a.SecurityContext, b.SecurityContext) {
+		return false
+	}
+	if !reflect.DeepEqual(a.NetworkPolicy, b.NetworkPolicy) {
+		return false
+	}
+	if !reflect.DeepEqual(a.DisruptionBudget, b.DisruptionBudget) || !reflect.DeepEqual(a.DefaultResources, b.DefaultResources) {
+		return false
+	}
+	if !reflect.DeepEqual(a.PriorityClass, b.PriorityClass) {
+		return false
+	}
+	if !reflect.DeepEqual(a.RolloutDeadline, b.RolloutDeadline) {
+		return false
+	}
+	if !reflect.DeepEqual(a.Metadata, b.Metadata) {
+		return false
+	}
+	if !reflect.DeepEqual(a.ReadinessGates, b.ReadinessGates) || a.RestartedAt != b.RestartedAt {
+		return false
+	}
+	if !reflect.DeepEqual(a.Naming, b.Naming) {
+		return false
+	}
+	if !reflect.DeepEqual(a.Claims, b.Claims) {
+		return false
+	}
+	if !resolvedValuesEqual(a.WorkloadSnapshot, b.WorkloadSnapshot) {
 		return false
 	}
 
-	// For MVP, we do a simple length check for slices
-	// More sophisticated comparison could be added if needed
-	if len(a.Claims) != len(b.Claims) {
+	if !reflect.DeepEqual(a.ResolvedValuesRef, b.ResolvedValuesRef) {
 		return false
 	}
 
-	return true
+	// Claim outputs may change after the plan exists (e.g., a rotated password), so the resolved values are compared too
+	return resolvedValuesEqual(a.ResolvedValues, b.ResolvedValues)
+}
+
+// runtimeLocation names the runtime class of a plan spec and, for delivered plans, the target it runs on,
+// followed by the environment namespace it is materialized into and the name it is materialized under
+// when the naming strategy changes it. A renamed plan is migrated like a moved one, so the runtime
+// removes the resources of the previous name.
+func runtimeLocation(spec scorev1b1.WorkloadPlanSpec) string {
+	location := spec.RuntimeClass
+	if spec.Target != "" {
+		location += "@" + spec.Target
+	}
+	if spec.Namespace != "" {
+		location += "/" + spec.Namespace
+	}
+	if name := MaterializedName(spec); name != spec.WorkloadRef.Name {
+		location += " as " + name
+	}
+	return location
+}
+
+// MaterializedName returns the name runtimes give the resources materialized for the plan spec:
+// the Workload name with the prefix and suffix of the naming strategy of the plan
+func MaterializedName(spec scorev1b1.WorkloadPlanSpec) string {
+	if spec.Naming == nil {
+		return spec.WorkloadRef.Name
+	}
+	return spec.Naming.Prefix + spec.WorkloadRef.Name + spec.Naming.Suffix
+}
+
+// resolvedValuesEqual compares resolved values by their canonical JSON encoding, as the API server may
+// re-encode them and equal values may be written with different key order or number formatting
+func resolvedValuesEqual(a, b *runtime.RawExtension) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if bytes.Equal(a.Raw, b.Raw) {
+		return true
+	}
+
+	aCanonical, err := canonicalizeJSON(a.Raw)
+	if err != nil {
+		return false
+	}
+	bCanonical, err := canonicalizeJSON(b.Raw)
+	if err != nil {
+		return false
+	}
+	return bytes.Equal(aCanonical, bCanonical)
 }
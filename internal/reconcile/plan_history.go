@@ -0,0 +1,231 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+// ErrPlanRolledBack indicates that the rollout of the current Workload generation failed and the
+// WorkloadPlan was restored from the last successfully rolled out revision
+var ErrPlanRolledBack = errors.New("workload plan rolled back")
+
+// planHistoryLimit is the number of successfully rolled out plan revisions kept per Workload
+const planHistoryLimit = 5
+
+// planRevision is the content of a plan history entry: the plan spec together with the Workload spec
+// it was computed from, since runtimes read parts of the Workload spec directly
+type planRevision struct {
+	Plan     scorev1b1.WorkloadPlanSpec `json:"plan"`
+	Workload scorev1b1.WorkloadSpec     `json:"workload"`
+}
+
+// RolledBackGeneration returns the Workload generation whose failed rollout made the Orchestrator
+// restore the plan from history, or zero when the plan was not restored
+func RolledBackGeneration(plan *scorev1b1.WorkloadPlan) int64 {
+	generation, err := strconv.ParseInt(plan.Annotations[meta.AnnotationRolledBackGeneration], 10, 64)
+	if err != nil {
+		return 0
+	}
+	return generation
+}
+
+// reconcilePlanHistory records the plan as a good revision once the runtime rolled it out, and restores the
+// last good revision when the rollout of the current Workload generation failed. It reports whether the
+// existing plan must be kept as is, which is the case while the current generation is rolled back.
+func reconcilePlanHistory(ctx context.Context, c client.Client, workload *scorev1b1.Workload, plan *scorev1b1.WorkloadPlan) (bool, error) {
+	if !plan.DeletionTimestamp.IsZero() {
+		return false, nil
+	}
+	if generation := RolledBackGeneration(plan); generation != 0 && generation == workload.Generation {
+		// Keep the restored plan until the Workload changes
+		return true, nil
+	}
+	if plan.Spec.ObservedWorkloadGeneration != workload.Generation {
+		return false, nil
+	}
+
+	ready := apimeta.FindStatusCondition(plan.Status.Conditions, meta.PlanConditionReady)
+	if ready == nil || ready.ObservedGeneration != plan.Generation {
+		// The runtime has not observed the current plan yet
+		return false, nil
+	}
+
+	switch plan.Status.Phase {
+	case scorev1b1.WorkloadPlanPhaseReady:
+		return false, recordPlanRevision(ctx, c, workload, plan)
+	case scorev1b1.WorkloadPlanPhaseFailed:
+		// Only rollouts bounded by the rollout deadline are rolled back; failed Jobs are not rollouts
+		if plan.Spec.Kind != "" && plan.Spec.Kind != scorev1b1.WorkloadKindService {
+			return false, nil
+		}
+		restored, err := rollbackPlan(ctx, c, workload, plan)
+		if err != nil || restored == nil {
+			return false, err
+		}
+		return true, fmt.Errorf("%w: generation %d failed to roll out, restored generation %d",
+			ErrPlanRolledBack, workload.Generation, restored.ObservedWorkloadGeneration)
+	default:
+		return false, nil
+	}
+}
+
+// recordPlanRevision stores the plan as the newest entry of the Workload's plan history and prunes the
+// oldest entries beyond planHistoryLimit
+func recordPlanRevision(ctx context.Context, c client.Client, workload *scorev1b1.Workload, plan *scorev1b1.WorkloadPlan) error {
+	spec := plan.Spec.DeepCopy()
+	workloadSpec := workload.Spec
+	// A plan computed from a spec merged with workload defaults carries that spec
+	if snapshot := spec.WorkloadSnapshot; snapshot != nil {
+		workloadSpec = scorev1b1.WorkloadSpec{}
+		if err := json.Unmarshal(snapshot.Raw, &workloadSpec); err != nil {
+			return fmt.Errorf("failed to unmarshal workload snapshot: %w", err)
+		}
+	}
+	spec.WorkloadSnapshot = nil
+	data, err := json.Marshal(planRevision{Plan: *spec, Workload: workloadSpec})
+	if err != nil {
+		return fmt.Errorf("failed to marshal plan revision: %w", err)
+	}
+	hash := sha256.Sum256(data)
+	name := fmt.Sprintf("%s-plan-%s", workload.Name, hex.EncodeToString(hash[:])[:10])
+
+	history, err := listPlanHistory(ctx, c, workload)
+	if err != nil {
+		return err
+	}
+	var latest int64
+	if len(history) > 0 {
+		latest = history[0].Revision
+		if history[0].Name == name {
+			return nil
+		}
+	}
+
+	// A revision rolled out again after another one becomes the newest entry
+	for i := range history {
+		if history[i].Name != name {
+			continue
+		}
+		history[i].Revision = latest + 1
+		if err := c.Update(ctx, &history[i]); err != nil {
+			return fmt.Errorf("failed to update plan revision %s: %w", name, err)
+		}
+		return nil
+	}
+
+	revision := &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: workload.Namespace,
+			Labels:    map[string]string{meta.LabelPlanHistory: workload.Name},
+		},
+		Data:     runtime.RawExtension{Raw: data},
+		Revision: latest + 1,
+	}
+	if err := controllerutil.SetControllerReference(workload, revision, c.Scheme()); err != nil {
+		return fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	if err := c.Create(ctx, revision); err != nil {
+		return fmt.Errorf("failed to record plan revision %s: %w", name, err)
+	}
+	log.FromContext(ctx).V(1).Info("Recorded plan revision", "revision", name, "workloadGeneration", plan.Spec.ObservedWorkloadGeneration)
+
+	for i := planHistoryLimit - 1; i < len(history); i++ {
+		if err := c.Delete(ctx, &history[i]); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to prune plan revision %s: %w", history[i].Name, err)
+		}
+	}
+	return nil
+}
+
+// rollbackPlan restores the newest good revision that differs from the failed plan and targets the same
+// runtime class. It returns the restored plan spec, or nil when the history holds no such revision.
+func rollbackPlan(ctx context.Context, c client.Client, workload *scorev1b1.Workload, plan *scorev1b1.WorkloadPlan) (*scorev1b1.WorkloadPlanSpec, error) {
+	history, err := listPlanHistory(ctx, c, workload)
+	if err != nil {
+		return nil, err
+	}
+
+	// Revisions are stored without the Workload spec snapshot
+	failed := plan.Spec.DeepCopy()
+	failed.WorkloadSnapshot = nil
+	for i := range history {
+		var revision planRevision
+		if err := json.Unmarshal(history[i].Data.Raw, &revision); err != nil {
+			log.FromContext(ctx).Error(err, "Ignoring malformed plan revision", "revision", history[i].Name)
+			continue
+		}
+		if revision.Plan.RuntimeClass != plan.Spec.RuntimeClass || workloadPlanSpecEqual(revision.Plan, *failed) {
+			continue
+		}
+
+		snapshot, err := json.Marshal(revision.Workload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal workload snapshot: %w", err)
+		}
+		spec := revision.Plan
+		spec.WorkloadSnapshot = &runtime.RawExtension{Raw: snapshot}
+		annotations := map[string]string{
+			meta.AnnotationRolledBackGeneration: strconv.FormatInt(workload.Generation, 10),
+		}
+		if err := applyWorkloadPlan(ctx, c, workload, spec, annotations); err != nil {
+			return nil, fmt.Errorf("failed to restore plan revision %s: %w", history[i].Name, err)
+		}
+		log.FromContext(ctx).Info("Rolled back WorkloadPlan after a failed rollout",
+			"revision", history[i].Name, "failedGeneration", workload.Generation,
+			"restoredGeneration", spec.ObservedWorkloadGeneration)
+		return &spec, nil
+	}
+	return nil, nil
+}
+
+// listPlanHistory returns the plan history of the workload, newest first
+func listPlanHistory(ctx context.Context, c client.Client, workload *scorev1b1.Workload) ([]appsv1.ControllerRevision, error) {
+	var revisions appsv1.ControllerRevisionList
+	if err := c.List(ctx, &revisions, client.InNamespace(workload.Namespace),
+		client.MatchingLabels{meta.LabelPlanHistory: workload.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list plan history: %w", err)
+	}
+
+	history := make([]appsv1.ControllerRevision, 0, len(revisions.Items))
+	for _, revision := range revisions.Items {
+		if metav1.IsControlledBy(&revision, workload) {
+			history = append(history, revision)
+		}
+	}
+	sort.Slice(history, func(i, j int) bool { return history[i].Revision > history[j].Revision })
+	return history, nil
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+func TestReconcilePlanHistoryRollsBackFailedRollout(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "uid-1", Generation: 1},
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx:1"}},
+		},
+	}
+	plan := &scorev1b1.WorkloadPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Generation: 1},
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef:                scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+			ObservedWorkloadGeneration: 1,
+			RuntimeClass:               meta.RuntimeClassKubernetes,
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(workload, plan).
+		WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate}).
+		Build()
+
+	rolledOut := func(plan *scorev1b1.WorkloadPlan, phase scorev1b1.WorkloadPlanPhase) {
+		status := metav1.ConditionFalse
+		if phase == scorev1b1.WorkloadPlanPhaseReady {
+			status = metav1.ConditionTrue
+		}
+		plan.Status = scorev1b1.WorkloadPlanStatus{
+			Phase: phase,
+			Conditions: []metav1.Condition{{
+				Type: meta.PlanConditionReady, Status: status, Reason: string(phase), ObservedGeneration: plan.Generation,
+			}},
+		}
+	}
+
+	// A plan the runtime rolled out is recorded
+	rolledOut(plan, scorev1b1.WorkloadPlanPhaseReady)
+	if keep, err := reconcilePlanHistory(ctx, c, workload, plan); keep || err != nil {
+		t.Fatalf("reconcilePlanHistory() = (%v, %v), want (false, nil)", keep, err)
+	}
+	history, err := listPlanHistory(ctx, c, workload)
+	if err != nil || len(history) != 1 {
+		t.Fatalf("plan history = %d revisions (err = %v), want 1", len(history), err)
+	}
+
+	// The next generation fails to roll out
+	workload.Generation = 2
+	workload.Spec.Containers = map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx:broken"}}
+	plan.Generation = 2
+	plan.Spec.ObservedWorkloadGeneration = 2
+	rolledOut(plan, scorev1b1.WorkloadPlanPhaseFailed)
+
+	keep, err := reconcilePlanHistory(ctx, c, workload, plan)
+	if !keep || !errors.Is(err, ErrPlanRolledBack) {
+		t.Fatalf("reconcilePlanHistory() = (%v, %v), want (true, %v)", keep, err, ErrPlanRolledBack)
+	}
+
+	restored := &scorev1b1.WorkloadPlan{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(plan), restored); err != nil {
+		t.Fatal(err)
+	}
+	if restored.Spec.ObservedWorkloadGeneration != 1 {
+		t.Errorf("restored plan generation = %d, want 1", restored.Spec.ObservedWorkloadGeneration)
+	}
+	if got := RolledBackGeneration(restored); got != 2 {
+		t.Errorf("RolledBackGeneration() = %d, want 2", got)
+	}
+	var snapshot scorev1b1.WorkloadSpec
+	if restored.Spec.WorkloadSnapshot == nil {
+		t.Fatal("restored plan carries no workload snapshot")
+	}
+	if err := json.Unmarshal(restored.Spec.WorkloadSnapshot.Raw, &snapshot); err != nil {
+		t.Fatal(err)
+	}
+	if image := snapshot.Containers["app"].Image; image != "nginx:1" {
+		t.Errorf("snapshot image = %s, want nginx:1", image)
+	}
+
+	// The restored plan is kept until the Workload changes
+	if keep, err := reconcilePlanHistory(ctx, c, workload, restored); !keep || err != nil {
+		t.Errorf("reconcilePlanHistory() = (%v, %v) for the restored plan, want (true, nil)", keep, err)
+	}
+	workload.Generation = 3
+	if keep, err := reconcilePlanHistory(ctx, c, workload, restored); keep || err != nil {
+		t.Errorf("reconcilePlanHistory() = (%v, %v) after the Workload changed, want (false, nil)", keep, err)
+	}
+}
+
+func TestRecordPlanRevisionPrunesHistory(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "uid-1"}}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(workload).Build()
+
+	for generation := int64(1); generation <= planHistoryLimit+2; generation++ {
+		workload.Generation = generation
+		workload.Spec.Containers = map[string]scorev1b1.ContainerSpec{"app": {Image: fmt.Sprintf("nginx:%d", generation)}}
+		plan := &scorev1b1.WorkloadPlan{Spec: scorev1b1.WorkloadPlanSpec{ObservedWorkloadGeneration: generation}}
+		if err := recordPlanRevision(ctx, c, workload, plan); err != nil {
+			t.Fatalf("recordPlanRevision() error = %v", err)
+		}
+	}
+
+	var revisions appsv1.ControllerRevisionList
+	if err := c.List(ctx, &revisions); err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions.Items) != planHistoryLimit {
+		t.Fatalf("plan history holds %d revisions, want %d", len(revisions.Items), planHistoryLimit)
+	}
+	history, err := listPlanHistory(ctx, c, workload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if history[0].Revision != planHistoryLimit+2 || history[len(history)-1].Revision != 3 {
+		t.Errorf("plan history spans revisions %d..%d, want %d..3", history[0].Revision, history[len(history)-1].Revision, planHistoryLimit+2)
+	}
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+func TestWorkloadKind(t *testing.T) {
+	tests := []struct {
+		name         string
+		schedule     *string
+		profileKind  string
+		wantKind     string
+		wantSchedule string
+	}{
+		{"default profile", nil, "", scorev1b1.WorkloadKindService, ""},
+		{"service profile", nil, scorev1b1.WorkloadKindService, scorev1b1.WorkloadKindService, ""},
+		{"job profile", nil, scorev1b1.WorkloadKindJob, scorev1b1.WorkloadKindJob, ""},
+		{"cronjob profile without schedule runs once", nil, scorev1b1.WorkloadKindCronJob, scorev1b1.WorkloadKindJob, ""},
+		{"schedule wins over profile kind", ptr.To("0 3 * * *"), scorev1b1.WorkloadKindService, scorev1b1.WorkloadKindCronJob, "0 3 * * *"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workload := &scorev1b1.Workload{Spec: scorev1b1.WorkloadSpec{Schedule: tt.schedule}}
+			kind, schedule := WorkloadKind(workload, tt.profileKind)
+			if kind != tt.wantKind || schedule != tt.wantSchedule {
+				t.Errorf("WorkloadKind() = (%q, %q), want (%q, %q)", kind, schedule, tt.wantKind, tt.wantSchedule)
+			}
+		})
+	}
+}
+
+func TestWorkloadSecurityContext(t *testing.T) {
+	restricted := &scorev1b1.SecurityContextSpec{
+		RunAsNonRoot:           ptr.To(true),
+		SeccompProfile:         scorev1b1.SeccompProfileRuntimeDefault,
+		DropCapabilities:       []string{"ALL"},
+		ReadOnlyRootFilesystem: ptr.To(false),
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		configured  *scorev1b1.SecurityContextSpec
+		want        *scorev1b1.SecurityContextSpec
+	}{
+		{"no defaults without configuration", nil, nil, nil},
+		{"restricted defaults for an empty configuration", nil, &scorev1b1.SecurityContextSpec{}, restricted},
+		{"enabled annotation without configuration", map[string]string{meta.AnnotationSecurityDefaults: meta.SecurityDefaultsEnabled}, nil, restricted},
+		{
+			"configured values override defaults",
+			nil,
+			&scorev1b1.SecurityContextSpec{DropCapabilities: []string{"NET_RAW"}, ReadOnlyRootFilesystem: ptr.To(true)},
+			&scorev1b1.SecurityContextSpec{
+				RunAsNonRoot:           ptr.To(true),
+				SeccompProfile:         scorev1b1.SeccompProfileRuntimeDefault,
+				DropCapabilities:       []string{"NET_RAW"},
+				ReadOnlyRootFilesystem: ptr.To(true),
+			},
+		},
+		{"opted out", map[string]string{meta.AnnotationSecurityDefaults: meta.SecurityDefaultsDisabled}, restricted, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			got := workloadSecurityContext(workload, tt.configured)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("workloadSecurityContext() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWorkloadDefaults(t *testing.T) {
+	minAvailable := intstr.FromInt32(1)
+	defaults := &scorev1b1.WorkloadDefaultsSpec{
+		DisruptionBudget: &scorev1b1.DisruptionBudgetSpec{MinAvailable: &minAvailable},
+		Resources:        &scorev1b1.ResourceRequirements{Limits: map[string]string{"memory": "256Mi"}},
+	}
+
+	tests := []struct {
+		name          string
+		kind          string
+		defaults      *scorev1b1.WorkloadDefaultsSpec
+		wantBudget    *scorev1b1.DisruptionBudgetSpec
+		wantResources *scorev1b1.ResourceRequirements
+	}{
+		{"no defaults", scorev1b1.WorkloadKindService, nil, nil, nil},
+		{"service", scorev1b1.WorkloadKindService, defaults, defaults.DisruptionBudget, defaults.Resources},
+		{"job gets no disruption budget", scorev1b1.WorkloadKindJob, defaults, nil, defaults.Resources},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budget, resources := workloadDefaults(tt.kind, tt.defaults)
+			if !reflect.DeepEqual(budget, tt.wantBudget) {
+				t.Errorf("workloadDefaults() budget = %+v, want %+v", budget, tt.wantBudget)
+			}
+			if !reflect.DeepEqual(resources, tt.wantResources) {
+				t.Errorf("workloadDefaults() resources = %+v, want %+v", resources, tt.wantResources)
+			}
+		})
+	}
+}
+
+func TestWorkloadPlanSpecEqualComparesResolvedValues(t *testing.T) {
+	values := func(raw string) *runtime.RawExtension { return &runtime.RawExtension{Raw: []byte(raw)} }
+
+	tests := []struct {
+		name string
+		a, b *runtime.RawExtension
+		want bool
+	}{
+		{"identical values", values(`{"containers":{"app":{"env":{"PASSWORD":"old"}}}}`), values(`{"containers":{"app":{"env":{"PASSWORD":"old"}}}}`), true},
+		{"re-encoded values", values(`{"a":"1","b":"2"}`), values(`{ "b": "2", "a": "1" }`), true},
+		{"re-formatted numbers", values(`{"port":8080,"ratio":0.5}`), values(`{"ratio":5e-1,"port":8080.0}`), true},
+		{"escaped HTML", values(`{"uri":"a&b"}`), values(`{"uri":"a\u0026b"}`), true},
+		{"large integers", values(`{"id":9007199254740993}`), values(`{"id":9007199254740992}`), false},
+		{"rotated output", values(`{"containers":{"app":{"env":{"PASSWORD":"old"}}}}`), values(`{"containers":{"app":{"env":{"PASSWORD":"new"}}}}`), false},
+		{"values added", nil, values(`{}`), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := scorev1b1.WorkloadPlanSpec{RuntimeClass: "kubernetes", ResolvedValues: tt.a}
+			b := scorev1b1.WorkloadPlanSpec{RuntimeClass: "kubernetes", ResolvedValues: tt.b}
+			if got := workloadPlanSpecEqual(a, b); got != tt.want {
+				t.Errorf("workloadPlanSpecEqual() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRuntimeLocation(t *testing.T) {
+	tests := []struct {
+		spec scorev1b1.WorkloadPlanSpec
+		want string
+	}{
+		{scorev1b1.WorkloadPlanSpec{RuntimeClass: "kubernetes"}, "kubernetes"},
+		{scorev1b1.WorkloadPlanSpec{RuntimeClass: "kubernetes", Target: "eu-west"}, "kubernetes@eu-west"},
+		{scorev1b1.WorkloadPlanSpec{RuntimeClass: "kubernetes", Namespace: "team-a-staging"}, "kubernetes/team-a-staging"},
+		{scorev1b1.WorkloadPlanSpec{RuntimeClass: "kubernetes", Target: "eu-west", Namespace: "team-a-staging"}, "kubernetes@eu-west/team-a-staging"},
+		{scorev1b1.WorkloadPlanSpec{
+			WorkloadRef:  scorev1b1.WorkloadPlanWorkloadRef{Name: "web"},
+			RuntimeClass: "kubernetes",
+			Naming:       &scorev1b1.NamingSpec{Prefix: "score-"},
+		}, "kubernetes as score-web"},
+		{scorev1b1.WorkloadPlanSpec{
+			WorkloadRef:  scorev1b1.WorkloadPlanWorkloadRef{Name: "web"},
+			RuntimeClass: "kubernetes",
+			Naming:       &scorev1b1.NamingSpec{ConflictPolicy: scorev1b1.ConflictPolicyAdopt},
+		}, "kubernetes"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := runtimeLocation(tt.spec); got != tt.want {
+				t.Errorf("runtimeLocation() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/planvalues"
+)
+
+// externalizePlanValues records the size of the resolved values and, when they exceed the configured inline
+// size, stores them in an immutable ConfigMap owned by the Workload. It returns the reference to the ConfigMap,
+// or nil when the values are embedded in the plan.
+func externalizePlanValues(ctx context.Context, c client.Client, workload *scorev1b1.Workload, values *runtime.RawExtension, spec *scorev1b1.PlanValuesSpec) (*scorev1b1.ValuesReference, error) {
+	if values == nil {
+		return nil, nil
+	}
+	size := len(values.Raw)
+	PlanValuesBytes.Observe(float64(size))
+
+	limit, err := planvalues.MaxInlineSize(spec)
+	if err != nil {
+		return nil, err
+	}
+	if int64(size) <= limit {
+		return nil, nil
+	}
+
+	hash := planvalues.Hash(values.Raw)
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      planvalues.ConfigMapName(workload.Name, hash),
+			Namespace: workload.Namespace,
+			Labels:    map[string]string{meta.LabelPlanValues: workload.Name},
+		},
+		Immutable: ptr.To(true),
+		Data:      map[string]string{planvalues.DataKey: string(values.Raw)},
+	}
+	if err := controllerutil.SetControllerReference(workload, configMap, c.Scheme()); err != nil {
+		return nil, fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	// The name is derived from the content, so an existing ConfigMap already holds these values
+	if err := c.Create(ctx, configMap); err != nil && !apierrors.IsAlreadyExists(err) {
+		return nil, fmt.Errorf("failed to store resolved values in ConfigMap %s: %w", configMap.Name, err)
+	}
+	log.FromContext(ctx).V(1).Info("Externalized resolved values", "configMap", configMap.Name, "bytes", size, "maxInlineSize", limit)
+
+	return &scorev1b1.ValuesReference{Name: configMap.Name, SHA256: hash}, nil
+}
+
+// prunePlanValues deletes the externalized values of the Workload that are referenced neither by the current
+// plan nor by its plan history, which rollbacks restore plans from
+func prunePlanValues(ctx context.Context, c client.Client, workload *scorev1b1.Workload, current *scorev1b1.ValuesReference) error {
+	var configMaps corev1.ConfigMapList
+	if err := c.List(ctx, &configMaps, client.InNamespace(workload.Namespace),
+		client.MatchingLabels{meta.LabelPlanValues: workload.Name}); err != nil {
+		return fmt.Errorf("failed to list externalized values: %w", err)
+	}
+	if len(configMaps.Items) == 0 {
+		return nil
+	}
+
+	referenced := map[string]bool{}
+	if current != nil {
+		referenced[current.Name] = true
+	}
+	history, err := listPlanHistory(ctx, c, workload)
+	if err != nil {
+		return err
+	}
+	for i := range history {
+		var revision planRevision
+		if err := json.Unmarshal(history[i].Data.Raw, &revision); err != nil {
+			// Keep everything rather than delete values a malformed revision might reference
+			return fmt.Errorf("failed to decode plan revision %s: %w", history[i].Name, err)
+		}
+		if ref := revision.Plan.ResolvedValuesRef; ref != nil {
+			referenced[ref.Name] = true
+		}
+	}
+
+	for i := range configMaps.Items {
+		configMap := &configMaps.Items[i]
+		if referenced[configMap.Name] || !metav1.IsControlledBy(configMap, workload) {
+			continue
+		}
+		if err := c.Delete(ctx, configMap); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to prune externalized values %s: %w", configMap.Name, err)
+		}
+	}
+	return nil
+}
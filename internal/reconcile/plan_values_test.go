@@ -0,0 +1,147 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/planvalues"
+)
+
+func TestExternalizePlanValues(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "uid-1"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(workload).Build()
+	limit := &scorev1b1.PlanValuesSpec{MaxInlineSize: "1Ki"}
+
+	// Values within the limit stay in the plan
+	small := &runtime.RawExtension{Raw: []byte(`{"containers":{}}`)}
+	if ref, err := externalizePlanValues(ctx, c, workload, small, limit); err != nil || ref != nil {
+		t.Fatalf("externalizePlanValues() = (%v, %v), want (nil, nil)", ref, err)
+	}
+
+	large := &runtime.RawExtension{Raw: []byte(`{"blob":"` + strings.Repeat("x", 2048) + `"}`)}
+	ref, err := externalizePlanValues(ctx, c, workload, large, limit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ref == nil || ref.SHA256 != planvalues.Hash(large.Raw) {
+		t.Fatalf("externalizePlanValues() = %v, want a reference with hash %s", ref, planvalues.Hash(large.Raw))
+	}
+
+	// Externalizing the same values again reuses the ConfigMap
+	again, err := externalizePlanValues(ctx, c, workload, large, limit)
+	if err != nil || *again != *ref {
+		t.Fatalf("externalizePlanValues() = (%v, %v), want (%v, nil)", again, err, ref)
+	}
+
+	plan := &scorev1b1.WorkloadPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec:       scorev1b1.WorkloadPlanSpec{ResolvedValuesRef: ref},
+	}
+	values, err := planvalues.Load(ctx, c, plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(values.Raw) != string(large.Raw) {
+		t.Errorf("Load() = %s, want the externalized values", values.Raw)
+	}
+}
+
+func TestPrunePlanValuesKeepsHistory(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "uid-1"},
+	}
+	controlled := func(obj client.Object) {
+		obj.SetOwnerReferences([]metav1.OwnerReference{{
+			APIVersion: scorev1b1.GroupVersion.String(), Kind: "Workload", Name: "app", UID: "uid-1", Controller: ptr.To(true),
+		}})
+	}
+	values := func(name string) *corev1.ConfigMap {
+		configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+			Name: name, Namespace: "default", Labels: map[string]string{meta.LabelPlanValues: "app"},
+		}}
+		controlled(configMap)
+		return configMap
+	}
+
+	data, err := json.Marshal(planRevision{Plan: scorev1b1.WorkloadPlanSpec{
+		ResolvedValuesRef: &scorev1b1.ValuesReference{Name: "app-values-history"},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	revision := &appsv1.ControllerRevision{
+		ObjectMeta: metav1.ObjectMeta{Name: "app-plan-1", Namespace: "default", Labels: map[string]string{meta.LabelPlanHistory: "app"}},
+		Data:       runtime.RawExtension{Raw: data},
+		Revision:   1,
+	}
+	controlled(revision)
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(workload, revision, values("app-values-current"), values("app-values-history"), values("app-values-stale")).
+		Build()
+
+	if err := prunePlanValues(ctx, c, workload, &scorev1b1.ValuesReference{Name: "app-values-current"}); err != nil {
+		t.Fatal(err)
+	}
+
+	var configMaps corev1.ConfigMapList
+	if err := c.List(ctx, &configMaps, client.InNamespace("default")); err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, configMap := range configMaps.Items {
+		names = append(names, configMap.Name)
+	}
+	if want := "app-values-current,app-values-history"; strings.Join(names, ",") != want {
+		t.Errorf("remaining ConfigMaps = %v, want %s", names, want)
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/selection"
+)
+
+// IsDryRun reports whether the Workload requests a dry-run preview instead of materialization
+func IsDryRun(workload *scorev1b1.Workload) bool {
+	return strings.EqualFold(strings.TrimSpace(workload.Annotations[meta.AnnotationDryRun]), "true")
+}
+
+// PreviewWorkloadPlan computes what UpsertWorkloadPlan would emit for the Workload without writing anything.
+// Claims are optional; outputs of existing claims are used for values composition, and
+// placeholders referencing unavailable outputs are reported instead of failing the preview.
+func PreviewWorkloadPlan(ctx context.Context, c client.Client, workload *scorev1b1.Workload, claims []scorev1b1.ResourceClaim, selectedBackend *selection.SelectedBackend) (*scorev1b1.WorkloadPreview, error) {
+	workload = EffectiveWorkload(workload, selectedBackend.WorkloadDefaults)
+	resolvedValues, unresolved, err := resolvePlaceholders(ctx, c, workload, claims, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to compose values: %w", err)
+	}
+
+	return &scorev1b1.WorkloadPreview{
+		ObservedGeneration:     workload.Generation,
+		BackendID:              selectedBackend.BackendID,
+		RuntimeClass:           selectedBackend.RuntimeClass,
+		Template:               selectedBackend.Template.DeepCopy(),
+		Exposure:               selectedBackend.Exposure.DeepCopy(),
+		Claims:                 buildPreviewClaims(workload),
+		ResolvedValues:         resolvedValues,
+		UnresolvedPlaceholders: unresolved,
+	}, nil
+}
+
+// buildPreviewClaims derives the claim requirements from the Workload resources, ordered by key
+func buildPreviewClaims(workload *scorev1b1.Workload) []scorev1b1.PlanClaim {
+	keys := make([]string, 0, len(workload.Spec.Resources))
+	for key := range workload.Spec.Resources {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	planClaims := make([]scorev1b1.PlanClaim, 0, len(keys))
+	for _, key := range keys {
+		resource := workload.Spec.Resources[key]
+		planClaims = append(planClaims, scorev1b1.PlanClaim{
+			Key:   key,
+			Type:  resource.Type,
+			Class: resource.Class,
+		})
+	}
+
+	return planClaims
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/selection"
+)
+
+func TestIsDryRun(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{
+		{name: "no annotations", annotations: nil, want: false},
+		{name: "true", annotations: map[string]string{meta.AnnotationDryRun: "true"}, want: true},
+		{name: "case insensitive", annotations: map[string]string{meta.AnnotationDryRun: " True "}, want: true},
+		{name: "false", annotations: map[string]string{meta.AnnotationDryRun: "false"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			if got := IsDryRun(workload); got != tt.want {
+				t.Errorf("IsDryRun() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPreviewWorkloadPlan(t *testing.T) {
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Generation: 3},
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{
+				"main": {
+					Variables: map[string]string{
+						"DATABASE_URL": "${resources.db.uri}",
+						"CACHE_URL":    "${resources.cache.uri}",
+						"STATIC_VAR":   "static-value",
+					},
+				},
+			},
+			Resources: map[string]scorev1b1.ResourceSpec{
+				"db":    {Type: "postgres", Class: ptr.To("small")},
+				"cache": {Type: "redis"},
+			},
+		},
+	}
+	claims := []scorev1b1.ResourceClaim{
+		{
+			Spec: scorev1b1.ResourceClaimSpec{Key: "cache"},
+			Status: scorev1b1.ResourceClaimStatus{
+				OutputsAvailable: true,
+				Outputs:          &scorev1b1.ResourceClaimOutputs{URI: ptr.To("redis://cache:6379")},
+			},
+		},
+	}
+	backend := &selection.SelectedBackend{
+		BackendID:    "k8s-default",
+		RuntimeClass: "kubernetes",
+		Template:     scorev1b1.TemplateSpec{Kind: "manifests", Ref: "registry.example.com/templates/web:1"},
+	}
+
+	preview, err := PreviewWorkloadPlan(context.TODO(), fake.NewClientBuilder().Build(), workload, claims, backend)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if preview.ObservedGeneration != 3 || preview.BackendID != "k8s-default" || preview.RuntimeClass != "kubernetes" {
+		t.Errorf("unexpected preview header: %+v", preview)
+	}
+	if preview.Template == nil || preview.Template.Ref != backend.Template.Ref {
+		t.Errorf("expected template %v, got %v", backend.Template, preview.Template)
+	}
+
+	wantClaims := []scorev1b1.PlanClaim{
+		{Key: "cache", Type: "redis"},
+		{Key: "db", Type: "postgres", Class: ptr.To("small")},
+	}
+	if !reflect.DeepEqual(preview.Claims, wantClaims) {
+		t.Errorf("claims = %+v, want %+v", preview.Claims, wantClaims)
+	}
+
+	wantUnresolved := []string{"containers.main.variables.DATABASE_URL"}
+	if !reflect.DeepEqual(preview.UnresolvedPlaceholders, wantUnresolved) {
+		t.Errorf("unresolved = %v, want %v", preview.UnresolvedPlaceholders, wantUnresolved)
+	}
+
+	var values struct {
+		Containers map[string]struct {
+			Env map[string]string `json:"env"`
+		} `json:"containers"`
+	}
+	if err := json.Unmarshal(preview.ResolvedValues.Raw, &values); err != nil {
+		t.Fatalf("failed to unmarshal resolved values: %v", err)
+	}
+	wantEnv := map[string]string{
+		"DATABASE_URL": "${resources.db.uri}",
+		"CACHE_URL":    "redis://cache:6379",
+		"STATIC_VAR":   "static-value",
+	}
+	if got := values.Containers["main"].Env; !reflect.DeepEqual(got, wantEnv) {
+		t.Errorf("env = %v, want %v", got, wantEnv)
+	}
+}
+
+func TestPreviewWorkloadPlanRedactsSecretOutputs(t *testing.T) {
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{
+				"main": {
+					Variables: map[string]string{
+						"DB_HOST":     "${resources.db.host}",
+						"DB_PASSWORD": "${resources.db.password}",
+						"DB_DSN":      "postgres://app:${resources.db.password}@${resources.db.host}",
+						"DB_FALLBACK": "${resources.db.password:-none}",
+						"DB_URI":      "${resources.db.uri}",
+					},
+				},
+			},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "default"},
+		Data: map[string][]byte{
+			"host":     []byte("db.internal"),
+			"password": []byte("s3cr3t"),
+		},
+	}
+	claims := []scorev1b1.ResourceClaim{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-db", Namespace: "default"},
+			Spec:       scorev1b1.ResourceClaimSpec{Key: "db", Type: "postgres"},
+			Status: scorev1b1.ResourceClaimStatus{
+				OutputsAvailable: true,
+				Outputs: &scorev1b1.ResourceClaimOutputs{
+					URI:       ptr.To("postgres://db.internal:5432"),
+					SecretRef: &scorev1b1.LocalObjectReference{Name: "db-credentials"},
+				},
+			},
+		},
+	}
+	backend := &selection.SelectedBackend{BackendID: "k8s-default", RuntimeClass: "kubernetes"}
+
+	preview, err := PreviewWorkloadPlan(context.TODO(), fake.NewClientBuilder().WithObjects(secret).Build(), workload, claims, backend)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(string(preview.ResolvedValues.Raw), "s3cr3t") {
+		t.Fatalf("preview leaks Secret data: %s", preview.ResolvedValues.Raw)
+	}
+	var values struct {
+		Containers map[string]struct {
+			Env map[string]string `json:"env"`
+		} `json:"containers"`
+	}
+	if err := json.Unmarshal(preview.ResolvedValues.Raw, &values); err != nil {
+		t.Fatalf("failed to unmarshal resolved values: %v", err)
+	}
+	wantEnv := map[string]string{
+		"DB_HOST":     redactedValue,
+		"DB_PASSWORD": redactedValue,
+		"DB_DSN":      redactedValue,
+		"DB_FALLBACK": redactedValue,
+		"DB_URI":      "postgres://db.internal:5432",
+	}
+	if got := values.Containers["main"].Env; !reflect.DeepEqual(got, wantEnv) {
+		t.Errorf("env = %v, want %v", got, wantEnv)
+	}
+
+	// The materialized plan cannot embed Secret data into a longer value
+	_, err = resolveAllPlaceholders(context.TODO(), fake.NewClientBuilder().WithObjects(secret).Build(), workload, claims)
+	var placeholderErr *PlaceholderError
+	if !errors.As(err, &placeholderErr) || placeholderErr.Path != "containers.main.variables.DB_DSN" {
+		t.Errorf("resolveAllPlaceholders() error = %v, want a placeholder error for DB_DSN", err)
+	}
+}
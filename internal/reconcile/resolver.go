@@ -18,22 +18,112 @@ package reconcile
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"regexp"
+	"sort"
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner"
+	"github.com/cappyzawa/score-orchestrator/pkg/resolvedvalues"
 )
 
+// ErrUnresolvedPlaceholders indicates that the workload projection references outputs that are not available
+var ErrUnresolvedPlaceholders = errors.New("unresolved placeholders")
+
+// redactedValue replaces resolved values derived from Secret data in previews
+const redactedValue = "<redacted>"
+
 // resolveAllPlaceholders creates a fully resolved values structure with all placeholders substituted
 func resolveAllPlaceholders(ctx context.Context, c client.Client, workload *scorev1b1.Workload, claims []scorev1b1.ResourceClaim) (*runtime.RawExtension, error) {
+	resolvedValues, _, err := resolvePlaceholders(ctx, c, workload, claims, false)
+	return resolvedValues, err
+}
+
+// resolvePlaceholders substitutes placeholders with the outputs of the given claims.
+// Outputs read from Secret data are sensitive and never inlined: a container variable whose entire value
+// is such an output resolves to a {"secretKeyRef": {"name", "key"}} reference the runtime projects from the
+// Secret, and any other use is reported as a *PlaceholderError.
+// In preview mode, placeholders whose outputs are not available are kept verbatim and
+// reported as unresolved instead of failing the resolution, and values derived from Secret
+// data are redacted because the preview is published in the Workload status.
+func resolvePlaceholders(ctx context.Context, c client.Client, workload *scorev1b1.Workload, claims []scorev1b1.ResourceClaim, preview bool) (*runtime.RawExtension, []string, error) {
+	var unresolved []string
+
 	// Build a map of available outputs for quick lookup
-	availableOutputs := buildResolvedOutputsMap(ctx, c, claims)
+	availableOutputs, publicOutputs, secretSources := buildResolvedOutputsMap(ctx, c, claims)
+	// Placeholders of optional resources that are not bound yet resolve to their default or to ""
+	optional := optionalResources(workload)
+
+	// substitute substitutes the placeholders of the value found at path in the Workload spec and
+	// returns the placeholders that resolved to sensitive outputs
+	substitute := func(path, value string) (string, []string, error) {
+		var sensitive []string
+		resolvedValue, err := expandPlaceholders(value, func(placeholder string) (string, error) {
+			resolved, err := resolveOptionalPlaceholder(placeholder, availableOutputs, optional)
+			if err != nil {
+				return "", err
+			}
+			// A placeholder is sensitive if it resolves differently without the Secret-sourced outputs
+			if public, err := resolveOptionalPlaceholder(placeholder, publicOutputs, optional); err != nil || public != resolved {
+				sensitive = append(sensitive, placeholder)
+			}
+			return resolved, nil
+		})
+		if err != nil {
+			var placeholderErr *PlaceholderError
+			if !errors.As(err, &placeholderErr) {
+				return "", nil, fmt.Errorf("failed to resolve %s: %w", path, err)
+			}
+			if !preview {
+				placeholderErr.Path = path
+				return "", nil, placeholderErr
+			}
+			unresolved = append(unresolved, path)
+			return value, nil, nil
+		}
+		return resolvedValue, sensitive, nil
+	}
+
+	// resolve resolves a value that cannot reference sensitive outputs
+	resolve := func(path, value string) (string, error) {
+		resolvedValue, sensitive, err := substitute(path, value)
+		if err != nil || len(sensitive) == 0 {
+			return resolvedValue, err
+		}
+		if preview {
+			return redactedValue, nil
+		}
+		return "", &PlaceholderError{Path: path, Placeholder: sensitive[0],
+			Reason: "references a sensitive output, which may only be used as the entire value of a container variable"}
+	}
+
+	// resolveVariable resolves a container variable, which references a sensitive output by Secret key
+	resolveVariable := func(path, value string) (interface{}, error) {
+		resolvedValue, sensitive, err := substitute(path, value)
+		if err != nil || len(sensitive) == 0 {
+			return resolvedValue, err
+		}
+		if preview {
+			return redactedValue, nil
+		}
+		if len(sensitive) > 1 || sensitive[0] != value {
+			return nil, &PlaceholderError{Path: path, Placeholder: sensitive[0],
+				Reason: "references a sensitive output, which must be the entire value of the variable"}
+		}
+		reference, ok := secretKeyReference(value, secretSources)
+		if !ok {
+			return nil, &PlaceholderError{Path: path, Placeholder: value,
+				Reason: "references a sensitive output that is not published through a Secret key"}
+		}
+		return reference, nil
+	}
 
 	// Create the resolved values structure
 	resolvedValues := make(map[string]interface{})
@@ -43,104 +133,268 @@ func resolveAllPlaceholders(ctx context.Context, c client.Client, workload *scor
 	for containerName, containerSpec := range workload.Spec.Containers {
 		container := make(map[string]interface{})
 
+		// Resolve the command, its arguments and working directory, which cannot reference sensitive outputs
+		resolveList := func(field string, values []string) error {
+			if len(values) == 0 {
+				return nil
+			}
+			resolved := make([]interface{}, 0, len(values))
+			for i, value := range values {
+				resolvedValue, err := resolve(fmt.Sprintf("containers.%s.%s[%d]", containerName, field, i), value)
+				if err != nil {
+					return err
+				}
+				resolved = append(resolved, resolvedValue)
+			}
+			container[field] = resolved
+			return nil
+		}
+		if err := resolveList("command", containerSpec.Command); err != nil {
+			return nil, nil, err
+		}
+		if err := resolveList("args", containerSpec.Args); err != nil {
+			return nil, nil, err
+		}
+		if containerSpec.WorkingDir != "" {
+			workingDir, err := resolve(fmt.Sprintf("containers.%s.workingDir", containerName), containerSpec.WorkingDir)
+			if err != nil {
+				return nil, nil, err
+			}
+			container["workingDir"] = workingDir
+		}
+
 		// Resolve environment variables
 		if containerSpec.Variables != nil {
 			env := make(map[string]interface{})
 			for envName, envValue := range containerSpec.Variables {
-				resolvedValue, err := resolveValue(envValue, availableOutputs)
+				resolvedValue, err := resolveVariable(fmt.Sprintf("containers.%s.variables.%s", containerName, envName), envValue)
 				if err != nil {
-					return nil, fmt.Errorf("failed to resolve env var %s in container %s: %w", envName, containerName, err)
+					return nil, nil, err
 				}
 				env[envName] = resolvedValue
 			}
 			container["env"] = env
 		}
 
-		// TODO: Resolve other container fields (files, volumes, etc.)
+		// Resolve file contents and source URIs; binary content is passed through as is
+		if len(containerSpec.Files) > 0 {
+			files := make([]interface{}, 0, len(containerSpec.Files))
+			for i, fileSpec := range containerSpec.Files {
+				path := fmt.Sprintf("containers.%s.files[%d]", containerName, i)
+				file := map[string]interface{}{"target": fileSpec.Target}
+				if fileSpec.Mode != nil {
+					file["mode"] = *fileSpec.Mode
+				}
+				if fileSpec.Content != nil {
+					content := *fileSpec.Content
+					if !fileSpec.NoExpand {
+						var err error
+						if content, err = resolve(path+".content", content); err != nil {
+							return nil, nil, err
+						}
+					}
+					file["content"] = content
+				}
+				if fileSpec.Source != nil {
+					uri, err := resolve(path+".source.uri", fileSpec.Source.URI)
+					if err != nil {
+						return nil, nil, err
+					}
+					file["source"] = map[string]interface{}{"uri": uri}
+				}
+				if fileSpec.BinaryContent != nil {
+					file["binaryContent"] = *fileSpec.BinaryContent
+				}
+				files = append(files, file)
+			}
+			container["files"] = files
+		}
+
 		containers[containerName] = container
 	}
 	resolvedValues["containers"] = containers
 
-	// TODO: Resolve service ports and other top-level fields
+	// Surface the ServiceAccount name so that templates can reference it, and resolve its annotations
+	// (e.g., a cloud role provisioned as a resource)
+	if serviceAccountSpec := workload.Spec.ServiceAccount; serviceAccountSpec != nil {
+		serviceAccount := map[string]interface{}{
+			"name": serviceAccountName(workload),
+		}
+		if len(serviceAccountSpec.Annotations) > 0 {
+			annotations := make(map[string]interface{}, len(serviceAccountSpec.Annotations))
+			for key, value := range serviceAccountSpec.Annotations {
+				resolvedValue, err := resolve(fmt.Sprintf("serviceAccount.annotations.%s", key), value)
+				if err != nil {
+					return nil, nil, err
+				}
+				annotations[key] = resolvedValue
+			}
+			serviceAccount["annotations"] = annotations
+		}
+		resolvedValues["serviceAccount"] = serviceAccount
+	}
 
-	// Convert to RawExtension
-	jsonData, err := json.Marshal(resolvedValues)
+	// Resolve service target ports, which may name a container port or come from a resource output
+	if service := workload.Spec.Service; service != nil && len(service.Ports) > 0 {
+		ports := make([]interface{}, 0, len(service.Ports))
+		for i, port := range service.Ports {
+			targetPort := intstr.FromInt32(port.Port)
+			if port.TargetPort != nil {
+				targetPort = *port.TargetPort
+			}
+			if targetPort.Type == intstr.String {
+				path := fmt.Sprintf("service.ports[%d].targetPort", i)
+				resolvedValue, err := resolve(path, targetPort.StrVal)
+				if err != nil {
+					return nil, nil, err
+				}
+				targetPort = intstr.Parse(resolvedValue)
+				if !preview && !validTargetPort(targetPort) {
+					return nil, nil, &PlaceholderError{Path: path, Placeholder: port.TargetPort.StrVal,
+						Reason: fmt.Sprintf("resolved to %q, which is neither a port number nor a port name", resolvedValue)}
+				}
+			}
+			ports = append(ports, map[string]interface{}{
+				"port":       port.Port,
+				"targetPort": targetPort,
+			})
+		}
+		resolvedValues["service"] = map[string]interface{}{"ports": ports}
+	}
+
+	// Resolve the claims shared volumes are mounted from, which usually come from a volume resource
+	if storage := workload.Spec.Storage; storage != nil {
+		var volumes []interface{}
+		for i, volume := range storage.Volumes {
+			if volume.Source == "" {
+				continue
+			}
+			path := fmt.Sprintf("storage.volumes[%d].source", i)
+			source, err := resolve(path, volume.Source)
+			if err != nil {
+				return nil, nil, err
+			}
+			if !preview && len(validation.IsDNS1123Subdomain(source)) > 0 {
+				return nil, nil, &PlaceholderError{Path: path, Placeholder: volume.Source,
+					Reason: fmt.Sprintf("resolved to %q, which is not a valid claim name", source)}
+			}
+			volumes = append(volumes, map[string]interface{}{
+				"name":   volume.Name,
+				"source": source,
+			})
+		}
+		if len(volumes) > 0 {
+			resolvedValues["storage"] = map[string]interface{}{"volumes": volumes}
+		}
+	}
+
+	// Credentials held by external secret stores are synced into Secrets by the runtime
+	if externalSecrets := buildExternalSecrets(claims); len(externalSecrets) > 0 {
+		resolvedValues["externalSecrets"] = externalSecrets
+	}
+
+	// Convert to RawExtension in canonical form, so that the plan only changes with its content
+	jsonData, err := canonicalJSON(resolvedValues)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal resolved values: %w", err)
+		return nil, nil, fmt.Errorf("failed to marshal resolved values: %w", err)
 	}
 
-	return &runtime.RawExtension{Raw: jsonData}, nil
+	// Map iteration order is random; keep the report stable
+	sort.Strings(unresolved)
+
+	return &runtime.RawExtension{Raw: jsonData}, unresolved, nil
 }
 
-// resolveValue resolves a single value string by substituting placeholders
-func resolveValue(value string, availableOutputs map[string]map[string]string) (interface{}, error) {
-	// Regular expressions to match both ${resources.<key>.<name>} and ${resources.<key>.outputs.<name>} patterns
-	resourceRefPattern := regexp.MustCompile(`\$\{resources\.([^.]+)\.([^}]+)\}`)
-	resourceRefWithOutputsPattern := regexp.MustCompile(`\$\{resources\.([^.]+)\.outputs\.([^}]+)\}`)
-
-	result := value
-
-	// First try the old format: ${resources.<key>.outputs.<name>} (more specific)
-	outputsMatches := resourceRefWithOutputsPattern.FindAllStringSubmatch(value, -1)
-	for _, match := range outputsMatches {
-		if len(match) >= 3 {
-			resourceKey := match[1]
-			outputKey := match[2]
-
-			if outputs, exists := availableOutputs[resourceKey]; exists {
-				if resolvedValue, exists := outputs[outputKey]; exists {
-					templateVar := fmt.Sprintf("${resources.%s.outputs.%s}", resourceKey, outputKey)
-					result = strings.ReplaceAll(result, templateVar, resolvedValue)
-				} else {
-					return nil, fmt.Errorf("resource '%s' missing output '%s'", resourceKey, outputKey)
-				}
-			} else {
-				return nil, fmt.Errorf("resource '%s' has no outputs available", resourceKey)
-			}
-		}
+// validTargetPort reports whether a resolved target port is a valid port number or container port name
+func validTargetPort(targetPort intstr.IntOrString) bool {
+	if targetPort.Type == intstr.Int {
+		return len(validation.IsValidPortNum(targetPort.IntValue())) == 0
 	}
+	return len(validation.IsValidPortName(targetPort.StrVal)) == 0
+}
 
-	// If no old format found, try the new format: ${resources.<key>.<name>}
-	if len(outputsMatches) == 0 {
-		matches := resourceRefPattern.FindAllStringSubmatch(value, -1)
-		for _, match := range matches {
-			if len(match) >= 3 {
-				resourceKey := match[1]
-				outputKey := match[2]
+// serviceAccountName returns the name of the ServiceAccount declared by the workload, defaulting to the workload name
+func serviceAccountName(workload *scorev1b1.Workload) string {
+	if workload.Spec.ServiceAccount != nil && workload.Spec.ServiceAccount.Name != "" {
+		return workload.Spec.ServiceAccount.Name
+	}
+	return workload.Name
+}
 
-				if outputs, exists := availableOutputs[resourceKey]; exists {
-					if resolvedValue, exists := outputs[outputKey]; exists {
-						templateVar := fmt.Sprintf("${resources.%s.%s}", resourceKey, outputKey)
-						result = strings.ReplaceAll(result, templateVar, resolvedValue)
-					} else {
-						return nil, fmt.Errorf("resource '%s' missing output '%s'", resourceKey, outputKey)
-					}
-				} else {
-					return nil, fmt.Errorf("resource '%s' has no outputs available", resourceKey)
-				}
-			}
+// secretKeyReference returns the {"secretKeyRef": {"name", "key"}} reference of a placeholder that names
+// a Secret-sourced output as a whole
+func secretKeyReference(placeholder string, secretSources map[string]map[string]string) (map[string]interface{}, bool) {
+	resourceKey, path, err := parsePlaceholder(placeholder)
+	if err != nil {
+		return nil, false
+	}
+	key := strings.Join(path, ".")
+	secretName, ok := secretSources[resourceKey][key]
+	if !ok {
+		return nil, false
+	}
+	return map[string]interface{}{
+		"secretKeyRef": map[string]interface{}{"name": secretName, "key": key},
+	}, true
+}
+
+// buildExternalSecrets lists the credentials of the claims held by external secret stores, ordered by Secret name
+func buildExternalSecrets(claims []scorev1b1.ResourceClaim) []interface{} {
+	var names []string
+	byName := make(map[string]interface{})
+	for i := range claims {
+		claim := &claims[i]
+		if !claim.Status.OutputsAvailable || claim.Status.Outputs == nil || claim.Status.Outputs.ExternalSecretRef == nil {
+			continue
+		}
+		ref := claim.Status.Outputs.ExternalSecretRef
+		keys := make([]interface{}, 0, len(ref.Keys))
+		for _, key := range ref.Keys {
+			keys = append(keys, key)
+		}
+		name := resolvedvalues.ExternalSecretName(claim)
+		names = append(names, name)
+		byName[name] = map[string]interface{}{
+			"name":    name,
+			"store":   ref.Store,
+			"path":    ref.Path,
+			"version": ref.Version,
+			"keys":    keys,
 		}
 	}
+	sort.Strings(names)
 
-	// Return the resolved string value
-	return result, nil
+	externalSecrets := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		externalSecrets = append(externalSecrets, byName[name])
+	}
+	return externalSecrets
 }
 
-// buildResolvedOutputsMap creates a map of available resolved outputs for each claim
-func buildResolvedOutputsMap(ctx context.Context, c client.Client, claims []scorev1b1.ResourceClaim) map[string]map[string]string {
+// buildResolvedOutputsMap creates a map of available resolved outputs for each claim.
+// The second map holds the same outputs without the sensitive ones, i.e. those read from (or standing in for)
+// Secret or external secret store data and URIs carrying a password. The third map names the Secret each Secret-sourced output is read
+// from, by resource key and output key.
+func buildResolvedOutputsMap(ctx context.Context, c client.Client, claims []scorev1b1.ResourceClaim) (map[string]map[string]string, map[string]map[string]string, map[string]map[string]string) {
 	availableOutputs := make(map[string]map[string]string)
+	publicOutputs := make(map[string]map[string]string)
+	secretSources := make(map[string]map[string]string)
 	for _, claim := range claims {
 		if claim.Status.OutputsAvailable {
 			outputs := make(map[string]string)
+			sources := make(map[string]string)
+			sensitiveKeys := make(map[string]bool)
 
 			if claim.Status.Outputs.URI != nil {
 				outputs["uri"] = *claim.Status.Outputs.URI
+				sensitiveKeys["uri"] = provisioner.URIContainsPassword(outputs["uri"])
 			}
 			if claim.Status.Outputs.SecretRef != nil {
+				secretName := claim.Status.Outputs.SecretRef.Name
 				// Read actual Secret data instead of using hardcoded values
 				secret := &corev1.Secret{}
 				err := c.Get(ctx, client.ObjectKey{
-					Name:      claim.Status.Outputs.SecretRef.Name,
+					Name:      secretName,
 					Namespace: claim.Namespace,
 				}, secret)
 
@@ -148,6 +402,7 @@ func buildResolvedOutputsMap(ctx context.Context, c client.Client, claims []scor
 					// Use actual Secret data
 					for key, value := range secret.Data {
 						outputs[key] = string(value)
+						sources[key] = secretName
 					}
 				} else {
 					// Fallback to hardcoded values only if Secret read fails
@@ -159,14 +414,27 @@ func buildResolvedOutputsMap(ctx context.Context, c client.Client, claims []scor
 						outputs["port"] = "5432"
 						outputs["database"] = fmt.Sprintf("db_%s", claim.Name)
 						outputs["uri"] = fmt.Sprintf("postgresql://%s:%s@%s:5432/%s", outputs["username"], outputs["password"], outputs["host"], outputs["database"])
+						for _, key := range []string{"username", "password", "host", "port", "database", "uri"} {
+							sources[key] = secretName
+						}
 					}
 					// TODO: Handle other resource types
 				}
 			}
+			if ref := claim.Status.Outputs.ExternalSecretRef; ref != nil {
+				// The values are only known to the store; they are referenced through the Secret the runtime syncs
+				for _, key := range ref.Keys {
+					outputs[key] = ""
+					sources[key] = resolvedvalues.ExternalSecretName(&claim)
+				}
+			}
 			// TODO: Handle ConfigMap references when needed
 			if claim.Status.Outputs.Image != nil {
 				outputs["image"] = *claim.Status.Outputs.Image
 			}
+			if claim.Status.Outputs.Hostname != nil {
+				outputs["hostname"] = *claim.Status.Outputs.Hostname
+			}
 			if claim.Status.Outputs.Cert != nil {
 				// For certificate outputs, we'll use the SecretName if available
 				if claim.Status.Outputs.Cert.SecretName != nil {
@@ -174,9 +442,35 @@ func buildResolvedOutputsMap(ctx context.Context, c client.Client, claims []scor
 				}
 				// TODO: Handle inline certificate data
 			}
+			if pvcRef := claim.Status.Outputs.PVCRef; pvcRef != nil {
+				// The claim name is what Workloads mount; storage class and capacity are informational
+				outputs["pvcRef"] = pvcRef.Name
+				outputs["storageClass"] = pvcRef.StorageClass
+				outputs["capacity"] = pvcRef.Capacity
+			}
 
 			availableOutputs[claim.Spec.Key] = outputs
+			secretSources[claim.Spec.Key] = sources
+
+			public := make(map[string]string, len(outputs))
+			for key, value := range outputs {
+				if _, fromSecret := sources[key]; !fromSecret && !sensitiveKeys[key] {
+					public[key] = value
+				}
+			}
+			publicOutputs[claim.Spec.Key] = public
+		}
+	}
+	return availableOutputs, publicOutputs, secretSources
+}
+
+// optionalResources returns the keys of the optional resources of the Workload
+func optionalResources(workload *scorev1b1.Workload) map[string]bool {
+	optional := make(map[string]bool)
+	for key, resource := range workload.Spec.Resources {
+		if resource.Optional {
+			optional[key] = true
 		}
 	}
-	return availableOutputs
+	return optional
 }
@@ -19,9 +19,14 @@ package reconcile
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -86,6 +91,47 @@ func TestResolveAllPlaceholders(t *testing.T) {
 			expectError: true,
 			errorMsg:    "resource 'missing' has no outputs available",
 		},
+		{
+			name: "error points at the offending container variable",
+			workload: &scorev1b1.Workload{
+				Spec: scorev1b1.WorkloadSpec{
+					Containers: map[string]scorev1b1.ContainerSpec{
+						"app": {
+							Variables: map[string]string{
+								"CACHE_URL": "${resources.cache.outputs.uri}",
+							},
+						},
+					},
+				},
+			},
+			claims:      []scorev1b1.ResourceClaim{},
+			expectedEnv: nil,
+			expectError: true,
+			errorMsg:    "containers.app.variables.CACHE_URL: ${resources.cache.outputs.uri}",
+		},
+		{
+			name: "optional resources without outputs resolve to their default or empty",
+			workload: &scorev1b1.Workload{
+				Spec: scorev1b1.WorkloadSpec{
+					Containers: map[string]scorev1b1.ContainerSpec{
+						"app": {
+							Variables: map[string]string{
+								"SEARCH_URL":  "${resources.search.uri}",
+								"SEARCH_HOST": "${resources.search.host:-localhost}",
+							},
+						},
+					},
+					Resources: map[string]scorev1b1.ResourceSpec{
+						"search": {Type: "elasticsearch", Optional: true},
+					},
+				},
+			},
+			claims: []scorev1b1.ResourceClaim{},
+			expectedEnv: map[string]string{
+				"SEARCH_URL":  "",
+				"SEARCH_HOST": "localhost",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -153,3 +199,491 @@ func TestResolveAllPlaceholders(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveSensitiveOutputs(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "default"},
+		Data: map[string][]byte{
+			"password": []byte("s3cr3t"),
+			"uri":      []byte("postgres://app:s3cr3t@db:5432/app"),
+		},
+	}
+	claim := func(outputs scorev1b1.ResourceClaimOutputs) []scorev1b1.ResourceClaim {
+		return []scorev1b1.ResourceClaim{{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-db", Namespace: "default"},
+			Spec:       scorev1b1.ResourceClaimSpec{Key: "db", Type: "postgres"},
+			Status:     scorev1b1.ResourceClaimStatus{OutputsAvailable: true, Outputs: &outputs},
+		}}
+	}
+	fromSecret := claim(scorev1b1.ResourceClaimOutputs{
+		URI:       ptr.To("postgres://db:5432/app"),
+		SecretRef: &scorev1b1.LocalObjectReference{Name: "db-credentials"},
+	})
+	workload := func(container scorev1b1.ContainerSpec) *scorev1b1.Workload {
+		return &scorev1b1.Workload{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec:       scorev1b1.WorkloadSpec{Containers: map[string]scorev1b1.ContainerSpec{"app": container}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		workload *scorev1b1.Workload
+		claims   []scorev1b1.ResourceClaim
+		wantEnv  string
+		wantPath string
+		// wantExternal is the externalSecrets section of the resolved values
+		wantExternal string
+	}{
+		{
+			name: "whole values become secret key references",
+			workload: workload(scorev1b1.ContainerSpec{Variables: map[string]string{
+				"DB_URL":      "${resources.db.outputs.uri}",
+				"DB_PASSWORD": "${resources.db.password:-none}",
+				"DB_HOST":     "db",
+			}}),
+			claims: fromSecret,
+			wantEnv: `{"DB_HOST":"db",` +
+				`"DB_PASSWORD":{"secretKeyRef":{"key":"password","name":"db-credentials"}},` +
+				`"DB_URL":{"secretKeyRef":{"key":"uri","name":"db-credentials"}}}`,
+		},
+		{
+			name: "credentials in an external secret store",
+			workload: workload(scorev1b1.ContainerSpec{Variables: map[string]string{
+				"DB_PASSWORD": "${resources.db.password}",
+			}}),
+			claims: claim(scorev1b1.ResourceClaimOutputs{ExternalSecretRef: &scorev1b1.ExternalSecretReference{
+				Store: "vault", Path: "score/default/app-db", Version: "2", Keys: []string{"password", "username"},
+			}}),
+			wantEnv: `{"DB_PASSWORD":{"secretKeyRef":{"key":"password","name":"app-db-external"}}}`,
+			wantExternal: `[{"keys":["password","username"],"name":"app-db-external",` +
+				`"path":"score/default/app-db","store":"vault","version":"2"}]`,
+		},
+		{
+			name: "embedded in a longer variable",
+			workload: workload(scorev1b1.ContainerSpec{Variables: map[string]string{
+				"DB_DSN": "host=db password=${resources.db.password}",
+			}}),
+			claims:   fromSecret,
+			wantPath: "containers.app.variables.DB_DSN",
+		},
+		{
+			name: "in file content",
+			workload: workload(scorev1b1.ContainerSpec{Files: []scorev1b1.FileSpec{
+				{Target: "/etc/app/db.conf", Content: ptr.To("password=${resources.db.password}")},
+			}}),
+			claims:   fromSecret,
+			wantPath: "containers.app.files[0].content",
+		},
+		{
+			name: "plaintext uri carrying a password",
+			workload: workload(scorev1b1.ContainerSpec{Variables: map[string]string{
+				"DB_URL": "${resources.db.uri}",
+			}}),
+			claims:   claim(scorev1b1.ResourceClaimOutputs{URI: ptr.To("postgres://app:s3cr3t@db:5432/app")}),
+			wantPath: "containers.app.variables.DB_URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(secret.DeepCopy()).Build()
+			resolvedValues, err := resolveAllPlaceholders(context.TODO(), c, tt.workload, tt.claims)
+
+			if tt.wantPath != "" {
+				var placeholderErr *PlaceholderError
+				if !errors.As(err, &placeholderErr) || placeholderErr.Path != tt.wantPath {
+					t.Fatalf("resolveAllPlaceholders() error = %v, want a placeholder error at %s", err, tt.wantPath)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveAllPlaceholders() error = %v", err)
+			}
+			if strings.Contains(string(resolvedValues.Raw), "s3cr3t") {
+				t.Fatalf("resolved values leak Secret data: %s", resolvedValues.Raw)
+			}
+
+			var values struct {
+				Containers map[string]struct {
+					Env json.RawMessage `json:"env"`
+				} `json:"containers"`
+				ExternalSecrets json.RawMessage `json:"externalSecrets"`
+			}
+			if err := json.Unmarshal(resolvedValues.Raw, &values); err != nil {
+				t.Fatalf("failed to unmarshal resolved values: %v", err)
+			}
+			if got := string(values.Containers["app"].Env); got != tt.wantEnv {
+				t.Errorf("env = %s, want %s", got, tt.wantEnv)
+			}
+			if got := string(values.ExternalSecrets); got != tt.wantExternal {
+				t.Errorf("externalSecrets = %s, want %s", got, tt.wantExternal)
+			}
+		})
+	}
+}
+
+func TestResolveServiceAccountName(t *testing.T) {
+	tests := []struct {
+		name           string
+		serviceAccount *scorev1b1.ServiceAccountSpec
+		want           string
+	}{
+		{"no service account", nil, ""},
+		{"defaults to workload name", &scorev1b1.ServiceAccountSpec{}, "web"},
+		{"explicit name", &scorev1b1.ServiceAccountSpec{Name: "web-reader", Create: ptr.To(false)}, "web-reader"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workload := &scorev1b1.Workload{
+				ObjectMeta: metav1.ObjectMeta{Name: "web"},
+				Spec: scorev1b1.WorkloadSpec{
+					Containers:     map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx"}},
+					ServiceAccount: tt.serviceAccount,
+				},
+			}
+
+			resolvedValues, err := resolveAllPlaceholders(context.TODO(), fake.NewClientBuilder().Build(), workload, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var values struct {
+				ServiceAccount *struct {
+					Name string `json:"name"`
+				} `json:"serviceAccount"`
+			}
+			if err := json.Unmarshal(resolvedValues.Raw, &values); err != nil {
+				t.Fatalf("failed to unmarshal resolved values: %v", err)
+			}
+
+			got := ""
+			if values.ServiceAccount != nil {
+				got = values.ServiceAccount.Name
+			}
+			if got != tt.want {
+				t.Errorf("serviceAccount.name = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveFilesAndAnnotations(t *testing.T) {
+	claims := []scorev1b1.ResourceClaim{
+		{
+			Spec: scorev1b1.ResourceClaimSpec{Key: "db"},
+			Status: scorev1b1.ResourceClaimStatus{
+				OutputsAvailable: true,
+				Outputs:          &scorev1b1.ResourceClaimOutputs{URI: ptr.To("postgres://db:5432/app")},
+			},
+		},
+	}
+
+	newWorkload := func(content, sourceURI, annotation string) *scorev1b1.Workload {
+		return &scorev1b1.Workload{
+			ObjectMeta: metav1.ObjectMeta{Name: "web"},
+			Spec: scorev1b1.WorkloadSpec{
+				Containers: map[string]scorev1b1.ContainerSpec{
+					"app": {
+						Image: "nginx",
+						Files: []scorev1b1.FileSpec{
+							{Target: "/etc/app/config.yaml", Mode: ptr.To("0644"), Content: ptr.To(content)},
+							{Target: "/etc/app/schema.sql", Source: &scorev1b1.FileSourceSpec{URI: sourceURI}},
+							{Target: "/etc/app/logo.png", BinaryContent: ptr.To("aGVsbG8=")},
+						},
+					},
+				},
+				ServiceAccount: &scorev1b1.ServiceAccountSpec{
+					Annotations: map[string]string{"example.com/database": annotation},
+				},
+			},
+		}
+	}
+
+	t.Run("resolves files and service account annotations", func(t *testing.T) {
+		workload := newWorkload("database: ${resources.db.outputs.uri}\n", "${resources.db.uri}/schema.sql", "${resources.db.uri}")
+		resolvedValues, err := resolveAllPlaceholders(context.TODO(), fake.NewClientBuilder().Build(), workload, claims)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var values struct {
+			Containers map[string]struct {
+				Files []struct {
+					Target        string `json:"target"`
+					Mode          string `json:"mode"`
+					Content       string `json:"content"`
+					BinaryContent string `json:"binaryContent"`
+					Source        *struct {
+						URI string `json:"uri"`
+					} `json:"source"`
+				} `json:"files"`
+			} `json:"containers"`
+			ServiceAccount struct {
+				Annotations map[string]string `json:"annotations"`
+			} `json:"serviceAccount"`
+		}
+		if err := json.Unmarshal(resolvedValues.Raw, &values); err != nil {
+			t.Fatalf("failed to unmarshal resolved values: %v", err)
+		}
+
+		files := values.Containers["app"].Files
+		if len(files) != 3 {
+			t.Fatalf("expected 3 files, got %d", len(files))
+		}
+		if files[0].Content != "database: postgres://db:5432/app\n" || files[0].Mode != "0644" {
+			t.Errorf("files[0] = %+v, want resolved content with mode 0644", files[0])
+		}
+		if files[1].Source == nil || files[1].Source.URI != "postgres://db:5432/app/schema.sql" {
+			t.Errorf("files[1].source = %+v, want resolved URI", files[1].Source)
+		}
+		if files[2].BinaryContent != "aGVsbG8=" {
+			t.Errorf("files[2].binaryContent = %q, want it passed through", files[2].BinaryContent)
+		}
+		if got := values.ServiceAccount.Annotations["example.com/database"]; got != "postgres://db:5432/app" {
+			t.Errorf("serviceAccount annotation = %q, want resolved URI", got)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		workload *scorev1b1.Workload
+		wantPath string
+	}{
+		{
+			"unresolved file content",
+			newWorkload("cache: ${resources.cache.outputs.uri}", "https://example.com/schema.sql", "static"),
+			"containers.app.files[0].content",
+		},
+		{
+			"unresolved file source",
+			newWorkload("static", "${resources.bucket.outputs.uri}/schema.sql", "static"),
+			"containers.app.files[1].source.uri",
+		},
+		{
+			"unresolved annotation",
+			newWorkload("static", "https://example.com/schema.sql", "${resources.role.outputs.arn}"),
+			"serviceAccount.annotations.example.com/database",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := resolveAllPlaceholders(context.TODO(), fake.NewClientBuilder().Build(), tt.workload, claims)
+			var placeholderErr *PlaceholderError
+			if !errors.As(err, &placeholderErr) {
+				t.Fatalf("expected a *PlaceholderError, got %v", err)
+			}
+			if placeholderErr.Path != tt.wantPath {
+				t.Errorf("error path = %q, want %q", placeholderErr.Path, tt.wantPath)
+			}
+
+			_, unresolved, err := resolvePlaceholders(context.TODO(), fake.NewClientBuilder().Build(), tt.workload, claims, true)
+			if err != nil {
+				t.Fatalf("lenient resolution failed: %v", err)
+			}
+			if len(unresolved) != 1 || unresolved[0] != tt.wantPath {
+				t.Errorf("unresolved = %v, want [%s]", unresolved, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestResolveNoExpandAndServicePorts(t *testing.T) {
+	claims := []scorev1b1.ResourceClaim{
+		{
+			Spec: scorev1b1.ResourceClaimSpec{Key: "db"},
+			Status: scorev1b1.ResourceClaimStatus{
+				OutputsAvailable: true,
+				Outputs:          &scorev1b1.ResourceClaimOutputs{URI: ptr.To("postgres://db:5432/app")},
+			},
+		},
+	}
+
+	newWorkload := func(targetPort intstr.IntOrString) *scorev1b1.Workload {
+		return &scorev1b1.Workload{
+			ObjectMeta: metav1.ObjectMeta{Name: "web"},
+			Spec: scorev1b1.WorkloadSpec{
+				Containers: map[string]scorev1b1.ContainerSpec{
+					"app": {
+						Image: "nginx",
+						Files: []scorev1b1.FileSpec{
+							{Target: "/etc/app/run.sh", Content: ptr.To("echo ${HOME} ${resources.cache.outputs.uri}"), NoExpand: true},
+						},
+					},
+				},
+				Service: &scorev1b1.ServiceSpec{Ports: []scorev1b1.ServicePort{
+					{Port: 80, TargetPort: &targetPort},
+					{Port: 9090, TargetPort: ptr.To(intstr.FromString("metrics"))},
+					{Port: 443},
+				}},
+			},
+		}
+	}
+
+	t.Run("keeps noExpand content and resolves target ports", func(t *testing.T) {
+		workload := newWorkload(intstr.FromString("${resources.app.outputs.port:-8080}"))
+		resolvedValues, err := resolveAllPlaceholders(context.TODO(), fake.NewClientBuilder().Build(), workload, claims)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var values struct {
+			Containers map[string]struct {
+				Files []struct {
+					Content string `json:"content"`
+				} `json:"files"`
+			} `json:"containers"`
+			Service struct {
+				Ports []struct {
+					Port       int32              `json:"port"`
+					TargetPort intstr.IntOrString `json:"targetPort"`
+				} `json:"ports"`
+			} `json:"service"`
+		}
+		if err := json.Unmarshal(resolvedValues.Raw, &values); err != nil {
+			t.Fatalf("failed to unmarshal resolved values: %v", err)
+		}
+
+		if got := values.Containers["app"].Files[0].Content; got != "echo ${HOME} ${resources.cache.outputs.uri}" {
+			t.Errorf("noExpand content = %q, want it verbatim", got)
+		}
+		want := []intstr.IntOrString{intstr.FromInt32(8080), intstr.FromString("metrics"), intstr.FromInt32(443)}
+		if len(values.Service.Ports) != len(want) {
+			t.Fatalf("expected %d service ports, got %d", len(want), len(values.Service.Ports))
+		}
+		for i, port := range values.Service.Ports {
+			if port.TargetPort != want[i] {
+				t.Errorf("service.ports[%d].targetPort = %s, want %s", i, port.TargetPort.String(), want[i].String())
+			}
+		}
+	})
+
+	t.Run("rejects a target port that does not resolve to a port", func(t *testing.T) {
+		workload := newWorkload(intstr.FromString("${resources.db.outputs.uri}"))
+		_, err := resolveAllPlaceholders(context.TODO(), fake.NewClientBuilder().Build(), workload, claims)
+		var placeholderErr *PlaceholderError
+		if !errors.As(err, &placeholderErr) {
+			t.Fatalf("expected a *PlaceholderError, got %v", err)
+		}
+		if placeholderErr.Path != "service.ports[0].targetPort" {
+			t.Errorf("error path = %q, want %q", placeholderErr.Path, "service.ports[0].targetPort")
+		}
+	})
+}
+
+func TestResolveVolumeSources(t *testing.T) {
+	claims := []scorev1b1.ResourceClaim{
+		{
+			Spec: scorev1b1.ResourceClaimSpec{Key: "data"},
+			Status: scorev1b1.ResourceClaimStatus{
+				OutputsAvailable: true,
+				Outputs: &scorev1b1.ResourceClaimOutputs{PVCRef: &scorev1b1.PersistentVolumeClaimOutput{
+					Name: "web-data-volume", StorageClass: "ssd", Capacity: "10Gi",
+				}},
+			},
+		},
+	}
+	newWorkload := func(source string) *scorev1b1.Workload {
+		return &scorev1b1.Workload{
+			ObjectMeta: metav1.ObjectMeta{Name: "web"},
+			Spec: scorev1b1.WorkloadSpec{
+				Containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx"}},
+				Storage: &scorev1b1.StorageSpec{Volumes: []scorev1b1.PersistentVolumeSpec{
+					{Name: "cache", Size: "1Gi", Target: "/cache"},
+					{Name: "data", Source: source, Target: "/data"},
+				}},
+			},
+		}
+	}
+
+	t.Run("resolves the claim of shared volumes", func(t *testing.T) {
+		resolvedValues, err := resolveAllPlaceholders(context.TODO(), fake.NewClientBuilder().Build(), newWorkload("${resources.data.outputs.pvcRef}"), claims)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var values struct {
+			Storage struct {
+				Volumes []map[string]string `json:"volumes"`
+			} `json:"storage"`
+		}
+		if err := json.Unmarshal(resolvedValues.Raw, &values); err != nil {
+			t.Fatalf("failed to unmarshal resolved values: %v", err)
+		}
+		if len(values.Storage.Volumes) != 1 || values.Storage.Volumes[0]["name"] != "data" || values.Storage.Volumes[0]["source"] != "web-data-volume" {
+			t.Errorf("storage.volumes = %v, want data from web-data-volume", values.Storage.Volumes)
+		}
+	})
+
+	t.Run("rejects a source that is not a claim name", func(t *testing.T) {
+		_, err := resolveAllPlaceholders(context.TODO(), fake.NewClientBuilder().Build(), newWorkload("${resources.data.outputs.capacity}"), claims)
+		var placeholderErr *PlaceholderError
+		if !errors.As(err, &placeholderErr) {
+			t.Fatalf("expected a *PlaceholderError, got %v", err)
+		}
+		if placeholderErr.Path != "storage.volumes[1].source" {
+			t.Errorf("error path = %q, want %q", placeholderErr.Path, "storage.volumes[1].source")
+		}
+	})
+}
+
+func TestResolveContainerCommands(t *testing.T) {
+	claims := []scorev1b1.ResourceClaim{
+		{
+			Spec: scorev1b1.ResourceClaimSpec{Key: "db"},
+			Status: scorev1b1.ResourceClaimStatus{
+				OutputsAvailable: true,
+				Outputs:          &scorev1b1.ResourceClaimOutputs{URI: ptr.To("postgres://db:5432/app")},
+			},
+		},
+	}
+	newWorkload := func(container scorev1b1.ContainerSpec) *scorev1b1.Workload {
+		container.Image = "app"
+		return &scorev1b1.Workload{
+			ObjectMeta: metav1.ObjectMeta{Name: "web"},
+			Spec:       scorev1b1.WorkloadSpec{Containers: map[string]scorev1b1.ContainerSpec{"app": container}},
+		}
+	}
+
+	t.Run("resolves the command, args and working directory", func(t *testing.T) {
+		workload := newWorkload(scorev1b1.ContainerSpec{
+			Command:    []string{"/bin/migrate"},
+			Args:       []string{"--database", "${resources.db.outputs.uri}"},
+			WorkingDir: "/srv",
+		})
+		resolvedValues, err := resolveAllPlaceholders(context.TODO(), fake.NewClientBuilder().Build(), workload, claims)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var values struct {
+			Containers map[string]struct {
+				Command    []string `json:"command"`
+				Args       []string `json:"args"`
+				WorkingDir string   `json:"workingDir"`
+			} `json:"containers"`
+		}
+		if err := json.Unmarshal(resolvedValues.Raw, &values); err != nil {
+			t.Fatalf("failed to unmarshal resolved values: %v", err)
+		}
+		app := values.Containers["app"]
+		if !reflect.DeepEqual(app.Command, []string{"/bin/migrate"}) || app.WorkingDir != "/srv" {
+			t.Errorf("command = %v, workingDir = %q, want [/bin/migrate] and /srv", app.Command, app.WorkingDir)
+		}
+		if want := []string{"--database", "postgres://db:5432/app"}; !reflect.DeepEqual(app.Args, want) {
+			t.Errorf("args = %v, want %v", app.Args, want)
+		}
+	})
+
+	t.Run("reports the path of unresolved arguments", func(t *testing.T) {
+		workload := newWorkload(scorev1b1.ContainerSpec{Args: []string{"serve", "${resources.cache.outputs.host}"}})
+		_, err := resolveAllPlaceholders(context.TODO(), fake.NewClientBuilder().Build(), workload, claims)
+		var placeholderErr *PlaceholderError
+		if !errors.As(err, &placeholderErr) {
+			t.Fatalf("expected a *PlaceholderError, got %v", err)
+		}
+		if placeholderErr.Path != "containers.app.args[1]" {
+			t.Errorf("error path = %q, want %q", placeholderErr.Path, "containers.app.args[1]")
+		}
+	})
+}
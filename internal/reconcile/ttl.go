@@ -0,0 +1,45 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+// ExpiresAt returns when a Workload annotated with score.dev/ttl expires: its creation time plus the TTL.
+// It returns nil if the Workload has no TTL, and an error if the annotation is not a positive duration.
+func ExpiresAt(workload *scorev1b1.Workload) (*metav1.Time, error) {
+	value, ok := workload.Annotations[meta.AnnotationTTL]
+	if !ok {
+		return nil, nil
+	}
+	ttl, err := time.ParseDuration(value)
+	if err != nil {
+		return nil, fmt.Errorf("annotation %s must be a duration such as \"72h\", got %q", meta.AnnotationTTL, value)
+	}
+	if ttl <= 0 {
+		return nil, fmt.Errorf("annotation %s must be a positive duration, got %q", meta.AnnotationTTL, value)
+	}
+	expiresAt := metav1.NewTime(workload.CreationTimestamp.Add(ttl))
+	return &expiresAt, nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+func TestExpiresAt(t *testing.T) {
+	created := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        *time.Time
+		wantErr     bool
+	}{
+		{
+			name: "no ttl",
+		},
+		{
+			name:        "ttl from creation",
+			annotations: map[string]string{meta.AnnotationTTL: "72h"},
+			want:        ptr.To(created.Add(72 * time.Hour)),
+		},
+		{
+			name:        "not a duration",
+			annotations: map[string]string{meta.AnnotationTTL: "3d"},
+			wantErr:     true,
+		},
+		{
+			name:        "not positive",
+			annotations: map[string]string{meta.AnnotationTTL: "0s"},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workload := &scorev1b1.Workload{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created), Annotations: tt.annotations},
+			}
+			got, err := ExpiresAt(workload)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ExpiresAt() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("ExpiresAt() = %v, want nil", got)
+			case tt.want != nil && (got == nil || !got.Time.Equal(*tt.want)):
+				t.Errorf("ExpiresAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -51,7 +51,7 @@ func composeValues(
 	result := mergeMaps(defaultsMap, normalizedMap, outputsMap)
 
 	// Convert back to RawExtension
-	resultBytes, err := json.Marshal(result)
+	resultBytes, err := canonicalJSON(result)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal composed values: %w", err)
 	}
@@ -182,6 +182,10 @@ func extractOutputs(claims []scorev1b1.ResourceClaim) map[string]interface{} {
 			resourceOutputs["image"] = *claim.Status.Outputs.Image
 		}
 
+		if claim.Status.Outputs.Hostname != nil {
+			resourceOutputs["hostname"] = *claim.Status.Outputs.Hostname
+		}
+
 		if claim.Status.Outputs.Cert != nil {
 			certMap := make(map[string]interface{})
 			if claim.Status.Outputs.Cert.SecretName != nil {
@@ -193,6 +197,14 @@ func extractOutputs(claims []scorev1b1.ResourceClaim) map[string]interface{} {
 			resourceOutputs["cert"] = certMap
 		}
 
+		if pvcRef := claim.Status.Outputs.PVCRef; pvcRef != nil {
+			resourceOutputs["pvcRef"] = map[string]interface{}{
+				"name":         pvcRef.Name,
+				"storageClass": pvcRef.StorageClass,
+				"capacity":     pvcRef.Capacity,
+			}
+		}
+
 		if len(resourceOutputs) > 0 {
 			resources[claim.Spec.Key] = map[string]interface{}{
 				"outputs": resourceOutputs,
@@ -0,0 +1,115 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// applyValuesFrom writes the claim outputs routed by the valuesFrom mappings of the selected backend into
+// the given values. Mappings of resources the Workload does not declare are skipped. Outputs read from
+// Secret data are never inlined, so mapping them is reported as a *PlaceholderError like any output
+// that is not available.
+func applyValuesFrom(ctx context.Context, c client.Client, values *runtime.RawExtension, workload *scorev1b1.Workload, claims []scorev1b1.ResourceClaim, valuesFrom []scorev1b1.ValuesFromSpec) (*runtime.RawExtension, error) {
+	if len(valuesFrom) == 0 {
+		return values, nil
+	}
+
+	valuesMap := make(map[string]interface{})
+	if values != nil && len(values.Raw) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(values.Raw))
+		decoder.UseNumber()
+		if err := decoder.Decode(&valuesMap); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal values: %w", err)
+		}
+	}
+
+	availableOutputs, publicOutputs, _ := buildResolvedOutputsMap(ctx, c, claims)
+	for i, mapping := range valuesFrom {
+		resource, declared := workload.Spec.Resources[mapping.Claim]
+		if !declared {
+			continue
+		}
+		// Mappings of optional resources apply once they are bound
+		if _, available := availableOutputs[mapping.Claim]; !available && resource.Optional {
+			continue
+		}
+		source := fmt.Sprintf("valuesFrom[%d]", i)
+		placeholder := fmt.Sprintf("${resources.%s.outputs.%s}", mapping.Claim, mapping.Output)
+		value, err := resolvePlaceholder(placeholder, availableOutputs)
+		if err != nil {
+			var placeholderErr *PlaceholderError
+			if errors.As(err, &placeholderErr) {
+				placeholderErr.Path = source
+			}
+			return nil, err
+		}
+		if public, err := resolvePlaceholder(placeholder, publicOutputs); err != nil || public != value {
+			return nil, &PlaceholderError{Path: source, Placeholder: placeholder,
+				Reason: "references a sensitive output, which cannot be routed to template values"}
+		}
+		if err := setValuesPath(valuesMap, mapping.Path, value); err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+	}
+
+	data, err := canonicalJSON(valuesMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal values: %w", err)
+	}
+	return &runtime.RawExtension{Raw: data}, nil
+}
+
+// setValuesPath sets the value at a dot-separated path (e.g., ".db.connectionString"), creating the
+// intermediate objects. A path cannot replace an object or descend into a value that is not an object.
+func setValuesPath(values map[string]interface{}, path, value string) error {
+	segments := strings.Split(strings.TrimPrefix(path, "."), ".")
+	current := values
+	for i, segment := range segments {
+		if segment == "" {
+			return fmt.Errorf("invalid values path %q: empty key", path)
+		}
+		if i == len(segments)-1 {
+			if _, isObject := current[segment].(map[string]interface{}); isObject {
+				return fmt.Errorf("values path %q would replace an object", path)
+			}
+			current[segment] = value
+			return nil
+		}
+		switch next := current[segment].(type) {
+		case map[string]interface{}:
+			current = next
+		case nil:
+			child := make(map[string]interface{})
+			current[segment] = child
+			current = child
+		default:
+			return fmt.Errorf("values path %q descends into %q, which is not an object", path, strings.Join(segments[:i+1], "."))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestApplyValuesFrom(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "web-cache", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("s3cr3t")},
+	}
+	claims := []scorev1b1.ResourceClaim{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+			Spec:       scorev1b1.ResourceClaimSpec{Key: "db"},
+			Status: scorev1b1.ResourceClaimStatus{
+				OutputsAvailable: true,
+				Outputs:          &scorev1b1.ResourceClaimOutputs{URI: ptr.To("postgres://db:5432/app")},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default"},
+			Spec:       scorev1b1.ResourceClaimSpec{Key: "cache"},
+			Status: scorev1b1.ResourceClaimStatus{
+				OutputsAvailable: true,
+				Outputs:          &scorev1b1.ResourceClaimOutputs{SecretRef: &scorev1b1.LocalObjectReference{Name: "web-cache"}},
+			},
+		},
+	}
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: scorev1b1.WorkloadSpec{
+			Resources: map[string]scorev1b1.ResourceSpec{
+				"db":     {Type: "postgres"},
+				"cache":  {Type: "redis"},
+				"search": {Type: "elasticsearch", Optional: true},
+			},
+		},
+	}
+	base := `{"containers":{"app":{"image":"nginx"}},"replicas":2}`
+
+	tests := []struct {
+		name       string
+		valuesFrom []scorev1b1.ValuesFromSpec
+		want       string
+		wantErr    bool
+	}{
+		{
+			name: "no mappings",
+			want: base,
+		},
+		{
+			name:       "routes an output to a nested path",
+			valuesFrom: []scorev1b1.ValuesFromSpec{{Claim: "db", Output: "uri", Path: ".db.connectionString"}},
+			want:       `{"containers":{"app":{"image":"nginx"}},"db":{"connectionString":"postgres://db:5432/app"},"replicas":2}`,
+		},
+		{
+			name:       "skips resources the workload does not declare",
+			valuesFrom: []scorev1b1.ValuesFromSpec{{Claim: "queue", Output: "uri", Path: ".queue.url"}},
+			want:       base,
+		},
+		{
+			name:       "skips optional resources that are not bound",
+			valuesFrom: []scorev1b1.ValuesFromSpec{{Claim: "search", Output: "uri", Path: ".search.url"}},
+			want:       base,
+		},
+		{
+			name:       "missing output",
+			valuesFrom: []scorev1b1.ValuesFromSpec{{Claim: "db", Output: "host", Path: ".db.host"}},
+			wantErr:    true,
+		},
+		{
+			name:       "sensitive output",
+			valuesFrom: []scorev1b1.ValuesFromSpec{{Claim: "cache", Output: "password", Path: ".cache.password"}},
+			wantErr:    true,
+		},
+		{
+			name:       "replaces an object",
+			valuesFrom: []scorev1b1.ValuesFromSpec{{Claim: "db", Output: "uri", Path: ".containers.app"}},
+			wantErr:    true,
+		},
+		{
+			name:       "descends into a scalar",
+			valuesFrom: []scorev1b1.ValuesFromSpec{{Claim: "db", Output: "uri", Path: ".replicas.db"}},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := fake.NewClientBuilder().WithObjects(secret.DeepCopy()).Build()
+			values, err := applyValuesFrom(context.TODO(), c, &runtime.RawExtension{Raw: []byte(base)}, workload, claims, tt.valuesFrom)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("applyValuesFrom() = %s, want an error", values.Raw)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("applyValuesFrom() error = %v", err)
+			}
+			if string(values.Raw) != tt.want {
+				t.Errorf("applyValuesFrom() = %s, want %s", values.Raw, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyValuesFromReportsPlaceholderErrors(t *testing.T) {
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{Resources: map[string]scorev1b1.ResourceSpec{"db": {Type: "postgres"}}},
+	}
+	valuesFrom := []scorev1b1.ValuesFromSpec{{Claim: "db", Output: "uri", Path: ".db.url"}}
+
+	_, err := applyValuesFrom(context.TODO(), fake.NewClientBuilder().Build(), nil, workload, nil, valuesFrom)
+	var placeholderErr *PlaceholderError
+	if !errors.As(err, &placeholderErr) || placeholderErr.Path != "valuesFrom[0]" {
+		t.Errorf("applyValuesFrom() error = %v, want a placeholder error at valuesFrom[0]", err)
+	}
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/valuesschema"
+)
+
+// ValidateTemplateValues composes the template values of the workload (template defaults, the normalized
+// Workload, claim outputs and the outputs routed by valuesFrom) and validates them against the values
+// schema of the template.
+// Values that violate the schema are reported as a *valuesschema.ValidationError naming the JSON pointers
+// of the offending values. Templates without a schema accept any values.
+func ValidateTemplateValues(ctx context.Context, c client.Client, workload *scorev1b1.Workload, claims []scorev1b1.ResourceClaim, template *scorev1b1.TemplateSpec, valuesFrom []scorev1b1.ValuesFromSpec) error {
+	if template == nil || template.ValuesSchema == nil {
+		return nil
+	}
+
+	schema, err := loadValuesSchema(ctx, c, template.ValuesSchema)
+	if err != nil {
+		return err
+	}
+
+	values, err := composeValues(template.Values, workload, claims)
+	if err != nil {
+		return err
+	}
+	if values, err = applyValuesFrom(ctx, c, values, workload, claims, valuesFrom); err != nil {
+		return err
+	}
+
+	if err := schema.Validate(values.Raw); err != nil {
+		return fmt.Errorf("template values: %w", err)
+	}
+	return nil
+}
+
+// loadValuesSchema compiles the inline schema or the schema held by the referenced ConfigMap key
+func loadValuesSchema(ctx context.Context, c client.Client, spec *scorev1b1.ValuesSchemaSpec) (*valuesschema.Schema, error) {
+	if spec.Inline != nil {
+		schema, err := valuesschema.Compile(spec.Inline.Raw)
+		if err != nil {
+			return nil, fmt.Errorf("invalid inline values schema: %w", err)
+		}
+		return schema, nil
+	}
+	if spec.Ref == nil {
+		return nil, fmt.Errorf("values schema sets neither inline nor ref")
+	}
+
+	ref := spec.Ref
+	configMap := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, configMap); err != nil {
+		return nil, fmt.Errorf("failed to get values schema ConfigMap %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	document, ok := configMap.Data[ref.Key]
+	if !ok {
+		return nil, fmt.Errorf("values schema ConfigMap %s/%s has no key %q", ref.Namespace, ref.Name, ref.Key)
+	}
+
+	// The document may be JSON or YAML
+	data, err := yaml.YAMLToJSON([]byte(document))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse values schema %s/%s[%s]: %w", ref.Namespace, ref.Name, ref.Key, err)
+	}
+	schema, err := valuesschema.Compile(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid values schema %s/%s[%s]: %w", ref.Namespace, ref.Name, ref.Key, err)
+	}
+	return schema, nil
+}
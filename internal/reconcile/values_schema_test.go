@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/valuesschema"
+)
+
+func TestValidateTemplateValues(t *testing.T) {
+	const schema = `{
+		"type": "object",
+		"required": ["replicas"],
+		"properties": {
+			"replicas": {"type": "integer", "minimum": 1},
+			"containers": {"additionalProperties": {"properties": {"image": {"pattern": "@sha256:"}}}}
+		}
+	}`
+	const yamlSchema = `
+type: object
+required: [replicas]
+properties:
+  replicas: {type: integer, minimum: 1}
+`
+	schemas := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "schemas", Namespace: "score-system"},
+		Data:       map[string]string{"web.yaml": yamlSchema},
+	}
+	c := fake.NewClientBuilder().WithObjects(schemas).Build()
+
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"main": {Image: "nginx:latest"}},
+		},
+	}
+	inline := func(schema string) *scorev1b1.ValuesSchemaSpec {
+		return &scorev1b1.ValuesSchemaSpec{Inline: &runtime.RawExtension{Raw: []byte(schema)}}
+	}
+	ref := func(key string) *scorev1b1.ValuesSchemaSpec {
+		return &scorev1b1.ValuesSchemaSpec{Ref: &scorev1b1.ValuesSchemaRef{Namespace: "score-system", Name: "schemas", Key: key}}
+	}
+
+	tests := []struct {
+		name     string
+		defaults string
+		schema   *scorev1b1.ValuesSchemaSpec
+		want     []string
+		wantErr  bool
+	}{
+		{name: "no schema", defaults: `{"replicas":0}`},
+		{
+			name:     "bad default and workload value",
+			defaults: `{"replicas":0}`,
+			schema:   inline(schema),
+			want:     []string{"/containers/main/image", "/replicas"},
+		},
+		{name: "missing default", defaults: `{}`, schema: ref("web.yaml"), want: []string{"/replicas"}},
+		{name: "valid defaults", defaults: `{"replicas":2}`, schema: ref("web.yaml")},
+		{name: "missing key", defaults: `{"replicas":2}`, schema: ref("missing"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			template := &scorev1b1.TemplateSpec{
+				Kind:         "manifests",
+				Ref:          "registry.example.com/web:1",
+				Values:       &runtime.RawExtension{Raw: []byte(tt.defaults)},
+				ValuesSchema: tt.schema,
+			}
+			err := ValidateTemplateValues(context.Background(), c, workload, nil, template, nil)
+
+			var schemaErr *valuesschema.ValidationError
+			switch {
+			case tt.wantErr:
+				if err == nil || errors.As(err, &schemaErr) {
+					t.Fatalf("ValidateTemplateValues() error = %v, want a schema loading error", err)
+				}
+			case tt.want == nil:
+				if err != nil {
+					t.Fatalf("ValidateTemplateValues() error = %v, want nil", err)
+				}
+			default:
+				if !errors.As(err, &schemaErr) {
+					t.Fatalf("ValidateTemplateValues() error = %v, want a *valuesschema.ValidationError", err)
+				}
+				var paths []string
+				for _, violation := range schemaErr.Violations {
+					paths = append(paths, violation.Path)
+				}
+				if !reflect.DeepEqual(paths, tt.want) {
+					t.Errorf("violation paths = %v, want %v", paths, tt.want)
+				}
+			}
+		})
+	}
+}
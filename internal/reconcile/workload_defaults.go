@@ -0,0 +1,121 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// EffectiveWorkload returns the workload with the workload defaults of its profile merged into its spec.
+// Values declared by the workload take precedence. The workload itself is returned when the fragment
+// changes nothing; otherwise a copy is returned and the workload is left untouched.
+func EffectiveWorkload(workload *scorev1b1.Workload, fragment *scorev1b1.WorkloadFragmentSpec) *scorev1b1.Workload {
+	if fragment == nil {
+		return workload
+	}
+
+	effective := workload.DeepCopy()
+	if len(fragment.Containers) > 0 && effective.Spec.Containers == nil {
+		effective.Spec.Containers = make(map[string]scorev1b1.ContainerSpec, len(fragment.Containers))
+	}
+	for name, container := range fragment.Containers {
+		if _, ok := effective.Spec.Containers[name]; !ok {
+			effective.Spec.Containers[name] = *container.DeepCopy()
+		}
+	}
+
+	for name, container := range effective.Spec.Containers {
+		for key, value := range fragment.Variables {
+			if _, ok := container.Variables[key]; ok {
+				continue
+			}
+			if container.Variables == nil {
+				container.Variables = make(map[string]string, len(fragment.Variables))
+			}
+			container.Variables[key] = value
+		}
+		container.Resources = mergeContainerResources(container.Resources, fragment.Resources)
+		effective.Spec.Containers[name] = container
+	}
+
+	if reflect.DeepEqual(effective.Spec, workload.Spec) {
+		return workload
+	}
+	return effective
+}
+
+// mergeContainerResources sets the default requests and limits for every resource the container declares
+// neither a request nor a limit for
+func mergeContainerResources(declared, defaults *scorev1b1.ResourceRequirements) *scorev1b1.ResourceRequirements {
+	if defaults == nil {
+		return declared
+	}
+	merged := declared.DeepCopy()
+	if merged == nil {
+		merged = &scorev1b1.ResourceRequirements{}
+	}
+	isDeclared := func(name string) bool {
+		if declared == nil {
+			return false
+		}
+		_, request := declared.Requests[name]
+		_, limit := declared.Limits[name]
+		return request || limit
+	}
+
+	for name, value := range defaults.Requests {
+		if isDeclared(name) {
+			continue
+		}
+		if merged.Requests == nil {
+			merged.Requests = make(map[string]string, len(defaults.Requests))
+		}
+		merged.Requests[name] = value
+	}
+	for name, value := range defaults.Limits {
+		if isDeclared(name) {
+			continue
+		}
+		if merged.Limits == nil {
+			merged.Limits = make(map[string]string, len(defaults.Limits))
+		}
+		merged.Limits[name] = value
+	}
+
+	if declared == nil && merged.Requests == nil && merged.Limits == nil {
+		return nil
+	}
+	return merged
+}
+
+// workloadSnapshot returns the spec of the effective workload for the plan to carry, or nil when no
+// workload defaults were merged into it and runtimes can read the Workload itself
+func workloadSnapshot(workload, effective *scorev1b1.Workload) (*runtime.RawExtension, error) {
+	if effective == workload {
+		return nil, nil
+	}
+	data, err := canonicalJSON(effective.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal effective workload spec: %w", err)
+	}
+	return &runtime.RawExtension{Raw: data}, nil
+}
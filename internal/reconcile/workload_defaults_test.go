@@ -0,0 +1,152 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reconcile
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestEffectiveWorkload(t *testing.T) {
+	fragment := &scorev1b1.WorkloadFragmentSpec{
+		Containers: map[string]scorev1b1.ContainerSpec{
+			"proxy": {Image: "envoy:v1", Variables: map[string]string{"LOG_LEVEL": "warn"}},
+		},
+		Variables: map[string]string{"LOG_LEVEL": "info", "OTEL_ENDPOINT": "http://collector:4317"},
+		Resources: &scorev1b1.ResourceRequirements{
+			Requests: map[string]string{"cpu": "100m", "memory": "128Mi"},
+			Limits:   map[string]string{"memory": "256Mi"},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		containers map[string]scorev1b1.ContainerSpec
+		fragment   *scorev1b1.WorkloadFragmentSpec
+		want       map[string]scorev1b1.ContainerSpec
+	}{
+		{
+			name:       "no fragment",
+			containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "app:v1"}},
+			want:       map[string]scorev1b1.ContainerSpec{"app": {Image: "app:v1"}},
+		},
+		{
+			name:       "fragment merged",
+			containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "app:v1"}},
+			fragment:   fragment,
+			want: map[string]scorev1b1.ContainerSpec{
+				"app": {
+					Image:     "app:v1",
+					Variables: map[string]string{"LOG_LEVEL": "info", "OTEL_ENDPOINT": "http://collector:4317"},
+					Resources: &scorev1b1.ResourceRequirements{
+						Requests: map[string]string{"cpu": "100m", "memory": "128Mi"},
+						Limits:   map[string]string{"memory": "256Mi"},
+					},
+				},
+				"proxy": {
+					Image:     "envoy:v1",
+					Variables: map[string]string{"LOG_LEVEL": "warn", "OTEL_ENDPOINT": "http://collector:4317"},
+					Resources: &scorev1b1.ResourceRequirements{
+						Requests: map[string]string{"cpu": "100m", "memory": "128Mi"},
+						Limits:   map[string]string{"memory": "256Mi"},
+					},
+				},
+			},
+		},
+		{
+			name: "workload values take precedence",
+			containers: map[string]scorev1b1.ContainerSpec{
+				"app": {
+					Image:     "app:v1",
+					Variables: map[string]string{"LOG_LEVEL": "debug"},
+					Resources: &scorev1b1.ResourceRequirements{Limits: map[string]string{"memory": "1Gi"}},
+				},
+				"proxy": {Image: "envoy:v2"},
+			},
+			fragment: fragment,
+			want: map[string]scorev1b1.ContainerSpec{
+				"app": {
+					Image:     "app:v1",
+					Variables: map[string]string{"LOG_LEVEL": "debug", "OTEL_ENDPOINT": "http://collector:4317"},
+					Resources: &scorev1b1.ResourceRequirements{
+						Requests: map[string]string{"cpu": "100m"},
+						Limits:   map[string]string{"memory": "1Gi"},
+					},
+				},
+				"proxy": {
+					Image:     "envoy:v2",
+					Variables: map[string]string{"LOG_LEVEL": "info", "OTEL_ENDPOINT": "http://collector:4317"},
+					Resources: &scorev1b1.ResourceRequirements{
+						Requests: map[string]string{"cpu": "100m", "memory": "128Mi"},
+						Limits:   map[string]string{"memory": "256Mi"},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workload := &scorev1b1.Workload{Spec: scorev1b1.WorkloadSpec{Containers: tt.containers}}
+			original := workload.DeepCopy()
+
+			got := EffectiveWorkload(workload, tt.fragment)
+			if !reflect.DeepEqual(got.Spec.Containers, tt.want) {
+				t.Errorf("EffectiveWorkload() containers = %+v, want %+v", got.Spec.Containers, tt.want)
+			}
+			if !reflect.DeepEqual(workload, original) {
+				t.Errorf("EffectiveWorkload() modified the workload: %+v", workload.Spec)
+			}
+		})
+	}
+}
+
+func TestWorkloadSnapshot(t *testing.T) {
+	workload := &scorev1b1.Workload{Spec: scorev1b1.WorkloadSpec{
+		Containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "app:v1"}},
+	}}
+
+	// A fragment that changes nothing leaves the runtimes reading the Workload itself
+	unchanged := EffectiveWorkload(workload, &scorev1b1.WorkloadFragmentSpec{
+		Containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "other:v1"}},
+	})
+	snapshot, err := workloadSnapshot(workload, unchanged)
+	if err != nil {
+		t.Fatalf("workloadSnapshot() error = %v", err)
+	}
+	if snapshot != nil {
+		t.Errorf("workloadSnapshot() = %s, want nil", snapshot.Raw)
+	}
+
+	effective := EffectiveWorkload(workload, &scorev1b1.WorkloadFragmentSpec{
+		Containers: map[string]scorev1b1.ContainerSpec{"proxy": {Image: "envoy:v1"}},
+	})
+	snapshot, err = workloadSnapshot(workload, effective)
+	if err != nil {
+		t.Fatalf("workloadSnapshot() error = %v", err)
+	}
+	var spec scorev1b1.WorkloadSpec
+	if err := json.Unmarshal(snapshot.Raw, &spec); err != nil {
+		t.Fatalf("failed to unmarshal snapshot: %v", err)
+	}
+	if !reflect.DeepEqual(spec, effective.Spec) {
+		t.Errorf("workloadSnapshot() = %+v, want %+v", spec, effective.Spec)
+	}
+}
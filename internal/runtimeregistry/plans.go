@@ -0,0 +1,123 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtimeregistry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+// PlanHeartbeatTimeout is how long a WorkloadPlan may go without an acknowledgment, or without a renewed
+// heartbeat, before its runtime is considered unresponsive
+const PlanHeartbeatTimeout = 5 * time.Minute
+
+// PlanHeartbeatDeadline returns when the runtime of the plan becomes unresponsive unless it renews its
+// heartbeat. Plans that were never acknowledged are given PlanHeartbeatTimeout from their creation.
+// The zero time is returned for plans not yet persisted.
+func PlanHeartbeatDeadline(plan *scorev1b1.WorkloadPlan) time.Time {
+	if ack := plan.Status.Acknowledgment; ack != nil {
+		return ack.LastHeartbeat.Add(PlanHeartbeatTimeout)
+	}
+	if plan.CreationTimestamp.IsZero() {
+		return time.Time{}
+	}
+	return plan.CreationTimestamp.Add(PlanHeartbeatTimeout)
+}
+
+// PlanUnresponsive reports whether the runtime of the plan missed its heartbeat deadline at now,
+// along with a message telling a runtime that never acknowledged the plan from one that stopped
+func PlanUnresponsive(plan *scorev1b1.WorkloadPlan, now time.Time) (bool, string) {
+	deadline := PlanHeartbeatDeadline(plan)
+	if deadline.IsZero() || now.Before(deadline) {
+		return false, ""
+	}
+	ack := plan.Status.Acknowledgment
+	if ack == nil {
+		return true, fmt.Sprintf("No %s runtime has acknowledged the plan within %s", plan.Spec.RuntimeClass, PlanHeartbeatTimeout)
+	}
+	return true, fmt.Sprintf("Runtime %s has not sent a heartbeat since %s",
+		ack.ConsumedBy, ack.LastHeartbeat.UTC().Format(time.RFC3339))
+}
+
+// PlanHeartbeat acknowledges the WorkloadPlans of a runtimeClass and renews their heartbeat until the
+// context is cancelled. It is meant to be added to the runtime's controller manager. Plans delivered to
+// another cluster are acknowledged by the runtime of that cluster.
+type PlanHeartbeat struct {
+	Client client.Client
+	// RuntimeClass of the plans acknowledged
+	RuntimeClass string
+	// ConsumedBy and ConsumerVersion identify the runtime in the acknowledgment
+	ConsumedBy      string
+	ConsumerVersion string
+}
+
+// Start renews the heartbeats every third of PlanHeartbeatTimeout until ctx is cancelled
+func (h *PlanHeartbeat) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("plan-heartbeat")
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if err := h.renew(ctx, time.Now()); err != nil {
+			logger.Error(err, "Failed to renew WorkloadPlan heartbeats", "runtimeClass", h.RuntimeClass)
+		}
+	}, PlanHeartbeatTimeout/3)
+	return nil
+}
+
+// NeedLeaderElection ensures only the active runtime replica renews the heartbeats
+func (h *PlanHeartbeat) NeedLeaderElection() bool {
+	return true
+}
+
+// renew sets the acknowledgment of every plan of the runtimeClass with now as its heartbeat.
+// Failures to patch individual plans are retried on the next renewal.
+func (h *PlanHeartbeat) renew(ctx context.Context, now time.Time) error {
+	plans := &scorev1b1.WorkloadPlanList{}
+	if err := h.Client.List(ctx, plans, client.MatchingLabels{meta.LabelRuntimeClass: h.RuntimeClass}); err != nil {
+		return fmt.Errorf("failed to list WorkloadPlans: %w", err)
+	}
+
+	var failed int
+	for i := range plans.Items {
+		plan := &plans.Items[i]
+		if plan.Spec.RuntimeClass != h.RuntimeClass || plan.Spec.Target != "" || !plan.DeletionTimestamp.IsZero() {
+			continue
+		}
+		original := plan.DeepCopy()
+		plan.Status.Acknowledgment = &scorev1b1.WorkloadPlanAcknowledgment{
+			ConsumedBy:      h.ConsumedBy,
+			ConsumerVersion: h.ConsumerVersion,
+			LastHeartbeat:   metav1.NewTime(now),
+		}
+		if err := h.Client.Status().Patch(ctx, plan, client.MergeFrom(original)); client.IgnoreNotFound(err) != nil {
+			log.FromContext(ctx).V(1).Info("Failed to renew WorkloadPlan heartbeat",
+				"workloadplan", client.ObjectKeyFromObject(plan), "error", err.Error())
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("failed to renew the heartbeat of %d of %d WorkloadPlans", failed, len(plans.Items))
+	}
+	return nil
+}
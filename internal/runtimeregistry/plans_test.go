@@ -0,0 +1,109 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtimeregistry
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+func TestPlanUnresponsive(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	acknowledged := func(age time.Duration) *scorev1b1.WorkloadPlanAcknowledgment {
+		return &scorev1b1.WorkloadPlanAcknowledgment{ConsumedBy: "kubernetes", LastHeartbeat: metav1.NewTime(now.Add(-age))}
+	}
+
+	tests := []struct {
+		name        string
+		created     time.Time
+		ack         *scorev1b1.WorkloadPlanAcknowledgment
+		want        bool
+		wantMessage string
+	}{
+		{name: "plan not persisted"},
+		{name: "new plan awaiting acknowledgment", created: now.Add(-time.Minute)},
+		{name: "plan never acknowledged", created: now.Add(-time.Hour), want: true, wantMessage: "No kubernetes runtime has acknowledged"},
+		{name: "recent heartbeat", created: now.Add(-time.Hour), ack: acknowledged(time.Minute)},
+		{name: "stale heartbeat", created: now.Add(-time.Hour), ack: acknowledged(10 * time.Minute), want: true, wantMessage: "has not sent a heartbeat since"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &scorev1b1.WorkloadPlan{
+				ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(tt.created)},
+				Spec:       scorev1b1.WorkloadPlanSpec{RuntimeClass: "kubernetes"},
+				Status:     scorev1b1.WorkloadPlanStatus{Acknowledgment: tt.ack},
+			}
+			got, message := PlanUnresponsive(plan, now)
+			if got != tt.want || !strings.Contains(message, tt.wantMessage) {
+				t.Errorf("PlanUnresponsive() = %v, %q, want %v containing %q", got, message, tt.want, tt.wantMessage)
+			}
+		})
+	}
+}
+
+func TestPlanHeartbeatAcknowledgesPlans(t *testing.T) {
+	s := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(s); err != nil {
+		t.Fatal(err)
+	}
+	plan := func(name, runtimeClass, target string) *scorev1b1.WorkloadPlan {
+		return &scorev1b1.WorkloadPlan{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{meta.LabelRuntimeClass: runtimeClass}},
+			Spec:       scorev1b1.WorkloadPlanSpec{RuntimeClass: runtimeClass, Target: target},
+		}
+	}
+	c := fake.NewClientBuilder().WithScheme(s).
+		WithObjects(plan("local", "kubernetes", ""), plan("delivered", "kubernetes", "edge"), plan("other", "docker", "")).
+		WithStatusSubresource(&scorev1b1.WorkloadPlan{}).
+		Build()
+	heartbeat := &PlanHeartbeat{Client: c, RuntimeClass: "kubernetes", ConsumedBy: "kubernetes", ConsumerVersion: "v1.2.3"}
+
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := heartbeat.renew(context.Background(), now); err != nil {
+		t.Fatalf("renew() error = %v", err)
+	}
+
+	for name, wantAck := range map[string]bool{"local": true, "delivered": false, "other": false} {
+		got := &scorev1b1.WorkloadPlan{}
+		if err := c.Get(context.Background(), client.ObjectKey{Namespace: "default", Name: name}, got); err != nil {
+			t.Fatal(err)
+		}
+		ack := got.Status.Acknowledgment
+		if (ack != nil) != wantAck {
+			t.Errorf("plan %s acknowledgment = %+v, want acknowledged %v", name, ack, wantAck)
+			continue
+		}
+		if ack != nil && (ack.ConsumerVersion != "v1.2.3" || !ack.LastHeartbeat.Time.Equal(now)) {
+			t.Errorf("plan %s acknowledgment = %+v, want version v1.2.3 renewed at %s", name, ack, now)
+		}
+	}
+}
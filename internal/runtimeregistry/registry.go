@@ -0,0 +1,231 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package runtimeregistry lets runtime controllers announce the runtimeClass they serve.
+// A runtime registers itself with a ConfigMap labeled LabelRegistration and renews its heartbeat
+// periodically; the Orchestrator only selects backends whose runtimeClass has a live registration.
+package runtimeregistry
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// LabelRegistration marks the ConfigMaps that carry runtime registrations
+const LabelRegistration = "score.dev/runtime-registration"
+
+// Data keys of a registration ConfigMap
+const (
+	keyRuntimeClass  = "runtimeClass"
+	keyVersion       = "version"
+	keyFeatures      = "features"
+	keyMissing       = "missingCapabilities"
+	keyRenewTime     = "renewTime"
+	keyLeaseDuration = "leaseDuration"
+)
+
+// DefaultLeaseDuration is how long a registration stays live without being renewed
+const DefaultLeaseDuration = 90 * time.Second
+
+// Registration describes a runtime controller and the capabilities it offers
+type Registration struct {
+	// RuntimeClass is the runtime class served, matching backends[].runtimeClass
+	RuntimeClass string
+	// Version is the version of the runtime controller
+	Version string
+	// Features lists the optional capabilities the runtime supports
+	Features []string
+	// MissingCapabilities lists the capabilities the runtime disabled because the cluster does not serve their API
+	MissingCapabilities []string
+	// RenewTime is when the runtime last confirmed it is running
+	RenewTime time.Time
+	// LeaseDuration is how long the registration stays live after RenewTime
+	LeaseDuration time.Duration
+}
+
+// Live reports whether the registration was renewed within its lease duration
+func (r Registration) Live(now time.Time) bool {
+	return now.Before(r.RenewTime.Add(r.LeaseDuration))
+}
+
+// ConfigMap renders the registration as a ConfigMap with the given name and namespace
+func (r Registration) ConfigMap(namespace, name string) *corev1.ConfigMap {
+	cm := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "ConfigMap"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+			Labels:    map[string]string{LabelRegistration: "true"},
+		},
+		Data: map[string]string{
+			keyRuntimeClass:  r.RuntimeClass,
+			keyVersion:       r.Version,
+			keyFeatures:      strings.Join(r.Features, ","),
+			keyRenewTime:     r.RenewTime.UTC().Format(time.RFC3339),
+			keyLeaseDuration: r.LeaseDuration.String(),
+		},
+	}
+	if len(r.MissingCapabilities) > 0 {
+		cm.Data[keyMissing] = strings.Join(r.MissingCapabilities, ",")
+	}
+	return cm
+}
+
+// FromConfigMap parses a registration ConfigMap
+func FromConfigMap(cm *corev1.ConfigMap) (Registration, error) {
+	r := Registration{
+		RuntimeClass: cm.Data[keyRuntimeClass],
+		Version:      cm.Data[keyVersion],
+	}
+	if r.RuntimeClass == "" {
+		return Registration{}, fmt.Errorf("registration %s/%s has no %s", cm.Namespace, cm.Name, keyRuntimeClass)
+	}
+	r.Features = splitList(cm.Data[keyFeatures])
+	r.MissingCapabilities = splitList(cm.Data[keyMissing])
+
+	renewTime, err := time.Parse(time.RFC3339, cm.Data[keyRenewTime])
+	if err != nil {
+		return Registration{}, fmt.Errorf("registration %s/%s has an invalid %s: %w", cm.Namespace, cm.Name, keyRenewTime, err)
+	}
+	r.RenewTime = renewTime
+
+	r.LeaseDuration = DefaultLeaseDuration
+	if value := cm.Data[keyLeaseDuration]; value != "" {
+		if r.LeaseDuration, err = time.ParseDuration(value); err != nil {
+			return Registration{}, fmt.Errorf("registration %s/%s has an invalid %s: %w", cm.Namespace, cm.Name, keyLeaseDuration, err)
+		}
+	}
+	return r, nil
+}
+
+// splitList splits a comma-separated list, dropping empty items
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// list returns the well-formed registrations in the cluster. Malformed registrations are skipped.
+func list(ctx context.Context, c client.Reader) ([]Registration, error) {
+	var configMaps corev1.ConfigMapList
+	if err := c.List(ctx, &configMaps, client.MatchingLabels{LabelRegistration: "true"}); err != nil {
+		return nil, fmt.Errorf("failed to list runtime registrations: %w", err)
+	}
+
+	registrations := make([]Registration, 0, len(configMaps.Items))
+	for i := range configMaps.Items {
+		registration, err := FromConfigMap(&configMaps.Items[i])
+		if err != nil {
+			log.FromContext(ctx).V(1).Info("Ignoring malformed runtime registration", "error", err.Error())
+			continue
+		}
+		registrations = append(registrations, registration)
+	}
+	return registrations, nil
+}
+
+// LiveRuntimeClasses lists the registrations in the cluster and returns the live ones keyed by runtimeClass.
+// Malformed registrations are skipped. When several runtimes register the same class, the most recently
+// renewed registration is returned.
+func LiveRuntimeClasses(ctx context.Context, c client.Reader, now time.Time) (map[string]Registration, error) {
+	registrations, err := list(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	live := make(map[string]Registration)
+	for _, registration := range registrations {
+		if !registration.Live(now) {
+			continue
+		}
+		if current, ok := live[registration.RuntimeClass]; !ok || registration.RenewTime.After(current.RenewTime) {
+			live[registration.RuntimeClass] = registration
+		}
+	}
+	return live, nil
+}
+
+// LatestRegistrations returns the most recently renewed registration of every runtimeClass that has
+// registered, whether live or not, so that runtimes which stopped renewing can be reported
+func LatestRegistrations(ctx context.Context, c client.Reader) (map[string]Registration, error) {
+	registrations, err := list(ctx, c)
+	if err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]Registration)
+	for _, registration := range registrations {
+		if current, ok := latest[registration.RuntimeClass]; !ok || registration.RenewTime.After(current.RenewTime) {
+			latest[registration.RuntimeClass] = registration
+		}
+	}
+	return latest, nil
+}
+
+// Heartbeat registers a runtime and renews its registration until the context is cancelled.
+// It is meant to be added to the runtime's controller manager.
+type Heartbeat struct {
+	Client client.Client
+	// Namespace and Name of the registration ConfigMap
+	Namespace string
+	Name      string
+	// Registration to publish; RenewTime is set on every renewal and LeaseDuration defaults to DefaultLeaseDuration
+	Registration Registration
+	// FieldManager used to apply the registration
+	FieldManager string
+}
+
+// Start renews the registration every third of its lease duration until ctx is cancelled
+func (h *Heartbeat) Start(ctx context.Context) error {
+	registration := h.Registration
+	if registration.LeaseDuration <= 0 {
+		registration.LeaseDuration = DefaultLeaseDuration
+	}
+	features := slices.Clone(registration.Features)
+	slices.Sort(features)
+	registration.Features = features
+	missing := slices.Clone(registration.MissingCapabilities)
+	slices.Sort(missing)
+	registration.MissingCapabilities = missing
+
+	logger := log.FromContext(ctx).WithName("runtime-registration")
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		registration.RenewTime = time.Now()
+		cm := registration.ConfigMap(h.Namespace, h.Name)
+		if err := h.Client.Patch(ctx, cm, client.Apply, client.FieldOwner(h.FieldManager), client.ForceOwnership); err != nil {
+			logger.Error(err, "Failed to renew runtime registration", "runtimeClass", registration.RuntimeClass)
+		}
+	}, registration.LeaseDuration/3)
+	return nil
+}
+
+// NeedLeaderElection ensures only the active runtime replica renews the registration
+func (h *Heartbeat) NeedLeaderElection() bool {
+	return true
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runtimeregistry
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// applyAsCreateOrUpdate emulates server-side apply, which the fake client does not support,
+// by creating the applied object or replacing the existing one. Other patches pass through.
+func applyAsCreateOrUpdate(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+
+	existing, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", obj)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		return c.Create(ctx, obj)
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return c.Update(ctx, obj)
+}
+
+func TestRegistrationRoundTrip(t *testing.T) {
+	want := Registration{
+		RuntimeClass:        "kubernetes",
+		Version:             "v1.2.3",
+		Features:            []string{"CronJob", "Service"},
+		MissingCapabilities: []string{"ingress"},
+		RenewTime:           time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		LeaseDuration:       2 * time.Minute,
+	}
+
+	got, err := FromConfigMap(want.ConfigMap("score-system", "score-runtime-kubernetes"))
+	if err != nil {
+		t.Fatalf("FromConfigMap() error = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromConfigMap() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFromConfigMapErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		data map[string]string
+	}{
+		{"missing runtimeClass", map[string]string{keyRenewTime: "2025-01-02T03:04:05Z"}},
+		{"invalid renewTime", map[string]string{keyRuntimeClass: "kubernetes", keyRenewTime: "yesterday"}},
+		{"invalid leaseDuration", map[string]string{
+			keyRuntimeClass: "kubernetes", keyRenewTime: "2025-01-02T03:04:05Z", keyLeaseDuration: "long",
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := FromConfigMap(&corev1.ConfigMap{Data: tt.data}); err == nil {
+				t.Error("FromConfigMap() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestLiveRuntimeClasses(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	registration := func(runtimeClass, version string, age time.Duration) Registration {
+		return Registration{RuntimeClass: runtimeClass, Version: version, RenewTime: now.Add(-age), LeaseDuration: time.Minute}
+	}
+	malformed := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Name: "broken", Namespace: "ns", Labels: map[string]string{LabelRegistration: "true"},
+	}}
+	unlabeled := registration("unlabeled", "v1", 0).ConfigMap("ns", "unlabeled")
+	unlabeled.Labels = nil
+
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects(
+		registration("kubernetes", "v1", 30*time.Second).ConfigMap("ns", "k8s-old"),
+		registration("kubernetes", "v2", 10*time.Second).ConfigMap("ns", "k8s-new"),
+		registration("ecs", "v1", 2*time.Minute).ConfigMap("ns", "ecs"),
+		malformed,
+		unlabeled,
+	).Build()
+
+	live, err := LiveRuntimeClasses(context.Background(), c, now)
+	if err != nil {
+		t.Fatalf("LiveRuntimeClasses() error = %v", err)
+	}
+	if len(live) != 1 {
+		t.Fatalf("LiveRuntimeClasses() returned %d classes, want 1: %+v", len(live), live)
+	}
+	if got := live["kubernetes"].Version; got != "v2" {
+		t.Errorf("kubernetes registration version = %q, want the most recently renewed %q", got, "v2")
+	}
+}
+
+func TestHeartbeatRegistersRuntime(t *testing.T) {
+	var applies atomic.Int32
+	c := fake.NewClientBuilder().WithScheme(scheme.Scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			if patch.Type() == types.ApplyPatchType {
+				applies.Add(1)
+			}
+			return applyAsCreateOrUpdate(ctx, c, obj, patch, opts...)
+		},
+	}).Build()
+	heartbeat := &Heartbeat{
+		Client:       c,
+		Namespace:    "ns",
+		Name:         "score-runtime-kubernetes",
+		Registration: Registration{RuntimeClass: "kubernetes", Features: []string{"Service", "Job"}},
+		FieldManager: "test",
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- heartbeat.Start(ctx) }()
+
+	var cm corev1.ConfigMap
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		err := c.Get(context.Background(), client.ObjectKey{Namespace: "ns", Name: heartbeat.Name}, &cm)
+		if err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("registration was not created: %v", err)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	if applies.Load() == 0 {
+		t.Error("registration was not written with server-side apply")
+	}
+
+	registration, err := FromConfigMap(&cm)
+	if err != nil {
+		t.Fatalf("FromConfigMap() error = %v", err)
+	}
+	if !registration.Live(time.Now()) {
+		t.Errorf("registration renewed at %s is not live", registration.RenewTime)
+	}
+	if want := []string{"Job", "Service"}; !reflect.DeepEqual(registration.Features, want) {
+		t.Errorf("Features = %v, want %v", registration.Features, want)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// field describes one field of a standard cron expression
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+	// anyDay allows "?" as an alias of "*"
+	anyDay bool
+}
+
+// fields lists the five fields of a standard cron expression in order
+var fields = []field{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31, anyDay: true},
+	{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}},
+	{name: "day of week", min: 0, max: 6, anyDay: true, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}},
+}
+
+// descriptors are the predefined schedules accepted in place of the five fields
+var descriptors = map[string]bool{
+	"@yearly": true, "@annually": true, "@monthly": true, "@weekly": true,
+	"@daily": true, "@midnight": true, "@hourly": true,
+}
+
+// Validate checks that expr is a schedule accepted by Kubernetes CronJobs: five space-separated
+// fields (minute, hour, day of month, month, day of week) or a descriptor such as @daily or @every 1h.
+// Time zones are configured separately and may not be embedded with a TZ= or CRON_TZ= prefix.
+func Validate(expr string) error {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return fmt.Errorf("schedule must not be empty")
+	}
+	if strings.HasPrefix(expr, "TZ=") || strings.HasPrefix(expr, "CRON_TZ=") {
+		return fmt.Errorf("schedule must not specify a time zone")
+	}
+
+	if strings.HasPrefix(expr, "@") {
+		if every, ok := strings.CutPrefix(expr, "@every "); ok {
+			if d, err := time.ParseDuration(strings.TrimSpace(every)); err != nil || d <= 0 {
+				return fmt.Errorf("invalid duration in %q", expr)
+			}
+			return nil
+		}
+		if !descriptors[expr] {
+			return fmt.Errorf("unknown schedule descriptor %q", expr)
+		}
+		return nil
+	}
+
+	parts := strings.Fields(expr)
+	if len(parts) != len(fields) {
+		return fmt.Errorf("expected %d fields (minute hour day-of-month month day-of-week), found %d", len(fields), len(parts))
+	}
+	for i, part := range parts {
+		if err := fields[i].validate(part); err != nil {
+			return fmt.Errorf("invalid %s %q: %w", fields[i].name, part, err)
+		}
+	}
+	return nil
+}
+
+// validate checks a comma-separated list of values, ranges and steps
+func (f field) validate(value string) error {
+	for _, item := range strings.Split(value, ",") {
+		rangePart, step, hasStep := strings.Cut(item, "/")
+		if hasStep {
+			if n, err := strconv.Atoi(step); err != nil || n <= 0 {
+				return fmt.Errorf("step %q must be a positive integer", step)
+			}
+		}
+
+		if rangePart == "*" || (rangePart == "?" && f.anyDay) {
+			continue
+		}
+		low, high, isRange := strings.Cut(rangePart, "-")
+		lowValue, err := f.parse(low)
+		if err != nil {
+			return err
+		}
+		highValue := lowValue
+		if isRange {
+			if highValue, err = f.parse(high); err != nil {
+				return err
+			}
+			if highValue < lowValue {
+				return fmt.Errorf("range %q is reversed", rangePart)
+			}
+		}
+	}
+	return nil
+}
+
+// parse converts a number or name into a value within the field bounds
+func (f field) parse(value string) (int, error) {
+	if n, ok := f.names[strings.ToLower(value)]; ok {
+		return n, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", value)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%d is out of range [%d, %d]", n, f.min, f.max)
+	}
+	return n, nil
+}
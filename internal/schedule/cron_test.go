@@ -0,0 +1,56 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import "testing"
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		expr    string
+		wantErr bool
+	}{
+		{"0 3 * * *", false},
+		{"*/15 * * * *", false},
+		{"0 9-17/2 * * MON-FRI", false},
+		{"30 4 1,15 jan,jul ?", false},
+		{"@daily", false},
+		{"@every 90m", false},
+		{"", true},
+		{"0 3 * *", true},
+		{"0 3 * * * *", true},
+		{"60 * * * *", true},
+		{"0 24 * * *", true},
+		{"0 0 0 * *", true},
+		{"0 0 * 13 *", true},
+		{"0 0 * * 7", true},
+		{"? * * * *", true},
+		{"*/0 * * * *", true},
+		{"0 17-9 * * *", true},
+		{"0 3 * * funday", true},
+		{"@fortnightly", true},
+		{"@every soon", true},
+		{"TZ=Europe/Berlin 0 3 * * *", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			if err := Validate(tt.expr); (err != nil) != tt.wantErr {
+				t.Errorf("Validate(%q) error = %v, wantErr %v", tt.expr, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,338 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selection
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/runtimeregistry"
+)
+
+// Profile sources reported in an Explanation, in pipeline order
+const (
+	// ProfileSourceHint means the profile came from spec.profile or the score.dev/profile annotation
+	ProfileSourceHint = "hint"
+	// ProfileSourceDerived means the profile was derived from workload characteristics
+	ProfileSourceDerived = "auto-derivation"
+	// ProfileSourceSelector means the profile came from a matching defaults.selectors[] entry
+	ProfileSourceSelector = "selector"
+	// ProfileSourceDefault means the profile is the global defaults.profile
+	ProfileSourceDefault = "default"
+)
+
+// Explanation describes how the selection pipeline evaluated a workload
+type Explanation struct {
+	// ProfileHint is the value of spec.profile or the score.dev/profile annotation, if any
+	ProfileHint string
+	// DerivedProfile is the profile inferred from workload characteristics, if any
+	DerivedProfile string
+	// MatchedSelector is the index of the first matching defaults.selectors[] entry, or -1
+	MatchedSelector int
+	// SelectorProfile is the profile of the matching selector, if any
+	SelectorProfile string
+	// DefaultProfile is the global defaults.profile
+	DefaultProfile string
+
+	// Profile is the profile the pipeline settled on, and ProfileSource the step that decided it
+	Profile       string
+	ProfileSource string
+
+	// Features are the capabilities detected on the workload, sorted
+	Features []string
+	// Region is the workload region taken from its labels or the cluster nodes, empty if unknown
+	Region string
+
+	// Backends lists every backend of the selected profile in selection order:
+	// accepted candidates first (ranked), followed by rejected backends
+	Backends []BackendEvaluation
+
+	// RecordedBackend is the backend of the Workload's status.binding, empty if unbound
+	RecordedBackend string
+	// BindingKept is true if the recorded backend was kept by the reselection policy
+	BindingKept bool
+
+	// Selected is the ID of the winning backend, empty if none
+	Selected string
+	// Error describes why selection failed, empty on success
+	Error string
+}
+
+// BackendEvaluation describes how a single backend was evaluated
+type BackendEvaluation struct {
+	BackendID string
+	Priority  int
+	Version   string
+	// Rank is the 1-based position among accepted candidates, or 0 if rejected
+	Rank int
+	// Rejection is the reason the backend was filtered out, empty if accepted
+	Rejection string
+}
+
+// Explain runs the selection pipeline for the workload without side effects and
+// reports every decision it took, so platform teams can see why a backend was chosen.
+// A recorded status.binding is honored the same way the controller honors it.
+// The cluster Node region fallback and namespace selectors are evaluated only when k8sClient is non-nil.
+func Explain(ctx context.Context, k8sClient client.Client, workload *scorev1b1.Workload, config *scorev1b1.OrchestratorConfig) *Explanation {
+	s := &profileSelector{config: config, client: k8sClient}
+	explanation := &Explanation{
+		ProfileHint:     ProfileHint(workload),
+		DerivedProfile:  s.deriveProfileFromWorkload(workload),
+		MatchedSelector: -1,
+		DefaultProfile:  config.Spec.Defaults.Profile,
+	}
+	if workload.Status.Binding != nil {
+		explanation.RecordedBackend = workload.Status.Binding.BackendID
+	}
+
+	workloadLabels := workload.Labels
+	if workloadLabels == nil {
+		workloadLabels = make(map[string]string)
+	}
+	for i, selector := range config.Spec.Defaults.Selectors {
+		if s.selectorMatches(selector, workloadLabels) && selector.Profile != "" {
+			explanation.MatchedSelector = i
+			explanation.SelectorProfile = selector.Profile
+			break
+		}
+	}
+
+	for feature, enabled := range s.getWorkloadFeatures(workload) {
+		if enabled {
+			explanation.Features = append(explanation.Features, feature)
+		}
+	}
+	sort.Strings(explanation.Features)
+	explanation.Region = s.resolveRegion(ctx, workload)
+
+	// Profile selection, mirroring selectProfile
+	profileName, err := s.selectProfile(workload)
+	if err != nil {
+		explanation.Error = err.Error()
+		return explanation
+	}
+	explanation.Profile = profileName
+	switch {
+	case explanation.ProfileHint != "":
+		explanation.ProfileSource = ProfileSourceHint
+	case explanation.DerivedProfile != "":
+		explanation.ProfileSource = ProfileSourceDerived
+	case explanation.SelectorProfile != "":
+		explanation.ProfileSource = ProfileSourceSelector
+	default:
+		explanation.ProfileSource = ProfileSourceDefault
+	}
+
+	var backends []scorev1b1.BackendSpec
+	for _, profile := range config.Spec.Profiles {
+		if profile.Name == profileName {
+			backends = profile.Backends
+			break
+		}
+	}
+
+	// Backend filtering, starting with the namespace restrictions
+	var rejected []BackendEvaluation
+	namespaceLabels, err := s.namespaceLabels(ctx, workload.Namespace, backends)
+	if err != nil {
+		explanation.Error = err.Error()
+		return explanation
+	}
+	allowed, namespaceRejections := filterByNamespace(workload.Namespace, namespaceLabels, backends)
+	for _, backend := range backends {
+		if reason, ok := namespaceRejections[backend.BackendId]; ok {
+			rejected = append(rejected, BackendEvaluation{
+				BackendID: backend.BackendId,
+				Priority:  backend.Priority,
+				Version:   backend.Version,
+				Rejection: reason,
+			})
+		}
+	}
+	if len(backends) > 0 && len(allowed) == 0 {
+		explanation.Error = fmt.Sprintf("%v: no backend of profile %q is allowed in namespace %q", ErrBackendFiltered, profileName, workload.Namespace)
+		explanation.Backends = rejected
+		return explanation
+	}
+
+	var candidates []scorev1b1.BackendSpec
+	for _, backend := range allowed {
+		if reason := s.rejectBackend(logr.Discard(), workload, workloadLabels, backend); reason != "" {
+			rejected = append(rejected, BackendEvaluation{
+				BackendID: backend.BackendId,
+				Priority:  backend.Priority,
+				Version:   backend.Version,
+				Rejection: reason,
+			})
+			continue
+		}
+		candidates = append(candidates, backend)
+	}
+
+	candidates, regionRejections := filterByRegion(explanation.Region, candidates)
+	for _, backend := range backends {
+		if reason, ok := regionRejections[backend.BackendId]; ok {
+			rejected = append(rejected, BackendEvaluation{
+				BackendID: backend.BackendId,
+				Priority:  backend.Priority,
+				Version:   backend.Version,
+				Rejection: reason,
+			})
+		}
+	}
+
+	if len(candidates) == 0 {
+		explanation.Error = fmt.Sprintf("%v: no suitable backend candidates found for profile %q", ErrNoBackendAvailable, profileName)
+		explanation.Backends = rejected
+		return explanation
+	}
+
+	if s.requireRuntimeRegistration() {
+		live, err := runtimeregistry.LiveRuntimeClasses(ctx, k8sClient, time.Now())
+		if err != nil {
+			explanation.Error = err.Error()
+			explanation.Backends = rejected
+			return explanation
+		}
+		var runtimeRejections map[string]string
+		candidates, runtimeRejections = filterByRuntime(live, candidates)
+		for _, backend := range backends {
+			if reason, ok := runtimeRejections[backend.BackendId]; ok {
+				rejected = append(rejected, BackendEvaluation{
+					BackendID: backend.BackendId,
+					Priority:  backend.Priority,
+					Version:   backend.Version,
+					Rejection: reason,
+				})
+			}
+		}
+		if len(candidates) == 0 {
+			explanation.Error = fmt.Sprintf("%v: no live runtime is registered for the backends of profile %q", ErrRuntimeUnavailable, profileName)
+			explanation.Backends = rejected
+			return explanation
+		}
+	}
+
+	// Backend selection ranks the candidates in place
+	explanation.Selected = s.selectBackend(candidates).BackendId
+
+	// The recorded backend wins while the reselection policy keeps it and it is still a candidate
+	if KeepBinding(config.Spec.Defaults.ReselectionPolicy, workload.Status.Binding, ConfigHash(config)) {
+		for _, candidate := range candidates {
+			if candidate.BackendId == explanation.RecordedBackend {
+				explanation.Selected = candidate.BackendId
+				explanation.BindingKept = true
+				break
+			}
+		}
+	}
+	for i, candidate := range candidates {
+		explanation.Backends = append(explanation.Backends, BackendEvaluation{
+			BackendID: candidate.BackendId,
+			Priority:  candidate.Priority,
+			Version:   candidate.Version,
+			Rank:      i + 1,
+		})
+	}
+	explanation.Backends = append(explanation.Backends, rejected...)
+
+	return explanation
+}
+
+// Summary renders the explanation as a single line suitable for events and logs
+func (e *Explanation) Summary() string {
+	var parts []string
+	if e.Profile != "" {
+		parts = append(parts, fmt.Sprintf("profile %s (%s)", e.Profile, e.ProfileSource))
+	}
+	for _, backend := range e.Backends {
+		if backend.Rejection != "" {
+			parts = append(parts, fmt.Sprintf("%s rejected: %s", backend.BackendID, backend.Rejection))
+		} else {
+			parts = append(parts, fmt.Sprintf("%s ranked #%d (priority %d, version %s)", backend.BackendID, backend.Rank, backend.Priority, backend.Version))
+		}
+	}
+	if e.BindingKept {
+		parts = append(parts, "kept recorded "+e.Selected)
+	} else if e.Selected != "" {
+		parts = append(parts, "selected "+e.Selected)
+	}
+	if e.Error != "" {
+		parts = append(parts, e.Error)
+	}
+	return strings.Join(parts, "; ")
+}
+
+// String renders the explanation as a multi-line report
+func (e *Explanation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Profile hint:     %s\n", valueOrNone(e.ProfileHint))
+	fmt.Fprintf(&b, "Derived profile:  %s\n", valueOrNone(e.DerivedProfile))
+	if e.MatchedSelector >= 0 {
+		fmt.Fprintf(&b, "Selector match:   defaults.selectors[%d] -> %s\n", e.MatchedSelector, e.SelectorProfile)
+	} else {
+		fmt.Fprintf(&b, "Selector match:   %s\n", valueOrNone(""))
+	}
+	fmt.Fprintf(&b, "Default profile:  %s\n", valueOrNone(e.DefaultProfile))
+	if e.Profile != "" {
+		fmt.Fprintf(&b, "Selected profile: %s (%s)\n", e.Profile, e.ProfileSource)
+	}
+	fmt.Fprintf(&b, "Features:         %s\n", valueOrNone(strings.Join(e.Features, ", ")))
+	fmt.Fprintf(&b, "Region:           %s\n", valueOrNone(e.Region))
+	if len(e.Backends) > 0 {
+		b.WriteString("Backends:\n")
+		for _, backend := range e.Backends {
+			if backend.Rejection != "" {
+				fmt.Fprintf(&b, "  - %s: rejected, %s\n", backend.BackendID, backend.Rejection)
+			} else {
+				fmt.Fprintf(&b, "  - %s: #%d (priority %d, version %s)\n", backend.BackendID, backend.Rank, backend.Priority, backend.Version)
+			}
+		}
+	}
+	if e.RecordedBackend != "" {
+		outcome := "reselected"
+		switch {
+		case e.BindingKept:
+			outcome = "kept"
+		case e.Selected == "":
+			outcome = "not a candidate"
+		}
+		fmt.Fprintf(&b, "Recorded backend: %s (%s)\n", e.RecordedBackend, outcome)
+	}
+	if e.Selected != "" {
+		fmt.Fprintf(&b, "Selected backend: %s\n", e.Selected)
+	}
+	if e.Error != "" {
+		fmt.Fprintf(&b, "Error:            %s\n", e.Error)
+	}
+	return b.String()
+}
+
+// valueOrNone returns value, or a placeholder if it is empty
+func valueOrNone(value string) string {
+	if value == "" {
+		return "<none>"
+	}
+	return value
+}
@@ -0,0 +1,157 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selection
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+var _ = Describe("Explain", func() {
+	var config *scorev1b1.OrchestratorConfig
+
+	BeforeEach(func() {
+		config = &scorev1b1.OrchestratorConfig{
+			Spec: scorev1b1.OrchestratorConfigSpec{
+				Profiles: []scorev1b1.ProfileSpec{
+					{
+						Name: "web-service",
+						Backends: []scorev1b1.BackendSpec{
+							{BackendId: "k8s-web", Priority: 100, Version: "1.0.0"},
+							{BackendId: "k8s-web-next", Priority: 100, Version: "2.0.0"},
+							{
+								BackendId: "k8s-web-canary",
+								Priority:  200,
+								Version:   "1.0.0",
+								Constraints: &scorev1b1.ConstraintsSpec{
+									Selectors: []scorev1b1.SelectorSpec{{MatchLabels: map[string]string{"canary": "true"}}},
+								},
+							},
+						},
+					},
+					{
+						Name: "edge",
+						Backends: []scorev1b1.BackendSpec{
+							{
+								BackendId:   "edge-fn",
+								Priority:    100,
+								Version:     "1.0.0",
+								Constraints: &scorev1b1.ConstraintsSpec{Features: []string{"scale-to-zero"}},
+							},
+						},
+					},
+				},
+				Defaults: scorev1b1.DefaultsSpec{
+					Profile: "web-service",
+					Selectors: []scorev1b1.SelectorSpec{
+						{MatchLabels: map[string]string{"tier": "edge"}, Profile: "edge"},
+					},
+				},
+			},
+		}
+	})
+
+	It("should rank candidates and report rejected backends", func() {
+		workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+
+		explanation := Explain(context.Background(), nil, workload, config)
+
+		Expect(explanation.Error).To(BeEmpty())
+		Expect(explanation.Profile).To(Equal("web-service"))
+		Expect(explanation.ProfileSource).To(Equal(ProfileSourceDefault))
+		Expect(explanation.Selected).To(Equal("k8s-web-next"))
+		Expect(explanation.Backends).To(Equal([]BackendEvaluation{
+			{BackendID: "k8s-web-next", Priority: 100, Version: "2.0.0", Rank: 1},
+			{BackendID: "k8s-web", Priority: 100, Version: "1.0.0", Rank: 2},
+			{BackendID: "k8s-web-canary", Priority: 200, Version: "1.0.0", Rejection: rejectedBySelectors},
+		}))
+		Expect(explanation.Summary()).To(ContainSubstring("k8s-web-canary rejected"))
+	})
+
+	It("should explain why no backend is available", func() {
+		workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{
+			Name:      "app",
+			Namespace: "default",
+			Labels:    map[string]string{"tier": "edge"},
+		}}
+
+		explanation := Explain(context.Background(), nil, workload, config)
+
+		Expect(explanation.MatchedSelector).To(Equal(0))
+		Expect(explanation.Profile).To(Equal("edge"))
+		Expect(explanation.ProfileSource).To(Equal(ProfileSourceSelector))
+		Expect(explanation.Selected).To(BeEmpty())
+		Expect(explanation.Error).To(ContainSubstring(ErrNoBackendAvailable.Error()))
+		Expect(explanation.Backends).To(HaveLen(1))
+		Expect(explanation.Backends[0].Rejection).To(HavePrefix(rejectedByFeatures))
+	})
+
+	It("should report an invalid profile hint", func() {
+		workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{
+			Name:        "app",
+			Namespace:   "default",
+			Annotations: map[string]string{"score.dev/profile": "missing"},
+		}}
+
+		explanation := Explain(context.Background(), nil, workload, config)
+
+		Expect(explanation.ProfileHint).To(Equal("missing"))
+		Expect(explanation.Profile).To(BeEmpty())
+		Expect(explanation.Error).To(ContainSubstring(ErrProfileNotFound.Error()))
+	})
+
+	It("should keep the recorded backend under the sticky policy", func() {
+		workload := &scorev1b1.Workload{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Status: scorev1b1.WorkloadStatus{
+				Binding: &scorev1b1.WorkloadBinding{Profile: "web-service", BackendID: "k8s-web", ConfigHash: "stale"},
+			},
+		}
+
+		explanation := Explain(context.Background(), nil, workload, config)
+
+		Expect(explanation.RecordedBackend).To(Equal("k8s-web"))
+		Expect(explanation.BindingKept).To(BeTrue())
+		Expect(explanation.Selected).To(Equal("k8s-web"))
+		Expect(explanation.Backends[0].BackendID).To(Equal("k8s-web-next"))
+		Expect(explanation.String()).To(ContainSubstring("Recorded backend: k8s-web (kept)"))
+	})
+
+	It("should reselect when reselect-on-change sees a configuration change", func() {
+		config.Spec.Defaults.ReselectionPolicy = scorev1b1.ReselectionPolicyReselectOnChange
+		workload := &scorev1b1.Workload{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Status: scorev1b1.WorkloadStatus{
+				Binding: &scorev1b1.WorkloadBinding{Profile: "web-service", BackendID: "k8s-web", ConfigHash: "stale"},
+			},
+		}
+
+		explanation := Explain(context.Background(), nil, workload, config)
+		Expect(explanation.BindingKept).To(BeFalse())
+		Expect(explanation.Selected).To(Equal("k8s-web-next"))
+
+		workload.Status.Binding.ConfigHash = ConfigHash(config)
+		explanation = Explain(context.Background(), nil, workload, config)
+		Expect(explanation.BindingKept).To(BeTrue())
+		Expect(explanation.Selected).To(Equal("k8s-web"))
+	})
+})
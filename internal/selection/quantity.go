@@ -0,0 +1,154 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selection
+
+import (
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// QuantityConstraint is a parsed backend resource constraint.
+// Bounds are compared numerically, so "1", "1000m" and "1.0" are equivalent,
+// as are "1Gi" and "1024Mi".
+type QuantityConstraint struct {
+	// Min is the lower bound, nil if unbounded
+	Min *resource.Quantity
+	// MinExclusive excludes Min itself from the accepted range
+	MinExclusive bool
+	// Max is the upper bound, nil if unbounded
+	Max *resource.Quantity
+	// MaxExclusive excludes Max itself from the accepted range
+	MaxExclusive bool
+}
+
+// ParseQuantityConstraint parses a resource constraint expression. Supported forms:
+//
+//	"<q>" or "=<q>"       exactly q
+//	"<min>-<max>"         min <= x <= max
+//	"<min>-"              x >= min
+//	"-<max>"              x <= max
+//	">=<q>", "><q>"       lower bound (inclusive, exclusive)
+//	"<=<q>", "<<q>"       upper bound (inclusive, exclusive)
+//
+// Each bound is a Kubernetes quantity such as "500m", "1.5", "512Ki", "1.5Gi" or "2".
+func ParseQuantityConstraint(constraint string) (QuantityConstraint, error) {
+	expr := strings.TrimSpace(constraint)
+	if expr == "" {
+		return QuantityConstraint{}, fmt.Errorf("empty constraint")
+	}
+
+	for _, op := range []string{">=", "<=", ">", "<", "="} {
+		if value, ok := strings.CutPrefix(expr, op); ok {
+			q, err := parseBound(value)
+			if err != nil {
+				return QuantityConstraint{}, err
+			}
+			switch op {
+			case ">=":
+				return QuantityConstraint{Min: q}, nil
+			case ">":
+				return QuantityConstraint{Min: q, MinExclusive: true}, nil
+			case "<=":
+				return QuantityConstraint{Max: q}, nil
+			case "<":
+				return QuantityConstraint{Max: q, MaxExclusive: true}, nil
+			default:
+				return QuantityConstraint{Min: q, Max: q}, nil
+			}
+		}
+	}
+
+	minStr, maxStr, isRange := cutRange(expr)
+	if !isRange {
+		q, err := parseBound(expr)
+		if err != nil {
+			return QuantityConstraint{}, err
+		}
+		return QuantityConstraint{Min: q, Max: q}, nil
+	}
+
+	if minStr == "" && maxStr == "" {
+		return QuantityConstraint{}, fmt.Errorf("range %q has no bounds", constraint)
+	}
+
+	var result QuantityConstraint
+	if minStr != "" {
+		q, err := parseBound(minStr)
+		if err != nil {
+			return QuantityConstraint{}, err
+		}
+		result.Min = q
+	}
+	if maxStr != "" {
+		q, err := parseBound(maxStr)
+		if err != nil {
+			return QuantityConstraint{}, err
+		}
+		result.Max = q
+	}
+	if result.Min != nil && result.Max != nil && result.Min.Cmp(*result.Max) > 0 {
+		return QuantityConstraint{}, fmt.Errorf("range %q has a minimum greater than its maximum", constraint)
+	}
+
+	return result, nil
+}
+
+// Contains reports whether the quantity satisfies the constraint
+func (c QuantityConstraint) Contains(q resource.Quantity) bool {
+	if c.Min != nil {
+		cmp := q.Cmp(*c.Min)
+		if cmp < 0 || (cmp == 0 && c.MinExclusive) {
+			return false
+		}
+	}
+	if c.Max != nil {
+		cmp := q.Cmp(*c.Max)
+		if cmp > 0 || (cmp == 0 && c.MaxExclusive) {
+			return false
+		}
+	}
+	return true
+}
+
+// cutRange splits a "<min>-<max>" expression at the range separator.
+// A '-' that is part of a quantity exponent (e.g., "1e-3") is not a separator.
+func cutRange(expr string) (string, string, bool) {
+	for i := 0; i < len(expr); i++ {
+		if expr[i] != '-' {
+			continue
+		}
+		if i > 0 && (expr[i-1] == 'e' || expr[i-1] == 'E') {
+			continue
+		}
+		return strings.TrimSpace(expr[:i]), strings.TrimSpace(expr[i+1:]), true
+	}
+	return "", "", false
+}
+
+// parseBound parses a single non-negative quantity bound
+func parseBound(value string) (*resource.Quantity, error) {
+	q, err := resource.ParseQuantity(strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid quantity %q: %w", value, err)
+	}
+	if q.Sign() < 0 {
+		return nil, fmt.Errorf("quantity %q must not be negative", value)
+	}
+	return &q, nil
+}
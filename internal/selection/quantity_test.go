@@ -0,0 +1,81 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package selection
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+var _ = DescribeTable("QuantityConstraint",
+	func(constraint, actual string, expected bool) {
+		parsed, err := ParseQuantityConstraint(constraint)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(parsed.Contains(resource.MustParse(actual))).To(Equal(expected))
+	},
+	Entry("exact match compares numerically", "1", "1000m", true),
+	Entry("exact mismatch", "500m", "501m", false),
+	Entry("range includes bounds", "100m-2", "2000m", true),
+	Entry("range rejects above max", "100m-2", "2001m", false),
+	Entry("fractional binary units", "1.5Gi-", "1536Mi", true),
+	Entry("kibibytes below min", "512Ki-", "500Ki", false),
+	Entry("max only", "-4Gi", "4096Mi", true),
+	Entry("decimal and binary units mix", "-1G", "1Gi", false),
+	Entry("inclusive lower bound", ">=2", "2", true),
+	Entry("exclusive lower bound", ">2", "2", false),
+	Entry("inclusive upper bound", "<=1Gi", "1Gi", true),
+	Entry("exclusive upper bound", "<1Gi", "1023Mi", true),
+	Entry("explicit equality", "=250m", "0.25", true),
+	Entry("exponent is not a range separator", "1e-3-", "1m", true),
+)
+
+var _ = DescribeTable("ParseQuantityConstraint errors",
+	func(constraint string) {
+		_, err := ParseQuantityConstraint(constraint)
+		Expect(err).To(HaveOccurred())
+	},
+	Entry("empty", ""),
+	Entry("bare separator", "-"),
+	Entry("not a quantity", "invalid-format"),
+	Entry("extra separator", "128-4-Gi"),
+	Entry("negative comparison", ">=-1"),
+	Entry("inverted range", "4Gi-1Gi"),
+)
+
+var _ = Describe("validateResourceConstraints", func() {
+	It("should sum container requests across units", func() {
+		s := &profileSelector{}
+		workload := &scorev1b1.Workload{
+			Spec: scorev1b1.WorkloadSpec{
+				Containers: map[string]scorev1b1.ContainerSpec{
+					"main":    {Resources: &scorev1b1.ResourceRequirements{Requests: map[string]string{"cpu": "1.5", "memory": "1Gi"}}},
+					"sidecar": {Resources: &scorev1b1.ResourceRequirements{Requests: map[string]string{"cpu": "500m", "memory": "512Mi"}}},
+				},
+			},
+		}
+
+		cpu, memory, _ := s.calculateWorkloadResources(workload)
+		Expect(cpu.Cmp(resource.MustParse("2"))).To(Equal(0))
+		Expect(memory.Cmp(resource.MustParse("1.5Gi"))).To(Equal(0))
+
+		Expect(s.validateResourceConstraints(GinkgoLogr, workload, scorev1b1.ResourceConstraints{CPU: "-2", Memory: "1Gi-2Gi"})).To(BeTrue())
+		Expect(s.validateResourceConstraints(GinkgoLogr, workload, scorev1b1.ResourceConstraints{CPU: "<2"})).To(BeFalse())
+	})
+})
@@ -18,26 +18,133 @@ package selection
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"path"
+	"slices"
 	"sort"
-	"strconv"
 	"strings"
+	"time"
 
 	"github.com/Masterminds/semver/v3"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/runtimeregistry"
 )
 
+// Selection errors
+var (
+	// ErrProfileNotFound indicates that no usable profile could be resolved for the workload
+	ErrProfileNotFound = errors.New("profile not found")
+	// ErrNoBackendAvailable indicates that no backend of the selected profile satisfies the workload
+	ErrNoBackendAvailable = errors.New("no backend available")
+	// ErrBackendFiltered indicates that no backend of the selected profile is allowed in the namespace of the workload
+	ErrBackendFiltered = errors.New("backend filtered")
+	// ErrRuntimeUnavailable indicates that backends satisfy the workload but none of their runtimes is registered
+	ErrRuntimeUnavailable = errors.New("runtime unavailable")
+	// ErrInvalidHint indicates that spec.profile or spec.requirements of the workload do not match the configuration
+	ErrInvalidHint = errors.New("invalid selection hint")
+)
+
+// Workload annotations read by the selector; the spec fields of the same name take precedence
+const (
+	annotationProfile      = "score.dev/profile"
+	annotationRequirements = "score.dev/requirements"
+)
+
+// ProfileHint returns the profile the workload asks for: spec.profile, or else the score.dev/profile annotation
+func ProfileHint(workload *scorev1b1.Workload) string {
+	if workload.Spec.Profile != nil && *workload.Spec.Profile != "" {
+		return *workload.Spec.Profile
+	}
+	return workload.Annotations[annotationProfile]
+}
+
+// Requirements returns the features the workload declares in spec.requirements and in the
+// comma-separated score.dev/requirements annotation
+func Requirements(workload *scorev1b1.Workload) []string {
+	requirements := append([]string(nil), workload.Spec.Requirements...)
+	if annotation, exists := workload.Annotations[annotationRequirements]; exists {
+		for _, feature := range strings.Split(annotation, ",") {
+			if feature = strings.TrimSpace(feature); feature != "" {
+				requirements = append(requirements, feature)
+			}
+		}
+	}
+	return requirements
+}
+
+// ValidateHints checks the typed selection hints of the workload against the configuration: spec.profile must
+// name a configured profile, and every entry of spec.requirements must be a feature constrained by a backend.
+// The annotations are not validated, to keep accepting the Workloads written before the fields existed.
+func ValidateHints(workload *scorev1b1.Workload, config *scorev1b1.OrchestratorConfig) error {
+	if workload.Spec.Profile != nil {
+		found := false
+		for _, profile := range config.Spec.Profiles {
+			if profile.Name == *workload.Spec.Profile {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%w: spec.profile: profile %q is not configured", ErrInvalidHint, *workload.Spec.Profile)
+		}
+	}
+
+	if len(workload.Spec.Requirements) == 0 {
+		return nil
+	}
+	features := make(map[string]bool)
+	for _, profile := range config.Spec.Profiles {
+		for _, backend := range profile.Backends {
+			if backend.Constraints == nil {
+				continue
+			}
+			for _, feature := range backend.Constraints.Features {
+				features[feature] = true
+			}
+		}
+	}
+	for i, requirement := range workload.Spec.Requirements {
+		if !features[requirement] {
+			return fmt.Errorf("%w: spec.requirements[%d]: feature %q is not constrained by any backend", ErrInvalidHint, i, requirement)
+		}
+	}
+	return nil
+}
+
 // SelectedBackend represents the result of backend selection
 type SelectedBackend struct {
+	Profile      string
 	BackendID    string
 	RuntimeClass string
 	Template     scorev1b1.TemplateSpec
 	Priority     int
 	Version      string
+	Exposure     *scorev1b1.ExposureSpec
+	Kind         string
+	// Target is the remote cluster the plans of the backend are delivered to; empty for this cluster
+	Target string
+	// Defaults are the workload defaults of the profile overridden by those of the backend
+	Defaults *scorev1b1.WorkloadDefaultsSpec
+	// WorkloadDefaults is the base Workload fragment of the profile merged into the Workload spec
+	WorkloadDefaults *scorev1b1.WorkloadFragmentSpec
+	// ValuesFrom are the claim outputs the backend routes to additional paths of the resolved values
+	ValuesFrom []scorev1b1.ValuesFromSpec
+	// ImagePolicy is the image policy of the profile
+	ImagePolicy *scorev1b1.ImagePolicySpec
+	// ReadinessGates are the plan conditions the profile requires before its Workloads are Ready
+	ReadinessGates []scorev1b1.ReadinessGate
 }
 
 // ProfileSelector interface defines the contract for profile and backend selection
@@ -45,6 +152,14 @@ type ProfileSelector interface {
 	// SelectBackend selects the appropriate backend for a workload based on the
 	// deterministic selection pipeline specified in the orchestrator config spec
 	SelectBackend(ctx context.Context, workload *scorev1b1.Workload) (*SelectedBackend, error)
+
+	// SelectBackendByID returns the given backend if it is still an eligible candidate for the workload.
+	// It returns an error wrapping ErrNoBackendAvailable if the backend was removed or no longer matches.
+	SelectBackendByID(ctx context.Context, workload *scorev1b1.Workload, backendID string) (*SelectedBackend, error)
+
+	// SelectProfile runs profile selection only. It returns an error wrapping ErrProfileNotFound
+	// if no configured profile applies to the workload.
+	SelectProfile(workload *scorev1b1.Workload) (*scorev1b1.ProfileSpec, error)
 }
 
 // profileSelector implements ProfileSelector interface
@@ -66,55 +181,192 @@ func NewProfileSelector(config *scorev1b1.OrchestratorConfig, k8sClient client.C
 // 2. Backend Filtering (selectors, features, constraints, admission)
 // 3. Backend Selection (deterministic sorting by priority → version → backendId)
 func (s *profileSelector) SelectBackend(ctx context.Context, workload *scorev1b1.Workload) (*SelectedBackend, error) {
-	// 1. Profile Selection
-	profileName, err := s.selectProfile(workload)
+	profile, candidates, err := s.selectCandidates(ctx, workload)
 	if err != nil {
-		return nil, fmt.Errorf("profile selection failed: %w", err)
+		return nil, err
 	}
 
-	fmt.Printf("DEBUG: Selected profile: %s\n", profileName)
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("%w: no suitable backend candidates found for profile %q", ErrNoBackendAvailable, profile.Name)
+	}
+
+	// 3. Backend Selection
+	return s.newSelectedBackend(profile, s.selectBackend(candidates)), nil
+}
+
+// SelectBackendByID runs profile selection and backend filtering, and returns the given backend if it survived
+func (s *profileSelector) SelectBackendByID(ctx context.Context, workload *scorev1b1.Workload, backendID string) (*SelectedBackend, error) {
+	profile, candidates, err := s.selectCandidates(ctx, workload)
+	if err != nil {
+		return nil, err
+	}
 
-	// Find the selected profile
-	var selectedProfile *scorev1b1.ProfileSpec
-	for _, profile := range s.config.Spec.Profiles {
-		fmt.Printf("DEBUG: Available profile: %s\n", profile.Name)
-		if profile.Name == profileName {
-			selectedProfile = &profile
-			break
+	for _, candidate := range candidates {
+		if candidate.BackendId == backendID {
+			return s.newSelectedBackend(profile, candidate), nil
 		}
 	}
 
-	if selectedProfile == nil {
-		return nil, fmt.Errorf("profile %q not found in configuration", profileName)
+	return nil, fmt.Errorf("%w: backend %q is not a candidate for profile %q", ErrNoBackendAvailable, backendID, profile.Name)
+}
+
+// selectCandidates performs profile selection and backend filtering
+func (s *profileSelector) selectCandidates(ctx context.Context, workload *scorev1b1.Workload) (*scorev1b1.ProfileSpec, []scorev1b1.BackendSpec, error) {
+	logger := log.FromContext(ctx)
+
+	// 1. Profile Selection
+	selectedProfile, err := s.SelectProfile(workload)
+	if err != nil {
+		return nil, nil, err
 	}
+	profileName := selectedProfile.Name
 
-	fmt.Printf("DEBUG: Found profile %s with %d backends\n", selectedProfile.Name, len(selectedProfile.Backends))
+	logger.V(1).Info("Selected profile", "profile", profileName)
 
 	// 2. Backend Filtering
-	candidates := s.filterBackends(workload, selectedProfile.Backends)
+	// Namespace restrictions scope backends to tenants before any other filter
+	namespaceLabels, err := s.namespaceLabels(ctx, workload.Namespace, selectedProfile.Backends)
+	if err != nil {
+		return nil, nil, err
+	}
+	candidates, namespaceRejections := filterByNamespace(workload.Namespace, namespaceLabels, selectedProfile.Backends)
+	for backendID, reason := range namespaceRejections {
+		logger.V(2).Info("Backend rejected", "backend", backendID, "reason", reason)
+	}
+	if len(selectedProfile.Backends) > 0 && len(candidates) == 0 {
+		return nil, nil, fmt.Errorf("%w: no backend of profile %q is allowed in namespace %q", ErrBackendFiltered, profileName, workload.Namespace)
+	}
 
-	fmt.Printf("DEBUG: After filtering: %d candidates\n", len(candidates))
+	candidates = s.filterBackends(ctx, workload, candidates)
 
-	if len(candidates) == 0 {
-		return nil, fmt.Errorf("no suitable backend candidates found for profile %q", profileName)
+	// Region filtering prefers backends pinned to the workload region
+	region := s.resolveRegion(ctx, workload)
+	candidates, regionRejections := filterByRegion(region, candidates)
+	for backendID, reason := range regionRejections {
+		logger.V(2).Info("Backend rejected", "backend", backendID, "reason", reason)
 	}
 
-	// 3. Backend Selection
-	selectedBackend := s.selectBackend(candidates)
+	// Runtime filtering drops backends whose runtimeClass has no live runtime registered
+	if s.requireRuntimeRegistration() && len(candidates) > 0 {
+		live, err := runtimeregistry.LiveRuntimeClasses(ctx, s.client, time.Now())
+		if err != nil {
+			return nil, nil, err
+		}
+		var runtimeRejections map[string]string
+		candidates, runtimeRejections = filterByRuntime(live, candidates)
+		for backendID, reason := range runtimeRejections {
+			logger.V(2).Info("Backend rejected", "backend", backendID, "reason", reason)
+		}
+		if len(candidates) == 0 {
+			return nil, nil, fmt.Errorf("%w: no live runtime is registered for the backends of profile %q", ErrRuntimeUnavailable, profileName)
+		}
+	}
+
+	logger.V(1).Info("Filtered backends", "profile", profileName, "region", region, "backends", len(selectedProfile.Backends), "candidates", len(candidates))
 
+	return selectedProfile, candidates, nil
+}
+
+// SelectProfile resolves the profile of the workload from the configuration
+func (s *profileSelector) SelectProfile(workload *scorev1b1.Workload) (*scorev1b1.ProfileSpec, error) {
+	profileName, err := s.selectProfile(workload)
+	if err != nil {
+		return nil, fmt.Errorf("profile selection failed: %w", err)
+	}
+
+	for i := range s.config.Spec.Profiles {
+		if s.config.Spec.Profiles[i].Name == profileName {
+			return &s.config.Spec.Profiles[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: profile %q not found in configuration", ErrProfileNotFound, profileName)
+}
+
+// newSelectedBackend converts a backend spec of the profile into a selection result
+func (s *profileSelector) newSelectedBackend(profile *scorev1b1.ProfileSpec, backend scorev1b1.BackendSpec) *SelectedBackend {
 	return &SelectedBackend{
-		BackendID:    selectedBackend.BackendId,
-		RuntimeClass: selectedBackend.RuntimeClass,
-		Template:     selectedBackend.Template,
-		Priority:     selectedBackend.Priority,
-		Version:      selectedBackend.Version,
-	}, nil
+		Profile:          profile.Name,
+		Kind:             profile.Kind,
+		BackendID:        backend.BackendId,
+		RuntimeClass:     backend.RuntimeClass,
+		Template:         backend.Template,
+		Priority:         backend.Priority,
+		Version:          backend.Version,
+		Exposure:         backend.Exposure,
+		Target:           backend.Target,
+		Defaults:         mergeWorkloadDefaults(profile.Defaults, backend.Defaults),
+		WorkloadDefaults: profile.WorkloadDefaults.DeepCopy(),
+		ImagePolicy:      profile.ImagePolicy.DeepCopy(),
+		ReadinessGates:   profile.ReadinessGates,
+		ValuesFrom:       backend.ValuesFrom,
+	}
+}
+
+// mergeWorkloadDefaults overrides the workload defaults of a profile with those set on its backend
+func mergeWorkloadDefaults(profile, backend *scorev1b1.WorkloadDefaultsSpec) *scorev1b1.WorkloadDefaultsSpec {
+	if profile == nil && backend == nil {
+		return nil
+	}
+	merged := profile.DeepCopy()
+	if merged == nil {
+		merged = &scorev1b1.WorkloadDefaultsSpec{}
+	}
+	if backend != nil {
+		if backend.DisruptionBudget != nil {
+			merged.DisruptionBudget = backend.DisruptionBudget.DeepCopy()
+		}
+		if backend.Resources != nil {
+			merged.Resources = backend.Resources.DeepCopy()
+		}
+		if backend.PriorityClass != nil {
+			merged.PriorityClass = backend.PriorityClass.DeepCopy()
+		}
+	}
+	return merged
+}
+
+// ConfigHash returns a stable hash of the parts of the configuration that affect backend selection.
+// It is recorded with the selection so that configuration changes can be detected on later reconciles.
+func ConfigHash(config *scorev1b1.OrchestratorConfig) string {
+	data, err := json.Marshal(struct {
+		Profiles []scorev1b1.ProfileSpec `json:"profiles"`
+		Defaults scorev1b1.DefaultsSpec  `json:"defaults"`
+	}{config.Spec.Profiles, config.Spec.Defaults})
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])[:16]
+}
+
+// KeepBinding reports whether a recorded binding should be kept under the given reselection policy.
+// Callers must still check that the recorded backend is a candidate for the workload.
+func KeepBinding(policy string, binding *scorev1b1.WorkloadBinding, configHash string) bool {
+	if binding == nil || binding.BackendID == "" {
+		return false
+	}
+	if policy == scorev1b1.ReselectionPolicyReselectOnChange {
+		return binding.ConfigHash == configHash
+	}
+	return true
+}
+
+// TemplateDigest returns the sha256 digest of the template a backend resolves to.
+// Unlike the ref alone, it changes whenever the kind, ref or default values change.
+func TemplateDigest(template scorev1b1.TemplateSpec) string {
+	data, err := json.Marshal(template)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
 }
 
 // selectProfile implements the profile selection pipeline
 func (s *profileSelector) selectProfile(workload *scorev1b1.Workload) (string, error) {
-	// 1. User hint evaluation: score.dev/profile annotation on Workload
-	if profileHint, exists := workload.Annotations["score.dev/profile"]; exists && profileHint != "" {
+	// 1. User hint evaluation: spec.profile or the score.dev/profile annotation on Workload
+	if profileHint := ProfileHint(workload); profileHint != "" {
 		// Validate that the hinted profile exists
 		for _, profile := range s.config.Spec.Profiles {
 			if profile.Name == profileHint {
@@ -122,7 +374,7 @@ func (s *profileSelector) selectProfile(workload *scorev1b1.Workload) (string, e
 			}
 		}
 		// Profile hint is invalid - this should result in SpecInvalid
-		return "", fmt.Errorf("hinted profile %q does not exist", profileHint)
+		return "", fmt.Errorf("%w: hinted profile %q does not exist", ErrProfileNotFound, profileHint)
 	}
 
 	// 2. Auto-derivation: Profile inferred from Workload characteristics
@@ -141,34 +393,49 @@ func (s *profileSelector) selectProfile(workload *scorev1b1.Workload) (string, e
 		return s.config.Spec.Defaults.Profile, nil
 	}
 
-	return "", fmt.Errorf("no profile could be determined and no default profile is configured")
+	return "", fmt.Errorf("%w: no profile could be determined and no default profile is configured", ErrProfileNotFound)
 }
 
-// deriveProfileFromWorkload derives profile from workload characteristics
+// deriveProfileFromWorkload evaluates defaults.derivationRules[] in document order and returns the profile
+// of the first rule matching the workload characteristics, or "" when none matches
 func (s *profileSelector) deriveProfileFromWorkload(workload *scorev1b1.Workload) string {
-	// Auto-derivation rules (basic implementation):
-	// - If workload has service ports: likely web-service
-	// - If workload has no service: likely batch-job
+	for _, rule := range s.config.Spec.Defaults.DerivationRules {
+		if rule.Profile != "" && derivationRuleMatches(rule, workload) {
+			return rule.Profile
+		}
+	}
+	return ""
+}
 
-	if workload.Spec.Service != nil && len(workload.Spec.Service.Ports) > 0 {
-		// Look for web-service profile
-		for _, profile := range s.config.Spec.Profiles {
-			if strings.Contains(strings.ToLower(profile.Name), "web") ||
-				strings.Contains(strings.ToLower(profile.Name), "service") {
-				return profile.Name
-			}
+// derivationRuleMatches checks if all predicates set on the rule hold for the workload
+func derivationRuleMatches(rule scorev1b1.DerivationRuleSpec, workload *scorev1b1.Workload) bool {
+	hasService := workload.Spec.Service != nil && len(workload.Spec.Service.Ports) > 0
+	if rule.HasService != nil && *rule.HasService != hasService {
+		return false
+	}
+
+	if len(rule.Ports) > 0 {
+		if !hasService {
+			return false
 		}
-	} else {
-		// Look for batch-job profile
-		for _, profile := range s.config.Spec.Profiles {
-			if strings.Contains(strings.ToLower(profile.Name), "batch") ||
-				strings.Contains(strings.ToLower(profile.Name), "job") {
-				return profile.Name
+		exposed := false
+		for _, port := range workload.Spec.Service.Ports {
+			if slices.Contains(rule.Ports, port.Port) {
+				exposed = true
+				break
 			}
 		}
+		if !exposed {
+			return false
+		}
 	}
 
-	return ""
+	for key, value := range rule.Annotations {
+		if actual, exists := workload.Annotations[key]; !exists || actual != value {
+			return false
+		}
+	}
+	return true
 }
 
 // selectProfileFromSelectors evaluates defaults.selectors[] in document order
@@ -208,7 +475,8 @@ func (s *profileSelector) selectorMatches(selector scorev1b1.SelectorSpec, targe
 
 // filterBackends applies filtering to backend candidates
 // Based on ADR-0004: Simplified filtering without namespace labels
-func (s *profileSelector) filterBackends(workload *scorev1b1.Workload, backends []scorev1b1.BackendSpec) []scorev1b1.BackendSpec {
+func (s *profileSelector) filterBackends(ctx context.Context, workload *scorev1b1.Workload, backends []scorev1b1.BackendSpec) []scorev1b1.BackendSpec {
+	logger := log.FromContext(ctx)
 	candidates := make([]scorev1b1.BackendSpec, 0, len(backends))
 
 	// Use only Workload labels (cluster-level environment model per ADR-0004)
@@ -219,36 +487,214 @@ func (s *profileSelector) filterBackends(workload *scorev1b1.Workload, backends
 
 	// Get workload features for debugging
 	workloadFeatures := s.getWorkloadFeatures(workload)
-	fmt.Printf("DEBUG: Workload features: %v\n", workloadFeatures)
 
 	for _, backend := range backends {
-		fmt.Printf("DEBUG: Checking backend %s\n", backend.BackendId)
+		backendLog := logger.V(2).WithValues("backend", backend.BackendId)
 
-		// Apply backend selectors using only workload labels (skip if no constraints)
-		if backend.Constraints != nil && !s.backendSelectorsMatch(backend.Constraints.Selectors, workloadLabels) {
-			fmt.Printf("DEBUG: Backend %s rejected by selectors\n", backend.BackendId)
+		if reason := s.rejectBackend(backendLog, workload, workloadLabels, backend); reason != "" {
+			backendLog.Info("Backend rejected", "reason", reason, "features", workloadFeatures)
 			continue
 		}
 
-		// Validate feature requirements (skip if no constraints)
-		if backend.Constraints != nil && !s.validateFeatureRequirements(workload, backend.Constraints.Features) {
-			fmt.Printf("DEBUG: Backend %s rejected by features. Required: %v, Available: %v\n",
-				backend.BackendId, backend.Constraints.Features, workloadFeatures)
+		// Backend passes all filters
+		backendLog.Info("Backend accepted")
+		candidates = append(candidates, backend)
+	}
+
+	return candidates
+}
+
+// Backend rejection reasons reported by rejectBackend
+const (
+	rejectedBySelectors           = "selectors do not match workload labels"
+	rejectedByFeatures            = "required features are not provided by the workload"
+	rejectedByResourceConstraints = "resource constraints are not satisfied"
+)
+
+// rejectBackend returns why the backend cannot serve the workload, or "" if it passes all filters
+func (s *profileSelector) rejectBackend(logger logr.Logger, workload *scorev1b1.Workload, workloadLabels map[string]string, backend scorev1b1.BackendSpec) string {
+	// Backends without constraints accept every workload
+	if backend.Constraints == nil {
+		return ""
+	}
+
+	// Apply backend selectors using only workload labels
+	if !s.backendSelectorsMatch(backend.Constraints.Selectors, workloadLabels) {
+		return rejectedBySelectors
+	}
+
+	// Validate feature requirements
+	if !s.validateFeatureRequirements(workload, backend.Constraints.Features) {
+		return fmt.Sprintf("%s (required: %s)", rejectedByFeatures, strings.Join(backend.Constraints.Features, ", "))
+	}
+
+	// Check resource constraints
+	if backend.Constraints.Resources != nil && !s.validateResourceConstraints(logger, workload, *backend.Constraints.Resources) {
+		return rejectedByResourceConstraints
+	}
+
+	return ""
+}
+
+// regionLabel returns the label key carrying the region
+func (s *profileSelector) regionLabel() string {
+	if s.config.Spec.Defaults.RegionLabel != "" {
+		return s.config.Spec.Defaults.RegionLabel
+	}
+	return scorev1b1.DefaultRegionLabel
+}
+
+// resolveRegion determines the region of the workload from its labels, falling back to the
+// region of the cluster nodes. It returns "" if the region is unknown.
+func (s *profileSelector) resolveRegion(ctx context.Context, workload *scorev1b1.Workload) string {
+	key := s.regionLabel()
+	if region := workload.Labels[key]; region != "" {
+		return region
+	}
+	if s.client == nil {
+		return ""
+	}
+
+	nodes := &corev1.NodeList{}
+	if err := s.client.List(ctx, nodes, client.HasLabels{key}); err != nil {
+		log.FromContext(ctx).V(1).Info("Failed to list nodes for region detection", "error", err.Error())
+		return ""
+	}
+	return clusterRegion(nodes.Items, key)
+}
+
+// clusterRegion returns the most common region among the nodes; ties are broken lexicographically
+func clusterRegion(nodes []corev1.Node, key string) string {
+	counts := make(map[string]int)
+	for _, node := range nodes {
+		if region := node.Labels[key]; region != "" {
+			counts[region]++
+		}
+	}
+
+	var region string
+	for candidate, count := range counts {
+		if count > counts[region] || (count == counts[region] && candidate < region) {
+			region = candidate
+		}
+	}
+	return region
+}
+
+// filterByRegion keeps the backends whose regions include the given region. Region-agnostic backends
+// (without regions) are the deterministic fallback when no backend is pinned to the region.
+// It returns the kept backends and, per dropped backend ID, the reason it was rejected.
+// An unknown region keeps all backends.
+func filterByRegion(region string, backends []scorev1b1.BackendSpec) ([]scorev1b1.BackendSpec, map[string]string) {
+	if region == "" {
+		return backends, nil
+	}
+
+	var regional, agnostic []scorev1b1.BackendSpec
+	rejections := make(map[string]string)
+	for _, backend := range backends {
+		if backend.Constraints == nil || len(backend.Constraints.Regions) == 0 {
+			agnostic = append(agnostic, backend)
 			continue
 		}
+		if slices.Contains(backend.Constraints.Regions, region) {
+			regional = append(regional, backend)
+			continue
+		}
+		rejections[backend.BackendId] = fmt.Sprintf("region %q is not in allowed regions [%s]", region, strings.Join(backend.Constraints.Regions, ", "))
+	}
+
+	if len(regional) == 0 {
+		return agnostic, rejections
+	}
+	for _, backend := range agnostic {
+		rejections[backend.BackendId] = fmt.Sprintf("a backend pinned to region %q is preferred", region)
+	}
+	return regional, rejections
+}
 
-		// Check resource constraints (skip if no constraints)
-		if backend.Constraints != nil && backend.Constraints.Resources != nil && !s.validateResourceConstraints(workload, *backend.Constraints.Resources) {
-			fmt.Printf("DEBUG: Backend %s rejected by resource constraints\n", backend.BackendId)
+// namespaceLabels returns the labels of the namespace when a backend restricts it with a namespaceSelector.
+// It returns nil when no backend does, or when the namespace cannot be read without a client.
+func (s *profileSelector) namespaceLabels(ctx context.Context, namespace string, backends []scorev1b1.BackendSpec) (map[string]string, error) {
+	if s.client == nil || !slices.ContainsFunc(backends, func(backend scorev1b1.BackendSpec) bool {
+		return backend.Constraints != nil && backend.Constraints.NamespaceSelector != nil
+	}) {
+		return nil, nil
+	}
+
+	ns := &corev1.Namespace{}
+	if err := s.client.Get(ctx, client.ObjectKey{Name: namespace}, ns); err != nil {
+		return nil, fmt.Errorf("failed to get namespace %s: %w", namespace, err)
+	}
+	if ns.Labels == nil {
+		return map[string]string{}, nil
+	}
+	return ns.Labels, nil
+}
+
+// filterByNamespace keeps the backends whose namespaces and namespaceSelector allow the namespace.
+// Nil namespaceLabels mean the labels are unknown, which rejects the backends with a namespaceSelector.
+// It returns the kept backends and, per dropped backend ID, the reason it was rejected.
+func filterByNamespace(namespace string, namespaceLabels map[string]string, backends []scorev1b1.BackendSpec) ([]scorev1b1.BackendSpec, map[string]string) {
+	kept := make([]scorev1b1.BackendSpec, 0, len(backends))
+	rejections := make(map[string]string)
+	for _, backend := range backends {
+		if reason := rejectNamespace(namespace, namespaceLabels, backend.Constraints); reason != "" {
+			rejections[backend.BackendId] = reason
 			continue
 		}
+		kept = append(kept, backend)
+	}
+	return kept, rejections
+}
 
-		// Backend passes all filters
-		fmt.Printf("DEBUG: Backend %s accepted\n", backend.BackendId)
-		candidates = append(candidates, backend)
+// rejectNamespace returns why the constraints do not allow the namespace, or "" if they do
+func rejectNamespace(namespace string, namespaceLabels map[string]string, constraints *scorev1b1.ConstraintsSpec) string {
+	if constraints == nil {
+		return ""
 	}
 
-	return candidates
+	if len(constraints.Namespaces) > 0 && !slices.ContainsFunc(constraints.Namespaces, func(pattern string) bool {
+		matched, err := path.Match(pattern, namespace)
+		return err == nil && matched
+	}) {
+		return fmt.Sprintf("namespace %q is not in allowed namespaces [%s]", namespace, strings.Join(constraints.Namespaces, ", "))
+	}
+
+	if constraints.NamespaceSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(constraints.NamespaceSelector)
+		if err != nil {
+			return fmt.Sprintf("invalid namespaceSelector: %v", err)
+		}
+		if namespaceLabels == nil {
+			return fmt.Sprintf("labels of namespace %q are unavailable for the namespaceSelector", namespace)
+		}
+		if !selector.Matches(labels.Set(namespaceLabels)) {
+			return fmt.Sprintf("namespace %q does not match the namespaceSelector", namespace)
+		}
+	}
+	return ""
+}
+
+// requireRuntimeRegistration reports whether backends need a live runtime registration to be selected.
+// Registrations cannot be listed without a client, so the filter is skipped in that case.
+func (s *profileSelector) requireRuntimeRegistration() bool {
+	return s.config.Spec.Defaults.RequireRuntimeRegistration && s.client != nil
+}
+
+// filterByRuntime keeps the backends whose runtimeClass has a live registration.
+// It returns the kept backends and, per dropped backend ID, the reason it was rejected.
+func filterByRuntime(live map[string]runtimeregistry.Registration, backends []scorev1b1.BackendSpec) ([]scorev1b1.BackendSpec, map[string]string) {
+	kept := make([]scorev1b1.BackendSpec, 0, len(backends))
+	rejections := make(map[string]string)
+	for _, backend := range backends {
+		if _, ok := live[backend.RuntimeClass]; !ok {
+			rejections[backend.BackendId] = fmt.Sprintf("no live runtime is registered for runtimeClass %q", backend.RuntimeClass)
+			continue
+		}
+		kept = append(kept, backend)
+	}
+	return kept, rejections
 }
 
 // backendSelectorsMatch checks if backend constraint selectors match
@@ -288,17 +734,14 @@ func (s *profileSelector) validateFeatureRequirements(workload *scorev1b1.Worklo
 }
 
 // validateResourceConstraints validates resource constraints against workload requirements
-func (s *profileSelector) validateResourceConstraints(workload *scorev1b1.Workload, constraints scorev1b1.ResourceConstraints) bool {
+func (s *profileSelector) validateResourceConstraints(logger logr.Logger, workload *scorev1b1.Workload, constraints scorev1b1.ResourceConstraints) bool {
 	// Extract total resource requirements from all containers
 	totalCPU, totalMemory, totalStorage := s.calculateWorkloadResources(workload)
 
-	fmt.Printf("DEBUG: Resource validation - totalCPU: %s, totalMemory: %s, totalStorage: %s\n", totalCPU, totalMemory, totalStorage)
-	fmt.Printf("DEBUG: Constraints - CPU: %s, Memory: %s, Storage: %s\n", constraints.CPU, constraints.Memory, constraints.Storage)
-
 	// Validate CPU constraints
 	if constraints.CPU != "" {
 		if !s.validateQuantityConstraint(totalCPU, constraints.CPU) {
-			fmt.Printf("DEBUG: CPU constraint validation failed: %s does not match %s\n", totalCPU, constraints.CPU)
+			logger.Info("CPU constraint not satisfied", "required", totalCPU.String(), "constraint", constraints.CPU)
 			return false
 		}
 	}
@@ -306,7 +749,7 @@ func (s *profileSelector) validateResourceConstraints(workload *scorev1b1.Worklo
 	// Validate Memory constraints
 	if constraints.Memory != "" {
 		if !s.validateQuantityConstraint(totalMemory, constraints.Memory) {
-			fmt.Printf("DEBUG: Memory constraint validation failed: %s does not match %s\n", totalMemory, constraints.Memory)
+			logger.Info("Memory constraint not satisfied", "required", totalMemory.String(), "constraint", constraints.Memory)
 			return false
 		}
 	}
@@ -314,12 +757,11 @@ func (s *profileSelector) validateResourceConstraints(workload *scorev1b1.Worklo
 	// Validate Storage constraints
 	if constraints.Storage != "" {
 		if !s.validateQuantityConstraint(totalStorage, constraints.Storage) {
-			fmt.Printf("DEBUG: Storage constraint validation failed: %s does not match %s\n", totalStorage, constraints.Storage)
+			logger.Info("Storage constraint not satisfied", "required", totalStorage.String(), "constraint", constraints.Storage)
 			return false
 		}
 	}
 
-	fmt.Printf("DEBUG: All resource constraints passed\n")
 	return true
 }
 
@@ -358,141 +800,56 @@ func (s *profileSelector) selectBackend(candidates []scorev1b1.BackendSpec) scor
 	return candidates[0]
 }
 
-// calculateWorkloadResources calculates total resource requirements from all containers
-func (s *profileSelector) calculateWorkloadResources(workload *scorev1b1.Workload) (string, string, string) {
-	var totalCPU, totalMemory, totalStorage int64
+// calculateWorkloadResources calculates total resource requirements from all containers.
+// Requests that are not valid quantities are ignored.
+func (s *profileSelector) calculateWorkloadResources(workload *scorev1b1.Workload) (resource.Quantity, resource.Quantity, resource.Quantity) {
+	var totalCPU, totalMemory, totalStorage resource.Quantity
 
 	// Sum up resources from all containers
 	for _, container := range workload.Spec.Containers {
-		if container.Resources != nil && container.Resources.Requests != nil {
-			// Parse CPU requests
-			if cpuStr, exists := container.Resources.Requests["cpu"]; exists {
-				if cpu, err := parseQuantity(cpuStr); err == nil {
-					totalCPU += cpu
-				}
-			}
-
-			// Parse Memory requests
-			if memoryStr, exists := container.Resources.Requests["memory"]; exists {
-				if memory, err := parseQuantity(memoryStr); err == nil {
-					totalMemory += memory
-				}
-			}
-
-			// Parse Storage requests (ephemeral storage)
-			if storageStr, exists := container.Resources.Requests["ephemeral-storage"]; exists {
-				if storage, err := parseQuantity(storageStr); err == nil {
-					totalStorage += storage
-				}
-			}
+		if container.Resources == nil || container.Resources.Requests == nil {
+			continue
 		}
+		addQuantity(&totalCPU, container.Resources.Requests["cpu"])
+		addQuantity(&totalMemory, container.Resources.Requests["memory"])
+		// Storage maps to ephemeral storage requests
+		addQuantity(&totalStorage, container.Resources.Requests["ephemeral-storage"])
 	}
 
-	// Convert back to string format
-	cpuStr := fmt.Sprintf("%dm", totalCPU)
-	memoryStr := fmt.Sprintf("%d", totalMemory)
-	storageStr := fmt.Sprintf("%d", totalStorage)
-
-	return cpuStr, memoryStr, storageStr
+	return totalCPU, totalMemory, totalStorage
 }
 
-// validateQuantityConstraint validates a quantity against a range constraint
-func (s *profileSelector) validateQuantityConstraint(actual, constraint string) bool {
-	if constraint == "" {
-		return true
+// addQuantity adds the parsed value to total if it is a valid quantity
+func addQuantity(total *resource.Quantity, value string) {
+	if value == "" {
+		return
 	}
-
-	// Parse constraint format: "100m-4000m", "100m-", "-4000m", or "100m"
-	parts := strings.Split(constraint, "-")
-
-	switch len(parts) {
-	case 1:
-		// Exact match: "100m"
-		return actual == constraint
-	case 2:
-		minStr, maxStr := parts[0], parts[1]
-
-		// Parse actual value
-		actualQty, err := parseQuantity(actual)
-		if err != nil {
-			return false
-		}
-
-		// Check minimum (if specified)
-		if minStr != "" {
-			minQty, err := parseQuantity(minStr)
-			if err != nil {
-				return false
-			}
-			if actualQty < minQty {
-				return false
-			}
-		}
-
-		// Check maximum (if specified)
-		if maxStr != "" {
-			maxQty, err := parseQuantity(maxStr)
-			if err != nil {
-				return false
-			}
-			if actualQty > maxQty {
-				return false
-			}
-		}
-
-		return true
-	default:
-		return false
+	if q, err := resource.ParseQuantity(value); err == nil {
+		total.Add(q)
 	}
 }
 
-// parseQuantity parses a Kubernetes quantity string to a comparable value
-func parseQuantity(quantityStr string) (int64, error) {
-	if quantityStr == "" {
-		return 0, nil
-	}
-
-	// Simple parsing for common units (millicpu, memory)
-	if strings.HasSuffix(quantityStr, "m") {
-		// CPU millicores
-		value := strings.TrimSuffix(quantityStr, "m")
-		return strconv.ParseInt(value, 10, 64)
-	}
-
-	if strings.HasSuffix(quantityStr, "Mi") {
-		// Memory in MiB
-		value := strings.TrimSuffix(quantityStr, "Mi")
-		val, err := strconv.ParseInt(value, 10, 64)
-		if err != nil {
-			return 0, err
-		}
-		return val * 1024 * 1024, nil
+// validateQuantityConstraint validates a quantity against a constraint expression.
+// Invalid constraints never match.
+func (s *profileSelector) validateQuantityConstraint(actual resource.Quantity, constraint string) bool {
+	if constraint == "" {
+		return true
 	}
 
-	if strings.HasSuffix(quantityStr, "Gi") {
-		// Memory in GiB
-		value := strings.TrimSuffix(quantityStr, "Gi")
-		val, err := strconv.ParseInt(value, 10, 64)
-		if err != nil {
-			return 0, err
-		}
-		return val * 1024 * 1024 * 1024, nil
+	parsed, err := ParseQuantityConstraint(constraint)
+	if err != nil {
+		return false
 	}
-
-	// Plain number (bytes)
-	return strconv.ParseInt(quantityStr, 10, 64)
+	return parsed.Contains(actual)
 }
 
-// getWorkloadFeatures returns a set of workload features (from annotation and auto-detection)
+// getWorkloadFeatures returns a set of workload features (from requirements and auto-detection)
 func (s *profileSelector) getWorkloadFeatures(workload *scorev1b1.Workload) map[string]bool {
 	featureSet := make(map[string]bool)
 
-	// Get explicit requirements from annotation
-	if requirementsAnnotation, exists := workload.Annotations["score.dev/requirements"]; exists {
-		workloadFeatures := strings.Split(requirementsAnnotation, ",")
-		for _, feature := range workloadFeatures {
-			featureSet[strings.TrimSpace(feature)] = true
-		}
+	// Get explicit requirements from the spec and the annotation
+	for _, feature := range Requirements(workload) {
+		featureSet[feature] = true
 	}
 
 	// Auto-detect features from workload characteristics
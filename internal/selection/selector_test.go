@@ -19,15 +19,19 @@ package selection
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/runtimeregistry"
 )
 
 func TestProfileSelector(t *testing.T) {
@@ -88,6 +92,7 @@ var _ = Describe("ProfileSelector", func() {
 
 				Expect(err).ToNot(HaveOccurred())
 				Expect(result).ToNot(BeNil())
+				Expect(result.Profile).To(Equal("web-service"))
 				Expect(result.BackendID).To(Equal("k8s-web"))
 				Expect(result.RuntimeClass).To(Equal("kubernetes"))
 				Expect(result.Priority).To(Equal(100))
@@ -139,4 +144,570 @@ var _ = Describe("ProfileSelector", func() {
 			})
 		})
 	})
+
+	Describe("SelectBackendByID", func() {
+		var config *scorev1b1.OrchestratorConfig
+
+		BeforeEach(func() {
+			config = &scorev1b1.OrchestratorConfig{
+				Spec: scorev1b1.OrchestratorConfigSpec{
+					Profiles: []scorev1b1.ProfileSpec{
+						{
+							Name: "web-service",
+							Backends: []scorev1b1.BackendSpec{
+								{BackendId: "k8s-web", RuntimeClass: "kubernetes", Priority: 100, Version: "1.0.0"},
+								{BackendId: "k8s-web-legacy", RuntimeClass: "kubernetes", Priority: 50, Version: "0.9.0"},
+							},
+						},
+					},
+					Defaults: scorev1b1.DefaultsSpec{Profile: "web-service"},
+				},
+			}
+		})
+
+		It("should keep a previously selected backend that is still a candidate", func() {
+			selector := NewProfileSelector(config, fake.NewClientBuilder().WithScheme(scheme).Build())
+			workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "test-workload", Namespace: "default"}}
+
+			result, err := selector.SelectBackendByID(context.Background(), workload, "k8s-web-legacy")
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.BackendID).To(Equal("k8s-web-legacy"))
+		})
+
+		It("should fail when the backend is no longer a candidate", func() {
+			selector := NewProfileSelector(config, fake.NewClientBuilder().WithScheme(scheme).Build())
+			workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "test-workload", Namespace: "default"}}
+
+			result, err := selector.SelectBackendByID(context.Background(), workload, "removed-backend")
+
+			Expect(err).To(MatchError(ErrNoBackendAvailable))
+			Expect(result).To(BeNil())
+		})
+	})
+
+	Describe("SelectProfile", func() {
+		It("should resolve the profile without requiring a backend", func() {
+			config := &scorev1b1.OrchestratorConfig{
+				Spec: scorev1b1.OrchestratorConfigSpec{
+					Profiles: []scorev1b1.ProfileSpec{{Name: "batch", Kind: scorev1b1.WorkloadKindJob}},
+					Defaults: scorev1b1.DefaultsSpec{Profile: "batch"},
+				},
+			}
+			selector := NewProfileSelector(config, fake.NewClientBuilder().WithScheme(scheme).Build())
+			workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "test-workload", Namespace: "default"}}
+
+			profile, err := selector.SelectProfile(workload)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(profile.Name).To(Equal("batch"))
+			Expect(profile.Kind).To(Equal(scorev1b1.WorkloadKindJob))
+		})
+
+		It("should fail when the hinted profile does not exist", func() {
+			selector := NewProfileSelector(&scorev1b1.OrchestratorConfig{}, fake.NewClientBuilder().WithScheme(scheme).Build())
+			workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{
+				Name: "test-workload", Namespace: "default",
+				Annotations: map[string]string{"score.dev/profile": "missing"},
+			}}
+
+			profile, err := selector.SelectProfile(workload)
+
+			Expect(err).To(MatchError(ErrProfileNotFound))
+			Expect(profile).To(BeNil())
+		})
+
+		It("should prefer spec.profile over the annotation", func() {
+			config := &scorev1b1.OrchestratorConfig{
+				Spec: scorev1b1.OrchestratorConfigSpec{
+					Profiles: []scorev1b1.ProfileSpec{{Name: "web-service"}, {Name: "batch"}},
+				},
+			}
+			selector := NewProfileSelector(config, fake.NewClientBuilder().WithScheme(scheme).Build())
+			workload := &scorev1b1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-workload", Namespace: "default",
+					Annotations: map[string]string{"score.dev/profile": "web-service"},
+				},
+				Spec: scorev1b1.WorkloadSpec{Profile: ptr.To("batch")},
+			}
+
+			profile, err := selector.SelectProfile(workload)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(profile.Name).To(Equal("batch"))
+		})
+	})
+
+	Describe("profile derivation", func() {
+		var config *scorev1b1.OrchestratorConfig
+
+		BeforeEach(func() {
+			config = &scorev1b1.OrchestratorConfig{
+				Spec: scorev1b1.OrchestratorConfigSpec{
+					Profiles: []scorev1b1.ProfileSpec{{Name: "web-service"}, {Name: "event-consumer"}, {Name: "batch-job"}},
+					Defaults: scorev1b1.DefaultsSpec{
+						Profile: "web-service",
+						DerivationRules: []scorev1b1.DerivationRuleSpec{
+							{Profile: "event-consumer", Annotations: map[string]string{"example.com/workload-type": "worker"}},
+							{Profile: "web-service", Ports: []int32{80, 8080}},
+							{Profile: "batch-job", HasService: ptr.To(false)},
+						},
+					},
+				},
+			}
+		})
+
+		selectProfile := func(workload *scorev1b1.Workload) string {
+			selector := NewProfileSelector(config, fake.NewClientBuilder().WithScheme(scheme).Build())
+			profile, err := selector.SelectProfile(workload)
+			Expect(err).ToNot(HaveOccurred())
+			return profile.Name
+		}
+		withPorts := func(ports ...int32) *scorev1b1.ServiceSpec {
+			service := &scorev1b1.ServiceSpec{}
+			for _, port := range ports {
+				service.Ports = append(service.Ports, scorev1b1.ServicePort{Port: port})
+			}
+			return service
+		}
+
+		It("should select the profile of the first matching rule", func() {
+			workload := &scorev1b1.Workload{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{"example.com/workload-type": "worker"}},
+				Spec:       scorev1b1.WorkloadSpec{Service: withPorts(8080)},
+			}
+
+			Expect(selectProfile(workload)).To(Equal("event-consumer"))
+		})
+
+		It("should match service ports and service presence", func() {
+			Expect(selectProfile(&scorev1b1.Workload{Spec: scorev1b1.WorkloadSpec{Service: withPorts(9090, 80)}})).To(Equal("web-service"))
+			Expect(selectProfile(&scorev1b1.Workload{})).To(Equal("batch-job"))
+		})
+
+		It("should fall through to the selectors and the default when no rule matches", func() {
+			workload := &scorev1b1.Workload{Spec: scorev1b1.WorkloadSpec{Service: withPorts(9090)}}
+			config.Spec.Defaults.Profile = "event-consumer"
+
+			Expect(selectProfile(workload)).To(Equal("event-consumer"))
+		})
+
+		It("should derive no profile without rules", func() {
+			config.Spec.Defaults.DerivationRules = nil
+
+			Expect(selectProfile(&scorev1b1.Workload{})).To(Equal("web-service"))
+		})
+	})
+
+	Describe("selection hints", func() {
+		var config *scorev1b1.OrchestratorConfig
+
+		BeforeEach(func() {
+			config = &scorev1b1.OrchestratorConfig{
+				Spec: scorev1b1.OrchestratorConfigSpec{
+					Profiles: []scorev1b1.ProfileSpec{
+						{
+							Name: "web-service",
+							Backends: []scorev1b1.BackendSpec{
+								{
+									BackendId: "k8s-knative", RuntimeClass: "kubernetes", Priority: 100, Version: "1.0.0",
+									Constraints: &scorev1b1.ConstraintsSpec{Features: []string{"scale-to-zero"}},
+								},
+								{BackendId: "k8s-web", RuntimeClass: "kubernetes", Priority: 50, Version: "1.0.0"},
+							},
+						},
+					},
+					Defaults: scorev1b1.DefaultsSpec{Profile: "web-service"},
+				},
+			}
+		})
+
+		It("should merge spec.requirements with the annotation", func() {
+			workload := &scorev1b1.Workload{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{"score.dev/requirements": "monitoring, ,tls"},
+				},
+				Spec: scorev1b1.WorkloadSpec{Requirements: []string{"scale-to-zero"}},
+			}
+
+			Expect(Requirements(workload)).To(Equal([]string{"scale-to-zero", "monitoring", "tls"}))
+		})
+
+		It("should select a backend offering the features of spec.requirements", func() {
+			selector := NewProfileSelector(config, fake.NewClientBuilder().WithScheme(scheme).Build())
+			workload := &scorev1b1.Workload{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-workload", Namespace: "default"},
+				Spec:       scorev1b1.WorkloadSpec{Requirements: []string{"scale-to-zero"}},
+			}
+
+			result, err := selector.SelectBackend(context.Background(), workload)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.BackendID).To(Equal("k8s-knative"))
+		})
+
+		It("should accept hints that match the configuration", func() {
+			workload := &scorev1b1.Workload{
+				Spec: scorev1b1.WorkloadSpec{Profile: ptr.To("web-service"), Requirements: []string{"scale-to-zero"}},
+			}
+
+			Expect(ValidateHints(workload, config)).To(Succeed())
+		})
+
+		It("should reject a profile that is not configured", func() {
+			workload := &scorev1b1.Workload{Spec: scorev1b1.WorkloadSpec{Profile: ptr.To("batch")}}
+
+			err := ValidateHints(workload, config)
+
+			Expect(err).To(MatchError(ErrInvalidHint))
+			Expect(err.Error()).To(ContainSubstring("spec.profile"))
+		})
+
+		It("should reject requirements no backend offers", func() {
+			workload := &scorev1b1.Workload{Spec: scorev1b1.WorkloadSpec{Requirements: []string{"scale-to-zero", "gpu"}}}
+
+			err := ValidateHints(workload, config)
+
+			Expect(err).To(MatchError(ErrInvalidHint))
+			Expect(err.Error()).To(ContainSubstring("spec.requirements[1]"))
+		})
+
+		It("should not validate the annotations", func() {
+			workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{"score.dev/profile": "batch", "score.dev/requirements": "gpu"},
+			}}
+
+			Expect(ValidateHints(workload, config)).To(Succeed())
+		})
+	})
+
+	Describe("workload defaults", func() {
+		It("should override the defaults of the profile with those of the backend", func() {
+			minAvailable := intstr.FromInt32(1)
+			maxUnavailable := intstr.FromString("25%")
+			config := &scorev1b1.OrchestratorConfig{
+				Spec: scorev1b1.OrchestratorConfigSpec{
+					Profiles: []scorev1b1.ProfileSpec{{
+						Name: "web-service",
+						Defaults: &scorev1b1.WorkloadDefaultsSpec{
+							DisruptionBudget: &scorev1b1.DisruptionBudgetSpec{MinAvailable: &minAvailable},
+							Resources:        &scorev1b1.ResourceRequirements{Limits: map[string]string{"memory": "256Mi"}},
+						},
+						Backends: []scorev1b1.BackendSpec{{
+							BackendId: "k8s-web", RuntimeClass: "kubernetes", Priority: 100, Version: "1.0.0",
+							Defaults: &scorev1b1.WorkloadDefaultsSpec{
+								DisruptionBudget: &scorev1b1.DisruptionBudgetSpec{MaxUnavailable: &maxUnavailable},
+							},
+						}},
+					}},
+					Defaults: scorev1b1.DefaultsSpec{Profile: "web-service"},
+				},
+			}
+			selector := NewProfileSelector(config, fake.NewClientBuilder().WithScheme(scheme).Build())
+			workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "test-workload", Namespace: "default"}}
+
+			result, err := selector.SelectBackend(context.Background(), workload)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Defaults.DisruptionBudget).To(Equal(&scorev1b1.DisruptionBudgetSpec{MaxUnavailable: &maxUnavailable}))
+			Expect(result.Defaults.Resources.Limits).To(HaveKeyWithValue("memory", "256Mi"))
+		})
+	})
+
+	Describe("ConfigHash", func() {
+		It("should change only when selection-relevant configuration changes", func() {
+			config := &scorev1b1.OrchestratorConfig{
+				Spec: scorev1b1.OrchestratorConfigSpec{
+					Profiles: []scorev1b1.ProfileSpec{
+						{Name: "web-service", Backends: []scorev1b1.BackendSpec{{BackendId: "k8s-web", Priority: 100}}},
+					},
+					Defaults: scorev1b1.DefaultsSpec{Profile: "web-service"},
+				},
+			}
+			hash := ConfigHash(config)
+			Expect(hash).To(HaveLen(16))
+
+			config.Spec.Provisioners = []scorev1b1.ProvisionerSpec{{Type: "postgres"}}
+			Expect(ConfigHash(config)).To(Equal(hash))
+
+			config.Spec.Profiles[0].Backends[0].Priority = 200
+			Expect(ConfigHash(config)).ToNot(Equal(hash))
+		})
+	})
+
+	Describe("TemplateDigest", func() {
+		It("should change when the template content changes under the same ref", func() {
+			template := scorev1b1.TemplateSpec{Kind: "manifests", Ref: "registry.example.com/web:stable"}
+			digest := TemplateDigest(template)
+			Expect(digest).To(HavePrefix("sha256:"))
+			Expect(TemplateDigest(template)).To(Equal(digest))
+
+			template.Values = &runtime.RawExtension{Raw: []byte(`{"replicas":2}`)}
+			Expect(TemplateDigest(template)).ToNot(Equal(digest))
+		})
+	})
+
+	Describe("region constraints", func() {
+		var config *scorev1b1.OrchestratorConfig
+
+		BeforeEach(func() {
+			config = &scorev1b1.OrchestratorConfig{
+				Spec: scorev1b1.OrchestratorConfigSpec{
+					Profiles: []scorev1b1.ProfileSpec{
+						{
+							Name: "web-service",
+							Backends: []scorev1b1.BackendSpec{
+								{BackendId: "k8s-global", Priority: 200, Version: "1.0.0"},
+								{
+									BackendId:   "k8s-eu",
+									Priority:    100,
+									Version:     "1.0.0",
+									Constraints: &scorev1b1.ConstraintsSpec{Regions: []string{"eu-west-1", "eu-central-1"}},
+								},
+								{
+									BackendId:   "k8s-us",
+									Priority:    100,
+									Version:     "1.0.0",
+									Constraints: &scorev1b1.ConstraintsSpec{Regions: []string{"us-east-1"}},
+								},
+							},
+						},
+					},
+					Defaults: scorev1b1.DefaultsSpec{Profile: "web-service"},
+				},
+			}
+		})
+
+		newNode := func(name, region string) *corev1.Node {
+			return &corev1.Node{ObjectMeta: metav1.ObjectMeta{
+				Name:   name,
+				Labels: map[string]string{scorev1b1.DefaultRegionLabel: region},
+			}}
+		}
+
+		It("should prefer the backend pinned to the workload region", func() {
+			selector := NewProfileSelector(config, fake.NewClientBuilder().WithScheme(scheme).Build())
+			workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-workload",
+				Namespace: "default",
+				Labels:    map[string]string{scorev1b1.DefaultRegionLabel: "eu-central-1"},
+			}}
+
+			result, err := selector.SelectBackend(context.Background(), workload)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.BackendID).To(Equal("k8s-eu"))
+		})
+
+		It("should derive the region from cluster nodes using the configured label", func() {
+			config.Spec.Defaults.RegionLabel = "example.com/region"
+			node := newNode("node-a", "ignored")
+			node.Labels["example.com/region"] = "us-east-1"
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(node).Build()
+			selector := NewProfileSelector(config, k8sClient)
+			workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "test-workload", Namespace: "default"}}
+
+			result, err := selector.SelectBackend(context.Background(), workload)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.BackendID).To(Equal("k8s-us"))
+
+			explanation := Explain(context.Background(), k8sClient, workload, config)
+			Expect(explanation.Region).To(Equal("us-east-1"))
+			Expect(explanation.Selected).To(Equal("k8s-us"))
+		})
+
+		It("should fall back to region-agnostic backends when no backend is pinned to the region", func() {
+			selector := NewProfileSelector(config, fake.NewClientBuilder().WithScheme(scheme).Build())
+			workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-workload",
+				Namespace: "default",
+				Labels:    map[string]string{scorev1b1.DefaultRegionLabel: "ap-northeast-1"},
+			}}
+
+			result, err := selector.SelectBackend(context.Background(), workload)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.BackendID).To(Equal("k8s-global"))
+
+			explanation := Explain(context.Background(), nil, workload, config)
+			Expect(explanation.Region).To(Equal("ap-northeast-1"))
+			Expect(explanation.Selected).To(Equal("k8s-global"))
+			Expect(explanation.Backends).To(HaveLen(3))
+		})
+
+		It("should keep every backend when the region is unknown", func() {
+			selector := NewProfileSelector(config, fake.NewClientBuilder().WithScheme(scheme).Build())
+			workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "test-workload", Namespace: "default"}}
+
+			result, err := selector.SelectBackend(context.Background(), workload)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.BackendID).To(Equal("k8s-global"))
+		})
+
+		It("should pick the most common node region deterministically", func() {
+			nodes := []corev1.Node{
+				*newNode("a", "us-east-1"), *newNode("b", "eu-west-1"),
+				*newNode("c", "eu-west-1"), *newNode("d", "us-east-1"),
+				*newNode("e", "ap-south-1"),
+			}
+			Expect(clusterRegion(nodes, scorev1b1.DefaultRegionLabel)).To(Equal("eu-west-1"))
+			Expect(clusterRegion(nil, scorev1b1.DefaultRegionLabel)).To(BeEmpty())
+		})
+	})
+
+	Describe("runtime registration", func() {
+		var (
+			config   *scorev1b1.OrchestratorConfig
+			workload *scorev1b1.Workload
+		)
+
+		BeforeEach(func() {
+			config = &scorev1b1.OrchestratorConfig{
+				Spec: scorev1b1.OrchestratorConfigSpec{
+					Profiles: []scorev1b1.ProfileSpec{
+						{
+							Name: "web-service",
+							Backends: []scorev1b1.BackendSpec{
+								{BackendId: "ecs-web", RuntimeClass: "ecs", Priority: 200, Version: "1.0.0"},
+								{BackendId: "k8s-web", RuntimeClass: "kubernetes", Priority: 100, Version: "1.0.0"},
+							},
+						},
+					},
+					Defaults: scorev1b1.DefaultsSpec{Profile: "web-service", RequireRuntimeRegistration: true},
+				},
+			}
+			workload = &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "test-workload", Namespace: "default"}}
+		})
+
+		registration := func(runtimeClass string, renewed time.Time) *corev1.ConfigMap {
+			return runtimeregistry.Registration{
+				RuntimeClass:  runtimeClass,
+				RenewTime:     renewed,
+				LeaseDuration: time.Minute,
+			}.ConfigMap("score-system", "score-runtime-"+runtimeClass)
+		}
+
+		It("should skip backends whose runtime is not registered", func() {
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(registration("kubernetes", time.Now())).Build()
+			selector := NewProfileSelector(config, k8sClient)
+
+			result, err := selector.SelectBackend(context.Background(), workload)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.BackendID).To(Equal("k8s-web"))
+
+			explanation := Explain(context.Background(), k8sClient, workload, config)
+			Expect(explanation.Selected).To(Equal("k8s-web"))
+			Expect(explanation.Backends[1].BackendID).To(Equal("ecs-web"))
+			Expect(explanation.Backends[1].Rejection).To(ContainSubstring(`runtimeClass "ecs"`))
+		})
+
+		It("should report RuntimeUnavailable when every registration has expired", func() {
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(registration("kubernetes", time.Now().Add(-time.Hour))).Build()
+			selector := NewProfileSelector(config, k8sClient)
+
+			_, err := selector.SelectBackend(context.Background(), workload)
+
+			Expect(err).To(MatchError(ErrRuntimeUnavailable))
+		})
+
+		It("should ignore registrations unless the configuration requires them", func() {
+			config.Spec.Defaults.RequireRuntimeRegistration = false
+			selector := NewProfileSelector(config, fake.NewClientBuilder().WithScheme(scheme).Build())
+
+			result, err := selector.SelectBackend(context.Background(), workload)
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.BackendID).To(Equal("ecs-web"))
+		})
+	})
+
+	Describe("namespace restrictions", func() {
+		var config *scorev1b1.OrchestratorConfig
+
+		BeforeEach(func() {
+			config = &scorev1b1.OrchestratorConfig{
+				Spec: scorev1b1.OrchestratorConfigSpec{
+					Profiles: []scorev1b1.ProfileSpec{
+						{
+							Name: "batch",
+							Backends: []scorev1b1.BackendSpec{
+								{
+									BackendId: "k8s-gpu", RuntimeClass: "kubernetes", Priority: 200, Version: "1.0.0",
+									Constraints: &scorev1b1.ConstraintsSpec{Namespaces: []string{"ml-*"}},
+								},
+								{
+									BackendId: "k8s-tenant", RuntimeClass: "kubernetes", Priority: 100, Version: "1.0.0",
+									Constraints: &scorev1b1.ConstraintsSpec{
+										NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"tenant": "research"}},
+									},
+								},
+							},
+						},
+					},
+					Defaults: scorev1b1.DefaultsSpec{Profile: "batch"},
+				},
+			}
+		})
+
+		namespace := func(name string, labels map[string]string) *corev1.Namespace {
+			return &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+		}
+		workloadIn := func(namespace string) *scorev1b1.Workload {
+			return &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "train", Namespace: namespace}}
+		}
+
+		It("should select backends whose namespace patterns match", func() {
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespace("ml-vision", nil)).Build()
+			selector := NewProfileSelector(config, k8sClient)
+
+			result, err := selector.SelectBackend(context.Background(), workloadIn("ml-vision"))
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.BackendID).To(Equal("k8s-gpu"))
+		})
+
+		It("should select backends whose namespaceSelector matches the namespace labels", func() {
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(namespace("lab", map[string]string{"tenant": "research"})).Build()
+			selector := NewProfileSelector(config, k8sClient)
+
+			result, err := selector.SelectBackend(context.Background(), workloadIn("lab"))
+
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.BackendID).To(Equal("k8s-tenant"))
+
+			explanation := Explain(context.Background(), k8sClient, workloadIn("lab"), config)
+			Expect(explanation.Selected).To(Equal("k8s-tenant"))
+			Expect(explanation.Backends[1].BackendID).To(Equal("k8s-gpu"))
+			Expect(explanation.Backends[1].Rejection).To(ContainSubstring("allowed namespaces [ml-*]"))
+		})
+
+		It("should report BackendFiltered when no backend is allowed in the namespace", func() {
+			k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(namespace("default", nil)).Build()
+			selector := NewProfileSelector(config, k8sClient)
+
+			_, err := selector.SelectBackend(context.Background(), workloadIn("default"))
+
+			Expect(err).To(MatchError(ErrBackendFiltered))
+			Expect(err.Error()).To(ContainSubstring(`namespace "default"`))
+
+			explanation := Explain(context.Background(), k8sClient, workloadIn("default"), config)
+			Expect(explanation.Error).To(ContainSubstring(ErrBackendFiltered.Error()))
+			Expect(explanation.Backends).To(HaveLen(2))
+		})
+
+		It("should not select backends with a namespaceSelector without a client", func() {
+			selector := NewProfileSelector(config, nil)
+
+			_, err := selector.SelectBackend(context.Background(), workloadIn("lab"))
+
+			Expect(err).To(MatchError(ErrBackendFiltered))
+		})
+	})
 })
@@ -0,0 +1,95 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// Shard identifies the slice of Workloads reconciled by one group of orchestrator replicas.
+// Workloads are assigned by hashing namespace/name onto Count shards. The zero value disables
+// sharding and owns every Workload.
+type Shard struct {
+	// Index of this shard in [0, Count)
+	Index int
+	// Count is the total number of shards; values below 2 disable sharding
+	Count int
+}
+
+// Validate checks that the shard index is within the shard count.
+func (s Shard) Validate() error {
+	if s.Count < 0 {
+		return fmt.Errorf("shard count must not be negative, got %d", s.Count)
+	}
+	if s.Index < 0 || (s.Enabled() && s.Index >= s.Count) {
+		return fmt.Errorf("shard index %d is out of range for %d shards", s.Index, s.Count)
+	}
+	return nil
+}
+
+// Enabled reports whether Workloads are split across more than one shard.
+func (s Shard) Enabled() bool {
+	return s.Count > 1
+}
+
+// Owns reports whether the Workload identified by key is reconciled by this shard.
+func (s Shard) Owns(key types.NamespacedName) bool {
+	if !s.Enabled() {
+		return true
+	}
+	return For(key, s.Count) == s.Index
+}
+
+// LeaderElectionID returns the lease name for this shard. Replicas started with the same shard
+// index compete for one lease, so each shard has exactly one active leader and the others stand by.
+func (s Shard) LeaderElectionID(base string) string {
+	if !s.Enabled() {
+		return base
+	}
+	return fmt.Sprintf("%s-shard-%d", base, s.Index)
+}
+
+// For returns the shard in [0, count) that owns the Workload identified by key.
+// The assignment is stable across replicas and restarts for a given count.
+func For(key types.NamespacedName, count int) int {
+	if count <= 1 {
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key.Namespace + "/" + key.Name))
+	return int(h.Sum32() % uint32(count))
+}
+
+// IndexForPod returns the shard in [0, count) of the replica running in the pod podName: the ordinal suffix of
+// the pod name (e.g. 5 for "score-orchestrator-5" of a StatefulSet) modulo count. Pods without an ordinal
+// suffix cannot be assigned a shard when sharding is enabled.
+func IndexForPod(podName string, count int) (int, error) {
+	if count <= 1 {
+		return 0, nil
+	}
+	separator := strings.LastIndex(podName, "-")
+	ordinal, err := strconv.Atoi(podName[separator+1:])
+	if separator < 0 || err != nil || ordinal < 0 {
+		return 0, fmt.Errorf("pod name %q has no ordinal suffix to derive the shard index from", podName)
+	}
+	return ordinal % count, nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestShardValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		shard   Shard
+		wantErr bool
+	}{
+		{"disabled", Shard{}, false},
+		{"single shard", Shard{Index: 0, Count: 1}, false},
+		{"last shard", Shard{Index: 3, Count: 4}, false},
+		{"index out of range", Shard{Index: 4, Count: 4}, true},
+		{"negative index", Shard{Index: -1, Count: 4}, true},
+		{"negative count", Shard{Count: -1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.shard.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestShardsPartitionWorkloads(t *testing.T) {
+	const count = 4
+	perShard := make([]int, count)
+
+	for i := 0; i < 1000; i++ {
+		key := types.NamespacedName{Namespace: fmt.Sprintf("team-%d", i%7), Name: fmt.Sprintf("app-%d", i)}
+		owners := 0
+		for index := 0; index < count; index++ {
+			if (Shard{Index: index, Count: count}).Owns(key) {
+				owners++
+				perShard[index]++
+			}
+		}
+		if owners != 1 {
+			t.Fatalf("Workload %s is owned by %d shards, want exactly 1", key, owners)
+		}
+		if !(Shard{}).Owns(key) {
+			t.Fatalf("disabled shard does not own Workload %s", key)
+		}
+	}
+
+	for index, n := range perShard {
+		if n == 0 {
+			t.Errorf("shard %d owns no Workloads", index)
+		}
+	}
+}
+
+func TestShardLeaderElectionID(t *testing.T) {
+	if got := (Shard{}).LeaderElectionID("lease"); got != "lease" {
+		t.Errorf("LeaderElectionID() = %q, want %q", got, "lease")
+	}
+	if got := (Shard{Index: 2, Count: 3}).LeaderElectionID("lease"); got != "lease-shard-2" {
+		t.Errorf("LeaderElectionID() = %q, want %q", got, "lease-shard-2")
+	}
+}
+
+func TestIndexForPod(t *testing.T) {
+	tests := []struct {
+		name    string
+		podName string
+		count   int
+		want    int
+		wantErr bool
+	}{
+		{"sharding disabled", "score-orchestrator-7d9f8-x2k4p", 1, 0, false},
+		{"ordinal below count", "score-orchestrator-2", 4, 2, false},
+		{"ordinal wraps around", "score-orchestrator-5", 4, 1, false},
+		{"no ordinal", "score-orchestrator-7d9f8-x2k4p", 4, 0, true},
+		{"no separator", "orchestrator", 4, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := IndexForPod(tt.podName, tt.count)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("IndexForPod() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("IndexForPod() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,18 +17,34 @@ limitations under the License.
 package status
 
 import (
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sort"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
 	"github.com/cappyzawa/score-orchestrator/internal/conditions"
 )
 
-// ClaimAggregation holds the aggregated claim information
+// ClaimAggregation holds the aggregated claim information.
+// Ready, Reason and Message only cover the claims of required resources.
 type ClaimAggregation struct {
 	Ready   bool
 	Reason  string
 	Message string
 	Claims  []scorev1b1.ClaimSummary
+
+	// OptionalPending and OptionalFailed are the sorted keys of the optional claims that are not bound yet
+	// and that failed
+	OptionalPending []string
+	OptionalFailed  []string
+}
+
+// HasOptional reports whether any claim of the aggregation is for an optional resource
+func (a ClaimAggregation) HasOptional() bool {
+	for _, claim := range a.Claims {
+		if claim.Optional {
+			return true
+		}
+	}
+	return false
 }
 
 // AggregateClaimStatuses processes all ResourceClaims and returns aggregated status
@@ -43,15 +59,18 @@ func AggregateClaimStatuses(claims []scorev1b1.ResourceClaim) ClaimAggregation {
 	}
 
 	summaries := make([]scorev1b1.ClaimSummary, 0, len(claims))
-	var boundCount, failedCount int
+	var requiredCount, boundCount, failedCount int
+	var optionalPending, optionalFailed []string
 
 	for _, claim := range claims {
 		summary := scorev1b1.ClaimSummary{
 			Key:              claim.Spec.Key,
+			Type:             claim.Spec.Type,
 			Phase:            claim.Status.Phase,
 			Reason:           claim.Status.Reason,
 			Message:          claim.Status.Message,
 			OutputsAvailable: claim.Status.OutputsAvailable,
+			Optional:         claim.Spec.Optional,
 		}
 
 		// Handle empty phase as Pending
@@ -61,19 +80,40 @@ func AggregateClaimStatuses(claims []scorev1b1.ResourceClaim) ClaimAggregation {
 
 		summaries = append(summaries, summary)
 
-		// Count phases for overall status
-		switch claim.Status.Phase {
-		case scorev1b1.ResourceClaimPhaseBound:
-			if claim.Status.OutputsAvailable {
-				boundCount++
+		// Outputs of a claim whose updated spec was not provisioned yet are not ready
+		bound := claim.Status.Phase == scorev1b1.ResourceClaimPhaseBound && claim.Status.OutputsAvailable && !ClaimStatusStale(&claim)
+		failed := claim.Status.Phase == scorev1b1.ResourceClaimPhaseFailed
+
+		// Optional claims are reported separately and do not affect the overall status
+		if claim.Spec.Optional {
+			switch {
+			case failed:
+				optionalFailed = append(optionalFailed, claim.Spec.Key)
+			case !bound:
+				optionalPending = append(optionalPending, claim.Spec.Key)
 			}
-		case scorev1b1.ResourceClaimPhaseFailed:
+			continue
+		}
+
+		// Count phases for overall status
+		requiredCount++
+		switch {
+		case bound:
+			boundCount++
+		case failed:
 			failedCount++
 		}
 	}
 
+	// Claims are listed in arbitrary order; sort them so the status does not change between reconciles
+	sort.Slice(summaries, func(i, j int) bool {
+		return summaries[i].Key < summaries[j].Key
+	})
+
+	sort.Strings(optionalPending)
+	sort.Strings(optionalFailed)
+
 	// Determine overall claim readiness
-	totalClaims := len(claims)
 	var ready bool
 	var reason, message string
 
@@ -81,7 +121,7 @@ func AggregateClaimStatuses(claims []scorev1b1.ResourceClaim) ClaimAggregation {
 		ready = false
 		reason = conditions.ReasonClaimFailed
 		message = conditions.MessageClaimsFailed
-	} else if boundCount == totalClaims {
+	} else if boundCount == requiredCount {
 		ready = true
 		reason = conditions.ReasonSucceeded
 		message = conditions.MessageAllClaimsReady
@@ -92,29 +132,11 @@ func AggregateClaimStatuses(claims []scorev1b1.ResourceClaim) ClaimAggregation {
 	}
 
 	return ClaimAggregation{
-		Ready:   ready,
-		Reason:  reason,
-		Message: message,
-		Claims:  summaries,
-	}
-}
-
-// UpdateWorkloadStatusFromAggregation updates the Workload status based on claim aggregation
-func UpdateWorkloadStatusFromAggregation(workload *scorev1b1.Workload, agg ClaimAggregation) {
-	// Update claims summary
-	workload.Status.Claims = agg.Claims
-
-	// Update ClaimsReady condition
-	var status metav1.ConditionStatus
-	if agg.Ready {
-		status = metav1.ConditionTrue
-	} else {
-		status = metav1.ConditionFalse
+		Ready:           ready,
+		Reason:          reason,
+		Message:         message,
+		Claims:          summaries,
+		OptionalPending: optionalPending,
+		OptionalFailed:  optionalFailed,
 	}
-
-	conditions.SetCondition(&workload.Status.Conditions,
-		conditions.ConditionClaimsReady,
-		status,
-		agg.Reason,
-		agg.Message)
 }
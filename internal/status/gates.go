@@ -0,0 +1,62 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"fmt"
+	"strings"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// UnmetReadinessGates returns the readiness gates of the plan whose condition is not satisfied, each with
+// the reason it is not: the condition was not reported, is not True, or describes an older plan generation.
+// Conditions that do not report observedGeneration are trusted.
+func UnmetReadinessGates(plan *scorev1b1.WorkloadPlan) []string {
+	var unmet []string
+	for _, gate := range plan.Spec.ReadinessGates {
+		condition := apimeta.FindStatusCondition(plan.Status.Conditions, gate.ConditionType)
+		switch {
+		case condition == nil:
+			unmet = append(unmet, fmt.Sprintf("%s (not reported)", gate.ConditionType))
+		case condition.ObservedGeneration != 0 && condition.ObservedGeneration < plan.Generation:
+			unmet = append(unmet, fmt.Sprintf("%s (stale)", gate.ConditionType))
+		case condition.Status != metav1.ConditionTrue:
+			unmet = append(unmet, fmt.Sprintf("%s (%s)", gate.ConditionType, conditionDetail(condition)))
+		}
+	}
+	return unmet
+}
+
+// ReadinessGatesMessage describes the unmet readiness gates returned by UnmetReadinessGates
+func ReadinessGatesMessage(unmet []string) string {
+	return "Readiness gates not satisfied: " + strings.Join(unmet, ", ")
+}
+
+// conditionDetail summarizes why a condition is not True, preferring its message over its reason
+func conditionDetail(condition *metav1.Condition) string {
+	switch {
+	case condition.Message != "":
+		return condition.Message
+	case condition.Reason != "":
+		return condition.Reason
+	}
+	return string(condition.Status)
+}
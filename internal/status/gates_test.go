@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestUnmetReadinessGates(t *testing.T) {
+	gates := []scorev1b1.ReadinessGate{{ConditionType: "MeshInjected"}, {ConditionType: "DNSReady"}}
+
+	tests := []struct {
+		name       string
+		conditions []metav1.Condition
+		want       []string
+	}{
+		{name: "not reported", want: []string{"MeshInjected (not reported)", "DNSReady (not reported)"}},
+		{
+			name: "all true",
+			conditions: []metav1.Condition{
+				{Type: "MeshInjected", Status: metav1.ConditionTrue, ObservedGeneration: 2},
+				{Type: "DNSReady", Status: metav1.ConditionTrue},
+			},
+		},
+		{
+			name: "false with message",
+			conditions: []metav1.Condition{
+				{Type: "MeshInjected", Status: metav1.ConditionTrue},
+				{Type: "DNSReady", Status: metav1.ConditionFalse, Reason: "RecordPending", Message: "record not propagated"},
+			},
+			want: []string{"DNSReady (record not propagated)"},
+		},
+		{
+			name: "unknown without message",
+			conditions: []metav1.Condition{
+				{Type: "MeshInjected", Status: metav1.ConditionUnknown, Reason: "Checking"},
+				{Type: "DNSReady", Status: metav1.ConditionTrue},
+			},
+			want: []string{"MeshInjected (Checking)"},
+		},
+		{
+			name: "stale",
+			conditions: []metav1.Condition{
+				{Type: "MeshInjected", Status: metav1.ConditionTrue, ObservedGeneration: 1},
+				{Type: "DNSReady", Status: metav1.ConditionTrue},
+			},
+			want: []string{"MeshInjected (stale)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &scorev1b1.WorkloadPlan{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       scorev1b1.WorkloadPlanSpec{ReadinessGates: gates},
+				Status:     scorev1b1.WorkloadPlanStatus{Conditions: tt.conditions},
+			}
+			if got := UnmetReadinessGates(plan); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("UnmetReadinessGates() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// ClaimStatusStale reports whether the provisioner has not yet observed the current spec of the claim,
+// so that its phase and outputs may still describe the previous spec.
+// Provisioners that do not report observedGeneration are trusted.
+func ClaimStatusStale(claim *scorev1b1.ResourceClaim) bool {
+	return claim.Status.ObservedGeneration != 0 && claim.Status.ObservedGeneration < claim.Generation
+}
+
+// PlanStatusStale reports whether the runtime has not yet observed the current spec of the plan,
+// so that its phase may still describe the previous plan.
+// Runtimes that do not report observedGeneration are trusted.
+func PlanStatusStale(plan *scorev1b1.WorkloadPlan) bool {
+	return plan.Status.ObservedGeneration != 0 && plan.Status.ObservedGeneration < plan.Generation
+}
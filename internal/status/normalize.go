@@ -64,13 +64,16 @@ func normalizeReason(reason string) string {
 		"ComplianceFailure": "PolicyViolation",
 		"AdmissionDenied":   "PolicyViolation",
 
-		// Binding issues
-		"Pending":             "BindingPending",
-		"Waiting":             "BindingPending",
-		"Provisioning":        "BindingPending",
-		"BindingFailed":       "BindingFailed",
-		"ProvisioningFailed":  "BindingFailed",
-		"ResourceUnavailable": "BindingFailed",
+		// Claim issues; the Binding reasons predate the rename of ResourceBinding to ResourceClaim (ADR-0006)
+		"Pending":             "ClaimPending",
+		"Waiting":             "ClaimPending",
+		"Provisioning":        "ClaimPending",
+		"BindingPending":      "ClaimPending",
+		"ClaimPending":        "ClaimPending",
+		"BindingFailed":       "ClaimFailed",
+		"ProvisioningFailed":  "ClaimFailed",
+		"ResourceUnavailable": "ClaimFailed",
+		"ClaimFailed":         "ClaimFailed",
 
 		// Runtime projection errors
 		"ProjectionError":     "ProjectionError",
@@ -132,10 +135,11 @@ func normalizeConditionType(conditionType string) string {
 		"Validated": "InputsValid",
 		"SpecValid": "InputsValid",
 
-		// Claims/binding mappings
+		// Claims mappings, including the BindingsReady condition of ResourceBinding (ADR-0006)
 		"Bound":         "ClaimsReady",
 		"Provisioned":   "ClaimsReady",
 		"ResourceReady": "ClaimsReady",
+		"BindingsReady": "ClaimsReady",
 	}
 
 	if normalized, exists := typeMap[conditionType]; exists {
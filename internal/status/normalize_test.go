@@ -0,0 +1,54 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package status
+
+import (
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNormalizeConditions(t *testing.T) {
+	tests := []struct {
+		name       string
+		condition  metav1.Condition
+		wantType   string
+		wantReason string
+	}{
+		{name: "runtime ready", condition: metav1.Condition{Type: "Available", Reason: "Ready"}, wantType: "RuntimeReady", wantReason: "Succeeded"},
+		{name: "claim pending", condition: metav1.Condition{Type: "ClaimsReady", Reason: "Provisioning"}, wantType: "ClaimsReady", wantReason: "ClaimPending"},
+		{name: "claim failed", condition: metav1.Condition{Type: "ClaimsReady", Reason: "ClaimFailed"}, wantType: "ClaimsReady", wantReason: "ClaimFailed"},
+		{name: "legacy binding pending", condition: metav1.Condition{Type: "BindingsReady", Reason: "BindingPending"}, wantType: "ClaimsReady", wantReason: "ClaimPending"},
+		{name: "legacy binding failed", condition: metav1.Condition{Type: "BindingsReady", Reason: "BindingFailed"}, wantType: "ClaimsReady", wantReason: "ClaimFailed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalized := NormalizeConditions([]metav1.Condition{tt.condition})
+			if len(normalized) != 1 {
+				t.Fatalf("NormalizeConditions() = %v, want one condition", normalized)
+			}
+			if normalized[0].Type != tt.wantType || normalized[0].Reason != tt.wantReason {
+				t.Errorf("NormalizeConditions() = %s/%s, want %s/%s", normalized[0].Type, normalized[0].Reason, tt.wantType, tt.wantReason)
+			}
+		})
+	}
+
+	if normalized := NormalizeConditions([]metav1.Condition{{Type: "PodScheduled", Reason: "Unschedulable"}}); len(normalized) != 0 {
+		t.Errorf("NormalizeConditions() kept unmappable conditions: %v", normalized)
+	}
+}
@@ -0,0 +1,407 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package summary maintains the WorkloadSummary of each namespace from the Workload statuses written by the
+// StatusManager, so that dashboards read readiness counts, claim failure hotspots and the slowest pending
+// Workloads without listing and joining Workloads, ResourceClaims and WorkloadPlans themselves.
+package summary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+	"github.com/cappyzawa/score-orchestrator/internal/sharding"
+)
+
+// Defaults of the Aggregator and limits of the lists of a summary
+const (
+	// DefaultFlushInterval is how often changed summaries are written
+	DefaultFlushInterval = 15 * time.Second
+	// DefaultResyncInterval is how often the summaries are rebuilt from a list of the Workloads, which catches
+	// Workloads that were deleted without going through the deletion pipeline
+	DefaultResyncInterval = 10 * time.Minute
+	// MaxClaimFailures is the number of resource types reported in claimFailures
+	MaxClaimFailures = 10
+	// MaxClaimFailureWorkloads is the number of Workloads listed per resource type in claimFailures
+	MaxClaimFailureWorkloads = 10
+	// MaxSlowestPending is the number of Workloads reported in slowestPending
+	MaxSlowestPending = 5
+)
+
+// +kubebuilder:rbac:groups=score.dev,resources=workloads,verbs=get;list;watch
+// +kubebuilder:rbac:groups=score.dev,resources=workloadsummaries,verbs=get;list;watch;create;update;delete
+// +kubebuilder:rbac:groups=score.dev,resources=workloadsummaries/status,verbs=get;update;patch
+
+// Name returns the name of the WorkloadSummary maintained by the shard in each namespace
+func Name(shard sharding.Shard) string {
+	if !shard.Enabled() {
+		return scorev1b1.WorkloadSummaryName
+	}
+	return fmt.Sprintf("%s-%d", scorev1b1.WorkloadSummaryName, shard.Index)
+}
+
+// entry is what the summary needs to know about one Workload
+type entry struct {
+	ready  metav1.ConditionStatus
+	reason string
+	// since is when the Workload stopped being ready, or when it was created
+	since time.Time
+	// failedClaimTypes are the resource types of the failed claims of the Workload, sorted
+	failedClaimTypes []string
+}
+
+// equal reports whether two entries summarize the same. Times are compared at the precision the API keeps.
+func (e entry) equal(other entry) bool {
+	return e.ready == other.ready && e.reason == other.reason &&
+		e.since.Truncate(time.Second).Equal(other.since.Truncate(time.Second)) &&
+		slices.Equal(e.failedClaimTypes, other.failedClaimTypes)
+}
+
+// entriesEqual reports whether two sets of entries summarize the same
+func entriesEqual(a, b map[string]entry) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for name, e := range a {
+		if other, ok := b[name]; !ok || !e.equal(other) {
+			return false
+		}
+	}
+	return true
+}
+
+// entryFor derives the entry of a Workload from its status
+func entryFor(workload *scorev1b1.Workload) entry {
+	e := entry{ready: metav1.ConditionUnknown, since: workload.CreationTimestamp.Time}
+	if ready := conditions.GetCondition(workload.Status.Conditions, conditions.ConditionReady); ready != nil {
+		e.ready = ready.Status
+		e.reason = ready.Reason
+		if ready.Status != metav1.ConditionTrue && !ready.LastTransitionTime.IsZero() {
+			e.since = ready.LastTransitionTime.Time
+		}
+	}
+	for _, claim := range workload.Status.Claims {
+		if claim.Phase == scorev1b1.ResourceClaimPhaseFailed {
+			e.failedClaimTypes = append(e.failedClaimTypes, claim.Type)
+		}
+	}
+	sort.Strings(e.failedClaimTypes)
+	return e
+}
+
+// Aggregator keeps an entry per Workload, updated as the StatusManager writes Workload statuses, and
+// periodically writes the WorkloadSummary of the namespaces whose entries changed. It is a leader-elected
+// Runnable; a nil Aggregator aggregates nothing.
+type Aggregator struct {
+	client client.Client
+	shard  sharding.Shard
+
+	// Interval between flushes. Defaults to DefaultFlushInterval.
+	Interval time.Duration
+	// ResyncInterval between rebuilds from a list of the Workloads. Defaults to DefaultResyncInterval.
+	ResyncInterval time.Duration
+
+	mu         sync.Mutex
+	namespaces map[string]map[string]entry
+	dirty      map[string]bool
+	now        func() time.Time
+}
+
+// NewAggregator creates an Aggregator for the Workloads reconciled by the shard
+func NewAggregator(c client.Client, shard sharding.Shard) *Aggregator {
+	return &Aggregator{
+		client:     c,
+		shard:      shard,
+		namespaces: make(map[string]map[string]entry),
+		dirty:      make(map[string]bool),
+		now:        time.Now,
+	}
+}
+
+// Observe records the current status of the Workload
+func (a *Aggregator) Observe(workload *scorev1b1.Workload) {
+	if a == nil {
+		return
+	}
+	e := entryFor(workload)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	entries := a.namespaces[workload.Namespace]
+	if entries == nil {
+		entries = make(map[string]entry)
+		a.namespaces[workload.Namespace] = entries
+	}
+	if current, ok := entries[workload.Name]; ok && current.equal(e) {
+		return
+	}
+	entries[workload.Name] = e
+	a.dirty[workload.Namespace] = true
+}
+
+// Forget removes a deleted Workload from the summary of its namespace
+func (a *Aggregator) Forget(key types.NamespacedName) {
+	if a == nil {
+		return
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	entries := a.namespaces[key.Namespace]
+	if _, ok := entries[key.Name]; !ok {
+		return
+	}
+	delete(entries, key.Name)
+	if len(entries) == 0 {
+		delete(a.namespaces, key.Namespace)
+	}
+	a.dirty[key.Namespace] = true
+}
+
+// Start rebuilds the summaries from the Workloads and then writes the changed summaries once per interval
+// until ctx is cancelled
+func (a *Aggregator) Start(ctx context.Context) error {
+	interval := a.Interval
+	if interval <= 0 {
+		interval = DefaultFlushInterval
+	}
+	resyncInterval := a.ResyncInterval
+	if resyncInterval <= 0 {
+		resyncInterval = DefaultResyncInterval
+	}
+
+	logger := log.FromContext(ctx).WithName("workload-summary")
+	var lastResync time.Time
+	wait.UntilWithContext(ctx, func(ctx context.Context) {
+		if now := a.now(); now.Sub(lastResync) >= resyncInterval {
+			if err := a.Resync(ctx); err != nil {
+				logger.Error(err, "Failed to rebuild Workload summaries")
+			} else {
+				lastResync = now
+			}
+		}
+		if err := a.Flush(ctx); err != nil {
+			logger.Error(err, "Failed to write Workload summaries")
+		}
+	}, interval)
+	return nil
+}
+
+// NeedLeaderElection ensures only the leader writes summaries
+func (a *Aggregator) NeedLeaderElection() bool {
+	return true
+}
+
+// Resync replaces the entries with the Workloads reconciled by the shard, and marks the namespaces of
+// existing summaries as changed so that summaries of namespaces without Workloads are deleted
+func (a *Aggregator) Resync(ctx context.Context) error {
+	var workloads scorev1b1.WorkloadList
+	if err := a.client.List(ctx, &workloads); err != nil {
+		return fmt.Errorf("failed to list Workloads: %w", err)
+	}
+	var summaries scorev1b1.WorkloadSummaryList
+	if err := a.client.List(ctx, &summaries); err != nil {
+		return fmt.Errorf("failed to list Workload summaries: %w", err)
+	}
+
+	namespaces := make(map[string]map[string]entry)
+	for i := range workloads.Items {
+		workload := &workloads.Items[i]
+		if !a.shard.Owns(client.ObjectKeyFromObject(workload)) {
+			continue
+		}
+		if namespaces[workload.Namespace] == nil {
+			namespaces[workload.Namespace] = make(map[string]entry)
+		}
+		namespaces[workload.Namespace][workload.Name] = entryFor(workload)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for namespace, entries := range namespaces {
+		if !entriesEqual(a.namespaces[namespace], entries) {
+			a.dirty[namespace] = true
+		}
+	}
+	for namespace := range a.namespaces {
+		if _, ok := namespaces[namespace]; !ok {
+			a.dirty[namespace] = true
+		}
+	}
+	name := Name(a.shard)
+	for i := range summaries.Items {
+		if summary := &summaries.Items[i]; summary.Name == name {
+			if _, ok := namespaces[summary.Namespace]; !ok {
+				a.dirty[summary.Namespace] = true
+			}
+		}
+	}
+	a.namespaces = namespaces
+	return nil
+}
+
+// Flush writes the summaries of the namespaces whose entries changed since the last flush. Summaries that
+// could not be written are retried on the next flush.
+func (a *Aggregator) Flush(ctx context.Context) error {
+	a.mu.Lock()
+	now := a.now()
+	statuses := make(map[string]*scorev1b1.WorkloadSummaryStatus, len(a.dirty))
+	for namespace := range a.dirty {
+		if entries := a.namespaces[namespace]; len(entries) > 0 {
+			statuses[namespace] = summarize(entries, now)
+		} else {
+			statuses[namespace] = nil
+		}
+	}
+	a.dirty = make(map[string]bool)
+	a.mu.Unlock()
+
+	var errs []error
+	for namespace, status := range statuses {
+		if err := a.write(ctx, namespace, status); err != nil {
+			errs = append(errs, err)
+			a.mu.Lock()
+			a.dirty[namespace] = true
+			a.mu.Unlock()
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// write writes the summary of the namespace, or deletes it when the namespace has no Workloads left
+func (a *Aggregator) write(ctx context.Context, namespace string, status *scorev1b1.WorkloadSummaryStatus) error {
+	key := types.NamespacedName{Namespace: namespace, Name: Name(a.shard)}
+	summary := &scorev1b1.WorkloadSummary{}
+	err := a.client.Get(ctx, key, summary)
+	switch {
+	case status == nil && apierrors.IsNotFound(err):
+		return nil
+	case status == nil && err == nil:
+		if err := a.client.Delete(ctx, summary); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete Workload summary %s: %w", key, err)
+		}
+		return nil
+	case apierrors.IsNotFound(err):
+		summary = &scorev1b1.WorkloadSummary{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}
+		if err := a.client.Create(ctx, summary); err != nil {
+			return fmt.Errorf("failed to create Workload summary %s: %w", key, err)
+		}
+	case err != nil:
+		return fmt.Errorf("failed to get Workload summary %s: %w", key, err)
+	}
+
+	// The update time alone does not warrant a write
+	current := summary.Status.DeepCopy()
+	current.LastUpdateTime = status.LastUpdateTime
+	if equality.Semantic.DeepEqual(current, status) {
+		return nil
+	}
+	summary.Status = *status
+	if err := a.client.Status().Update(ctx, summary); err != nil {
+		return fmt.Errorf("failed to update Workload summary %s: %w", key, err)
+	}
+	return nil
+}
+
+// summarize computes the summary of the entries of a namespace at the given time
+func summarize(entries map[string]entry, now time.Time) *scorev1b1.WorkloadSummaryStatus {
+	status := &scorev1b1.WorkloadSummaryStatus{
+		Workloads:      int32(len(entries)),
+		LastUpdateTime: &metav1.Time{Time: now.Truncate(time.Second)},
+	}
+
+	failures := make(map[string]*scorev1b1.ClaimFailureSummary)
+	var pending []scorev1b1.PendingWorkloadSummary
+	for name, e := range entries {
+		switch e.ready {
+		case metav1.ConditionTrue:
+			status.Ready++
+		case metav1.ConditionFalse:
+			status.NotReady++
+			if e.reason != "" {
+				if status.Reasons == nil {
+					status.Reasons = make(map[string]int32)
+				}
+				status.Reasons[e.reason]++
+			}
+		default:
+			status.Unknown++
+		}
+		if e.ready != metav1.ConditionTrue {
+			pending = append(pending, scorev1b1.PendingWorkloadSummary{
+				Name:   name,
+				Reason: e.reason,
+				Since:  metav1.NewTime(e.since.Truncate(time.Second)),
+			})
+		}
+
+		for i, claimType := range e.failedClaimTypes {
+			failure := failures[claimType]
+			if failure == nil {
+				failure = &scorev1b1.ClaimFailureSummary{Type: claimType}
+				failures[claimType] = failure
+			}
+			failure.Failed++
+			// A Workload with several failed claims of the type is listed once
+			if i == 0 || e.failedClaimTypes[i-1] != claimType {
+				failure.Workloads = append(failure.Workloads, name)
+			}
+		}
+	}
+
+	for _, failure := range failures {
+		sort.Strings(failure.Workloads)
+		if len(failure.Workloads) > MaxClaimFailureWorkloads {
+			failure.Workloads = failure.Workloads[:MaxClaimFailureWorkloads]
+		}
+		status.ClaimFailures = append(status.ClaimFailures, *failure)
+	}
+	sort.Slice(status.ClaimFailures, func(i, j int) bool {
+		if status.ClaimFailures[i].Failed != status.ClaimFailures[j].Failed {
+			return status.ClaimFailures[i].Failed > status.ClaimFailures[j].Failed
+		}
+		return status.ClaimFailures[i].Type < status.ClaimFailures[j].Type
+	})
+	if len(status.ClaimFailures) > MaxClaimFailures {
+		status.ClaimFailures = status.ClaimFailures[:MaxClaimFailures]
+	}
+
+	sort.Slice(pending, func(i, j int) bool {
+		if !pending[i].Since.Equal(&pending[j].Since) {
+			return pending[i].Since.Before(&pending[j].Since)
+		}
+		return pending[i].Name < pending[j].Name
+	})
+	if len(pending) > MaxSlowestPending {
+		pending = pending[:MaxSlowestPending]
+	}
+	status.SlowestPending = pending
+	return status
+}
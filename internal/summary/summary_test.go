@@ -0,0 +1,203 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package summary
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+	"github.com/cappyzawa/score-orchestrator/internal/sharding"
+)
+
+var (
+	created = time.Date(2025, 1, 2, 3, 0, 0, 0, time.UTC)
+	now     = created.Add(time.Hour)
+)
+
+func newTestAggregator(t *testing.T, shard sharding.Shard, objects ...client.Object) (*Aggregator, client.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).
+		WithStatusSubresource(&scorev1b1.WorkloadSummary{}).Build()
+	aggregator := NewAggregator(c, shard)
+	aggregator.now = func() time.Time { return now }
+	return aggregator, c
+}
+
+func newWorkload(namespace, name string, ready metav1.ConditionStatus, reason string, since time.Time, claims ...scorev1b1.ClaimSummary) *scorev1b1.Workload {
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, CreationTimestamp: metav1.NewTime(created)},
+		Status:     scorev1b1.WorkloadStatus{Claims: claims},
+	}
+	if ready != metav1.ConditionUnknown {
+		workload.Status.Conditions = []metav1.Condition{{
+			Type:               conditions.ConditionReady,
+			Status:             ready,
+			Reason:             reason,
+			LastTransitionTime: metav1.NewTime(since),
+		}}
+	}
+	return workload
+}
+
+func getSummary(t *testing.T, c client.Client, namespace, name string) *scorev1b1.WorkloadSummary {
+	t.Helper()
+	summary := &scorev1b1.WorkloadSummary{}
+	if err := c.Get(context.Background(), types.NamespacedName{Namespace: namespace, Name: name}, summary); err != nil {
+		t.Fatalf("failed to get Workload summary: %v", err)
+	}
+	return summary
+}
+
+func TestFlush(t *testing.T) {
+	aggregator, c := newTestAggregator(t, sharding.Shard{})
+	failedDB := scorev1b1.ClaimSummary{Key: "db", Type: "postgres", Phase: scorev1b1.ResourceClaimPhaseFailed}
+	failedCache := scorev1b1.ClaimSummary{Key: "cache", Type: "redis", Phase: scorev1b1.ResourceClaimPhaseFailed}
+
+	aggregator.Observe(newWorkload("team-a", "web", metav1.ConditionTrue, conditions.ReasonSucceeded, created))
+	aggregator.Observe(newWorkload("team-a", "api", metav1.ConditionFalse, conditions.ReasonClaimFailed, created.Add(10*time.Minute), failedDB, failedCache))
+	aggregator.Observe(newWorkload("team-a", "worker", metav1.ConditionFalse, conditions.ReasonClaimFailed, created.Add(20*time.Minute), failedDB))
+	aggregator.Observe(newWorkload("team-a", "batch", metav1.ConditionUnknown, "", time.Time{}))
+	aggregator.Observe(newWorkload("team-b", "web", metav1.ConditionTrue, conditions.ReasonSucceeded, created))
+
+	if err := aggregator.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	status := getSummary(t, c, "team-a", scorev1b1.WorkloadSummaryName).Status
+	if status.Workloads != 4 || status.Ready != 1 || status.NotReady != 2 || status.Unknown != 1 {
+		t.Errorf("counts = %d/%d/%d/%d, want 4 Workloads, 1 ready, 2 not ready, 1 unknown",
+			status.Workloads, status.Ready, status.NotReady, status.Unknown)
+	}
+	if status.Reasons[conditions.ReasonClaimFailed] != 2 || len(status.Reasons) != 1 {
+		t.Errorf("reasons = %v, want 2 ClaimFailed", status.Reasons)
+	}
+	wantFailures := []scorev1b1.ClaimFailureSummary{
+		{Type: "postgres", Failed: 2, Workloads: []string{"api", "worker"}},
+		{Type: "redis", Failed: 1, Workloads: []string{"api"}},
+	}
+	if fmt.Sprint(status.ClaimFailures) != fmt.Sprint(wantFailures) {
+		t.Errorf("claimFailures = %v, want %v", status.ClaimFailures, wantFailures)
+	}
+	var pending []string
+	for _, workload := range status.SlowestPending {
+		pending = append(pending, workload.Name)
+	}
+	if fmt.Sprint(pending) != "[batch api worker]" {
+		t.Errorf("slowestPending = %v, want [batch api worker]", pending)
+	}
+	if status.LastUpdateTime == nil {
+		t.Errorf("lastUpdateTime is not set")
+	}
+	if getSummary(t, c, "team-b", scorev1b1.WorkloadSummaryName).Status.Ready != 1 {
+		t.Errorf("summary of team-b does not count its ready Workload")
+	}
+}
+
+func TestObserveOnlyFlushesChanges(t *testing.T) {
+	aggregator, c := newTestAggregator(t, sharding.Shard{})
+	workload := newWorkload("team-a", "web", metav1.ConditionFalse, conditions.ReasonClaimPending, created)
+	aggregator.Observe(workload)
+	if err := aggregator.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	// The same status with a finer timestamp, as held in memory before it is written, changes nothing
+	aggregator.Observe(newWorkload("team-a", "web", metav1.ConditionFalse, conditions.ReasonClaimPending, created.Add(time.Millisecond)))
+	if len(aggregator.dirty) != 0 {
+		t.Errorf("Observe() of an unchanged status marked %v as changed", aggregator.dirty)
+	}
+
+	aggregator.Forget(client.ObjectKeyFromObject(workload))
+	if err := aggregator.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+	err := c.Get(context.Background(), types.NamespacedName{Namespace: "team-a", Name: scorev1b1.WorkloadSummaryName}, &scorev1b1.WorkloadSummary{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("summary of a namespace without Workloads still exists (error %v)", err)
+	}
+}
+
+func TestResync(t *testing.T) {
+	shard := sharding.Shard{Index: 1, Count: 2}
+	var owned, other *scorev1b1.Workload
+	for i := 0; owned == nil || other == nil; i++ {
+		workload := newWorkload("team-a", fmt.Sprintf("web-%d", i), metav1.ConditionTrue, conditions.ReasonSucceeded, created)
+		if shard.Owns(client.ObjectKeyFromObject(workload)) {
+			owned = workload
+		} else {
+			other = workload
+		}
+	}
+	stale := &scorev1b1.WorkloadSummary{ObjectMeta: metav1.ObjectMeta{Name: Name(shard), Namespace: "team-b"}}
+	aggregator, c := newTestAggregator(t, shard, owned, other, stale)
+
+	if err := aggregator.Resync(context.Background()); err != nil {
+		t.Fatalf("Resync() error = %v", err)
+	}
+	if err := aggregator.Flush(context.Background()); err != nil {
+		t.Fatalf("Flush() error = %v", err)
+	}
+
+	if name := Name(shard); name != "workloads-1" {
+		t.Errorf("Name() = %s, want workloads-1", name)
+	}
+	if status := getSummary(t, c, "team-a", "workloads-1").Status; status.Workloads != 1 || status.Ready != 1 {
+		t.Errorf("summary = %+v, want the Workload of the shard only", status)
+	}
+	err := c.Get(context.Background(), types.NamespacedName{Namespace: "team-b", Name: "workloads-1"}, &scorev1b1.WorkloadSummary{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("stale summary still exists (error %v)", err)
+	}
+}
+
+func TestSummarizeLimits(t *testing.T) {
+	entries := make(map[string]entry)
+	for i := 0; i < MaxClaimFailures+2; i++ {
+		entries[fmt.Sprintf("web-%d", i)] = entry{
+			ready:            metav1.ConditionFalse,
+			reason:           conditions.ReasonClaimFailed,
+			since:            created.Add(time.Duration(i) * time.Minute),
+			failedClaimTypes: []string{fmt.Sprintf("type-%02d", i), fmt.Sprintf("type-%02d", i+1)},
+		}
+	}
+
+	status := summarize(entries, now)
+	if len(status.SlowestPending) != MaxSlowestPending || status.SlowestPending[0].Name != "web-0" {
+		t.Errorf("slowestPending = %v, want the %d oldest starting with web-0", status.SlowestPending, MaxSlowestPending)
+	}
+	if len(status.ClaimFailures) != MaxClaimFailures {
+		t.Errorf("claimFailures has %d types, want %d", len(status.ClaimFailures), MaxClaimFailures)
+	}
+	if first := status.ClaimFailures[0]; first.Failed != 2 || first.Type != "type-01" {
+		t.Errorf("claimFailures[0] = %+v, want type-01 with 2 failures", first)
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supplychain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+const (
+	// signatureAnnotation is the layer annotation carrying the base64 cosign signature of the layer
+	signatureAnnotation = "dev.cosignproject.cosign/signature"
+	// maxPayloadSize bounds the signed payloads read from a registry
+	maxPayloadSize = 1 << 20
+)
+
+// signatureManifest is the part of a cosign signature manifest that is verified
+type signatureManifest struct {
+	Layers []struct {
+		Digest      string            `json:"digest"`
+		Annotations map[string]string `json:"annotations"`
+	} `json:"layers"`
+}
+
+// simpleSigningPayload is the part of a cosign payload naming the signed manifest
+type simpleSigningPayload struct {
+	Critical struct {
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+	} `json:"critical"`
+}
+
+// ParsePublicKey parses a PEM encoded ECDSA public key of a trust policy
+func ParsePublicKey(data string) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, errors.New("not a PEM encoded public key")
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	ecdsaKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type %T, want ECDSA", key)
+	}
+	return ecdsaKey, nil
+}
+
+// signatureTag returns the tag cosign stores the signatures of the manifest with digest under
+func signatureTag(digest string) string {
+	return strings.Replace(digest, ":", "-", 1) + ".sig"
+}
+
+// verifySignature checks that the manifest with digest carries a cosign signature by a key of the policy
+func verifySignature(ctx context.Context, reg *registry, repository, digest string, policy *scorev1b1.TrustPolicySpec) error {
+	keys := make([]*ecdsa.PublicKey, 0, len(policy.PublicKeys))
+	for i, data := range policy.PublicKeys {
+		key, err := ParsePublicKey(data)
+		if err != nil {
+			return fmt.Errorf("trust policy %s has an invalid public key %d: %w", policy.Name, i, err)
+		}
+		keys = append(keys, key)
+	}
+
+	var manifest signatureManifest
+	if err := reg.manifest(ctx, repository, signatureTag(digest), &manifest); err != nil {
+		var notFound *notFoundError
+		if errors.As(err, &notFound) {
+			return &VerificationError{Detail: fmt.Sprintf("is not signed, as trust policy %s requires", policy.Name)}
+		}
+		return err
+	}
+
+	for _, layer := range manifest.Layers {
+		encoded, ok := layer.Annotations[signatureAnnotation]
+		if !ok {
+			continue
+		}
+		signature, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			continue
+		}
+		payload, err := reg.blob(ctx, repository, layer.Digest, maxPayloadSize)
+		if err != nil {
+			return err
+		}
+		var signed simpleSigningPayload
+		if err := json.Unmarshal(payload, &signed); err != nil || signed.Critical.Image.DockerManifestDigest != digest {
+			// A signature of another manifest copied next to this one
+			continue
+		}
+		sum := sha256.Sum256(payload)
+		for _, key := range keys {
+			if ecdsa.VerifyASN1(key, sum[:], signature) {
+				return nil
+			}
+		}
+	}
+	return &VerificationError{Detail: fmt.Sprintf("has no valid signature by a key of trust policy %s", policy.Name)}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supplychain
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// ResolveImage returns the image pinned by the digest its tag currently points to, e.g. "nginx:1.27" becomes
+// "nginx:1.27@sha256:...". Images already pinned by a digest are returned unchanged. The registry is accessed
+// with the credentials and insecure registries of spec, which may be nil. Images the registry does not serve
+// fail with an error wrapping ErrVerification; other errors (e.g., an unreachable registry) are transient.
+func ResolveImage(ctx context.Context, c client.Client, spec *scorev1b1.SupplyChainSpec, image string) (string, error) {
+	ref, ok := ParseReference(image)
+	if !ok || strings.HasPrefix(image, "oci://") {
+		return "", &VerificationError{Ref: image, Image: true, Detail: "is not an image reference"}
+	}
+	if ref.Digest != "" {
+		return image, nil
+	}
+	if spec == nil {
+		spec = &scorev1b1.SupplyChainSpec{}
+	}
+
+	reg, err := newRegistry(ctx, c, spec, ref.Registry)
+	if err != nil {
+		return "", err
+	}
+	digest, err := reg.headDigest(ctx, ref.Repository, ref.Tag)
+	if err != nil {
+		err = wrapNotFound(image, err)
+		var verificationErr *VerificationError
+		if errors.As(err, &verificationErr) {
+			verificationErr.Image = true
+		}
+		return "", err
+	}
+	return image + "@" + digest, nil
+}
+
+// IsLatest reports whether the image is tagged "latest", or has neither a tag nor a digest
+func IsLatest(image string) bool {
+	ref, ok := ParseReference(image)
+	return ok && ref.Digest == "" && ref.Tag == defaultTag
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supplychain
+
+import "strings"
+
+const (
+	// dockerHubRegistry is the registry of references without a registry host
+	dockerHubRegistry = "docker.io"
+	// defaultTag is the tag of references with neither a tag nor a digest
+	defaultTag = "latest"
+)
+
+// Reference is a parsed OCI reference such as "registry.example.com/templates/web@sha256:..."
+type Reference struct {
+	// Registry is the registry host, with its port if any
+	Registry string
+	// Repository is the repository path within the registry
+	Repository string
+	// Tag is the tag of the reference, if any
+	Tag string
+	// Digest is the digest the reference is pinned by, if any
+	Digest string
+}
+
+// Reference returns the digest of the reference if it is pinned, and its tag otherwise
+func (r Reference) Reference() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	return r.Tag
+}
+
+// ParseReference parses an OCI reference, optionally prefixed with "oci://". It reports false for refs
+// that are not OCI references, e.g. the git URLs of kustomize templates.
+func ParseReference(ref string) (Reference, bool) {
+	ref = strings.TrimPrefix(ref, "oci://")
+	if ref == "" || strings.Contains(ref, "://") || strings.ContainsAny(ref, "?# ") || strings.Contains(ref, "//") {
+		return Reference{}, false
+	}
+
+	var parsed Reference
+	name, digest, pinned := strings.Cut(ref, "@")
+	if pinned {
+		parsed.Digest = digest
+	}
+	if slash := strings.LastIndex(name, "/"); strings.LastIndex(name, ":") > slash {
+		colon := strings.LastIndex(name, ":")
+		name, parsed.Tag = name[:colon], name[colon+1:]
+	}
+
+	host, repository, found := strings.Cut(name, "/")
+	if found && (strings.ContainsAny(host, ".:") || host == "localhost") {
+		parsed.Registry, parsed.Repository = host, repository
+	} else {
+		parsed.Registry, parsed.Repository = dockerHubRegistry, name
+		if !strings.Contains(name, "/") {
+			parsed.Repository = "library/" + name
+		}
+	}
+	if parsed.Repository == "" || parsed.Repository != strings.ToLower(parsed.Repository) {
+		return Reference{}, false
+	}
+	if parsed.Tag == "" && parsed.Digest == "" {
+		parsed.Tag = defaultTag
+	}
+	return parsed, true
+}
@@ -0,0 +1,309 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supplychain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+const (
+	// requestTimeout bounds each request to a registry
+	requestTimeout = 30 * time.Second
+	// maxManifestSize bounds the manifests read from a registry
+	maxManifestSize = 4 << 20
+	// dockerHubAPI is the host serving the registry API of Docker Hub
+	dockerHubAPI = "registry-1.docker.io"
+)
+
+// manifestMediaTypes are the manifest formats accepted from registries
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+}
+
+// challengeParam matches the parameters of a WWW-Authenticate challenge
+var challengeParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// notFoundError reports a manifest or blob the registry does not have
+type notFoundError struct {
+	what string
+}
+
+func (e *notFoundError) Error() string {
+	return e.what + " not found"
+}
+
+// registry reads manifests and blobs through the OCI distribution API
+type registry struct {
+	baseURL    string
+	username   string
+	password   string
+	token      string
+	httpClient *http.Client
+}
+
+// newRegistry creates a client of the registry host with the credentials of the pull secret, if any
+func newRegistry(ctx context.Context, c client.Client, spec *scorev1b1.SupplyChainSpec, host string) (*registry, error) {
+	scheme := "https"
+	if slices.Contains(spec.InsecureRegistries, host) {
+		scheme = "http"
+	}
+	apiHost := host
+	if host == dockerHubRegistry {
+		apiHost = dockerHubAPI
+	}
+	reg := &registry{
+		baseURL:    scheme + "://" + apiHost,
+		httpClient: &http.Client{Timeout: requestTimeout},
+	}
+	if spec.PullSecretRef != nil {
+		username, password, err := readCredentials(ctx, c, *spec.PullSecretRef, host)
+		if err != nil {
+			return nil, err
+		}
+		reg.username, reg.password = username, password
+	}
+	return reg, nil
+}
+
+// readCredentials returns the credentials for host in the referenced kubernetes.io/dockerconfigjson Secret
+func readCredentials(ctx context.Context, c client.Client, ref scorev1b1.NamespacedName, host string) (string, string, error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, secret); err != nil {
+		return "", "", fmt.Errorf("failed to get registry credentials %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	var config struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(secret.Data[corev1.DockerConfigJsonKey], &config); err != nil {
+		return "", "", fmt.Errorf("registry credentials %s/%s are not a docker config: %w", ref.Namespace, ref.Name, err)
+	}
+
+	keys := []string{host, "https://" + host, "http://" + host}
+	if host == dockerHubRegistry {
+		keys = append(keys, "https://index.docker.io/v1/", "index.docker.io")
+	}
+	for _, key := range keys {
+		auth, ok := config.Auths[key]
+		if !ok {
+			continue
+		}
+		if auth.Username != "" {
+			return auth.Username, auth.Password, nil
+		}
+		decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+		if err != nil {
+			return "", "", fmt.Errorf("registry credentials %s/%s have an invalid auth for %s: %w", ref.Namespace, ref.Name, host, err)
+		}
+		username, password, _ := strings.Cut(string(decoded), ":")
+		return username, password, nil
+	}
+	// No credentials for the host: access it anonymously
+	return "", "", nil
+}
+
+// manifestDigest fetches the manifest of reference (a tag or digest) and returns its sha256 digest,
+// computed from the content rather than trusted from the registry
+func (r *registry) manifestDigest(ctx context.Context, repository, reference string) (string, error) {
+	body, err := r.get(ctx, repository, "manifests/"+reference, manifestMediaTypes, maxManifestSize)
+	if err != nil {
+		return "", err
+	}
+	return sha256Digest(body), nil
+}
+
+// manifest fetches and decodes the manifest of reference
+func (r *registry) manifest(ctx context.Context, repository, reference string, out any) error {
+	body, err := r.get(ctx, repository, "manifests/"+reference, manifestMediaTypes, maxManifestSize)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("failed to decode manifest %s: %w", reference, err)
+	}
+	return nil
+}
+
+// blob fetches the blob with digest and checks that its content has that digest
+func (r *registry) blob(ctx context.Context, repository, digest string, maxSize int64) ([]byte, error) {
+	body, err := r.get(ctx, repository, "blobs/"+digest, nil, maxSize)
+	if err != nil {
+		return nil, err
+	}
+	if got := sha256Digest(body); got != digest {
+		return nil, &VerificationError{Detail: fmt.Sprintf("registry serves blob %s, not %s", got, digest)}
+	}
+	return body, nil
+}
+
+// headDigest returns the digest of the manifest of reference from the Docker-Content-Digest header of a HEAD
+// request, which registries do not count as a pull. The manifest itself is read from registries that do not
+// send the header.
+func (r *registry) headDigest(ctx context.Context, repository, reference string) (string, error) {
+	_, header, err := r.request(ctx, http.MethodHead, repository, "manifests/"+reference, manifestMediaTypes, 0)
+	if err != nil {
+		return "", err
+	}
+	if digest := header.Get("Docker-Content-Digest"); validDigest(digest) {
+		return digest, nil
+	}
+	return r.manifestDigest(ctx, repository, reference)
+}
+
+// get reads a resource of the repository, authenticating as the registry challenges
+func (r *registry) get(ctx context.Context, repository, resource string, accept []string, maxSize int64) ([]byte, error) {
+	body, _, err := r.request(ctx, http.MethodGet, repository, resource, accept, maxSize)
+	return body, err
+}
+
+// request sends a request for a resource of the repository, authenticating as the registry challenges, and
+// returns the body and headers of the response
+func (r *registry) request(ctx context.Context, method, repository, resource string, accept []string, maxSize int64) ([]byte, http.Header, error) {
+	target := fmt.Sprintf("%s/v2/%s/%s", r.baseURL, repository, resource)
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, method, target, nil)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to create registry request: %w", err)
+		}
+		if len(accept) > 0 {
+			req.Header.Set("Accept", strings.Join(accept, ", "))
+		}
+		switch {
+		case r.token != "":
+			req.Header.Set("Authorization", "Bearer "+r.token)
+		case r.username != "":
+			req.SetBasicAuth(r.username, r.password)
+		}
+
+		resp, err := r.httpClient.Do(req)
+		if err != nil {
+			return nil, nil, fmt.Errorf("registry request %s %s failed: %w", method, target, err)
+		}
+		body, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read registry response: %w", err)
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusUnauthorized && attempt == 0 && r.token == "":
+			if err := r.authenticate(ctx, resp.Header.Get("WWW-Authenticate")); err != nil {
+				return nil, nil, err
+			}
+			continue
+		case resp.StatusCode == http.StatusNotFound:
+			return nil, nil, &notFoundError{what: fmt.Sprintf("%s of %s", resource, repository)}
+		case resp.StatusCode < 200 || resp.StatusCode > 299:
+			return nil, nil, fmt.Errorf("registry request %s %s returned %d: %s", method, target, resp.StatusCode, strings.TrimSpace(string(body)))
+		case int64(len(body)) > maxSize:
+			return nil, nil, fmt.Errorf("registry response of %s %s exceeds %d bytes", method, target, maxSize)
+		}
+		return body, resp.Header, nil
+	}
+}
+
+// authenticate answers a WWW-Authenticate challenge. Bearer challenges are answered with a token from the
+// realm, requested with the credentials if any; basic challenges are answered by the credentials.
+func (r *registry) authenticate(ctx context.Context, challenge string) error {
+	scheme, rest, _ := strings.Cut(challenge, " ")
+	switch strings.ToLower(scheme) {
+	case "basic":
+		if r.username == "" {
+			return fmt.Errorf("registry %s requires credentials", r.baseURL)
+		}
+		return nil
+	case "bearer":
+	default:
+		return fmt.Errorf("registry %s requires unsupported authentication %q", r.baseURL, challenge)
+	}
+
+	params := map[string]string{}
+	for _, match := range challengeParam.FindAllStringSubmatch(rest, -1) {
+		params[match[1]] = match[2]
+	}
+	realm, err := url.Parse(params["realm"])
+	if err != nil || realm.Scheme == "" {
+		return fmt.Errorf("registry %s sent an invalid token realm %q", r.baseURL, params["realm"])
+	}
+	query := realm.Query()
+	for _, key := range []string{"service", "scope"} {
+		if params[key] != "" {
+			query.Set(key, params[key])
+		}
+	}
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create token request: %w", err)
+	}
+	if r.username != "" {
+		req.SetBasicAuth(r.username, r.password)
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("token request to %s failed: %w", realm.Host, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("token request to %s returned %d", realm.Host, resp.StatusCode)
+	}
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxManifestSize)).Decode(&token); err != nil {
+		return fmt.Errorf("failed to decode token response: %w", err)
+	}
+	r.token = token.Token
+	if r.token == "" {
+		r.token = token.AccessToken
+	}
+	if r.token == "" {
+		return fmt.Errorf("token response of %s carries no token", realm.Host)
+	}
+	return nil
+}
+
+// sha256Digest returns the OCI digest of data
+func sha256Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package supplychain verifies the template refs of backends before WorkloadPlans are created from them.
+// A ref pinned by a digest must resolve to a manifest with that digest, and a ref of a repository matched by
+// a trust policy must carry a cosign signature by one of the keys of the policy. It also resolves the tags of
+// container images to the digests they point to, so that plans pin what nodes pull.
+package supplychain
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// ErrVerification is wrapped by the errors of templates that fail verification
+var ErrVerification = errors.New("supply-chain verification failed")
+
+// VerificationError reports why a template ref failed verification, or why an image could not be resolved
+type VerificationError struct {
+	// Ref is the template ref or the image
+	Ref string
+	// Image reports whether Ref is a container image
+	Image bool
+	// Detail describes the failure
+	Detail string
+}
+
+func (e *VerificationError) Error() string {
+	if e.Image {
+		return fmt.Sprintf("image %s: %s", e.Ref, e.Detail)
+	}
+	return fmt.Sprintf("template %s: %s", e.Ref, e.Detail)
+}
+
+// Is makes VerificationError match ErrVerification
+func (e *VerificationError) Is(target error) bool {
+	return target == ErrVerification
+}
+
+// Verifier verifies template refs. Refs pinned by a digest are immutable, so their successful verifications
+// are remembered until the supply-chain configuration changes.
+type Verifier struct {
+	mu       sync.Mutex
+	verified map[string]bool
+}
+
+// NewVerifier creates a new Verifier
+func NewVerifier() *Verifier {
+	return &Verifier{verified: make(map[string]bool)}
+}
+
+// Verify checks the ref of template against spec. Nothing is verified when spec is nil. Errors of templates
+// that fail verification wrap ErrVerification; other errors (e.g., an unreachable registry) are transient.
+func (v *Verifier) Verify(ctx context.Context, c client.Client, spec *scorev1b1.SupplyChainSpec, template *scorev1b1.TemplateSpec) error {
+	if spec == nil {
+		return nil
+	}
+	ref, ok := ParseReference(template.Ref)
+	if !ok {
+		if spec.RequireDigest {
+			return &VerificationError{Ref: template.Ref, Detail: "is not an OCI reference pinned by a digest"}
+		}
+		return nil
+	}
+	if ref.Digest == "" && spec.RequireDigest {
+		return &VerificationError{Ref: template.Ref, Detail: "is not pinned by a digest"}
+	}
+	if ref.Digest != "" && !validDigest(ref.Digest) {
+		return &VerificationError{Ref: template.Ref, Detail: fmt.Sprintf("digest %s is not a sha256 digest", ref.Digest)}
+	}
+
+	policy, err := matchTrustPolicy(spec.TrustPolicies, ref)
+	if err != nil {
+		return err
+	}
+	if ref.Digest == "" && policy == nil {
+		// A tag without a trust policy has nothing to verify against
+		return nil
+	}
+
+	key := cacheKey(spec, template.Ref)
+	if ref.Digest != "" && v.isVerified(key) {
+		return nil
+	}
+
+	reg, err := newRegistry(ctx, c, spec, ref.Registry)
+	if err != nil {
+		return err
+	}
+	digest, err := reg.manifestDigest(ctx, ref.Repository, ref.Reference())
+	if err != nil {
+		return wrapNotFound(template.Ref, err)
+	}
+	if ref.Digest != "" && digest != ref.Digest {
+		return &VerificationError{Ref: template.Ref, Detail: fmt.Sprintf("registry serves manifest %s, not the pinned digest %s", digest, ref.Digest)}
+	}
+	if policy != nil {
+		if err := verifySignature(ctx, reg, ref.Repository, digest, policy); err != nil {
+			return wrapNotFound(template.Ref, err)
+		}
+	}
+
+	if ref.Digest != "" {
+		v.setVerified(key)
+	}
+	return nil
+}
+
+func (v *Verifier) isVerified(key string) bool {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.verified[key]
+}
+
+func (v *Verifier) setVerified(key string) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.verified[key] = true
+}
+
+// cacheKey identifies the verification of ref under spec
+func cacheKey(spec *scorev1b1.SupplyChainSpec, ref string) string {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return ""
+	}
+	return ref + "\n" + string(data)
+}
+
+// wrapNotFound turns errors about missing manifests, blobs and signatures into verification errors
+func wrapNotFound(ref string, err error) error {
+	var notFound *notFoundError
+	var verificationErr *VerificationError
+	switch {
+	case errors.As(err, &verificationErr):
+		verificationErr.Ref = ref
+		return verificationErr
+	case errors.As(err, &notFound):
+		return &VerificationError{Ref: ref, Detail: notFound.Error()}
+	default:
+		return err
+	}
+}
+
+// matchTrustPolicy returns the first trust policy with a repository pattern matching ref, or nil
+func matchTrustPolicy(policies []scorev1b1.TrustPolicySpec, ref Reference) (*scorev1b1.TrustPolicySpec, error) {
+	repository := ref.Registry + "/" + ref.Repository
+	for i := range policies {
+		for _, pattern := range policies[i].Repositories {
+			matched, err := path.Match(pattern, repository)
+			if err != nil {
+				return nil, fmt.Errorf("trust policy %s has an invalid repository pattern %q: %w", policies[i].Name, pattern, err)
+			}
+			if matched {
+				return &policies[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// validDigest reports whether digest is a sha256 digest, the only algorithm OCI registries are required to support
+func validDigest(digest string) bool {
+	hex, ok := strings.CutPrefix(digest, "sha256:")
+	if !ok || len(hex) != 64 {
+		return false
+	}
+	for _, r := range hex {
+		if (r < '0' || r > '9') && (r < 'a' || r > 'f') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,299 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package supplychain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestParseReference(t *testing.T) {
+	digest := "sha256:" + strings.Repeat("a", 64)
+	tests := []struct {
+		ref    string
+		want   Reference
+		wantOK bool
+	}{
+		{"registry.example.com/templates/web@" + digest, Reference{Registry: "registry.example.com", Repository: "templates/web", Digest: digest}, true},
+		{"oci://registry.example.com/charts/web:1.2.0", Reference{Registry: "registry.example.com", Repository: "charts/web", Tag: "1.2.0"}, true},
+		{"localhost:5000/web:v1@" + digest, Reference{Registry: "localhost:5000", Repository: "web", Tag: "v1", Digest: digest}, true},
+		{"test/web-template:v1.0.0", Reference{Registry: "docker.io", Repository: "test/web-template", Tag: "v1.0.0"}, true},
+		{"nginx", Reference{Registry: "docker.io", Repository: "library/nginx", Tag: "latest"}, true},
+		{"https://github.com/example/k8s-configs//overlays/production?ref=v1.2.3", Reference{}, false},
+		{"registry.example.com/Templates/web", Reference{}, false},
+		{"", Reference{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, ok := ParseReference(tt.ref)
+			if ok != tt.wantOK || got != tt.want {
+				t.Errorf("ParseReference() = %+v, %v; want %+v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+// testRegistry serves manifests and blobs of the repository "templates/web", behind token authentication
+type testRegistry struct {
+	manifests map[string][]byte
+	blobs     map[string][]byte
+	requests  int
+}
+
+func (r *testRegistry) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		if user, password, ok := req.BasicAuth(); !ok || user != "robot" || password != "s3cr3t" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"token":"registry-token"}`))
+		return
+	}
+	r.requests++
+	if req.Header.Get("Authorization") != "Bearer registry-token" {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="http://`+req.Host+`/token",service="registry",scope="repository:templates/web:pull"`)
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	var content []byte
+	switch {
+	case strings.HasPrefix(req.URL.Path, "/v2/templates/web/manifests/"):
+		content = r.manifests[strings.TrimPrefix(req.URL.Path, "/v2/templates/web/manifests/")]
+	case strings.HasPrefix(req.URL.Path, "/v2/templates/web/blobs/"):
+		content = r.blobs[strings.TrimPrefix(req.URL.Path, "/v2/templates/web/blobs/")]
+	}
+	if content == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if strings.Contains(req.URL.Path, "/manifests/") {
+		w.Header().Set("Docker-Content-Digest", sha256Digest(content))
+	}
+	_, _ = w.Write(content)
+}
+
+// sign adds a cosign signature of the manifest with digest by key to the registry
+func (r *testRegistry) sign(t *testing.T, digest string, key *ecdsa.PrivateKey) {
+	t.Helper()
+	payload := []byte(`{"critical":{"identity":{"docker-reference":"templates/web"},"image":{"docker-manifest-digest":"` + digest + `"},"type":"cosign container image signature"},"optional":null}`)
+	sum := sha256.Sum256(payload)
+	signature, err := ecdsa.SignASN1(rand.Reader, key, sum[:])
+	if err != nil {
+		t.Fatalf("failed to sign: %v", err)
+	}
+	payloadDigest := sha256Digest(payload)
+	manifest, _ := json.Marshal(map[string]any{
+		"schemaVersion": 2,
+		"layers": []map[string]any{{
+			"mediaType":   "application/vnd.dev.cosign.simplesigning.v1+json",
+			"digest":      payloadDigest,
+			"size":        len(payload),
+			"annotations": map[string]string{signatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+		}},
+	})
+	r.blobs[payloadDigest] = payload
+	r.manifests[signatureTag(digest)] = manifest
+}
+
+func publicKeyPEM(t *testing.T, key *ecdsa.PrivateKey) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatalf("failed to marshal public key: %v", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestVerify(t *testing.T) {
+	manifest := []byte(`{"schemaVersion":2,"layers":[]}`)
+	digest := sha256Digest(manifest)
+	unsigned := []byte(`{"schemaVersion":2,"layers":[{}]}`)
+	trusted, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	untrusted, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	reg := &testRegistry{
+		manifests: map[string][]byte{digest: manifest, "v1": manifest, sha256Digest(unsigned): unsigned, "unsigned": unsigned},
+		blobs:     map[string][]byte{},
+	}
+	reg.sign(t, digest, trusted)
+	server := httptest.NewServer(reg)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	pullSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "score-system"},
+		Type:       corev1.SecretTypeDockerConfigJson,
+		Data: map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"` + host + `":{"auth":"` +
+			base64.StdEncoding.EncodeToString([]byte("robot:s3cr3t")) + `"}}}`)},
+	}
+	c := fake.NewClientBuilder().WithObjects(pullSecret).Build()
+	spec := func(requireDigest bool, keys ...string) *scorev1b1.SupplyChainSpec {
+		spec := &scorev1b1.SupplyChainSpec{
+			RequireDigest:      requireDigest,
+			PullSecretRef:      &scorev1b1.NamespacedName{Namespace: "score-system", Name: "registry"},
+			InsecureRegistries: []string{host},
+		}
+		if len(keys) > 0 {
+			spec.TrustPolicies = []scorev1b1.TrustPolicySpec{{Name: "platform", Repositories: []string{host + "/templates/*"}, PublicKeys: keys}}
+		}
+		return spec
+	}
+	otherDigest := "sha256:" + strings.Repeat("0", 64)
+
+	tests := []struct {
+		name      string
+		spec      *scorev1b1.SupplyChainSpec
+		ref       string
+		wantError bool
+	}{
+		{name: "no supply-chain configuration", ref: host + "/templates/web@" + otherDigest},
+		{name: "pinned digest", spec: spec(false), ref: host + "/templates/web@" + digest},
+		{name: "tag without trust policy", spec: spec(false), ref: host + "/templates/web:v2"},
+		{name: "unknown digest", spec: spec(false), ref: host + "/templates/web@" + otherDigest, wantError: true},
+		{name: "malformed digest", spec: spec(false), ref: host + "/templates/web@sha256:abc123", wantError: true},
+		{name: "tag when digests are required", spec: spec(true), ref: host + "/templates/web:v1", wantError: true},
+		{name: "git ref when digests are required", spec: spec(true), ref: "https://github.com/example/configs?ref=v1", wantError: true},
+		{name: "signed by a trusted key", spec: spec(true, publicKeyPEM(t, trusted)), ref: host + "/templates/web@" + digest},
+		{name: "signed tag", spec: spec(false, publicKeyPEM(t, trusted)), ref: host + "/templates/web:v1"},
+		{name: "signed by another key", spec: spec(true, publicKeyPEM(t, untrusted)), ref: host + "/templates/web@" + digest, wantError: true},
+		{name: "unsigned", spec: spec(false, publicKeyPEM(t, trusted)), ref: host + "/templates/web:unsigned", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NewVerifier().Verify(context.Background(), c, tt.spec, &scorev1b1.TemplateSpec{Kind: "manifests", Ref: tt.ref})
+			if tt.wantError {
+				if !errors.Is(err, ErrVerification) {
+					t.Errorf("Verify() error = %v, want a verification error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("Verify() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestVerifyRemembersPinnedDigests(t *testing.T) {
+	manifest := []byte(`{"schemaVersion":2}`)
+	reg := &testRegistry{manifests: map[string][]byte{sha256Digest(manifest): manifest}}
+	server := httptest.NewServer(reg)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	pullSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "score-system"},
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"` + host + `":{"username":"robot","password":"s3cr3t"}}}`)},
+	}
+	c := fake.NewClientBuilder().WithObjects(pullSecret).Build()
+	spec := &scorev1b1.SupplyChainSpec{
+		PullSecretRef:      &scorev1b1.NamespacedName{Namespace: "score-system", Name: "registry"},
+		InsecureRegistries: []string{host},
+	}
+	template := &scorev1b1.TemplateSpec{Kind: "manifests", Ref: host + "/templates/web@" + sha256Digest(manifest)}
+
+	verifier := NewVerifier()
+	for range 3 {
+		if err := verifier.Verify(context.Background(), c, spec, template); err != nil {
+			t.Fatalf("Verify() error = %v", err)
+		}
+	}
+	// The first manifest request is challenged, the second one authenticated; later verifications are remembered
+	if reg.requests != 2 {
+		t.Errorf("registry served %d requests, want 2", reg.requests)
+	}
+}
+
+func TestResolveImage(t *testing.T) {
+	manifest := []byte(`{"schemaVersion":2,"layers":[]}`)
+	digest := sha256Digest(manifest)
+	reg := &testRegistry{manifests: map[string][]byte{"1.27": manifest}}
+	server := httptest.NewServer(reg)
+	defer server.Close()
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	pullSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "registry", Namespace: "score-system"},
+		Data:       map[string][]byte{corev1.DockerConfigJsonKey: []byte(`{"auths":{"` + host + `":{"username":"robot","password":"s3cr3t"}}}`)},
+	}
+	c := fake.NewClientBuilder().WithObjects(pullSecret).Build()
+	spec := &scorev1b1.SupplyChainSpec{
+		PullSecretRef:      &scorev1b1.NamespacedName{Namespace: "score-system", Name: "registry"},
+		InsecureRegistries: []string{host},
+	}
+
+	tests := []struct {
+		name      string
+		image     string
+		want      string
+		wantError bool
+	}{
+		{name: "tag", image: host + "/templates/web:1.27", want: host + "/templates/web:1.27@" + digest},
+		{name: "pinned digest", image: host + "/templates/web@" + digest, want: host + "/templates/web@" + digest},
+		{name: "unknown tag", image: host + "/templates/web:1.28", wantError: true},
+		{name: "not an image reference", image: "oci://" + host + "/templates/web:1.27", wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveImage(context.Background(), c, spec, tt.image)
+			if tt.wantError {
+				var verificationErr *VerificationError
+				if !errors.As(err, &verificationErr) || !verificationErr.Image {
+					t.Errorf("ResolveImage() error = %v, want an image verification error", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("ResolveImage() = %q, %v; want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsLatest(t *testing.T) {
+	for image, want := range map[string]bool{
+		"nginx":                 true,
+		"nginx:latest":          true,
+		"ghcr.io/app/web:1.2.0": false,
+		"nginx:latest@sha256:" + strings.Repeat("a", 64): false,
+	} {
+		if got := IsLatest(image); got != want {
+			t.Errorf("IsLatest(%q) = %v, want %v", image, got, want)
+		}
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+// TracerName is the instrumentation scope name used for all spans
+const TracerName = "github.com/cappyzawa/score-orchestrator"
+
+// Span attribute keys
+const (
+	// AttrWorkloadUID is the UID of the Workload the span belongs to
+	AttrWorkloadUID = "score.workload.uid"
+	// AttrWorkloadName is the name of the Workload the span belongs to
+	AttrWorkloadName = "score.workload.name"
+	// AttrWorkloadNamespace is the namespace of the Workload the span belongs to
+	AttrWorkloadNamespace = "score.workload.namespace"
+)
+
+// traceParentKey is the W3C Trace Context header carried in object annotations
+const traceParentKey = "traceparent"
+
+// propagator is used for annotation propagation regardless of the global propagator,
+// so that runtimes can join a trace even if they configure tracing differently
+var propagator = propagation.TraceContext{}
+
+// Setup installs a global tracer provider exporting spans over OTLP/gRPC.
+// The exporter is configured through the standard OTEL_EXPORTER_OTLP_* environment variables.
+// When Setup is not called, the global no-op provider is used and tracing has no effect.
+func Setup(ctx context.Context, serviceName string) (func(context.Context) error, error) {
+	exporter, err := otlptracegrpc.New(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(),
+		resource.NewSchemaless(attribute.String("service.name", serviceName)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagator)
+
+	return provider.Shutdown, nil
+}
+
+// Tracer returns the tracer used by the orchestrator and runtime controllers
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// StartSpan starts a span as a child of the span in ctx
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// WorkloadAttributes returns the span attributes identifying a Workload
+func WorkloadAttributes(workload *scorev1b1.Workload) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String(AttrWorkloadUID, string(workload.UID)),
+		attribute.String(AttrWorkloadName, workload.Name),
+		attribute.String(AttrWorkloadNamespace, workload.Namespace),
+	}
+}
+
+// RecordError records err on the span and marks the span as failed
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// InjectIntoAnnotations stores the span context of ctx in the object's annotations.
+// It is a no-op if ctx carries no valid span context.
+// Callers should inject right before a write they already perform, so that a new
+// trace never causes an extra update on its own.
+func InjectIntoAnnotations(ctx context.Context, object metav1.Object) {
+	if !trace.SpanContextFromContext(ctx).IsValid() {
+		return
+	}
+
+	carrier := propagation.MapCarrier{}
+	propagator.Inject(ctx, carrier)
+	traceParent := carrier.Get(traceParentKey)
+	if traceParent == "" {
+		return
+	}
+
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string, 1)
+	}
+	annotations[meta.AnnotationTraceParent] = traceParent
+	object.SetAnnotations(annotations)
+}
+
+// ExtractFromAnnotations returns a context carrying the remote span context stored
+// in the object's annotations, or ctx unchanged if there is none
+func ExtractFromAnnotations(ctx context.Context, object metav1.Object) context.Context {
+	traceParent := object.GetAnnotations()[meta.AnnotationTraceParent]
+	if traceParent == "" {
+		return ctx
+	}
+	return propagator.Extract(ctx, propagation.MapCarrier{traceParentKey: traceParent})
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tracing
+
+import (
+	"context"
+	"testing"
+
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+func TestInjectAndExtractAnnotations(t *testing.T) {
+	provider := sdktrace.NewTracerProvider()
+	defer func() { _ = provider.Shutdown(context.Background()) }()
+
+	ctx, span := provider.Tracer(TracerName).Start(context.Background(), "Workload.Reconcile")
+	defer span.End()
+
+	plan := &scorev1b1.WorkloadPlan{ObjectMeta: metav1.ObjectMeta{Name: "app"}}
+	InjectIntoAnnotations(ctx, plan)
+	if plan.Annotations[meta.AnnotationTraceParent] == "" {
+		t.Fatal("expected traceparent annotation to be set")
+	}
+
+	extracted := trace.SpanContextFromContext(ExtractFromAnnotations(context.Background(), plan))
+	if !extracted.IsRemote() {
+		t.Error("expected extracted span context to be remote")
+	}
+	if extracted.TraceID() != span.SpanContext().TraceID() {
+		t.Errorf("trace ID = %s, want %s", extracted.TraceID(), span.SpanContext().TraceID())
+	}
+	if extracted.SpanID() != span.SpanContext().SpanID() {
+		t.Errorf("span ID = %s, want %s", extracted.SpanID(), span.SpanContext().SpanID())
+	}
+}
+
+func TestInjectIntoAnnotations_NoSpan(t *testing.T) {
+	plan := &scorev1b1.WorkloadPlan{ObjectMeta: metav1.ObjectMeta{Name: "app"}}
+	InjectIntoAnnotations(context.Background(), plan)
+	if plan.Annotations != nil {
+		t.Errorf("expected no annotations without an active span, got %v", plan.Annotations)
+	}
+
+	ctx := ExtractFromAnnotations(context.Background(), plan)
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		t.Error("expected no span context from an object without a traceparent annotation")
+	}
+}
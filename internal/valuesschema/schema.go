@@ -0,0 +1,447 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package valuesschema validates template values against a JSON Schema.
+// It supports the structural and value keywords that matter for template values: type, enum, const,
+// properties, required, additionalProperties, items, minimum, maximum, exclusiveMinimum, exclusiveMaximum,
+// minLength, maxLength, pattern, minItems, maxItems, allOf, anyOf, oneOf and not.
+// Annotation keywords such as title, description and default are ignored; $ref is rejected.
+package valuesschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrViolation is wrapped by errors of values that do not satisfy their schema
+var ErrViolation = errors.New("values do not satisfy the schema")
+
+// maxReportedViolations bounds the violations listed in an error message
+const maxReportedViolations = 10
+
+// Violation is a value that does not satisfy its schema
+type Violation struct {
+	// Path is the JSON pointer of the value
+	Path string
+	// Message describes the violated constraint
+	Message string
+}
+
+func (v Violation) String() string {
+	return displayPath(v.Path) + ": " + v.Message
+}
+
+// ValidationError lists the violations found in a values document, ordered by path
+type ValidationError struct {
+	Violations []Violation
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrViolation, e.Details())
+}
+
+// Details lists the violations, the first maxReportedViolations of them by name
+func (e *ValidationError) Details() string {
+	reported := e.Violations
+	if len(reported) > maxReportedViolations {
+		reported = reported[:maxReportedViolations]
+	}
+	messages := make([]string, 0, len(reported)+1)
+	for _, violation := range reported {
+		messages = append(messages, violation.String())
+	}
+	if omitted := len(e.Violations) - len(reported); omitted > 0 {
+		messages = append(messages, fmt.Sprintf("and %d more", omitted))
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Unwrap() error {
+	return ErrViolation
+}
+
+// Schema is a compiled JSON Schema
+type Schema struct {
+	// deny is set for the false schema, which no value satisfies
+	deny bool
+
+	types                []string
+	enum                 []any
+	constant             *any
+	properties           map[string]*Schema
+	required             []string
+	additionalProperties *Schema
+	items                *Schema
+	minimum              *float64
+	maximum              *float64
+	exclusiveMinimum     *float64
+	exclusiveMaximum     *float64
+	minLength            *int
+	maxLength            *int
+	pattern              *regexp.Regexp
+	minItems             *int
+	maxItems             *int
+	allOf                []*Schema
+	anyOf                []*Schema
+	oneOf                []*Schema
+	not                  *Schema
+}
+
+// schemaDocument is the wire form of a schema object
+type schemaDocument struct {
+	Ref                  string                     `json:"$ref"`
+	Type                 json.RawMessage            `json:"type"`
+	Enum                 []any                      `json:"enum"`
+	Const                json.RawMessage            `json:"const"`
+	Properties           map[string]json.RawMessage `json:"properties"`
+	Required             []string                   `json:"required"`
+	AdditionalProperties json.RawMessage            `json:"additionalProperties"`
+	Items                json.RawMessage            `json:"items"`
+	Minimum              *float64                   `json:"minimum"`
+	Maximum              *float64                   `json:"maximum"`
+	ExclusiveMinimum     *float64                   `json:"exclusiveMinimum"`
+	ExclusiveMaximum     *float64                   `json:"exclusiveMaximum"`
+	MinLength            *int                       `json:"minLength"`
+	MaxLength            *int                       `json:"maxLength"`
+	Pattern              *string                    `json:"pattern"`
+	MinItems             *int                       `json:"minItems"`
+	MaxItems             *int                       `json:"maxItems"`
+	AllOf                []json.RawMessage          `json:"allOf"`
+	AnyOf                []json.RawMessage          `json:"anyOf"`
+	OneOf                []json.RawMessage          `json:"oneOf"`
+	Not                  json.RawMessage            `json:"not"`
+}
+
+// validTypes are the JSON Schema type names
+var validTypes = map[string]bool{
+	"null": true, "boolean": true, "object": true, "array": true, "number": true, "integer": true, "string": true,
+}
+
+// Compile parses a JSON Schema document. Errors name the JSON pointer of the offending schema keyword.
+func Compile(data []byte) (*Schema, error) {
+	return compile(data, "")
+}
+
+func compile(data json.RawMessage, path string) (*Schema, error) {
+	data = bytes.TrimSpace(data)
+	switch string(data) {
+	case "true":
+		return &Schema{}, nil
+	case "false":
+		return &Schema{deny: true}, nil
+	}
+
+	var doc schemaDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("schema %s: %w", displayPath(path), err)
+	}
+	if doc.Ref != "" {
+		return nil, fmt.Errorf("schema %s: $ref is not supported", displayPath(path+"/$ref"))
+	}
+
+	schema := &Schema{
+		enum:             doc.Enum,
+		required:         doc.Required,
+		minimum:          doc.Minimum,
+		maximum:          doc.Maximum,
+		exclusiveMinimum: doc.ExclusiveMinimum,
+		exclusiveMaximum: doc.ExclusiveMaximum,
+		minLength:        doc.MinLength,
+		maxLength:        doc.MaxLength,
+		minItems:         doc.MinItems,
+		maxItems:         doc.MaxItems,
+	}
+
+	if len(doc.Type) > 0 {
+		var single string
+		if err := json.Unmarshal(doc.Type, &single); err == nil {
+			schema.types = []string{single}
+		} else if err := json.Unmarshal(doc.Type, &schema.types); err != nil {
+			return nil, fmt.Errorf("schema %s: type must be a string or an array of strings", displayPath(path+"/type"))
+		}
+		for _, t := range schema.types {
+			if !validTypes[t] {
+				return nil, fmt.Errorf("schema %s: unknown type %q", displayPath(path+"/type"), t)
+			}
+		}
+	}
+	if len(doc.Const) > 0 {
+		var constant any
+		if err := json.Unmarshal(doc.Const, &constant); err != nil {
+			return nil, fmt.Errorf("schema %s: %w", displayPath(path+"/const"), err)
+		}
+		schema.constant = &constant
+	}
+	if doc.Pattern != nil {
+		pattern, err := regexp.Compile(*doc.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("schema %s: %w", displayPath(path+"/pattern"), err)
+		}
+		schema.pattern = pattern
+	}
+
+	var err error
+	if len(doc.Properties) > 0 {
+		schema.properties = make(map[string]*Schema, len(doc.Properties))
+		for name, raw := range doc.Properties {
+			if schema.properties[name], err = compile(raw, path+"/properties/"+escape(name)); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(doc.AdditionalProperties) > 0 {
+		if schema.additionalProperties, err = compile(doc.AdditionalProperties, path+"/additionalProperties"); err != nil {
+			return nil, err
+		}
+	}
+	if len(doc.Items) > 0 {
+		if schema.items, err = compile(doc.Items, path+"/items"); err != nil {
+			return nil, err
+		}
+	}
+	if len(doc.Not) > 0 {
+		if schema.not, err = compile(doc.Not, path+"/not"); err != nil {
+			return nil, err
+		}
+	}
+	if schema.allOf, err = compileAll(doc.AllOf, path+"/allOf"); err != nil {
+		return nil, err
+	}
+	if schema.anyOf, err = compileAll(doc.AnyOf, path+"/anyOf"); err != nil {
+		return nil, err
+	}
+	if schema.oneOf, err = compileAll(doc.OneOf, path+"/oneOf"); err != nil {
+		return nil, err
+	}
+
+	return schema, nil
+}
+
+// compileAll compiles the schemas of an allOf, anyOf or oneOf keyword
+func compileAll(raws []json.RawMessage, path string) ([]*Schema, error) {
+	schemas := make([]*Schema, 0, len(raws))
+	for i, raw := range raws {
+		schema, err := compile(raw, fmt.Sprintf("%s/%d", path, i))
+		if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+	return schemas, nil
+}
+
+// Validate validates a JSON values document. It returns a *ValidationError listing every violation.
+func (s *Schema) Validate(data []byte) error {
+	var value any
+	if len(bytes.TrimSpace(data)) > 0 {
+		if err := json.Unmarshal(data, &value); err != nil {
+			return fmt.Errorf("failed to unmarshal values: %w", err)
+		}
+	}
+
+	violations := s.validate(value, "")
+	if len(violations) == 0 {
+		return nil
+	}
+	sort.SliceStable(violations, func(i, j int) bool { return violations[i].Path < violations[j].Path })
+	return &ValidationError{Violations: violations}
+}
+
+func (s *Schema) validate(value any, path string) []Violation {
+	if s.deny {
+		return []Violation{{Path: path, Message: "is not allowed"}}
+	}
+
+	if len(s.types) > 0 && !matchesType(value, s.types) {
+		return []Violation{{Path: path, Message: fmt.Sprintf("must be of type %s", strings.Join(s.types, " or "))}}
+	}
+
+	var violations []Violation
+	violate := func(format string, args ...any) {
+		violations = append(violations, Violation{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.enum) > 0 && !containsValue(s.enum, value) {
+		violate("must be one of %s", formatValues(s.enum))
+	}
+	if s.constant != nil && !reflect.DeepEqual(*s.constant, value) {
+		violate("must be %s", formatValues([]any{*s.constant}))
+	}
+
+	switch v := value.(type) {
+	case float64:
+		if s.minimum != nil && v < *s.minimum {
+			violate("must be greater than or equal to %v", *s.minimum)
+		}
+		if s.maximum != nil && v > *s.maximum {
+			violate("must be less than or equal to %v", *s.maximum)
+		}
+		if s.exclusiveMinimum != nil && v <= *s.exclusiveMinimum {
+			violate("must be greater than %v", *s.exclusiveMinimum)
+		}
+		if s.exclusiveMaximum != nil && v >= *s.exclusiveMaximum {
+			violate("must be less than %v", *s.exclusiveMaximum)
+		}
+	case string:
+		length := utf8.RuneCountInString(v)
+		if s.minLength != nil && length < *s.minLength {
+			violate("must be at least %d characters long", *s.minLength)
+		}
+		if s.maxLength != nil && length > *s.maxLength {
+			violate("must be at most %d characters long", *s.maxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(v) {
+			violate("must match pattern %q", s.pattern.String())
+		}
+	case []any:
+		if s.minItems != nil && len(v) < *s.minItems {
+			violate("must have at least %d items", *s.minItems)
+		}
+		if s.maxItems != nil && len(v) > *s.maxItems {
+			violate("must have at most %d items", *s.maxItems)
+		}
+		if s.items != nil {
+			for i, item := range v {
+				violations = append(violations, s.items.validate(item, fmt.Sprintf("%s/%d", path, i))...)
+			}
+		}
+	case map[string]any:
+		for _, name := range s.required {
+			if _, ok := v[name]; !ok {
+				violations = append(violations, Violation{Path: path + "/" + escape(name), Message: "is required"})
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			sub, ok := s.properties[name]
+			if !ok {
+				sub = s.additionalProperties
+			}
+			if sub != nil {
+				violations = append(violations, sub.validate(v[name], path+"/"+escape(name))...)
+			}
+		}
+	}
+
+	for _, sub := range s.allOf {
+		violations = append(violations, sub.validate(value, path)...)
+	}
+	if len(s.anyOf) > 0 && countMatches(s.anyOf, value, path) == 0 {
+		violate("must match at least one schema in anyOf")
+	}
+	if len(s.oneOf) > 0 {
+		if matches := countMatches(s.oneOf, value, path); matches != 1 {
+			violate("must match exactly one schema in oneOf, matched %d", matches)
+		}
+	}
+	if s.not != nil && len(s.not.validate(value, path)) == 0 {
+		violate("must not match the schema in not")
+	}
+
+	return violations
+}
+
+// countMatches returns the number of schemas the value satisfies
+func countMatches(schemas []*Schema, value any, path string) int {
+	matches := 0
+	for _, sub := range schemas {
+		if len(sub.validate(value, path)) == 0 {
+			matches++
+		}
+	}
+	return matches
+}
+
+// matchesType reports whether the decoded JSON value has one of the given types
+func matchesType(value any, types []string) bool {
+	for _, t := range types {
+		switch v := value.(type) {
+		case nil:
+			if t == "null" {
+				return true
+			}
+		case bool:
+			if t == "boolean" {
+				return true
+			}
+		case float64:
+			if t == "number" || (t == "integer" && v == math.Trunc(v)) {
+				return true
+			}
+		case string:
+			if t == "string" {
+				return true
+			}
+		case []any:
+			if t == "array" {
+				return true
+			}
+		case map[string]any:
+			if t == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// containsValue reports whether values holds a JSON value equal to value
+func containsValue(values []any, value any) bool {
+	for _, candidate := range values {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// formatValues renders schema values as JSON for violation messages
+func formatValues(values []any) string {
+	formatted := make([]string, 0, len(values))
+	for _, value := range values {
+		data, err := json.Marshal(value)
+		if err != nil {
+			data = []byte(fmt.Sprint(value))
+		}
+		formatted = append(formatted, string(data))
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// escape escapes a reference token of a JSON pointer (RFC 6901)
+func escape(token string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(token, "~", "~0"), "/", "~1")
+}
+
+// displayPath renders a JSON pointer, using "(root)" for the empty pointer
+func displayPath(path string) string {
+	if path == "" {
+		return "(root)"
+	}
+	return path
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package valuesschema
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCompileErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{"not a schema object", `"object"`, "(root)"},
+		{"unknown type", `{"properties":{"replicas":{"type":"int"}}}`, "/properties/replicas/type"},
+		{"invalid pattern", `{"items":{"pattern":"("}}`, "/items/pattern"},
+		{"references", `{"anyOf":[{"$ref":"#/definitions/port"}]}`, "/anyOf/0/$ref"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Compile([]byte(tt.schema))
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Compile() error = %v, want an error naming %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	schema := `{
+		"type": "object",
+		"required": ["replicas"],
+		"properties": {
+			"replicas": {"type": "integer", "minimum": 1, "maximum": 10},
+			"strategy": {"enum": ["Recreate", "RollingUpdate"]},
+			"image": {"type": "string", "pattern": "^[a-z0-9./:-]+$", "maxLength": 64},
+			"ports": {"type": "array", "maxItems": 2, "items": {"type": "integer", "exclusiveMinimum": 0}},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+			"ingress": {"oneOf": [{"required": ["host"]}, {"required": ["path"]}]},
+			"a/b": {"const": true}
+		},
+		"additionalProperties": false
+	}`
+
+	tests := []struct {
+		name   string
+		values string
+		want   []Violation
+	}{
+		{
+			name:   "valid values",
+			values: `{"replicas":3,"strategy":"Recreate","image":"nginx:1.27","ports":[80],"labels":{"team":"web"},"ingress":{"host":"example.com"},"a/b":true}`,
+		},
+		{
+			name:   "missing required value",
+			values: `{}`,
+			want:   []Violation{{Path: "/replicas", Message: "is required"}},
+		},
+		{
+			name:   "type and range violations",
+			values: `{"replicas":2.5,"ports":[80,0,443]}`,
+			want: []Violation{
+				{Path: "/ports", Message: "must have at most 2 items"},
+				{Path: "/ports/1", Message: "must be greater than 0"},
+				{Path: "/replicas", Message: "must be of type integer"},
+			},
+		},
+		{
+			name:   "nested and escaped paths",
+			values: `{"replicas":20,"labels":{"team":1},"a/b":false,"extra":"x"}`,
+			want: []Violation{
+				{Path: "/a~1b", Message: "must be true"},
+				{Path: "/extra", Message: "is not allowed"},
+				{Path: "/labels/team", Message: "must be of type string"},
+				{Path: "/replicas", Message: "must be less than or equal to 10"},
+			},
+		},
+		{
+			name:   "enum, pattern and oneOf",
+			values: `{"replicas":1,"strategy":"BlueGreen","image":"NGINX","ingress":{"host":"a","path":"/"}}`,
+			want: []Violation{
+				{Path: "/image", Message: `must match pattern "^[a-z0-9./:-]+$"`},
+				{Path: "/ingress", Message: "must match exactly one schema in oneOf, matched 2"},
+				{Path: "/strategy", Message: `must be one of "Recreate", "RollingUpdate"`},
+			},
+		},
+	}
+
+	compiled, err := Compile([]byte(schema))
+	if err != nil {
+		t.Fatalf("Compile() error = %v", err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := compiled.Validate([]byte(tt.values))
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || !errors.Is(err, ErrViolation) {
+				t.Fatalf("Validate() error = %v, want a *ValidationError", err)
+			}
+			if !reflect.DeepEqual(validationErr.Violations, tt.want) {
+				t.Errorf("Validate() violations = %v, want %v", validationErr.Violations, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidationErrorBoundsDetails(t *testing.T) {
+	err := &ValidationError{}
+	for range maxReportedViolations + 3 {
+		err.Violations = append(err.Violations, Violation{Path: "/x", Message: "is required"})
+	}
+	if details := err.Details(); !strings.HasSuffix(details, "; and 3 more") {
+		t.Errorf("Details() = %q, want the omitted violations to be counted", details)
+	}
+}
@@ -0,0 +1,243 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package client provides typed helpers to automate the lifecycle of Workloads, e.g. from CI systems:
+// apply a Workload, wait until it is ready, read its endpoints and delete it once its finalizers ran.
+// It is built on the controller-runtime client, so it works in-cluster as well as from a kubeconfig:
+//
+//	cfg, err := config.GetConfig() // sigs.k8s.io/controller-runtime/pkg/client/config
+//	if err != nil {
+//		return err
+//	}
+//	c, err := client.NewForConfig(cfg)
+//	if err != nil {
+//		return err
+//	}
+//	if _, err := c.ApplyWorkload(ctx, workload); err != nil {
+//		return err
+//	}
+//	ready, err := c.WaitForReady(ctx, client.ObjectKeyFromObject(workload), 5*time.Minute, func(t client.Transition) {
+//		log.Printf("%s=%s (%s): %s", t.Type, t.Status, t.Reason, t.Message)
+//	})
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/rest"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+)
+
+// ObjectKey identifies a Workload by namespace and name
+type ObjectKey = ctrlclient.ObjectKey
+
+// ObjectKeyFromObject returns the key of the given Workload
+func ObjectKeyFromObject(workload *scorev1b1.Workload) ObjectKey {
+	return ctrlclient.ObjectKeyFromObject(workload)
+}
+
+// ErrWorkloadFailed indicates that the Workload reports a failure that waiting cannot resolve, such as an
+// invalid spec or a policy violation; the Workload has to be changed first
+var ErrWorkloadFailed = errors.New("workload failed")
+
+// defaultPollInterval is how often the Workload is read while waiting
+const defaultPollInterval = 2 * time.Second
+
+// terminalReasons are the Ready reasons that persist until the Workload or the platform configuration changes
+var terminalReasons = map[string]bool{
+	conditions.ReasonSpecInvalid:     true,
+	conditions.ReasonPolicyViolation: true,
+	conditions.ReasonProfileNotFound: true,
+}
+
+// Transition is an observed change of a Workload condition
+type Transition struct {
+	// Type is the condition type, e.g. "Ready" or "ClaimsReady"
+	Type string
+	// Status is the new status of the condition
+	Status metav1.ConditionStatus
+	// Reason is the new reason, from the abstract reason vocabulary
+	Reason string
+	// Message is the human-readable message of the condition
+	Message string
+	// ObservedGeneration is the Workload generation the condition was computed from
+	ObservedGeneration int64
+}
+
+// Client manages Workloads through a controller-runtime client
+type Client struct {
+	client       ctrlclient.Client
+	pollInterval time.Duration
+}
+
+// New returns a Client that uses the given controller-runtime client. Its scheme must include the score.dev types.
+func New(c ctrlclient.Client) *Client {
+	return &Client{client: c, pollInterval: defaultPollInterval}
+}
+
+// NewForConfig returns a Client for the cluster of the given REST config
+func NewForConfig(cfg *rest.Config) (*Client, error) {
+	scheme := runtime.NewScheme()
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		return nil, fmt.Errorf("failed to register score.dev types: %w", err)
+	}
+	c, err := ctrlclient.New(cfg, ctrlclient.Options{Scheme: scheme})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+	return New(c), nil
+}
+
+// ApplyWorkload creates the Workload or updates the spec of an existing one. Labels and annotations of the
+// given Workload are added to those of the existing one. It returns the Workload as stored.
+func (c *Client) ApplyWorkload(ctx context.Context, workload *scorev1b1.Workload) (*scorev1b1.Workload, error) {
+	applied := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: workload.Name, Namespace: workload.Namespace}}
+	_, err := controllerutil.CreateOrUpdate(ctx, c.client, applied, func() error {
+		applied.Spec = *workload.Spec.DeepCopy()
+		for key, value := range workload.Labels {
+			if applied.Labels == nil {
+				applied.Labels = make(map[string]string, len(workload.Labels))
+			}
+			applied.Labels[key] = value
+		}
+		for key, value := range workload.Annotations {
+			if applied.Annotations == nil {
+				applied.Annotations = make(map[string]string, len(workload.Annotations))
+			}
+			applied.Annotations[key] = value
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply workload %s/%s: %w", workload.Namespace, workload.Name, err)
+	}
+	return applied, nil
+}
+
+// WaitForReady waits until the Workload reports Ready=True for its current generation and returns it.
+// Condition changes observed meanwhile are passed to onTransition, if not nil. It returns an error wrapping
+// ErrWorkloadFailed as soon as the Workload reports a failure that waiting cannot resolve, and an error
+// with the last Ready condition when the timeout expires.
+func (c *Client) WaitForReady(ctx context.Context, key ObjectKey, timeout time.Duration, onTransition func(Transition)) (*scorev1b1.Workload, error) {
+	observed := make(map[string]Transition)
+	workload := &scorev1b1.Workload{}
+	var failure error
+	err := wait.PollUntilContextTimeout(ctx, c.pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		if err := c.client.Get(ctx, key, workload); err != nil {
+			if apierrors.IsNotFound(err) {
+				// The Workload may not be visible yet right after it was created
+				return false, nil
+			}
+			return false, fmt.Errorf("failed to get workload %s: %w", key, err)
+		}
+
+		for _, condition := range workload.Status.Conditions {
+			transition := Transition{
+				Type:               condition.Type,
+				Status:             condition.Status,
+				Reason:             condition.Reason,
+				Message:            condition.Message,
+				ObservedGeneration: condition.ObservedGeneration,
+			}
+			if previous, ok := observed[condition.Type]; ok && previous == transition {
+				continue
+			}
+			observed[condition.Type] = transition
+			if onTransition != nil {
+				onTransition(transition)
+			}
+		}
+
+		ready := apimeta.FindStatusCondition(workload.Status.Conditions, conditions.ConditionReady)
+		if ready == nil || ready.ObservedGeneration != workload.Generation {
+			// The Orchestrator has not observed the current generation yet
+			return false, nil
+		}
+		if ready.Status == metav1.ConditionTrue {
+			return true, nil
+		}
+		if terminalReasons[ready.Reason] {
+			failure = fmt.Errorf("%w: %s: %s", ErrWorkloadFailed, ready.Reason, ready.Message)
+			return false, failure
+		}
+		return false, nil
+	})
+	switch {
+	case failure != nil:
+		return nil, failure
+	case err != nil && wait.Interrupted(err):
+		if ready := apimeta.FindStatusCondition(workload.Status.Conditions, conditions.ConditionReady); ready != nil {
+			return nil, fmt.Errorf("workload %s is not ready: %s: %s: %w", key, ready.Reason, ready.Message, err)
+		}
+		return nil, fmt.Errorf("workload %s is not ready: %w", key, err)
+	case err != nil:
+		return nil, err
+	}
+	return workload, nil
+}
+
+// GetEndpoints returns the endpoints the Workload is reachable through, ordered by priority
+func (c *Client) GetEndpoints(ctx context.Context, key ObjectKey) ([]scorev1b1.WorkloadEndpoint, error) {
+	workload := &scorev1b1.Workload{}
+	if err := c.client.Get(ctx, key, workload); err != nil {
+		return nil, fmt.Errorf("failed to get workload %s: %w", key, err)
+	}
+	if len(workload.Status.Endpoints) > 0 {
+		return workload.Status.Endpoints, nil
+	}
+	if workload.Status.Endpoint != nil {
+		return []scorev1b1.WorkloadEndpoint{{URL: *workload.Status.Endpoint}}, nil
+	}
+	return nil, nil
+}
+
+// DeleteAndWait deletes the Workload and waits until it is gone, i.e. until the Orchestrator ran its
+// finalizer and deprovisioned the resources of the Workload. A Workload that does not exist is not an error.
+func (c *Client) DeleteAndWait(ctx context.Context, key ObjectKey, timeout time.Duration) error {
+	workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace}}
+	if err := c.client.Delete(ctx, workload); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to delete workload %s: %w", key, err)
+	}
+
+	err := wait.PollUntilContextTimeout(ctx, c.pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		if err := c.client.Get(ctx, key, workload); err != nil {
+			if apierrors.IsNotFound(err) {
+				return true, nil
+			}
+			return false, fmt.Errorf("failed to get workload %s: %w", key, err)
+		}
+		return false, nil
+	})
+	if err != nil && wait.Interrupted(err) {
+		return fmt.Errorf("workload %s still has finalizers %v: %w", key, workload.Finalizers, err)
+	}
+	return err
+}
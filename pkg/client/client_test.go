@@ -0,0 +1,241 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/conditions"
+)
+
+func newTestClient(t *testing.T, objs ...ctrlclient.Object) (*Client, ctrlclient.Client) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatalf("failed to add scheme: %v", err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	client := New(c)
+	client.pollInterval = 10 * time.Millisecond
+	return client, c
+}
+
+func testWorkload(generation int64, conds ...metav1.Condition) *scorev1b1.Workload {
+	return &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "team-a", Generation: generation},
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"main": {Image: "app:v1"}},
+		},
+		Status: scorev1b1.WorkloadStatus{Conditions: conds},
+	}
+}
+
+func TestApplyWorkload(t *testing.T) {
+	ctx := context.Background()
+	client, c := newTestClient(t)
+
+	workload := testWorkload(0)
+	workload.Labels = map[string]string{"team": "a"}
+	if _, err := client.ApplyWorkload(ctx, workload); err != nil {
+		t.Fatalf("ApplyWorkload() create error = %v", err)
+	}
+
+	update := testWorkload(0)
+	update.Spec.Containers["main"] = scorev1b1.ContainerSpec{Image: "app:v2"}
+	update.Labels = map[string]string{"stage": "ci"}
+	applied, err := client.ApplyWorkload(ctx, update)
+	if err != nil {
+		t.Fatalf("ApplyWorkload() update error = %v", err)
+	}
+
+	stored := &scorev1b1.Workload{}
+	if err := c.Get(ctx, ObjectKeyFromObject(workload), stored); err != nil {
+		t.Fatalf("failed to get workload: %v", err)
+	}
+	if got := stored.Spec.Containers["main"].Image; got != "app:v2" {
+		t.Errorf("image = %q, want app:v2", got)
+	}
+	if want := map[string]string{"team": "a", "stage": "ci"}; !reflect.DeepEqual(stored.Labels, want) {
+		t.Errorf("labels = %v, want %v", stored.Labels, want)
+	}
+	if applied.ResourceVersion != stored.ResourceVersion {
+		t.Errorf("ApplyWorkload() resourceVersion = %q, want %q", applied.ResourceVersion, stored.ResourceVersion)
+	}
+}
+
+func TestWaitForReady(t *testing.T) {
+	ready := func(status metav1.ConditionStatus, reason string, generation int64) metav1.Condition {
+		return metav1.Condition{Type: conditions.ConditionReady, Status: status, Reason: reason, ObservedGeneration: generation}
+	}
+
+	tests := []struct {
+		name            string
+		workload        *scorev1b1.Workload
+		wantErr         error
+		wantTimeout     bool
+		wantTransitions int
+	}{
+		{
+			name:            "ready",
+			workload:        testWorkload(2, ready(metav1.ConditionTrue, conditions.ReasonSucceeded, 2)),
+			wantTransitions: 1,
+		},
+		{
+			name:            "ready for a previous generation",
+			workload:        testWorkload(3, ready(metav1.ConditionTrue, conditions.ReasonSucceeded, 2)),
+			wantTimeout:     true,
+			wantTransitions: 1,
+		},
+		{
+			name:            "still provisioning",
+			workload:        testWorkload(1, ready(metav1.ConditionFalse, conditions.ReasonClaimPending, 1)),
+			wantTimeout:     true,
+			wantTransitions: 1,
+		},
+		{
+			name:            "policy violation",
+			workload:        testWorkload(1, ready(metav1.ConditionFalse, conditions.ReasonPolicyViolation, 1)),
+			wantErr:         ErrWorkloadFailed,
+			wantTransitions: 1,
+		},
+		{
+			name:        "missing workload",
+			wantTimeout: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var objs []ctrlclient.Object
+			if tt.workload != nil {
+				objs = append(objs, tt.workload)
+			}
+			client, _ := newTestClient(t, objs...)
+
+			var transitions []Transition
+			key := ObjectKey{Namespace: "team-a", Name: "app"}
+			got, err := client.WaitForReady(context.Background(), key, 100*time.Millisecond, func(transition Transition) {
+				transitions = append(transitions, transition)
+			})
+
+			switch {
+			case tt.wantErr != nil:
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("WaitForReady() error = %v, want %v", err, tt.wantErr)
+				}
+			case tt.wantTimeout:
+				if err == nil {
+					t.Errorf("WaitForReady() = %v, want a timeout", got)
+				}
+			case err != nil:
+				t.Errorf("WaitForReady() error = %v", err)
+			}
+			if len(transitions) != tt.wantTransitions {
+				t.Errorf("WaitForReady() reported %d transitions, want %d: %+v", len(transitions), tt.wantTransitions, transitions)
+			}
+		})
+	}
+}
+
+func TestWaitForReadyReportsTransitions(t *testing.T) {
+	ctx := context.Background()
+	workload := testWorkload(1, metav1.Condition{
+		Type: conditions.ConditionReady, Status: metav1.ConditionFalse, Reason: conditions.ReasonClaimPending, ObservedGeneration: 1,
+	})
+	client, c := newTestClient(t, workload)
+
+	transitions := make(chan Transition, 10)
+	go func() {
+		<-transitions
+		stored := &scorev1b1.Workload{}
+		if err := c.Get(ctx, ObjectKeyFromObject(workload), stored); err != nil {
+			return
+		}
+		stored.Status.Conditions[0].Status = metav1.ConditionTrue
+		stored.Status.Conditions[0].Reason = conditions.ReasonSucceeded
+		_ = c.Update(ctx, stored)
+	}()
+
+	if _, err := client.WaitForReady(ctx, ObjectKeyFromObject(workload), 5*time.Second, func(transition Transition) {
+		transitions <- transition
+	}); err != nil {
+		t.Fatalf("WaitForReady() error = %v", err)
+	}
+
+	close(transitions)
+	var reasons []string
+	for transition := range transitions {
+		reasons = append(reasons, transition.Reason)
+	}
+	if want := []string{conditions.ReasonSucceeded}; !reflect.DeepEqual(reasons, want) {
+		t.Errorf("remaining transitions = %v, want %v", reasons, want)
+	}
+}
+
+func TestGetEndpoints(t *testing.T) {
+	workload := testWorkload(1)
+	workload.Status.Endpoint = ptr.To("http://app.team-a.svc:8080")
+	client, _ := newTestClient(t, workload)
+
+	endpoints, err := client.GetEndpoints(context.Background(), ObjectKeyFromObject(workload))
+	if err != nil {
+		t.Fatalf("GetEndpoints() error = %v", err)
+	}
+	if want := []scorev1b1.WorkloadEndpoint{{URL: "http://app.team-a.svc:8080"}}; !reflect.DeepEqual(endpoints, want) {
+		t.Errorf("GetEndpoints() = %+v, want %+v", endpoints, want)
+	}
+}
+
+func TestDeleteAndWait(t *testing.T) {
+	ctx := context.Background()
+	workload := testWorkload(1)
+	workload.Finalizers = []string{"score.dev/finalizer"}
+	client, c := newTestClient(t, workload)
+
+	// Without the Orchestrator removing the finalizer, the Workload is not deleted
+	if err := client.DeleteAndWait(ctx, ObjectKeyFromObject(workload), 50*time.Millisecond); err == nil {
+		t.Fatal("DeleteAndWait() = nil, want a timeout while the finalizer is present")
+	}
+
+	stored := &scorev1b1.Workload{}
+	if err := c.Get(ctx, ObjectKeyFromObject(workload), stored); err != nil {
+		t.Fatalf("failed to get workload: %v", err)
+	}
+	stored.Finalizers = nil
+	if err := c.Update(ctx, stored); err != nil {
+		t.Fatalf("failed to remove finalizer: %v", err)
+	}
+
+	if err := client.DeleteAndWait(ctx, ObjectKeyFromObject(workload), time.Second); err != nil {
+		t.Errorf("DeleteAndWait() error = %v", err)
+	}
+	if err := c.Get(ctx, ObjectKeyFromObject(workload), stored); !apierrors.IsNotFound(err) {
+		t.Errorf("workload still exists: %v", err)
+	}
+}
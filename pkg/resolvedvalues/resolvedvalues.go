@@ -0,0 +1,319 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package resolvedvalues provides typed access to WorkloadPlan.ResolvedValues, the values the Orchestrator
+// resolves the placeholders of a Workload into, and to the outputs of ResourceClaims, so that runtimes and
+// templates do not navigate the raw JSON by hand:
+//
+//	values, err := resolvedvalues.FromPlan(plan)
+//	if err != nil {
+//		return err
+//	}
+//	env, err := values.GetContainerEnv("app")
+//	if err != nil {
+//		return err
+//	}
+//	for name, value := range env {
+//		if value.SecretKeyRef != nil {
+//			// project value.SecretKeyRef.Name / value.SecretKeyRef.Key instead of writing the value
+//		}
+//	}
+//
+// The format is versioned by the top-level "version" key. Values without it are in the v1 format, which is
+// the only one this package reads; Parse rejects other versions with ErrUnsupportedVersion so that a runtime
+// never materializes values it misreads.
+package resolvedvalues
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/provisioner"
+)
+
+// Version1 is the version of the resolved values format this package reads
+const Version1 = "v1"
+
+// externalSecretSuffix names the Secret the runtime syncs the credentials of a claim from an external store into
+const externalSecretSuffix = "-external"
+
+var (
+	// ErrUnsupportedVersion is returned for resolved values in a format this package does not read
+	ErrUnsupportedVersion = errors.New("unsupported resolved values version")
+
+	// ErrExternalized is returned for plans whose values are held by the ConfigMap named in
+	// spec.resolvedValuesRef; its "values.json" key is read with Parse
+	ErrExternalized = errors.New("resolved values are externalized")
+
+	// ErrContainerNotFound is returned for containers the resolved values do not declare
+	ErrContainerNotFound = errors.New("container not found in resolved values")
+
+	// ErrOutputsNotAvailable is returned for claims whose outputs are not available yet
+	ErrOutputsNotAvailable = errors.New("resource outputs are not available")
+
+	// ErrOutputNotFound is returned for outputs a claim does not expose
+	ErrOutputNotFound = errors.New("resource output not found")
+)
+
+// Values are the resolved values of a WorkloadPlan
+type Values struct {
+	// Version is the version of the format; empty means Version1
+	Version         string               `json:"version,omitempty"`
+	Containers      map[string]Container `json:"containers,omitempty"`
+	ServiceAccount  *ServiceAccount      `json:"serviceAccount,omitempty"`
+	Service         *Service             `json:"service,omitempty"`
+	Storage         *Storage             `json:"storage,omitempty"`
+	ExternalSecrets []ExternalSecret     `json:"externalSecrets,omitempty"`
+}
+
+// Container holds the resolved command, environment and files of a container
+type Container struct {
+	// Image is the image pinned by digest, when the Orchestrator resolved one
+	Image      string              `json:"image,omitempty"`
+	Command    []string            `json:"command,omitempty"`
+	Args       []string            `json:"args,omitempty"`
+	WorkingDir string              `json:"workingDir,omitempty"`
+	Env        map[string]EnvValue `json:"env,omitempty"`
+	Files      []File              `json:"files,omitempty"`
+}
+
+// EnvValue is the value of an environment variable: a literal, or a reference to the Secret key holding a
+// sensitive output, which runtimes project instead of writing the value
+type EnvValue struct {
+	Value        string
+	SecretKeyRef *SecretKeyRef
+}
+
+// SecretKeyRef references a key of a Secret in the namespace of the WorkloadPlan
+type SecretKeyRef struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// UnmarshalJSON reads a literal, of any JSON scalar type, or a {"secretKeyRef": {"name", "key"}} reference
+func (v *EnvValue) UnmarshalJSON(data []byte) error {
+	*v = EnvValue{}
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		return json.Unmarshal(data, &v.Value)
+	case len(data) > 0 && data[0] == '{':
+		var ref struct {
+			SecretKeyRef *SecretKeyRef `json:"secretKeyRef"`
+		}
+		if err := json.Unmarshal(data, &ref); err != nil {
+			return err
+		}
+		if ref.SecretKeyRef == nil {
+			return fmt.Errorf("value is neither a literal nor a secretKeyRef")
+		}
+		if ref.SecretKeyRef.Name == "" || ref.SecretKeyRef.Key == "" {
+			return fmt.Errorf("secretKeyRef requires name and key")
+		}
+		v.SecretKeyRef = ref.SecretKeyRef
+		return nil
+	case len(data) > 0 && data[0] == '[':
+		return fmt.Errorf("value is neither a literal nor a secretKeyRef")
+	}
+	// Numbers and booleans are passed as their JSON text
+	v.Value = string(data)
+	return nil
+}
+
+// MarshalJSON writes the value in the format UnmarshalJSON reads
+func (v EnvValue) MarshalJSON() ([]byte, error) {
+	if v.SecretKeyRef != nil {
+		return json.Marshal(map[string]*SecretKeyRef{"secretKeyRef": v.SecretKeyRef})
+	}
+	return json.Marshal(v.Value)
+}
+
+// File is a file mounted into a container. Exactly one of Content, BinaryContent and Source is set.
+type File struct {
+	Target        string      `json:"target"`
+	Mode          *string     `json:"mode,omitempty"`
+	Content       *string     `json:"content,omitempty"`
+	BinaryContent *string     `json:"binaryContent,omitempty"`
+	Source        *FileSource `json:"source,omitempty"`
+}
+
+// FileSource is the URI a file is read from
+type FileSource struct {
+	URI string `json:"uri"`
+}
+
+// ServiceAccount is the ServiceAccount of the Workload with its resolved annotations
+type ServiceAccount struct {
+	Name        string            `json:"name"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+// Service holds the resolved ports of the Workload service
+type Service struct {
+	Ports []ServicePort `json:"ports,omitempty"`
+}
+
+// ServicePort is a service port with its resolved target port, in the order of the Workload spec
+type ServicePort struct {
+	Port       int32              `json:"port"`
+	TargetPort intstr.IntOrString `json:"targetPort"`
+}
+
+// Storage holds the resolved sources of the shared volumes of the Workload
+type Storage struct {
+	Volumes []Volume `json:"volumes,omitempty"`
+}
+
+// Volume is a shared volume mounted from the claim named by Source
+type Volume struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+}
+
+// ExternalSecret is a Secret the runtime syncs from an external secret store
+type ExternalSecret struct {
+	Name    string   `json:"name"`
+	Store   string   `json:"store"`
+	Path    string   `json:"path"`
+	Version string   `json:"version,omitempty"`
+	Keys    []string `json:"keys,omitempty"`
+}
+
+// Parse reads resolved values. Empty input yields empty values.
+func Parse(raw []byte) (*Values, error) {
+	values := &Values{}
+	if len(bytes.TrimSpace(raw)) == 0 {
+		return values, nil
+	}
+	if err := json.Unmarshal(raw, values); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resolved values: %w", err)
+	}
+	if values.Version != "" && values.Version != Version1 {
+		return nil, fmt.Errorf("%w %q, want %s", ErrUnsupportedVersion, values.Version, Version1)
+	}
+	return values, nil
+}
+
+// FromPlan reads the resolved values of the plan. Plans without resolved values yield empty values.
+func FromPlan(plan *scorev1b1.WorkloadPlan) (*Values, error) {
+	if plan.Spec.ResolvedValues == nil {
+		if ref := plan.Spec.ResolvedValuesRef; ref != nil {
+			return nil, fmt.Errorf("%w in ConfigMap %s", ErrExternalized, ref.Name)
+		}
+		return &Values{}, nil
+	}
+	return Parse(plan.Spec.ResolvedValues.Raw)
+}
+
+// GetContainerEnv returns the resolved environment of the container, which is empty when the container
+// declares no variables
+func (v *Values) GetContainerEnv(container string) (map[string]EnvValue, error) {
+	resolved, ok := v.Containers[container]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrContainerNotFound, container)
+	}
+	return resolved.Env, nil
+}
+
+// TargetPorts returns the resolved target ports of the service, in port order
+func (v *Values) TargetPorts() []intstr.IntOrString {
+	if v.Service == nil {
+		return nil
+	}
+	targetPorts := make([]intstr.IntOrString, 0, len(v.Service.Ports))
+	for _, port := range v.Service.Ports {
+		targetPorts = append(targetPorts, port.TargetPort)
+	}
+	return targetPorts
+}
+
+// Output is an output of a ResourceClaim
+type Output struct {
+	// Value is the value of a literal output
+	Value string
+	// SecretKeyRef references the Secret key holding the output when its value is not exposed on the claim
+	SecretKeyRef *SecretKeyRef
+	// Sensitive is set for outputs that must not be written into plain configuration, e.g. URIs carrying a password
+	Sensitive bool
+}
+
+// GetResourceOutput returns the named output of the claim. The typed outputs (uri, image, hostname, cert,
+// pvcRef, storageClass and capacity) are literals; other outputs reference the key of the claim's Secret, or
+// of the Secret its external store credentials are synced into, without reading it.
+func GetResourceOutput(claim *scorev1b1.ResourceClaim, output string) (Output, error) {
+	outputs := claim.Status.Outputs
+	if !claim.Status.OutputsAvailable || outputs == nil {
+		return Output{}, fmt.Errorf("%w: claim %s", ErrOutputsNotAvailable, claim.Name)
+	}
+
+	literal := func(value *string) (Output, error) {
+		if value == nil {
+			return Output{}, fmt.Errorf("%w: claim %s has no output %s", ErrOutputNotFound, claim.Name, output)
+		}
+		return Output{Value: *value}, nil
+	}
+	switch output {
+	case "uri":
+		if outputs.URI != nil {
+			return Output{Value: *outputs.URI, Sensitive: provisioner.URIContainsPassword(*outputs.URI)}, nil
+		}
+	case "image":
+		return literal(outputs.Image)
+	case "hostname":
+		return literal(outputs.Hostname)
+	case "cert":
+		if outputs.Cert != nil {
+			return literal(outputs.Cert.SecretName)
+		}
+	case "pvcRef":
+		if outputs.PVCRef != nil {
+			return Output{Value: outputs.PVCRef.Name}, nil
+		}
+	case "storageClass":
+		if outputs.PVCRef != nil {
+			return Output{Value: outputs.PVCRef.StorageClass}, nil
+		}
+	case "capacity":
+		if outputs.PVCRef != nil {
+			return Output{Value: outputs.PVCRef.Capacity}, nil
+		}
+	}
+
+	if ref := outputs.ExternalSecretRef; ref != nil {
+		for _, key := range ref.Keys {
+			if key == output {
+				return Output{SecretKeyRef: &SecretKeyRef{Name: ExternalSecretName(claim), Key: key}, Sensitive: true}, nil
+			}
+		}
+	}
+	if ref := outputs.SecretRef; ref != nil {
+		return Output{SecretKeyRef: &SecretKeyRef{Name: ref.Name, Key: output}, Sensitive: true}, nil
+	}
+	return Output{}, fmt.Errorf("%w: claim %s has no output %s", ErrOutputNotFound, claim.Name, output)
+}
+
+// ExternalSecretName returns the name of the Secret the runtime syncs the external store credentials of the
+// claim into
+func ExternalSecretName(claim *scorev1b1.ResourceClaim) string {
+	return claim.Name + externalSecretSuffix
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package resolvedvalues
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/ptr"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    *Values
+		wantErr bool
+		errIs   error
+	}{
+		{name: "empty", want: &Values{}},
+		{
+			name: "containers and service",
+			raw: `{"containers":{"app":{"image":"nginx@sha256:abc","command":["run"],"env":{` +
+				`"A":"literal","B":8080,"C":true,"D":null,"E":{"secretKeyRef":{"name":"db","key":"password"}}},` +
+				`"files":[{"target":"/etc/app.conf","mode":"0600","content":"x"}]}},` +
+				`"service":{"ports":[{"port":80,"targetPort":8080},{"port":443,"targetPort":"https"}]}}`,
+			want: &Values{
+				Containers: map[string]Container{"app": {
+					Image:   "nginx@sha256:abc",
+					Command: []string{"run"},
+					Env: map[string]EnvValue{
+						"A": {Value: "literal"},
+						"B": {Value: "8080"},
+						"C": {Value: "true"},
+						"D": {},
+						"E": {SecretKeyRef: &SecretKeyRef{Name: "db", Key: "password"}},
+					},
+					Files: []File{{Target: "/etc/app.conf", Mode: ptr.To("0600"), Content: ptr.To("x")}},
+				}},
+				Service: &Service{Ports: []ServicePort{
+					{Port: 80, TargetPort: intstr.FromInt32(8080)},
+					{Port: 443, TargetPort: intstr.FromString("https")},
+				}},
+			},
+		},
+		{name: "current version", raw: `{"version":"v1"}`, want: &Values{Version: Version1}},
+		{name: "unsupported version", raw: `{"version":"v2"}`, wantErr: true, errIs: ErrUnsupportedVersion},
+		{name: "env object without reference", raw: `{"containers":{"app":{"env":{"A":{"value":"x"}}}}}`, wantErr: true},
+		{name: "reference without key", raw: `{"containers":{"app":{"env":{"A":{"secretKeyRef":{"name":"db"}}}}}}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse([]byte(tt.raw))
+			if (err != nil) != tt.wantErr || (tt.errIs != nil && !errors.Is(err, tt.errIs)) {
+				t.Fatalf("Parse() error = %v, want error %v (%v)", err, tt.wantErr, tt.errIs)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEnvValueRoundTrip(t *testing.T) {
+	env := map[string]EnvValue{
+		"A": {Value: "literal"},
+		"B": {SecretKeyRef: &SecretKeyRef{Name: "db", Key: "password"}},
+	}
+	raw, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"A":"literal","B":{"secretKeyRef":{"name":"db","key":"password"}}}`; string(raw) != want {
+		t.Errorf("Marshal() = %s, want %s", raw, want)
+	}
+	var got map[string]EnvValue
+	if err := json.Unmarshal(raw, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, env) {
+		t.Errorf("Unmarshal() = %+v, want %+v", got, env)
+	}
+}
+
+func TestGetContainerEnv(t *testing.T) {
+	plan := &scorev1b1.WorkloadPlan{Spec: scorev1b1.WorkloadPlanSpec{
+		ResolvedValues: &runtime.RawExtension{Raw: []byte(`{"containers":{"app":{"env":{"A":"x"}},"sidecar":{}}}`)},
+	}}
+	values, err := FromPlan(plan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := values.GetContainerEnv("app")
+	if err != nil || !reflect.DeepEqual(env, map[string]EnvValue{"A": {Value: "x"}}) {
+		t.Errorf("GetContainerEnv(app) = %+v, %v", env, err)
+	}
+	if env, err := values.GetContainerEnv("sidecar"); err != nil || len(env) != 0 {
+		t.Errorf("GetContainerEnv(sidecar) = %+v, %v, want no variables", env, err)
+	}
+	if _, err := values.GetContainerEnv("missing"); !errors.Is(err, ErrContainerNotFound) {
+		t.Errorf("GetContainerEnv(missing) error = %v, want %v", err, ErrContainerNotFound)
+	}
+
+	plan.Spec.ResolvedValues = nil
+	plan.Spec.ResolvedValuesRef = &scorev1b1.ValuesReference{Name: "app-values-0123456789"}
+	if _, err := FromPlan(plan); !errors.Is(err, ErrExternalized) {
+		t.Errorf("FromPlan() error = %v, want %v", err, ErrExternalized)
+	}
+}
+
+func TestGetResourceOutput(t *testing.T) {
+	claim := func(outputs *scorev1b1.ResourceClaimOutputs) *scorev1b1.ResourceClaim {
+		c := &scorev1b1.ResourceClaim{}
+		c.Name = "db"
+		c.Status.Outputs = outputs
+		c.Status.OutputsAvailable = outputs != nil
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		claim   *scorev1b1.ResourceClaim
+		output  string
+		want    Output
+		wantErr error
+	}{
+		{name: "not available", claim: claim(nil), output: "uri", wantErr: ErrOutputsNotAvailable},
+		{name: "uri", claim: claim(&scorev1b1.ResourceClaimOutputs{URI: ptr.To("redis://db:6379")}), output: "uri",
+			want: Output{Value: "redis://db:6379"}},
+		{name: "uri with a password", claim: claim(&scorev1b1.ResourceClaimOutputs{URI: ptr.To("postgres://u:p@db/app")}), output: "uri",
+			want: Output{Value: "postgres://u:p@db/app", Sensitive: true}},
+		{name: "pvc capacity", claim: claim(&scorev1b1.ResourceClaimOutputs{PVCRef: &scorev1b1.PersistentVolumeClaimOutput{Name: "data", Capacity: "1Gi"}}),
+			output: "capacity", want: Output{Value: "1Gi"}},
+		{name: "secret key", claim: claim(&scorev1b1.ResourceClaimOutputs{SecretRef: &scorev1b1.LocalObjectReference{Name: "db-creds"}}),
+			output: "password", want: Output{SecretKeyRef: &SecretKeyRef{Name: "db-creds", Key: "password"}, Sensitive: true}},
+		{name: "external secret key", claim: claim(&scorev1b1.ResourceClaimOutputs{ExternalSecretRef: &scorev1b1.ExternalSecretReference{Keys: []string{"password"}}}),
+			output: "password", want: Output{SecretKeyRef: &SecretKeyRef{Name: "db-external", Key: "password"}, Sensitive: true}},
+		{name: "missing output", claim: claim(&scorev1b1.ResourceClaimOutputs{Image: ptr.To("nginx:1")}), output: "hostname", wantErr: ErrOutputNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetResourceOutput(tt.claim, tt.output)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("GetResourceOutput() error = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetResourceOutput() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("GetResourceOutput() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"os"
@@ -27,15 +28,22 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/discovery"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/backoff"
+	"github.com/cappyzawa/score-orchestrator/internal/events"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/runtimeregistry"
+	"github.com/cappyzawa/score-orchestrator/internal/tracing"
 	runtimectrl "github.com/cappyzawa/score-orchestrator/runtimes/kubernetes/internal/controller"
 	// +kubebuilder:scaffold:imports
 )
@@ -43,6 +51,9 @@ import (
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
+
+	// version is published in the runtime registration; set at build time with -ldflags "-X main.version=..."
+	version = "dev"
 )
 
 func init() {
@@ -60,6 +71,9 @@ func main() {
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
+	var enableTracing bool
+	var planConcurrency, exposureConcurrency int
+	var registrationNamespace string
 	var tlsOpts []func(*tls.Config)
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
@@ -80,9 +94,18 @@ func main() {
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 
+	flag.BoolVar(&enableTracing, "enable-tracing", false,
+		"If set, reconcile spans are exported via OTLP/gRPC. Configure the exporter with OTEL_EXPORTER_OTLP_* variables.")
+	flag.IntVar(&planConcurrency, "plan-max-concurrent-reconciles", 1,
+		"The maximum number of WorkloadPlans reconciled in parallel.")
+	flag.IntVar(&exposureConcurrency, "exposure-max-concurrent-reconciles", 1,
+		"The maximum number of WorkloadExposures reconciled in parallel.")
 	opts := zap.Options{
 		Development: true,
 	}
+	flag.StringVar(&registrationNamespace, "registration-namespace", os.Getenv("POD_NAMESPACE"),
+		"The namespace of the ConfigMap that registers this runtime with the Orchestrator. "+
+			"Defaults to $POD_NAMESPACE; registration is disabled when empty.")
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
@@ -174,12 +197,53 @@ func main() {
 	// Setup signal handler and context
 	ctx := ctrl.SetupSignalHandler()
 
+	// Setup tracing
+	if enableTracing {
+		shutdownTracing, err := tracing.Setup(ctx, "score-runtime-kubernetes")
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		// Flush pending spans when the manager stops
+		if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+			<-ctx.Done()
+			return shutdownTracing(context.Background())
+		})); err != nil {
+			setupLog.Error(err, "unable to register tracing shutdown")
+			os.Exit(1)
+		}
+	}
+
+	// Discover the optional APIs the cluster serves; capabilities without their API are disabled
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(mgr.GetConfig())
+	if err != nil {
+		setupLog.Error(err, "unable to create discovery client")
+		os.Exit(1)
+	}
+	capabilities, err := runtimectrl.DiscoverCapabilities(discoveryClient)
+	if err != nil {
+		setupLog.Error(err, "unable to discover cluster capabilities")
+		os.Exit(1)
+	}
+	if missing := capabilities.Missing(); len(missing) > 0 {
+		setupLog.Info("Disabling capabilities whose API the cluster does not serve", "capabilities", missing)
+	}
+
+	// Setup indexers
+	if err := runtimectrl.SetupIndexers(ctx, mgr, capabilities); err != nil {
+		setupLog.Error(err, "unable to set up indexers")
+		os.Exit(1)
+	}
+
 	// Setup WorkloadPlan controller
 	setupLog.Info("Setting up WorkloadPlan Controller")
 	planController := &runtimectrl.KubernetesRuntimePlanReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
-		Recorder: mgr.GetEventRecorderFor("kubernetes-plan-controller"),
+		Recorder: events.NewEmitter(mgr.GetEventRecorderFor("kubernetes-plan-controller"), events.DefaultOptions()),
+		Backoff:  backoff.New("k8s-runtime-plan", nil),
+
+		MaxConcurrentReconciles: planConcurrency,
 	}
 
 	if err := planController.SetupWithManager(mgr); err != nil {
@@ -192,7 +256,10 @@ func main() {
 	exposureController := &runtimectrl.KubernetesRuntimeExposureReconciler{
 		Client:   mgr.GetClient(),
 		Scheme:   mgr.GetScheme(),
-		Recorder: mgr.GetEventRecorderFor("kubernetes-exposure-controller"),
+		Recorder: events.NewEmitter(mgr.GetEventRecorderFor("kubernetes-exposure-controller"), events.DefaultOptions()),
+
+		MaxConcurrentReconciles: exposureConcurrency,
+		Capabilities:            capabilities,
 	}
 
 	if err := exposureController.SetupWithManager(mgr); err != nil {
@@ -200,6 +267,37 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Register the runtime so the Orchestrator can select backends of its runtimeClass
+	if registrationNamespace != "" {
+		if err := mgr.Add(&runtimeregistry.Heartbeat{
+			Client:    mgr.GetClient(),
+			Namespace: registrationNamespace,
+			Name:      "score-runtime-" + meta.RuntimeClassKubernetes,
+			Registration: runtimeregistry.Registration{
+				RuntimeClass: meta.RuntimeClassKubernetes,
+				Version:      version,
+				Features:     []string{scorev1b1.WorkloadKindService, scorev1b1.WorkloadKindJob, scorev1b1.WorkloadKindCronJob},
+
+				MissingCapabilities: capabilities.Missing(),
+			},
+			FieldManager: meta.FieldManagerRuntimeKubernetes,
+		}); err != nil {
+			setupLog.Error(err, "unable to register runtime")
+			os.Exit(1)
+		}
+	}
+
+	// Acknowledge the plans of the runtimeClass so the Orchestrator can tell this runtime is running
+	if err := mgr.Add(&runtimeregistry.PlanHeartbeat{
+		Client:          mgr.GetClient(),
+		RuntimeClass:    meta.RuntimeClassKubernetes,
+		ConsumedBy:      "score-runtime-" + meta.RuntimeClassKubernetes,
+		ConsumerVersion: version,
+	}); err != nil {
+		setupLog.Error(err, "unable to add plan heartbeat")
+		os.Exit(1)
+	}
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
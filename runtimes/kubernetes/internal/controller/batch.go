@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/propagation"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+)
+
+// Kubernetes resource kinds materialized for a WorkloadPlan
+const (
+	kindDeployment  = "Deployment"
+	kindStatefulSet = "StatefulSet"
+	kindJob         = "Job"
+	kindCronJob     = "CronJob"
+)
+
+// materializedKind returns the Kubernetes resource kind materialized for the plan.
+// Continuously running workloads with per-replica volumes get a StatefulSet instead of a Deployment.
+func materializedKind(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) string {
+	switch plan.Spec.Kind {
+	case scorev1b1.WorkloadKindJob:
+		return kindJob
+	case scorev1b1.WorkloadKindCronJob:
+		return kindCronJob
+	}
+	if hasPerReplicaVolumes(workload) {
+		return kindStatefulSet
+	}
+	return kindDeployment
+}
+
+// reconcileJob applies the Job for the WorkloadPlan.
+// The pod template of a Job is immutable, so a Job created for an older plan generation is deleted
+// and recreated on the next reconcile (triggered by the deletion); replaced reports that case.
+func (r *KubernetesRuntimePlanReconciler) reconcileJob(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) (replaced bool, err error) {
+	job, err := r.buildJob(ctx, plan, workload)
+	if err != nil {
+		return false, fmt.Errorf("failed to build job: %w", err)
+	}
+
+	existing := &batchv1.Job{}
+	err = r.Get(ctx, client.ObjectKeyFromObject(job), existing)
+	switch {
+	case err == nil && existing.Annotations["score.dev/plan-generation"] != job.Annotations["score.dev/plan-generation"]:
+		if existing.DeletionTimestamp.IsZero() {
+			if err := r.Delete(ctx, existing, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+				return false, fmt.Errorf("failed to delete outdated job: %w", err)
+			}
+			log.FromContext(ctx).Info("Deleted Job created for an outdated plan", "name", existing.Name)
+		}
+		return true, nil
+	case err == nil:
+		// The Job already runs this plan generation; re-applying would only be rejected for immutable fields
+		return false, nil
+	case !apierrors.IsNotFound(err):
+		return false, fmt.Errorf("failed to get job: %w", err)
+	}
+
+	if err := r.setOwner(plan, job); err != nil {
+		return false, fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	if err := reconcile.Apply(ctx, r.Client, job, meta.FieldManagerRuntimeKubernetes); err != nil {
+		return false, fmt.Errorf("failed to apply job: %w", err)
+	}
+	log.FromContext(ctx).V(1).Info("Applied Job", "name", job.Name)
+
+	return false, nil
+}
+
+// reconcileCronJob applies the CronJob for the WorkloadPlan with server-side apply
+func (r *KubernetesRuntimePlanReconciler) reconcileCronJob(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) error {
+	cronJob, err := r.buildCronJob(ctx, plan, workload)
+	if err != nil {
+		return fmt.Errorf("failed to build cronjob: %w", err)
+	}
+
+	if err := r.setOwner(plan, cronJob); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	if err := reconcile.Apply(ctx, r.Client, cronJob, meta.FieldManagerRuntimeKubernetes); err != nil {
+		return fmt.Errorf("failed to apply cronjob: %w", err)
+	}
+	log.FromContext(ctx).V(1).Info("Applied CronJob", "name", cronJob.Name)
+
+	return nil
+}
+
+// buildJob constructs a Job that runs the Workload containers to completion
+func (r *KubernetesRuntimePlanReconciler) buildJob(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) (*batchv1.Job, error) {
+	jobSpec, err := r.buildJobSpec(ctx, plan, workload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchv1.Job{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: batchv1.SchemeGroupVersion.String(),
+			Kind:       "Job",
+		},
+		ObjectMeta: runtimeObjectMeta(plan, workload),
+		Spec:       *jobSpec,
+	}, nil
+}
+
+// buildCronJob constructs a CronJob that runs the Workload containers on the plan schedule
+func (r *KubernetesRuntimePlanReconciler) buildCronJob(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) (*batchv1.CronJob, error) {
+	if plan.Spec.Schedule == "" {
+		return nil, fmt.Errorf("plan of kind %s has no schedule", scorev1b1.WorkloadKindCronJob)
+	}
+
+	jobSpec, err := r.buildJobSpec(ctx, plan, workload)
+	if err != nil {
+		return nil, err
+	}
+
+	return &batchv1.CronJob{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: batchv1.SchemeGroupVersion.String(),
+			Kind:       "CronJob",
+		},
+		ObjectMeta: runtimeObjectMeta(plan, workload),
+		Spec: batchv1.CronJobSpec{
+			Schedule:          plan.Spec.Schedule,
+			ConcurrencyPolicy: batchv1.ForbidConcurrent,
+			JobTemplate: batchv1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: propagation.Labels(runtimeLabels(plan.Spec.WorkloadRef.Name), plan.Spec.Metadata),
+				},
+				Spec: *jobSpec,
+			},
+		},
+	}, nil
+}
+
+// buildJobSpec constructs the Job spec shared by Jobs and CronJobs.
+// No TTL is set: a Job removed after completion would be recreated and run again.
+func (r *KubernetesRuntimePlanReconciler) buildJobSpec(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) (*batchv1.JobSpec, error) {
+	containers, err := r.buildContainers(ctx, plan, workload)
+	if err != nil {
+		return nil, err
+	}
+	serviceAccount, err := serviceAccountName(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	jobSpec := &batchv1.JobSpec{
+		Template: corev1.PodTemplateSpec{
+			ObjectMeta: podTemplateMeta(plan),
+			Spec: corev1.PodSpec{
+				Containers:         containers,
+				RestartPolicy:      corev1.RestartPolicyOnFailure,
+				ServiceAccountName: serviceAccount,
+				SecurityContext:    podSecurityContext(plan),
+				PriorityClassName:  priorityClassName(plan),
+			},
+		},
+	}
+
+	files, err := buildFileProjection(plan, workload)
+	if err != nil {
+		return nil, err
+	}
+	files.applyTo(&jobSpec.Template)
+
+	return jobSpec, nil
+}
+
+// jobPhase derives the plan phase from Job completion: Ready once the Job completed, Failed once it failed
+func jobPhase(job *batchv1.Job) (scorev1b1.WorkloadPlanPhase, string) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return scorev1b1.WorkloadPlanPhaseReady, "Job completed successfully"
+		case batchv1.JobFailed:
+			message := "Job failed"
+			if condition.Message != "" {
+				message = fmt.Sprintf("Job failed: %s", condition.Message)
+			}
+			return scorev1b1.WorkloadPlanPhaseFailed, message
+		}
+	}
+	return scorev1b1.WorkloadPlanPhaseProvisioning, "Job is running"
+}
@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestJobPhase(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions []batchv1.JobCondition
+		want       scorev1b1.WorkloadPlanPhase
+	}{
+		{
+			name: "running",
+			want: scorev1b1.WorkloadPlanPhaseProvisioning,
+		},
+		{
+			name:       "complete",
+			conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}},
+			want:       scorev1b1.WorkloadPlanPhaseReady,
+		},
+		{
+			name:       "failed",
+			conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}},
+			want:       scorev1b1.WorkloadPlanPhaseFailed,
+		},
+		{
+			name:       "condition not true",
+			conditions: []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionFalse}},
+			want:       scorev1b1.WorkloadPlanPhaseProvisioning,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			job := &batchv1.Job{Status: batchv1.JobStatus{Conditions: tt.conditions}}
+			if got, _ := jobPhase(job); got != tt.want {
+				t.Errorf("jobPhase() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildCronJob(t *testing.T) {
+	r := &KubernetesRuntimePlanReconciler{}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"main": {Image: "busybox"}},
+		},
+	}
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "report", Namespace: "default"},
+			Kind:        scorev1b1.WorkloadKindCronJob,
+			Schedule:    "0 3 * * *",
+		},
+	}
+
+	cronJob, err := r.buildCronJob(context.Background(), plan, workload)
+	if err != nil {
+		t.Fatalf("buildCronJob() error = %v", err)
+	}
+	if cronJob.Spec.Schedule != "0 3 * * *" {
+		t.Errorf("schedule = %q, want %q", cronJob.Spec.Schedule, "0 3 * * *")
+	}
+	podSpec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	if podSpec.RestartPolicy != corev1.RestartPolicyOnFailure {
+		t.Errorf("restartPolicy = %q, want %q", podSpec.RestartPolicy, corev1.RestartPolicyOnFailure)
+	}
+	if len(podSpec.Containers) != 1 || podSpec.Containers[0].Image != "busybox" {
+		t.Errorf("containers = %v, want the workload container", podSpec.Containers)
+	}
+	if cronJob.Spec.JobTemplate.Spec.TTLSecondsAfterFinished != nil {
+		t.Error("jobs must not set a TTL")
+	}
+
+	plan.Spec.Schedule = ""
+	if _, err := r.buildCronJob(context.Background(), plan, workload); err == nil {
+		t.Error("buildCronJob() without schedule should fail")
+	}
+}
+
+func TestMaterializedKind(t *testing.T) {
+	storage := &scorev1b1.StorageSpec{Volumes: []scorev1b1.PersistentVolumeSpec{{Name: "data", Size: "1Gi", Target: "/data"}}}
+
+	tests := []struct {
+		name     string
+		kind     string
+		storage  *scorev1b1.StorageSpec
+		expected string
+	}{
+		{"default kind", "", nil, kindDeployment},
+		{"service kind", scorev1b1.WorkloadKindService, nil, kindDeployment},
+		{"service kind with storage", scorev1b1.WorkloadKindService, storage, kindStatefulSet},
+		{"job kind", scorev1b1.WorkloadKindJob, nil, kindJob},
+		{"job kind ignores storage", scorev1b1.WorkloadKindJob, storage, kindJob},
+		{"cronjob kind", scorev1b1.WorkloadKindCronJob, nil, kindCronJob},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &scorev1b1.WorkloadPlan{Spec: scorev1b1.WorkloadPlanSpec{Kind: tt.kind}}
+			workload := &scorev1b1.Workload{Spec: scorev1b1.WorkloadSpec{Storage: tt.storage}}
+			if got := materializedKind(plan, workload); got != tt.expected {
+				t.Errorf("materializedKind() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestStaleObjects(t *testing.T) {
+	tests := []struct {
+		kind string
+		want []string
+	}{
+		{kindDeployment, []string{"*v1.StatefulSet", "*v1.Job", "*v1.CronJob"}},
+		{kindStatefulSet, []string{"*v1.Deployment", "*v1.Job", "*v1.CronJob"}},
+		{kindJob, []string{"*v1.Deployment", "*v1.StatefulSet", "*v1.CronJob"}},
+		{kindCronJob, []string{"*v1.Deployment", "*v1.StatefulSet", "*v1.Job"}},
+		{"", []string{"*v1.Deployment", "*v1.StatefulSet", "*v1.Job", "*v1.CronJob"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			objs := staleObjects(tt.kind)
+			if len(objs) != len(tt.want) {
+				t.Fatalf("staleObjects() returned %d objects, want %d", len(objs), len(tt.want))
+			}
+			for i, obj := range objs {
+				if got := fmt.Sprintf("%T", obj); got != tt.want[i] {
+					t.Errorf("staleObjects()[%d] = %s, want %s", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
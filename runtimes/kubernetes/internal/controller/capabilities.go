@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+// Capabilities of the runtime that depend on APIs a cluster may not serve
+const (
+	// CapabilityIngress publishes the hosts of Ingresses routing to Workload Services as exposures
+	CapabilityIngress = "ingress"
+)
+
+// optionalAPIs maps each capability to the API resource it requires
+var optionalAPIs = map[string]schema.GroupVersionResource{
+	CapabilityIngress: networkingv1.SchemeGroupVersion.WithResource("ingresses"),
+}
+
+// Capabilities records which capabilities the cluster serves the APIs of.
+// A nil Capabilities enables every capability, so reconcilers set up without discovery keep all code paths.
+type Capabilities map[string]bool
+
+// DiscoverCapabilities queries the discovery API for the resources of every optional API.
+// Group versions the cluster does not serve disable their capabilities; other discovery failures are returned.
+func DiscoverCapabilities(client discovery.DiscoveryInterface) (Capabilities, error) {
+	capabilities := make(Capabilities, len(optionalAPIs))
+	for name, gvr := range optionalAPIs {
+		resources, err := client.ServerResourcesForGroupVersion(gvr.GroupVersion().String())
+		if apierrors.IsNotFound(err) {
+			capabilities[name] = false
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to discover %s: %w", gvr.GroupVersion(), err)
+		}
+		capabilities[name] = slices.ContainsFunc(resources.APIResources, func(resource metav1.APIResource) bool {
+			return resource.Name == gvr.Resource
+		})
+	}
+	return capabilities, nil
+}
+
+// Has reports whether the capability is enabled
+func (c Capabilities) Has(name string) bool {
+	if c == nil {
+		return true
+	}
+	return c[name]
+}
+
+// Missing returns the disabled capabilities in lexical order
+func (c Capabilities) Missing() []string {
+	var missing []string
+	for name, enabled := range c {
+		if !enabled {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
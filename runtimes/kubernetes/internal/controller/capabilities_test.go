@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	clienttesting "k8s.io/client-go/testing"
+)
+
+func TestDiscoverCapabilities(t *testing.T) {
+	tests := []struct {
+		name        string
+		resources   []*metav1.APIResourceList
+		wantIngress bool
+		wantMissing []string
+	}{
+		{
+			name: "ingresses served",
+			resources: []*metav1.APIResourceList{{
+				GroupVersion: "networking.k8s.io/v1",
+				APIResources: []metav1.APIResource{{Name: "networkpolicies"}, {Name: "ingresses"}},
+			}},
+			wantIngress: true,
+		},
+		{
+			name: "group version without ingresses",
+			resources: []*metav1.APIResourceList{{
+				GroupVersion: "networking.k8s.io/v1",
+				APIResources: []metav1.APIResource{{Name: "networkpolicies"}},
+			}},
+			wantMissing: []string{CapabilityIngress},
+		},
+		{
+			name:        "group version not served",
+			wantMissing: []string{CapabilityIngress},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakediscovery.FakeDiscovery{Fake: &clienttesting.Fake{Resources: tt.resources}}
+			capabilities, err := DiscoverCapabilities(client)
+			if err != nil {
+				t.Fatalf("DiscoverCapabilities() error = %v", err)
+			}
+			if capabilities.Has(CapabilityIngress) != tt.wantIngress {
+				t.Errorf("Has(%s) = %v, want %v", CapabilityIngress, capabilities.Has(CapabilityIngress), tt.wantIngress)
+			}
+			if !reflect.DeepEqual(capabilities.Missing(), tt.wantMissing) {
+				t.Errorf("Missing() = %v, want %v", capabilities.Missing(), tt.wantMissing)
+			}
+		})
+	}
+}
+
+func TestDiscoverCapabilitiesReportsDiscoveryErrors(t *testing.T) {
+	fake := &clienttesting.Fake{}
+	fake.AddReactor("get", "resource", func(clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("connection refused")
+	})
+
+	if _, err := DiscoverCapabilities(&fakediscovery.FakeDiscovery{Fake: fake}); err == nil {
+		t.Error("DiscoverCapabilities() error = nil, want the discovery error")
+	}
+}
+
+func TestNilCapabilitiesEnableEverything(t *testing.T) {
+	var capabilities Capabilities
+	if !capabilities.Has(CapabilityIngress) || len(capabilities.Missing()) != 0 {
+		t.Errorf("nil Capabilities disable %v", capabilities.Missing())
+	}
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// resolvedCommand is the command of a container with its placeholders resolved by the Orchestrator, and its
+// image when the Orchestrator pinned it by digest
+type resolvedCommand struct {
+	Image      string   `json:"image"`
+	Command    []string `json:"command"`
+	Args       []string `json:"args"`
+	WorkingDir string   `json:"workingDir"`
+}
+
+// resolvedCommands returns the resolved commands of the plan by container name
+func resolvedCommands(plan *scorev1b1.WorkloadPlan) (map[string]resolvedCommand, error) {
+	if plan.Spec.ResolvedValues == nil {
+		return nil, nil
+	}
+	var resolvedValues struct {
+		Containers map[string]resolvedCommand `json:"containers"`
+	}
+	if err := json.Unmarshal(plan.Spec.ResolvedValues.Raw, &resolvedValues); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resolved container commands: %w", err)
+	}
+	return resolvedValues.Containers, nil
+}
+
+// applyCommand sets the image, command, arguments and working directory of the container. Values resolved in
+// the plan take precedence; the Workload spec is used for values the plan does not carry, such as plans
+// resolved before commands were resolved or plans without resolved values.
+func applyCommand(container *corev1.Container, spec scorev1b1.ContainerSpec, resolved resolvedCommand) {
+	container.Image = spec.Image
+	if resolved.Image != "" {
+		container.Image = resolved.Image
+	}
+	container.Command = spec.Command
+	if len(resolved.Command) > 0 {
+		container.Command = resolved.Command
+	}
+	container.Args = spec.Args
+	if len(resolved.Args) > 0 {
+		container.Args = resolved.Args
+	}
+	container.WorkingDir = spec.WorkingDir
+	if resolved.WorkingDir != "" {
+		container.WorkingDir = resolved.WorkingDir
+	}
+}
+
+// containerLifecycle converts the lifecycle hooks of a container, or returns nil when it declares none
+func containerLifecycle(lifecycle *scorev1b1.LifecycleSpec) *corev1.Lifecycle {
+	if lifecycle == nil || (lifecycle.PostStart == nil && lifecycle.PreStop == nil) {
+		return nil
+	}
+	return &corev1.Lifecycle{
+		PostStart: lifecycleHandler(lifecycle.PostStart),
+		PreStop:   lifecycleHandler(lifecycle.PreStop),
+	}
+}
+
+// lifecycleHandler converts a lifecycle hook, or returns nil when it is not set
+func lifecycleHandler(handler *scorev1b1.LifecycleHandler) *corev1.LifecycleHandler {
+	if handler == nil {
+		return nil
+	}
+	converted := &corev1.LifecycleHandler{}
+	if handler.Exec != nil {
+		converted.Exec = &corev1.ExecAction{Command: handler.Exec.Command}
+	}
+	if handler.HTTPGet != nil {
+		headers := make([]corev1.HTTPHeader, 0, len(handler.HTTPGet.Headers))
+		for name, value := range handler.HTTPGet.Headers {
+			headers = append(headers, corev1.HTTPHeader{Name: name, Value: value})
+		}
+		// Sort headers so that the applied configuration is stable across reconciles
+		sort.Slice(headers, func(i, j int) bool { return headers[i].Name < headers[j].Name })
+		converted.HTTPGet = &corev1.HTTPGetAction{
+			Path: handler.HTTPGet.Path,
+			Port: intstr.FromInt32(handler.HTTPGet.Port),
+		}
+		if len(headers) > 0 {
+			converted.HTTPGet.HTTPHeaders = headers
+		}
+	}
+	return converted
+}
@@ -0,0 +1,102 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestBuildContainersCommand(t *testing.T) {
+	r := &KubernetesRuntimePlanReconciler{}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"app": {
+				Image:      "app",
+				Command:    []string{"/bin/server"},
+				Args:       []string{"--db", "${resources.db.outputs.uri}"},
+				WorkingDir: "/srv",
+			}},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		resolvedValues string
+		want           corev1.Container
+	}{
+		{
+			name: "without resolved values the Workload spec is used",
+			want: corev1.Container{Command: []string{"/bin/server"}, Args: []string{"--db", "${resources.db.outputs.uri}"}, WorkingDir: "/srv"},
+		},
+		{
+			name:           "resolved values take precedence",
+			resolvedValues: `{"containers":{"app":{"command":["/bin/server"],"args":["--db","postgres://db"],"workingDir":"/srv"}}}`,
+			want:           corev1.Container{Command: []string{"/bin/server"}, Args: []string{"--db", "postgres://db"}, WorkingDir: "/srv"},
+		},
+		{
+			name:           "values missing from an older plan fall back to the Workload spec",
+			resolvedValues: `{"containers":{"app":{"env":{"PORT":"8080"}}}}`,
+			want:           corev1.Container{Command: []string{"/bin/server"}, Args: []string{"--db", "${resources.db.outputs.uri}"}, WorkingDir: "/srv"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &scorev1b1.WorkloadPlan{}
+			if tt.resolvedValues != "" {
+				plan.Spec.ResolvedValues = &runtime.RawExtension{Raw: []byte(tt.resolvedValues)}
+			}
+			containers, err := r.buildContainers(context.Background(), plan, workload)
+			if err != nil {
+				t.Fatalf("buildContainers() error = %v", err)
+			}
+			got := containers[0]
+			if !equality.Semantic.DeepEqual(got.Command, tt.want.Command) || !equality.Semantic.DeepEqual(got.Args, tt.want.Args) || got.WorkingDir != tt.want.WorkingDir {
+				t.Errorf("command = %v, args = %v, workingDir = %q, want %v, %v, %q",
+					got.Command, got.Args, got.WorkingDir, tt.want.Command, tt.want.Args, tt.want.WorkingDir)
+			}
+		})
+	}
+}
+
+func TestBuildContainersLifecycle(t *testing.T) {
+	r := &KubernetesRuntimePlanReconciler{}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{
+				"app": {
+					Image: "app",
+					Lifecycle: &scorev1b1.LifecycleSpec{
+						PostStart: &scorev1b1.LifecycleHandler{Exec: &scorev1b1.ExecProbe{Command: []string{"/bin/warm-up"}}},
+						PreStop: &scorev1b1.LifecycleHandler{HTTPGet: &scorev1b1.HTTPGetProbe{
+							Path: "/drain", Port: 8080, Headers: map[string]string{"X-Reason": "shutdown"},
+						}},
+					},
+				},
+				"sidecar": {Image: "sidecar"},
+			},
+		},
+	}
+
+	containers, err := r.buildContainers(context.Background(), &scorev1b1.WorkloadPlan{}, workload)
+	if err != nil {
+		t.Fatalf("buildContainers() error = %v", err)
+	}
+	want := &corev1.Lifecycle{
+		PostStart: &corev1.LifecycleHandler{Exec: &corev1.ExecAction{Command: []string{"/bin/warm-up"}}},
+		PreStop: &corev1.LifecycleHandler{HTTPGet: &corev1.HTTPGetAction{
+			Path: "/drain", Port: intstr.FromInt32(8080), HTTPHeaders: []corev1.HTTPHeader{{Name: "X-Reason", Value: "shutdown"}},
+		}},
+	}
+	if !equality.Semantic.DeepEqual(containers[0].Lifecycle, want) {
+		t.Errorf("app lifecycle = %+v, want %+v", containers[0].Lifecycle, want)
+	}
+	if containers[1].Lifecycle != nil {
+		t.Errorf("sidecar lifecycle = %+v, want none", containers[1].Lifecycle)
+	}
+}
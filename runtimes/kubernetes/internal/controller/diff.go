@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// upToDate reports whether applying desired would leave existing materially unchanged.
+// desired must hold only the fields the runtime declares. Fields that exist only on existing, such as
+// values defaulted by the API server or set by other controllers, are ignored, and lists of named items
+// (containers, env, ports, volumes) are matched by name, so reordered items do not count as changes.
+// Quantities compare in their canonical form, as both sides are serialized from resource.Quantity.
+//
+// A field dropped from desired is not detected here; callers rely on a content hash annotation,
+// such as the pod template revision, to surface removals.
+func upToDate(desired, existing client.Object) (bool, error) {
+	if !subsetOf(desired.GetLabels(), existing.GetLabels()) || !subsetOf(desired.GetAnnotations(), existing.GetAnnotations()) {
+		return false, nil
+	}
+	for _, ref := range desired.GetOwnerReferences() {
+		if !containsOwnerReference(existing, ref.UID) {
+			return false, nil
+		}
+	}
+
+	desiredContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(desired)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert %T: %w", desired, err)
+	}
+	existingContent, err := runtime.DefaultUnstructuredConverter.ToUnstructured(existing)
+	if err != nil {
+		return false, fmt.Errorf("failed to convert %T: %w", existing, err)
+	}
+	return semanticSubset(desiredContent["spec"], existingContent["spec"]), nil
+}
+
+// subsetOf reports whether every entry of desired has the same value in existing
+func subsetOf(desired, existing map[string]string) bool {
+	for key, value := range desired {
+		if current, ok := existing[key]; !ok || current != value {
+			return false
+		}
+	}
+	return true
+}
+
+// containsOwnerReference reports whether obj is owned by the object with the given UID
+func containsOwnerReference(obj client.Object, uid types.UID) bool {
+	for _, ref := range obj.GetOwnerReferences() {
+		if ref.UID == uid {
+			return true
+		}
+	}
+	return false
+}
+
+// semanticSubset reports whether every value declared in desired is present with the same value in existing.
+// Null values in desired are treated as not declared.
+func semanticSubset(desired, existing interface{}) bool {
+	switch desired := desired.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		existing, ok := existing.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range desired {
+			if !semanticSubset(value, existing[key]) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		existing, ok := existing.([]interface{})
+		if !ok || len(desired) != len(existing) {
+			return false
+		}
+		for i, item := range desired {
+			if !semanticSubset(item, matchingItem(item, i, existing)) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(desired, existing)
+	}
+}
+
+// matchingItem returns the item of existing that corresponds to item, the i-th item of a desired list.
+// Named items are matched by name, everything else by position.
+func matchingItem(item interface{}, i int, existing []interface{}) interface{} {
+	if fields, ok := item.(map[string]interface{}); ok {
+		if name, ok := fields["name"].(string); ok {
+			for _, candidate := range existing {
+				if candidateFields, ok := candidate.(map[string]interface{}); ok && candidateFields["name"] == name {
+					return candidate
+				}
+			}
+			return nil
+		}
+	}
+	return existing[i]
+}
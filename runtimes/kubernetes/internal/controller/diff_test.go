@@ -0,0 +1,187 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func desiredDeployment() *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "app", Namespace: "default",
+			Labels:      runtimeLabels("app"),
+			Annotations: map[string]string{annotationRolloutRevision: "abc"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(2)),
+			Selector: &metav1.LabelSelector{MatchLabels: runtimeLabels("app")},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: runtimeLabels("app")},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{{
+					Name:  "app",
+					Image: "nginx:1",
+					Env:   []corev1.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "2"}},
+					Resources: corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1000m")},
+					},
+				}}},
+			},
+		},
+	}
+}
+
+// defaulted returns the Deployment as the API server stores it after defaulting
+func defaulted(deployment *appsv1.Deployment) *appsv1.Deployment {
+	stored := deployment.DeepCopy()
+	stored.ResourceVersion = "7"
+	stored.Annotations["deployment.kubernetes.io/revision"] = "1"
+	stored.Spec.RevisionHistoryLimit = ptr.To(int32(10))
+	stored.Spec.ProgressDeadlineSeconds = ptr.To(int32(600))
+	stored.Spec.Strategy = appsv1.DeploymentStrategy{Type: appsv1.RollingUpdateDeploymentStrategyType}
+	stored.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyAlways
+	stored.Spec.Template.Spec.DNSPolicy = corev1.DNSClusterFirst
+	container := &stored.Spec.Template.Spec.Containers[0]
+	container.ImagePullPolicy = corev1.PullIfNotPresent
+	container.TerminationMessagePath = corev1.TerminationMessagePathDefault
+	container.Resources.Limits[corev1.ResourceCPU] = resource.MustParse("1")
+	return stored
+}
+
+func TestUpToDate(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(existing *appsv1.Deployment)
+		want   bool
+	}{
+		{"defaulted fields", func(*appsv1.Deployment) {}, true},
+		{"reordered env", func(existing *appsv1.Deployment) {
+			env := existing.Spec.Template.Spec.Containers[0].Env
+			env[0], env[1] = env[1], env[0]
+		}, true},
+		{"labels added by others", func(existing *appsv1.Deployment) {
+			existing.Labels["team"] = "payments"
+		}, true},
+		{"changed image", func(existing *appsv1.Deployment) {
+			existing.Spec.Template.Spec.Containers[0].Image = "nginx:0"
+		}, false},
+		{"changed env value", func(existing *appsv1.Deployment) {
+			existing.Spec.Template.Spec.Containers[0].Env[1].Value = "3"
+		}, false},
+		{"missing env", func(existing *appsv1.Deployment) {
+			existing.Spec.Template.Spec.Containers[0].Env = existing.Spec.Template.Spec.Containers[0].Env[:1]
+		}, false},
+		{"changed quantity", func(existing *appsv1.Deployment) {
+			existing.Spec.Template.Spec.Containers[0].Resources.Limits[corev1.ResourceCPU] = resource.MustParse("500m")
+		}, false},
+		{"changed replicas", func(existing *appsv1.Deployment) {
+			existing.Spec.Replicas = ptr.To(int32(3))
+		}, false},
+		{"changed revision", func(existing *appsv1.Deployment) {
+			existing.Annotations[annotationRolloutRevision] = "def"
+		}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := desiredDeployment()
+			existing := defaulted(desired)
+			tt.mutate(existing)
+
+			got, err := upToDate(desired, existing)
+			if err != nil {
+				t.Fatalf("upToDate() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("upToDate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileDeploymentNoop(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	key := types.NamespacedName{Name: "app", Namespace: "default"}
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"app": {
+				Image:     "nginx:1",
+				Variables: map[string]string{"B": "2", "A": "1"},
+			}},
+		},
+	}
+	plan := &scorev1b1.WorkloadPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Generation: 1, Finalizers: []string{kubernetesRuntimeFinalizer}},
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef:  scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+			RuntimeClass: kubernetesRuntimeClass,
+		},
+	}
+
+	deploymentPatches := 0
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(workload, plan).
+		WithStatusSubresource(&scorev1b1.WorkloadPlan{}, &appsv1.Deployment{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+				if _, ok := obj.(*appsv1.Deployment); ok {
+					deploymentPatches++
+				}
+				return applyAsCreateOrUpdate(ctx, c, obj, patch, opts...)
+			},
+		}).
+		Build()
+	r := &KubernetesRuntimePlanReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(20)}
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if deploymentPatches == 0 {
+		t.Fatal("first reconcile did not apply the Deployment")
+	}
+
+	// Default the stored Deployment the way the API server does
+	deployment := &appsv1.Deployment{}
+	if err := c.Get(ctx, key, deployment); err != nil {
+		t.Fatal(err)
+	}
+	deployment.Spec.RevisionHistoryLimit = ptr.To(int32(10))
+	deployment.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyAlways
+	deployment.Spec.Template.Spec.Containers[0].ImagePullPolicy = corev1.PullIfNotPresent
+	deployment.Spec.Template.Spec.Containers[0].TerminationMessagePath = corev1.TerminationMessagePathDefault
+	if err := c.Update(ctx, deployment); err != nil {
+		t.Fatal(err)
+	}
+
+	deploymentPatches = 0
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if deploymentPatches != 0 {
+		t.Errorf("no-op reconcile patched the Deployment %d times, want 0", deploymentPatches)
+	}
+}
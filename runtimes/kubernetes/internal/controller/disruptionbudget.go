@@ -0,0 +1,66 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+)
+
+// reconcileDisruptionBudget applies the PodDisruptionBudget of WorkloadPlan.spec.disruptionBudget for the
+// continuously running kinds, and deletes it when the plan carries none or the Workload runs to completion.
+// An existing PodDisruptionBudget of the same name without the runtime labels is never adopted.
+func (r *KubernetesRuntimePlanReconciler) reconcileDisruptionBudget(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload, kind string) error {
+	if plan.Spec.DisruptionBudget == nil || (kind != kindDeployment && kind != kindStatefulSet) {
+		return r.deleteMaterialized(ctx, plan, &policyv1.PodDisruptionBudget{})
+	}
+
+	budget := buildDisruptionBudget(plan, workload)
+	existing := &policyv1.PodDisruptionBudget{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(budget), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get pod disruption budget: %w", err)
+		}
+	} else if !isMaterializedFor(existing, plan) {
+		return fmt.Errorf("pod disruption budget %s already exists and is not managed by the runtime", budget.Name)
+	}
+
+	if err := r.setOwner(plan, budget); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	if err := reconcile.Apply(ctx, r.Client, budget, meta.FieldManagerRuntimeKubernetes); err != nil {
+		return fmt.Errorf("failed to apply pod disruption budget: %w", err)
+	}
+	log.FromContext(ctx).V(1).Info("Applied PodDisruptionBudget", "name", budget.Name)
+	return nil
+}
+
+// buildDisruptionBudget constructs the PodDisruptionBudget selecting the pods materialized for the plan
+func buildDisruptionBudget(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) *policyv1.PodDisruptionBudget {
+	name := plan.Spec.WorkloadRef.Name
+	return &policyv1.PodDisruptionBudget{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: policyv1.SchemeGroupVersion.String(),
+			Kind:       "PodDisruptionBudget",
+		},
+		ObjectMeta: runtimeObjectMeta(plan, workload),
+		Spec: policyv1.PodDisruptionBudgetSpec{
+			MinAvailable:   plan.Spec.DisruptionBudget.MinAvailable,
+			MaxUnavailable: plan.Spec.DisruptionBudget.MaxUnavailable,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":     name,
+					"app.kubernetes.io/instance": name,
+				},
+			},
+		},
+	}
+}
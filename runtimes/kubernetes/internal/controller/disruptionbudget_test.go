@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestReconcileDisruptionBudget(t *testing.T) {
+	ctx := context.Background()
+	minAvailable := intstr.FromString("50%")
+	plan := environmentPlan(`{}`)
+	plan.Spec.Namespace = ""
+	plan.Spec.DisruptionBudget = &scorev1b1.DisruptionBudgetSpec{MinAvailable: &minAvailable}
+	workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}}
+	c := applyingClientBuilder(planScheme(t)).Build()
+	r := &KubernetesRuntimePlanReconciler{Client: c, Scheme: c.Scheme()}
+
+	if err := r.reconcileDisruptionBudget(ctx, plan, workload, kindDeployment); err != nil {
+		t.Fatalf("reconcileDisruptionBudget() error = %v", err)
+	}
+	budget := &policyv1.PodDisruptionBudget{}
+	key := types.NamespacedName{Namespace: "team-a", Name: "web"}
+	if err := c.Get(ctx, key, budget); err != nil {
+		t.Fatalf("pod disruption budget was not created: %v", err)
+	}
+	if budget.Spec.MinAvailable == nil || *budget.Spec.MinAvailable != minAvailable || budget.Spec.MaxUnavailable != nil {
+		t.Errorf("budget = %+v, want minAvailable %s", budget.Spec, minAvailable.String())
+	}
+	if budget.Spec.Selector.MatchLabels["app.kubernetes.io/instance"] != "web" {
+		t.Errorf("selector = %v, want the pods of the Workload", budget.Spec.Selector.MatchLabels)
+	}
+	if len(budget.OwnerReferences) != 1 {
+		t.Errorf("owner references = %v, want the plan", budget.OwnerReferences)
+	}
+
+	// A Workload that runs to completion gets no budget
+	if err := r.reconcileDisruptionBudget(ctx, plan, workload, kindJob); err != nil {
+		t.Fatalf("reconcileDisruptionBudget() error = %v", err)
+	}
+	if err := c.Get(ctx, key, budget); !apierrors.IsNotFound(err) {
+		t.Errorf("pod disruption budget still exists: %v", err)
+	}
+}
+
+func TestReconcileDisruptionBudgetRefusesAdoption(t *testing.T) {
+	maxUnavailable := intstr.FromInt32(1)
+	plan := environmentPlan(`{}`)
+	plan.Spec.Namespace = ""
+	plan.Spec.DisruptionBudget = &scorev1b1.DisruptionBudgetSpec{MaxUnavailable: &maxUnavailable}
+	workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}}
+	foreign := &policyv1.PodDisruptionBudget{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}}
+	c := fake.NewClientBuilder().WithScheme(planScheme(t)).WithObjects(foreign).Build()
+	r := &KubernetesRuntimePlanReconciler{Client: c, Scheme: c.Scheme()}
+
+	if err := r.reconcileDisruptionBudget(context.Background(), plan, workload, kindDeployment); err == nil {
+		t.Fatal("reconcileDisruptionBudget() should refuse to overwrite a PodDisruptionBudget the runtime did not create")
+	}
+}
@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+// platformEnv returns the reserved variables the runtime injects into every container of the Workload
+func platformEnv(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) []corev1.EnvVar {
+	env := []corev1.EnvVar{
+		{Name: meta.EnvWorkloadName, Value: plan.Spec.WorkloadRef.Name},
+		{Name: meta.EnvWorkloadNamespace, Value: plan.Spec.WorkloadRef.Namespace},
+	}
+	if endpoint := serviceEndpoint(plan, workload); endpoint != "" {
+		env = append(env, corev1.EnvVar{Name: meta.EnvWorkloadEndpoint, Value: endpoint})
+	}
+	return env
+}
+
+// serviceEndpoint returns the in-cluster URL of the first port of the Service generated for the Workload,
+// or "" when the Workload declares no service
+func serviceEndpoint(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) string {
+	if workload.Spec.Service == nil || len(workload.Spec.Service.Ports) == 0 {
+		return ""
+	}
+	port := workload.Spec.Service.Ports[0].Port
+	scheme := schemeHTTP
+	if port == 443 || port == 8443 {
+		scheme = schemeHTTPS
+	}
+	return fmt.Sprintf("%s://%s.%s.svc:%d", scheme, materializedName(plan), materializedNamespace(plan), port)
+}
@@ -5,19 +5,33 @@ import (
 	"fmt"
 	"net/url"
 	"reflect"
+	"sort"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+const (
+	schemeHTTP  = "http"
+	schemeHTTPS = "https"
 )
 
 // KubernetesRuntimeExposureReconciler reconciles a WorkloadExposure object for Kubernetes runtime
@@ -25,12 +39,20 @@ type KubernetesRuntimeExposureReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// MaxConcurrentReconciles is the number of WorkloadExposures reconciled in parallel (default 1)
+	MaxConcurrentReconciles int
+
+	// Capabilities disables the code paths of optional APIs the cluster does not serve; all are enabled when nil
+	Capabilities Capabilities
 }
 
 // +kubebuilder:rbac:groups=score.dev,resources=workloadexposures,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=score.dev,resources=workloadexposures/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=score.dev,resources=workloadexposures/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=discovery.k8s.io,resources=endpointslices,verbs=get;list;watch
 // +kubebuilder:rbac:groups=networking.k8s.io,resources=ingresses,verbs=get;list;watch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
@@ -56,39 +78,52 @@ func (r *KubernetesRuntimeExposureReconciler) Reconcile(ctx context.Context, req
 		return ctrl.Result{}, nil
 	}
 
-	// Get all Services in the namespace with the workload label
+	mode, namespace, err := r.exposurePlacement(ctx, req.NamespacedName)
+	if err != nil {
+		logger.Error(err, "Failed to determine exposure mode")
+		return ctrl.Result{}, err
+	}
+
+	// Get all Services in the namespace the workload is materialized into with the workload label
 	services := &corev1.ServiceList{}
 	workloadName := workloadExposure.Name
 	listOpts := []client.ListOption{
-		client.InNamespace(req.Namespace),
+		client.InNamespace(namespace),
 		client.MatchingLabels{
-			"score.dev/workload": workloadName,
+			meta.LabelWorkload: workloadName,
 		},
 	}
+	if namespace != req.Namespace {
+		listOpts = append(listOpts, client.MatchingLabels{labelPlanNamespace: req.Namespace})
+	}
 	if err := r.List(ctx, services, listOpts...); err != nil {
 		logger.Error(err, "Failed to list Services")
 		return ctrl.Result{}, err
 	}
 
-	// Find the primary service and generate URL
-	var exposureURL string
+	// NodePort URLs of every Service share one node address, so Nodes are listed at most once per reconcile
+	var nodeAddress string
+	if hasNodePortService(services.Items) {
+		if nodeAddress, err = r.getNodeAddress(ctx); err != nil {
+			logger.Error(err, "Failed to determine node address")
+			return ctrl.Result{}, err
+		}
+	}
+
+	// Publish one exposure per service port; the first entry is the primary endpoint
+	var exposures []scorev1b1.ExposureEntry
 	for _, service := range services.Items {
-		if serviceURL, err := r.getURLFromService(&service); err != nil {
-			logger.Error(err, "Failed to get URL from service", "serviceName", service.Name)
-		} else if serviceURL != "" {
-			exposureURL = serviceURL
-			break // Use the first valid URL found
+		entries, err := r.getExposuresFromService(ctx, workloadExposure, &service, mode, nodeAddress)
+		if err != nil {
+			logger.Error(err, "Failed to get exposures from service", "serviceName", service.Name)
+			continue
 		}
+		exposures = append(exposures, entries...)
 	}
 
-	// Update WorkloadExposure status with the URL
+	// Update WorkloadExposure status with the URLs
 	exposureStatus := scorev1b1.WorkloadExposureStatus{
-		Exposures: []scorev1b1.ExposureEntry{
-			{
-				URL:   exposureURL,
-				Ready: exposureURL != "",
-			},
-		},
+		Exposures: exposures,
 	}
 
 	if !reflect.DeepEqual(workloadExposure.Status, exposureStatus) {
@@ -97,29 +132,254 @@ func (r *KubernetesRuntimeExposureReconciler) Reconcile(ctx context.Context, req
 			logger.Error(err, "Failed to update WorkloadExposure status")
 			return ctrl.Result{}, err
 		}
-		logger.Info("Updated WorkloadExposure status", "url", exposureURL)
+		logger.Info("Updated WorkloadExposure status", "exposures", len(exposures))
 	}
 
 	return ctrl.Result{}, nil
 }
 
-// getURLFromService generates a URL from the given Service
-func (r *KubernetesRuntimeExposureReconciler) getURLFromService(service *corev1.Service) (string, error) {
+// exposurePlacement returns the exposure mode of the backend selected for the workload and the namespace
+// its Services are materialized into, as carried by its WorkloadPlan.
+// An absent plan yields the default mode and the namespace of the workload.
+func (r *KubernetesRuntimeExposureReconciler) exposurePlacement(ctx context.Context, key types.NamespacedName) (string, string, error) {
+	plan := &scorev1b1.WorkloadPlan{}
+	if err := r.Get(ctx, key, plan); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", key.Namespace, nil
+		}
+		return "", "", fmt.Errorf("failed to get WorkloadPlan: %w", err)
+	}
+	namespace := key.Namespace
+	if plan.Spec.Namespace != "" {
+		namespace = plan.Spec.Namespace
+	}
+	if plan.Spec.Exposure == nil {
+		return "", namespace, nil
+	}
+	return plan.Spec.Exposure.Mode, namespace, nil
+}
+
+// hasNodePortService reports whether any of the Services is exposed on node ports
+func hasNodePortService(services []corev1.Service) bool {
+	for _, service := range services {
+		if service.Spec.Type == corev1.ServiceTypeNodePort {
+			return true
+		}
+	}
+	return false
+}
+
+// getExposuresFromService generates an exposure entry for each port of the given Service.
+// Ports named https or http come first, so that the Workload endpoint is published for them.
+func (r *KubernetesRuntimeExposureReconciler) getExposuresFromService(ctx context.Context, exposure *scorev1b1.WorkloadExposure, service *corev1.Service, mode, nodeAddress string) ([]scorev1b1.ExposureEntry, error) {
+	readyPorts, err := r.readyServicePorts(ctx, service)
+	if err != nil {
+		return nil, err
+	}
+
+	ports := append([]corev1.ServicePort(nil), service.Spec.Ports...)
+	sort.SliceStable(ports, func(i, j int) bool {
+		return portNamePriority(ports[i].Name) < portNamePriority(ports[j].Name)
+	})
+
+	var entries []scorev1b1.ExposureEntry
+	for _, port := range ports {
+		scheme := resolveScheme(exposure, port)
+		serviceURL, err := r.getURLFromService(service, port, scheme, nodeAddress)
+		if err != nil {
+			return nil, err
+		}
+		if serviceURL == "" {
+			continue
+		}
+		entries = append(entries, scorev1b1.ExposureEntry{
+			Name:       port.Name,
+			URL:        serviceURL,
+			Type:       exposureType(service, mode),
+			Ready:      readyPorts[port.Name],
+			SchemeHint: strings.ToUpper(scheme),
+		})
+	}
+
+	// Ingresses routing to the Service publish their hosts as well, on clusters that serve them
+	if !r.Capabilities.Has(CapabilityIngress) {
+		return entries, nil
+	}
+	ingressEntries, err := r.getExposuresFromIngresses(ctx, service, readyPorts)
+	if err != nil {
+		return nil, err
+	}
+	return append(entries, ingressEntries...), nil
+}
+
+// getExposuresFromIngresses generates an exposure entry for every Ingress rule that routes a host to a port of
+// the given Service. Rules of hosts listed under spec.tls are published as https.
+func (r *KubernetesRuntimeExposureReconciler) getExposuresFromIngresses(ctx context.Context, service *corev1.Service, readyPorts map[string]bool) ([]scorev1b1.ExposureEntry, error) {
+	ingresses := &networkingv1.IngressList{}
+	if err := r.List(ctx, ingresses,
+		client.InNamespace(service.Namespace),
+		client.MatchingFields{meta.IndexIngressByBackendService: service.Namespace + "/" + service.Name},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list Ingresses: %w", err)
+	}
+	// The cache returns Ingresses in no particular order
+	sort.Slice(ingresses.Items, func(i, j int) bool { return ingresses.Items[i].Name < ingresses.Items[j].Name })
+
+	var entries []scorev1b1.ExposureEntry
+	seen := make(map[string]bool)
+	for _, ingress := range ingresses.Items {
+		tlsHosts := make(map[string]bool)
+		for _, tls := range ingress.Spec.TLS {
+			for _, host := range tls.Hosts {
+				tlsHosts[host] = true
+			}
+		}
+		for _, rule := range ingress.Spec.Rules {
+			// Rules without a host or with a wildcard host do not name a reachable URL
+			if rule.Host == "" || strings.HasPrefix(rule.Host, "*") || rule.HTTP == nil {
+				continue
+			}
+			scheme := schemeHTTP
+			if tlsHosts[rule.Host] {
+				scheme = schemeHTTPS
+			}
+			for _, path := range rule.HTTP.Paths {
+				port, ok := backendServicePort(service, path.Backend.Service)
+				if !ok {
+					continue
+				}
+				ingressURL := scheme + "://" + rule.Host + strings.TrimSuffix(path.Path, "/")
+				if seen[ingressURL] || !r.isValidURL(ingressURL) {
+					continue
+				}
+				seen[ingressURL] = true
+				entries = append(entries, scorev1b1.ExposureEntry{
+					Name:       port.Name,
+					URL:        ingressURL,
+					Type:       "ingress",
+					Ready:      readyPorts[port.Name],
+					SchemeHint: strings.ToUpper(scheme),
+				})
+			}
+		}
+	}
+	return entries, nil
+}
+
+// backendServicePort returns the port of the Service an Ingress backend routes to, if the backend
+// references the Service
+func backendServicePort(service *corev1.Service, backend *networkingv1.IngressServiceBackend) (corev1.ServicePort, bool) {
+	if backend == nil || backend.Name != service.Name {
+		return corev1.ServicePort{}, false
+	}
+	for _, port := range service.Spec.Ports {
+		if (backend.Port.Name != "" && backend.Port.Name == port.Name) ||
+			(backend.Port.Name == "" && backend.Port.Number == port.Port) {
+			return port, true
+		}
+	}
+	return corev1.ServicePort{}, false
+}
+
+// readyServicePorts returns the names of the Service ports that have at least one ready endpoint,
+// as reported by the EndpointSlices of the Service
+func (r *KubernetesRuntimeExposureReconciler) readyServicePorts(ctx context.Context, service *corev1.Service) (map[string]bool, error) {
+	slices := &discoveryv1.EndpointSliceList{}
+	if err := r.List(ctx, slices,
+		client.InNamespace(service.Namespace),
+		client.MatchingLabels{discoveryv1.LabelServiceName: service.Name},
+	); err != nil {
+		return nil, fmt.Errorf("failed to list EndpointSlices: %w", err)
+	}
+
+	ready := make(map[string]bool)
+	for _, slice := range slices.Items {
+		if !hasReadyEndpoint(slice.Endpoints) {
+			continue
+		}
+		for _, port := range slice.Ports {
+			ready[ptr.Deref(port.Name, "")] = true
+		}
+	}
+	return ready, nil
+}
+
+// hasReadyEndpoint reports whether any endpoint is ready. A nil Ready condition means ready, as defined by the EndpointSlice API.
+func hasReadyEndpoint(endpoints []discoveryv1.Endpoint) bool {
+	for _, endpoint := range endpoints {
+		if ptr.Deref(endpoint.Conditions.Ready, true) {
+			return true
+		}
+	}
+	return false
+}
+
+// portNamePriority ranks Service ports by name: https, then http, then any other port
+func portNamePriority(name string) int {
+	switch name {
+	case schemeHTTPS:
+		return 0
+	case schemeHTTP:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// resolveScheme determines the URL scheme for a Service port.
+// Precedence: WorkloadExposure spec override, the port's appProtocol (set for Workload ports declared with TLS),
+// well-known TLS ports.
+func resolveScheme(exposure *scorev1b1.WorkloadExposure, port corev1.ServicePort) string {
+	if exposure.Spec.Scheme != "" {
+		return exposure.Spec.Scheme
+	}
+
+	switch strings.ToLower(ptr.Deref(port.AppProtocol, "")) {
+	case schemeHTTP:
+		return schemeHTTP
+	case schemeHTTPS:
+		return schemeHTTPS
+	}
+
+	if port.Port == 443 || port.Port == 8443 || port.Name == schemeHTTPS {
+		return schemeHTTPS
+	}
+	return schemeHTTP
+}
+
+// exposureType returns the exposure mechanism name for the given Service
+func exposureType(service *corev1.Service, mode string) string {
+	switch service.Spec.Type {
+	case corev1.ServiceTypeLoadBalancer:
+		return "loadbalancer"
+	case corev1.ServiceTypeNodePort:
+		return "nodeport"
+	default:
+		if mode == scorev1b1.ExposureModePortForward {
+			return "portforward"
+		}
+		return "clusterip"
+	}
+}
+
+// getURLFromService generates a URL for the given port of the Service
+func (r *KubernetesRuntimeExposureReconciler) getURLFromService(service *corev1.Service, port corev1.ServicePort, scheme, nodeAddress string) (string, error) {
 	switch service.Spec.Type {
 	case corev1.ServiceTypeLoadBalancer:
-		return r.getURLFromLoadBalancer(service)
+		return r.getURLFromLoadBalancer(service, port, scheme)
 	case corev1.ServiceTypeNodePort:
-		return r.getURLFromNodePort(service)
+		return r.getURLFromNodePort(port, scheme, nodeAddress)
 	case corev1.ServiceTypeClusterIP, "":
-		// Handle both explicit ClusterIP and empty type (default ClusterIP)
-		return r.getURLFromClusterIP(service)
+		// Handle both explicit ClusterIP and empty type (default ClusterIP).
+		// The localhost URL is what `kubectl port-forward` serves in PortForward mode.
+		return r.getURLFromClusterIP(service, port, scheme)
 	default:
 		return "", nil
 	}
 }
 
 // getURLFromLoadBalancer gets URL from LoadBalancer service
-func (r *KubernetesRuntimeExposureReconciler) getURLFromLoadBalancer(service *corev1.Service) (string, error) {
+func (r *KubernetesRuntimeExposureReconciler) getURLFromLoadBalancer(service *corev1.Service, port corev1.ServicePort, scheme string) (string, error) {
 	if len(service.Status.LoadBalancer.Ingress) == 0 {
 		return "", nil
 	}
@@ -133,12 +393,7 @@ func (r *KubernetesRuntimeExposureReconciler) getURLFromLoadBalancer(service *co
 		return "", nil
 	}
 
-	if len(service.Spec.Ports) == 0 {
-		return "", nil
-	}
-
-	port := service.Spec.Ports[0].Port
-	serviceURL := fmt.Sprintf("http://%s:%d", host, port)
+	serviceURL := fmt.Sprintf("%s://%s:%d", scheme, host, port.Port)
 
 	if r.isValidURL(serviceURL) {
 		return serviceURL, nil
@@ -147,18 +402,13 @@ func (r *KubernetesRuntimeExposureReconciler) getURLFromLoadBalancer(service *co
 	return "", nil
 }
 
-// getURLFromNodePort gets URL from NodePort service
-func (r *KubernetesRuntimeExposureReconciler) getURLFromNodePort(service *corev1.Service) (string, error) {
-	if len(service.Spec.Ports) == 0 {
+// getURLFromNodePort gets URL from NodePort service using a node address
+func (r *KubernetesRuntimeExposureReconciler) getURLFromNodePort(port corev1.ServicePort, scheme, nodeAddress string) (string, error) {
+	if port.NodePort == 0 {
 		return "", nil
 	}
 
-	nodePort := service.Spec.Ports[0].NodePort
-	if nodePort == 0 {
-		return "", nil
-	}
-
-	serviceURL := fmt.Sprintf("http://localhost:%d", nodePort)
+	serviceURL := fmt.Sprintf("%s://%s:%d", scheme, nodeAddress, port.NodePort)
 
 	if r.isValidURL(serviceURL) {
 		return serviceURL, nil
@@ -167,19 +417,35 @@ func (r *KubernetesRuntimeExposureReconciler) getURLFromNodePort(service *corev1
 	return "", nil
 }
 
+// getNodeAddress returns a reachable node address, preferring external over internal IPs.
+// Falls back to localhost when no node reports an address (e.g., port-forwarded local clusters).
+func (r *KubernetesRuntimeExposureReconciler) getNodeAddress(ctx context.Context) (string, error) {
+	nodes := &corev1.NodeList{}
+	if err := r.List(ctx, nodes); err != nil {
+		return "", fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	for _, addressType := range []corev1.NodeAddressType{corev1.NodeExternalIP, corev1.NodeInternalIP} {
+		for _, node := range nodes.Items {
+			for _, address := range node.Status.Addresses {
+				if address.Type == addressType && address.Address != "" {
+					return address.Address, nil
+				}
+			}
+		}
+	}
+
+	return "localhost", nil
+}
+
 // getURLFromClusterIP gets URL from ClusterIP service
-func (r *KubernetesRuntimeExposureReconciler) getURLFromClusterIP(service *corev1.Service) (string, error) {
+func (r *KubernetesRuntimeExposureReconciler) getURLFromClusterIP(service *corev1.Service, port corev1.ServicePort, scheme string) (string, error) {
 	// Handle ClusterIP type services (including default/empty type)
 	if service.Spec.Type != "" && service.Spec.Type != corev1.ServiceTypeClusterIP {
 		return "", nil
 	}
 
-	if len(service.Spec.Ports) == 0 {
-		return "", nil
-	}
-
-	port := service.Spec.Ports[0].Port
-	serviceURL := fmt.Sprintf("http://localhost:%d", port)
+	serviceURL := fmt.Sprintf("%s://localhost:%d", scheme, port.Port)
 
 	if r.isValidURL(serviceURL) {
 		return serviceURL, nil
@@ -204,42 +470,102 @@ func (r *KubernetesRuntimeExposureReconciler) isValidURL(urlStr string) bool {
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *KubernetesRuntimeExposureReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&scorev1b1.WorkloadExposure{}).
+	b := ctrl.NewControllerManagedBy(mgr).
+		// The exposure status written by this runtime does not need a reconcile of the exposure
+		For(&scorev1b1.WorkloadExposure{}, builder.WithPredicates(
+			predicate.NewPredicateFuncs(handlesExposure),
+			predicate.GenerationChangedPredicate{},
+		)).
 		Watches(
 			&corev1.Service{},
 			handler.EnqueueRequestsFromMapFunc(r.findWorkloadExposuresForService),
 		).
+		Watches(
+			&discoveryv1.EndpointSlice{},
+			handler.EnqueueRequestsFromMapFunc(r.findWorkloadExposuresForEndpointSlice),
+		)
+	// Watching a kind the cluster does not serve would fail the cache sync
+	if r.Capabilities.Has(CapabilityIngress) {
+		b = b.Watches(
+			&networkingv1.Ingress{},
+			handler.EnqueueRequestsFromMapFunc(r.findWorkloadExposuresForIngress),
+		)
+	}
+	return b.
+		Named("k8s-runtime-exposure").
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
 
+// handlesExposure reports whether the exposure is published by the Kubernetes runtime
+func handlesExposure(obj client.Object) bool {
+	exposure, ok := obj.(*scorev1b1.WorkloadExposure)
+	return ok && exposure.Spec.RuntimeClass == kubernetesRuntimeClass
+}
+
 // findWorkloadExposuresForService maps Service events to WorkloadExposure reconciliation requests
 func (r *KubernetesRuntimeExposureReconciler) findWorkloadExposuresForService(ctx context.Context, obj client.Object) []reconcile.Request {
 	service := obj.(*corev1.Service)
 
 	// Check if this service has the workload label
-	workloadName, exists := service.Labels["score.dev/workload"]
+	workloadName, exists := service.Labels[meta.LabelWorkload]
 	if !exists {
 		return nil
 	}
 
-	// Find WorkloadExposure with the same name
-	workloadExposure := &scorev1b1.WorkloadExposure{}
-	namespacedName := types.NamespacedName{
-		Name:      workloadName,
-		Namespace: service.Namespace,
+	// Find the WorkloadExposures of the Workload, in the namespace of the plan for Services in an environment namespace
+	namespace := service.Namespace
+	if planNamespace, ok := service.Labels[labelPlanNamespace]; ok {
+		namespace = planNamespace
 	}
-
-	if err := r.Get(ctx, namespacedName, workloadExposure); err != nil {
+	exposures := &scorev1b1.WorkloadExposureList{}
+	if err := r.List(ctx, exposures,
+		client.InNamespace(namespace),
+		client.MatchingFields{meta.IndexWorkloadExposureByWorkload: namespace + "/" + workloadName},
+	); err != nil {
+		log.FromContext(ctx).Error(err, "Failed to list WorkloadExposures", "service", client.ObjectKeyFromObject(service))
 		return nil
 	}
 
-	// Only reconcile if this is a Kubernetes runtime WorkloadExposure
-	if workloadExposure.Spec.RuntimeClass != kubernetesRuntimeClass {
+	var requests []reconcile.Request
+	for _, exposure := range exposures.Items {
+		// Only reconcile Kubernetes runtime WorkloadExposures
+		if exposure.Spec.RuntimeClass != kubernetesRuntimeClass {
+			continue
+		}
+		requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&exposure)})
+	}
+	return requests
+}
+
+// findWorkloadExposuresForIngress maps Ingress events to the WorkloadExposures of the Services it routes to,
+// so that Ingress hosts are published
+func (r *KubernetesRuntimeExposureReconciler) findWorkloadExposuresForIngress(ctx context.Context, obj client.Object) []reconcile.Request {
+	ingress := obj.(*networkingv1.Ingress)
+
+	var requests []reconcile.Request
+	for _, name := range ingressBackendServices(ingress) {
+		service := &corev1.Service{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: ingress.Namespace}, service); err != nil {
+			continue
+		}
+		requests = append(requests, r.findWorkloadExposuresForService(ctx, service)...)
+	}
+	return requests
+}
+
+// findWorkloadExposuresForEndpointSlice maps EndpointSlice events to the WorkloadExposure of the owning Service,
+// so that endpoint readiness changes are published
+func (r *KubernetesRuntimeExposureReconciler) findWorkloadExposuresForEndpointSlice(ctx context.Context, obj client.Object) []reconcile.Request {
+	serviceName, exists := obj.GetLabels()[discoveryv1.LabelServiceName]
+	if !exists {
 		return nil
 	}
 
-	return []reconcile.Request{
-		{NamespacedName: namespacedName},
+	service := &corev1.Service{}
+	if err := r.Get(ctx, types.NamespacedName{Name: serviceName, Namespace: obj.GetNamespace()}, service); err != nil {
+		return nil
 	}
+
+	return r.findWorkloadExposuresForService(ctx, service)
 }
@@ -0,0 +1,488 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+// newExposureTestReconciler returns an exposure reconciler backed by a fake client holding objs
+func newExposureTestReconciler(t *testing.T, objs ...client.Object) *KubernetesRuntimeExposureReconciler {
+	t.Helper()
+	return newExposureTestReconcilerWithInterceptor(t, interceptor.Funcs{}, objs...)
+}
+
+// newExposureTestReconcilerWithInterceptor is newExposureTestReconciler with client calls routed through funcs
+func newExposureTestReconcilerWithInterceptor(t *testing.T, funcs interceptor.Funcs, objs ...client.Object) *KubernetesRuntimeExposureReconciler {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithStatusSubresource(&scorev1b1.WorkloadExposure{}).
+		WithIndex(&scorev1b1.WorkloadExposure{}, meta.IndexWorkloadExposureByWorkload, exposureWorkloadIndex).
+		WithIndex(&networkingv1.Ingress{}, meta.IndexIngressByBackendService, ingressBackendServiceIndex).
+		WithInterceptorFuncs(funcs).
+		Build()
+	return &KubernetesRuntimeExposureReconciler{Client: c, Scheme: scheme}
+}
+
+// reconcileExposure runs one reconcile for the "web" WorkloadExposure and returns its published exposures
+func reconcileExposure(t *testing.T, r *KubernetesRuntimeExposureReconciler) []scorev1b1.ExposureEntry {
+	t.Helper()
+	key := types.NamespacedName{Name: "web", Namespace: "default"}
+	if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	exposure := &scorev1b1.WorkloadExposure{}
+	if err := r.Get(context.Background(), key, exposure); err != nil {
+		t.Fatal(err)
+	}
+	return exposure.Status.Exposures
+}
+
+func testWorkloadExposure() *scorev1b1.WorkloadExposure {
+	return &scorev1b1.WorkloadExposure{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec:       scorev1b1.WorkloadExposureSpec{RuntimeClass: kubernetesRuntimeClass},
+	}
+}
+
+func testExposedService(serviceType corev1.ServiceType, ports ...corev1.ServicePort) *corev1.Service {
+	return &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "default",
+			Labels:    map[string]string{"score.dev/workload": "web"},
+		},
+		Spec: corev1.ServiceSpec{Type: serviceType, Ports: ports},
+	}
+}
+
+func testEndpointSlice(ready *bool, portNames ...string) *discoveryv1.EndpointSlice {
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web-abc12",
+			Namespace: "default",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "web"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Endpoints: []discoveryv1.Endpoint{
+			{Addresses: []string{"10.0.0.1"}, Conditions: discoveryv1.EndpointConditions{Ready: ready}},
+		},
+	}
+	for _, name := range portNames {
+		slice.Ports = append(slice.Ports, discoveryv1.EndpointPort{Name: ptr.To(name)})
+	}
+	return slice
+}
+
+func TestReconcilePublishesEveryServicePort(t *testing.T) {
+	ports := []corev1.ServicePort{
+		{Name: "port-0", Port: 8080},
+		{Name: "port-1", Port: 9090},
+	}
+
+	tests := []struct {
+		name      string
+		slice     *discoveryv1.EndpointSlice
+		wantReady bool
+	}{
+		{
+			name:      "ready endpoints",
+			slice:     testEndpointSlice(ptr.To(true), "port-0", "port-1"),
+			wantReady: true,
+		},
+		{
+			name:      "ready condition unset",
+			slice:     testEndpointSlice(nil, "port-0", "port-1"),
+			wantReady: true,
+		},
+		{
+			name:      "endpoints not ready",
+			slice:     testEndpointSlice(ptr.To(false), "port-0", "port-1"),
+			wantReady: false,
+		},
+		{
+			name:      "no EndpointSlice yet",
+			wantReady: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			objs := []client.Object{testWorkloadExposure(), testExposedService(corev1.ServiceTypeClusterIP, ports...)}
+			if tt.slice != nil {
+				objs = append(objs, tt.slice)
+			}
+
+			exposures := reconcileExposure(t, newExposureTestReconciler(t, objs...))
+
+			want := []scorev1b1.ExposureEntry{
+				{Name: "port-0", URL: "http://localhost:8080", Type: "clusterip", Ready: tt.wantReady, SchemeHint: "HTTP"},
+				{Name: "port-1", URL: "http://localhost:9090", Type: "clusterip", Ready: tt.wantReady, SchemeHint: "HTTP"},
+			}
+			if len(exposures) != len(want) {
+				t.Fatalf("got %d exposures, want %d: %+v", len(exposures), len(want), exposures)
+			}
+			for i := range want {
+				if exposures[i] != want[i] {
+					t.Errorf("exposure[%d] = %+v, want %+v", i, exposures[i], want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReconcilePublishesNamedHTTPPortsFirst(t *testing.T) {
+	ports := []corev1.ServicePort{
+		{Name: "metrics", Port: 9090},
+		{Name: "http", Port: 8080},
+		{Name: "https", Port: 8443},
+	}
+
+	exposures := reconcileExposure(t, newExposureTestReconciler(t,
+		testWorkloadExposure(), testExposedService(corev1.ServiceTypeClusterIP, ports...)))
+
+	want := []string{"https", "http", "metrics"}
+	if len(exposures) != len(want) {
+		t.Fatalf("got %d exposures, want %d: %+v", len(exposures), len(want), exposures)
+	}
+	for i, name := range want {
+		if exposures[i].Name != name {
+			t.Errorf("exposure[%d] = %q, want %q", i, exposures[i].Name, name)
+		}
+	}
+}
+
+func TestReconcileLoadBalancerReadiness(t *testing.T) {
+	port := corev1.ServicePort{Name: "port-0", Port: 443}
+
+	pending := testExposedService(corev1.ServiceTypeLoadBalancer, port)
+	if exposures := reconcileExposure(t, newExposureTestReconciler(t,
+		testWorkloadExposure(), pending, testEndpointSlice(ptr.To(true), "port-0"))); len(exposures) != 0 {
+		t.Errorf("expected no exposure before the ingress is assigned, got %+v", exposures)
+	}
+
+	assigned := testExposedService(corev1.ServiceTypeLoadBalancer, port)
+	assigned.Status.LoadBalancer.Ingress = []corev1.LoadBalancerIngress{{IP: "203.0.113.10"}}
+	exposures := reconcileExposure(t, newExposureTestReconciler(t,
+		testWorkloadExposure(), assigned, testEndpointSlice(ptr.To(true), "port-0")))
+	if len(exposures) != 1 {
+		t.Fatalf("got %d exposures, want 1", len(exposures))
+	}
+	if exposures[0].URL != "https://203.0.113.10:443" || !exposures[0].Ready {
+		t.Errorf("exposure = %+v, want ready https URL on the ingress address", exposures[0])
+	}
+}
+
+func TestResolveScheme(t *testing.T) {
+	tests := []struct {
+		name     string
+		override string
+		port     corev1.ServicePort
+		want     string
+	}{
+		{
+			name: "plain port",
+			port: corev1.ServicePort{Name: "port-0", Port: 8080},
+			want: schemeHTTP,
+		},
+		{
+			name: "well-known TLS port",
+			port: corev1.ServicePort{Name: "port-0", Port: 8443},
+			want: schemeHTTPS,
+		},
+		{
+			name: "port declared with TLS",
+			port: corev1.ServicePort{Name: "port-0", Port: 8080, AppProtocol: ptr.To(schemeHTTPS)},
+			want: schemeHTTPS,
+		},
+		{
+			name: "explicit http appProtocol on a well-known TLS port",
+			port: corev1.ServicePort{Name: "port-0", Port: 443, AppProtocol: ptr.To(schemeHTTP)},
+			want: schemeHTTP,
+		},
+		{
+			name:     "spec override wins",
+			override: schemeHTTP,
+			port:     corev1.ServicePort{Name: "port-0", Port: 8080, AppProtocol: ptr.To(schemeHTTPS)},
+			want:     schemeHTTP,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exposure := testWorkloadExposure()
+			exposure.Spec.Scheme = tt.override
+			if got := resolveScheme(exposure, tt.port); got != tt.want {
+				t.Errorf("resolveScheme() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcilePublishesNodePortURLs(t *testing.T) {
+	nodeWithAddresses := func(name string, addresses ...corev1.NodeAddress) *corev1.Node {
+		return &corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: name}, Status: corev1.NodeStatus{Addresses: addresses}}
+	}
+
+	tests := []struct {
+		name  string
+		nodes []client.Object
+		want  []string
+	}{
+		{
+			name: "external IP preferred",
+			nodes: []client.Object{
+				nodeWithAddresses("a", corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.5"}),
+				nodeWithAddresses("b", corev1.NodeAddress{Type: corev1.NodeExternalIP, Address: "198.51.100.7"}),
+			},
+			want: []string{"http://198.51.100.7:30080", "http://198.51.100.7:30090"},
+		},
+		{
+			name:  "internal IP",
+			nodes: []client.Object{nodeWithAddresses("a", corev1.NodeAddress{Type: corev1.NodeInternalIP, Address: "10.0.0.5"})},
+			want:  []string{"http://10.0.0.5:30080", "http://10.0.0.5:30090"},
+		},
+		{
+			name: "no node address",
+			want: []string{"http://localhost:30080", "http://localhost:30090"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := testExposedService(corev1.ServiceTypeNodePort,
+				corev1.ServicePort{Name: "port-0", Port: 8080, NodePort: 30080},
+				corev1.ServicePort{Name: "port-1", Port: 9090, NodePort: 30090},
+			)
+			nodeLists := 0
+			funcs := interceptor.Funcs{
+				List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+					if _, ok := list.(*corev1.NodeList); ok {
+						nodeLists++
+					}
+					return c.List(ctx, list, opts...)
+				},
+			}
+			objs := append([]client.Object{testWorkloadExposure(), service}, tt.nodes...)
+
+			exposures := reconcileExposure(t, newExposureTestReconcilerWithInterceptor(t, funcs, objs...))
+
+			if nodeLists != 1 {
+				t.Errorf("listed Nodes %d times, want once per reconcile", nodeLists)
+			}
+			if len(exposures) != len(tt.want) {
+				t.Fatalf("got %d exposures, want %d", len(exposures), len(tt.want))
+			}
+			for i, want := range tt.want {
+				if exposures[i].URL != want || exposures[i].Type != "nodeport" {
+					t.Errorf("exposure[%d] = %s (%s), want %s (nodeport)", i, exposures[i].URL, exposures[i].Type, want)
+				}
+			}
+		})
+	}
+}
+
+func TestReconcileDoesNotListNodesWithoutNodePorts(t *testing.T) {
+	funcs := interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, ok := list.(*corev1.NodeList); ok {
+				t.Error("Nodes must not be listed when no Service uses node ports")
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}
+	service := testExposedService(corev1.ServiceTypeClusterIP, corev1.ServicePort{Name: "port-0", Port: 8080})
+
+	reconcileExposure(t, newExposureTestReconcilerWithInterceptor(t, funcs, testWorkloadExposure(), service))
+}
+
+func TestReconcilePortForwardMode(t *testing.T) {
+	plan := &scorev1b1.WorkloadPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: scorev1b1.WorkloadPlanSpec{
+			Exposure: &scorev1b1.ExposureSpec{Mode: scorev1b1.ExposureModePortForward},
+		},
+	}
+	service := testExposedService(corev1.ServiceTypeClusterIP, corev1.ServicePort{Name: "port-0", Port: 8080})
+
+	exposures := reconcileExposure(t, newExposureTestReconciler(t, testWorkloadExposure(), plan, service))
+
+	if len(exposures) != 1 {
+		t.Fatalf("got %d exposures, want 1", len(exposures))
+	}
+	if exposures[0].URL != "http://localhost:8080" || exposures[0].Type != "portforward" {
+		t.Errorf("exposure = %s (%s), want http://localhost:8080 (portforward)", exposures[0].URL, exposures[0].Type)
+	}
+}
+
+func TestReconcilePublishesIngressHosts(t *testing.T) {
+	service := testExposedService(corev1.ServiceTypeClusterIP,
+		corev1.ServicePort{Name: "web", Port: 8080},
+		corev1.ServicePort{Name: "metrics", Port: 9090},
+	)
+	pathType := networkingv1.PathTypePrefix
+	backend := func(service string, port networkingv1.ServiceBackendPort) networkingv1.IngressBackend {
+		return networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: service, Port: port}}
+	}
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: networkingv1.IngressSpec{
+			TLS: []networkingv1.IngressTLS{{Hosts: []string{"web.example.com"}}},
+			Rules: []networkingv1.IngressRule{
+				{Host: "web.example.com", IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{Path: "/", PathType: &pathType, Backend: backend("web", networkingv1.ServiceBackendPort{Name: "web"})},
+						{Path: "/other", PathType: &pathType, Backend: backend("other", networkingv1.ServiceBackendPort{Number: 80})},
+					},
+				}}},
+				{Host: "metrics.internal", IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{Path: "/metrics", PathType: &pathType, Backend: backend("web", networkingv1.ServiceBackendPort{Number: 9090})},
+					},
+				}}},
+				{Host: "*.example.com", IngressRuleValue: networkingv1.IngressRuleValue{HTTP: &networkingv1.HTTPIngressRuleValue{
+					Paths: []networkingv1.HTTPIngressPath{
+						{Path: "/", PathType: &pathType, Backend: backend("web", networkingv1.ServiceBackendPort{Name: "web"})},
+					},
+				}}},
+			},
+		},
+	}
+	// An Ingress of another Service is not published
+	unrelated := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+		Spec:       networkingv1.IngressSpec{DefaultBackend: &networkingv1.IngressBackend{Service: &networkingv1.IngressServiceBackend{Name: "other"}}},
+	}
+
+	exposures := reconcileExposure(t, newExposureTestReconciler(t,
+		testWorkloadExposure(), service, testEndpointSlice(ptr.To(true), "web"), ingress, unrelated))
+
+	want := []scorev1b1.ExposureEntry{
+		{Name: "web", URL: "http://localhost:8080", Type: "clusterip", Ready: true, SchemeHint: "HTTP"},
+		{Name: "metrics", URL: "http://localhost:9090", Type: "clusterip", Ready: false, SchemeHint: "HTTP"},
+		{Name: "web", URL: "https://web.example.com", Type: "ingress", Ready: true, SchemeHint: "HTTPS"},
+		{Name: "metrics", URL: "http://metrics.internal/metrics", Type: "ingress", Ready: false, SchemeHint: "HTTP"},
+	}
+	if len(exposures) != len(want) {
+		t.Fatalf("got %d exposures, want %d: %+v", len(exposures), len(want), exposures)
+	}
+	for i := range want {
+		if exposures[i] != want[i] {
+			t.Errorf("exposure[%d] = %+v, want %+v", i, exposures[i], want[i])
+		}
+	}
+}
+
+func TestReconcileWithoutIngressCapability(t *testing.T) {
+	funcs := interceptor.Funcs{
+		List: func(ctx context.Context, c client.WithWatch, list client.ObjectList, opts ...client.ListOption) error {
+			if _, ok := list.(*networkingv1.IngressList); ok {
+				t.Error("Ingresses must not be listed when the cluster does not serve them")
+			}
+			return c.List(ctx, list, opts...)
+		},
+	}
+	service := testExposedService(corev1.ServiceTypeClusterIP, corev1.ServicePort{Name: "web", Port: 8080})
+	r := newExposureTestReconcilerWithInterceptor(t, funcs, testWorkloadExposure(), service)
+	r.Capabilities = Capabilities{CapabilityIngress: false}
+
+	exposures := reconcileExposure(t, r)
+
+	if len(exposures) != 1 || exposures[0].Type != "clusterip" {
+		t.Errorf("exposures = %+v, want the clusterip exposure only", exposures)
+	}
+}
+
+func TestFindWorkloadExposuresForService(t *testing.T) {
+	exposure := func(name, namespace, workload, runtimeClass string) *scorev1b1.WorkloadExposure {
+		return &scorev1b1.WorkloadExposure{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+			Spec: scorev1b1.WorkloadExposureSpec{
+				WorkloadRef:  scorev1b1.WorkloadExposureWorkloadRef{Name: workload},
+				RuntimeClass: runtimeClass,
+			},
+		}
+	}
+	r := newExposureTestReconciler(t,
+		exposure("web", "default", "web", kubernetesRuntimeClass),
+		exposure("web", "team-a", "web", kubernetesRuntimeClass),
+		exposure("api", "default", "api", kubernetesRuntimeClass),
+		exposure("web-docker", "default", "web", "docker"),
+	)
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   []reconcile.Request
+	}{
+		{
+			name:   "workload service",
+			labels: map[string]string{meta.LabelWorkload: "web"},
+			want:   []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "web", Namespace: "default"}}},
+		},
+		{
+			name:   "service in an environment namespace",
+			labels: map[string]string{meta.LabelWorkload: "web", labelPlanNamespace: "team-a"},
+			want:   []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "web", Namespace: "team-a"}}},
+		},
+		{
+			name:   "unlabeled service",
+			labels: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: tt.labels}}
+			got := r.findWorkloadExposuresForService(context.Background(), service)
+			if len(got) != len(tt.want) {
+				t.Fatalf("findWorkloadExposuresForService() = %v, want %v", got, tt.want)
+			}
+			for i := range tt.want {
+				if got[i] != tt.want[i] {
+					t.Errorf("findWorkloadExposuresForService()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
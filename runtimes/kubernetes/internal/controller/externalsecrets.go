@@ -0,0 +1,171 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+const (
+	// annotationExternalSecretVersion carries the store version of the credentials, so that a rotation
+	// written by the provisioner changes the ExternalSecret and triggers an immediate refresh
+	annotationExternalSecretVersion = "score.dev/external-secret-version"
+
+	// externalSecretRefreshInterval is how often the external-secrets operator re-reads the store
+	externalSecretRefreshInterval = "1h"
+)
+
+// externalSecretGVK is the kind of the external-secrets operator that syncs a store path into a Secret.
+// The runtime does not depend on the operator's Go types and handles the objects as unstructured.
+var externalSecretGVK = schema.GroupVersionKind{
+	Group:   "external-secrets.io",
+	Version: "v1beta1",
+	Kind:    "ExternalSecret",
+}
+
+// resolvedExternalSecret is an entry of externalSecrets in WorkloadPlan.ResolvedValues: claim credentials
+// held by an external secret store that container variables reference through the Secret named Name
+type resolvedExternalSecret struct {
+	Name    string   `json:"name"`
+	Store   string   `json:"store"`
+	Path    string   `json:"path"`
+	Version string   `json:"version,omitempty"`
+	Keys    []string `json:"keys"`
+}
+
+// resolvedExternalSecrets returns the external secrets published in WorkloadPlan.ResolvedValues
+func resolvedExternalSecrets(plan *scorev1b1.WorkloadPlan) ([]resolvedExternalSecret, error) {
+	if plan.Spec.ResolvedValues == nil {
+		return nil, nil
+	}
+	var resolvedValues struct {
+		ExternalSecrets []resolvedExternalSecret `json:"externalSecrets"`
+	}
+	if err := json.Unmarshal(plan.Spec.ResolvedValues.Raw, &resolvedValues); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resolved external secrets: %w", err)
+	}
+	return resolvedValues.ExternalSecrets, nil
+}
+
+// reconcileExternalSecrets applies an ExternalSecret for each claim whose credentials live in an external
+// secret store and deletes ExternalSecrets of claims the plan no longer references
+func (r *KubernetesRuntimePlanReconciler) reconcileExternalSecrets(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) error {
+	secrets, err := resolvedExternalSecrets(plan)
+	if err != nil {
+		return err
+	}
+
+	keep := make(map[string]bool, len(secrets))
+	for _, secret := range secrets {
+		externalSecret := buildExternalSecret(plan, workload, secret)
+
+		// Set WorkloadPlan as owner for garbage collection
+		if err := r.setOwner(plan, externalSecret); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		if err := r.Patch(ctx, externalSecret, client.Apply,
+			client.FieldOwner(meta.FieldManagerRuntimeKubernetes), client.ForceOwnership); err != nil {
+			if apimeta.IsNoMatchError(err) {
+				return fmt.Errorf("claim %s stores its credentials in %s, but the external-secrets operator is not installed",
+					secret.Name, secret.Store)
+			}
+			return fmt.Errorf("failed to apply external secret %s: %w", secret.Name, err)
+		}
+		log.FromContext(ctx).V(1).Info("Applied ExternalSecret", "name", secret.Name)
+		keep[secret.Name] = true
+	}
+
+	return r.deleteExternalSecrets(ctx, plan, keep)
+}
+
+// buildExternalSecret constructs the ExternalSecret syncing the store path of a claim into a Secret of the
+// same name, which is owned by the ExternalSecret and removed with it
+func buildExternalSecret(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload, secret resolvedExternalSecret) *unstructured.Unstructured {
+	objectMeta := runtimeObjectMeta(plan, workload)
+	if secret.Version != "" {
+		objectMeta.Annotations[annotationExternalSecretVersion] = secret.Version
+	}
+
+	data := make([]interface{}, 0, len(secret.Keys))
+	for _, key := range secret.Keys {
+		data = append(data, map[string]interface{}{
+			"secretKey": key,
+			"remoteRef": map[string]interface{}{
+				"key":      secret.Path,
+				"property": key,
+			},
+		})
+	}
+
+	annotations := make(map[string]interface{}, len(objectMeta.Annotations))
+	for key, value := range objectMeta.Annotations {
+		annotations[key] = value
+	}
+	labels := make(map[string]interface{}, len(objectMeta.Labels))
+	for key, value := range objectMeta.Labels {
+		labels[key] = value
+	}
+
+	obj := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{
+			"name":        secret.Name,
+			"namespace":   objectMeta.Namespace,
+			"labels":      labels,
+			"annotations": annotations,
+		},
+		"spec": map[string]interface{}{
+			"refreshInterval": externalSecretRefreshInterval,
+			"secretStoreRef": map[string]interface{}{
+				"kind": "ClusterSecretStore",
+				"name": secret.Store,
+			},
+			"target": map[string]interface{}{
+				"name":           secret.Name,
+				"creationPolicy": "Owner",
+			},
+			"data": data,
+		},
+	}}
+	obj.SetGroupVersionKind(externalSecretGVK)
+	return obj
+}
+
+// deleteExternalSecrets deletes the ExternalSecrets materialized for the plan except those in keep.
+// A cluster without the external-secrets operator has none to delete.
+func (r *KubernetesRuntimePlanReconciler) deleteExternalSecrets(ctx context.Context, plan *scorev1b1.WorkloadPlan, keep map[string]bool) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(externalSecretGVK.GroupVersion().WithKind(externalSecretGVK.Kind + "List"))
+	if err := r.List(ctx, list,
+		client.InNamespace(materializedNamespace(plan)),
+		client.MatchingLabels{
+			"score.dev/runtime": kubernetesRuntimeClass,
+			meta.LabelWorkload:  plan.Spec.WorkloadRef.Name,
+		},
+	); err != nil {
+		if apimeta.IsNoMatchError(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to list external secrets: %w", err)
+	}
+
+	for i := range list.Items {
+		externalSecret := &list.Items[i]
+		if keep[externalSecret.GetName()] || !externalSecret.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, externalSecret); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete external secret %s: %w", externalSecret.GetName(), err)
+		}
+		log.FromContext(ctx).Info("Deleted runtime resource", "kind", externalSecretGVK.Kind, "name", externalSecret.GetName())
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package controller
+
+import (
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestResolvedExternalSecrets(t *testing.T) {
+	tests := []struct {
+		name           string
+		resolvedValues *runtime.RawExtension
+		want           int
+		wantErr        bool
+	}{
+		{"no resolved values", nil, 0, false},
+		{"no external secrets", &runtime.RawExtension{Raw: []byte(`{"containers":{}}`)}, 0, false},
+		{"external secret", &runtime.RawExtension{Raw: []byte(
+			`{"externalSecrets":[{"name":"db-external","store":"vault","path":"score/default/db","keys":["uri"]}]}`,
+		)}, 1, false},
+		{"malformed resolved values", &runtime.RawExtension{Raw: []byte(`{"externalSecrets":{}}`)}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &scorev1b1.WorkloadPlan{Spec: scorev1b1.WorkloadPlanSpec{ResolvedValues: tt.resolvedValues}}
+			got, err := resolvedExternalSecrets(plan)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolvedExternalSecrets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(got) != tt.want {
+				t.Errorf("resolvedExternalSecrets() = %v, want %d entries", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildExternalSecret(t *testing.T) {
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "web", Namespace: "default"},
+		},
+	}
+	workload := &scorev1b1.Workload{}
+
+	externalSecret := buildExternalSecret(plan, workload, resolvedExternalSecret{
+		Name:    "db-external",
+		Store:   "vault",
+		Path:    "score/default/db",
+		Version: "3",
+		Keys:    []string{"password", "uri"},
+	})
+
+	if externalSecret.GroupVersionKind() != externalSecretGVK {
+		t.Errorf("kind = %v, want %v", externalSecret.GroupVersionKind(), externalSecretGVK)
+	}
+	if externalSecret.GetName() != "db-external" || externalSecret.GetNamespace() != "default" {
+		t.Errorf("externalSecret = %s/%s, want default/db-external", externalSecret.GetNamespace(), externalSecret.GetName())
+	}
+	if externalSecret.GetLabels()["score.dev/workload"] != "web" {
+		t.Errorf("labels = %v, want runtime labels of workload web", externalSecret.GetLabels())
+	}
+	if externalSecret.GetAnnotations()[annotationExternalSecretVersion] != "3" {
+		t.Errorf("annotations = %v, want the store version", externalSecret.GetAnnotations())
+	}
+
+	store, _, _ := unstructured.NestedString(externalSecret.Object, "spec", "secretStoreRef", "name")
+	kind, _, _ := unstructured.NestedString(externalSecret.Object, "spec", "secretStoreRef", "kind")
+	if store != "vault" || kind != "ClusterSecretStore" {
+		t.Errorf("secretStoreRef = %s/%s, want ClusterSecretStore/vault", kind, store)
+	}
+	target, _, _ := unstructured.NestedString(externalSecret.Object, "spec", "target", "name")
+	if target != "db-external" {
+		t.Errorf("target = %q, want db-external", target)
+	}
+	data, _, _ := unstructured.NestedSlice(externalSecret.Object, "spec", "data")
+	if len(data) != 2 {
+		t.Fatalf("data = %v, want an entry per key", data)
+	}
+	property, _, _ := unstructured.NestedString(data[1].(map[string]interface{}), "remoteRef", "property")
+	key, _, _ := unstructured.NestedString(data[1].(map[string]interface{}), "remoteRef", "key")
+	if property != "uri" || key != "score/default/db" {
+		t.Errorf("remoteRef = %s#%s, want score/default/db#uri", key, property)
+	}
+}
@@ -0,0 +1,310 @@
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+)
+
+const (
+	// filesVolumeName is the pod volume projecting the files ConfigMap and Secret
+	filesVolumeName = "score-files"
+
+	// filesSecretSuffix names the Secret holding files whose content had placeholders substituted.
+	// A distinct name keeps the runtime from writing into a Secret named after the Workload.
+	filesSecretSuffix = "-files"
+
+	// annotationFilesHash carries the hash of the projected files on the pod template, so that
+	// content changes roll out new pods (files are mounted with subPath and never updated in place)
+	annotationFilesHash = "score.dev/files-hash"
+
+	// maxProjectedFilesSize is the limit the API server enforces on the data of a ConfigMap or Secret
+	maxProjectedFilesSize = 1 << 20
+)
+
+// resolvedFile is an inline file of a container as published in WorkloadPlan.ResolvedValues
+type resolvedFile struct {
+	Target        string  `json:"target"`
+	Mode          *string `json:"mode,omitempty"`
+	Content       *string `json:"content,omitempty"`
+	BinaryContent *string `json:"binaryContent,omitempty"`
+
+	// sensitive marks content that differs from the Workload spec, i.e. carries resolved resource outputs
+	sensitive bool
+}
+
+// fileProjection holds the inline files of a Workload and how they are mounted. Static files go to a
+// ConfigMap; files with substituted placeholders may carry credentials and go to a Secret.
+type fileProjection struct {
+	configMap   *corev1.ConfigMap
+	secret      *corev1.Secret
+	items       []corev1.KeyToPath
+	secretItems []corev1.KeyToPath
+	mounts      map[string][]corev1.VolumeMount
+	hash        string
+}
+
+// filesSecretName returns the name of the Secret holding the sensitive files of the Workload
+func filesSecretName(name string) string {
+	return name + filesSecretSuffix
+}
+
+// filesSecretRef returns an empty Secret carrying the key of the plan's files Secret
+func filesSecretRef(plan *scorev1b1.WorkloadPlan) *corev1.Secret {
+	return &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      filesSecretName(materializedName(plan)),
+		Namespace: materializedNamespace(plan),
+	}}
+}
+
+// reconcileFiles applies the ConfigMap and Secret with the inline files of the Workload, deleting
+// either one when the Workload no longer declares files that belong in it
+func (r *KubernetesRuntimePlanReconciler) reconcileFiles(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) error {
+	projection, err := buildFileProjection(plan, workload)
+	if err != nil {
+		return err
+	}
+	if projection == nil {
+		projection = &fileProjection{}
+	}
+
+	if projection.configMap == nil {
+		if err := r.deleteMaterialized(ctx, plan, &corev1.ConfigMap{}); err != nil {
+			return err
+		}
+	} else {
+		// Set WorkloadPlan as owner for garbage collection
+		if err := r.setOwner(plan, projection.configMap); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+		if err := reconcile.Apply(ctx, r.Client, projection.configMap, meta.FieldManagerRuntimeKubernetes); err != nil {
+			return fmt.Errorf("failed to apply files configmap: %w", err)
+		}
+		log.FromContext(ctx).V(1).Info("Applied files ConfigMap", "name", projection.configMap.Name)
+	}
+
+	if projection.secret == nil {
+		return r.deleteMaterialized(ctx, plan, filesSecretRef(plan))
+	}
+	if err := r.setOwner(plan, projection.secret); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	if err := reconcile.Apply(ctx, r.Client, projection.secret, meta.FieldManagerRuntimeKubernetes); err != nil {
+		return fmt.Errorf("failed to apply files secret: %w", err)
+	}
+	log.FromContext(ctx).V(1).Info("Applied files Secret", "name", projection.secret.Name)
+
+	return nil
+}
+
+// buildFileProjection collects the inline files (content and binaryContent) of all containers into a
+// ConfigMap named after the Workload and, for files with substituted placeholders, a Secret.
+// Files with an external source are not projected. It returns nil when the Workload has no inline files.
+func buildFileProjection(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) (*fileProjection, error) {
+	files, err := containerFiles(plan, workload)
+	if err != nil {
+		return nil, err
+	}
+
+	configMap := &corev1.ConfigMap{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ConfigMap",
+		},
+		ObjectMeta: runtimeObjectMeta(plan, workload),
+	}
+	secretMeta := runtimeObjectMeta(plan, workload)
+	secretMeta.Name = filesSecretName(materializedName(plan))
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: secretMeta,
+		Type:       corev1.SecretTypeOpaque,
+	}
+	projection := &fileProjection{mounts: make(map[string][]corev1.VolumeMount)}
+	hash := sha256.New()
+	var configMapSize, secretSize int
+
+	containerNames := make([]string, 0, len(files))
+	for containerName := range files {
+		containerNames = append(containerNames, containerName)
+	}
+	sort.Strings(containerNames)
+
+	for _, containerName := range containerNames {
+		for i, file := range files[containerName] {
+			if file.Content == nil && file.BinaryContent == nil {
+				continue
+			}
+
+			// Container names are DNS labels, so the key is a valid ConfigMap and Secret key
+			key := fmt.Sprintf("%s-%d", containerName, i)
+			item := corev1.KeyToPath{Key: key, Path: key}
+			if file.Mode != nil {
+				mode, err := strconv.ParseInt(*file.Mode, 8, 32)
+				if err != nil {
+					return nil, fmt.Errorf("invalid mode %s for file %s of container %s: %w", *file.Mode, file.Target, containerName, err)
+				}
+				item.Mode = ptr.To(int32(mode))
+			}
+
+			switch {
+			case file.sensitive:
+				if secret.Data == nil {
+					secret.Data = make(map[string][]byte)
+				}
+				secret.Data[key] = []byte(*file.Content)
+				secretSize += len(key) + len(*file.Content)
+				projection.secretItems = append(projection.secretItems, item)
+			case file.Content != nil:
+				if configMap.Data == nil {
+					configMap.Data = make(map[string]string)
+				}
+				configMap.Data[key] = *file.Content
+				configMapSize += len(key) + len(*file.Content)
+				projection.items = append(projection.items, item)
+			default:
+				data, err := base64.StdEncoding.DecodeString(*file.BinaryContent)
+				if err != nil {
+					return nil, fmt.Errorf("invalid binaryContent for file %s of container %s: %w", file.Target, containerName, err)
+				}
+				if configMap.BinaryData == nil {
+					configMap.BinaryData = make(map[string][]byte)
+				}
+				configMap.BinaryData[key] = data
+				configMapSize += len(key) + len(data)
+				projection.items = append(projection.items, item)
+			}
+
+			projection.mounts[containerName] = append(projection.mounts[containerName], corev1.VolumeMount{
+				Name:      filesVolumeName,
+				MountPath: file.Target,
+				SubPath:   key,
+				ReadOnly:  true,
+			})
+
+			fmt.Fprintf(hash, "%s\x00%s\x00%s\x00", key, file.Target, ptr.Deref(file.Mode, ""))
+			if file.Content != nil {
+				hash.Write([]byte(*file.Content))
+			} else {
+				hash.Write([]byte(*file.BinaryContent))
+			}
+			hash.Write([]byte{0})
+		}
+	}
+
+	if configMapSize > maxProjectedFilesSize {
+		return nil, fmt.Errorf("inline files of %s total %d bytes, exceeding the 1MiB limit of a ConfigMap", workload.Name, configMapSize)
+	}
+	if secretSize > maxProjectedFilesSize {
+		return nil, fmt.Errorf("inline files with resolved placeholders of %s total %d bytes, exceeding the 1MiB limit of a Secret", workload.Name, secretSize)
+	}
+
+	if len(projection.items) == 0 && len(projection.secretItems) == 0 {
+		return nil, nil
+	}
+	if len(projection.items) > 0 {
+		projection.configMap = configMap
+	}
+	if len(projection.secretItems) > 0 {
+		projection.secret = secret
+	}
+	projection.hash = hex.EncodeToString(hash.Sum(nil))[:16]
+	return projection, nil
+}
+
+// applyTo mounts the projected files into the pod template and records their hash on it
+func (p *fileProjection) applyTo(template *corev1.PodTemplateSpec) {
+	if p == nil {
+		return
+	}
+
+	var sources []corev1.VolumeProjection
+	if p.configMap != nil {
+		sources = append(sources, corev1.VolumeProjection{ConfigMap: &corev1.ConfigMapProjection{
+			LocalObjectReference: corev1.LocalObjectReference{Name: p.configMap.Name},
+			Items:                p.items,
+		}})
+	}
+	if p.secret != nil {
+		sources = append(sources, corev1.VolumeProjection{Secret: &corev1.SecretProjection{
+			LocalObjectReference: corev1.LocalObjectReference{Name: p.secret.Name},
+			Items:                p.secretItems,
+		}})
+	}
+	template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+		Name: filesVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{Sources: sources},
+		},
+	})
+	for i := range template.Spec.Containers {
+		container := &template.Spec.Containers[i]
+		container.VolumeMounts = append(container.VolumeMounts, p.mounts[container.Name]...)
+	}
+
+	if template.Annotations == nil {
+		template.Annotations = make(map[string]string)
+	}
+	template.Annotations[annotationFilesHash] = p.hash
+}
+
+// containerFiles returns the files of each container with placeholders resolved from
+// WorkloadPlan.ResolvedValues, falling back to the Workload spec when no values were resolved.
+// Resolved content that differs from the spec is marked sensitive.
+func containerFiles(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) (map[string][]resolvedFile, error) {
+	files := make(map[string][]resolvedFile)
+
+	if plan.Spec.ResolvedValues == nil {
+		for containerName, containerSpec := range workload.Spec.Containers {
+			for _, file := range containerSpec.Files {
+				files[containerName] = append(files[containerName], resolvedFile{
+					Target:        file.Target,
+					Mode:          file.Mode,
+					Content:       file.Content,
+					BinaryContent: file.BinaryContent,
+				})
+			}
+		}
+		return files, nil
+	}
+
+	var resolvedValues struct {
+		Containers map[string]struct {
+			Files []resolvedFile `json:"files"`
+		} `json:"containers"`
+	}
+	if err := json.Unmarshal(plan.Spec.ResolvedValues.Raw, &resolvedValues); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resolved values: %w", err)
+	}
+	for containerName, container := range resolvedValues.Containers {
+		if len(container.Files) == 0 {
+			continue
+		}
+		specFiles := workload.Spec.Containers[containerName].Files
+		for i := range container.Files {
+			file := &container.Files[i]
+			if file.Content != nil && (i >= len(specFiles) || ptr.Deref(specFiles[i].Content, "") != *file.Content) {
+				file.sensitive = true
+			}
+		}
+		files[containerName] = container.Files
+	}
+	return files, nil
+}
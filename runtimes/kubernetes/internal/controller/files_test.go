@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestBuildFileProjection(t *testing.T) {
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "web", Namespace: "default"},
+			ResolvedValues: &runtime.RawExtension{Raw: []byte(`{"containers":{"app":{"files":[` +
+				`{"target":"/etc/app/config.yaml","mode":"0600","content":"database: postgres://db:5432/app\n"},` +
+				`{"target":"/etc/app/schema.sql","source":{"uri":"https://example.com/schema.sql"}},` +
+				`{"target":"/etc/app/logo.png","binaryContent":"aGVsbG8="}]}}}`)},
+		},
+	}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{
+				"app": {Image: "nginx", Files: []scorev1b1.FileSpec{
+					{Target: "/etc/app/config.yaml", Mode: ptr.To("0600"), Content: ptr.To("database: ${resources.db.outputs.uri}\n")},
+					{Target: "/etc/app/schema.sql", Source: &scorev1b1.FileSourceSpec{URI: "https://example.com/schema.sql"}},
+					{Target: "/etc/app/logo.png", BinaryContent: ptr.To("aGVsbG8=")},
+				}},
+				"sidecar": {Image: "envoy"},
+			},
+		},
+	}
+
+	projection, err := buildFileProjection(plan, workload)
+	if err != nil {
+		t.Fatalf("buildFileProjection() error = %v", err)
+	}
+	if projection == nil {
+		t.Fatal("buildFileProjection() = nil, want a projection")
+	}
+
+	// Content with substituted placeholders may carry credentials and goes to the Secret
+	configMap, secret := projection.configMap, projection.secret
+	if configMap == nil || configMap.Name != "web" || configMap.Namespace != "default" {
+		t.Fatalf("configMap = %+v, want default/web", configMap)
+	}
+	if secret == nil || secret.Name != "web-files" || !isMaterializedFor(secret, plan) {
+		t.Fatalf("secret = %+v, want default/web-files with runtime labels", secret)
+	}
+	if _, ok := configMap.Data["app-0"]; ok {
+		t.Error("resolved content must not be stored in the ConfigMap")
+	}
+	if got := string(secret.Data["app-0"]); got != "database: postgres://db:5432/app\n" {
+		t.Errorf("secret data[app-0] = %q, want the resolved content", got)
+	}
+	if got := string(configMap.BinaryData["app-2"]); got != "hello" {
+		t.Errorf("binaryData[app-2] = %q, want the decoded content", got)
+	}
+	if len(projection.secretItems) != 1 || ptr.Deref(projection.secretItems[0].Mode, 0) != 0o600 {
+		t.Errorf("secret items = %+v, want one item with mode 0600", projection.secretItems)
+	}
+	if len(projection.items) != 1 || projection.items[0].Key != "app-2" {
+		t.Errorf("configMap items = %+v, want app-2", projection.items)
+	}
+
+	r := &KubernetesRuntimePlanReconciler{}
+	deployment, err := r.buildDeployment(context.Background(), plan, workload)
+	if err != nil {
+		t.Fatalf("buildDeployment() error = %v", err)
+	}
+	template := deployment.Spec.Template
+	if len(template.Spec.Volumes) != 1 || template.Spec.Volumes[0].Projected == nil {
+		t.Fatalf("volumes = %+v, want one projected files volume", template.Spec.Volumes)
+	}
+	if sources := template.Spec.Volumes[0].Projected.Sources; len(sources) != 2 ||
+		sources[0].ConfigMap == nil || sources[0].ConfigMap.Name != "web" ||
+		sources[1].Secret == nil || sources[1].Secret.Name != "web-files" {
+		t.Errorf("projected sources = %+v, want the files ConfigMap and Secret", sources)
+	}
+	if template.Annotations[annotationFilesHash] != projection.hash {
+		t.Errorf("pod template hash = %q, want %q", template.Annotations[annotationFilesHash], projection.hash)
+	}
+	for _, container := range template.Spec.Containers {
+		switch container.Name {
+		case "app":
+			if len(container.VolumeMounts) != 2 || container.VolumeMounts[0].MountPath != "/etc/app/config.yaml" || container.VolumeMounts[0].SubPath != "app-0" {
+				t.Errorf("app volumeMounts = %+v, want config.yaml and logo.png", container.VolumeMounts)
+			}
+		case "sidecar":
+			if len(container.VolumeMounts) != 0 {
+				t.Errorf("sidecar volumeMounts = %+v, want none", container.VolumeMounts)
+			}
+		}
+	}
+
+	// Changing the content changes the hash, which rolls out new pods
+	changed := plan.DeepCopy()
+	changed.Spec.ResolvedValues = &runtime.RawExtension{Raw: []byte(`{"containers":{"app":{"files":[` +
+		`{"target":"/etc/app/config.yaml","mode":"0600","content":"database: postgres://replica:5432/app\n"}]}}}`)}
+	changedProjection, err := buildFileProjection(changed, workload)
+	if err != nil {
+		t.Fatalf("buildFileProjection() error = %v", err)
+	}
+	if changedProjection.hash == projection.hash {
+		t.Error("hash did not change with the file content")
+	}
+}
+
+func TestBuildFileProjectionKeepsStaticContentInConfigMap(t *testing.T) {
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "web", Namespace: "default"},
+			ResolvedValues: &runtime.RawExtension{Raw: []byte(`{"containers":{"app":{"files":[` +
+				`{"target":"/etc/app/static.conf","content":"listen 8080\n"}]}}}`)},
+		},
+	}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{
+				"app": {Image: "nginx", Files: []scorev1b1.FileSpec{
+					{Target: "/etc/app/static.conf", Content: ptr.To("listen 8080\n")},
+				}},
+			},
+		},
+	}
+
+	projection, err := buildFileProjection(plan, workload)
+	if err != nil {
+		t.Fatalf("buildFileProjection() error = %v", err)
+	}
+	if projection.secret != nil {
+		t.Errorf("secret = %+v, want none for static content", projection.secret)
+	}
+	if projection.configMap == nil || projection.configMap.Data["app-0"] != "listen 8080\n" {
+		t.Errorf("configMap = %+v, want the static content", projection.configMap)
+	}
+
+	// The API server rejects ConfigMaps and Secrets with more than 1MiB of data
+	large := strings.Repeat("x", maxProjectedFilesSize)
+	plan.Spec.ResolvedValues = nil
+	workload.Spec.Containers["app"] = scorev1b1.ContainerSpec{Image: "nginx", Files: []scorev1b1.FileSpec{
+		{Target: "/etc/app/large.txt", Content: ptr.To(large)},
+	}}
+	if _, err := buildFileProjection(plan, workload); err == nil {
+		t.Error("buildFileProjection() with more than 1MiB of files should fail")
+	}
+}
+
+func TestBuildFileProjectionWithoutInlineFiles(t *testing.T) {
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "web", Namespace: "default"},
+		},
+	}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{
+				"app": {Image: "nginx", Files: []scorev1b1.FileSpec{
+					{Target: "/etc/app/schema.sql", Source: &scorev1b1.FileSourceSpec{URI: "https://example.com/schema.sql"}},
+				}},
+			},
+		},
+	}
+
+	projection, err := buildFileProjection(plan, workload)
+	if err != nil {
+		t.Fatalf("buildFileProjection() error = %v", err)
+	}
+	if projection != nil {
+		t.Errorf("buildFileProjection() = %+v, want nil", projection)
+	}
+
+	workload.Spec.Containers["app"] = scorev1b1.ContainerSpec{Image: "nginx", Files: []scorev1b1.FileSpec{
+		{Target: "/etc/app/config.yaml", Mode: ptr.To("rw-r--r--"), Content: ptr.To("static")},
+	}}
+	if _, err := buildFileProjection(plan, workload); err == nil {
+		t.Error("buildFileProjection() with an invalid mode should fail")
+	}
+}
@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"context"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+// SetupIndexers sets up the indexers the runtime controllers list objects by, so that event mapping
+// and reconciles do not scan every object of a kind. Kinds of disabled capabilities are not indexed.
+func SetupIndexers(ctx context.Context, mgr manager.Manager, capabilities Capabilities) error {
+	if err := mgr.GetFieldIndexer().IndexField(ctx, &scorev1b1.WorkloadExposure{}, meta.IndexWorkloadExposureByWorkload,
+		exposureWorkloadIndex); err != nil {
+		return err
+	}
+	if !capabilities.Has(CapabilityIngress) {
+		return nil
+	}
+	return mgr.GetFieldIndexer().IndexField(ctx, &networkingv1.Ingress{}, meta.IndexIngressByBackendService,
+		ingressBackendServiceIndex)
+}
+
+// exposureWorkloadIndex indexes a WorkloadExposure by the namespace/name of its Workload
+func exposureWorkloadIndex(obj client.Object) []string {
+	exposure := obj.(*scorev1b1.WorkloadExposure)
+	namespace := ptr.Deref(exposure.Spec.WorkloadRef.Namespace, exposure.Namespace)
+	return []string{namespace + "/" + exposure.Spec.WorkloadRef.Name}
+}
+
+// ingressBackendServiceIndex indexes an Ingress by the namespace/name of every Service it routes to
+func ingressBackendServiceIndex(obj client.Object) []string {
+	ingress := obj.(*networkingv1.Ingress)
+	var keys []string
+	for _, name := range ingressBackendServices(ingress) {
+		keys = append(keys, ingress.Namespace+"/"+name)
+	}
+	return keys
+}
+
+// ingressBackendServices returns the names of the Services the Ingress routes to, each name once
+func ingressBackendServices(ingress *networkingv1.Ingress) []string {
+	seen := make(map[string]bool)
+	var names []string
+	add := func(backend *networkingv1.IngressBackend) {
+		if backend == nil || backend.Service == nil || seen[backend.Service.Name] {
+			return
+		}
+		seen[backend.Service.Name] = true
+		names = append(names, backend.Service.Name)
+	}
+
+	add(ingress.Spec.DefaultBackend)
+	for _, rule := range ingress.Spec.Rules {
+		if rule.HTTP == nil {
+			continue
+		}
+		for i := range rule.HTTP.Paths {
+			add(&rule.HTTP.Paths[i].Backend)
+		}
+	}
+	return names
+}
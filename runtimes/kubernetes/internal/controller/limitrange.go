@@ -0,0 +1,183 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// errLimitRangeViolation is returned by checkLimitRanges when the API server would reject the pods of the plan
+var errLimitRangeViolation = errors.New("pods would be rejected by the LimitRanges of the namespace")
+
+// checkLimitRanges returns an error wrapping errLimitRangeViolation, with every violated constraint, when the
+// LimitRanges of the namespace the plan is materialized into would reject pods with the containers. The
+// defaults of each LimitRange are applied to the containers first, as the LimitRanger admission plugin does,
+// so that pods are not created only to be rejected.
+func (r *KubernetesRuntimePlanReconciler) checkLimitRanges(ctx context.Context, plan *scorev1b1.WorkloadPlan, containers []corev1.Container) error {
+	limitRanges := &corev1.LimitRangeList{}
+	if err := r.List(ctx, limitRanges, client.InNamespace(materializedNamespace(plan))); err != nil {
+		return fmt.Errorf("failed to list LimitRanges: %w", err)
+	}
+	sort.Slice(limitRanges.Items, func(i, j int) bool { return limitRanges.Items[i].Name < limitRanges.Items[j].Name })
+
+	var violations []string
+	for i := range limitRanges.Items {
+		violations = append(violations, limitRangeViolations(&limitRanges.Items[i], containers)...)
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%w: %s", errLimitRangeViolation, strings.Join(violations, "; "))
+}
+
+// limitRangeViolations returns the constraints of the LimitRange that pods with the containers violate
+func limitRangeViolations(limitRange *corev1.LimitRange, containers []corev1.Container) []string {
+	admitted := make([]corev1.ResourceRequirements, len(containers))
+	for i := range containers {
+		admitted[i] = admittedResources(containers[i].Resources, limitRange)
+	}
+
+	var violations []string
+	for _, item := range limitRange.Spec.Limits {
+		switch item.Type {
+		case corev1.LimitTypeContainer:
+			for i, container := range containers {
+				for _, violation := range resourceViolations(item, admitted[i]) {
+					violations = append(violations, fmt.Sprintf("container %s: %s per Container of LimitRange %s",
+						container.Name, violation, limitRange.Name))
+				}
+			}
+		case corev1.LimitTypePod:
+			for _, violation := range resourceViolations(item, podResources(admitted)) {
+				violations = append(violations, fmt.Sprintf("pod: %s per Pod of LimitRange %s", violation, limitRange.Name))
+			}
+		}
+	}
+	return violations
+}
+
+// admittedResources returns the resources of a container as admitted by the API server: requests default to
+// the declared limits, then missing limits and requests are taken from the Container defaults of the LimitRange
+func admittedResources(resources corev1.ResourceRequirements, limitRange *corev1.LimitRange) corev1.ResourceRequirements {
+	admitted := corev1.ResourceRequirements{Requests: corev1.ResourceList{}, Limits: corev1.ResourceList{}}
+	for name, quantity := range resources.Limits {
+		admitted.Limits[name] = quantity.DeepCopy()
+		admitted.Requests[name] = quantity.DeepCopy()
+	}
+	for name, quantity := range resources.Requests {
+		admitted.Requests[name] = quantity.DeepCopy()
+	}
+
+	for _, item := range limitRange.Spec.Limits {
+		if item.Type != corev1.LimitTypeContainer {
+			continue
+		}
+		for name, quantity := range item.Default {
+			if _, ok := admitted.Limits[name]; !ok {
+				admitted.Limits[name] = quantity.DeepCopy()
+			}
+		}
+		for name, quantity := range item.DefaultRequest {
+			if _, ok := admitted.Requests[name]; !ok {
+				admitted.Requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	return admitted
+}
+
+// podResources sums the resources of the containers of a pod. A resource is only summed when every
+// container sets it, as a pod with an unbounded container is unbounded.
+func podResources(containers []corev1.ResourceRequirements) corev1.ResourceRequirements {
+	sum := func(list func(corev1.ResourceRequirements) corev1.ResourceList) corev1.ResourceList {
+		total := corev1.ResourceList{}
+		if len(containers) == 0 {
+			return total
+		}
+		for name := range list(containers[0]) {
+			quantity := resource.Quantity{}
+			complete := true
+			for _, container := range containers {
+				value, ok := list(container)[name]
+				if !ok {
+					complete = false
+					break
+				}
+				quantity.Add(value)
+			}
+			if complete {
+				total[name] = quantity
+			}
+		}
+		return total
+	}
+	return corev1.ResourceRequirements{
+		Requests: sum(func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Requests }),
+		Limits:   sum(func(r corev1.ResourceRequirements) corev1.ResourceList { return r.Limits }),
+	}
+}
+
+// resourceViolations returns the min, max and limit/request ratio constraints of the item that the
+// resources violate, in resource name order
+func resourceViolations(item corev1.LimitRangeItem, resources corev1.ResourceRequirements) []string {
+	var violations []string
+	for _, name := range sortedResourceNames(item.Min) {
+		minimum := item.Min[name]
+		request, ok := resources.Requests[name]
+		switch {
+		case !ok:
+			violations = append(violations, fmt.Sprintf("%s request is not set but the minimum is %s", name, minimum.String()))
+		case request.Cmp(minimum) < 0:
+			violations = append(violations, fmt.Sprintf("%s request %s is below the minimum %s", name, request.String(), minimum.String()))
+		}
+		if limit, ok := resources.Limits[name]; ok && limit.Cmp(minimum) < 0 {
+			violations = append(violations, fmt.Sprintf("%s limit %s is below the minimum %s", name, limit.String(), minimum.String()))
+		}
+	}
+	for _, name := range sortedResourceNames(item.Max) {
+		maximum := item.Max[name]
+		limit, ok := resources.Limits[name]
+		switch {
+		case !ok:
+			violations = append(violations, fmt.Sprintf("%s limit is not set but the maximum is %s", name, maximum.String()))
+		case limit.Cmp(maximum) > 0:
+			violations = append(violations, fmt.Sprintf("%s limit %s exceeds the maximum %s", name, limit.String(), maximum.String()))
+		}
+		if request, ok := resources.Requests[name]; ok && request.Cmp(maximum) > 0 {
+			violations = append(violations, fmt.Sprintf("%s request %s exceeds the maximum %s", name, request.String(), maximum.String()))
+		}
+	}
+	for _, name := range sortedResourceNames(item.MaxLimitRequestRatio) {
+		ratio := item.MaxLimitRequestRatio[name]
+		request, hasRequest := resources.Requests[name]
+		limit, hasLimit := resources.Limits[name]
+		if !hasRequest || !hasLimit || request.IsZero() {
+			violations = append(violations, fmt.Sprintf("%s request and limit are required by the maximum limit/request ratio %s",
+				name, ratio.String()))
+			continue
+		}
+		if limit.AsApproximateFloat64()/request.AsApproximateFloat64() > ratio.AsApproximateFloat64() {
+			violations = append(violations, fmt.Sprintf("%s limit %s to request %s exceeds the maximum limit/request ratio %s",
+				name, limit.String(), request.String(), ratio.String()))
+		}
+	}
+	return violations
+}
+
+// sortedResourceNames returns the resource names of the list in order
+func sortedResourceNames(list corev1.ResourceList) []corev1.ResourceName {
+	names := make([]corev1.ResourceName, 0, len(list))
+	for name := range list {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool { return names[i] < names[j] })
+	return names
+}
@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+func TestLimitRangeViolations(t *testing.T) {
+	container := func(name string, requests, limits corev1.ResourceList) corev1.Container {
+		return corev1.Container{Name: name, Resources: corev1.ResourceRequirements{Requests: requests, Limits: limits}}
+	}
+	limits := func(items ...corev1.LimitRangeItem) *corev1.LimitRange {
+		return &corev1.LimitRange{ObjectMeta: metav1.ObjectMeta{Name: "limits"}, Spec: corev1.LimitRangeSpec{Limits: items}}
+	}
+	memory := func(value string) corev1.ResourceList {
+		return corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(value)}
+	}
+
+	tests := []struct {
+		name       string
+		limitRange *corev1.LimitRange
+		containers []corev1.Container
+		want       []string
+	}{
+		{
+			name:       "within bounds",
+			limitRange: limits(corev1.LimitRangeItem{Type: corev1.LimitTypeContainer, Min: memory("64Mi"), Max: memory("1Gi")}),
+			containers: []corev1.Container{container("app", memory("128Mi"), memory("512Mi"))},
+		},
+		{
+			name:       "limit above the maximum",
+			limitRange: limits(corev1.LimitRangeItem{Type: corev1.LimitTypeContainer, Max: memory("1Gi")}),
+			containers: []corev1.Container{container("app", memory("128Mi"), memory("2Gi"))},
+			want:       []string{"container app: memory limit 2Gi exceeds the maximum 1Gi per Container of LimitRange limits"},
+		},
+		{
+			name:       "missing limit is taken from the default",
+			limitRange: limits(corev1.LimitRangeItem{Type: corev1.LimitTypeContainer, Max: memory("1Gi"), Default: memory("512Mi")}),
+			containers: []corev1.Container{container("app", nil, nil)},
+		},
+		{
+			name:       "missing limit without a default",
+			limitRange: limits(corev1.LimitRangeItem{Type: corev1.LimitTypeContainer, Max: memory("1Gi")}),
+			containers: []corev1.Container{container("app", memory("128Mi"), nil)},
+			want:       []string{"container app: memory limit is not set but the maximum is 1Gi per Container of LimitRange limits"},
+		},
+		{
+			name:       "request defaults to the declared limit",
+			limitRange: limits(corev1.LimitRangeItem{Type: corev1.LimitTypeContainer, Min: memory("64Mi")}),
+			containers: []corev1.Container{container("app", nil, memory("32Mi"))},
+			want: []string{
+				"container app: memory request 32Mi is below the minimum 64Mi per Container of LimitRange limits",
+				"container app: memory limit 32Mi is below the minimum 64Mi per Container of LimitRange limits",
+			},
+		},
+		{
+			name: "limit to request ratio",
+			limitRange: limits(corev1.LimitRangeItem{Type: corev1.LimitTypeContainer,
+				MaxLimitRequestRatio: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2")}}),
+			containers: []corev1.Container{container("app", memory("128Mi"), memory("512Mi"))},
+			want:       []string{"container app: memory limit 512Mi to request 128Mi exceeds the maximum limit/request ratio 2 per Container of LimitRange limits"},
+		},
+		{
+			name:       "pod total above the maximum",
+			limitRange: limits(corev1.LimitRangeItem{Type: corev1.LimitTypePod, Max: memory("1Gi")}),
+			containers: []corev1.Container{container("app", memory("256Mi"), memory("768Mi")), container("proxy", memory("64Mi"), memory("512Mi"))},
+			want:       []string{"pod: memory limit 1280Mi exceeds the maximum 1Gi per Pod of LimitRange limits"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := limitRangeViolations(tt.limitRange, tt.containers); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("limitRangeViolations() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileReportsLimitRangeViolation(t *testing.T) {
+	ctx := context.Background()
+	scheme := namingScheme(t)
+
+	key := types.NamespacedName{Name: "app", Namespace: "default"}
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx:1"}},
+		},
+	}
+	plan := &scorev1b1.WorkloadPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Generation: 1, Finalizers: []string{kubernetesRuntimeFinalizer}},
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef:      scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+			RuntimeClass:     kubernetesRuntimeClass,
+			DefaultResources: &scorev1b1.ResourceRequirements{Limits: map[string]string{"memory": "2Gi"}},
+		},
+	}
+	limitRange := &corev1.LimitRange{
+		ObjectMeta: metav1.ObjectMeta{Name: "limits", Namespace: "default"},
+		Spec: corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{{
+			Type: corev1.LimitTypeContainer,
+			Max:  corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+		}}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(workload, plan, limitRange).
+		WithStatusSubresource(&scorev1b1.WorkloadPlan{}).
+		Build()
+	r := &KubernetesRuntimePlanReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(20)}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter == 0 {
+		t.Error("Reconcile() did not requeue the rejected plan")
+	}
+
+	if err := c.Get(ctx, key, plan); err != nil {
+		t.Fatal(err)
+	}
+	ready := apimeta.FindStatusCondition(plan.Status.Conditions, conditionReady)
+	if plan.Status.Phase != scorev1b1.WorkloadPlanPhaseFailed || ready == nil || ready.Reason != meta.PlanReasonLimitRangeViolation {
+		t.Errorf("plan status = %s %v, want Failed with reason %s", plan.Status.Phase, ready, meta.PlanReasonLimitRangeViolation)
+	}
+	if !strings.Contains(plan.Status.Message, "memory limit 2Gi exceeds the maximum 1Gi per Container of LimitRange limits") {
+		t.Errorf("plan message = %q, want the LimitRange details", plan.Status.Message)
+	}
+	if err := c.Get(ctx, key, &appsv1.Deployment{}); err == nil {
+		t.Error("Deployment was created although its pods would be rejected")
+	}
+}
@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+)
+
+const (
+	// labelPlanNamespace carries the namespace of the plan on resources materialized into the environment
+	// namespace of the plan, which cannot reference the plan as their owner
+	labelPlanNamespace = "score.dev/plan-namespace"
+
+	// labelMirroredSecret marks the copies of claim Secrets made in the environment namespace of a plan
+	labelMirroredSecret = "score.dev/mirrored-secret"
+)
+
+// materializedNamespace returns the namespace the resources of the plan are materialized into:
+// the environment namespace provisioned by the Orchestrator, or else the namespace of the Workload
+func materializedNamespace(plan *scorev1b1.WorkloadPlan) string {
+	if plan.Spec.Namespace != "" {
+		return plan.Spec.Namespace
+	}
+	return plan.Spec.WorkloadRef.Namespace
+}
+
+// setOwner makes the plan the controller of obj. Owner references cannot cross namespaces, so resources
+// materialized into an environment namespace are labeled with the namespace of the plan instead; they are
+// removed by the teardown of the plan rather than by garbage collection.
+func (r *KubernetesRuntimePlanReconciler) setOwner(plan *scorev1b1.WorkloadPlan, obj client.Object) error {
+	if obj.GetNamespace() == plan.Namespace {
+		return ctrl.SetControllerReference(plan, obj, r.Scheme)
+	}
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = make(map[string]string)
+	}
+	labels[labelPlanNamespace] = plan.Namespace
+	obj.SetLabels(labels)
+	return nil
+}
+
+// planForMaterialized maps a resource materialized into an environment namespace to the plan it belongs to
+func planForMaterialized(_ context.Context, obj client.Object) []ctrl.Request {
+	labels := obj.GetLabels()
+	namespace, name := labels[labelPlanNamespace], labels[meta.LabelWorkload]
+	if namespace == "" || name == "" || labels["score.dev/runtime"] != kubernetesRuntimeClass {
+		return nil
+	}
+	return []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: namespace, Name: name}}}
+}
+
+// reconcileSecretMirrors copies the Secrets referenced by the container variables of a plan materialized into an
+// environment namespace from the namespace of the Workload, since pods can only reference Secrets of their own
+// namespace. Copies the plan no longer references are deleted. Copies are refreshed whenever the plan is reconciled.
+func (r *KubernetesRuntimePlanReconciler) reconcileSecretMirrors(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) error {
+	keep := make(map[string]bool)
+	if materializedNamespace(plan) != plan.Spec.WorkloadRef.Namespace {
+		names, err := mirroredSecretNames(plan)
+		if err != nil {
+			return err
+		}
+		for _, name := range names {
+			mirror, err := r.buildSecretMirror(ctx, plan, workload, name)
+			if err != nil {
+				return err
+			}
+			if err := reconcile.Apply(ctx, r.Client, mirror, meta.FieldManagerRuntimeKubernetes); err != nil {
+				return fmt.Errorf("failed to apply copy of secret %s: %w", name, err)
+			}
+			log.FromContext(ctx).V(1).Info("Applied Secret copy", "name", name, "namespace", mirror.Namespace)
+			keep[name] = true
+		}
+	}
+	return r.deleteSecretMirrors(ctx, plan, keep)
+}
+
+// buildSecretMirror constructs the copy of the named Secret of the Workload namespace in the environment namespace.
+// An existing Secret of the same name that the runtime did not create is never overwritten.
+func (r *KubernetesRuntimePlanReconciler) buildSecretMirror(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload, name string) (*corev1.Secret, error) {
+	source := &corev1.Secret{}
+	sourceKey := types.NamespacedName{Namespace: plan.Spec.WorkloadRef.Namespace, Name: name}
+	if err := r.Get(ctx, sourceKey, source); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", sourceKey, err)
+	}
+
+	objectMeta := runtimeObjectMeta(plan, workload)
+	objectMeta.Name = name
+	objectMeta.Labels[labelMirroredSecret] = "true"
+	mirror := &corev1.Secret{
+		TypeMeta:   metav1.TypeMeta{APIVersion: corev1.SchemeGroupVersion.String(), Kind: "Secret"},
+		ObjectMeta: objectMeta,
+		Type:       source.Type,
+		Data:       source.Data,
+	}
+
+	existing := &corev1.Secret{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(mirror), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get secret copy: %w", err)
+		}
+	} else if !isMaterializedFor(existing, plan) {
+		return nil, fmt.Errorf("secret %s already exists in namespace %s and is not managed by the runtime", name, mirror.Namespace)
+	}
+
+	if err := r.setOwner(plan, mirror); err != nil {
+		return nil, fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	return mirror, nil
+}
+
+// deleteSecretMirrors deletes the Secret copies made for the plan except those in keep
+func (r *KubernetesRuntimePlanReconciler) deleteSecretMirrors(ctx context.Context, plan *scorev1b1.WorkloadPlan, keep map[string]bool) error {
+	secrets := &corev1.SecretList{}
+	if err := r.List(ctx, secrets,
+		client.InNamespace(materializedNamespace(plan)),
+		client.MatchingLabels{
+			"score.dev/runtime": kubernetesRuntimeClass,
+			meta.LabelWorkload:  plan.Spec.WorkloadRef.Name,
+			labelMirroredSecret: "true",
+		},
+	); err != nil {
+		return fmt.Errorf("failed to list secret copies: %w", err)
+	}
+
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if keep[secret.Name] || !isMaterializedFor(secret, plan) || !secret.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, secret); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete secret copy %s: %w", secret.Name, err)
+		}
+		log.FromContext(ctx).Info("Deleted runtime resource", "kind", "Secret", "name", secret.Name)
+	}
+	return nil
+}
+
+// mirroredSecretNames returns the Secrets container variables of the plan reference, in name order.
+// Secrets synced by an ExternalSecret are created in the environment namespace itself and are not copied.
+func mirroredSecretNames(plan *scorev1b1.WorkloadPlan) ([]string, error) {
+	if plan.Spec.ResolvedValues == nil {
+		return nil, nil
+	}
+	var resolvedValues struct {
+		Containers map[string]struct {
+			Env map[string]interface{} `json:"env"`
+		} `json:"containers"`
+	}
+	if err := json.Unmarshal(plan.Spec.ResolvedValues.Raw, &resolvedValues); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resolved values: %w", err)
+	}
+	externalSecrets, err := resolvedExternalSecrets(plan)
+	if err != nil {
+		return nil, err
+	}
+	synced := make(map[string]bool, len(externalSecrets))
+	for _, secret := range externalSecrets {
+		synced[secret.Name] = true
+	}
+
+	referenced := make(map[string]bool)
+	for containerName, container := range resolvedValues.Containers {
+		for key, value := range container.Env {
+			fields, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			ref, err := secretKeyRef(fields)
+			if err != nil {
+				return nil, fmt.Errorf("env %s of container %s: %w", key, containerName, err)
+			}
+			if !synced[ref.Name] {
+				referenced[ref.Name] = true
+			}
+		}
+	}
+
+	names := make([]string, 0, len(referenced))
+	for name := range referenced {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
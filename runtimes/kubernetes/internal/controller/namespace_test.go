@@ -0,0 +1,188 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// environmentPlan returns a plan of the "web" Workload in team-a materialized into team-a-staging
+func environmentPlan(resolvedValues string) *scorev1b1.WorkloadPlan {
+	return &scorev1b1.WorkloadPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a", UID: "plan-uid"},
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef:    scorev1b1.WorkloadPlanWorkloadRef{Name: "web", Namespace: "team-a"},
+			RuntimeClass:   kubernetesRuntimeClass,
+			Namespace:      "team-a-staging",
+			ResolvedValues: &runtime.RawExtension{Raw: []byte(resolvedValues)},
+		},
+	}
+}
+
+// planScheme returns a scheme with the Kubernetes and score.dev types, needed to set plans as owners
+func planScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+// applyAsCreateOrUpdate emulates server-side apply, which the fake client does not support,
+// by creating the applied object or replacing the existing one. Other patches pass through.
+func applyAsCreateOrUpdate(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if patch.Type() != types.ApplyPatchType {
+		return c.Patch(ctx, obj, patch, opts...)
+	}
+
+	existing, ok := obj.DeepCopyObject().(client.Object)
+	if !ok {
+		return fmt.Errorf("unexpected object type %T", obj)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(obj), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return err
+		}
+		return c.Create(ctx, obj)
+	}
+	obj.SetResourceVersion(existing.GetResourceVersion())
+	return c.Update(ctx, obj)
+}
+
+// applyingClientBuilder returns a fake client builder that emulates server-side apply
+func applyingClientBuilder(scheme *runtime.Scheme) *fake.ClientBuilder {
+	return fake.NewClientBuilder().WithScheme(scheme).
+		WithInterceptorFuncs(interceptor.Funcs{Patch: applyAsCreateOrUpdate})
+}
+
+func TestSetOwnerAcrossNamespaces(t *testing.T) {
+	r := &KubernetesRuntimePlanReconciler{Scheme: planScheme(t)}
+	plan := environmentPlan(`{}`)
+
+	local := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}}
+	if err := r.setOwner(plan, local); err != nil {
+		t.Fatalf("setOwner() error = %v", err)
+	}
+	if len(local.OwnerReferences) != 1 || local.Labels[labelPlanNamespace] != "" {
+		t.Errorf("Deployment in the plan namespace: owners %v, labels %v, want an owner reference", local.OwnerReferences, local.Labels)
+	}
+
+	remote := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a-staging"}}
+	if err := r.setOwner(plan, remote); err != nil {
+		t.Fatalf("setOwner() error = %v", err)
+	}
+	if len(remote.OwnerReferences) != 0 || remote.Labels[labelPlanNamespace] != "team-a" {
+		t.Errorf("Deployment in the environment namespace: owners %v, labels %v, want the plan namespace label", remote.OwnerReferences, remote.Labels)
+	}
+
+	remote.Labels["score.dev/workload"] = "web"
+	remote.Labels["score.dev/runtime"] = kubernetesRuntimeClass
+	requests := planForMaterialized(context.Background(), remote)
+	if len(requests) != 1 || requests[0].NamespacedName != (types.NamespacedName{Namespace: "team-a", Name: "web"}) {
+		t.Errorf("planForMaterialized() = %v, want team-a/web", requests)
+	}
+	if requests := planForMaterialized(context.Background(), local); len(requests) != 0 {
+		t.Errorf("planForMaterialized() = %v, want no request for owned resources", requests)
+	}
+}
+
+func TestMirroredSecretNames(t *testing.T) {
+	plan := environmentPlan(`{
+		"containers": {
+			"app": {"env": {
+				"DB_PASSWORD": {"secretKeyRef": {"name": "db-outputs", "key": "password"}},
+				"DB_USER": {"secretKeyRef": {"name": "db-outputs", "key": "username"}},
+				"API_TOKEN": {"secretKeyRef": {"name": "api-credentials", "key": "token"}},
+				"LOG_LEVEL": "info"
+			}},
+			"sidecar": {"env": {"CACHE_PASSWORD": {"secretKeyRef": {"name": "cache-outputs", "key": "password"}}}}
+		},
+		"externalSecrets": [{"name": "api-credentials", "store": "vault", "path": "claims/api", "keys": ["token"]}]
+	}`)
+
+	names, err := mirroredSecretNames(plan)
+	if err != nil {
+		t.Fatalf("mirroredSecretNames() error = %v", err)
+	}
+	if len(names) != 2 || names[0] != "cache-outputs" || names[1] != "db-outputs" {
+		t.Errorf("mirroredSecretNames() = %v, want the claim Secrets not synced by an ExternalSecret", names)
+	}
+}
+
+func TestReconcileSecretMirrors(t *testing.T) {
+	ctx := context.Background()
+	plan := environmentPlan(`{"containers":{"app":{"env":{"DB_PASSWORD":{"secretKeyRef":{"name":"db-outputs","key":"password"}}}}}}`)
+	workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}}
+
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-outputs", Namespace: "team-a"},
+		Type:       corev1.SecretTypeOpaque,
+		Data:       map[string][]byte{"password": []byte("s3cret")},
+	}
+	stale := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "old-outputs", Namespace: "team-a-staging", Labels: map[string]string{
+		"score.dev/runtime": kubernetesRuntimeClass, "score.dev/workload": "web", labelMirroredSecret: "true", labelPlanNamespace: "team-a",
+	}}}
+	c := applyingClientBuilder(planScheme(t)).WithObjects(source, stale).Build()
+	r := &KubernetesRuntimePlanReconciler{Client: c, Scheme: c.Scheme()}
+
+	if err := r.reconcileSecretMirrors(ctx, plan, workload); err != nil {
+		t.Fatalf("reconcileSecretMirrors() error = %v", err)
+	}
+
+	mirror := &corev1.Secret{}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "team-a-staging", Name: "db-outputs"}, mirror); err != nil {
+		t.Fatalf("Secret was not copied: %v", err)
+	}
+	if string(mirror.Data["password"]) != "s3cret" || mirror.Labels[labelPlanNamespace] != "team-a" {
+		t.Errorf("copy = %v with labels %v, want the source data labeled with the plan namespace", mirror.Data, mirror.Labels)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(stale), &corev1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Errorf("copy the plan no longer references still exists: %v", err)
+	}
+
+	// Teardown deletes every copy made for the plan
+	if err := r.deleteSecretMirrors(ctx, plan, nil); err != nil {
+		t.Fatalf("deleteSecretMirrors() error = %v", err)
+	}
+	if err := c.Get(ctx, types.NamespacedName{Namespace: "team-a-staging", Name: "db-outputs"}, &corev1.Secret{}); !apierrors.IsNotFound(err) {
+		t.Errorf("copy still exists after teardown: %v", err)
+	}
+}
+
+func TestReconcileSecretMirrorsRefusesAdoption(t *testing.T) {
+	plan := environmentPlan(`{"containers":{"app":{"env":{"DB_PASSWORD":{"secretKeyRef":{"name":"db-outputs","key":"password"}}}}}}`)
+	workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}}
+
+	source := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "db-outputs", Namespace: "team-a"}}
+	foreign := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-outputs", Namespace: "team-a-staging"},
+		Data:       map[string][]byte{"password": []byte("theirs")},
+	}
+	c := fake.NewClientBuilder().WithObjects(source, foreign).Build()
+	r := &KubernetesRuntimePlanReconciler{Client: c, Scheme: c.Scheme()}
+
+	if err := r.reconcileSecretMirrors(context.Background(), plan, workload); err == nil {
+		t.Fatal("reconcileSecretMirrors() should refuse to overwrite a Secret the runtime did not create")
+	}
+	got := &corev1.Secret{}
+	if err := c.Get(context.Background(), client.ObjectKeyFromObject(foreign), got); err != nil || string(got.Data["password"]) != "theirs" {
+		t.Errorf("existing Secret was modified: %v, err = %v", got.Data, err)
+	}
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+)
+
+// errRuntimeConflict is returned by checkConflicts when an object of a materialized name exists but was not
+// created by the runtime for the plan, e.g. a Deployment created by hand before the Workload
+var errRuntimeConflict = errors.New("runtime resource conflict")
+
+// materializedName returns the name of the workload resources materialized for the plan, following the
+// naming strategy of the plan. Labels and selectors keep the Workload name.
+func materializedName(plan *scorev1b1.WorkloadPlan) string {
+	return reconcile.MaterializedName(plan.Spec)
+}
+
+// conflictCandidates returns the workload resources of the plan kind, and the Services, whose names may be
+// taken by objects the runtime did not create
+func conflictCandidates(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload, kind string) []client.Object {
+	var objs []client.Object
+	switch kind {
+	case kindStatefulSet:
+		objs = append(objs, &appsv1.StatefulSet{}, headlessServiceRef(plan))
+	case kindJob:
+		objs = append(objs, &batchv1.Job{})
+	case kindCronJob:
+		objs = append(objs, &batchv1.CronJob{})
+	default:
+		objs = append(objs, &appsv1.Deployment{})
+	}
+	if workload.Spec.Service != nil && len(workload.Spec.Service.Ports) > 0 {
+		objs = append(objs, &corev1.Service{})
+	}
+	return objs
+}
+
+// checkConflicts returns an error wrapping errRuntimeConflict when one of the objects exists but was not
+// materialized for the plan. Under the Adopt conflict policy, an object without a controller is taken over
+// by the next apply instead. Objects are looked up by the materialized name unless they carry a name.
+func (r *KubernetesRuntimePlanReconciler) checkConflicts(ctx context.Context, plan *scorev1b1.WorkloadPlan, objs ...client.Object) error {
+	adopt := plan.Spec.Naming != nil && plan.Spec.Naming.ConflictPolicy == scorev1b1.ConflictPolicyAdopt
+	for _, obj := range objs {
+		key := types.NamespacedName{
+			Namespace: materializedNamespace(plan),
+			Name:      materializedName(plan),
+		}
+		if obj.GetName() != "" {
+			key.Name = obj.GetName()
+		}
+		if err := r.Get(ctx, key, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get %T %s: %w", obj, key, err)
+		}
+		if isMaterializedFor(obj, plan) || metav1.IsControlledBy(obj, plan) {
+			continue
+		}
+
+		kind := fmt.Sprintf("%T", obj)
+		if gvk, err := apiutil.GVKForObject(obj, r.Scheme); err == nil {
+			kind = gvk.Kind
+		}
+		if adopt && metav1.GetControllerOf(obj) == nil {
+			log.FromContext(ctx).Info("Adopting existing runtime resource", "kind", kind, "name", key.Name)
+			continue
+		}
+		return fmt.Errorf("%w: %s %s exists and was not created for Workload %s",
+			errRuntimeConflict, kind, key, plan.Spec.WorkloadRef.Name)
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+func namingScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return scheme
+}
+
+func TestMaterializedNameAppliesNaming(t *testing.T) {
+	plan := &scorev1b1.WorkloadPlan{Spec: scorev1b1.WorkloadPlanSpec{
+		WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+		Naming:      &scorev1b1.NamingSpec{Prefix: "score-", Suffix: "-svc"},
+	}}
+	workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+
+	objectMeta := runtimeObjectMeta(plan, workload)
+	if objectMeta.Name != "score-app-svc" {
+		t.Errorf("runtimeObjectMeta() name = %q, want score-app-svc", objectMeta.Name)
+	}
+	if objectMeta.Labels[meta.LabelWorkload] != "app" {
+		t.Errorf("runtimeObjectMeta() workload label = %q, want the Workload name", objectMeta.Labels[meta.LabelWorkload])
+	}
+	if name := headlessServiceRef(plan).Name; name != "score-app-svc-headless" {
+		t.Errorf("headlessServiceRef() name = %q, want score-app-svc-headless", name)
+	}
+}
+
+func TestCheckConflicts(t *testing.T) {
+	plan := &scorev1b1.WorkloadPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", UID: "plan-uid"},
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+		},
+	}
+	foreign := func(owner *metav1.OwnerReference) *appsv1.Deployment {
+		deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"}}
+		if owner != nil {
+			deployment.OwnerReferences = []metav1.OwnerReference{*owner}
+		}
+		return deployment
+	}
+	otherController := &metav1.OwnerReference{APIVersion: "apps/v1", Kind: "ReplicaSet", Name: "other", UID: "other-uid", Controller: ptr.To(true)}
+
+	tests := []struct {
+		name         string
+		existing     client.Object
+		naming       *scorev1b1.NamingSpec
+		wantConflict bool
+	}{
+		{name: "no existing object"},
+		{
+			name: "materialized for the plan",
+			existing: &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default",
+				Labels: map[string]string{"score.dev/runtime": "kubernetes", meta.LabelWorkload: "app"}}},
+		},
+		{name: "foreign object", existing: foreign(nil), wantConflict: true},
+		{name: "foreign object adopted", existing: foreign(nil), naming: &scorev1b1.NamingSpec{ConflictPolicy: scorev1b1.ConflictPolicyAdopt}},
+		{
+			name:         "foreign object with another controller",
+			existing:     foreign(otherController),
+			naming:       &scorev1b1.NamingSpec{ConflictPolicy: scorev1b1.ConflictPolicyAdopt},
+			wantConflict: true,
+		},
+		{name: "foreign object avoided by a prefix", existing: foreign(nil), naming: &scorev1b1.NamingSpec{Prefix: "score-"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := namingScheme(t)
+			builder := fake.NewClientBuilder().WithScheme(scheme)
+			if tt.existing != nil {
+				builder = builder.WithObjects(tt.existing)
+			}
+			r := &KubernetesRuntimePlanReconciler{Client: builder.Build(), Scheme: scheme}
+
+			plan := plan.DeepCopy()
+			plan.Spec.Naming = tt.naming
+			err := r.checkConflicts(context.Background(), plan, &appsv1.Deployment{})
+			if got := errors.Is(err, errRuntimeConflict); got != tt.wantConflict {
+				t.Errorf("checkConflicts() error = %v, want conflict %v", err, tt.wantConflict)
+			}
+			if !tt.wantConflict && err != nil {
+				t.Errorf("checkConflicts() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestReconcileReportsRuntimeConflict(t *testing.T) {
+	ctx := context.Background()
+	scheme := namingScheme(t)
+
+	key := types.NamespacedName{Name: "app", Namespace: "default"}
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx:1"}},
+			Service:    &scorev1b1.ServiceSpec{Ports: []scorev1b1.ServicePort{{Port: 80}}},
+		},
+	}
+	plan := &scorev1b1.WorkloadPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Generation: 1, Finalizers: []string{kubernetesRuntimeFinalizer}},
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef:  scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+			RuntimeClass: kubernetesRuntimeClass,
+		},
+	}
+	service := &corev1.Service{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Labels: map[string]string{"team": "legacy"}},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "legacy"}},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(workload, plan, service).
+		WithStatusSubresource(&scorev1b1.WorkloadPlan{}).
+		Build()
+	recorder := record.NewFakeRecorder(20)
+	r := &KubernetesRuntimePlanReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+
+	result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	if result.RequeueAfter == 0 {
+		t.Error("Reconcile() did not requeue the conflicting plan")
+	}
+
+	if err := c.Get(ctx, key, plan); err != nil {
+		t.Fatal(err)
+	}
+	ready := apimeta.FindStatusCondition(plan.Status.Conditions, conditionReady)
+	if plan.Status.Phase != scorev1b1.WorkloadPlanPhaseFailed || ready == nil || ready.Reason != meta.PlanReasonRuntimeConflict {
+		t.Errorf("plan status = %s %v, want Failed with reason %s", plan.Status.Phase, ready, meta.PlanReasonRuntimeConflict)
+	}
+
+	current := &corev1.Service{}
+	if err := c.Get(ctx, key, current); err != nil {
+		t.Fatal(err)
+	}
+	if current.Spec.Selector["app"] != "legacy" || len(current.OwnerReferences) != 0 {
+		t.Errorf("conflicting Service was modified: %+v", current)
+	}
+	if err := c.Get(ctx, key, &appsv1.Deployment{}); err == nil {
+		t.Error("Deployment was created next to the conflicting Service")
+	}
+}
@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/networkpolicy"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+)
+
+// reconcileNetworkPolicy applies the NetworkPolicy denying ingress to the Workload pods except from the pods of
+// the Workload itself and the peers of WorkloadPlan.spec.networkPolicy, and deletes it when the plan carries none.
+// An existing NetworkPolicy of the same name without the runtime labels is never adopted.
+func (r *KubernetesRuntimePlanReconciler) reconcileNetworkPolicy(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) error {
+	if plan.Spec.NetworkPolicy == nil {
+		return r.deleteMaterialized(ctx, plan, &networkingv1.NetworkPolicy{})
+	}
+
+	policy := buildNetworkPolicy(plan, workload)
+	existing := &networkingv1.NetworkPolicy{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(policy), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get network policy: %w", err)
+		}
+	} else if !isMaterializedFor(existing, plan) {
+		return fmt.Errorf("network policy %s already exists and is not managed by the runtime", policy.Name)
+	}
+
+	if err := r.setOwner(plan, policy); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	if err := reconcile.Apply(ctx, r.Client, policy, meta.FieldManagerRuntimeKubernetes); err != nil {
+		return fmt.Errorf("failed to apply network policy: %w", err)
+	}
+	log.FromContext(ctx).V(1).Info("Applied NetworkPolicy", "name", policy.Name)
+	return nil
+}
+
+// buildNetworkPolicy constructs the NetworkPolicy protecting the pods materialized for the plan
+func buildNetworkPolicy(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) *networkingv1.NetworkPolicy {
+	name := plan.Spec.WorkloadRef.Name
+	objectMeta := runtimeObjectMeta(plan, workload)
+	from := append(networkpolicy.WorkloadPeers(name, plan.Spec.WorkloadRef.Namespace), networkpolicy.Peers(plan.Spec.NetworkPolicy)...)
+	policy := networkpolicy.Ingress(objectMeta.Name, objectMeta.Namespace, objectMeta.Labels, map[string]string{
+		networkpolicy.LabelWorkload: name,
+		"score.dev/runtime":         kubernetesRuntimeClass,
+	}, from)
+	policy.Annotations = objectMeta.Annotations
+	return policy
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestReconcileNetworkPolicy(t *testing.T) {
+	ctx := context.Background()
+	plan := environmentPlan(`{}`)
+	plan.Spec.Namespace = ""
+	plan.Spec.NetworkPolicy = &scorev1b1.IngressPolicySpec{AllowFrom: []scorev1b1.NetworkPolicyPeerSpec{
+		{NamespaceLabels: map[string]string{"kubernetes.io/metadata.name": "ingress-nginx"}},
+	}}
+	workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}}
+	c := applyingClientBuilder(planScheme(t)).Build()
+	r := &KubernetesRuntimePlanReconciler{Client: c, Scheme: c.Scheme()}
+
+	if err := r.reconcileNetworkPolicy(ctx, plan, workload); err != nil {
+		t.Fatalf("reconcileNetworkPolicy() error = %v", err)
+	}
+	policy := &networkingv1.NetworkPolicy{}
+	key := types.NamespacedName{Namespace: "team-a", Name: "web"}
+	if err := c.Get(ctx, key, policy); err != nil {
+		t.Fatalf("network policy was not created: %v", err)
+	}
+	if policy.Spec.PodSelector.MatchLabels["score.dev/workload"] != "web" {
+		t.Errorf("pod selector = %v, want the pods of the Workload", policy.Spec.PodSelector.MatchLabels)
+	}
+	if len(policy.Spec.Ingress) != 1 || len(policy.Spec.Ingress[0].From) != 3 {
+		t.Errorf("ingress = %+v, want the Workload pods and the configured peer", policy.Spec.Ingress)
+	}
+	if len(policy.OwnerReferences) != 1 {
+		t.Errorf("owner references = %v, want the plan", policy.OwnerReferences)
+	}
+
+	// Dropping the policy from the plan deletes it
+	plan.Spec.NetworkPolicy = nil
+	if err := r.reconcileNetworkPolicy(ctx, plan, workload); err != nil {
+		t.Fatalf("reconcileNetworkPolicy() error = %v", err)
+	}
+	if err := c.Get(ctx, key, policy); !apierrors.IsNotFound(err) {
+		t.Errorf("network policy still exists: %v", err)
+	}
+}
+
+func TestReconcileNetworkPolicyRefusesAdoption(t *testing.T) {
+	plan := environmentPlan(`{}`)
+	plan.Spec.Namespace = ""
+	plan.Spec.NetworkPolicy = &scorev1b1.IngressPolicySpec{}
+	workload := &scorev1b1.Workload{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}}
+	foreign := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "team-a"}}
+	c := fake.NewClientBuilder().WithScheme(planScheme(t)).WithObjects(foreign).Build()
+	r := &KubernetesRuntimePlanReconciler{Client: c, Scheme: c.Scheme()}
+
+	if err := r.reconcileNetworkPolicy(context.Background(), plan, workload); err == nil {
+		t.Fatal("reconcileNetworkPolicy() should refuse to overwrite a NetworkPolicy the runtime did not create")
+	}
+}
@@ -2,31 +2,59 @@ package controller
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"strings"
+	"sort"
 	"time"
 
 	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
-	"k8s.io/apimachinery/pkg/api/equality"
+	networkingv1 "k8s.io/api/networking/v1"
+	policyv1 "k8s.io/api/policy/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/backoff"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/planvalues"
+	"github.com/cappyzawa/score-orchestrator/internal/propagation"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+	"github.com/cappyzawa/score-orchestrator/internal/tracing"
+	"github.com/cappyzawa/score-orchestrator/pkg/resolvedvalues"
 )
 
 const (
 	kubernetesRuntimeClass = "kubernetes"
+
+	// kubernetesRuntimeFinalizer keeps a WorkloadPlan around until the resources materialized for it are removed
+	kubernetesRuntimeFinalizer = "runtime.score.dev/kubernetes"
+
+	// annotationValuesHash carries the hash of the resolved values on the pod template, so that
+	// changed claim outputs (e.g., a rotated password) roll out new pods
+	annotationValuesHash = "score.dev/values-hash"
+
+	// conditionReady is the WorkloadPlan condition whose transition time marks the start of a rollout
+	conditionReady = meta.PlanConditionReady
 )
 
 // KubernetesRuntimePlanReconciler reconciles WorkloadPlan resources and materializes Kubernetes resources
@@ -34,16 +62,29 @@ type KubernetesRuntimePlanReconciler struct {
 	client.Client
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+
+	// MaxConcurrentReconciles is the number of WorkloadPlans reconciled in parallel (default 1)
+	MaxConcurrentReconciles int
+
+	// Backoff computes the delays of plans requeued while waiting for their Workload
+	Backoff *backoff.Backoff
 }
 
 // +kubebuilder:rbac:groups=score.dev,resources=workloadplans,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=score.dev,resources=workloadplans/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=score.dev,resources=workloadplans/finalizers,verbs=update
 // +kubebuilder:rbac:groups=score.dev,resources=workloads,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
-// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
-// +kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=apps,resources=deployments;statefulsets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=batch,resources=jobs;cronjobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=limitranges,verbs=get;list;watch
+// +kubebuilder:rbac:groups=policy,resources=poddisruptionbudgets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=scheduling.k8s.io,resources=priorityclasses,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=external-secrets.io,resources=externalsecrets,verbs=get;list;watch;create;update;patch;delete
+// ServiceAccount permissions are granted by manifests/rbac.yaml only, so the Orchestrator role does not gain them.
 
 // Reconcile handles WorkloadPlan changes and materializes Kubernetes resources
 func (r *KubernetesRuntimePlanReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -59,43 +100,212 @@ func (r *KubernetesRuntimePlanReconciler) Reconcile(ctx context.Context, req ctr
 		return ctrl.Result{}, err
 	}
 
-	// Skip non-kubernetes runtime classes
-	if plan.Spec.RuntimeClass != kubernetesRuntimeClass {
-		logger.V(1).Info("Skipping WorkloadPlan with non-kubernetes runtime class",
-			"runtimeClass", plan.Spec.RuntimeClass)
-		return ctrl.Result{}, nil
+	// Tear down materialized resources when the plan is deleted or moved to another runtime class or cluster
+	if !plan.DeletionTimestamp.IsZero() || plan.Spec.RuntimeClass != kubernetesRuntimeClass || plan.Spec.Target != "" {
+		if !controllerutil.ContainsFinalizer(plan, kubernetesRuntimeFinalizer) {
+			logger.V(1).Info("Skipping WorkloadPlan not materialized by the Kubernetes runtime",
+				"runtimeClass", plan.Spec.RuntimeClass, "target", plan.Spec.Target)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, r.teardown(ctx, plan)
+	}
+
+	if !controllerutil.ContainsFinalizer(plan, kubernetesRuntimeFinalizer) {
+		controllerutil.AddFinalizer(plan, kubernetesRuntimeFinalizer)
+		if err := r.Update(ctx, plan); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
+		}
 	}
 
 	logger.Info("Reconciling WorkloadPlan for Kubernetes runtime",
 		"workloadPlan", req.NamespacedName,
 		"runtimeClass", plan.Spec.RuntimeClass)
 
+	// Continue the trace started by the orchestrator when the plan was written
+	ctx, span := tracing.StartSpan(tracing.ExtractFromAnnotations(ctx, plan), "KubernetesRuntime.ApplyPlan")
+	defer span.End()
+
 	// Get referenced Workload for metadata and spec
 	workload, err := r.getWorkload(ctx, plan)
 	if err != nil {
 		logger.Error(err, "Failed to get referenced Workload")
+		tracing.RecordError(span, err)
 		r.Recorder.Event(plan, corev1.EventTypeWarning, "WorkloadNotFound", err.Error())
-		return ctrl.Result{RequeueAfter: time.Minute}, nil
+		return r.Backoff.Result(req.NamespacedName, backoff.ClassWaiting), nil
+	}
+	span.SetAttributes(tracing.WorkloadAttributes(workload)...)
+
+	// Values too large to be embedded in the plan are read from the ConfigMap it references
+	if err := planvalues.Inline(ctx, r.Client, plan); err != nil {
+		logger.Error(err, "Failed to load resolved values")
+		tracing.RecordError(span, err)
+		r.Recorder.Event(plan, corev1.EventTypeWarning, "ValuesUnavailable", err.Error())
+		return r.Backoff.Error(err)
+	}
+
+	// Objects of the materialized names that the runtime did not create are left untouched
+	kind := materializedKind(plan, workload)
+	if err := r.checkConflicts(ctx, plan, conflictCandidates(plan, workload, kind)...); err != nil {
+		logger.Error(err, "Runtime resources conflict with existing objects")
+		tracing.RecordError(span, err)
+		if errors.Is(err, errRuntimeConflict) {
+			return r.reportFailure(ctx, plan, meta.PlanReasonRuntimeConflict, err)
+		}
+		return r.Backoff.Error(err)
+	}
+
+	// Pods the API server would reject are not created; the plan fails with the violated constraints instead
+	containers, err := r.buildContainers(ctx, plan, workload)
+	if err != nil {
+		logger.Error(err, "Failed to build containers")
+		tracing.RecordError(span, err)
+		return r.Backoff.Error(err)
+	}
+	if err := r.checkLimitRanges(ctx, plan, containers); err != nil {
+		logger.Error(err, "Runtime resources violate the LimitRanges of the namespace")
+		tracing.RecordError(span, err)
+		if errors.Is(err, errLimitRangeViolation) {
+			return r.reportFailure(ctx, plan, meta.PlanReasonLimitRangeViolation, err)
+		}
+		return r.Backoff.Error(err)
+	}
+
+	// The PriorityClass must exist before pods referencing it can be admitted
+	if err := r.reconcilePriorityClass(ctx, plan); err != nil {
+		logger.Error(err, "Failed to reconcile PriorityClass")
+		tracing.RecordError(span, err)
+		if errors.Is(err, errPriorityClassNotFound) {
+			return r.reportFailure(ctx, plan, meta.PlanReasonPriorityClassNotFound, err)
+		}
+		r.Recorder.Event(plan, corev1.EventTypeWarning, "PriorityClassFailed", err.Error())
+		return r.Backoff.Error(err)
+	}
+
+	// The ServiceAccount must exist before pods referencing it can be created
+	if err := r.reconcileServiceAccount(ctx, plan, workload); err != nil {
+		logger.Error(err, "Failed to reconcile ServiceAccount")
+		tracing.RecordError(span, err)
+		r.Recorder.Event(plan, corev1.EventTypeWarning, "ServiceAccountFailed", err.Error())
+		return r.Backoff.Error(err)
+	}
+
+	// Ingress to the Workload pods is restricted before they start
+	if err := r.reconcileNetworkPolicy(ctx, plan, workload); err != nil {
+		logger.Error(err, "Failed to reconcile NetworkPolicy")
+		tracing.RecordError(span, err)
+		r.Recorder.Event(plan, corev1.EventTypeWarning, "NetworkPolicyFailed", err.Error())
+		return r.Backoff.Error(err)
 	}
 
-	// Build and apply Kubernetes resources
-	if err := r.reconcileDeployment(ctx, plan, workload); err != nil {
-		logger.Error(err, "Failed to reconcile Deployment")
-		r.Recorder.Event(plan, corev1.EventTypeWarning, "DeploymentFailed", err.Error())
-		return ctrl.Result{RequeueAfter: time.Minute}, err
+	// Inline files are mounted from a ConfigMap and Secret that must exist before the pods
+	if err := r.reconcileFiles(ctx, plan, workload); err != nil {
+		logger.Error(err, "Failed to reconcile inline files")
+		tracing.RecordError(span, err)
+		r.Recorder.Event(plan, corev1.EventTypeWarning, "FilesFailed", err.Error())
+		return r.Backoff.Error(err)
+	}
+
+	// Pods in an environment namespace reference copies of the claim Secrets of the Workload namespace
+	if err := r.reconcileSecretMirrors(ctx, plan, workload); err != nil {
+		logger.Error(err, "Failed to reconcile Secret copies")
+		tracing.RecordError(span, err)
+		r.Recorder.Event(plan, corev1.EventTypeWarning, "SecretCopyFailed", err.Error())
+		return r.Backoff.Error(err)
+	}
+
+	// Credentials held by external secret stores are synced into Secrets the containers reference
+	if err := r.reconcileExternalSecrets(ctx, plan, workload); err != nil {
+		logger.Error(err, "Failed to reconcile external secrets")
+		tracing.RecordError(span, err)
+		r.Recorder.Event(plan, corev1.EventTypeWarning, "ExternalSecretsFailed", err.Error())
+		return r.Backoff.Error(err)
+	}
+
+	// Build and apply Kubernetes resources for the plan kind
+	var pauseIn time.Duration
+	switch kind {
+	case kindStatefulSet:
+		if err := r.reconcileStatefulSet(ctx, plan, workload); err != nil {
+			logger.Error(err, "Failed to reconcile StatefulSet")
+			tracing.RecordError(span, err)
+			r.Recorder.Event(plan, corev1.EventTypeWarning, "StatefulSetFailed", err.Error())
+			return r.Backoff.Error(err)
+		}
+	case kindJob:
+		replaced, err := r.reconcileJob(ctx, plan, workload)
+		if err != nil {
+			logger.Error(err, "Failed to reconcile Job")
+			tracing.RecordError(span, err)
+			r.Recorder.Event(plan, corev1.EventTypeWarning, "JobFailed", err.Error())
+			return r.Backoff.Error(err)
+		}
+		if replaced {
+			// The deletion of the outdated Job triggers the reconcile that creates the new one
+			plan.Status.Phase = scorev1b1.WorkloadPlanPhaseProvisioning
+			plan.Status.Message = "Runtime job is being replaced"
+			plan.Status.ObservedGeneration = plan.Generation
+			return ctrl.Result{}, r.Status().Update(ctx, plan)
+		}
+	case kindCronJob:
+		if err := r.reconcileCronJob(ctx, plan, workload); err != nil {
+			logger.Error(err, "Failed to reconcile CronJob")
+			tracing.RecordError(span, err)
+			r.Recorder.Event(plan, corev1.EventTypeWarning, "CronJobFailed", err.Error())
+			return r.Backoff.Error(err)
+		}
+	default:
+		if pauseIn, err = r.reconcileDeployment(ctx, plan, workload); err != nil {
+			logger.Error(err, "Failed to reconcile Deployment")
+			tracing.RecordError(span, err)
+			r.Recorder.Event(plan, corev1.EventTypeWarning, "DeploymentFailed", err.Error())
+			return r.Backoff.Error(err)
+		}
+	}
+
+	// Remove resources left over from a previous kind of the plan
+	stale := staleObjects(kind)
+	if kind != kindStatefulSet {
+		stale = append(stale, headlessServiceRef(plan))
+	}
+	if kind != kindDeployment {
+		plan.Status.Rollout = nil
+	}
+	if err := r.deleteMaterialized(ctx, plan, stale...); err != nil {
+		logger.Error(err, "Failed to delete resources of a previous workload kind")
+		tracing.RecordError(span, err)
+		return r.Backoff.Error(err)
+	}
+
+	if err := r.reconcileDisruptionBudget(ctx, plan, workload, kind); err != nil {
+		logger.Error(err, "Failed to reconcile PodDisruptionBudget")
+		tracing.RecordError(span, err)
+		r.Recorder.Event(plan, corev1.EventTypeWarning, "DisruptionBudgetFailed", err.Error())
+		return r.Backoff.Error(err)
 	}
 
 	if err := r.reconcileService(ctx, plan, workload); err != nil {
 		logger.Error(err, "Failed to reconcile Service")
+		tracing.RecordError(span, err)
 		r.Recorder.Event(plan, corev1.EventTypeWarning, "ServiceFailed", err.Error())
-		return ctrl.Result{RequeueAfter: time.Minute}, err
+		return r.Backoff.Error(err)
+	}
+
+	// The canary of a finished rollout is removed once the Service no longer routes to it
+	if !rolloutInProgress(plan) {
+		if err := r.deleteMaterialized(ctx, plan, canaryDeploymentRef(plan)); err != nil {
+			logger.Error(err, "Failed to delete canary Deployment")
+			tracing.RecordError(span, err)
+			return r.Backoff.Error(err)
+		}
 	}
 
 	// Update WorkloadPlan status based on runtime resource readiness
-	if err := r.updateWorkloadPlanStatus(ctx, plan); err != nil {
+	deadlineIn, err := r.updateWorkloadPlanStatus(ctx, plan, kind)
+	if err != nil {
 		logger.Error(err, "Failed to update WorkloadPlan status")
+		tracing.RecordError(span, err)
 		r.Recorder.Event(plan, corev1.EventTypeWarning, "StatusUpdateFailed", err.Error())
-		return ctrl.Result{RequeueAfter: time.Minute}, err
+		return r.Backoff.Error(err)
 	}
 
 	// Record successful reconciliation
@@ -103,10 +313,126 @@ func (r *KubernetesRuntimePlanReconciler) Reconcile(ctx context.Context, req ctr
 		"Successfully reconciled Kubernetes resources")
 
 	logger.Info("Successfully reconciled WorkloadPlan", "workloadPlan", req.NamespacedName)
-	return ctrl.Result{}, nil
+	r.Backoff.Forget(req.NamespacedName)
+	// Revisit a rollout in progress when its deadline or a rollout pause passes, even if the workload
+	// resources do not change
+	requeueAfter := deadlineIn
+	if pauseIn > 0 && (requeueAfter == 0 || pauseIn < requeueAfter) {
+		requeueAfter = pauseIn
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reportFailure fails the plan with the reason, leaving the runtime resources untouched. The objects causing
+// the failure, such as foreign objects of the materialized names or LimitRanges, are not watched, so the plan
+// is retried until they are removed or changed.
+func (r *KubernetesRuntimePlanReconciler) reportFailure(ctx context.Context, plan *scorev1b1.WorkloadPlan, reason string, failure error) (ctrl.Result, error) {
+	if plan.Status.Phase != scorev1b1.WorkloadPlanPhaseFailed || plan.Status.Message != failure.Error() {
+		r.Recorder.Event(plan, corev1.EventTypeWarning, reason, failure.Error())
+	}
+
+	plan.Status.Phase = scorev1b1.WorkloadPlanPhaseFailed
+	plan.Status.Message = failure.Error()
+	plan.Status.ObservedGeneration = plan.Generation
+	apimeta.SetStatusCondition(&plan.Status.Conditions, metav1.Condition{
+		Type:               conditionReady,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            failure.Error(),
+		ObservedGeneration: plan.Generation,
+	})
+	if err := r.Status().Update(ctx, plan); err != nil {
+		return r.Backoff.Error(fmt.Errorf("failed to update WorkloadPlan status: %w", err))
+	}
+	return r.Backoff.Result(client.ObjectKeyFromObject(plan), backoff.ClassWaiting), nil
+}
+
+// teardown deletes the resources materialized for the plan and releases the finalizer.
+// Resources are matched by runtime labels rather than owner references, so children retained by an
+// orphaning delete are removed as well.
+func (r *KubernetesRuntimePlanReconciler) teardown(ctx context.Context, plan *scorev1b1.WorkloadPlan) error {
+	objs := append(staleObjects(""), canaryDeploymentRef(plan), &corev1.Service{}, headlessServiceRef(plan), &corev1.ConfigMap{}, filesSecretRef(plan), &networkingv1.NetworkPolicy{}, &policyv1.PodDisruptionBudget{})
+	if err := r.deleteMaterialized(ctx, plan, objs...); err != nil {
+		return err
+	}
+	if err := r.deleteServiceAccounts(ctx, plan, ""); err != nil {
+		return err
+	}
+	if err := r.deleteExternalSecrets(ctx, plan, nil); err != nil {
+		return err
+	}
+	if err := r.deleteSecretMirrors(ctx, plan, nil); err != nil {
+		return err
+	}
+
+	r.Recorder.Event(plan, corev1.EventTypeNormal, "ResourcesDeleted",
+		"Deleted Kubernetes resources materialized for the plan")
+
+	controllerutil.RemoveFinalizer(plan, kubernetesRuntimeFinalizer)
+	if err := r.Update(ctx, plan); err != nil {
+		return fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+	return nil
+}
+
+// deleteMaterialized deletes the objects of the given types that were materialized for the plan.
+// Objects are looked up by the materialized name of the Workload unless they already carry a name of their own.
+func (r *KubernetesRuntimePlanReconciler) deleteMaterialized(ctx context.Context, plan *scorev1b1.WorkloadPlan, objs ...client.Object) error {
+	for _, obj := range objs {
+		key := types.NamespacedName{
+			Namespace: materializedNamespace(plan),
+			Name:      materializedName(plan),
+		}
+		if obj.GetName() != "" {
+			key.Name = obj.GetName()
+		}
+		if err := r.Get(ctx, key, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get %T %s: %w", obj, key, err)
+		}
+		if !isMaterializedFor(obj, plan) || !obj.GetDeletionTimestamp().IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete %T %s: %w", obj, key, err)
+		}
+		log.FromContext(ctx).Info("Deleted runtime resource", "kind", fmt.Sprintf("%T", obj), "name", key.Name)
+	}
+	return nil
+}
+
+// staleObjects returns the workload resource types other than the given kind
+func staleObjects(kind string) []client.Object {
+	all := map[string]client.Object{
+		kindDeployment:  &appsv1.Deployment{},
+		kindStatefulSet: &appsv1.StatefulSet{},
+		kindJob:         &batchv1.Job{},
+		kindCronJob:     &batchv1.CronJob{},
+	}
+	objs := make([]client.Object, 0, len(all))
+	for _, k := range []string{kindDeployment, kindStatefulSet, kindJob, kindCronJob} {
+		if k != kind {
+			objs = append(objs, all[k])
+		}
+	}
+	return objs
 }
 
-// getWorkload retrieves the referenced Workload from WorkloadPlan
+// isMaterializedFor reports whether obj was created by this runtime for the plan's Workload.
+// Resources in an environment namespace must also name the namespace of the plan.
+func isMaterializedFor(obj client.Object, plan *scorev1b1.WorkloadPlan) bool {
+	labels := obj.GetLabels()
+	if planNamespace, ok := labels[labelPlanNamespace]; ok && planNamespace != plan.Namespace {
+		return false
+	}
+	return labels["score.dev/runtime"] == kubernetesRuntimeClass &&
+		labels[meta.LabelWorkload] == plan.Spec.WorkloadRef.Name
+}
+
+// getWorkload retrieves the referenced Workload from WorkloadPlan, with the spec snapshot the plan carries, if any.
+// A plan delivered from another cluster has no Workload in this cluster and is materialized from its snapshot alone.
 func (r *KubernetesRuntimePlanReconciler) getWorkload(ctx context.Context, plan *scorev1b1.WorkloadPlan) (*scorev1b1.Workload, error) {
 	workload := &scorev1b1.Workload{}
 	key := types.NamespacedName{
@@ -115,67 +441,103 @@ func (r *KubernetesRuntimePlanReconciler) getWorkload(ctx context.Context, plan
 	}
 
 	if err := r.Get(ctx, key, workload); err != nil {
-		return nil, fmt.Errorf("failed to get workload %s: %w", key, err)
+		if !apierrors.IsNotFound(err) || plan.Spec.WorkloadSnapshot == nil {
+			return nil, fmt.Errorf("failed to get workload %s: %w", key, err)
+		}
+		workload.Name, workload.Namespace = key.Name, key.Namespace
+	}
+
+	// A restored plan, or a plan computed from a spec merged with workload defaults, carries that spec
+	if snapshot := plan.Spec.WorkloadSnapshot; snapshot != nil {
+		workload.Spec = scorev1b1.WorkloadSpec{}
+		if err := json.Unmarshal(snapshot.Raw, &workload.Spec); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal workload snapshot: %w", err)
+		}
+		workload.Generation = plan.Spec.ObservedWorkloadGeneration
 	}
 
 	return workload, nil
 }
 
-// reconcileDeployment creates or updates the Deployment for the WorkloadPlan
-func (r *KubernetesRuntimePlanReconciler) reconcileDeployment(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) error {
+// reconcileDeployment applies the Deployment for the WorkloadPlan with server-side apply, unless the
+// existing Deployment already holds every declared value.
+// When the backend configures a progressive rollout, a changed pod template is first rolled out by a
+// canary Deployment and applied to the stable Deployment only once it is promoted. It returns the time
+// after which a pending rollout pause ends, or zero.
+func (r *KubernetesRuntimePlanReconciler) reconcileDeployment(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) (time.Duration, error) {
 	deployment, err := r.buildDeployment(ctx, plan, workload)
 	if err != nil {
-		return fmt.Errorf("failed to build deployment: %w", err)
+		return 0, fmt.Errorf("failed to build deployment: %w", err)
+	}
+	revision, err := templateRevision(plan, &deployment.Spec.Template)
+	if err != nil {
+		return 0, err
 	}
+	deployment.Annotations[annotationRolloutRevision] = revision
 
-	// Set WorkloadPlan as owner for garbage collection
-	if err := ctrl.SetControllerReference(plan, deployment, r.Scheme); err != nil {
-		return fmt.Errorf("failed to set controller reference: %w", err)
+	strategy, err := rolloutStrategyForPlan(plan)
+	if err != nil {
+		return 0, err
 	}
 
-	// Apply defaults to ensure consistent comparison
-	r.Scheme.Default(deployment)
+	// Set WorkloadPlan as owner for garbage collection
+	if err := r.setOwner(plan, deployment); err != nil {
+		return 0, fmt.Errorf("failed to set controller reference: %w", err)
+	}
 
-	// Create or update the deployment
+	// Leave replicas to an autoscaler (or any other manager) once it has taken them over
 	existing := &appsv1.Deployment{}
-	key := types.NamespacedName{Namespace: deployment.Namespace, Name: deployment.Name}
+	found := true
+	if err := r.Get(ctx, client.ObjectKeyFromObject(deployment), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return 0, fmt.Errorf("failed to get deployment: %w", err)
+		}
+		found = false
+	} else {
+		if replicasManagedByOthers(existing.ManagedFields) {
+			deployment.Spec.Replicas = nil
+		}
+		keepPodTemplateMetadata(&deployment.Spec.Template, &existing.Spec.Template, revision, existing.Annotations[annotationRolloutRevision])
+	}
 
-	if err := r.Get(ctx, key, existing); err != nil {
-		if client.IgnoreNotFound(err) == nil {
-			// Create new deployment
-			if err := r.Create(ctx, deployment); err != nil {
-				return fmt.Errorf("failed to create deployment: %w", err)
-			}
-			log.FromContext(ctx).Info("Created Deployment", "name", deployment.Name)
-		} else {
-			return fmt.Errorf("failed to get deployment: %w", err)
+	var pauseIn time.Duration
+	switch {
+	case strategy == nil:
+		plan.Status.Rollout = nil
+	case !found:
+		// The first revision has nothing to be compared against
+		plan.Status.Rollout = &scorev1b1.RolloutStatus{
+			Strategy: strategy.Strategy,
+			Revision: revision,
+			Phase:    scorev1b1.RolloutPhaseCompleted,
 		}
+	default:
+		var apply bool
+		if apply, pauseIn, err = r.progressRollout(ctx, plan, strategy, deployment, existing); err != nil || !apply {
+			return pauseIn, err
+		}
+	}
+
+	// Skip the patch when nothing declared changed, so defaulted fields never restart pods
+	current, err := upToDate(deployment, existing)
+	if err != nil {
+		return 0, err
+	}
+	if found && current {
+		deployment = existing
 	} else {
-		// Use in-place mutation with MergeFrom patch
-		before := existing.DeepCopy()
-
-		// Only update fields we own - merge labels and annotations instead of replacing
-		existing.Labels = r.mergeOwnedFields(existing.Labels, deployment.Labels, "score.dev/", "app.kubernetes.io/")
-		existing.Annotations = r.mergeOwnedFields(existing.Annotations, deployment.Annotations, "score.dev/")
-		existing.Spec = deployment.Spec
-
-		// Check if update is needed
-		if !equality.Semantic.DeepEqual(before.Spec, existing.Spec) ||
-			!equality.Semantic.DeepEqual(before.Labels, existing.Labels) ||
-			!equality.Semantic.DeepEqual(before.Annotations, existing.Annotations) {
-
-			patch := client.MergeFrom(before)
-			if err := r.Patch(ctx, existing, patch); err != nil {
-				return fmt.Errorf("failed to update deployment: %w", err)
-			}
-			log.FromContext(ctx).Info("Updated Deployment", "name", deployment.Name)
+		if err := reconcile.Apply(ctx, r.Client, deployment, meta.FieldManagerRuntimeKubernetes); err != nil {
+			return 0, fmt.Errorf("failed to apply deployment: %w", err)
 		}
+		log.FromContext(ctx).V(1).Info("Applied Deployment", "name", deployment.Name)
 	}
+	r.completeRollout(plan, deployment)
 
-	return nil
+	return pauseIn, nil
 }
 
-// reconcileService creates or updates the Service for the WorkloadPlan
+// reconcileService applies the Service for the WorkloadPlan with server-side apply.
+// ClusterIP and node ports are not declared, so the values allocated by the API server are kept.
 func (r *KubernetesRuntimePlanReconciler) reconcileService(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) error {
 	// Skip service creation if no service ports are defined
 	if workload.Spec.Service == nil || len(workload.Spec.Service.Ports) == 0 {
@@ -183,103 +545,178 @@ func (r *KubernetesRuntimePlanReconciler) reconcileService(ctx context.Context,
 		return nil
 	}
 
-	service := r.buildService(plan, workload)
+	service, err := r.buildService(plan, workload)
+	if err != nil {
+		return fmt.Errorf("failed to build service: %w", err)
+	}
 
 	// Set WorkloadPlan as owner for garbage collection
-	if err := ctrl.SetControllerReference(plan, service, r.Scheme); err != nil {
+	if err := r.setOwner(plan, service); err != nil {
 		return fmt.Errorf("failed to set controller reference: %w", err)
 	}
 
-	// Apply defaults to ensure consistent comparison
-	r.Scheme.Default(service)
+	if err := reconcile.Apply(ctx, r.Client, service, meta.FieldManagerRuntimeKubernetes); err != nil {
+		return fmt.Errorf("failed to apply service: %w", err)
+	}
+	log.FromContext(ctx).V(1).Info("Applied Service", "name", service.Name)
 
-	// Create or update the service
-	existing := &corev1.Service{}
-	key := types.NamespacedName{Namespace: service.Namespace, Name: service.Name}
+	return nil
+}
 
-	if err := r.Get(ctx, key, existing); err != nil {
-		if client.IgnoreNotFound(err) == nil {
-			// Create new service
-			if err := r.Create(ctx, service); err != nil {
-				return fmt.Errorf("failed to create service: %w", err)
-			}
-			log.FromContext(ctx).Info("Created Service", "name", service.Name)
-		} else {
-			return fmt.Errorf("failed to get service: %w", err)
+// replicasManagedByOthers reports whether a field manager other than the runtime, such as the
+// HorizontalPodAutoscaler or `kubectl scale`, owns spec.replicas
+func replicasManagedByOthers(managedFields []metav1.ManagedFieldsEntry) bool {
+	for _, entry := range managedFields {
+		if entry.Manager == meta.FieldManagerRuntimeKubernetes || entry.FieldsV1 == nil {
+			continue
 		}
-	} else {
-		// Use in-place mutation with MergeFrom patch
-		before := existing.DeepCopy()
-
-		// Preserve ClusterIP as it's immutable
-		service.Spec.ClusterIP = existing.Spec.ClusterIP
-
-		// Only update fields we own - merge labels and annotations instead of replacing
-		existing.Labels = r.mergeOwnedFields(existing.Labels, service.Labels, "score.dev/", "app.kubernetes.io/")
-		existing.Annotations = r.mergeOwnedFields(existing.Annotations, service.Annotations, "score.dev/")
-		existing.Spec = service.Spec
-
-		// Check if update is needed
-		if !equality.Semantic.DeepEqual(before.Spec, existing.Spec) ||
-			!equality.Semantic.DeepEqual(before.Labels, existing.Labels) ||
-			!equality.Semantic.DeepEqual(before.Annotations, existing.Annotations) {
-
-			patch := client.MergeFrom(before)
-			if err := r.Patch(ctx, existing, patch); err != nil {
-				return fmt.Errorf("failed to update service: %w", err)
+		var fields map[string]interface{}
+		if err := json.Unmarshal(entry.FieldsV1.Raw, &fields); err != nil {
+			continue
+		}
+		if spec, ok := fields["f:spec"].(map[string]interface{}); ok {
+			if _, ok := spec["f:replicas"]; ok {
+				return true
 			}
-			log.FromContext(ctx).Info("Updated Service", "name", service.Name)
 		}
 	}
-
-	return nil
+	return false
 }
 
 // buildDeployment constructs a Deployment from WorkloadPlan and Workload
 func (r *KubernetesRuntimePlanReconciler) buildDeployment(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) (*appsv1.Deployment, error) {
 	name := plan.Spec.WorkloadRef.Name
-	namespace := plan.Spec.WorkloadRef.Namespace
 
 	// Default replicas to 1 if not specified
 	replicas := int32(1)
 
+	containers, err := r.buildContainers(ctx, plan, workload)
+	if err != nil {
+		return nil, err
+	}
+	serviceAccount, err := serviceAccountName(plan)
+	if err != nil {
+		return nil, err
+	}
+	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "Deployment",
+		},
+		ObjectMeta: runtimeObjectMeta(plan, workload),
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":     name,
+					"app.kubernetes.io/instance": name,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: podTemplateMeta(plan),
+				Spec: corev1.PodSpec{
+					Containers:         containers,
+					ServiceAccountName: serviceAccount,
+					SecurityContext:    podSecurityContext(plan),
+					PriorityClassName:  priorityClassName(plan),
+				},
+			},
+		},
+	}
+
+	files, err := buildFileProjection(plan, workload)
+	if err != nil {
+		return nil, err
+	}
+	files.applyTo(&deployment.Spec.Template)
+	if err := applySharedVolumes(plan, workload, &deployment.Spec.Template); err != nil {
+		return nil, err
+	}
+
+	return deployment, nil
+}
+
+// runtimeLabels returns the labels identifying resources materialized for the Workload
+func runtimeLabels(name string) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       name,
+		"app.kubernetes.io/instance":   name,
+		"app.kubernetes.io/managed-by": "score-orchestrator",
+		meta.LabelWorkload:             name,
+		"score.dev/runtime":            "kubernetes",
+	}
+}
+
+// runtimeObjectMeta returns the metadata shared by the workload resources materialized for the plan,
+// including the Workload labels and annotations propagated through the plan
+func runtimeObjectMeta(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      materializedName(plan),
+		Namespace: materializedNamespace(plan),
+		Labels:    propagation.Labels(runtimeLabels(plan.Spec.WorkloadRef.Name), plan.Spec.Metadata),
+		Annotations: propagation.Annotations(map[string]string{
+			"score.dev/workload-generation": fmt.Sprintf("%d", workload.Generation),
+			"score.dev/plan-generation":     fmt.Sprintf("%d", plan.Generation),
+		}, plan.Spec.Metadata),
+	}
+}
+
+// podTemplateMeta returns the metadata of the pod template materialized for the plan, including the Workload
+// labels and annotations propagated through the plan
+func podTemplateMeta(plan *scorev1b1.WorkloadPlan) metav1.ObjectMeta {
+	owned := podTemplateOwnedMeta(plan)
+	return metav1.ObjectMeta{
+		Labels:      propagation.Labels(owned.Labels, plan.Spec.Metadata),
+		Annotations: propagation.Annotations(owned.Annotations, plan.Spec.Metadata),
+	}
+}
+
+// resolvedValuesHash returns a short hash of WorkloadPlan.ResolvedValues
+func resolvedValuesHash(plan *scorev1b1.WorkloadPlan) string {
+	hash := sha256.New()
+	if plan.Spec.ResolvedValues != nil {
+		hash.Write(plan.Spec.ResolvedValues.Raw)
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// buildContainers constructs the pod containers from the Workload and the resolved values of the plan
+func (r *KubernetesRuntimePlanReconciler) buildContainers(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) ([]corev1.Container, error) {
+	commands, err := resolvedCommands(plan)
+	if err != nil {
+		return nil, err
+	}
+	values, err := resolvedvalues.FromPlan(plan)
+	if err != nil {
+		log.FromContext(ctx).Error(err, "Failed to read resolved values")
+		return nil, err
+	}
+
 	// Build containers from workload spec
 	containers := make([]corev1.Container, 0, len(workload.Spec.Containers))
 	for containerName, containerSpec := range workload.Spec.Containers {
 		container := corev1.Container{
-			Name:  containerName,
-			Image: containerSpec.Image,
+			Name:            containerName,
+			Image:           containerSpec.Image,
+			Lifecycle:       containerLifecycle(containerSpec.Lifecycle),
+			SecurityContext: containerSecurityContext(plan),
 		}
+		applyCommand(&container, containerSpec, commands[containerName])
 
-		// Get resolved environment variables from WorkloadPlan.ResolvedValues
-		if plan.Spec.ResolvedValues != nil {
-			// Convert RawExtension to map[string]interface{}
-			var resolvedValues map[string]interface{}
-			if err := json.Unmarshal(plan.Spec.ResolvedValues.Raw, &resolvedValues); err != nil {
-				log.FromContext(ctx).Error(err, "Failed to unmarshal resolved values")
-				return nil, fmt.Errorf("failed to unmarshal resolved values: %w", err)
-			}
-
-			resolvedEnv, err := r.extractResolvedEnv(resolvedValues, containerName)
-			if err != nil {
-				log.FromContext(ctx).Error(err, "Failed to extract resolved environment variables")
-				return nil, fmt.Errorf("failed to extract resolved environment variables: %w", err)
-			}
-
-			for envName, envValue := range resolvedEnv {
-				container.Env = append(container.Env, corev1.EnvVar{
-					Name:  envName,
-					Value: envValue,
-				})
-			}
-		} else if containerSpec.Variables != nil {
-			// Fallback: use raw values from containerSpec.Variables (no placeholder resolution)
-			for key, value := range containerSpec.Variables {
-				container.Env = append(container.Env, corev1.EnvVar{
-					Name:  key,
-					Value: value,
-				})
-			}
+		// Raw Workload variables are the base; resolved values override them and the platform variables
+		// override both (the Orchestrator rejects Workload variables under the reserved prefix)
+		env := make(map[string]corev1.EnvVar, len(containerSpec.Variables))
+		for key, value := range containerSpec.Variables {
+			env[key] = corev1.EnvVar{Name: key, Value: value}
+		}
+		for key, value := range values.Containers[containerName].Env {
+			env[key] = resolvedEnvVar(key, value)
+		}
+		for _, envVar := range platformEnv(plan, workload) {
+			env[envVar.Name] = envVar
+		}
+		for _, envVar := range env {
+			container.Env = append(container.Env, envVar)
 		}
 
 		// Add resource requirements if specified
@@ -311,76 +748,116 @@ func (r *KubernetesRuntimePlanReconciler) buildDeployment(ctx context.Context, p
 				}
 			}
 		}
+		if err := applyDefaultResources(&container.Resources, plan.Spec.DefaultResources); err != nil {
+			return nil, err
+		}
+
+		// Sort env vars so that the applied configuration is stable across reconciles
+		sort.Slice(container.Env, func(i, j int) bool { return container.Env[i].Name < container.Env[j].Name })
 
 		containers = append(containers, container)
 	}
+	sort.Slice(containers, func(i, j int) bool { return containers[i].Name < containers[j].Name })
 
-	// Build labels with identification
-	labels := map[string]string{
-		"app.kubernetes.io/name":       name,
-		"app.kubernetes.io/instance":   name,
-		"app.kubernetes.io/managed-by": "score-orchestrator",
-		"score.dev/workload":           name,
-		"score.dev/runtime":            "kubernetes",
+	return containers, nil
+}
+
+// applyDefaultResources sets the default requests and limits of the plan for every resource the container
+// declares neither a request nor a limit for, so that a default never conflicts with a declared value
+func applyDefaultResources(resources *corev1.ResourceRequirements, defaults *scorev1b1.ResourceRequirements) error {
+	if defaults == nil {
+		return nil
+	}
+	declared := func(name corev1.ResourceName) bool {
+		_, request := resources.Requests[name]
+		_, limit := resources.Limits[name]
+		return request || limit
 	}
 
-	deployment := &appsv1.Deployment{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
-			Namespace: namespace,
-			Labels:    labels,
-			Annotations: map[string]string{
-				"score.dev/workload-generation": fmt.Sprintf("%d", workload.Generation),
-				"score.dev/plan-generation":     fmt.Sprintf("%d", plan.Generation),
-			},
-		},
-		Spec: appsv1.DeploymentSpec{
-			Replicas: &replicas,
-			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"app.kubernetes.io/name":     name,
-					"app.kubernetes.io/instance": name,
-				},
-			},
-			Template: corev1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: labels,
-				},
-				Spec: corev1.PodSpec{
-					Containers: containers,
-				},
-			},
-		},
+	requests := corev1.ResourceList{}
+	for key, value := range defaults.Requests {
+		if declared(corev1.ResourceName(key)) {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("invalid default request %s value %s: %w", key, value, err)
+		}
+		requests[corev1.ResourceName(key)] = quantity
+	}
+	limits := corev1.ResourceList{}
+	for key, value := range defaults.Limits {
+		if declared(corev1.ResourceName(key)) {
+			continue
+		}
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("invalid default limit %s value %s: %w", key, value, err)
+		}
+		limits[corev1.ResourceName(key)] = quantity
 	}
 
-	return deployment, nil
+	for name, quantity := range requests {
+		if resources.Requests == nil {
+			resources.Requests = corev1.ResourceList{}
+		}
+		resources.Requests[name] = quantity
+	}
+	for name, quantity := range limits {
+		if resources.Limits == nil {
+			resources.Limits = corev1.ResourceList{}
+		}
+		resources.Limits[name] = quantity
+	}
+	return nil
+}
+
+// servicePortName returns the name of the Service port: the declared name, or one generated from the index
+func servicePortName(port scorev1b1.ServicePort, index int) string {
+	if port.Name != "" {
+		return port.Name
+	}
+	return fmt.Sprintf("port-%d", index)
 }
 
 // buildService constructs a Service from WorkloadPlan and Workload
-func (r *KubernetesRuntimePlanReconciler) buildService(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) *corev1.Service {
+func (r *KubernetesRuntimePlanReconciler) buildService(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) (*corev1.Service, error) {
 	name := plan.Spec.WorkloadRef.Name
-	namespace := plan.Spec.WorkloadRef.Namespace
+	namespace := materializedNamespace(plan)
+
+	targetPorts, err := resolvedTargetPorts(plan)
+	if err != nil {
+		return nil, err
+	}
 
 	// Build service ports
 	ports := make([]corev1.ServicePort, 0, len(workload.Spec.Service.Ports))
 	for i, port := range workload.Spec.Service.Ports {
 		servicePort := corev1.ServicePort{
-			Name:     fmt.Sprintf("port-%d", i), // Generate name from index
+			Name:     servicePortName(port, i),
 			Port:     port.Port,
 			Protocol: corev1.ProtocolTCP, // Default to TCP
 		}
 
-		// Set target port - use port number if targetPort is nil
-		if port.TargetPort != nil {
-			servicePort.TargetPort = intstr.FromInt(int(*port.TargetPort))
-		} else {
-			servicePort.TargetPort = intstr.FromInt(int(port.Port))
+		// Prefer the target port resolved in the plan, then the declared one, then the port number
+		switch {
+		case i < len(targetPorts):
+			servicePort.TargetPort = targetPorts[i]
+		case port.TargetPort != nil:
+			servicePort.TargetPort = *port.TargetPort
+		default:
+			servicePort.TargetPort = intstr.FromInt32(port.Port)
 		}
 
 		if port.Protocol != "" {
 			servicePort.Protocol = corev1.Protocol(port.Protocol)
 		}
 
+		// The exposure controller publishes https URLs for ports that terminate TLS
+		if port.TLS {
+			servicePort.AppProtocol = ptr.To(schemeHTTPS)
+		}
+
 		ports = append(ports, servicePort)
 	}
 
@@ -389,90 +866,187 @@ func (r *KubernetesRuntimePlanReconciler) buildService(plan *scorev1b1.WorkloadP
 		"app.kubernetes.io/name":       name,
 		"app.kubernetes.io/instance":   name,
 		"app.kubernetes.io/managed-by": "score-orchestrator",
-		"score.dev/workload":           name,
+		meta.LabelWorkload:             name,
 		"score.dev/runtime":            "kubernetes",
 		"score.dev/exposure":           "true", // Enable exposure mapping
 	}
 
 	service := &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Service",
+		},
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      name,
+			Name:      materializedName(plan),
 			Namespace: namespace,
-			Labels:    labels,
-			Annotations: map[string]string{
+			Labels:    propagation.Labels(labels, plan.Spec.Metadata),
+			Annotations: propagation.Annotations(map[string]string{
 				"score.dev/workload-generation": fmt.Sprintf("%d", workload.Generation),
 				"score.dev/plan-generation":     fmt.Sprintf("%d", plan.Generation),
-			},
+			}, plan.Spec.Metadata),
 		},
 		Spec: corev1.ServiceSpec{
-			Type:  corev1.ServiceTypeClusterIP,
-			Ports: ports,
-			Selector: map[string]string{
-				"app.kubernetes.io/name":     name,
-				"app.kubernetes.io/instance": name,
-			},
+			Type:     serviceTypeForPlan(plan),
+			Ports:    ports,
+			Selector: serviceSelector(plan),
 		},
 	}
 
-	return service
+	return service, nil
 }
 
-// extractResolvedEnv extracts resolved environment variables for a specific container from WorkloadPlan.ResolvedValues
-func (r *KubernetesRuntimePlanReconciler) extractResolvedEnv(resolvedValues map[string]interface{}, containerName string) (map[string]string, error) {
-	result := make(map[string]string)
-
-	// Navigate to containers.<containerName>.env
-	containersRaw, exists := resolvedValues["containers"]
-	if !exists {
-		return result, nil
+// resolvedTargetPorts returns the service target ports resolved in WorkloadPlan.ResolvedValues, in port order.
+// Plans created before target ports were resolved carry none.
+func resolvedTargetPorts(plan *scorev1b1.WorkloadPlan) ([]intstr.IntOrString, error) {
+	values, err := resolvedvalues.FromPlan(plan)
+	if err != nil {
+		return nil, err
 	}
+	return values.TargetPorts(), nil
+}
 
-	containersMap, ok := containersRaw.(map[string]interface{})
-	if !ok {
-		return result, fmt.Errorf("containers field is not a map")
+// serviceTypeForPlan maps the exposure mode of the selected backend to a Service type
+func serviceTypeForPlan(plan *scorev1b1.WorkloadPlan) corev1.ServiceType {
+	if plan.Spec.Exposure != nil && plan.Spec.Exposure.Mode == scorev1b1.ExposureModeNodePort {
+		return corev1.ServiceTypeNodePort
 	}
+	return corev1.ServiceTypeClusterIP
+}
 
-	containerRaw, exists := containersMap[containerName]
-	if !exists {
-		return result, nil
+// resolvedEnvVar returns the variable of a resolved env value. Sensitive outputs, which the Orchestrator passes
+// as Secret key references, are projected from the Secret instead of being written into the pod spec.
+func resolvedEnvVar(name string, value resolvedvalues.EnvValue) corev1.EnvVar {
+	if ref := value.SecretKeyRef; ref != nil {
+		return corev1.EnvVar{Name: name, ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: ref.Name},
+			Key:                  ref.Key,
+		}}}
 	}
+	return corev1.EnvVar{Name: name, Value: value.Value}
+}
 
-	containerMap, ok := containerRaw.(map[string]interface{})
+// secretKeyRef parses a {"secretKeyRef": {"name", "key"}} env value
+func secretKeyRef(value map[string]interface{}) (*corev1.SecretKeySelector, error) {
+	ref, ok := value["secretKeyRef"].(map[string]interface{})
 	if !ok {
-		return result, fmt.Errorf("container %s is not a map", containerName)
+		return nil, fmt.Errorf("value is neither a literal nor a secretKeyRef")
 	}
-
-	envRaw, exists := containerMap["env"]
-	if !exists {
-		return result, nil
+	name, _ := ref["name"].(string)
+	key, _ := ref["key"].(string)
+	if name == "" || key == "" {
+		return nil, fmt.Errorf("secretKeyRef requires name and key")
 	}
+	return &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: name}, Key: key}, nil
+}
 
-	envMap, ok := envRaw.(map[string]interface{})
-	if !ok {
-		return result, fmt.Errorf("env field for container %s is not a map", containerName)
+// updateWorkloadPlanStatus updates the WorkloadPlan status based on runtime resource readiness.
+// It returns the time left before the rollout deadline of the plan, or zero when no deadline applies.
+func (r *KubernetesRuntimePlanReconciler) updateWorkloadPlanStatus(ctx context.Context, plan *scorev1b1.WorkloadPlan, kind string) (time.Duration, error) {
+	previousPhase := plan.Status.Phase
+	key := types.NamespacedName{
+		Name:      materializedName(plan),
+		Namespace: materializedNamespace(plan),
 	}
 
-	// Convert all values to strings
-	for key, value := range envMap {
-		if value == nil {
-			result[key] = ""
+	switch kind {
+	case kindStatefulSet:
+		if err := r.setStatefulSetStatus(ctx, key, plan); err != nil {
+			return 0, err
+		}
+	case kindJob:
+		// Jobs are ready once they completed
+		job := &batchv1.Job{}
+		if err := r.Get(ctx, key, job); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return 0, fmt.Errorf("failed to get job: %w", err)
+			}
+			plan.Status.Phase = scorev1b1.WorkloadPlanPhaseProvisioning
+			plan.Status.Message = "Runtime job is being created"
 		} else {
-			result[key] = fmt.Sprintf("%v", value)
+			plan.Status.Phase, plan.Status.Message = jobPhase(job)
+		}
+	case kindCronJob:
+		// CronJobs are ready once scheduled; individual runs are reported by the CronJob itself
+		cronJob := &batchv1.CronJob{}
+		if err := r.Get(ctx, key, cronJob); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return 0, fmt.Errorf("failed to get cronjob: %w", err)
+			}
+			plan.Status.Phase = scorev1b1.WorkloadPlanPhaseProvisioning
+			plan.Status.Message = "Runtime cronjob is being created"
+		} else {
+			plan.Status.Phase = scorev1b1.WorkloadPlanPhaseReady
+			plan.Status.Message = "Runtime cronjob is scheduled"
+		}
+	default:
+		if rolloutInProgress(plan) {
+			// The Workload becomes ready only once the new revision is fully promoted
+			plan.Status.Phase = scorev1b1.WorkloadPlanPhaseProvisioning
+			plan.Status.Message = rolloutMessage(plan.Status.Rollout)
+		} else if err := r.setDeploymentStatus(ctx, key, plan); err != nil {
+			return 0, err
 		}
 	}
 
-	return result, nil
+	deadlineIn := r.applyRolloutDeadline(plan, kind, previousPhase)
+
+	// Update the status
+	plan.Status.ObservedGeneration = plan.Generation
+	if err := r.Status().Update(ctx, plan); err != nil {
+		return 0, fmt.Errorf("failed to update WorkloadPlan status: %w", err)
+	}
+
+	return deadlineIn, nil
 }
 
-// updateWorkloadPlanStatus updates the WorkloadPlan status based on runtime resource readiness
-func (r *KubernetesRuntimePlanReconciler) updateWorkloadPlanStatus(ctx context.Context, plan *scorev1b1.WorkloadPlan) error {
-	// Check deployment readiness
-	deployment := &appsv1.Deployment{}
-	deploymentKey := types.NamespacedName{
-		Name:      plan.Spec.WorkloadRef.Name,
-		Namespace: plan.Spec.WorkloadRef.Namespace,
+// applyRolloutDeadline fails a Deployment or StatefulSet rollout that has not become ready within the
+// rollout deadline of the plan, and records the Ready condition whose transition time marks the start
+// of the rollout. An updated plan starts a new rollout. It returns the time left before the deadline,
+// or zero when no deadline applies.
+func (r *KubernetesRuntimePlanReconciler) applyRolloutDeadline(plan *scorev1b1.WorkloadPlan, kind string, previousPhase scorev1b1.WorkloadPlanPhase) time.Duration {
+	ready := apimeta.FindStatusCondition(plan.Status.Conditions, conditionReady)
+	if ready != nil && ready.ObservedGeneration != plan.Generation {
+		apimeta.RemoveStatusCondition(&plan.Status.Conditions, conditionReady)
+		ready = nil
+	}
+
+	var deadlineIn time.Duration
+	// Progressive rollouts pause on purpose and are not bounded by the deadline
+	if plan.Spec.RolloutDeadline != nil && plan.Status.Phase == scorev1b1.WorkloadPlanPhaseProvisioning &&
+		(kind == kindDeployment || kind == kindStatefulSet) && !rolloutInProgress(plan) {
+		deadline := plan.Spec.RolloutDeadline.Duration
+		deadlineIn = deadline
+		if ready != nil && ready.Status == metav1.ConditionFalse {
+			deadlineIn = time.Until(ready.LastTransitionTime.Add(deadline))
+		}
+		if deadlineIn <= 0 {
+			deadlineIn = 0
+			plan.Status.Phase = scorev1b1.WorkloadPlanPhaseFailed
+			plan.Status.Message = fmt.Sprintf("Runtime rollout did not complete within %s", deadline)
+			if previousPhase != scorev1b1.WorkloadPlanPhaseFailed {
+				r.Recorder.Event(plan, corev1.EventTypeWarning, "RolloutTimeout", plan.Status.Message)
+			}
+		}
 	}
 
+	status := metav1.ConditionFalse
+	if plan.Status.Phase == scorev1b1.WorkloadPlanPhaseReady {
+		status = metav1.ConditionTrue
+	}
+	apimeta.SetStatusCondition(&plan.Status.Conditions, metav1.Condition{
+		Type:               conditionReady,
+		Status:             status,
+		Reason:             string(plan.Status.Phase),
+		Message:            plan.Status.Message,
+		ObservedGeneration: plan.Generation,
+	})
+
+	return deadlineIn
+}
+
+// setDeploymentStatus derives the plan phase from Deployment readiness
+func (r *KubernetesRuntimePlanReconciler) setDeploymentStatus(ctx context.Context, deploymentKey types.NamespacedName, plan *scorev1b1.WorkloadPlan) error {
+	deployment := &appsv1.Deployment{}
 	if err := r.Get(ctx, deploymentKey, deployment); err != nil {
 		if apierrors.IsNotFound(err) {
 			plan.Status.Phase = "Provisioning"
@@ -493,45 +1067,35 @@ func (r *KubernetesRuntimePlanReconciler) updateWorkloadPlanStatus(ctx context.C
 			plan.Status.Message = "Runtime deployment is starting up"
 		}
 	}
-
-	// Update the status
-	if err := r.Status().Update(ctx, plan); err != nil {
-		return fmt.Errorf("failed to update WorkloadPlan status: %w", err)
-	}
-
 	return nil
 }
 
-// mergeOwnedFields merges fields that we own into the existing map while preserving others
-func (r *KubernetesRuntimePlanReconciler) mergeOwnedFields(existing map[string]string, desired map[string]string, ownedPrefixes ...string) map[string]string {
-	if existing == nil {
-		existing = make(map[string]string)
-	}
-
-	// Remove existing keys that we own
-	for key := range existing {
-		for _, prefix := range ownedPrefixes {
-			if strings.HasPrefix(key, prefix) {
-				delete(existing, key)
-				break
-			}
-		}
-	}
-
-	// Add our desired keys
-	for key, value := range desired {
-		existing[key] = value
+// handlesPlan reports whether the Kubernetes runtime materializes the plan, or still has to tear down the
+// resources it materialized for a plan moved to another runtime class
+func handlesPlan(obj client.Object) bool {
+	plan, ok := obj.(*scorev1b1.WorkloadPlan)
+	if !ok {
+		return false
 	}
-
-	return existing
+	return plan.Spec.RuntimeClass == kubernetesRuntimeClass || controllerutil.ContainsFinalizer(plan, kubernetesRuntimeFinalizer)
 }
 
 // SetupWithManager sets up the controller with the Manager
 func (r *KubernetesRuntimePlanReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&scorev1b1.WorkloadPlan{}).
+		// Status updates, including the ones written by this runtime, do not need a reconcile of the plan
+		For(&scorev1b1.WorkloadPlan{}, builder.WithPredicates(
+			predicate.NewPredicateFuncs(handlesPlan),
+			predicate.Or(predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}),
+		)).
 		Owns(&appsv1.Deployment{}).
+		Owns(&appsv1.StatefulSet{}).
+		Owns(&batchv1.Job{}).
+		Owns(&batchv1.CronJob{}).
 		Owns(&corev1.Service{}).
+		Owns(&corev1.ServiceAccount{}).
+		Owns(&corev1.ConfigMap{}).
+		Owns(&corev1.Secret{}).
 		Watches(
 			&appsv1.Deployment{},
 			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &scorev1b1.WorkloadPlan{}),
@@ -540,6 +1104,13 @@ func (r *KubernetesRuntimePlanReconciler) SetupWithManager(mgr ctrl.Manager) err
 			&corev1.Service{},
 			handler.EnqueueRequestForOwner(mgr.GetScheme(), mgr.GetRESTMapper(), &scorev1b1.WorkloadPlan{}),
 		).
+		// Resources in environment namespaces are not owned by their plan and are mapped through their labels
+		Watches(&appsv1.Deployment{}, handler.EnqueueRequestsFromMapFunc(planForMaterialized)).
+		Watches(&appsv1.StatefulSet{}, handler.EnqueueRequestsFromMapFunc(planForMaterialized)).
+		Watches(&batchv1.Job{}, handler.EnqueueRequestsFromMapFunc(planForMaterialized)).
+		Watches(&batchv1.CronJob{}, handler.EnqueueRequestsFromMapFunc(planForMaterialized)).
+		Watches(&corev1.Service{}, handler.EnqueueRequestsFromMapFunc(planForMaterialized)).
 		Named("k8s-runtime-plan").
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		Complete(r)
 }
@@ -0,0 +1,656 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+func TestReplicasManagedByOthers(t *testing.T) {
+	replicasFields := &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:replicas":{}}}`)}
+	templateFields := &metav1.FieldsV1{Raw: []byte(`{"f:spec":{"f:template":{}}}`)}
+
+	tests := []struct {
+		name          string
+		managedFields []metav1.ManagedFieldsEntry
+		want          bool
+	}{
+		{
+			name: "no managed fields",
+			want: false,
+		},
+		{
+			name: "replicas owned by the runtime",
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: meta.FieldManagerRuntimeKubernetes, Operation: metav1.ManagedFieldsOperationApply, FieldsV1: replicasFields},
+			},
+			want: false,
+		},
+		{
+			name: "replicas owned by the HPA through the scale subresource",
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: meta.FieldManagerRuntimeKubernetes, Operation: metav1.ManagedFieldsOperationApply, FieldsV1: templateFields},
+				{Manager: "kube-controller-manager", Operation: metav1.ManagedFieldsOperationUpdate, Subresource: "scale", FieldsV1: replicasFields},
+			},
+			want: true,
+		},
+		{
+			name: "other manager owns unrelated fields",
+			managedFields: []metav1.ManagedFieldsEntry{
+				{Manager: "kubectl-edit", Operation: metav1.ManagedFieldsOperationUpdate, FieldsV1: templateFields},
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := replicasManagedByOthers(tt.managedFields); got != tt.want {
+				t.Errorf("replicasManagedByOthers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildServiceLeavesAllocatedFields(t *testing.T) {
+	r := &KubernetesRuntimePlanReconciler{}
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+			Exposure:    &scorev1b1.ExposureSpec{Mode: scorev1b1.ExposureModeNodePort},
+		},
+	}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Service: &scorev1b1.ServiceSpec{Ports: []scorev1b1.ServicePort{{Port: 80}}},
+		},
+	}
+
+	service, err := r.buildService(plan, workload)
+	if err != nil {
+		t.Fatalf("buildService() error = %v", err)
+	}
+
+	if service.Spec.Type != corev1.ServiceTypeNodePort {
+		t.Errorf("service type = %q, want %q", service.Spec.Type, corev1.ServiceTypeNodePort)
+	}
+	if service.Spec.ClusterIP != "" || len(service.Spec.ClusterIPs) != 0 {
+		t.Errorf("service must not declare cluster IPs, got %q %v", service.Spec.ClusterIP, service.Spec.ClusterIPs)
+	}
+	for _, port := range service.Spec.Ports {
+		if port.NodePort != 0 {
+			t.Errorf("port %q must not declare a node port, got %d", port.Name, port.NodePort)
+		}
+	}
+	if service.APIVersion != "v1" || service.Kind != "Service" {
+		t.Errorf("apply configuration requires type meta, got %s/%s", service.APIVersion, service.Kind)
+	}
+}
+
+func TestServiceTypeForPlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		exposure *scorev1b1.ExposureSpec
+		want     corev1.ServiceType
+	}{
+		{name: "no exposure configured", want: corev1.ServiceTypeClusterIP},
+		{name: "ClusterIP mode", exposure: &scorev1b1.ExposureSpec{Mode: scorev1b1.ExposureModeClusterIP}, want: corev1.ServiceTypeClusterIP},
+		{name: "NodePort mode", exposure: &scorev1b1.ExposureSpec{Mode: scorev1b1.ExposureModeNodePort}, want: corev1.ServiceTypeNodePort},
+		{name: "PortForward mode", exposure: &scorev1b1.ExposureSpec{Mode: scorev1b1.ExposureModePortForward}, want: corev1.ServiceTypeClusterIP},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &scorev1b1.WorkloadPlan{Spec: scorev1b1.WorkloadPlanSpec{Exposure: tt.exposure}}
+			if got := serviceTypeForPlan(plan); got != tt.want {
+				t.Errorf("serviceTypeForPlan() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildServiceMarksTLSPorts(t *testing.T) {
+	r := &KubernetesRuntimePlanReconciler{}
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+		},
+	}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Service: &scorev1b1.ServiceSpec{Ports: []scorev1b1.ServicePort{{Port: 8080}, {Port: 8081, TLS: true}}},
+		},
+	}
+
+	service, err := r.buildService(plan, workload)
+	if err != nil {
+		t.Fatalf("buildService() error = %v", err)
+	}
+	ports := service.Spec.Ports
+
+	if ports[0].AppProtocol != nil {
+		t.Errorf("plain port must not declare an appProtocol, got %q", *ports[0].AppProtocol)
+	}
+	if ports[1].AppProtocol == nil || *ports[1].AppProtocol != schemeHTTPS {
+		t.Errorf("TLS port appProtocol = %v, want %q", ports[1].AppProtocol, schemeHTTPS)
+	}
+	if got := resolveScheme(&scorev1b1.WorkloadExposure{}, ports[1]); got != schemeHTTPS {
+		t.Errorf("TLS port is published as %q, want %q", got, schemeHTTPS)
+	}
+}
+
+func TestBuildServiceNamesPorts(t *testing.T) {
+	r := &KubernetesRuntimePlanReconciler{}
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+		},
+	}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Service: &scorev1b1.ServiceSpec{Ports: []scorev1b1.ServicePort{{Port: 9090}, {Name: "http", Port: 80}}},
+		},
+	}
+
+	service, err := r.buildService(plan, workload)
+	if err != nil {
+		t.Fatalf("buildService() error = %v", err)
+	}
+
+	for i, want := range []string{"port-0", "http"} {
+		if got := service.Spec.Ports[i].Name; got != want {
+			t.Errorf("port %d name = %q, want %q", i, got, want)
+		}
+	}
+}
+
+func TestBuildServiceUsesResolvedTargetPorts(t *testing.T) {
+	r := &KubernetesRuntimePlanReconciler{}
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+			ResolvedValues: &runtime.RawExtension{Raw: []byte(
+				`{"service":{"ports":[{"port":80,"targetPort":8080},{"port":9090,"targetPort":"metrics"}]}}`,
+			)},
+		},
+	}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Service: &scorev1b1.ServiceSpec{Ports: []scorev1b1.ServicePort{
+				{Port: 80, TargetPort: ptr.To(intstr.FromString("${resources.app.outputs.port}"))},
+				{Port: 9090, TargetPort: ptr.To(intstr.FromString("metrics"))},
+				{Port: 443},
+			}},
+		},
+	}
+
+	service, err := r.buildService(plan, workload)
+	if err != nil {
+		t.Fatalf("buildService() error = %v", err)
+	}
+
+	want := []intstr.IntOrString{intstr.FromInt32(8080), intstr.FromString("metrics"), intstr.FromInt32(443)}
+	for i, port := range service.Spec.Ports {
+		if port.TargetPort != want[i] {
+			t.Errorf("port %d targetPort = %s, want %s", i, port.TargetPort.String(), want[i].String())
+		}
+	}
+}
+
+func TestBuildDeploymentRollsOnResolvedValues(t *testing.T) {
+	r := &KubernetesRuntimePlanReconciler{}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx"}},
+		},
+	}
+	planWithValues := func(raw string) *scorev1b1.WorkloadPlan {
+		return &scorev1b1.WorkloadPlan{
+			Spec: scorev1b1.WorkloadPlanSpec{
+				WorkloadRef:    scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+				ResolvedValues: &runtime.RawExtension{Raw: []byte(raw)},
+			},
+		}
+	}
+
+	before, err := r.buildDeployment(context.Background(), planWithValues(`{"containers":{"app":{"env":{"PASSWORD":"old"}}}}`), workload)
+	if err != nil {
+		t.Fatalf("buildDeployment() error = %v", err)
+	}
+	after, err := r.buildDeployment(context.Background(), planWithValues(`{"containers":{"app":{"env":{"PASSWORD":"new"}}}}`), workload)
+	if err != nil {
+		t.Fatalf("buildDeployment() error = %v", err)
+	}
+
+	hash := before.Spec.Template.Annotations[annotationValuesHash]
+	if hash == "" {
+		t.Fatal("pod template has no values hash annotation")
+	}
+	if after.Spec.Template.Annotations[annotationValuesHash] == hash {
+		t.Error("values hash did not change with the resolved values")
+	}
+}
+
+func TestBuildDeploymentPropagatesMetadata(t *testing.T) {
+	r := &KubernetesRuntimePlanReconciler{}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx"}},
+			Service:    &scorev1b1.ServiceSpec{Ports: []scorev1b1.ServicePort{{Port: 8080}}},
+		},
+	}
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+			Metadata: &scorev1b1.PropagatedMetadata{
+				Labels:      map[string]string{"cost-center": "cc-42", "score.dev/workload": "other"},
+				Annotations: map[string]string{"example.com/owner": "payments"},
+			},
+		},
+	}
+
+	deployment, err := r.buildDeployment(context.Background(), plan, workload)
+	if err != nil {
+		t.Fatalf("buildDeployment() error = %v", err)
+	}
+	service, err := r.buildService(plan, workload)
+	if err != nil {
+		t.Fatalf("buildService() error = %v", err)
+	}
+
+	for name, meta := range map[string]metav1.ObjectMeta{
+		"deployment":   deployment.ObjectMeta,
+		"pod template": deployment.Spec.Template.ObjectMeta,
+		"service":      service.ObjectMeta,
+	} {
+		if meta.Labels["cost-center"] != "cc-42" || meta.Annotations["example.com/owner"] != "payments" {
+			t.Errorf("%s metadata = %v, %v, want the propagated label and annotation", name, meta.Labels, meta.Annotations)
+		}
+		// Owned keys are never overridden by propagated ones
+		if meta.Labels["score.dev/workload"] != "app" {
+			t.Errorf("%s label score.dev/workload = %q, want app", name, meta.Labels["score.dev/workload"])
+		}
+	}
+	if _, ok := deployment.Spec.Selector.MatchLabels["cost-center"]; ok {
+		t.Error("propagated labels must not change the immutable selector")
+	}
+}
+
+func TestBuildContainersProjectsSecretKeyRefs(t *testing.T) {
+	r := &KubernetesRuntimePlanReconciler{}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx"}},
+		},
+	}
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+			ResolvedValues: &runtime.RawExtension{Raw: []byte(
+				`{"containers":{"app":{"env":{"DB_HOST":"db","DB_URL":{"secretKeyRef":{"name":"db-credentials","key":"uri"}}}}}}`,
+			)},
+		},
+	}
+
+	containers, err := r.buildContainers(context.Background(), plan, workload)
+	if err != nil {
+		t.Fatalf("buildContainers() error = %v", err)
+	}
+	want := []corev1.EnvVar{
+		{Name: "DB_HOST", Value: "db"},
+		{Name: "DB_URL", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "db-credentials"}, Key: "uri",
+		}}},
+		{Name: "SCORE_WORKLOAD_NAME", Value: "app"},
+		{Name: "SCORE_WORKLOAD_NAMESPACE", Value: "default"},
+	}
+	if !equality.Semantic.DeepEqual(containers[0].Env, want) {
+		t.Errorf("env = %+v, want %+v", containers[0].Env, want)
+	}
+
+	plan.Spec.ResolvedValues.Raw = []byte(`{"containers":{"app":{"env":{"DB_URL":{"secretKeyRef":{"name":"db-credentials"}}}}}}`)
+	if _, err := r.buildContainers(context.Background(), plan, workload); err == nil {
+		t.Error("buildContainers() error = nil, want an error for a secretKeyRef without key")
+	}
+}
+
+func TestBuildContainersAppliesDefaultResources(t *testing.T) {
+	r := &KubernetesRuntimePlanReconciler{}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{
+				"app":     {Image: "nginx"},
+				"sidecar": {Image: "envoy", Resources: &scorev1b1.ResourceRequirements{Limits: map[string]string{"cpu": "2"}}},
+			},
+		},
+	}
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			DefaultResources: &scorev1b1.ResourceRequirements{
+				Requests: map[string]string{"cpu": "500m", "memory": "256Mi"},
+				Limits:   map[string]string{"cpu": "500m", "memory": "256Mi"},
+			},
+		},
+	}
+
+	containers, err := r.buildContainers(context.Background(), plan, workload)
+	if err != nil {
+		t.Fatalf("buildContainers() error = %v", err)
+	}
+	guaranteed := corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m"), corev1.ResourceMemory: resource.MustParse("256Mi")}
+	if !equality.Semantic.DeepEqual(containers[0].Resources, corev1.ResourceRequirements{Requests: guaranteed, Limits: guaranteed}) {
+		t.Errorf("app resources = %+v, want the defaults", containers[0].Resources)
+	}
+
+	// The declared cpu limit of the sidecar is kept and only memory is defaulted
+	want := corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+		Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2"), corev1.ResourceMemory: resource.MustParse("256Mi")},
+	}
+	if !equality.Semantic.DeepEqual(containers[1].Resources, want) {
+		t.Errorf("sidecar resources = %+v, want %+v", containers[1].Resources, want)
+	}
+}
+
+func TestReconcileTearsDownMaterializedResources(t *testing.T) {
+	runtimeLabels := map[string]string{"score.dev/runtime": "kubernetes", "score.dev/workload": "app"}
+	now := metav1.Now()
+
+	tests := []struct {
+		name string
+		plan *scorev1b1.WorkloadPlan
+	}{
+		{
+			name: "runtime class changed",
+			plan: &scorev1b1.WorkloadPlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Finalizers: []string{kubernetesRuntimeFinalizer}},
+				Spec: scorev1b1.WorkloadPlanSpec{
+					WorkloadRef:  scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+					RuntimeClass: "ecs",
+				},
+			},
+		},
+		{
+			name: "plan delivered to a remote cluster",
+			plan: &scorev1b1.WorkloadPlan{
+				ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Finalizers: []string{kubernetesRuntimeFinalizer}},
+				Spec: scorev1b1.WorkloadPlanSpec{
+					WorkloadRef:  scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+					RuntimeClass: kubernetesRuntimeClass,
+					Target:       "eu-west",
+				},
+			},
+		},
+		{
+			name: "plan deleted with retained children",
+			plan: &scorev1b1.WorkloadPlan{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "app", Namespace: "default",
+					Finalizers:        []string{kubernetesRuntimeFinalizer},
+					DeletionTimestamp: &now,
+				},
+				Spec: scorev1b1.WorkloadPlanSpec{
+					WorkloadRef:  scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+					RuntimeClass: kubernetesRuntimeClass,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			if err := clientgoscheme.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+			if err := scorev1b1.AddToScheme(scheme); err != nil {
+				t.Fatal(err)
+			}
+
+			deployment := &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Labels: runtimeLabels}}
+			service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Labels: runtimeLabels}}
+			configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Labels: runtimeLabels}}
+			serviceAccount := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "app-identity", Namespace: "default", Labels: runtimeLabels}}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.plan, deployment, service, configMap, serviceAccount).Build()
+
+			r := &KubernetesRuntimePlanReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+			key := types.NamespacedName{Name: "app", Namespace: "default"}
+			if _, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("Reconcile() error = %v", err)
+			}
+
+			for _, obj := range []client.Object{&appsv1.Deployment{}, &corev1.Service{}, &corev1.ConfigMap{}} {
+				if err := c.Get(context.Background(), key, obj); !apierrors.IsNotFound(err) {
+					t.Errorf("expected %T to be deleted, got err = %v", obj, err)
+				}
+			}
+			if err := c.Get(context.Background(), client.ObjectKeyFromObject(serviceAccount), &corev1.ServiceAccount{}); !apierrors.IsNotFound(err) {
+				t.Errorf("expected ServiceAccount to be deleted, got err = %v", err)
+			}
+
+			plan := &scorev1b1.WorkloadPlan{}
+			err := c.Get(context.Background(), key, plan)
+			if err == nil && controllerutil.ContainsFinalizer(plan, kubernetesRuntimeFinalizer) {
+				t.Errorf("expected finalizer %q to be removed", kubernetesRuntimeFinalizer)
+			}
+			if err != nil && !apierrors.IsNotFound(err) {
+				t.Fatalf("failed to get plan: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetWorkloadFromDeliveredSnapshot(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	r := &KubernetesRuntimePlanReconciler{Client: fake.NewClientBuilder().WithScheme(scheme).Build(), Scheme: scheme}
+
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef:                scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+			ObservedWorkloadGeneration: 4,
+		},
+	}
+	if _, err := r.getWorkload(context.Background(), plan); !apierrors.IsNotFound(err) {
+		t.Fatalf("getWorkload() error = %v, want not found without a snapshot", err)
+	}
+
+	plan.Spec.WorkloadSnapshot = &runtime.RawExtension{Raw: []byte(`{"containers":{"app":{"image":"nginx:1"}}}`)}
+	workload, err := r.getWorkload(context.Background(), plan)
+	if err != nil {
+		t.Fatalf("getWorkload() error = %v", err)
+	}
+	if workload.Name != "app" || workload.Namespace != "default" || workload.Generation != 4 {
+		t.Errorf("workload = %s/%s generation %d, want default/app generation 4", workload.Namespace, workload.Name, workload.Generation)
+	}
+	if workload.Spec.Containers["app"].Image != "nginx:1" {
+		t.Errorf("containers = %v, want the snapshot containers", workload.Spec.Containers)
+	}
+}
+
+func TestIsMaterializedFor(t *testing.T) {
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"}},
+	}
+
+	tests := []struct {
+		name   string
+		labels map[string]string
+		want   bool
+	}{
+		{name: "runtime labels", labels: map[string]string{"score.dev/runtime": "kubernetes", "score.dev/workload": "app"}, want: true},
+		{name: "other workload", labels: map[string]string{"score.dev/runtime": "kubernetes", "score.dev/workload": "other"}, want: false},
+		{name: "unmanaged", labels: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			obj := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Labels: tt.labels}}
+			if got := isMaterializedFor(obj, plan); got != tt.want {
+				t.Errorf("isMaterializedFor() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHandlesPlan(t *testing.T) {
+	tests := []struct {
+		name         string
+		runtimeClass string
+		finalizers   []string
+		want         bool
+	}{
+		{name: "kubernetes runtime class", runtimeClass: "kubernetes", want: true},
+		{name: "other runtime class", runtimeClass: "ecs", want: false},
+		{name: "moved to another runtime class", runtimeClass: "ecs", finalizers: []string{kubernetesRuntimeFinalizer}, want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &scorev1b1.WorkloadPlan{
+				ObjectMeta: metav1.ObjectMeta{Finalizers: tt.finalizers},
+				Spec:       scorev1b1.WorkloadPlanSpec{RuntimeClass: tt.runtimeClass},
+			}
+			if got := handlesPlan(plan); got != tt.want {
+				t.Errorf("handlesPlan() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyRolloutDeadline(t *testing.T) {
+	startedAt := func(ago time.Duration, generation int64) []metav1.Condition {
+		return []metav1.Condition{{
+			Type:               conditionReady,
+			Status:             metav1.ConditionFalse,
+			Reason:             string(scorev1b1.WorkloadPlanPhaseProvisioning),
+			ObservedGeneration: generation,
+			LastTransitionTime: metav1.NewTime(time.Now().Add(-ago)),
+		}}
+	}
+
+	tests := []struct {
+		name          string
+		kind          string
+		phase         scorev1b1.WorkloadPlanPhase
+		previousPhase scorev1b1.WorkloadPlanPhase
+		conditions    []metav1.Condition
+		wantPhase     scorev1b1.WorkloadPlanPhase
+		wantEvent     bool
+		wantDeadline  bool
+	}{
+		{"new rollout", kindDeployment, scorev1b1.WorkloadPlanPhaseProvisioning, "", nil, scorev1b1.WorkloadPlanPhaseProvisioning, false, true},
+		{"rollout within the deadline", kindStatefulSet, scorev1b1.WorkloadPlanPhaseProvisioning, scorev1b1.WorkloadPlanPhaseProvisioning, startedAt(time.Minute, 2), scorev1b1.WorkloadPlanPhaseProvisioning, false, true},
+		{"rollout past the deadline", kindDeployment, scorev1b1.WorkloadPlanPhaseProvisioning, scorev1b1.WorkloadPlanPhaseProvisioning, startedAt(time.Hour, 2), scorev1b1.WorkloadPlanPhaseFailed, true, false},
+		{"timed out rollout is reported once", kindDeployment, scorev1b1.WorkloadPlanPhaseProvisioning, scorev1b1.WorkloadPlanPhaseFailed, startedAt(time.Hour, 2), scorev1b1.WorkloadPlanPhaseFailed, false, false},
+		{"updated plan starts a new rollout", kindDeployment, scorev1b1.WorkloadPlanPhaseProvisioning, scorev1b1.WorkloadPlanPhaseFailed, startedAt(time.Hour, 1), scorev1b1.WorkloadPlanPhaseProvisioning, false, true},
+		{"jobs have no rollout deadline", kindJob, scorev1b1.WorkloadPlanPhaseProvisioning, scorev1b1.WorkloadPlanPhaseProvisioning, startedAt(time.Hour, 2), scorev1b1.WorkloadPlanPhaseProvisioning, false, false},
+		{"ready rollout", kindDeployment, scorev1b1.WorkloadPlanPhaseReady, scorev1b1.WorkloadPlanPhaseProvisioning, startedAt(time.Hour, 2), scorev1b1.WorkloadPlanPhaseReady, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := record.NewFakeRecorder(10)
+			r := &KubernetesRuntimePlanReconciler{Recorder: recorder}
+			plan := &scorev1b1.WorkloadPlan{
+				ObjectMeta: metav1.ObjectMeta{Generation: 2},
+				Spec:       scorev1b1.WorkloadPlanSpec{RolloutDeadline: &metav1.Duration{Duration: 10 * time.Minute}},
+				Status:     scorev1b1.WorkloadPlanStatus{Phase: tt.phase, Conditions: tt.conditions},
+			}
+
+			deadlineIn := r.applyRolloutDeadline(plan, tt.kind, tt.previousPhase)
+
+			if plan.Status.Phase != tt.wantPhase {
+				t.Errorf("phase = %s, want %s", plan.Status.Phase, tt.wantPhase)
+			}
+			if (deadlineIn > 0) != tt.wantDeadline {
+				t.Errorf("deadlineIn = %s, want a pending deadline %v", deadlineIn, tt.wantDeadline)
+			}
+			if gotEvent := len(recorder.Events) > 0; gotEvent != tt.wantEvent {
+				t.Errorf("event emitted = %v, want %v", gotEvent, tt.wantEvent)
+			}
+			ready := apimeta.FindStatusCondition(plan.Status.Conditions, conditionReady)
+			if ready == nil || ready.ObservedGeneration != 2 ||
+				(ready.Status == metav1.ConditionTrue) != (tt.wantPhase == scorev1b1.WorkloadPlanPhaseReady) {
+				t.Errorf("ready condition = %+v, want one for generation 2 matching phase %s", ready, tt.wantPhase)
+			}
+		})
+	}
+}
+
+func TestBuildContainersEnvPrecedence(t *testing.T) {
+	r := &KubernetesRuntimePlanReconciler{}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx", Variables: map[string]string{
+				"DB_URL":    "${resources.db.outputs.uri}",
+				"LOG_LEVEL": "debug",
+			}}},
+			Service: &scorev1b1.ServiceSpec{Ports: []scorev1b1.ServicePort{{Port: 8080}}},
+		},
+	}
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "team-a"},
+			Namespace:   "team-a-staging",
+			// The plan predates LOG_LEVEL and carries a reserved variable the platform overrides
+			ResolvedValues: &runtime.RawExtension{Raw: []byte(
+				`{"containers":{"app":{"env":{"DB_URL":"postgres://db","SCORE_WORKLOAD_NAME":"other"}}}}`,
+			)},
+		},
+	}
+
+	containers, err := r.buildContainers(context.Background(), plan, workload)
+	if err != nil {
+		t.Fatalf("buildContainers() error = %v", err)
+	}
+	want := []corev1.EnvVar{
+		{Name: "DB_URL", Value: "postgres://db"},
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "SCORE_WORKLOAD_ENDPOINT", Value: "http://app.team-a-staging.svc:8080"},
+		{Name: "SCORE_WORKLOAD_NAME", Value: "app"},
+		{Name: "SCORE_WORKLOAD_NAMESPACE", Value: "team-a"},
+	}
+	if !equality.Semantic.DeepEqual(containers[0].Env, want) {
+		t.Errorf("env = %+v, want %+v", containers[0].Env, want)
+	}
+}
@@ -0,0 +1,75 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// errPriorityClassNotFound reports a PriorityClass the pods of a plan reference that neither exists nor
+// can be created from the plan
+var errPriorityClassNotFound = errors.New("priority class not found")
+
+// priorityClassName returns the PriorityClass of the pods materialized for the plan, if any
+func priorityClassName(plan *scorev1b1.WorkloadPlan) string {
+	if plan.Spec.PriorityClass == nil {
+		return ""
+	}
+	return plan.Spec.PriorityClass.Name
+}
+
+// reconcilePriorityClass makes sure the PriorityClass of WorkloadPlan.spec.priorityClass exists before pods
+// referencing it are created, creating it when the plan sets a value. An existing PriorityClass is used as
+// is: its value and preemption policy are immutable and it may be shared by other Workloads. PriorityClasses
+// are cluster-scoped and never deleted by the runtime.
+func (r *KubernetesRuntimePlanReconciler) reconcilePriorityClass(ctx context.Context, plan *scorev1b1.WorkloadPlan) error {
+	spec := plan.Spec.PriorityClass
+	if spec == nil {
+		return nil
+	}
+
+	err := r.Get(ctx, client.ObjectKey{Name: spec.Name}, &schedulingv1.PriorityClass{})
+	switch {
+	case err == nil:
+		return nil
+	case !apierrors.IsNotFound(err):
+		return fmt.Errorf("failed to get priority class: %w", err)
+	case spec.Value == nil:
+		return fmt.Errorf("%w: %s does not exist and the plan sets no value to create it", errPriorityClassNotFound, spec.Name)
+	}
+
+	if err := r.Create(ctx, buildPriorityClass(spec)); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create priority class: %w", err)
+	}
+	log.FromContext(ctx).V(1).Info("Created PriorityClass", "name", spec.Name)
+	return nil
+}
+
+// buildPriorityClass constructs the PriorityClass of the spec
+func buildPriorityClass(spec *scorev1b1.PriorityClassSpec) *schedulingv1.PriorityClass {
+	priorityClass := &schedulingv1.PriorityClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: spec.Name,
+			Labels: map[string]string{
+				"app.kubernetes.io/managed-by": "score-orchestrator",
+				"score.dev/runtime":            kubernetesRuntimeClass,
+			},
+		},
+		Value:       *spec.Value,
+		Description: spec.Description,
+	}
+	if spec.PreemptionPolicy != "" {
+		priorityClass.PreemptionPolicy = ptr.To(corev1.PreemptionPolicy(spec.PreemptionPolicy))
+	}
+	return priorityClass
+}
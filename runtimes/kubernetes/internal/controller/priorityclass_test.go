@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	schedulingv1 "k8s.io/api/scheduling/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestReconcilePriorityClass(t *testing.T) {
+	existing := &schedulingv1.PriorityClass{ObjectMeta: metav1.ObjectMeta{Name: "platform-critical"}, Value: 500}
+
+	tests := []struct {
+		name         string
+		spec         *scorev1b1.PriorityClassSpec
+		wantNotFound bool
+		wantCreated  *schedulingv1.PriorityClass
+	}{
+		{name: "no priority class"},
+		{name: "existing priority class", spec: &scorev1b1.PriorityClassSpec{Name: "platform-critical"}},
+		{
+			name:        "existing priority class is not updated",
+			spec:        &scorev1b1.PriorityClassSpec{Name: "platform-critical", Value: ptr.To[int32](1000)},
+			wantCreated: &schedulingv1.PriorityClass{Value: 500},
+		},
+		{name: "missing priority class without value", spec: &scorev1b1.PriorityClassSpec{Name: "batch-low"}, wantNotFound: true},
+		{
+			name: "missing priority class created",
+			spec: &scorev1b1.PriorityClassSpec{Name: "batch-low", Value: ptr.To[int32](-10), PreemptionPolicy: scorev1b1.PreemptionPolicyNever, Description: "Batch jobs"},
+			wantCreated: &schedulingv1.PriorityClass{
+				Value: -10, PreemptionPolicy: ptr.To(corev1.PreemptNever), Description: "Batch jobs",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			c := fake.NewClientBuilder().WithScheme(namingScheme(t)).WithObjects(existing.DeepCopy()).Build()
+			r := &KubernetesRuntimePlanReconciler{Client: c}
+			plan := &scorev1b1.WorkloadPlan{Spec: scorev1b1.WorkloadPlanSpec{PriorityClass: tt.spec}}
+
+			err := r.reconcilePriorityClass(ctx, plan)
+			if got := errors.Is(err, errPriorityClassNotFound); got != tt.wantNotFound {
+				t.Fatalf("reconcilePriorityClass() error = %v, want not found %v", err, tt.wantNotFound)
+			}
+			if !tt.wantNotFound && err != nil {
+				t.Fatalf("reconcilePriorityClass() error = %v", err)
+			}
+			if tt.wantCreated == nil {
+				return
+			}
+
+			priorityClass := &schedulingv1.PriorityClass{}
+			if err := c.Get(ctx, client.ObjectKey{Name: tt.spec.Name}, priorityClass); err != nil {
+				t.Fatal(err)
+			}
+			if priorityClass.Value != tt.wantCreated.Value || priorityClass.Description != tt.wantCreated.Description ||
+				ptr.Deref(priorityClass.PreemptionPolicy, "") != ptr.Deref(tt.wantCreated.PreemptionPolicy, "") {
+				t.Errorf("priority class = %+v, want %+v", priorityClass, tt.wantCreated)
+			}
+		})
+	}
+}
+
+func TestBuildDeploymentSetsPriorityClass(t *testing.T) {
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec:       scorev1b1.WorkloadSpec{Containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx:1"}}},
+	}
+	plan := &scorev1b1.WorkloadPlan{Spec: scorev1b1.WorkloadPlanSpec{
+		WorkloadRef:   scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+		PriorityClass: &scorev1b1.PriorityClassSpec{Name: "platform-critical"},
+	}}
+
+	r := &KubernetesRuntimePlanReconciler{}
+	deployment, err := r.buildDeployment(context.Background(), plan, workload)
+	if err != nil {
+		t.Fatalf("buildDeployment() error = %v", err)
+	}
+	if got := deployment.Spec.Template.Spec.PriorityClassName; got != "platform-critical" {
+		t.Errorf("priorityClassName = %q, want platform-critical", got)
+	}
+}
@@ -0,0 +1,67 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+// podTemplateOwnedMeta returns the pod template metadata owned by the runtime: the runtime labels, the hash of
+// the resolved values, so that changed claim outputs (e.g., a rotated password) roll out new pods, and the
+// restart requested on the Workload
+func podTemplateOwnedMeta(plan *scorev1b1.WorkloadPlan) metav1.ObjectMeta {
+	annotations := map[string]string{
+		annotationValuesHash: resolvedValuesHash(plan),
+	}
+	if plan.Spec.RestartedAt != "" {
+		annotations[meta.AnnotationRestart] = plan.Spec.RestartedAt
+	}
+	return metav1.ObjectMeta{
+		Labels:      runtimeLabels(plan.Spec.WorkloadRef.Name),
+		Annotations: annotations,
+	}
+}
+
+// templateRevision returns a short hash identifying the restart-worthy content of a pod template built for
+// the plan: everything but the labels and annotations propagated from the Workload
+func templateRevision(plan *scorev1b1.WorkloadPlan, template *corev1.PodTemplateSpec) (string, error) {
+	restartWorthy := template.DeepCopy()
+	if metadata := plan.Spec.Metadata; metadata != nil {
+		owned := podTemplateOwnedMeta(plan)
+		for key := range metadata.Labels {
+			if _, ok := owned.Labels[key]; !ok {
+				delete(restartWorthy.Labels, key)
+			}
+		}
+		for key := range metadata.Annotations {
+			if _, ok := owned.Annotations[key]; !ok {
+				delete(restartWorthy.Annotations, key)
+			}
+		}
+	}
+	raw, err := json.Marshal(restartWorthy)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal pod template: %w", err)
+	}
+	sum := sha256.Sum256(raw)
+	return hex.EncodeToString(sum[:])[:16], nil
+}
+
+// keepPodTemplateMetadata keeps the labels and annotations of the existing pod template when its revision
+// matches the desired one, so that changes to the propagated metadata are applied in place to the workload
+// resource and reach the pods at their next rollout instead of restarting them
+func keepPodTemplateMetadata(desired, existing *corev1.PodTemplateSpec, revision, existingRevision string) {
+	if existingRevision == "" || existingRevision != revision {
+		return
+	}
+	desired.Labels = maps.Clone(existing.Labels)
+	desired.Annotations = maps.Clone(existing.Annotations)
+}
@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+)
+
+func TestTemplateRevisionOnlyCoversRestartWorthyFields(t *testing.T) {
+	r := &KubernetesRuntimePlanReconciler{}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx", Variables: map[string]string{"MODE": "a"}}},
+		},
+	}
+	base := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+		},
+	}
+	revision := func(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) string {
+		t.Helper()
+		deployment, err := r.buildDeployment(context.Background(), plan, workload)
+		if err != nil {
+			t.Fatalf("buildDeployment() error = %v", err)
+		}
+		revision, err := templateRevision(plan, &deployment.Spec.Template)
+		if err != nil {
+			t.Fatalf("templateRevision() error = %v", err)
+		}
+		return revision
+	}
+	want := revision(base, workload)
+
+	labeled := base.DeepCopy()
+	labeled.Spec.Metadata = &scorev1b1.PropagatedMetadata{
+		Labels:      map[string]string{"cost-center": "cc-42"},
+		Annotations: map[string]string{"example.com/owner": "payments"},
+	}
+	if got := revision(labeled, workload); got != want {
+		t.Errorf("revision changed with the propagated metadata: %s, want %s", got, want)
+	}
+
+	restarted := base.DeepCopy()
+	restarted.Spec.RestartedAt = "2026-10-16T09:00:00Z"
+	if got := revision(restarted, workload); got == want {
+		t.Error("revision did not change with the restart request")
+	}
+
+	changed := workload.DeepCopy()
+	changed.Spec.Containers["app"] = scorev1b1.ContainerSpec{Image: "nginx", Variables: map[string]string{"MODE": "b"}}
+	if got := revision(base, changed); got == want {
+		t.Error("revision did not change with the container variables")
+	}
+}
+
+func TestBuildDeploymentAnnotatesRestart(t *testing.T) {
+	r := &KubernetesRuntimePlanReconciler{}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{Containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx"}}},
+	}
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+			RestartedAt: "2026-10-16T09:00:00Z",
+		},
+	}
+
+	deployment, err := r.buildDeployment(context.Background(), plan, workload)
+	if err != nil {
+		t.Fatalf("buildDeployment() error = %v", err)
+	}
+	if got := deployment.Spec.Template.Annotations[meta.AnnotationRestart]; got != plan.Spec.RestartedAt {
+		t.Errorf("pod template annotation %s = %q, want %q", meta.AnnotationRestart, got, plan.Spec.RestartedAt)
+	}
+}
+
+func TestKeepPodTemplateMetadata(t *testing.T) {
+	existing := &corev1.PodTemplateSpec{ObjectMeta: metav1.ObjectMeta{
+		Labels:      map[string]string{"app": "web", "cost-center": "cc-1"},
+		Annotations: map[string]string{annotationValuesHash: "abc"},
+	}}
+	desiredMeta := metav1.ObjectMeta{
+		Labels:      map[string]string{"app": "web", "cost-center": "cc-2"},
+		Annotations: map[string]string{annotationValuesHash: "abc"},
+	}
+
+	tests := []struct {
+		name             string
+		revision         string
+		existingRevision string
+		wantCostCenter   string
+	}{
+		{name: "same revision keeps the running metadata", revision: "r1", existingRevision: "r1", wantCostCenter: "cc-1"},
+		{name: "new revision rolls out the metadata", revision: "r2", existingRevision: "r1", wantCostCenter: "cc-2"},
+		{name: "unrecorded revision rolls out the metadata", revision: "r1", wantCostCenter: "cc-2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := &corev1.PodTemplateSpec{ObjectMeta: *desiredMeta.DeepCopy()}
+			keepPodTemplateMetadata(desired, existing, tt.revision, tt.existingRevision)
+			if got := desired.Labels["cost-center"]; got != tt.wantCostCenter {
+				t.Errorf("cost-center label = %q, want %q", got, tt.wantCostCenter)
+			}
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+)
+
+const (
+	// annotationRolloutRevision records the revision of the pod template a Deployment or StatefulSet runs
+	annotationRolloutRevision = "score.dev/rollout-revision"
+
+	// canaryDeploymentSuffix names the Deployment running the new revision during a progressive rollout
+	canaryDeploymentSuffix = "-canary"
+)
+
+// rolloutStrategy is the progressive rollout configured in the `rollout` template value of the backend
+type rolloutStrategy struct {
+	// Strategy is Canary or BlueGreen
+	Strategy scorev1b1.RolloutStrategy `json:"strategy"`
+	// Steps are the canary steps, in order
+	Steps []rolloutStep `json:"steps,omitempty"`
+	// Pause is how long a ready blue/green preview runs before it is promoted
+	Pause metav1.Duration `json:"pause,omitempty"`
+}
+
+// rolloutStep is a canary step
+type rolloutStep struct {
+	// Weight is the replica share of the new revision, in percent of the stable replicas
+	Weight int32 `json:"weight"`
+	// Pause is how long the step runs once its replicas are ready
+	Pause metav1.Duration `json:"pause,omitempty"`
+}
+
+// rolloutStrategyForPlan returns the rollout strategy declared in the template values of the plan,
+// or nil when the workload is updated in place
+func rolloutStrategyForPlan(plan *scorev1b1.WorkloadPlan) (*rolloutStrategy, error) {
+	if plan.Spec.Template == nil || plan.Spec.Template.Values == nil {
+		return nil, nil
+	}
+	var values struct {
+		Rollout *rolloutStrategy `json:"rollout"`
+	}
+	if err := json.Unmarshal(plan.Spec.Template.Values.Raw, &values); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal rollout strategy: %w", err)
+	}
+	strategy := values.Rollout
+	if strategy == nil {
+		return nil, nil
+	}
+
+	switch strategy.Strategy {
+	case scorev1b1.RolloutStrategyCanary:
+		if len(strategy.Steps) == 0 {
+			return nil, fmt.Errorf("canary rollout requires at least one step")
+		}
+		previous := int32(0)
+		for i, step := range strategy.Steps {
+			if step.Weight <= previous || step.Weight >= 100 {
+				return nil, fmt.Errorf("canary step %d weight %d must be greater than the previous weight and below 100", i, step.Weight)
+			}
+			previous = step.Weight
+		}
+	case scorev1b1.RolloutStrategyBlueGreen:
+		if len(strategy.Steps) > 0 {
+			return nil, fmt.Errorf("blue/green rollout does not take steps")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported rollout strategy %q", strategy.Strategy)
+	}
+	return strategy, nil
+}
+
+// canaryDeploymentName returns the name of the Deployment running the new revision of the Workload
+func canaryDeploymentName(name string) string {
+	return name + canaryDeploymentSuffix
+}
+
+// canaryDeploymentRef returns an empty Deployment carrying the key of the plan's canary Deployment
+func canaryDeploymentRef(plan *scorev1b1.WorkloadPlan) *appsv1.Deployment {
+	return &appsv1.Deployment{ObjectMeta: metav1.ObjectMeta{
+		Name:      canaryDeploymentName(materializedName(plan)),
+		Namespace: materializedNamespace(plan),
+	}}
+}
+
+// rolloutInProgress reports whether the plan is between two revisions
+func rolloutInProgress(plan *scorev1b1.WorkloadPlan) bool {
+	return plan.Status.Rollout != nil && plan.Status.Rollout.Phase != scorev1b1.RolloutPhaseCompleted
+}
+
+// progressRollout advances the progressive rollout of desired, the stable Deployment built for the plan,
+// and records its progress in plan.Status.Rollout. It reports whether the stable Deployment should be applied,
+// which is the case once the new revision is promoted or when there is nothing to roll out, and the time
+// after which a pending pause ends.
+func (r *KubernetesRuntimePlanReconciler) progressRollout(ctx context.Context, plan *scorev1b1.WorkloadPlan, strategy *rolloutStrategy, desired, existing *appsv1.Deployment) (bool, time.Duration, error) {
+	logger := log.FromContext(ctx)
+	revision := desired.Annotations[annotationRolloutRevision]
+
+	status := plan.Status.Rollout
+	if status == nil || status.Revision != revision || status.Strategy != strategy.Strategy {
+		// Deployments created before revisions were recorded are adopted in place
+		current := existing.Annotations[annotationRolloutRevision]
+		if current == "" || current == revision {
+			plan.Status.Rollout = &scorev1b1.RolloutStatus{
+				Strategy: strategy.Strategy,
+				Revision: revision,
+				Phase:    scorev1b1.RolloutPhaseCompleted,
+			}
+			return true, 0, nil
+		}
+		status = &scorev1b1.RolloutStatus{
+			Strategy: strategy.Strategy,
+			Revision: revision,
+			Phase:    scorev1b1.RolloutPhaseProgressing,
+		}
+		if strategy.Strategy == scorev1b1.RolloutStrategyCanary {
+			status.Weight = strategy.Steps[0].Weight
+		}
+		plan.Status.Rollout = status
+		r.Recorder.Eventf(plan, corev1.EventTypeNormal, "RolloutStarted",
+			"Started %s rollout of revision %s", strategy.Strategy, revision)
+	}
+	if status.Phase != scorev1b1.RolloutPhaseProgressing {
+		return true, 0, nil
+	}
+	if strategy.Strategy == scorev1b1.RolloutStrategyCanary && int(status.Step) >= len(strategy.Steps) {
+		// Steps were removed from the configuration mid-rollout
+		status.Step = int32(len(strategy.Steps) - 1)
+	}
+
+	// Size the canary relative to the stable replicas, which an autoscaler may own
+	stableReplicas := int32(1)
+	if desired.Spec.Replicas != nil {
+		stableReplicas = *desired.Spec.Replicas
+	} else if existing.Spec.Replicas != nil {
+		stableReplicas = *existing.Spec.Replicas
+	}
+
+	for {
+		canary := buildCanaryDeployment(desired, canaryReplicas(stableReplicas, status))
+		if err := reconcile.Apply(ctx, r.Client, canary, meta.FieldManagerRuntimeKubernetes); err != nil {
+			return false, 0, fmt.Errorf("failed to apply canary deployment: %w", err)
+		}
+		logger.V(1).Info("Applied canary Deployment", "name", canary.Name, "step", status.Step, "weight", status.Weight)
+
+		if !deploymentRolledOut(canary) {
+			status.StepStartTime = nil
+			return false, 0, nil
+		}
+		if status.StepStartTime == nil {
+			status.StepStartTime = ptr.To(metav1.Now())
+		}
+
+		pause := strategy.Pause.Duration
+		if strategy.Strategy == scorev1b1.RolloutStrategyCanary {
+			pause = strategy.Steps[status.Step].Pause.Duration
+		}
+		if remaining := time.Until(status.StepStartTime.Add(pause)); remaining > 0 {
+			return false, remaining, nil
+		}
+
+		if strategy.Strategy == scorev1b1.RolloutStrategyCanary && int(status.Step)+1 < len(strategy.Steps) {
+			status.Step++
+			status.Weight = strategy.Steps[status.Step].Weight
+			status.StepStartTime = nil
+			continue
+		}
+
+		status.Phase = scorev1b1.RolloutPhasePromoting
+		status.StepStartTime = nil
+		r.Recorder.Eventf(plan, corev1.EventTypeNormal, "RolloutPromoting",
+			"Promoting revision %s to the stable deployment", revision)
+		return true, 0, nil
+	}
+}
+
+// completeRollout finishes a promotion once the stable Deployment runs the new revision.
+// The canary Deployment is removed after the Service routes to the stable Deployment again.
+func (r *KubernetesRuntimePlanReconciler) completeRollout(plan *scorev1b1.WorkloadPlan, stable *appsv1.Deployment) {
+	status := plan.Status.Rollout
+	if status == nil || status.Phase != scorev1b1.RolloutPhasePromoting || !deploymentRolledOut(stable) {
+		return
+	}
+	status.Phase = scorev1b1.RolloutPhaseCompleted
+	status.Weight = 0
+	r.Recorder.Eventf(plan, corev1.EventTypeNormal, "RolloutCompleted",
+		"Revision %s is fully promoted", status.Revision)
+}
+
+// canaryReplicas returns the replicas of the canary Deployment for the current rollout step.
+// A canary runs the step weight in percent of the stable replicas, rounded up; a blue/green preview
+// runs as many replicas as the stable Deployment.
+func canaryReplicas(stableReplicas int32, status *scorev1b1.RolloutStatus) int32 {
+	if status.Strategy != scorev1b1.RolloutStrategyCanary {
+		return stableReplicas
+	}
+	return max((stableReplicas*status.Weight+99)/100, 1)
+}
+
+// buildCanaryDeployment derives the canary Deployment from the stable Deployment built for the plan.
+// Canary pods carry their own instance label so that the stable Deployment does not select them.
+func buildCanaryDeployment(stable *appsv1.Deployment, replicas int32) *appsv1.Deployment {
+	canary := stable.DeepCopy()
+	canary.Name = canaryDeploymentName(stable.Name)
+	canary.Spec.Replicas = ptr.To(replicas)
+	canary.Spec.Selector.MatchLabels["app.kubernetes.io/instance"] = canary.Name
+	canary.Spec.Template.Labels["app.kubernetes.io/instance"] = canary.Name
+	return canary
+}
+
+// deploymentRolledOut reports whether all replicas of the current Deployment generation are ready
+func deploymentRolledOut(deployment *appsv1.Deployment) bool {
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	return deployment.Status.ObservedGeneration >= deployment.Generation &&
+		deployment.Status.UpdatedReplicas == replicas &&
+		deployment.Status.ReadyReplicas == replicas
+}
+
+// serviceSelector returns the pods the Workload Service routes to. During a canary rollout the Service
+// selects both Deployments, so traffic is split in proportion to their ready replicas; a blue/green
+// preview receives all traffic while the stable Deployment is promoted.
+func serviceSelector(plan *scorev1b1.WorkloadPlan) map[string]string {
+	name := plan.Spec.WorkloadRef.Name
+	selector := map[string]string{
+		"app.kubernetes.io/name":     name,
+		"app.kubernetes.io/instance": name,
+	}
+
+	rollout := plan.Status.Rollout
+	switch {
+	case rollout == nil || rollout.Phase == scorev1b1.RolloutPhaseCompleted:
+	case rollout.Strategy == scorev1b1.RolloutStrategyCanary:
+		delete(selector, "app.kubernetes.io/instance")
+	case rollout.Phase == scorev1b1.RolloutPhasePromoting:
+		selector["app.kubernetes.io/instance"] = canaryDeploymentName(materializedName(plan))
+	}
+	return selector
+}
+
+// rolloutMessage describes a rollout in progress for the plan status
+func rolloutMessage(rollout *scorev1b1.RolloutStatus) string {
+	switch {
+	case rollout.Phase == scorev1b1.RolloutPhasePromoting:
+		return fmt.Sprintf("Promoting revision %s", rollout.Revision)
+	case rollout.Strategy == scorev1b1.RolloutStrategyCanary:
+		return fmt.Sprintf("Canary step %d of revision %s runs %d%% of the replicas", rollout.Step+1, rollout.Revision, rollout.Weight)
+	default:
+		return fmt.Sprintf("Blue/green preview of revision %s runs next to the stable deployment", rollout.Revision)
+	}
+}
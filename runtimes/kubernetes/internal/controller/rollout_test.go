@@ -0,0 +1,259 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestRolloutStrategyForPlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   string
+		want     scorev1b1.RolloutStrategy
+		wantErr  bool
+		wantNone bool
+	}{
+		{name: "no template values", wantNone: true},
+		{name: "no rollout value", values: `{"replicas":2}`, wantNone: true},
+		{name: "canary", values: `{"rollout":{"strategy":"Canary","steps":[{"weight":10,"pause":"5m"},{"weight":50}]}}`, want: scorev1b1.RolloutStrategyCanary},
+		{name: "blue/green", values: `{"rollout":{"strategy":"BlueGreen","pause":"1m"}}`, want: scorev1b1.RolloutStrategyBlueGreen},
+		{name: "canary without steps", values: `{"rollout":{"strategy":"Canary"}}`, wantErr: true},
+		{name: "canary weights must increase", values: `{"rollout":{"strategy":"Canary","steps":[{"weight":50},{"weight":20}]}}`, wantErr: true},
+		{name: "canary weight of 100", values: `{"rollout":{"strategy":"Canary","steps":[{"weight":100}]}}`, wantErr: true},
+		{name: "blue/green with steps", values: `{"rollout":{"strategy":"BlueGreen","steps":[{"weight":50}]}}`, wantErr: true},
+		{name: "unknown strategy", values: `{"rollout":{"strategy":"Shadow"}}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &scorev1b1.WorkloadPlan{Spec: scorev1b1.WorkloadPlanSpec{Template: &scorev1b1.TemplateSpec{}}}
+			if tt.values != "" {
+				plan.Spec.Template.Values = &runtime.RawExtension{Raw: []byte(tt.values)}
+			}
+
+			strategy, err := rolloutStrategyForPlan(plan)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("rolloutStrategyForPlan() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if (strategy == nil) != tt.wantNone {
+				t.Fatalf("rolloutStrategyForPlan() = %+v, want none %v", strategy, tt.wantNone)
+			}
+			if strategy != nil && strategy.Strategy != tt.want {
+				t.Errorf("strategy = %q, want %q", strategy.Strategy, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanaryReplicas(t *testing.T) {
+	tests := []struct {
+		name     string
+		stable   int32
+		status   scorev1b1.RolloutStatus
+		expected int32
+	}{
+		{"canary rounds up", 3, scorev1b1.RolloutStatus{Strategy: scorev1b1.RolloutStrategyCanary, Weight: 50}, 2},
+		{"canary runs at least one replica", 4, scorev1b1.RolloutStatus{Strategy: scorev1b1.RolloutStrategyCanary, Weight: 10}, 1},
+		{"blue/green preview matches the stable replicas", 4, scorev1b1.RolloutStatus{Strategy: scorev1b1.RolloutStrategyBlueGreen}, 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := canaryReplicas(tt.stable, &tt.status); got != tt.expected {
+				t.Errorf("canaryReplicas() = %d, want %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestServiceSelector(t *testing.T) {
+	stable := map[string]string{"app.kubernetes.io/name": "app", "app.kubernetes.io/instance": "app"}
+
+	tests := []struct {
+		name    string
+		rollout *scorev1b1.RolloutStatus
+		want    map[string]string
+	}{
+		{"no rollout", nil, stable},
+		{"completed rollout", &scorev1b1.RolloutStatus{Strategy: scorev1b1.RolloutStrategyCanary, Phase: scorev1b1.RolloutPhaseCompleted}, stable},
+		{"canary splits traffic", &scorev1b1.RolloutStatus{Strategy: scorev1b1.RolloutStrategyCanary, Phase: scorev1b1.RolloutPhaseProgressing},
+			map[string]string{"app.kubernetes.io/name": "app"}},
+		{"blue/green preview receives no traffic", &scorev1b1.RolloutStatus{Strategy: scorev1b1.RolloutStrategyBlueGreen, Phase: scorev1b1.RolloutPhaseProgressing}, stable},
+		{"blue/green promotion switches traffic", &scorev1b1.RolloutStatus{Strategy: scorev1b1.RolloutStrategyBlueGreen, Phase: scorev1b1.RolloutPhasePromoting},
+			map[string]string{"app.kubernetes.io/name": "app", "app.kubernetes.io/instance": "app-canary"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &scorev1b1.WorkloadPlan{
+				Spec:   scorev1b1.WorkloadPlanSpec{WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"}},
+				Status: scorev1b1.WorkloadPlanStatus{Rollout: tt.rollout},
+			}
+			if got := serviceSelector(plan); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("serviceSelector() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestReconcileCanaryRollout(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	key := types.NamespacedName{Name: "app", Namespace: "default"}
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx:2"}},
+		},
+	}
+	plan := &scorev1b1.WorkloadPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default", Generation: 3, Finalizers: []string{kubernetesRuntimeFinalizer}},
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef:  scorev1b1.WorkloadPlanWorkloadRef{Name: "app", Namespace: "default"},
+			RuntimeClass: kubernetesRuntimeClass,
+			Template: &scorev1b1.TemplateSpec{Values: &runtime.RawExtension{Raw: []byte(
+				`{"rollout":{"strategy":"Canary","steps":[{"weight":50}]}}`,
+			)}},
+		},
+	}
+	stable := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "app", Namespace: "default",
+			Labels:      runtimeLabels("app"),
+			Annotations: map[string]string{annotationRolloutRevision: "previous"},
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: ptr.To(int32(1)),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app.kubernetes.io/name": "app", "app.kubernetes.io/instance": "app"}},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: runtimeLabels("app")},
+				Spec:       corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: "nginx:1"}}},
+			},
+		},
+	}
+
+	c := applyingClientBuilder(scheme).
+		WithObjects(workload, plan, stable).
+		WithStatusSubresource(&scorev1b1.WorkloadPlan{}, &appsv1.Deployment{}).
+		Build()
+	r := &KubernetesRuntimePlanReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(20)}
+
+	reconcileAndGet := func() *scorev1b1.WorkloadPlan {
+		t.Helper()
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		got := &scorev1b1.WorkloadPlan{}
+		if err := c.Get(ctx, key, got); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+	markRolledOut := func(name string) {
+		t.Helper()
+		deployment := &appsv1.Deployment{}
+		if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, deployment); err != nil {
+			t.Fatal(err)
+		}
+		deployment.Status = appsv1.DeploymentStatus{
+			ObservedGeneration: deployment.Generation,
+			Replicas:           *deployment.Spec.Replicas,
+			UpdatedReplicas:    *deployment.Spec.Replicas,
+			ReadyReplicas:      *deployment.Spec.Replicas,
+		}
+		if err := c.Status().Update(ctx, deployment); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stableImage := func() string {
+		t.Helper()
+		deployment := &appsv1.Deployment{}
+		if err := c.Get(ctx, key, deployment); err != nil {
+			t.Fatal(err)
+		}
+		return deployment.Spec.Template.Spec.Containers[0].Image
+	}
+
+	got := reconcileAndGet()
+	if got.Status.Rollout == nil || got.Status.Rollout.Phase != scorev1b1.RolloutPhaseProgressing || got.Status.Rollout.Weight != 50 {
+		t.Fatalf("rollout = %+v, want a progressing canary at 50%%", got.Status.Rollout)
+	}
+	if got.Status.Phase != scorev1b1.WorkloadPlanPhaseProvisioning {
+		t.Errorf("plan phase = %s during the canary, want %s", got.Status.Phase, scorev1b1.WorkloadPlanPhaseProvisioning)
+	}
+	if got.Status.ObservedGeneration != 3 {
+		t.Errorf("plan observedGeneration = %d, want the plan generation 3", got.Status.ObservedGeneration)
+	}
+	canary := &appsv1.Deployment{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "app-canary", Namespace: "default"}, canary); err != nil {
+		t.Fatalf("canary deployment was not created: %v", err)
+	}
+	if image := canary.Spec.Template.Spec.Containers[0].Image; image != "nginx:2" {
+		t.Errorf("canary image = %s, want nginx:2", image)
+	}
+	if image := stableImage(); image != "nginx:1" {
+		t.Errorf("stable image = %s during the canary, want nginx:1", image)
+	}
+
+	markRolledOut("app-canary")
+	got = reconcileAndGet()
+	if got.Status.Rollout.Phase != scorev1b1.RolloutPhasePromoting {
+		t.Fatalf("rollout phase = %s after the last step, want %s", got.Status.Rollout.Phase, scorev1b1.RolloutPhasePromoting)
+	}
+	if image := stableImage(); image != "nginx:2" {
+		t.Errorf("stable image = %s after promotion, want nginx:2", image)
+	}
+
+	markRolledOut("app")
+	got = reconcileAndGet()
+	if got.Status.Rollout.Phase != scorev1b1.RolloutPhaseCompleted {
+		t.Fatalf("rollout phase = %s, want %s", got.Status.Rollout.Phase, scorev1b1.RolloutPhaseCompleted)
+	}
+	if got.Status.Phase != scorev1b1.WorkloadPlanPhaseReady {
+		t.Errorf("plan phase = %s after promotion, want %s", got.Status.Phase, scorev1b1.WorkloadPlanPhaseReady)
+	}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(canary), &appsv1.Deployment{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected canary deployment to be deleted, got err = %v", err)
+	}
+}
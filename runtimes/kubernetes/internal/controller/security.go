@@ -0,0 +1,49 @@
+package controller
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// podSecurityContext converts the security context resolved by the Orchestrator into a pod security context.
+// It returns nil when the Workload opted out of the security defaults.
+func podSecurityContext(plan *scorev1b1.WorkloadPlan) *corev1.PodSecurityContext {
+	securityContext := plan.Spec.SecurityContext
+	if securityContext == nil {
+		return nil
+	}
+
+	podSecurityContext := &corev1.PodSecurityContext{
+		RunAsNonRoot: ptr.To(ptr.Deref(securityContext.RunAsNonRoot, true)),
+	}
+	if securityContext.SeccompProfile != "" {
+		podSecurityContext.SeccompProfile = &corev1.SeccompProfile{
+			Type: corev1.SeccompProfileType(securityContext.SeccompProfile),
+		}
+	}
+	return podSecurityContext
+}
+
+// containerSecurityContext converts the security context resolved by the Orchestrator into a container
+// security context. Privilege escalation is always disallowed, as the "restricted" Pod Security Standard requires.
+// It returns nil when the Workload opted out of the security defaults.
+func containerSecurityContext(plan *scorev1b1.WorkloadPlan) *corev1.SecurityContext {
+	securityContext := plan.Spec.SecurityContext
+	if securityContext == nil {
+		return nil
+	}
+
+	containerSecurityContext := &corev1.SecurityContext{
+		AllowPrivilegeEscalation: ptr.To(false),
+		ReadOnlyRootFilesystem:   ptr.To(ptr.Deref(securityContext.ReadOnlyRootFilesystem, false)),
+	}
+	if len(securityContext.DropCapabilities) > 0 {
+		containerSecurityContext.Capabilities = &corev1.Capabilities{}
+		for _, capability := range securityContext.DropCapabilities {
+			containerSecurityContext.Capabilities.Drop = append(containerSecurityContext.Capabilities.Drop, corev1.Capability(capability))
+		}
+	}
+	return containerSecurityContext
+}
@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/utils/ptr"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestBuildDeploymentSecurityContext(t *testing.T) {
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"main": {Image: "nginx"}, "sidecar": {Image: "envoy"}},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		securityContext *scorev1b1.SecurityContextSpec
+	}{
+		{
+			"restricted defaults",
+			&scorev1b1.SecurityContextSpec{
+				RunAsNonRoot:           ptr.To(true),
+				SeccompProfile:         scorev1b1.SeccompProfileRuntimeDefault,
+				DropCapabilities:       []string{"ALL"},
+				ReadOnlyRootFilesystem: ptr.To(true),
+			},
+		},
+		{"opted out", nil},
+	}
+
+	r := &KubernetesRuntimePlanReconciler{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &scorev1b1.WorkloadPlan{
+				Spec: scorev1b1.WorkloadPlanSpec{
+					WorkloadRef:     scorev1b1.WorkloadPlanWorkloadRef{Name: "web", Namespace: "default"},
+					SecurityContext: tt.securityContext,
+				},
+			}
+
+			deployment, err := r.buildDeployment(context.Background(), plan, workload)
+			if err != nil {
+				t.Fatalf("buildDeployment() error = %v", err)
+			}
+
+			podSpec := deployment.Spec.Template.Spec
+			if tt.securityContext == nil {
+				if podSpec.SecurityContext != nil {
+					t.Errorf("pod securityContext = %+v, want nil", podSpec.SecurityContext)
+				}
+				for _, container := range podSpec.Containers {
+					if container.SecurityContext != nil {
+						t.Errorf("container %s securityContext = %+v, want nil", container.Name, container.SecurityContext)
+					}
+				}
+				return
+			}
+
+			if podSpec.SecurityContext == nil || !ptr.Deref(podSpec.SecurityContext.RunAsNonRoot, false) {
+				t.Fatalf("pod securityContext = %+v, want runAsNonRoot", podSpec.SecurityContext)
+			}
+			if seccomp := podSpec.SecurityContext.SeccompProfile; seccomp == nil || seccomp.Type != corev1.SeccompProfileTypeRuntimeDefault {
+				t.Errorf("seccompProfile = %+v, want RuntimeDefault", seccomp)
+			}
+			for _, container := range podSpec.Containers {
+				securityContext := container.SecurityContext
+				if securityContext == nil {
+					t.Fatalf("container %s has no securityContext", container.Name)
+				}
+				if ptr.Deref(securityContext.AllowPrivilegeEscalation, true) {
+					t.Errorf("container %s allows privilege escalation", container.Name)
+				}
+				if !ptr.Deref(securityContext.ReadOnlyRootFilesystem, false) {
+					t.Errorf("container %s root filesystem is writable", container.Name)
+				}
+				if securityContext.Capabilities == nil || len(securityContext.Capabilities.Drop) != 1 || securityContext.Capabilities.Drop[0] != "ALL" {
+					t.Errorf("container %s capabilities = %+v, want drop ALL", container.Name, securityContext.Capabilities)
+				}
+			}
+		})
+	}
+}
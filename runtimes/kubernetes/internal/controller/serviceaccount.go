@@ -0,0 +1,129 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+)
+
+// reconcileServiceAccount applies the ServiceAccount declared by the Workload with server-side apply and
+// deletes ServiceAccounts created for it earlier that are no longer wanted (renamed or create: false).
+// An existing ServiceAccount without the runtime labels belongs to someone else and is never adopted.
+func (r *KubernetesRuntimePlanReconciler) reconcileServiceAccount(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) error {
+	keep := ""
+	if spec := workload.Spec.ServiceAccount; spec != nil && ptr.Deref(spec.Create, true) {
+		serviceAccount, err := buildServiceAccount(plan, workload)
+		if err != nil {
+			return err
+		}
+
+		existing := &corev1.ServiceAccount{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(serviceAccount), existing); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to get service account: %w", err)
+			}
+		} else if !isMaterializedFor(existing, plan) {
+			return fmt.Errorf("service account %s already exists and is not managed by the runtime; "+
+				"set serviceAccount.create to false to use it", serviceAccount.Name)
+		}
+
+		// Set WorkloadPlan as owner for garbage collection
+		if err := r.setOwner(plan, serviceAccount); err != nil {
+			return fmt.Errorf("failed to set controller reference: %w", err)
+		}
+
+		if err := reconcile.Apply(ctx, r.Client, serviceAccount, meta.FieldManagerRuntimeKubernetes); err != nil {
+			return fmt.Errorf("failed to apply service account: %w", err)
+		}
+		log.FromContext(ctx).V(1).Info("Applied ServiceAccount", "name", serviceAccount.Name)
+		keep = serviceAccount.Name
+	}
+
+	return r.deleteServiceAccounts(ctx, plan, keep)
+}
+
+// buildServiceAccount constructs the ServiceAccount declared by the Workload
+func buildServiceAccount(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) (*corev1.ServiceAccount, error) {
+	resolved, err := resolvedServiceAccount(plan)
+	if err != nil {
+		return nil, err
+	}
+	objectMeta := runtimeObjectMeta(plan, workload)
+	objectMeta.Name = resolved.Name
+	for key, value := range resolved.Annotations {
+		objectMeta.Annotations[key] = value
+	}
+
+	return &corev1.ServiceAccount{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "ServiceAccount",
+		},
+		ObjectMeta: objectMeta,
+	}, nil
+}
+
+// deleteServiceAccounts deletes the ServiceAccounts materialized for the plan except the one named keep.
+// ServiceAccounts may be named independently of the Workload, so they are found by runtime labels.
+func (r *KubernetesRuntimePlanReconciler) deleteServiceAccounts(ctx context.Context, plan *scorev1b1.WorkloadPlan, keep string) error {
+	serviceAccounts := &corev1.ServiceAccountList{}
+	if err := r.List(ctx, serviceAccounts,
+		client.InNamespace(materializedNamespace(plan)),
+		client.MatchingLabels{
+			"score.dev/runtime": kubernetesRuntimeClass,
+			meta.LabelWorkload:  plan.Spec.WorkloadRef.Name,
+		},
+	); err != nil {
+		return fmt.Errorf("failed to list service accounts: %w", err)
+	}
+
+	for i := range serviceAccounts.Items {
+		serviceAccount := &serviceAccounts.Items[i]
+		if serviceAccount.Name == keep || !serviceAccount.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, serviceAccount); client.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete service account %s: %w", serviceAccount.Name, err)
+		}
+		log.FromContext(ctx).Info("Deleted runtime resource", "kind", "ServiceAccount", "name", serviceAccount.Name)
+	}
+	return nil
+}
+
+// serviceAccountName returns the ServiceAccount name resolved in WorkloadPlan.ResolvedValues,
+// or an empty string when the Workload does not declare one (the namespace default is used)
+func serviceAccountName(plan *scorev1b1.WorkloadPlan) (string, error) {
+	resolved, err := resolvedServiceAccount(plan)
+	return resolved.Name, err
+}
+
+// resolvedServiceAccountValues is the serviceAccount section of WorkloadPlan.ResolvedValues
+type resolvedServiceAccountValues struct {
+	Name        string            `json:"name"`
+	Annotations map[string]string `json:"annotations"`
+}
+
+// resolvedServiceAccount returns the ServiceAccount name and annotations with placeholders resolved
+func resolvedServiceAccount(plan *scorev1b1.WorkloadPlan) (resolvedServiceAccountValues, error) {
+	var resolvedValues struct {
+		ServiceAccount resolvedServiceAccountValues `json:"serviceAccount"`
+	}
+	if plan.Spec.ResolvedValues == nil {
+		return resolvedValues.ServiceAccount, nil
+	}
+	if err := json.Unmarshal(plan.Spec.ResolvedValues.Raw, &resolvedValues); err != nil {
+		return resolvedServiceAccountValues{}, fmt.Errorf("failed to unmarshal resolved service account: %w", err)
+	}
+	return resolvedValues.ServiceAccount, nil
+}
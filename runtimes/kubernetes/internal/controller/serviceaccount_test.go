@@ -0,0 +1,137 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestServiceAccountName(t *testing.T) {
+	tests := []struct {
+		name           string
+		resolvedValues *runtime.RawExtension
+		want           string
+		wantErr        bool
+	}{
+		{"no resolved values", nil, "", false},
+		{"no service account", &runtime.RawExtension{Raw: []byte(`{"containers":{}}`)}, "", false},
+		{"resolved service account", &runtime.RawExtension{Raw: []byte(`{"serviceAccount":{"name":"web-reader"}}`)}, "web-reader", false},
+		{"malformed resolved values", &runtime.RawExtension{Raw: []byte(`{"serviceAccount":"web-reader"}`)}, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := &scorev1b1.WorkloadPlan{Spec: scorev1b1.WorkloadPlanSpec{ResolvedValues: tt.resolvedValues}}
+			got, err := serviceAccountName(plan)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("serviceAccountName() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("serviceAccountName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildServiceAccount(t *testing.T) {
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "web", Namespace: "default"},
+			ResolvedValues: &runtime.RawExtension{Raw: []byte(
+				`{"serviceAccount":{"name":"web-reader","annotations":{"eks.amazonaws.com/role-arn":"arn:aws:iam::123456789012:role/web"}}}`,
+			)},
+		},
+	}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			ServiceAccount: &scorev1b1.ServiceAccountSpec{
+				Name:        "web-reader",
+				Annotations: map[string]string{"eks.amazonaws.com/role-arn": "${resources.role.outputs.arn}"},
+			},
+		},
+	}
+
+	serviceAccount, err := buildServiceAccount(plan, workload)
+	if err != nil {
+		t.Fatalf("buildServiceAccount() error = %v", err)
+	}
+
+	if serviceAccount.Name != "web-reader" || serviceAccount.Namespace != "default" {
+		t.Errorf("serviceAccount = %s/%s, want default/web-reader", serviceAccount.Namespace, serviceAccount.Name)
+	}
+	if serviceAccount.Annotations["eks.amazonaws.com/role-arn"] != "arn:aws:iam::123456789012:role/web" {
+		t.Errorf("annotations = %v, want the resolved IRSA role annotation", serviceAccount.Annotations)
+	}
+	if serviceAccount.Labels["score.dev/workload"] != "web" {
+		t.Errorf("labels = %v, want runtime labels of workload web", serviceAccount.Labels)
+	}
+}
+
+func TestReconcileServiceAccountDeletesUnwanted(t *testing.T) {
+	runtimeLabels := map[string]string{"score.dev/runtime": "kubernetes", "score.dev/workload": "web"}
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "web", Namespace: "default"},
+		},
+	}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			ServiceAccount: &scorev1b1.ServiceAccountSpec{Name: "shared", Create: ptr.To(false)},
+		},
+	}
+
+	created := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Labels: runtimeLabels}}
+	shared := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "default"}}
+	c := fake.NewClientBuilder().WithObjects(created, shared).Build()
+	r := &KubernetesRuntimePlanReconciler{Client: c}
+
+	if err := r.reconcileServiceAccount(context.Background(), plan, workload); err != nil {
+		t.Fatalf("reconcileServiceAccount() error = %v", err)
+	}
+
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "web", Namespace: "default"}, &corev1.ServiceAccount{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected previously created ServiceAccount to be deleted, got err = %v", err)
+	}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "shared", Namespace: "default"}, &corev1.ServiceAccount{}); err != nil {
+		t.Errorf("expected existing ServiceAccount to be kept, got err = %v", err)
+	}
+}
+
+func TestReconcileServiceAccountRefusesAdoption(t *testing.T) {
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef:    scorev1b1.WorkloadPlanWorkloadRef{Name: "web", Namespace: "default"},
+			ResolvedValues: &runtime.RawExtension{Raw: []byte(`{"serviceAccount":{"name":"shared"}}`)},
+		},
+	}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{ServiceAccount: &scorev1b1.ServiceAccountSpec{Name: "shared"}},
+	}
+
+	shared := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{
+		Name: "shared", Namespace: "default", Annotations: map[string]string{"owner": "platform"},
+	}}
+	c := fake.NewClientBuilder().WithObjects(shared).Build()
+	r := &KubernetesRuntimePlanReconciler{Client: c}
+
+	if err := r.reconcileServiceAccount(context.Background(), plan, workload); err == nil {
+		t.Fatal("reconcileServiceAccount() should refuse to adopt a ServiceAccount without runtime labels")
+	}
+
+	got := &corev1.ServiceAccount{}
+	if err := c.Get(context.Background(), types.NamespacedName{Name: "shared", Namespace: "default"}, got); err != nil {
+		t.Fatalf("expected existing ServiceAccount to be kept, got err = %v", err)
+	}
+	if len(got.Labels) != 0 || got.Annotations["owner"] != "platform" {
+		t.Errorf("existing ServiceAccount was modified: labels %v, annotations %v", got.Labels, got.Annotations)
+	}
+}
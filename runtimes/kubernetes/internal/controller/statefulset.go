@@ -0,0 +1,259 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/reconcile"
+)
+
+// headlessServiceSuffix names the governing Service of a StatefulSet. The Workload Service keeps the
+// plain name because a headless Service cannot also allocate the ClusterIP clients connect to.
+const headlessServiceSuffix = "-headless"
+
+// headlessServiceName returns the name of the governing Service for the Workload
+func headlessServiceName(name string) string {
+	return name + headlessServiceSuffix
+}
+
+// headlessServiceRef returns an empty Service carrying the key of the plan's governing Service
+func headlessServiceRef(plan *scorev1b1.WorkloadPlan) *corev1.Service {
+	return &corev1.Service{ObjectMeta: metav1.ObjectMeta{
+		Name:      headlessServiceName(materializedName(plan)),
+		Namespace: materializedNamespace(plan),
+	}}
+}
+
+// reconcileStatefulSet applies the StatefulSet and its governing headless Service with server-side apply.
+// Changes to the propagated metadata alone are kept off the pod template, as for Deployments. Volume claim templates cannot be changed once the StatefulSet exists, so changes to
+// spec.storage are reported as an event and the existing templates are kept.
+func (r *KubernetesRuntimePlanReconciler) reconcileStatefulSet(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) error {
+	// The governing Service gives each pod a stable DNS name and must exist for the StatefulSet to use it
+	headless := buildHeadlessService(plan, workload)
+	if err := r.setOwner(plan, headless); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+	if err := reconcile.Apply(ctx, r.Client, headless, meta.FieldManagerRuntimeKubernetes); err != nil {
+		return fmt.Errorf("failed to apply headless service: %w", err)
+	}
+
+	statefulSet, err := r.buildStatefulSet(ctx, plan, workload)
+	if err != nil {
+		return fmt.Errorf("failed to build statefulset: %w", err)
+	}
+	revision, err := templateRevision(plan, &statefulSet.Spec.Template)
+	if err != nil {
+		return err
+	}
+	statefulSet.Annotations[annotationRolloutRevision] = revision
+
+	// Set WorkloadPlan as owner for garbage collection
+	if err := r.setOwner(plan, statefulSet); err != nil {
+		return fmt.Errorf("failed to set controller reference: %w", err)
+	}
+
+	existing := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(statefulSet), existing); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get statefulset: %w", err)
+		}
+	} else {
+		// Leave replicas to an autoscaler (or any other manager) once it has taken them over
+		if replicasManagedByOthers(existing.ManagedFields) {
+			statefulSet.Spec.Replicas = nil
+		}
+		keepPodTemplateMetadata(&statefulSet.Spec.Template, &existing.Spec.Template, revision, existing.Annotations[annotationRolloutRevision])
+		if !volumeClaimTemplatesMatch(existing.Spec.VolumeClaimTemplates, statefulSet.Spec.VolumeClaimTemplates) {
+			r.Recorder.Event(plan, corev1.EventTypeWarning, "StorageImmutable",
+				"Persistent volumes cannot be changed after creation; recreate the Workload to apply spec.storage changes")
+			statefulSet.Spec.VolumeClaimTemplates = existingVolumeClaimTemplates(existing.Spec.VolumeClaimTemplates)
+		}
+	}
+
+	if err := reconcile.Apply(ctx, r.Client, statefulSet, meta.FieldManagerRuntimeKubernetes); err != nil {
+		return fmt.Errorf("failed to apply statefulset: %w", err)
+	}
+	log.FromContext(ctx).V(1).Info("Applied StatefulSet", "name", statefulSet.Name)
+
+	return nil
+}
+
+// buildStatefulSet constructs a StatefulSet with one volume claim template per per-replica volume of the Workload
+func (r *KubernetesRuntimePlanReconciler) buildStatefulSet(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) (*appsv1.StatefulSet, error) {
+	name := plan.Spec.WorkloadRef.Name
+
+	// Default replicas to 1 if not specified
+	replicas := int32(1)
+
+	containers, err := r.buildContainers(ctx, plan, workload)
+	if err != nil {
+		return nil, err
+	}
+	serviceAccount, err := serviceAccountName(plan)
+	if err != nil {
+		return nil, err
+	}
+
+	var claimTemplates []corev1.PersistentVolumeClaim
+	if workload.Spec.Storage != nil {
+		for _, volume := range workload.Spec.Storage.Volumes {
+			// Shared volumes are mounted from their claim rather than templated per replica
+			if volume.Source != "" {
+				continue
+			}
+			size, err := resource.ParseQuantity(volume.Size)
+			if err != nil {
+				return nil, fmt.Errorf("invalid size %s for volume %s: %w", volume.Size, volume.Name, err)
+			}
+			claimTemplates = append(claimTemplates, corev1.PersistentVolumeClaim{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: volume.Name,
+				},
+				Spec: corev1.PersistentVolumeClaimSpec{
+					AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+					Resources: corev1.VolumeResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceStorage: size},
+					},
+				},
+			})
+			for i := range containers {
+				containers[i].VolumeMounts = append(containers[i].VolumeMounts, corev1.VolumeMount{
+					Name:      volume.Name,
+					MountPath: volume.Target,
+					ReadOnly:  volume.ReadOnly,
+				})
+			}
+		}
+	}
+
+	statefulSet := &appsv1.StatefulSet{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: appsv1.SchemeGroupVersion.String(),
+			Kind:       "StatefulSet",
+		},
+		ObjectMeta: runtimeObjectMeta(plan, workload),
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:    &replicas,
+			ServiceName: headlessServiceName(materializedName(plan)),
+			Selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					"app.kubernetes.io/name":     name,
+					"app.kubernetes.io/instance": name,
+				},
+			},
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: podTemplateMeta(plan),
+				Spec: corev1.PodSpec{
+					Containers:         containers,
+					ServiceAccountName: serviceAccount,
+					SecurityContext:    podSecurityContext(plan),
+					PriorityClassName:  priorityClassName(plan),
+				},
+			},
+			VolumeClaimTemplates: claimTemplates,
+		},
+	}
+
+	files, err := buildFileProjection(plan, workload)
+	if err != nil {
+		return nil, err
+	}
+	files.applyTo(&statefulSet.Spec.Template)
+	if err := applySharedVolumes(plan, workload, &statefulSet.Spec.Template); err != nil {
+		return nil, err
+	}
+
+	return statefulSet, nil
+}
+
+// buildHeadlessService constructs the headless Service that governs the StatefulSet pods.
+// Not-ready pods are published so peers can discover each other while they start up.
+func buildHeadlessService(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) *corev1.Service {
+	name := plan.Spec.WorkloadRef.Name
+	objectMeta := runtimeObjectMeta(plan, workload)
+	objectMeta.Name = headlessServiceName(objectMeta.Name)
+
+	return &corev1.Service{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Service",
+		},
+		ObjectMeta: objectMeta,
+		Spec: corev1.ServiceSpec{
+			ClusterIP:                corev1.ClusterIPNone,
+			PublishNotReadyAddresses: true,
+			Selector: map[string]string{
+				"app.kubernetes.io/name":     name,
+				"app.kubernetes.io/instance": name,
+			},
+		},
+	}
+}
+
+// volumeClaimTemplatesMatch reports whether the existing templates request the same volumes and sizes
+func volumeClaimTemplatesMatch(existing, desired []corev1.PersistentVolumeClaim) bool {
+	if len(existing) != len(desired) {
+		return false
+	}
+	sizes := make(map[string]resource.Quantity, len(existing))
+	for _, claim := range existing {
+		sizes[claim.Name] = claim.Spec.Resources.Requests[corev1.ResourceStorage]
+	}
+	for _, claim := range desired {
+		size, ok := sizes[claim.Name]
+		if !ok || size.Cmp(claim.Spec.Resources.Requests[corev1.ResourceStorage]) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// existingVolumeClaimTemplates returns the existing templates reduced to the fields the runtime declares
+func existingVolumeClaimTemplates(existing []corev1.PersistentVolumeClaim) []corev1.PersistentVolumeClaim {
+	templates := make([]corev1.PersistentVolumeClaim, 0, len(existing))
+	for _, claim := range existing {
+		templates = append(templates, corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: claim.Name},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				AccessModes: claim.Spec.AccessModes,
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: claim.Spec.Resources.Requests[corev1.ResourceStorage]},
+				},
+			},
+		})
+	}
+	return templates
+}
+
+// setStatefulSetStatus derives the plan phase from StatefulSet readiness
+func (r *KubernetesRuntimePlanReconciler) setStatefulSetStatus(ctx context.Context, key types.NamespacedName, plan *scorev1b1.WorkloadPlan) error {
+	statefulSet := &appsv1.StatefulSet{}
+	if err := r.Get(ctx, key, statefulSet); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get statefulset: %w", err)
+		}
+		plan.Status.Phase = scorev1b1.WorkloadPlanPhaseProvisioning
+		plan.Status.Message = "Runtime statefulset is being created"
+		return nil
+	}
+
+	if statefulSet.Status.ReadyReplicas > 0 && statefulSet.Status.ReadyReplicas == statefulSet.Status.Replicas {
+		plan.Status.Phase = scorev1b1.WorkloadPlanPhaseReady
+		plan.Status.Message = "Runtime resources are ready"
+	} else {
+		plan.Status.Phase = scorev1b1.WorkloadPlanPhaseProvisioning
+		plan.Status.Message = "Runtime statefulset is starting up"
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestBuildStatefulSet(t *testing.T) {
+	r := &KubernetesRuntimePlanReconciler{}
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "db", Namespace: "default"},
+		},
+	}
+	workload := &scorev1b1.Workload{
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"main": {Image: "postgres"}, "exporter": {Image: "exporter"}},
+			Storage: &scorev1b1.StorageSpec{Volumes: []scorev1b1.PersistentVolumeSpec{
+				{Name: "data", Size: "10Gi", Target: "/var/lib/postgresql/data"},
+				{Name: "config", Size: "1Gi", Target: "/etc/postgresql", ReadOnly: true},
+			}},
+		},
+	}
+
+	statefulSet, err := r.buildStatefulSet(context.Background(), plan, workload)
+	if err != nil {
+		t.Fatalf("buildStatefulSet() error = %v", err)
+	}
+
+	if statefulSet.Spec.ServiceName != "db-headless" {
+		t.Errorf("serviceName = %q, want %q", statefulSet.Spec.ServiceName, "db-headless")
+	}
+	templates := statefulSet.Spec.VolumeClaimTemplates
+	if len(templates) != 2 || templates[0].Name != "data" || templates[1].Name != "config" {
+		t.Fatalf("volumeClaimTemplates = %v, want data and config", templates)
+	}
+	if size := templates[0].Spec.Resources.Requests[corev1.ResourceStorage]; size.Cmp(resource.MustParse("10Gi")) != 0 {
+		t.Errorf("data size = %s, want 10Gi", size.String())
+	}
+	for _, container := range statefulSet.Spec.Template.Spec.Containers {
+		if len(container.VolumeMounts) != 2 {
+			t.Errorf("container %s has %d volume mounts, want 2", container.Name, len(container.VolumeMounts))
+			continue
+		}
+		if !container.VolumeMounts[1].ReadOnly {
+			t.Errorf("container %s mounts config read-write, want read-only", container.Name)
+		}
+	}
+
+	workload.Spec.Storage.Volumes[0].Size = "ten gigs"
+	if _, err := r.buildStatefulSet(context.Background(), plan, workload); err == nil {
+		t.Error("buildStatefulSet() with an invalid size should fail")
+	}
+}
+
+func TestBuildHeadlessService(t *testing.T) {
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "db", Namespace: "default"},
+		},
+	}
+
+	service := buildHeadlessService(plan, &scorev1b1.Workload{})
+
+	if service.Name != "db-headless" || service.Namespace != "default" {
+		t.Errorf("service = %s/%s, want default/db-headless", service.Namespace, service.Name)
+	}
+	if service.Spec.ClusterIP != corev1.ClusterIPNone {
+		t.Errorf("clusterIP = %q, want %q", service.Spec.ClusterIP, corev1.ClusterIPNone)
+	}
+	if !isMaterializedFor(service, plan) {
+		t.Error("headless service should carry the runtime labels of the plan")
+	}
+	if service.Spec.Selector["app.kubernetes.io/instance"] != "db" {
+		t.Errorf("selector = %v, want the Workload pods", service.Spec.Selector)
+	}
+}
+
+func TestVolumeClaimTemplatesMatch(t *testing.T) {
+	claim := func(name, size string) corev1.PersistentVolumeClaim {
+		return corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: corev1.PersistentVolumeClaimSpec{
+				Resources: corev1.VolumeResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceStorage: resource.MustParse(size)},
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		existing []corev1.PersistentVolumeClaim
+		desired  []corev1.PersistentVolumeClaim
+		want     bool
+	}{
+		{"same volumes", []corev1.PersistentVolumeClaim{claim("data", "10Gi")}, []corev1.PersistentVolumeClaim{claim("data", "10Gi")}, true},
+		{"equal quantities", []corev1.PersistentVolumeClaim{claim("data", "1Gi")}, []corev1.PersistentVolumeClaim{claim("data", "1024Mi")}, true},
+		{"resized", []corev1.PersistentVolumeClaim{claim("data", "10Gi")}, []corev1.PersistentVolumeClaim{claim("data", "20Gi")}, false},
+		{"renamed", []corev1.PersistentVolumeClaim{claim("data", "10Gi")}, []corev1.PersistentVolumeClaim{claim("state", "10Gi")}, false},
+		{"added", []corev1.PersistentVolumeClaim{claim("data", "10Gi")}, []corev1.PersistentVolumeClaim{claim("data", "10Gi"), claim("logs", "1Gi")}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := volumeClaimTemplatesMatch(tt.existing, tt.desired); got != tt.want {
+				t.Errorf("volumeClaimTemplatesMatch() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
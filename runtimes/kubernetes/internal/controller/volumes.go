@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+// resolvedVolume is an entry of storage.volumes in WorkloadPlan.ResolvedValues: a shared volume of the
+// Workload mounted from the PersistentVolumeClaim named Source
+type resolvedVolume struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+}
+
+// hasPerReplicaVolumes reports whether the Workload declares volumes each replica gets its own copy of
+func hasPerReplicaVolumes(workload *scorev1b1.Workload) bool {
+	if workload.Spec.Storage == nil {
+		return false
+	}
+	for _, volume := range workload.Spec.Storage.Volumes {
+		if volume.Source == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// sharedVolumeSources returns the claim each shared volume is mounted from, by volume name, as resolved in
+// WorkloadPlan.ResolvedValues, falling back to the Workload spec when no values were resolved
+func sharedVolumeSources(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) (map[string]string, error) {
+	sources := make(map[string]string)
+	if workload.Spec.Storage == nil {
+		return sources, nil
+	}
+
+	if plan.Spec.ResolvedValues == nil {
+		for _, volume := range workload.Spec.Storage.Volumes {
+			if volume.Source != "" {
+				sources[volume.Name] = volume.Source
+			}
+		}
+		return sources, nil
+	}
+
+	var resolvedValues struct {
+		Storage struct {
+			Volumes []resolvedVolume `json:"volumes"`
+		} `json:"storage"`
+	}
+	if err := json.Unmarshal(plan.Spec.ResolvedValues.Raw, &resolvedValues); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal resolved volumes: %w", err)
+	}
+	for _, volume := range resolvedValues.Storage.Volumes {
+		sources[volume.Name] = volume.Source
+	}
+	return sources, nil
+}
+
+// applySharedVolumes mounts the shared volumes of the Workload into every container of the pod template.
+// PersistentVolumeClaims cannot be referenced across namespaces, so shared volumes are rejected for plans
+// materialized outside the Workload namespace.
+func applySharedVolumes(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload, template *corev1.PodTemplateSpec) error {
+	sources, err := sharedVolumeSources(plan, workload)
+	if err != nil {
+		return err
+	}
+	if workload.Spec.Storage == nil {
+		return nil
+	}
+
+	for _, volume := range workload.Spec.Storage.Volumes {
+		if volume.Source == "" {
+			continue
+		}
+		source, ok := sources[volume.Name]
+		if !ok {
+			return fmt.Errorf("volume %s has no resolved source", volume.Name)
+		}
+		if materializedNamespace(plan) != workload.Namespace {
+			return fmt.Errorf("volume %s cannot be mounted in namespace %s, which differs from the Workload namespace %s",
+				volume.Name, materializedNamespace(plan), workload.Namespace)
+		}
+		template.Spec.Volumes = append(template.Spec.Volumes, corev1.Volume{
+			Name: volume.Name,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: source,
+					ReadOnly:  volume.ReadOnly,
+				},
+			},
+		})
+		for i := range template.Spec.Containers {
+			container := &template.Spec.Containers[i]
+			container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+				Name:      volume.Name,
+				MountPath: volume.Target,
+				ReadOnly:  volume.ReadOnly,
+			})
+		}
+	}
+	return nil
+}
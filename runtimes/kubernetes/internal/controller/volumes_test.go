@@ -0,0 +1,60 @@
+package controller
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+)
+
+func TestBuildDeploymentWithSharedVolume(t *testing.T) {
+	r := &KubernetesRuntimePlanReconciler{}
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef:    scorev1b1.WorkloadPlanWorkloadRef{Name: "web", Namespace: "default"},
+			ResolvedValues: &runtime.RawExtension{Raw: []byte(`{"storage":{"volumes":[{"name":"data","source":"web-data-volume"}]}}`)},
+		},
+	}
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"main": {Image: "nginx"}},
+			Storage: &scorev1b1.StorageSpec{Volumes: []scorev1b1.PersistentVolumeSpec{
+				{Name: "data", Source: "${resources.data.outputs.pvcRef}", Target: "/data", ReadOnly: true},
+			}},
+		},
+	}
+
+	if kind := materializedKind(plan, workload); kind != kindDeployment {
+		t.Errorf("materializedKind() = %q, want %q", kind, kindDeployment)
+	}
+
+	deployment, err := r.buildDeployment(context.Background(), plan, workload)
+	if err != nil {
+		t.Fatalf("buildDeployment() error = %v", err)
+	}
+	volumes := deployment.Spec.Template.Spec.Volumes
+	if len(volumes) != 1 || volumes[0].PersistentVolumeClaim == nil || volumes[0].PersistentVolumeClaim.ClaimName != "web-data-volume" {
+		t.Fatalf("volumes = %+v, want the claim web-data-volume", volumes)
+	}
+	mounts := deployment.Spec.Template.Spec.Containers[0].VolumeMounts
+	if len(mounts) != 1 || mounts[0].Name != "data" || mounts[0].MountPath != "/data" || !mounts[0].ReadOnly {
+		t.Errorf("volumeMounts = %+v, want data read-only at /data", mounts)
+	}
+
+	// Claims cannot be mounted from another namespace
+	plan.Spec.Namespace = "team-a-staging"
+	if _, err := r.buildDeployment(context.Background(), plan, workload); err == nil {
+		t.Error("buildDeployment() in an environment namespace should fail")
+	}
+
+	// A plan without the resolved source is not materialized without the volume
+	plan.Spec.Namespace = ""
+	plan.Spec.ResolvedValues = &runtime.RawExtension{Raw: []byte(`{}`)}
+	if _, err := r.buildDeployment(context.Background(), plan, workload); err == nil {
+		t.Error("buildDeployment() without a resolved source should fail")
+	}
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+
+	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
+	// to ensure that exec-entrypoint and run can make use of them.
+	_ "k8s.io/client-go/plugin/pkg/client/auth"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/events"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/runtimeregistry"
+	"github.com/cappyzawa/score-orchestrator/runtimes/local/internal/compose"
+	runtimectrl "github.com/cappyzawa/score-orchestrator/runtimes/local/internal/controller"
+)
+
+var (
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+
+	// version is published in the runtime registration; set at build time with -ldflags "-X main.version=..."
+	version = "dev"
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(scorev1b1.AddToScheme(scheme))
+}
+
+func main() {
+	var metricsAddr, probeAddr string
+	var projectsDir, network, docker string
+	var planConcurrency int
+	var registrationNamespace string
+
+	defaultProjectsDir := filepath.Join(os.TempDir(), "score-local")
+	if cacheDir, err := os.UserCacheDir(); err == nil {
+		defaultProjectsDir = filepath.Join(cacheDir, "score-orchestrator", "local")
+	}
+
+	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
+		"Use :8080 for HTTP, or leave as 0 to disable the metrics service.")
+	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8082", "The address the probe endpoint binds to.")
+	flag.StringVar(&projectsDir, "projects-dir", defaultProjectsDir,
+		"The directory the Docker Compose project of every WorkloadPlan is written to.")
+	flag.StringVar(&network, "network", "score-local",
+		"The Docker network shared by all Workloads, so that they reach each other by name.")
+	flag.StringVar(&docker, "docker", "docker", "The docker executable, which must provide the compose plugin.")
+	flag.IntVar(&planConcurrency, "plan-max-concurrent-reconciles", 1,
+		"The maximum number of WorkloadPlans reconciled in parallel.")
+	flag.StringVar(&registrationNamespace, "registration-namespace", "",
+		"The namespace of the ConfigMap that registers this runtime with the Orchestrator; registration is disabled when empty.")
+	opts := zap.Options{
+		Development: true,
+	}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                metricsserver.Options{BindAddress: metricsAddr},
+		HealthProbeBindAddress: probeAddr,
+		// A developer machine runs a single instance and projects live on its local disk
+		LeaderElection: false,
+	})
+	if err != nil {
+		setupLog.Error(err, "unable to start manager")
+		os.Exit(1)
+	}
+
+	setupLog.Info("Setting up WorkloadPlan Controller", "projectsDir", projectsDir, "network", network)
+	planController := &runtimectrl.LocalRuntimePlanReconciler{
+		Client:      mgr.GetClient(),
+		Scheme:      mgr.GetScheme(),
+		Recorder:    events.NewEmitter(mgr.GetEventRecorderFor("local-plan-controller"), events.DefaultOptions()),
+		Runner:      &compose.CLI{Docker: docker},
+		ProjectsDir: projectsDir,
+		Network:     network,
+
+		MaxConcurrentReconciles: planConcurrency,
+	}
+	if err := planController.SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "WorkloadPlan")
+		os.Exit(1)
+	}
+
+	// Register the runtime so the Orchestrator can select backends of its runtimeClass
+	if registrationNamespace != "" {
+		if err := mgr.Add(&runtimeregistry.Heartbeat{
+			Client:    mgr.GetClient(),
+			Namespace: registrationNamespace,
+			Name:      "score-runtime-" + meta.RuntimeClassDocker,
+			Registration: runtimeregistry.Registration{
+				RuntimeClass: meta.RuntimeClassDocker,
+				Version:      version,
+				Features:     []string{scorev1b1.WorkloadKindService, scorev1b1.WorkloadKindJob},
+			},
+			FieldManager: meta.FieldManagerRuntimeDocker,
+		}); err != nil {
+			setupLog.Error(err, "unable to register runtime")
+			os.Exit(1)
+		}
+	}
+
+	// Acknowledge the plans of the runtimeClass so the Orchestrator can tell this runtime is running
+	if err := mgr.Add(&runtimeregistry.PlanHeartbeat{
+		Client:          mgr.GetClient(),
+		RuntimeClass:    meta.RuntimeClassDocker,
+		ConsumedBy:      "score-runtime-" + meta.RuntimeClassDocker,
+		ConsumerVersion: version,
+	}); err != nil {
+		setupLog.Error(err, "unable to add plan heartbeat")
+		os.Exit(1)
+	}
+
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up health check")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up ready check")
+		os.Exit(1)
+	}
+
+	setupLog.Info("starting local Docker Runtime Controller manager")
+	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+		setupLog.Error(err, "problem running manager")
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,155 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Container states reported by Docker
+const (
+	StateRunning    = "running"
+	StateExited     = "exited"
+	StateRestarting = "restarting"
+)
+
+// Container health states reported by Docker
+const (
+	HealthStarting  = "starting"
+	HealthUnhealthy = "unhealthy"
+)
+
+// ContainerState is the state of a container of a project
+type ContainerState struct {
+	Service  string `json:"Service"`
+	State    string `json:"State"`
+	Health   string `json:"Health"`
+	ExitCode int    `json:"ExitCode"`
+}
+
+// Runner runs the Compose projects written to disk
+type Runner interface {
+	// EnsureNetwork creates the named Docker network unless it exists
+	EnsureNetwork(ctx context.Context, name string) error
+	// Up creates or updates the containers of the project in dir
+	Up(ctx context.Context, dir, project string) error
+	// Down removes the containers and volumes of the project
+	Down(ctx context.Context, dir, project string) error
+	// Status returns the state of every container of the project, including stopped ones
+	Status(ctx context.Context, dir, project string) ([]ContainerState, error)
+}
+
+// CLI is a Runner that invokes the docker CLI with the compose plugin
+type CLI struct {
+	// Docker is the docker executable (default "docker")
+	Docker string
+}
+
+var _ Runner = &CLI{}
+
+// EnsureNetwork creates the named Docker network unless it exists
+func (c *CLI) EnsureNetwork(ctx context.Context, name string) error {
+	if _, err := c.run(ctx, "network", "inspect", name); err == nil {
+		return nil
+	}
+	if _, err := c.run(ctx, "network", "create", name); err != nil {
+		// Another project may have created the network in the meantime
+		if _, inspectErr := c.run(ctx, "network", "inspect", name); inspectErr == nil {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+// Up creates or updates the containers of the project in dir, removing those of services it no longer declares
+func (c *CLI) Up(ctx context.Context, dir, project string) error {
+	_, err := c.run(ctx, c.composeArgs(dir, project, "up", "--detach", "--remove-orphans")...)
+	return err
+}
+
+// Down removes the containers and volumes of the project
+func (c *CLI) Down(ctx context.Context, dir, project string) error {
+	_, err := c.run(ctx, c.composeArgs(dir, project, "down", "--volumes", "--remove-orphans")...)
+	return err
+}
+
+// Status returns the state of every container of the project, including stopped ones
+func (c *CLI) Status(ctx context.Context, dir, project string) ([]ContainerState, error) {
+	out, err := c.run(ctx, c.composeArgs(dir, project, "ps", "--all", "--format", "json")...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseStatus(out)
+}
+
+// ParseStatus parses the output of "docker compose ps --format json", which is a JSON array in
+// Compose releases before 2.21 and one JSON object per line since
+func ParseStatus(out []byte) ([]ContainerState, error) {
+	out = bytes.TrimSpace(out)
+	if len(out) == 0 {
+		return nil, nil
+	}
+	var states []ContainerState
+	if out[0] == '[' {
+		if err := json.Unmarshal(out, &states); err != nil {
+			return nil, fmt.Errorf("failed to parse compose status: %w", err)
+		}
+		return states, nil
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var state ContainerState
+		if err := json.Unmarshal(line, &state); err != nil {
+			return nil, fmt.Errorf("failed to parse compose status: %w", err)
+		}
+		states = append(states, state)
+	}
+	return states, scanner.Err()
+}
+
+// composeArgs returns the arguments of a compose command on the project in dir
+func (c *CLI) composeArgs(dir, project string, args ...string) []string {
+	return append([]string{"compose", "--project-name", project, "--project-directory", dir,
+		"--file", filepath.Join(dir, FileName)}, args...)
+}
+
+// run runs docker with the given arguments and returns its standard output
+func (c *CLI) run(ctx context.Context, args ...string) ([]byte, error) {
+	docker := c.Docker
+	if docker == "" {
+		docker = "docker"
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, docker, args...)
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("%s %s: %w: %s", docker, strings.Join(args[:min(len(args), 2)], " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
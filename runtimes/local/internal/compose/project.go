@@ -0,0 +1,196 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package compose models the Docker Compose projects the local runtime materializes WorkloadPlans into,
+// writes them to disk and runs them with the docker CLI.
+package compose
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// FileName is the name of the Compose file in the directory of a project
+	FileName = "compose.yaml"
+
+	// FilesDir is the directory of a project holding the files bind-mounted into its containers
+	FilesDir = "files"
+)
+
+// Project is a Docker Compose project, limited to the fields the local runtime sets
+type Project struct {
+	Name     string             `json:"name"`
+	Services map[string]Service `json:"services"`
+	Networks map[string]Network `json:"networks,omitempty"`
+	Volumes  map[string]Volume  `json:"volumes,omitempty"`
+}
+
+// Service is a container of a Compose project
+type Service struct {
+	Image       string                    `json:"image"`
+	Entrypoint  []string                  `json:"entrypoint,omitempty"`
+	Command     []string                  `json:"command,omitempty"`
+	WorkingDir  string                    `json:"working_dir,omitempty"`
+	Environment map[string]string         `json:"environment,omitempty"`
+	Ports       []string                  `json:"ports,omitempty"`
+	Volumes     []string                  `json:"volumes,omitempty"`
+	NetworkMode string                    `json:"network_mode,omitempty"`
+	Networks    map[string]ServiceNetwork `json:"networks,omitempty"`
+	Restart     string                    `json:"restart,omitempty"`
+	Healthcheck *Healthcheck              `json:"healthcheck,omitempty"`
+	CPUs        string                    `json:"cpus,omitempty"`
+	MemLimit    string                    `json:"mem_limit,omitempty"`
+	Labels      map[string]string         `json:"labels,omitempty"`
+}
+
+// ServiceNetwork attaches a service to a network
+type ServiceNetwork struct {
+	Aliases []string `json:"aliases,omitempty"`
+}
+
+// Network is a network of a Compose project
+type Network struct {
+	Name     string `json:"name,omitempty"`
+	External bool   `json:"external,omitempty"`
+}
+
+// Volume is a named volume of a Compose project
+type Volume struct{}
+
+// Healthcheck reports whether a service is healthy
+type Healthcheck struct {
+	Test     []string `json:"test"`
+	Interval string   `json:"interval,omitempty"`
+}
+
+// File is a file bind-mounted into a container, relative to the files directory of the project
+type File struct {
+	Path    string
+	Mode    fs.FileMode
+	Content []byte
+}
+
+// Escape quotes the "$" of a value so that Compose does not interpolate it
+func Escape(value string) string {
+	return strings.ReplaceAll(value, "$", "$$")
+}
+
+// Write writes the project and its files to dir, replacing files left over from a previous version.
+// It reports whether anything changed, so that unchanged projects are not brought up again.
+// The Compose file may hold credentials and is only readable by the owner.
+func Write(dir string, project *Project, files []File) (bool, error) {
+	data, err := yaml.Marshal(project)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal compose project: %w", err)
+	}
+
+	changed, err := filesChanged(dir, data, files)
+	if err != nil || !changed {
+		return false, err
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return false, fmt.Errorf("failed to create project directory: %w", err)
+	}
+	filesDir := filepath.Join(dir, FilesDir)
+	if err := os.RemoveAll(filesDir); err != nil {
+		return false, fmt.Errorf("failed to remove previous files: %w", err)
+	}
+	for _, file := range files {
+		path := filepath.Join(filesDir, file.Path)
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			return false, fmt.Errorf("failed to create directory for %s: %w", file.Path, err)
+		}
+		if err := os.WriteFile(path, file.Content, file.Mode); err != nil {
+			return false, fmt.Errorf("failed to write %s: %w", file.Path, err)
+		}
+		// WriteFile applies the umask, which would drop permissions the Workload requested
+		if err := os.Chmod(path, file.Mode); err != nil {
+			return false, fmt.Errorf("failed to set mode of %s: %w", file.Path, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, FileName), data, 0o600); err != nil {
+		return false, fmt.Errorf("failed to write compose file: %w", err)
+	}
+	return true, nil
+}
+
+// filesChanged reports whether the Compose file or the files on disk differ from the given ones
+func filesChanged(dir string, data []byte, files []File) (bool, error) {
+	existing, err := os.ReadFile(filepath.Join(dir, FileName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return true, nil
+	} else if err != nil {
+		return false, fmt.Errorf("failed to read compose file: %w", err)
+	}
+	if !bytes.Equal(existing, data) {
+		return true, nil
+	}
+
+	want := make(map[string]File, len(files))
+	for _, file := range files {
+		want[filepath.Clean(file.Path)] = file
+	}
+	found := 0
+	filesDir := filepath.Join(dir, FilesDir)
+	changed := false
+	err = filepath.WalkDir(filesDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				return fs.SkipAll
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		rel, err := filepath.Rel(filesDir, path)
+		if err != nil {
+			return err
+		}
+		file, ok := want[rel]
+		if !ok {
+			changed = true
+			return fs.SkipAll
+		}
+		found++
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if info.Mode().Perm() != file.Mode.Perm() || !bytes.Equal(content, file.Content) {
+			changed = true
+			return fs.SkipAll
+		}
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to read project files: %w", err)
+	}
+	return changed || found != len(want), nil
+}
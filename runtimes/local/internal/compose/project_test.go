@@ -0,0 +1,106 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package compose
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "score-default-web")
+	project := &Project{
+		Name:     "score-default-web",
+		Services: map[string]Service{"app": {Image: "nginx", Environment: map[string]string{"GREETING": Escape("$HOME")}}},
+	}
+	files := []File{{Path: "app/0/config.yaml", Mode: 0o600, Content: []byte("key: value\n")}}
+
+	changed, err := Write(dir, project, files)
+	if err != nil || !changed {
+		t.Fatalf("Write() = %v, %v, want changed", changed, err)
+	}
+	info, err := os.Stat(filepath.Join(dir, FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Mode().Perm() != 0o600 {
+		t.Errorf("compose file mode = %v, want 0600", info.Mode().Perm())
+	}
+	data, err := os.ReadFile(filepath.Join(dir, FileName))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "name: score-default-web\nservices:\n  app:\n    environment:\n      GREETING: $$HOME\n    image: nginx\n"; string(data) != want {
+		t.Errorf("compose file = %q, want %q", data, want)
+	}
+
+	// Unchanged projects are not written again
+	if changed, err := Write(dir, project, files); err != nil || changed {
+		t.Errorf("Write() of an unchanged project = %v, %v, want unchanged", changed, err)
+	}
+
+	// Changed file content or mode is detected
+	files[0].Mode = 0o644
+	if changed, err := Write(dir, project, files); err != nil || !changed {
+		t.Errorf("Write() with a changed file mode = %v, %v, want changed", changed, err)
+	}
+
+	// Removed files are deleted from disk
+	if changed, err := Write(dir, project, nil); err != nil || !changed {
+		t.Errorf("Write() without files = %v, %v, want changed", changed, err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, FilesDir, "app/0/config.yaml")); !os.IsNotExist(err) {
+		t.Errorf("removed file still exists: %v", err)
+	}
+}
+
+func TestParseStatus(t *testing.T) {
+	want := []ContainerState{
+		{Service: "app", State: StateRunning, Health: HealthStarting},
+		{Service: "worker", State: StateExited, ExitCode: 1},
+	}
+	tests := []struct {
+		name string
+		out  string
+	}{
+		{
+			name: "json array",
+			out:  `[{"Service":"app","State":"running","Health":"starting"},{"Service":"worker","State":"exited","ExitCode":1}]`,
+		},
+		{
+			name: "json lines",
+			out:  "{\"Service\":\"app\",\"State\":\"running\",\"Health\":\"starting\"}\n{\"Service\":\"worker\",\"State\":\"exited\",\"ExitCode\":1}\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseStatus([]byte(tt.out))
+			if err != nil {
+				t.Fatalf("ParseStatus() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("ParseStatus() = %+v, want %+v", got, want)
+			}
+		})
+	}
+
+	if got, err := ParseStatus([]byte("\n")); err != nil || got != nil {
+		t.Errorf("ParseStatus() of empty output = %v, %v, want none", got, err)
+	}
+}
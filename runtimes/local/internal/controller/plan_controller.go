@@ -0,0 +1,302 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/internal/planvalues"
+	"github.com/cappyzawa/score-orchestrator/runtimes/local/internal/compose"
+)
+
+const (
+	localRuntimeClass = meta.RuntimeClassDocker
+
+	// localRuntimeFinalizer keeps a WorkloadPlan around until the Compose project materialized for it is removed
+	localRuntimeFinalizer = "runtime.score.dev/docker"
+
+	// pollInterval is how often the containers of a project are checked while it is not ready. Nothing
+	// notifies the runtime of container state changes, so ready projects are checked every readyPollInterval.
+	pollInterval      = 5 * time.Second
+	readyPollInterval = 30 * time.Second
+)
+
+// LocalRuntimePlanReconciler reconciles WorkloadPlan resources and materializes them as Docker Compose projects
+type LocalRuntimePlanReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// Runner runs the Compose projects
+	Runner compose.Runner
+
+	// ProjectsDir is the directory the Compose project of every plan is written to, one subdirectory per project
+	ProjectsDir string
+
+	// Network is the Docker network shared by all projects, so that Workloads reach each other by name
+	Network string
+
+	// MaxConcurrentReconciles is the number of WorkloadPlans reconciled in parallel (default 1)
+	MaxConcurrentReconciles int
+}
+
+// +kubebuilder:rbac:groups=score.dev,resources=workloadplans,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=score.dev,resources=workloadplans/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=score.dev,resources=workloadplans/finalizers,verbs=update
+// +kubebuilder:rbac:groups=score.dev,resources=workloads,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+// Reconcile handles WorkloadPlan changes and materializes Docker Compose projects
+func (r *LocalRuntimePlanReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	plan := &scorev1b1.WorkloadPlan{}
+	if err := r.Get(ctx, req.NamespacedName, plan); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.V(1).Info("WorkloadPlan not found, may have been deleted")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Remove the project when the plan is deleted or moved to another runtime class or cluster
+	if !plan.DeletionTimestamp.IsZero() || plan.Spec.RuntimeClass != localRuntimeClass || plan.Spec.Target != "" {
+		if !controllerutil.ContainsFinalizer(plan, localRuntimeFinalizer) {
+			logger.V(1).Info("Skipping WorkloadPlan not materialized by the local runtime",
+				"runtimeClass", plan.Spec.RuntimeClass, "target", plan.Spec.Target)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, r.teardown(ctx, plan)
+	}
+
+	if !controllerutil.ContainsFinalizer(plan, localRuntimeFinalizer) {
+		controllerutil.AddFinalizer(plan, localRuntimeFinalizer)
+		if err := r.Update(ctx, plan); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to add finalizer: %w", err)
+		}
+	}
+
+	logger.Info("Reconciling WorkloadPlan for the local runtime", "workloadPlan", req.NamespacedName)
+
+	if plan.Spec.Kind == scorev1b1.WorkloadKindCronJob {
+		return ctrl.Result{}, r.setStatus(ctx, plan, scorev1b1.WorkloadPlanPhaseFailed,
+			"CronJob workloads are not supported by the local runtime")
+	}
+
+	workload, err := r.getWorkload(ctx, plan)
+	if err != nil {
+		logger.Error(err, "Failed to get referenced Workload")
+		r.Recorder.Event(plan, corev1.EventTypeWarning, "WorkloadNotFound", err.Error())
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	// Values too large to be embedded in the plan are read from the ConfigMap it references
+	if err := planvalues.Inline(ctx, r.Client, plan); err != nil {
+		logger.Error(err, "Failed to load resolved values")
+		r.Recorder.Event(plan, corev1.EventTypeWarning, "ValuesUnavailable", err.Error())
+		if errors.Is(err, planvalues.ErrIntegrity) {
+			return ctrl.Result{RequeueAfter: time.Minute}, r.setStatus(ctx, plan, scorev1b1.WorkloadPlanPhaseFailed, err.Error())
+		}
+		return ctrl.Result{RequeueAfter: time.Minute}, nil
+	}
+
+	project, files, err := r.buildProject(ctx, plan, workload)
+	if err != nil {
+		logger.Error(err, "Failed to build Compose project")
+		r.Recorder.Event(plan, corev1.EventTypeWarning, "ProjectInvalid", err.Error())
+		return ctrl.Result{RequeueAfter: time.Minute}, r.setStatus(ctx, plan, scorev1b1.WorkloadPlanPhaseFailed, err.Error())
+	}
+
+	dir := r.projectDir(project.Name)
+	changed, err := compose.Write(dir, project, files)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	states, err := r.Runner.Status(ctx, dir, project.Name)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to get project status: %w", err)
+	}
+	// Containers are also recreated when they were removed outside of the runtime
+	if changed || len(states) < len(project.Services) {
+		if err := r.Runner.EnsureNetwork(ctx, r.Network); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to create network %s: %w", r.Network, err)
+		}
+		if err := r.Runner.Up(ctx, dir, project.Name); err != nil {
+			logger.Error(err, "Failed to bring up Compose project")
+			r.Recorder.Event(plan, corev1.EventTypeWarning, "ProjectFailed", err.Error())
+			// Rewrite the project on the next attempt even if it did not change
+			_ = os.Remove(filepath.Join(dir, compose.FileName))
+			return ctrl.Result{RequeueAfter: time.Minute}, r.setStatus(ctx, plan, scorev1b1.WorkloadPlanPhaseFailed, err.Error())
+		}
+		r.Recorder.Event(plan, corev1.EventTypeNormal, "ProjectApplied", "Applied Compose project "+project.Name)
+		if states, err = r.Runner.Status(ctx, dir, project.Name); err != nil {
+			return ctrl.Result{}, fmt.Errorf("failed to get project status: %w", err)
+		}
+	}
+
+	phase, message := projectPhase(plan.Spec.Kind, project, states)
+	if err := r.setStatus(ctx, plan, phase, message); err != nil {
+		return ctrl.Result{}, err
+	}
+	if phase == scorev1b1.WorkloadPlanPhaseReady {
+		return ctrl.Result{RequeueAfter: readyPollInterval}, nil
+	}
+	return ctrl.Result{RequeueAfter: pollInterval}, nil
+}
+
+// teardown removes the Compose project of the plan and releases the finalizer
+func (r *LocalRuntimePlanReconciler) teardown(ctx context.Context, plan *scorev1b1.WorkloadPlan) error {
+	name := projectName(plan)
+	dir := r.projectDir(name)
+	if _, err := os.Stat(filepath.Join(dir, compose.FileName)); err == nil {
+		if err := r.Runner.Down(ctx, dir, name); err != nil {
+			return fmt.Errorf("failed to remove Compose project %s: %w", name, err)
+		}
+	}
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("failed to remove project directory: %w", err)
+	}
+	r.Recorder.Event(plan, corev1.EventTypeNormal, "ProjectDeleted", "Removed Compose project "+name)
+
+	controllerutil.RemoveFinalizer(plan, localRuntimeFinalizer)
+	if err := r.Update(ctx, plan); err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to remove finalizer: %w", err)
+	}
+	return nil
+}
+
+// projectDir returns the directory of the named project
+func (r *LocalRuntimePlanReconciler) projectDir(name string) string {
+	return filepath.Join(r.ProjectsDir, name)
+}
+
+// projectPhase derives the plan phase from the states of the containers of the project. Services are ready
+// once every container runs and is not unhealthy; Jobs once every container exited successfully.
+func projectPhase(kind string, project *compose.Project, states []compose.ContainerState) (scorev1b1.WorkloadPlanPhase, string) {
+	byService := make(map[string]compose.ContainerState, len(states))
+	for _, state := range states {
+		byService[state.Service] = state
+	}
+
+	names := make([]string, 0, len(project.Services))
+	for name := range project.Services {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var pending []string
+	for _, name := range names {
+		state, ok := byService[name]
+		switch {
+		case !ok:
+			pending = append(pending, name)
+		case kind == scorev1b1.WorkloadKindJob:
+			if state.State == compose.StateExited && state.ExitCode != 0 {
+				return scorev1b1.WorkloadPlanPhaseFailed, fmt.Sprintf("Container %s exited with code %d", name, state.ExitCode)
+			}
+			if state.State != compose.StateExited {
+				pending = append(pending, name)
+			}
+		case state.State == compose.StateExited || state.State == compose.StateRestarting:
+			return scorev1b1.WorkloadPlanPhaseFailed, fmt.Sprintf("Container %s is %s (exit code %d)", name, state.State, state.ExitCode)
+		case state.Health == compose.HealthUnhealthy:
+			return scorev1b1.WorkloadPlanPhaseFailed, fmt.Sprintf("Container %s is unhealthy", name)
+		case state.State != compose.StateRunning || state.Health == compose.HealthStarting:
+			pending = append(pending, name)
+		}
+	}
+
+	if len(pending) > 0 {
+		return scorev1b1.WorkloadPlanPhaseProvisioning, "Waiting for containers " + strings.Join(pending, ", ")
+	}
+	if kind == scorev1b1.WorkloadKindJob {
+		return scorev1b1.WorkloadPlanPhaseReady, "Runtime job completed"
+	}
+	return scorev1b1.WorkloadPlanPhaseReady, "Runtime containers are running"
+}
+
+// setStatus records the phase of the plan with its Ready condition
+func (r *LocalRuntimePlanReconciler) setStatus(ctx context.Context, plan *scorev1b1.WorkloadPlan, phase scorev1b1.WorkloadPlanPhase, message string) error {
+	status := metav1.ConditionFalse
+	if phase == scorev1b1.WorkloadPlanPhaseReady {
+		status = metav1.ConditionTrue
+	}
+	unchanged := plan.Status.Phase == phase && plan.Status.Message == message && plan.Status.ObservedGeneration == plan.Generation
+	if ready := apimeta.FindStatusCondition(plan.Status.Conditions, meta.PlanConditionReady); unchanged && ready != nil &&
+		ready.Status == status && ready.ObservedGeneration == plan.Generation {
+		return nil
+	}
+
+	plan.Status.Phase = phase
+	plan.Status.Message = message
+	plan.Status.ObservedGeneration = plan.Generation
+	apimeta.SetStatusCondition(&plan.Status.Conditions, metav1.Condition{
+		Type:               meta.PlanConditionReady,
+		Status:             status,
+		Reason:             string(phase),
+		Message:            message,
+		ObservedGeneration: plan.Generation,
+	})
+	if err := r.Status().Update(ctx, plan); err != nil {
+		return fmt.Errorf("failed to update WorkloadPlan status: %w", err)
+	}
+	return nil
+}
+
+// getWorkload retrieves the referenced Workload from WorkloadPlan, with the spec snapshot the plan carries, if any
+func (r *LocalRuntimePlanReconciler) getWorkload(ctx context.Context, plan *scorev1b1.WorkloadPlan) (*scorev1b1.Workload, error) {
+	workload := &scorev1b1.Workload{}
+	key := types.NamespacedName{
+		Namespace: plan.Spec.WorkloadRef.Namespace,
+		Name:      plan.Spec.WorkloadRef.Name,
+	}
+
+	if err := r.Get(ctx, key, workload); err != nil {
+		if !apierrors.IsNotFound(err) || plan.Spec.WorkloadSnapshot == nil {
+			return nil, fmt.Errorf("failed to get workload %s: %w", key, err)
+		}
+		workload.Name, workload.Namespace = key.Name, key.Namespace
+	}
+
+	if snapshot := plan.Spec.WorkloadSnapshot; snapshot != nil {
+		workload.Spec = scorev1b1.WorkloadSpec{}
+		if err := json.Unmarshal(snapshot.Raw, &workload.Spec); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal workload snapshot: %w", err)
+		}
+		workload.Generation = plan.Spec.ObservedWorkloadGeneration
+	}
+
+	return workload, nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *LocalRuntimePlanReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&scorev1b1.WorkloadPlan{}).
+		Named("local-runtime-plan").
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
+		Complete(r)
+}
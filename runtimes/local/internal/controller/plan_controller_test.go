@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/runtimes/local/internal/compose"
+)
+
+// fakeRunner records the projects brought up and reports their containers as running
+type fakeRunner struct {
+	up, down []string
+	running  map[string][]compose.ContainerState
+}
+
+func (f *fakeRunner) EnsureNetwork(context.Context, string) error { return nil }
+
+func (f *fakeRunner) Up(_ context.Context, dir, project string) error {
+	f.up = append(f.up, project)
+	if _, err := os.Stat(filepath.Join(dir, compose.FileName)); err != nil {
+		return err
+	}
+	f.running[project] = []compose.ContainerState{{Service: "app", State: compose.StateRunning}}
+	return nil
+}
+
+func (f *fakeRunner) Down(_ context.Context, _, project string) error {
+	f.down = append(f.down, project)
+	delete(f.running, project)
+	return nil
+}
+
+func (f *fakeRunner) Status(_ context.Context, _, project string) ([]compose.ContainerState, error) {
+	return f.running[project], nil
+}
+
+func TestReconcileComposeProject(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	key := types.NamespacedName{Name: "web", Namespace: "default"}
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx"}},
+		},
+	}
+	plan := &scorev1b1.WorkloadPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default", Generation: 2},
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef:  scorev1b1.WorkloadPlanWorkloadRef{Name: "web", Namespace: "default"},
+			RuntimeClass: localRuntimeClass,
+		},
+	}
+
+	c := fake.NewClientBuilder().WithScheme(scheme).
+		WithObjects(workload, plan).
+		WithStatusSubresource(&scorev1b1.WorkloadPlan{}).
+		Build()
+	runner := &fakeRunner{running: map[string][]compose.ContainerState{}}
+	r := &LocalRuntimePlanReconciler{
+		Client:      c,
+		Scheme:      scheme,
+		Recorder:    record.NewFakeRecorder(20),
+		Runner:      runner,
+		ProjectsDir: t.TempDir(),
+		Network:     "score-local",
+	}
+
+	reconcileAndGet := func() *scorev1b1.WorkloadPlan {
+		t.Helper()
+		if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("Reconcile() error = %v", err)
+		}
+		got := &scorev1b1.WorkloadPlan{}
+		if err := c.Get(ctx, key, got); err != nil {
+			t.Fatal(err)
+		}
+		return got
+	}
+
+	got := reconcileAndGet()
+	if !controllerutil.ContainsFinalizer(got, localRuntimeFinalizer) {
+		t.Error("plan has no local runtime finalizer")
+	}
+	if got.Status.Phase != scorev1b1.WorkloadPlanPhaseReady || got.Status.ObservedGeneration != 2 {
+		t.Errorf("status = %s (observedGeneration %d), want Ready for generation 2", got.Status.Phase, got.Status.ObservedGeneration)
+	}
+	if len(runner.up) != 1 || runner.up[0] != "score-default-web" {
+		t.Errorf("projects brought up = %v, want score-default-web", runner.up)
+	}
+
+	// An unchanged project with running containers is not brought up again
+	reconcileAndGet()
+	if len(runner.up) != 1 {
+		t.Errorf("projects brought up = %v, want no second up", runner.up)
+	}
+
+	// Moving the plan to another runtime class removes the project
+	got.Spec.RuntimeClass = "kubernetes"
+	if err := c.Update(ctx, got); err != nil {
+		t.Fatal(err)
+	}
+	got = reconcileAndGet()
+	if len(runner.down) != 1 || runner.down[0] != "score-default-web" {
+		t.Errorf("projects removed = %v, want score-default-web", runner.down)
+	}
+	if controllerutil.ContainsFinalizer(got, localRuntimeFinalizer) {
+		t.Error("finalizer was not removed")
+	}
+	if _, err := os.Stat(filepath.Join(r.ProjectsDir, "score-default-web")); !os.IsNotExist(err) {
+		t.Errorf("project directory still exists: %v", err)
+	}
+}
+
+func TestReconcileRejectsCronJob(t *testing.T) {
+	ctx := context.Background()
+	scheme := runtime.NewScheme()
+	if err := scorev1b1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	plan := &scorev1b1.WorkloadPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "report", Namespace: "default"},
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef:  scorev1b1.WorkloadPlanWorkloadRef{Name: "report", Namespace: "default"},
+			RuntimeClass: localRuntimeClass,
+			Kind:         scorev1b1.WorkloadKindCronJob,
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(plan).WithStatusSubresource(plan).Build()
+	r := &LocalRuntimePlanReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(5), Runner: &fakeRunner{}}
+
+	if _, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(plan)}); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+	got := &scorev1b1.WorkloadPlan{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(plan), got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Status.Phase != scorev1b1.WorkloadPlanPhaseFailed {
+		t.Errorf("phase = %s, want Failed", got.Status.Phase)
+	}
+}
@@ -0,0 +1,326 @@
+package controller
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/internal/meta"
+	"github.com/cappyzawa/score-orchestrator/pkg/resolvedvalues"
+	"github.com/cappyzawa/score-orchestrator/runtimes/local/internal/compose"
+)
+
+// projectNameInvalid matches the characters Compose does not accept in project names
+var projectNameInvalid = regexp.MustCompile(`[^a-z0-9_-]`)
+
+// projectName returns the Compose project name of the plan, unique per Workload
+func projectName(plan *scorev1b1.WorkloadPlan) string {
+	name := strings.ToLower("score-" + plan.Spec.WorkloadRef.Namespace + "-" + plan.Spec.WorkloadRef.Name)
+	return projectNameInvalid.ReplaceAllString(name, "-")
+}
+
+// buildProject constructs the Compose project of the plan and the files bind-mounted into its containers.
+// The containers of a Workload share the network namespace of the first one, like the containers of a pod,
+// which joins the shared network under the Workload name so that other Workloads reach it by that name.
+func (r *LocalRuntimePlanReconciler) buildProject(ctx context.Context, plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) (*compose.Project, []compose.File, error) {
+	values, err := planValues(plan, workload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	names := make([]string, 0, len(workload.Spec.Containers))
+	for name := range workload.Spec.Containers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return nil, nil, fmt.Errorf("workload %s declares no containers", workload.Name)
+	}
+
+	restart := "unless-stopped"
+	if plan.Spec.Kind == scorev1b1.WorkloadKindJob {
+		restart = "no"
+	}
+
+	project := &compose.Project{
+		Name:     projectName(plan),
+		Services: make(map[string]compose.Service, len(names)),
+		Networks: map[string]compose.Network{"default": {Name: r.Network, External: true}},
+	}
+	var files []compose.File
+	for i, name := range names {
+		spec := workload.Spec.Containers[name]
+		if spec.Image == "." {
+			return nil, nil, fmt.Errorf("container %s builds from source, which the local runtime does not support", name)
+		}
+		// Commands resolved in the plan take precedence over the Workload spec
+		command, args, workingDir := spec.Command, spec.Args, spec.WorkingDir
+		if resolved := values.Containers[name]; len(resolved.Command) > 0 || len(resolved.Args) > 0 || resolved.WorkingDir != "" {
+			command, args, workingDir = resolved.Command, resolved.Args, resolved.WorkingDir
+		}
+		// Images pinned by digest in the plan take precedence over the tags of the Workload spec
+		image := spec.Image
+		if resolved := values.Containers[name].Image; resolved != "" {
+			image = resolved
+		}
+		service := compose.Service{
+			Image:      image,
+			Entrypoint: escapeAll(command),
+			Command:    escapeAll(args),
+			WorkingDir: workingDir,
+			Restart:    restart,
+			Labels: map[string]string{
+				meta.LabelWorkload:    plan.Spec.WorkloadRef.Name,
+				"score.dev/namespace": plan.Spec.WorkloadRef.Namespace,
+				"score.dev/runtime":   localRuntimeClass,
+			},
+		}
+		// Compose recreates the containers of a service whose configuration changed, so a new restart
+		// request restarts them
+		if plan.Spec.RestartedAt != "" {
+			service.Labels[meta.AnnotationRestart] = plan.Spec.RestartedAt
+		}
+
+		service.Environment, err = r.containerEnv(ctx, plan, mergedEnv(spec.Variables, values.Containers[name].Env, platformEnv(plan, workload, values)))
+		if err != nil {
+			return nil, nil, fmt.Errorf("container %s: %w", name, err)
+		}
+
+		containerFiles, mounts, err := bindFiles(name, values.Containers[name].Files)
+		if err != nil {
+			return nil, nil, fmt.Errorf("container %s: %w", name, err)
+		}
+		files = append(files, containerFiles...)
+		service.Volumes = mounts
+
+		if err := setLimits(&service, spec.Resources, plan.Spec.DefaultResources); err != nil {
+			return nil, nil, fmt.Errorf("container %s: %w", name, err)
+		}
+		if probe := spec.ReadinessProbe; probe != nil && probe.Exec != nil {
+			service.Healthcheck = &compose.Healthcheck{Test: append([]string{"CMD"}, escapeAll(probe.Exec.Command)...), Interval: "10s"}
+		}
+
+		if i == 0 {
+			service.Networks = map[string]compose.ServiceNetwork{"default": {Aliases: []string{plan.Spec.WorkloadRef.Name}}}
+			service.Ports = publishedPorts(workload, values)
+		} else {
+			service.NetworkMode = "service:" + names[0]
+		}
+		if storage := workload.Spec.Storage; storage != nil {
+			for _, volume := range storage.Volumes {
+				if volume.Source != "" {
+					return nil, nil, fmt.Errorf("volume %s mounts a claim, which the local runtime does not support", volume.Name)
+				}
+				mount := volume.Name + ":" + volume.Target
+				if volume.ReadOnly {
+					mount += ":ro"
+				}
+				service.Volumes = append(service.Volumes, mount)
+				if project.Volumes == nil {
+					project.Volumes = make(map[string]compose.Volume)
+				}
+				project.Volumes[volume.Name] = compose.Volume{}
+			}
+		}
+		project.Services[name] = service
+	}
+	return project, files, nil
+}
+
+// planValues returns the resolved values of the plan, falling back to the Workload spec when none were resolved
+func planValues(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload) (*resolvedvalues.Values, error) {
+	if plan.Spec.ResolvedValues != nil {
+		return resolvedvalues.FromPlan(plan)
+	}
+
+	values := &resolvedvalues.Values{Containers: make(map[string]resolvedvalues.Container, len(workload.Spec.Containers))}
+	for name, spec := range workload.Spec.Containers {
+		container := resolvedvalues.Container{Env: make(map[string]resolvedvalues.EnvValue, len(spec.Variables))}
+		for key, value := range spec.Variables {
+			container.Env[key] = resolvedvalues.EnvValue{Value: value}
+		}
+		for _, file := range spec.Files {
+			container.Files = append(container.Files, resolvedvalues.File{
+				Target: file.Target, Mode: file.Mode, Content: file.Content, BinaryContent: file.BinaryContent,
+			})
+		}
+		values.Containers[name] = container
+	}
+	return values, nil
+}
+
+// containerEnv returns the environment of a container. Sensitive outputs, which the Orchestrator passes as
+// Secret key references, are read from the Secret in the namespace of the plan.
+func (r *LocalRuntimePlanReconciler) containerEnv(ctx context.Context, plan *scorev1b1.WorkloadPlan, env map[string]resolvedvalues.EnvValue) (map[string]string, error) {
+	if len(env) == 0 {
+		return nil, nil
+	}
+	result := make(map[string]string, len(env))
+	for key, value := range env {
+		ref := value.SecretKeyRef
+		if ref == nil {
+			result[key] = compose.Escape(value.Value)
+			continue
+		}
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: plan.Namespace, Name: ref.Name}, secret); err != nil {
+			return nil, fmt.Errorf("failed to get secret %s for env %s: %w", ref.Name, key, err)
+		}
+		data, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("secret %s has no key %s for env %s", ref.Name, ref.Key, key)
+		}
+		result[key] = compose.Escape(string(data))
+	}
+	return result, nil
+}
+
+// bindFiles returns the inline files of a container and the bind mounts of their targets
+func bindFiles(container string, files []resolvedvalues.File) ([]compose.File, []string, error) {
+	var result []compose.File
+	var mounts []string
+	for i, file := range files {
+		var content []byte
+		switch {
+		case file.Content != nil:
+			content = []byte(*file.Content)
+		case file.BinaryContent != nil:
+			decoded, err := base64.StdEncoding.DecodeString(*file.BinaryContent)
+			if err != nil {
+				return nil, nil, fmt.Errorf("file %s: invalid binaryContent: %w", file.Target, err)
+			}
+			content = decoded
+		default:
+			return nil, nil, fmt.Errorf("file %s: only inline content is supported by the local runtime", file.Target)
+		}
+		mode := uint64(0o644)
+		if file.Mode != nil {
+			parsed, err := strconv.ParseUint(*file.Mode, 8, 32)
+			if err != nil {
+				return nil, nil, fmt.Errorf("file %s: invalid mode %q: %w", file.Target, *file.Mode, err)
+			}
+			mode = parsed
+		}
+		relative := path.Join(container, strconv.Itoa(i), path.Base(file.Target))
+		result = append(result, compose.File{Path: relative, Mode: fs.FileMode(mode), Content: content})
+		mounts = append(mounts, "./"+path.Join(compose.FilesDir, relative)+":"+file.Target+":ro")
+	}
+	return result, mounts, nil
+}
+
+// publishedPorts publishes the service ports of the Workload on the loopback interface of the host
+func publishedPorts(workload *scorev1b1.Workload, values *resolvedvalues.Values) []string {
+	if workload.Spec.Service == nil {
+		return nil
+	}
+	ports := make([]string, 0, len(workload.Spec.Service.Ports))
+	for i, port := range workload.Spec.Service.Ports {
+		published := fmt.Sprintf("127.0.0.1:%d:%d", port.Port, targetPort(workload, values, i))
+		if port.Protocol == string(corev1.ProtocolUDP) {
+			published += "/udp"
+		}
+		ports = append(ports, published)
+	}
+	return ports
+}
+
+// targetPort returns the container port the i-th service port of the Workload is served on
+func targetPort(workload *scorev1b1.Workload, values *resolvedvalues.Values, i int) int32 {
+	port := workload.Spec.Service.Ports[i]
+	resolved := values.TargetPorts()
+	switch {
+	case i < len(resolved) && resolved[i].Type == intstr.Int:
+		return resolved[i].IntVal
+	case port.TargetPort != nil && port.TargetPort.Type == intstr.Int:
+		return port.TargetPort.IntVal
+	}
+	return port.Port
+}
+
+// platformEnv returns the reserved variables the runtime injects into every container of the Workload.
+// Other Workloads reach it by name on the shared network, on the container port of its first service port.
+func platformEnv(plan *scorev1b1.WorkloadPlan, workload *scorev1b1.Workload, values *resolvedvalues.Values) map[string]string {
+	env := map[string]string{
+		meta.EnvWorkloadName:      plan.Spec.WorkloadRef.Name,
+		meta.EnvWorkloadNamespace: plan.Spec.WorkloadRef.Namespace,
+	}
+	if workload.Spec.Service != nil && len(workload.Spec.Service.Ports) > 0 {
+		env[meta.EnvWorkloadEndpoint] = fmt.Sprintf("http://%s:%d", plan.Spec.WorkloadRef.Name, targetPort(workload, values, 0))
+	}
+	return env
+}
+
+// mergedEnv returns the variables of a container: the raw Workload variables, overridden by the resolved
+// ones, overridden by the platform variables
+func mergedEnv(raw map[string]string, resolved map[string]resolvedvalues.EnvValue, platform map[string]string) map[string]resolvedvalues.EnvValue {
+	env := make(map[string]resolvedvalues.EnvValue, len(raw)+len(resolved)+len(platform))
+	for key, value := range raw {
+		env[key] = resolvedvalues.EnvValue{Value: value}
+	}
+	for key, value := range resolved {
+		env[key] = value
+	}
+	for key, value := range platform {
+		env[key] = resolvedvalues.EnvValue{Value: value}
+	}
+	return env
+}
+
+// setLimits sets the cpu and memory limits of a service from the declared limits of the container,
+// falling back to the default limits of the plan
+func setLimits(service *compose.Service, declared, defaults *scorev1b1.ResourceRequirements) error {
+	limit := func(name string) string {
+		if declared != nil {
+			if value, ok := declared.Limits[name]; ok {
+				return value
+			}
+			if _, ok := declared.Requests[name]; ok {
+				return ""
+			}
+		}
+		if defaults != nil {
+			return defaults.Limits[name]
+		}
+		return ""
+	}
+
+	if value := limit(string(corev1.ResourceCPU)); value != "" {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("invalid cpu limit %s: %w", value, err)
+		}
+		service.CPUs = strconv.FormatFloat(quantity.AsApproximateFloat64(), 'f', -1, 64)
+	}
+	if value := limit(string(corev1.ResourceMemory)); value != "" {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			return fmt.Errorf("invalid memory limit %s: %w", value, err)
+		}
+		service.MemLimit = strconv.FormatInt(quantity.Value(), 10)
+	}
+	return nil
+}
+
+// escapeAll quotes the values so that Compose does not interpolate them
+func escapeAll(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	escaped := make([]string, len(values))
+	for i, value := range values {
+		escaped[i] = compose.Escape(value)
+	}
+	return escaped
+}
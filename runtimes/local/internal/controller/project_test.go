@@ -0,0 +1,215 @@
+package controller
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/utils/ptr"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	scorev1b1 "github.com/cappyzawa/score-orchestrator/api/v1b1"
+	"github.com/cappyzawa/score-orchestrator/runtimes/local/internal/compose"
+)
+
+func TestBuildProject(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-outputs", Namespace: "default"},
+		Data:       map[string][]byte{"password": []byte("pa$$word")},
+	}
+	r := &LocalRuntimePlanReconciler{
+		Client:  fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build(),
+		Network: "score-local",
+	}
+
+	plan := &scorev1b1.WorkloadPlan{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: scorev1b1.WorkloadPlanSpec{
+			WorkloadRef:  scorev1b1.WorkloadPlanWorkloadRef{Name: "web", Namespace: "default"},
+			RuntimeClass: localRuntimeClass,
+			Kind:         scorev1b1.WorkloadKindService,
+			ResolvedValues: &runtime.RawExtension{Raw: []byte(`{"containers":{` +
+				`"app":{"env":{"DB_HOST":"db","DB_PASSWORD":{"secretKeyRef":{"name":"db-outputs","key":"password"}}},` +
+				`"files":[{"target":"/etc/app/config.yaml","mode":"0600","content":"host: db\n"}]},` +
+				`"proxy":{"env":{},"args":["--config","/etc/envoy/db.yaml"],"workingDir":"/etc/envoy"}},` +
+				`"service":{"ports":[{"port":80,"targetPort":8080}]}}`)},
+			DefaultResources: &scorev1b1.ResourceRequirements{Limits: map[string]string{"cpu": "500m", "memory": "256Mi"}},
+			RestartedAt:      "2026-10-16T09:00:00Z",
+		},
+	}
+	workload := &scorev1b1.Workload{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: scorev1b1.WorkloadSpec{
+			Containers: map[string]scorev1b1.ContainerSpec{
+				"app":   {Image: "example/app:1", Args: []string{"--home=$HOME"}, Files: []scorev1b1.FileSpec{{Target: "/etc/app/config.yaml", Mode: ptr.To("0600"), Content: ptr.To("host: ${resources.db.outputs.host}\n")}}},
+				"proxy": {Image: "envoy", Variables: map[string]string{"LOG_LEVEL": "debug"}, Args: []string{"--config", "${resources.db.outputs.config}"}, WorkingDir: "/etc/envoy", Resources: &scorev1b1.ResourceRequirements{Requests: map[string]string{"cpu": "100m"}}},
+			},
+			Service: &scorev1b1.ServiceSpec{Ports: []scorev1b1.ServicePort{{Port: 80}}},
+		},
+	}
+
+	project, files, err := r.buildProject(context.Background(), plan, workload)
+	if err != nil {
+		t.Fatalf("buildProject() error = %v", err)
+	}
+	if project.Name != "score-default-web" {
+		t.Errorf("project name = %q, want score-default-web", project.Name)
+	}
+	if got := project.Networks["default"]; !got.External || got.Name != "score-local" {
+		t.Errorf("default network = %+v, want the external shared network", got)
+	}
+
+	app := project.Services["app"]
+	want := map[string]string{
+		"DB_HOST":                  "db",
+		"DB_PASSWORD":              "pa$$$$word",
+		"SCORE_WORKLOAD_NAME":      "web",
+		"SCORE_WORKLOAD_NAMESPACE": "default",
+		"SCORE_WORKLOAD_ENDPOINT":  "http://web:8080",
+	}
+	if !reflect.DeepEqual(app.Environment, want) {
+		t.Errorf("app environment = %v, want %v", app.Environment, want)
+	}
+	if !reflect.DeepEqual(app.Command, []string{"--home=$$HOME"}) {
+		t.Errorf("app command = %v, want escaped args", app.Command)
+	}
+	if !reflect.DeepEqual(app.Ports, []string{"127.0.0.1:80:8080"}) {
+		t.Errorf("app ports = %v, want the resolved target port on loopback", app.Ports)
+	}
+	if !reflect.DeepEqual(app.Networks["default"].Aliases, []string{"web"}) {
+		t.Errorf("app aliases = %v, want the Workload name", app.Networks["default"].Aliases)
+	}
+	if app.CPUs != "0.5" || app.MemLimit != "268435456" {
+		t.Errorf("app limits = %s/%s, want the plan defaults", app.CPUs, app.MemLimit)
+	}
+	if !reflect.DeepEqual(app.Volumes, []string{"./files/app/0/config.yaml:/etc/app/config.yaml:ro"}) {
+		t.Errorf("app volumes = %v, want the bind-mounted file", app.Volumes)
+	}
+	if app.Labels["score.dev/restart"] != "2026-10-16T09:00:00Z" {
+		t.Errorf("app labels = %v, want the restart request", app.Labels)
+	}
+	if want := []compose.File{{Path: "app/0/config.yaml", Mode: 0o600, Content: []byte("host: db\n")}}; !reflect.DeepEqual(files, want) {
+		t.Errorf("files = %+v, want %+v", files, want)
+	}
+
+	// Sidecars share the network namespace of the first container; a declared request disables the default limit
+	proxy := project.Services["proxy"]
+	if proxy.NetworkMode != "service:app" || proxy.Ports != nil {
+		t.Errorf("proxy network = %q ports = %v, want the network of app", proxy.NetworkMode, proxy.Ports)
+	}
+	if proxy.CPUs != "" || proxy.MemLimit != "268435456" {
+		t.Errorf("proxy limits = %s/%s, want only the default memory limit", proxy.CPUs, proxy.MemLimit)
+	}
+	// Variables missing from the resolved values fall back to the Workload spec
+	if proxy.Environment["LOG_LEVEL"] != "debug" || proxy.Environment["SCORE_WORKLOAD_NAME"] != "web" {
+		t.Errorf("proxy environment = %v, want the raw and the platform variables", proxy.Environment)
+	}
+	// Resolved commands take precedence over the Workload spec
+	if !reflect.DeepEqual(proxy.Command, []string{"--config", "/etc/envoy/db.yaml"}) || proxy.WorkingDir != "/etc/envoy" {
+		t.Errorf("proxy command = %v, working dir = %q, want the resolved values", proxy.Command, proxy.WorkingDir)
+	}
+}
+
+func TestBuildProjectRejectsUnsupported(t *testing.T) {
+	r := &LocalRuntimePlanReconciler{Network: "score-local"}
+	plan := &scorev1b1.WorkloadPlan{
+		Spec: scorev1b1.WorkloadPlanSpec{WorkloadRef: scorev1b1.WorkloadPlanWorkloadRef{Name: "web", Namespace: "default"}},
+	}
+
+	tests := []struct {
+		name       string
+		containers map[string]scorev1b1.ContainerSpec
+	}{
+		{name: "no containers"},
+		{name: "build from source", containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "."}}},
+		{
+			name: "file from source",
+			containers: map[string]scorev1b1.ContainerSpec{"app": {Image: "nginx", Files: []scorev1b1.FileSpec{
+				{Target: "/etc/schema.sql", Source: &scorev1b1.FileSourceSpec{URI: "https://example.com/schema.sql"}},
+			}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			workload := &scorev1b1.Workload{Spec: scorev1b1.WorkloadSpec{Containers: tt.containers}}
+			if _, _, err := r.buildProject(context.Background(), plan, workload); err == nil {
+				t.Error("buildProject() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func TestProjectPhase(t *testing.T) {
+	project := &compose.Project{Services: map[string]compose.Service{"app": {}, "proxy": {}}}
+
+	tests := []struct {
+		name   string
+		kind   string
+		states []compose.ContainerState
+		want   scorev1b1.WorkloadPlanPhase
+	}{
+		{
+			name:   "running",
+			kind:   scorev1b1.WorkloadKindService,
+			states: []compose.ContainerState{{Service: "app", State: compose.StateRunning}, {Service: "proxy", State: compose.StateRunning}},
+			want:   scorev1b1.WorkloadPlanPhaseReady,
+		},
+		{
+			name:   "missing container",
+			kind:   scorev1b1.WorkloadKindService,
+			states: []compose.ContainerState{{Service: "app", State: compose.StateRunning}},
+			want:   scorev1b1.WorkloadPlanPhaseProvisioning,
+		},
+		{
+			name:   "health starting",
+			kind:   scorev1b1.WorkloadKindService,
+			states: []compose.ContainerState{{Service: "app", State: compose.StateRunning, Health: compose.HealthStarting}, {Service: "proxy", State: compose.StateRunning}},
+			want:   scorev1b1.WorkloadPlanPhaseProvisioning,
+		},
+		{
+			name:   "exited service",
+			kind:   scorev1b1.WorkloadKindService,
+			states: []compose.ContainerState{{Service: "app", State: compose.StateExited}, {Service: "proxy", State: compose.StateRunning}},
+			want:   scorev1b1.WorkloadPlanPhaseFailed,
+		},
+		{
+			name:   "unhealthy",
+			kind:   scorev1b1.WorkloadKindService,
+			states: []compose.ContainerState{{Service: "app", State: compose.StateRunning, Health: compose.HealthUnhealthy}, {Service: "proxy", State: compose.StateRunning}},
+			want:   scorev1b1.WorkloadPlanPhaseFailed,
+		},
+		{
+			name:   "job completed",
+			kind:   scorev1b1.WorkloadKindJob,
+			states: []compose.ContainerState{{Service: "app", State: compose.StateExited}, {Service: "proxy", State: compose.StateExited}},
+			want:   scorev1b1.WorkloadPlanPhaseReady,
+		},
+		{
+			name:   "job running",
+			kind:   scorev1b1.WorkloadKindJob,
+			states: []compose.ContainerState{{Service: "app", State: compose.StateRunning}, {Service: "proxy", State: compose.StateExited}},
+			want:   scorev1b1.WorkloadPlanPhaseProvisioning,
+		},
+		{
+			name:   "job failed",
+			kind:   scorev1b1.WorkloadKindJob,
+			states: []compose.ContainerState{{Service: "app", State: compose.StateExited, ExitCode: 2}, {Service: "proxy", State: compose.StateExited}},
+			want:   scorev1b1.WorkloadPlanPhaseFailed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, message := projectPhase(tt.kind, project, tt.states); got != tt.want {
+				t.Errorf("projectPhase() = %s (%s), want %s", got, message, tt.want)
+			}
+		})
+	}
+}
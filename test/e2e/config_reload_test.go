@@ -0,0 +1,68 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cappyzawa/score-orchestrator/test/e2e/harness"
+)
+
+var _ = Describe("Config Reload E2E Test", Serial, func() {
+	var ns *harness.Namespace
+
+	BeforeEach(func() {
+		var err error
+		ns, err = harness.NewNamespace("e2e-config-reload")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(h.RestoreConfig()).To(Succeed())
+		ns.Delete()
+	})
+
+	It("should apply a reloaded OrchestratorConfig to running Workloads", func() {
+		expectReady(ns, "service-c")
+
+		workload := harness.Workload("service-c")
+		deployment := harness.Ref{Resource: "deployment", Name: "service-c"}
+		_, err := harness.Kubectl("label", "workload.score.dev", "service-c", "-n", ns.Name, "team=payments")
+		Expect(err).NotTo(HaveOccurred())
+		Consistently(ns.HasValue(deployment, "{.metadata.labels.team}", ""), 10*time.Second, 2*time.Second).Should(Succeed())
+
+		By("Reloading the configuration with label propagation")
+		Expect(h.ApplyConfig("test/e2e/fixtures/orchestrator-config-propagation.yaml")).To(Succeed())
+
+		By("Verifying the propagated label reaches the Deployment")
+		// Workloads pick up the new configuration on their next reconcile, which the annotation triggers
+		// without waiting for the periodic resync
+		Eventually(func() error {
+			if err := ns.Annotate(workload, "e2e.score.dev/reconcile", time.Now().Format(time.RFC3339Nano)); err != nil {
+				return err
+			}
+			return ns.HasValue(deployment, "{.metadata.labels.team}", "payments")()
+		}, 2*time.Minute, 5*time.Second).Should(Succeed())
+		Eventually(ns.IsReady(workload), time.Minute, 5*time.Second).Should(Succeed())
+	})
+})
@@ -0,0 +1,66 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/cappyzawa/score-orchestrator/test/e2e/harness"
+)
+
+var _ = Describe("Deletion E2E Test", func() {
+	var ns *harness.Namespace
+
+	BeforeEach(func() {
+		var err error
+		ns, err = harness.NewNamespace("e2e-deletion")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		ns.Delete()
+	})
+
+	It("should deprovision the claims before releasing the Workload", func() {
+		expectReady(ns, "service-b")
+
+		claim := harness.ResourceClaim("service-b-db")
+		secretName, err := ns.Get(claim, "{.status.outputs.secretRef.name}")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(secretName).NotTo(BeEmpty())
+
+		By("Deleting the Workload")
+		workload := harness.Workload("service-b")
+		secret := harness.Ref{Resource: "secret", Name: secretName}
+		order, err := ns.DeletionOrder(workload, []harness.Ref{claim, secret, workload}, 3*time.Minute, 500*time.Millisecond)
+		Expect(err).NotTo(HaveOccurred())
+
+		By("Verifying the claim and its Secret were removed before the Workload finalizer was released")
+		Expect(order[len(order)-1]).To(Equal(workload), "deletion order: %v", order)
+
+		By("Verifying the runtime resources were removed")
+		Eventually(ns.Gone(harness.WorkloadPlan("service-b")), time.Minute, time.Second).Should(Succeed())
+		Eventually(ns.Gone(harness.Ref{Resource: "deployment", Name: "service-b"}), time.Minute, time.Second).Should(Succeed())
+		Eventually(ns.Gone(harness.Ref{Resource: "service", Name: "service-b"}), time.Minute, time.Second).Should(Succeed())
+	})
+})
@@ -21,35 +21,24 @@ package e2e
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
-	"github.com/cappyzawa/score-orchestrator/test/utils"
+	"github.com/cappyzawa/score-orchestrator/test/e2e/harness"
 )
 
-var (
-	// Optional Environment Variables:
-	// - CERT_MANAGER_INSTALL_SKIP=true: Skips CertManager installation during test setup.
-	// These variables are useful if CertManager is already installed, avoiding
-	// re-installation and conflicts.
-	skipCertManagerInstall = os.Getenv("CERT_MANAGER_INSTALL_SKIP") == "true"
-	// isCertManagerAlreadyInstalled will be set true when CertManager CRDs be found on the cluster
-	isCertManagerAlreadyInstalled = false
-
-	// projectImage is the name of the image which will be build and loaded
-	// with the code source changes to be tested.
-	projectImage = "score-orchestrator:latest"
-	// runtimeImage is the name of the kubernetes-runtime controller image
-	runtimeImage = "kubernetes-runtime:latest"
-)
+// h deploys the controllers under test. Its options are read from the environment:
+// - IMG, RUNTIME_IMG: images of the manager and the Kubernetes runtime.
+// - E2E_SKIP_BUILD=true: reuses images already loaded into the kind cluster.
+// - CERT_MANAGER_INSTALL_SKIP=true: skips the CertManager installation, e.g. when it is already installed.
+// - E2E_CONFIG: OrchestratorConfig ConfigMap fixture deployed for the suite.
+var h = harness.New(harness.OptionsFromEnv())
 
 // TestE2E runs the end-to-end (e2e) test suite for the project. These tests execute in an isolated,
 // temporary environment to validate project changes with the purpose of being used in CI jobs.
-// The default setup requires Kind, builds/loads the Manager Docker image locally, and installs
+// The default setup requires Kind, builds/loads the manager and runtime images locally, and installs
 // CertManager.
 func TestE2E(t *testing.T) {
 	RegisterFailHandler(Fail)
@@ -58,96 +47,9 @@ func TestE2E(t *testing.T) {
 }
 
 var _ = BeforeSuite(func() {
-	By("building the manager(Operator) image")
-	cmd := exec.Command("make", "docker-build", fmt.Sprintf("IMG=%s", projectImage))
-	_, err := utils.Run(cmd)
-	ExpectWithOffset(1, err).NotTo(HaveOccurred(), "Failed to build the manager(Operator) image")
-
-	By("building the kubernetes-runtime controller image")
-	cmd = exec.Command("make", "docker-build-runtime")
-	_, err = utils.Run(cmd)
-	ExpectWithOffset(1, err).NotTo(HaveOccurred(), "Failed to build the kubernetes-runtime controller image")
-
-	// TODO(user): If you want to change the e2e test vendor from Kind, ensure the image is
-	// built and available before running the tests. Also, remove the following block.
-	By("loading the manager(Operator) image on Kind")
-	err = utils.LoadImageToKindClusterWithName(projectImage)
-	ExpectWithOffset(1, err).NotTo(HaveOccurred(), "Failed to load the manager(Operator) image into Kind")
-
-	By("loading the kubernetes-runtime controller image on Kind")
-	err = utils.LoadImageToKindClusterWithName(runtimeImage)
-	ExpectWithOffset(1, err).NotTo(HaveOccurred(), "Failed to load the kubernetes-runtime controller image into Kind")
-
-	// The tests-e2e are intended to run on a temporary cluster that is created and destroyed for testing.
-	// To prevent errors when tests run in environments with CertManager already installed,
-	// we check for its presence before execution.
-	// Setup CertManager before the suite if not skipped and if not already installed
-	if !skipCertManagerInstall {
-		By("checking if cert manager is installed already")
-		isCertManagerAlreadyInstalled = utils.IsCertManagerCRDsInstalled()
-		if !isCertManagerAlreadyInstalled {
-			_, _ = fmt.Fprintf(GinkgoWriter, "Installing CertManager...\n")
-			Expect(utils.InstallCertManager()).To(Succeed(), "Failed to install CertManager")
-		} else {
-			_, _ = fmt.Fprintf(GinkgoWriter, "WARNING: CertManager is already installed. Skipping installation...\n")
-		}
-	}
-
-	// Deploy the manager to the cluster
-	By("deploying the manager to the cluster")
-	cmd = exec.Command("make", "deploy", fmt.Sprintf("IMG=%s", projectImage))
-	_, err = utils.Run(cmd)
-	ExpectWithOffset(1, err).NotTo(HaveOccurred(), "Failed to deploy the manager to the cluster")
-
-	// Deploy the kubernetes-runtime controller to the cluster
-	By("deploying the kubernetes-runtime controller to the cluster")
-	cmd = exec.Command("make", "deploy-runtime", fmt.Sprintf("RUNTIME_IMG=%s", runtimeImage))
-	_, err = utils.Run(cmd)
-	ExpectWithOffset(1, err).NotTo(HaveOccurred(), "Failed to deploy the kubernetes-runtime controller to the cluster")
-
-	// Deploy OrchestratorConfig for E2E tests
-	By("deploying test OrchestratorConfig")
-	cmd = exec.Command("kubectl", "apply", "-f", "test/e2e/fixtures/orchestrator-config.yaml")
-	_, err = utils.Run(cmd)
-	ExpectWithOffset(1, err).NotTo(HaveOccurred(), "Failed to deploy test OrchestratorConfig")
-
-	// Wait for namespace to be ready
-	By("waiting for test namespace to be ready")
-	cmd = exec.Command("kubectl", "wait", "--for=condition=Ready", "namespace/score-system", "--timeout=30s")
-	if _, err := utils.Run(cmd); err != nil {
-		_, _ = fmt.Fprintf(GinkgoWriter, "Warning: namespace may not be fully ready: %v\n", err)
-	}
+	Expect(h.Setup()).To(Succeed(), "Failed to set up the e2e environment")
 })
 
 var _ = AfterSuite(func() {
-	// Clean up test OrchestratorConfig with timeout and force deletion
-	By("cleaning up test OrchestratorConfig")
-	cmd := exec.Command("kubectl", "delete", "-f", "test/e2e/fixtures/orchestrator-config.yaml",
-		"--ignore-not-found=true",
-		"--timeout=30s",
-		"--force",
-		"--grace-period=0")
-	if _, err := utils.Run(cmd); err != nil {
-		_, _ = fmt.Fprintf(GinkgoWriter, "Warning: failed to clean up OrchestratorConfig: %v\n", err)
-	}
-
-	// Undeploy the kubernetes-runtime controller from the cluster
-	By("undeploying the kubernetes-runtime controller from the cluster")
-	cmd = exec.Command("make", "undeploy-runtime")
-	if _, err := utils.Run(cmd); err != nil {
-		_, _ = fmt.Fprintf(GinkgoWriter, "Warning: failed to undeploy kubernetes-runtime controller: %v\n", err)
-	}
-
-	// Undeploy the manager from the cluster
-	By("undeploying the manager from the cluster")
-	cmd = exec.Command("make", "undeploy")
-	if _, err := utils.Run(cmd); err != nil {
-		_, _ = fmt.Fprintf(GinkgoWriter, "Warning: failed to undeploy manager: %v\n", err)
-	}
-
-	// Teardown CertManager after the suite if not skipped and if it was not already installed
-	if !skipCertManagerInstall && !isCertManagerAlreadyInstalled {
-		_, _ = fmt.Fprintf(GinkgoWriter, "Uninstalling CertManager...\n")
-		utils.UninstallCertManager()
-	}
+	h.Teardown()
 })
@@ -1,39 +1,40 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
 package e2e
 
 import (
 	"fmt"
-	"os"
-	"os/exec"
-	"strings"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
-	"github.com/cappyzawa/score-orchestrator/test/utils"
+	"github.com/cappyzawa/score-orchestrator/test/e2e/harness"
 )
 
-var _ = Describe("Golden Path E2E Test", func() {
-	var (
-		namespaceName string
-		workloadFile  string
-	)
-
-	BeforeEach(func() {
-		// Create a unique namespace for this test
-		namespaceName = fmt.Sprintf("e2e-golden-path-%d", time.Now().UnixNano())
-
-		// Create namespace
-		cmd := exec.Command("kubectl", "create", "namespace", namespaceName)
-		_, err := utils.Run(cmd)
-		Expect(err).NotTo(HaveOccurred())
-
-		// Create workload YAML content (Workload CRD format based on user's Score spec)
-		workloadContent := fmt.Sprintf(`apiVersion: score.dev/v1b1
+// webService returns a Workload exposing nginx on port 8000, backed by a postgres database named db
+func webService(name string) string {
+	return fmt.Sprintf(`apiVersion: score.dev/v1b1
 kind: Workload
 metadata:
-  name: service-a
-  namespace: %s
+  name: %s
 spec:
   service:
     ports:
@@ -55,204 +56,78 @@ spec:
   resources:
     db:
       type: postgres
-`, namespaceName)
+`, name)
+}
+
+// expectReady applies the web service and waits until it is Ready with an endpoint
+func expectReady(ns *harness.Namespace, name string) {
+	Expect(ns.Apply(webService(name))).To(Succeed())
+	Eventually(ns.IsReady(harness.Workload(name)), 3*time.Minute, 5*time.Second).Should(Succeed())
+}
 
-		// Write workload to temporary file
-		workloadFile = fmt.Sprintf("/tmp/workload-%s.yaml", namespaceName)
-		err = os.WriteFile(workloadFile, []byte(workloadContent), 0644)
+var _ = Describe("Golden Path E2E Test", func() {
+	var ns *harness.Namespace
+
+	BeforeEach(func() {
+		var err error
+		ns, err = harness.NewNamespace("e2e-golden-path")
 		Expect(err).NotTo(HaveOccurred())
 	})
 
 	AfterEach(func() {
-		// Clean up the namespace
-		if namespaceName != "" {
-			cmd := exec.Command("kubectl", "delete", "namespace", namespaceName, "--ignore-not-found=true")
-			_, _ = utils.Run(cmd)
-		}
-		// Clean up temporary file
-		if workloadFile != "" {
-			_ = exec.Command("rm", "-f", workloadFile).Run()
-		}
+		ns.Delete()
 	})
 
 	It("should complete the golden path from Workload to Ready state", func() {
-		By("Creating the Workload")
-		cmd := exec.Command("kubectl", "apply", "-f", workloadFile)
-		_, err := utils.Run(cmd)
-		Expect(err).NotTo(HaveOccurred())
-
-		By("Waiting for ResourceClaim to be created")
-		Eventually(func() bool {
-			cmd := exec.Command("kubectl", "get", "resourceclaim.score.dev", "service-a-db", "-n", namespaceName, "-o", "name")
-			_, err := utils.Run(cmd)
-			return err == nil
-		}, time.Minute, time.Second).Should(BeTrue())
-
-		By("Verifying ResourceClaim type")
-		cmd = exec.Command("kubectl", "get", "resourceclaim.score.dev", "service-a-db", "-n", namespaceName,
-			"-o", "jsonpath={.spec.type}")
-		output, err := utils.Run(cmd)
-		Expect(err).NotTo(HaveOccurred())
-		Expect(strings.TrimSpace(output)).To(Equal("postgres"))
-
-		By("Waiting for ResourceClaim to reach Bound phase")
-		Eventually(func() string {
-			cmd := exec.Command("kubectl", "get", "resourceclaim.score.dev", "service-a-db", "-n", namespaceName,
-				"-o", "jsonpath={.status.phase}")
-			output, err := utils.Run(cmd)
-			if err != nil {
-				return ""
-			}
-			return strings.TrimSpace(output)
-		}, time.Minute*2, time.Second*5).Should(Equal("Bound"))
-
-		By("Verifying ResourceClaim outputs available")
-		Eventually(func() string {
-			cmd := exec.Command("kubectl", "get", "resourceclaim.score.dev", "service-a-db", "-n", namespaceName,
-				"-o", "jsonpath={.status.outputsAvailable}")
-			output, err := utils.Run(cmd)
-			if err != nil {
-				return ""
-			}
-			return strings.TrimSpace(output)
-		}, time.Minute, time.Second*5).Should(Equal("true"))
-
-		By("Verifying the generated Secret exists")
-		Eventually(func() bool {
-			cmd := exec.Command("kubectl", "get", "secret", "service-a-db-postgres-secret", "-n", namespaceName, "-o", "name")
-			_, err := utils.Run(cmd)
-			return err == nil
-		}, time.Minute, time.Second).Should(BeTrue())
-
-		By("Verifying Secret contains expected keys")
-		cmd = exec.Command("kubectl", "get", "secret", "service-a-db-postgres-secret", "-n", namespaceName,
-			"-o", "jsonpath={.data}")
-		output, err = utils.Run(cmd)
-		Expect(err).NotTo(HaveOccurred())
-		secretData := output
-		Expect(secretData).To(ContainSubstring("username"))
-		Expect(secretData).To(ContainSubstring("password"))
-		Expect(secretData).To(ContainSubstring("host"))
-		Expect(secretData).To(ContainSubstring("port"))
-		Expect(secretData).To(ContainSubstring("database"))
-		Expect(secretData).To(ContainSubstring("uri"))
+		workload := harness.Workload("service-a")
+		claim := harness.ResourceClaim("service-a-db")
 
-		By("Waiting for WorkloadPlan to be created")
-		Eventually(func() bool {
-			cmd := exec.Command("kubectl", "get", "workloadplan", "service-a", "-n", namespaceName, "-o", "name")
-			_, err := utils.Run(cmd)
-			return err == nil
-		}, time.Minute, time.Second).Should(BeTrue())
+		By("Creating the Workload")
+		Expect(ns.Apply(webService("service-a"))).To(Succeed())
 
-		By("Waiting for WorkloadExposure to be created by Registrar")
-		Eventually(func() bool {
-			cmd := exec.Command("kubectl", "get", "workloadexposure", "service-a", "-n", namespaceName, "-o", "name")
-			_, err := utils.Run(cmd)
-			return err == nil
-		}, time.Minute, time.Second*2).Should(BeTrue())
+		By("Waiting for the postgres ResourceClaim to be Bound with outputs")
+		Eventually(ns.HasValue(claim, "{.spec.type}", "postgres"), time.Minute, time.Second).Should(Succeed())
+		Eventually(ns.HasValue(claim, "{.status.phase}", "Bound"), 2*time.Minute, 5*time.Second).Should(Succeed())
+		Eventually(ns.HasValue(claim, "{.status.outputsAvailable}", "true"), time.Minute, 5*time.Second).Should(Succeed())
 
-		By("Verifying WorkloadExposure was created with correct runtime class")
-		cmd = exec.Command("kubectl", "get", "workloadexposure", "service-a", "-n", namespaceName,
-			"-o", "jsonpath={.spec.runtimeClass}")
-		output, err = utils.Run(cmd)
+		By("Verifying the generated Secret contains the connection keys")
+		secretName, err := ns.Get(claim, "{.status.outputs.secretRef.name}")
 		Expect(err).NotTo(HaveOccurred())
-		Expect(strings.TrimSpace(output)).To(Equal("kubernetes"))
+		Expect(secretName).NotTo(BeEmpty())
+		secret := harness.Ref{Resource: "secret", Name: secretName}
+		for _, key := range []string{"username", "password", "host", "port", "database", "uri"} {
+			Eventually(ns.ContainsValue(secret, "{.data}", key), time.Minute, time.Second).Should(Succeed())
+		}
 
-		By("Waiting for Kubernetes Deployment to be created by runtime controller")
-		Eventually(func() bool {
-			cmd := exec.Command("kubectl", "get", "deployment", "service-a", "-n", namespaceName, "-o", "name")
-			_, err := utils.Run(cmd)
-			return err == nil
-		}, time.Minute, time.Second).Should(BeTrue())
+		By("Waiting for the WorkloadPlan and WorkloadExposure to be created")
+		Eventually(ns.Exists(harness.WorkloadPlan("service-a")), time.Minute, time.Second).Should(Succeed())
+		exposure := harness.Ref{Resource: "workloadexposure.score.dev", Name: "service-a"}
+		Eventually(ns.HasValue(exposure, "{.spec.runtimeClass}", "kubernetes"), time.Minute, 2*time.Second).Should(Succeed())
 
-		By("Waiting for Kubernetes Service to be created by runtime controller")
-		Eventually(func() bool {
-			cmd := exec.Command("kubectl", "get", "service", "service-a", "-n", namespaceName, "-o", "name")
-			_, err := utils.Run(cmd)
-			return err == nil
-		}, time.Minute, time.Second).Should(BeTrue())
+		By("Waiting for the runtime to materialize the Deployment and Service")
+		deployment := harness.Ref{Resource: "deployment", Name: "service-a"}
+		Eventually(ns.Exists(deployment), time.Minute, time.Second).Should(Succeed())
+		Eventually(ns.Exists(harness.Ref{Resource: "service", Name: "service-a"}), time.Minute, time.Second).Should(Succeed())
 
-		By("Verifying Deployment has correct resource requirements")
-		cmd = exec.Command("kubectl", "get", "deployment", "service-a", "-n", namespaceName,
-			"-o", "jsonpath={.spec.template.spec.containers[0].resources}")
-		output, err = utils.Run(cmd)
+		By("Verifying the Deployment has the requested resources")
+		resources, err := ns.Get(deployment, "{.spec.template.spec.containers[0].resources}")
 		Expect(err).NotTo(HaveOccurred())
-		resourcesOutput := strings.TrimSpace(output)
-		Expect(resourcesOutput).To(ContainSubstring("100m"))  // CPU request
-		Expect(resourcesOutput).To(ContainSubstring("64Mi"))  // Memory request
-		Expect(resourcesOutput).To(ContainSubstring("200m"))  // CPU limit
-		Expect(resourcesOutput).To(ContainSubstring("128Mi")) // Memory limit
-
-		By("Waiting for Deployment to be ready")
-		Eventually(func() bool {
-			cmd := exec.Command("kubectl", "get", "deployment", "service-a", "-n", namespaceName,
-				"-o", "jsonpath={.status.readyReplicas}")
-			output, err := utils.Run(cmd)
-			if err != nil {
-				return false
-			}
-			readyReplicas := strings.TrimSpace(output)
-			return readyReplicas == "1"
-		}, time.Minute*2, time.Second*5).Should(BeTrue())
-
-		By("Verifying WorkloadExposure status gets published with URL")
-		Eventually(func() string {
-			cmd := exec.Command("kubectl", "get", "workloadexposure", "service-a", "-n", namespaceName,
-				"-o", "jsonpath={.status.exposures[0].url}")
-			output, err := utils.Run(cmd)
-			if err != nil {
-				return ""
-			}
-			return strings.TrimSpace(output)
-		}, time.Minute*2, time.Second*5).Should(ContainSubstring("localhost"))
-
-		By("Verifying Workload endpoint is mirrored correctly")
-		Eventually(func() *string {
-			cmd := exec.Command("kubectl", "get", "workload", "service-a", "-n", namespaceName,
-				"-o", "jsonpath={.status.endpoint}")
-			output, err := utils.Run(cmd)
-			if err != nil {
-				return nil
-			}
-			endpoint := strings.TrimSpace(output)
-			if endpoint == "" || endpoint == "<no value>" {
-				return nil
+		for _, quantity := range []string{"100m", "64Mi", "200m", "128Mi"} {
+			Expect(resources).To(ContainSubstring(quantity))
+		}
+		Eventually(ns.HasValue(deployment, "{.status.readyReplicas}", "1"), 2*time.Minute, 5*time.Second).Should(Succeed())
+
+		By("Verifying the exposure URL is published and mirrored to the Workload")
+		Eventually(ns.ContainsValue(exposure, "{.status.exposures[0].url}", "localhost"), 2*time.Minute, 5*time.Second).Should(Succeed())
+		Eventually(func() error {
+			endpoint, err := ns.Get(workload, "{.status.endpoint}")
+			if err == nil && endpoint == "" {
+				err = fmt.Errorf("the endpoint of %s is not set", workload)
 			}
-			return &endpoint
-		}, time.Minute*2, time.Second*5).ShouldNot(BeNil())
+			return err
+		}, 2*time.Minute, 5*time.Second).Should(Succeed())
 
 		By("Verifying Workload Ready condition - the ultimate goal")
-		Eventually(func() string {
-			cmd := exec.Command("kubectl", "get", "workload", "service-a", "-n", namespaceName,
-				"-o", "jsonpath={.status.conditions[?(@.type==\"Ready\")].status}")
-			output, err := utils.Run(cmd)
-			if err != nil {
-				return ""
-			}
-			readyStatus := strings.TrimSpace(output)
-
-			// Log current status if not ready for debugging
-			if readyStatus != "True" {
-				// Check individual conditions for troubleshooting
-				inputsValidCmd := exec.Command("kubectl", "get", "workload", "service-a", "-n", namespaceName,
-					"-o", "jsonpath={.status.conditions[?(@.type==\"InputsValid\")].status}")
-				inputsValidOutput, _ := utils.Run(inputsValidCmd)
-
-				claimsReadyCmd := exec.Command("kubectl", "get", "workload", "service-a", "-n", namespaceName,
-					"-o", "jsonpath={.status.conditions[?(@.type==\"ClaimsReady\")].status}")
-				claimsReadyOutput, _ := utils.Run(claimsReadyCmd)
-
-				runtimeReadyCmd := exec.Command("kubectl", "get", "workload", "service-a", "-n", namespaceName,
-					"-o", "jsonpath={.status.conditions[?(@.type==\"RuntimeReady\")].status}")
-				runtimeReadyOutput, _ := utils.Run(runtimeReadyCmd)
-
-				By(fmt.Sprintf("Workload conditions - Ready: %s, InputsValid: %s, ClaimsReady: %s, RuntimeReady: %s",
-					readyStatus, strings.TrimSpace(inputsValidOutput),
-					strings.TrimSpace(claimsReadyOutput), strings.TrimSpace(runtimeReadyOutput)))
-			}
-
-			return readyStatus
-		}, time.Minute*3, time.Second*5).Should(Equal("True"))
+		Eventually(ns.IsReady(workload), 3*time.Minute, 5*time.Second).Should(Succeed())
 	})
-
 })
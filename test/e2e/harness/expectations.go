@@ -0,0 +1,97 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package harness
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Expectations return functions that fail until the cluster reaches the expected state, to be polled with
+// Eventually(ns.HasValue(...), timeout, interval).Should(Succeed()).
+
+// Workload, ResourceClaim and WorkloadPlan return the references of the Orchestrator resources
+func Workload(name string) Ref      { return Ref{Resource: "workload.score.dev", Name: name} }
+func ResourceClaim(name string) Ref { return Ref{Resource: "resourceclaim.score.dev", Name: name} }
+func WorkloadPlan(name string) Ref  { return Ref{Resource: "workloadplan.score.dev", Name: name} }
+
+// ConditionStatus returns the JSONPath of the status of a condition, e.g. ConditionStatus("Ready")
+func ConditionStatus(conditionType string) string {
+	return fmt.Sprintf(`{.status.conditions[?(@.type=="%s")].status}`, conditionType)
+}
+
+// Exists fails until the object exists
+func (n *Namespace) Exists(ref Ref) func() error {
+	return func() error {
+		_, err := Kubectl("get", ref.Resource, ref.Name, "-n", n.Name, "-o", "name")
+		return err
+	}
+}
+
+// Gone fails until the object no longer exists
+func (n *Namespace) Gone(ref Ref) func() error {
+	return func() error {
+		if n.exists(ref) {
+			return fmt.Errorf("%s still exists", ref)
+		}
+		return nil
+	}
+}
+
+// HasValue fails until the JSONPath expression evaluated on the object equals the value
+func (n *Namespace) HasValue(ref Ref, jsonPath, value string) func() error {
+	return func() error {
+		got, err := n.Get(ref, jsonPath)
+		if err != nil {
+			return err
+		}
+		if got != value {
+			return fmt.Errorf("%s %s = %q, want %q", ref, jsonPath, got, value)
+		}
+		return nil
+	}
+}
+
+// ContainsValue fails until the JSONPath expression evaluated on the object contains the substring
+func (n *Namespace) ContainsValue(ref Ref, jsonPath, substring string) func() error {
+	return func() error {
+		got, err := n.Get(ref, jsonPath)
+		if err != nil {
+			return err
+		}
+		if !strings.Contains(got, substring) {
+			return fmt.Errorf("%s %s = %q, want it to contain %q", ref, jsonPath, got, substring)
+		}
+		return nil
+	}
+}
+
+// IsReady fails until the Ready condition of the object is True, reporting the other conditions of the
+// object meanwhile to ease troubleshooting
+func (n *Namespace) IsReady(ref Ref) func() error {
+	return func() error {
+		ready, err := n.Get(ref, ConditionStatus("Ready"))
+		if err != nil {
+			return err
+		}
+		if ready != "True" {
+			conditions, _ := n.Get(ref, `{range .status.conditions[*]}{.type}={.status} ({.reason}) {end}`)
+			return fmt.Errorf("%s is not ready: %s", ref, conditions)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package harness sets up a kind cluster running the Orchestrator, its provisioners and the Kubernetes
+// runtime, and provides the building blocks of end-to-end scenarios: a namespace per scenario, kubectl
+// helpers and expectations polled with Gomega's Eventually. Suites for new provisioning strategies or
+// runtimes reuse the Harness in their BeforeSuite and describe their scenarios with these helpers.
+package harness
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo/v2" // nolint:revive,staticcheck
+
+	"github.com/cappyzawa/score-orchestrator/test/utils"
+)
+
+const (
+	// SystemNamespace is the namespace the controllers are deployed into
+	SystemNamespace = "score-system"
+
+	// ManagerDeployment runs the Orchestrator and the built-in provisioners
+	ManagerDeployment = "score-controller-manager"
+
+	// RuntimeDeployment runs the Kubernetes runtime
+	RuntimeDeployment = "kubernetes-runtime-controller"
+
+	// ConfigMapName is the ConfigMap the Orchestrator reads its configuration from
+	ConfigMapName = "orchestrator-config"
+
+	// DefaultConfigFile is the OrchestratorConfig fixture deployed by Setup, relative to the project root
+	DefaultConfigFile = "test/e2e/fixtures/orchestrator-config.yaml"
+)
+
+// Options configure the images and cluster of a Harness
+type Options struct {
+	// ManagerImage is the image of the Orchestrator manager (IMG, default score-orchestrator:latest)
+	ManagerImage string
+	// RuntimeImage is the image of the Kubernetes runtime (RUNTIME_IMG, default kubernetes-runtime:latest)
+	RuntimeImage string
+	// SkipBuild reuses images already loaded into the cluster (E2E_SKIP_BUILD=true)
+	SkipBuild bool
+	// SkipCertManager does not install cert-manager (CERT_MANAGER_INSTALL_SKIP=true)
+	SkipCertManager bool
+	// ConfigFile is the OrchestratorConfig ConfigMap applied by Setup (E2E_CONFIG, default DefaultConfigFile)
+	ConfigFile string
+}
+
+// OptionsFromEnv reads the options from the environment, falling back to the defaults
+func OptionsFromEnv() Options {
+	return Options{
+		ManagerImage:    envOr("IMG", "score-orchestrator:latest"),
+		RuntimeImage:    envOr("RUNTIME_IMG", "kubernetes-runtime:latest"),
+		SkipBuild:       os.Getenv("E2E_SKIP_BUILD") == "true",
+		SkipCertManager: os.Getenv("CERT_MANAGER_INSTALL_SKIP") == "true",
+		ConfigFile:      envOr("E2E_CONFIG", DefaultConfigFile),
+	}
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return fallback
+}
+
+// Harness deploys the controllers under test into the kind cluster named by KIND_CLUSTER
+type Harness struct {
+	Options
+
+	// installedCertManager records whether Setup installed cert-manager, so that Teardown only removes
+	// an installation of its own
+	installedCertManager bool
+}
+
+// New returns a Harness with the given options
+func New(options Options) *Harness {
+	return &Harness{Options: options}
+}
+
+// Setup builds and loads the images, installs the CRDs, deploys the manager and the Kubernetes runtime,
+// applies the configuration and waits until the controllers are available
+func (h *Harness) Setup() error {
+	if !h.SkipBuild {
+		By("building the manager and runtime images")
+		if err := runMake("docker-build", "IMG="+h.ManagerImage); err != nil {
+			return err
+		}
+		if err := runMake("docker-build-runtime", "RUNTIME_IMG="+h.RuntimeImage); err != nil {
+			return err
+		}
+
+		By("loading the images into kind")
+		for _, image := range []string{h.ManagerImage, h.RuntimeImage} {
+			if err := utils.LoadImageToKindClusterWithName(image); err != nil {
+				return fmt.Errorf("failed to load image %s: %w", image, err)
+			}
+		}
+	}
+
+	if !h.SkipCertManager && !utils.IsCertManagerCRDsInstalled() {
+		By("installing cert-manager")
+		if err := utils.InstallCertManager(); err != nil {
+			return fmt.Errorf("failed to install cert-manager: %w", err)
+		}
+		h.installedCertManager = true
+	}
+
+	By("installing the CRDs")
+	if err := runMake("install"); err != nil {
+		return err
+	}
+
+	By("deploying the manager and the Kubernetes runtime")
+	if err := runMake("deploy", "IMG="+h.ManagerImage); err != nil {
+		return err
+	}
+	if err := runMake("deploy-runtime", "RUNTIME_IMG="+h.RuntimeImage); err != nil {
+		return err
+	}
+
+	By("applying the OrchestratorConfig")
+	if err := h.ApplyConfig(h.ConfigFile); err != nil {
+		return err
+	}
+
+	By("waiting for the controllers to become available")
+	for _, deployment := range []string{ManagerDeployment, RuntimeDeployment} {
+		if _, err := Kubectl("wait", "deployment/"+deployment, "-n", SystemNamespace,
+			"--for=condition=Available", "--timeout=5m"); err != nil {
+			return fmt.Errorf("deployment %s did not become available: %w", deployment, err)
+		}
+	}
+	return nil
+}
+
+// Teardown removes what Setup deployed. Failures are logged, so that the remaining steps still run.
+func (h *Harness) Teardown() {
+	By("removing the OrchestratorConfig")
+	if _, err := Kubectl("delete", "-f", h.ConfigFile, "--ignore-not-found=true", "--timeout=30s"); err != nil {
+		warn(err)
+	}
+
+	By("undeploying the Kubernetes runtime and the manager")
+	if err := runMake("undeploy-runtime"); err != nil {
+		warn(err)
+	}
+	if err := runMake("undeploy", "ignore-not-found=true"); err != nil {
+		warn(err)
+	}
+
+	if h.installedCertManager {
+		By("uninstalling cert-manager")
+		utils.UninstallCertManager()
+	}
+}
+
+// ApplyConfig applies an OrchestratorConfig ConfigMap fixture. Managers replace their configuration
+// snapshot when the ConfigMap changes, and apply it to each Workload on its next reconcile.
+func (h *Harness) ApplyConfig(file string) error {
+	if _, err := Kubectl("apply", "-f", file); err != nil {
+		return fmt.Errorf("failed to apply configuration %s: %w", file, err)
+	}
+	return nil
+}
+
+// RestoreConfig applies the configuration Setup deployed again, after a scenario changed it
+func (h *Harness) RestoreConfig() error {
+	return h.ApplyConfig(h.ConfigFile)
+}
+
+// runMake runs a target of the project Makefile
+func runMake(target string, variables ...string) error {
+	if _, err := utils.Run(exec.Command("make", append([]string{target}, variables...)...)); err != nil {
+		return fmt.Errorf("make %s failed: %w", target, err)
+	}
+	return nil
+}
+
+func warn(err error) {
+	_, _ = fmt.Fprintf(GinkgoWriter, "warning: %v\n", err)
+}
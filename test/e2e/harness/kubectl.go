@@ -0,0 +1,38 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package harness
+
+import (
+	"os/exec"
+	"strings"
+
+	"github.com/cappyzawa/score-orchestrator/test/utils"
+)
+
+// Kubectl runs kubectl with the given arguments against the current context and returns its trimmed output
+func Kubectl(args ...string) (string, error) {
+	output, err := utils.Run(exec.Command("kubectl", args...))
+	return strings.TrimSpace(output), err
+}
+
+// ApplyManifest applies the given YAML manifest with kubectl, passing it the extra arguments, e.g. "-n", namespace
+func ApplyManifest(manifest string, args ...string) error {
+	cmd := exec.Command("kubectl", append([]string{"apply", "-f", "-"}, args...)...)
+	cmd.Stdin = strings.NewReader(manifest)
+	_, err := utils.Run(cmd)
+	return err
+}
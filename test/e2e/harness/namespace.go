@@ -0,0 +1,119 @@
+/*
+Copyright 2025.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package harness
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Ref names an object of a scenario namespace by its kubectl resource and name, e.g. {"deployment", "web"}
+type Ref struct {
+	Resource string
+	Name     string
+}
+
+func (r Ref) String() string {
+	return r.Resource + "/" + r.Name
+}
+
+// Namespace is the namespace of a scenario. Scenarios run in a namespace of their own, which is deleted
+// with everything in it once the scenario is over.
+type Namespace struct {
+	Name string
+}
+
+// NewNamespace creates a namespace with a unique name starting with the given prefix
+func NewNamespace(prefix string) (*Namespace, error) {
+	name := fmt.Sprintf("%s-%d", prefix, time.Now().UnixNano())
+	if _, err := Kubectl("create", "namespace", name); err != nil {
+		return nil, err
+	}
+	return &Namespace{Name: name}, nil
+}
+
+// Delete deletes the namespace without waiting for its finalization
+func (n *Namespace) Delete() {
+	if _, err := Kubectl("delete", "namespace", n.Name, "--ignore-not-found=true", "--wait=false"); err != nil {
+		warn(err)
+	}
+}
+
+// Apply applies a manifest into the namespace. The manifest may leave metadata.namespace unset.
+func (n *Namespace) Apply(manifest string) error {
+	return ApplyManifest(manifest, "-n", n.Name)
+}
+
+// Get returns the JSONPath expression evaluated on an object of the namespace, e.g. "{.status.phase}"
+func (n *Namespace) Get(ref Ref, jsonPath string) (string, error) {
+	return Kubectl("get", ref.Resource, ref.Name, "-n", n.Name, "-o", "jsonpath="+jsonPath)
+}
+
+// exists reports whether the object exists
+func (n *Namespace) exists(ref Ref) bool {
+	_, err := Kubectl("get", ref.Resource, ref.Name, "-n", n.Name, "-o", "name")
+	return err == nil
+}
+
+// DeleteObject deletes an object of the namespace without waiting for its finalizers
+func (n *Namespace) DeleteObject(ref Ref) error {
+	_, err := Kubectl("delete", ref.Resource, ref.Name, "-n", n.Name, "--wait=false")
+	return err
+}
+
+// Annotate sets an annotation on an object of the namespace, e.g. to trigger its reconcile
+func (n *Namespace) Annotate(ref Ref, key, value string) error {
+	_, err := Kubectl("annotate", ref.Resource, ref.Name, "-n", n.Name, "--overwrite", key+"="+value)
+	return err
+}
+
+// DeletionOrder deletes the object and polls the watched objects until all of them are gone, returning them
+// in the order they disappeared. Objects that disappeared between the same two polls are returned in the
+// order they were given, so an interval well below the expected gaps keeps the order meaningful.
+func (n *Namespace) DeletionOrder(ref Ref, watched []Ref, timeout, interval time.Duration) ([]Ref, error) {
+	if err := n.DeleteObject(ref); err != nil {
+		return nil, err
+	}
+
+	var order []Ref
+	gone := make(map[Ref]bool, len(watched))
+	deadline := time.Now().Add(timeout)
+	for len(order) < len(watched) {
+		for _, w := range watched {
+			if !gone[w] && !n.exists(w) {
+				gone[w] = true
+				order = append(order, w)
+			}
+		}
+		if len(order) == len(watched) {
+			break
+		}
+		if time.Now().After(deadline) {
+			var remaining []string
+			for _, w := range watched {
+				if !gone[w] {
+					remaining = append(remaining, w.String())
+				}
+			}
+			return order, errors.New("objects still exist after " + timeout.String() + ": " + strings.Join(remaining, ", "))
+		}
+		time.Sleep(interval)
+	}
+	return order, nil
+}